@@ -63,3 +63,26 @@ Create the name of the service account to use.
 {{- default "default" .Values.serviceAccount.name }}
 {{- end }}
 {{- end }}
+
+{{/*
+Create the name of the Trivy server.
+*/}}
+{{- define "starboard-operator.trivyServer.fullname" -}}
+{{- printf "%s-trivy" (include "starboard-operator.fullname" .) | trunc 63 | trimSuffix "-" }}
+{{- end }}
+
+{{/*
+Selector labels of the Trivy server, which are distinct from selector labels
+of the operator, so that Services and Deployments do not select each other's pods.
+*/}}
+{{- define "starboard-operator.trivyServer.selectorLabels" -}}
+app.kubernetes.io/name: trivy-server
+app.kubernetes.io/instance: {{ .Release.Name }}
+{{- end }}
+
+{{/*
+Create the URL of the Trivy server.
+*/}}
+{{- define "starboard-operator.trivyServer.url" -}}
+{{- printf "http://%s.%s:4954" (include "starboard-operator.trivyServer.fullname" .) .Release.Namespace }}
+{{- end }}
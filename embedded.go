@@ -15,6 +15,12 @@ var (
 	vulnerabilityReportsCRD []byte
 	//go:embed deploy/crd/clustervulnerabilityreports.crd.yaml
 	clusterVulnerabilityReportsCRD []byte
+	//go:embed deploy/crd/packageinventories.crd.yaml
+	packageInventoriesCRD []byte
+	//go:embed deploy/crd/workloadvulnerabilitysummaries.crd.yaml
+	workloadVulnerabilitySummariesCRD []byte
+	//go:embed deploy/crd/imageinventories.crd.yaml
+	imageInventoriesCRD []byte
 	//go:embed deploy/crd/configauditreports.crd.yaml
 	configAuditReportsCRD []byte
 	//go:embed deploy/crd/clusterconfigauditreports.crd.yaml
@@ -23,6 +29,10 @@ var (
 	clusterComplianceReportsCRD []byte
 	//go:embed deploy/crd/clustercompliancedetailreports.crd.yaml
 	clusterComplianceDetailReportsCRD []byte
+	//go:embed deploy/crd/compliancereports.crd.yaml
+	complianceReportsCRD []byte
+	//go:embed deploy/crd/clusterriskreports.crd.yaml
+	clusterRiskReportsCRD []byte
 	//go:embed deploy/crd/ciskubebenchreports.crd.yaml
 	kubeBenchReportsCRD []byte
 	//go:embed deploy/crd/kubehunterreports.crd.yaml
@@ -51,6 +61,18 @@ func GetClusterVulnerabilityReportsCRD() (apiextensionsv1.CustomResourceDefiniti
 	return getCRDFromBytes(clusterVulnerabilityReportsCRD)
 }
 
+func GetPackageInventoriesCRD() (apiextensionsv1.CustomResourceDefinition, error) {
+	return getCRDFromBytes(packageInventoriesCRD)
+}
+
+func GetWorkloadVulnerabilitySummariesCRD() (apiextensionsv1.CustomResourceDefinition, error) {
+	return getCRDFromBytes(workloadVulnerabilitySummariesCRD)
+}
+
+func GetImageInventoriesCRD() (apiextensionsv1.CustomResourceDefinition, error) {
+	return getCRDFromBytes(imageInventoriesCRD)
+}
+
 func GetConfigAuditReportsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
 	return getCRDFromBytes(configAuditReportsCRD)
 }
@@ -67,6 +89,14 @@ func GetClusterComplianceDetailReportsCRD() (apiextensionsv1.CustomResourceDefin
 	return getCRDFromBytes(clusterComplianceDetailReportsCRD)
 }
 
+func GetComplianceReportsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
+	return getCRDFromBytes(complianceReportsCRD)
+}
+
+func GetClusterRiskReportsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
+	return getCRDFromBytes(clusterRiskReportsCRD)
+}
+
 func GetCISKubeBenchReportsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
 	return getCRDFromBytes(kubeBenchReportsCRD)
 }
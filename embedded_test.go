@@ -0,0 +1,201 @@
+package starboard_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	structuraldefaulting "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// admitComplianceReport defaults and validates the given report against the
+// schema of the ClusterComplianceReport CRD the same way the API server does.
+// It returns the defaulted object and validation error messages.
+func admitComplianceReport(t *testing.T, report v1alpha1.ClusterComplianceReport) (map[string]interface{}, []string) {
+	t.Helper()
+	crd, err := starboard.GetClusterComplianceReportsCRD()
+	require.NoError(t, err)
+	var crv apiextensions.CustomResourceValidation
+	err = apiextensionsv1.Convert_v1_CustomResourceValidation_To_apiextensions_CustomResourceValidation(crd.Spec.Versions[0].Schema, &crv, nil)
+	require.NoError(t, err)
+	structural, err := structuralschema.NewStructural(crv.OpenAPIV3Schema)
+	require.NoError(t, err)
+	schemaValidator, _, err := validation.NewSchemaValidator(&crv)
+	require.NoError(t, err)
+
+	report.APIVersion = v1alpha1.SchemeGroupVersion.String()
+	report.Kind = "ClusterComplianceReport"
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&report)
+	require.NoError(t, err)
+	structuraldefaulting.Default(obj, structural)
+
+	errs := validation.ValidateCustomResource(nil, obj, schemaValidator)
+	errs = append(errs, cel.NewValidator(structural).Validate(nil, structural, obj)...)
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return obj, messages
+}
+
+func TestClusterComplianceReportsCRD(t *testing.T) {
+	newReport := func(controls ...v1alpha1.Control) v1alpha1.ClusterComplianceReport {
+		report := v1alpha1.ClusterComplianceReport{}
+		report.Name = "custom"
+		report.Spec = v1alpha1.ReportSpec{
+			Name:        "custom",
+			Description: "custom compliance report",
+			Cron:        "0 */6 * * *",
+			Version:     "1.0",
+			Controls:    controls,
+		}
+		return report
+	}
+	newControl := func(id string) v1alpha1.Control {
+		return v1alpha1.Control{
+			ID:       id,
+			Name:     "Non-root containers",
+			Kinds:    []string{"Workload"},
+			Severity: v1alpha1.SeverityMedium,
+			Mapping: v1alpha1.Mapping{
+				Scanner: "config-audit",
+				Checks:  []v1alpha1.SpecCheck{{ID: "KSV012"}},
+			},
+		}
+	}
+
+	t.Run("Should admit NSA spec", func(t *testing.T) {
+		report, err := starboard.GetNSASpecV10()
+		require.NoError(t, err)
+		_, messages := admitComplianceReport(t, report)
+		assert.Empty(t, messages)
+	})
+
+	t.Run("Should default aggregation", func(t *testing.T) {
+		obj, messages := admitComplianceReport(t, newReport(newControl("1.0")))
+		assert.Empty(t, messages)
+		controls, _, err := unstructured.NestedSlice(obj, "spec", "controls")
+		require.NoError(t, err)
+		require.Len(t, controls, 1)
+		aggregation, _, err := unstructured.NestedString(controls[0].(map[string]interface{}), "mapping", "aggregation")
+		require.NoError(t, err)
+		assert.Equal(t, "count", aggregation)
+	})
+
+	t.Run("Should admit kube-hunter mapping", func(t *testing.T) {
+		control := newControl("9.0")
+		control.Kinds = []string{"Cluster"}
+		control.Mapping = v1alpha1.Mapping{
+			Scanner: "kube-hunter",
+			Checks:  []v1alpha1.SpecCheck{{ID: "KHV005"}},
+		}
+		_, messages := admitComplianceReport(t, newReport(control))
+		assert.Empty(t, messages)
+	})
+
+	t.Run("Should admit mapping of multiple scanners", func(t *testing.T) {
+		control := newControl("1.0")
+		control.Kinds = []string{"Workload", "Node"}
+		control.Mapping = v1alpha1.Mapping{Scanners: []v1alpha1.ScannerMapping{
+			{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}},
+			{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{{ID: "4.2.6"}}},
+		}}
+		_, messages := admitComplianceReport(t, newReport(control))
+		assert.Empty(t, messages)
+	})
+
+	testCases := []struct {
+		name     string
+		mutate   func(report *v1alpha1.ClusterComplianceReport)
+		expected string
+	}{
+		{
+			name: "Should reject empty control ID",
+			mutate: func(report *v1alpha1.ClusterComplianceReport) {
+				report.Spec.Controls[0].ID = ""
+			},
+			expected: "spec.controls.id in body should be at least 1 chars long",
+		},
+		{
+			name: "Should reject invalid severity",
+			mutate: func(report *v1alpha1.ClusterComplianceReport) {
+				report.Spec.Controls[0].Severity = "SEVERE"
+			},
+			expected: `spec.controls.severity: Unsupported value: "SEVERE"`,
+		},
+		{
+			name: "Should reject invalid cron",
+			mutate: func(report *v1alpha1.ClusterComplianceReport) {
+				report.Spec.Cron = "every hour"
+			},
+			expected: "spec.cron in body should match",
+		},
+		{
+			name: "Should reject mapping without checks",
+			mutate: func(report *v1alpha1.ClusterComplianceReport) {
+				report.Spec.Controls[0].Mapping.Checks = []v1alpha1.SpecCheck{}
+			},
+			expected: "either scanner and checks, or scanners must be specified",
+		},
+		{
+			name: "Should reject scanners along with scanner",
+			mutate: func(report *v1alpha1.ClusterComplianceReport) {
+				report.Spec.Controls[0].Mapping.Scanners = []v1alpha1.ScannerMapping{
+					{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{{ID: "1.2.22"}}},
+				}
+			},
+			expected: "either scanner and checks, or scanners must be specified",
+		},
+		{
+			name: "Should reject scanners without checks",
+			mutate: func(report *v1alpha1.ClusterComplianceReport) {
+				report.Spec.Controls[0].Mapping = v1alpha1.Mapping{Scanners: []v1alpha1.ScannerMapping{
+					{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{}},
+				}}
+			},
+			expected: "spec.controls.mapping.scanners.checks in body should have at least 1 items",
+		},
+		{
+			name: "Should reject duplicate control IDs",
+			mutate: func(report *v1alpha1.ClusterComplianceReport) {
+				report.Spec.Controls = append(report.Spec.Controls, newControl("1.0"))
+			},
+			expected: "control IDs must be unique",
+		},
+		{
+			name: "Should reject empty excluded control ID",
+			mutate: func(report *v1alpha1.ClusterComplianceReport) {
+				report.Spec.ExcludedControls = []string{""}
+			},
+			expected: "spec.excludedControls in body should be at least 1 chars long",
+		},
+		{
+			name: "Should reject providers with excluded providers",
+			mutate: func(report *v1alpha1.ClusterComplianceReport) {
+				report.Spec.Controls[0].Applicability = &v1alpha1.Applicability{
+					Providers:         []string{"baremetal"},
+					ExcludedProviders: []string{"eks"},
+				}
+			},
+			expected: "providers and excludedProviders are mutually exclusive",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := newReport(newControl("1.0"))
+			tc.mutate(&report)
+			_, messages := admitComplianceReport(t, report)
+			require.Len(t, messages, 1)
+			assert.Contains(t, messages[0], tc.expected)
+		})
+	}
+}
@@ -115,6 +115,20 @@ var _ = Describe("Starboard CLI", func() {
 						"Scope": Equal(apiextensionsv1beta1.ClusterScoped),
 					}),
 				}),
+				"compliancereports.aquasecurity.github.io": MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"Group":   Equal("aquasecurity.github.io"),
+						"Version": Equal("v1alpha1"),
+						"Names": Equal(apiextensionsv1beta1.CustomResourceDefinitionNames{
+							Plural:     "compliancereports",
+							Singular:   "compliancereport",
+							ShortNames: []string{"nscompliance"},
+							Kind:       "ComplianceReport",
+							ListKind:   "ComplianceReportList",
+						}),
+						"Scope": Equal(apiextensionsv1beta1.NamespaceScoped),
+					}),
+				}),
 				"configauditreports.aquasecurity.github.io": MatchFields(IgnoreExtras, Fields{
 					"Spec": MatchFields(IgnoreExtras, Fields{
 						"Group":   Equal("aquasecurity.github.io"),
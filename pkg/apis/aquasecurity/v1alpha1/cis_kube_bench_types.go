@@ -38,6 +38,10 @@ type CISKubeBenchReportData struct {
 	Scanner         Scanner               `json:"scanner"`
 	Summary         CISKubeBenchSummary   `json:"summary"`
 	Sections        []CISKubeBenchSection `json:"sections"`
+	// Status is set to "Skipped: <reason>" for a node which is not eligible
+	// for CIS Kubernetes Benchmark checks, e.g. a Fargate or virtual-kubelet
+	// node, in which case the report has no sections.
+	Status string `json:"status,omitempty"`
 }
 
 type CISKubeBenchSummary struct {
@@ -7,6 +7,13 @@ import (
 
 const (
 	TTLReportAnnotation = "starboard.aquasecurity.github.io/report-ttl"
+
+	// ReportInitiatorAnnotation records whether a report was last written by
+	// Starboard Operator (InitiatorOperator) or Starboard CLI (InitiatorCLI).
+	ReportInitiatorAnnotation = "starboard.aquasecurity.github.io/initiator"
+
+	InitiatorOperator = "operator"
+	InitiatorCLI      = "cli"
 )
 
 // Severity level of a vulnerability or a configuration audit check.
@@ -55,3 +62,23 @@ type Scanner struct {
 	// Version the version of the scanner.
 	Version string `json:"version"`
 }
+
+// ClusterMetadata describes the Kubernetes cluster where a report was
+// generated, so that reports exported off-cluster retain their origin.
+type ClusterMetadata struct {
+	// Name the name of the cluster as configured for Starboard.
+	Name string `json:"name,omitempty"`
+
+	// KubernetesVersion the version of the Kubernetes API server.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// NodeCount the number of nodes in the cluster.
+	NodeCount int `json:"nodeCount"`
+
+	// Provider the provider of the cluster detected from provider IDs of
+	// nodes, e.g. eks, gke, aks, or baremetal.
+	Provider string `json:"provider,omitempty"`
+
+	// APIGroups names of API groups served by the API server.
+	APIGroups []string `json:"apiGroups,omitempty"`
+}
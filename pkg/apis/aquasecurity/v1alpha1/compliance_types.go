@@ -6,11 +6,74 @@ import (
 
 const (
 	ClusterComplianceReportCRName = "clustercompliancereports.aquasecurity.github.io"
+	ComplianceReportCRName        = "compliancereports.aquasecurity.github.io"
+
+	// ComplianceReportGenerateAnnotation requests immediate generation of a
+	// ClusterComplianceReport regardless of its cron schedule. The value is
+	// the RFC 3339 timestamp of the request.
+	ComplianceReportGenerateAnnotation = "starboard.aquasecurity.github.io/generate-requested-at"
+
+	// ComplianceExcludeControlAnnotationPrefix is the prefix of annotations
+	// of a ClusterComplianceReport which waive controls, i.e. exclude them from
+	// fail counts, until the specified date. For example, the annotation
+	// compliance.aquasecurity.github.io/exclude-control.1.1=2024-12-31 waives
+	// control 1.1 until the end of 31 Dec 2024 UTC.
+	ComplianceExcludeControlAnnotationPrefix = "compliance.aquasecurity.github.io/exclude-control."
+
+	// ComplianceForceGenerateAnnotation set to "true" forces generation of a
+	// ClusterComplianceReport even if its inputs did not change since the
+	// latest generation, i.e. the inputs hash in its status matches.
+	ComplianceForceGenerateAnnotation = "compliance.aquasecurity.github.io/force-generate"
 )
 
 type ClusterComplianceSummary struct {
 	PassCount int `json:"passCount"`
 	FailCount int `json:"failCount"`
+	// WarnCount is the number of results with the WARN status, i.e. the sum
+	// of WarnTotal of controls. Warnings are also counted in PassCount, or in
+	// FailCount if they're reported as failures, unless the
+	// compliance.separateWarnings setting is enabled, in which case they're
+	// counted in WarnCount only.
+	WarnCount int `json:"warnCount"`
+	// SummaryBySeverity holds the number of passing and failing controls
+	// keyed by control severity. Controls which are not applicable, whose
+	// scanner results are unavailable or which are waived are not counted.
+	SummaryBySeverity map[string]ControlCount `json:"summaryBySeverity,omitempty"`
+	// CriticalFailCount is the number of failing controls with critical
+	// severity. The fail counts by severity are the same as the fail counts
+	// of SummaryBySeverity, but are always set, e.g. for printer columns.
+	CriticalFailCount int `json:"criticalFailCount"`
+	// HighFailCount is the number of failing controls with high severity.
+	HighFailCount int `json:"highFailCount"`
+	// MediumFailCount is the number of failing controls with medium severity.
+	MediumFailCount int `json:"mediumFailCount"`
+	// LowFailCount is the number of failing controls with low severity.
+	LowFailCount int `json:"lowFailCount"`
+	// UnknownFailCount is the number of failing controls with unknown or
+	// empty severity.
+	UnknownFailCount int `json:"unknownFailCount"`
+	// ManualCount is the number of controls with ManualStatus, i.e. controls
+	// which must be assessed manually. They're not counted as passed or failed.
+	ManualCount int `json:"manualCount"`
+	// ErrorCount is the number of controls with ErrorStatus, i.e. controls
+	// which could not be evaluated because results of their scanners could
+	// not be mapped. They're not counted as passed or failed.
+	ErrorCount int `json:"errorCount"`
+	// Score is the percentage of passing controls, rounded to an integer.
+	// Only controls with pass or fail results and without a status are
+	// counted, e.g. controls with no applicable resources are not. Score is
+	// not set if there are no such controls.
+	Score *int `json:"score,omitempty"`
+	// NoResultsCount is the number of controls with NoResultsStatus, i.e.
+	// controls which were not evaluated because none of their checks has
+	// scanner results, e.g. before the first scan completed.
+	NoResultsCount int `json:"noResultsCount"`
+}
+
+// ControlCount holds the number of passing and failing controls.
+type ControlCount struct {
+	Pass int `json:"pass"`
+	Fail int `json:"fail"`
 }
 
 // +genclient
@@ -24,37 +87,175 @@ type ClusterComplianceReport struct {
 	Status            ReportStatus `json:"status,omitempty"`
 }
 
-//ReportSpec represent the compliance specification
+// ReportSpec represent the compliance specification
+//
+// Validation markers document constraints of the schema maintained in
+// deploy/crd/clustercompliancereports.crd.yaml, which must be kept in sync.
 type ReportSpec struct {
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Cron        string    `json:"cron"`
-	Version     string    `json:"version"`
-	Controls    []Control `json:"controls"`
+	// +kubebuilder:validation:MinLength=1
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Cron is the schedule of report generation in the standard five-field
+	// cron format, which is validated with a pattern in the CRD.
+	Cron string `json:"cron"`
+	// CronJitterPercent delays each generation by up to the given percentage
+	// of the interval between activations of the cron expression, so that
+	// reports with the same cron expression are not generated at once. The
+	// delay is derived from the name of the report.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	CronJitterPercent int    `json:"cronJitterPercent,omitempty"`
+	Version           string `json:"version"`
+	// +kubebuilder:validation:MaxItems=500
+	// +kubebuilder:validation:XValidation:rule="self.all(c, self.exists_one(d, d.id == c.id))",message="control IDs must be unique"
+	Controls []Control `json:"controls"`
+	// Includes lists names of other ClusterComplianceReports whose controls are
+	// merged into this report. Controls of this report take precedence over
+	// included controls with the same ID.
+	Includes []string `json:"includes,omitempty"`
+	// ExcludedControls lists IDs of controls, including controls of included
+	// specs, which are deliberately excluded from the report, e.g. accepted
+	// risks. Excluded controls are not evaluated, and are reported with
+	// ExcludedStatus instead.
+	ExcludedControls []string `json:"excludedControls,omitempty"`
+	// ReportWarningsAsFail counts results of checks with the WARN status,
+	// e.g. kube-bench checks which must be verified manually, as failed
+	// instead of passed. It's overridden by Control.ReportWarningsAsFail.
+	ReportWarningsAsFail bool `json:"reportWarningsAsFail,omitempty"`
+	// SeverityThreshold is the minimum severity of results of checks which
+	// are counted and listed in details, e.g. HIGH to ignore LOW and MEDIUM
+	// misconfigurations. Results without a severity are never filtered out.
+	// It's overridden by Control.SeverityThreshold. Results are not filtered
+	// if it's not set.
+	// +kubebuilder:validation:Enum=CRITICAL;HIGH;MEDIUM;LOW;UNKNOWN
+	SeverityThreshold Severity `json:"severityThreshold,omitempty"`
+	// Namespaces lists namespaces whose results are included in the report.
+	// Results of other namespaces are included only if their namespaces match
+	// NamespaceSelector. Results of cluster-scoped resources, e.g. nodes, are
+	// always included. Results of all namespaces are included if neither
+	// Namespaces nor NamespaceSelector is set.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceSelector selects namespaces by labels whose results are
+	// included in the report, in addition to Namespaces. It's evaluated each
+	// time the report is generated.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 }
 
-//Control represent the cps controls data and mapping checks
+// Control represent the cps controls data and mapping checks
 type Control struct {
-	ID            string        `json:"id"`
-	Name          string        `json:"name"`
-	Description   string        `json:"description,omitempty"`
-	Kinds         []string      `json:"kinds"`
-	Mapping       Mapping       `json:"mapping"`
-	Severity      Severity      `json:"severity"`
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=32
+	ID string `json:"id"`
+	// +kubebuilder:validation:MinLength=1
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// +kubebuilder:validation:MinItems=1
+	Kinds []string `json:"kinds"`
+	// Mapping maps checks of scanners to the control. It's required unless
+	// the control is manual. The mapping of a manual control is empty, and
+	// serialized without a scanner and checks, so that updates of reports
+	// with manual controls pass the validation of the CRD.
+	Mapping Mapping `json:"mapping,omitempty"`
+	// Manual marks a control which cannot be verified by scanners, e.g. a
+	// review of procedures. Manual controls have no mapping, and are reported
+	// with ManualStatus so that they're assessed by auditors.
+	Manual bool `json:"manual,omitempty"`
+	// +kubebuilder:validation:Enum=CRITICAL;HIGH;MEDIUM;LOW;UNKNOWN
+	Severity Severity `json:"severity"`
+	// DefaultStatus is the result of the control if none of its required
+	// checks has results of any resource, e.g. because the scanner does not
+	// run the checks. PASS and FAIL count the control as passed or failed,
+	// whereas WARN and MANUAL report the control with WarnStatus or
+	// ManualStatus. The control has no result if it's not set.
+	// +kubebuilder:validation:Enum=PASS;WARN;FAIL;MANUAL
 	DefaultStatus ControlStatus `json:"defaultStatus,omitempty"`
+	// Applicability restricts the control to clusters with specified
+	// characteristics. The control is reported with NotApplicableStatus
+	// on other clusters.
+	Applicability *Applicability `json:"applicability,omitempty"`
+	// ReportWarningsAsFail overrides ReportSpec.ReportWarningsAsFail for the
+	// control if it's set.
+	ReportWarningsAsFail *bool `json:"reportWarningsAsFail,omitempty"`
+	// ExcludedNamespaces lists namespaces whose resources are exempt from the
+	// control, e.g. kube-system. Results of resources in these namespaces are
+	// neither counted nor listed in the details of the control.
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+	// SeverityThreshold overrides ReportSpec.SeverityThreshold for the control
+	// if it's set.
+	// +kubebuilder:validation:Enum=CRITICAL;HIGH;MEDIUM;LOW;UNKNOWN
+	SeverityThreshold Severity `json:"severityThreshold,omitempty"`
 }
 
-//SpecCheck represent the scanner who perform the control check
+// Applicability describes characteristics of clusters which a control applies
+// to. The control applies only if all specified conditions are met.
+//
+// +kubebuilder:validation:XValidation:rule="!has(self.providers) || !has(self.excludedProviders)",message="providers and excludedProviders are mutually exclusive"
+type Applicability struct {
+	// Providers lists providers of clusters which the control applies to,
+	// e.g. baremetal.
+	Providers []string `json:"providers,omitempty"`
+	// ExcludedProviders lists providers of clusters which the control does not
+	// apply to, e.g. eks, gke, and aks for controls of managed control planes.
+	ExcludedProviders []string `json:"excludedProviders,omitempty"`
+	// KubernetesVersion is a constraint of the Kubernetes version, e.g.
+	// ">= 1.21, < 1.25".
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// APIGroups lists API groups which must be served by the cluster, e.g.
+	// policy.
+	APIGroups []string `json:"apiGroups,omitempty"`
+}
+
+// SpecCheck represent the scanner who perform the control check
 type SpecCheck struct {
+	// +kubebuilder:validation:MinLength=1
 	ID string `json:"id"`
+	// Optional marks a check that may be absent from scanner results, e.g. because it's
+	// only implemented by newer scanner versions. A missing optional check is recorded
+	// as NotAvailableStatus instead of failing the control with its DefaultStatus.
+	Optional bool `json:"optional,omitempty"`
 }
 
-//Mapping represent the scanner who perform the control check
+// Mapping represent the scanner who perform the control check
+//
+// Checks of a single scanner are mapped with Scanner and Checks, and checks of
+// multiple scanners with Scanners, whose results are merged into the control.
+// Both forms are mutually exclusive.
 type Mapping struct {
-	Scanner string      `json:"scanner"`
-	Checks  []SpecCheck `json:"checks"`
+	// +kubebuilder:validation:Pattern=`^config-audit$|^kube-bench$|^kube-hunter$`
+	Scanner string `json:"scanner,omitempty"`
+	// +kubebuilder:validation:MinItems=1
+	Checks []SpecCheck `json:"checks,omitempty"`
+	// +kubebuilder:validation:MinItems=1
+	Scanners []ScannerMapping `json:"scanners,omitempty"`
+	// +kubebuilder:validation:Enum=count;allOf;anyOf
+	// +kubebuilder:default=count
+	Aggregation Aggregation `json:"aggregation,omitempty"`
+}
+
+// ScannerMapping maps checks of a single scanner to a control.
+type ScannerMapping struct {
+	// +kubebuilder:validation:Pattern=`^config-audit$|^kube-bench$|^kube-hunter$`
+	Scanner string `json:"scanner"`
+	// +kubebuilder:validation:MinItems=1
+	Checks []SpecCheck `json:"checks"`
 }
 
+// Aggregation defines how the results of the checks mapped to a control
+// are combined into the control pass and fail totals.
+type Aggregation string
+
+const (
+	// CountAggregation sums the statuses of all mapped checks for all resources.
+	CountAggregation Aggregation = "count"
+	// AllOfAggregation passes a resource only if all mapped checks pass for it.
+	AllOfAggregation Aggregation = "allOf"
+	// AnyOfAggregation passes a resource if any mapped check passes for it.
+	AnyOfAggregation Aggregation = "anyOf"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // ClusterComplianceReportList is a list of compliance kinds.
@@ -64,12 +265,103 @@ type ClusterComplianceReportList struct {
 	Items           []ClusterComplianceReport `json:"items"`
 }
 
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ComplianceReport is a namespaced compliance report, which summarizes results
+// of controls of a ClusterComplianceReport for resources in its namespace.
+// It's generated along with the ClusterComplianceReport, which owns it.
+type ComplianceReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Report            ComplianceReportData `json:"report"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ComplianceReportList is a list of ComplianceReport resources.
+type ComplianceReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ComplianceReport `json:"items"`
+}
+
+// ComplianceReportData holds results of controls of namespaced kinds, which
+// only count results of resources in the namespace of the report.
+type ComplianceReportData struct {
+	UpdateTimestamp metav1.Time              `json:"updateTimestamp"`
+	Type            Compliance               `json:"type"`
+	Summary         ClusterComplianceSummary `json:"summary"`
+	ControlChecks   []ControlCheck           `json:"controlCheck"`
+}
+
 type ReportStatus struct {
 	UpdateTimestamp metav1.Time              `json:"updateTimestamp"`
 	Summary         ClusterComplianceSummary `json:"summary"`
 	ControlChecks   []ControlCheck           `json:"controlCheck"`
+	// Cluster describes the Kubernetes cluster where the report was generated.
+	Cluster *ClusterMetadata `json:"cluster,omitempty"`
+	// Conditions describe the latest observations of the report generation,
+	// e.g. the DegradedCondition.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// NextScheduleTime is the time when the report is generated next
+	// according to the cron expression of the spec.
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+	// LastGenerationDuration is how long the latest generation of the report
+	// took.
+	LastGenerationDuration *metav1.Duration `json:"lastGenerationDuration,omitempty"`
+	// InputsHash is the hash of inputs of the latest generation, i.e. the
+	// resolved spec, waivers, cluster metadata, and UIDs and resource versions
+	// of consumed scanner reports. Generation is skipped if it matches.
+	InputsHash string `json:"inputsHash,omitempty"`
+	// GenerationCount is the number of times the status was written at the
+	// end of a generation. Skipped generations are not counted.
+	GenerationCount int64 `json:"generationCount,omitempty"`
+	// LastChangedTimestamp is the UpdateTimestamp of the latest generation
+	// whose Summary, ControlChecks or Cluster differed from the previous one.
+	LastChangedTimestamp *metav1.Time `json:"lastChangedTimestamp,omitempty"`
+	// History holds snapshots of the latest generations, the latest first,
+	// up to the limit set by the compliance.historyLimit setting.
+	History []ComplianceSnapshot `json:"history,omitempty"`
 }
 
+// ComplianceSnapshot holds the results of a single generation of the report.
+type ComplianceSnapshot struct {
+	UpdateTimestamp metav1.Time              `json:"updateTimestamp"`
+	Summary         ClusterComplianceSummary `json:"summary"`
+	ControlChecks   []ControlCheckTotals     `json:"controlCheck,omitempty"`
+}
+
+// ControlCheckTotals holds the numbers of passing and failing results of a
+// control.
+type ControlCheckTotals struct {
+	ID        string        `json:"id"`
+	PassTotal int           `json:"passTotal"`
+	FailTotal int           `json:"failTotal"`
+	Status    ControlStatus `json:"status,omitempty"`
+}
+
+const (
+	// DegradedCondition is true when the report was generated without
+	// results of some scanners, e.g. because reading them timed out.
+	DegradedCondition = "Degraded"
+	// ReadyCondition is true when the latest generation of the report
+	// succeeded with results of scanners.
+	ReadyCondition = "Ready"
+)
+
+const (
+	// ReasonScannerResultsMissing is the reason of the ReadyCondition when
+	// none of the controls of the report has results of scanners.
+	ReasonScannerResultsMissing = "ScannerResultsMissing"
+	// ReasonGenerationError is the reason of the ReadyCondition when the
+	// latest generation of the report failed.
+	ReasonGenerationError = "GenerationError"
+	// ReasonInvalidCron is the reason of the ReadyCondition when the cron
+	// expression of the report cannot be parsed.
+	ReasonInvalidCron = "InvalidCron"
+)
+
 // ControlCheck provides the result of conducting a single audit step.
 type ControlCheck struct {
 	ID          string   `json:"id"`
@@ -78,6 +370,35 @@ type ControlCheck struct {
 	PassTotal   int      `json:"passTotal"`
 	FailTotal   int      `json:"failTotal"`
 	Severity    Severity `json:"severity"`
+	// WarnTotal is the number of results of the mapped checks with the WARN
+	// status. Warnings are counted as passed, or as failed if warnings are
+	// reported as failures. With the compliance.separateWarnings setting they
+	// are counted as neither, unless they're reported as failures.
+	WarnTotal int `json:"warnTotal,omitempty"`
+	// Score is the percentage of passing results of the control, rounded to
+	// an integer. Score is not set if the control has no results.
+	Score *int `json:"score,omitempty"`
+	// Status is set to WaivedStatus for a waived control, whose failures are
+	// not counted in FailTotal, or to DataUnavailableStatus for a control
+	// whose scanner results could not be read, or to NotApplicableStatus for
+	// a control which does not apply to the cluster, or to ExcludedStatus for
+	// a control excluded by the spec, or to NoResultsStatus for a control
+	// whose checks have no scanner results, or to ManualStatus for a control
+	// which must be assessed manually, or to ErrorStatus for a control whose
+	// scanner results could not be mapped.
+	Status ControlStatus  `json:"status,omitempty"`
+	Waiver *ControlWaiver `json:"waiver,omitempty"`
+	// Error is the reason of ErrorStatus.
+	Error string `json:"error,omitempty"`
+}
+
+// ControlWaiver records the exclusion of a control from fail counts granted
+// with an annotation of the ClusterComplianceReport.
+type ControlWaiver struct {
+	// Annotation is the key of the annotation which granted the waiver.
+	Annotation string `json:"annotation"`
+	// Expires is the time when the control is reinstated.
+	Expires metav1.Time `json:"expires"`
 }
 
 type ControlStatus string
@@ -86,4 +407,28 @@ const (
 	FailStatus ControlStatus = "FAIL"
 	PassStatus ControlStatus = "PASS"
 	WarnStatus ControlStatus = "WARN"
+	// ManualStatus is reported for a manual control, or for a control without
+	// scanner results whose DefaultStatus is ManualStatus, which must be
+	// assessed manually.
+	ManualStatus ControlStatus = "MANUAL"
+	// NotAvailableStatus is reported for an optional check which is missing in scanner results.
+	NotAvailableStatus ControlStatus = "NOT_AVAILABLE"
+	// WaivedStatus is reported for a failed check of a control which is waived.
+	WaivedStatus ControlStatus = "WAIVED"
+	// DataUnavailableStatus is reported for a control whose scanner results
+	// could not be read, e.g. because reading them timed out.
+	DataUnavailableStatus ControlStatus = "DATA_UNAVAILABLE"
+	// NotApplicableStatus is reported for a control whose applicability
+	// conditions are not met by the cluster.
+	NotApplicableStatus ControlStatus = "NOT_APPLICABLE"
+	// ExcludedStatus is reported for a control which is listed in the
+	// excluded controls of the spec.
+	ExcludedStatus ControlStatus = "EXCLUDED"
+	// ErrorStatus is reported for a control whose scanner results could not
+	// be mapped to checks, e.g. because the scanner is not supported. Other
+	// controls of the report are evaluated nevertheless.
+	ErrorStatus ControlStatus = "ERROR"
+	// NoResultsStatus is reported for a control without DefaultStatus whose
+	// checks have no results of any resource, so it was not evaluated.
+	NoResultsStatus ControlStatus = "NO_RESULTS"
 )
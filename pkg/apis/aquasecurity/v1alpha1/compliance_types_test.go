@@ -0,0 +1,31 @@
+package v1alpha1_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControl_ManualRoundTrip(t *testing.T) {
+	control := v1alpha1.Control{
+		ID:       "3.2",
+		Name:     "Review network policies",
+		Kinds:    []string{"NetworkPolicy"},
+		Manual:   true,
+		Severity: v1alpha1.SeverityMedium,
+	}
+
+	data, err := json.Marshal(control)
+	require.NoError(t, err)
+
+	var raw map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &raw))
+	assert.Equal(t, map[string]interface{}{}, raw["mapping"])
+
+	var actual v1alpha1.Control
+	require.NoError(t, json.Unmarshal(data, &actual))
+	assert.Equal(t, control, actual)
+}
@@ -41,6 +41,17 @@ type ControlCheckDetails struct {
 	Description        string               `json:"description,omitempty"`
 	Severity           Severity             `json:"severity"`
 	ScannerCheckResult []ScannerCheckResult `json:"checkResults"`
+	// Spec is the name of the compliance report the control originates from.
+	Spec string `json:"spec,omitempty"`
+	// Waiver is set if the control was waived when the report was generated.
+	Waiver *ControlWaiver `json:"waiver,omitempty"`
+	// MissingChecks lists IDs of required checks of the control without
+	// results of any resource, for which the DefaultStatus of the control
+	// applies.
+	MissingChecks []string `json:"missingChecks,omitempty"`
+	// ExcludedNamespaces lists namespaces exempt from the control whose
+	// results were skipped.
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
 }
 
 type ResultDetails struct {
@@ -48,13 +59,29 @@ type ResultDetails struct {
 	Namespace string        `json:"namespace,omitempty"`
 	Msg       string        `json:"msg"`
 	Status    ControlStatus `json:"status"`
+	// Severity is the severity of the result as reported by the scanner,
+	// e.g. the severity of a kube-hunter vulnerability. It's empty for
+	// scanners which do not report severities of results.
+	Severity Severity `json:"severity,omitempty"`
+	// ClusterScoped is true for results of cluster-scoped resources in reports
+	// scoped to namespaces, which are included regardless of the namespaces.
+	ClusterScoped bool `json:"clusterScoped,omitempty"`
 }
 
 type ScannerCheckResult struct {
-	ObjectType  string          `json:"objectType"`
-	ID          string          `json:"id,omitempty"`
+	ObjectType string `json:"objectType"`
+	ID         string `json:"id,omitempty"`
+	// Scanner is the name of the scanner which the check is mapped to, e.g.
+	// config-audit, because a control may map checks of multiple scanners.
+	Scanner     string          `json:"scanner,omitempty"`
 	Remediation string          `json:"remediation,omitempty"`
 	Details     []ResultDetails `json:"details"`
+	// Truncated is true if Details were truncated to the limit of entries
+	// stored per check, so that the report fits the size limit of objects.
+	Truncated bool `json:"truncated,omitempty"`
+	// TotalCount is the number of entries before Details were truncated. It's
+	// set only if Truncated is true.
+	TotalCount int `json:"totalCount,omitempty"`
 }
 
 // Compliance is the specs for a security assessment report.
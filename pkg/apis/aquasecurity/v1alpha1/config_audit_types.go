@@ -27,6 +27,9 @@ type ConfigAuditSummary struct {
 
 	// LowCount is the number of failed check with low severity.
 	LowCount int `json:"lowCount"`
+
+	// ErrorCount is the number of checks which could not be evaluated.
+	ErrorCount int `json:"errorCount,omitempty"`
 }
 
 // +genclient
@@ -112,6 +115,12 @@ type Check struct {
 	Severity    Severity `json:"severity"`
 	Category    string   `json:"category,omitempty"`
 
+	// ScannerCheckID is the ID of the check as reported by the scanner. It
+	// differs from ID if the scanner renamed the check, and ID is the
+	// canonical ID of the check.
+	// +optional
+	ScannerCheckID string `json:"scannerCheckID,omitempty"`
+
 	Messages []string `json:"messages,omitempty"`
 
 	// Remediation provides description or links to external resources to remediate failing check.
@@ -123,12 +132,22 @@ type Check struct {
 	// Scope indicates the section of config that was audited.
 	// +optional
 	Scope *CheckScope `json:"scope,omitempty"`
+
+	// Error is the error which occurred evaluating the check, e.g. a runtime
+	// error of a Rego policy. A check with an error is neither passed nor
+	// failed, and it's counted as ErrorCount in the summary.
+	// +optional
+	Error string `json:"error,omitempty"`
 }
 
 func ConfigAuditSummaryFromChecks(checks []Check) ConfigAuditSummary {
 	summary := ConfigAuditSummary{}
 
 	for _, check := range checks {
+		if check.Error != "" {
+			summary.ErrorCount++
+			continue
+		}
 		if check.Success {
 			continue
 		}
@@ -58,6 +58,10 @@ func TestConfigAuditSummaryFromChecks(t *testing.T) {
 			Severity: v1alpha1.SeverityLow,
 			Success:  true,
 		},
+		{
+			Severity: v1alpha1.SeverityUnknown,
+			Error:    "eval_conflict_error: functions must not produce multiple outputs for same inputs",
+		},
 	}
 	summary := v1alpha1.ConfigAuditSummaryFromChecks(checks)
 	assert.Equal(t, v1alpha1.ConfigAuditSummary{
@@ -65,5 +69,6 @@ func TestConfigAuditSummaryFromChecks(t *testing.T) {
 		HighCount:     1,
 		MediumCount:   3,
 		LowCount:      1,
+		ErrorCount:    1,
 	}, summary)
 }
@@ -0,0 +1,78 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ImageInventoriesCRName    = "imageinventories.aquasecurity.github.io"
+	ImageInventoriesCRVersion = "v1alpha1"
+	ImageInventoryKind        = "ImageInventory"
+	ImageInventoryListKind    = "ImageInventoryList"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ImageInventory lists container images running in the cluster and the
+// workloads that run them. The inventory is split into chunks by registry,
+// and each chunk is stored in a separate ImageInventory.
+type ImageInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Report ImageInventoryData `json:"report"`
+}
+
+// ImageInventoryData is the actual image inventory data.
+type ImageInventoryData struct {
+	// UpdateTimestamp is a timestamp representing the server time in UTC when
+	// this inventory was updated.
+	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
+
+	// Registry is the registry of all Images listed in this inventory.
+	Registry Registry `json:"registry"`
+
+	// Images is a list of images, identified by digest, with workloads that
+	// run them.
+	Images []InventoryImage `json:"images"`
+}
+
+// InventoryImage is a container image identified by digest.
+type InventoryImage struct {
+	// Repository is the name of the repository in the image registry.
+	Repository string `json:"repository"`
+
+	// Digest is a unique and immutable identifier of the image.
+	Digest string `json:"digest"`
+
+	// Workloads is a list of workload containers that run the image.
+	Workloads []ImageWorkload `json:"workloads"`
+}
+
+// ImageWorkload is a container of a Kubernetes workload.
+type ImageWorkload struct {
+	// Namespace is the namespace of the workload.
+	Namespace string `json:"namespace"`
+
+	// Kind is the kind of the workload, e.g. ReplicaSet or StatefulSet. It's
+	// Pod for pods without controller.
+	Kind string `json:"kind"`
+
+	// Name is the name of the workload.
+	Name string `json:"name"`
+
+	// Container is the name of the container.
+	Container string `json:"container"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ImageInventoryList is a list of ImageInventory resources.
+type ImageInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ImageInventory `json:"items"`
+}
@@ -52,6 +52,11 @@ type KubeHunterReportData struct {
 	Scanner         Scanner                   `json:"scanner"`
 	Summary         KubeHunterSummary         `json:"summary"`
 	Vulnerabilities []KubeHunterVulnerability `json:"vulnerabilities"`
+	// Truncated indicates that Vulnerabilities were cut to the configured
+	// maximum number of findings. Summary always reflects all findings.
+	Truncated bool `json:"truncated,omitempty"`
+	// Cluster describes the Kubernetes cluster which was scanned.
+	Cluster *ClusterMetadata `json:"cluster,omitempty"`
 }
 
 type KubeHunterVulnerability struct {
@@ -34,6 +34,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&VulnerabilityReportList{},
 		&ClusterVulnerabilityReport{},
 		&ClusterVulnerabilityReportList{},
+		&PackageInventory{},
+		&PackageInventoryList{},
+		&WorkloadVulnerabilitySummary{},
+		&WorkloadVulnerabilitySummaryList{},
+		&ImageInventory{},
+		&ImageInventoryList{},
 		&CISKubeBenchReport{},
 		&CISKubeBenchReportList{},
 		&KubeHunterReport{},
@@ -44,8 +50,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ClusterConfigAuditReportList{},
 		&ClusterComplianceReport{},
 		&ClusterComplianceReportList{},
+		&ComplianceReport{},
+		&ComplianceReportList{},
 		&ClusterComplianceDetailReport{},
 		&ClusterComplianceDetailReportList{},
+		&ClusterRiskReport{},
+		&ClusterRiskReportList{},
 	)
 	meta.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
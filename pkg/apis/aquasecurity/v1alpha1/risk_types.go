@@ -0,0 +1,66 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ClusterRiskReportsCRName    = "clusterriskreports.aquasecurity.github.io"
+	ClusterRiskReportsCRVersion = "v1alpha1"
+	ClusterRiskReportKind       = "ClusterRiskReport"
+	ClusterRiskReportListKind   = "ClusterRiskReportList"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterRiskReport holds the risk score of the cluster, which is the weighted
+// sum of findings of all scanners, along with the breakdown of the score by
+// component.
+type ClusterRiskReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Report ClusterRiskReportData `json:"report"`
+}
+
+// ClusterRiskReportData is the actual cluster risk report data.
+type ClusterRiskReportData struct {
+	// UpdateTimestamp is a timestamp representing the server time in UTC when
+	// this report was updated.
+	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
+
+	// Score is the sum of scores of all Components.
+	Score float64 `json:"score"`
+
+	// Components is the breakdown of the Score.
+	Components []RiskComponent `json:"components"`
+}
+
+// RiskComponent is a component of the cluster risk score.
+type RiskComponent struct {
+	// Name is the name of the component, e.g. criticalVulnerabilities.
+	Name string `json:"name"`
+
+	// Value is the measured value of the component, e.g. the number of
+	// unique critical vulnerabilities.
+	Value float64 `json:"value"`
+
+	// Weight is the weight of the component configured when the score was
+	// computed.
+	Weight float64 `json:"weight"`
+
+	// Score is the Value multiplied by the Weight.
+	Score float64 `json:"score"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterRiskReportList is a list of ClusterRiskReport resources.
+type ClusterRiskReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterRiskReport `json:"items"`
+}
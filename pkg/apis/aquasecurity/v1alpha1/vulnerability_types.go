@@ -11,6 +11,16 @@ const (
 	VulnerabilityReportListKind   = "VulnerabilityReportList"
 
 	ClusterVulnerabilityReportsCRName = "clustervulnerabilityreports.aquasecurity.github.io"
+
+	PackageInventoriesCRName    = "packageinventories.aquasecurity.github.io"
+	PackageInventoriesCRVersion = "v1alpha1"
+	PackageInventoryKind        = "PackageInventory"
+	PackageInventoryListKind    = "PackageInventoryList"
+
+	WorkloadVulnerabilitySummariesCRName    = "workloadvulnerabilitysummaries.aquasecurity.github.io"
+	WorkloadVulnerabilitySummariesCRVersion = "v1alpha1"
+	WorkloadVulnerabilitySummaryKind        = "WorkloadVulnerabilitySummary"
+	WorkloadVulnerabilitySummaryListKind    = "WorkloadVulnerabilitySummaryList"
 )
 
 // VulnerabilitySummary is a summary of Vulnerability counts grouped by Severity.
@@ -32,6 +42,15 @@ type VulnerabilitySummary struct {
 
 	// NoneCount is the number of packages without any vulnerability.
 	NoneCount int `json:"noneCount"`
+
+	// KnownExploitedCount is the number of vulnerabilities listed in the
+	// known exploited vulnerabilities catalog. It's only set when
+	// vulnerabilities are prioritized.
+	KnownExploitedCount int `json:"knownExploitedCount,omitempty"`
+
+	// PriorityCounts is the number of vulnerabilities by Priority. It's only
+	// set when vulnerabilities are prioritized.
+	PriorityCounts map[Priority]int `json:"priorityCounts,omitempty"`
 }
 
 // Registry is a collection of repositories used to store Artifacts.
@@ -54,6 +73,16 @@ type Artifact struct {
 
 	// MimeType represents a type and format of an Artifact.
 	MimeType string `json:"mimeType,omitempty"`
+
+	// Created is the time when the Artifact was built, if known.
+	Created *metav1.Time `json:"created,omitempty"`
+
+	// LayersCount is the number of filesystem layers of the Artifact, if known.
+	LayersCount int `json:"layersCount,omitempty"`
+
+	// Platform is the platform of the image scanned from a multi-arch image
+	// index in the os/arch[/variant] format, e.g. linux/arm64, if known.
+	Platform string `json:"platform,omitempty"`
 }
 
 // Vulnerability is the spec for a vulnerability record.
@@ -76,8 +105,46 @@ type Vulnerability struct {
 	PrimaryLink string   `json:"primaryLink,omitempty"`
 	Links       []string `json:"links"`
 	Score       *float64 `json:"score,omitempty"`
+
+	// Target is the source in which the vulnerability was found if it's not
+	// the Artifact itself, e.g. an SBOM attached to the workload.
+	Target string `json:"target,omitempty"`
+
+	// KnownExploited is true if the vulnerability is listed in the known
+	// exploited vulnerabilities catalog, e.g. CISA KEV.
+	KnownExploited bool `json:"knownExploited,omitempty"`
+
+	// EPSSScore is the probability of exploitation of the vulnerability in
+	// the next 30 days according to the Exploit Prediction Scoring System.
+	EPSSScore *float64 `json:"epssScore,omitempty"`
+
+	// Priority is derived from the Severity, KnownExploited and EPSSScore. It's
+	// only set when vulnerabilities are prioritized.
+	Priority Priority `json:"priority,omitempty"`
 }
 
+// Priority ranks a vulnerability by urgency of remediation, P1 being the most
+// urgent.
+type Priority string
+
+const (
+	// PriorityP1 is the priority of known exploited vulnerabilities with
+	// CRITICAL or HIGH severity.
+	PriorityP1 Priority = "P1"
+	// PriorityP2 is the priority of other known exploited vulnerabilities,
+	// and of vulnerabilities with CRITICAL or HIGH severity likely to be
+	// exploited according to their EPSS score.
+	PriorityP2 Priority = "P2"
+	// PriorityP3 is the priority of other vulnerabilities with CRITICAL or
+	// HIGH severity, or likely to be exploited.
+	PriorityP3 Priority = "P3"
+	// PriorityP4 is the priority of all other vulnerabilities.
+	PriorityP4 Priority = "P4"
+)
+
+// Priorities lists priorities from the most urgent one.
+var Priorities = []Priority{PriorityP1, PriorityP2, PriorityP3, PriorityP4}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -98,6 +165,16 @@ type VulnerabilityReportData struct {
 	// UpdateTimestamp is a timestamp representing the server time in UTC when this report was updated.
 	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
 
+	// GenerationCount is the number of times this report was written. It's
+	// maintained by the report writer.
+	GenerationCount int64 `json:"generationCount,omitempty"`
+
+	// LastChangedTimestamp is the UpdateTimestamp of the latest revision of
+	// this report whose data, excluding timestamps and GenerationCount,
+	// differed from the previous revision. It's maintained by the report
+	// writer.
+	LastChangedTimestamp *metav1.Time `json:"lastChangedTimestamp,omitempty"`
+
 	// Scanner is the scanner that generated this report.
 	Scanner Scanner `json:"scanner"`
 
@@ -112,6 +189,49 @@ type VulnerabilityReportData struct {
 
 	// Vulnerabilities is a list of operating system (OS) or application software Vulnerability items found in the Artifact.
 	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+
+	// Packages is an inventory of operating system (OS) and application
+	// software packages found in the Artifact. It's only set when the scanner
+	// is configured to list all packages and the inventory is small enough to
+	// be stored inline. Otherwise, it's stored in the PackageInventory with
+	// the same name as the report.
+	Packages []Package `json:"packages,omitempty"`
+
+	// Warnings is a list of problems which did not fail the scan, but may
+	// have caused some Vulnerabilities to be missed, e.g. a malformed SBOM.
+	Warnings []string `json:"warnings,omitempty"`
+
+	// SkipReason is set if the Artifact was deliberately not scanned. Such a
+	// report has no Vulnerabilities and an empty Summary, which does not mean
+	// that the Artifact is free of vulnerabilities.
+	SkipReason SkipReason `json:"skipReason,omitempty"`
+}
+
+// SkipReason explains why an Artifact was not scanned for vulnerabilities.
+type SkipReason string
+
+const (
+	// SkipReasonRegistryExcluded is the reason for not scanning an Artifact
+	// pulled from a registry which is excluded from scanning, e.g. because
+	// the license of its images forbids scanning.
+	SkipReasonRegistryExcluded SkipReason = "RegistryExcluded"
+
+	// SkipReasonContainerExcluded is the reason for not scanning an Artifact
+	// of a container excluded with the skip-containers annotation of the pod
+	// template.
+	SkipReasonContainerExcluded SkipReason = "ContainerExcluded"
+)
+
+// Package is a compact description of a software package found in the Artifact.
+type Package struct {
+	// Name is the name of the package.
+	Name string `json:"name"`
+
+	// Version is the installed version of the package.
+	Version string `json:"version"`
+
+	// Type is the type of the package, e.g. alpine, debian, npm, or jar.
+	Type string `json:"type,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -145,3 +265,104 @@ type ClusterVulnerabilityReportList struct {
 
 	Items []ClusterVulnerabilityReport `json:"items"`
 }
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PackageInventory is a specification for the PackageInventory resource.
+//
+// It holds the inventory of packages found in a container image when it is
+// too large to be stored inline in the corresponding VulnerabilityReport.
+type PackageInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Report is the actual package inventory data.
+	Report PackageInventoryData `json:"report"`
+}
+
+// PackageInventoryData is the spec for the package inventory.
+type PackageInventoryData struct {
+	// UpdateTimestamp is a timestamp representing the server time in UTC when this inventory was updated.
+	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
+
+	// Artifact is a container image the Packages were found in.
+	Artifact Artifact `json:"artifact"`
+
+	// Packages is a list of operating system (OS) and application software packages found in the Artifact.
+	Packages []Package `json:"packages"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PackageInventoryList is a list of PackageInventory resources.
+type PackageInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []PackageInventory `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WorkloadVulnerabilitySummary is a specification for the
+// WorkloadVulnerabilitySummary resource.
+//
+// It consolidates summaries of VulnerabilityReports of all containers of a
+// workload, so that consumers do not have to join them.
+type WorkloadVulnerabilitySummary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Report is the actual workload vulnerability summary data.
+	Report WorkloadVulnerabilitySummaryData `json:"report"`
+}
+
+// WorkloadVulnerabilitySummaryData is the spec for the workload vulnerability
+// summary.
+type WorkloadVulnerabilitySummaryData struct {
+	// UpdateTimestamp is a timestamp representing the server time in UTC when this summary was updated.
+	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
+
+	// MaxSeverity is the highest severity of vulnerabilities found in any
+	// container of the workload, or NONE if no vulnerabilities were found.
+	MaxSeverity Severity `json:"maxSeverity"`
+
+	// Summary is the sum of vulnerability counts of all containers.
+	Summary VulnerabilitySummary `json:"summary"`
+
+	// Containers lists the summary of the VulnerabilityReport of each
+	// container sorted by the container and report name.
+	Containers []ContainerVulnerabilitySummary `json:"containers"`
+}
+
+// ContainerVulnerabilitySummary is the summary of the VulnerabilityReport of a
+// container of a workload.
+type ContainerVulnerabilitySummary struct {
+	// Name is the name of the container.
+	Name string `json:"name"`
+
+	// Report is the name of the VulnerabilityReport of the container.
+	Report string `json:"report"`
+
+	// Artifact is the container image scanned for vulnerabilities.
+	Artifact Artifact `json:"artifact"`
+
+	// Summary is the summary of the VulnerabilityReport.
+	Summary VulnerabilitySummary `json:"summary"`
+
+	// SkipReason is set if the container image was deliberately not scanned.
+	SkipReason SkipReason `json:"skipReason,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WorkloadVulnerabilitySummaryList is a list of WorkloadVulnerabilitySummary
+// resources.
+type WorkloadVulnerabilitySummaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []WorkloadVulnerabilitySummary `json:"items"`
+}
@@ -6,12 +6,48 @@
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Applicability) DeepCopyInto(out *Applicability) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedProviders != nil {
+		in, out := &in.ExcludedProviders, &out.ExcludedProviders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.APIGroups != nil {
+		in, out := &in.APIGroups, &out.APIGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Applicability.
+func (in *Applicability) DeepCopy() *Applicability {
+	if in == nil {
+		return nil
+	}
+	out := new(Applicability)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Artifact) DeepCopyInto(out *Artifact) {
 	*out = *in
+	if in.Created != nil {
+		in, out := &in.Created, &out.Created
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -261,7 +297,7 @@ func (in *ClusterComplianceDetailReportData) DeepCopyInto(out *ClusterCompliance
 	*out = *in
 	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
 	out.Type = in.Type
-	out.Summary = in.Summary
+	in.Summary.DeepCopyInto(&out.Summary)
 	if in.ControlChecks != nil {
 		in, out := &in.ControlChecks, &out.ControlChecks
 		*out = make([]ControlCheckDetails, len(*in))
@@ -379,6 +415,18 @@ func (in *ClusterComplianceReportList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterComplianceSummary) DeepCopyInto(out *ClusterComplianceSummary) {
 	*out = *in
+	if in.SummaryBySeverity != nil {
+		in, out := &in.SummaryBySeverity, &out.SummaryBySeverity
+		*out = make(map[string]ControlCount, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Score != nil {
+		in, out := &in.Score, &out.Score
+		*out = new(int)
+		**out = **in
+	}
 	return
 }
 
@@ -452,6 +500,109 @@ func (in *ClusterConfigAuditReportList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMetadata) DeepCopyInto(out *ClusterMetadata) {
+	*out = *in
+	if in.APIGroups != nil {
+		in, out := &in.APIGroups, &out.APIGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMetadata.
+func (in *ClusterMetadata) DeepCopy() *ClusterMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRiskReport) DeepCopyInto(out *ClusterRiskReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRiskReport.
+func (in *ClusterRiskReport) DeepCopy() *ClusterRiskReport {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRiskReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRiskReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRiskReportData) DeepCopyInto(out *ClusterRiskReportData) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]RiskComponent, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRiskReportData.
+func (in *ClusterRiskReportData) DeepCopy() *ClusterRiskReportData {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRiskReportData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRiskReportList) DeepCopyInto(out *ClusterRiskReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterRiskReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRiskReportList.
+func (in *ClusterRiskReportList) DeepCopy() *ClusterRiskReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRiskReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRiskReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterVulnerabilityReport) DeepCopyInto(out *ClusterVulnerabilityReport) {
 	*out = *in
@@ -528,6 +679,115 @@ func (in *Compliance) DeepCopy() *Compliance {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceReport) DeepCopyInto(out *ComplianceReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceReport.
+func (in *ComplianceReport) DeepCopy() *ComplianceReport {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComplianceReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceReportData) DeepCopyInto(out *ComplianceReportData) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	out.Type = in.Type
+	in.Summary.DeepCopyInto(&out.Summary)
+	if in.ControlChecks != nil {
+		in, out := &in.ControlChecks, &out.ControlChecks
+		*out = make([]ControlCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceReportData.
+func (in *ComplianceReportData) DeepCopy() *ComplianceReportData {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceReportData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceReportList) DeepCopyInto(out *ComplianceReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ComplianceReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceReportList.
+func (in *ComplianceReportList) DeepCopy() *ComplianceReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComplianceReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceSnapshot) DeepCopyInto(out *ComplianceSnapshot) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	in.Summary.DeepCopyInto(&out.Summary)
+	if in.ControlChecks != nil {
+		in, out := &in.ControlChecks, &out.ControlChecks
+		*out = make([]ControlCheckTotals, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceSnapshot.
+func (in *ComplianceSnapshot) DeepCopy() *ComplianceSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigAuditReport) DeepCopyInto(out *ConfigAuditReport) {
 	*out = *in
@@ -654,6 +914,24 @@ func (in *ConfigAuditSummary) DeepCopy() *ConfigAuditSummary {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerVulnerabilitySummary) DeepCopyInto(out *ContainerVulnerabilitySummary) {
+	*out = *in
+	in.Artifact.DeepCopyInto(&out.Artifact)
+	in.Summary.DeepCopyInto(&out.Summary)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerVulnerabilitySummary.
+func (in *ContainerVulnerabilitySummary) DeepCopy() *ContainerVulnerabilitySummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerVulnerabilitySummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Control) DeepCopyInto(out *Control) {
 	*out = *in
@@ -663,6 +941,21 @@ func (in *Control) DeepCopyInto(out *Control) {
 		copy(*out, *in)
 	}
 	in.Mapping.DeepCopyInto(&out.Mapping)
+	if in.Applicability != nil {
+		in, out := &in.Applicability, &out.Applicability
+		*out = new(Applicability)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReportWarningsAsFail != nil {
+		in, out := &in.ReportWarningsAsFail, &out.ReportWarningsAsFail
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExcludedNamespaces != nil {
+		in, out := &in.ExcludedNamespaces, &out.ExcludedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -679,6 +972,16 @@ func (in *Control) DeepCopy() *Control {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlCheck) DeepCopyInto(out *ControlCheck) {
 	*out = *in
+	if in.Score != nil {
+		in, out := &in.Score, &out.Score
+		*out = new(int)
+		**out = **in
+	}
+	if in.Waiver != nil {
+		in, out := &in.Waiver, &out.Waiver
+		*out = new(ControlWaiver)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -702,6 +1005,21 @@ func (in *ControlCheckDetails) DeepCopyInto(out *ControlCheckDetails) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Waiver != nil {
+		in, out := &in.Waiver, &out.Waiver
+		*out = new(ControlWaiver)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MissingChecks != nil {
+		in, out := &in.MissingChecks, &out.MissingChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedNamespaces != nil {
+		in, out := &in.ExcludedNamespaces, &out.ExcludedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -716,62 +1034,238 @@ func (in *ControlCheckDetails) DeepCopy() *ControlCheckDetails {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeHunterReport) DeepCopyInto(out *KubeHunterReport) {
+func (in *ControlCheckTotals) DeepCopyInto(out *ControlCheckTotals) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Report.DeepCopyInto(&out.Report)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeHunterReport.
-func (in *KubeHunterReport) DeepCopy() *KubeHunterReport {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlCheckTotals.
+func (in *ControlCheckTotals) DeepCopy() *ControlCheckTotals {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeHunterReport)
+	out := new(ControlCheckTotals)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KubeHunterReport) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeHunterReportData) DeepCopyInto(out *KubeHunterReportData) {
+func (in *ControlCount) DeepCopyInto(out *ControlCount) {
 	*out = *in
-	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
-	out.Scanner = in.Scanner
-	out.Summary = in.Summary
-	if in.Vulnerabilities != nil {
-		in, out := &in.Vulnerabilities, &out.Vulnerabilities
-		*out = make([]KubeHunterVulnerability, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeHunterReportData.
-func (in *KubeHunterReportData) DeepCopy() *KubeHunterReportData {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlCount.
+func (in *ControlCount) DeepCopy() *ControlCount {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeHunterReportData)
+	out := new(ControlCount)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeHunterReportList) DeepCopyInto(out *KubeHunterReportList) {
+func (in *ControlWaiver) DeepCopyInto(out *ControlWaiver) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
+	in.Expires.DeepCopyInto(&out.Expires)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlWaiver.
+func (in *ControlWaiver) DeepCopy() *ControlWaiver {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlWaiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageInventory) DeepCopyInto(out *ImageInventory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageInventory.
+func (in *ImageInventory) DeepCopy() *ImageInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageInventory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageInventoryData) DeepCopyInto(out *ImageInventoryData) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	out.Registry = in.Registry
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]InventoryImage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageInventoryData.
+func (in *ImageInventoryData) DeepCopy() *ImageInventoryData {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageInventoryData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageInventoryList) DeepCopyInto(out *ImageInventoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ImageInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageInventoryList.
+func (in *ImageInventoryList) DeepCopy() *ImageInventoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageInventoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageInventoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageWorkload) DeepCopyInto(out *ImageWorkload) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageWorkload.
+func (in *ImageWorkload) DeepCopy() *ImageWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryImage) DeepCopyInto(out *InventoryImage) {
+	*out = *in
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]ImageWorkload, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryImage.
+func (in *InventoryImage) DeepCopy() *InventoryImage {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeHunterReport) DeepCopyInto(out *KubeHunterReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeHunterReport.
+func (in *KubeHunterReport) DeepCopy() *KubeHunterReport {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeHunterReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubeHunterReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeHunterReportData) DeepCopyInto(out *KubeHunterReportData) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	out.Scanner = in.Scanner
+	out.Summary = in.Summary
+	if in.Vulnerabilities != nil {
+		in, out := &in.Vulnerabilities, &out.Vulnerabilities
+		*out = make([]KubeHunterVulnerability, len(*in))
+		copy(*out, *in)
+	}
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(ClusterMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeHunterReportData.
+func (in *KubeHunterReportData) DeepCopy() *KubeHunterReportData {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeHunterReportData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeHunterReportList) DeepCopyInto(out *KubeHunterReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
 		in, out := &in.Items, &out.Items
 		*out = make([]KubeHunterReport, len(*in))
 		for i := range *in {
@@ -839,6 +1333,13 @@ func (in *Mapping) DeepCopyInto(out *Mapping) {
 		*out = make([]SpecCheck, len(*in))
 		copy(*out, *in)
 	}
+	if in.Scanners != nil {
+		in, out := &in.Scanners, &out.Scanners
+		*out = make([]ScannerMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -852,6 +1353,105 @@ func (in *Mapping) DeepCopy() *Mapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Package) DeepCopyInto(out *Package) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Package.
+func (in *Package) DeepCopy() *Package {
+	if in == nil {
+		return nil
+	}
+	out := new(Package)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageInventory) DeepCopyInto(out *PackageInventory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageInventory.
+func (in *PackageInventory) DeepCopy() *PackageInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackageInventory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageInventoryData) DeepCopyInto(out *PackageInventoryData) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	in.Artifact.DeepCopyInto(&out.Artifact)
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = make([]Package, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageInventoryData.
+func (in *PackageInventoryData) DeepCopy() *PackageInventoryData {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageInventoryData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageInventoryList) DeepCopyInto(out *PackageInventoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PackageInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageInventoryList.
+func (in *PackageInventoryList) DeepCopy() *PackageInventoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageInventoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackageInventoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Registry) DeepCopyInto(out *Registry) {
 	*out = *in
@@ -878,6 +1478,26 @@ func (in *ReportSpec) DeepCopyInto(out *ReportSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Includes != nil {
+		in, out := &in.Includes, &out.Includes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedControls != nil {
+		in, out := &in.ExcludedControls, &out.ExcludedControls
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -895,11 +1515,45 @@ func (in *ReportSpec) DeepCopy() *ReportSpec {
 func (in *ReportStatus) DeepCopyInto(out *ReportStatus) {
 	*out = *in
 	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
-	out.Summary = in.Summary
+	in.Summary.DeepCopyInto(&out.Summary)
 	if in.ControlChecks != nil {
 		in, out := &in.ControlChecks, &out.ControlChecks
 		*out = make([]ControlCheck, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(ClusterMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastGenerationDuration != nil {
+		in, out := &in.LastGenerationDuration, &out.LastGenerationDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LastChangedTimestamp != nil {
+		in, out := &in.LastChangedTimestamp, &out.LastChangedTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]ComplianceSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -930,6 +1584,22 @@ func (in *ResultDetails) DeepCopy() *ResultDetails {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RiskComponent) DeepCopyInto(out *RiskComponent) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RiskComponent.
+func (in *RiskComponent) DeepCopy() *RiskComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(RiskComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Scanner) DeepCopyInto(out *Scanner) {
 	*out = *in
@@ -967,6 +1637,27 @@ func (in *ScannerCheckResult) DeepCopy() *ScannerCheckResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScannerMapping) DeepCopyInto(out *ScannerMapping) {
+	*out = *in
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]SpecCheck, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScannerMapping.
+func (in *ScannerMapping) DeepCopy() *ScannerMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(ScannerMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpecCheck) DeepCopyInto(out *SpecCheck) {
 	*out = *in
@@ -996,6 +1687,11 @@ func (in *Vulnerability) DeepCopyInto(out *Vulnerability) {
 		*out = new(float64)
 		**out = **in
 	}
+	if in.EPSSScore != nil {
+		in, out := &in.EPSSScore, &out.EPSSScore
+		*out = new(float64)
+		**out = **in
+	}
 	return
 }
 
@@ -1040,10 +1736,14 @@ func (in *VulnerabilityReport) DeepCopyObject() runtime.Object {
 func (in *VulnerabilityReportData) DeepCopyInto(out *VulnerabilityReportData) {
 	*out = *in
 	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	if in.LastChangedTimestamp != nil {
+		in, out := &in.LastChangedTimestamp, &out.LastChangedTimestamp
+		*out = (*in).DeepCopy()
+	}
 	out.Scanner = in.Scanner
 	out.Registry = in.Registry
-	out.Artifact = in.Artifact
-	out.Summary = in.Summary
+	in.Artifact.DeepCopyInto(&out.Artifact)
+	in.Summary.DeepCopyInto(&out.Summary)
 	if in.Vulnerabilities != nil {
 		in, out := &in.Vulnerabilities, &out.Vulnerabilities
 		*out = make([]Vulnerability, len(*in))
@@ -1051,6 +1751,16 @@ func (in *VulnerabilityReportData) DeepCopyInto(out *VulnerabilityReportData) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = make([]Package, len(*in))
+		copy(*out, *in)
+	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1100,6 +1810,13 @@ func (in *VulnerabilityReportList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VulnerabilitySummary) DeepCopyInto(out *VulnerabilitySummary) {
 	*out = *in
+	if in.PriorityCounts != nil {
+		in, out := &in.PriorityCounts, &out.PriorityCounts
+		*out = make(map[Priority]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -1112,3 +1829,88 @@ func (in *VulnerabilitySummary) DeepCopy() *VulnerabilitySummary {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadVulnerabilitySummary) DeepCopyInto(out *WorkloadVulnerabilitySummary) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadVulnerabilitySummary.
+func (in *WorkloadVulnerabilitySummary) DeepCopy() *WorkloadVulnerabilitySummary {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadVulnerabilitySummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkloadVulnerabilitySummary) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadVulnerabilitySummaryData) DeepCopyInto(out *WorkloadVulnerabilitySummaryData) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	in.Summary.DeepCopyInto(&out.Summary)
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ContainerVulnerabilitySummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadVulnerabilitySummaryData.
+func (in *WorkloadVulnerabilitySummaryData) DeepCopy() *WorkloadVulnerabilitySummaryData {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadVulnerabilitySummaryData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadVulnerabilitySummaryList) DeepCopyInto(out *WorkloadVulnerabilitySummaryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkloadVulnerabilitySummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadVulnerabilitySummaryList.
+func (in *WorkloadVulnerabilitySummaryList) DeepCopy() *WorkloadVulnerabilitySummaryList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadVulnerabilitySummaryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkloadVulnerabilitySummaryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// timestampFormat is the format of the timestamp in keys of archived
+// reports.
+const timestampFormat = "20060102T150405Z"
+
+// maxPendingReports bounds the number of reports waiting to be uploaded, so
+// that an unavailable store does not exhaust the memory of the operator.
+const maxPendingReports = 10000
+
+// WorkloadPrefix returns the prefix of keys of reports archived for the
+// specified workload, i.e. <cluster>/<namespace>/<kind>-<name>/.
+func WorkloadPrefix(cluster, namespace, kind, name string) string {
+	return path.Join(cluster, namespace, strings.ToLower(kind)+"-"+name) + "/"
+}
+
+// ReportKey returns the key of the specified archived report, i.e.
+// <cluster>/<namespace>/<kind>-<name>/<container>/<timestamp>.json.
+func ReportKey(cluster string, report v1alpha1.VulnerabilityReport) string {
+	prefix := WorkloadPrefix(cluster, report.Namespace,
+		report.Labels[starboard.LabelResourceKind], report.Labels[starboard.LabelResourceName])
+	return prefix + path.Join(report.Labels[starboard.LabelContainerName],
+		report.Report.UpdateTimestamp.UTC().Format(timestampFormat)+".json")
+}
+
+// Version is an archived version of the report of a container.
+type Version struct {
+	Key       string
+	Container string
+	Timestamp time.Time
+}
+
+// ListVersions returns archived versions of reports of the specified
+// workload sorted by container and timestamp.
+func ListVersions(ctx context.Context, config Config, namespace, kind, name string) ([]Version, error) {
+	prefix := WorkloadPrefix(config.Cluster, namespace, kind, name)
+	objects, err := config.Store.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	var versions []Version
+	for _, object := range objects {
+		container, file := path.Split(strings.TrimPrefix(object.Key, prefix))
+		timestamp, err := time.Parse(timestampFormat, strings.TrimSuffix(file, ".json"))
+		if err != nil || container == "" {
+			// Not an archived report.
+			continue
+		}
+		versions = append(versions, Version{
+			Key:       object.Key,
+			Container: strings.TrimSuffix(container, "/"),
+			Timestamp: timestamp,
+		})
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		if versions[i].Container != versions[j].Container {
+			return versions[i].Container < versions[j].Container
+		}
+		return versions[i].Timestamp.Before(versions[j].Timestamp)
+	})
+	return versions, nil
+}
+
+// GetVersion returns the archived report with the specified key.
+func GetVersion(ctx context.Context, config Config, key string) (v1alpha1.VulnerabilityReport, error) {
+	var report v1alpha1.VulnerabilityReport
+	data, err := config.Store.Get(ctx, key)
+	if err != nil {
+		return report, err
+	}
+	err = json.Unmarshal(data, &report)
+	if err != nil {
+		return report, fmt.Errorf("decoding archived report %s: %w", key, err)
+	}
+	return report, nil
+}
+
+// Archiver uploads previous versions of v1alpha1.VulnerabilityReport objects
+// to a Store when they're replaced by new scans or deleted, e.g. with their
+// workloads or when their TTL expires.
+//
+// Reports are uploaded in the background so that failures never block
+// writing of reports. Failed uploads are retried with exponential backoff.
+// Reports replaced or deleted while the operator is not running are not
+// archived.
+type Archiver struct {
+	logr.Logger
+	config Config
+	queue  workqueue.RateLimitingInterface
+
+	mu      sync.Mutex
+	pending map[string][]byte
+}
+
+// NewArchiver constructs a new Archiver with the specified configuration.
+func NewArchiver(logger logr.Logger, config Config) *Archiver {
+	return &Archiver{
+		Logger: logger,
+		config: config,
+		queue: workqueue.NewNamedRateLimitingQueue(
+			workqueue.NewItemExponentialFailureRateLimiter(time.Second, 5*time.Minute), "archive"),
+		pending: make(map[string][]byte),
+	}
+}
+
+// SetupWithManager archives reports replaced or deleted in the cache of the
+// manager while it's the leader.
+func (a *Archiver) SetupWithManager(mgr ctrl.Manager) error {
+	return mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		err := a.Watch(ctx, mgr.GetCache())
+		if err != nil {
+			return err
+		}
+		a.Run(ctx)
+		return nil
+	}))
+}
+
+// Watch archives reports replaced or deleted in the given informers.
+func (a *Archiver) Watch(ctx context.Context, informers cache.Informers) error {
+	informer, err := informers.GetInformer(ctx, &v1alpha1.VulnerabilityReport{})
+	if err != nil {
+		return fmt.Errorf("getting informer for %T: %w", &v1alpha1.VulnerabilityReport{}, err)
+	}
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldReport, ok := oldObj.(*v1alpha1.VulnerabilityReport)
+			if !ok {
+				return
+			}
+			newReport, ok := newObj.(*v1alpha1.VulnerabilityReport)
+			if !ok {
+				return
+			}
+			// Ignore updates of metadata, e.g. labels or annotations.
+			if oldReport.Report.UpdateTimestamp.Equal(&newReport.Report.UpdateTimestamp) {
+				return
+			}
+			a.Archive(*oldReport)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if report, ok := obj.(*v1alpha1.VulnerabilityReport); ok {
+				a.Archive(*report)
+			}
+		},
+	})
+	return nil
+}
+
+// Archive queues the specified report for upload.
+func (a *Archiver) Archive(report v1alpha1.VulnerabilityReport) {
+	report.APIVersion = v1alpha1.SchemeGroupVersion.String()
+	report.Kind = v1alpha1.VulnerabilityReportKind
+	report.ManagedFields = nil
+	key := ReportKey(a.config.Cluster, report)
+	data, err := json.Marshal(report)
+	if err != nil {
+		a.Logger.Error(err, "Unable to encode report", "report", report.Namespace+"/"+report.Name)
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.pending[key]; !ok && len(a.pending) >= maxPendingReports {
+		a.Logger.Error(fmt.Errorf("too many pending reports"), "Dropping report", "key", key, "pending", len(a.pending))
+		return
+	}
+	a.pending[key] = data
+	a.queue.Add(key)
+}
+
+// Run uploads queued reports until the given context is done.
+func (a *Archiver) Run(ctx context.Context) {
+	go func() {
+		<-ctx.Done()
+		a.queue.ShutDown()
+	}()
+	for a.processNextReport(ctx) {
+	}
+}
+
+// Pending returns the number of reports waiting to be uploaded.
+func (a *Archiver) Pending() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.pending)
+}
+
+func (a *Archiver) processNextReport(ctx context.Context) bool {
+	item, shutdown := a.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer a.queue.Done(item)
+	key := item.(string)
+
+	a.mu.Lock()
+	data, ok := a.pending[key]
+	a.mu.Unlock()
+	if !ok {
+		a.queue.Forget(item)
+		return true
+	}
+
+	err := a.config.Store.Put(ctx, key, data)
+	if err != nil {
+		a.Logger.Error(err, "Unable to archive report, will retry", "key", key, "retries", a.queue.NumRequeues(item))
+		a.queue.AddRateLimited(item)
+		return true
+	}
+	a.Logger.V(1).Info("Archived report", "key", key)
+	a.queue.Forget(item)
+
+	a.mu.Lock()
+	// The report may have been queued again while it was uploaded.
+	if string(a.pending[key]) == string(data) {
+		delete(a.pending, key)
+	}
+	a.mu.Unlock()
+	return true
+}
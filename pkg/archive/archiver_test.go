@@ -0,0 +1,169 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+)
+
+// memoryStore is a Store which keeps objects in memory and fails the
+// specified number of uploads.
+type memoryStore struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	failures int
+}
+
+func newMemoryStore(failures int) *memoryStore {
+	return &memoryStore{objects: map[string][]byte{}, failures: failures}
+}
+
+func (s *memoryStore) Put(_ context.Context, key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("503 Service Unavailable")
+	}
+	s.objects[key] = data
+	return nil
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return data, nil
+}
+
+func (s *memoryStore) List(_ context.Context, prefix string) ([]Object, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var objects []Object
+	for key, data := range s.objects {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, Object{Key: key, Size: int64(len(data))})
+		}
+	}
+	return objects, nil
+}
+
+func (s *memoryStore) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []string
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func newReport(container string, updated time.Time) *v1alpha1.VulnerabilityReport {
+	return &v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "replicaset-nginx-6d4cf56db6-" + container,
+			Labels: map[string]string{
+				starboard.LabelResourceKind:  "ReplicaSet",
+				starboard.LabelResourceName:  "nginx-6d4cf56db6",
+				starboard.LabelContainerName: container,
+			},
+		},
+		Report: v1alpha1.VulnerabilityReportData{
+			UpdateTimestamp: metav1.NewTime(updated),
+			Summary:         v1alpha1.VulnerabilitySummary{CriticalCount: 1},
+		},
+	}
+}
+
+var (
+	firstScan  = time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	secondScan = time.Date(2022, 6, 2, 10, 0, 0, 0, time.UTC)
+)
+
+func TestReportKey(t *testing.T) {
+	assert.Equal(t, "prod/default/replicaset-nginx-6d4cf56db6/nginx/20220601T100000Z.json",
+		ReportKey("prod", *newReport("nginx", firstScan)))
+}
+
+func TestArchiver(t *testing.T) {
+	store := newMemoryStore(2)
+	archiver := NewArchiver(logr.Discard(), Config{Cluster: "prod", Store: store})
+	archiver.queue = workqueue.NewRateLimitingQueue(
+		workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond, 10*time.Millisecond))
+
+	informers := &informertest.FakeInformers{Scheme: starboard.NewScheme()}
+	informer, err := informers.FakeInformerFor(&v1alpha1.VulnerabilityReport{})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, archiver.Watch(ctx, informers))
+	go archiver.Run(ctx)
+
+	first := newReport("nginx", firstScan)
+	informer.Add(first)
+
+	relabeled := first.DeepCopy()
+	relabeled.Labels["team"] = "a"
+	informer.Update(first, relabeled)
+
+	second := newReport("nginx", secondScan)
+	informer.Update(relabeled, second)
+	informer.Delete(second)
+
+	assert.Eventually(t, func() bool {
+		return archiver.Pending() == 0
+	}, 5*time.Second, 10*time.Millisecond, "failed uploads should be retried")
+	assert.ElementsMatch(t, []string{
+		"prod/default/replicaset-nginx-6d4cf56db6/nginx/20220601T100000Z.json",
+		"prod/default/replicaset-nginx-6d4cf56db6/nginx/20220602T100000Z.json",
+	}, store.Keys())
+
+	versions, err := ListVersions(ctx, archiver.config, "default", "ReplicaSet", "nginx-6d4cf56db6")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, Version{
+		Key:       "prod/default/replicaset-nginx-6d4cf56db6/nginx/20220601T100000Z.json",
+		Container: "nginx",
+		Timestamp: firstScan,
+	}, versions[0])
+
+	report, err := GetVersion(ctx, archiver.config, versions[0].Key)
+	require.NoError(t, err)
+	assert.Equal(t, "VulnerabilityReport", report.Kind)
+	assert.Equal(t, first.Name, report.Name)
+	assert.True(t, report.Report.UpdateTimestamp.Equal(&first.Report.UpdateTimestamp))
+	assert.Equal(t, 1, report.Report.Summary.CriticalCount)
+}
+
+func TestArchiver_Archive(t *testing.T) {
+	archiver := NewArchiver(logr.Discard(), Config{Cluster: "prod", Store: newMemoryStore(0)})
+	report := newReport("nginx", firstScan)
+	report.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "starboard-operator"}}
+
+	archiver.Archive(*report)
+	archiver.Archive(*report)
+	assert.Equal(t, 1, archiver.Pending(), "reports should be deduplicated by key")
+
+	var archived map[string]interface{}
+	require.NoError(t, json.Unmarshal(archiver.pending[ReportKey("prod", *report)], &archived))
+	assert.Equal(t, "aquasecurity.github.io/v1alpha1", archived["apiVersion"])
+	assert.NotContains(t, archived["metadata"], "managedFields")
+}
@@ -0,0 +1,140 @@
+package archive
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	signingAlgorithm  = "AWS4-HMAC-SHA256"
+	signingService    = "s3"
+	amzDateFormat     = "20060102T150405Z"
+	shortDateFormat   = "20060102"
+	headerDate        = "X-Amz-Date"
+	headerContentHash = "X-Amz-Content-Sha256"
+	headerToken       = "X-Amz-Security-Token"
+)
+
+// Credentials are the access keys used to sign requests with AWS Signature
+// Version 4. Google Cloud Storage accepts HMAC keys in the same way.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// signV4 signs the specified request, whose payload has the specified SHA-256
+// hash, with AWS Signature Version 4. The host and all headers of the request
+// are signed.
+func signV4(req *http.Request, payloadHash string, credentials Credentials, region string, now time.Time) {
+	now = now.UTC()
+	req.Header.Set(headerDate, now.Format(amzDateFormat))
+	req.Header.Set(headerContentHash, payloadHash)
+	if credentials.SessionToken != "" {
+		req.Header.Set(headerToken, credentials.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	if req.Host != "" {
+		headers["host"] = req.Host
+	}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalPath(req.URL),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{now.Format(shortDateFormat), region, signingService, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		now.Format(amzDateFormat),
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+credentials.SecretAccessKey), now.Format(shortDateFormat))
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, signingService)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, credentials.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalPath returns the URI-encoded path of the specified URL. Unlike
+// other services, S3 expects the path to be encoded only once.
+func canonicalPath(u *url.URL) string {
+	path := u.Path
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// canonicalQuery returns the URI-encoded query parameters sorted by name.
+func canonicalQuery(query url.Values) string {
+	params := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			params = append(params, uriEncode(name)+"="+uriEncode(value))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+// uriEncode encodes every byte of the specified string except unreserved
+// characters as defined by RFC 3986.
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func hashHex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,271 @@
+// Package archive provides retention of vulnerability reports in an external
+// object store, i.e. an Amazon S3 or Google Cloud Storage bucket, so that
+// full scan results can be kept far longer than in etcd, where only the latest
+// report of each container is stored.
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+)
+
+// Configuration keys of the archive. Credentials are read from the
+// starboard Secret, other keys from the starboard ConfigMap.
+const (
+	// KeyURL is the location of archived reports in the s3://bucket/prefix or
+	// gs://bucket/prefix format. Reports are not archived if it's not set.
+	KeyURL = "vulnerabilityReports.archive.url"
+	// KeyEndpoint is the endpoint of an S3 compatible object store, e.g.
+	// MinIO. It defaults to Amazon S3 or Google Cloud Storage.
+	KeyEndpoint = "vulnerabilityReports.archive.endpoint"
+	// KeyRegion is the region of the bucket.
+	KeyRegion = "vulnerabilityReports.archive.region"
+	// KeyCluster is the name of the cluster which prefixes paths of reports.
+	KeyCluster = "vulnerabilityReports.archive.cluster"
+	// KeyAccessKeyID is the access key ID, or the HMAC key ID of Google
+	// Cloud Storage.
+	KeyAccessKeyID = "vulnerabilityReports.archive.accessKeyID"
+	// KeySecretAccessKey is the secret access key, or the HMAC secret of
+	// Google Cloud Storage.
+	KeySecretAccessKey = "vulnerabilityReports.archive.secretAccessKey"
+	// KeySessionToken is the optional session token of temporary credentials.
+	KeySessionToken = "vulnerabilityReports.archive.sessionToken"
+)
+
+const (
+	defaultCluster  = "default"
+	defaultS3Region = "us-east-1"
+	gcsEndpoint     = "https://storage.googleapis.com"
+	gcsRegion       = "auto"
+)
+
+// ErrNotFound is returned by Store.Get if there's no object with the given key.
+var ErrNotFound = errors.New("archived object not found")
+
+// Object describes an object in a Store.
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// Store is the interface of object stores where reports are archived. Keys
+// are relative to the location of archived reports.
+type Store interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	List(ctx context.Context, prefix string) ([]Object, error)
+}
+
+// Config is the configuration of the archive.
+type Config struct {
+	// Cluster is the name of the cluster which prefixes paths of reports.
+	Cluster string
+	// Store is the store of archived reports.
+	Store Store
+}
+
+// GetConfig returns the configuration of the archive from the given
+// starboard.ConfigData, or false if reports are not archived.
+func GetConfig(config starboard.ConfigData) (Config, bool, error) {
+	value := config[KeyURL]
+	if value == "" {
+		return Config{}, false, nil
+	}
+	location, err := url.Parse(value)
+	if err != nil {
+		return Config{}, false, fmt.Errorf("parsing incorrectly formatted %s: %w", KeyURL, err)
+	}
+	if location.Host == "" {
+		return Config{}, false, fmt.Errorf("parsing incorrectly formatted %s: bucket not specified", KeyURL)
+	}
+	endpoint, region := config[KeyEndpoint], config[KeyRegion]
+	switch location.Scheme {
+	case "s3":
+		if region == "" {
+			region = defaultS3Region
+		}
+		if endpoint == "" {
+			endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+		}
+	case "gs":
+		if region == "" {
+			region = gcsRegion
+		}
+		if endpoint == "" {
+			endpoint = gcsEndpoint
+		}
+	default:
+		return Config{}, false, fmt.Errorf("unsupported scheme of %s: %q, allowed schemes are: s3,gs", KeyURL, location.Scheme)
+	}
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return Config{}, false, fmt.Errorf("parsing incorrectly formatted %s: %w", KeyEndpoint, err)
+	}
+	cluster := config[KeyCluster]
+	if cluster == "" {
+		cluster = defaultCluster
+	}
+	return Config{
+		Cluster: cluster,
+		Store: &bucketStore{
+			endpoint: endpointURL,
+			bucket:   location.Host,
+			prefix:   strings.Trim(location.Path, "/"),
+			region:   region,
+			credentials: Credentials{
+				AccessKeyID:     config[KeyAccessKeyID],
+				SecretAccessKey: config[KeySecretAccessKey],
+				SessionToken:    config[KeySessionToken],
+			},
+			client: http.DefaultClient,
+			clock:  ext.NewSystemClock(),
+		},
+	}, true, nil
+}
+
+// bucketStore is a Store backed by a bucket of an object store compatible
+// with the Amazon S3 API, including the XML API of Google Cloud Storage.
+// Buckets are addressed with path-style URLs.
+type bucketStore struct {
+	endpoint    *url.URL
+	bucket      string
+	prefix      string
+	region      string
+	credentials Credentials
+	client      *http.Client
+	clock       ext.Clock
+}
+
+func (s *bucketStore) Put(ctx context.Context, key string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, s.objectPath(key), nil, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp, "uploading "+key)
+	}
+	return nil
+}
+
+func (s *bucketStore) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, s.objectPath(key), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp, "downloading "+key)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// listBucketResult is the response of the ListObjectsV2 operation.
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *bucketStore) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	query := url.Values{
+		"list-type": {"2"},
+		"prefix":    {s.objectKey(prefix)},
+	}
+	for {
+		result, err := s.list(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, content := range result.Contents {
+			objects = append(objects, Object{
+				Key:          strings.TrimPrefix(content.Key, s.objectKey("")),
+				Size:         content.Size,
+				LastModified: content.LastModified,
+			})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+func (s *bucketStore) list(ctx context.Context, query url.Values) (listBucketResult, error) {
+	var result listBucketResult
+	resp, err := s.do(ctx, http.MethodGet, "/"+s.bucket, query, nil)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return result, responseError(resp, "listing bucket "+s.bucket)
+	}
+	err = xml.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return result, fmt.Errorf("decoding list of bucket %s: %w", s.bucket, err)
+	}
+	return result, nil
+}
+
+// objectKey returns the key of the object in the bucket.
+func (s *bucketStore) objectKey(key string) string {
+	if s.prefix == "" {
+		return key
+	}
+	return s.prefix + "/" + key
+}
+
+func (s *bucketStore) objectPath(key string) string {
+	return "/" + s.bucket + "/" + s.objectKey(key)
+}
+
+func (s *bucketStore) do(ctx context.Context, method, path string, query url.Values, body []byte) (*http.Response, error) {
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	// Escape the path the same way it's signed.
+	u.RawPath = canonicalPath(&u)
+	u.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	signV4(req, hashHex(body), s.credentials, s.region, s.clock.Now())
+	return s.client.Do(req)
+}
+
+// responseError returns an error with the code of the given error response.
+func responseError(resp *http.Response, action string) error {
+	var errorResponse struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if xml.Unmarshal(body, &errorResponse) == nil && errorResponse.Code != "" {
+		return fmt.Errorf("%s: %s: %s: %s", action, resp.Status, errorResponse.Code, errorResponse.Message)
+	}
+	return fmt.Errorf("%s: %s", action, resp.Status)
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aquasecurity/starboard/pkg/operator/permissions"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	serviceAccountFlagName   = "service-account"
+	targetNamespacesFlagName = "target-namespaces"
+	defaultServiceAccount    = "starboard-operator"
+)
+
+func NewCheckCmd(executable string, cf *ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check prerequisites of Starboard Operator",
+	}
+	cmd.AddCommand(NewCheckPermissionsCmd(executable, cf, out))
+	return cmd
+}
+
+func NewCheckPermissionsCmd(executable string, cf *ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "permissions",
+		Aliases: []string{"perms"},
+		Short:   "Check permissions of the Starboard Operator service account",
+		Long: `Check permissions of the Starboard Operator service account
+
+Reviews with SubjectAccessReviews whether the service account is granted each permission required by
+each feature of the operator, i.e. the same checks the operator performs at startup, and lists the
+missing ones. Features with missing permissions are disabled by the operator.
+`,
+		Example: fmt.Sprintf(`  # Check permissions of the operator installed in the starboard-system namespace
+  %[1]s check permissions
+
+  # Check permissions of the specified service account for scanning the specified namespaces
+  %[1]s check permissions --operator-namespace security --service-account starboard \
+    --target-namespaces default,qa`, executable),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			namespace, err := cmd.Flags().GetString(operatorNamespaceFlagName)
+			if err != nil {
+				return err
+			}
+			serviceAccount, err := cmd.Flags().GetString(serviceAccountFlagName)
+			if err != nil {
+				return err
+			}
+			targetNamespaces, err := cmd.Flags().GetStringSlice(targetNamespacesFlagName)
+			if err != nil {
+				return err
+			}
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			kubeClientset, err := kubernetes.NewForConfig(kubeConfig)
+			if err != nil {
+				return err
+			}
+			return CheckPermissions(ctx, permissions.NewServiceAccountReviewer(kubeClientset, namespace, serviceAccount), out,
+				permissions.Namespaces{Operator: namespace, Targets: targetNamespaces})
+		},
+	}
+	cmd.Flags().String(operatorNamespaceFlagName, defaultOperatorNamespace, "The namespace of Starboard Operator")
+	cmd.Flags().String(serviceAccountFlagName, defaultServiceAccount, "The service account of Starboard Operator")
+	cmd.Flags().StringSlice(targetNamespacesFlagName, nil, "The namespaces scanned by Starboard Operator, all namespaces if blank")
+	return cmd
+}
+
+// CheckPermissions reviews permissions required by all features of the
+// operator and writes the missing ones to out. Returns an error if any
+// permission is missing.
+func CheckPermissions(ctx context.Context, reviewer permissions.Reviewer, out io.Writer, namespaces permissions.Namespaces) error {
+	missing, err := permissions.Check(ctx, reviewer, permissions.Required(namespaces, permissions.Features()...))
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		fmt.Fprintln(out, "All permissions required by Starboard Operator are granted.")
+		return nil
+	}
+	if err = permissions.WriteTable(out, missing); err != nil {
+		return err
+	}
+	return fmt.Errorf("missing %d permissions required by features %v", len(missing), permissions.MissingFeatures(missing))
+}
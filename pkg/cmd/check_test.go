@@ -0,0 +1,42 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/cmd"
+	"github.com/aquasecurity/starboard/pkg/operator/permissions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCheckPermissions(t *testing.T) {
+	newReviewer := func(deniedResource string) permissions.Reviewer {
+		clientset := fake.NewSimpleClientset()
+		clientset.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+			review.Status.Allowed = review.Spec.ResourceAttributes.Resource != deniedResource
+			return true, review, nil
+		})
+		return permissions.NewServiceAccountReviewer(clientset, "starboard-system", "starboard-operator")
+	}
+
+	t.Run("Should report granted permissions", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		err := cmd.CheckPermissions(context.TODO(), newReviewer(""), out, permissions.Namespaces{Operator: "starboard-system"})
+		require.NoError(t, err)
+		assert.Equal(t, "All permissions required by Starboard Operator are granted.\n", out.String())
+	})
+
+	t.Run("Should list missing permissions", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		err := cmd.CheckPermissions(context.TODO(), newReviewer("imageinventories"), out, permissions.Namespaces{Operator: "starboard-system"})
+		require.EqualError(t, err, "missing 6 permissions required by features [image-inventory]")
+		assert.Contains(t, out.String(), "image-inventory  *          create  imageinventories.aquasecurity.github.io\n")
+	})
+}
@@ -2,21 +2,49 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
-	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func NewCleanupCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) *cobra.Command {
+func NewCleanupCmd(buildInfo starboard.BuildInfo, cf *ConfigFlags, out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "uninstall",
 		Aliases: []string{"cleanup"},
 		Short:   "Delete Kubernetes resources created by Starboard",
+		Long: `Delete Kubernetes resources created by Starboard
+
+Optionally, all reports are exported to NDJSON files, one file per kind, in the directory specified
+with the --export-dir flag before they are deleted. If the export is interrupted, running the command
+again skips reports which were already exported.
+`,
+		Example: fmt.Sprintf(`  # Delete Kubernetes resources created by Starboard
+  %[1]s uninstall
+
+  # Export all reports to the starboard-export directory before deleting them
+  %[1]s uninstall --export-dir ./starboard-export
+
+  # Export all reports to the starboard-export.tar.gz archive before deleting them
+  %[1]s uninstall --export-dir ./starboard-export --archive`, buildInfo.Executable),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			exportDir, err := cmd.Flags().GetString(exportDirFlagName)
+			if err != nil {
+				return err
+			}
+			archive, err := cmd.Flags().GetBool(archiveFlagName)
+			if err != nil {
+				return err
+			}
+			if archive && exportDir == "" {
+				return fmt.Errorf("flag --%s requires flag --%s", archiveFlagName, exportDirFlagName)
+			}
 			kubeConfig, err := cf.ToRESTConfig()
 			if err != nil {
 				return err
@@ -34,10 +62,44 @@ func NewCleanupCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFl
 			if err != nil {
 				return err
 			}
+			if exportDir != "" {
+				err = exportReports(kubeClient, exportDir, archive, out)
+				if err != nil {
+					return err
+				}
+			}
 			configManager := starboard.NewConfigManager(kubeClientset, starboard.NamespaceName)
 			installer := NewInstaller(buildInfo, kubeClientset, apiExtensionsClientset, kubeClient, configManager)
-			return installer.Uninstall(context.Background())
+			err = installer.Uninstall(context.Background())
+			if err != nil {
+				return err
+			}
+			// The export directory is removed only after a successful uninstall,
+			// so that a failed uninstall can be resumed without losing reports.
+			if archive {
+				return os.RemoveAll(exportDir)
+			}
+			return nil
 		},
 	}
+	cmd.Flags().String(exportDirFlagName, "", "Export all reports to NDJSON files in this directory before deleting them")
+	cmd.Flags().Bool(archiveFlagName, false, "Pack exported reports into a single tar.gz archive next to the export directory")
 	return cmd
 }
+
+func exportReports(kubeClient client.Reader, exportDir string, archive bool, out io.Writer) error {
+	err := NewReportExporter(kubeClient, exportDir, out).Export(context.Background())
+	if err != nil {
+		return err
+	}
+	if !archive {
+		return nil
+	}
+	archivePath := filepath.Clean(exportDir) + ".tar.gz"
+	err = archiveExport(exportDir, archivePath)
+	if err != nil {
+		return fmt.Errorf("archiving exported reports: %w", err)
+	}
+	fmt.Fprintf(out, "Archived exported reports to %s\n", archivePath)
+	return nil
+}
@@ -3,20 +3,25 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"github.com/spf13/cobra"
-	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
 )
 
-func SetGlobalFlags(cf *genericclioptions.ConfigFlags, cmd *cobra.Command) {
+func SetGlobalFlags(cf *ConfigFlags, cmd *cobra.Command) {
 	cf.AddFlags(cmd.Flags())
 	for _, c := range cmd.Commands() {
 		SetGlobalFlags(cf, c)
@@ -69,8 +74,123 @@ func ComplianceNameFromArgs(args []string, suffix ...string) (types.NamespacedNa
 const (
 	scanJobTimeoutFlagName = "scan-job-timeout"
 	deleteScanJobFlagName  = "delete-scan-job"
+	clusterNameFlagName    = "cluster-name"
+	dryRunFlagName         = "dry-run"
+	scanModeFlagName       = "scan-mode"
+	scannerPathFlagName    = "scanner-path"
+)
+
+const (
+	dryRunNone   = "none"
+	dryRunServer = "server"
 )
 
+const (
+	scanModeJob    = "job"
+	scanModeDirect = "direct"
+)
+
+func registerDryRunFlag(cmd *cobra.Command) {
+	cmd.Flags().String(dryRunFlagName, dryRunNone,
+		`Must be "none" or "server". If server, reports are validated by the API server with a server-side dry-run`+
+			` and printed instead of being persisted. The scan job is deleted regardless of the delete-scan-job flag`)
+}
+
+// getDryRun returns true if the dry-run flag is set to server.
+func getDryRun(cmd *cobra.Command) (bool, error) {
+	dryRun, err := cmd.Flags().GetString(dryRunFlagName)
+	if err != nil {
+		return false, err
+	}
+	switch dryRun {
+	case dryRunNone:
+		return false, nil
+	case dryRunServer:
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid %s value %q, must be %q or %q", dryRunFlagName, dryRun, dryRunNone, dryRunServer)
+	}
+}
+
+func registerScanModeFlags(cmd *cobra.Command) {
+	cmd.Flags().String(scanModeFlagName, scanModeJob,
+		`Must be "job" or "direct". If direct, images are scanned by the scanner binary executed locally, or run with`+
+			` docker if the binary is not found, instead of scan jobs`)
+	cmd.Flags().String(scannerPathFlagName, "",
+		"The path of the scanner binary executed in the direct scan mode. If blank, the binary is looked up in PATH")
+}
+
+// getDirectScanOpts returns true and options of the direct scanner if the
+// scan-mode flag is set to direct.
+func getDirectScanOpts(cmd *cobra.Command) (opts vulnerabilityreport.DirectScanOpts, direct bool, err error) {
+	scanMode, err := cmd.Flags().GetString(scanModeFlagName)
+	if err != nil {
+		return
+	}
+	switch scanMode {
+	case scanModeJob:
+		return
+	case scanModeDirect:
+		direct = true
+	default:
+		err = fmt.Errorf("invalid %s value %q, must be %q or %q", scanModeFlagName, scanMode, scanModeJob, scanModeDirect)
+		return
+	}
+	opts.ScannerPath, err = cmd.Flags().GetString(scannerPathFlagName)
+	return
+}
+
+// printDryRunObjects prints the given objects, which were validated with
+// a server-side dry-run, in YAML format.
+func printDryRunObjects(out io.Writer, scheme *runtime.Scheme, objects ...runtime.Object) error {
+	printer, err := genericclioptions.NewPrintFlags("").
+		WithTypeSetter(scheme).
+		WithDefaultOutput("yaml").
+		ToPrinter()
+	if err != nil {
+		return fmt.Errorf("create printer: %w", err)
+	}
+	for _, object := range objects {
+		err = printer.PrintObj(object, out)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func registerClusterNameFlag(cmd *cobra.Command) {
+	cmd.Flags().String(clusterNameFlagName, "",
+		"The name of the cluster stamped into reports. Defaults to the cluster of the current kubeconfig context")
+}
+
+// newClusterMetadataReader constructs a kube.ClusterMetadataReader for the
+// cluster name specified with the cluster-name flag or, if not specified, the
+// cluster of the current kubeconfig context.
+func newClusterMetadataReader(cmd *cobra.Command, cf *ConfigFlags, clientset kubernetes.Interface) (kube.ClusterMetadataReader, error) {
+	clusterName, err := cmd.Flags().GetString(clusterNameFlagName)
+	if err != nil {
+		return nil, err
+	}
+	if clusterName == "" {
+		rawConfig, err := cf.ToRawKubeConfigLoader().RawConfig()
+		if err != nil {
+			return nil, err
+		}
+		contextName := rawConfig.CurrentContext
+		if cf.Context != nil && *cf.Context != "" {
+			contextName = *cf.Context
+		}
+		if kubeContext, ok := rawConfig.Contexts[contextName]; ok {
+			clusterName = kubeContext.Cluster
+		}
+		if cf.ClusterName != nil && *cf.ClusterName != "" {
+			clusterName = *cf.ClusterName
+		}
+	}
+	return kube.NewClusterMetadataReader(clientset, clusterName, 0, ext.NewSystemClock()), nil
+}
+
 func registerScannerOpts(cmd *cobra.Command) {
 	cmd.Flags().Duration(scanJobTimeoutFlagName, time.Duration(0),
 		"The length of time to wait before giving up on a scan job. Non-zero values should contain a"+
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/compliance"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewComplianceCmd(buildInfo starboard.BuildInfo, cf *ConfigFlags, outWriter io.Writer) *cobra.Command {
+	complianceCmd := &cobra.Command{
+		Use:   "compliance",
+		Short: "Manage cluster compliance reports",
+	}
+	complianceCmd.AddCommand(NewComplianceGenerateCmd(buildInfo.Executable, cf, outWriter))
+	complianceCmd.AddCommand(NewComplianceExportCmd(buildInfo.Executable, cf, outWriter))
+
+	return complianceCmd
+}
+
+const (
+	waitFlagName    = "wait"
+	timeoutFlagName = "timeout"
+	formatFlagName  = "format"
+)
+
+func NewComplianceGenerateCmd(executable string, cf *ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate (NAME)",
+		Short: "Request immediate generation of a cluster compliance report",
+		Long: `Request immediate generation of a cluster compliance report by Starboard Operator
+
+The request is recorded as an annotation on the ClusterComplianceReport, which the operator
+consumes and generates the report regardless of its cron schedule.
+`,
+		Example: fmt.Sprintf(`  # Request generation of the nsa cluster compliance report
+  %[1]s compliance generate nsa
+
+  # Request generation of the nsa cluster compliance report and wait for completion
+  %[1]s compliance generate nsa --wait --timeout 5m`, executable),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return fmt.Errorf("failed to create kubeConfig: %w", err)
+			}
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+			if err != nil {
+				return fmt.Errorf("failed to create kubernetes client: %w", err)
+			}
+			namespaceName, err := ComplianceNameFromArgs(args)
+			if err != nil {
+				return err
+			}
+			waitForCompletion, err := cmd.Flags().GetBool(waitFlagName)
+			if err != nil {
+				return err
+			}
+			timeout, err := cmd.Flags().GetDuration(timeoutFlagName)
+			if err != nil {
+				return err
+			}
+
+			lastUpdated, err := compliance.RequestGeneration(ctx, kubeClient, namespaceName.Name, ext.NewSystemClock().Now())
+			if err != nil {
+				return err
+			}
+			if !waitForCompletion {
+				fmt.Fprintf(out, "Requested generation of compliance report %s.\n", namespaceName.Name)
+				return nil
+			}
+
+			report, err := compliance.WaitForGeneration(ctx, kubeClient, namespaceName.Name, lastUpdated, 2*time.Second, timeout)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Generated compliance report %s at %s: %d passed, %d failed.\n",
+				report.Name,
+				report.Status.UpdateTimestamp.Format(time.RFC3339),
+				report.Status.Summary.PassCount,
+				report.Status.Summary.FailCount)
+			color, err := useColor(cmd, out)
+			if err != nil {
+				return err
+			}
+			return WriteComplianceSummary(out, *report, color)
+		},
+	}
+	cmd.Flags().Bool(waitFlagName, false, "If true, wait until the report is generated")
+	cmd.Flags().Duration(timeoutFlagName, 5*time.Minute, "The length of time to wait for the report to be generated")
+	registerNoColorFlag(cmd)
+	return cmd
+}
+
+func NewComplianceExportCmd(executable string, cf *ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export (NAME)",
+		Short: "Export a cluster compliance report",
+		Long: `Export a cluster compliance report and its details report in a format consumed by GRC tools
+
+Supported formats:
+  oscal  OSCAL assessment results in JSON, where each control is a finding and each checked object is a subject
+`,
+		Example: fmt.Sprintf(`  # Export the nsa cluster compliance report as OSCAL assessment results
+  %[1]s compliance export nsa --format oscal > nsa-assessment-results.json`, executable),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			format, err := cmd.Flags().GetString(formatFlagName)
+			if err != nil {
+				return err
+			}
+			if format != "oscal" {
+				return fmt.Errorf("unsupported format %q, supported formats: oscal", format)
+			}
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return fmt.Errorf("failed to create kubeConfig: %w", err)
+			}
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+			if err != nil {
+				return fmt.Errorf("failed to create kubernetes client: %w", err)
+			}
+			namespaceName, err := ComplianceNameFromArgs(args)
+			if err != nil {
+				return err
+			}
+			detailNamespaceName, err := ComplianceNameFromArgs(args, "details")
+			if err != nil {
+				return err
+			}
+
+			var report v1alpha1.ClusterComplianceReport
+			err = kubeClient.Get(ctx, namespaceName, &report)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					return fmt.Errorf("compliance report %s not found", namespaceName.Name)
+				}
+				return err
+			}
+			var detail v1alpha1.ClusterComplianceDetailReport
+			err = kubeClient.Get(ctx, detailNamespaceName, &detail)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					return fmt.Errorf("compliance details report %s not found, the report may not be generated yet", detailNamespaceName.Name)
+				}
+				return err
+			}
+
+			document := compliance.ToOSCALAssessmentResults(report, detail, ext.NewGoogleUUIDGenerator(), ext.NewSystemClock())
+			encoder := json.NewEncoder(out)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(document)
+		},
+	}
+	cmd.Flags().String(formatFlagName, "oscal", "Export format. One of: oscal")
+	return cmd
+}
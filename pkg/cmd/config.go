@@ -8,7 +8,6 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
-	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -17,7 +16,7 @@ type LocalFlags struct {
 	nameOnly bool
 }
 
-func NewConfigCmd(cf *genericclioptions.ConfigFlags, outWriter io.Writer) *cobra.Command {
+func NewConfigCmd(cf *ConfigFlags, outWriter io.Writer) *cobra.Command {
 	var localFlags LocalFlags
 	cmd := &cobra.Command{
 		Use:   "config",
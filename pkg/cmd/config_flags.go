@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// ConfigFlags are the global flags of Starboard CLI used to construct
+// Kubernetes clients. Unlike genericclioptions.ConfigFlags, they fall back to
+// the in-cluster config when no kubeconfig can be resolved, e.g. when the CLI
+// runs in a pipeline pod. Impersonation flags, such as --as, apply to either
+// config.
+type ConfigFlags struct {
+	*genericclioptions.ConfigFlags
+
+	// InClusterConfig returns the config of the pod where the CLI is running.
+	InClusterConfig func() (*rest.Config, error)
+}
+
+// NewConfigFlags constructs ConfigFlags with the in-cluster config read from
+// the service account of the pod where the CLI is running.
+func NewConfigFlags() *ConfigFlags {
+	return &ConfigFlags{
+		ConfigFlags:     genericclioptions.NewConfigFlags(true),
+		InClusterConfig: rest.InClusterConfig,
+	}
+}
+
+// ConfigNotFoundError is returned when neither kubeconfig nor in-cluster
+// config can be resolved.
+type ConfigNotFoundError struct {
+	// KubeConfigPaths are paths of kubeconfig files which were tried.
+	KubeConfigPaths []string
+	// InClusterErr is the error of resolving the in-cluster config.
+	InClusterErr error
+}
+
+func (e *ConfigNotFoundError) Error() string {
+	return fmt.Sprintf("unable to resolve Kubernetes config, tried:\n"+
+		"  - kubeconfig: no cluster configured in %s (set with the --kubeconfig flag or the KUBECONFIG environment variable)\n"+
+		"  - in-cluster config: %v",
+		strings.Join(e.KubeConfigPaths, ", "), e.InClusterErr)
+}
+
+// ToRESTConfig returns the config resolved from the kubeconfig or, if no
+// kubeconfig is resolvable, the in-cluster config.
+func (f *ConfigFlags) ToRESTConfig() (*rest.Config, error) {
+	resolvable, err := f.kubeConfigResolvable()
+	if err != nil {
+		return nil, err
+	}
+	if resolvable {
+		return f.ConfigFlags.ToRESTConfig()
+	}
+	return f.toInClusterRESTConfig()
+}
+
+// ToDiscoveryClient returns a discovery client for the config returned by
+// ToRESTConfig.
+func (f *ConfigFlags) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	resolvable, err := f.kubeConfigResolvable()
+	if err != nil {
+		return nil, err
+	}
+	if resolvable {
+		return f.ConfigFlags.ToDiscoveryClient()
+	}
+	config, err := f.toInClusterRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return memory.NewMemCacheClient(discoveryClient), nil
+}
+
+// ToRESTMapper returns a REST mapper for the config returned by ToRESTConfig.
+func (f *ConfigFlags) ToRESTMapper() (meta.RESTMapper, error) {
+	resolvable, err := f.kubeConfigResolvable()
+	if err != nil {
+		return nil, err
+	}
+	if resolvable {
+		return f.ConfigFlags.ToRESTMapper()
+	}
+	discoveryClient, err := f.ToDiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)
+	return restmapper.NewShortcutExpander(mapper, discoveryClient), nil
+}
+
+// kubeConfigResolvable returns true if the API server is specified with the
+// --server flag or a cluster is configured in kubeconfig files. Errors of
+// loading kubeconfig files are returned as is, because kubeconfig files
+// which are specified but invalid must never be ignored.
+func (f *ConfigFlags) kubeConfigResolvable() (bool, error) {
+	if f.APIServer != nil && *f.APIServer != "" {
+		return true, nil
+	}
+	rawConfig, err := f.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return false, err
+	}
+	return len(rawConfig.Clusters) > 0, nil
+}
+
+// toInClusterRESTConfig returns the in-cluster config with impersonation,
+// bearer token, and timeout flags applied.
+func (f *ConfigFlags) toInClusterRESTConfig() (*rest.Config, error) {
+	config, err := f.InClusterConfig()
+	if err != nil {
+		return nil, &ConfigNotFoundError{
+			KubeConfigPaths: f.kubeConfigPaths(),
+			InClusterErr:    err,
+		}
+	}
+	if f.Impersonate != nil {
+		config.Impersonate.UserName = *f.Impersonate
+	}
+	if f.ImpersonateUID != nil {
+		config.Impersonate.UID = *f.ImpersonateUID
+	}
+	if f.ImpersonateGroup != nil {
+		config.Impersonate.Groups = *f.ImpersonateGroup
+	}
+	if f.BearerToken != nil && *f.BearerToken != "" {
+		config.BearerToken = *f.BearerToken
+		config.BearerTokenFile = ""
+	}
+	if f.Timeout != nil {
+		config.Timeout, err = clientcmd.ParseTimeout(*f.Timeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if f.WrapConfigFn != nil {
+		return f.WrapConfigFn(config), nil
+	}
+	return config, nil
+}
+
+func (f *ConfigFlags) kubeConfigPaths() []string {
+	if f.KubeConfig != nil && *f.KubeConfig != "" {
+		return []string{*f.KubeConfig}
+	}
+	return clientcmd.NewDefaultClientConfigLoadingRules().GetLoadingPrecedence()
+}
@@ -0,0 +1,106 @@
+package cmd_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/cmd"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+const kubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: kind
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: kind
+  context:
+    cluster: kind
+    user: kind
+current-context: kind
+users:
+- name: kind
+  user:
+    token: kubeconfig-token
+`
+
+func newConfigFlags(t *testing.T, inClusterConfig func() (*rest.Config, error), args ...string) *cmd.ConfigFlags {
+	t.Helper()
+	cf := cmd.NewConfigFlags()
+	cf.InClusterConfig = inClusterConfig
+	flags := pflag.NewFlagSet("starboard", pflag.ContinueOnError)
+	cf.AddFlags(flags)
+	require.NoError(t, flags.Parse(args))
+	return cf
+}
+
+func TestConfigFlags_ToRESTConfig(t *testing.T) {
+	inClusterConfig := func() (*rest.Config, error) {
+		return &rest.Config{
+			Host:            "https://10.96.0.1:443",
+			BearerTokenFile: "/var/run/secrets/kubernetes.io/serviceaccount/token",
+		}, nil
+	}
+
+	t.Run("Should use kubeconfig when it's resolvable", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config")
+		require.NoError(t, ioutil.WriteFile(path, []byte(kubeConfig), 0600))
+		t.Setenv("KUBECONFIG", path)
+		cf := newConfigFlags(t, func() (*rest.Config, error) {
+			t.Fatal("unexpected call to in-cluster config")
+			return nil, nil
+		}, "--as", "jane")
+
+		config, err := cf.ToRESTConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "https://127.0.0.1:6443", config.Host)
+		assert.Equal(t, "kubeconfig-token", config.BearerToken)
+		assert.Equal(t, "jane", config.Impersonate.UserName)
+	})
+
+	t.Run("Should fall back to in-cluster config with impersonation", func(t *testing.T) {
+		t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "missing"))
+		cf := newConfigFlags(t, inClusterConfig,
+			"--as", "system:serviceaccount:ci:scanner", "--as-group", "ci", "--request-timeout", "30s")
+
+		config, err := cf.ToRESTConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "https://10.96.0.1:443", config.Host)
+		assert.Equal(t, "/var/run/secrets/kubernetes.io/serviceaccount/token", config.BearerTokenFile)
+		assert.Equal(t, rest.ImpersonationConfig{
+			UserName: "system:serviceaccount:ci:scanner",
+			Groups:   []string{"ci"},
+		}, config.Impersonate)
+		assert.Equal(t, "30s", config.Timeout.String())
+	})
+
+	t.Run("Should list attempted config sources when none is resolvable", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing")
+		t.Setenv("KUBECONFIG", path)
+		cf := newConfigFlags(t, func() (*rest.Config, error) {
+			return nil, rest.ErrNotInCluster
+		})
+
+		_, err := cf.ToRESTConfig()
+		var notFound *cmd.ConfigNotFoundError
+		require.True(t, errors.As(err, &notFound))
+		assert.Equal(t, []string{path}, notFound.KubeConfigPaths)
+		assert.EqualError(t, err, "unable to resolve Kubernetes config, tried:\n"+
+			"  - kubeconfig: no cluster configured in "+path+" (set with the --kubeconfig flag or the KUBECONFIG environment variable)\n"+
+			"  - in-cluster config: unable to load in-cluster configuration, KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT must be defined")
+	})
+
+	t.Run("Should not fall back to in-cluster config when kubeconfig is invalid", func(t *testing.T) {
+		cf := newConfigFlags(t, inClusterConfig, "--kubeconfig", filepath.Join(t.TempDir(), "missing"))
+
+		_, err := cf.ToRESTConfig()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	exportDirFlagName = "export-dir"
+	archiveFlagName   = "archive"
+
+	// exportPageSize is the maximum number of reports listed at once, which
+	// bounds memory used by the export regardless of the number of reports.
+	exportPageSize = 100
+)
+
+// exportedKinds are kinds of reports exported before they are deleted.
+var exportedKinds = []string{
+	v1alpha1.VulnerabilityReportKind,
+	"ClusterVulnerabilityReport",
+	v1alpha1.ConfigAuditReportKind,
+	"ClusterConfigAuditReport",
+	v1alpha1.CISKubeBenchReportKind,
+	v1alpha1.KubeHunterReportKind,
+	"ClusterComplianceReport",
+	"ClusterComplianceDetailReport",
+	"ComplianceReport",
+	v1alpha1.PackageInventoryKind,
+	v1alpha1.ImageInventoryKind,
+	v1alpha1.WorkloadVulnerabilitySummaryKind,
+	v1alpha1.ClusterRiskReportKind,
+}
+
+// ReportExporter writes all reports to NDJSON files, one file per kind, in
+// the specified directory. Reports already exported to that directory, e.g.
+// by an interrupted export, are skipped, so that the export can be resumed.
+type ReportExporter struct {
+	client   client.Reader
+	dir      string
+	out      io.Writer
+	pageSize int64
+}
+
+// NewReportExporter constructs a new ReportExporter.
+func NewReportExporter(client client.Reader, dir string, out io.Writer) *ReportExporter {
+	return &ReportExporter{
+		client:   client,
+		dir:      dir,
+		out:      out,
+		pageSize: exportPageSize,
+	}
+}
+
+// Export exports reports of all kinds. Kinds whose CRDs are not installed
+// are skipped.
+func (e *ReportExporter) Export(ctx context.Context) error {
+	err := os.MkdirAll(e.dir, 0755)
+	if err != nil {
+		return fmt.Errorf("creating export directory: %w", err)
+	}
+	for _, kind := range exportedKinds {
+		err = e.exportKind(ctx, kind)
+		if err != nil {
+			return fmt.Errorf("exporting %s objects: %w", kind, err)
+		}
+	}
+	return nil
+}
+
+func exportFileName(kind string) string {
+	return strings.ToLower(kind) + ".ndjson"
+}
+
+func (e *ReportExporter) exportKind(ctx context.Context, kind string) error {
+	path := filepath.Join(e.dir, exportFileName(kind))
+	exported, err := readExportedUIDs(path)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	writer := bufio.NewWriter(file)
+
+	var count, skipped int
+	continueToken := ""
+	for {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(kind + "List"))
+		err = e.client.List(ctx, list, client.Limit(e.pageSize), client.Continue(continueToken))
+		if meta.IsNoMatchError(err) {
+			fmt.Fprintf(e.out, "Skipping %s objects, CRD is not installed\n", kind)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, item := range list.Items {
+			if _, ok := exported[item.GetUID()]; ok {
+				skipped++
+				continue
+			}
+			line, err := json.Marshal(item.Object)
+			if err != nil {
+				return err
+			}
+			_, err = writer.Write(append(line, '\n'))
+			if err != nil {
+				return err
+			}
+			count++
+		}
+		// flush each page, so that an interrupted export can be resumed
+		err = writer.Flush()
+		if err != nil {
+			return err
+		}
+		continueToken = list.GetContinue()
+		if continueToken == "" {
+			break
+		}
+		fmt.Fprintf(e.out, "Exported %d %s objects so far\n", count, kind)
+	}
+	fmt.Fprintf(e.out, "Exported %d %s objects to %s, skipped %d already exported\n", count, kind, path, skipped)
+	return nil
+}
+
+// readExportedUIDs returns UIDs of objects exported to the specified file.
+// A partially written last line, left by an interrupted export, is truncated.
+func readExportedUIDs(path string) (map[types.UID]struct{}, error) {
+	uids := make(map[types.UID]struct{})
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if os.IsNotExist(err) {
+		return uids, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	reader := bufio.NewReader(file)
+	var offset int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			if len(line) > 0 {
+				return uids, file.Truncate(offset)
+			}
+			return uids, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var object struct {
+			Metadata struct {
+				UID types.UID `json:"uid"`
+			} `json:"metadata"`
+		}
+		if err := json.Unmarshal(bytes.TrimSpace(line), &object); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		uids[object.Metadata.UID] = struct{}{}
+		offset += int64(len(line))
+	}
+}
+
+// archiveExport writes NDJSON files exported to the specified directory to a
+// tar.gz archive at the specified path.
+func archiveExport(dir, path string) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, kind := range exportedKinds {
+		err = addToArchive(tarWriter, filepath.Join(dir, exportFileName(kind)))
+		if err != nil {
+			return err
+		}
+	}
+	err = tarWriter.Close()
+	if err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+func addToArchive(tarWriter *tar.Writer, path string) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	err = tarWriter.WriteHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tarWriter, file)
+	return err
+}
@@ -0,0 +1,82 @@
+package cmd_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/cmd"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func readExportedNames(t *testing.T, path string) []string {
+	t.Helper()
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+	var names []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var object struct {
+			Kind     string            `json:"kind"`
+			Metadata metav1.ObjectMeta `json:"metadata"`
+		}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &object))
+		names = append(names, object.Kind+"/"+object.Metadata.Name)
+	}
+	require.NoError(t, scanner.Err())
+	return names
+}
+
+func TestReportExporter(t *testing.T) {
+	client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.VulnerabilityReport{ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default", UID: "uid-1"}},
+		&v1alpha1.VulnerabilityReport{ObjectMeta: metav1.ObjectMeta{Name: "redis", Namespace: "default", UID: "uid-2"}},
+		&v1alpha1.ClusterComplianceReport{ObjectMeta: metav1.ObjectMeta{Name: "nsa", UID: "uid-3"}},
+		&v1alpha1.ComplianceReport{ObjectMeta: metav1.ObjectMeta{Name: "nsa", Namespace: "default", UID: "uid-4"}},
+		&v1alpha1.WorkloadVulnerabilitySummary{ObjectMeta: metav1.ObjectMeta{Name: "replicaset-nginx", Namespace: "default", UID: "uid-5"}},
+		&v1alpha1.ClusterRiskReport{ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "uid-6"}},
+	).Build()
+
+	t.Run("Should export reports grouped by kind", func(t *testing.T) {
+		dir := t.TempDir()
+
+		err := cmd.NewReportExporter(client, dir, ioutil.Discard).Export(context.TODO())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"VulnerabilityReport/nginx", "VulnerabilityReport/redis"},
+			readExportedNames(t, filepath.Join(dir, "vulnerabilityreport.ndjson")))
+		assert.Equal(t, []string{"ClusterComplianceReport/nsa"},
+			readExportedNames(t, filepath.Join(dir, "clustercompliancereport.ndjson")))
+		assert.Equal(t, []string{"ComplianceReport/nsa"},
+			readExportedNames(t, filepath.Join(dir, "compliancereport.ndjson")))
+		assert.Equal(t, []string{"WorkloadVulnerabilitySummary/replicaset-nginx"},
+			readExportedNames(t, filepath.Join(dir, "workloadvulnerabilitysummary.ndjson")))
+		assert.Equal(t, []string{"ClusterRiskReport/cluster"},
+			readExportedNames(t, filepath.Join(dir, "clusterriskreport.ndjson")))
+	})
+
+	t.Run("Should resume interrupted export", func(t *testing.T) {
+		dir := t.TempDir()
+		// the second line was only partially written when the export was interrupted
+		interrupted := `{"kind":"VulnerabilityReport","metadata":{"name":"nginx","namespace":"default","uid":"uid-1"}}
+{"kind":"VulnerabilityReport","metadata":{"name":"re`
+		err := ioutil.WriteFile(filepath.Join(dir, "vulnerabilityreport.ndjson"), []byte(interrupted), 0644)
+		require.NoError(t, err)
+
+		err = cmd.NewReportExporter(client, dir, ioutil.Discard).Export(context.TODO())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"VulnerabilityReport/nginx", "VulnerabilityReport/redis"},
+			readExportedNames(t, filepath.Join(dir, "vulnerabilityreport.ndjson")))
+	})
+}
@@ -5,18 +5,20 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
-	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
-func NewGetCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, outWriter io.Writer) *cobra.Command {
+func NewGetCmd(buildInfo starboard.BuildInfo, cf *ConfigFlags, outWriter io.Writer) *cobra.Command {
 	getCmd := &cobra.Command{
 		Use:   "get",
 		Short: "Get security reports",
 	}
 	getCmd.AddCommand(NewGetVulnerabilityReportsCmd(buildInfo.Executable, cf, outWriter))
+	getCmd.AddCommand(NewGetPackagesCmd(buildInfo.Executable, cf, outWriter))
+	getCmd.AddCommand(NewGetFixesCmd(buildInfo.Executable, cf, outWriter))
 	getCmd.AddCommand(NewGetConfigAuditReportsCmd(buildInfo.Executable, cf, outWriter))
 	getCmd.AddCommand(NewGetClusterComplianceReportsCmd(buildInfo.Executable, cf, outWriter))
-	getCmd.PersistentFlags().StringP("output", "o", "", "Output format. One of yaml|json")
+	getCmd.AddCommand(NewGetComplianceCmd(buildInfo.Executable, cf, outWriter))
+	getCmd.PersistentFlags().StringP("output", "o", "", "Output format. One of yaml|json, sarif for vulnerability and configuration audit reports, or table for compliance controls")
 
 	return getCmd
 }
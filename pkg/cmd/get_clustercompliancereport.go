@@ -18,7 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func NewGetClusterComplianceReportsCmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+func NewGetClusterComplianceReportsCmd(executable string, cf *ConfigFlags, out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "clustercompliancereports (NAME)",
 		Aliases: []string{"clustercompliance"},
@@ -60,7 +60,11 @@ func NewGetClusterComplianceReportsCmd(executable string, cf *genericclioptions.
 			if err != nil {
 				return err
 			}
-			complianceMgr := compliance.NewMgr(kubeClient, logger, starboardConfig)
+			clusterMetadata, err := newClusterMetadataReader(cmd, cf, kubeClientset)
+			if err != nil {
+				return err
+			}
+			complianceMgr := compliance.NewMgr(kubeClient, logger, starboardConfig, clusterMetadata, nil)
 			err = complianceMgr.GenerateComplianceReport(ctx, report.Spec)
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %w", err)
@@ -107,6 +111,7 @@ func NewGetClusterComplianceReportsCmd(executable string, cf *genericclioptions.
 		},
 	}
 	cmd.PersistentFlags().BoolP("detail", "d", false, "Get compliance detail report for control checks failure")
+	registerClusterNameFlag(cmd)
 	return cmd
 }
 
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const detailsFlagName = "details"
+
+// ComplianceControl is a control of a cluster compliance report as printed
+// by the get compliance command.
+type ComplianceControl struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	Severity  v1alpha1.Severity      `json:"severity"`
+	Status    v1alpha1.ControlStatus `json:"status,omitempty"`
+	PassTotal int                    `json:"passTotal"`
+	FailTotal int                    `json:"failTotal"`
+	// Failures lists failing resources of the control. It's only set if the
+	// corresponding detail report was requested.
+	Failures []ComplianceFailure `json:"failures,omitempty"`
+}
+
+// ComplianceFailure is a resource which failed a check of a control.
+type ComplianceFailure struct {
+	CheckID   string `json:"checkId,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Message   string `json:"message"`
+}
+
+func NewGetComplianceCmd(executable string, cf *ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compliance (NAME)",
+		Short: "Get controls of a cluster compliance report",
+		Long: `Get controls of a cluster compliance report with their pass and fail totals
+
+The report is read as last generated by Starboard Operator, or by the compliance generate command.
+The command fails if the report does not exist yet.
+`,
+		Example: fmt.Sprintf(`  # Get controls of the nsa cluster compliance report
+  %[1]s get compliance nsa
+
+  # Get controls of the nsa cluster compliance report with failing resources
+  %[1]s get compliance nsa --details
+
+  # Get controls of the nsa cluster compliance report in JSON output format
+  %[1]s get compliance nsa -o json`, executable),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return fmt.Errorf("failed to create kubeConfig: %w", err)
+			}
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+			if err != nil {
+				return fmt.Errorf("failed to create kubernetes client: %w", err)
+			}
+			namespaceName, err := ComplianceNameFromArgs(args)
+			if err != nil {
+				return err
+			}
+			details, err := cmd.Flags().GetBool(detailsFlagName)
+			if err != nil {
+				return err
+			}
+
+			var report v1alpha1.ClusterComplianceReport
+			if err := getGeneratedReport(ctx, kubeClient, namespaceName, &report); err != nil {
+				return err
+			}
+			var detailReport *v1alpha1.ClusterComplianceDetailReport
+			if details {
+				detailNamespaceName, err := ComplianceNameFromArgs(args, "details")
+				if err != nil {
+					return err
+				}
+				detailReport = &v1alpha1.ClusterComplianceDetailReport{}
+				if err := getGeneratedReport(ctx, kubeClient, detailNamespaceName, detailReport); err != nil {
+					return err
+				}
+			}
+			controls := ComplianceControls(report, detailReport)
+
+			switch format := cmd.Flag("output").Value.String(); format {
+			case "json":
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(controls)
+			case "yaml":
+				data, err := yaml.Marshal(controls)
+				if err != nil {
+					return err
+				}
+				_, err = out.Write(data)
+				return err
+			case "", "table":
+				color, err := useColor(cmd, out)
+				if err != nil {
+					return err
+				}
+				return WriteComplianceControls(out, controls, details, color)
+			default:
+				return fmt.Errorf("invalid output format %q, allowed formats are: yaml,json,table", format)
+			}
+		},
+	}
+	cmd.Flags().Bool(detailsFlagName, false, "If true, list failing resources of each control from the compliance detail report")
+	registerNoColorFlag(cmd)
+	return cmd
+}
+
+// getGeneratedReport gets the given compliance report, or returns an error
+// which explains that the report was not generated yet if it's not found.
+func getGeneratedReport(ctx context.Context, c client.Client, namespaceName types.NamespacedName, report client.Object) error {
+	err := c.Get(ctx, namespaceName, report)
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("compliance report %s not found, it may not be generated yet: %w", namespaceName.Name, err)
+	}
+	if err != nil {
+		return fmt.Errorf("getting compliance report %s: %w", namespaceName.Name, err)
+	}
+	return nil
+}
+
+// ComplianceControls returns controls of the given compliance report. If the
+// detail report is not nil, failing results of each control are flattened
+// into its failures.
+func ComplianceControls(report v1alpha1.ClusterComplianceReport, detailReport *v1alpha1.ClusterComplianceDetailReport) []ComplianceControl {
+	failures := make(map[string][]ComplianceFailure)
+	if detailReport != nil {
+		for _, control := range detailReport.Report.ControlChecks {
+			for _, result := range control.ScannerCheckResult {
+				for _, detail := range result.Details {
+					if detail.Status != v1alpha1.FailStatus {
+						continue
+					}
+					failures[control.ID] = append(failures[control.ID], ComplianceFailure{
+						CheckID:   result.ID,
+						Namespace: detail.Namespace,
+						Name:      detail.Name,
+						Message:   detail.Msg,
+					})
+				}
+			}
+		}
+	}
+	controls := make([]ComplianceControl, 0, len(report.Status.ControlChecks))
+	for _, check := range report.Status.ControlChecks {
+		controls = append(controls, ComplianceControl{
+			ID:        check.ID,
+			Name:      check.Name,
+			Severity:  check.Severity,
+			Status:    check.Status,
+			PassTotal: check.PassTotal,
+			FailTotal: check.FailTotal,
+			Failures:  failures[check.ID],
+		})
+	}
+	return controls
+}
+
+// WriteComplianceControls writes a table of the given controls and, if
+// details is true, a table of their failing resources.
+func WriteComplianceControls(out io.Writer, controls []ComplianceControl, details bool, color bool) error {
+	var rows, failureRows [][]cell
+	for _, control := range controls {
+		rows = append(rows, []cell{
+			{text: control.ID},
+			{text: control.Name},
+			{text: string(control.Severity), severity: control.Severity},
+			{text: fmt.Sprintf("%d", control.PassTotal)},
+			countCell(control.FailTotal, control.Severity),
+		})
+		for _, failure := range control.Failures {
+			failureRows = append(failureRows, []cell{
+				{text: control.ID},
+				{text: failure.Namespace},
+				{text: failure.Name},
+				{text: failure.Message},
+			})
+		}
+	}
+	if err := writeTable(out, color, "", []string{"ID", "NAME", "SEVERITY", "PASS", "FAIL"}, rows); err != nil {
+		return err
+	}
+	if !details {
+		return nil
+	}
+	if len(failureRows) == 0 {
+		_, err := fmt.Fprintln(out, "\nNo failing resources found.")
+		return err
+	}
+	if _, err := fmt.Fprintf(out, "\nFailing resources:\n"); err != nil {
+		return err
+	}
+	return writeTable(out, color, "  ", []string{"CONTROL", "NAMESPACE", "NAME", "MESSAGE"}, failureRows)
+}
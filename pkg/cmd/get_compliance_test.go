@@ -0,0 +1,87 @@
+package cmd_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComplianceControls(t *testing.T) {
+	report := v1alpha1.ClusterComplianceReport{
+		Status: v1alpha1.ReportStatus{
+			ControlChecks: []v1alpha1.ControlCheck{
+				{ID: "1.0", Name: "Non-root containers", Severity: v1alpha1.SeverityMedium, PassTotal: 2, FailTotal: 1},
+				{ID: "2.0", Name: "Immutable container file systems", Severity: v1alpha1.SeverityLow, PassTotal: 3},
+			},
+		},
+	}
+	detailReport := &v1alpha1.ClusterComplianceDetailReport{
+		Report: v1alpha1.ClusterComplianceDetailReportData{
+			ControlChecks: []v1alpha1.ControlCheckDetails{
+				{
+					ID: "1.0",
+					ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+						{
+							ObjectType: "Pod",
+							ID:         "KSV012",
+							Details: []v1alpha1.ResultDetails{
+								{Namespace: "default", Name: "nginx", Msg: "Container 'nginx' should set 'securityContext.runAsNonRoot' to true", Status: v1alpha1.FailStatus},
+								{Namespace: "default", Name: "redis", Status: v1alpha1.PassStatus},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("Should return controls without failures", func(t *testing.T) {
+		assert.Equal(t, []cmd.ComplianceControl{
+			{ID: "1.0", Name: "Non-root containers", Severity: v1alpha1.SeverityMedium, PassTotal: 2, FailTotal: 1},
+			{ID: "2.0", Name: "Immutable container file systems", Severity: v1alpha1.SeverityLow, PassTotal: 3},
+		}, cmd.ComplianceControls(report, nil))
+	})
+
+	t.Run("Should flatten failing resources of detail report", func(t *testing.T) {
+		controls := cmd.ComplianceControls(report, detailReport)
+		require.Len(t, controls, 2)
+		assert.Equal(t, []cmd.ComplianceFailure{
+			{CheckID: "KSV012", Namespace: "default", Name: "nginx", Message: "Container 'nginx' should set 'securityContext.runAsNonRoot' to true"},
+		}, controls[0].Failures)
+		assert.Empty(t, controls[1].Failures)
+	})
+}
+
+func TestWriteComplianceControls(t *testing.T) {
+	controls := []cmd.ComplianceControl{
+		{ID: "1.0", Name: "Non-root containers", Severity: v1alpha1.SeverityMedium, PassTotal: 2, FailTotal: 1,
+			Failures: []cmd.ComplianceFailure{{Namespace: "default", Name: "nginx", Message: "Runs as root"}}},
+		{ID: "2.0", Name: "Immutable container file systems", Severity: v1alpha1.SeverityLow, PassTotal: 3},
+	}
+
+	t.Run("Should write controls", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		require.NoError(t, cmd.WriteComplianceControls(out, controls, false, false))
+		assert.Equal(t, `ID    NAME                               SEVERITY   PASS   FAIL
+1.0   Non-root containers                MEDIUM     2      1
+2.0   Immutable container file systems   LOW        3      0
+`, out.String())
+	})
+
+	t.Run("Should write controls with failing resources", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		require.NoError(t, cmd.WriteComplianceControls(out, controls, true, false))
+		assert.Equal(t, `ID    NAME                               SEVERITY   PASS   FAIL
+1.0   Non-root containers                MEDIUM     2      1
+2.0   Immutable container file systems   LOW        3      0
+
+Failing resources:
+  CONTROL   NAMESPACE   NAME    MESSAGE
+  1.0       default     nginx   Runs as root
+`, out.String())
+	})
+}
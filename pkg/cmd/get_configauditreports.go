@@ -5,14 +5,16 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/sarif"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func NewGetConfigAuditReportsCmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+func NewGetConfigAuditReportsCmd(executable string, cf *ConfigFlags, out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "configauditreports (NAME | TYPE/NAME)",
 		Aliases: []string{"configaudit"},
@@ -32,7 +34,10 @@ NAME is the name of a particular Kubernetes resource.
   %[1]s get configaudit replicaset/nginx
 
   # Get configuration audit report for a CronJob with the specified name in JSON output format
-  %[1]s get configaudit cj/my-job -o json`, executable),
+  %[1]s get configaudit cj/my-job -o json
+
+  # Get configuration audit report for a Deployment with the specified name in SARIF output format
+  %[1]s get configaudit deploy/nginx -o sarif`, executable),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
@@ -69,6 +74,9 @@ NAME is the name of a particular Kubernetes resource.
 			}
 
 			format := cmd.Flag("output").Value.String()
+			if format == "sarif" {
+				return sarif.Write(out, sarif.FromConfigAuditReports([]v1alpha1.ConfigAuditReport{*report}))
+			}
 			printer, err := genericclioptions.NewPrintFlags("").
 				WithTypeSetter(scheme).
 				WithDefaultOutput(format).
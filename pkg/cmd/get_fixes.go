@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/report"
+	"github.com/aquasecurity/starboard/pkg/report/templates"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+func NewGetFixesCmd(executable string, cf *ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "fixes (NAME | TYPE/NAME)",
+		Aliases: []string{"fix"},
+		Short:   "Get package upgrades which fix the most vulnerabilities",
+		Long: `Get package upgrades which fix vulnerabilities in container images of the specified workload
+
+TYPE is a Kubernetes workload. Shortcuts and API groups will be resolved, e.g. 'po' or 'deployments.apps'.
+NAME is the name of a particular Kubernetes workload.
+
+Vulnerabilities are grouped by package and fixed version. Upgrades are sorted by score, i.e. the number of fixed
+vulnerabilities weighted by severity, so that the upgrade which removes the most findings is listed first.
+`,
+		Example: fmt.Sprintf(`  # Get package upgrades for a Deployment with the specified name
+  %[1]s get fixes deploy/nginx
+
+  # Get top 5 package upgrades for a Deployment with the specified name
+  # in JSON output format
+  %[1]s get fixes deploy/nginx --top 5 -o json`, executable),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			scheme := starboard.NewScheme()
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: scheme})
+			if err != nil {
+				return err
+			}
+			ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
+			if err != nil {
+				return err
+			}
+			mapper, err := cf.ToRESTMapper()
+			if err != nil {
+				return err
+			}
+			workload, _, err := WorkloadFromArgs(mapper, ns, args)
+			if err != nil {
+				return err
+			}
+
+			items, err := vulnerabilityreport.NewReadWriter(kubeClient).FindByOwnerInHierarchy(ctx, workload)
+			if err != nil {
+				return fmt.Errorf("list vulnerability reports: %w", err)
+			}
+			if len(items) == 0 {
+				fmt.Fprintf(out, "No reports found in %s namespace.\n", workload.Namespace)
+				return nil
+			}
+
+			format := cmd.Flag("output").Value.String()
+			top, err := cmd.Flags().GetInt("top")
+			if err != nil {
+				return err
+			}
+
+			reports := make(map[string]v1alpha1.VulnerabilityReportData)
+			for _, item := range items {
+				reports[item.Labels[starboard.LabelContainerName]] = item.Report
+			}
+			fixes := report.GroupFixes(reports)
+			if top > 0 && top < len(fixes) {
+				fixes = fixes[:top]
+			}
+
+			switch format {
+			case "json":
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(fixes)
+			case "yaml":
+				data, err := yaml.Marshal(fixes)
+				if err != nil {
+					return err
+				}
+				_, err = out.Write(data)
+				return err
+			case "":
+				return printFixes(out, fixes)
+			default:
+				return fmt.Errorf("invalid output format %q, allowed formats are: yaml,json", format)
+			}
+		},
+	}
+
+	cmd.Flags().Int("top", 0, "Limit the number of listed upgrades, 0 lists all of them")
+
+	return cmd
+}
+
+func printFixes(out io.Writer, fixes []templates.Fix) error {
+	if len(fixes) == 0 {
+		_, err := fmt.Fprintln(out, "No fixable vulnerabilities found.")
+		return err
+	}
+	w := printers.GetNewTabWriter(out)
+	fmt.Fprintln(w, "RESOURCE\tFIXED VERSION\tCONTAINERS\tCRITICAL\tHIGH\tMEDIUM\tLOW\tUNKNOWN\tSCORE")
+	for _, fix := range fixes {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\n", fix.Resource, fix.FixedVersion,
+			strings.Join(fix.Containers, ","), fix.Summary.CriticalCount, fix.Summary.HighCount,
+			fix.Summary.MediumCount, fix.Summary.LowCount, fix.Summary.UnknownCount, fix.Score)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewGetPackagesCmd(executable string, cf *ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "packages (NAME | TYPE/NAME)",
+		Aliases: []string{"pkgs", "pkg", "package"},
+		Short:   "Get package inventories",
+		Long: `Get inventories of packages installed in container images of the specified workload
+
+TYPE is a Kubernetes workload. Shortcuts and API groups will be resolved, e.g. 'po' or 'deployments.apps'.
+NAME is the name of a particular Kubernetes workload.
+
+Package inventories are only available if Trivy is configured with trivy.listAllPackages set to "true".
+`,
+		Example: fmt.Sprintf(`  # Get package inventories for a Deployment with the specified name
+  %[1]s get packages deploy/nginx
+
+  # Get package inventories for the specified container belonging to
+  # a Deployment with the specified name in JSON output format
+  %[1]s get pkgs deploy/nginx --container nginx -o json`, executable),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			scheme := starboard.NewScheme()
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: scheme})
+			if err != nil {
+				return err
+			}
+			ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
+			if err != nil {
+				return err
+			}
+			mapper, err := cf.ToRESTMapper()
+			if err != nil {
+				return err
+			}
+			workload, _, err := WorkloadFromArgs(mapper, ns, args)
+			if err != nil {
+				return err
+			}
+
+			reader := vulnerabilityreport.NewReadWriter(kubeClient)
+			items, err := reader.FindByOwnerInHierarchy(ctx, workload)
+			if err != nil {
+				return fmt.Errorf("list vulnerability reports: %w", err)
+			}
+			if len(items) == 0 {
+				fmt.Fprintf(out, "No reports found in %s namespace.\n", workload.Namespace)
+				return nil
+			}
+
+			format := cmd.Flag("output").Value.String()
+			container := cmd.Flag("container").Value.String()
+
+			var printer printers.ResourcePrinter
+
+			switch format {
+			case "yaml", "json":
+				printer, err = genericclioptions.NewPrintFlags("").
+					WithTypeSetter(starboard.NewScheme()).
+					WithDefaultOutput(format).
+					ToPrinter()
+				if err != nil {
+					return err
+				}
+			case "":
+				printer = printers.NewTablePrinter(printers.PrintOptions{})
+			default:
+				return fmt.Errorf("invalid output format %q, allowed formats are: yaml,json", format)
+			}
+
+			list := &v1alpha1.PackageInventoryList{
+				Items: []v1alpha1.PackageInventory{},
+			}
+
+			for _, item := range items {
+				if container != "" && item.Labels[starboard.LabelContainerName] != container {
+					continue
+				}
+				packages, err := reader.FindPackages(ctx, item)
+				if err != nil {
+					return fmt.Errorf("getting packages of %s: %w", item.Name, err)
+				}
+				list.Items = append(list.Items, v1alpha1.PackageInventory{
+					ObjectMeta: *item.ObjectMeta.DeepCopy(),
+					Report: v1alpha1.PackageInventoryData{
+						UpdateTimestamp: item.Report.UpdateTimestamp,
+						Artifact:        item.Report.Artifact,
+						Packages:        packages,
+					},
+				})
+			}
+			if len(items) > 0 && len(list.Items) == 0 {
+				return fmt.Errorf("container %s is not valid for %s %s", container, strings.ToLower(string(workload.Kind)), workload.Name)
+			}
+
+			return printer.PrintObj(list, out)
+		},
+	}
+
+	cmd.PersistentFlags().StringP("container", "c", "", "Get package inventory of this container")
+
+	return cmd
+}
@@ -5,17 +5,22 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/archive"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/sarif"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func NewGetVulnerabilityReportsCmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+func NewGetVulnerabilityReportsCmd(executable string, cf *ConfigFlags, out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "vulnerabilityreports (NAME | TYPE/NAME)",
 		Aliases: []string{"vulns", "vuln", "vulnerabilities"},
@@ -39,7 +44,22 @@ NAME is the name of a particular Kubernetes workload.
   %[1]s get vulns replicaset/nginx --container nginx
 
   # Get vulnerability reports for a CronJob with the specified name in JSON output format
-  %[1]s get vuln cj/my-job -o json`, executable),
+  %[1]s get vuln cj/my-job -o json
+
+  # Get vulnerability reports of images built before the specified date
+  %[1]s get vulns deploy/nginx --built-before 2022-01-01
+
+  # Get prioritized vulnerabilities of the most urgent priorities for a Deployment with the specified name
+  %[1]s get vulns deploy/nginx --priority P1,P2 -o yaml
+
+  # Get vulnerability reports for a Deployment with the specified name in SARIF output format
+  %[1]s get vulns deploy/nginx -o sarif
+
+  # List archived versions of vulnerability reports for a Deployment with the specified name
+  %[1]s get vulns deploy/nginx --history
+
+  # Get archived vulnerability reports for a Deployment with the specified name from the specified scan
+  %[1]s get vulns deploy/nginx --history-version 2022-06-01T10:00:00Z -o yaml`, executable),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
@@ -70,13 +90,67 @@ NAME is the name of a particular Kubernetes workload.
 			if err != nil {
 				return fmt.Errorf("list vulnerability reports: %w", err)
 			}
+
+			format := cmd.Flag("output").Value.String()
+			container := cmd.Flag("container").Value.String()
+			history, err := cmd.Flags().GetBool(historyFlagName)
+			if err != nil {
+				return err
+			}
+			historyVersion := cmd.Flag(historyVersionFlagName).Value.String()
+			if history || historyVersion != "" {
+				kubeClientset, err := kubernetes.NewForConfig(kubeConfig)
+				if err != nil {
+					return err
+				}
+				starboardConfig, err := starboard.NewConfigManager(kubeClientset, starboard.NamespaceName).Read(ctx)
+				if err != nil {
+					return err
+				}
+				archiveConfig, enabled, err := archive.GetConfig(starboardConfig)
+				if err != nil {
+					return err
+				}
+				if !enabled {
+					return fmt.Errorf("vulnerability reports are not archived, %s is not set", archive.KeyURL)
+				}
+				owners := reportOwners(items, workload)
+				if history {
+					if format != "" {
+						return fmt.Errorf("invalid output format %q, archived versions are listed as a table", format)
+					}
+					versions, err := listArchivedVersions(ctx, archiveConfig, owners, container)
+					if err != nil {
+						return err
+					}
+					if len(versions) == 0 {
+						fmt.Fprintf(out, "No archived reports found in %s namespace.\n", workload.Namespace)
+						return nil
+					}
+					return WriteArchivedVersions(out, versions)
+				}
+				items, err = getArchivedReports(ctx, archiveConfig, owners, historyVersion)
+				if err != nil {
+					return err
+				}
+				if len(items) == 0 {
+					fmt.Fprintf(out, "No archived reports found at %s.\n", historyVersion)
+					return nil
+				}
+			}
 			if len(items) == 0 {
 				fmt.Fprintf(out, "No reports found in %s namespace.\n", workload.Namespace)
 				return nil
 			}
 
-			format := cmd.Flag("output").Value.String()
-			container := cmd.Flag("container").Value.String()
+			builtBefore, err := parseBuiltBefore(cmd.Flag("built-before").Value.String())
+			if err != nil {
+				return err
+			}
+			priorities, err := parsePriorities(cmd.Flag(priorityFlagName).Value.String())
+			if err != nil {
+				return err
+			}
 
 			var printer printers.ResourcePrinter
 
@@ -94,8 +168,10 @@ NAME is the name of a particular Kubernetes workload.
 				if err != nil {
 					return err
 				}
+			case "sarif":
+				// Printed with sarif.Write rather than a resource printer.
 			default:
-				return fmt.Errorf("invalid output format %q, allowed formats are: yaml,json", format)
+				return fmt.Errorf("invalid output format %q, allowed formats are: yaml,json,sarif", format)
 			}
 
 			list := &v1alpha1.VulnerabilityReportList{
@@ -111,12 +187,198 @@ NAME is the name of a particular Kubernetes workload.
 			if len(items) > 0 && len(list.Items) == 0 {
 				return fmt.Errorf("container %s is not valid for %s %s", container, strings.ToLower(string(workload.Kind)), workload.Name)
 			}
+			if !builtBefore.IsZero() {
+				list.Items = filterBuiltBefore(list.Items, builtBefore)
+			}
+			if len(priorities) > 0 {
+				list.Items = filterPriorities(list.Items, priorities)
+			}
 
-			return printer.PrintObj(list, out)
+			if format == "sarif" {
+				return sarif.Write(out, sarif.FromVulnerabilityReports(list.Items))
+			}
+			err = printer.PrintObj(list, out)
+			if err != nil || format != "" {
+				return err
+			}
+			// The table only lists names of reports, so tell skipped containers
+			// apart from containers which were scanned and found clean.
+			for _, item := range list.Items {
+				if item.Report.SkipReason != "" {
+					fmt.Fprintf(out, "Container %s was not scanned: %s.\n",
+						item.Labels[starboard.LabelContainerName], item.Report.SkipReason)
+				}
+			}
+			return nil
 		},
 	}
 
 	cmd.PersistentFlags().StringP("container", "c", "", "Get vulnerability report of this container")
+	cmd.PersistentFlags().String("built-before", "", "Get vulnerability reports of images built before this date, e.g. 2022-01-01 or 2022-01-01T00:00:00Z")
+	cmd.PersistentFlags().String(priorityFlagName, "", "Get only vulnerabilities with these comma-separated priorities, e.g. P1,P2, of reports whose vulnerabilities are prioritized")
+	cmd.PersistentFlags().Bool(historyFlagName, false, "List archived versions of vulnerability reports")
+	cmd.PersistentFlags().String(historyVersionFlagName, "", "Get archived vulnerability reports of the scan at this timestamp, e.g. 2022-06-01T10:00:00Z")
 
 	return cmd
 }
+
+const priorityFlagName = "priority"
+
+const (
+	historyFlagName        = "history"
+	historyVersionFlagName = "history-version"
+)
+
+// reportOwners returns owners of the given reports, which are archived by
+// owner. If there are no reports, e.g. because the workload was deleted, the
+// given workload is returned.
+func reportOwners(reports []v1alpha1.VulnerabilityReport, workload kube.ObjectRef) []kube.ObjectRef {
+	var owners []kube.ObjectRef
+	seen := make(map[kube.ObjectRef]bool)
+	for _, report := range reports {
+		owner := kube.ObjectRef{
+			Kind:      kube.Kind(report.Labels[starboard.LabelResourceKind]),
+			Name:      report.Labels[starboard.LabelResourceName],
+			Namespace: report.Namespace,
+		}
+		if !seen[owner] {
+			seen[owner] = true
+			owners = append(owners, owner)
+		}
+	}
+	if len(owners) == 0 {
+		owners = append(owners, workload)
+	}
+	return owners
+}
+
+// listArchivedVersions returns archived versions of reports of the given
+// owners, optionally of the given container only.
+func listArchivedVersions(ctx context.Context, config archive.Config, owners []kube.ObjectRef, container string) ([]archive.Version, error) {
+	var versions []archive.Version
+	for _, owner := range owners {
+		ownerVersions, err := archive.ListVersions(ctx, config, owner.Namespace, string(owner.Kind), owner.Name)
+		if err != nil {
+			return nil, fmt.Errorf("listing archived reports: %w", err)
+		}
+		for _, version := range ownerVersions {
+			if container == "" || version.Container == container {
+				versions = append(versions, version)
+			}
+		}
+	}
+	return versions, nil
+}
+
+// getArchivedReports returns archived reports of the given owners from the
+// scan at the given timestamp.
+func getArchivedReports(ctx context.Context, config archive.Config, owners []kube.ObjectRef, timestamp string) ([]v1alpha1.VulnerabilityReport, error) {
+	at, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s value %q, expected RFC 3339 timestamp", historyVersionFlagName, timestamp)
+	}
+	versions, err := listArchivedVersions(ctx, config, owners, "")
+	if err != nil {
+		return nil, err
+	}
+	var reports []v1alpha1.VulnerabilityReport
+	for _, version := range versions {
+		if !version.Timestamp.Equal(at) {
+			continue
+		}
+		report, err := archive.GetVersion(ctx, config, version.Key)
+		if err != nil {
+			return nil, fmt.Errorf("getting archived report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// WriteArchivedVersions writes the given archived versions of vulnerability
+// reports as a table.
+func WriteArchivedVersions(out io.Writer, versions []archive.Version) error {
+	rows := make([][]cell, 0, len(versions))
+	for _, version := range versions {
+		rows = append(rows, []cell{
+			{text: version.Container},
+			{text: version.Timestamp.UTC().Format(time.RFC3339)},
+			{text: version.Key},
+		})
+	}
+	return writeTable(out, false, "", []string{"CONTAINER", "TIMESTAMP", "KEY"}, rows)
+}
+
+// parseBuiltBefore parses the value of the --built-before flag, which is
+// either a date or an RFC 3339 timestamp. It returns zero time if the value
+// is empty.
+func parseBuiltBefore(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --built-before value %q, expected date (2006-01-02) or RFC 3339 timestamp", value)
+	}
+	return t, nil
+}
+
+// filterBuiltBefore returns reports of images built before the specified
+// time. Reports without the image creation time are filtered out.
+func filterBuiltBefore(reports []v1alpha1.VulnerabilityReport, before time.Time) []v1alpha1.VulnerabilityReport {
+	filtered := make([]v1alpha1.VulnerabilityReport, 0)
+	for _, report := range reports {
+		created := report.Report.Artifact.Created
+		if created != nil && created.Time.Before(before) {
+			filtered = append(filtered, report)
+		}
+	}
+	return filtered
+}
+
+// parsePriorities parses the value of the --priority flag, which is a comma
+// separated list of priorities. It returns nil if the value is empty.
+func parsePriorities(value string) (map[v1alpha1.Priority]bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	priorities := make(map[v1alpha1.Priority]bool)
+	for _, item := range strings.Split(value, ",") {
+		priority := v1alpha1.Priority(strings.ToUpper(strings.TrimSpace(item)))
+		valid := false
+		for _, p := range v1alpha1.Priorities {
+			valid = valid || p == priority
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid --%s value %q, allowed priorities are: P1,P2,P3,P4", priorityFlagName, item)
+		}
+		priorities[priority] = true
+	}
+	return priorities, nil
+}
+
+// filterPriorities returns reports with vulnerabilities of the specified
+// priorities only. Reports without such vulnerabilities, including reports
+// whose vulnerabilities are not prioritized, are filtered out. Summaries of
+// reports are left intact.
+func filterPriorities(reports []v1alpha1.VulnerabilityReport, priorities map[v1alpha1.Priority]bool) []v1alpha1.VulnerabilityReport {
+	filtered := make([]v1alpha1.VulnerabilityReport, 0)
+	for _, report := range reports {
+		var vulnerabilities []v1alpha1.Vulnerability
+		for _, vulnerability := range report.Report.Vulnerabilities {
+			if priorities[vulnerability.Priority] {
+				vulnerabilities = append(vulnerabilities, vulnerability)
+			}
+		}
+		if len(vulnerabilities) == 0 {
+			continue
+		}
+		report = *report.DeepCopy()
+		report.Report.Vulnerabilities = vulnerabilities
+		filtered = append(filtered, report)
+	}
+	return filtered
+}
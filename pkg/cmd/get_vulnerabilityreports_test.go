@@ -0,0 +1,33 @@
+package cmd_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/archive"
+	"github.com/aquasecurity/starboard/pkg/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteArchivedVersions(t *testing.T) {
+	var out bytes.Buffer
+	err := cmd.WriteArchivedVersions(&out, []archive.Version{
+		{
+			Key:       "prod/default/replicaset-nginx-6d4cf56db6/nginx/20220601T100000Z.json",
+			Container: "nginx",
+			Timestamp: time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			Key:       "prod/default/replicaset-nginx-6d4cf56db6/sidecar/20220602T100000Z.json",
+			Container: "sidecar",
+			Timestamp: time.Date(2022, 6, 2, 10, 0, 0, 0, time.UTC),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `CONTAINER   TIMESTAMP              KEY
+nginx       2022-06-01T10:00:00Z   prod/default/replicaset-nginx-6d4cf56db6/nginx/20220601T100000Z.json
+sidecar     2022-06-02T10:00:00Z   prod/default/replicaset-nginx-6d4cf56db6/sidecar/20220602T100000Z.json
+`, out.String())
+}
@@ -158,6 +158,22 @@ func (m *Installer) Install(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	packageInventoriesCRD, err := embedded.GetPackageInventoriesCRD()
+	if err != nil {
+		return err
+	}
+	err = m.createOrUpdateCRD(ctx, &packageInventoriesCRD)
+	if err != nil {
+		return err
+	}
+	imageInventoriesCRD, err := embedded.GetImageInventoriesCRD()
+	if err != nil {
+		return err
+	}
+	err = m.createOrUpdateCRD(ctx, &imageInventoriesCRD)
+	if err != nil {
+		return err
+	}
 	clusterVulnerabilityReportsCRD, err := embedded.GetClusterVulnerabilityReportsCRD()
 	if err != nil {
 		return err
@@ -216,6 +232,22 @@ func (m *Installer) Install(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	complianceReportsCRD, err := embedded.GetComplianceReportsCRD()
+	if err != nil {
+		return err
+	}
+	err = m.createOrUpdateCRD(ctx, &complianceReportsCRD)
+	if err != nil {
+		return err
+	}
+	clusterRiskReportsCRD, err := embedded.GetClusterRiskReportsCRD()
+	if err != nil {
+		return err
+	}
+	err = m.createOrUpdateCRD(ctx, &clusterRiskReportsCRD)
+	if err != nil {
+		return err
+	}
 
 	// TODO We should wait for CRD statuses and make sure that the names were accepted
 
@@ -444,6 +476,18 @@ func (m *Installer) Uninstall(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	err = m.deleteCRD(ctx, v1alpha1.PackageInventoriesCRName)
+	if err != nil {
+		return err
+	}
+	err = m.deleteCRD(ctx, v1alpha1.WorkloadVulnerabilitySummariesCRName)
+	if err != nil {
+		return err
+	}
+	err = m.deleteCRD(ctx, v1alpha1.ImageInventoriesCRName)
+	if err != nil {
+		return err
+	}
 	err = m.deleteCRD(ctx, v1alpha1.ClusterVulnerabilityReportsCRName)
 	if err != nil {
 		return err
@@ -472,6 +516,14 @@ func (m *Installer) Uninstall(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	err = m.deleteCRD(ctx, v1alpha1.ComplianceReportCRName)
+	if err != nil {
+		return err
+	}
+	err = m.deleteCRD(ctx, v1alpha1.ClusterRiskReportsCRName)
+	if err != nil {
+		return err
+	}
 	err = m.cleanupRBAC(ctx)
 	if err != nil {
 		return err
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	operatorNamespaceFlagName = "operator-namespace"
+	defaultOperatorNamespace  = "starboard-system"
+)
+
+func NewPauseCmd(executable string, cf *ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pause",
+		Short: "Pause scanning by Starboard Operator",
+		Long: `Pause scanning by Starboard Operator without deleting anything
+
+Sets the scanJob.suspended key of the starboard ConfigMap in the operator namespace, so that the operator
+suspends running scan jobs and creates new scan jobs suspended until scanning is resumed.
+`,
+		Example: fmt.Sprintf(`  # Pause scanning by the operator installed in the starboard-system namespace
+  %[1]s pause
+
+  # Pause scanning by the operator installed in the specified namespace
+  %[1]s pause --operator-namespace security`, executable),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return setScanJobsSuspended(cmd, cf, out, true)
+		},
+	}
+	cmd.Flags().String(operatorNamespaceFlagName, defaultOperatorNamespace, "The namespace of Starboard Operator")
+	return cmd
+}
+
+func NewResumeCmd(executable string, cf *ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resume",
+		Short: "Resume scanning by Starboard Operator",
+		Long: `Resume scanning by Starboard Operator paused with the pause command
+
+Sets the scanJob.suspended key of the starboard ConfigMap in the operator namespace, so that the operator
+resumes suspended scan jobs within the limit of concurrent scan jobs.
+`,
+		Example: fmt.Sprintf(`  # Resume scanning by the operator installed in the starboard-system namespace
+  %[1]s resume`, executable),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return setScanJobsSuspended(cmd, cf, out, false)
+		},
+	}
+	cmd.Flags().String(operatorNamespaceFlagName, defaultOperatorNamespace, "The namespace of Starboard Operator")
+	return cmd
+}
+
+func setScanJobsSuspended(cmd *cobra.Command, cf *ConfigFlags, out io.Writer, suspended bool) error {
+	ctx := context.Background()
+	namespace, err := cmd.Flags().GetString(operatorNamespaceFlagName)
+	if err != nil {
+		return err
+	}
+	kubeConfig, err := cf.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	kubeClientset, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return err
+	}
+	data := starboard.ConfigData{}
+	data.SetScanJobsSuspended(suspended)
+	patch, err := json.Marshal(map[string]interface{}{
+		"data": data,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = kubeClientset.CoreV1().ConfigMaps(namespace).
+		Patch(ctx, starboard.ConfigMapName, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("patching configmap %s/%s: %w", namespace, starboard.ConfigMapName, err)
+	}
+	if suspended {
+		fmt.Fprintf(out, "Paused scanning by Starboard Operator in %s namespace.\n", namespace)
+	} else {
+		fmt.Fprintf(out, "Resumed scanning by Starboard Operator in %s namespace.\n", namespace)
+	}
+	return nil
+}
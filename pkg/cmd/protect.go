@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	selectorFlagName      = "selector"
+	allFlagName           = "all"
+	allNamespacesFlagName = "all-namespaces"
+)
+
+// protectableKinds are kinds of reports which can be protected from deletion.
+var protectableKinds = map[string]bool{
+	v1alpha1.VulnerabilityReportKind: true,
+	"ClusterVulnerabilityReport":     true,
+	v1alpha1.ConfigAuditReportKind:   true,
+	"ClusterConfigAuditReport":       true,
+	v1alpha1.CISKubeBenchReportKind:  true,
+	v1alpha1.KubeHunterReportKind:    true,
+	"ClusterComplianceReport":        true,
+	"ClusterComplianceDetailReport":  true,
+	"ComplianceReport":               true,
+}
+
+func NewProtectCmd(executable string, cf *ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "protect TYPE [NAME...]",
+		Short: "Protect reports from deletion",
+		Long: `Protect reports from deletion
+
+Sets the starboard.aquasecurity.github.io/protected=true annotation of the specified reports. Deletion of
+protected reports is denied by the validating webhook of Starboard Operator, if enabled, unless they are
+deleted by one of the override users.
+
+TYPE is a kind of reports. Shortcuts will be resolved, e.g. 'vulns' or 'configaudit'.
+`,
+		Example: fmt.Sprintf(`  # Protect all vulnerability reports in the current namespace
+  %[1]s protect vulnerabilityreports --all
+
+  # Protect vulnerability reports of the nginx container in all namespaces
+  %[1]s protect vulns -l starboard.container.name=nginx --all-namespaces
+
+  # Protect the nsa compliance report
+  %[1]s protect clustercompliancereports nsa`, executable),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setProtected(cmd, cf, out, args, true)
+		},
+	}
+	registerProtectFlags(cmd)
+	return cmd
+}
+
+func NewUnprotectCmd(executable string, cf *ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unprotect TYPE [NAME...]",
+		Short: "Remove protection of reports from deletion",
+		Long: `Remove protection of reports from deletion set with the protect command
+
+Removes the starboard.aquasecurity.github.io/protected annotation of the specified reports, so that they
+can be deleted.
+
+TYPE is a kind of reports. Shortcuts will be resolved, e.g. 'vulns' or 'configaudit'.
+`,
+		Example: fmt.Sprintf(`  # Remove protection of all vulnerability reports in the current namespace
+  %[1]s unprotect vulnerabilityreports --all
+
+  # Remove protection of the specified config audit report
+  %[1]s unprotect configauditreports replicaset-nginx-6d4cf56db6`, executable),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setProtected(cmd, cf, out, args, false)
+		},
+	}
+	registerProtectFlags(cmd)
+	return cmd
+}
+
+func registerProtectFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(selectorFlagName, "l", "", "Selector (label query) to filter reports on")
+	cmd.Flags().Bool(allFlagName, false, "Select all reports of the specified type in the namespace")
+	cmd.Flags().BoolP(allNamespacesFlagName, "A", false, "Select reports in all namespaces")
+}
+
+func setProtected(cmd *cobra.Command, cf *ConfigFlags, out io.Writer, args []string, protected bool) error {
+	if len(args) < 1 {
+		return fmt.Errorf("required report type not specified")
+	}
+	rawSelector, err := cmd.Flags().GetString(selectorFlagName)
+	if err != nil {
+		return err
+	}
+	all, err := cmd.Flags().GetBool(allFlagName)
+	if err != nil {
+		return err
+	}
+	allNamespaces, err := cmd.Flags().GetBool(allNamespacesFlagName)
+	if err != nil {
+		return err
+	}
+	names := args[1:]
+	if len(names) == 0 && rawSelector == "" && !all {
+		return fmt.Errorf("no reports specified, specify report names or use the --%s or --%s flags", selectorFlagName, allFlagName)
+	}
+	if len(names) > 0 && (rawSelector != "" || all) {
+		return fmt.Errorf("report names cannot be specified along with the --%s or --%s flags", selectorFlagName, allFlagName)
+	}
+	selector, err := labels.Parse(rawSelector)
+	if err != nil {
+		return fmt.Errorf("parsing selector: %w", err)
+	}
+
+	mapper, err := cf.ToRESTMapper()
+	if err != nil {
+		return err
+	}
+	_, gvk, err := kube.GVRForResource(mapper, args[0])
+	if err != nil {
+		return err
+	}
+	if gvk.Group != v1alpha1.SchemeGroupVersion.Group || !protectableKinds[gvk.Kind] {
+		return fmt.Errorf("%s are not reports which can be protected", args[0])
+	}
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return err
+	}
+	namespace := ""
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace && !allNamespaces {
+		namespace, _, err = cf.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return err
+		}
+	}
+
+	kubeConfig, err := cf.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+	if err != nil {
+		return err
+	}
+	return SetReportsProtected(context.Background(), kubeClient, out, ReportSelector{
+		Kind:      gvk.Kind,
+		Namespace: namespace,
+		Names:     names,
+		Selector:  selector,
+	}, protected)
+}
+
+// ReportSelector selects reports of a single kind.
+type ReportSelector struct {
+	Kind string
+	// Namespace of reports, or blank for cluster-scoped reports or reports
+	// in all namespaces.
+	Namespace string
+	// Names of reports. If blank, all reports matching Selector are selected.
+	Names    []string
+	Selector labels.Selector
+}
+
+// SetReportsProtected sets or removes the starboard.AnnotationProtected
+// annotation of the selected reports, and writes a line for each report.
+func SetReportsProtected(ctx context.Context, c client.Client, out io.Writer, selector ReportSelector, protected bool) error {
+	var reports []unstructured.Unstructured
+	if len(selector.Names) > 0 {
+		for _, name := range selector.Names {
+			report := unstructured.Unstructured{}
+			report.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(selector.Kind))
+			err := c.Get(ctx, types.NamespacedName{Namespace: selector.Namespace, Name: name}, &report)
+			if err != nil {
+				return err
+			}
+			reports = append(reports, report)
+		}
+	} else {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(selector.Kind + "List"))
+		err := c.List(ctx, list, client.InNamespace(selector.Namespace), client.MatchingLabelsSelector{Selector: selector.Selector})
+		if err != nil {
+			return err
+		}
+		reports = list.Items
+	}
+	if len(reports) == 0 {
+		fmt.Fprintf(out, "No %s objects found.\n", selector.Kind)
+		return nil
+	}
+
+	verb := "protected"
+	if !protected {
+		verb = "unprotected"
+	}
+	for i := range reports {
+		report := &reports[i]
+		annotations := report.GetAnnotations()
+		if (annotations[starboard.AnnotationProtected] == "true") == protected {
+			fmt.Fprintf(out, "%s/%s already %s\n", strings.ToLower(selector.Kind), report.GetName(), verb)
+			continue
+		}
+		patch := client.MergeFrom(report.DeepCopy())
+		if protected {
+			if annotations == nil {
+				annotations = make(map[string]string)
+			}
+			annotations[starboard.AnnotationProtected] = "true"
+		} else {
+			delete(annotations, starboard.AnnotationProtected)
+		}
+		report.SetAnnotations(annotations)
+		err := c.Patch(ctx, report, patch)
+		if err != nil {
+			return fmt.Errorf("patching %s %s: %w", selector.Kind, report.GetName(), err)
+		}
+		fmt.Fprintf(out, "%s/%s %s\n", strings.ToLower(selector.Kind), report.GetName(), verb)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/cmd"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newProtectedTestReport(namespace, name, container string, protected bool) *v1alpha1.VulnerabilityReport {
+	report := &v1alpha1.VulnerabilityReport{ObjectMeta: metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels:    map[string]string{starboard.LabelContainerName: container},
+	}}
+	if protected {
+		report.Annotations = map[string]string{starboard.AnnotationProtected: "true"}
+	}
+	return report
+}
+
+func getProtected(t *testing.T, c client.Client, namespace, name string) bool {
+	t.Helper()
+	var report v1alpha1.VulnerabilityReport
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, &report)
+	require.NoError(t, err)
+	_, ok := report.Annotations[starboard.AnnotationProtected]
+	return ok
+}
+
+func TestSetReportsProtected(t *testing.T) {
+	newClient := func() client.Client {
+		return fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			newProtectedTestReport("default", "nginx", "nginx", false),
+			newProtectedTestReport("default", "sidecar", "envoy", false),
+			newProtectedTestReport("default", "redis", "redis", true),
+			newProtectedTestReport("qa", "nginx", "nginx", false),
+		).Build()
+	}
+
+	t.Run("Should protect reports matching selector in namespace", func(t *testing.T) {
+		c := newClient()
+		out := &bytes.Buffer{}
+		err := cmd.SetReportsProtected(context.TODO(), c, out, cmd.ReportSelector{
+			Kind:      v1alpha1.VulnerabilityReportKind,
+			Namespace: "default",
+			Selector:  labels.SelectorFromSet(labels.Set{starboard.LabelContainerName: "nginx"}),
+		}, true)
+		require.NoError(t, err)
+		assert.Equal(t, "vulnerabilityreport/nginx protected\n", out.String())
+		assert.True(t, getProtected(t, c, "default", "nginx"))
+		assert.False(t, getProtected(t, c, "default", "sidecar"))
+		assert.False(t, getProtected(t, c, "qa", "nginx"))
+	})
+
+	t.Run("Should protect all reports in all namespaces", func(t *testing.T) {
+		c := newClient()
+		out := &bytes.Buffer{}
+		err := cmd.SetReportsProtected(context.TODO(), c, out, cmd.ReportSelector{
+			Kind:     v1alpha1.VulnerabilityReportKind,
+			Selector: labels.Everything(),
+		}, true)
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "vulnerabilityreport/redis already protected\n")
+		assert.True(t, getProtected(t, c, "default", "nginx"))
+		assert.True(t, getProtected(t, c, "default", "sidecar"))
+		assert.True(t, getProtected(t, c, "qa", "nginx"))
+	})
+
+	t.Run("Should unprotect reports with the specified names", func(t *testing.T) {
+		c := newClient()
+		out := &bytes.Buffer{}
+		err := cmd.SetReportsProtected(context.TODO(), c, out, cmd.ReportSelector{
+			Kind:      v1alpha1.VulnerabilityReportKind,
+			Namespace: "default",
+			Names:     []string{"redis", "nginx"},
+		}, false)
+		require.NoError(t, err)
+		assert.Equal(t, "vulnerabilityreport/redis unprotected\nvulnerabilityreport/nginx already unprotected\n", out.String())
+		assert.False(t, getProtected(t, c, "default", "redis"))
+	})
+}
@@ -9,15 +9,15 @@ import (
 	"github.com/aquasecurity/starboard/pkg/report"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
-	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func NewReportCmd(info starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
-	return &cobra.Command{
-		Use:   "report (NAME | TYPE/NAME)",
-		Short: "Generate an HTML security report for a specified Kubernetes object",
-		Long: fmt.Sprintf(`Generate an HTML security report for a specified Kubernetes object.
+func NewReportCmd(info starboard.BuildInfo, cf *ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report [NAME | TYPE/NAME]",
+		Short: "Generate an HTML security report for a specified Kubernetes object or the cluster",
+		Long: fmt.Sprintf(`Generate an HTML security report for a specified Kubernetes object or the cluster.
 
 If the specified object is a Kubernetes workload, for example Pod or Deployment,
 the report will contain vulnerabilities found in its container images as well as
@@ -36,6 +36,10 @@ pitfalls. You can run "%[1]s scan vulnerabilityreports -h" and
 If the specified object is a Kubernetes node, the report will contain configuration
 checks based on CIS Kubernetes Benchmark guides.
 
+If no object is specified, the report will contain the risk score of the cluster,
+as stored in the ClusterRiskReport resource, and the summary of cluster compliance
+reports.
+
 TYPE is a Kubernetes workload. Shortcuts and API groups will be resolved, e.g. 'po' or 'deployments.apps'.
 NAME is the name of a particular Kubernetes workload.
 `, info.Executable),
@@ -47,6 +51,9 @@ NAME is the name of a particular Kubernetes workload.
 
   # Generate an HTML report for a node with the specified name and save it to a file.
   %[1]s report node/kind-control-plane > kind-control-plane.node.html
+
+  # Generate an HTML report for the cluster and save it to a file.
+  %[1]s report > cluster.html
 `, info.Executable),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			kubeConfig, err := cf.ToRESTConfig()
@@ -54,6 +61,22 @@ NAME is the name of a particular Kubernetes workload.
 				return err
 			}
 			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+			if err != nil {
+				return err
+			}
+			kubeClientset, err := kubernetes.NewForConfig(kubeConfig)
+			if err != nil {
+				return err
+			}
+			clusterMetadata, err := newClusterMetadataReader(cmd, cf, kubeClientset)
+			if err != nil {
+				return err
+			}
+			clock := ext.NewSystemClock()
+			if len(args) == 0 {
+				reporter := report.NewClusterReporter(clock, kubeClient, clusterMetadata)
+				return reporter.Generate(out)
+			}
 			ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
 			if err != nil {
 				return err
@@ -66,7 +89,6 @@ NAME is the name of a particular Kubernetes workload.
 			if err != nil {
 				return err
 			}
-			clock := ext.NewSystemClock()
 			switch workload.Kind {
 			case kube.KindDeployment,
 				kube.KindReplicaSet,
@@ -76,17 +98,19 @@ NAME is the name of a particular Kubernetes workload.
 				kube.KindCronJob,
 				kube.KindJob,
 				kube.KindPod:
-				reporter := report.NewWorkloadReporter(clock, kubeClient)
+				reporter := report.NewWorkloadReporter(clock, kubeClient, clusterMetadata)
 				return reporter.Generate(workload, out)
 			case kube.KindNamespace:
-				reporter := report.NewNamespaceReporter(clock, kubeClient)
+				reporter := report.NewNamespaceReporter(clock, kubeClient, clusterMetadata)
 				return reporter.Generate(workload, out)
 			case kube.KindNode:
-				reporter := report.NewNodeReporter(clock, kubeClient)
+				reporter := report.NewNodeReporter(clock, kubeClient, clusterMetadata)
 				return reporter.Generate(workload, out)
 			default:
 				return fmt.Errorf("HTML report is not supported for %q", workload.Kind)
 			}
 		},
 	}
+	registerClusterNameFlag(cmd)
+	return cmd
 }
@@ -8,7 +8,6 @@ import (
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
-	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
 const (
@@ -39,7 +38,7 @@ $ %[1]s get vulnerabilityreports deployment/nginx -o yaml
 )
 
 func NewRootCmd(buildInfo starboard.BuildInfo, args []string, outWriter io.Writer, errWriter io.Writer) *cobra.Command {
-	var cf *genericclioptions.ConfigFlags
+	var cf *ConfigFlags
 
 	rootCmd := &cobra.Command{
 		Use:           "starboard",
@@ -49,15 +48,21 @@ func NewRootCmd(buildInfo starboard.BuildInfo, args []string, outWriter io.Write
 		SilenceUsage:  true,
 	}
 
-	cf = genericclioptions.NewConfigFlags(true)
+	cf = NewConfigFlags()
 
 	rootCmd.AddCommand(NewVersionCmd(buildInfo, outWriter))
 	rootCmd.AddCommand(NewInitCmd(buildInfo, cf))
 	rootCmd.AddCommand(NewScanCmd(buildInfo, cf))
 	rootCmd.AddCommand(NewGetCmd(buildInfo, cf, outWriter))
 	rootCmd.AddCommand(NewReportCmd(buildInfo, cf, outWriter))
-	rootCmd.AddCommand(NewCleanupCmd(buildInfo, cf))
+	rootCmd.AddCommand(NewComplianceCmd(buildInfo, cf, outWriter))
+	rootCmd.AddCommand(NewCleanupCmd(buildInfo, cf, outWriter))
 	rootCmd.AddCommand(NewConfigCmd(cf, outWriter))
+	rootCmd.AddCommand(NewPauseCmd(buildInfo.Executable, cf, outWriter))
+	rootCmd.AddCommand(NewResumeCmd(buildInfo.Executable, cf, outWriter))
+	rootCmd.AddCommand(NewProtectCmd(buildInfo.Executable, cf, outWriter))
+	rootCmd.AddCommand(NewUnprotectCmd(buildInfo.Executable, cf, outWriter))
+	rootCmd.AddCommand(NewCheckCmd(buildInfo.Executable, cf, outWriter))
 
 	SetGlobalFlags(cf, rootCmd)
 
@@ -3,10 +3,9 @@ package cmd
 import (
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
-	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
-func NewScanCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) *cobra.Command {
+func NewScanCmd(buildInfo starboard.BuildInfo, cf *ConfigFlags) *cobra.Command {
 	scanCmd := &cobra.Command{
 		Use:     "scan",
 		Aliases: []string{"generate"},
@@ -11,7 +11,6 @@ import (
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,7 +20,7 @@ const (
 	kubeBenchCmdShort = "Run the CIS Kubernetes Benchmark for each node of your cluster"
 )
 
-func NewScanKubeBenchReportsCmd(cf *genericclioptions.ConfigFlags) *cobra.Command {
+func NewScanKubeBenchReportsCmd(cf *ConfigFlags) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "ciskubebenchreports",
 		Short: kubeBenchCmdShort,
@@ -33,7 +32,7 @@ func NewScanKubeBenchReportsCmd(cf *genericclioptions.ConfigFlags) *cobra.Comman
 	return cmd
 }
 
-func ScanKubeBenchReports(cf *genericclioptions.ConfigFlags) func(cmd *cobra.Command, args []string) error {
+func ScanKubeBenchReports(cf *ConfigFlags) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
 		kubeConfig, err := cf.ToRESTConfig()
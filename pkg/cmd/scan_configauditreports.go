@@ -4,9 +4,10 @@ import (
 	"context"
 
 	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
-	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -14,7 +15,7 @@ const (
 	configAuditCmdShort = "Run a variety of checks to ensure that a given workload is configured using best practices"
 )
 
-func NewScanConfigAuditReportsCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) *cobra.Command {
+func NewScanConfigAuditReportsCmd(buildInfo starboard.BuildInfo, cf *ConfigFlags) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "configauditreports",
 		Short: configAuditCmdShort,
@@ -23,11 +24,12 @@ func NewScanConfigAuditReportsCmd(buildInfo starboard.BuildInfo, cf *genericclio
 	}
 
 	registerScannerOpts(cmd)
+	registerDryRunFlag(cmd)
 
 	return cmd
 }
 
-func ScanConfigAuditReports(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) func(cmd *cobra.Command, args []string) error {
+func ScanConfigAuditReports(buildInfo starboard.BuildInfo, cf *ConfigFlags) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
 		ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
@@ -48,12 +50,51 @@ func ScanConfigAuditReports(buildInfo starboard.BuildInfo, cf *genericclioptions
 		}
 		scheme := starboard.NewScheme()
 		kubeClient, err := client.New(kubeConfig, client.Options{Scheme: scheme})
+		if err != nil {
+			return err
+		}
+		kubeClientset, err := kubernetes.NewForConfig(kubeConfig)
+		if err != nil {
+			return err
+		}
+		config, err := starboard.NewConfigManager(kubeClientset, starboard.NamespaceName).Read(ctx)
+		if err != nil {
+			return err
+		}
+		maxMessageLength, err := config.GetConfigAuditMaxMessageLength()
+		if err != nil {
+			return err
+		}
+		dryRun, err := getDryRun(cmd)
+		if err != nil {
+			return err
+		}
 		scanner := configauditreport.NewScanner(buildInfo, kubeClient)
 		reportBuilder, err := scanner.Scan(ctx, workload)
 		if err != nil {
 			return err
 		}
+		reportBuilder.MaxMessageLength(maxMessageLength).
+			StoreFullMessages(config.ConfigAuditStoreFullMessages(), starboard.NamespaceName)
 		writer := configauditreport.NewReadWriter(kubeClient)
-		return reportBuilder.Write(ctx, writer)
+		if !dryRun {
+			return reportBuilder.Write(ctx, writer)
+		}
+		err = reportBuilder.Write(ctx, writer, kube.DryRun())
+		if err != nil {
+			return err
+		}
+		if kube.IsClusterScopedKind(string(workload.Kind)) {
+			report, err := reportBuilder.GetClusterReport()
+			if err != nil {
+				return err
+			}
+			return printDryRunObjects(cmd.OutOrStdout(), scheme, &report)
+		}
+		report, err := reportBuilder.GetReport()
+		if err != nil {
+			return err
+		}
+		return printDryRunObjects(cmd.OutOrStdout(), scheme, &report)
 	}
 }
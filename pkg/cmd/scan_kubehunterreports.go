@@ -7,7 +7,6 @@ import (
 	"github.com/aquasecurity/starboard/pkg/kubehunter"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
-	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -15,7 +14,7 @@ const (
 	kubeHunterCmdShort = "Hunt for security weaknesses in your Kubernetes cluster"
 )
 
-func NewScanKubeHunterReportsCmd(cf *genericclioptions.ConfigFlags) *cobra.Command {
+func NewScanKubeHunterReportsCmd(cf *ConfigFlags) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "kubehunterreports",
 		Short: kubeHunterCmdShort,
@@ -23,6 +22,7 @@ func NewScanKubeHunterReportsCmd(cf *genericclioptions.ConfigFlags) *cobra.Comma
 	}
 
 	registerScannerOpts(cmd)
+	registerClusterNameFlag(cmd)
 
 	return cmd
 }
@@ -31,7 +31,7 @@ const (
 	kubeHunterReportName = "cluster"
 )
 
-func ScanKubeHunterReports(cf *genericclioptions.ConfigFlags) func(cmd *cobra.Command, args []string) (err error) {
+func ScanKubeHunterReports(cf *ConfigFlags) func(cmd *cobra.Command, args []string) (err error) {
 	return func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
 		kubeConfig, err := cf.ToRESTConfig()
@@ -55,6 +55,15 @@ func ScanKubeHunterReports(cf *genericclioptions.ConfigFlags) func(cmd *cobra.Co
 		if err != nil {
 			return err
 		}
+		clusterMetadata, err := newClusterMetadataReader(cmd, cf, kubeClientset)
+		if err != nil {
+			return err
+		}
+		metadata, err := clusterMetadata.Read(ctx)
+		if err != nil {
+			return err
+		}
+		report.Cluster = &metadata
 		starboardClientset, err := versioned.NewForConfig(kubeConfig)
 		if err != nil {
 			return err
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/plugin"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"github.com/spf13/cobra"
-	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -22,7 +24,7 @@ NAME is the name of a particular Kubernetes workload.
 `
 )
 
-func NewScanVulnerabilityReportsCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) *cobra.Command {
+func NewScanVulnerabilityReportsCmd(buildInfo starboard.BuildInfo, cf *ConfigFlags) *cobra.Command {
 	cmd := &cobra.Command{
 		Aliases: []string{"vulns", "vuln"},
 		Use:     "vulnerabilityreports (NAME | TYPE/NAME)",
@@ -53,16 +55,28 @@ func NewScanVulnerabilityReportsCmd(buildInfo starboard.BuildInfo, cf *genericcl
   %[1]s scan vulnerabilityreports job/my-job
 
   # Scan a cronjob with the specified name and the specified scan job timeout
-  %[1]s scan vulnerabilityreports cj/my-cronjob --scan-job-timeout 2m`, buildInfo.Executable),
+  %[1]s scan vulnerabilityreports cj/my-cronjob --scan-job-timeout 2m
+
+  # Validate reports of a deployment with the API server and print them without persisting
+  %[1]s scan vulnerabilityreports deployments.apps/nginx --dry-run=server
+
+  # Scan a deployment with the specified name with Trivy executed locally instead of a scan job
+  %[1]s scan vulnerabilityreports deployments.apps/nginx --scan-mode direct
+
+  # Scan a deployment with the specified name and print the summary without colors
+  %[1]s scan vulnerabilityreports deployments.apps/nginx --no-color`, buildInfo.Executable),
 		RunE: ScanVulnerabilityReports(buildInfo, cf),
 	}
 
 	registerScannerOpts(cmd)
+	registerScanModeFlags(cmd)
+	registerDryRunFlag(cmd)
+	registerNoColorFlag(cmd)
 
 	return cmd
 }
 
-func ScanVulnerabilityReports(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) func(cmd *cobra.Command, args []string) error {
+func ScanVulnerabilityReports(buildInfo starboard.BuildInfo, cf *ConfigFlags) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
 		ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
@@ -98,6 +112,13 @@ func ScanVulnerabilityReports(buildInfo starboard.BuildInfo, cf *genericclioptio
 		if err != nil {
 			return err
 		}
+		dryRun, err := getDryRun(cmd)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			opts.DeleteScanJob = true
+		}
 		plugin, pluginContext, err := plugin.NewResolver().
 			WithBuildInfo(buildInfo).
 			WithNamespace(starboard.NamespaceName).
@@ -108,12 +129,52 @@ func ScanVulnerabilityReports(buildInfo starboard.BuildInfo, cf *genericclioptio
 		if err != nil {
 			return err
 		}
-		scanner := vulnerabilityreport.NewScanner(kubeClientset, kubeClient, plugin, pluginContext, config, opts)
-		reports, err := scanner.Scan(ctx, workload)
+		directOpts, direct, err := getDirectScanOpts(cmd)
 		if err != nil {
 			return err
 		}
+		var reports []v1alpha1.VulnerabilityReport
+		if direct {
+			scanner, err := vulnerabilityreport.NewDirectScanner(kubeClient, plugin, pluginContext, directOpts)
+			if err != nil {
+				return err
+			}
+			reports, err = scanner.Scan(ctx, workload)
+			if err != nil {
+				return err
+			}
+		} else {
+			scanner := vulnerabilityreport.NewScanner(kubeClientset, kubeClient, plugin, pluginContext, config, opts)
+			reports, err = scanner.Scan(ctx, workload)
+			if err != nil {
+				return err
+			}
+		}
 		writer := vulnerabilityreport.NewReadWriter(kubeClient)
-		return writer.Write(ctx, reports)
+		if !dryRun {
+			err = writer.Write(ctx, reports)
+		} else {
+			err = writer.Write(ctx, reports, kube.DryRun())
+		}
+		if err != nil {
+			return err
+		}
+		// The summary is written to stderr so that stdout remains machine-readable.
+		color, err := useColor(cmd, cmd.ErrOrStderr())
+		if err != nil {
+			return err
+		}
+		err = WriteVulnerabilitySummary(cmd.ErrOrStderr(), reports, color)
+		if err != nil {
+			return err
+		}
+		if !dryRun {
+			return nil
+		}
+		objects := make([]runtime.Object, 0, len(reports))
+		for i := range reports {
+			objects = append(objects, &reports[i])
+		}
+		return printDryRunObjects(cmd.OutOrStdout(), scheme, objects...)
 	}
 }
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	reportsummary "github.com/aquasecurity/starboard/pkg/summary"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	noColorFlagName = "no-color"
+
+	// maxTopVulnerabilities is the maximum number of vulnerabilities listed
+	// for each container in the vulnerability summary.
+	maxTopVulnerabilities = 3
+
+	ansiReset = "\x1b[0m"
+)
+
+// severityColors are ANSI escape codes used to render text of each severity.
+var severityColors = map[v1alpha1.Severity]string{
+	v1alpha1.SeverityCritical: "\x1b[1;31m",
+	v1alpha1.SeverityHigh:     "\x1b[31m",
+	v1alpha1.SeverityMedium:   "\x1b[33m",
+	v1alpha1.SeverityLow:      "\x1b[34m",
+}
+
+// severityRanks orders severities from the most to the least severe.
+var severityRanks = map[v1alpha1.Severity]int{
+	v1alpha1.SeverityCritical: 0,
+	v1alpha1.SeverityHigh:     1,
+	v1alpha1.SeverityMedium:   2,
+	v1alpha1.SeverityLow:      3,
+	v1alpha1.SeverityNone:     4,
+	v1alpha1.SeverityUnknown:  5,
+}
+
+func registerNoColorFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(noColorFlagName, false,
+		"If true, print the summary without colors. Colors are also disabled when the output is not a terminal or NO_COLOR is set")
+}
+
+// useColor returns true if text written to the given writer should be
+// colored, i.e. the writer is a terminal and colors are not disabled with
+// the no-color flag or the NO_COLOR environment variable.
+func useColor(cmd *cobra.Command, out io.Writer) (bool, error) {
+	noColor, err := cmd.Flags().GetBool(noColorFlagName)
+	if err != nil {
+		return false, err
+	}
+	if noColor || os.Getenv("NO_COLOR") != "" {
+		return false, nil
+	}
+	file, ok := out.(*os.File)
+	return ok && term.IsTerminal(int(file.Fd())), nil
+}
+
+// cell is a table cell whose text is colored by severity, if set.
+type cell struct {
+	text     string
+	severity v1alpha1.Severity
+}
+
+// writeTable writes rows aligned in columns. Column widths are computed from
+// plain text so that color escape codes do not break the alignment.
+func writeTable(out io.Writer, color bool, indent string, header []string, rows [][]cell) error {
+	widths := make([]int, len(header))
+	for i, title := range header {
+		widths[i] = utf8.RuneCountInString(title)
+	}
+	for _, row := range rows {
+		for i, c := range row {
+			if w := utf8.RuneCountInString(c.text); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	writeRow := func(row []cell) error {
+		var b strings.Builder
+		b.WriteString(indent)
+		for i, c := range row {
+			if code, ok := severityColors[c.severity]; ok && color {
+				b.WriteString(code + c.text + ansiReset)
+			} else {
+				b.WriteString(c.text)
+			}
+			if i < len(row)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(c.text)+3))
+			}
+		}
+		b.WriteString("\n")
+		_, err := io.WriteString(out, b.String())
+		return err
+	}
+	headerRow := make([]cell, len(header))
+	for i, title := range header {
+		headerRow[i] = cell{text: title}
+	}
+	if err := writeRow(headerRow); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := writeRow(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countCell returns a cell with the given count, which is colored by the
+// given severity unless the count is zero.
+func countCell(count int, severity v1alpha1.Severity) cell {
+	if count == 0 {
+		return cell{text: "0"}
+	}
+	return cell{text: fmt.Sprintf("%d", count), severity: severity}
+}
+
+// WriteVulnerabilitySummary writes a human readable summary of the given
+// vulnerability reports, i.e. the number of vulnerabilities by severity and
+// the most severe vulnerabilities with fixed versions of each container. The
+// total of all containers is written if there are several ones.
+func WriteVulnerabilitySummary(out io.Writer, reports []v1alpha1.VulnerabilityReport, color bool) error {
+	reports = append([]v1alpha1.VulnerabilityReport(nil), reports...)
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].Labels[starboard.LabelContainerName] < reports[j].Labels[starboard.LabelContainerName]
+	})
+
+	var summaryRows, topRows [][]cell
+	var summaries []v1alpha1.VulnerabilitySummary
+	for _, report := range reports {
+		container := report.Labels[starboard.LabelContainerName]
+		summary := report.Report.Summary
+		summaries = append(summaries, summary)
+		summaryRows = append(summaryRows, []cell{
+			{text: container},
+			{text: imageRef(report.Report)},
+			countCell(summary.CriticalCount, v1alpha1.SeverityCritical),
+			countCell(summary.HighCount, v1alpha1.SeverityHigh),
+			countCell(summary.MediumCount, v1alpha1.SeverityMedium),
+			countCell(summary.LowCount, v1alpha1.SeverityLow),
+		})
+		for _, vulnerability := range topVulnerabilities(report.Report.Vulnerabilities, maxTopVulnerabilities) {
+			fixedVersion := vulnerability.FixedVersion
+			if fixedVersion == "" {
+				fixedVersion = "<none>"
+			}
+			topRows = append(topRows, []cell{
+				{text: container},
+				{text: vulnerability.VulnerabilityID},
+				{text: string(vulnerability.Severity), severity: vulnerability.Severity},
+				{text: vulnerability.Resource},
+				{text: vulnerability.InstalledVersion},
+				{text: fixedVersion},
+			})
+		}
+	}
+
+	if len(reports) > 1 {
+		total := reportsummary.MergeVulnerabilities(summaries...)
+		summaryRows = append(summaryRows, []cell{
+			{text: "TOTAL"},
+			{text: ""},
+			countCell(total.CriticalCount, v1alpha1.SeverityCritical),
+			countCell(total.HighCount, v1alpha1.SeverityHigh),
+			countCell(total.MediumCount, v1alpha1.SeverityMedium),
+			countCell(total.LowCount, v1alpha1.SeverityLow),
+		})
+	}
+
+	err := writeTable(out, color, "", []string{"CONTAINER", "IMAGE", "CRITICAL", "HIGH", "MEDIUM", "LOW"}, summaryRows)
+	if err != nil {
+		return err
+	}
+	if len(topRows) == 0 {
+		return nil
+	}
+	if _, err = fmt.Fprintf(out, "\nTop vulnerabilities:\n"); err != nil {
+		return err
+	}
+	return writeTable(out, color, "  ", []string{"CONTAINER", "VULNERABILITY ID", "SEVERITY", "RESOURCE", "INSTALLED VERSION", "FIXED VERSION"}, topRows)
+}
+
+// topVulnerabilities returns at most max vulnerabilities ordered by severity,
+// then by score, and then by vulnerability ID.
+func topVulnerabilities(vulnerabilities []v1alpha1.Vulnerability, max int) []v1alpha1.Vulnerability {
+	sorted := append([]v1alpha1.Vulnerability(nil), vulnerabilities...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ri, rj := severityRank(sorted[i].Severity), severityRank(sorted[j].Severity)
+		if ri != rj {
+			return ri < rj
+		}
+		si, sj := score(sorted[i]), score(sorted[j])
+		if si != sj {
+			return si > sj
+		}
+		return sorted[i].VulnerabilityID < sorted[j].VulnerabilityID
+	})
+	if len(sorted) > max {
+		sorted = sorted[:max]
+	}
+	return sorted
+}
+
+func severityRank(severity v1alpha1.Severity) int {
+	if rank, ok := severityRanks[severity]; ok {
+		return rank
+	}
+	return len(severityRanks)
+}
+
+func score(vulnerability v1alpha1.Vulnerability) float64 {
+	if vulnerability.Score == nil {
+		return 0
+	}
+	return *vulnerability.Score
+}
+
+func imageRef(report v1alpha1.VulnerabilityReportData) string {
+	ref := report.Artifact.Repository
+	if report.Registry.Server != "" {
+		ref = report.Registry.Server + "/" + ref
+	}
+	if report.Artifact.Tag != "" {
+		ref += ":" + report.Artifact.Tag
+	} else if report.Artifact.Digest != "" {
+		ref += "@" + report.Artifact.Digest
+	}
+	return ref
+}
+
+// WriteComplianceSummary writes a human readable summary of the given
+// compliance report, i.e. the number of passing and failing controls by
+// severity.
+func WriteComplianceSummary(out io.Writer, report v1alpha1.ClusterComplianceReport, color bool) error {
+	var rows [][]cell
+	for _, severity := range []v1alpha1.Severity{
+		v1alpha1.SeverityCritical,
+		v1alpha1.SeverityHigh,
+		v1alpha1.SeverityMedium,
+		v1alpha1.SeverityLow,
+	} {
+		count, ok := report.Status.Summary.SummaryBySeverity[string(severity)]
+		if !ok {
+			continue
+		}
+		rows = append(rows, []cell{
+			{text: string(severity), severity: severity},
+			{text: fmt.Sprintf("%d", count.Pass)},
+			countCell(count.Fail, severity),
+		})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return writeTable(out, color, "", []string{"SEVERITY", "PASS", "FAIL"}, rows)
+}
@@ -0,0 +1,94 @@
+package cmd_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/cmd"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestWriteVulnerabilitySummary(t *testing.T) {
+	reports := []v1alpha1.VulnerabilityReport{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{starboard.LabelContainerName: "sidecar"},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Artifact: v1alpha1.Artifact{Repository: "busybox", Tag: "1.35"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{starboard.LabelContainerName: "nginx"},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Registry: v1alpha1.Registry{Server: "index.docker.io"},
+				Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+				Summary: v1alpha1.VulnerabilitySummary{
+					CriticalCount: 1,
+					HighCount:     2,
+					LowCount:      1,
+				},
+				Vulnerabilities: []v1alpha1.Vulnerability{
+					{VulnerabilityID: "CVE-2021-0004", Severity: v1alpha1.SeverityLow, Resource: "tar", InstalledVersion: "1.30"},
+					{VulnerabilityID: "CVE-2021-0003", Severity: v1alpha1.SeverityHigh, Resource: "curl", InstalledVersion: "7.64.0", Score: pointer.Float64(7.5)},
+					{VulnerabilityID: "CVE-2021-0002", Severity: v1alpha1.SeverityHigh, Resource: "libxml2", InstalledVersion: "2.9.4", FixedVersion: "2.9.4+dfsg1-7", Score: pointer.Float64(8.1)},
+					{VulnerabilityID: "CVE-2021-0001", Severity: v1alpha1.SeverityCritical, Resource: "openssl", InstalledVersion: "1.1.1d", FixedVersion: "1.1.1k"},
+				},
+			},
+		},
+	}
+
+	t.Run("Should write summary without colors", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		err := cmd.WriteVulnerabilitySummary(out, reports, false)
+		require.NoError(t, err)
+		assert.Equal(t, `CONTAINER   IMAGE                                CRITICAL   HIGH   MEDIUM   LOW
+nginx       index.docker.io/library/nginx:1.16   1          2      0        1
+sidecar     busybox:1.35                         0          0      0        0
+TOTAL                                            1          2      0        1
+
+Top vulnerabilities:
+  CONTAINER   VULNERABILITY ID   SEVERITY   RESOURCE   INSTALLED VERSION   FIXED VERSION
+  nginx       CVE-2021-0001      CRITICAL   openssl    1.1.1d              1.1.1k
+  nginx       CVE-2021-0002      HIGH       libxml2    2.9.4               2.9.4+dfsg1-7
+  nginx       CVE-2021-0003      HIGH       curl       7.64.0              <none>
+`, out.String())
+	})
+
+	t.Run("Should color counts and severities by severity", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		err := cmd.WriteVulnerabilitySummary(out, reports[1:], true)
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "\x1b[1;31m1\x1b[0m          \x1b[31m2\x1b[0m      0        \x1b[34m1\x1b[0m\n")
+		assert.Contains(t, out.String(), "\x1b[1;31mCRITICAL\x1b[0m   openssl")
+	})
+}
+
+func TestWriteComplianceSummary(t *testing.T) {
+	report := v1alpha1.ClusterComplianceReport{
+		Status: v1alpha1.ReportStatus{
+			Summary: v1alpha1.ClusterComplianceSummary{
+				PassCount: 5,
+				FailCount: 3,
+				SummaryBySeverity: map[string]v1alpha1.ControlCount{
+					"HIGH":   {Pass: 2, Fail: 3},
+					"MEDIUM": {Pass: 3, Fail: 0},
+				},
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := cmd.WriteComplianceSummary(out, report, false)
+	require.NoError(t, err)
+	assert.Equal(t, `SEVERITY   PASS   FAIL
+HIGH       2      3
+MEDIUM     3      0
+`, out.String())
+}
@@ -0,0 +1,58 @@
+package compliance
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/hashicorp/go-version"
+)
+
+// controlApplicability evaluates the applicability conditions of a control
+// against the specified cluster. It returns an empty string if the control
+// applies to the cluster, otherwise the reason why it does not apply.
+// Controls always apply if the cluster metadata is unknown, so that failures
+// are never hidden.
+func controlApplicability(applicability *v1alpha1.Applicability, cluster *v1alpha1.ClusterMetadata) (string, error) {
+	if applicability == nil || cluster == nil {
+		return "", nil
+	}
+	if len(applicability.Providers) > 0 && !containsString(applicability.Providers, cluster.Provider) {
+		return fmt.Sprintf("Control applies to %s clusters only, cluster provider is %s",
+			strings.Join(applicability.Providers, ", "), cluster.Provider), nil
+	}
+	if containsString(applicability.ExcludedProviders, cluster.Provider) {
+		return fmt.Sprintf("Control does not apply to %s clusters", cluster.Provider), nil
+	}
+	if applicability.KubernetesVersion != "" {
+		constraints, err := version.NewConstraint(applicability.KubernetesVersion)
+		if err != nil {
+			return "", fmt.Errorf("parsing kubernetes version constraint %q: %w", applicability.KubernetesVersion, err)
+		}
+		clusterVersion, err := version.NewVersion(cluster.KubernetesVersion)
+		if err != nil {
+			return "", fmt.Errorf("parsing kubernetes version %q: %w", cluster.KubernetesVersion, err)
+		}
+		// versions of managed clusters have pre-release suffixes, e.g. v1.22.9-eks-a64ea69,
+		// which never satisfy constraints without pre-release suffixes
+		if !constraints.Check(clusterVersion.Core()) {
+			return fmt.Sprintf("Control applies to Kubernetes versions %s only, cluster version is %s",
+				applicability.KubernetesVersion, cluster.KubernetesVersion), nil
+		}
+	}
+	for _, group := range applicability.APIGroups {
+		if !containsString(cluster.APIGroups, group) {
+			return fmt.Sprintf("Control applies to clusters serving the %s API group only", group), nil
+		}
+	}
+	return "", nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package compliance
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControlApplicability(t *testing.T) {
+	managedControlPlane := &v1alpha1.Applicability{
+		ExcludedProviders: []string{kube.ProviderEKS, kube.ProviderGKE, kube.ProviderAKS},
+	}
+	testCases := []struct {
+		name          string
+		applicability *v1alpha1.Applicability
+		cluster       *v1alpha1.ClusterMetadata
+		reason        string
+	}{
+		{
+			name:          "Should apply control without conditions",
+			applicability: nil,
+			cluster:       &v1alpha1.ClusterMetadata{Provider: kube.ProviderEKS},
+		},
+		{
+			name:          "Should apply control when cluster is unknown",
+			applicability: managedControlPlane,
+			cluster:       nil,
+		},
+		{
+			name:          "Should not apply control to eks clusters",
+			applicability: managedControlPlane,
+			cluster:       &v1alpha1.ClusterMetadata{Provider: kube.ProviderEKS},
+			reason:        "Control does not apply to eks clusters",
+		},
+		{
+			name:          "Should not apply control to gke clusters",
+			applicability: managedControlPlane,
+			cluster:       &v1alpha1.ClusterMetadata{Provider: kube.ProviderGKE},
+			reason:        "Control does not apply to gke clusters",
+		},
+		{
+			name:          "Should not apply control to aks clusters",
+			applicability: managedControlPlane,
+			cluster:       &v1alpha1.ClusterMetadata{Provider: kube.ProviderAKS},
+			reason:        "Control does not apply to aks clusters",
+		},
+		{
+			name:          "Should apply control to bare metal clusters",
+			applicability: managedControlPlane,
+			cluster:       &v1alpha1.ClusterMetadata{Provider: kube.ProviderBareMetal},
+		},
+		{
+			name:          "Should not apply control to providers which are not listed",
+			applicability: &v1alpha1.Applicability{Providers: []string{kube.ProviderBareMetal}},
+			cluster:       &v1alpha1.ClusterMetadata{Provider: kube.ProviderGKE},
+			reason:        "Control applies to baremetal clusters only, cluster provider is gke",
+		},
+		{
+			name:          "Should apply control to Kubernetes version in range",
+			applicability: &v1alpha1.Applicability{KubernetesVersion: ">= 1.21, < 1.25"},
+			cluster:       &v1alpha1.ClusterMetadata{KubernetesVersion: "v1.22.9-eks-a64ea69"},
+		},
+		{
+			name:          "Should not apply control to Kubernetes version out of range",
+			applicability: &v1alpha1.Applicability{KubernetesVersion: "< 1.25"},
+			cluster:       &v1alpha1.ClusterMetadata{KubernetesVersion: "v1.25.0"},
+			reason:        "Control applies to Kubernetes versions < 1.25 only, cluster version is v1.25.0",
+		},
+		{
+			name:          "Should apply control when API group is served",
+			applicability: &v1alpha1.Applicability{APIGroups: []string{"policy"}},
+			cluster:       &v1alpha1.ClusterMetadata{APIGroups: []string{"apps", "policy"}},
+		},
+		{
+			name:          "Should not apply control when API group is not served",
+			applicability: &v1alpha1.Applicability{APIGroups: []string{"policy"}},
+			cluster:       &v1alpha1.ClusterMetadata{APIGroups: []string{"apps"}},
+			reason:        "Control applies to clusters serving the policy API group only",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reason, err := controlApplicability(tc.applicability, tc.cluster)
+			require.NoError(t, err)
+			assert.Equal(t, tc.reason, reason)
+		})
+	}
+
+	t.Run("Should return error when version constraint is invalid", func(t *testing.T) {
+		_, err := controlApplicability(&v1alpha1.Applicability{KubernetesVersion: "not a constraint"},
+			&v1alpha1.ClusterMetadata{KubernetesVersion: "v1.23.4"})
+		assert.Error(t, err)
+	})
+}
@@ -9,14 +9,27 @@ import (
 	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/utils"
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+var generationDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "starboard_compliance_report_generation_duration_seconds",
+	Help:    "Time taken to generate a cluster compliance report.",
+	Buckets: prometheus.ExponentialBuckets(0.1, 2, 12),
+}, []string{"spec"})
+
+func init() {
+	metrics.Registry.MustRegister(generationDurationSeconds)
+}
+
 type ClusterComplianceReportReconciler struct {
 	logr.Logger
 	client.Client
@@ -53,15 +66,35 @@ func (r *ClusterComplianceReportReconciler) generateComplianceReport(ctx context
 			}
 			return fmt.Errorf("getting report from cache: %w", err)
 		}
-		durationToNextGeneration, err := utils.NextCronDuration(report.Spec.Cron, r.reportLastUpdatedTime(&report), r.Clock)
+		if _, requested := report.Annotations[v1alpha1.ComplianceReportGenerateAnnotation]; requested {
+			// Consume the request before generating the report. The update fails with
+			// a conflict if the request was changed concurrently, in which case it's
+			// retried with the latest version, so each request is handled exactly once.
+			delete(report.Annotations, v1alpha1.ComplianceReportGenerateAnnotation)
+			err = r.Client.Update(ctx, &report)
+			if err != nil {
+				return err
+			}
+			log.V(1).Info("Generating report on demand")
+			return r.generate(ctx, log, &report)
+		}
+		lastUpdated := r.reportLastUpdatedTime(&report)
+		nextGeneration, err := nextGenerationTime(report.Name, report.Spec, lastUpdated)
 		if err != nil {
-			return fmt.Errorf("failed to check report cron expression %w", err)
+			return r.invalidCron(ctx, namespaceName, report.Spec.Cron, err)
 		}
-		if utils.DurationExceeded(durationToNextGeneration) {
-			err = r.Mgr.GenerateComplianceReport(ctx, report.Spec)
-			if err != nil {
-				log.Error(err, "failed to generate compliance report")
+		durationToNextGeneration := nextGeneration.Sub(r.Clock.Now())
+		// reinstate waived controls as soon as their waivers expire
+		if expiry, ok := NextWaiverExpiry(report.Annotations, lastUpdated); ok {
+			if durationToExpiry := expiry.Sub(r.Clock.Now()); durationToExpiry < durationToNextGeneration {
+				durationToNextGeneration = durationToExpiry
 			}
+		}
+		if utils.DurationExceeded(durationToNextGeneration) {
+			return r.generate(ctx, log, &report)
+		}
+		err = r.updateSchedule(ctx, namespaceName, nil)
+		if err != nil {
 			return err
 		}
 		log.V(1).Info("RequeueAfter", "durationToNextGeneration", durationToNextGeneration)
@@ -71,6 +104,71 @@ func (r *ClusterComplianceReportReconciler) generateComplianceReport(ctx context
 	return ctrlResult, err
 }
 
+// generate generates the specified report and records how long it took. The
+// ReadyCondition of a report whose generation failed is set by the Mgr.
+func (r *ClusterComplianceReportReconciler) generate(ctx context.Context, log logr.Logger, report *v1alpha1.ClusterComplianceReport) error {
+	start := r.Clock.Now()
+	err := r.Mgr.GenerateComplianceReport(ctx, report.Spec)
+	if err != nil {
+		log.Error(err, "failed to generate compliance report")
+		return err
+	}
+	duration := r.Clock.Now().Sub(start)
+	generationDurationSeconds.WithLabelValues(report.Spec.Name).Observe(duration.Seconds())
+	return r.updateSchedule(ctx, types.NamespacedName{Name: report.Name}, &generation{start: start, duration: duration})
+}
+
+// invalidCron sets the ReadyCondition of the specified report to false with
+// the given error of parsing its cron expression. The error is not returned,
+// because retrying does not help until the spec is fixed, and the update of
+// the spec triggers another reconciliation anyway.
+func (r *ClusterComplianceReportReconciler) invalidCron(ctx context.Context, namespaceName types.NamespacedName, cron string, err error) error {
+	r.Logger.Info("Skipping compliance report with invalid cron expression", "report", namespaceName, "cron", cron, "error", err.Error())
+	return setReadyCondition(ctx, r.Client, namespaceName.Name, metav1.Condition{
+		Type:    v1alpha1.ReadyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.ReasonInvalidCron,
+		Message: fmt.Sprintf("invalid cron expression %q: %v", cron, err),
+	})
+}
+
+// generation describes the latest generation of a report.
+type generation struct {
+	start    time.Time
+	duration time.Duration
+}
+
+// updateSchedule sets the time when the report is generated next according to
+// its cron expression and, unless nil, the duration of the latest generation
+// in the report status. The update timestamp is advanced to the start of the
+// latest generation if the generation was skipped because inputs of the
+// report did not change, so that the schedule moves on.
+func (r *ClusterComplianceReportReconciler) updateSchedule(ctx context.Context, namespaceName types.NamespacedName, latest *generation) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var report v1alpha1.ClusterComplianceReport
+		err := r.Client.Get(ctx, namespaceName, &report)
+		if err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if latest != nil && report.Status.UpdateTimestamp.Time.Before(latest.start.Truncate(time.Second)) {
+			report.Status.UpdateTimestamp = metav1.NewTime(latest.start)
+		}
+		next, err := nextGenerationTime(report.Name, report.Spec, r.reportLastUpdatedTime(&report))
+		if err != nil {
+			return r.invalidCron(ctx, namespaceName, report.Spec.Cron, err)
+		}
+		nextScheduleTime := metav1.NewTime(next)
+		if latest == nil && report.Status.NextScheduleTime.Equal(&nextScheduleTime) {
+			return nil
+		}
+		report.Status.NextScheduleTime = &nextScheduleTime
+		if latest != nil {
+			report.Status.LastGenerationDuration = &metav1.Duration{Duration: latest.duration}
+		}
+		return r.Client.Status().Update(ctx, &report)
+	})
+}
+
 func (r *ClusterComplianceReportReconciler) reportLastUpdatedTime(report *v1alpha1.ClusterComplianceReport) time.Time {
 	updateTimeStamp := report.Status.UpdateTimestamp.Time
 	lastUpdated := updateTimeStamp
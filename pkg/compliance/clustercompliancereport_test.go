@@ -10,8 +10,10 @@ import (
 	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
@@ -56,7 +58,7 @@ var _ = ginkgo.Describe("cluster compliance report", func() {
 		).Build()
 
 		// create compliance controller
-		instance := ClusterComplianceReportReconciler{Logger: logger, Client: client, Mgr: NewMgr(client, logger, config), Clock: ext.NewSystemClock()}
+		instance := ClusterComplianceReportReconciler{Logger: logger, Client: client, Mgr: NewMgr(client, logger, config, nil, nil), Clock: ext.NewSystemClock()}
 
 		// trigger compliance report generation
 		_, err = instance.generateComplianceReport(context.TODO(), types.NamespacedName{Namespace: "", Name: "nsa"})
@@ -85,7 +87,7 @@ var _ = ginkgo.Describe("cluster compliance report", func() {
 			Expect(err).ToNot(HaveOccurred())
 			sort.Sort(controlSort(complianceReport.Status.ControlChecks))
 			sort.Sort(controlSort(clusterComplianceReport.Status.ControlChecks))
-			Expect(cmp.Equal(complianceReport.Status, clusterComplianceReport.Status, ignoreTimeStamp())).To(BeTrue())
+			Expect(cmp.Equal(complianceReport.Status, clusterComplianceReport.Status, ignoreTimeStamp(), ignoreInputsHash())).To(BeTrue())
 		})
 
 		ginkgo.It("check requeue interval bigger then 0", func() {
@@ -123,7 +125,7 @@ var _ = ginkgo.Describe("cluster compliance report", func() {
 			sort.Sort(controlSort(complianceReportUpdate.Status.ControlChecks))
 			sort.Sort(controlSort(clusterComplianceReportUpdate.Status.ControlChecks))
 			// validate updated cluster compliance report status
-			Expect(cmp.Equal(complianceReportUpdate.Status, clusterComplianceReportUpdate.Status, ignoreTimeStamp())).To(BeTrue())
+			Expect(cmp.Equal(complianceReportUpdate.Status, clusterComplianceReportUpdate.Status, ignoreTimeStamp(), ignoreInputsHash())).To(BeTrue())
 		})
 		ginkgo.It("check compliance compliance report detail is updated following to changes occur with cis-bench and config-audit report", func() {
 			// update cis-benchmark report and config-audit with failed tests and compare update compliance report
@@ -147,36 +149,160 @@ var _ = ginkgo.Describe("cluster compliance report", func() {
 		// create new client
 		clientWithComplianceSpecOnly := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(&clusterComplianceSpec).Build()
 		// create compliance controller
-		complianceControllerInstance := ClusterComplianceReportReconciler{Logger: logger, Client: clientWithComplianceSpecOnly, Mgr: NewMgr(clientWithComplianceSpecOnly, logger, config), Clock: ext.NewSystemClock()}
+		complianceControllerInstance := ClusterComplianceReportReconciler{Logger: logger, Client: clientWithComplianceSpecOnly, Mgr: NewMgr(clientWithComplianceSpecOnly, logger, config, nil, nil), Clock: ext.NewSystemClock()}
 		reconcileReport, err := complianceControllerInstance.generateComplianceReport(context.TODO(), types.NamespacedName{Namespace: "", Name: "nsa"})
 		Expect(err).ToNot(HaveOccurred())
 
 		ginkgo.It("check compliance reconcile where cis-benchmark and config-audit reports are not present", func() {
-			// validate compliance reports has no status / data
+			// validate compliance reports has no results of controls
 			complianceDetailReport, err := getDetailReport(context.TODO(), types.NamespacedName{Namespace: "", Name: "nsa-details"}, clientWithComplianceSpecOnly)
 			Expect(err).ToNot(HaveOccurred())
-			Expect(len(complianceDetailReport.Report.ControlChecks) == 0).To(BeTrue())
+			Expect(complianceDetailReport.Report.ControlChecks).To(HaveLen(len(clusterComplianceSpec.Spec.Controls)))
+			for _, controlCheck := range complianceDetailReport.Report.ControlChecks {
+				for _, result := range controlCheck.ScannerCheckResult {
+					Expect(result.Details).To(Equal([]v1alpha1.ResultDetails{{Msg: NoScannerResults, Status: v1alpha1.NoResultsStatus}}))
+				}
+			}
 
 			// validate cluster compliance report
 			complianceReport, err := getReport(context.TODO(), types.NamespacedName{Namespace: "", Name: "nsa"}, clientWithComplianceSpecOnly)
 			Expect(err).ToNot(HaveOccurred())
-			Expect(len(complianceReport.Status.ControlChecks) == 0).To(BeTrue())
+			Expect(complianceReport.Status.ControlChecks).To(HaveLen(len(clusterComplianceSpec.Spec.Controls)))
+			for _, controlCheck := range complianceReport.Status.ControlChecks {
+				Expect(controlCheck.Status).To(Equal(v1alpha1.NoResultsStatus))
+			}
+			Expect(complianceReport.Status.Summary.NoResultsCount).To(Equal(len(clusterComplianceSpec.Spec.Controls)))
+			Expect(complianceReport.Status.Summary.Score).To(BeNil())
+			Expect(meta.IsStatusConditionFalse(complianceReport.Status.Conditions, v1alpha1.ReadyCondition)).To(BeTrue())
+			Expect(meta.FindStatusCondition(complianceReport.Status.Conditions, v1alpha1.ReadyCondition).Reason).To(Equal(v1alpha1.ReasonScannerResultsMissing))
 			// validate reconcile requeue
 			Expect(reconcileReport.RequeueAfter == 0).To(BeTrue())
 		})
 	})
+
+	ginkgo.Context("reconcile compliance spec report while spec is changed by user and validate spec is not overwritten", func() {
+		ginkgo.It("check compliance report spec changed by user is kept and status is updated", func() {
+			var clusterComplianceSpec v1alpha1.ClusterComplianceReport
+			err := loadResource("./testdata/fixture/clusterComplianceSpec.json", &clusterComplianceSpec)
+			Expect(err).ToNot(HaveOccurred())
+			var confAuditList v1alpha1.ConfigAuditReportList
+			err = loadResource("./testdata/fixture/configAuditReportList.json", &confAuditList)
+			Expect(err).ToNot(HaveOccurred())
+			fakeClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithLists(&confAuditList).WithObjects(&clusterComplianceSpec).Build()
+			// change cron of compliance spec while the report is generated
+			specEditingClient := &specEditingClient{Client: fakeClient, name: "nsa", cron: "0 */12 * * *"}
+			complianceControllerInstance := ClusterComplianceReportReconciler{Logger: logger, Client: specEditingClient, Mgr: NewMgr(specEditingClient, logger, config, nil, nil), Clock: ext.NewSystemClock()}
+			_, err = complianceControllerInstance.generateComplianceReport(context.TODO(), types.NamespacedName{Namespace: "", Name: "nsa"})
+			Expect(err).ToNot(HaveOccurred())
+
+			complianceReport, err := getReport(context.TODO(), types.NamespacedName{Namespace: "", Name: "nsa"}, fakeClient)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(specEditingClient.edited).To(BeTrue())
+			Expect(complianceReport.Spec.Cron).To(Equal("0 */12 * * *"))
+			Expect(complianceReport.Spec.Controls).To(Equal(clusterComplianceSpec.Spec.Controls))
+			Expect(complianceReport.Status.Summary.PassCount + complianceReport.Status.Summary.FailCount).To(BeNumerically(">", 0))
+		})
+	})
+
+	ginkgo.Context("reconcile compliance spec report with invalid or changed cron expression", func() {
+		ginkgo.It("check invalid cron expression sets ready condition instead of failing reconciliation", func() {
+			var clusterComplianceSpec v1alpha1.ClusterComplianceReport
+			err := loadResource("./testdata/fixture/clusterComplianceSpec.json", &clusterComplianceSpec)
+			Expect(err).ToNot(HaveOccurred())
+			clusterComplianceSpec.Spec.Cron = "every day"
+			fakeClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(&clusterComplianceSpec).Build()
+			complianceControllerInstance := ClusterComplianceReportReconciler{Logger: logger, Client: fakeClient, Mgr: NewMgr(fakeClient, logger, config, nil, nil), Clock: ext.NewSystemClock()}
+			result, err := complianceControllerInstance.generateComplianceReport(context.TODO(), types.NamespacedName{Namespace: "", Name: "nsa"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			complianceReport, err := getReport(context.TODO(), types.NamespacedName{Namespace: "", Name: "nsa"}, fakeClient)
+			Expect(err).ToNot(HaveOccurred())
+			condition := meta.FindStatusCondition(complianceReport.Status.Conditions, v1alpha1.ReadyCondition)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1alpha1.ReasonInvalidCron))
+			Expect(condition.Message).To(Equal(`invalid cron expression "every day": missing field(s)`))
+		})
+
+		ginkgo.It("check changed cron expression and jitter take effect on next reconcile", func() {
+			var clusterComplianceSpec v1alpha1.ClusterComplianceReport
+			err := loadResource("./testdata/fixture/clusterComplianceSpec.json", &clusterComplianceSpec)
+			Expect(err).ToNot(HaveOccurred())
+			clusterComplianceSpec.Spec.Cron = "0 */6 * * *"
+			clusterComplianceSpec.Status.UpdateTimestamp = metav1.NewTime(time.Now())
+			fakeClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(&clusterComplianceSpec).Build()
+			complianceControllerInstance := ClusterComplianceReportReconciler{Logger: logger, Client: fakeClient, Mgr: NewMgr(fakeClient, logger, config, nil, nil), Clock: ext.NewSystemClock()}
+			_, err = complianceControllerInstance.generateComplianceReport(context.TODO(), types.NamespacedName{Namespace: "", Name: "nsa"})
+			Expect(err).ToNot(HaveOccurred())
+			complianceReport, err := getReport(context.TODO(), types.NamespacedName{Namespace: "", Name: "nsa"}, fakeClient)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(complianceReport.Status.NextScheduleTime).ToNot(BeNil())
+			scheduled := complianceReport.Status.NextScheduleTime.Time
+
+			complianceReport.Spec.Cron = "0 0 */2 * *"
+			complianceReport.Spec.CronJitterPercent = 10
+			Expect(fakeClient.Update(context.TODO(), complianceReport)).To(Succeed())
+			result, err := complianceControllerInstance.generateComplianceReport(context.TODO(), types.NamespacedName{Namespace: "", Name: "nsa"})
+			Expect(err).ToNot(HaveOccurred())
+			complianceReport, err = getReport(context.TODO(), types.NamespacedName{Namespace: "", Name: "nsa"}, fakeClient)
+			Expect(err).ToNot(HaveOccurred())
+			expected, err := nextGenerationTime("nsa", complianceReport.Spec, complianceReport.Status.UpdateTimestamp.Time)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(complianceReport.Status.NextScheduleTime.Time).To(BeTemporally("==", expected.Truncate(time.Second)))
+			Expect(complianceReport.Status.NextScheduleTime.Time).ToNot(BeTemporally("==", scheduled))
+			Expect(result.RequeueAfter).To(BeNumerically("~", time.Until(expected), time.Minute))
+		})
+	})
 })
 
+// specEditingClient changes the cron of the compliance report spec, as a user
+// would do, while the compliance report is generated, i.e. when reports of
+// scanners are listed.
+type specEditingClient struct {
+	client.Client
+	name   string
+	cron   string
+	edited bool
+}
+
+func (c *specEditingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if !c.edited {
+		var report v1alpha1.ClusterComplianceReport
+		if err := c.Client.Get(ctx, types.NamespacedName{Name: c.name}, &report); err != nil {
+			return err
+		}
+		report.Spec.Cron = c.cron
+		if err := c.Client.Update(ctx, &report); err != nil {
+			return err
+		}
+		c.edited = true
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
 func ignoreTimeStamp() cmp.Options {
 	alwaysEqual := cmp.Comparer(func(_, _ interface{}) bool { return true })
 	opts := cmp.Options{
 		cmp.FilterValues(func(t1, t2 metav1.Time) bool {
 			return true
 		}, alwaysEqual),
+		cmp.FilterValues(func(t1, t2 *metav1.Time) bool {
+			return t1 != nil && t2 != nil
+		}, alwaysEqual),
+		cmp.FilterValues(func(d1, d2 metav1.Duration) bool {
+			return true
+		}, alwaysEqual),
 	}
 	return opts
 }
 
+// ignoreInputsHash ignores the inputs hash of report statuses, which depends on
+// resource versions assigned by the fake client.
+func ignoreInputsHash() cmp.Option {
+	return cmpopts.IgnoreFields(v1alpha1.ReportStatus{}, "InputsHash")
+}
+
 type controlSort []v1alpha1.ControlCheck
 
 func (a controlSort) Len() int           { return len(a) }
@@ -185,15 +311,32 @@ func (a controlSort) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 
 type controlDetailSort []v1alpha1.ControlCheckDetails
 
-func (a controlDetailSort) Len() int           { return len(a) }
-func (a controlDetailSort) Less(i, j int) bool { return a[i].ID < a[j].ID }
-func (a controlDetailSort) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a controlDetailSort) Len() int { return len(a) }
+func (a controlDetailSort) Less(i, j int) bool {
+	if a[i].ID != a[j].ID {
+		return a[i].ID < a[j].ID
+	}
+	return firstCheckID(a[i]) < firstCheckID(a[j])
+}
+func (a controlDetailSort) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+
+func firstCheckID(details v1alpha1.ControlCheckDetails) string {
+	if len(details.ScannerCheckResult) == 0 {
+		return ""
+	}
+	return details.ScannerCheckResult[0].ID
+}
 
 type controlObjectTypeSort []v1alpha1.ScannerCheckResult
 
-func (a controlObjectTypeSort) Len() int           { return len(a) }
-func (a controlObjectTypeSort) Less(i, j int) bool { return a[i].ObjectType < a[j].ObjectType }
-func (a controlObjectTypeSort) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a controlObjectTypeSort) Len() int { return len(a) }
+func (a controlObjectTypeSort) Less(i, j int) bool {
+	if a[i].ObjectType != a[j].ObjectType {
+		return a[i].ObjectType < a[j].ObjectType
+	}
+	return a[i].ID < a[j].ID
+}
+func (a controlObjectTypeSort) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 
 func getReport(ctx context.Context, namespaceName types.NamespacedName, client client.Client) (*v1alpha1.ClusterComplianceReport, error) {
 	var report v1alpha1.ClusterComplianceReport
@@ -0,0 +1,133 @@
+package compliance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxOwnerDepth is the maximum number of controllers followed to find the root
+// workload of a resource, e.g. Pod, ReplicaSet and Deployment.
+const maxOwnerDepth = 4
+
+// rootResolver resolves the root workload of resources, i.e. the top-level
+// controller which is a workload, e.g. the Deployment of a ReplicaSet or of a
+// Pod. Roots are cached, so that each resource is read once per generation.
+type rootResolver struct {
+	kube.ObjectResolver
+	roots map[kube.ObjectRef]kube.ObjectRef
+}
+
+// root returns the root workload of the given resource, or the resource itself
+// if it's not a workload, it's not controlled by a workload, or its controller
+// cannot be read.
+func (r *rootResolver) root(ctx context.Context, ref kube.ObjectRef) kube.ObjectRef {
+	if root, ok := r.roots[ref]; ok {
+		return root
+	}
+	root := ref
+	for depth := 0; depth < maxOwnerDepth && kube.IsWorkload(string(root.Kind)); depth++ {
+		obj, err := r.ObjectFromObjectRef(ctx, root)
+		if err != nil {
+			break
+		}
+		controller := metav1.GetControllerOf(obj)
+		if controller == nil || !kube.IsWorkload(controller.Kind) {
+			break
+		}
+		root = kube.ObjectRef{Kind: kube.Kind(controller.Kind), Name: controller.Name, Namespace: root.Namespace}
+	}
+	r.roots[ref] = root
+	return root
+}
+
+// deduplicateResults keeps a single result of each check for each root
+// workload, so that a misconfiguration reported for a Deployment as well as
+// for its ReplicaSets and Pods is counted once. The result with the worst
+// status is kept, preferring the result of the root workload itself. Results
+// without a resource, e.g. of kube-bench, are never deduplicated. It returns
+// the number of duplicates which were removed.
+func (w *cm) deduplicateResults(ctx context.Context, checkIdsToResults map[string][]*ScannerCheckResult) int {
+	resolver := &rootResolver{ObjectResolver: kube.ObjectResolver{Client: w.client}, roots: make(map[kube.ObjectRef]kube.ObjectRef)}
+	var removed int
+	for checkID, results := range checkIdsToResults {
+		type candidate struct {
+			result int
+			detail int
+			isRoot bool
+		}
+		kept := make(map[kube.ObjectRef]candidate)
+		for i, result := range results {
+			for j, detail := range result.Details {
+				if detail.Resource.Kind == "" {
+					continue
+				}
+				root := resolver.root(ctx, detail.Resource)
+				c := candidate{result: i, detail: j, isRoot: detail.Resource == root}
+				if previous, ok := kept[root]; ok {
+					removed++
+					if !preferred(detail, c.isRoot, results[previous.result].Details[previous.detail], previous.isRoot) {
+						continue
+					}
+				}
+				kept[root] = c
+			}
+		}
+		keep := make(map[[2]int]bool)
+		for _, c := range kept {
+			keep[[2]int{c.result, c.detail}] = true
+		}
+		deduplicated := make([]*ScannerCheckResult, 0, len(results))
+		for i, result := range results {
+			details := make([]ResultDetails, 0, len(result.Details))
+			for j, detail := range result.Details {
+				if detail.Resource.Kind == "" || keep[[2]int{i, j}] {
+					details = append(details, detail)
+				}
+			}
+			if len(details) == 0 {
+				continue
+			}
+			result.Details = details
+			deduplicated = append(deduplicated, result)
+		}
+		checkIdsToResults[checkID] = deduplicated
+	}
+	return removed
+}
+
+// statusRank ranks statuses of results, so that a failure of a check is never
+// hidden by a duplicate which passed, nor truncated in favor of a warning.
+var statusRank = map[v1alpha1.ControlStatus]int{
+	v1alpha1.PassStatus: 0,
+	v1alpha1.WarnStatus: 2,
+	v1alpha1.FailStatus: 3,
+}
+
+// statusRankOf returns the rank of the given status, where statuses other than
+// PASS, WARN and FAIL rank between PASS and WARN.
+func statusRankOf(status v1alpha1.ControlStatus) int {
+	if rank, ok := statusRank[status]; ok {
+		return rank
+	}
+	return 1
+}
+
+// preferred returns true if the result detail a, which is reported for the root
+// workload if aIsRoot is true, is preferred over the duplicate b.
+func preferred(a ResultDetails, aIsRoot bool, b ResultDetails, bIsRoot bool) bool {
+	if rankA, rankB := statusRankOf(a.Status), statusRankOf(b.Status); rankA != rankB {
+		return rankA > rankB
+	}
+	if aIsRoot != bIsRoot {
+		return aIsRoot
+	}
+	return resourceKey(a.Resource) < resourceKey(b.Resource)
+}
+
+func resourceKey(ref kube.ObjectRef) string {
+	return fmt.Sprintf("%s/%s/%s", ref.Namespace, ref.Kind, ref.Name)
+}
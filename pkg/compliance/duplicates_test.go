@@ -0,0 +1,140 @@
+package compliance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func controlledBy(kind, name string) []metav1.OwnerReference {
+	return []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: kind, Name: name, Controller: pointer.Bool(true)}}
+}
+
+func configAuditReport(kind, name string, success bool) v1alpha1.ConfigAuditReport {
+	return v1alpha1.ConfigAuditReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				starboard.LabelResourceKind:      kind,
+				starboard.LabelResourceName:      name,
+				starboard.LabelResourceNamespace: "default",
+			},
+		},
+		Report: v1alpha1.ConfigAuditReportData{
+			Checks: []v1alpha1.Check{{ID: "KSV014", Success: success, Messages: []string{kind + " " + name}}},
+		},
+	}
+}
+
+func TestDeduplicateResults(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "nginx-6d4cf56db6", Namespace: "default",
+			OwnerReferences: controlledBy("Deployment", "nginx")}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "nginx-7d8b49557c", Namespace: "default",
+			OwnerReferences: controlledBy("Deployment", "nginx")}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "nginx-7d8b49557c-x2v9k", Namespace: "default",
+			OwnerReferences: controlledBy("ReplicaSet", "nginx-7d8b49557c")}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "redis", Namespace: "default"}},
+	).Build()
+	mgr := &cm{client: c, log: logr.Discard(), config: starboard.ConfigData{"compliance.failEntriesLimit": "10"}}
+
+	scannerResourceMap := map[string]map[string]client.ObjectList{
+		ConfigAudit: {
+			"ReplicaSet": &v1alpha1.ConfigAuditReportList{Items: []v1alpha1.ConfigAuditReport{
+				configAuditReport("ReplicaSet", "nginx-6d4cf56db6", false),
+				configAuditReport("ReplicaSet", "nginx-7d8b49557c", false),
+				configAuditReport("ReplicaSet", "redis", false),
+			}},
+			"Pod": &v1alpha1.ConfigAuditReportList{Items: []v1alpha1.ConfigAuditReport{
+				configAuditReport("Pod", "nginx-7d8b49557c-x2v9k", true),
+			}},
+		},
+	}
+	checkIdsToResults, scannerErrors := mgr.checkIdsToResults(scannerResourceMap)
+	require.Empty(t, scannerErrors)
+
+	removed := mgr.deduplicateResults(context.TODO(), checkIdsToResults)
+	assert.Equal(t, 2, removed)
+
+	smd := mgr.populateSpecDataToMaps(v1alpha1.ReportSpec{
+		Name: "nsa",
+		Controls: []v1alpha1.Control{
+			{ID: "1.1", Name: "Immutable container file systems", Kinds: []string{"Workload"}, Severity: "LOW",
+				Mapping: v1alpha1.Mapping{Scanner: ConfigAudit, Checks: []v1alpha1.SpecCheck{{ID: "KSV014"}}}},
+		},
+	})
+	controlChecks := mgr.controlChecksByScannerChecks(smd, checkIdsToResults)
+	require.Len(t, controlChecks, 1)
+	assert.Equal(t, 0, controlChecks[0].PassTotal)
+	assert.Equal(t, 2, controlChecks[0].FailTotal, "failures of the nginx Deployment and of the redis ReplicaSet")
+
+	t.Run("Should list deduplicated results in details", func(t *testing.T) {
+		details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+		require.Len(t, details, 1)
+		assert.Equal(t, []v1alpha1.ScannerCheckResult{
+			{ID: "KSV014", ObjectType: "ReplicaSet", Scanner: ConfigAudit, Details: []v1alpha1.ResultDetails{
+				{Name: "nginx-6d4cf56db6", Namespace: "default", Msg: "ReplicaSet nginx-6d4cf56db6", Status: v1alpha1.FailStatus},
+				{Name: "redis", Namespace: "default", Msg: "ReplicaSet redis", Status: v1alpha1.FailStatus},
+			}},
+		}, details[0].ScannerCheckResult)
+	})
+}
+
+func TestRootResolver(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "nginx-6d4cf56db6", Namespace: "default",
+			OwnerReferences: controlledBy("Deployment", "nginx")}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "nginx-6d4cf56db6-x2v9k", Namespace: "default",
+			OwnerReferences: controlledBy("ReplicaSet", "nginx-6d4cf56db6")}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "operator-managed", Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "example.com/v1", Kind: "Memcached", Name: "memcached", Controller: pointer.Bool(true)}}}},
+	).Build()
+	resolver := &rootResolver{roots: make(map[kube.ObjectRef]kube.ObjectRef)}
+	resolver.Client = c
+
+	testCases := []struct {
+		name     string
+		resource kube.ObjectRef
+		want     kube.ObjectRef
+	}{
+		{
+			name:     "Should resolve Deployment of Pod",
+			resource: kube.ObjectRef{Kind: kube.KindPod, Name: "nginx-6d4cf56db6-x2v9k", Namespace: "default"},
+			want:     kube.ObjectRef{Kind: kube.KindDeployment, Name: "nginx", Namespace: "default"},
+		},
+		{
+			name:     "Should stop at controller which is not a workload",
+			resource: kube.ObjectRef{Kind: kube.KindPod, Name: "operator-managed", Namespace: "default"},
+			want:     kube.ObjectRef{Kind: kube.KindPod, Name: "operator-managed", Namespace: "default"},
+		},
+		{
+			name:     "Should return resource which cannot be read",
+			resource: kube.ObjectRef{Kind: kube.KindReplicaSet, Name: "deleted", Namespace: "default"},
+			want:     kube.ObjectRef{Kind: kube.KindReplicaSet, Name: "deleted", Namespace: "default"},
+		},
+		{
+			name:     "Should return resource which is not a workload",
+			resource: kube.ObjectRef{Kind: kube.KindService, Name: "nginx", Namespace: "default"},
+			want:     kube.ObjectRef{Kind: kube.KindService, Name: "nginx", Namespace: "default"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, resolver.root(context.TODO(), tc.resource))
+		})
+	}
+}
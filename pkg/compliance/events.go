@@ -0,0 +1,127 @@
+package compliance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ReasonFailuresIncreased is the reason of a warning event of a
+	// ClusterComplianceReport whose number of failing checks increased.
+	ReasonFailuresIncreased = "ComplianceFailuresIncreased"
+	// ReasonFailuresResolved is the reason of a normal event of a
+	// ClusterComplianceReport whose failing checks were all resolved.
+	ReasonFailuresResolved = "ComplianceFailuresResolved"
+	// ReasonControlStatusChanged is the reason of an event of a
+	// ClusterComplianceReport with a control whose state changed, which is a
+	// warning event if the control started failing.
+	ReasonControlStatusChanged = "ComplianceControlStatusChanged"
+)
+
+type statusEvent struct {
+	eventType string
+	reason    string
+	// controlID is the ID of the control which the event is about, if any
+	controlID string
+	message   string
+}
+
+// statusEvents returns events of the change from the previous to the current
+// status of a compliance report. There are no events for the first generation
+// of a report, nor for controls which were not reported before.
+func statusEvents(previous, current v1alpha1.ReportStatus) []statusEvent {
+	if previous.UpdateTimestamp.IsZero() {
+		return nil
+	}
+	var events []statusEvent
+	previousFail, currentFail := previous.Summary.FailCount, current.Summary.FailCount
+	if currentFail > previousFail {
+		events = append(events, statusEvent{
+			eventType: corev1.EventTypeWarning,
+			reason:    ReasonFailuresIncreased,
+			message:   fmt.Sprintf("Failing checks increased from %d to %d", previousFail, currentFail),
+		})
+	} else if previousFail > 0 && currentFail == 0 {
+		events = append(events, statusEvent{
+			eventType: corev1.EventTypeNormal,
+			reason:    ReasonFailuresResolved,
+			message:   fmt.Sprintf("All %d failing checks were resolved", previousFail),
+		})
+	}
+	previousStates := make(map[string]string)
+	for _, controlCheck := range previous.ControlChecks {
+		previousStates[controlCheck.ID] = ControlState(controlCheck)
+	}
+	for _, controlCheck := range current.ControlChecks {
+		previousState, ok := previousStates[controlCheck.ID]
+		state := ControlState(controlCheck)
+		if !ok || previousState == state {
+			continue
+		}
+		eventType := corev1.EventTypeNormal
+		if state == string(v1alpha1.FailStatus) {
+			eventType = corev1.EventTypeWarning
+		}
+		events = append(events, statusEvent{
+			eventType: eventType,
+			reason:    ReasonControlStatusChanged,
+			controlID: controlCheck.ID,
+			message: fmt.Sprintf("Control %s %q changed from %s to %s with %d passed and %d failed checks",
+				controlCheck.ID, controlCheck.Name, previousState, state, controlCheck.PassTotal, controlCheck.FailTotal),
+		})
+	}
+	return events
+}
+
+// ControlState returns the status of the given control check if it has one,
+// e.g. WAIVED, or otherwise FAIL if any of its checks failed and PASS if not.
+func ControlState(controlCheck v1alpha1.ControlCheck) string {
+	if controlCheck.Status != "" {
+		return string(controlCheck.Status)
+	}
+	if controlCheck.FailTotal > 0 {
+		return string(v1alpha1.FailStatus)
+	}
+	return string(v1alpha1.PassStatus)
+}
+
+// recordStatusEvents records events of the change from the previous status of
+// the given report to its current status. At most one event of each reason is
+// recorded for a report, or for a control of the report, within the interval
+// configured with the compliance.eventsInterval setting.
+func (w *cm) recordStatusEvents(report *v1alpha1.ClusterComplianceReport, previous v1alpha1.ReportStatus) {
+	if w.recorder == nil {
+		return
+	}
+	for _, event := range statusEvents(previous, report.Status) {
+		if !w.allowEvent(string(report.UID)+"/"+event.reason+"/"+event.controlID, w.config.ComplianceEventsInterval()) {
+			continue
+		}
+		w.recorder.Event(report, event.eventType, event.reason, event.message)
+	}
+}
+
+// allowEvent returns true unless an event with the given key was recorded
+// within the interval.
+func (w *cm) allowEvent(key string, interval time.Duration) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := ext.NewSystemClock().Now()
+	for k, last := range w.lastEvents {
+		if now.Sub(last) >= interval {
+			delete(w.lastEvents, k)
+		}
+	}
+	if _, ok := w.lastEvents[key]; ok {
+		return false
+	}
+	if w.lastEvents == nil {
+		w.lastEvents = make(map[string]time.Time)
+	}
+	w.lastEvents[key] = now
+	return true
+}
@@ -0,0 +1,138 @@
+package compliance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestStatusEvents(t *testing.T) {
+	generated := metav1.NewTime(time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC))
+	newStatus := func(failCount int, controlChecks ...v1alpha1.ControlCheck) v1alpha1.ReportStatus {
+		return v1alpha1.ReportStatus{
+			UpdateTimestamp: generated,
+			Summary:         v1alpha1.ClusterComplianceSummary{FailCount: failCount},
+			ControlChecks:   controlChecks,
+		}
+	}
+	nonRoot := func(pass, fail int) v1alpha1.ControlCheck {
+		return v1alpha1.ControlCheck{ID: "1.0", Name: "Non-root containers", PassTotal: pass, FailTotal: fail}
+	}
+	tests := []struct {
+		name     string
+		previous v1alpha1.ReportStatus
+		current  v1alpha1.ReportStatus
+		want     []statusEvent
+	}{
+		{
+			name:     "first generation",
+			previous: v1alpha1.ReportStatus{},
+			current:  newStatus(1, nonRoot(0, 1)),
+		},
+		{
+			name:     "unchanged results",
+			previous: newStatus(1, nonRoot(2, 1)),
+			current:  newStatus(1, nonRoot(2, 1)),
+		},
+		{
+			name:     "control started failing",
+			previous: newStatus(0, nonRoot(3, 0)),
+			current:  newStatus(1, nonRoot(2, 1)),
+			want: []statusEvent{
+				{eventType: corev1.EventTypeWarning, reason: ReasonFailuresIncreased,
+					message: "Failing checks increased from 0 to 1"},
+				{eventType: corev1.EventTypeWarning, reason: ReasonControlStatusChanged, controlID: "1.0",
+					message: `Control 1.0 "Non-root containers" changed from PASS to FAIL with 2 passed and 1 failed checks`},
+			},
+		},
+		{
+			name:     "failures resolved",
+			previous: newStatus(2, nonRoot(1, 2)),
+			current:  newStatus(0, nonRoot(3, 0)),
+			want: []statusEvent{
+				{eventType: corev1.EventTypeNormal, reason: ReasonFailuresResolved,
+					message: "All 2 failing checks were resolved"},
+				{eventType: corev1.EventTypeNormal, reason: ReasonControlStatusChanged, controlID: "1.0",
+					message: `Control 1.0 "Non-root containers" changed from FAIL to PASS with 3 passed and 0 failed checks`},
+			},
+		},
+		{
+			name:     "failures decreased",
+			previous: newStatus(2, nonRoot(1, 2)),
+			current:  newStatus(1, nonRoot(2, 1)),
+		},
+		{
+			name:     "control waived",
+			previous: newStatus(1, nonRoot(0, 1)),
+			current:  newStatus(1, v1alpha1.ControlCheck{ID: "1.0", Name: "Non-root containers", Status: v1alpha1.WaivedStatus}),
+			want: []statusEvent{
+				{eventType: corev1.EventTypeNormal, reason: ReasonControlStatusChanged, controlID: "1.0",
+					message: `Control 1.0 "Non-root containers" changed from FAIL to WAIVED with 0 passed and 0 failed checks`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, statusEvents(tt.previous, tt.current))
+		})
+	}
+}
+
+func TestRecordStatusEvents(t *testing.T) {
+	report := &v1alpha1.ClusterComplianceReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "nsa", UID: "nsa-uid"},
+		Status: v1alpha1.ReportStatus{
+			UpdateTimestamp: metav1.NewTime(time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)),
+			Summary:         v1alpha1.ClusterComplianceSummary{PassCount: 1},
+			ControlChecks:   []v1alpha1.ControlCheck{{ID: "1.0", Name: "Non-root containers", PassTotal: 1}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(report).Build()
+	recorder := record.NewFakeRecorder(10)
+	mgr := &cm{client: c, log: logr.Discard(), config: starboard.ConfigData{}, recorder: recorder}
+	passing := report.Status
+	failing := v1alpha1.ReportStatus{
+		UpdateTimestamp: metav1.NewTime(time.Date(2022, 5, 1, 11, 0, 0, 0, time.UTC)),
+		Summary:         v1alpha1.ClusterComplianceSummary{FailCount: 1},
+		ControlChecks:   []v1alpha1.ControlCheck{{ID: "1.0", Name: "Non-root containers", FailTotal: 1}},
+	}
+	events := func() []string {
+		var recorded []string
+		for {
+			select {
+			case event := <-recorder.Events:
+				recorded = append(recorded, event)
+			default:
+				return recorded
+			}
+		}
+	}
+
+	require.NoError(t, mgr.updateComplianceReportStatus(context.TODO(), v1alpha1.ReportSpec{Name: "nsa"}, failing))
+	assert.Equal(t, []string{
+		"Warning ComplianceFailuresIncreased Failing checks increased from 0 to 1",
+		`Warning ComplianceControlStatusChanged Control 1.0 "Non-root containers" changed from PASS to FAIL with 0 passed and 1 failed checks`,
+	}, events())
+
+	t.Run("Should not record events of unchanged status", func(t *testing.T) {
+		require.NoError(t, mgr.updateComplianceReportStatus(context.TODO(), v1alpha1.ReportSpec{Name: "nsa"}, failing))
+		assert.Empty(t, events())
+	})
+
+	t.Run("Should not record events of the same reason within interval", func(t *testing.T) {
+		require.NoError(t, mgr.updateComplianceReportStatus(context.TODO(), v1alpha1.ReportSpec{Name: "nsa"}, passing))
+		assert.Equal(t, []string{"Normal ComplianceFailuresResolved All 1 failing checks were resolved"}, events())
+		require.NoError(t, mgr.updateComplianceReportStatus(context.TODO(), v1alpha1.ReportSpec{Name: "nsa"}, failing))
+		assert.Empty(t, events())
+	})
+}
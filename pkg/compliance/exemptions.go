@@ -0,0 +1,107 @@
+package compliance
+
+import (
+	"sort"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+// exemptResults returns results of the given checks of the control without
+// results of resources in namespaces excluded by the control, along with the
+// sorted names of namespaces whose results were skipped. Results of checks
+// which are left without any details are omitted, as if the scanner had not
+// reported them. The given results are returned as is if the control does not
+// exclude any namespace.
+func exemptResults(control v1alpha1.Control, checkIds []string, checkIdsToResults map[string][]*ScannerCheckResult) (map[string][]*ScannerCheckResult, []string) {
+	if len(control.ExcludedNamespaces) == 0 {
+		return checkIdsToResults, nil
+	}
+	excluded := make(map[string]bool)
+	for _, namespace := range control.ExcludedNamespaces {
+		excluded[namespace] = true
+	}
+	skipped := make(map[string]bool)
+	results := make(map[string][]*ScannerCheckResult)
+	for _, checkId := range checkIds {
+		for _, result := range checkIdsToResults[checkId] {
+			details := make([]ResultDetails, 0, len(result.Details))
+			for _, detail := range result.Details {
+				if detail.Namespace != "" && excluded[detail.Namespace] {
+					skipped[detail.Namespace] = true
+					continue
+				}
+				details = append(details, detail)
+			}
+			if len(details) == 0 {
+				continue
+			}
+			exempted := *result
+			exempted.Details = details
+			results[checkId] = append(results[checkId], &exempted)
+		}
+	}
+	skippedNamespaces := make([]string, 0, len(skipped))
+	for namespace := range skipped {
+		skippedNamespaces = append(skippedNamespaces, namespace)
+	}
+	sort.Strings(skippedNamespaces)
+	return results, skippedNamespaces
+}
+
+// severityRank ranks severities of results, so that results below the severity
+// threshold of a control are filtered out.
+var severityRank = map[v1alpha1.Severity]int{
+	v1alpha1.SeverityNone:     0,
+	v1alpha1.SeverityUnknown:  1,
+	v1alpha1.SeverityLow:      2,
+	v1alpha1.SeverityMedium:   3,
+	v1alpha1.SeverityHigh:     4,
+	v1alpha1.SeverityCritical: 5,
+}
+
+// filterBySeverity returns results of the given checks without results whose
+// severity is below the given threshold. Results without a severity, e.g. of
+// kube-bench, are kept. Results of checks which are left without any details
+// are omitted, as if the scanner had not reported them. The given results are
+// returned as is if the threshold is not set.
+func filterBySeverity(checkIds []string, checkIdsToResults map[string][]*ScannerCheckResult, threshold v1alpha1.Severity) map[string][]*ScannerCheckResult {
+	if threshold == "" {
+		return checkIdsToResults
+	}
+	results := make(map[string][]*ScannerCheckResult)
+	for _, checkId := range checkIds {
+		for _, result := range checkIdsToResults[checkId] {
+			details := make([]ResultDetails, 0, len(result.Details))
+			for _, detail := range result.Details {
+				if detail.Severity != "" && severityRank[detail.Severity] < severityRank[threshold] {
+					continue
+				}
+				details = append(details, detail)
+			}
+			if len(details) == 0 {
+				continue
+			}
+			filtered := *result
+			filtered.Details = details
+			results[checkId] = append(results[checkId], &filtered)
+		}
+	}
+	return results
+}
+
+// controlResults returns results of the given checks of the control which are
+// counted and listed in details, i.e. without results of excluded namespaces
+// and results below the severity threshold, along with the sorted names of
+// namespaces whose results were skipped. Results of all controls are keyed by
+// checkKey, whereas returned results are keyed by check IDs of the control,
+// which are unique within the control.
+func (smd *specDataMapping) controlResults(control v1alpha1.Control, checkIds []string, checkKeysToResults map[string][]*ScannerCheckResult) (map[string][]*ScannerCheckResult, []string) {
+	checkIdsToResults := make(map[string][]*ScannerCheckResult)
+	for _, checkId := range checkIds {
+		if results, ok := checkKeysToResults[checkKey(smd.checkScanner(control.ID, checkId), checkId)]; ok {
+			checkIdsToResults[checkId] = results
+		}
+	}
+	results, skippedNamespaces := exemptResults(control, checkIds, checkIdsToResults)
+	return filterBySeverity(checkIds, results, smd.minSeverity(control)), skippedNamespaces
+}
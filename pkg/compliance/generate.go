@@ -0,0 +1,56 @@
+package compliance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RequestGeneration annotates the ClusterComplianceReport with the specified
+// name with v1alpha1.ComplianceReportGenerateAnnotation, which the reconciler
+// treats as a request to generate the report immediately. It returns the update
+// timestamp of the report read before the request, so that WaitForGeneration
+// does not depend on the clock of the cluster agreeing with the local one.
+func RequestGeneration(ctx context.Context, c client.Client, name string, now time.Time) (time.Time, error) {
+	var lastUpdated time.Time
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var report v1alpha1.ClusterComplianceReport
+		err := c.Get(ctx, types.NamespacedName{Name: name}, &report)
+		if err != nil {
+			return err
+		}
+		lastUpdated = report.Status.UpdateTimestamp.Time
+		if report.Annotations == nil {
+			report.Annotations = make(map[string]string)
+		}
+		report.Annotations[v1alpha1.ComplianceReportGenerateAnnotation] = now.UTC().Format(time.RFC3339Nano)
+		return c.Update(ctx, &report)
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("requesting generation of compliance report %s: %w", name, err)
+	}
+	return lastUpdated, nil
+}
+
+// WaitForGeneration polls the ClusterComplianceReport with the specified name
+// until its status is updated after the specified update timestamp.
+func WaitForGeneration(ctx context.Context, c client.Client, name string, lastUpdated time.Time, interval, timeout time.Duration) (*v1alpha1.ClusterComplianceReport, error) {
+	var report v1alpha1.ClusterComplianceReport
+	err := wait.PollImmediate(interval, timeout, func() (bool, error) {
+		err := c.Get(ctx, types.NamespacedName{Name: name}, &report)
+		if err != nil {
+			return false, err
+		}
+		return report.Status.UpdateTimestamp.Time.After(lastUpdated), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("waiting for generation of compliance report %s: %w", name, err)
+	}
+	return &report, nil
+}
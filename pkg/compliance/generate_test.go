@@ -0,0 +1,107 @@
+package compliance
+
+import (
+	"context"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type countingMgr struct {
+	calls int
+}
+
+func (m *countingMgr) GenerateComplianceReport(_ context.Context, _ v1alpha1.ReportSpec) error {
+	m.calls++
+	return nil
+}
+
+var _ = ginkgo.Describe("on demand compliance report generation", func() {
+	now := time.Date(2022, 4, 20, 10, 14, 38, 500, time.UTC)
+
+	newReport := func() *v1alpha1.ClusterComplianceReport {
+		return &v1alpha1.ClusterComplianceReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "nsa",
+				CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+			},
+			Spec: v1alpha1.ReportSpec{
+				Name: "nsa",
+				Cron: "0 */6 * * *",
+			},
+			Status: v1alpha1.ReportStatus{
+				UpdateTimestamp: metav1.NewTime(now.Add(-time.Minute)),
+			},
+		}
+	}
+
+	ginkgo.It("should annotate report with request time", func() {
+		client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(newReport()).Build()
+
+		lastUpdated, err := RequestGeneration(context.TODO(), client, "nsa", now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lastUpdated).To(BeTemporally("==", now.Add(-time.Minute).Truncate(time.Second)))
+
+		report, err := getReport(context.TODO(), types.NamespacedName{Name: "nsa"}, client)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Annotations).To(HaveKeyWithValue(v1alpha1.ComplianceReportGenerateAnnotation, "2022-04-20T10:14:38.0000005Z"))
+	})
+
+	ginkgo.It("should return error when report does not exist", func() {
+		client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+
+		_, err := RequestGeneration(context.TODO(), client, "nsa", now)
+		Expect(err).To(HaveOccurred())
+	})
+
+	ginkgo.It("should generate requested report exactly once", func() {
+		client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(newReport()).Build()
+		mgr := &countingMgr{}
+		instance := ClusterComplianceReportReconciler{Logger: log.Log, Client: client, Mgr: mgr, Clock: ext.NewFixedClock(now)}
+
+		_, err := RequestGeneration(context.TODO(), client, "nsa", now)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = instance.generateComplianceReport(context.TODO(), types.NamespacedName{Name: "nsa"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mgr.calls).To(Equal(1))
+
+		report, err := getReport(context.TODO(), types.NamespacedName{Name: "nsa"}, client)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Annotations).ToNot(HaveKey(v1alpha1.ComplianceReportGenerateAnnotation))
+
+		res, err := instance.generateComplianceReport(context.TODO(), types.NamespacedName{Name: "nsa"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mgr.calls).To(Equal(1))
+		Expect(res.RequeueAfter > 0).To(BeTrue())
+	})
+
+	ginkgo.It("should wait until report is generated after request", func() {
+		report := newReport()
+		report.Status.UpdateTimestamp = metav1.NewTime(now.Add(-time.Hour).Truncate(time.Second))
+		report.Status.Summary = v1alpha1.ClusterComplianceSummary{PassCount: 3, FailCount: 1}
+		client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(report).Build()
+
+		generated, err := WaitForGeneration(context.TODO(), client, "nsa", now.Add(-2*time.Hour).Truncate(time.Second), time.Millisecond, time.Second)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(generated.Status.Summary).To(Equal(v1alpha1.ClusterComplianceSummary{PassCount: 3, FailCount: 1}))
+	})
+
+	ginkgo.It("should time out when report is not generated after request", func() {
+		client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(newReport()).Build()
+
+		lastUpdated, err := RequestGeneration(context.TODO(), client, "nsa", now)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = WaitForGeneration(context.TODO(), client, "nsa", lastUpdated, time.Millisecond, 10*time.Millisecond)
+		Expect(err).To(MatchError(ContainSubstring("timed out waiting for the condition")))
+	})
+})
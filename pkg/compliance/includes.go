@@ -0,0 +1,72 @@
+package compliance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// MaxIncludeDepth is the maximum depth of compliance specs nested with ReportSpec.Includes.
+const MaxIncludeDepth = 5
+
+// resolveSpec return the spec with controls of included specs merged into it and
+// the name of the spec each control originates from keyed by control id.
+// Controls of the including spec win over included controls with the same id,
+// and controls of later includes win over controls of earlier ones.
+func (w *cm) resolveSpec(ctx context.Context, spec v1alpha1.ReportSpec) (v1alpha1.ReportSpec, map[string]string, error) {
+	controls, controlSpecNames, err := w.resolveControls(ctx, strings.ToLower(spec.Name), spec, nil)
+	if err != nil {
+		return spec, nil, err
+	}
+	resolved := spec
+	resolved.Controls = controls
+	return resolved, controlSpecNames, nil
+}
+
+func (w *cm) resolveControls(ctx context.Context, name string, spec v1alpha1.ReportSpec, path []string) ([]v1alpha1.Control, map[string]string, error) {
+	if len(path) > MaxIncludeDepth {
+		return nil, nil, fmt.Errorf("compliance spec %s exceeds max include depth %d", path[0], MaxIncludeDepth)
+	}
+	path = append(append([]string{}, path...), name)
+
+	controls := make([]v1alpha1.Control, 0)
+	controlSpecNames := make(map[string]string)
+	controlIndex := make(map[string]int)
+	add := func(control v1alpha1.Control, specName string) {
+		if i, ok := controlIndex[control.ID]; ok {
+			controls[i] = control
+		} else {
+			controlIndex[control.ID] = len(controls)
+			controls = append(controls, control)
+		}
+		controlSpecNames[control.ID] = specName
+	}
+
+	for _, include := range spec.Includes {
+		include = strings.ToLower(include)
+		for _, p := range path {
+			if p == include {
+				return nil, nil, fmt.Errorf("compliance spec include cycle detected: %s", strings.Join(append(path, include), " -> "))
+			}
+		}
+		var included v1alpha1.ClusterComplianceReport
+		err := w.client.Get(ctx, types.NamespacedName{Name: include}, &included)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting compliance spec %s included by %s: %w", include, name, err)
+		}
+		includedControls, includedSpecNames, err := w.resolveControls(ctx, include, included.Spec, path)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, control := range includedControls {
+			add(control, includedSpecNames[control.ID])
+		}
+	}
+	for _, control := range spec.Controls {
+		add(control, name)
+	}
+	return controls, controlSpecNames, nil
+}
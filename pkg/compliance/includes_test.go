@@ -0,0 +1,89 @@
+package compliance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newComplianceReport(name string, includes []string, controls ...v1alpha1.Control) *v1alpha1.ClusterComplianceReport {
+	return &v1alpha1.ClusterComplianceReport{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       v1alpha1.ReportSpec{Name: name, Includes: includes, Controls: controls},
+	}
+}
+
+func TestResolveSpec(t *testing.T) {
+	nsa := newComplianceReport("nsa", nil,
+		v1alpha1.Control{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM"},
+		v1alpha1.Control{ID: "1.1", Name: "Immutable container file systems", Severity: "LOW"},
+	)
+
+	t.Run("Should merge controls of included specs with local overrides", func(t *testing.T) {
+		mgr := cm{client: fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(nsa).Build()}
+		spec := newComplianceReport("nsa-plus", []string{"nsa"},
+			v1alpha1.Control{ID: "1.1", Name: "Immutable container file systems", Severity: "HIGH"},
+			v1alpha1.Control{ID: "100.0", Name: "Internal control", Severity: "LOW"},
+		).Spec
+
+		resolved, controlSpecNames, err := mgr.resolveSpec(context.TODO(), spec)
+		require.NoError(t, err)
+		assert.Equal(t, []v1alpha1.Control{
+			{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM"},
+			{ID: "1.1", Name: "Immutable container file systems", Severity: "HIGH"},
+			{ID: "100.0", Name: "Internal control", Severity: "LOW"},
+		}, resolved.Controls)
+		assert.Equal(t, map[string]string{"1.0": "nsa", "1.1": "nsa-plus", "100.0": "nsa-plus"}, controlSpecNames)
+		assert.Equal(t, []string{"nsa"}, resolved.Includes)
+	})
+
+	t.Run("Should resolve nested includes", func(t *testing.T) {
+		mgr := cm{client: fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			nsa,
+			newComplianceReport("nsa-plus", []string{"nsa"}, v1alpha1.Control{ID: "100.0", Name: "Internal control"}),
+		).Build()}
+		spec := newComplianceReport("team", []string{"nsa-plus"}, v1alpha1.Control{ID: "200.0", Name: "Team control"}).Spec
+
+		resolved, controlSpecNames, err := mgr.resolveSpec(context.TODO(), spec)
+		require.NoError(t, err)
+		assert.Len(t, resolved.Controls, 4)
+		assert.Equal(t, map[string]string{"1.0": "nsa", "1.1": "nsa", "100.0": "nsa-plus", "200.0": "team"}, controlSpecNames)
+	})
+
+	t.Run("Should detect include cycle", func(t *testing.T) {
+		mgr := cm{client: fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			newComplianceReport("a", []string{"b"}),
+			newComplianceReport("b", []string{"a"}),
+		).Build()}
+
+		_, _, err := mgr.resolveSpec(context.TODO(), newComplianceReport("a", []string{"b"}).Spec)
+		assert.EqualError(t, err, "compliance spec include cycle detected: a -> b -> a")
+	})
+
+	t.Run("Should return error when max include depth is exceeded", func(t *testing.T) {
+		var objects []client.Object
+		for i := 1; i <= MaxIncludeDepth+1; i++ {
+			objects = append(objects, newComplianceReport(fmt.Sprintf("spec-%d", i), []string{fmt.Sprintf("spec-%d", i+1)}))
+		}
+		objects = append(objects, newComplianceReport(fmt.Sprintf("spec-%d", MaxIncludeDepth+2), nil))
+		mgr := cm{client: fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build()}
+
+		_, _, err := mgr.resolveSpec(context.TODO(), newComplianceReport("spec-0", []string{"spec-1"}).Spec)
+		assert.EqualError(t, err, fmt.Sprintf("compliance spec spec-0 exceeds max include depth %d", MaxIncludeDepth))
+	})
+
+	t.Run("Should return error when included spec does not exist", func(t *testing.T) {
+		mgr := cm{client: fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()}
+
+		_, _, err := mgr.resolveSpec(context.TODO(), newComplianceReport("nsa-plus", []string{"nsa"}).Spec)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,111 @@
+package compliance
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reportInputs are inputs which determine the content of a compliance report.
+// Maps are encoded with sorted keys, so that the encoding is deterministic.
+type reportInputs struct {
+	Spec                  v1alpha1.ReportSpec               `json:"spec"`
+	ControlSpecNames      map[string]string                 `json:"controlSpecNames,omitempty"`
+	Cluster               *v1alpha1.ClusterMetadata         `json:"cluster,omitempty"`
+	ControlWaivers        map[string]v1alpha1.ControlWaiver `json:"controlWaivers,omitempty"`
+	NotApplicableControls map[string]string                 `json:"notApplicableControls,omitempty"`
+	UnavailableScanners   map[string]string                 `json:"unavailableScanners,omitempty"`
+	// SeparateWarnings and FailEntriesLimit are settings of the operator which
+	// change how results are counted and listed in details.
+	SeparateWarnings bool `json:"separateWarnings"`
+	FailEntriesLimit int  `json:"failEntriesLimit"`
+	// Reports are consumed scanner reports, each identified by the scanner,
+	// UID and resource version.
+	Reports []string `json:"reports"`
+}
+
+// inputsHash returns the hash of inputs of a compliance report generated from
+// the given resolved spec, spec data mapping, cluster metadata and scanner
+// reports with the settings of the operator.
+func (w *cm) inputsHash(spec v1alpha1.ReportSpec, smd *specDataMapping, cluster *v1alpha1.ClusterMetadata, scannerResourceMap map[string]map[string]client.ObjectList) (string, error) {
+	inputs := reportInputs{
+		Spec:                  spec,
+		ControlSpecNames:      smd.controlSpecNames,
+		Cluster:               cluster,
+		ControlWaivers:        smd.controlWaivers,
+		NotApplicableControls: smd.notApplicableControls,
+		UnavailableScanners:   smd.unavailableScanners,
+		SeparateWarnings:      w.config.ComplianceSeparateWarnings(),
+		FailEntriesLimit:      w.config.ComplianceFailEntriesLimit(),
+		Reports:               make([]string, 0),
+	}
+	for scanner, resourceLists := range scannerResourceMap {
+		for _, list := range resourceLists {
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				return "", err
+			}
+			for _, item := range items {
+				obj, err := meta.Accessor(item)
+				if err != nil {
+					return "", err
+				}
+				inputs.Reports = append(inputs.Reports, fmt.Sprintf("%s/%s/%s", scanner, obj.GetUID(), obj.GetResourceVersion()))
+			}
+		}
+	}
+	sort.Strings(inputs.Reports)
+	data, err := json.Marshal(inputs)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// inputsChanged returns true unless the ClusterComplianceReport with the given
+// name was generated from inputs with the given hash, or its generation is
+// forced with v1alpha1.ComplianceForceGenerateAnnotation.
+func (w *cm) inputsChanged(ctx context.Context, name string, hash string) (bool, error) {
+	var report v1alpha1.ClusterComplianceReport
+	err := w.client.Get(ctx, types.NamespacedName{Name: strings.ToLower(name)}, &report)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	if report.Annotations[v1alpha1.ComplianceForceGenerateAnnotation] == "true" {
+		return true, nil
+	}
+	return report.Status.InputsHash != hash, nil
+}
+
+// removeForceGenerateAnnotation removes v1alpha1.ComplianceForceGenerateAnnotation
+// from the ClusterComplianceReport with the given name once it's generated, so
+// that the request is handled once, like a request of immediate generation.
+func (w *cm) removeForceGenerateAnnotation(ctx context.Context, name string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var report v1alpha1.ClusterComplianceReport
+		err := w.client.Get(ctx, types.NamespacedName{Name: strings.ToLower(name)}, &report)
+		if err != nil {
+			return err
+		}
+		if _, forced := report.Annotations[v1alpha1.ComplianceForceGenerateAnnotation]; !forced {
+			return nil
+		}
+		delete(report.Annotations, v1alpha1.ComplianceForceGenerateAnnotation)
+		return w.client.Update(ctx, &report)
+	})
+}
@@ -0,0 +1,183 @@
+package compliance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestInputsHash(t *testing.T) {
+	spec := v1alpha1.ReportSpec{
+		Name: "nsa",
+		Controls: []v1alpha1.Control{
+			{ID: "1.0", Name: "Non-root containers", Kinds: []string{"Pod"}, Severity: "MEDIUM",
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}}},
+		},
+	}
+	newReports := func(resourceVersions ...string) map[string]map[string]client.ObjectList {
+		list := &v1alpha1.ConfigAuditReportList{}
+		for i, resourceVersion := range resourceVersions {
+			list.Items = append(list.Items, v1alpha1.ConfigAuditReport{ObjectMeta: metav1.ObjectMeta{
+				UID:             types.UID([]string{"uid-a", "uid-b"}[i]),
+				ResourceVersion: resourceVersion,
+			}})
+		}
+		return map[string]map[string]client.ObjectList{ConfigAudit: {"Pod": list}}
+	}
+	mgr := cm{config: getStarboardConfig()}
+	smd := mgr.populateSpecDataToMaps(spec)
+
+	hash, err := mgr.inputsHash(spec, smd, nil, newReports("1", "2"))
+	require.NoError(t, err)
+
+	t.Run("Should return the same hash for the same inputs", func(t *testing.T) {
+		smd.notApplicableControls = map[string]string{}
+		other, err := mgr.inputsHash(spec, smd, nil, newReports("1", "2"))
+		require.NoError(t, err)
+		assert.Equal(t, hash, other)
+	})
+
+	t.Run("Should return another hash if a report is updated", func(t *testing.T) {
+		other, err := mgr.inputsHash(spec, smd, nil, newReports("1", "3"))
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, other)
+	})
+
+	t.Run("Should return another hash if the spec is changed", func(t *testing.T) {
+		changed := *spec.DeepCopy()
+		changed.ExcludedControls = []string{"1.0"}
+		other, err := mgr.inputsHash(changed, smd, nil, newReports("1", "2"))
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, other)
+	})
+
+	t.Run("Should return another hash if a control is waived", func(t *testing.T) {
+		waived := *smd
+		waived.controlWaivers = map[string]v1alpha1.ControlWaiver{"1.0": {Annotation: v1alpha1.ComplianceExcludeControlAnnotationPrefix + "1.0"}}
+		other, err := mgr.inputsHash(spec, &waived, nil, newReports("1", "2"))
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, other)
+	})
+
+	t.Run("Should return another hash if settings are changed", func(t *testing.T) {
+		for key, value := range map[string]string{"compliance.separateWarnings": "true", "compliance.failEntriesLimit": "20"} {
+			config := getStarboardConfig()
+			config[key] = value
+			other, err := (&cm{config: config}).inputsHash(spec, smd, nil, newReports("1", "2"))
+			require.NoError(t, err)
+			assert.NotEqual(t, hash, other, key)
+		}
+	})
+}
+
+func TestGenerateComplianceReportSkipsUnchangedInputs(t *testing.T) {
+	spec := v1alpha1.ReportSpec{
+		Name:        "nsa",
+		Description: "National Security Agency",
+		Version:     "1.0",
+		Cron:        "0 */6 * * *",
+		Controls: []v1alpha1.Control{
+			{ID: "1.0", Name: "Non-root containers", Kinds: []string{"Pod"}, Severity: "MEDIUM",
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}}},
+		},
+	}
+	report := &v1alpha1.ClusterComplianceReport{ObjectMeta: metav1.ObjectMeta{Name: "nsa"}, Spec: spec}
+	auditReport := &v1alpha1.ConfigAuditReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod-nginx",
+			Namespace: "default",
+			Labels:    map[string]string{starboard.LabelResourceKind: "Pod", starboard.LabelResourceName: "nginx"},
+		},
+		Report: v1alpha1.ConfigAuditReportData{
+			Checks: []v1alpha1.Check{{ID: "KSV012", Success: false}},
+		},
+	}
+	ctx := context.TODO()
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(report, auditReport).Build()
+	mgr := NewMgr(c, log.Log, getStarboardConfig(), nil, nil)
+	resourceVersions := func() (string, string) {
+		var report v1alpha1.ClusterComplianceReport
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "nsa"}, &report))
+		var detail v1alpha1.ClusterComplianceDetailReport
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "nsa-details"}, &detail))
+		return report.ResourceVersion, detail.ResourceVersion
+	}
+
+	require.NoError(t, mgr.GenerateComplianceReport(ctx, spec))
+	reportVersion, detailVersion := resourceVersions()
+
+	t.Run("Should skip generation if inputs did not change", func(t *testing.T) {
+		require.NoError(t, mgr.GenerateComplianceReport(ctx, spec))
+		newReportVersion, newDetailVersion := resourceVersions()
+		assert.Equal(t, reportVersion, newReportVersion)
+		assert.Equal(t, detailVersion, newDetailVersion)
+	})
+
+	t.Run("Should generate report if a scanner report is updated", func(t *testing.T) {
+		var updated v1alpha1.ConfigAuditReport
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "pod-nginx"}, &updated))
+		updated.Report.Checks[0].Success = true
+		require.NoError(t, c.Update(ctx, &updated))
+
+		require.NoError(t, mgr.GenerateComplianceReport(ctx, spec))
+		newReportVersion, newDetailVersion := resourceVersions()
+		assert.NotEqual(t, reportVersion, newReportVersion)
+		assert.NotEqual(t, detailVersion, newDetailVersion)
+		reportVersion, detailVersion = newReportVersion, newDetailVersion
+	})
+
+	t.Run("Should generate report if forced with annotation", func(t *testing.T) {
+		var forced v1alpha1.ClusterComplianceReport
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "nsa"}, &forced))
+		forced.Annotations = map[string]string{v1alpha1.ComplianceForceGenerateAnnotation: "true"}
+		require.NoError(t, c.Update(ctx, &forced))
+		reportVersion = forced.ResourceVersion
+
+		require.NoError(t, mgr.GenerateComplianceReport(ctx, spec))
+		newReportVersion, newDetailVersion := resourceVersions()
+		assert.NotEqual(t, reportVersion, newReportVersion)
+		assert.NotEqual(t, detailVersion, newDetailVersion)
+		reportVersion, detailVersion = newReportVersion, newDetailVersion
+
+		var generated v1alpha1.ClusterComplianceReport
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "nsa"}, &generated))
+		assert.NotContains(t, generated.Annotations, v1alpha1.ComplianceForceGenerateAnnotation)
+
+		require.NoError(t, mgr.GenerateComplianceReport(ctx, spec))
+		newReportVersion, newDetailVersion = resourceVersions()
+		assert.Equal(t, reportVersion, newReportVersion, "generation is skipped once forced")
+		assert.Equal(t, detailVersion, newDetailVersion, "generation is skipped once forced")
+	})
+}
+
+func TestUpdateScheduleAfterSkippedGeneration(t *testing.T) {
+	generated := time.Date(2022, 3, 27, 6, 0, 0, 0, time.UTC)
+	start := generated.Add(6 * time.Hour)
+	report := &v1alpha1.ClusterComplianceReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "nsa", CreationTimestamp: metav1.NewTime(generated.Add(-time.Hour))},
+		Spec:       v1alpha1.ReportSpec{Name: "nsa", Cron: "0 */6 * * *"},
+		Status:     v1alpha1.ReportStatus{UpdateTimestamp: metav1.NewTime(generated)},
+	}
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(report).Build()
+	reconciler := ClusterComplianceReportReconciler{Logger: log.Log, Client: c, Clock: ext.NewFixedClock(start)}
+
+	err := reconciler.updateSchedule(context.TODO(), types.NamespacedName{Name: "nsa"}, &generation{start: start, duration: time.Second})
+	require.NoError(t, err)
+
+	var updated v1alpha1.ClusterComplianceReport
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "nsa"}, &updated))
+	assert.True(t, updated.Status.UpdateTimestamp.Time.Equal(start))
+	assert.True(t, updated.Status.NextScheduleTime.Time.Equal(start.Add(6*time.Hour)))
+	assert.Equal(t, time.Second, updated.Status.LastGenerationDuration.Duration)
+}
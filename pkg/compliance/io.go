@@ -3,45 +3,76 @@ package compliance
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aquasecurity/starboard/pkg/starboard"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/emirpasic/gods/sets/hashset"
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 const (
 	ResourceDoNotExistInCluster = "Resource do not exist in cluster"
+	CheckNotAvailable           = "Check is not available in scanner results"
+	ControlExcluded             = "Control is excluded by the compliance spec"
+	ManualControl               = "Control must be assessed manually"
+	NoScannerResults            = "No results of the check were reported by scanners"
 )
 
 type Mgr interface {
 	GenerateComplianceReport(ctx context.Context, spec v1alpha1.ReportSpec) error
 }
 
-func NewMgr(client client.Client, log logr.Logger, config starboard.ConfigData) Mgr {
+// NewMgr constructs a Mgr. Metadata read with the given ClusterMetadataReader
+// is stamped into the status of compliance reports. It may be nil. Changes of
+// the status of compliance reports are recorded as events of the reports with
+// the given EventRecorder, which may be nil as well.
+func NewMgr(client client.Client, log logr.Logger, config starboard.ConfigData, clusterMetadata kube.ClusterMetadataReader, recorder record.EventRecorder) Mgr {
 	return &cm{
-		client: client,
-		log:    log,
-		config: config,
+		client:          client,
+		log:             log,
+		config:          config,
+		clusterMetadata: clusterMetadata,
+		recorder:        recorder,
 	}
 }
 
 type cm struct {
-	client client.Client
-	log    logr.Logger
-	config starboard.ConfigData
+	client          client.Client
+	log             logr.Logger
+	config          starboard.ConfigData
+	clusterMetadata kube.ClusterMetadataReader
+	recorder        record.EventRecorder
+
+	mu         sync.Mutex
+	lastEvents map[string]time.Time
 }
 
 type summaryTotal struct {
-	pass int
-	fail int
+	pass       int
+	fail       int
+	warn       int
+	bySeverity map[string]v1alpha1.ControlCount
+	score      *int
+	noResults  int
+	manual     int
+	errors     int
 }
 
 type specDataMapping struct {
@@ -49,18 +80,110 @@ type specDataMapping struct {
 	controlIDControlObject   map[string]v1alpha1.Control
 	controlCheckIds          map[string][]string
 	controlIdResources       map[string][]string
+	controlOptionalCheckIds  map[string]*hashset.Set
+	// controlCheckScanners maps IDs of checks of each control to the scanner
+	// which the check is mapped to
+	controlCheckScanners map[string]map[string]string
+	controlSpecNames     map[string]string
+	controlWaivers       map[string]v1alpha1.ControlWaiver
+	// unavailableScanners maps scanners whose results are unavailable to the reason
+	unavailableScanners map[string]string
+	// scannerErrors maps scanners whose results could not be mapped to checks to the error
+	scannerErrors map[string]string
+	// notApplicableControls maps controls which do not apply to the cluster to the reason
+	notApplicableControls map[string]string
+	// excludedControls maps IDs of controls excluded by the spec to the control
+	excludedControls map[string]v1alpha1.Control
+	// reportWarningsAsFail counts warnings as failures for controls which don't override it
+	reportWarningsAsFail bool
+	// separateWarnings counts warnings neither as passes nor as failures unless they're reported as failures
+	separateWarnings bool
+	// severityThreshold is the minimum severity of results for controls which don't override it
+	severityThreshold v1alpha1.Severity
 }
 
+// GenerateComplianceReport generates the compliance report of the given spec.
+// If generation fails, the ReadyCondition of the report is set to false with
+// the error, so that the report never reflects a stale result as ready.
 func (w *cm) GenerateComplianceReport(ctx context.Context, spec v1alpha1.ReportSpec) error {
-	// map specs to key/value map for easy processing
-	smd := w.populateSpecDataToMaps(spec)
+	err := w.generateComplianceReport(ctx, spec)
+	if err != nil {
+		condErr := setReadyCondition(ctx, w.client, strings.ToLower(spec.Name), generationErrorCondition(err))
+		if condErr != nil {
+			w.log.Error(condErr, "Unable to set ready condition of compliance report", "report", strings.ToLower(spec.Name))
+		}
+		return err
+	}
+	return nil
+}
+
+func (w *cm) generateComplianceReport(ctx context.Context, spec v1alpha1.ReportSpec) error {
+	// merge controls of included specs
+	resolvedSpec, controlSpecNames, err := w.resolveSpec(ctx, spec)
+	if err != nil {
+		return err
+	}
+	// reject unknown kinds rather than reporting controls without results
+	for _, control := range resolvedSpec.Controls {
+		if _, err := mapKinds(control); err != nil {
+			return fmt.Errorf("control %s: %w", control.ID, err)
+		}
+	}
+	// map specs to key/value map for easy processing, skipping excluded controls
+	smd := w.populateSpecDataToMaps(resolvedSpec)
+	smd.controlSpecNames = controlSpecNames
+	// exclude controls which do not apply to the cluster
+	cluster := w.readClusterMetadata(ctx)
+	smd.notApplicableControls = w.notApplicableControls(resolvedSpec, cluster)
+	// exclude waived controls from fail counts
+	smd.controlWaivers, err = w.activeControlWaivers(ctx, spec.Name)
+	if err != nil {
+		return err
+	}
 	// map compliance scanner to resource data
-	scannerResourceMap := mapComplianceScannerToResource(w.client, ctx, smd.scannerResourceListNames)
-	// organized data by check id and it aggregated results
-	checkIdsToResults, err := w.checkIdsToResults(scannerResourceMap)
+	scannerResourceMap, unavailableScanners, listErrors := mapComplianceScannerToResource(w.client, ctx, smd.scannerResourceListNames,
+		w.config.ComplianceScannerTimeout(), w.config.ComplianceListWorkers())
+	for scanner, reason := range unavailableScanners {
+		w.log.Info("Omitting unavailable scanner results", "scanner", scanner, "reason", reason)
+	}
+	for scanner, err := range listErrors {
+		w.log.V(1).Info("Omitting scanner results which cannot be listed", "scanner", scanner, "error", err.Error())
+	}
+	smd.unavailableScanners = unavailableScanners
+	// omit results of namespaces out of the scope of the report
+	scope, err := w.namespaceScope(ctx, resolvedSpec)
 	if err != nil {
 		return err
 	}
+	err = scope.filterReports(scannerResourceMap)
+	if err != nil {
+		return err
+	}
+	// skip generation if inputs did not change since the latest generation
+	hash, err := w.inputsHash(resolvedSpec, smd, cluster, scannerResourceMap)
+	if err != nil {
+		return err
+	}
+	changed, err := w.inputsChanged(ctx, spec.Name, hash)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		w.log.Info("Skipping generation of compliance report, no change", "report", strings.ToLower(spec.Name))
+		return nil
+	}
+	// organized data by check id and it aggregated results, controls of
+	// scanners whose results cannot be mapped are reported with errors
+	checkIdsToResults, scannerErrors := w.checkIdsToResults(scannerResourceMap)
+	for scanner, reason := range scannerErrors {
+		w.log.Info("Reporting controls of scanner with errors", "scanner", scanner, "reason", reason)
+	}
+	smd.scannerErrors = scannerErrors
+	scope.markClusterScoped(checkIdsToResults)
+	// count results reported for resources of the same root workload once
+	if removed := w.deduplicateResults(ctx, checkIdsToResults); removed > 0 {
+		w.log.V(1).Info("Removed duplicate results of root workloads", "report", strings.ToLower(spec.Name), "count", removed)
+	}
 	// map scanner checks results to control check results
 	controlChecks := w.controlChecksByScannerChecks(smd, checkIdsToResults)
 	// find summary totals
@@ -70,51 +193,352 @@ func (w *cm) GenerateComplianceReport(ctx context.Context, spec v1alpha1.ReportS
 	if err != nil {
 		return fmt.Errorf("failed to create compliance detail report name: %s with error %w", strings.ToLower(fmt.Sprintf("%s-%s", spec.Name, "details")), err)
 	}
-	//generate cluster compliance report
-	updatedReport, err := w.createComplianceReport(ctx, spec, st, controlChecks)
+	// update cluster compliance report status
+	status := w.complianceReportStatus(st, controlChecks)
+	status.Cluster = cluster
+	status.InputsHash = hash
+	status.Conditions = []metav1.Condition{degradedCondition(unavailableScanners), readyCondition(controlChecks)}
+	err = w.updateComplianceReportStatus(ctx, spec, status)
+	if err != nil {
+		return err
+	}
+	// update compliance reports of namespaces with results of namespaced controls
+	err = w.updateNamespaceReports(ctx, spec, smd, checkIdsToResults)
 	if err != nil {
 		return err
 	}
-	// update compliance report status
-	return w.client.Status().Update(ctx, updatedReport)
+	// consume the request to generate the report regardless of its inputs
+	return w.removeForceGenerateAnnotation(ctx, spec.Name)
+}
+
+// readClusterMetadata returns metadata of the cluster, or nil if it cannot be
+// read. Missing metadata never fails report generation.
+func (w *cm) readClusterMetadata(ctx context.Context) *v1alpha1.ClusterMetadata {
+	if w.clusterMetadata == nil {
+		return nil
+	}
+	metadata, err := w.clusterMetadata.Read(ctx)
+	if err != nil {
+		w.log.Error(err, "Unable to read cluster metadata")
+		return nil
+	}
+	return &metadata
+}
 
+// notApplicableControls returns controls of the given spec whose applicability
+// conditions are not met by the given cluster mapped to the reason. Controls
+// with invalid conditions are logged and treated as applicable.
+func (w *cm) notApplicableControls(spec v1alpha1.ReportSpec, cluster *v1alpha1.ClusterMetadata) map[string]string {
+	notApplicable := make(map[string]string)
+	for _, control := range spec.Controls {
+		reason, err := controlApplicability(control.Applicability, cluster)
+		if err != nil {
+			w.log.Error(err, "Ignoring invalid control applicability", "control", control.ID)
+			continue
+		}
+		if reason != "" {
+			notApplicable[control.ID] = reason
+		}
+	}
+	return notApplicable
 }
 
-//createComplianceReport create compliance report
-func (w *cm) createComplianceReport(ctx context.Context, spec v1alpha1.ReportSpec, st summaryTotal, controlChecks []v1alpha1.ControlCheck) (*v1alpha1.ClusterComplianceReport, error) {
+// complianceReportStatus returns the status of the compliance report with
+// the given summary totals and control checks.
+func (w *cm) complianceReportStatus(st summaryTotal, controlChecks []v1alpha1.ControlCheck) v1alpha1.ReportStatus {
 	statusControlChecks := make([]v1alpha1.ControlCheck, 0)
 	//check if status data should be updated
-	if st.fail > 0 || st.pass > 0 {
+	if st.fail > 0 || st.pass > 0 || hasStatus(controlChecks, v1alpha1.DataUnavailableStatus, v1alpha1.NotApplicableStatus, v1alpha1.ExcludedStatus,
+		v1alpha1.WarnStatus, v1alpha1.ManualStatus, v1alpha1.NoResultsStatus, v1alpha1.ErrorStatus) {
 		statusControlChecks = append(statusControlChecks, controlChecks...)
 	}
-	summary := v1alpha1.ClusterComplianceSummary{PassCount: st.pass, FailCount: st.fail}
-	report := v1alpha1.ClusterComplianceReport{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: strings.ToLower(spec.Name),
-		},
-		Status: v1alpha1.ReportStatus{UpdateTimestamp: metav1.NewTime(ext.NewSystemClock().Now()), Summary: summary, ControlChecks: statusControlChecks},
+	return v1alpha1.ReportStatus{UpdateTimestamp: metav1.NewTime(ext.NewSystemClock().Now()), Summary: complianceSummary(st), ControlChecks: statusControlChecks}
+}
+
+// hasStatus returns true if any of the given control checks has any of the
+// given statuses.
+func hasStatus(controlChecks []v1alpha1.ControlCheck, statuses ...v1alpha1.ControlStatus) bool {
+	for _, controlCheck := range controlChecks {
+		for _, status := range statuses {
+			if controlCheck.Status == status {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// degradedCondition returns the DegradedCondition of the compliance report,
+// which lists scanners whose results were omitted from the report.
+func degradedCondition(unavailableScanners map[string]string) metav1.Condition {
+	if len(unavailableScanners) == 0 {
+		return metav1.Condition{
+			Type:    v1alpha1.DegradedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "AllScannersAvailable",
+			Message: "Results of all scanners are included in the report",
+		}
 	}
+	reasons := make([]string, 0, len(unavailableScanners))
+	for _, reason := range unavailableScanners {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	return metav1.Condition{
+		Type:    v1alpha1.DegradedCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ScannerTimeout",
+		Message: strings.Join(reasons, "; "),
+	}
+}
+
+// readyCondition returns the ReadyCondition of the compliance report with the
+// given control checks, which is false if none of the controls has results of
+// scanners, e.g. because all of them have errors. Controls which are excluded,
+// do not apply to the cluster or must be assessed manually are not expected to
+// have results and are ignored.
+func readyCondition(controlChecks []v1alpha1.ControlCheck) metav1.Condition {
+	var noResults, withResults int
+	for _, controlCheck := range controlChecks {
+		switch controlCheck.Status {
+		case v1alpha1.ExcludedStatus, v1alpha1.NotApplicableStatus, v1alpha1.ManualStatus:
+		case v1alpha1.NoResultsStatus, v1alpha1.ErrorStatus:
+			noResults++
+		default:
+			withResults++
+		}
+	}
+	if noResults > 0 && withResults == 0 {
+		return metav1.Condition{
+			Type:    v1alpha1.ReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  v1alpha1.ReasonScannerResultsMissing,
+			Message: "None of the controls has results of scanners",
+		}
+	}
+	return metav1.Condition{
+		Type:    v1alpha1.ReadyCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Generated",
+		Message: "The report was generated",
+	}
+}
+
+// generationErrorCondition returns the ReadyCondition of a compliance report
+// whose generation failed with the given error.
+func generationErrorCondition(err error) metav1.Condition {
+	return metav1.Condition{
+		Type:    v1alpha1.ReadyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.ReasonGenerationError,
+		Message: err.Error(),
+	}
+}
+
+// setReadyCondition sets the given ReadyCondition of the compliance report with
+// the specified name. A false condition also resets the hash of inputs of the
+// report, so that the next generation is not skipped. Reports which do not
+// exist are ignored.
+func setReadyCondition(ctx context.Context, c client.Client, name string, condition metav1.Condition) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var report v1alpha1.ClusterComplianceReport
+		err := c.Get(ctx, types.NamespacedName{Name: name}, &report)
+		if err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		current := meta.FindStatusCondition(report.Status.Conditions, condition.Type)
+		if current != nil && current.Status == condition.Status && current.Reason == condition.Reason &&
+			current.Message == condition.Message && (condition.Status == metav1.ConditionTrue || report.Status.InputsHash == "") {
+			return nil
+		}
+		meta.SetStatusCondition(&report.Status.Conditions, condition)
+		if condition.Status == metav1.ConditionFalse {
+			report.Status.InputsHash = ""
+		}
+		return c.Status().Update(ctx, &report)
+	})
+}
+
+// updateComplianceReportStatus writes the given status of the compliance report
+// of the given spec via the status subresource. The spec is owned by users, and
+// may be changed while the report is generated, therefore it's never written by
+// the controller. The report is created with the given spec if it does not
+// exist, e.g. if generation is driven by another controller with a spec loaded
+// from a file. The update is retried with the latest version of the report on
+// conflict. If the results changed since the previous generation, the change is
+// described with the starboard.AnnotationChangelog annotation. A snapshot of the
+// status is kept in its history if the compliance.historyLimit setting is set.
+func (w *cm) updateComplianceReportStatus(ctx context.Context, spec v1alpha1.ReportSpec, status v1alpha1.ReportStatus) error {
 	var existing v1alpha1.ClusterComplianceReport
-	err := w.client.Get(ctx, types.NamespacedName{
-		Name: strings.ToLower(spec.Name),
-	}, &existing)
+	var previous v1alpha1.ReportStatus
+	var changed bool
+	err := retry.OnError(retry.DefaultRetry, func(err error) bool {
+		// another writer may have created the report in the meantime
+		return errors.IsConflict(err) || errors.IsAlreadyExists(err)
+	}, func() error {
+		var err error
+		existing, err = w.getOrCreateComplianceReport(ctx, spec)
+		if err != nil {
+			return err
+		}
+		// keep transition times of conditions whose status did not change
+		conditions := existing.Status.Conditions
+		for _, condition := range status.Conditions {
+			meta.SetStatusCondition(&conditions, condition)
+		}
+		// the schedule is maintained by the reconciler
+		status.NextScheduleTime = existing.Status.NextScheduleTime
+		status.LastGenerationDuration = existing.Status.LastGenerationDuration
+		previous = existing.Status
+		existing.Status = status
+		existing.Status.Conditions = conditions
+		changed = trackStatusChanges(&existing.Status, previous)
+		// the history is pruned with the latest version of the report, so
+		// that overlapping generations conflict rather than drop snapshots
+		existing.Status.History = appendHistory(previous.History, existing.Status, w.config.ComplianceHistoryLimit())
+		return w.client.Status().Update(ctx, &existing)
+	})
+	if err != nil {
+		return err
+	}
+	w.recordStatusEvents(&existing, previous)
+	if changed && !previous.UpdateTimestamp.IsZero() {
+		return w.annotateChangelog(ctx, &existing, previous)
+	}
+	return nil
+}
+
+// getOrCreateComplianceReport returns the compliance report of the given
+// spec, which is created with the spec if it does not exist, e.g. because
+// the report is generated for a spec only.
+func (w *cm) getOrCreateComplianceReport(ctx context.Context, spec v1alpha1.ReportSpec) (v1alpha1.ClusterComplianceReport, error) {
+	name := strings.ToLower(spec.Name)
+	var report v1alpha1.ClusterComplianceReport
+	err := w.client.Get(ctx, types.NamespacedName{Name: name}, &report)
+	if err == nil {
+		return report, nil
+	}
+	if !errors.IsNotFound(err) {
+		return report, fmt.Errorf("getting compliance report %s: %w", name, err)
+	}
+	report = v1alpha1.ClusterComplianceReport{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec}
+	err = w.client.Create(ctx, &report)
 	if err != nil {
-		return nil, fmt.Errorf("compliance crd with name %s is missing", spec.Name)
+		return report, fmt.Errorf("creating compliance report %s: %w", name, err)
 	}
-	copied := existing.DeepCopy()
-	copied.Labels = report.Labels
-	copied.Status = report.Status
-	copied.Spec = spec
-	copied.Status.UpdateTimestamp = metav1.NewTime(ext.NewSystemClock().Now())
-	return copied, nil
+	return report, nil
 }
 
-//createComplianceDetailReport create and publish compliance details report
+// trackStatusChanges counts the generation of the given status, which is
+// written over the previous one, and advances its LastChangedTimestamp if its
+// results differ from the previous ones. It returns true if the results changed.
+func trackStatusChanges(status *v1alpha1.ReportStatus, previous v1alpha1.ReportStatus) bool {
+	status.GenerationCount = previous.GenerationCount + 1
+	if !previous.UpdateTimestamp.IsZero() &&
+		equality.Semantic.DeepEqual(status.Summary, previous.Summary) &&
+		equality.Semantic.DeepEqual(status.ControlChecks, previous.ControlChecks) &&
+		equality.Semantic.DeepEqual(status.Cluster, previous.Cluster) {
+		status.LastChangedTimestamp = previous.LastChangedTimestamp
+		if status.LastChangedTimestamp == nil {
+			status.LastChangedTimestamp = previous.UpdateTimestamp.DeepCopy()
+		}
+		return false
+	}
+	status.LastChangedTimestamp = status.UpdateTimestamp.DeepCopy()
+	return true
+}
+
+// appendHistory prepends the snapshot of the given status to the history and
+// prunes snapshots beyond the limit. Zero limit means that no history is kept.
+func appendHistory(history []v1alpha1.ComplianceSnapshot, status v1alpha1.ReportStatus, limit int) []v1alpha1.ComplianceSnapshot {
+	if limit <= 0 {
+		return nil
+	}
+	snapshot := v1alpha1.ComplianceSnapshot{
+		UpdateTimestamp: status.UpdateTimestamp,
+		Summary:         status.Summary,
+	}
+	for _, controlCheck := range status.ControlChecks {
+		snapshot.ControlChecks = append(snapshot.ControlChecks, v1alpha1.ControlCheckTotals{
+			ID:        controlCheck.ID,
+			PassTotal: controlCheck.PassTotal,
+			FailTotal: controlCheck.FailTotal,
+			Status:    controlCheck.Status,
+		})
+	}
+	history = append([]v1alpha1.ComplianceSnapshot{snapshot}, history...)
+	if len(history) > limit {
+		history = history[:limit]
+	}
+	return history
+}
+
+// annotateChangelog sets the starboard.AnnotationChangelog annotation of the
+// given compliance report, whose results changed since the previous status.
+// The update is retried with the latest version of the report on conflict, so
+// that the spec, which is owned by users, is never overwritten.
+func (w *cm) annotateChangelog(ctx context.Context, report *v1alpha1.ClusterComplianceReport, previous v1alpha1.ReportStatus) error {
+	current := report.Status.Summary
+	changelog := starboard.Changelog(report.Status.GenerationCount,
+		starboard.CountChange{Name: "passCount", Previous: previous.Summary.PassCount, Current: current.PassCount},
+		starboard.CountChange{Name: "failCount", Previous: previous.Summary.FailCount, Current: current.FailCount},
+	)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var latest v1alpha1.ClusterComplianceReport
+		err := w.client.Get(ctx, types.NamespacedName{Name: report.Name}, &latest)
+		if err != nil {
+			return err
+		}
+		if latest.Annotations == nil {
+			latest.Annotations = make(map[string]string)
+		}
+		latest.Annotations[starboard.AnnotationChangelog] = changelog
+		return w.client.Update(ctx, &latest)
+	})
+	if err != nil {
+		return fmt.Errorf("annotating compliance report %s: %w", report.Name, err)
+	}
+	return nil
+}
+
+// activeControlWaivers returns waivers granted with annotations of the compliance
+// report with the specified name, which have not expired yet. Invalid waivers are
+// logged and ignored, so that they never hide failures.
+func (w *cm) activeControlWaivers(ctx context.Context, name string) (map[string]v1alpha1.ControlWaiver, error) {
+	var report v1alpha1.ClusterComplianceReport
+	err := w.client.Get(ctx, types.NamespacedName{Name: strings.ToLower(name)}, &report)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	waivers, err := controlWaivers(report.Annotations)
+	if err != nil {
+		w.log.Error(err, "Ignoring invalid control waivers", "report", report.Name)
+	}
+	return activeWaivers(waivers, ext.NewSystemClock().Now()), nil
+}
+
+//createComplianceDetailReport create and publish compliance details report,
+//which is owned by the ClusterComplianceReport, so that it's garbage
+//collected along with it
 func (w *cm) createComplianceDetailReport(ctx context.Context, spec v1alpha1.ReportSpec, smd *specDataMapping, checkIdsToResults map[string][]*ScannerCheckResult, st summaryTotal) error {
 	controlChecksDetails := w.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
 	name := strings.ToLower(fmt.Sprintf("%s-%s", spec.Name, "details"))
+	// the details report is written before the status of its owner, which
+	// may not exist yet
+	var owner v1alpha1.ClusterComplianceReport
+	err := retry.OnError(retry.DefaultRetry, errors.IsAlreadyExists, func() error {
+		var err error
+		owner, err = w.getOrCreateComplianceReport(ctx, spec)
+		return err
+	})
+	if err != nil {
+		return err
+	}
 	// compliance details report
-	summary := v1alpha1.ClusterComplianceSummary{PassCount: st.pass, FailCount: st.fail}
+	summary := complianceSummary(st)
 	report := v1alpha1.ClusterComplianceDetailReport{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: name,
@@ -124,9 +548,13 @@ func (w *cm) createComplianceDetailReport(ctx context.Context, spec v1alpha1.Rep
 			Type:          v1alpha1.Compliance{Name: name, Description: strings.ToLower(spec.Description), Version: spec.Version},
 			ControlChecks: controlChecksDetails},
 	}
+	err = controllerutil.SetOwnerReference(&owner, &report, w.client.Scheme())
+	if err != nil {
+		return err
+	}
 
 	var existing v1alpha1.ClusterComplianceDetailReport
-	err := w.client.Get(ctx, types.NamespacedName{
+	err = w.client.Get(ctx, types.NamespacedName{
 		Name: name,
 	}, &existing)
 
@@ -135,157 +563,647 @@ func (w *cm) createComplianceDetailReport(ctx context.Context, spec v1alpha1.Rep
 		copied.Labels = report.Labels
 		copied.Report = report.Report
 		copied.Report.UpdateTimestamp = metav1.NewTime(ext.NewSystemClock().Now())
+		// reports created before they were owned, or owned by a deleted and
+		// recreated ClusterComplianceReport, are adopted by the current one
+		err = controllerutil.SetOwnerReference(&owner, copied, w.client.Scheme())
+		if err != nil {
+			return err
+		}
 		return w.client.Update(ctx, copied)
 	}
 
 	if errors.IsNotFound(err) {
 		return w.client.Create(ctx, &report)
 	}
-	return nil
+	return err
 }
 
-// getTotals return control check totals
+// getTotals return control check totals and the number of passing and failing
+// controls by severity. A control fails if any of its checks fails. Controls
+// with a status, i.e. not applicable, unavailable, waived or excluded, are not
+// counted. The score is the percentage of passing controls, where controls
+// without results are not counted. Controls without scanner results, controls
+// which must be assessed manually and controls with errors are counted
+// separately.
 func (w *cm) getTotals(controlChecks []v1alpha1.ControlCheck) summaryTotal {
-	var totalFail, totalPass int
-	if len(controlChecks) > 0 {
-		for _, controlCheck := range controlChecks {
-			totalFail = totalFail + controlCheck.FailTotal
-			totalPass = totalPass + controlCheck.PassTotal
+	var totalFail, totalPass, totalWarn, scoredControls, passedControls, noResults, manual, errors int
+	bySeverity := make(map[string]v1alpha1.ControlCount)
+	for _, controlCheck := range controlChecks {
+		totalFail = totalFail + controlCheck.FailTotal
+		totalPass = totalPass + controlCheck.PassTotal
+		totalWarn = totalWarn + controlCheck.WarnTotal
+		switch controlCheck.Status {
+		case v1alpha1.NoResultsStatus:
+			noResults++
+		case v1alpha1.ManualStatus:
+			manual++
+		case v1alpha1.ErrorStatus:
+			errors++
+		}
+		if controlCheck.Status != "" {
+			continue
+		}
+		severity := controlCheck.Severity
+		if severity == "" {
+			severity = v1alpha1.SeverityUnknown
+		}
+		count := bySeverity[string(severity)]
+		if controlCheck.FailTotal > 0 {
+			count.Fail++
+		} else {
+			count.Pass++
+		}
+		bySeverity[string(severity)] = count
+		if controlCheck.PassTotal+controlCheck.FailTotal > 0 {
+			scoredControls++
+			if controlCheck.FailTotal == 0 {
+				passedControls++
+			}
 		}
 	}
-	return summaryTotal{fail: totalFail, pass: totalPass}
+	return summaryTotal{fail: totalFail, pass: totalPass, warn: totalWarn, bySeverity: bySeverity, score: percentage(passedControls, scoredControls), noResults: noResults,
+		manual: manual, errors: errors}
+}
+
+// percentage returns part of total in percent rounded to an integer, or nil
+// if total is zero.
+func percentage(part, total int) *int {
+	if total == 0 {
+		return nil
+	}
+	p := int(math.Round(100 * float64(part) / float64(total)))
+	return &p
+}
+
+// complianceSummary returns the summary of a compliance report with the given
+// totals. Failing controls of severities other than critical, high, medium and
+// low are counted as unknown.
+func complianceSummary(st summaryTotal) v1alpha1.ClusterComplianceSummary {
+	summary := v1alpha1.ClusterComplianceSummary{PassCount: st.pass, FailCount: st.fail, WarnCount: st.warn, SummaryBySeverity: st.bySeverity, Score: st.score,
+		ManualCount: st.manual, ErrorCount: st.errors, NoResultsCount: st.noResults}
+	for severity, count := range st.bySeverity {
+		switch v1alpha1.Severity(severity) {
+		case v1alpha1.SeverityCritical:
+			summary.CriticalFailCount += count.Fail
+		case v1alpha1.SeverityHigh:
+			summary.HighFailCount += count.Fail
+		case v1alpha1.SeverityMedium:
+			summary.MediumFailCount += count.Fail
+		case v1alpha1.SeverityLow:
+			summary.LowFailCount += count.Fail
+		default:
+			summary.UnknownFailCount += count.Fail
+		}
+	}
+	return summary
 }
 
 // controlChecksByScannerChecks build control checks list by parsing test results and mapping it to relevant scanner
 func (w *cm) controlChecksByScannerChecks(smd *specDataMapping, checkIdsToResults map[string][]*ScannerCheckResult) []v1alpha1.ControlCheck {
 	controlChecks := make([]v1alpha1.ControlCheck, 0)
-	if len(checkIdsToResults) == 0 {
-		return controlChecks
-	}
 	for controlID, checkIds := range smd.controlCheckIds {
-		var passTotal, failTotal, total int
-		for _, checkId := range checkIds {
-			results, ok := checkIdsToResults[checkId]
-			if ok {
-				for _, checkResult := range results {
-					for _, crd := range checkResult.Details {
-						switch crd.Status {
-						case v1alpha1.PassStatus, v1alpha1.WarnStatus:
-							passTotal++
-						case v1alpha1.FailStatus:
-							failTotal++
-						}
-						total++
-					}
-				}
-			}
-		}
 		control, ok := smd.controlIDControlObject[controlID]
 		if ok {
-			if passTotal == 0 && failTotal == 0 {
-				if control.DefaultStatus == v1alpha1.FailStatus {
+			if _, notApplicable := smd.notApplicableControls[controlID]; notApplicable {
+				controlChecks = append(controlChecks, v1alpha1.ControlCheck{ID: controlID,
+					Name:        control.Name,
+					Description: control.Description,
+					Severity:    control.Severity,
+					Status:      v1alpha1.NotApplicableStatus})
+				continue
+			}
+			if control.Manual {
+				controlChecks = append(controlChecks, v1alpha1.ControlCheck{ID: controlID,
+					Name:        control.Name,
+					Description: control.Description,
+					Severity:    control.Severity,
+					Status:      v1alpha1.ManualStatus})
+				continue
+			}
+			if _, unavailable := smd.unavailableReason(control); unavailable {
+				controlChecks = append(controlChecks, v1alpha1.ControlCheck{ID: controlID,
+					Name:        control.Name,
+					Description: control.Description,
+					Severity:    control.Severity,
+					Status:      v1alpha1.DataUnavailableStatus})
+				continue
+			}
+			if reason, failed := smd.scannerError(control); failed {
+				controlChecks = append(controlChecks, v1alpha1.ControlCheck{ID: controlID,
+					Name:        control.Name,
+					Description: control.Description,
+					Severity:    control.Severity,
+					Status:      v1alpha1.ErrorStatus,
+					Error:       reason})
+				continue
+			}
+			results, _ := smd.controlResults(control, checkIds, checkIdsToResults)
+			passTotal, failTotal := aggregateChecks(control.Mapping.Aggregation, checkIds, results, smd.warnCounting(control))
+			var status v1alpha1.ControlStatus
+			if noScannerResults(control, checkIds, checkIdsToResults, results) {
+				status = v1alpha1.NoResultsStatus
+			} else if !hasCheckResults(checkIds, results) && len(smd.missingRequiredChecks(controlID, checkIds, results)) > 0 {
+				switch control.DefaultStatus {
+				case v1alpha1.FailStatus:
 					failTotal = 1
-				}
-				if control.DefaultStatus == v1alpha1.PassStatus {
+				case v1alpha1.PassStatus:
 					passTotal = 1
+				case v1alpha1.WarnStatus, v1alpha1.ManualStatus:
+					status = control.DefaultStatus
 				}
 			}
-			controlChecks = append(controlChecks, v1alpha1.ControlCheck{ID: controlID,
+			controlCheck := v1alpha1.ControlCheck{ID: controlID,
 				Name:        control.Name,
 				Description: control.Description,
 				Severity:    control.Severity,
 				PassTotal:   passTotal,
-				FailTotal:   failTotal})
+				FailTotal:   failTotal,
+				WarnTotal:   countWarnings(checkIds, results),
+				Status:      status}
+			if waiver, ok := smd.controlWaivers[controlID]; ok {
+				controlCheck.FailTotal = 0
+				controlCheck.Status = v1alpha1.WaivedStatus
+				controlCheck.Waiver = &waiver
+			}
+			controlCheck.Score = percentage(controlCheck.PassTotal, controlCheck.PassTotal+controlCheck.FailTotal)
+			controlChecks = append(controlChecks, controlCheck)
 		}
 	}
+	for controlID, control := range smd.excludedControls {
+		controlChecks = append(controlChecks, v1alpha1.ControlCheck{ID: controlID,
+			Name:        control.Name,
+			Description: control.Description,
+			Severity:    control.Severity,
+			Status:      v1alpha1.ExcludedStatus})
+	}
 	return controlChecks
 }
 
+// warnCounting represents how results with the WARN status are counted
+type warnCounting int
+
+const (
+	// warnAsPass counts warnings as passes, which is the default
+	warnAsPass warnCounting = iota
+	// warnAsFail counts warnings as failures
+	warnAsFail
+	// warnSeparately counts warnings neither as passes nor as failures
+	warnSeparately
+)
+
+// aggregateChecks return control pass and fail totals by applying the control aggregation operator on mapped checks results.
+// warnings are counted according to the given warnCounting
+func aggregateChecks(aggregation v1alpha1.Aggregation, checkIds []string, checkIdsToResults map[string][]*ScannerCheckResult, warnings warnCounting) (int, int) {
+	switch aggregation {
+	case v1alpha1.AllOfAggregation, v1alpha1.AnyOfAggregation:
+		return aggregateByResource(aggregation, statusesByResource(checkIds, checkIdsToResults), warnings)
+	default:
+		return countChecks(checkIds, checkIdsToResults, warnings)
+	}
+}
+
+// countedStatus return the status which a check result is counted with, i.e. the pass or fail status for a warning,
+// or the warn status if warnings are counted separately
+func countedStatus(status v1alpha1.ControlStatus, warnings warnCounting) v1alpha1.ControlStatus {
+	if status != v1alpha1.WarnStatus {
+		return status
+	}
+	switch warnings {
+	case warnAsFail:
+		return v1alpha1.FailStatus
+	case warnSeparately:
+		return v1alpha1.WarnStatus
+	}
+	return v1alpha1.PassStatus
+}
+
+// countChecks sum pass and fail statuses of all mapped checks results
+func countChecks(checkIds []string, checkIdsToResults map[string][]*ScannerCheckResult, warnings warnCounting) (int, int) {
+	var passTotal, failTotal int
+	for _, checkId := range checkIds {
+		results, ok := checkIdsToResults[checkId]
+		if !ok {
+			continue
+		}
+		for _, checkResult := range results {
+			for _, crd := range checkResult.Details {
+				switch countedStatus(crd.Status, warnings) {
+				case v1alpha1.PassStatus:
+					passTotal++
+				case v1alpha1.FailStatus:
+					failTotal++
+				}
+			}
+		}
+	}
+	return passTotal, failTotal
+}
+
+// countWarnings sum warn statuses of all mapped checks results
+func countWarnings(checkIds []string, checkIdsToResults map[string][]*ScannerCheckResult) int {
+	var warnTotal int
+	for _, checkId := range checkIds {
+		for _, checkResult := range checkIdsToResults[checkId] {
+			for _, crd := range checkResult.Details {
+				if crd.Status == v1alpha1.WarnStatus {
+					warnTotal++
+				}
+			}
+		}
+	}
+	return warnTotal
+}
+
+// statusesByResource group mapped checks results statuses by the resource they were reported for
+func statusesByResource(checkIds []string, checkIdsToResults map[string][]*ScannerCheckResult) map[string][]v1alpha1.ControlStatus {
+	resourceStatuses := make(map[string][]v1alpha1.ControlStatus)
+	for _, checkId := range checkIds {
+		results, ok := checkIdsToResults[checkId]
+		if !ok {
+			continue
+		}
+		for _, checkResult := range results {
+			for _, crd := range checkResult.Details {
+				key := fmt.Sprintf("%s/%s/%s", checkResult.ObjectType, crd.Namespace, crd.Name)
+				resourceStatuses[key] = append(resourceStatuses[key], crd.Status)
+			}
+		}
+	}
+	return resourceStatuses
+}
+
+// aggregateByResource count each resource once as pass or fail according to the aggregation operator.
+// checks which did not report a result for a resource are not taken into account for that resource
+func aggregateByResource(aggregation v1alpha1.Aggregation, resourceStatuses map[string][]v1alpha1.ControlStatus, warnings warnCounting) (int, int) {
+	var passTotal, failTotal int
+	for _, statuses := range resourceStatuses {
+		var pass, fail int
+		for _, status := range statuses {
+			switch countedStatus(status, warnings) {
+			case v1alpha1.PassStatus:
+				pass++
+			case v1alpha1.FailStatus:
+				fail++
+			}
+		}
+		if pass == 0 && fail == 0 {
+			continue
+		}
+		switch aggregation {
+		case v1alpha1.AllOfAggregation:
+			if fail > 0 {
+				failTotal++
+			} else {
+				passTotal++
+			}
+		case v1alpha1.AnyOfAggregation:
+			if pass > 0 {
+				passTotal++
+			} else {
+				failTotal++
+			}
+		}
+	}
+	return passTotal, failTotal
+}
+
 // controlChecksDetailsByScannerChecks build control checks with details list by parsing test results and mapping it to relevant tool
 func (w *cm) controlChecksDetailsByScannerChecks(smd *specDataMapping, checkIdsToResults map[string][]*ScannerCheckResult) []v1alpha1.ControlCheckDetails {
 	controlChecks := make([]v1alpha1.ControlCheckDetails, 0)
-	if len(checkIdsToResults) == 0 {
-		return controlChecks
-	}
 	for controlID, checkIds := range smd.controlCheckIds {
 		control, ok := smd.controlIDControlObject[controlID]
 		if ok {
+			if reason, notApplicable := smd.notApplicableControls[controlID]; notApplicable {
+				controlChecks = append(controlChecks, v1alpha1.ControlCheckDetails{ID: controlID,
+					Name:               control.Name,
+					Description:        control.Description,
+					Severity:           control.Severity,
+					Spec:               smd.controlSpecNames[controlID],
+					ScannerCheckResult: unassessedScanResults(smd, controlID, checkIds, v1alpha1.NotApplicableStatus, reason)})
+				continue
+			}
+			if control.Manual {
+				controlChecks = append(controlChecks, v1alpha1.ControlCheckDetails{ID: controlID,
+					Name:               control.Name,
+					Description:        control.Description,
+					Severity:           control.Severity,
+					Spec:               smd.controlSpecNames[controlID],
+					ScannerCheckResult: manualScanResults(smd, controlID)})
+				continue
+			}
+			if reason, unavailable := smd.unavailableReason(control); unavailable {
+				controlChecks = append(controlChecks, v1alpha1.ControlCheckDetails{ID: controlID,
+					Name:               control.Name,
+					Description:        control.Description,
+					Severity:           control.Severity,
+					Spec:               smd.controlSpecNames[controlID],
+					ScannerCheckResult: unassessedScanResults(smd, controlID, checkIds, v1alpha1.DataUnavailableStatus, reason)})
+				continue
+			}
+			if reason, failed := smd.scannerError(control); failed {
+				controlChecks = append(controlChecks, v1alpha1.ControlCheckDetails{ID: controlID,
+					Name:               control.Name,
+					Description:        control.Description,
+					Severity:           control.Severity,
+					Spec:               smd.controlSpecNames[controlID],
+					ScannerCheckResult: unassessedScanResults(smd, controlID, checkIds, v1alpha1.ErrorStatus, reason)})
+				continue
+			}
+			waiver, waived := smd.controlWaivers[controlID]
+			results, excludedNamespaces := smd.controlResults(control, checkIds, checkIdsToResults)
+			missingChecks := smd.missingRequiredChecks(controlID, checkIds, results)
+			if noScannerResults(control, checkIds, checkIdsToResults, results) {
+				details := v1alpha1.ControlCheckDetails{ID: controlID,
+					Name:               control.Name,
+					Description:        control.Description,
+					Severity:           control.Severity,
+					Spec:               smd.controlSpecNames[controlID],
+					ScannerCheckResult: unassessedScanResults(smd, controlID, checkIds, v1alpha1.NoResultsStatus, NoScannerResults),
+					MissingChecks:      missingChecks,
+					ExcludedNamespaces: excludedNamespaces}
+				if waived {
+					details.Waiver = &waiver
+				}
+				controlChecks = append(controlChecks, details)
+				continue
+			}
+			reported := false
 			for _, checkId := range checkIds {
-				results, ok := checkIdsToResults[checkId]
+				checkResults, ok := results[checkId]
 				ctta := make([]v1alpha1.ScannerCheckResult, 0)
 				if ok {
-					scr := w.createScanCheckResult(results)
+					scr := w.createScanCheckResult(checkResults, smd.warnCounting(control))
 					ctta = append(ctta, scr...)
-				} else {
-					w.createDefaultScanResult(smd, control, controlID, &ctta)
+				} else if smd.isOptionalCheck(controlID, checkId) {
+					w.createNotAvailableScanResult(smd, controlID, checkId, &ctta)
+				} else if !hasCheckResults(checkIds, results) {
+					w.createDefaultScanResult(smd, control, controlID, smd.checkScanner(controlID, checkId), &ctta)
 				}
 				if len(ctta) > 0 {
-					controlChecks = append(controlChecks, v1alpha1.ControlCheckDetails{ID: controlID,
+					details := v1alpha1.ControlCheckDetails{ID: controlID,
 						Name:               control.Name,
 						Description:        control.Description,
 						Severity:           control.Severity,
-						ScannerCheckResult: ctta})
+						Spec:               smd.controlSpecNames[controlID],
+						ScannerCheckResult: ctta,
+						MissingChecks:      missingChecks,
+						ExcludedNamespaces: excludedNamespaces}
+					if waived {
+						waiveFailures(ctta)
+						details.Waiver = &waiver
+					}
+					controlChecks = append(controlChecks, details)
+					reported = true
 				}
 			}
+			// record the waiver, missing checks and skipped namespaces for audit even if the control has no failures
+			if (waived || len(missingChecks) > 0 || len(excludedNamespaces) > 0) && !reported {
+				details := v1alpha1.ControlCheckDetails{ID: controlID,
+					Name:               control.Name,
+					Description:        control.Description,
+					Severity:           control.Severity,
+					Spec:               smd.controlSpecNames[controlID],
+					ScannerCheckResult: make([]v1alpha1.ScannerCheckResult, 0),
+					MissingChecks:      missingChecks,
+					ExcludedNamespaces: excludedNamespaces}
+				if waived {
+					details.Waiver = &waiver
+				}
+				controlChecks = append(controlChecks, details)
+			}
+		}
+	}
+	// record excluded controls for audit, although they are not evaluated
+	for controlID, control := range smd.excludedControls {
+		checkIds := make([]string, 0)
+		for _, mapping := range scannerMappings(control.Mapping) {
+			for _, check := range mapping.Checks {
+				checkIds = append(checkIds, check.ID)
+			}
 		}
+		controlChecks = append(controlChecks, v1alpha1.ControlCheckDetails{ID: controlID,
+			Name:               control.Name,
+			Description:        control.Description,
+			Severity:           control.Severity,
+			Spec:               smd.controlSpecNames[controlID],
+			ScannerCheckResult: unassessedScanResults(smd, controlID, checkIds, v1alpha1.ExcludedStatus, ControlExcluded)})
 	}
 	return controlChecks
 }
 
-func (w *cm) createDefaultScanResult(smd *specDataMapping, control v1alpha1.Control, controlID string, ctta *[]v1alpha1.ScannerCheckResult) {
+// waiveFailures report failed checks of a waived control with the waived status
+func waiveFailures(results []v1alpha1.ScannerCheckResult) {
+	for i := range results {
+		for j := range results[i].Details {
+			if results[i].Details[j].Status == v1alpha1.FailStatus {
+				results[i].Details[j].Status = v1alpha1.WaivedStatus
+			}
+		}
+	}
+}
+
+func (w *cm) createDefaultScanResult(smd *specDataMapping, control v1alpha1.Control, controlID string, scanner string, ctta *[]v1alpha1.ScannerCheckResult) {
 	if control.DefaultStatus == v1alpha1.FailStatus {
 		resources := smd.controlIdResources[controlID]
 		for _, resource := range resources {
-			ctt := v1alpha1.ScannerCheckResult{ObjectType: resource, Details: []v1alpha1.ResultDetails{{Msg: ResourceDoNotExistInCluster, Status: v1alpha1.FailStatus}}}
+			ctt := v1alpha1.ScannerCheckResult{ObjectType: resource, Scanner: scanner, Details: []v1alpha1.ResultDetails{{Msg: ResourceDoNotExistInCluster, Status: v1alpha1.FailStatus}}}
 			*ctta = append(*ctta, ctt)
 		}
 	}
 }
 
-func (w *cm) createScanCheckResult(results []*ScannerCheckResult) []v1alpha1.ScannerCheckResult {
+func (w *cm) createNotAvailableScanResult(smd *specDataMapping, controlID string, checkId string, ctta *[]v1alpha1.ScannerCheckResult) {
+	for _, resource := range smd.controlIdResources[controlID] {
+		ctt := v1alpha1.ScannerCheckResult{ID: checkId, ObjectType: resource, Scanner: smd.checkScanner(controlID, checkId), Details: []v1alpha1.ResultDetails{{Msg: CheckNotAvailable, Status: v1alpha1.NotAvailableStatus}}}
+		*ctta = append(*ctta, ctt)
+	}
+}
+
+// unassessedScanResults report each check of the control with the given status for each mapped resource
+func unassessedScanResults(smd *specDataMapping, controlID string, checkIds []string, status v1alpha1.ControlStatus, reason string) []v1alpha1.ScannerCheckResult {
+	ctta := make([]v1alpha1.ScannerCheckResult, 0)
+	for _, checkId := range checkIds {
+		for _, resource := range smd.controlIdResources[controlID] {
+			ctta = append(ctta, v1alpha1.ScannerCheckResult{ID: checkId, ObjectType: resource, Scanner: smd.checkScanner(controlID, checkId), Details: []v1alpha1.ResultDetails{{Msg: reason, Status: status}}})
+		}
+	}
+	return ctta
+}
+
+// manualScanResults report each mapped resource of a manual control with the manual status
+func manualScanResults(smd *specDataMapping, controlID string) []v1alpha1.ScannerCheckResult {
+	ctta := make([]v1alpha1.ScannerCheckResult, 0)
+	for _, resource := range smd.controlIdResources[controlID] {
+		ctta = append(ctta, v1alpha1.ScannerCheckResult{ObjectType: resource, Details: []v1alpha1.ResultDetails{{Msg: ManualControl, Status: v1alpha1.ManualStatus}}})
+	}
+	return ctta
+}
+
+// checkScanner return the scanner which the check of the control is mapped to
+func (smd *specDataMapping) checkScanner(controlID string, checkId string) string {
+	return smd.controlCheckScanners[controlID][checkId]
+}
+
+// unavailableReason return the reason why results of any of the scanners mapped
+// to the control are unavailable, so that the control is not assessed partially
+func (smd *specDataMapping) unavailableReason(control v1alpha1.Control) (string, bool) {
+	for _, mapping := range scannerMappings(control.Mapping) {
+		if reason, unavailable := smd.unavailableScanners[mapping.Scanner]; unavailable {
+			return reason, true
+		}
+	}
+	return "", false
+}
+
+// scannerError return the error of any of the scanners mapped to the control
+// whose results could not be mapped, so that the control is not assessed partially
+func (smd *specDataMapping) scannerError(control v1alpha1.Control) (string, bool) {
+	for _, mapping := range scannerMappings(control.Mapping) {
+		if reason, failed := smd.scannerErrors[mapping.Scanner]; failed {
+			return reason, true
+		}
+	}
+	return "", false
+}
+
+// isOptionalCheck return true if the check is marked as optional in the control mapping
+func (smd *specDataMapping) isOptionalCheck(controlID string, checkId string) bool {
+	optionalCheckIds, ok := smd.controlOptionalCheckIds[controlID]
+	return ok && optionalCheckIds.Contains(checkId)
+}
+
+// noScannerResults return true if there are no scanner results at all, e.g. before the first scan completed, or if the
+// control has no default status and none of its checks has results of any resource, so that the control is reported as
+// not evaluated rather than as passed without failures. Results of the control are given along with results of all
+// controls, which are keyed by checkKey
+func noScannerResults(control v1alpha1.Control, checkIds []string, checkIdsToResults map[string][]*ScannerCheckResult, results map[string][]*ScannerCheckResult) bool {
+	if len(checkIdsToResults) == 0 {
+		return true
+	}
+	if control.DefaultStatus != "" {
+		return false
+	}
+	return !hasCheckResults(checkIds, results)
+}
+
+// hasCheckResults return true if any of the checks has results of any resource. The default status of a control applies
+// only if none of its checks has results, so that partial results are not overridden by the default status
+func hasCheckResults(checkIds []string, checkIdsToResults map[string][]*ScannerCheckResult) bool {
+	for _, checkId := range checkIds {
+		if _, ok := checkIdsToResults[checkId]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// warnCounting return how warnings are counted for the control. Warnings reported as failures take precedence over
+// counting them separately
+func (smd *specDataMapping) warnCounting(control v1alpha1.Control) warnCounting {
+	warningsAsFail := smd.reportWarningsAsFail
+	if control.ReportWarningsAsFail != nil {
+		warningsAsFail = *control.ReportWarningsAsFail
+	}
+	switch {
+	case warningsAsFail:
+		return warnAsFail
+	case smd.separateWarnings:
+		return warnSeparately
+	}
+	return warnAsPass
+}
+
+// minSeverity return the minimum severity of results of the control, where the threshold of the control takes
+// precedence over the threshold of the spec. Results are not filtered if it's empty
+func (smd *specDataMapping) minSeverity(control v1alpha1.Control) v1alpha1.Severity {
+	if control.SeverityThreshold != "" {
+		return control.SeverityThreshold
+	}
+	return smd.severityThreshold
+}
+
+// missingRequiredChecks return the control checks which are not optional and are missing in scanner results
+func (smd *specDataMapping) missingRequiredChecks(controlID string, checkIds []string, checkIdsToResults map[string][]*ScannerCheckResult) []string {
+	var missing []string
+	for _, checkId := range checkIds {
+		if _, ok := checkIdsToResults[checkId]; ok {
+			continue
+		}
+		if !smd.isOptionalCheck(controlID, checkId) {
+			missing = append(missing, checkId)
+		}
+	}
+	return missing
+}
+
+// createScanCheckResult return failed results of a check, including warnings unless they're counted as passes. Results
+// keep the status reported by the scanner, e.g. WARN. Results beyond the fail entries limit are truncated, preferring
+// failures over warnings, and the check result is marked as truncated along with the total count of results
+func (w *cm) createScanCheckResult(results []*ScannerCheckResult, warnings warnCounting) []v1alpha1.ScannerCheckResult {
+	limit := w.config.ComplianceFailEntriesLimit()
 	ctta := make([]v1alpha1.ScannerCheckResult, 0)
 	for _, checkResult := range results {
 		var ctt v1alpha1.ScannerCheckResult
 		failedResultEntries := make([]v1alpha1.ResultDetails, 0)
 		for _, crd := range checkResult.Details {
-			if len(failedResultEntries) >= w.config.ComplianceFailEntriesLimit() {
-				continue
-			}
 			//control check detail relevant to fail checks only
-			if crd.Status == v1alpha1.PassStatus || crd.Status == v1alpha1.WarnStatus {
+			if countedStatus(crd.Status, warnings) == v1alpha1.PassStatus {
 				continue
 			}
-			failedResultEntries = append(failedResultEntries, v1alpha1.ResultDetails{Name: crd.Name, Namespace: crd.Namespace, Msg: crd.Msg, Status: crd.Status})
+			failedResultEntries = append(failedResultEntries, v1alpha1.ResultDetails{Name: crd.Name, Namespace: crd.Namespace, Msg: crd.Msg, Status: crd.Status, Severity: crd.Severity, ClusterScoped: crd.ClusterScoped})
 		}
 		if len(failedResultEntries) > 0 {
-			ctt = v1alpha1.ScannerCheckResult{ID: checkResult.ID, ObjectType: checkResult.ObjectType, Remediation: checkResult.Remediation, Details: failedResultEntries}
+			ctt = v1alpha1.ScannerCheckResult{ID: checkResult.ID, ObjectType: checkResult.ObjectType, Scanner: checkResult.Scanner, Remediation: checkResult.Remediation, Details: failedResultEntries}
+			if len(failedResultEntries) > limit {
+				sort.SliceStable(failedResultEntries, func(i, j int) bool {
+					return statusRankOf(failedResultEntries[i].Status) > statusRankOf(failedResultEntries[j].Status)
+				})
+				ctt.Details = failedResultEntries[:limit]
+				ctt.Truncated = true
+				ctt.TotalCount = len(failedResultEntries)
+			}
 			ctta = append(ctta, ctt)
 		}
 	}
 	return ctta
 }
 
-func (w *cm) checkIdsToResults(scannerResourceMap map[string]map[string]client.ObjectList) (map[string][]*ScannerCheckResult, error) {
+// checkIdsToResults maps results of scanners by scanners and check IDs, see
+// checkKey, so that results of checks of different scanners with the same ID
+// are not mixed up. Scanners whose results cannot be mapped are returned with
+// the error instead of failing the whole report, so that only their controls
+// are reported with errors.
+func (w *cm) checkIdsToResults(scannerResourceMap map[string]map[string]client.ObjectList) (map[string][]*ScannerCheckResult, map[string]string) {
 	checkIdsToResults := make(map[string][]*ScannerCheckResult)
+	scannerErrors := make(map[string]string)
 	for scanner, resourceListMap := range scannerResourceMap {
+		mapper, err := byScanner(scanner)
+		if err != nil {
+			scannerErrors[scanner] = err.Error()
+			continue
+		}
 		for resourceName, resourceList := range resourceListMap {
-			mapper, err := byScanner(scanner)
-			if err != nil {
-				return nil, err
-			}
-			idCheckResultMap := mapper.mapReportData(resourceName, resourceList)
+			idCheckResultMap := mapper.MapReportData(resourceName, resourceList)
 			if idCheckResultMap == nil {
 				continue
 			}
 			for id, scannerCheckResult := range idCheckResultMap {
-				if _, ok := checkIdsToResults[id]; !ok {
-					checkIdsToResults[id] = make([]*ScannerCheckResult, 0)
+				scannerCheckResult.Scanner = scanner
+				key := checkKey(scanner, id)
+				if _, ok := checkIdsToResults[key]; !ok {
+					checkIdsToResults[key] = make([]*ScannerCheckResult, 0)
 				}
-				checkIdsToResults[id] = append(checkIdsToResults[id], scannerCheckResult)
+				checkIdsToResults[key] = append(checkIdsToResults[key], scannerCheckResult)
 			}
 		}
 	}
-	return checkIdsToResults, nil
+	return checkIdsToResults, scannerErrors
+}
+
+// checkKey returns the key of results of the check of the scanner, e.g.
+// kube-bench/1.2.22
+func checkKey(scanner string, checkId string) string {
+	return scanner + "/" + checkId
 }
 
 //populateSpecDataToMaps populate spec data to map structures
@@ -298,31 +1216,79 @@ func (w *cm) populateSpecDataToMaps(spec v1alpha1.ReportSpec) *specDataMapping {
 	scannerResourceListName := make(map[string]*hashset.Set)
 	//controlOID to resources
 	controlIdResources := make(map[string][]string)
+	//control to optional checks map
+	controlOptionalCheckIds := make(map[string]*hashset.Set)
+	//control to scanners of checks map
+	controlCheckScanners := make(map[string]map[string]string)
+	//excluded control IDs
+	excludedControlIds := hashset.New()
+	for _, controlID := range spec.ExcludedControls {
+		excludedControlIds.Add(controlID)
+	}
+	var excludedControls map[string]v1alpha1.Control
 	for _, control := range spec.Controls {
-		control.Kinds = mapKinds(control)
-		if _, ok := scannerResourceListName[control.Mapping.Scanner]; !ok {
-			scannerResourceListName[control.Mapping.Scanner] = hashset.New()
+		// kinds of resolved specs are validated before generation
+		control.Kinds, _ = mapKinds(control)
+		mappings := scannerMappings(control.Mapping)
+		controlCheckScanners[control.ID] = make(map[string]string)
+		for _, mapping := range mappings {
+			for _, check := range mapping.Checks {
+				controlCheckScanners[control.ID][check.ID] = mapping.Scanner
+			}
+		}
+		if excludedControlIds.Contains(control.ID) {
+			if excludedControls == nil {
+				excludedControls = make(map[string]v1alpha1.Control)
+			}
+			excludedControls[control.ID] = control
+			controlIdResources[control.ID] = control.Kinds
+			continue
+		}
+		if control.Manual {
+			// manual controls have no checks, but are reported nevertheless
+			controlIdResources[control.ID] = control.Kinds
+			controlIDControlObject[control.ID] = control
+			controlCheckIds[control.ID] = make([]string, 0)
+			continue
 		}
 		if _, ok := controlIdResources[control.ID]; !ok {
 			controlIdResources[control.ID] = make([]string, 0)
 		}
-		for _, resource := range control.Kinds {
-			scannerResourceListName[control.Mapping.Scanner].Add(resource)
-			controlIdResources[control.ID] = append(controlIdResources[control.ID], resource)
-		}
+		controlIdResources[control.ID] = append(controlIdResources[control.ID], control.Kinds...)
 		controlIDControlObject[control.ID] = control
-		//update control resource list map
-		for _, check := range control.Mapping.Checks {
-			if _, ok := controlCheckIds[control.ID]; !ok {
-				controlCheckIds[control.ID] = make([]string, 0)
+		for _, mapping := range mappings {
+			if _, ok := scannerResourceListName[mapping.Scanner]; !ok {
+				scannerResourceListName[mapping.Scanner] = hashset.New()
+			}
+			for _, resource := range control.Kinds {
+				if reportsKind(mapping.Scanner, resource) {
+					scannerResourceListName[mapping.Scanner].Add(resource)
+				}
+			}
+			//update control resource list map
+			for _, check := range mapping.Checks {
+				if _, ok := controlCheckIds[control.ID]; !ok {
+					controlCheckIds[control.ID] = make([]string, 0)
+				}
+				controlCheckIds[control.ID] = append(controlCheckIds[control.ID], check.ID)
+				if check.Optional {
+					if _, ok := controlOptionalCheckIds[control.ID]; !ok {
+						controlOptionalCheckIds[control.ID] = hashset.New()
+					}
+					controlOptionalCheckIds[control.ID].Add(check.ID)
+				}
 			}
-			controlCheckIds[control.ID] = append(controlCheckIds[control.ID], check.ID)
 		}
-
 	}
 	return &specDataMapping{
 		scannerResourceListNames: scannerResourceListName,
 		controlIDControlObject:   controlIDControlObject,
 		controlCheckIds:          controlCheckIds,
-		controlIdResources:       controlIdResources}
+		controlIdResources:       controlIdResources,
+		controlOptionalCheckIds:  controlOptionalCheckIds,
+		controlCheckScanners:     controlCheckScanners,
+		excludedControls:         excludedControls,
+		reportWarningsAsFail:     spec.ReportWarningsAsFail,
+		separateWarnings:         w.config.ComplianceSeparateWarnings(),
+		severityThreshold:        spec.SeverityThreshold}
 }
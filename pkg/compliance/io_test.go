@@ -2,18 +2,36 @@ package compliance
 
 import (
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	//"github.com/stretchr/testify/assert"
+	"context"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/permissions"
+	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/emirpasic/gods/sets/hashset"
+	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	"gopkg.in/yaml.v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestPopulateSpecDataToMaps(t *testing.T) {
@@ -26,10 +44,10 @@ func TestPopulateSpecDataToMaps(t *testing.T) {
 		wantMappedData *specDataMapping
 	}{
 		{name: "spec file with good format", ids: []string{"1.0", "8.1"}, scanners: []string{"config-audit", "kube-bench"}, specPath: "./testdata/fixture/nsa-1.0.yaml", wantMappedData: &specDataMapping{
-			scannerResourceListNames: map[string]*hashset.Set{"config-audit": hashset.New("Job", "Pod", "ReplicationController", "ReplicaSet", "StatefulSet", "DaemonSet", "CronJob"),
+			scannerResourceListNames: map[string]*hashset.Set{"config-audit": hashset.New("Job", "Pod", "ReplicationController", "ReplicaSet", "Deployment", "StatefulSet", "DaemonSet", "CronJob", "Rollout"),
 				"kube-bench": hashset.New("Node")},
 			controlIDControlObject: map[string]v1alpha1.Control{"1.0": {ID: "1.0", Name: "Non-root containers",
-				Kinds: []string{"Job", "Pod", "ReplicationController", "ReplicaSet", "StatefulSet", "DaemonSet", "CronJob"}, Severity: "MEDIUM",
+				Kinds: []string{"Job", "Pod", "ReplicationController", "ReplicaSet", "Deployment", "StatefulSet", "DaemonSet", "CronJob", "Rollout"}, Severity: "MEDIUM",
 				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}}}, "8.1": {ID: "8.1", Name: "Audit log path is configure",
 				Kinds:   []string{"Node"},
 				Mapping: v1alpha1.Mapping{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{{ID: "1.2.22"}}}, Severity: "MEDIUM"}},
@@ -55,6 +73,11 @@ func TestPopulateSpecDataToMaps(t *testing.T) {
 				if !cmp.Equal(pd.controlIDControlObject, tt.wantMappedData.controlIDControlObject, option()) {
 					t.Errorf("TestPopulateSpecDataToMaps want %v got %v", tt.wantMappedData.controlIDControlObject, pd.controlIDControlObject)
 				}
+				for scanner, kinds := range tt.wantMappedData.scannerResourceListNames {
+					if assert.Contains(t, pd.scannerResourceListNames, scanner) {
+						assert.ElementsMatch(t, kinds.Values(), pd.scannerResourceListNames[scanner].Values())
+					}
+				}
 			}
 		})
 	}
@@ -69,6 +92,23 @@ func option() cmp.Option {
 	return trans
 }
 
+func TestPopulateSpecDataToMaps_WorkloadKind(t *testing.T) {
+	mgr := cm{}
+	smd := mgr.populateSpecDataToMaps(v1alpha1.ReportSpec{
+		Name: "nsa",
+		Controls: []v1alpha1.Control{
+			{ID: "1.0", Name: "Non-root containers", Kinds: []string{"workloads"}, Severity: "MEDIUM",
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}}},
+			{ID: "2.0", Name: "Network policies", Kinds: []string{"networkpolicy", "Deployments"}, Severity: "MEDIUM",
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV038"}}}},
+		},
+	})
+	require.Contains(t, smd.scannerResourceListNames, "config-audit")
+	assert.ElementsMatch(t, []interface{}{"Pod", "ReplicationController", "ReplicaSet", "Deployment", "StatefulSet", "DaemonSet",
+		"CronJob", "Job", "Rollout", "NetworkPolicy"}, smd.scannerResourceListNames["config-audit"].Values())
+	assert.ElementsMatch(t, []string{"NetworkPolicy", "Deployment"}, smd.controlIDControlObject["2.0"].Kinds)
+}
+
 func TestControlChecksByScannerChecks(t *testing.T) {
 	mgr := cm{}
 	tests := []struct {
@@ -78,10 +118,10 @@ func TestControlChecksByScannerChecks(t *testing.T) {
 		want             []v1alpha1.ControlCheck
 	}{
 		{name: " control checks by scanner checks", specPath: "./testdata/fixture/nsa-1.0.yaml", want: []v1alpha1.ControlCheck{{ID: "1.0", Name: "Non-root containers",
-			PassTotal: 1, FailTotal: 0, Severity: "MEDIUM"}, {ID: "8.1", Name: "Audit log path is configure", PassTotal: 0, FailTotal: 1, Severity: "MEDIUM"}},
+			PassTotal: 1, FailTotal: 0, Severity: "MEDIUM", Score: pointer.Int(100)}, {ID: "8.1", Name: "Audit log path is configure", PassTotal: 0, FailTotal: 1, Severity: "MEDIUM", Score: pointer.Int(0)}},
 			mapScannerResult: map[string][]*ScannerCheckResult{
-				"KSV012": {{ID: "1.0", Remediation: "aaa", Details: []ResultDetails{{Status: "PASS"}}}},
-				"1.2.22": {{ID: "2.0", Remediation: "bbb", Details: []ResultDetails{{Status: "FAIL"}}}},
+				checkKey(ConfigAudit, "KSV012"): {{ID: "1.0", Remediation: "aaa", Details: []ResultDetails{{Status: "PASS"}}}},
+				checkKey(KubeBench, "1.2.22"):   {{ID: "2.0", Remediation: "bbb", Details: []ResultDetails{{Status: "FAIL"}}}},
 			}}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -99,6 +139,1308 @@ func TestControlChecksByScannerChecks(t *testing.T) {
 	}
 }
 
+func TestAggregateChecks(t *testing.T) {
+	checkIdsToResults := map[string][]*ScannerCheckResult{
+		"KSV001": {{ID: "KSV001", ObjectType: "Pod", Details: []ResultDetails{
+			{Name: "pod-a", Namespace: "default", Status: v1alpha1.PassStatus},
+			{Name: "pod-b", Namespace: "default", Status: v1alpha1.FailStatus},
+			{Name: "pod-c", Namespace: "default", Status: v1alpha1.FailStatus},
+		}}},
+		"KSV002": {{ID: "KSV002", ObjectType: "Pod", Details: []ResultDetails{
+			{Name: "pod-a", Namespace: "default", Status: v1alpha1.FailStatus},
+			{Name: "pod-b", Namespace: "default", Status: v1alpha1.PassStatus},
+		}}},
+		"1.1.1": {{ID: "1.1.1", ObjectType: "Node", Details: []ResultDetails{
+			{Name: "node-1", Status: v1alpha1.WarnStatus},
+			{Name: "node-2", Status: v1alpha1.PassStatus},
+		}}},
+		"1.1.2": {{ID: "1.1.2", ObjectType: "Node", Details: []ResultDetails{
+			{Name: "node-1", Status: v1alpha1.PassStatus},
+			{Name: "node-2", Status: v1alpha1.PassStatus},
+		}}},
+	}
+	tests := []struct {
+		name        string
+		aggregation v1alpha1.Aggregation
+		checkIds    []string
+		warnings    warnCounting
+		wantPass    int
+		wantFail    int
+	}{
+		{name: "default sums all checks results", checkIds: []string{"KSV001", "KSV002"}, wantPass: 2, wantFail: 3},
+		{name: "count sums all checks results", aggregation: v1alpha1.CountAggregation, checkIds: []string{"KSV001", "KSV002"}, wantPass: 2, wantFail: 3},
+		{name: "allOf with resource missing from some checks", aggregation: v1alpha1.AllOfAggregation, checkIds: []string{"KSV001", "KSV002"}, wantPass: 0, wantFail: 3},
+		{name: "anyOf with resource missing from some checks", aggregation: v1alpha1.AnyOfAggregation, checkIds: []string{"KSV001", "KSV002"}, wantPass: 2, wantFail: 1},
+		{name: "allOf with single check", aggregation: v1alpha1.AllOfAggregation, checkIds: []string{"KSV002"}, wantPass: 1, wantFail: 1},
+		{name: "anyOf with no results", aggregation: v1alpha1.AnyOfAggregation, checkIds: []string{"KSV003"}, wantPass: 0, wantFail: 0},
+		{name: "count warnings as passes", checkIds: []string{"1.1.1", "1.1.2"}, wantPass: 4, wantFail: 0},
+		{name: "count warnings as failures", checkIds: []string{"1.1.1", "1.1.2"}, warnings: warnAsFail, wantPass: 3, wantFail: 1},
+		{name: "allOf with warnings as failures", aggregation: v1alpha1.AllOfAggregation, checkIds: []string{"1.1.1", "1.1.2"}, warnings: warnAsFail, wantPass: 1, wantFail: 1},
+		{name: "anyOf with warnings as failures", aggregation: v1alpha1.AnyOfAggregation, checkIds: []string{"1.1.1", "1.1.2"}, warnings: warnAsFail, wantPass: 2, wantFail: 0},
+		{name: "count warnings separately", checkIds: []string{"1.1.1", "1.1.2"}, warnings: warnSeparately, wantPass: 3, wantFail: 0},
+		{name: "allOf with warnings counted separately", aggregation: v1alpha1.AllOfAggregation, checkIds: []string{"1.1.1", "1.1.2"}, warnings: warnSeparately, wantPass: 2, wantFail: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pass, fail := aggregateChecks(tt.aggregation, tt.checkIds, checkIdsToResults, tt.warnings)
+			assert.Equal(t, tt.wantPass, pass)
+			assert.Equal(t, tt.wantFail, fail)
+		})
+	}
+}
+
+func TestWarningsAsFail(t *testing.T) {
+	mgr := cm{config: getStarboardConfig()}
+	notAsFail := false
+	spec := v1alpha1.ReportSpec{
+		Name:                 "cis",
+		ReportWarningsAsFail: true,
+		Controls: []v1alpha1.Control{
+			{ID: "1.1", Name: "Audit logging", Kinds: []string{"Node"}, Severity: "HIGH",
+				Mapping: v1alpha1.Mapping{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{{ID: "1.2.22"}}}},
+			{ID: "1.2", Name: "Audit log aging", Kinds: []string{"Node"}, Severity: "MEDIUM", ReportWarningsAsFail: &notAsFail,
+				Mapping: v1alpha1.Mapping{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{{ID: "1.2.23"}}}},
+		},
+	}
+	checkIdsToResults := map[string][]*ScannerCheckResult{
+		checkKey(KubeBench, "1.2.22"): {{ID: "1.2.22", ObjectType: "Node", Scanner: "kube-bench", Details: []ResultDetails{
+			{Name: "node-1", Msg: "Verify audit logging manually", Status: v1alpha1.WarnStatus},
+			{Name: "node-2", Status: v1alpha1.PassStatus}}}},
+		checkKey(KubeBench, "1.2.23"): {{ID: "1.2.23", ObjectType: "Node", Scanner: "kube-bench", Details: []ResultDetails{
+			{Name: "node-1", Msg: "Verify audit log aging manually", Status: v1alpha1.WarnStatus}}}},
+	}
+	smd := mgr.populateSpecDataToMaps(spec)
+
+	controlChecks := mgr.controlChecksByScannerChecks(smd, checkIdsToResults)
+	sort.Sort(scannerCheckSort(controlChecks))
+	assert.Equal(t, []v1alpha1.ControlCheck{
+		{ID: "1.1", Name: "Audit logging", Severity: "HIGH", PassTotal: 1, FailTotal: 1, WarnTotal: 1, Score: pointer.Int(50)},
+		{ID: "1.2", Name: "Audit log aging", Severity: "MEDIUM", PassTotal: 1, WarnTotal: 1, Score: pointer.Int(100)},
+	}, controlChecks)
+
+	t.Run("Should report warnings counted as failures with their original status", func(t *testing.T) {
+		details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+		require.Len(t, details, 1)
+		assert.Equal(t, []v1alpha1.ScannerCheckResult{
+			{ID: "1.2.22", ObjectType: "Node", Scanner: "kube-bench", Details: []v1alpha1.ResultDetails{
+				{Name: "node-1", Msg: "Verify audit logging manually", Status: v1alpha1.WarnStatus}}},
+		}, details[0].ScannerCheckResult)
+	})
+}
+
+func TestSeparateWarnings(t *testing.T) {
+	checkIdsToResults := map[string][]*ScannerCheckResult{
+		checkKey(KubeBench, "1.2.22"): {{ID: "1.2.22", ObjectType: "Node", Scanner: "kube-bench", Details: []ResultDetails{
+			{Name: "node-1", Status: v1alpha1.PassStatus},
+			{Name: "node-2", Status: v1alpha1.PassStatus},
+			{Name: "node-3", Status: v1alpha1.PassStatus},
+			{Name: "node-4", Status: v1alpha1.WarnStatus},
+			{Name: "node-5", Status: v1alpha1.WarnStatus},
+			{Name: "node-6", Status: v1alpha1.FailStatus}}}},
+	}
+	testCases := []struct {
+		name           string
+		config         starboard.ConfigData
+		warningsAsFail bool
+		wantPass       int
+		wantFail       int
+		wantDetails    []string
+	}{
+		{
+			name:        "Should count warnings as passes by default",
+			config:      starboard.ConfigData{},
+			wantPass:    5,
+			wantFail:    1,
+			wantDetails: []string{"node-6"},
+		},
+		{
+			name:        "Should count warnings separately",
+			config:      starboard.ConfigData{"compliance.separateWarnings": "true"},
+			wantPass:    3,
+			wantFail:    1,
+			wantDetails: []string{"node-4", "node-5", "node-6"},
+		},
+		{
+			name:           "Should count warnings as failures when they're reported as failures",
+			config:         starboard.ConfigData{"compliance.separateWarnings": "true"},
+			warningsAsFail: true,
+			wantPass:       3,
+			wantFail:       3,
+			wantDetails:    []string{"node-4", "node-5", "node-6"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mgr := cm{config: tc.config}
+			smd := mgr.populateSpecDataToMaps(v1alpha1.ReportSpec{
+				Name:                 "cis",
+				ReportWarningsAsFail: tc.warningsAsFail,
+				Controls: []v1alpha1.Control{
+					{ID: "1.1", Name: "Audit logging", Kinds: []string{"Node"}, Severity: "HIGH",
+						Mapping: v1alpha1.Mapping{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{{ID: "1.2.22"}}}},
+				},
+			})
+
+			controlChecks := mgr.controlChecksByScannerChecks(smd, checkIdsToResults)
+			require.Len(t, controlChecks, 1)
+			assert.Equal(t, tc.wantPass, controlChecks[0].PassTotal)
+			assert.Equal(t, tc.wantFail, controlChecks[0].FailTotal)
+			assert.Equal(t, 2, controlChecks[0].WarnTotal)
+
+			summary := complianceSummary(mgr.getTotals(controlChecks))
+			assert.Equal(t, tc.wantPass, summary.PassCount)
+			assert.Equal(t, tc.wantFail, summary.FailCount)
+			assert.Equal(t, 2, summary.WarnCount)
+
+			details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+			require.Len(t, details, 1)
+			var names []string
+			for _, result := range details[0].ScannerCheckResult {
+				for _, detail := range result.Details {
+					names = append(names, detail.Name)
+				}
+			}
+			assert.ElementsMatch(t, tc.wantDetails, names)
+		})
+	}
+}
+
+func TestTruncateDetails(t *testing.T) {
+	mgr := cm{config: starboard.ConfigData{"compliance.failEntriesLimit": "2", "compliance.separateWarnings": "true"}}
+	smd := mgr.populateSpecDataToMaps(v1alpha1.ReportSpec{
+		Name: "cis",
+		Controls: []v1alpha1.Control{
+			{ID: "1.1", Name: "Audit logging", Kinds: []string{"Node"}, Severity: "HIGH",
+				Mapping: v1alpha1.Mapping{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{{ID: "1.2.22"}}}},
+		},
+	})
+	checkIdsToResults := map[string][]*ScannerCheckResult{
+		checkKey(KubeBench, "1.2.22"): {{ID: "1.2.22", ObjectType: "Node", Scanner: "kube-bench", Details: []ResultDetails{
+			{Name: "node-1", Status: v1alpha1.WarnStatus},
+			{Name: "node-2", Status: v1alpha1.PassStatus},
+			{Name: "node-3", Status: v1alpha1.FailStatus},
+			{Name: "node-4", Status: v1alpha1.WarnStatus},
+			{Name: "node-5", Status: v1alpha1.FailStatus},
+			{Name: "node-6", Status: v1alpha1.FailStatus}}}},
+	}
+
+	t.Run("Should keep failures and mark check result as truncated", func(t *testing.T) {
+		details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+		require.Len(t, details, 1)
+		assert.Equal(t, []v1alpha1.ScannerCheckResult{
+			{ID: "1.2.22", ObjectType: "Node", Scanner: "kube-bench", Truncated: true, TotalCount: 5, Details: []v1alpha1.ResultDetails{
+				{Name: "node-3", Status: v1alpha1.FailStatus},
+				{Name: "node-5", Status: v1alpha1.FailStatus}}},
+		}, details[0].ScannerCheckResult)
+	})
+
+	t.Run("Should count all results", func(t *testing.T) {
+		summary := complianceSummary(mgr.getTotals(mgr.controlChecksByScannerChecks(smd, checkIdsToResults)))
+		assert.Equal(t, 1, summary.PassCount)
+		assert.Equal(t, 3, summary.FailCount)
+		assert.Equal(t, 2, summary.WarnCount)
+	})
+
+	t.Run("Should not truncate results within limit", func(t *testing.T) {
+		mgr := cm{config: starboard.ConfigData{"compliance.failEntriesLimit": "5", "compliance.separateWarnings": "true"}}
+		details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+		require.Len(t, details, 1)
+		require.Len(t, details[0].ScannerCheckResult, 1)
+		assert.False(t, details[0].ScannerCheckResult[0].Truncated)
+		assert.Zero(t, details[0].ScannerCheckResult[0].TotalCount)
+		assert.Len(t, details[0].ScannerCheckResult[0].Details, 5)
+	})
+}
+
+func TestOptionalChecks(t *testing.T) {
+	mgr := cm{config: getStarboardConfig()}
+	spec := v1alpha1.ReportSpec{
+		Name: "nsa",
+		Controls: []v1alpha1.Control{
+			{ID: "1.0", Name: "Non-root containers", Kinds: []string{"Pod"}, Severity: "MEDIUM", DefaultStatus: v1alpha1.FailStatus,
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}, {ID: "KSV999", Optional: true}}}},
+			{ID: "2.0", Name: "Forward-looking check", Kinds: []string{"Pod"}, Severity: "LOW", DefaultStatus: v1alpha1.FailStatus,
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV999", Optional: true}}}},
+			{ID: "3.0", Name: "Required missing check", Kinds: []string{"Pod"}, Severity: "LOW", DefaultStatus: v1alpha1.FailStatus,
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV998"}}}},
+		},
+	}
+	checkIdsToResults := map[string][]*ScannerCheckResult{
+		checkKey(ConfigAudit, "KSV012"): {{ID: "KSV012", ObjectType: "Pod", Details: []ResultDetails{{Name: "pod-a", Namespace: "default", Status: v1alpha1.FailStatus}}}},
+	}
+	smd := mgr.populateSpecDataToMaps(spec)
+
+	controlChecks := mgr.controlChecksByScannerChecks(smd, checkIdsToResults)
+	sort.Sort(scannerCheckSort(controlChecks))
+	assert.Equal(t, []v1alpha1.ControlCheck{
+		{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM", PassTotal: 0, FailTotal: 1, Score: pointer.Int(0)},
+		{ID: "2.0", Name: "Forward-looking check", Severity: "LOW", PassTotal: 0, FailTotal: 0},
+		{ID: "3.0", Name: "Required missing check", Severity: "LOW", PassTotal: 0, FailTotal: 1, Score: pointer.Int(0)},
+	}, controlChecks)
+
+	details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+	notAvailable := make(map[string][]v1alpha1.ScannerCheckResult)
+	for _, detail := range details {
+		for _, result := range detail.ScannerCheckResult {
+			if result.Details[0].Status == v1alpha1.NotAvailableStatus {
+				notAvailable[detail.ID] = append(notAvailable[detail.ID], result)
+			}
+		}
+	}
+	want := []v1alpha1.ScannerCheckResult{{ID: "KSV999", ObjectType: "Pod", Scanner: "config-audit", Details: []v1alpha1.ResultDetails{{Msg: CheckNotAvailable, Status: v1alpha1.NotAvailableStatus}}}}
+	assert.Equal(t, map[string][]v1alpha1.ScannerCheckResult{"1.0": want, "2.0": want}, notAvailable)
+}
+
+func TestDefaultStatusOfMissingChecks(t *testing.T) {
+	mgr := cm{config: getStarboardConfig()}
+	spec := v1alpha1.ReportSpec{
+		Name: "nsa",
+		Controls: []v1alpha1.Control{
+			{ID: "1.0", Name: "Non-root containers", Kinds: []string{"Pod"}, Severity: "MEDIUM", DefaultStatus: v1alpha1.WarnStatus,
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}}},
+			{ID: "2.0", Name: "Audit policy is configured", Kinds: []string{"Node"}, Severity: "HIGH", DefaultStatus: v1alpha1.ManualStatus,
+				Mapping: v1alpha1.Mapping{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{{ID: "3.2.1"}, {ID: "3.2.2", Optional: true}}}},
+			{ID: "3.0", Name: "Privileged containers", Kinds: []string{"Pod"}, Severity: "HIGH", DefaultStatus: v1alpha1.ManualStatus,
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV017"}, {ID: "KSV018"}}}},
+		},
+	}
+	checkIdsToResults := map[string][]*ScannerCheckResult{
+		checkKey(ConfigAudit, "KSV017"): {{ID: "KSV017", ObjectType: "Pod", Scanner: "config-audit", Details: []ResultDetails{{Name: "pod-a", Namespace: "default", Status: v1alpha1.PassStatus}}}},
+	}
+	smd := mgr.populateSpecDataToMaps(spec)
+
+	controlChecks := mgr.controlChecksByScannerChecks(smd, checkIdsToResults)
+	sort.Sort(scannerCheckSort(controlChecks))
+	assert.Equal(t, []v1alpha1.ControlCheck{
+		{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM", Status: v1alpha1.WarnStatus},
+		{ID: "2.0", Name: "Audit policy is configured", Severity: "HIGH", Status: v1alpha1.ManualStatus},
+		{ID: "3.0", Name: "Privileged containers", Severity: "HIGH", PassTotal: 1, Score: pointer.Int(100)},
+	}, controlChecks)
+
+	t.Run("Should not count controls with default status in totals", func(t *testing.T) {
+		totals := mgr.getTotals(controlChecks)
+		assert.Equal(t, 1, totals.pass)
+		assert.Equal(t, 0, totals.fail)
+		assert.Equal(t, map[string]v1alpha1.ControlCount{"HIGH": {Pass: 1}}, totals.bySeverity)
+		assert.Equal(t, pointer.Int(100), totals.score)
+	})
+
+	t.Run("Should report missing checks in details", func(t *testing.T) {
+		details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+		missingChecks := make(map[string][]string)
+		for _, detail := range details {
+			missingChecks[detail.ID] = detail.MissingChecks
+		}
+		assert.Equal(t, map[string][]string{
+			"1.0": {"KSV012"},
+			"2.0": {"3.2.1"},
+			"3.0": {"KSV018"},
+		}, missingChecks)
+	})
+}
+
+func TestDefaultStatusOfPartialResults(t *testing.T) {
+	mgr := cm{config: starboard.ConfigData{"compliance.separateWarnings": "true"}}
+	spec := v1alpha1.ReportSpec{
+		Name: "nsa",
+		Controls: []v1alpha1.Control{
+			{ID: "1.0", Name: "Privileged containers", Kinds: []string{"Pod"}, Severity: "HIGH", DefaultStatus: v1alpha1.FailStatus,
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV017"}, {ID: "KSV018"}}}},
+			{ID: "2.0", Name: "Host network", Kinds: []string{"Pod"}, Severity: "HIGH", DefaultStatus: v1alpha1.FailStatus,
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV009"}}}},
+		},
+	}
+	// warnings counted separately are results which are neither passes nor failures
+	checkIdsToResults := map[string][]*ScannerCheckResult{
+		checkKey(ConfigAudit, "KSV017"): {{ID: "KSV017", ObjectType: "Pod", Scanner: "config-audit", Details: []ResultDetails{{Name: "pod-a", Namespace: "default", Status: v1alpha1.WarnStatus}}}},
+	}
+	smd := mgr.populateSpecDataToMaps(spec)
+
+	controlChecks := mgr.controlChecksByScannerChecks(smd, checkIdsToResults)
+	sort.Sort(scannerCheckSort(controlChecks))
+	assert.Equal(t, []v1alpha1.ControlCheck{
+		{ID: "1.0", Name: "Privileged containers", Severity: "HIGH", WarnTotal: 1},
+		{ID: "2.0", Name: "Host network", Severity: "HIGH", FailTotal: 1, Score: pointer.Int(0)},
+	}, controlChecks)
+
+	t.Run("Should report default results only for controls without results", func(t *testing.T) {
+		details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+		defaults := make(map[string]int)
+		for _, detail := range details {
+			for _, result := range detail.ScannerCheckResult {
+				if result.Details[0].Msg == ResourceDoNotExistInCluster {
+					defaults[detail.ID]++
+				}
+			}
+		}
+		assert.Equal(t, map[string]int{"2.0": 1}, defaults)
+	})
+}
+
+func TestWaivedControls(t *testing.T) {
+	mgr := cm{config: getStarboardConfig()}
+	spec := v1alpha1.ReportSpec{
+		Name: "nsa",
+		Controls: []v1alpha1.Control{
+			{ID: "1.0", Name: "Non-root containers", Kinds: []string{"Pod"}, Severity: "MEDIUM",
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}}},
+			{ID: "1.1", Name: "Immutable container file systems", Kinds: []string{"Pod"}, Severity: "LOW",
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV014"}}}},
+			{ID: "2.0", Name: "Privileged containers", Kinds: []string{"Pod"}, Severity: "HIGH",
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV017"}}}},
+		},
+	}
+	checkIdsToResults := map[string][]*ScannerCheckResult{
+		checkKey(ConfigAudit, "KSV012"): {{ID: "KSV012", ObjectType: "Pod", Details: []ResultDetails{
+			{Name: "pod-a", Namespace: "default", Status: v1alpha1.FailStatus},
+			{Name: "pod-b", Namespace: "default", Status: v1alpha1.PassStatus},
+		}}},
+		checkKey(ConfigAudit, "KSV014"): {{ID: "KSV014", ObjectType: "Pod", Details: []ResultDetails{{Name: "pod-a", Namespace: "default", Status: v1alpha1.PassStatus}}}},
+		checkKey(ConfigAudit, "KSV017"): {{ID: "KSV017", ObjectType: "Pod", Details: []ResultDetails{{Name: "pod-a", Namespace: "default", Status: v1alpha1.FailStatus}}}},
+	}
+	expires := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	waiver := v1alpha1.ControlWaiver{Annotation: "compliance.aquasecurity.github.io/exclude-control.1.0", Expires: expires}
+	noFailuresWaiver := v1alpha1.ControlWaiver{Annotation: "compliance.aquasecurity.github.io/exclude-control.1.1", Expires: expires}
+	smd := mgr.populateSpecDataToMaps(spec)
+	smd.controlWaivers = map[string]v1alpha1.ControlWaiver{"1.0": waiver, "1.1": noFailuresWaiver}
+
+	controlChecks := mgr.controlChecksByScannerChecks(smd, checkIdsToResults)
+	sort.Sort(scannerCheckSort(controlChecks))
+	assert.Equal(t, []v1alpha1.ControlCheck{
+		{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM", PassTotal: 1, FailTotal: 0, Score: pointer.Int(100), Status: v1alpha1.WaivedStatus, Waiver: &waiver},
+		{ID: "1.1", Name: "Immutable container file systems", Severity: "LOW", PassTotal: 1, FailTotal: 0, Score: pointer.Int(100), Status: v1alpha1.WaivedStatus, Waiver: &noFailuresWaiver},
+		{ID: "2.0", Name: "Privileged containers", Severity: "HIGH", PassTotal: 0, FailTotal: 1, Score: pointer.Int(0)},
+	}, controlChecks)
+	assert.Equal(t, summaryTotal{pass: 2, fail: 1, bySeverity: map[string]v1alpha1.ControlCount{
+		"HIGH": {Fail: 1},
+	}, score: pointer.Int(0)}, mgr.getTotals(controlChecks))
+
+	details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].ID < details[j].ID
+	})
+	assert.Equal(t, []v1alpha1.ControlCheckDetails{
+		{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM", Waiver: &waiver, ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+			{ID: "KSV012", ObjectType: "Pod", Details: []v1alpha1.ResultDetails{{Name: "pod-a", Namespace: "default", Status: v1alpha1.WaivedStatus}}},
+		}},
+		{ID: "1.1", Name: "Immutable container file systems", Severity: "LOW", Waiver: &noFailuresWaiver, ScannerCheckResult: []v1alpha1.ScannerCheckResult{}},
+		{ID: "2.0", Name: "Privileged containers", Severity: "HIGH", ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+			{ID: "KSV017", ObjectType: "Pod", Details: []v1alpha1.ResultDetails{{Name: "pod-a", Namespace: "default", Status: v1alpha1.FailStatus}}},
+		}},
+	}, details)
+}
+
+func TestUnavailableScanners(t *testing.T) {
+	mgr := cm{config: getStarboardConfig()}
+	spec := v1alpha1.ReportSpec{
+		Name: "nsa",
+		Controls: []v1alpha1.Control{
+			{ID: "1.0", Name: "Non-root containers", Kinds: []string{"Pod"}, Severity: "MEDIUM",
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}}},
+			{ID: "5.0", Name: "Audit log path is configure", Kinds: []string{"Node"}, Severity: "MEDIUM", DefaultStatus: v1alpha1.FailStatus,
+				Mapping: v1alpha1.Mapping{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{{ID: "1.2.22"}}}},
+		},
+	}
+	checkIdsToResults := map[string][]*ScannerCheckResult{
+		checkKey(ConfigAudit, "KSV012"): {{ID: "KSV012", ObjectType: "Pod", Details: []ResultDetails{{Name: "pod-a", Namespace: "default", Status: v1alpha1.FailStatus}}}},
+	}
+	reason := "Reading results of kube-bench scanner timed out after 30s"
+	smd := mgr.populateSpecDataToMaps(spec)
+	smd.unavailableScanners = map[string]string{"kube-bench": reason}
+
+	controlChecks := mgr.controlChecksByScannerChecks(smd, checkIdsToResults)
+	sort.Sort(scannerCheckSort(controlChecks))
+	assert.Equal(t, []v1alpha1.ControlCheck{
+		{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM", PassTotal: 0, FailTotal: 1, Score: pointer.Int(0)},
+		{ID: "5.0", Name: "Audit log path is configure", Severity: "MEDIUM", Status: v1alpha1.DataUnavailableStatus},
+	}, controlChecks)
+
+	details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].ID < details[j].ID
+	})
+	assert.Equal(t, []v1alpha1.ControlCheckDetails{
+		{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM", ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+			{ID: "KSV012", ObjectType: "Pod", Details: []v1alpha1.ResultDetails{{Name: "pod-a", Namespace: "default", Status: v1alpha1.FailStatus}}},
+		}},
+		{ID: "5.0", Name: "Audit log path is configure", Severity: "MEDIUM", ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+			{ID: "1.2.22", ObjectType: "Node", Scanner: "kube-bench", Details: []v1alpha1.ResultDetails{{Msg: reason, Status: v1alpha1.DataUnavailableStatus}}},
+		}},
+	}, details)
+
+	t.Run("Should report unavailable controls without any scanner results", func(t *testing.T) {
+		smd.unavailableScanners = map[string]string{"config-audit": "timed out", "kube-bench": reason}
+		controlChecks := mgr.controlChecksByScannerChecks(smd, map[string][]*ScannerCheckResult{})
+		status := mgr.complianceReportStatus(mgr.getTotals(controlChecks), controlChecks)
+		assert.Len(t, status.ControlChecks, 2)
+	})
+}
+
+func TestNoResultsControls(t *testing.T) {
+	mgr := cm{config: getStarboardConfig()}
+	spec := v1alpha1.ReportSpec{
+		Name: "nsa",
+		Controls: []v1alpha1.Control{
+			{ID: "1.0", Name: "Non-root containers", Kinds: []string{"Pod"}, Severity: "MEDIUM",
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}}},
+			{ID: "5.0", Name: "Audit log path is configure", Kinds: []string{"Node"}, Severity: "MEDIUM",
+				Mapping: v1alpha1.Mapping{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{{ID: "1.2.22"}}}},
+			{ID: "5.1", Name: "Audit log aging", Kinds: []string{"Node"}, Severity: "MEDIUM", DefaultStatus: v1alpha1.FailStatus,
+				Mapping: v1alpha1.Mapping{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{{ID: "1.2.23"}}}},
+		},
+	}
+	checkIdsToResults := map[string][]*ScannerCheckResult{
+		checkKey(ConfigAudit, "KSV012"): {{ID: "KSV012", ObjectType: "Pod", Details: []ResultDetails{{Name: "pod-a", Namespace: "default", Status: v1alpha1.PassStatus}}}},
+	}
+	smd := mgr.populateSpecDataToMaps(spec)
+
+	controlChecks := mgr.controlChecksByScannerChecks(smd, checkIdsToResults)
+	sort.Sort(scannerCheckSort(controlChecks))
+	assert.Equal(t, []v1alpha1.ControlCheck{
+		{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM", PassTotal: 1, Score: pointer.Int(100)},
+		{ID: "5.0", Name: "Audit log path is configure", Severity: "MEDIUM", Status: v1alpha1.NoResultsStatus},
+		{ID: "5.1", Name: "Audit log aging", Severity: "MEDIUM", FailTotal: 1, Score: pointer.Int(0)},
+	}, controlChecks)
+	assert.Equal(t, 1, complianceSummary(mgr.getTotals(controlChecks)).NoResultsCount)
+
+	details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].ID < details[j].ID
+	})
+	require.Len(t, details, 2)
+	assert.Equal(t, v1alpha1.ControlCheckDetails{ID: "5.0", Name: "Audit log path is configure", Severity: "MEDIUM",
+		ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+			{ID: "1.2.22", ObjectType: "Node", Scanner: "kube-bench", Details: []v1alpha1.ResultDetails{{Msg: NoScannerResults, Status: v1alpha1.NoResultsStatus}}},
+		},
+		MissingChecks: []string{"1.2.22"},
+	}, details[0])
+
+	t.Run("Should report all controls without results before the first scan", func(t *testing.T) {
+		controlChecks := mgr.controlChecksByScannerChecks(smd, map[string][]*ScannerCheckResult{})
+		for _, controlCheck := range controlChecks {
+			assert.Equal(t, v1alpha1.NoResultsStatus, controlCheck.Status, controlCheck.ID)
+		}
+		status := mgr.complianceReportStatus(mgr.getTotals(controlChecks), controlChecks)
+		assert.Len(t, status.ControlChecks, 3)
+		assert.Equal(t, 3, status.Summary.NoResultsCount)
+		assert.Nil(t, status.Summary.Score)
+	})
+}
+
+func TestNotApplicableControls(t *testing.T) {
+	mgr := cm{config: getStarboardConfig(), log: logr.Discard()}
+	spec := v1alpha1.ReportSpec{
+		Name: "nsa",
+		Controls: []v1alpha1.Control{
+			{ID: "1.0", Name: "Non-root containers", Kinds: []string{"Pod"}, Severity: "MEDIUM",
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}}},
+			{ID: "5.0", Name: "Encryption configuration is set", Kinds: []string{"Node"}, Severity: "HIGH", DefaultStatus: v1alpha1.FailStatus,
+				Mapping:       v1alpha1.Mapping{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{{ID: "1.2.34"}}},
+				Applicability: &v1alpha1.Applicability{ExcludedProviders: []string{"eks", "gke", "aks"}}},
+			{ID: "6.0", Name: "Invalid applicability", Kinds: []string{"Node"}, Severity: "HIGH",
+				Mapping:       v1alpha1.Mapping{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{{ID: "1.2.35"}}},
+				Applicability: &v1alpha1.Applicability{KubernetesVersion: "not a constraint"}},
+		},
+	}
+	checkIdsToResults := map[string][]*ScannerCheckResult{
+		checkKey(ConfigAudit, "KSV012"): {{ID: "KSV012", ObjectType: "Pod", Details: []ResultDetails{{Name: "pod-a", Namespace: "default", Status: v1alpha1.FailStatus}}}},
+		checkKey(KubeBench, "1.2.34"):   {{ID: "1.2.34", ObjectType: "Node", Details: []ResultDetails{{Name: "node-1", Status: v1alpha1.FailStatus}}}},
+		checkKey(KubeBench, "1.2.35"):   {{ID: "1.2.35", ObjectType: "Node", Details: []ResultDetails{{Name: "node-1", Status: v1alpha1.FailStatus}}}},
+	}
+	reason := "Control does not apply to eks clusters"
+	smd := mgr.populateSpecDataToMaps(spec)
+	smd.notApplicableControls = mgr.notApplicableControls(spec, &v1alpha1.ClusterMetadata{Provider: "eks", KubernetesVersion: "v1.22.9-eks-a64ea69"})
+	assert.Equal(t, map[string]string{"5.0": reason}, smd.notApplicableControls)
+
+	controlChecks := mgr.controlChecksByScannerChecks(smd, checkIdsToResults)
+	sort.Sort(scannerCheckSort(controlChecks))
+	assert.Equal(t, []v1alpha1.ControlCheck{
+		{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM", PassTotal: 0, FailTotal: 1, Score: pointer.Int(0)},
+		{ID: "5.0", Name: "Encryption configuration is set", Severity: "HIGH", Status: v1alpha1.NotApplicableStatus},
+		{ID: "6.0", Name: "Invalid applicability", Severity: "HIGH", PassTotal: 0, FailTotal: 1, Score: pointer.Int(0)},
+	}, controlChecks)
+	assert.Equal(t, summaryTotal{pass: 0, fail: 2, bySeverity: map[string]v1alpha1.ControlCount{
+		"MEDIUM": {Fail: 1},
+		"HIGH":   {Fail: 1},
+	}, score: pointer.Int(0)}, mgr.getTotals(controlChecks))
+
+	details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].ID < details[j].ID
+	})
+	assert.Equal(t, v1alpha1.ControlCheckDetails{ID: "5.0", Name: "Encryption configuration is set", Severity: "HIGH", ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+		{ID: "1.2.34", ObjectType: "Node", Scanner: "kube-bench", Details: []v1alpha1.ResultDetails{{Msg: reason, Status: v1alpha1.NotApplicableStatus}}},
+	}}, details[1])
+}
+
+func TestExcludedControls(t *testing.T) {
+	mgr := cm{config: getStarboardConfig()}
+	spec := v1alpha1.ReportSpec{
+		Name:             "nsa",
+		ExcludedControls: []string{"1.1", "5.0"},
+		Controls: []v1alpha1.Control{
+			{ID: "1.0", Name: "Non-root containers", Kinds: []string{"Pod"}, Severity: "MEDIUM",
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}}},
+			{ID: "1.1", Name: "Host network usage", Kinds: []string{"Pod"}, Severity: "HIGH",
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV009"}}}},
+			{ID: "5.0", Name: "Audit log path is configure", Kinds: []string{"Node"}, Severity: "MEDIUM", DefaultStatus: v1alpha1.FailStatus,
+				Mapping: v1alpha1.Mapping{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{{ID: "1.2.22"}}}},
+		},
+	}
+	checkIdsToResults := map[string][]*ScannerCheckResult{
+		checkKey(ConfigAudit, "KSV012"): {{ID: "KSV012", ObjectType: "Pod", Details: []ResultDetails{{Name: "pod-a", Namespace: "default", Status: v1alpha1.PassStatus}}}},
+		checkKey(ConfigAudit, "KSV009"): {{ID: "KSV009", ObjectType: "Pod", Details: []ResultDetails{{Name: "cni", Namespace: "kube-system", Status: v1alpha1.FailStatus}}}},
+	}
+	smd := mgr.populateSpecDataToMaps(spec)
+	assert.Equal(t, map[string][]string{"1.0": {"KSV012"}}, smd.controlCheckIds)
+	assert.NotContains(t, smd.controlIDControlObject, "1.1")
+	assert.NotContains(t, smd.scannerResourceListNames, "kube-bench")
+
+	controlChecks := mgr.controlChecksByScannerChecks(smd, checkIdsToResults)
+	sort.Sort(scannerCheckSort(controlChecks))
+	assert.Equal(t, []v1alpha1.ControlCheck{
+		{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM", PassTotal: 1, Score: pointer.Int(100)},
+		{ID: "1.1", Name: "Host network usage", Severity: "HIGH", Status: v1alpha1.ExcludedStatus},
+		{ID: "5.0", Name: "Audit log path is configure", Severity: "MEDIUM", Status: v1alpha1.ExcludedStatus},
+	}, controlChecks)
+	assert.Equal(t, summaryTotal{pass: 1, bySeverity: map[string]v1alpha1.ControlCount{
+		"MEDIUM": {Pass: 1},
+	}, score: pointer.Int(100)}, mgr.getTotals(controlChecks))
+
+	details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].ID < details[j].ID
+	})
+	assert.Equal(t, []v1alpha1.ControlCheckDetails{
+		{ID: "1.1", Name: "Host network usage", Severity: "HIGH", ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+			{ID: "KSV009", ObjectType: "Pod", Scanner: "config-audit", Details: []v1alpha1.ResultDetails{{Msg: ControlExcluded, Status: v1alpha1.ExcludedStatus}}},
+		}},
+		{ID: "5.0", Name: "Audit log path is configure", Severity: "MEDIUM", ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+			{ID: "1.2.22", ObjectType: "Node", Scanner: "kube-bench", Details: []v1alpha1.ResultDetails{{Msg: ControlExcluded, Status: v1alpha1.ExcludedStatus}}},
+		}},
+	}, details)
+}
+
+func TestManualControls(t *testing.T) {
+	mgr := cm{config: getStarboardConfig()}
+	spec := v1alpha1.ReportSpec{
+		Name: "nsa",
+		Controls: []v1alpha1.Control{
+			{ID: "1.0", Name: "Non-root containers", Kinds: []string{"Pod"}, Severity: "MEDIUM",
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}}},
+			{ID: "3.2", Name: "Review network policies", Kinds: []string{"NetworkPolicy"}, Severity: "MEDIUM", Manual: true},
+		},
+	}
+	checkIdsToResults := map[string][]*ScannerCheckResult{
+		checkKey(ConfigAudit, "KSV012"): {{ID: "KSV012", ObjectType: "Pod", Details: []ResultDetails{{Name: "pod-a", Namespace: "default", Status: v1alpha1.PassStatus}}}},
+	}
+	smd := mgr.populateSpecDataToMaps(spec)
+	assert.Equal(t, map[string][]string{"1.0": {"KSV012"}, "3.2": {}}, smd.controlCheckIds)
+	assert.Len(t, smd.scannerResourceListNames, 1)
+	assert.Contains(t, smd.scannerResourceListNames, "config-audit")
+
+	controlChecks := mgr.controlChecksByScannerChecks(smd, checkIdsToResults)
+	sort.Sort(scannerCheckSort(controlChecks))
+	assert.Equal(t, []v1alpha1.ControlCheck{
+		{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM", PassTotal: 1, Score: pointer.Int(100)},
+		{ID: "3.2", Name: "Review network policies", Severity: "MEDIUM", Status: v1alpha1.ManualStatus},
+	}, controlChecks)
+	summary := complianceSummary(mgr.getTotals(controlChecks))
+	assert.Equal(t, 1, summary.ManualCount)
+	assert.Equal(t, pointer.Int(100), summary.Score)
+	assert.Equal(t, map[string]v1alpha1.ControlCount{"MEDIUM": {Pass: 1}}, summary.SummaryBySeverity)
+
+	details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].ID < details[j].ID
+	})
+	assert.Equal(t, []v1alpha1.ControlCheckDetails{
+		{ID: "3.2", Name: "Review network policies", Severity: "MEDIUM", ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+			{ObjectType: "NetworkPolicy", Details: []v1alpha1.ResultDetails{{Msg: ManualControl, Status: v1alpha1.ManualStatus}}},
+		}},
+	}, details)
+
+	t.Run("Should ignore manual controls in ready condition", func(t *testing.T) {
+		condition := readyCondition([]v1alpha1.ControlCheck{
+			{ID: "3.2", Status: v1alpha1.ManualStatus},
+			{ID: "1.0", Status: v1alpha1.NoResultsStatus},
+		})
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	})
+}
+
+func TestExcludedNamespaces(t *testing.T) {
+	mgr := cm{config: getStarboardConfig()}
+	spec := v1alpha1.ReportSpec{
+		Name: "nsa",
+		Controls: []v1alpha1.Control{
+			{ID: "1.0", Name: "Non-root containers", Kinds: []string{"Pod"}, Severity: "MEDIUM", ExcludedNamespaces: []string{"kube-system"},
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}}},
+			{ID: "1.1", Name: "Host network usage", Kinds: []string{"Pod"}, Severity: "HIGH",
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV009"}}}},
+		},
+	}
+	checkIdsToResults := map[string][]*ScannerCheckResult{
+		checkKey(ConfigAudit, "KSV012"): {
+			{ID: "KSV012", ObjectType: "Pod", Details: []ResultDetails{{Name: "pod-a", Namespace: "default", Status: v1alpha1.PassStatus}}},
+			{ID: "KSV012", ObjectType: "Pod", Details: []ResultDetails{{Name: "kube-proxy", Namespace: "kube-system", Status: v1alpha1.FailStatus}}},
+		},
+		checkKey(ConfigAudit, "KSV009"): {{ID: "KSV009", ObjectType: "Pod", Details: []ResultDetails{{Name: "cni", Namespace: "kube-system", Status: v1alpha1.FailStatus}}}},
+	}
+	smd := mgr.populateSpecDataToMaps(spec)
+
+	controlChecks := mgr.controlChecksByScannerChecks(smd, checkIdsToResults)
+	sort.Sort(scannerCheckSort(controlChecks))
+	assert.Equal(t, []v1alpha1.ControlCheck{
+		{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM", PassTotal: 1, Score: pointer.Int(100)},
+		{ID: "1.1", Name: "Host network usage", Severity: "HIGH", FailTotal: 1, Score: pointer.Int(0)},
+	}, controlChecks)
+
+	details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].ID < details[j].ID
+	})
+	assert.Equal(t, []v1alpha1.ControlCheckDetails{
+		{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM", ScannerCheckResult: []v1alpha1.ScannerCheckResult{}, ExcludedNamespaces: []string{"kube-system"}},
+		{ID: "1.1", Name: "Host network usage", Severity: "HIGH", ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+			{ID: "KSV009", ObjectType: "Pod", Details: []v1alpha1.ResultDetails{{Name: "cni", Namespace: "kube-system", Status: v1alpha1.FailStatus}}},
+		}},
+	}, details)
+
+	t.Run("Should keep results of cluster-scoped resources", func(t *testing.T) {
+		control := v1alpha1.Control{ID: "2.0", ExcludedNamespaces: []string{"kube-system"}}
+		results, excluded := exemptResults(control, []string{"KSV111"}, map[string][]*ScannerCheckResult{
+			"KSV111": {{ID: "KSV111", ObjectType: "ClusterRole", Details: []ResultDetails{{Name: "admin", Status: v1alpha1.FailStatus}}}},
+		})
+		assert.Empty(t, excluded)
+		assert.Equal(t, map[string][]*ScannerCheckResult{
+			"KSV111": {{ID: "KSV111", ObjectType: "ClusterRole", Details: []ResultDetails{{Name: "admin", Status: v1alpha1.FailStatus}}}},
+		}, results)
+	})
+}
+
+func TestSeverityThreshold(t *testing.T) {
+	mgr := cm{config: getStarboardConfig()}
+	spec := v1alpha1.ReportSpec{
+		Name:              "nsa",
+		SeverityThreshold: v1alpha1.SeverityHigh,
+		Controls: []v1alpha1.Control{
+			{ID: "1.0", Name: "Non-root containers", Kinds: []string{"Pod"}, Severity: "MEDIUM",
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}}},
+			{ID: "1.1", Name: "Non-root containers of all severities", Kinds: []string{"Pod"}, Severity: "MEDIUM", SeverityThreshold: v1alpha1.SeverityLow,
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}}},
+			{ID: "1.2", Name: "Audit logging", Kinds: []string{"Node"}, Severity: "MEDIUM",
+				Mapping: v1alpha1.Mapping{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{{ID: "1.2.22"}}}},
+			{ID: "1.3", Name: "Host network usage", Kinds: []string{"Pod"}, Severity: "HIGH",
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV009"}}}},
+		},
+	}
+	checkIdsToResults := map[string][]*ScannerCheckResult{
+		checkKey(ConfigAudit, "KSV012"): {{ID: "KSV012", ObjectType: "Pod", Scanner: "config-audit", Details: []ResultDetails{
+			{Name: "pod-a", Namespace: "default", Status: v1alpha1.FailStatus, Severity: v1alpha1.SeverityLow},
+			{Name: "pod-b", Namespace: "default", Status: v1alpha1.FailStatus, Severity: v1alpha1.SeverityHigh},
+			{Name: "pod-c", Namespace: "default", Status: v1alpha1.PassStatus, Severity: v1alpha1.SeverityCritical},
+		}}},
+		checkKey(KubeBench, "1.2.22"): {{ID: "1.2.22", ObjectType: "Node", Scanner: "kube-bench", Details: []ResultDetails{{Name: "node-1", Status: v1alpha1.FailStatus}}}},
+		checkKey(ConfigAudit, "KSV009"): {{ID: "KSV009", ObjectType: "Pod", Scanner: "config-audit", Details: []ResultDetails{
+			{Name: "pod-a", Namespace: "default", Status: v1alpha1.FailStatus, Severity: v1alpha1.SeverityMedium},
+		}}},
+	}
+	smd := mgr.populateSpecDataToMaps(spec)
+
+	controlChecks := mgr.controlChecksByScannerChecks(smd, checkIdsToResults)
+	sort.Sort(scannerCheckSort(controlChecks))
+	assert.Equal(t, []v1alpha1.ControlCheck{
+		{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM", PassTotal: 1, FailTotal: 1, Score: pointer.Int(50)},
+		{ID: "1.1", Name: "Non-root containers of all severities", Severity: "MEDIUM", PassTotal: 1, FailTotal: 2, Score: pointer.Int(33)},
+		{ID: "1.2", Name: "Audit logging", Severity: "MEDIUM", FailTotal: 1, Score: pointer.Int(0)},
+		{ID: "1.3", Name: "Host network usage", Severity: "HIGH", Status: v1alpha1.NoResultsStatus},
+	}, controlChecks)
+
+	details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].ID < details[j].ID
+	})
+	require.Len(t, details, 4)
+	assert.Equal(t, []v1alpha1.ScannerCheckResult{
+		{ID: "KSV012", ObjectType: "Pod", Scanner: "config-audit", Details: []v1alpha1.ResultDetails{
+			{Name: "pod-b", Namespace: "default", Status: v1alpha1.FailStatus, Severity: v1alpha1.SeverityHigh},
+		}},
+	}, details[0].ScannerCheckResult)
+	assert.Equal(t, 2, details[1].ScannerCheckResult[0].TotalCount, "failures of all severities")
+	assert.Equal(t, []v1alpha1.ScannerCheckResult{
+		{ID: "1.2.22", ObjectType: "Node", Scanner: "kube-bench", Details: []v1alpha1.ResultDetails{{Name: "node-1", Status: v1alpha1.FailStatus}}},
+	}, details[2].ScannerCheckResult)
+	assert.Equal(t, v1alpha1.NoResultsStatus, details[3].ScannerCheckResult[0].Details[0].Status)
+
+	t.Run("Should not filter results without threshold", func(t *testing.T) {
+		results := filterBySeverity([]string{"KSV012"}, checkIdsToResults, "")
+		assert.Equal(t, checkIdsToResults, results)
+	})
+}
+
+func TestMultipleScannerMappings(t *testing.T) {
+	mgr := cm{config: getStarboardConfig()}
+	spec := v1alpha1.ReportSpec{
+		Name: "nsa",
+		Controls: []v1alpha1.Control{
+			{ID: "1.0", Name: "Audit logging", Kinds: []string{"Pod", "Node"}, Severity: "MEDIUM",
+				Mapping: v1alpha1.Mapping{Scanners: []v1alpha1.ScannerMapping{
+					{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}},
+					{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{{ID: "1.2.22"}}},
+				}}},
+		},
+	}
+	checkIdsToResults := map[string][]*ScannerCheckResult{
+		checkKey(ConfigAudit, "KSV012"): {{ID: "KSV012", ObjectType: "Pod", Scanner: "config-audit", Details: []ResultDetails{{Name: "pod-a", Namespace: "default", Status: v1alpha1.PassStatus}}}},
+		checkKey(KubeBench, "1.2.22"):   {{ID: "1.2.22", ObjectType: "Node", Scanner: "kube-bench", Details: []ResultDetails{{Name: "node-1", Status: v1alpha1.FailStatus}}}},
+	}
+	smd := mgr.populateSpecDataToMaps(spec)
+	assert.Equal(t, map[string][]string{"1.0": {"KSV012", "1.2.22"}}, smd.controlCheckIds)
+	require.Contains(t, smd.scannerResourceListNames, "config-audit")
+	require.Contains(t, smd.scannerResourceListNames, "kube-bench")
+	assert.Equal(t, []interface{}{"Pod"}, smd.scannerResourceListNames["config-audit"].Values(), "kinds reported by config-audit")
+	assert.Equal(t, []interface{}{"Node"}, smd.scannerResourceListNames["kube-bench"].Values(), "kinds reported by kube-bench")
+
+	controlChecks := mgr.controlChecksByScannerChecks(smd, checkIdsToResults)
+	assert.Equal(t, []v1alpha1.ControlCheck{
+		{ID: "1.0", Name: "Audit logging", Severity: "MEDIUM", PassTotal: 1, FailTotal: 1, Score: pointer.Int(50)},
+	}, controlChecks)
+
+	details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+	require.Len(t, details, 1)
+	assert.ElementsMatch(t, []v1alpha1.ScannerCheckResult{
+		{ID: "1.2.22", ObjectType: "Node", Scanner: "kube-bench", Details: []v1alpha1.ResultDetails{{Name: "node-1", Status: v1alpha1.FailStatus}}},
+	}, details[0].ScannerCheckResult)
+
+	t.Run("Should report control unavailable if any scanner is unavailable", func(t *testing.T) {
+		smd.unavailableScanners = map[string]string{"kube-bench": "timed out"}
+		controlChecks := mgr.controlChecksByScannerChecks(smd, checkIdsToResults)
+		assert.Equal(t, []v1alpha1.ControlCheck{
+			{ID: "1.0", Name: "Audit logging", Severity: "MEDIUM", Status: v1alpha1.DataUnavailableStatus},
+		}, controlChecks)
+	})
+}
+
+func TestSameCheckIdOfMultipleScanners(t *testing.T) {
+	mgr := cm{config: getStarboardConfig()}
+	spec := v1alpha1.ReportSpec{
+		Name: "nsa",
+		Controls: []v1alpha1.Control{
+			{ID: "1.0", Name: "Audit logging", Kinds: []string{"Node"}, Severity: "MEDIUM",
+				Mapping: v1alpha1.Mapping{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{{ID: "1.1"}}}},
+			{ID: "2.0", Name: "Non-root containers", Kinds: []string{"Pod"}, Severity: "MEDIUM",
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "1.1"}}}},
+		},
+	}
+	checkIdsToResults, scannerErrors := mgr.checkIdsToResults(map[string]map[string]client.ObjectList{
+		ConfigAudit: {"Pod": getConfAudit([]string{"1.1", "KSV012"}, []bool{false, true}, []string{"aaa", "bbb"})},
+		KubeBench:   {"Node": getCisInstance([]string{"1.1", "1.2"}, []string{"PASS", "PASS"}, []string{"ccc", "ddd"})},
+	})
+	require.Empty(t, scannerErrors)
+	smd := mgr.populateSpecDataToMaps(spec)
+
+	controlChecks := mgr.controlChecksByScannerChecks(smd, checkIdsToResults)
+	sort.Sort(scannerCheckSort(controlChecks))
+	assert.Equal(t, []v1alpha1.ControlCheck{
+		{ID: "1.0", Name: "Audit logging", Severity: "MEDIUM", PassTotal: 1, Score: pointer.Int(100)},
+		{ID: "2.0", Name: "Non-root containers", Severity: "MEDIUM", FailTotal: 1, Score: pointer.Int(0)},
+	}, controlChecks)
+
+	details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+	require.Len(t, details, 1)
+	assert.Equal(t, "2.0", details[0].ID)
+	require.Len(t, details[0].ScannerCheckResult, 1)
+	assert.Equal(t, "config-audit", details[0].ScannerCheckResult[0].Scanner)
+	assert.Equal(t, "aaa", details[0].ScannerCheckResult[0].Remediation)
+}
+
+// failingGetClient fails to get objects with the given error.
+type failingGetClient struct {
+	client.Client
+	err error
+}
+
+func (c *failingGetClient) Get(_ context.Context, _ client.ObjectKey, _ client.Object) error {
+	return c.err
+}
+
+// rbacClient denies requests with verbs on aquasecurity.github.io resources
+// which are not required by permissions.FeatureClusterCompliance, like the API
+// server does if the operator is granted only the required permissions.
+type rbacClient struct {
+	client.Client
+	granted map[permissions.Permission]bool
+}
+
+func newRBACClient(c client.Client) *rbacClient {
+	granted := make(map[permissions.Permission]bool)
+	for _, requirement := range permissions.Required(permissions.Namespaces{}, permissions.FeatureClusterCompliance) {
+		permission := requirement.Permission
+		permission.Namespace = ""
+		granted[permission] = true
+	}
+	return &rbacClient{Client: c, granted: granted}
+}
+
+func (c *rbacClient) authorize(verb string, obj runtime.Object, subresource string) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return err
+	}
+	if gvk.Group != v1alpha1.SchemeGroupVersion.Group {
+		return nil
+	}
+	resource := strings.ToLower(strings.TrimSuffix(gvk.Kind, "List")) + "s"
+	permission := permissions.Permission{Verb: verb, Group: gvk.Group, Resource: resource, Subresource: subresource}
+	if !c.granted[permission] {
+		return apierrors.NewForbidden(schema.GroupResource{Group: gvk.Group, Resource: resource}, "",
+			fmt.Errorf("%s is not granted", permission))
+	}
+	return nil
+}
+
+func (c *rbacClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	if err := c.authorize("get", obj, ""); err != nil {
+		return err
+	}
+	return c.Client.Get(ctx, key, obj)
+}
+
+func (c *rbacClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := c.authorize("list", list, ""); err != nil {
+		return err
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+func (c *rbacClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.authorize("create", obj, ""); err != nil {
+		return err
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *rbacClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.authorize("update", obj, ""); err != nil {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *rbacClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.authorize("patch", obj, ""); err != nil {
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *rbacClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.authorize("delete", obj, ""); err != nil {
+		return err
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *rbacClient) Status() client.StatusWriter {
+	return &rbacStatusWriter{StatusWriter: c.Client.Status(), client: c}
+}
+
+type rbacStatusWriter struct {
+	client.StatusWriter
+	client *rbacClient
+}
+
+func (w *rbacStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := w.client.authorize("update", obj, "status"); err != nil {
+		return err
+	}
+	return w.StatusWriter.Update(ctx, obj, opts...)
+}
+
+func (w *rbacStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := w.client.authorize("patch", obj, "status"); err != nil {
+		return err
+	}
+	return w.StatusWriter.Patch(ctx, obj, patch, opts...)
+}
+
+// racingStatusClient runs the given func once before the first status update,
+// e.g. to write the report concurrently.
+type racingStatusClient struct {
+	client.Client
+	race func()
+}
+
+func (c *racingStatusClient) Status() client.StatusWriter {
+	return &racingStatusWriter{StatusWriter: c.Client.Status(), client: c}
+}
+
+type racingStatusWriter struct {
+	client.StatusWriter
+	client *racingStatusClient
+}
+
+func (w *racingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if race := w.client.race; race != nil {
+		w.client.race = nil
+		race()
+	}
+	return w.StatusWriter.Update(ctx, obj, opts...)
+}
+
+func TestUpdateComplianceReportStatus(t *testing.T) {
+	spec := v1alpha1.ReportSpec{Name: "NSA", Version: "1.0", Cron: "0 */6 * * *"}
+	status := v1alpha1.ReportStatus{
+		UpdateTimestamp: metav1.NewTime(time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)),
+		Summary:         v1alpha1.ClusterComplianceSummary{PassCount: 1},
+	}
+
+	t.Run("Should create missing report with spec", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+		mgr := &cm{client: c, log: logr.Discard()}
+		require.NoError(t, mgr.updateComplianceReportStatus(context.TODO(), spec, status))
+
+		var report v1alpha1.ClusterComplianceReport
+		require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "nsa"}, &report))
+		assert.Equal(t, spec, report.Spec)
+		assert.Equal(t, 1, report.Status.Summary.PassCount)
+	})
+
+	t.Run("Should not mask errors other than not found", func(t *testing.T) {
+		timeout := apierrors.NewTimeoutError("request timed out", 1)
+		c := &failingGetClient{Client: fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build(), err: timeout}
+		mgr := &cm{client: c, log: logr.Discard()}
+		err := mgr.updateComplianceReportStatus(context.TODO(), spec, status)
+		assert.EqualError(t, err, "getting compliance report nsa: Timeout: request timed out")
+		assert.True(t, apierrors.IsTimeout(err))
+
+		var reports v1alpha1.ClusterComplianceReportList
+		require.NoError(t, c.List(context.TODO(), &reports))
+		assert.Empty(t, reports.Items)
+	})
+
+	t.Run("Should track generations and last change", func(t *testing.T) {
+		c := newRBACClient(fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build())
+		mgr := &cm{client: c, log: logr.Discard()}
+		get := func() v1alpha1.ClusterComplianceReport {
+			var report v1alpha1.ClusterComplianceReport
+			require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "nsa"}, &report))
+			return report
+		}
+		t1 := status.UpdateTimestamp.Time
+		t2 := t1.Add(6 * time.Hour)
+		t3 := t2.Add(6 * time.Hour)
+
+		require.NoError(t, mgr.updateComplianceReportStatus(context.TODO(), spec, status))
+		report := get()
+		assert.Equal(t, int64(1), report.Status.GenerationCount)
+		assert.Equal(t, t1, report.Status.LastChangedTimestamp.UTC())
+		assert.NotContains(t, report.Annotations, starboard.AnnotationChangelog)
+
+		unchanged := *status.DeepCopy()
+		unchanged.UpdateTimestamp = metav1.NewTime(t2)
+		require.NoError(t, mgr.updateComplianceReportStatus(context.TODO(), spec, unchanged))
+		report = get()
+		assert.Equal(t, int64(2), report.Status.GenerationCount)
+		assert.Equal(t, t1, report.Status.LastChangedTimestamp.UTC())
+		assert.NotContains(t, report.Annotations, starboard.AnnotationChangelog)
+
+		changed := *status.DeepCopy()
+		changed.UpdateTimestamp = metav1.NewTime(t3)
+		changed.Summary = v1alpha1.ClusterComplianceSummary{PassCount: 1, FailCount: 2}
+		require.NoError(t, mgr.updateComplianceReportStatus(context.TODO(), spec, changed))
+		report = get()
+		assert.Equal(t, int64(3), report.Status.GenerationCount)
+		assert.Equal(t, t3, report.Status.LastChangedTimestamp.UTC())
+		assert.Equal(t, "generation 3: failCount 0 -> 2", report.Annotations[starboard.AnnotationChangelog])
+		assert.Equal(t, spec, report.Spec)
+		assert.Empty(t, report.Status.History)
+	})
+
+	t.Run("Should keep bounded history", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+		mgr := &cm{client: c, log: logr.Discard(), config: starboard.ConfigData{"compliance.historyLimit": "2"}}
+		for i := 0; i < 3; i++ {
+			generation := *status.DeepCopy()
+			generation.UpdateTimestamp = metav1.NewTime(status.UpdateTimestamp.Add(time.Duration(i) * time.Hour))
+			generation.Summary.FailCount = i
+			generation.ControlChecks = []v1alpha1.ControlCheck{
+				{ID: "1.0", Name: "Non-root containers", PassTotal: 1, FailTotal: i, Severity: v1alpha1.SeverityHigh},
+			}
+			require.NoError(t, mgr.updateComplianceReportStatus(context.TODO(), spec, generation))
+		}
+
+		var report v1alpha1.ClusterComplianceReport
+		require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "nsa"}, &report))
+		require.Len(t, report.Status.History, 2)
+		assert.Equal(t, status.UpdateTimestamp.Add(2*time.Hour), report.Status.History[0].UpdateTimestamp.UTC())
+		assert.Equal(t, 2, report.Status.History[0].Summary.FailCount)
+		assert.Equal(t, []v1alpha1.ControlCheckTotals{{ID: "1.0", PassTotal: 1, FailTotal: 2}}, report.Status.History[0].ControlChecks)
+		assert.Equal(t, status.UpdateTimestamp.Add(time.Hour), report.Status.History[1].UpdateTimestamp.UTC())
+		assert.Equal(t, 1, report.Status.History[1].Summary.FailCount)
+	})
+
+	t.Run("Should not drop snapshots of overlapping generations", func(t *testing.T) {
+		config := starboard.ConfigData{"compliance.historyLimit": "3"}
+		fakeClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			&v1alpha1.ClusterComplianceReport{ObjectMeta: metav1.ObjectMeta{Name: "nsa"}, Spec: spec},
+		).Build()
+		other := &cm{client: fakeClient, log: logr.Discard(), config: config}
+		overlapping := *status.DeepCopy()
+		overlapping.UpdateTimestamp = metav1.NewTime(status.UpdateTimestamp.Add(time.Minute))
+		c := &racingStatusClient{Client: fakeClient, race: func() {
+			require.NoError(t, other.updateComplianceReportStatus(context.TODO(), spec, overlapping))
+		}}
+		mgr := &cm{client: c, log: logr.Discard(), config: config}
+		require.NoError(t, mgr.updateComplianceReportStatus(context.TODO(), spec, status))
+
+		var report v1alpha1.ClusterComplianceReport
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: "nsa"}, &report))
+		require.Len(t, report.Status.History, 2)
+		assert.Equal(t, status.UpdateTimestamp.Time, report.Status.History[0].UpdateTimestamp.UTC())
+		assert.Equal(t, overlapping.UpdateTimestamp.Time, report.Status.History[1].UpdateTimestamp.UTC())
+		assert.Equal(t, int64(2), report.Status.GenerationCount)
+	})
+}
+
+func TestGenerateComplianceReportWithUnknownKind(t *testing.T) {
+	spec := v1alpha1.ReportSpec{Name: "nsa", Version: "1.0", Cron: "0 */6 * * *", Controls: []v1alpha1.Control{
+		{ID: "1.0", Name: "Non-root containers", Kinds: []string{"Worklaod"}, Severity: v1alpha1.SeverityMedium,
+			Mapping: v1alpha1.Mapping{Scanner: ConfigAudit, Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}}},
+	}}
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.ClusterComplianceReport{ObjectMeta: metav1.ObjectMeta{Name: "nsa"}, Spec: spec},
+	).Build()
+	mgr := &cm{client: c, log: logr.Discard()}
+
+	err := mgr.GenerateComplianceReport(context.TODO(), spec)
+	require.EqualError(t, err, `control 1.0: unknown kind "Worklaod": use one of the kind keywords or a fully qualified kind such as apps/v1/Deployment`)
+
+	var report v1alpha1.ClusterComplianceReport
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "nsa"}, &report))
+	require.Len(t, report.Status.Conditions, 1)
+	assert.Equal(t, metav1.ConditionFalse, report.Status.Conditions[0].Status)
+	assert.Equal(t, err.Error(), report.Status.Conditions[0].Message)
+}
+
+func TestDegradedCondition(t *testing.T) {
+	assert.Equal(t, metav1.Condition{
+		Type:    v1alpha1.DegradedCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  "AllScannersAvailable",
+		Message: "Results of all scanners are included in the report",
+	}, degradedCondition(map[string]string{}))
+	assert.Equal(t, metav1.Condition{
+		Type:    v1alpha1.DegradedCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ScannerTimeout",
+		Message: "Reading results of config-audit scanner timed out after 1m0s; Reading results of kube-bench scanner timed out after 1m0s",
+	}, degradedCondition(map[string]string{
+		"kube-bench":   "Reading results of kube-bench scanner timed out after 1m0s",
+		"config-audit": "Reading results of config-audit scanner timed out after 1m0s",
+	}))
+}
+
+func TestGenerateComplianceReport_ScannerError(t *testing.T) {
+	spec := v1alpha1.ReportSpec{Name: "NSA", Version: "1.0", Cron: "0 */6 * * *", Controls: []v1alpha1.Control{
+		{ID: "1.0", Name: "Audit log path is configure", Kinds: []string{"Node"}, Severity: "MEDIUM",
+			Mapping: v1alpha1.Mapping{Scanner: KubeBench, Checks: []v1alpha1.SpecCheck{{ID: "1.2.22"}}}},
+		{ID: "2.0", Name: "Image vulnerabilities", Kinds: []string{"Pod"}, Severity: "HIGH",
+			Mapping: v1alpha1.Mapping{Scanner: "trivy", Checks: []v1alpha1.SpecCheck{{ID: "CVE-2021-44228"}}}},
+	}}
+	cisReport := getCisInstance([]string{"1.2.22", "1.2.23"}, []string{"PASS", "FAIL"}, []string{"", ""}).Items[0]
+	cisReport.ObjectMeta = metav1.ObjectMeta{
+		Name:   "kind-control-plane",
+		Labels: map[string]string{starboard.LabelResourceKind: "Node"},
+	}
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.ClusterComplianceReport{ObjectMeta: metav1.ObjectMeta{Name: "nsa"}, Spec: spec},
+		&cisReport,
+	).Build()
+	mgr := NewMgr(c, logr.Discard(), starboard.ConfigData{}, nil, nil)
+
+	require.NoError(t, mgr.GenerateComplianceReport(context.TODO(), spec))
+
+	var report v1alpha1.ClusterComplianceReport
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "nsa"}, &report))
+	controlChecks := report.Status.ControlChecks
+	sort.Sort(scannerCheckSort(controlChecks))
+	assert.Equal(t, []v1alpha1.ControlCheck{
+		{ID: "1.0", Name: "Audit log path is configure", Severity: "MEDIUM", PassTotal: 1, Score: pointer.Int(100)},
+		{ID: "2.0", Name: "Image vulnerabilities", Severity: "HIGH", Status: v1alpha1.ErrorStatus,
+			Error: `mapper of scanner "trivy" is not registered; registered scanners: config-audit, kube-bench, kube-hunter`},
+	}, controlChecks)
+	assert.Equal(t, 1, report.Status.Summary.PassCount)
+	assert.Equal(t, 1, report.Status.Summary.ErrorCount)
+	assert.Equal(t, pointer.Int(100), report.Status.Summary.Score)
+
+	var details v1alpha1.ClusterComplianceDetailReport
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "nsa-details"}, &details))
+	require.Len(t, details.Report.ControlChecks, 1)
+	assert.Equal(t, []v1alpha1.ScannerCheckResult{
+		{ID: "CVE-2021-44228", ObjectType: "Pod", Scanner: "trivy", Details: []v1alpha1.ResultDetails{
+			{Msg: `mapper of scanner "trivy" is not registered; registered scanners: config-audit, kube-bench, kube-hunter`, Status: v1alpha1.ErrorStatus},
+		}},
+	}, details.Report.ControlChecks[0].ScannerCheckResult)
+}
+
+func TestGenerateComplianceReport_WithoutReport(t *testing.T) {
+	spec := v1alpha1.ReportSpec{Name: "NSA", Version: "1.0", Cron: "0 */6 * * *", Controls: []v1alpha1.Control{
+		{ID: "1.0", Name: "Audit log path is configure", Kinds: []string{"Node"}, Severity: "MEDIUM",
+			Mapping: v1alpha1.Mapping{Scanner: KubeBench, Checks: []v1alpha1.SpecCheck{{ID: "1.2.22"}}}},
+	}}
+	cisReport := getCisInstance([]string{"1.2.22", "1.2.23"}, []string{"PASS", "FAIL"}, []string{"", ""}).Items[0]
+	cisReport.ObjectMeta = metav1.ObjectMeta{
+		Name:   "kind-control-plane",
+		Labels: map[string]string{starboard.LabelResourceKind: "Node"},
+	}
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(&cisReport).Build()
+	mgr := NewMgr(c, logr.Discard(), starboard.ConfigData{}, nil, nil)
+
+	require.NoError(t, mgr.GenerateComplianceReport(context.TODO(), spec))
+
+	var report v1alpha1.ClusterComplianceReport
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "nsa"}, &report))
+	assert.Equal(t, spec, report.Spec)
+	assert.Equal(t, 1, report.Status.Summary.PassCount)
+
+	var details v1alpha1.ClusterComplianceDetailReport
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "nsa-details"}, &details))
+	require.Len(t, details.OwnerReferences, 1)
+	assert.Equal(t, "nsa", details.OwnerReferences[0].Name)
+	assert.Equal(t, report.UID, details.OwnerReferences[0].UID)
+}
+
+func TestGenerateComplianceReport_DetailReportOwner(t *testing.T) {
+	spec := v1alpha1.ReportSpec{Name: "NSA", Version: "1.0", Cron: "0 */6 * * *", Controls: []v1alpha1.Control{
+		{ID: "1.0", Name: "Audit log path is configure", Kinds: []string{"Node"}, Severity: "MEDIUM",
+			Mapping: v1alpha1.Mapping{Scanner: KubeBench, Checks: []v1alpha1.SpecCheck{{ID: "1.2.22"}}}},
+	}}
+	owner := &v1alpha1.ClusterComplianceReport{ObjectMeta: metav1.ObjectMeta{Name: "nsa", UID: "nsa-uid"}, Spec: spec}
+	cisReport := getCisInstance([]string{"1.2.22", "1.2.23"}, []string{"FAIL", "PASS"}, []string{"", ""}).Items[0]
+	cisReport.ObjectMeta = metav1.ObjectMeta{
+		Name:   "kind-control-plane",
+		Labels: map[string]string{starboard.LabelResourceKind: "Node"},
+	}
+	expectedOwner := metav1.OwnerReference{
+		APIVersion: "aquasecurity.github.io/v1alpha1",
+		Kind:       "ClusterComplianceReport",
+		Name:       "nsa",
+		UID:        "nsa-uid",
+	}
+
+	// collectable returns true if the garbage collector would delete the
+	// report, i.e. none of its owners exists
+	collectable := func(c client.Client, report v1alpha1.ClusterComplianceDetailReport) bool {
+		for _, ref := range report.OwnerReferences {
+			var existing v1alpha1.ClusterComplianceReport
+			err := c.Get(context.TODO(), types.NamespacedName{Name: ref.Name}, &existing)
+			if err == nil && existing.UID == ref.UID {
+				return false
+			}
+		}
+		return len(report.OwnerReferences) > 0
+	}
+
+	t.Run("Should set owner of created detail report", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(owner.DeepCopy(), cisReport.DeepCopy()).Build()
+		mgr := NewMgr(c, logr.Discard(), starboard.ConfigData{}, nil, nil)
+		require.NoError(t, mgr.GenerateComplianceReport(context.TODO(), spec))
+
+		var details v1alpha1.ClusterComplianceDetailReport
+		require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "nsa-details"}, &details))
+		assert.Equal(t, []metav1.OwnerReference{expectedOwner}, details.OwnerReferences)
+		assert.False(t, collectable(c, details))
+
+		require.NoError(t, c.Delete(context.TODO(), &v1alpha1.ClusterComplianceReport{ObjectMeta: metav1.ObjectMeta{Name: "nsa"}}))
+		assert.True(t, collectable(c, details))
+	})
+
+	t.Run("Should set owner of updated detail report", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			owner.DeepCopy(),
+			cisReport.DeepCopy(),
+			&v1alpha1.ClusterComplianceDetailReport{ObjectMeta: metav1.ObjectMeta{
+				Name: "nsa-details",
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "aquasecurity.github.io/v1alpha1",
+					Kind:       "ClusterComplianceReport",
+					Name:       "nsa",
+					UID:        "deleted-nsa-uid",
+				}},
+			}},
+		).Build()
+		mgr := NewMgr(c, logr.Discard(), starboard.ConfigData{}, nil, nil)
+		require.NoError(t, mgr.GenerateComplianceReport(context.TODO(), spec))
+
+		var details v1alpha1.ClusterComplianceDetailReport
+		require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "nsa-details"}, &details))
+		assert.Equal(t, []metav1.OwnerReference{expectedOwner}, details.OwnerReferences)
+		assert.Len(t, details.Report.ControlChecks, 1)
+	})
+}
+
+func TestReadyCondition(t *testing.T) {
+	ready := metav1.Condition{
+		Type:    v1alpha1.ReadyCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Generated",
+		Message: "The report was generated",
+	}
+	missing := metav1.Condition{
+		Type:    v1alpha1.ReadyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.ReasonScannerResultsMissing,
+		Message: "None of the controls has results of scanners",
+	}
+	assert.Equal(t, ready, readyCondition([]v1alpha1.ControlCheck{
+		{ID: "1.0", PassTotal: 3},
+		{ID: "1.1", Status: v1alpha1.NoResultsStatus},
+	}))
+	assert.Equal(t, missing, readyCondition([]v1alpha1.ControlCheck{
+		{ID: "1.0", Status: v1alpha1.NoResultsStatus},
+		{ID: "1.1", Status: v1alpha1.NoResultsStatus},
+	}))
+	assert.Equal(t, missing, readyCondition([]v1alpha1.ControlCheck{
+		{ID: "1.0", Status: v1alpha1.NoResultsStatus},
+		{ID: "1.1", Status: v1alpha1.ExcludedStatus},
+		{ID: "1.2", Status: v1alpha1.NotApplicableStatus},
+	}))
+	assert.Equal(t, ready, readyCondition([]v1alpha1.ControlCheck{
+		{ID: "1.0", Status: v1alpha1.ManualStatus},
+	}))
+}
+
+func TestGenerateComplianceReport_GenerationError(t *testing.T) {
+	spec := v1alpha1.ReportSpec{Name: "NSA", Version: "1.0", Cron: "0 */6 * * *", Includes: []string{"missing"}}
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(&v1alpha1.ClusterComplianceReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "nsa"},
+		Spec:       spec,
+		Status: v1alpha1.ReportStatus{
+			InputsHash: "5d41402abc",
+			Conditions: []metav1.Condition{{
+				Type:   v1alpha1.ReadyCondition,
+				Status: metav1.ConditionTrue,
+				Reason: "Generated",
+			}},
+		},
+	}).Build()
+	mgr := NewMgr(c, logr.Discard(), starboard.ConfigData{}, nil, nil)
+
+	err := mgr.GenerateComplianceReport(context.TODO(), spec)
+	require.Error(t, err)
+
+	var report v1alpha1.ClusterComplianceReport
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "nsa"}, &report))
+	require.Len(t, report.Status.Conditions, 1)
+	assert.Equal(t, v1alpha1.ReadyCondition, report.Status.Conditions[0].Type)
+	assert.Equal(t, metav1.ConditionFalse, report.Status.Conditions[0].Status)
+	assert.Equal(t, v1alpha1.ReasonGenerationError, report.Status.Conditions[0].Reason)
+	assert.Equal(t, err.Error(), report.Status.Conditions[0].Message)
+	assert.Empty(t, report.Status.InputsHash)
+}
+
 type scannerCheckSort []v1alpha1.ControlCheck
 
 func (a scannerCheckSort) Len() int           { return len(a) }
@@ -112,10 +1454,27 @@ func TestGetTotals(t *testing.T) {
 		controlCheck []v1alpha1.ControlCheck
 		want         summaryTotal
 	}{
-		{name: "get totals with data", controlCheck: []v1alpha1.ControlCheck{{ID: "1.0", Name: "Non-root containers", PassTotal: 1, FailTotal: 0}, {ID: "8.1", Name: "Audit log path is configure", PassTotal: 0, FailTotal: 1}},
-			want: summaryTotal{pass: 1, fail: 1}},
+		{name: "get totals with data", controlCheck: []v1alpha1.ControlCheck{{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM", PassTotal: 1, FailTotal: 0}, {ID: "8.1", Name: "Audit log path is configure", Severity: "MEDIUM", PassTotal: 0, FailTotal: 1}},
+			want: summaryTotal{pass: 1, fail: 1, bySeverity: map[string]v1alpha1.ControlCount{"MEDIUM": {Pass: 1, Fail: 1}}, score: pointer.Int(50)}},
+		{name: "get totals by severity", controlCheck: []v1alpha1.ControlCheck{
+			{ID: "1.0", Name: "Non-root containers", Severity: "CRITICAL", PassTotal: 2, FailTotal: 1},
+			{ID: "1.1", Name: "Immutable container file systems", Severity: "CRITICAL", PassTotal: 0, FailTotal: 3},
+			{ID: "2.0", Name: "Privileged containers", Severity: "HIGH", PassTotal: 4, FailTotal: 0},
+			{ID: "5.0", Name: "Encryption configuration is set", Severity: "HIGH", Status: v1alpha1.NotApplicableStatus},
+			{ID: "8.1", Name: "Audit log path is configure", Severity: "LOW", PassTotal: 1, Status: v1alpha1.WaivedStatus},
+			{ID: "8.2", Name: "Audit log aging", Severity: "LOW", Status: v1alpha1.NoResultsStatus}},
+			want: summaryTotal{pass: 7, fail: 4, bySeverity: map[string]v1alpha1.ControlCount{"CRITICAL": {Fail: 2}, "HIGH": {Pass: 1}}, score: pointer.Int(33), noResults: 1}},
+		{name: "get totals of controls without severity", controlCheck: []v1alpha1.ControlCheck{
+			{ID: "1.0", Name: "Non-root containers", PassTotal: 0, FailTotal: 1},
+			{ID: "1.1", Name: "Immutable container file systems", Severity: "UNKNOWN", PassTotal: 1, FailTotal: 0}},
+			want: summaryTotal{pass: 1, fail: 1, bySeverity: map[string]v1alpha1.ControlCount{"UNKNOWN": {Pass: 1, Fail: 1}}, score: pointer.Int(50)}},
+		{name: "get totals score of controls without results", controlCheck: []v1alpha1.ControlCheck{
+			{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM", PassTotal: 2, FailTotal: 0},
+			{ID: "1.1", Name: "Immutable container file systems", Severity: "MEDIUM", PassTotal: 1, FailTotal: 0},
+			{ID: "2.0", Name: "Privileged containers", Severity: "HIGH", PassTotal: 0, FailTotal: 0}},
+			want: summaryTotal{pass: 3, fail: 0, bySeverity: map[string]v1alpha1.ControlCount{"MEDIUM": {Pass: 2}, "HIGH": {Pass: 1}}, score: pointer.Int(100)}},
 		{name: "get totals with no data", controlCheck: []v1alpha1.ControlCheck{},
-			want: summaryTotal{pass: 0, fail: 0}}}
+			want: summaryTotal{pass: 0, fail: 0, bySeverity: map[string]v1alpha1.ControlCount{}}}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			sm := mgr.getTotals(tt.controlCheck)
@@ -124,6 +1483,53 @@ func TestGetTotals(t *testing.T) {
 	}
 }
 
+func TestComplianceSummary(t *testing.T) {
+	summary := complianceSummary(summaryTotal{pass: 9, fail: 7, bySeverity: map[string]v1alpha1.ControlCount{
+		"CRITICAL": {Pass: 1, Fail: 2},
+		"HIGH":     {Fail: 1},
+		"MEDIUM":   {Pass: 3},
+		"LOW":      {Fail: 1},
+		"UNKNOWN":  {Fail: 2},
+	}, score: pointer.Int(40), noResults: 3})
+	assert.Equal(t, 9, summary.PassCount)
+	assert.Equal(t, 7, summary.FailCount)
+	assert.Equal(t, 2, summary.CriticalFailCount)
+	assert.Equal(t, 1, summary.HighFailCount)
+	assert.Equal(t, 0, summary.MediumFailCount)
+	assert.Equal(t, 1, summary.LowFailCount)
+	assert.Equal(t, 2, summary.UnknownFailCount)
+	assert.Equal(t, pointer.Int(40), summary.Score)
+	assert.Equal(t, 3, summary.NoResultsCount)
+}
+
+type clusterMetadataReader struct {
+	metadata v1alpha1.ClusterMetadata
+	err      error
+}
+
+func (r *clusterMetadataReader) Read(_ context.Context) (v1alpha1.ClusterMetadata, error) {
+	return r.metadata, r.err
+}
+
+func TestReadClusterMetadata(t *testing.T) {
+	metadata := v1alpha1.ClusterMetadata{Name: "production", KubernetesVersion: "v1.23.4", NodeCount: 3}
+	tests := []struct {
+		name   string
+		reader kube.ClusterMetadataReader
+		want   *v1alpha1.ClusterMetadata
+	}{
+		{name: "without reader", want: nil},
+		{name: "with reader", reader: &clusterMetadataReader{metadata: metadata}, want: &metadata},
+		{name: "with failing reader", reader: &clusterMetadataReader{err: errors.New("forbidden")}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := cm{log: logr.Discard(), clusterMetadata: tt.reader}
+			assert.Equal(t, tt.want, mgr.readClusterMetadata(context.TODO()))
+		})
+	}
+}
+
 func TestCheckIdsToResults(t *testing.T) {
 	mgr := cm{}
 	tests := []struct {
@@ -136,10 +1542,8 @@ func TestCheckIdsToResults(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cct, err := mgr.checkIdsToResults(tt.reportList)
-			if err != nil {
-				t.Error(err)
-			}
+			cct, scannerErrors := mgr.checkIdsToResults(tt.reportList)
+			assert.Empty(t, scannerErrors)
 			assert.True(t, reflect.DeepEqual(cct, tt.wantResult))
 		})
 	}
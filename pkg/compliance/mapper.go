@@ -2,10 +2,22 @@ package compliance
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/emirpasic/gods/sets/hashset"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -14,10 +26,89 @@ const (
 	KubeBench = "kube-bench"
 	//ConfigAudit scanner name as appear in specs file
 	ConfigAudit = "config-audit"
+	//KubeHunter scanner name as appear in specs file
+	KubeHunter = "kube-hunter"
 )
 
+// kubeHunterKind is the kind of resources which kube-hunter reports are
+// labeled with, as they're reported for the whole cluster.
+const kubeHunterKind = "Cluster"
+
+// Mapper is the interface implemented by scanners whose results are mapped to
+// checks of compliance controls.
+//
+// NewObjectList returns an empty list of reports of the scanner. The type of
+// the list must be registered in the scheme of the client which is used to
+// generate compliance reports.
+//
+// MapReportData maps reports in the given list, which were listed for
+// resources of the specified kind, to results of checks by check ID.
 type Mapper interface {
-	mapReportData(objType string, objList client.ObjectList) map[string]*ScannerCheckResult
+	NewObjectList() client.ObjectList
+	MapReportData(objType string, objList client.ObjectList) map[string]*ScannerCheckResult
+}
+
+// KindMapper is the interface implemented by mappers whose reports of some
+// kinds of resources are of another type, e.g. reports of cluster-scoped
+// resources. NewObjectListForKind returns an empty list of reports of
+// resources of the specified kind, which MapReportData maps along with lists
+// returned by NewObjectList.
+type KindMapper interface {
+	Mapper
+	NewObjectListForKind(kind string) client.ObjectList
+}
+
+// KindFilter is the interface implemented by mappers whose scanners report
+// results of some kinds of resources only, e.g. kube-bench, which reports
+// results of nodes. ReportsKind returns true if the scanner reports results of
+// resources of the specified kind, so that reports of the scanner are not
+// listed for other kinds of resources mapped to the same control.
+type KindFilter interface {
+	Mapper
+	ReportsKind(kind string) bool
+}
+
+var (
+	mappersMu sync.RWMutex
+	mappers   = make(map[string]Mapper)
+)
+
+// RegisterMapper makes the given Mapper available by the name of the scanner
+// as it appears in compliance specs. Built-in mappers are registered in init,
+// and mappers of other scanners are registered by importing packages which
+// call RegisterMapper in their init function. It panics if the name is blank,
+// the Mapper is nil, or a Mapper is already registered with the name.
+func RegisterMapper(name string, m Mapper) {
+	mappersMu.Lock()
+	defer mappersMu.Unlock()
+	if name == "" {
+		panic("compliance: mapper name is blank")
+	}
+	if m == nil {
+		panic("compliance: mapper of scanner " + name + " is nil")
+	}
+	if _, dup := mappers[name]; dup {
+		panic("compliance: mapper of scanner " + name + " is already registered")
+	}
+	mappers[name] = m
+}
+
+// Scanners returns sorted names of scanners with registered mappers.
+func Scanners() []string {
+	mappersMu.RLock()
+	defer mappersMu.RUnlock()
+	names := make([]string, 0, len(mappers))
+	for name := range mappers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	RegisterMapper(KubeBench, &kubeBench{})
+	RegisterMapper(ConfigAudit, &configAudit{})
+	RegisterMapper(KubeHunter, &kubeHunter{})
 }
 
 type kubeBench struct {
@@ -26,15 +117,18 @@ type kubeBench struct {
 type configAudit struct {
 }
 
+type kubeHunter struct {
+}
+
 func byScanner(scanner string) (Mapper, error) {
-	switch scanner {
-	case KubeBench:
-		return &kubeBench{}, nil
-	case ConfigAudit:
-		return &configAudit{}, nil
+	mappersMu.RLock()
+	m, ok := mappers[scanner]
+	mappersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("mapper of scanner %q is not registered; registered scanners: %s",
+			scanner, strings.Join(Scanners(), ", "))
 	}
-	// scanner is not supported
-	return nil, fmt.Errorf("mapper scanner: %s is not supported", scanner)
+	return m, nil
 }
 
 type CheckDetails struct {
@@ -43,7 +137,17 @@ type CheckDetails struct {
 	Remediation string
 }
 
-func (kb kubeBench) mapReportData(objType string, objList client.ObjectList) map[string]*ScannerCheckResult {
+func (kb kubeBench) NewObjectList() client.ObjectList {
+	return &v1alpha1.CISKubeBenchReportList{}
+}
+
+// ReportsKind returns true for nodes, which are the only resources whose
+// results are reported by kube-bench.
+func (kb kubeBench) ReportsKind(kind string) bool {
+	return kind == string(kube.KindNode)
+}
+
+func (kb kubeBench) MapReportData(objType string, objList client.ObjectList) map[string]*ScannerCheckResult {
 	scannerCheckResultMap := make(map[string]*ScannerCheckResult, 0)
 	cb, ok := objList.(*v1alpha1.CISKubeBenchReportList)
 	if !ok || len(cb.Items) == 0 {
@@ -67,14 +171,42 @@ func (kb kubeBench) mapReportData(objType string, objList client.ObjectList) map
 	return scannerCheckResultMap
 }
 
-func (ac configAudit) mapReportData(objType string, objList client.ObjectList) map[string]*ScannerCheckResult {
+func (ac configAudit) NewObjectList() client.ObjectList {
+	return &v1alpha1.ConfigAuditReportList{}
+}
+
+// NewObjectListForKind returns an empty list of ClusterConfigAuditReports for
+// cluster-scoped kinds, e.g. ClusterRole, and of ConfigAuditReports otherwise.
+func (ac configAudit) NewObjectListForKind(kind string) client.ObjectList {
+	if kube.IsClusterScopedKind(kind) {
+		return &v1alpha1.ClusterConfigAuditReportList{}
+	}
+	return ac.NewObjectList()
+}
+
+// ReportsKind returns true for any kind of resources but nodes and the cluster,
+// whose results are reported by kube-bench and kube-hunter.
+func (ac configAudit) ReportsKind(kind string) bool {
+	return kind != string(kube.KindNode) && kind != kubeHunterKind
+}
+
+// MapReportData maps checks of ConfigAuditReports or ClusterConfigAuditReports
+// by check ID, with a result of each check for each resource.
+func (ac configAudit) MapReportData(objType string, objList client.ObjectList) map[string]*ScannerCheckResult {
 	scannerCheckResultMap := make(map[string]*ScannerCheckResult, 0)
-	cb, ok := objList.(*v1alpha1.ConfigAuditReportList)
-	if !ok || len(cb.Items) == 0 {
-		return scannerCheckResultMap
+	var items []configAuditItem
+	switch list := objList.(type) {
+	case *v1alpha1.ConfigAuditReportList:
+		for _, item := range list.Items {
+			items = append(items, configAuditItem{meta: item.ObjectMeta, report: item.Report})
+		}
+	case *v1alpha1.ClusterConfigAuditReportList:
+		for _, item := range list.Items {
+			items = append(items, configAuditItem{meta: item.ObjectMeta, report: item.Report})
+		}
 	}
-	for _, item := range cb.Items {
-		for _, check := range item.Report.Checks {
+	for _, item := range items {
+		for _, check := range item.report.Checks {
 			if _, ok := scannerCheckResultMap[check.ID]; !ok {
 				scannerCheckResultMap[check.ID] = &ScannerCheckResult{ID: check.ID, Remediation: check.Remediation, ObjectType: objType}
 				scannerCheckResultMap[check.ID].Details = make([]ResultDetails, 0)
@@ -87,48 +219,278 @@ func (ac configAudit) mapReportData(objType string, objList client.ObjectList) m
 			if check.Success {
 				status = v1alpha1.PassStatus
 			}
-			scannerCheckResultMap[check.ID].Details = append(scannerCheckResultMap[check.ID].Details, ResultDetails{Name: item.GetName(), Namespace: item.Namespace, Msg: message, Status: status})
+			// reports without resource labels are never deduplicated
+			resource, _ := kube.ObjectRefFromObjectMeta(item.meta)
+			scannerCheckResultMap[check.ID].Details = append(scannerCheckResultMap[check.ID].Details, ResultDetails{Name: item.meta.GetName(), Namespace: item.meta.Namespace, Msg: message, Status: status, Severity: configAuditSeverity(check.Severity), Resource: resource})
 
 		}
 	}
 	return scannerCheckResultMap
 }
 
-func mapComplianceScannerToResource(cli client.Client, ctx context.Context, resourceListNames map[string]*hashset.Set) map[string]map[string]client.ObjectList {
+// configAuditSeverity maps a severity of a config audit check, which may be a
+// Polaris severity such as danger, to the severity used in compliance reports.
+// Unrecognized severities are mapped to SeverityUnknown.
+func configAuditSeverity(severity v1alpha1.Severity) v1alpha1.Severity {
+	if severity == "" {
+		return ""
+	}
+	s, err := v1alpha1.StringToSeverity(string(severity))
+	if err != nil {
+		return v1alpha1.SeverityUnknown
+	}
+	return s
+}
+
+// configAuditItem is a ConfigAuditReport or a ClusterConfigAuditReport.
+type configAuditItem struct {
+	meta   metav1.ObjectMeta
+	report v1alpha1.ConfigAuditReportData
+}
+
+// mapComplianceScannerToResource lists reports of each scanner for each kind of
+// resources mapped to the scanner. Reports of distinct kinds are listed
+// concurrently by at most the given number of workers. If the timeout is
+// positive, reading reports of a single scanner is cancelled when it takes
+// longer than the timeout since its first listing started. The results of such scanners are omitted, and
+// returned with the reason why they are unavailable, so that the rest of the
+// compliance report can be generated. Reports of kinds which cannot be listed
+// for other reasons are omitted as well, and errors of listing them are
+// returned aggregated by scanner.
+func mapComplianceScannerToResource(cli client.Client, ctx context.Context, resourceListNames map[string]*hashset.Set, timeout time.Duration, workers int) (map[string]map[string]client.ObjectList, map[string]string, map[string]error) {
 	scannerResource := make(map[string]map[string]client.ObjectList)
+	unavailableScanners := make(map[string]string)
+	listErrors := make(map[string]error)
+	contexts := &scannerContexts{parent: ctx, timeout: timeout, contexts: make(map[string]context.Context)}
+	defer contexts.cancel()
+	var jobs []listJob
 	for scanner, objNames := range resourceListNames {
+		if getObjListByName(scanner) == nil {
+			// results of an unsupported scanner cannot be listed, its controls
+			// are reported with errors when results are mapped to checks
+			scannerResource[scanner] = make(map[string]client.ObjectList)
+			continue
+		}
 		for _, objName := range objNames.Values() {
-			objNameString, ok := objName.(string)
-			if !ok {
-				continue
+			if objNameString, ok := objName.(string); ok {
+				jobs = append(jobs, listJob{scanner: scanner, objName: objNameString})
 			}
-			labels := map[string]string{
-				starboard.LabelResourceKind: objNameString,
-			}
-			matchingLabel := client.MatchingLabels(labels)
-			objList := getObjListByName(scanner)
-			err := cli.List(ctx, objList, matchingLabel)
-			if err != nil {
-				continue
+		}
+	}
+	resourceLists := make(map[string]map[string]client.ObjectList)
+	errs := make(map[string][]error)
+	for _, result := range listConcurrently(cli, contexts, jobs, workers) {
+		switch {
+		case result.timedOut:
+			unavailableScanners[result.scanner] = fmt.Sprintf("Reading results of %s scanner timed out after %s", result.scanner, timeout)
+		case result.err != nil:
+			errs[result.scanner] = append(errs[result.scanner], result.err)
+		default:
+			if _, ok := resourceLists[result.scanner]; !ok {
+				resourceLists[result.scanner] = make(map[string]client.ObjectList)
 			}
-			if _, ok := scannerResource[scanner]; !ok {
-				scannerResource[scanner] = make(map[string]client.ObjectList)
+			resourceLists[result.scanner][result.objName] = result.list
+		}
+	}
+	for scanner, lists := range resourceLists {
+		if _, unavailable := unavailableScanners[scanner]; !unavailable {
+			scannerResource[scanner] = lists
+		}
+	}
+	for scanner, scannerErrs := range errs {
+		if _, unavailable := unavailableScanners[scanner]; !unavailable {
+			listErrors[scanner] = utilerrors.NewAggregate(scannerErrs)
+		}
+	}
+	return scannerResource, unavailableScanners, listErrors
+}
+
+// scannerContexts creates contexts of scanners, which are cancelled when
+// reading results of a scanner times out. The context of a scanner is created
+// when its first listing starts, so that the time spent waiting for workers
+// busy with other scanners does not count towards its timeout.
+type scannerContexts struct {
+	parent   context.Context
+	timeout  time.Duration
+	mu       sync.Mutex
+	contexts map[string]context.Context
+	cancels  []context.CancelFunc
+}
+
+func (c *scannerContexts) get(scanner string) context.Context {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if ctx, ok := c.contexts[scanner]; ok {
+		return ctx
+	}
+	ctx := c.parent
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(c.parent, c.timeout)
+		c.cancels = append(c.cancels, cancel)
+	}
+	c.contexts[scanner] = ctx
+	return ctx
+}
+
+func (c *scannerContexts) cancel() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, cancel := range c.cancels {
+		cancel()
+	}
+}
+
+// listJob lists reports of a scanner for a kind of resources.
+type listJob struct {
+	scanner string
+	objName string
+}
+
+type listResult struct {
+	scanner  string
+	objName  string
+	list     client.ObjectList
+	err      error
+	timedOut bool
+}
+
+// listConcurrently runs the given jobs by at most the given number of workers
+// and collects their results, so that results are assembled by the caller
+// without sharing maps between goroutines.
+func listConcurrently(cli client.Client, contexts *scannerContexts, jobs []listJob, workers int) []listResult {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+	jobsCh := make(chan listJob)
+	resultsCh := make(chan listResult, len(jobs))
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobsCh {
+				resultsCh <- job.run(contexts.get(job.scanner), cli)
 			}
-			scannerResource[scanner][objNameString] = objList
+		}()
+	}
+	for _, job := range jobs {
+		jobsCh <- job
+	}
+	close(jobsCh)
+	wg.Wait()
+	close(resultsCh)
+	results := make([]listResult, 0, len(jobs))
+	for result := range resultsCh {
+		results = append(results, result)
+	}
+	return results
+}
+
+// run lists reports of the scanner by the kind of resources. Reports are
+// labeled with kinds only, therefore reports of kinds qualified with a group
+// are listed by kind, and filtered by the group of their owner. Reports are
+// not listed if the context of the scanner is already done, e.g. because
+// reading results of the scanner timed out.
+func (j listJob) run(ctx context.Context, cli client.Client) listResult {
+	result := listResult{scanner: j.scanner, objName: j.objName}
+	if err := ctx.Err(); err != nil {
+		result.err = err
+		result.timedOut = errors.Is(err, context.DeadlineExceeded)
+		return result
+	}
+	kind := j.objName
+	qualifiedKind, err := parseQualifiedKind(j.objName)
+	qualified := err == nil
+	if qualified {
+		kind = qualifiedKind.Kind
+	}
+	objList := getObjListByKind(j.scanner, kind)
+	err = cli.List(ctx, objList, client.MatchingLabels{starboard.LabelResourceKind: kind})
+	if err != nil {
+		result.err = fmt.Errorf("listing results of %s: %w", j.objName, err)
+		result.timedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
+		return result
+	}
+	if qualified {
+		if err = filterByOwnerGroup(objList, qualifiedKind); err != nil {
+			result.err = fmt.Errorf("filtering results of %s: %w", j.objName, err)
+			return result
+		}
+	}
+	result.list = objList
+	return result
+}
+
+// filterByOwnerGroup removes reports whose owner of the given kind belongs to
+// a group other than the group of the given kind, e.g. Ingresses of the
+// extensions group when networking.k8s.io/v1/Ingress is specified. Reports
+// without such an owner are kept.
+func filterByOwnerGroup(objList client.ObjectList, gvk schema.GroupVersionKind) error {
+	items, err := meta.ExtractList(objList)
+	if err != nil {
+		return err
+	}
+	filtered := make([]runtime.Object, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok || ownedByGroup(obj, gvk) {
+			filtered = append(filtered, item)
+		}
+	}
+	return meta.SetList(objList, filtered)
+}
+
+func ownedByGroup(obj client.Object, gvk schema.GroupVersionKind) bool {
+	for _, owner := range obj.GetOwnerReferences() {
+		if owner.Kind != gvk.Kind {
+			continue
 		}
+		gv, err := schema.ParseGroupVersion(owner.APIVersion)
+		return err == nil && gv.Group == gvk.Group
 	}
-	return scannerResource
+	return true
 }
 
+// reportsKind returns true if the scanner reports results of resources of the
+// specified kind. Scanners whose mappers are not registered, or do not
+// implement KindFilter, are assumed to report results of any kind.
+func reportsKind(scannerName string, kind string) bool {
+	m, err := byScanner(scannerName)
+	if err != nil {
+		return true
+	}
+	if kf, ok := m.(KindFilter); ok {
+		return kf.ReportsKind(kind)
+	}
+	return true
+}
+
+// getObjListByName returns an empty list of reports of the specified scanner,
+// or nil if no mapper of the scanner is registered.
 func getObjListByName(scannerName string) client.ObjectList {
-	switch scannerName {
-	case KubeBench:
-		return &v1alpha1.CISKubeBenchReportList{}
-	case ConfigAudit:
-		return &v1alpha1.ConfigAuditReportList{}
-	default:
+	m, err := byScanner(scannerName)
+	if err != nil {
+		return nil
+	}
+	return m.NewObjectList()
+}
+
+// getObjListByKind returns an empty list of reports of the specified scanner
+// for resources of the specified kind, or nil if no mapper of the scanner is
+// registered.
+func getObjListByKind(scannerName string, kind string) client.ObjectList {
+	m, err := byScanner(scannerName)
+	if err != nil {
 		return nil
 	}
+	if km, ok := m.(KindMapper); ok {
+		return km.NewObjectListForKind(kind)
+	}
+	return m.NewObjectList()
 }
 
 type ResultDetails struct {
@@ -136,11 +498,71 @@ type ResultDetails struct {
 	Namespace string
 	Msg       string
 	Status    v1alpha1.ControlStatus
+	Severity  v1alpha1.Severity
+	// Resource is the resource which the result was reported for, if it's
+	// known, so that results reported for resources of the same root workload
+	// are deduplicated
+	Resource kube.ObjectRef
+	// ClusterScoped is true for results of cluster-scoped resources in reports
+	// scoped to namespaces
+	ClusterScoped bool
 }
 
 type ScannerCheckResult struct {
 	ObjectType  string
 	ID          string
+	Scanner     string
 	Remediation string
 	Details     []ResultDetails
 }
+
+func (kh kubeHunter) NewObjectList() client.ObjectList {
+	return &v1alpha1.KubeHunterReportList{}
+}
+
+// ReportsKind returns true for the cluster, which is the only resource whose
+// results are reported by kube-hunter.
+func (kh kubeHunter) ReportsKind(kind string) bool {
+	return kind == kubeHunterKind
+}
+
+// MapReportData maps vulnerabilities of kube-hunter reports by vulnerability ID,
+// e.g. KHV005. Kube-hunter reports only vulnerabilities which were found,
+// therefore each result fails. Checks which are not reported have no results,
+// so that their controls get the DefaultStatus of the control, e.g. PASS.
+func (kh kubeHunter) MapReportData(objType string, objList client.ObjectList) map[string]*ScannerCheckResult {
+	scannerCheckResultMap := make(map[string]*ScannerCheckResult, 0)
+	kr, ok := objList.(*v1alpha1.KubeHunterReportList)
+	if !ok || len(kr.Items) == 0 {
+		return scannerCheckResultMap
+	}
+	for _, item := range kr.Items {
+		for _, vulnerability := range item.Report.Vulnerabilities {
+			if _, ok := scannerCheckResultMap[vulnerability.ID]; !ok {
+				scannerCheckResultMap[vulnerability.ID] = &ScannerCheckResult{ID: vulnerability.ID, Remediation: vulnerability.AvdReference, ObjectType: objType}
+				scannerCheckResultMap[vulnerability.ID].Details = make([]ResultDetails, 0)
+			}
+			message := vulnerability.Vulnerability
+			if vulnerability.Location != "" {
+				message = fmt.Sprintf("%s at %s", vulnerability.Vulnerability, vulnerability.Location)
+			}
+			scannerCheckResultMap[vulnerability.ID].Details = append(scannerCheckResultMap[vulnerability.ID].Details, ResultDetails{Name: item.GetName(), Namespace: item.Namespace, Msg: message, Status: v1alpha1.FailStatus, Severity: kubeHunterSeverity(vulnerability.Severity)})
+		}
+	}
+	return scannerCheckResultMap
+}
+
+// kubeHunterSeverity maps a kube-hunter severity to the severity used in
+// compliance reports.
+func kubeHunterSeverity(severity v1alpha1.Severity) v1alpha1.Severity {
+	switch severity {
+	case v1alpha1.KubeHunterSeverityHigh:
+		return v1alpha1.SeverityHigh
+	case v1alpha1.KubeHunterSeverityMedium:
+		return v1alpha1.SeverityMedium
+	case v1alpha1.KubeHunterSeverityLow:
+		return v1alpha1.SeverityLow
+	default:
+		return v1alpha1.SeverityUnknown
+	}
+}
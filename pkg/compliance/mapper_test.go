@@ -3,13 +3,21 @@ package compliance
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"io/ioutil"
 	"reflect"
+	"sync/atomic"
+	"time"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -24,6 +32,7 @@ func TestGetObjListByName(t *testing.T) {
 	}{
 		{name: "kube bench scanner name", scannerName: KubeBench, want: "*v1alpha1.CISKubeBenchReportList"},
 		{name: "conf audit scanner name", scannerName: ConfigAudit, want: "*v1alpha1.ConfigAuditReportList"},
+		{name: "kube hunter scanner name", scannerName: KubeHunter, want: "*v1alpha1.KubeHunterReportList"},
 		{name: "no scanner name", scannerName: "", want: ""},
 	}
 	for _, tt := range tests {
@@ -45,6 +54,7 @@ func TestByScanner(t *testing.T) {
 	}{
 		{name: "kube bench scanner name", scannerName: KubeBench, want: "*compliance.kubeBench"},
 		{name: "conf audit scanner name", scannerName: ConfigAudit, want: "*compliance.configAudit"},
+		{name: "kube hunter scanner name", scannerName: KubeHunter, want: "*compliance.kubeHunter"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -60,6 +70,102 @@ func TestByScanner(t *testing.T) {
 	}
 }
 
+// vulnerabilityMapper maps VulnerabilityReports by vulnerability ID, as a
+// mapper of a scanner which is not built into Starboard.
+type vulnerabilityMapper struct {
+}
+
+func (vm vulnerabilityMapper) NewObjectList() client.ObjectList {
+	return &v1alpha1.VulnerabilityReportList{}
+}
+
+func (vm vulnerabilityMapper) MapReportData(objType string, objList client.ObjectList) map[string]*ScannerCheckResult {
+	results := make(map[string]*ScannerCheckResult)
+	for _, item := range objList.(*v1alpha1.VulnerabilityReportList).Items {
+		for _, vulnerability := range item.Report.Vulnerabilities {
+			if _, ok := results[vulnerability.VulnerabilityID]; !ok {
+				results[vulnerability.VulnerabilityID] = &ScannerCheckResult{ID: vulnerability.VulnerabilityID, ObjectType: objType}
+			}
+			results[vulnerability.VulnerabilityID].Details = append(results[vulnerability.VulnerabilityID].Details,
+				ResultDetails{Name: item.Name, Namespace: item.Namespace, Msg: vulnerability.Title, Status: v1alpha1.FailStatus})
+		}
+	}
+	return results
+}
+
+// registerTestMapper registers the given Mapper for the duration of the test.
+func registerTestMapper(t *testing.T, name string, m Mapper) {
+	RegisterMapper(name, m)
+	t.Cleanup(func() {
+		mappersMu.Lock()
+		defer mappersMu.Unlock()
+		delete(mappers, name)
+	})
+}
+
+func TestRegisterMapper(t *testing.T) {
+	t.Run("Should register mapper", func(t *testing.T) {
+		registerTestMapper(t, "acme", vulnerabilityMapper{})
+		assert.Equal(t, []string{"acme", ConfigAudit, KubeBench, KubeHunter}, Scanners())
+
+		m, err := byScanner("acme")
+		require.NoError(t, err)
+		assert.Equal(t, vulnerabilityMapper{}, m)
+		assert.Equal(t, &v1alpha1.VulnerabilityReportList{}, getObjListByName("acme"))
+	})
+
+	t.Run("Should panic when mapper is invalid or already registered", func(t *testing.T) {
+		assert.Panics(t, func() { RegisterMapper("", vulnerabilityMapper{}) })
+		assert.Panics(t, func() { RegisterMapper("acme", nil) })
+		assert.Panics(t, func() { RegisterMapper(KubeBench, vulnerabilityMapper{}) })
+	})
+
+	t.Run("Should return error listing registered scanners", func(t *testing.T) {
+		_, err := byScanner("acme")
+		assert.EqualError(t, err, `mapper of scanner "acme" is not registered; registered scanners: config-audit, kube-bench, kube-hunter`)
+	})
+}
+
+func TestGenerateComplianceReport_RegisteredMapper(t *testing.T) {
+	registerTestMapper(t, "acme", vulnerabilityMapper{})
+
+	spec := v1alpha1.ReportSpec{Name: "NSA", Version: "1.0", Cron: "0 */6 * * *", Controls: []v1alpha1.Control{
+		{ID: "1.0", Name: "Image vulnerabilities", Kinds: []string{"ReplicaSet"}, Severity: "HIGH",
+			Mapping: v1alpha1.Mapping{Scanner: "acme", Checks: []v1alpha1.SpecCheck{{ID: "CVE-2021-44228"}}}},
+	}}
+	require.NoError(t, ValidateSpec(spec))
+
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.ClusterComplianceReport{ObjectMeta: metav1.ObjectMeta{Name: "nsa"}, Spec: spec},
+		&v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "replicaset-app-6d4cf56db6-app", Namespace: "default",
+				Labels: map[string]string{starboard.LabelResourceKind: "ReplicaSet"}},
+			Report: v1alpha1.VulnerabilityReportData{Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2021-44228", Title: "log4j-core: Remote code execution"},
+			}},
+		},
+	).Build()
+	mgr := NewMgr(c, logr.Discard(), starboard.ConfigData{}, nil, nil)
+
+	require.NoError(t, mgr.GenerateComplianceReport(context.TODO(), spec))
+
+	var report v1alpha1.ClusterComplianceReport
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "nsa"}, &report))
+	require.Len(t, report.Status.ControlChecks, 1)
+	assert.Equal(t, "1.0", report.Status.ControlChecks[0].ID)
+	assert.Equal(t, 1, report.Status.ControlChecks[0].FailTotal)
+	assert.Empty(t, report.Status.ControlChecks[0].Error)
+
+	var details v1alpha1.ClusterComplianceDetailReport
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "nsa-details"}, &details))
+	require.Len(t, details.Report.ControlChecks, 1)
+	assert.Equal(t, []v1alpha1.ScannerCheckResult{
+		{ID: "CVE-2021-44228", ObjectType: "ReplicaSet", Scanner: "acme", Details: []v1alpha1.ResultDetails{
+			{Name: "replicaset-app-6d4cf56db6-app", Namespace: "default", Msg: "log4j-core: Remote code execution", Status: v1alpha1.FailStatus},
+		}},
+	}, details.Report.ControlChecks[0].ScannerCheckResult)
+}
+
 func TestMapComplianceScannerToResource(t *testing.T) {
 	mgr := cm{}
 	tests := []struct {
@@ -85,7 +191,7 @@ func TestMapComplianceScannerToResource(t *testing.T) {
 				t.Error(err)
 			}
 			pd := mgr.populateSpecDataToMaps(spec.Spec)
-			mapData := mapComplianceScannerToResource(tt.kClient, context.Background(), pd.scannerResourceListNames)
+			mapData, _, _ := mapComplianceScannerToResource(tt.kClient, context.Background(), pd.scannerResourceListNames, 0, 5)
 			var match bool
 			if len(mapData) > 0 {
 				for key, val := range tt.want {
@@ -110,6 +216,272 @@ func TestMapComplianceScannerToResource(t *testing.T) {
 	}
 }
 
+// slowListClient is a client whose List of the slowList type blocks until the
+// context is done.
+type slowListClient struct {
+	client.Client
+	slowList client.ObjectList
+}
+
+func (c *slowListClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if reflect.TypeOf(list) == reflect.TypeOf(c.slowList) {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+func TestMapComplianceScannerToResourceWithTimeout(t *testing.T) {
+	d, err := ioutil.ReadFile("./testdata/fixture/clusterComplianceSpec.json")
+	require.NoError(t, err)
+	var spec v1alpha1.ClusterComplianceReport
+	require.NoError(t, json.Unmarshal(d, &spec))
+	mgr := cm{}
+	pd := mgr.populateSpecDataToMaps(spec.Spec)
+	cli := &slowListClient{
+		Client:   GetClient(t, "./testdata/fixture/cisBenchmarkReportList.json", "./testdata/fixture/configAuditReportList.json"),
+		slowList: &v1alpha1.CISKubeBenchReportList{},
+	}
+
+	mapData, unavailableScanners, _ := mapComplianceScannerToResource(cli, context.Background(), pd.scannerResourceListNames, 100*time.Millisecond, 5)
+
+	assert.Equal(t, map[string]string{KubeBench: "Reading results of kube-bench scanner timed out after 100ms"}, unavailableScanners)
+	assert.NotContains(t, mapData, KubeBench)
+	require.Contains(t, mapData, ConfigAudit)
+	pods, ok := mapData[ConfigAudit]["Pod"].(*v1alpha1.ConfigAuditReportList)
+	require.True(t, ok)
+	assert.Len(t, pods.Items, 1)
+}
+
+// latencyClient is a client whose List takes at least the given latency, and
+// which records the maximum number of concurrent List calls.
+type latencyClient struct {
+	client.Client
+	latency  time.Duration
+	inFlight int32
+	overlap  int32
+}
+
+func (c *latencyClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	inFlight := atomic.AddInt32(&c.inFlight, 1)
+	defer atomic.AddInt32(&c.inFlight, -1)
+	for {
+		overlap := atomic.LoadInt32(&c.overlap)
+		if inFlight <= overlap || atomic.CompareAndSwapInt32(&c.overlap, overlap, inFlight) {
+			break
+		}
+	}
+	time.Sleep(c.latency)
+	return c.Client.List(ctx, list, opts...)
+}
+
+// failingListClient fails to list objects of the type of failList.
+type failingListClient struct {
+	client.Client
+	failList client.ObjectList
+	err      error
+}
+
+func (c *failingListClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if reflect.TypeOf(list) == reflect.TypeOf(c.failList) {
+		return c.err
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+func workloadSpecDataMapping() *specDataMapping {
+	mgr := cm{}
+	return mgr.populateSpecDataToMaps(v1alpha1.ReportSpec{Controls: []v1alpha1.Control{
+		{ID: "1.0", Kinds: []string{"Workload"}, Mapping: v1alpha1.Mapping{Scanner: ConfigAudit, Checks: []v1alpha1.SpecCheck{{ID: "KSV014"}}}},
+		{ID: "2.0", Kinds: []string{"Node"}, Mapping: v1alpha1.Mapping{Scanner: KubeBench, Checks: []v1alpha1.SpecCheck{{ID: "1.2.22"}}}},
+	}})
+}
+
+func TestMapComplianceScannerToResourceConcurrently(t *testing.T) {
+	pd := workloadSpecDataMapping()
+	cli := &latencyClient{
+		Client:  GetClient(t, "./testdata/fixture/cisBenchmarkReportList.json", "./testdata/fixture/configAuditReportList.json"),
+		latency: 20 * time.Millisecond,
+	}
+
+	mapData, unavailableScanners, listErrors := mapComplianceScannerToResource(cli, context.Background(), pd.scannerResourceListNames, 0, 3)
+
+	assert.Empty(t, unavailableScanners)
+	assert.Empty(t, listErrors)
+	assert.Len(t, mapData[ConfigAudit], 9)
+	assert.Len(t, mapData[KubeBench], 1)
+	overlap := atomic.LoadInt32(&cli.overlap)
+	assert.Greater(t, overlap, int32(1), "listings should overlap")
+	assert.LessOrEqual(t, overlap, int32(3), "listings should not exceed workers")
+}
+
+func TestMapComplianceScannerToResourceWithListErrors(t *testing.T) {
+	pd := workloadSpecDataMapping()
+	cli := &failingListClient{
+		Client:   GetClient(t, "./testdata/fixture/cisBenchmarkReportList.json", "./testdata/fixture/configAuditReportList.json"),
+		failList: &v1alpha1.CISKubeBenchReportList{},
+		err:      errors.New("forbidden"),
+	}
+
+	mapData, unavailableScanners, listErrors := mapComplianceScannerToResource(cli, context.Background(), pd.scannerResourceListNames, 0, 3)
+
+	assert.Empty(t, unavailableScanners)
+	assert.NotContains(t, mapData, KubeBench)
+	assert.Len(t, mapData[ConfigAudit], 9)
+	require.Contains(t, listErrors, KubeBench)
+	assert.EqualError(t, listErrors[KubeBench], "listing results of Node: forbidden")
+	assert.NotContains(t, listErrors, ConfigAudit)
+}
+
+func BenchmarkMapComplianceScannerToResource(b *testing.B) {
+	pd := workloadSpecDataMapping()
+	for _, workers := range []int{1, 5} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			cli := &latencyClient{
+				Client:  fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build(),
+				latency: time.Millisecond,
+			}
+			for i := 0; i < b.N; i++ {
+				mapComplianceScannerToResource(cli, context.Background(), pd.scannerResourceListNames, 0, workers)
+			}
+		})
+	}
+}
+
+func TestListScannerResourcesOfQualifiedKinds(t *testing.T) {
+	ingressReport := func(name, apiVersion string) *v1alpha1.ConfigAuditReport {
+		return &v1alpha1.ConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "default",
+				Labels:          map[string]string{starboard.LabelResourceKind: "Ingress"},
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: apiVersion, Kind: "Ingress", Name: name}},
+			},
+		}
+	}
+	cli := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		ingressReport("ingress-networking", "networking.k8s.io/v1"),
+		ingressReport("ingress-extensions", "extensions/v1beta1"),
+		&v1alpha1.ConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ingress-without-owner",
+				Namespace: "default",
+				Labels:    map[string]string{starboard.LabelResourceKind: "Ingress"},
+			},
+		},
+	).Build()
+	mgr := cm{}
+	pd := mgr.populateSpecDataToMaps(v1alpha1.ReportSpec{Controls: []v1alpha1.Control{
+		{ID: "1.0", Kinds: []string{"networking.k8s.io/v1/Ingress"}, Mapping: v1alpha1.Mapping{Scanner: ConfigAudit, Checks: []v1alpha1.SpecCheck{{ID: "KSV038"}}}},
+		{ID: "2.0", Kinds: []string{"Ingress"}, Mapping: v1alpha1.Mapping{Scanner: ConfigAudit, Checks: []v1alpha1.SpecCheck{{ID: "KSV039"}}}},
+	}})
+
+	mapData, unavailableScanners, listErrors := mapComplianceScannerToResource(cli, context.Background(), pd.scannerResourceListNames, 0, 5)
+	assert.Empty(t, listErrors)
+	assert.Empty(t, unavailableScanners)
+
+	names := func(kind string) []string {
+		list, ok := mapData[ConfigAudit][kind].(*v1alpha1.ConfigAuditReportList)
+		require.True(t, ok)
+		var names []string
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+		return names
+	}
+	assert.ElementsMatch(t, []string{"ingress-networking", "ingress-without-owner"}, names("networking.k8s.io/v1/Ingress"))
+	assert.ElementsMatch(t, []string{"ingress-networking", "ingress-extensions", "ingress-without-owner"}, names("Ingress"))
+}
+
+func TestGetObjListByKind(t *testing.T) {
+	tests := []struct {
+		name        string
+		scannerName string
+		kind        string
+		want        string
+	}{
+		{name: "conf audit namespaced kind", scannerName: ConfigAudit, kind: "Pod", want: "*v1alpha1.ConfigAuditReportList"},
+		{name: "conf audit cluster-scoped kind", scannerName: ConfigAudit, kind: "ClusterRole", want: "*v1alpha1.ClusterConfigAuditReportList"},
+		{name: "kube bench scanner name", scannerName: KubeBench, kind: "Node", want: "*v1alpha1.CISKubeBenchReportList"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, reflect.TypeOf(getObjListByKind(tt.scannerName, tt.kind)).String())
+		})
+	}
+	assert.Nil(t, getObjListByKind("", "Pod"))
+}
+
+func TestConfigAuditOfClusterScopedKinds(t *testing.T) {
+	cli := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.ClusterConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "clusterrole-admin",
+				Labels: map[string]string{
+					starboard.LabelResourceKind: "ClusterRole",
+					starboard.LabelResourceName: "admin",
+				},
+			},
+			Report: v1alpha1.ConfigAuditReportData{Checks: []v1alpha1.Check{
+				{ID: "KSV041", Messages: []string{"ClusterRole 'admin' shouldn't have access to manage secrets"}},
+			}},
+		},
+		&v1alpha1.ConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod-nginx",
+				Namespace: "default",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "Pod",
+					starboard.LabelResourceName:      "nginx",
+					starboard.LabelResourceNamespace: "default",
+				},
+			},
+			Report: v1alpha1.ConfigAuditReportData{Checks: []v1alpha1.Check{{ID: "KSV014", Success: true}}},
+		},
+	).Build()
+	mgr := &cm{client: cli, log: logr.Discard(), config: starboard.ConfigData{}}
+	smd := mgr.populateSpecDataToMaps(v1alpha1.ReportSpec{Controls: []v1alpha1.Control{
+		{ID: "1.0", Name: "Immutable container file systems", Kinds: []string{"Pod"}, Severity: "LOW",
+			Mapping: v1alpha1.Mapping{Scanner: ConfigAudit, Checks: []v1alpha1.SpecCheck{{ID: "KSV014"}}}},
+		{ID: "2.0", Name: "Manage secrets", Kinds: []string{"ClusterRole"}, Severity: "CRITICAL",
+			Mapping: v1alpha1.Mapping{Scanner: ConfigAudit, Checks: []v1alpha1.SpecCheck{{ID: "KSV041"}}}},
+		{ID: "3.0", Name: "Disabled check", Kinds: []string{"ClusterRole"}, Severity: "HIGH",
+			Mapping: v1alpha1.Mapping{Scanner: ConfigAudit, Checks: []v1alpha1.SpecCheck{{ID: "KSV042"}}}},
+	}})
+
+	mapData, unavailableScanners, listErrors := mapComplianceScannerToResource(cli, context.Background(), smd.scannerResourceListNames, 0, 5)
+	require.Empty(t, listErrors)
+	require.Empty(t, unavailableScanners)
+	require.IsType(t, &v1alpha1.ClusterConfigAuditReportList{}, mapData[ConfigAudit]["ClusterRole"])
+
+	checkIdsToResults, scannerErrors := mgr.checkIdsToResults(mapData)
+	require.Empty(t, scannerErrors)
+	require.Len(t, checkIdsToResults[checkKey(ConfigAudit, "KSV041")], 1)
+	assert.Equal(t, []ResultDetails{
+		{Name: "clusterrole-admin", Msg: "ClusterRole 'admin' shouldn't have access to manage secrets", Status: v1alpha1.FailStatus,
+			Resource: kube.ObjectRef{Kind: kube.KindClusterRole, Name: "admin"}},
+	}, checkIdsToResults[checkKey(ConfigAudit, "KSV041")][0].Details)
+
+	controlChecks := mgr.controlChecksByScannerChecks(smd, checkIdsToResults)
+	require.Len(t, controlChecks, 3)
+	totals := make(map[string][2]int)
+	for _, cc := range controlChecks {
+		totals[cc.ID] = [2]int{cc.PassTotal, cc.FailTotal}
+	}
+	assert.Equal(t, map[string][2]int{"1.0": {1, 0}, "2.0": {0, 1}, "3.0": {0, 0}}, totals)
+
+	t.Run("Should report checks disabled in Polaris without results", func(t *testing.T) {
+		details := mgr.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
+		statuses := make(map[string]v1alpha1.ControlStatus)
+		for _, detail := range details {
+			require.Len(t, detail.ScannerCheckResult, 1)
+			require.NotEmpty(t, detail.ScannerCheckResult[0].Details)
+			statuses[detail.ID] = detail.ScannerCheckResult[0].Details[0].Status
+		}
+		assert.Equal(t, v1alpha1.NoResultsStatus, statuses["3.0"])
+	})
+}
+
 func GetClient(t *testing.T, filePath ...string) client.Client {
 	if len(filePath) == 0 {
 		return fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithLists().Build()
@@ -139,10 +511,12 @@ func TestMapReportDataToMap(t *testing.T) {
 		reportList client.ObjectList
 		wantResult map[string]*ScannerCheckResult
 	}{
-		{name: "map config audit report", objectType: "Pod", reportList: getConfAudit([]string{"KSV037", "KSV038"}, []bool{true, false}, []string{"aaa", "bbb"}), wantResult: getWantResults("./testdata/fixture/config_audit_check_result.json"), mapfunc: configAudit{}.mapReportData},
-		{name: "map cis benchmark report", objectType: "Node", reportList: getCisInstance([]string{"1.1", "2.2"}, []string{"PASS", "FAIL"}, []string{"aaa", "bbb"}), wantResult: getWantResults("./testdata/fixture/cis_bench_check_result.json"), mapfunc: kubeBench{}.mapReportData},
-		{name: "map empty config report", objectType: "Pod", reportList: &v1alpha1.ConfigAuditReportList{}, wantResult: map[string]*ScannerCheckResult{}, mapfunc: configAudit{}.mapReportData},
-		{name: "map empty cis report ", objectType: "Node", reportList: &v1alpha1.CISKubeBenchReportList{}, wantResult: map[string]*ScannerCheckResult{}, mapfunc: kubeBench{}.mapReportData},
+		{name: "map config audit report", objectType: "Pod", reportList: getConfAudit([]string{"KSV037", "KSV038"}, []bool{true, false}, []string{"aaa", "bbb"}), wantResult: getWantResults("./testdata/fixture/config_audit_check_result.json"), mapfunc: configAudit{}.MapReportData},
+		{name: "map cis benchmark report", objectType: "Node", reportList: getCisInstance([]string{"1.1", "2.2"}, []string{"PASS", "FAIL"}, []string{"aaa", "bbb"}), wantResult: getWantResults("./testdata/fixture/cis_bench_check_result.json"), mapfunc: kubeBench{}.MapReportData},
+		{name: "map empty config report", objectType: "Pod", reportList: &v1alpha1.ConfigAuditReportList{}, wantResult: map[string]*ScannerCheckResult{}, mapfunc: configAudit{}.MapReportData},
+		{name: "map empty cis report ", objectType: "Node", reportList: &v1alpha1.CISKubeBenchReportList{}, wantResult: map[string]*ScannerCheckResult{}, mapfunc: kubeBench{}.MapReportData},
+		{name: "map kube hunter report", objectType: "Cluster", reportList: getKubeHunterInstance(), wantResult: getWantResults("./testdata/fixture/kube_hunter_check_result.json"), mapfunc: kubeHunter{}.MapReportData},
+		{name: "map empty kube hunter report", objectType: "Cluster", reportList: &v1alpha1.KubeHunterReportList{}, wantResult: map[string]*ScannerCheckResult{}, mapfunc: kubeHunter{}.MapReportData},
 	}
 
 	for _, tt := range tests {
@@ -195,3 +569,12 @@ func getCisInstance(testIds []string, testStatus []string, remediation []string)
 					{TestNumber: testIds[1], Status: testStatus[1], Remediation: remediation[1]}}}},
 			}}}}}}
 }
+
+func getKubeHunterInstance() *v1alpha1.KubeHunterReportList {
+	report := v1alpha1.KubeHunterReport{Report: v1alpha1.KubeHunterReportData{Vulnerabilities: []v1alpha1.KubeHunterVulnerability{
+		{ID: "KHV005", Severity: v1alpha1.KubeHunterSeverityMedium, Vulnerability: "Access to API using service account token", Location: "10.96.0.1:443", AvdReference: "https://avd.aquasec.com/kube-hunter/khv005/"},
+		{ID: "KHV050", Severity: v1alpha1.KubeHunterSeverityLow, Vulnerability: "Read access to pod's service account token", AvdReference: "https://avd.aquasec.com/kube-hunter/khv050/"},
+	}}}
+	report.Name = "cluster"
+	return &v1alpha1.KubeHunterReportList{Items: []v1alpha1.KubeHunterReport{report}}
+}
@@ -0,0 +1,193 @@
+package compliance
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// namespacedScanners are scanners whose results describe namespaced resources,
+// i.e. ConfigAuditReports. Results of other scanners describe nodes or the
+// whole cluster, therefore their controls are omitted from namespaced reports.
+var namespacedScanners = map[string]bool{
+	ConfigAudit: true,
+}
+
+// withControls returns a copy of the spec data mapping restricted to controls
+// for which the given function returns true. Excluded controls are omitted,
+// because they are not evaluated in any namespace.
+func (smd *specDataMapping) withControls(include func(control v1alpha1.Control, checkIds []string) bool) *specDataMapping {
+	filtered := *smd
+	filtered.excludedControls = nil
+	filtered.controlCheckIds = make(map[string][]string)
+	for controlID, checkIds := range smd.controlCheckIds {
+		if include(smd.controlIDControlObject[controlID], checkIds) {
+			filtered.controlCheckIds[controlID] = checkIds
+		}
+	}
+	return &filtered
+}
+
+// resultsByNamespace splits results of checks of the given controls by
+// namespaces of the resources they were reported for, keyed by checkKey as
+// the given results. Results of cluster-scoped resources are omitted, so that
+// namespaces without any results are not returned.
+func resultsByNamespace(smd *specDataMapping, checkIdsToResults map[string][]*ScannerCheckResult) map[string]map[string][]*ScannerCheckResult {
+	byNamespace := make(map[string]map[string][]*ScannerCheckResult)
+	seen := make(map[string]bool)
+	for controlID, checkIds := range smd.controlCheckIds {
+		for _, checkId := range checkIds {
+			key := checkKey(smd.checkScanner(controlID, checkId), checkId)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			for _, result := range checkIdsToResults[key] {
+				detailsByNamespace := make(map[string][]ResultDetails)
+				for _, details := range result.Details {
+					if details.Namespace == "" {
+						continue
+					}
+					detailsByNamespace[details.Namespace] = append(detailsByNamespace[details.Namespace], details)
+				}
+				for namespace, details := range detailsByNamespace {
+					if _, ok := byNamespace[namespace]; !ok {
+						byNamespace[namespace] = make(map[string][]*ScannerCheckResult)
+					}
+					namespaceResult := *result
+					namespaceResult.Details = details
+					byNamespace[namespace][key] = append(byNamespace[namespace][key], &namespaceResult)
+				}
+			}
+		}
+	}
+	return byNamespace
+}
+
+// namespaceControlChecks returns control checks of the given namespaced
+// controls computed only from results in a single namespace. Controls without
+// results in the namespace are omitted rather than reported with their default
+// status, because the namespace may not have resources of mapped kinds. So are
+// controls which exclude the namespace.
+func (w *cm) namespaceControlChecks(smd *specDataMapping, results map[string][]*ScannerCheckResult) []v1alpha1.ControlCheck {
+	reported := smd.withControls(func(control v1alpha1.Control, checkIds []string) bool {
+		exempted, _ := smd.controlResults(control, checkIds, results)
+		for _, checkId := range checkIds {
+			if _, ok := exempted[checkId]; ok {
+				return true
+			}
+		}
+		return false
+	})
+	controlChecks := w.controlChecksByScannerChecks(reported, results)
+	sort.Slice(controlChecks, func(i, j int) bool {
+		return controlChecks[i].ID < controlChecks[j].ID
+	})
+	return controlChecks
+}
+
+// updateNamespaceReports writes a ComplianceReport to each namespace with
+// results of namespaced controls of the given spec, and deletes reports of
+// namespaces which no longer have any results. Reports are left intact if
+// results of namespaced scanners are unavailable, or skipped altogether if
+// the ComplianceReport CRD is not installed.
+func (w *cm) updateNamespaceReports(ctx context.Context, spec v1alpha1.ReportSpec, smd *specDataMapping, checkIdsToResults map[string][]*ScannerCheckResult) error {
+	name := strings.ToLower(spec.Name)
+	var existing v1alpha1.ComplianceReportList
+	err := w.client.List(ctx, &existing, client.MatchingLabels{starboard.LabelComplianceReportName: name})
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			w.log.V(1).Info("Skipping namespaced compliance reports, the ComplianceReport CRD is not installed")
+			return nil
+		}
+		return err
+	}
+	for scanner := range smd.unavailableScanners {
+		if namespacedScanners[scanner] {
+			w.log.Info("Skipping namespaced compliance reports of unavailable scanner", "scanner", scanner)
+			return nil
+		}
+	}
+	var owner v1alpha1.ClusterComplianceReport
+	err = w.client.Get(ctx, types.NamespacedName{Name: name}, &owner)
+	if err != nil {
+		return err
+	}
+
+	namespaced := smd.withControls(func(control v1alpha1.Control, _ []string) bool {
+		for _, mapping := range scannerMappings(control.Mapping) {
+			if namespacedScanners[mapping.Scanner] {
+				return true
+			}
+		}
+		return false
+	})
+	namespaceResults := resultsByNamespace(namespaced, checkIdsToResults)
+	for namespace, results := range namespaceResults {
+		controlChecks := w.namespaceControlChecks(namespaced, results)
+		st := w.getTotals(controlChecks)
+		report := v1alpha1.ComplianceReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					starboard.LabelComplianceReportName: name,
+				},
+			},
+			Report: v1alpha1.ComplianceReportData{
+				UpdateTimestamp: metav1.NewTime(ext.NewSystemClock().Now()),
+				Type:            v1alpha1.Compliance{Name: name, Description: strings.ToLower(spec.Description), Version: spec.Version},
+				Summary:         complianceSummary(st),
+				ControlChecks:   controlChecks,
+			},
+		}
+		err = controllerutil.SetOwnerReference(&owner, &report, w.client.Scheme())
+		if err != nil {
+			return err
+		}
+		err = w.writeNamespaceReport(ctx, report)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range existing.Items {
+		if _, ok := namespaceResults[existing.Items[i].Namespace]; ok || kube.IsProtected(&existing.Items[i]) {
+			continue
+		}
+		err = w.client.Delete(ctx, &existing.Items[i])
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeNamespaceReport creates the given ComplianceReport or updates the
+// existing one.
+func (w *cm) writeNamespaceReport(ctx context.Context, report v1alpha1.ComplianceReport) error {
+	var existing v1alpha1.ComplianceReport
+	err := w.client.Get(ctx, types.NamespacedName{Namespace: report.Namespace, Name: report.Name}, &existing)
+	if err == nil {
+		copied := existing.DeepCopy()
+		copied.Labels = report.Labels
+		copied.OwnerReferences = report.OwnerReferences
+		copied.Report = report.Report
+		return w.client.Update(ctx, copied)
+	}
+	if errors.IsNotFound(err) {
+		return w.client.Create(ctx, &report)
+	}
+	return err
+}
@@ -0,0 +1,113 @@
+package compliance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestUpdateNamespaceReports(t *testing.T) {
+	spec := v1alpha1.ReportSpec{
+		Name:        "nsa",
+		Description: "National Security Agency",
+		Version:     "1.0",
+		Controls: []v1alpha1.Control{
+			{ID: "1.0", Name: "Non-root containers", Kinds: []string{"Pod"}, Severity: "MEDIUM",
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}}},
+			{ID: "1.1", Name: "Immutable container file systems", Kinds: []string{"Pod"}, Severity: "LOW", DefaultStatus: v1alpha1.FailStatus,
+				Mapping: v1alpha1.Mapping{Scanner: "config-audit", Checks: []v1alpha1.SpecCheck{{ID: "KSV014"}}}},
+			{ID: "5.0", Name: "Encrypt etcd", Kinds: []string{"Node"}, Severity: "CRITICAL",
+				Mapping: v1alpha1.Mapping{Scanner: "kube-bench", Checks: []v1alpha1.SpecCheck{{ID: "2.1"}}}},
+		},
+	}
+	checkIdsToResults := map[string][]*ScannerCheckResult{
+		checkKey(ConfigAudit, "KSV012"): {{ID: "KSV012", ObjectType: "Pod", Details: []ResultDetails{
+			{Name: "pod-a", Namespace: "default", Status: v1alpha1.FailStatus},
+			{Name: "pod-b", Namespace: "default", Status: v1alpha1.PassStatus},
+			{Name: "pod-c", Namespace: "qa", Status: v1alpha1.PassStatus},
+		}}},
+		checkKey(ConfigAudit, "KSV014"): {{ID: "KSV014", ObjectType: "Pod", Details: []ResultDetails{
+			{Name: "pod-a", Namespace: "default", Status: v1alpha1.PassStatus},
+		}}},
+		checkKey(KubeBench, "2.1"): {{ID: "2.1", ObjectType: "Node", Details: []ResultDetails{
+			{Name: "node-1", Status: v1alpha1.FailStatus},
+		}}},
+	}
+	owner := &v1alpha1.ClusterComplianceReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "nsa", UID: "nsa-uid"},
+		Spec:       spec,
+	}
+	stale := &v1alpha1.ComplianceReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nsa",
+			Namespace: "old",
+			Labels:    map[string]string{starboard.LabelComplianceReportName: "nsa"},
+		},
+	}
+	ctx := context.TODO()
+
+	t.Run("Should write reports of namespaces with results", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(owner, stale).Build()
+		mgr := cm{client: client, log: log.Log, config: getStarboardConfig()}
+		smd := mgr.populateSpecDataToMaps(spec)
+
+		err := mgr.updateNamespaceReports(ctx, spec, smd, checkIdsToResults)
+		require.NoError(t, err)
+
+		var report v1alpha1.ComplianceReport
+		err = client.Get(ctx, types.NamespacedName{Namespace: "default", Name: "nsa"}, &report)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{starboard.LabelComplianceReportName: "nsa"}, report.Labels)
+		require.Len(t, report.OwnerReferences, 1)
+		assert.Equal(t, "ClusterComplianceReport", report.OwnerReferences[0].Kind)
+		assert.Equal(t, v1alpha1.Compliance{Name: "nsa", Description: "national security agency", Version: "1.0"}, report.Report.Type)
+		assert.Equal(t, v1alpha1.ClusterComplianceSummary{PassCount: 2, FailCount: 1, MediumFailCount: 1, SummaryBySeverity: map[string]v1alpha1.ControlCount{
+			"MEDIUM": {Fail: 1},
+			"LOW":    {Pass: 1},
+		}, Score: pointer.Int(50)}, report.Report.Summary)
+		assert.Equal(t, []v1alpha1.ControlCheck{
+			{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM", PassTotal: 1, FailTotal: 1, Score: pointer.Int(50)},
+			{ID: "1.1", Name: "Immutable container file systems", Severity: "LOW", PassTotal: 1, Score: pointer.Int(100)},
+		}, report.Report.ControlChecks)
+
+		err = client.Get(ctx, types.NamespacedName{Namespace: "qa", Name: "nsa"}, &report)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.ClusterComplianceSummary{PassCount: 1, SummaryBySeverity: map[string]v1alpha1.ControlCount{
+			"MEDIUM": {Pass: 1},
+		}, Score: pointer.Int(100)}, report.Report.Summary)
+		assert.Equal(t, []v1alpha1.ControlCheck{
+			{ID: "1.0", Name: "Non-root containers", Severity: "MEDIUM", PassTotal: 1, Score: pointer.Int(100)},
+		}, report.Report.ControlChecks)
+
+		err = client.Get(ctx, types.NamespacedName{Namespace: "old", Name: "nsa"}, &report)
+		assert.True(t, errors.IsNotFound(err))
+		err = client.Get(ctx, types.NamespacedName{Namespace: "", Name: "nsa"}, &report)
+		assert.True(t, errors.IsNotFound(err))
+	})
+
+	t.Run("Should keep reports when namespaced scanner is unavailable", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(owner, stale).Build()
+		mgr := cm{client: client, log: log.Log, config: getStarboardConfig()}
+		smd := mgr.populateSpecDataToMaps(spec)
+		smd.unavailableScanners = map[string]string{ConfigAudit: "Reading results of config-audit scanner timed out after 1s"}
+
+		err := mgr.updateNamespaceReports(ctx, spec, smd, map[string][]*ScannerCheckResult{})
+		require.NoError(t, err)
+
+		var report v1alpha1.ComplianceReport
+		err = client.Get(ctx, types.NamespacedName{Namespace: "old", Name: "nsa"}, &report)
+		assert.NoError(t, err)
+		err = client.Get(ctx, types.NamespacedName{Namespace: "default", Name: "nsa"}, &report)
+		assert.True(t, errors.IsNotFound(err))
+	})
+}
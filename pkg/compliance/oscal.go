@@ -0,0 +1,342 @@
+package compliance
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+)
+
+const (
+	// OSCALVersion is the version of the OSCAL model of exported documents.
+	OSCALVersion = "1.0.4"
+
+	// oscalNamespace is the namespace of OSCAL properties defined by Starboard.
+	oscalNamespace = "https://aquasecurity.github.io/starboard"
+)
+
+// OSCALAssessmentResultsDocument is the root of an OSCAL assessment-results
+// document. Only the subset of the model populated from compliance reports is
+// defined.
+type OSCALAssessmentResultsDocument struct {
+	AssessmentResults OSCALAssessmentResults `json:"assessment-results"`
+}
+
+type OSCALAssessmentResults struct {
+	UUID     string        `json:"uuid"`
+	Metadata OSCALMetadata `json:"metadata"`
+	ImportAP OSCALImportAP `json:"import-ap"`
+	Results  []OSCALResult `json:"results"`
+}
+
+type OSCALMetadata struct {
+	Title        string    `json:"title"`
+	LastModified time.Time `json:"last-modified"`
+	Version      string    `json:"version"`
+	OSCALVersion string    `json:"oscal-version"`
+}
+
+type OSCALImportAP struct {
+	Href string `json:"href"`
+}
+
+type OSCALProperty struct {
+	Name  string `json:"name"`
+	NS    string `json:"ns,omitempty"`
+	Value string `json:"value"`
+}
+
+type OSCALResult struct {
+	UUID             string                 `json:"uuid"`
+	Title            string                 `json:"title"`
+	Description      string                 `json:"description"`
+	Start            time.Time              `json:"start"`
+	Props            []OSCALProperty        `json:"props,omitempty"`
+	LocalDefinitions *OSCALLocalDefinitions `json:"local-definitions,omitempty"`
+	ReviewedControls OSCALReviewedControls  `json:"reviewed-controls"`
+	Observations     []OSCALObservation     `json:"observations,omitempty"`
+	Findings         []OSCALFinding         `json:"findings,omitempty"`
+}
+
+type OSCALLocalDefinitions struct {
+	InventoryItems []OSCALInventoryItem `json:"inventory-items,omitempty"`
+}
+
+type OSCALInventoryItem struct {
+	UUID        string          `json:"uuid"`
+	Description string          `json:"description"`
+	Props       []OSCALProperty `json:"props,omitempty"`
+}
+
+type OSCALReviewedControls struct {
+	ControlSelections []OSCALControlSelection `json:"control-selections"`
+}
+
+type OSCALControlSelection struct {
+	IncludeControls []OSCALControlReference `json:"include-controls,omitempty"`
+}
+
+type OSCALControlReference struct {
+	ControlID string `json:"control-id"`
+}
+
+type OSCALObservation struct {
+	UUID             string                  `json:"uuid"`
+	Title            string                  `json:"title"`
+	Description      string                  `json:"description"`
+	Props            []OSCALProperty         `json:"props,omitempty"`
+	Methods          []string                `json:"methods"`
+	Subjects         []OSCALSubjectReference `json:"subjects,omitempty"`
+	RelevantEvidence []OSCALRelevantEvidence `json:"relevant-evidence,omitempty"`
+	Collected        time.Time               `json:"collected"`
+}
+
+type OSCALSubjectReference struct {
+	SubjectUUID string          `json:"subject-uuid"`
+	Type        string          `json:"type"`
+	Title       string          `json:"title,omitempty"`
+	Props       []OSCALProperty `json:"props,omitempty"`
+	Remarks     string          `json:"remarks,omitempty"`
+}
+
+type OSCALRelevantEvidence struct {
+	Description string `json:"description"`
+}
+
+type OSCALFinding struct {
+	UUID                string                    `json:"uuid"`
+	Title               string                    `json:"title"`
+	Description         string                    `json:"description"`
+	Props               []OSCALProperty           `json:"props,omitempty"`
+	Target              OSCALFindingTarget        `json:"target"`
+	RelatedObservations []OSCALRelatedObservation `json:"related-observations,omitempty"`
+	Remarks             string                    `json:"remarks,omitempty"`
+}
+
+type OSCALFindingTarget struct {
+	Type     string               `json:"type"`
+	TargetID string               `json:"target-id"`
+	Status   OSCALObjectiveStatus `json:"status"`
+}
+
+type OSCALObjectiveStatus struct {
+	State string `json:"state"`
+}
+
+type OSCALRelatedObservation struct {
+	ObservationUUID string `json:"observation-uuid"`
+}
+
+// ToOSCALAssessmentResults converts the given ClusterComplianceReport and its
+// ClusterComplianceDetailReport into an OSCAL assessment-results document.
+// Each control is converted into a finding, which is satisfied unless the
+// control failed and is not waived. Each scanner check result of the detail
+// report is converted into an observation related to the finding of its
+// control, and objects which were checked are converted into subjects of
+// the observation.
+func ToOSCALAssessmentResults(report v1alpha1.ClusterComplianceReport, detail v1alpha1.ClusterComplianceDetailReport,
+	idGenerator ext.IDGenerator, clock ext.Clock) OSCALAssessmentResultsDocument {
+	controlChecks := append(report.Status.ControlChecks[:0:0], report.Status.ControlChecks...)
+	sort.SliceStable(controlChecks, func(i, j int) bool {
+		return controlChecks[i].ID < controlChecks[j].ID
+	})
+	detailsByControlID := make(map[string]v1alpha1.ControlCheckDetails)
+	for _, details := range detail.Report.ControlChecks {
+		detailsByControlID[details.ID] = details
+	}
+
+	result := OSCALResult{
+		UUID:        idGenerator.GenerateID(),
+		Title:       fmt.Sprintf("Compliance assessment with %s %s", report.Spec.Name, report.Spec.Version),
+		Description: report.Spec.Description,
+		Start:       report.Status.UpdateTimestamp.UTC(),
+		Props:       clusterProps(report.Status.Cluster),
+	}
+	inventory := newOSCALInventory(idGenerator)
+	var includeControls []OSCALControlReference
+
+	for _, controlCheck := range controlChecks {
+		includeControls = append(includeControls, OSCALControlReference{ControlID: controlCheck.ID})
+
+		description := controlCheck.Description
+		if description == "" {
+			description = controlCheck.Name
+		}
+		finding := OSCALFinding{
+			UUID:        idGenerator.GenerateID(),
+			Title:       controlCheck.Name,
+			Description: description,
+			Props: []OSCALProperty{
+				{Name: "severity", NS: oscalNamespace, Value: string(controlCheck.Severity)},
+				{Name: "pass-total", NS: oscalNamespace, Value: strconv.Itoa(controlCheck.PassTotal)},
+				{Name: "fail-total", NS: oscalNamespace, Value: strconv.Itoa(controlCheck.FailTotal)},
+			},
+			Target: OSCALFindingTarget{
+				Type:     "objective-id",
+				TargetID: controlCheck.ID,
+				Status:   OSCALObjectiveStatus{State: objectiveState(controlCheck)},
+			},
+		}
+		if controlCheck.Status == v1alpha1.DataUnavailableStatus {
+			finding.Remarks = "Not assessed because scanner results were unavailable."
+		}
+		if controlCheck.Status == v1alpha1.NotApplicableStatus {
+			finding.Remarks = "Not assessed because the control does not apply to the cluster."
+		}
+		if controlCheck.Status == v1alpha1.ExcludedStatus {
+			finding.Remarks = "Not assessed because the control is excluded by the compliance spec."
+		}
+		if controlCheck.Status == v1alpha1.ManualStatus {
+			finding.Remarks = "Not assessed because the control must be assessed manually."
+		}
+		if controlCheck.Status == v1alpha1.ErrorStatus {
+			finding.Remarks = fmt.Sprintf("Not assessed because scanner results could not be mapped: %s.", controlCheck.Error)
+		}
+		if controlCheck.Status == v1alpha1.NoResultsStatus {
+			finding.Remarks = "Not assessed because scanners reported no results of the mapped checks."
+		}
+		if controlCheck.Waiver != nil {
+			finding.Remarks = fmt.Sprintf("Waived with the %s annotation until %s.",
+				controlCheck.Waiver.Annotation, controlCheck.Waiver.Expires.UTC().Format(time.RFC3339))
+		}
+
+		for _, checkResult := range detailsByControlID[controlCheck.ID].ScannerCheckResult {
+			observation := toOSCALObservation(checkResult, idGenerator, inventory, detail.Report.UpdateTimestamp.UTC())
+			result.Observations = append(result.Observations, observation)
+			finding.RelatedObservations = append(finding.RelatedObservations, OSCALRelatedObservation{
+				ObservationUUID: observation.UUID,
+			})
+		}
+		result.Findings = append(result.Findings, finding)
+	}
+
+	result.ReviewedControls = OSCALReviewedControls{
+		ControlSelections: []OSCALControlSelection{{IncludeControls: includeControls}},
+	}
+	if len(inventory.items) > 0 {
+		result.LocalDefinitions = &OSCALLocalDefinitions{InventoryItems: inventory.items}
+	}
+
+	return OSCALAssessmentResultsDocument{
+		AssessmentResults: OSCALAssessmentResults{
+			UUID: idGenerator.GenerateID(),
+			Metadata: OSCALMetadata{
+				Title:        fmt.Sprintf("%s compliance assessment results", report.Spec.Name),
+				LastModified: clock.Now().UTC(),
+				Version:      report.Spec.Version,
+				OSCALVersion: OSCALVersion,
+			},
+			ImportAP: OSCALImportAP{Href: "#" + report.Name},
+			Results:  []OSCALResult{result},
+		},
+	}
+}
+
+func toOSCALObservation(checkResult v1alpha1.ScannerCheckResult, idGenerator ext.IDGenerator, inventory *oscalInventory, collected time.Time) OSCALObservation {
+	title := checkResult.ID
+	if title == "" {
+		title = checkResult.ObjectType
+	}
+	observation := OSCALObservation{
+		UUID:        idGenerator.GenerateID(),
+		Title:       title,
+		Description: fmt.Sprintf("Results of the %s check of %s objects", title, checkResult.ObjectType),
+		Methods:     []string{"TEST"},
+		Collected:   collected,
+	}
+	if checkResult.Remediation != "" {
+		observation.Props = []OSCALProperty{
+			{Name: "remediation", NS: oscalNamespace, Value: checkResult.Remediation},
+		}
+	}
+	for _, details := range checkResult.Details {
+		if details.Name == "" {
+			observation.RelevantEvidence = append(observation.RelevantEvidence, OSCALRelevantEvidence{
+				Description: fmt.Sprintf("%s: %s", details.Status, details.Msg),
+			})
+			continue
+		}
+		observation.Subjects = append(observation.Subjects, OSCALSubjectReference{
+			SubjectUUID: inventory.itemUUID(checkResult.ObjectType, details.Namespace, details.Name),
+			Type:        "inventory-item",
+			Title:       objectTitle(checkResult.ObjectType, details.Namespace, details.Name),
+			Props: []OSCALProperty{
+				{Name: "status", NS: oscalNamespace, Value: string(details.Status)},
+			},
+			Remarks: details.Msg,
+		})
+	}
+	return observation
+}
+
+func objectiveState(controlCheck v1alpha1.ControlCheck) string {
+	if controlCheck.Status == v1alpha1.DataUnavailableStatus || controlCheck.Status == v1alpha1.NoResultsStatus ||
+		controlCheck.Status == v1alpha1.ManualStatus || controlCheck.Status == v1alpha1.ErrorStatus {
+		return "not-satisfied"
+	}
+	if controlCheck.FailTotal > 0 && controlCheck.Status != v1alpha1.WaivedStatus {
+		return "not-satisfied"
+	}
+	return "satisfied"
+}
+
+func clusterProps(cluster *v1alpha1.ClusterMetadata) []OSCALProperty {
+	if cluster == nil {
+		return nil
+	}
+	var props []OSCALProperty
+	if cluster.Name != "" {
+		props = append(props, OSCALProperty{Name: "cluster-name", NS: oscalNamespace, Value: cluster.Name})
+	}
+	props = append(props,
+		OSCALProperty{Name: "kubernetes-version", NS: oscalNamespace, Value: cluster.KubernetesVersion},
+		OSCALProperty{Name: "node-count", NS: oscalNamespace, Value: strconv.Itoa(cluster.NodeCount)})
+	if cluster.Provider != "" {
+		props = append(props, OSCALProperty{Name: "cluster-provider", NS: oscalNamespace, Value: cluster.Provider})
+	}
+	return props
+}
+
+func objectTitle(kind, namespace, name string) string {
+	if namespace == "" {
+		return fmt.Sprintf("%s %s", kind, name)
+	}
+	return fmt.Sprintf("%s %s/%s", kind, namespace, name)
+}
+
+// oscalInventory assigns a single inventory item to each Kubernetes object
+// referenced by observations.
+type oscalInventory struct {
+	idGenerator ext.IDGenerator
+	uuids       map[string]string
+	items       []OSCALInventoryItem
+}
+
+func newOSCALInventory(idGenerator ext.IDGenerator) *oscalInventory {
+	return &oscalInventory{
+		idGenerator: idGenerator,
+		uuids:       make(map[string]string),
+	}
+}
+
+func (i *oscalInventory) itemUUID(kind, namespace, name string) string {
+	key := kind + "/" + namespace + "/" + name
+	if uuid, ok := i.uuids[key]; ok {
+		return uuid
+	}
+	uuid := i.idGenerator.GenerateID()
+	i.uuids[key] = uuid
+	props := []OSCALProperty{{Name: "kind", NS: oscalNamespace, Value: kind}}
+	if namespace != "" {
+		props = append(props, OSCALProperty{Name: "namespace", NS: oscalNamespace, Value: namespace})
+	}
+	i.items = append(i.items, OSCALInventoryItem{
+		UUID:        uuid,
+		Description: objectTitle(kind, namespace, name),
+		Props:       append(props, OSCALProperty{Name: "name", NS: oscalNamespace, Value: name}),
+	})
+	return uuid
+}
@@ -0,0 +1,54 @@
+package compliance
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToOSCALAssessmentResults(t *testing.T) {
+	testCases := []struct {
+		name   string
+		report string
+		detail string
+		golden string
+	}{
+		{
+			name:   "Should map controls to findings and checked objects to subjects",
+			report: "./testdata/fixture/oscal/clusterComplianceReport.json",
+			detail: "./testdata/fixture/oscal/clusterComplianceDetailReport.json",
+			golden: "./testdata/fixture/oscal/assessmentResults.json",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var report v1alpha1.ClusterComplianceReport
+			readJSONFixture(t, tc.report, &report)
+			var detail v1alpha1.ClusterComplianceDetailReport
+			readJSONFixture(t, tc.detail, &detail)
+
+			document := ToOSCALAssessmentResults(report, detail, ext.NewSimpleIDGenerator(),
+				ext.NewFixedClock(time.Date(2022, 4, 1, 12, 0, 0, 0, time.UTC)))
+
+			actual, err := json.MarshalIndent(document, "", "  ")
+			require.NoError(t, err)
+			expected, err := ioutil.ReadFile(tc.golden)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(actual))
+		})
+	}
+}
+
+func readJSONFixture(t *testing.T, path string, v interface{}) {
+	t.Helper()
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, v))
+}
@@ -0,0 +1,55 @@
+package compliance
+
+import (
+	"hash/fnv"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/gorhill/cronexpr"
+)
+
+// nextGenerationTime returns the time when the compliance report with the given
+// name and spec is generated next after the specified time. Each activation of
+// the cron expression is delayed by the jitter of the report, which is a fixed
+// fraction, derived from the name of the report, of up to CronJitterPercent of
+// the interval until the following activation. Reports with the same cron
+// expression are therefore generated at different times, while the schedule
+// of each report stays stable.
+func nextGenerationTime(name string, spec v1alpha1.ReportSpec, after time.Time) (time.Time, error) {
+	expr, err := cronexpr.Parse(spec.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+	fraction := jitterFraction(name, spec.CronJitterPercent)
+	delayed := func(activation time.Time) time.Time {
+		if fraction == 0 {
+			return activation
+		}
+		interval := expr.Next(activation).Sub(activation)
+		return activation.Add(time.Duration(fraction * float64(interval)))
+	}
+	// The delayed activation preceding the next one may still be ahead.
+	next := expr.Next(after)
+	activation := expr.Next(after.Add(-expr.Next(next).Sub(next)))
+	for !activation.IsZero() && !delayed(activation).After(after) {
+		activation = expr.Next(activation)
+	}
+	if activation.IsZero() {
+		return activation, nil
+	}
+	return delayed(activation), nil
+}
+
+// jitterFraction returns the fraction of the interval between activations of
+// the cron expression by which generations of the named report are delayed.
+func jitterFraction(name string, percent int) float64 {
+	if percent <= 0 {
+		return 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	return float64(h.Sum32()) / (1 << 32) * float64(percent) / 100
+}
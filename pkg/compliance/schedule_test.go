@@ -0,0 +1,60 @@
+package compliance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextGenerationTime(t *testing.T) {
+	after := time.Date(2022, 6, 1, 10, 20, 0, 0, time.UTC)
+
+	t.Run("Should return next activation without jitter", func(t *testing.T) {
+		next, err := nextGenerationTime("nsa", v1alpha1.ReportSpec{Cron: "0 * * * *"}, after)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2022, 6, 1, 11, 0, 0, 0, time.UTC), next)
+	})
+
+	t.Run("Should delay activations by the jitter of the report", func(t *testing.T) {
+		spec := v1alpha1.ReportSpec{Cron: "0 * * * *", CronJitterPercent: 50}
+		delay := time.Duration(jitterFraction("nsa", 50) * float64(time.Hour))
+		require.True(t, delay > 0 && delay < 30*time.Minute)
+
+		next, err := nextGenerationTime("nsa", spec, time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC).Add(delay))
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2022, 6, 1, 11, 0, 0, 0, time.UTC).Add(delay), next)
+
+		next, err = nextGenerationTime("nsa", spec, time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC).Add(delay), next,
+			"delayed activation which precedes the next activation should not be skipped")
+	})
+
+	t.Run("Should spread reports with the same cron expression", func(t *testing.T) {
+		spec := v1alpha1.ReportSpec{Cron: "0 * * * *", CronJitterPercent: 100}
+		nsa, err := nextGenerationTime("nsa", spec, after)
+		require.NoError(t, err)
+		cis, err := nextGenerationTime("cis", spec, after)
+		require.NoError(t, err)
+		assert.NotEqual(t, nsa, cis)
+		for _, next := range []time.Time{nsa, cis} {
+			assert.True(t, next.After(after))
+			assert.True(t, next.Before(after.Add(time.Hour)))
+		}
+	})
+
+	t.Run("Should return error for invalid cron expression", func(t *testing.T) {
+		_, err := nextGenerationTime("nsa", v1alpha1.ReportSpec{Cron: "every day"}, after)
+		assert.EqualError(t, err, "missing field(s)")
+	})
+}
+
+func TestJitterFraction(t *testing.T) {
+	assert.Equal(t, 0.0, jitterFraction("nsa", 0))
+	assert.Equal(t, jitterFraction("nsa", 10), jitterFraction("nsa", 10))
+	assert.InDelta(t, jitterFraction("nsa", 100)/10, jitterFraction("nsa", 10), 1e-9)
+	assert.Less(t, jitterFraction("nsa", 100), 1.0)
+}
@@ -0,0 +1,102 @@
+package compliance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// namespaceScope is the set of namespaces whose results are included in a
+// report scoped with v1alpha1.ReportSpec.Namespaces or
+// v1alpha1.ReportSpec.NamespaceSelector. Namespaces matching the selector are
+// looked up once per generation, so that namespaces created or relabeled
+// since the previous generation are in the scope of the next one. A nil
+// namespaceScope includes all namespaces.
+type namespaceScope map[string]bool
+
+// namespaceScope returns the namespaces in the scope of the given spec, or nil
+// if the spec is not scoped to namespaces.
+func (w *cm) namespaceScope(ctx context.Context, spec v1alpha1.ReportSpec) (namespaceScope, error) {
+	if len(spec.Namespaces) == 0 && spec.NamespaceSelector == nil {
+		return nil, nil
+	}
+	scope := make(namespaceScope)
+	for _, namespace := range spec.Namespaces {
+		scope[namespace] = true
+	}
+	if spec.NamespaceSelector == nil {
+		return scope, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(spec.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid compliance spec namespaceSelector: %w", err)
+	}
+	var namespaces corev1.NamespaceList
+	err = w.client.List(ctx, &namespaces, client.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("listing namespaces: %w", err)
+	}
+	for _, namespace := range namespaces.Items {
+		scope[namespace.Name] = true
+	}
+	return scope, nil
+}
+
+// includes returns true if results of resources in the given namespace are in
+// the scope. Results of cluster-scoped resources are always in the scope.
+func (s namespaceScope) includes(namespace string) bool {
+	return s == nil || namespace == "" || s[namespace]
+}
+
+// filterReports removes reports of namespaces out of the scope from the given
+// lists of scanner reports, before they're hashed and mapped to checks.
+func (s namespaceScope) filterReports(scannerResourceMap map[string]map[string]client.ObjectList) error {
+	if s == nil {
+		return nil
+	}
+	for _, resourceLists := range scannerResourceMap {
+		for _, list := range resourceLists {
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				return err
+			}
+			kept := items[:0]
+			for _, item := range items {
+				obj, err := meta.Accessor(item)
+				if err != nil {
+					return err
+				}
+				if s.includes(obj.GetNamespace()) {
+					kept = append(kept, item)
+				}
+			}
+			err = meta.SetList(list, kept)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// markClusterScoped flags results of cluster-scoped resources, which are
+// included in the report regardless of the scope.
+func (s namespaceScope) markClusterScoped(checkIdsToResults map[string][]*ScannerCheckResult) {
+	if s == nil {
+		return
+	}
+	for _, results := range checkIdsToResults {
+		for _, result := range results {
+			for i := range result.Details {
+				if result.Details[i].Namespace == "" {
+					result.Details[i].ClusterScoped = true
+				}
+			}
+		}
+	}
+}
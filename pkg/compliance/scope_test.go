@@ -0,0 +1,161 @@
+package compliance
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGenerateComplianceReport_NamespaceScope(t *testing.T) {
+	spec := v1alpha1.ReportSpec{Name: "prod", Version: "1.0", Cron: "0 */6 * * *",
+		Namespaces:        []string{"payments"},
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+		Controls: []v1alpha1.Control{
+			{ID: "1.0", Name: "Non-root containers", Kinds: []string{"Pod"}, Severity: "MEDIUM",
+				Mapping: v1alpha1.Mapping{Scanner: ConfigAudit, Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}}},
+			{ID: "2.0", Name: "Manage secrets", Kinds: []string{"ClusterRole"}, Severity: "CRITICAL",
+				Mapping: v1alpha1.Mapping{Scanner: ConfigAudit, Checks: []v1alpha1.SpecCheck{{ID: "KSV041"}}}},
+		}}
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	podReport := func(namespace string) *v1alpha1.ConfigAuditReport {
+		return &v1alpha1.ConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod-nginx",
+				Namespace: namespace,
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "Pod",
+					starboard.LabelResourceName:      "nginx",
+					starboard.LabelResourceNamespace: namespace,
+				},
+			},
+			Report: v1alpha1.ConfigAuditReportData{Checks: []v1alpha1.Check{{ID: "KSV012", Messages: []string{"Container 'nginx' should set 'securityContext.runAsNonRoot' to true"}}}},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.ClusterComplianceReport{ObjectMeta: metav1.ObjectMeta{Name: "prod"}, Spec: spec},
+		namespace("prod-a", map[string]string{"env": "prod"}),
+		namespace("dev-a", map[string]string{"env": "dev"}),
+		namespace("payments", nil),
+		podReport("prod-a"),
+		podReport("dev-a"),
+		podReport("payments"),
+		&v1alpha1.ClusterConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "clusterrole-admin",
+				Labels: map[string]string{
+					starboard.LabelResourceKind: "ClusterRole",
+					starboard.LabelResourceName: "admin",
+				},
+			},
+			Report: v1alpha1.ConfigAuditReportData{Checks: []v1alpha1.Check{
+				{ID: "KSV041", Messages: []string{"ClusterRole 'admin' shouldn't have access to manage secrets"}},
+			}},
+		},
+	).Build()
+	mgr := NewMgr(c, logr.Discard(), starboard.ConfigData{}, nil, nil)
+
+	// namespacedResults returns namespaces of details and whether details of
+	// the given check are flagged as cluster-scoped
+	namespacedResults := func(t *testing.T, checkId string) ([]string, []bool) {
+		var details v1alpha1.ClusterComplianceDetailReport
+		require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "prod-details"}, &details))
+		var namespaces []string
+		var clusterScoped []bool
+		for _, control := range details.Report.ControlChecks {
+			for _, result := range control.ScannerCheckResult {
+				if result.ID != checkId {
+					continue
+				}
+				for _, detail := range result.Details {
+					namespaces = append(namespaces, detail.Namespace)
+					clusterScoped = append(clusterScoped, detail.ClusterScoped)
+				}
+			}
+		}
+		sort.Strings(namespaces)
+		return namespaces, clusterScoped
+	}
+
+	require.NoError(t, mgr.GenerateComplianceReport(context.TODO(), spec))
+	namespaces, clusterScoped := namespacedResults(t, "KSV012")
+	assert.Equal(t, []string{"payments", "prod-a"}, namespaces)
+	assert.Equal(t, []bool{false, false}, clusterScoped)
+	namespaces, clusterScoped = namespacedResults(t, "KSV041")
+	assert.Equal(t, []string{""}, namespaces, "results of cluster-scoped resources are always included")
+	assert.Equal(t, []bool{true}, clusterScoped)
+
+	t.Run("Should include namespaces created since previous generation", func(t *testing.T) {
+		require.NoError(t, c.Create(context.TODO(), namespace("prod-b", map[string]string{"env": "prod"})))
+		require.NoError(t, c.Create(context.TODO(), podReport("prod-b")))
+
+		require.NoError(t, mgr.GenerateComplianceReport(context.TODO(), spec))
+		namespaces, _ := namespacedResults(t, "KSV012")
+		assert.Equal(t, []string{"payments", "prod-a", "prod-b"}, namespaces)
+	})
+
+	t.Run("Should exclude namespaces which no longer match selector", func(t *testing.T) {
+		var prod corev1.Namespace
+		require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "prod-a"}, &prod))
+		prod.Labels["env"] = "dev"
+		require.NoError(t, c.Update(context.TODO(), &prod))
+
+		require.NoError(t, mgr.GenerateComplianceReport(context.TODO(), spec))
+		namespaces, _ := namespacedResults(t, "KSV012")
+		assert.Equal(t, []string{"payments", "prod-b"}, namespaces)
+	})
+}
+
+func TestNamespaceScope(t *testing.T) {
+	mgr := &cm{client: fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod-a", Labels: map[string]string{"env": "prod"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev-a", Labels: map[string]string{"env": "dev"}}},
+	).Build()}
+
+	t.Run("Should include all namespaces of spec without scope", func(t *testing.T) {
+		scope, err := mgr.namespaceScope(context.TODO(), v1alpha1.ReportSpec{})
+		require.NoError(t, err)
+		assert.Nil(t, scope)
+		assert.True(t, scope.includes("dev-a"))
+	})
+
+	t.Run("Should include listed and selected namespaces", func(t *testing.T) {
+		scope, err := mgr.namespaceScope(context.TODO(), v1alpha1.ReportSpec{
+			Namespaces:        []string{"payments"},
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, namespaceScope{"payments": true, "prod-a": true}, scope)
+		assert.True(t, scope.includes(""), "cluster-scoped resources")
+		assert.False(t, scope.includes("dev-a"))
+	})
+
+	t.Run("Should filter reports of namespaces out of scope", func(t *testing.T) {
+		scope := namespaceScope{"prod-a": true}
+		reports := &v1alpha1.ConfigAuditReportList{Items: []v1alpha1.ConfigAuditReport{
+			{ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: "prod-a"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "pod-b", Namespace: "dev-a"}},
+		}}
+		clusterReports := &v1alpha1.ClusterConfigAuditReportList{Items: []v1alpha1.ClusterConfigAuditReport{
+			{ObjectMeta: metav1.ObjectMeta{Name: "clusterrole-admin"}},
+		}}
+		require.NoError(t, scope.filterReports(map[string]map[string]client.ObjectList{
+			ConfigAudit: {"Pod": reports, "ClusterRole": clusterReports},
+		}))
+		require.Len(t, reports.Items, 1)
+		assert.Equal(t, "pod-a", reports.Items[0].Name)
+		assert.Len(t, clusterReports.Items, 1)
+	})
+}
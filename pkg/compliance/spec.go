@@ -0,0 +1,135 @@
+package compliance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// SpecConfigMapKey is the key of the compliance spec in ConfigMaps labeled
+// with starboard.LabelComplianceSpec.
+const SpecConfigMapKey = "spec.yaml"
+
+// ParseSpec parses the compliance spec in YAML format and validates it.
+// Unknown fields are rejected, so that misspelled fields are not silently
+// ignored.
+func ParseSpec(data []byte) (v1alpha1.ReportSpec, error) {
+	var spec v1alpha1.ReportSpec
+	err := yaml.UnmarshalStrict(data, &spec)
+	if err != nil {
+		return spec, fmt.Errorf("parsing compliance spec: %w", err)
+	}
+	return spec, ValidateSpec(spec)
+}
+
+// ValidateSpec returns an error if the compliance spec does not have the
+// fields required by the ClusterComplianceReport CRD, or has invalid values.
+func ValidateSpec(spec v1alpha1.ReportSpec) error {
+	if spec.Name == "" {
+		return fmt.Errorf("compliance spec name is required")
+	}
+	if spec.Version == "" {
+		return fmt.Errorf("compliance spec version is required")
+	}
+	if _, err := utils.NextCronTime(spec.Cron, time.Time{}); err != nil {
+		return fmt.Errorf("invalid compliance spec cron %q: %w", spec.Cron, err)
+	}
+	if err := validateSeverityThreshold(spec.SeverityThreshold); err != nil {
+		return fmt.Errorf("compliance spec %w", err)
+	}
+	if spec.CronJitterPercent < 0 || spec.CronJitterPercent > 100 {
+		return fmt.Errorf("compliance spec cronJitterPercent must be between 0 and 100, got %d", spec.CronJitterPercent)
+	}
+	for _, namespace := range spec.Namespaces {
+		if namespace == "" {
+			return fmt.Errorf("compliance spec namespaces must not be empty")
+		}
+	}
+	if _, err := metav1.LabelSelectorAsSelector(spec.NamespaceSelector); err != nil {
+		return fmt.Errorf("invalid compliance spec namespaceSelector: %w", err)
+	}
+	if len(spec.Controls) == 0 && len(spec.Includes) == 0 {
+		return fmt.Errorf("compliance spec has neither controls nor includes")
+	}
+	ids := make(map[string]bool)
+	for i, control := range spec.Controls {
+		if control.ID == "" {
+			return fmt.Errorf("control #%d: id is required", i+1)
+		}
+		if ids[control.ID] {
+			return fmt.Errorf("control %s: duplicate id", control.ID)
+		}
+		ids[control.ID] = true
+		if err := validateControl(control); err != nil {
+			return fmt.Errorf("control %s: %w", control.ID, err)
+		}
+	}
+	return nil
+}
+
+func validateControl(control v1alpha1.Control) error {
+	if control.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(control.Kinds) == 0 {
+		return fmt.Errorf("kinds are required")
+	}
+	if _, err := mapKinds(control); err != nil {
+		return err
+	}
+	if len(control.Mapping.Scanners) > 0 && (control.Mapping.Scanner != "" || len(control.Mapping.Checks) > 0) {
+		return fmt.Errorf("scanner and checks cannot be mapped along with scanners")
+	}
+	if control.Manual && len(scannerMappings(control.Mapping)) > 0 {
+		return fmt.Errorf("checks cannot be mapped to manual control")
+	}
+	if !control.Manual && len(scannerMappings(control.Mapping)) == 0 {
+		return fmt.Errorf("mapping is required unless control is manual")
+	}
+	checkIds := make(map[string]bool)
+	for _, mapping := range scannerMappings(control.Mapping) {
+		if _, err := byScanner(mapping.Scanner); err != nil {
+			return fmt.Errorf("unsupported scanner %q", mapping.Scanner)
+		}
+		if len(mapping.Checks) == 0 {
+			return fmt.Errorf("checks of scanner %s are required", mapping.Scanner)
+		}
+		for _, check := range mapping.Checks {
+			if check.ID == "" {
+				return fmt.Errorf("check id is required")
+			}
+			if checkIds[check.ID] {
+				return fmt.Errorf("check %s is mapped more than once", check.ID)
+			}
+			checkIds[check.ID] = true
+		}
+	}
+	switch control.Mapping.Aggregation {
+	case "", v1alpha1.CountAggregation, v1alpha1.AllOfAggregation, v1alpha1.AnyOfAggregation:
+	default:
+		return fmt.Errorf("unsupported aggregation %q", control.Mapping.Aggregation)
+	}
+	switch control.Severity {
+	case v1alpha1.SeverityCritical, v1alpha1.SeverityHigh, v1alpha1.SeverityMedium, v1alpha1.SeverityLow, v1alpha1.SeverityUnknown:
+	default:
+		return fmt.Errorf("unsupported severity %q", control.Severity)
+	}
+	switch control.DefaultStatus {
+	case "", v1alpha1.PassStatus, v1alpha1.WarnStatus, v1alpha1.FailStatus, v1alpha1.ManualStatus:
+	default:
+		return fmt.Errorf("unsupported default status %q", control.DefaultStatus)
+	}
+	return validateSeverityThreshold(control.SeverityThreshold)
+}
+
+func validateSeverityThreshold(threshold v1alpha1.Severity) error {
+	switch threshold {
+	case "", v1alpha1.SeverityCritical, v1alpha1.SeverityHigh, v1alpha1.SeverityMedium, v1alpha1.SeverityLow, v1alpha1.SeverityUnknown:
+		return nil
+	}
+	return fmt.Errorf("unsupported severity threshold %q", threshold)
+}
@@ -0,0 +1,149 @@
+package compliance
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpec(t *testing.T) {
+	data, err := ioutil.ReadFile("./testdata/fixture/nsa-1.0.yaml")
+	require.NoError(t, err)
+	spec, err := ParseSpec(data)
+	require.NoError(t, err)
+	assert.Equal(t, "nsa", spec.Name)
+	assert.Equal(t, "* * * * *", spec.Cron)
+	require.Len(t, spec.Controls, 2)
+	assert.Equal(t, v1alpha1.Mapping{Scanner: KubeBench, Checks: []v1alpha1.SpecCheck{{ID: "1.2.22"}}}, spec.Controls[1].Mapping)
+
+	spec, err = ParseSpec([]byte(`name: acme
+version: "1.0"
+cron: "0 */6 * * *"
+controls:
+  - name: Review network policies
+    id: '1.0'
+    kinds: [NetworkPolicy]
+    severity: MEDIUM
+    manual: true`))
+	require.NoError(t, err)
+	assert.True(t, spec.Controls[0].Manual)
+
+	_, err = ParseSpec([]byte(`name: acme
+version: "1.0"
+cron: "0 */6 * * *"
+controls:
+  - name: Audit logging
+    id: '1.0'
+    kinds: [Pod, Node]
+    severity: MEDIUM
+    defaultStatus: MANUAL
+    mapping:
+      scanners:
+        - scanner: config-audit
+          checks: [{id: KSV012}]
+        - scanner: kube-bench
+          checks: [{id: 1.2.22}]`))
+	assert.NoError(t, err)
+
+	spec, err = ParseSpec([]byte(`name: acme
+version: "1.0"
+cron: "0 */6 * * *"
+controls:
+  - name: Deployments run as non-root
+    id: '1.0'
+    kinds: [apps/v1/Deployment, networking.k8s.io/v1/Ingress]
+    severity: MEDIUM
+    mapping:
+      scanner: config-audit
+      checks: [{id: KSV012}]`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apps/v1/Deployment", "networking.k8s.io/v1/Ingress"}, spec.Controls[0].Kinds)
+
+	const header = `name: acme
+description: ACME hardening standard
+version: "1.0"
+cron: "0 */6 * * *"
+`
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{name: "unknown field", spec: header + `controls:
+  - name: Non-root containers
+    id: '1.0'
+    kinds: [Workload]
+    severity: MEDIUM
+    maping:
+      scanner: config-audit`,
+			wantErr: `parsing compliance spec: error unmarshaling JSON: while decoding JSON: json: unknown field "maping"`},
+		{name: "missing name", spec: `version: "1.0"
+cron: "* * * * *"`,
+			wantErr: "compliance spec name is required"},
+		{name: "invalid cron", spec: `name: acme
+version: "1.0"
+cron: "every day"
+includes: [nsa]`,
+			wantErr: `invalid compliance spec cron "every day": missing field(s)`},
+		{name: "unsupported severity threshold", spec: `name: acme
+version: "1.0"
+cron: "0 * * * *"
+severityThreshold: SEVERE
+includes: [nsa]`,
+			wantErr: `compliance spec unsupported severity threshold "SEVERE"`},
+		{name: "invalid cron jitter", spec: `name: acme
+version: "1.0"
+cron: "0 * * * *"
+cronJitterPercent: 150
+includes: [nsa]`,
+			wantErr: "compliance spec cronJitterPercent must be between 0 and 100, got 150"},
+		{name: "invalid namespace selector", spec: `name: acme
+version: "1.0"
+cron: "0 * * * *"
+namespaceSelector: {matchExpressions: [{key: env, operator: Matches, values: [prod]}]}
+includes: [nsa]`,
+			wantErr: `invalid compliance spec namespaceSelector: "Matches" is not a valid pod selector operator`},
+		{name: "no controls", spec: header,
+			wantErr: "compliance spec has neither controls nor includes"},
+		{name: "duplicate control id", spec: header + `controls:
+  - {name: Non-root containers, id: '1.0', kinds: [Workload], severity: MEDIUM, mapping: {scanner: config-audit, checks: [{id: KSV012}]}}
+  - {name: Privileged containers, id: '1.0', kinds: [Workload], severity: HIGH, mapping: {scanner: config-audit, checks: [{id: KSV017}]}}`,
+			wantErr: "control 1.0: duplicate id"},
+		{name: "unsupported scanner", spec: header + `controls:
+  - {name: Non-root containers, id: '1.0', kinds: [Workload], severity: MEDIUM, mapping: {scanner: trivy, checks: [{id: KSV012}]}}`,
+			wantErr: `control 1.0: unsupported scanner "trivy"`},
+		{name: "unsupported severity", spec: header + `controls:
+  - {name: Non-root containers, id: '1.0', kinds: [Workload], severity: medium, mapping: {scanner: config-audit, checks: [{id: KSV012}]}}`,
+			wantErr: `control 1.0: unsupported severity "medium"`},
+		{name: "unsupported control severity threshold", spec: header + `controls:
+  - {name: Non-root containers, id: '1.0', kinds: [Workload], severity: MEDIUM, severityThreshold: high, mapping: {scanner: config-audit, checks: [{id: KSV012}]}}`,
+			wantErr: `control 1.0: unsupported severity threshold "high"`},
+		{name: "missing mapping", spec: header + `controls:
+  - {name: Non-root containers, id: '1.0', kinds: [Workload], severity: MEDIUM}`,
+			wantErr: "control 1.0: mapping is required unless control is manual"},
+		{name: "manual control with mapping", spec: header + `controls:
+  - {name: Review network policies, id: '1.0', kinds: [NetworkPolicy], severity: MEDIUM, manual: true, mapping: {scanner: config-audit, checks: [{id: KSV038}]}}`,
+			wantErr: "control 1.0: checks cannot be mapped to manual control"},
+		{name: "scanners along with scanner", spec: header + `controls:
+  - {name: Non-root containers, id: '1.0', kinds: [Workload], severity: MEDIUM, mapping: {scanner: config-audit, scanners: [{scanner: kube-bench, checks: [{id: 1.2.22}]}]}}`,
+			wantErr: "control 1.0: scanner and checks cannot be mapped along with scanners"},
+		{name: "check mapped by multiple scanners", spec: header + `controls:
+  - {name: Non-root containers, id: '1.0', kinds: [Workload], severity: MEDIUM, mapping: {scanners: [{scanner: config-audit, checks: [{id: KSV012}]}, {scanner: kube-bench, checks: [{id: KSV012}]}]}}`,
+			wantErr: "control 1.0: check KSV012 is mapped more than once"},
+		{name: "unknown kind", spec: header + `controls:
+  - {name: Non-root containers, id: '1.0', kinds: [Worklaod], severity: MEDIUM, mapping: {scanner: config-audit, checks: [{id: KSV012}]}}`,
+			wantErr: `control 1.0: unknown kind "Worklaod": use one of the kind keywords or a fully qualified kind such as apps/v1/Deployment`},
+		{name: "unsupported default status", spec: header + `controls:
+  - {name: Non-root containers, id: '1.0', kinds: [Workload], severity: MEDIUM, defaultStatus: SKIP, mapping: {scanner: config-audit, checks: [{id: KSV012}]}}`,
+			wantErr: `control 1.0: unsupported default status "SKIP"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSpec([]byte(tt.spec))
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
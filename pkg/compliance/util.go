@@ -1,23 +1,158 @@
 package compliance
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/emirpasic/gods/sets/hashset"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-//mapKinds map resource data
-func mapKinds(control v1alpha1.Control) []string {
+// WorkloadKind is the keyword of controls which apply to all kinds of workloads.
+const WorkloadKind = "Workload"
+
+// workloadKinds are kinds which the Workload keyword expands to, i.e. all
+// kinds of workloads recognized by kube.IsWorkload.
+var workloadKinds = []interface{}{
+	string(kube.KindPod),
+	string(kube.KindReplicationController),
+	string(kube.KindReplicaSet),
+	string(kube.KindDeployment),
+	string(kube.KindStatefulSet),
+	string(kube.KindDaemonSet),
+	string(kube.KindCronJob),
+	string(kube.KindJob),
+	string(kube.KindRollout),
+}
+
+// kindKeywords are kinds which controls may refer to by name. Other kinds must
+// be fully qualified with group and version, e.g. apps/v1/Deployment.
+var kindKeywords = hashset.New(
+	WorkloadKind,
+	"Cluster",
+	string(kube.KindNode),
+	string(kube.KindNamespace),
+	string(kube.KindPod),
+	string(kube.KindReplicaSet),
+	string(kube.KindReplicationController),
+	string(kube.KindDeployment),
+	string(kube.KindStatefulSet),
+	string(kube.KindDaemonSet),
+	string(kube.KindCronJob),
+	string(kube.KindJob),
+	string(kube.KindService),
+	string(kube.KindConfigMap),
+	string(kube.KindRole),
+	string(kube.KindRoleBinding),
+	string(kube.KindNetworkPolicy),
+	string(kube.KindIngress),
+	string(kube.KindResourceQuota),
+	string(kube.KindLimitRange),
+	string(kube.KindClusterRole),
+	string(kube.KindClusterRoleBindings),
+	string(kube.KindCustomResourceDefinition),
+	string(kube.KindPodSecurityPolicy),
+	string(kube.KindRollout),
+)
+
+// kindKeywordSpellings maps lower case singular and plural spellings of kind
+// keywords to the keywords, e.g. pods and pod to Pod.
+var kindKeywordSpellings = func() map[string]string {
+	spellings := make(map[string]string)
+	for _, value := range kindKeywords.Values() {
+		keyword := strings.ToLower(value.(string))
+		spellings[keyword] = value.(string)
+		spellings[plural(keyword)] = value.(string)
+	}
+	return spellings
+}()
+
+// plural returns the plural of the given lower case kind, e.g. networkpolicies
+// for networkpolicy.
+func plural(kind string) string {
+	switch {
+	case strings.HasSuffix(kind, "y"):
+		return strings.TrimSuffix(kind, "y") + "ies"
+	case strings.HasSuffix(kind, "s"):
+		return kind + "es"
+	}
+	return kind + "s"
+}
+
+// kindKeyword returns the kind keyword of the given kind, which may be spelled
+// in lower case or in plural, e.g. Deployment for deployments. It returns false
+// if the kind is not a keyword.
+func kindKeyword(kind string) (string, bool) {
+	if kindKeywords.Contains(kind) {
+		return kind, true
+	}
+	keyword, ok := kindKeywordSpellings[strings.ToLower(kind)]
+	return keyword, ok
+}
+
+// mapKinds returns kinds of resources of the given control, where the Workload
+// keyword is replaced with kinds of workloads. Keywords may be spelled in lower
+// case or in plural, e.g. workloads or deployments, and are returned as they're
+// spelled in kindKeywords. Besides keywords, kinds may be fully qualified as
+// group/version/Kind, e.g. networking.k8s.io/v1/NetworkPolicy, or as
+// version/Kind for the core group, e.g. v1/Secret. Qualified kinds are returned
+// as they are. It returns an error for an unknown keyword, so that a misspelled
+// kind does not silently drop results of the control.
+func mapKinds(control v1alpha1.Control) ([]string, error) {
 	set := hashset.New()
-	updatedKinds := make([]string, 0)
-	for _, resource := range control.Kinds {
-		if resource == "Workload" {
-			set.Add("Pod", "ReplicationController", "ReplicaSet", "StatefulSet", "DaemonSet", "CronJob", "Job")
+	for _, kind := range control.Kinds {
+		if strings.Contains(kind, "/") {
+			if _, err := parseQualifiedKind(kind); err != nil {
+				return nil, err
+			}
+			set.Add(kind)
+			continue
+		}
+		keyword, ok := kindKeyword(kind)
+		if !ok {
+			return nil, fmt.Errorf("unknown kind %q: use one of the kind keywords or a fully qualified kind such as apps/v1/Deployment", kind)
+		}
+		if keyword == WorkloadKind {
+			set.Add(workloadKinds...)
 		} else {
-			set.Add(resource)
+			set.Add(keyword)
 		}
 	}
+	updatedKinds := make([]string, 0)
 	for _, setResource := range set.Values() {
 		updatedKinds = append(updatedKinds, setResource.(string))
 	}
-	return updatedKinds
+	return updatedKinds, nil
+}
+
+var versionPattern = regexp.MustCompile(`^v[0-9]+((alpha|beta)[0-9]+)?$`)
+
+// parseQualifiedKind parses a kind qualified as group/version/Kind or as
+// version/Kind.
+func parseQualifiedKind(kind string) (schema.GroupVersionKind, error) {
+	i := strings.LastIndex(kind, "/")
+	if i < 0 {
+		return schema.GroupVersionKind{}, fmt.Errorf("kind %q is not qualified", kind)
+	}
+	gv, err := schema.ParseGroupVersion(kind[:i])
+	if err != nil || !versionPattern.MatchString(gv.Version) || kind[i+1:] == "" {
+		return schema.GroupVersionKind{}, fmt.Errorf("invalid kind %q: expected group/version/Kind or version/Kind", kind)
+	}
+	return gv.WithKind(kind[i+1:]), nil
+}
+
+// scannerMappings returns checks mapped to a control by scanner, for mappings
+// of a single scanner as well as mappings of multiple scanners. It returns nil
+// for an empty mapping, e.g. of a manual control.
+func scannerMappings(mapping v1alpha1.Mapping) []v1alpha1.ScannerMapping {
+	if len(mapping.Scanners) > 0 {
+		return mapping.Scanners
+	}
+	if mapping.Scanner == "" && len(mapping.Checks) == 0 {
+		return nil
+	}
+	return []v1alpha1.ScannerMapping{{Scanner: mapping.Scanner, Checks: mapping.Checks}}
 }
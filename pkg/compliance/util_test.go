@@ -1,9 +1,11 @@
 package compliance
 
 import (
-	"github.com/stretchr/testify/assert"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 )
 
@@ -13,14 +15,55 @@ func TestMapResources(t *testing.T) {
 		kinds []string
 		want  int
 	}{
-		{name: "with workload", kinds: []string{"Workload"}, want: 7},
-		{name: "dup kinds", kinds: []string{"Workload", "Pod", "Job"}, want: 7},
+		{name: "with workload", kinds: []string{"Workload"}, want: 9},
+		{name: "dup kinds", kinds: []string{"Workload", "Pod", "Job"}, want: 9},
+		{name: "with service and ingress", kinds: []string{"Workload", "Service", "Ingress"}, want: 11},
+		{name: "lower case and plural spellings", kinds: []string{"workloads", "pod", "Deployments", "ingresses", "networkpolicies"}, want: 11},
 		{name: "empty kinds", kinds: []string{}, want: 0},
+		{name: "with qualified kinds", kinds: []string{"apps/v1/Deployment", "networking.k8s.io/v1/NetworkPolicy", "v1/Secret"}, want: 3},
+		{name: "with keyword and qualified kind", kinds: []string{"Deployment", "apps/v1/Deployment"}, want: 2},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := mapKinds(v1alpha1.Control{Kinds: tt.kinds})
+			got, err := mapKinds(v1alpha1.Control{Kinds: tt.kinds})
+			require.NoError(t, err)
 			assert.Equal(t, len(got), tt.want)
 		})
 	}
 }
+
+func TestMapKindsSpellings(t *testing.T) {
+	got, err := mapKinds(v1alpha1.Control{Kinds: []string{"workloads"}})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Pod", "ReplicationController", "ReplicaSet", "Deployment", "StatefulSet", "DaemonSet",
+		"CronJob", "Job", "Rollout"}, got)
+
+	got, err = mapKinds(v1alpha1.Control{Kinds: []string{"nodes", "namespace", "networkpolicies", "Ingresses", "clusterrolebindings"}})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Node", "Namespace", "NetworkPolicy", "Ingress", "ClusterRoleBinding"}, got)
+}
+
+func TestMapKindsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		kinds   []string
+		wantErr string
+	}{
+		{name: "misspelled keyword", kinds: []string{"Worklaod"},
+			wantErr: `unknown kind "Worklaod": use one of the kind keywords or a fully qualified kind such as apps/v1/Deployment`},
+		{name: "misspelled plural", kinds: []string{"Node", "podss"},
+			wantErr: `unknown kind "podss": use one of the kind keywords or a fully qualified kind such as apps/v1/Deployment`},
+		{name: "missing version", kinds: []string{"apps/Deployment"},
+			wantErr: `invalid kind "apps/Deployment": expected group/version/Kind or version/Kind`},
+		{name: "missing kind", kinds: []string{"apps/v1/"},
+			wantErr: `invalid kind "apps/v1/": expected group/version/Kind or version/Kind`},
+		{name: "too many segments", kinds: []string{"a/b/v1/Deployment"},
+			wantErr: `invalid kind "a/b/v1/Deployment": expected group/version/Kind or version/Kind`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mapKinds(v1alpha1.Control{Kinds: tt.kinds})
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
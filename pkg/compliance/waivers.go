@@ -0,0 +1,74 @@
+package compliance
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// waiverDateLayout is the layout of the date in waiver annotations.
+const waiverDateLayout = "2006-01-02"
+
+// controlWaivers returns waivers granted with annotations of a compliance report
+// keyed by control id, including expired ones. A control is waived until the end
+// of the annotated date in UTC. Annotations with invalid dates are skipped and
+// reported in the returned error.
+func controlWaivers(annotations map[string]string) (map[string]v1alpha1.ControlWaiver, error) {
+	waivers := make(map[string]v1alpha1.ControlWaiver)
+	var errs []error
+	for key, value := range annotations {
+		if !strings.HasPrefix(key, v1alpha1.ComplianceExcludeControlAnnotationPrefix) {
+			continue
+		}
+		controlID := strings.TrimPrefix(key, v1alpha1.ComplianceExcludeControlAnnotationPrefix)
+		if controlID == "" {
+			continue
+		}
+		date, err := time.Parse(waiverDateLayout, strings.TrimSpace(value))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("parsing expiry date of annotation %s: %w", key, err))
+			continue
+		}
+		waivers[controlID] = v1alpha1.ControlWaiver{
+			Annotation: key,
+			Expires:    metav1.NewTime(date.AddDate(0, 0, 1)),
+		}
+	}
+	return waivers, utilerrors.NewAggregate(errs)
+}
+
+// activeWaivers returns waivers which have not expired at the specified time.
+func activeWaivers(waivers map[string]v1alpha1.ControlWaiver, now time.Time) map[string]v1alpha1.ControlWaiver {
+	active := make(map[string]v1alpha1.ControlWaiver)
+	for controlID, waiver := range waivers {
+		if now.Before(waiver.Expires.Time) {
+			active[controlID] = waiver
+		}
+	}
+	return active
+}
+
+// NextWaiverExpiry returns the earliest expiry of a waiver granted with the
+// given annotations after the specified time, i.e. when a waived control must
+// be reinstated by generating the report again.
+func NextWaiverExpiry(annotations map[string]string, since time.Time) (time.Time, bool) {
+	waivers, _ := controlWaivers(annotations)
+	var expiries []time.Time
+	for _, waiver := range waivers {
+		if waiver.Expires.Time.After(since) {
+			expiries = append(expiries, waiver.Expires.Time)
+		}
+	}
+	if len(expiries) == 0 {
+		return time.Time{}, false
+	}
+	sort.Slice(expiries, func(i, j int) bool {
+		return expiries[i].Before(expiries[j])
+	})
+	return expiries[0], true
+}
@@ -0,0 +1,54 @@
+package compliance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestControlWaivers(t *testing.T) {
+	annotations := map[string]string{
+		"compliance.aquasecurity.github.io/exclude-control.1.1": "2024-12-31",
+		"compliance.aquasecurity.github.io/exclude-control.5.2": "2024-06-30",
+		"compliance.aquasecurity.github.io/exclude-control.2.0": "next week",
+		"compliance.aquasecurity.github.io/exclude-control.":    "2024-12-31",
+		v1alpha1.ComplianceReportGenerateAnnotation:             "2024-06-01T10:00:00Z",
+	}
+
+	waivers, err := controlWaivers(annotations)
+	assert.EqualError(t, err, `parsing expiry date of annotation compliance.aquasecurity.github.io/exclude-control.2.0: parsing time "next week" as "2006-01-02": cannot parse "next week" as "2006"`)
+	assert.Equal(t, map[string]v1alpha1.ControlWaiver{
+		"1.1": {
+			Annotation: "compliance.aquasecurity.github.io/exclude-control.1.1",
+			Expires:    metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		"5.2": {
+			Annotation: "compliance.aquasecurity.github.io/exclude-control.5.2",
+			Expires:    metav1.NewTime(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)),
+		},
+	}, waivers)
+
+	t.Run("Should reinstate controls after expiry", func(t *testing.T) {
+		active := activeWaivers(waivers, time.Date(2024, 6, 30, 23, 59, 59, 0, time.UTC))
+		assert.Len(t, active, 2)
+		active = activeWaivers(waivers, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC))
+		assert.Len(t, active, 1)
+		assert.Contains(t, active, "1.1")
+	})
+
+	t.Run("Should return next expiry after the last generation", func(t *testing.T) {
+		expiry, ok := NextWaiverExpiry(annotations, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+		assert.True(t, ok)
+		assert.Equal(t, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), expiry)
+
+		expiry, ok = NextWaiverExpiry(annotations, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC))
+		assert.True(t, ok)
+		assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), expiry)
+
+		_, ok = NextWaiverExpiry(annotations, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+		assert.False(t, ok)
+	})
+}
@@ -28,6 +28,7 @@ type ScanJobBuilder struct {
 	tolerations       []corev1.Toleration
 	annotations       map[string]string
 	podTemplateLabels labels.Set
+	ttl               *int32
 }
 
 func NewScanJobBuilder() *ScanJobBuilder {
@@ -69,20 +70,36 @@ func (s *ScanJobBuilder) WithPodTemplateLabels(podTemplateLabels labels.Set) *Sc
 	return s
 }
 
+// WithTTLSecondsAfterFinished sets the TTLSecondsAfterFinished of the scan
+// job, which is honored by clusters running the TTL controller.
+func (s *ScanJobBuilder) WithTTLSecondsAfterFinished(ttl *int32) *ScanJobBuilder {
+	s.ttl = ttl
+	return s
+}
+
 func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 	jobSpec, secrets, err := s.plugin.GetScanJobSpec(s.pluginContext, s.object)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	resourceSpecHash, err := kube.ComputeSpecHash(s.object)
+	resourceSpecHash, err := ResourceHash(s.pluginContext, s.object)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	jobSpec.Tolerations = append(jobSpec.Tolerations, s.tolerations...)
+	defaultResources, err := s.pluginContext.GetStarboardConfig().GetScanJobDefaultResources()
+	if err != nil {
+		return nil, nil, err
+	}
+	kube.SetDefaultResources(&jobSpec, defaultResources)
+	err = s.pluginContext.GetImagePolicy().VerifyPodSpec(jobSpec, nil)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	pluginConfigHash, err := s.plugin.ConfigHash(s.pluginContext, kube.Kind(s.object.GetObjectKind().GroupVersionKind().Kind))
+	pluginConfigHash, err := ConfigHash(s.plugin, s.pluginContext, kube.Kind(s.object.GetObjectKind().GroupVersionKind().Kind))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -102,17 +119,28 @@ func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 		podTemplateLabelsSet[index] = element
 	}
 
+	jobAnnotations := s.annotations
+	if len(jobSpec.Containers) > 0 {
+		jobAnnotations = map[string]string{
+			starboard.AnnotationScannerImage: jobSpec.Containers[0].Image,
+		}
+		for key, value := range s.annotations {
+			jobAnnotations[key] = value
+		}
+	}
+
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        GetScanJobName(s.object),
 			Namespace:   s.pluginContext.GetNamespace(),
 			Labels:      labelsSet,
-			Annotations: s.annotations,
+			Annotations: jobAnnotations,
 		},
 		Spec: batchv1.JobSpec{
-			BackoffLimit:          pointer.Int32Ptr(0),
-			Completions:           pointer.Int32Ptr(1),
-			ActiveDeadlineSeconds: kube.GetActiveDeadlineSeconds(s.timeout),
+			BackoffLimit:            pointer.Int32Ptr(0),
+			Completions:             pointer.Int32Ptr(1),
+			ActiveDeadlineSeconds:   kube.GetActiveDeadlineSeconds(s.timeout),
+			TTLSecondsAfterFinished: s.ttl,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      podTemplateLabelsSet,
@@ -140,6 +168,7 @@ func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 		for k, v := range labelsSet {
 			secret.Labels[k] = v
 		}
+		secret.Labels[starboard.LabelScanJobName] = job.Name
 		err = kube.ObjectToObjectMeta(s.object, &secret.ObjectMeta)
 	}
 
@@ -155,11 +184,15 @@ func GetScanJobName(obj client.Object) string {
 }
 
 type ReportBuilder struct {
-	scheme           *runtime.Scheme
-	controller       client.Object
-	resourceSpecHash string
-	pluginConfigHash string
-	data             v1alpha1.ConfigAuditReportData
+	scheme             *runtime.Scheme
+	controller         client.Object
+	resourceSpecHash   string
+	pluginConfigHash   string
+	data               v1alpha1.ConfigAuditReportData
+	skipOwnerReference bool
+	maxMessageLength   int
+	storeFullMessages  bool
+	secretNamespace    string
 }
 
 func NewReportBuilder(scheme *runtime.Scheme) *ReportBuilder {
@@ -188,6 +221,31 @@ func (b *ReportBuilder) Data(data v1alpha1.ConfigAuditReportData) *ReportBuilder
 	return b
 }
 
+// SkipOwnerReference tells the builder not to set the controller reference
+// to the owner of a report. Such reports are cleaned up based on labels.
+func (b *ReportBuilder) SkipOwnerReference(skip bool) *ReportBuilder {
+	b.skipOwnerReference = skip
+	return b
+}
+
+// MaxMessageLength sets the maximum number of characters of check messages.
+// Longer messages are truncated. Zero means that messages are not truncated.
+func (b *ReportBuilder) MaxMessageLength(maxLength int) *ReportBuilder {
+	b.maxMessageLength = maxLength
+	return b
+}
+
+// StoreFullMessages tells the builder to preserve full messages of truncated
+// checks in a Secret referenced from the report with the
+// starboard.AnnotationFullMessagesSecret annotation. Secrets of namespaced
+// reports are created in the namespace of the report, whereas Secrets of
+// cluster reports are created in the given namespace.
+func (b *ReportBuilder) StoreFullMessages(store bool, namespace string) *ReportBuilder {
+	b.storeFullMessages = store
+	b.secretNamespace = namespace
+	return b
+}
+
 func (b *ReportBuilder) reportName() string {
 	kind := b.controller.GetObjectKind().GroupVersionKind().Kind
 	name := b.controller.GetName()
@@ -207,17 +265,22 @@ func (b *ReportBuilder) GetClusterReport() (v1alpha1.ClusterConfigAuditReport, e
 		labelsSet[starboard.LabelPluginConfigHash] = b.pluginConfigHash
 	}
 
+	data, fullMessages := TruncateMessages(b.data, b.maxMessageLength)
 	report := v1alpha1.ClusterConfigAuditReport{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   b.reportName(),
 			Labels: labelsSet,
 		},
-		Report: b.data,
+		Report: data,
 	}
+	b.annotateFullMessages(&report.ObjectMeta, b.secretNamespace, fullMessages)
 	err := kube.ObjectToObjectMeta(b.controller, &report.ObjectMeta)
 	if err != nil {
 		return v1alpha1.ClusterConfigAuditReport{}, err
 	}
+	if b.skipOwnerReference {
+		return report, nil
+	}
 	err = controllerutil.SetControllerReference(b.controller, &report, b.scheme)
 	if err != nil {
 		return v1alpha1.ClusterConfigAuditReport{}, fmt.Errorf("setting controller reference: %w", err)
@@ -243,18 +306,23 @@ func (b *ReportBuilder) GetReport() (v1alpha1.ConfigAuditReport, error) {
 		labelsSet[starboard.LabelPluginConfigHash] = b.pluginConfigHash
 	}
 
+	data, fullMessages := TruncateMessages(b.data, b.maxMessageLength)
 	report := v1alpha1.ConfigAuditReport{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      b.reportName(),
 			Namespace: b.controller.GetNamespace(),
 			Labels:    labelsSet,
 		},
-		Report: b.data,
+		Report: data,
 	}
+	b.annotateFullMessages(&report.ObjectMeta, report.Namespace, fullMessages)
 	err := kube.ObjectToObjectMeta(b.controller, &report.ObjectMeta)
 	if err != nil {
 		return v1alpha1.ConfigAuditReport{}, err
 	}
+	if b.skipOwnerReference {
+		return report, nil
+	}
 	err = controllerutil.SetControllerReference(b.controller, &report, b.scheme)
 	if err != nil {
 		return v1alpha1.ConfigAuditReport{}, fmt.Errorf("setting controller reference: %w", err)
@@ -271,18 +339,95 @@ func (b *ReportBuilder) GetReport() (v1alpha1.ConfigAuditReport, error) {
 	return report, nil
 }
 
-func (b *ReportBuilder) Write(ctx context.Context, writer Writer) error {
+// annotateFullMessages refers to the Secret with full messages of truncated
+// checks from the report with the given metadata.
+func (b *ReportBuilder) annotateFullMessages(meta *metav1.ObjectMeta, namespace string, fullMessages map[string][]string) {
+	if !b.storeFullMessages || namespace == "" || len(fullMessages) == 0 {
+		return
+	}
+	meta.Annotations = map[string]string{
+		starboard.AnnotationFullMessagesSecret: namespace + "/" + fullMessagesSecretName(meta.Name),
+	}
+}
+
+// GetFullMessagesSecret returns the Secret which holds full messages of
+// truncated checks of the report, or nil if no message is truncated or full
+// messages are not stored.
+func (b *ReportBuilder) GetFullMessagesSecret() (*corev1.Secret, error) {
+	if !b.storeFullMessages {
+		return nil, nil
+	}
+	namespace := b.secretNamespace
+	if !kube.IsClusterScopedKind(b.controller.GetObjectKind().GroupVersionKind().Kind) {
+		namespace = b.controller.GetNamespace()
+	}
+	_, fullMessages := TruncateMessages(b.data, b.maxMessageLength)
+	if namespace == "" || len(fullMessages) == 0 {
+		return nil, nil
+	}
+	encoded, err := EncodeFullMessages(fullMessages)
+	if err != nil {
+		return nil, fmt.Errorf("encoding full messages: %w", err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fullMessagesSecretName(b.reportName()),
+			Namespace: namespace,
+			Labels: labels.Set{
+				starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+			},
+		},
+		Data: map[string][]byte{
+			FullMessagesKey: encoded,
+		},
+	}
+	err = kube.ObjectToObjectMeta(b.controller, &secret.ObjectMeta)
+	if err != nil {
+		return nil, err
+	}
+	if b.skipOwnerReference {
+		return secret, nil
+	}
+	// The Secret is deleted along with the resource rather than the report, so
+	// that the report doesn't depend on the Secret and vice versa.
+	err = controllerutil.SetOwnerReference(b.controller, secret, b.scheme)
+	if err != nil {
+		return nil, fmt.Errorf("setting owner reference: %w", err)
+	}
+	return secret, nil
+}
+
+// fullMessagesSecretName returns the name of the Secret which holds full
+// messages of truncated checks of the report with the given name.
+func fullMessagesSecretName(reportName string) string {
+	return reportName + "-messages"
+}
+
+// Write writes the report, and the Secret which holds full messages of
+// truncated checks if any, with the given Writer and options.
+func (b *ReportBuilder) Write(ctx context.Context, writer Writer, opts ...kube.WriteOption) error {
 	if kube.IsClusterScopedKind(b.controller.GetObjectKind().GroupVersionKind().Kind) {
 		report, err := b.GetClusterReport()
 		if err != nil {
 			return err
 		}
-		return writer.WriteClusterReport(ctx, report)
+		err = writer.WriteClusterReport(ctx, report, opts...)
+		if err != nil {
+			return err
+		}
 	} else {
 		report, err := b.GetReport()
 		if err != nil {
 			return err
 		}
-		return writer.WriteReport(ctx, report)
+		err = writer.WriteReport(ctx, report, opts...)
+		if err != nil {
+			return err
+		}
+	}
+	secret, err := b.GetFullMessagesSecret()
+	if err != nil || secret == nil {
+		return err
 	}
+	return writer.WriteFullMessages(ctx, secret, opts...)
 }
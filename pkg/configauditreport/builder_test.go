@@ -113,6 +113,93 @@ func TestReportBuilder(t *testing.T) {
 			Report: v1alpha1.ConfigAuditReportData{},
 		}))
 	})
+
+	t.Run("Should build report without owner reference", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		report, err := configauditreport.NewReportBuilder(scheme.Scheme).
+			Controller(&appsv1.ReplicaSet{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "ReplicaSet",
+					APIVersion: "apps/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "some-owner",
+					Namespace: "qa",
+				},
+			}).
+			ResourceSpecHash("xyz").
+			PluginConfigHash("nop").
+			Data(v1alpha1.ConfigAuditReportData{}).
+			SkipOwnerReference(true).
+			GetReport()
+
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(report).To(Equal(v1alpha1.ConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-some-owner",
+				Namespace: "qa",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "ReplicaSet",
+					starboard.LabelResourceName:      "some-owner",
+					starboard.LabelResourceNamespace: "qa",
+					starboard.LabelResourceSpecHash:  "xyz",
+					starboard.LabelPluginConfigHash:  "nop",
+				},
+			},
+			Report: v1alpha1.ConfigAuditReportData{},
+		}))
+	})
+
+	t.Run("Should build report with truncated messages and Secret with full messages", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		checks := []v1alpha1.Check{
+			{ID: "deny-dump", Messages: []string{"object dump: {...}"}},
+			{ID: "deny-short", Messages: []string{"short"}},
+		}
+		builder := configauditreport.NewReportBuilder(scheme.Scheme).
+			Controller(&appsv1.ReplicaSet{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "ReplicaSet",
+					APIVersion: "apps/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "some-owner",
+					Namespace: "qa",
+				},
+			}).
+			Data(v1alpha1.ConfigAuditReportData{Checks: checks}).
+			MaxMessageLength(11).
+			StoreFullMessages(true, "starboard-system")
+
+		report, err := builder.GetReport()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(report.Annotations).To(Equal(map[string]string{
+			starboard.AnnotationFullMessagesSecret: "qa/replicaset-some-owner-messages",
+		}))
+		g.Expect(report.Report.Checks).To(Equal([]v1alpha1.Check{
+			{ID: "deny-dump", Messages: []string{"object dump... (7 characters truncated)"}},
+			{ID: "deny-short", Messages: []string{"short"}},
+		}))
+
+		secret, err := builder.GetFullMessagesSecret()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(secret.Namespace).To(Equal("qa"))
+		g.Expect(secret.Name).To(Equal("replicaset-some-owner-messages"))
+		g.Expect(secret.OwnerReferences).To(Equal([]metav1.OwnerReference{
+			{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Name:       "some-owner",
+			},
+		}))
+		fullMessages, err := configauditreport.DecodeFullMessages(secret)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(fullMessages).To(Equal(map[string][]string{
+			"deny-dump": {"object dump: {...}"},
+		}))
+	})
 }
 
 type testPlugin struct {
@@ -178,8 +265,8 @@ func TestScanJobBuilder(t *testing.T) {
 				Name:      "scan-configauditreport-64d65c457",
 				Namespace: "starboard-ns",
 				Labels: map[string]string{
-					starboard.LabelResourceSpecHash:         "755877d4bb",
-					starboard.LabelPluginConfigHash:         "hash-test",
+					starboard.LabelResourceSpecHash:         "8745df54f",
+					starboard.LabelPluginConfigHash:         "84558fdc76",
 					starboard.LabelConfigAuditReportScanner: "plugin-test",
 					starboard.LabelK8SAppManagedBy:          "starboard",
 					starboard.LabelResourceKind:             "ReplicaSet",
@@ -194,8 +281,8 @@ func TestScanJobBuilder(t *testing.T) {
 				Template: corev1.PodTemplateSpec{
 					ObjectMeta: metav1.ObjectMeta{
 						Labels: map[string]string{
-							starboard.LabelResourceSpecHash:         "755877d4bb",
-							starboard.LabelPluginConfigHash:         "hash-test",
+							starboard.LabelResourceSpecHash:         "8745df54f",
+							starboard.LabelPluginConfigHash:         "84558fdc76",
 							starboard.LabelConfigAuditReportScanner: "plugin-test",
 							starboard.LabelK8SAppManagedBy:          "starboard",
 							starboard.LabelResourceKind:             "ReplicaSet",
@@ -238,8 +325,8 @@ func TestScanJobBuilder(t *testing.T) {
 				Name:      "scan-configauditreport-5bfbdd65c9",
 				Namespace: "starboard-ns",
 				Labels: map[string]string{
-					starboard.LabelResourceSpecHash:         "7c48697ccf",
-					starboard.LabelPluginConfigHash:         "hash-test",
+					starboard.LabelResourceSpecHash:         "846f45ddf7",
+					starboard.LabelPluginConfigHash:         "84558fdc76",
 					starboard.LabelConfigAuditReportScanner: "plugin-test",
 					starboard.LabelK8SAppManagedBy:          "starboard",
 					starboard.LabelResourceKind:             "ClusterRole",
@@ -257,8 +344,8 @@ func TestScanJobBuilder(t *testing.T) {
 				Template: corev1.PodTemplateSpec{
 					ObjectMeta: metav1.ObjectMeta{
 						Labels: map[string]string{
-							starboard.LabelResourceSpecHash:         "7c48697ccf",
-							starboard.LabelPluginConfigHash:         "hash-test",
+							starboard.LabelResourceSpecHash:         "846f45ddf7",
+							starboard.LabelPluginConfigHash:         "84558fdc76",
 							starboard.LabelConfigAuditReportScanner: "plugin-test",
 							starboard.LabelK8SAppManagedBy:          "starboard",
 							starboard.LabelResourceKind:             "ClusterRole",
@@ -275,3 +362,29 @@ func TestScanJobBuilder(t *testing.T) {
 		}))
 	})
 }
+
+func TestConfigHash(t *testing.T) {
+	g := NewGomegaWithT(t)
+	plugin := &testPlugin{configHash: "hash-test"}
+	pluginContext := func(config starboard.ConfigData) starboard.PluginContext {
+		return starboard.NewPluginContext().
+			WithName("plugin-test").
+			WithStarboardConfig(config).
+			Get()
+	}
+
+	defaultHash, err := configauditreport.ConfigHash(plugin, pluginContext(starboard.ConfigData{}), kube.KindReplicaSet)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	sameHash, err := configauditreport.ConfigHash(plugin, pluginContext(starboard.ConfigData{
+		"configAudit.ignoredAnnotations": "kubectl.kubernetes.io/*,argocd.argoproj.io/*,deployment.kubernetes.io/*",
+	}), kube.KindReplicaSet)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sameHash).To(Equal(defaultHash))
+
+	changedHash, err := configauditreport.ConfigHash(plugin, pluginContext(starboard.ConfigData{
+		"configAudit.ignoredAnnotations": "kubectl.kubernetes.io/*",
+	}), kube.KindReplicaSet)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(changedHash).ToNot(Equal(defaultHash))
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
 	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/operator/throttle"
 	"github.com/aquasecurity/starboard/pkg/policy"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/go-logr/logr"
@@ -40,6 +41,9 @@ type ResourceController struct {
 	kube.ObjectResolver
 	ReadWriter
 	starboard.BuildInfo
+	// Throttle requeues reconciliations throttled by the API server after
+	// backoff. It is optional.
+	Throttle *throttle.Throttle
 }
 
 func (r *ResourceController) SetupWithManager(mgr ctrl.Manager) error {
@@ -90,7 +94,7 @@ func (r *ResourceController) SetupWithManager(mgr ctrl.Manager) error {
 				installModePredicate,
 			)).
 			Owns(resource.ownsObject).
-			Complete(r.reconcileResource(resource.kind))
+			Complete(r.Throttle.Reconciler(r.reconcileResource(resource.kind)))
 		if err != nil {
 			return fmt.Errorf("constructing controller for %s: %w", resource.kind, err)
 		}
@@ -101,7 +105,7 @@ func (r *ResourceController) SetupWithManager(mgr ctrl.Manager) error {
 				predicate.HasName(starboard.PoliciesConfigMapName),
 				predicate.InNamespace(r.Config.Namespace),
 			)).
-			Complete(r.reconcileConfig(resource.kind))
+			Complete(r.Throttle.Reconciler(r.reconcileConfig(resource.kind)))
 		if err != nil {
 			return err
 		}
@@ -116,7 +120,7 @@ func (r *ResourceController) SetupWithManager(mgr ctrl.Manager) error {
 				predicate.Not(predicate.IsBeingTerminated),
 			)).
 			Owns(resource.ownsObject).
-			Complete(r.reconcileResource(resource.kind))
+			Complete(r.Throttle.Reconciler(r.reconcileResource(resource.kind)))
 		if err != nil {
 			return fmt.Errorf("constructing controller for %s: %w", resource.kind, err)
 		}
@@ -126,7 +130,7 @@ func (r *ResourceController) SetupWithManager(mgr ctrl.Manager) error {
 				predicate.Not(predicate.IsBeingTerminated),
 				predicate.HasName(starboard.PoliciesConfigMapName),
 				predicate.InNamespace(r.Config.Namespace))).
-			Complete(r.reconcileClusterConfig(resource.kind))
+			Complete(r.Throttle.Reconciler(r.reconcileClusterConfig(resource.kind)))
 		if err != nil {
 			return err
 		}
@@ -215,11 +219,19 @@ func (r *ResourceController) reconcileResource(resourceKind kube.Kind) reconcile
 			return ctrl.Result{}, fmt.Errorf("evaluating resource: %w", err)
 		}
 
+		maxMessageLength, err := r.ConfigData.GetConfigAuditMaxMessageLength()
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
 		reportBuilder := NewReportBuilder(r.Client.Scheme()).
 			Controller(resource).
 			ResourceSpecHash(resourceHash).
 			PluginConfigHash(policiesHash).
-			Data(reportData)
+			Data(reportData).
+			SkipOwnerReference(r.Config.SkipOwnerReference()).
+			MaxMessageLength(maxMessageLength).
+			StoreFullMessages(r.ConfigData.ConfigAuditStoreFullMessages(), r.Config.Namespace)
 		err = reportBuilder.Write(ctx, r.ReadWriter)
 		if err != nil {
 			return ctrl.Result{}, err
@@ -349,8 +361,16 @@ func (r *ResourceController) reconcileConfig(kind kube.Kind) reconcile.Func {
 			"batchDeleteLimit", r.Config.BatchDeleteLimit,
 			"labelSelector", labelSelector.String())
 
+		// Protected reports are kept. A batch of protected reports only would
+		// be listed again, hence reconciliation is requeued only if any report
+		// of the batch was deleted.
+		deleted := 0
 		for i := 0; i < ext.MinInt(r.Config.BatchDeleteLimit, len(reportList.Items)); i++ {
 			report := reportList.Items[i]
+			if kube.IsProtected(&report) {
+				log.V(1).Info("Keeping protected ConfigAuditReport", "report", report.Namespace+"/"+report.Name)
+				continue
+			}
 			log.V(1).Info("Deleting ConfigAuditReport", "report", report.Namespace+"/"+report.Name)
 			err := r.Client.Delete(ctx, &report)
 			if err != nil {
@@ -358,8 +378,9 @@ func (r *ResourceController) reconcileConfig(kind kube.Kind) reconcile.Func {
 					return ctrl.Result{}, fmt.Errorf("deleting ConfigAuditReport: %w", err)
 				}
 			}
+			deleted++
 		}
-		if len(reportList.Items)-r.Config.BatchDeleteLimit > 0 {
+		if len(reportList.Items)-r.Config.BatchDeleteLimit > 0 && deleted > 0 {
 			log.V(1).Info("Requeuing reconciliation key", "requeueAfter", r.Config.BatchDeleteDelay)
 			return ctrl.Result{RequeueAfter: r.Config.BatchDeleteDelay}, nil
 		}
@@ -414,8 +435,16 @@ func (r *ResourceController) reconcileClusterConfig(kind kube.Kind) reconcile.Fu
 			"batchDeleteLimit", r.Config.BatchDeleteLimit,
 			"labelSelector", labelSelector)
 
+		// Protected reports are kept. A batch of protected reports only would
+		// be listed again, hence reconciliation is requeued only if any report
+		// of the batch was deleted.
+		deleted := 0
 		for i := 0; i < ext.MinInt(r.Config.BatchDeleteLimit, len(clusterReportList.Items)); i++ {
 			report := clusterReportList.Items[i]
+			if kube.IsProtected(&report) {
+				log.V(1).Info("Keeping protected ClusterConfigAuditReport", "report", report.Name)
+				continue
+			}
 			log.V(1).Info("Deleting ClusterConfigAuditReport", "report", report.Name)
 			err := r.Client.Delete(ctx, &report)
 			if err != nil {
@@ -423,8 +452,9 @@ func (r *ResourceController) reconcileClusterConfig(kind kube.Kind) reconcile.Fu
 					return ctrl.Result{}, fmt.Errorf("deleting ClusterConfigAuditReport: %w", err)
 				}
 			}
+			deleted++
 		}
-		if len(clusterReportList.Items)-r.Config.BatchDeleteLimit > 0 {
+		if len(clusterReportList.Items)-r.Config.BatchDeleteLimit > 0 && deleted > 0 {
 			log.V(1).Info("Requeuing reconciliation key", "requeueAfter", r.Config.BatchDeleteDelay)
 			return ctrl.Result{RequeueAfter: r.Config.BatchDeleteDelay}, nil
 		}
@@ -0,0 +1,74 @@
+package configauditreport
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+// ChecksDiff holds keys of checks whose outcome changed between two revisions
+// of a report. Checks are keyed by check ID, or by container name and check
+// ID separated by a slash for container checks.
+type ChecksDiff struct {
+	// Failing are keys of checks which passed, or were not reported, in the
+	// previous revision and fail in the current one.
+	Failing []string
+	// Resolved are keys of checks which failed in the previous revision and
+	// pass, or are not reported, in the current one.
+	Resolved []string
+}
+
+// IsEmpty returns true if no check changed its outcome.
+func (d ChecksDiff) IsEmpty() bool {
+	return len(d.Failing) == 0 && len(d.Resolved) == 0
+}
+
+// DiffChecks compares checks of the current revision of a report with checks
+// of the previous revision. Keys in the returned ChecksDiff are sorted.
+func DiffChecks(previous, current v1alpha1.ConfigAuditReportData) ChecksDiff {
+	previousFailing := failingChecks(previous)
+	currentFailing := failingChecks(current)
+
+	var diff ChecksDiff
+	for key := range currentFailing {
+		if !previousFailing[key] {
+			diff.Failing = append(diff.Failing, key)
+		}
+	}
+	for key := range previousFailing {
+		if !currentFailing[key] {
+			diff.Resolved = append(diff.Resolved, key)
+		}
+	}
+	sort.Strings(diff.Failing)
+	sort.Strings(diff.Resolved)
+	return diff
+}
+
+func failingChecks(data v1alpha1.ConfigAuditReportData) map[string]bool {
+	failing := make(map[string]bool)
+	addFailing := func(checks []v1alpha1.Check, keyPrefix string) {
+		for _, check := range checks {
+			if !check.Success {
+				failing[keyPrefix+check.ID] = true
+			}
+		}
+	}
+	addFailing(data.Checks, "")
+	addFailing(data.PodChecks, "")
+	for container, checks := range data.ContainerChecks {
+		addFailing(checks, container+"/")
+	}
+	return failing
+}
+
+// FormatChecks returns a comma separated list of at most max check keys,
+// followed by the number of omitted keys, e.g. "KSV001, KSV003 +2 more".
+func FormatChecks(keys []string, max int) string {
+	if len(keys) <= max {
+		return strings.Join(keys, ", ")
+	}
+	return fmt.Sprintf("%s +%d more", strings.Join(keys[:max], ", "), len(keys)-max)
+}
@@ -0,0 +1,44 @@
+package configauditreport_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffChecks(t *testing.T) {
+	previous := v1alpha1.ConfigAuditReportData{
+		Checks: []v1alpha1.Check{
+			{ID: "KSV001", Success: true},
+			{ID: "KSV002", Success: false},
+			{ID: "KSV003", Success: false},
+		},
+		ContainerChecks: map[string][]v1alpha1.Check{
+			"nginx": {{ID: "KSV011", Success: true}},
+		},
+	}
+	current := v1alpha1.ConfigAuditReportData{
+		Checks: []v1alpha1.Check{
+			{ID: "KSV001", Success: false},
+			{ID: "KSV002", Success: true},
+			{ID: "KSV004", Success: false},
+		},
+		ContainerChecks: map[string][]v1alpha1.Check{
+			"nginx": {{ID: "KSV011", Success: false}},
+		},
+	}
+
+	assert.Equal(t, configauditreport.ChecksDiff{
+		Failing:  []string{"KSV001", "KSV004", "nginx/KSV011"},
+		Resolved: []string{"KSV002", "KSV003"},
+	}, configauditreport.DiffChecks(previous, current))
+	assert.True(t, configauditreport.DiffChecks(current, current).IsEmpty())
+}
+
+func TestFormatChecks(t *testing.T) {
+	assert.Equal(t, "KSV001, KSV002", configauditreport.FormatChecks([]string{"KSV001", "KSV002"}, 5))
+	assert.Equal(t, "KSV001, KSV002 +3 more",
+		configauditreport.FormatChecks([]string{"KSV001", "KSV002", "KSV003", "KSV004", "KSV005"}, 2))
+}
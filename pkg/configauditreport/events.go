@@ -0,0 +1,137 @@
+package configauditreport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ReasonChecksFailing is the reason of a warning event of a resource
+	// whose updated ConfigAuditReport has checks which passed before.
+	ReasonChecksFailing = "ConfigAuditChecksFailing"
+	// ReasonChecksResolved is the reason of a normal event of a resource
+	// whose updated ConfigAuditReport has checks which failed before.
+	ReasonChecksResolved = "ConfigAuditChecksResolved"
+
+	// MaxEventChecks is the maximum number of checks listed in an event.
+	MaxEventChecks = 5
+)
+
+type eventingReadWriter struct {
+	ReadWriter
+	kube.ObjectResolver
+	recorder record.EventRecorder
+	interval time.Duration
+	clock    ext.Clock
+
+	mu         sync.Mutex
+	lastEvents map[string]time.Time
+}
+
+// NewReadWriterWithEvents decorates the given ReadWriter to record events of
+// resources whose reports are updated with checks that changed outcome, i.e.
+// a warning event listing checks which started failing and a normal event
+// listing checks which were resolved. At most one event of each reason is
+// recorded for a resource within the specified interval.
+func NewReadWriterWithEvents(readWriter ReadWriter, client client.Client, recorder record.EventRecorder, interval time.Duration, clock ext.Clock) ReadWriter {
+	return &eventingReadWriter{
+		ReadWriter:     readWriter,
+		ObjectResolver: kube.ObjectResolver{Client: client},
+		recorder:       recorder,
+		interval:       interval,
+		clock:          clock,
+		lastEvents:     make(map[string]time.Time),
+	}
+}
+
+func (w *eventingReadWriter) WriteReport(ctx context.Context, report v1alpha1.ConfigAuditReport, opts ...kube.WriteOption) error {
+	if kube.NewWriteOptions(opts...).DryRun {
+		return w.ReadWriter.WriteReport(ctx, report, opts...)
+	}
+	var previous v1alpha1.ConfigAuditReport
+	err := w.Get(ctx, types.NamespacedName{Namespace: report.Namespace, Name: report.Name}, &previous)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	found := err == nil
+	err = w.ReadWriter.WriteReport(ctx, report, opts...)
+	if err != nil || !found {
+		return err
+	}
+	return w.recordEvents(ctx, report.ObjectMeta, DiffChecks(previous.Report, report.Report))
+}
+
+func (w *eventingReadWriter) WriteClusterReport(ctx context.Context, report v1alpha1.ClusterConfigAuditReport, opts ...kube.WriteOption) error {
+	if kube.NewWriteOptions(opts...).DryRun {
+		return w.ReadWriter.WriteClusterReport(ctx, report, opts...)
+	}
+	var previous v1alpha1.ClusterConfigAuditReport
+	err := w.Get(ctx, types.NamespacedName{Name: report.Name}, &previous)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	found := err == nil
+	err = w.ReadWriter.WriteClusterReport(ctx, report, opts...)
+	if err != nil || !found {
+		return err
+	}
+	return w.recordEvents(ctx, report.ObjectMeta, DiffChecks(previous.Report, report.Report))
+}
+
+// recordEvents records events of the resource described by the report with
+// the given metadata. Resources which no longer exist are ignored.
+func (w *eventingReadWriter) recordEvents(ctx context.Context, reportMeta metav1.ObjectMeta, diff ChecksDiff) error {
+	if diff.IsEmpty() {
+		return nil
+	}
+	ref, err := kube.ObjectRefFromObjectMeta(reportMeta)
+	if err != nil {
+		return err
+	}
+	resource, err := w.ObjectFromObjectRef(ctx, ref)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if len(diff.Failing) > 0 && w.allow(resource.GetUID(), ReasonChecksFailing) {
+		w.recorder.Eventf(resource, corev1.EventTypeWarning, ReasonChecksFailing,
+			"Configuration audit checks started failing: %s", FormatChecks(diff.Failing, MaxEventChecks))
+	}
+	if len(diff.Resolved) > 0 && w.allow(resource.GetUID(), ReasonChecksResolved) {
+		w.recorder.Eventf(resource, corev1.EventTypeNormal, ReasonChecksResolved,
+			"Configuration audit checks resolved: %s", FormatChecks(diff.Resolved, MaxEventChecks))
+	}
+	return nil
+}
+
+// allow returns true unless an event of the given reason was recorded for the
+// resource with the given UID within the interval.
+func (w *eventingReadWriter) allow(uid types.UID, reason string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := w.clock.Now()
+	for key, last := range w.lastEvents {
+		if now.Sub(last) >= w.interval {
+			delete(w.lastEvents, key)
+		}
+	}
+	key := string(uid) + "/" + reason
+	if _, ok := w.lastEvents[key]; ok {
+		return false
+	}
+	w.lastEvents[key] = now
+	return true
+}
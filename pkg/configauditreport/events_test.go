@@ -0,0 +1,84 @@
+package configauditreport_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewReadWriterWithEvents(t *testing.T) {
+	newReport := func(checks ...v1alpha1.Check) v1alpha1.ConfigAuditReport {
+		return v1alpha1.ConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "deployment-app",
+				Namespace: "qa",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "Deployment",
+					starboard.LabelResourceName:      "app",
+					starboard.LabelResourceNamespace: "qa",
+				},
+			},
+			Report: v1alpha1.ConfigAuditReportData{Checks: checks},
+		}
+	}
+	events := func(recorder *record.FakeRecorder) []string {
+		var recorded []string
+		for {
+			select {
+			case event := <-recorder.Events:
+				recorded = append(recorded, event)
+			default:
+				return recorded
+			}
+		}
+	}
+
+	client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "qa", UID: "app-uid"}},
+	).Build()
+	recorder := record.NewFakeRecorder(10)
+	readWriter := configauditreport.NewReadWriterWithEvents(configauditreport.NewReadWriter(client), client,
+		recorder, 5*time.Minute, ext.NewFixedClock(time.Now()))
+	ctx := context.TODO()
+
+	t.Run("Should not record events of new report", func(t *testing.T) {
+		err := readWriter.WriteReport(ctx, newReport(
+			v1alpha1.Check{ID: "KSV001", Success: true},
+			v1alpha1.Check{ID: "KSV002", Success: false},
+		))
+		require.NoError(t, err)
+		assert.Empty(t, events(recorder))
+	})
+
+	t.Run("Should record events of failing and resolved checks", func(t *testing.T) {
+		err := readWriter.WriteReport(ctx, newReport(
+			v1alpha1.Check{ID: "KSV001", Success: false},
+			v1alpha1.Check{ID: "KSV002", Success: true},
+		))
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"Warning ConfigAuditChecksFailing Configuration audit checks started failing: KSV001",
+			"Normal ConfigAuditChecksResolved Configuration audit checks resolved: KSV002",
+		}, events(recorder))
+	})
+
+	t.Run("Should not record events within interval", func(t *testing.T) {
+		err := readWriter.WriteReport(ctx, newReport(
+			v1alpha1.Check{ID: "KSV001", Success: true},
+			v1alpha1.Check{ID: "KSV002", Success: false},
+		))
+		require.NoError(t, err)
+		assert.Empty(t, events(recorder))
+	})
+}
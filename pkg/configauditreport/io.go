@@ -6,20 +6,31 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/quota"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // Writer is the interface for saving v1alpha1.ClusterConfigAuditReport
 // and v1alpha1.ConfigAuditReport instances.
+//
+// With the kube.DryRun option objects are validated by the API server, but
+// not persisted, and errors returned by admission webhooks are returned as is.
 type Writer interface {
 
 	// WriteReport creates or updates the given v1alpha1.ConfigAuditReport instance.
-	WriteReport(ctx context.Context, report v1alpha1.ConfigAuditReport) error
+	WriteReport(ctx context.Context, report v1alpha1.ConfigAuditReport, opts ...kube.WriteOption) error
 
 	// WriteClusterReport creates or updates the given v1alpha1.ClusterConfigAuditReport instance.
-	WriteClusterReport(ctx context.Context, report v1alpha1.ClusterConfigAuditReport) error
+	WriteClusterReport(ctx context.Context, report v1alpha1.ClusterConfigAuditReport, opts ...kube.WriteOption) error
+
+	// WriteFullMessages creates or updates the given Secret which holds full
+	// messages of truncated checks.
+	WriteFullMessages(ctx context.Context, secret *corev1.Secret, opts ...kube.WriteOption) error
 }
 
 // Reader is the interface that wraps methods for finding v1alpha1.ConfigAuditReport
@@ -49,18 +60,28 @@ type ReadWriter interface {
 
 type readWriter struct {
 	*kube.ObjectResolver
+	quota quota.Quota
 }
 
 // NewReadWriter constructs a new ReadWriter which is using the client package
 // provided by the controller-runtime libraries for interacting with the
 // Kubernetes API server.
 func NewReadWriter(client client.Client) ReadWriter {
+	return NewReadWriterWithQuota(client, nil)
+}
+
+// NewReadWriterWithQuota is similar to NewReadWriter except that the returned
+// ReadWriter admits new v1alpha1.ConfigAuditReport instances with the given
+// quota.Quota before it creates them. A nil quota.Quota admits all reports.
+func NewReadWriterWithQuota(client client.Client, quota quota.Quota) ReadWriter {
 	return &readWriter{
 		ObjectResolver: &kube.ObjectResolver{Client: client},
+		quota:          quota,
 	}
 }
 
-func (r *readWriter) WriteReport(ctx context.Context, report v1alpha1.ConfigAuditReport) error {
+func (r *readWriter) WriteReport(ctx context.Context, report v1alpha1.ConfigAuditReport, opts ...kube.WriteOption) error {
+	options := kube.NewWriteOptions(opts...)
 	var existing v1alpha1.ConfigAuditReport
 	err := r.Get(ctx, types.NamespacedName{
 		Name:      report.Name,
@@ -71,18 +92,27 @@ func (r *readWriter) WriteReport(ctx context.Context, report v1alpha1.ConfigAudi
 		copied := existing.DeepCopy()
 		copied.Labels = report.Labels
 		copied.Report = report.Report
+		copyFullMessagesAnnotation(&copied.ObjectMeta, report.ObjectMeta)
 
-		return r.Update(ctx, copied)
+		return r.Update(ctx, copied, options.UpdateOptions()...)
 	}
 
 	if errors.IsNotFound(err) {
-		return r.Create(ctx, &report)
+		// quota admission evicts reports, which must never happen on dry run
+		if r.quota != nil && !options.DryRun {
+			err = r.quota.Admit(ctx, report.Namespace)
+			if err != nil {
+				return fmt.Errorf("admitting config audit report: %w", err)
+			}
+		}
+		return r.Create(ctx, &report, options.CreateOptions()...)
 	}
 
 	return err
 }
 
-func (r *readWriter) WriteClusterReport(ctx context.Context, report v1alpha1.ClusterConfigAuditReport) error {
+func (r *readWriter) WriteClusterReport(ctx context.Context, report v1alpha1.ClusterConfigAuditReport, opts ...kube.WriteOption) error {
+	options := kube.NewWriteOptions(opts...)
 	var existing v1alpha1.ClusterConfigAuditReport
 	err := r.Get(ctx, types.NamespacedName{
 		Name: report.Name,
@@ -92,12 +122,51 @@ func (r *readWriter) WriteClusterReport(ctx context.Context, report v1alpha1.Clu
 		copied := existing.DeepCopy()
 		copied.Labels = report.Labels
 		copied.Report = report.Report
+		copyFullMessagesAnnotation(&copied.ObjectMeta, report.ObjectMeta)
+
+		return r.Update(ctx, copied, options.UpdateOptions()...)
+	}
+
+	if errors.IsNotFound(err) {
+		return r.Create(ctx, &report, options.CreateOptions()...)
+	}
+
+	return err
+}
+
+// copyFullMessagesAnnotation sets or removes the
+// starboard.AnnotationFullMessagesSecret annotation of an existing report
+// depending on whether messages of the updated report are truncated.
+func copyFullMessagesAnnotation(existing *metav1.ObjectMeta, updated metav1.ObjectMeta) {
+	secretRef, ok := updated.Annotations[starboard.AnnotationFullMessagesSecret]
+	if !ok {
+		delete(existing.Annotations, starboard.AnnotationFullMessagesSecret)
+		return
+	}
+	if existing.Annotations == nil {
+		existing.Annotations = make(map[string]string)
+	}
+	existing.Annotations[starboard.AnnotationFullMessagesSecret] = secretRef
+}
+
+func (r *readWriter) WriteFullMessages(ctx context.Context, secret *corev1.Secret, opts ...kube.WriteOption) error {
+	options := kube.NewWriteOptions(opts...)
+	var existing corev1.Secret
+	err := r.Get(ctx, types.NamespacedName{
+		Name:      secret.Name,
+		Namespace: secret.Namespace,
+	}, &existing)
+
+	if err == nil {
+		copied := existing.DeepCopy()
+		copied.Labels = secret.Labels
+		copied.Data = secret.Data
 
-		return r.Update(ctx, copied)
+		return r.Update(ctx, copied, options.UpdateOptions()...)
 	}
 
 	if errors.IsNotFound(err) {
-		return r.Create(ctx, &report)
+		return r.Create(ctx, secret, options.CreateOptions()...)
 	}
 
 	return err
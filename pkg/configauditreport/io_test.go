@@ -410,4 +410,59 @@ func TestReadWriter(t *testing.T) {
 			Report: v1alpha1.ConfigAuditReportData{},
 		}, found)
 	})
+
+	t.Run("Should admit ConfigAuditReport with quota before creating it", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		admitted := &admittedNamespaces{}
+		readWriter := configauditreport.NewReadWriterWithQuota(client, admitted)
+		report := v1alpha1.ConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-app",
+				Namespace: "ci",
+			},
+		}
+
+		require.NoError(t, readWriter.WriteReport(context.TODO(), report))
+		assert.Equal(t, []string{"ci"}, admitted.namespaces)
+
+		require.NoError(t, readWriter.WriteReport(context.TODO(), report))
+		assert.Equal(t, []string{"ci"}, admitted.namespaces, "updated reports must not be admitted")
+	})
+
+	t.Run("Should not persist ConfigAuditReport on dry run", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		admitted := &admittedNamespaces{}
+		readWriter := configauditreport.NewReadWriterWithQuota(client, admitted)
+
+		require.NoError(t, readWriter.WriteReport(context.TODO(), v1alpha1.ConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-app",
+				Namespace: "ci",
+			},
+		}, kube.DryRun()))
+		require.NoError(t, readWriter.WriteClusterReport(context.TODO(), v1alpha1.ClusterConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "clusterrole-admin",
+			},
+		}, kube.DryRun()))
+		assert.Empty(t, admitted.namespaces, "reports must not be admitted on dry run")
+
+		var reports v1alpha1.ConfigAuditReportList
+		require.NoError(t, client.List(context.TODO(), &reports))
+		assert.Empty(t, reports.Items)
+		var clusterReports v1alpha1.ClusterConfigAuditReportList
+		require.NoError(t, client.List(context.TODO(), &clusterReports))
+		assert.Empty(t, clusterReports.Items)
+	})
+}
+
+// admittedNamespaces is a quota.Quota which records namespaces of admitted
+// reports.
+type admittedNamespaces struct {
+	namespaces []string
+}
+
+func (a *admittedNamespaces) Admit(_ context.Context, namespace string) error {
+	a.namespaces = append(a.namespaces, namespace)
+	return nil
 }
@@ -0,0 +1,113 @@
+package configauditreport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// FullMessagesKey is the key of a Secret which holds full messages of
+// truncated checks as gzip compressed JSON.
+const FullMessagesKey = "messages.json.gz"
+
+// TruncateMessages returns a copy of the given report data with check messages
+// longer than maxLength characters truncated. The number of truncated characters
+// is appended to each truncated message. Full messages of truncated checks are
+// returned keyed by check ID, or by container name and check ID separated by a
+// slash for container checks. Zero maxLength disables truncation.
+func TruncateMessages(data v1alpha1.ConfigAuditReportData, maxLength int) (v1alpha1.ConfigAuditReportData, map[string][]string) {
+	fullMessages := make(map[string][]string)
+	if maxLength <= 0 {
+		return data, fullMessages
+	}
+	data.Checks = truncateChecks(data.Checks, "", maxLength, fullMessages)
+	data.PodChecks = truncateChecks(data.PodChecks, "", maxLength, fullMessages)
+	if data.ContainerChecks != nil {
+		containerChecks := make(map[string][]v1alpha1.Check, len(data.ContainerChecks))
+		for container, checks := range data.ContainerChecks {
+			containerChecks[container] = truncateChecks(checks, container+"/", maxLength, fullMessages)
+		}
+		data.ContainerChecks = containerChecks
+	}
+	return data, fullMessages
+}
+
+func truncateChecks(checks []v1alpha1.Check, keyPrefix string, maxLength int, fullMessages map[string][]string) []v1alpha1.Check {
+	if checks == nil {
+		return nil
+	}
+	truncatedChecks := make([]v1alpha1.Check, len(checks))
+	for i, check := range checks {
+		truncatedChecks[i] = check
+		if check.Messages == nil {
+			continue
+		}
+		messages := make([]string, len(check.Messages))
+		truncated := false
+		for j, message := range check.Messages {
+			var ok bool
+			messages[j], ok = truncateMessage(message, maxLength)
+			truncated = truncated || ok
+		}
+		if truncated {
+			truncatedChecks[i].Messages = messages
+			fullMessages[keyPrefix+check.ID] = check.Messages
+		}
+	}
+	return truncatedChecks
+}
+
+// truncateMessage truncates the given message to maxLength characters and
+// appends the number of truncated characters. It returns true if the message
+// was truncated.
+func truncateMessage(message string, maxLength int) (string, bool) {
+	length := utf8.RuneCountInString(message)
+	if length <= maxLength {
+		return message, false
+	}
+	runes := []rune(message)
+	return fmt.Sprintf("%s... (%d characters truncated)", string(runes[:maxLength]), length-maxLength), true
+}
+
+// EncodeFullMessages returns the given full messages of truncated checks as
+// gzip compressed JSON.
+func EncodeFullMessages(fullMessages map[string][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	err := json.NewEncoder(writer).Encode(fullMessages)
+	if err != nil {
+		return nil, err
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeFullMessages returns full messages of truncated checks stored in the
+// given Secret.
+func DecodeFullMessages(secret *corev1.Secret) (map[string][]string, error) {
+	data, ok := secret.Data[FullMessagesKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s does not have key %s", secret.Namespace, secret.Name, FullMessagesKey)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decompressing full messages: %w", err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	var fullMessages map[string][]string
+	err = json.NewDecoder(reader).Decode(&fullMessages)
+	if err != nil {
+		return nil, fmt.Errorf("decoding full messages: %w", err)
+	}
+	return fullMessages, nil
+}
@@ -0,0 +1,70 @@
+package configauditreport_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestTruncateMessages(t *testing.T) {
+	data := v1alpha1.ConfigAuditReportData{
+		Checks: []v1alpha1.Check{
+			{ID: "KSV001", Messages: []string{"short", strings.Repeat("x", 12)}},
+			{ID: "KSV002"},
+		},
+		ContainerChecks: map[string][]v1alpha1.Check{
+			"nginx": {
+				{ID: "KSV003", Messages: []string{"zażółć gęślą jaźń"}},
+			},
+		},
+	}
+
+	t.Run("Should truncate messages longer than max length", func(t *testing.T) {
+		truncated, fullMessages := configauditreport.TruncateMessages(data, 10)
+		assert.Equal(t, v1alpha1.ConfigAuditReportData{
+			Checks: []v1alpha1.Check{
+				{ID: "KSV001", Messages: []string{"short", "xxxxxxxxxx... (2 characters truncated)"}},
+				{ID: "KSV002"},
+			},
+			ContainerChecks: map[string][]v1alpha1.Check{
+				"nginx": {
+					{ID: "KSV003", Messages: []string{"zażółć gęś... (7 characters truncated)"}},
+				},
+			},
+		}, truncated)
+		assert.Equal(t, map[string][]string{
+			"KSV001":       {"short", strings.Repeat("x", 12)},
+			"nginx/KSV003": {"zażółć gęślą jaźń"},
+		}, fullMessages)
+		assert.Equal(t, strings.Repeat("x", 12), data.Checks[0].Messages[1], "given data must not be modified")
+	})
+
+	t.Run("Should not truncate messages when max length is zero", func(t *testing.T) {
+		truncated, fullMessages := configauditreport.TruncateMessages(data, 0)
+		assert.Equal(t, data, truncated)
+		assert.Empty(t, fullMessages)
+	})
+}
+
+func TestFullMessages(t *testing.T) {
+	fullMessages := map[string][]string{
+		"KSV001": {strings.Repeat("x", 4096)},
+	}
+	encoded, err := configauditreport.EncodeFullMessages(fullMessages)
+	require.NoError(t, err)
+	assert.Less(t, len(encoded), 4096)
+
+	decoded, err := configauditreport.DecodeFullMessages(&corev1.Secret{
+		Data: map[string][]byte{configauditreport.FullMessagesKey: encoded},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, fullMessages, decoded)
+
+	_, err = configauditreport.DecodeFullMessages(&corev1.Secret{})
+	assert.Error(t, err)
+}
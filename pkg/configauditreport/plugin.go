@@ -44,3 +44,27 @@ type Plugin interface {
 	// plugin, false otherwise.
 	IsApplicable(ctx starboard.PluginContext, obj client.Object) (bool, string, error)
 }
+
+// ResourceHash returns the hash of the specified resource recorded in the
+// starboard.LabelResourceSpecHash label of configuration audit reports.
+// Annotations matching the configAudit.ignoredAnnotations setting of Starboard
+// are left out, so that changing them does not trigger a new audit.
+func ResourceHash(ctx starboard.PluginContext, obj client.Object) (string, error) {
+	return kube.ComputeAuditHash(obj, ctx.GetStarboardConfig().GetConfigAuditIgnoredAnnotations())
+}
+
+// ConfigHash returns the hash of settings affecting results of configuration
+// audits of the specified resource kind, which is recorded in the
+// starboard.LabelPluginConfigHash label of configuration audit reports. Besides
+// the configuration of the plugin the hash covers the ignored annotations, so
+// that changing them invalidates reports.
+func ConfigHash(plugin Plugin, ctx starboard.PluginContext, kind kube.Kind) (string, error) {
+	pluginConfigHash, err := plugin.ConfigHash(ctx, kind)
+	if err != nil {
+		return "", err
+	}
+	return kube.ComputeHash([]interface{}{
+		pluginConfigHash,
+		ctx.GetStarboardConfig().GetConfigAuditIgnoredAnnotations(),
+	}), nil
+}
@@ -0,0 +1,101 @@
+// Package exploitability prioritizes vulnerabilities by the likelihood of
+// their exploitation, according to the catalog of known exploited
+// vulnerabilities (KEV) and the Exploit Prediction Scoring System (EPSS), so
+// that the few vulnerabilities exploited in the wild stand out among the many
+// with CRITICAL severity.
+//
+// Feeds are downloaded by the operator and cached in a ConfigMap. Nothing is
+// downloaded unless a URL of a feed is configured.
+package exploitability
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+)
+
+// Configuration keys of the starboard ConfigMap.
+const (
+	// KeyKEVURL is the URL of the known exploited vulnerabilities catalog in
+	// the JSON format of CISA KEV.
+	KeyKEVURL = "vulnerabilityReports.exploitability.kevURL"
+	// KeyEPSSURL is the URL of EPSS scores in the CSV format published by
+	// FIRST, optionally gzip compressed.
+	KeyEPSSURL = "vulnerabilityReports.exploitability.epssURL"
+	// KeyRefreshInterval is the interval between downloads of feeds.
+	KeyRefreshInterval = "vulnerabilityReports.exploitability.refreshInterval"
+	// KeyEPSSThreshold is the EPSS score at or above which a vulnerability
+	// is considered likely to be exploited.
+	KeyEPSSThreshold = "vulnerabilityReports.exploitability.epssThreshold"
+)
+
+const (
+	defaultRefreshInterval = 24 * time.Hour
+	defaultEPSSThreshold   = 0.1
+)
+
+// Config is the configuration of prioritization.
+type Config struct {
+	// KEVURL is the URL of the known exploited vulnerabilities catalog. It's
+	// not downloaded if it's empty.
+	KEVURL string
+	// EPSSURL is the URL of EPSS scores. They're not downloaded if it's
+	// empty.
+	EPSSURL string
+	// RefreshInterval is the interval between downloads of feeds.
+	RefreshInterval time.Duration
+	// EPSSThreshold is the EPSS score at or above which a vulnerability is
+	// considered likely to be exploited.
+	EPSSThreshold float64
+}
+
+// GetConfig returns the configuration of prioritization from the given
+// starboard.ConfigData, or false if vulnerabilities are not prioritized
+// because no feed URL is configured.
+func GetConfig(config starboard.ConfigData) (Config, bool, error) {
+	c := Config{
+		KEVURL:          config[KeyKEVURL],
+		EPSSURL:         config[KeyEPSSURL],
+		RefreshInterval: defaultRefreshInterval,
+		EPSSThreshold:   defaultEPSSThreshold,
+	}
+	if c.KEVURL == "" && c.EPSSURL == "" {
+		return Config{}, false, nil
+	}
+	for key, value := range map[string]string{KeyKEVURL: c.KEVURL, KeyEPSSURL: c.EPSSURL} {
+		if value == "" {
+			continue
+		}
+		u, err := url.Parse(value)
+		if err != nil {
+			return Config{}, false, fmt.Errorf("parsing incorrectly formatted %s: %w", key, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return Config{}, false, fmt.Errorf("unsupported scheme of %s: %q, allowed schemes are: http,https", key, u.Scheme)
+		}
+	}
+	if value, ok := config[KeyRefreshInterval]; ok {
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			return Config{}, false, fmt.Errorf("parsing incorrectly formatted %s: %w", KeyRefreshInterval, err)
+		}
+		if interval <= 0 {
+			return Config{}, false, fmt.Errorf("%s must be positive, got %s", KeyRefreshInterval, value)
+		}
+		c.RefreshInterval = interval
+	}
+	if value, ok := config[KeyEPSSThreshold]; ok {
+		threshold, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return Config{}, false, fmt.Errorf("parsing incorrectly formatted %s: %w", KeyEPSSThreshold, err)
+		}
+		if threshold < 0 || threshold > 1 {
+			return Config{}, false, fmt.Errorf("%s must be between 0 and 1, got %s", KeyEPSSThreshold, value)
+		}
+		c.EPSSThreshold = threshold
+	}
+	return c, true, nil
+}
@@ -0,0 +1,87 @@
+package exploitability_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/exploitability"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetConfig(t *testing.T) {
+	testCases := []struct {
+		name            string
+		configData      starboard.ConfigData
+		expectedConfig  exploitability.Config
+		expectedEnabled bool
+		expectedError   string
+	}{
+		{
+			name:       "Should be disabled without feed URLs",
+			configData: starboard.ConfigData{exploitability.KeyRefreshInterval: "1h"},
+		},
+		{
+			name: "Should return defaults",
+			configData: starboard.ConfigData{
+				exploitability.KeyKEVURL: "https://www.cisa.gov/sites/default/files/feeds/known_exploited_vulnerabilities.json",
+			},
+			expectedConfig: exploitability.Config{
+				KEVURL:          "https://www.cisa.gov/sites/default/files/feeds/known_exploited_vulnerabilities.json",
+				RefreshInterval: 24 * time.Hour,
+				EPSSThreshold:   0.1,
+			},
+			expectedEnabled: true,
+		},
+		{
+			name: "Should return configured values",
+			configData: starboard.ConfigData{
+				exploitability.KeyEPSSURL:         "https://epss.cyentia.com/epss_scores-current.csv.gz",
+				exploitability.KeyRefreshInterval: "6h",
+				exploitability.KeyEPSSThreshold:   "0.5",
+			},
+			expectedConfig: exploitability.Config{
+				EPSSURL:         "https://epss.cyentia.com/epss_scores-current.csv.gz",
+				RefreshInterval: 6 * time.Hour,
+				EPSSThreshold:   0.5,
+			},
+			expectedEnabled: true,
+		},
+		{
+			name: "Should return error when URL scheme is not supported",
+			configData: starboard.ConfigData{
+				exploitability.KeyKEVURL: "file:///kev.json",
+			},
+			expectedError: `unsupported scheme of vulnerabilityReports.exploitability.kevURL: "file", allowed schemes are: http,https`,
+		},
+		{
+			name: "Should return error when refresh interval is invalid",
+			configData: starboard.ConfigData{
+				exploitability.KeyKEVURL:          "https://example.com/kev.json",
+				exploitability.KeyRefreshInterval: "daily",
+			},
+			expectedError: `parsing incorrectly formatted vulnerabilityReports.exploitability.refreshInterval: time: invalid duration "daily"`,
+		},
+		{
+			name: "Should return error when EPSS threshold is out of range",
+			configData: starboard.ConfigData{
+				exploitability.KeyEPSSURL:       "https://example.com/epss.csv",
+				exploitability.KeyEPSSThreshold: "10",
+			},
+			expectedError: "vulnerabilityReports.exploitability.epssThreshold must be between 0 and 1, got 10",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, enabled, err := exploitability.GetConfig(tc.configData)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedEnabled, enabled)
+			assert.Equal(t, tc.expectedConfig, config)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package exploitability
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Prioritize returns the v1alpha1.Priority of a vulnerability with the given
+// severity, which is likely to be exploited if it's known exploited or its
+// EPSS score is at or above the threshold.
+func Prioritize(severity v1alpha1.Severity, knownExploited bool, epssScore *float64, epssThreshold float64) v1alpha1.Priority {
+	severe := severity == v1alpha1.SeverityCritical || severity == v1alpha1.SeverityHigh
+	likely := epssScore != nil && *epssScore >= epssThreshold
+	switch {
+	case knownExploited && severe:
+		return v1alpha1.PriorityP1
+	case knownExploited, likely && severe:
+		return v1alpha1.PriorityP2
+	case severe, likely:
+		return v1alpha1.PriorityP3
+	default:
+		return v1alpha1.PriorityP4
+	}
+}
+
+// Enricher annotates vulnerabilities of reports with feeds cached in the
+// ConfigMapName ConfigMap by the Updater. Reports are not enriched until
+// feeds are downloaded.
+//
+// It's safe for concurrent use. A nil Enricher doesn't enrich reports.
+type Enricher struct {
+	reader        client.Reader
+	namespace     string
+	epssThreshold float64
+
+	mu              sync.Mutex
+	resourceVersion string
+	index           *index
+}
+
+// index holds Feeds decoded from the ConfigMap with the resourceVersion.
+type index struct {
+	knownExploited map[string]bool
+	epssScores     map[string]float64
+}
+
+// NewEnricher constructs an Enricher which reads feeds from the given
+// namespace.
+func NewEnricher(reader client.Reader, namespace string, config Config) *Enricher {
+	return &Enricher{
+		reader:        reader,
+		namespace:     namespace,
+		epssThreshold: config.EPSSThreshold,
+	}
+}
+
+// Enrich sets KnownExploited, EPSSScore and Priority of vulnerabilities of
+// the given report, as well as KnownExploitedCount and PriorityCounts of its
+// summary.
+func (e *Enricher) Enrich(ctx context.Context, data *v1alpha1.VulnerabilityReportData) error {
+	if e == nil {
+		return nil
+	}
+	idx, err := e.load(ctx)
+	if err != nil {
+		return err
+	}
+	if idx == nil {
+		return nil
+	}
+	idx.apply(data, e.epssThreshold)
+	return nil
+}
+
+func (e *Enricher) load(ctx context.Context) (*index, error) {
+	var cm corev1.ConfigMap
+	err := e.reader.Get(ctx, client.ObjectKey{Namespace: e.namespace, Name: ConfigMapName}, &cm)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting exploitability feeds: %w", err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.index != nil && e.resourceVersion == cm.ResourceVersion {
+		return e.index, nil
+	}
+	feeds, err := decodeFeeds(cm.BinaryData[FeedsKey])
+	if err != nil {
+		return nil, fmt.Errorf("decoding exploitability feeds: %w", err)
+	}
+	idx := &index{
+		knownExploited: make(map[string]bool, len(feeds.KnownExploited)),
+		epssScores:     feeds.EPSSScores,
+	}
+	for _, id := range feeds.KnownExploited {
+		idx.knownExploited[id] = true
+	}
+	e.index, e.resourceVersion = idx, cm.ResourceVersion
+	return idx, nil
+}
+
+func (idx *index) apply(data *v1alpha1.VulnerabilityReportData, epssThreshold float64) {
+	data.Summary.KnownExploitedCount = 0
+	data.Summary.PriorityCounts = nil
+	for i := range data.Vulnerabilities {
+		v := &data.Vulnerabilities[i]
+		v.KnownExploited = idx.knownExploited[v.VulnerabilityID]
+		v.EPSSScore = nil
+		if score, ok := idx.epssScores[v.VulnerabilityID]; ok {
+			v.EPSSScore = &score
+		}
+		v.Priority = Prioritize(v.Severity, v.KnownExploited, v.EPSSScore, epssThreshold)
+
+		if v.KnownExploited {
+			data.Summary.KnownExploitedCount++
+		}
+		if data.Summary.PriorityCounts == nil {
+			data.Summary.PriorityCounts = make(map[v1alpha1.Priority]int)
+		}
+		data.Summary.PriorityCounts[v.Priority]++
+	}
+}
@@ -0,0 +1,111 @@
+package exploitability_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/exploitability"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPrioritize(t *testing.T) {
+	testCases := []struct {
+		severity       v1alpha1.Severity
+		knownExploited bool
+		epssScore      *float64
+		expected       v1alpha1.Priority
+	}{
+		{severity: v1alpha1.SeverityCritical, knownExploited: true, expected: v1alpha1.PriorityP1},
+		{severity: v1alpha1.SeverityHigh, knownExploited: true, epssScore: pointer.Float64(0.01), expected: v1alpha1.PriorityP1},
+		{severity: v1alpha1.SeverityLow, knownExploited: true, expected: v1alpha1.PriorityP2},
+		{severity: v1alpha1.SeverityCritical, epssScore: pointer.Float64(0.1), expected: v1alpha1.PriorityP2},
+		{severity: v1alpha1.SeverityCritical, epssScore: pointer.Float64(0.09), expected: v1alpha1.PriorityP3},
+		{severity: v1alpha1.SeverityHigh, expected: v1alpha1.PriorityP3},
+		{severity: v1alpha1.SeverityMedium, epssScore: pointer.Float64(0.5), expected: v1alpha1.PriorityP3},
+		{severity: v1alpha1.SeverityMedium, epssScore: pointer.Float64(0.05), expected: v1alpha1.PriorityP4},
+		{severity: v1alpha1.SeverityUnknown, expected: v1alpha1.PriorityP4},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, exploitability.Prioritize(tc.severity, tc.knownExploited, tc.epssScore, 0.1),
+			"severity: %s, knownExploited: %t, epssScore: %v", tc.severity, tc.knownExploited, tc.epssScore)
+	}
+}
+
+func TestEnricher_Enrich(t *testing.T) {
+	server, _ := newFeedsServer(t)
+	config := exploitability.Config{
+		KEVURL:          server.URL + "/kev.json",
+		EPSSURL:         server.URL + "/epss.csv",
+		RefreshInterval: 24 * time.Hour,
+		EPSSThreshold:   0.1,
+	}
+	kubeClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+	newReportData := func() v1alpha1.VulnerabilityReportData {
+		return v1alpha1.VulnerabilityReportData{
+			Summary: v1alpha1.VulnerabilitySummary{CriticalCount: 2, MediumCount: 1},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2021-44228", Severity: v1alpha1.SeverityCritical},
+				{VulnerabilityID: "CVE-2022-22965", Severity: v1alpha1.SeverityCritical},
+				{VulnerabilityID: "CVE-2022-0001", Severity: v1alpha1.SeverityMedium},
+			},
+		}
+	}
+
+	t.Run("Should not enrich report before feeds are downloaded", func(t *testing.T) {
+		data := newReportData()
+		err := exploitability.NewEnricher(kubeClient, "starboard-system", config).Enrich(context.TODO(), &data)
+		require.NoError(t, err)
+		assert.Equal(t, newReportData(), data)
+	})
+
+	t.Run("Should not enrich report with nil enricher", func(t *testing.T) {
+		var enricher *exploitability.Enricher
+		data := newReportData()
+		require.NoError(t, enricher.Enrich(context.TODO(), &data))
+		assert.Equal(t, newReportData(), data)
+	})
+
+	t.Run("Should enrich report with cached feeds", func(t *testing.T) {
+		err := (&exploitability.Updater{
+			Logger:     logr.Discard(),
+			Client:     kubeClient,
+			Config:     config,
+			Namespace:  "starboard-system",
+			HTTPClient: server.Client(),
+			Clock:      ext.NewSystemClock(),
+		}).Refresh(context.TODO())
+		require.NoError(t, err)
+
+		data := newReportData()
+		err = exploitability.NewEnricher(kubeClient, "starboard-system", config).Enrich(context.TODO(), &data)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.VulnerabilityReportData{
+			Summary: v1alpha1.VulnerabilitySummary{
+				CriticalCount:       2,
+				MediumCount:         1,
+				KnownExploitedCount: 1,
+				PriorityCounts: map[v1alpha1.Priority]int{
+					v1alpha1.PriorityP1: 1,
+					v1alpha1.PriorityP2: 1,
+					v1alpha1.PriorityP4: 1,
+				},
+			},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2021-44228", Severity: v1alpha1.SeverityCritical,
+					KnownExploited: true, EPSSScore: pointer.Float64(0.97565), Priority: v1alpha1.PriorityP1},
+				{VulnerabilityID: "CVE-2022-22965", Severity: v1alpha1.SeverityCritical,
+					EPSSScore: pointer.Float64(0.97471), Priority: v1alpha1.PriorityP2},
+				{VulnerabilityID: "CVE-2022-0001", Severity: v1alpha1.SeverityMedium,
+					Priority: v1alpha1.PriorityP4},
+			},
+		}, data)
+	})
+}
@@ -0,0 +1,193 @@
+package exploitability
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// minCachedEPSSScore is the lowest EPSS score cached in the ConfigMap.
+	// Scores of most CVEs are lower and wouldn't fit in a ConfigMap, so
+	// vulnerabilities with lower scores are reported without one.
+	minCachedEPSSScore = 0.01
+	// maxFeedSize bounds the size of a downloaded feed after decompression.
+	maxFeedSize = 256 << 20
+)
+
+// Feeds holds exploitability data of vulnerabilities keyed by CVE ID.
+type Feeds struct {
+	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
+	// KEVURL and EPSSURL are URLs the feeds were downloaded from.
+	KEVURL  string `json:"kevURL,omitempty"`
+	EPSSURL string `json:"epssURL,omitempty"`
+	// KnownExploited lists CVE IDs of known exploited vulnerabilities.
+	KnownExploited []string `json:"knownExploited,omitempty"`
+	// EPSSScores maps CVE IDs to EPSS scores of at least minCachedEPSSScore.
+	EPSSScores map[string]float64 `json:"epssScores,omitempty"`
+}
+
+// Download downloads feeds of the given Config. Feeds without a URL are left
+// empty.
+func Download(ctx context.Context, httpClient *http.Client, config Config) (Feeds, error) {
+	var feeds Feeds
+	if config.KEVURL != "" {
+		err := download(ctx, httpClient, config.KEVURL, func(r io.Reader) (err error) {
+			feeds.KnownExploited, err = ParseKEV(r)
+			return
+		})
+		if err != nil {
+			return Feeds{}, fmt.Errorf("downloading known exploited vulnerabilities: %w", err)
+		}
+	}
+	if config.EPSSURL != "" {
+		err := download(ctx, httpClient, config.EPSSURL, func(r io.Reader) (err error) {
+			feeds.EPSSScores, err = ParseEPSS(r, minCachedEPSSScore)
+			return
+		})
+		if err != nil {
+			return Feeds{}, fmt.Errorf("downloading EPSS scores: %w", err)
+		}
+	}
+	return feeds, nil
+}
+
+func download(ctx context.Context, httpClient *http.Client, url string, parse func(io.Reader) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status of %s: %s", url, resp.Status)
+	}
+	body, err := decompress(resp.Body)
+	if err != nil {
+		return err
+	}
+	return parse(io.LimitReader(body, maxFeedSize))
+}
+
+// decompress returns a reader of the decompressed content of r if it's gzip
+// compressed, regardless of the Content-Encoding of the response.
+func decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(br)
+	}
+	return br, nil
+}
+
+// ParseKEV returns sorted CVE IDs listed in the known exploited
+// vulnerabilities catalog in the JSON format of CISA KEV.
+func ParseKEV(r io.Reader) ([]string, error) {
+	var catalog struct {
+		Vulnerabilities []struct {
+			CVEID string `json:"cveID"`
+		} `json:"vulnerabilities"`
+	}
+	if err := json.NewDecoder(r).Decode(&catalog); err != nil {
+		return nil, fmt.Errorf("decoding known exploited vulnerabilities catalog: %w", err)
+	}
+	var ids []string
+	for _, vulnerability := range catalog.Vulnerabilities {
+		if vulnerability.CVEID != "" {
+			ids = append(ids, vulnerability.CVEID)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// ParseEPSS returns EPSS scores of at least minScore by CVE ID from the CSV
+// format published by FIRST, whose header row names the cve and epss columns.
+// Comment lines, which carry the model version, are skipped.
+func ParseEPSS(r io.Reader, minScore float64) (map[string]float64, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading EPSS header: %w", err)
+	}
+	cveColumn, epssColumn := -1, -1
+	for i, name := range header {
+		switch strings.TrimSpace(name) {
+		case "cve":
+			cveColumn = i
+		case "epss":
+			epssColumn = i
+		}
+	}
+	if cveColumn < 0 || epssColumn < 0 {
+		return nil, fmt.Errorf("EPSS header %q misses cve or epss column", strings.Join(header, ","))
+	}
+	scores := make(map[string]float64)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading EPSS scores: %w", err)
+		}
+		if len(record) <= cveColumn || len(record) <= epssColumn {
+			continue
+		}
+		score, err := strconv.ParseFloat(record[epssColumn], 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing EPSS score of %s: %w", record[cveColumn], err)
+		}
+		if score >= minScore {
+			scores[record[cveColumn]] = score
+		}
+	}
+	return scores, nil
+}
+
+// encode returns Feeds as gzip compressed JSON.
+func (f Feeds) encode() ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(f); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeFeeds(data []byte) (Feeds, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return Feeds{}, err
+	}
+	var feeds Feeds
+	if err := json.NewDecoder(zr).Decode(&feeds); err != nil {
+		return Feeds{}, err
+	}
+	return feeds, nil
+}
@@ -0,0 +1,111 @@
+package exploitability_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/exploitability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	kevCatalog = `{
+  "title": "CISA Catalog of Known Exploited Vulnerabilities",
+  "count": 2,
+  "vulnerabilities": [
+    {"cveID": "CVE-2021-44228", "vendorProject": "Apache", "product": "Log4j2"},
+    {"cveID": "CVE-2014-0160", "vendorProject": "OpenSSL", "product": "OpenSSL"}
+  ]
+}`
+	epssScores = `#model_version:v2023.03.01,score_date:2023-06-01T00:00:00+0000
+cve,epss,percentile
+CVE-2021-44228,0.97565,0.99996
+CVE-2022-0001,0.00045,0.11000
+CVE-2022-22965,0.97471,0.99965
+`
+)
+
+func TestParseKEV(t *testing.T) {
+	ids, err := exploitability.ParseKEV(strings.NewReader(kevCatalog))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CVE-2014-0160", "CVE-2021-44228"}, ids)
+
+	_, err = exploitability.ParseKEV(strings.NewReader("<html></html>"))
+	assert.Error(t, err)
+}
+
+func TestParseEPSS(t *testing.T) {
+	t.Run("Should skip scores below minimum", func(t *testing.T) {
+		scores, err := exploitability.ParseEPSS(strings.NewReader(epssScores), 0.01)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]float64{
+			"CVE-2021-44228": 0.97565,
+			"CVE-2022-22965": 0.97471,
+		}, scores)
+	})
+
+	t.Run("Should return error when header misses columns", func(t *testing.T) {
+		_, err := exploitability.ParseEPSS(strings.NewReader("id,score\nCVE-2021-44228,0.9\n"), 0)
+		assert.EqualError(t, err, `EPSS header "id,score" misses cve or epss column`)
+	})
+
+	t.Run("Should return error when score is malformed", func(t *testing.T) {
+		_, err := exploitability.ParseEPSS(strings.NewReader("cve,epss\nCVE-2021-44228,high\n"), 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestDownload(t *testing.T) {
+	var gzipped bytes.Buffer
+	zw := gzip.NewWriter(&gzipped)
+	_, err := zw.Write([]byte(epssScores))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/kev.json":
+			_, _ = w.Write([]byte(kevCatalog))
+		case "/epss.csv.gz":
+			_, _ = w.Write(gzipped.Bytes())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("Should download and decompress feeds", func(t *testing.T) {
+		feeds, err := exploitability.Download(context.TODO(), server.Client(), exploitability.Config{
+			KEVURL:  server.URL + "/kev.json",
+			EPSSURL: server.URL + "/epss.csv.gz",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"CVE-2014-0160", "CVE-2021-44228"}, feeds.KnownExploited)
+		assert.Equal(t, map[string]float64{
+			"CVE-2021-44228": 0.97565,
+			"CVE-2022-22965": 0.97471,
+		}, feeds.EPSSScores)
+	})
+
+	t.Run("Should skip feeds without URL", func(t *testing.T) {
+		feeds, err := exploitability.Download(context.TODO(), server.Client(), exploitability.Config{
+			KEVURL: server.URL + "/kev.json",
+		})
+		require.NoError(t, err)
+		assert.Len(t, feeds.KnownExploited, 2)
+		assert.Empty(t, feeds.EPSSScores)
+	})
+
+	t.Run("Should return error when feed is not found", func(t *testing.T) {
+		_, err := exploitability.Download(context.TODO(), server.Client(), exploitability.Config{
+			KEVURL: server.URL + "/missing.json",
+		})
+		assert.EqualError(t, err, "downloading known exploited vulnerabilities: unexpected status of "+server.URL+"/missing.json: 404 Not Found")
+	})
+}
@@ -0,0 +1,117 @@
+package exploitability
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap in the operator namespace
+	// which caches downloaded feeds.
+	ConfigMapName = "starboard-exploitability"
+	// FeedsKey is the key of the ConfigMap binary data which holds Feeds
+	// encoded as gzip compressed JSON.
+	FeedsKey = "feeds.json.gz"
+
+	// maxCheckInterval bounds the interval between checks whether cached
+	// feeds are stale, so that feeds are refreshed on time after a restart.
+	maxCheckInterval = time.Hour
+)
+
+// Updater periodically downloads feeds and caches them in the ConfigMapName
+// ConfigMap in the operator namespace, so that they're downloaded once per
+// refresh interval rather than by each operator replica or at each restart.
+type Updater struct {
+	logr.Logger
+	client.Client
+	Config     Config
+	Namespace  string
+	HTTPClient *http.Client
+	Clock      ext.Clock
+}
+
+// Start refreshes stale feeds until the given context is done. It implements
+// manager.Runnable.
+func (u *Updater) Start(ctx context.Context) error {
+	interval := u.Config.RefreshInterval
+	if interval > maxCheckInterval {
+		interval = maxCheckInterval
+	}
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		// Do not stop the manager if the refresh fails. Stale feeds are kept.
+		if err := u.Refresh(ctx); err != nil {
+			u.Logger.Error(err, "Unable to refresh exploitability feeds")
+		}
+	}, interval)
+	return nil
+}
+
+// Refresh downloads feeds unless cached feeds were downloaded from the same
+// URLs within the refresh interval.
+func (u *Updater) Refresh(ctx context.Context) error {
+	var cm corev1.ConfigMap
+	err := u.Client.Get(ctx, client.ObjectKey{Namespace: u.Namespace, Name: ConfigMapName}, &cm)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+	if exists && !u.stale(cm) {
+		return nil
+	}
+
+	feeds, err := Download(ctx, u.HTTPClient, u.Config)
+	if err != nil {
+		return err
+	}
+	feeds.UpdateTimestamp = metav1.NewTime(u.Clock.Now())
+	feeds.KEVURL = u.Config.KEVURL
+	feeds.EPSSURL = u.Config.EPSSURL
+	data, err := feeds.encode()
+	if err != nil {
+		return fmt.Errorf("encoding exploitability feeds: %w", err)
+	}
+	u.Logger.Info("Downloaded exploitability feeds",
+		"knownExploited", len(feeds.KnownExploited), "epssScores", len(feeds.EPSSScores))
+
+	if !exists {
+		cm = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ConfigMapName,
+				Namespace: u.Namespace,
+				Labels: map[string]string{
+					starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+				},
+			},
+			BinaryData: map[string][]byte{
+				FeedsKey: data,
+			},
+		}
+		return u.Client.Create(ctx, &cm)
+	}
+	cm.BinaryData = map[string][]byte{
+		FeedsKey: data,
+	}
+	return u.Client.Update(ctx, &cm)
+}
+
+func (u *Updater) stale(cm corev1.ConfigMap) bool {
+	feeds, err := decodeFeeds(cm.BinaryData[FeedsKey])
+	if err != nil {
+		return true
+	}
+	if feeds.KEVURL != u.Config.KEVURL || feeds.EPSSURL != u.Config.EPSSURL {
+		return true
+	}
+	return u.Clock.Now().Sub(feeds.UpdateTimestamp.Time) >= u.Config.RefreshInterval
+}
@@ -0,0 +1,99 @@
+package exploitability_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/exploitability"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newFeedsServer returns a server of feeds which counts downloads.
+func newFeedsServer(t *testing.T) (*httptest.Server, *int32) {
+	t.Helper()
+	var downloads int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&downloads, 1)
+		switch r.URL.Path {
+		case "/kev.json":
+			_, _ = w.Write([]byte(kevCatalog))
+		case "/epss.csv":
+			_, _ = w.Write([]byte(epssScores))
+		default:
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &downloads
+}
+
+func TestUpdater_Refresh(t *testing.T) {
+	now := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	server, downloads := newFeedsServer(t)
+	config := exploitability.Config{
+		KEVURL:          server.URL + "/kev.json",
+		EPSSURL:         server.URL + "/epss.csv",
+		RefreshInterval: 24 * time.Hour,
+		EPSSThreshold:   0.1,
+	}
+	kubeClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+	newUpdater := func(config exploitability.Config, now time.Time) *exploitability.Updater {
+		return &exploitability.Updater{
+			Logger:     logr.Discard(),
+			Client:     kubeClient,
+			Config:     config,
+			Namespace:  "starboard-system",
+			HTTPClient: server.Client(),
+			Clock:      ext.NewFixedClock(now),
+		}
+	}
+	key := client.ObjectKey{Namespace: "starboard-system", Name: exploitability.ConfigMapName}
+
+	require.NoError(t, newUpdater(config, now).Refresh(context.TODO()))
+	assert.Equal(t, int32(2), atomic.LoadInt32(downloads))
+	var cm corev1.ConfigMap
+	require.NoError(t, kubeClient.Get(context.TODO(), key, &cm))
+	assert.Equal(t, starboard.AppStarboard, cm.Labels[starboard.LabelK8SAppManagedBy])
+	assert.NotEmpty(t, cm.BinaryData[exploitability.FeedsKey])
+
+	t.Run("Should not download fresh feeds", func(t *testing.T) {
+		require.NoError(t, newUpdater(config, now.Add(time.Hour)).Refresh(context.TODO()))
+		assert.Equal(t, int32(2), atomic.LoadInt32(downloads))
+	})
+
+	t.Run("Should download stale feeds", func(t *testing.T) {
+		require.NoError(t, newUpdater(config, now.Add(25*time.Hour)).Refresh(context.TODO()))
+		assert.Equal(t, int32(4), atomic.LoadInt32(downloads))
+	})
+
+	t.Run("Should download feeds when URLs change", func(t *testing.T) {
+		kevOnly := config
+		kevOnly.EPSSURL = ""
+		require.NoError(t, newUpdater(kevOnly, now.Add(25*time.Hour)).Refresh(context.TODO()))
+		assert.Equal(t, int32(5), atomic.LoadInt32(downloads))
+	})
+
+	t.Run("Should keep cached feeds when download fails", func(t *testing.T) {
+		require.NoError(t, kubeClient.Get(context.TODO(), key, &cm))
+		cached := cm.BinaryData[exploitability.FeedsKey]
+
+		unavailable := config
+		unavailable.KEVURL = server.URL + "/unavailable.json"
+		err := newUpdater(unavailable, now.Add(50*time.Hour)).Refresh(context.TODO())
+		require.Error(t, err)
+
+		require.NoError(t, kubeClient.Get(context.TODO(), key, &cm))
+		assert.Equal(t, cached, cm.BinaryData[exploitability.FeedsKey])
+	})
+}
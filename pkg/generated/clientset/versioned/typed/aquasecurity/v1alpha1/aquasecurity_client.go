@@ -16,10 +16,15 @@ type AquasecurityV1alpha1Interface interface {
 	ClusterComplianceDetailReportsGetter
 	ClusterComplianceReportsGetter
 	ClusterConfigAuditReportsGetter
+	ClusterRiskReportsGetter
 	ClusterVulnerabilityReportsGetter
+	ComplianceReportsGetter
 	ConfigAuditReportsGetter
+	ImageInventoriesGetter
 	KubeHunterReportsGetter
+	PackageInventoriesGetter
 	VulnerabilityReportsGetter
+	WorkloadVulnerabilitySummariesGetter
 }
 
 // AquasecurityV1alpha1Client is used to interact with features provided by the aquasecurity.github.io group.
@@ -43,22 +48,42 @@ func (c *AquasecurityV1alpha1Client) ClusterConfigAuditReports() ClusterConfigAu
 	return newClusterConfigAuditReports(c)
 }
 
+func (c *AquasecurityV1alpha1Client) ClusterRiskReports() ClusterRiskReportInterface {
+	return newClusterRiskReports(c)
+}
+
 func (c *AquasecurityV1alpha1Client) ClusterVulnerabilityReports() ClusterVulnerabilityReportInterface {
 	return newClusterVulnerabilityReports(c)
 }
 
+func (c *AquasecurityV1alpha1Client) ComplianceReports(namespace string) ComplianceReportInterface {
+	return newComplianceReports(c, namespace)
+}
+
 func (c *AquasecurityV1alpha1Client) ConfigAuditReports(namespace string) ConfigAuditReportInterface {
 	return newConfigAuditReports(c, namespace)
 }
 
+func (c *AquasecurityV1alpha1Client) ImageInventories() ImageInventoryInterface {
+	return newImageInventories(c)
+}
+
 func (c *AquasecurityV1alpha1Client) KubeHunterReports() KubeHunterReportInterface {
 	return newKubeHunterReports(c)
 }
 
+func (c *AquasecurityV1alpha1Client) PackageInventories(namespace string) PackageInventoryInterface {
+	return newPackageInventories(c, namespace)
+}
+
 func (c *AquasecurityV1alpha1Client) VulnerabilityReports(namespace string) VulnerabilityReportInterface {
 	return newVulnerabilityReports(c, namespace)
 }
 
+func (c *AquasecurityV1alpha1Client) WorkloadVulnerabilitySummaries(namespace string) WorkloadVulnerabilitySummaryInterface {
+	return newWorkloadVulnerabilitySummaries(c, namespace)
+}
+
 // NewForConfig creates a new AquasecurityV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -28,22 +28,42 @@ func (c *FakeAquasecurityV1alpha1) ClusterConfigAuditReports() v1alpha1.ClusterC
 	return &FakeClusterConfigAuditReports{c}
 }
 
+func (c *FakeAquasecurityV1alpha1) ClusterRiskReports() v1alpha1.ClusterRiskReportInterface {
+	return &FakeClusterRiskReports{c}
+}
+
 func (c *FakeAquasecurityV1alpha1) ClusterVulnerabilityReports() v1alpha1.ClusterVulnerabilityReportInterface {
 	return &FakeClusterVulnerabilityReports{c}
 }
 
+func (c *FakeAquasecurityV1alpha1) ComplianceReports(namespace string) v1alpha1.ComplianceReportInterface {
+	return &FakeComplianceReports{c, namespace}
+}
+
 func (c *FakeAquasecurityV1alpha1) ConfigAuditReports(namespace string) v1alpha1.ConfigAuditReportInterface {
 	return &FakeConfigAuditReports{c, namespace}
 }
 
+func (c *FakeAquasecurityV1alpha1) ImageInventories() v1alpha1.ImageInventoryInterface {
+	return &FakeImageInventories{c}
+}
+
 func (c *FakeAquasecurityV1alpha1) KubeHunterReports() v1alpha1.KubeHunterReportInterface {
 	return &FakeKubeHunterReports{c}
 }
 
+func (c *FakeAquasecurityV1alpha1) PackageInventories(namespace string) v1alpha1.PackageInventoryInterface {
+	return &FakePackageInventories{c, namespace}
+}
+
 func (c *FakeAquasecurityV1alpha1) VulnerabilityReports(namespace string) v1alpha1.VulnerabilityReportInterface {
 	return &FakeVulnerabilityReports{c, namespace}
 }
 
+func (c *FakeAquasecurityV1alpha1) WorkloadVulnerabilitySummaries(namespace string) v1alpha1.WorkloadVulnerabilitySummaryInterface {
+	return &FakeWorkloadVulnerabilitySummaries{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeAquasecurityV1alpha1) RESTClient() rest.Interface {
@@ -0,0 +1,106 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterRiskReports implements ClusterRiskReportInterface
+type FakeClusterRiskReports struct {
+	Fake *FakeAquasecurityV1alpha1
+}
+
+var clusterriskreportsResource = schema.GroupVersionResource{Group: "aquasecurity.github.io", Version: "v1alpha1", Resource: "clusterriskreports"}
+
+var clusterriskreportsKind = schema.GroupVersionKind{Group: "aquasecurity.github.io", Version: "v1alpha1", Kind: "ClusterRiskReport"}
+
+// Get takes name of the clusterRiskReport, and returns the corresponding clusterRiskReport object, and an error if there is any.
+func (c *FakeClusterRiskReports) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ClusterRiskReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clusterriskreportsResource, name), &v1alpha1.ClusterRiskReport{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterRiskReport), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterRiskReports that match those selectors.
+func (c *FakeClusterRiskReports) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ClusterRiskReportList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clusterriskreportsResource, clusterriskreportsKind, opts), &v1alpha1.ClusterRiskReportList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ClusterRiskReportList{ListMeta: obj.(*v1alpha1.ClusterRiskReportList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ClusterRiskReportList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterRiskReports.
+func (c *FakeClusterRiskReports) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clusterriskreportsResource, opts))
+}
+
+// Create takes the representation of a clusterRiskReport and creates it.  Returns the server's representation of the clusterRiskReport, and an error, if there is any.
+func (c *FakeClusterRiskReports) Create(ctx context.Context, clusterRiskReport *v1alpha1.ClusterRiskReport, opts v1.CreateOptions) (result *v1alpha1.ClusterRiskReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clusterriskreportsResource, clusterRiskReport), &v1alpha1.ClusterRiskReport{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterRiskReport), err
+}
+
+// Update takes the representation of a clusterRiskReport and updates it. Returns the server's representation of the clusterRiskReport, and an error, if there is any.
+func (c *FakeClusterRiskReports) Update(ctx context.Context, clusterRiskReport *v1alpha1.ClusterRiskReport, opts v1.UpdateOptions) (result *v1alpha1.ClusterRiskReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clusterriskreportsResource, clusterRiskReport), &v1alpha1.ClusterRiskReport{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterRiskReport), err
+}
+
+// Delete takes name of the clusterRiskReport and deletes it. Returns an error if one occurs.
+func (c *FakeClusterRiskReports) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(clusterriskreportsResource, name, opts), &v1alpha1.ClusterRiskReport{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterRiskReports) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clusterriskreportsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ClusterRiskReportList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterRiskReport.
+func (c *FakeClusterRiskReports) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterRiskReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterriskreportsResource, name, pt, data, subresources...), &v1alpha1.ClusterRiskReport{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterRiskReport), err
+}
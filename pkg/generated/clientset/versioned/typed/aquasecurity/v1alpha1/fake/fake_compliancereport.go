@@ -0,0 +1,114 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeComplianceReports implements ComplianceReportInterface
+type FakeComplianceReports struct {
+	Fake *FakeAquasecurityV1alpha1
+	ns   string
+}
+
+var compliancereportsResource = schema.GroupVersionResource{Group: "aquasecurity.github.io", Version: "v1alpha1", Resource: "compliancereports"}
+
+var compliancereportsKind = schema.GroupVersionKind{Group: "aquasecurity.github.io", Version: "v1alpha1", Kind: "ComplianceReport"}
+
+// Get takes name of the complianceReport, and returns the corresponding complianceReport object, and an error if there is any.
+func (c *FakeComplianceReports) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ComplianceReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(compliancereportsResource, c.ns, name), &v1alpha1.ComplianceReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ComplianceReport), err
+}
+
+// List takes label and field selectors, and returns the list of ComplianceReports that match those selectors.
+func (c *FakeComplianceReports) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ComplianceReportList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(compliancereportsResource, compliancereportsKind, c.ns, opts), &v1alpha1.ComplianceReportList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ComplianceReportList{ListMeta: obj.(*v1alpha1.ComplianceReportList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ComplianceReportList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested complianceReports.
+func (c *FakeComplianceReports) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(compliancereportsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a complianceReport and creates it.  Returns the server's representation of the complianceReport, and an error, if there is any.
+func (c *FakeComplianceReports) Create(ctx context.Context, complianceReport *v1alpha1.ComplianceReport, opts v1.CreateOptions) (result *v1alpha1.ComplianceReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(compliancereportsResource, c.ns, complianceReport), &v1alpha1.ComplianceReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ComplianceReport), err
+}
+
+// Update takes the representation of a complianceReport and updates it. Returns the server's representation of the complianceReport, and an error, if there is any.
+func (c *FakeComplianceReports) Update(ctx context.Context, complianceReport *v1alpha1.ComplianceReport, opts v1.UpdateOptions) (result *v1alpha1.ComplianceReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(compliancereportsResource, c.ns, complianceReport), &v1alpha1.ComplianceReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ComplianceReport), err
+}
+
+// Delete takes name of the complianceReport and deletes it. Returns an error if one occurs.
+func (c *FakeComplianceReports) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(compliancereportsResource, c.ns, name, opts), &v1alpha1.ComplianceReport{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeComplianceReports) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(compliancereportsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ComplianceReportList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched complianceReport.
+func (c *FakeComplianceReports) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ComplianceReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(compliancereportsResource, c.ns, name, pt, data, subresources...), &v1alpha1.ComplianceReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ComplianceReport), err
+}
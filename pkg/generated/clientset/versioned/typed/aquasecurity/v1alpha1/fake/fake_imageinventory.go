@@ -0,0 +1,106 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeImageInventories implements ImageInventoryInterface
+type FakeImageInventories struct {
+	Fake *FakeAquasecurityV1alpha1
+}
+
+var imageinventoriesResource = schema.GroupVersionResource{Group: "aquasecurity.github.io", Version: "v1alpha1", Resource: "imageinventories"}
+
+var imageinventoriesKind = schema.GroupVersionKind{Group: "aquasecurity.github.io", Version: "v1alpha1", Kind: "ImageInventory"}
+
+// Get takes name of the imageInventory, and returns the corresponding imageInventory object, and an error if there is any.
+func (c *FakeImageInventories) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ImageInventory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(imageinventoriesResource, name), &v1alpha1.ImageInventory{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ImageInventory), err
+}
+
+// List takes label and field selectors, and returns the list of ImageInventories that match those selectors.
+func (c *FakeImageInventories) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ImageInventoryList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(imageinventoriesResource, imageinventoriesKind, opts), &v1alpha1.ImageInventoryList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ImageInventoryList{ListMeta: obj.(*v1alpha1.ImageInventoryList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ImageInventoryList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested imageInventories.
+func (c *FakeImageInventories) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(imageinventoriesResource, opts))
+}
+
+// Create takes the representation of a imageInventory and creates it.  Returns the server's representation of the imageInventory, and an error, if there is any.
+func (c *FakeImageInventories) Create(ctx context.Context, imageInventory *v1alpha1.ImageInventory, opts v1.CreateOptions) (result *v1alpha1.ImageInventory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(imageinventoriesResource, imageInventory), &v1alpha1.ImageInventory{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ImageInventory), err
+}
+
+// Update takes the representation of a imageInventory and updates it. Returns the server's representation of the imageInventory, and an error, if there is any.
+func (c *FakeImageInventories) Update(ctx context.Context, imageInventory *v1alpha1.ImageInventory, opts v1.UpdateOptions) (result *v1alpha1.ImageInventory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(imageinventoriesResource, imageInventory), &v1alpha1.ImageInventory{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ImageInventory), err
+}
+
+// Delete takes name of the imageInventory and deletes it. Returns an error if one occurs.
+func (c *FakeImageInventories) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(imageinventoriesResource, name, opts), &v1alpha1.ImageInventory{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeImageInventories) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(imageinventoriesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ImageInventoryList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched imageInventory.
+func (c *FakeImageInventories) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ImageInventory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(imageinventoriesResource, name, pt, data, subresources...), &v1alpha1.ImageInventory{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ImageInventory), err
+}
@@ -0,0 +1,114 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakePackageInventories implements PackageInventoryInterface
+type FakePackageInventories struct {
+	Fake *FakeAquasecurityV1alpha1
+	ns   string
+}
+
+var packageinventoriesResource = schema.GroupVersionResource{Group: "aquasecurity.github.io", Version: "v1alpha1", Resource: "packageinventories"}
+
+var packageinventoriesKind = schema.GroupVersionKind{Group: "aquasecurity.github.io", Version: "v1alpha1", Kind: "PackageInventory"}
+
+// Get takes name of the packageInventory, and returns the corresponding packageInventory object, and an error if there is any.
+func (c *FakePackageInventories) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.PackageInventory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(packageinventoriesResource, c.ns, name), &v1alpha1.PackageInventory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.PackageInventory), err
+}
+
+// List takes label and field selectors, and returns the list of PackageInventories that match those selectors.
+func (c *FakePackageInventories) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.PackageInventoryList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(packageinventoriesResource, packageinventoriesKind, c.ns, opts), &v1alpha1.PackageInventoryList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.PackageInventoryList{ListMeta: obj.(*v1alpha1.PackageInventoryList).ListMeta}
+	for _, item := range obj.(*v1alpha1.PackageInventoryList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested packageInventories.
+func (c *FakePackageInventories) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(packageinventoriesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a packageInventory and creates it.  Returns the server's representation of the packageInventory, and an error, if there is any.
+func (c *FakePackageInventories) Create(ctx context.Context, packageInventory *v1alpha1.PackageInventory, opts v1.CreateOptions) (result *v1alpha1.PackageInventory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(packageinventoriesResource, c.ns, packageInventory), &v1alpha1.PackageInventory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.PackageInventory), err
+}
+
+// Update takes the representation of a packageInventory and updates it. Returns the server's representation of the packageInventory, and an error, if there is any.
+func (c *FakePackageInventories) Update(ctx context.Context, packageInventory *v1alpha1.PackageInventory, opts v1.UpdateOptions) (result *v1alpha1.PackageInventory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(packageinventoriesResource, c.ns, packageInventory), &v1alpha1.PackageInventory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.PackageInventory), err
+}
+
+// Delete takes name of the packageInventory and deletes it. Returns an error if one occurs.
+func (c *FakePackageInventories) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(packageinventoriesResource, c.ns, name, opts), &v1alpha1.PackageInventory{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakePackageInventories) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(packageinventoriesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.PackageInventoryList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched packageInventory.
+func (c *FakePackageInventories) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.PackageInventory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(packageinventoriesResource, c.ns, name, pt, data, subresources...), &v1alpha1.PackageInventory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.PackageInventory), err
+}
@@ -0,0 +1,114 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeWorkloadVulnerabilitySummaries implements WorkloadVulnerabilitySummaryInterface
+type FakeWorkloadVulnerabilitySummaries struct {
+	Fake *FakeAquasecurityV1alpha1
+	ns   string
+}
+
+var workloadvulnerabilitysummariesResource = schema.GroupVersionResource{Group: "aquasecurity.github.io", Version: "v1alpha1", Resource: "workloadvulnerabilitysummaries"}
+
+var workloadvulnerabilitysummariesKind = schema.GroupVersionKind{Group: "aquasecurity.github.io", Version: "v1alpha1", Kind: "WorkloadVulnerabilitySummary"}
+
+// Get takes name of the workloadVulnerabilitySummary, and returns the corresponding workloadVulnerabilitySummary object, and an error if there is any.
+func (c *FakeWorkloadVulnerabilitySummaries) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.WorkloadVulnerabilitySummary, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(workloadvulnerabilitysummariesResource, c.ns, name), &v1alpha1.WorkloadVulnerabilitySummary{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WorkloadVulnerabilitySummary), err
+}
+
+// List takes label and field selectors, and returns the list of WorkloadVulnerabilitySummaries that match those selectors.
+func (c *FakeWorkloadVulnerabilitySummaries) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.WorkloadVulnerabilitySummaryList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(workloadvulnerabilitysummariesResource, workloadvulnerabilitysummariesKind, c.ns, opts), &v1alpha1.WorkloadVulnerabilitySummaryList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.WorkloadVulnerabilitySummaryList{ListMeta: obj.(*v1alpha1.WorkloadVulnerabilitySummaryList).ListMeta}
+	for _, item := range obj.(*v1alpha1.WorkloadVulnerabilitySummaryList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested workloadVulnerabilitySummaries.
+func (c *FakeWorkloadVulnerabilitySummaries) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(workloadvulnerabilitysummariesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a workloadVulnerabilitySummary and creates it.  Returns the server's representation of the workloadVulnerabilitySummary, and an error, if there is any.
+func (c *FakeWorkloadVulnerabilitySummaries) Create(ctx context.Context, workloadVulnerabilitySummary *v1alpha1.WorkloadVulnerabilitySummary, opts v1.CreateOptions) (result *v1alpha1.WorkloadVulnerabilitySummary, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(workloadvulnerabilitysummariesResource, c.ns, workloadVulnerabilitySummary), &v1alpha1.WorkloadVulnerabilitySummary{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WorkloadVulnerabilitySummary), err
+}
+
+// Update takes the representation of a workloadVulnerabilitySummary and updates it. Returns the server's representation of the workloadVulnerabilitySummary, and an error, if there is any.
+func (c *FakeWorkloadVulnerabilitySummaries) Update(ctx context.Context, workloadVulnerabilitySummary *v1alpha1.WorkloadVulnerabilitySummary, opts v1.UpdateOptions) (result *v1alpha1.WorkloadVulnerabilitySummary, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(workloadvulnerabilitysummariesResource, c.ns, workloadVulnerabilitySummary), &v1alpha1.WorkloadVulnerabilitySummary{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WorkloadVulnerabilitySummary), err
+}
+
+// Delete takes name of the workloadVulnerabilitySummary and deletes it. Returns an error if one occurs.
+func (c *FakeWorkloadVulnerabilitySummaries) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(workloadvulnerabilitysummariesResource, c.ns, name, opts), &v1alpha1.WorkloadVulnerabilitySummary{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeWorkloadVulnerabilitySummaries) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(workloadvulnerabilitysummariesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.WorkloadVulnerabilitySummaryList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched workloadVulnerabilitySummary.
+func (c *FakeWorkloadVulnerabilitySummaries) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.WorkloadVulnerabilitySummary, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(workloadvulnerabilitysummariesResource, c.ns, name, pt, data, subresources...), &v1alpha1.WorkloadVulnerabilitySummary{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WorkloadVulnerabilitySummary), err
+}
@@ -10,10 +10,20 @@ type ClusterComplianceReportExpansion interface{}
 
 type ClusterConfigAuditReportExpansion interface{}
 
+type ClusterRiskReportExpansion interface{}
+
 type ClusterVulnerabilityReportExpansion interface{}
 
+type ComplianceReportExpansion interface{}
+
 type ConfigAuditReportExpansion interface{}
 
+type ImageInventoryExpansion interface{}
+
 type KubeHunterReportExpansion interface{}
 
+type PackageInventoryExpansion interface{}
+
 type VulnerabilityReportExpansion interface{}
+
+type WorkloadVulnerabilitySummaryExpansion interface{}
@@ -0,0 +1,162 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	scheme "github.com/aquasecurity/starboard/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// PackageInventoriesGetter has a method to return a PackageInventoryInterface.
+// A group's client should implement this interface.
+type PackageInventoriesGetter interface {
+	PackageInventories(namespace string) PackageInventoryInterface
+}
+
+// PackageInventoryInterface has methods to work with PackageInventory resources.
+type PackageInventoryInterface interface {
+	Create(ctx context.Context, packageInventory *v1alpha1.PackageInventory, opts v1.CreateOptions) (*v1alpha1.PackageInventory, error)
+	Update(ctx context.Context, packageInventory *v1alpha1.PackageInventory, opts v1.UpdateOptions) (*v1alpha1.PackageInventory, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.PackageInventory, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.PackageInventoryList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.PackageInventory, err error)
+	PackageInventoryExpansion
+}
+
+// packageInventories implements PackageInventoryInterface
+type packageInventories struct {
+	client rest.Interface
+	ns     string
+}
+
+// newPackageInventories returns a PackageInventories
+func newPackageInventories(c *AquasecurityV1alpha1Client, namespace string) *packageInventories {
+	return &packageInventories{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the packageInventory, and returns the corresponding packageInventory object, and an error if there is any.
+func (c *packageInventories) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.PackageInventory, err error) {
+	result = &v1alpha1.PackageInventory{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("packageinventories").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of PackageInventories that match those selectors.
+func (c *packageInventories) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.PackageInventoryList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.PackageInventoryList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("packageinventories").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested packageInventories.
+func (c *packageInventories) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("packageinventories").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a packageInventory and creates it.  Returns the server's representation of the packageInventory, and an error, if there is any.
+func (c *packageInventories) Create(ctx context.Context, packageInventory *v1alpha1.PackageInventory, opts v1.CreateOptions) (result *v1alpha1.PackageInventory, err error) {
+	result = &v1alpha1.PackageInventory{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("packageinventories").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(packageInventory).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a packageInventory and updates it. Returns the server's representation of the packageInventory, and an error, if there is any.
+func (c *packageInventories) Update(ctx context.Context, packageInventory *v1alpha1.PackageInventory, opts v1.UpdateOptions) (result *v1alpha1.PackageInventory, err error) {
+	result = &v1alpha1.PackageInventory{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("packageinventories").
+		Name(packageInventory.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(packageInventory).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the packageInventory and deletes it. Returns an error if one occurs.
+func (c *packageInventories) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("packageinventories").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *packageInventories) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("packageinventories").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched packageInventory.
+func (c *packageInventories) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.PackageInventory, err error) {
+	result = &v1alpha1.PackageInventory{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("packageinventories").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
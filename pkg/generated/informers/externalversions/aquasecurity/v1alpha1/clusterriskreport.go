@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	aquasecurityv1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	versioned "github.com/aquasecurity/starboard/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/aquasecurity/starboard/pkg/generated/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/generated/listers/aquasecurity/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterRiskReportInformer provides access to a shared informer and lister for
+// ClusterRiskReports.
+type ClusterRiskReportInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.ClusterRiskReportLister
+}
+
+type clusterRiskReportInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewClusterRiskReportInformer constructs a new informer for ClusterRiskReport type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewClusterRiskReportInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredClusterRiskReportInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredClusterRiskReportInformer constructs a new informer for ClusterRiskReport type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredClusterRiskReportInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AquasecurityV1alpha1().ClusterRiskReports().List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AquasecurityV1alpha1().ClusterRiskReports().Watch(context.TODO(), options)
+			},
+		},
+		&aquasecurityv1alpha1.ClusterRiskReport{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *clusterRiskReportInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredClusterRiskReportInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *clusterRiskReportInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&aquasecurityv1alpha1.ClusterRiskReport{}, f.defaultInformer)
+}
+
+func (f *clusterRiskReportInformer) Lister() v1alpha1.ClusterRiskReportLister {
+	return v1alpha1.NewClusterRiskReportLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	aquasecurityv1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	versioned "github.com/aquasecurity/starboard/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/aquasecurity/starboard/pkg/generated/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/generated/listers/aquasecurity/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ImageInventoryInformer provides access to a shared informer and lister for
+// ImageInventories.
+type ImageInventoryInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.ImageInventoryLister
+}
+
+type imageInventoryInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewImageInventoryInformer constructs a new informer for ImageInventory type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewImageInventoryInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredImageInventoryInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredImageInventoryInformer constructs a new informer for ImageInventory type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredImageInventoryInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AquasecurityV1alpha1().ImageInventories().List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AquasecurityV1alpha1().ImageInventories().Watch(context.TODO(), options)
+			},
+		},
+		&aquasecurityv1alpha1.ImageInventory{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *imageInventoryInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredImageInventoryInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *imageInventoryInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&aquasecurityv1alpha1.ImageInventory{}, f.defaultInformer)
+}
+
+func (f *imageInventoryInformer) Lister() v1alpha1.ImageInventoryLister {
+	return v1alpha1.NewImageInventoryLister(f.Informer().GetIndexer())
+}
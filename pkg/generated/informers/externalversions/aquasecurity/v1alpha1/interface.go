@@ -16,14 +16,24 @@ type Interface interface {
 	ClusterComplianceReports() ClusterComplianceReportInformer
 	// ClusterConfigAuditReports returns a ClusterConfigAuditReportInformer.
 	ClusterConfigAuditReports() ClusterConfigAuditReportInformer
+	// ClusterRiskReports returns a ClusterRiskReportInformer.
+	ClusterRiskReports() ClusterRiskReportInformer
 	// ClusterVulnerabilityReports returns a ClusterVulnerabilityReportInformer.
 	ClusterVulnerabilityReports() ClusterVulnerabilityReportInformer
+	// ComplianceReports returns a ComplianceReportInformer.
+	ComplianceReports() ComplianceReportInformer
 	// ConfigAuditReports returns a ConfigAuditReportInformer.
 	ConfigAuditReports() ConfigAuditReportInformer
+	// ImageInventories returns a ImageInventoryInformer.
+	ImageInventories() ImageInventoryInformer
 	// KubeHunterReports returns a KubeHunterReportInformer.
 	KubeHunterReports() KubeHunterReportInformer
+	// PackageInventories returns a PackageInventoryInformer.
+	PackageInventories() PackageInventoryInformer
 	// VulnerabilityReports returns a VulnerabilityReportInformer.
 	VulnerabilityReports() VulnerabilityReportInformer
+	// WorkloadVulnerabilitySummaries returns a WorkloadVulnerabilitySummaryInformer.
+	WorkloadVulnerabilitySummaries() WorkloadVulnerabilitySummaryInformer
 }
 
 type version struct {
@@ -57,22 +67,47 @@ func (v *version) ClusterConfigAuditReports() ClusterConfigAuditReportInformer {
 	return &clusterConfigAuditReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterRiskReports returns a ClusterRiskReportInformer.
+func (v *version) ClusterRiskReports() ClusterRiskReportInformer {
+	return &clusterRiskReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterVulnerabilityReports returns a ClusterVulnerabilityReportInformer.
 func (v *version) ClusterVulnerabilityReports() ClusterVulnerabilityReportInformer {
 	return &clusterVulnerabilityReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ComplianceReports returns a ComplianceReportInformer.
+func (v *version) ComplianceReports() ComplianceReportInformer {
+	return &complianceReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ConfigAuditReports returns a ConfigAuditReportInformer.
 func (v *version) ConfigAuditReports() ConfigAuditReportInformer {
 	return &configAuditReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ImageInventories returns a ImageInventoryInformer.
+func (v *version) ImageInventories() ImageInventoryInformer {
+	return &imageInventoryInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // KubeHunterReports returns a KubeHunterReportInformer.
 func (v *version) KubeHunterReports() KubeHunterReportInformer {
 	return &kubeHunterReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// PackageInventories returns a PackageInventoryInformer.
+func (v *version) PackageInventories() PackageInventoryInformer {
+	return &packageInventoryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VulnerabilityReports returns a VulnerabilityReportInformer.
 func (v *version) VulnerabilityReports() VulnerabilityReportInformer {
 	return &vulnerabilityReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// WorkloadVulnerabilitySummaries returns a WorkloadVulnerabilitySummaryInformer.
+func (v *version) WorkloadVulnerabilitySummaries() WorkloadVulnerabilitySummaryInformer {
+	return &workloadVulnerabilitySummaryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -0,0 +1,74 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	aquasecurityv1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	versioned "github.com/aquasecurity/starboard/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/aquasecurity/starboard/pkg/generated/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/generated/listers/aquasecurity/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// PackageInventoryInformer provides access to a shared informer and lister for
+// PackageInventories.
+type PackageInventoryInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.PackageInventoryLister
+}
+
+type packageInventoryInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewPackageInventoryInformer constructs a new informer for PackageInventory type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewPackageInventoryInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredPackageInventoryInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredPackageInventoryInformer constructs a new informer for PackageInventory type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredPackageInventoryInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AquasecurityV1alpha1().PackageInventories(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AquasecurityV1alpha1().PackageInventories(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&aquasecurityv1alpha1.PackageInventory{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *packageInventoryInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredPackageInventoryInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *packageInventoryInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&aquasecurityv1alpha1.PackageInventory{}, f.defaultInformer)
+}
+
+func (f *packageInventoryInformer) Lister() v1alpha1.PackageInventoryLister {
+	return v1alpha1.NewPackageInventoryLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,74 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	aquasecurityv1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	versioned "github.com/aquasecurity/starboard/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/aquasecurity/starboard/pkg/generated/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/generated/listers/aquasecurity/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// WorkloadVulnerabilitySummaryInformer provides access to a shared informer and lister for
+// WorkloadVulnerabilitySummaries.
+type WorkloadVulnerabilitySummaryInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.WorkloadVulnerabilitySummaryLister
+}
+
+type workloadVulnerabilitySummaryInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewWorkloadVulnerabilitySummaryInformer constructs a new informer for WorkloadVulnerabilitySummary type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewWorkloadVulnerabilitySummaryInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredWorkloadVulnerabilitySummaryInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredWorkloadVulnerabilitySummaryInformer constructs a new informer for WorkloadVulnerabilitySummary type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredWorkloadVulnerabilitySummaryInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AquasecurityV1alpha1().WorkloadVulnerabilitySummaries(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AquasecurityV1alpha1().WorkloadVulnerabilitySummaries(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&aquasecurityv1alpha1.WorkloadVulnerabilitySummary{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *workloadVulnerabilitySummaryInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredWorkloadVulnerabilitySummaryInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *workloadVulnerabilitySummaryInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&aquasecurityv1alpha1.WorkloadVulnerabilitySummary{}, f.defaultInformer)
+}
+
+func (f *workloadVulnerabilitySummaryInformer) Lister() v1alpha1.WorkloadVulnerabilitySummaryLister {
+	return v1alpha1.NewWorkloadVulnerabilitySummaryLister(f.Informer().GetIndexer())
+}
@@ -45,14 +45,24 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().ClusterComplianceReports().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("clusterconfigauditreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().ClusterConfigAuditReports().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("clusterriskreports"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().ClusterRiskReports().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("clustervulnerabilityreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().ClusterVulnerabilityReports().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("compliancereports"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().ComplianceReports().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("configauditreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().ConfigAuditReports().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("imageinventories"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().ImageInventories().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("kubehunterreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().KubeHunterReports().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("packageinventories"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().PackageInventories().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("vulnerabilityreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().VulnerabilityReports().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("workloadvulnerabilitysummaries"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Aquasecurity().V1alpha1().WorkloadVulnerabilitySummaries().Informer()}, nil
 
 	}
 
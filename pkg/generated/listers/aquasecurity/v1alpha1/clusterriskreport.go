@@ -0,0 +1,52 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ClusterRiskReportLister helps list ClusterRiskReports.
+// All objects returned here must be treated as read-only.
+type ClusterRiskReportLister interface {
+	// List lists all ClusterRiskReports in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ClusterRiskReport, err error)
+	// Get retrieves the ClusterRiskReport from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.ClusterRiskReport, error)
+	ClusterRiskReportListerExpansion
+}
+
+// clusterRiskReportLister implements the ClusterRiskReportLister interface.
+type clusterRiskReportLister struct {
+	indexer cache.Indexer
+}
+
+// NewClusterRiskReportLister returns a new ClusterRiskReportLister.
+func NewClusterRiskReportLister(indexer cache.Indexer) ClusterRiskReportLister {
+	return &clusterRiskReportLister{indexer: indexer}
+}
+
+// List lists all ClusterRiskReports in the indexer.
+func (s *clusterRiskReportLister) List(selector labels.Selector) (ret []*v1alpha1.ClusterRiskReport, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ClusterRiskReport))
+	})
+	return ret, err
+}
+
+// Get retrieves the ClusterRiskReport from the index for a given name.
+func (s *clusterRiskReportLister) Get(name string) (*v1alpha1.ClusterRiskReport, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("clusterriskreport"), name)
+	}
+	return obj.(*v1alpha1.ClusterRiskReport), nil
+}
@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ComplianceReportLister helps list ComplianceReports.
+// All objects returned here must be treated as read-only.
+type ComplianceReportLister interface {
+	// List lists all ComplianceReports in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ComplianceReport, err error)
+	// ComplianceReports returns an object that can list and get ComplianceReports.
+	ComplianceReports(namespace string) ComplianceReportNamespaceLister
+	ComplianceReportListerExpansion
+}
+
+// complianceReportLister implements the ComplianceReportLister interface.
+type complianceReportLister struct {
+	indexer cache.Indexer
+}
+
+// NewComplianceReportLister returns a new ComplianceReportLister.
+func NewComplianceReportLister(indexer cache.Indexer) ComplianceReportLister {
+	return &complianceReportLister{indexer: indexer}
+}
+
+// List lists all ComplianceReports in the indexer.
+func (s *complianceReportLister) List(selector labels.Selector) (ret []*v1alpha1.ComplianceReport, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ComplianceReport))
+	})
+	return ret, err
+}
+
+// ComplianceReports returns an object that can list and get ComplianceReports.
+func (s *complianceReportLister) ComplianceReports(namespace string) ComplianceReportNamespaceLister {
+	return complianceReportNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ComplianceReportNamespaceLister helps list and get ComplianceReports.
+// All objects returned here must be treated as read-only.
+type ComplianceReportNamespaceLister interface {
+	// List lists all ComplianceReports in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ComplianceReport, err error)
+	// Get retrieves the ComplianceReport from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.ComplianceReport, error)
+	ComplianceReportNamespaceListerExpansion
+}
+
+// complianceReportNamespaceLister implements the ComplianceReportNamespaceLister
+// interface.
+type complianceReportNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all ComplianceReports in the indexer for a given namespace.
+func (s complianceReportNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.ComplianceReport, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ComplianceReport))
+	})
+	return ret, err
+}
+
+// Get retrieves the ComplianceReport from the indexer for a given namespace and name.
+func (s complianceReportNamespaceLister) Get(name string) (*v1alpha1.ComplianceReport, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("compliancereport"), name)
+	}
+	return obj.(*v1alpha1.ComplianceReport), nil
+}
@@ -26,10 +26,22 @@ type ClusterComplianceReportNamespaceListerExpansion interface{}
 // ClusterConfigAuditReportLister.
 type ClusterConfigAuditReportListerExpansion interface{}
 
+// ClusterRiskReportListerExpansion allows custom methods to be added to
+// ClusterRiskReportLister.
+type ClusterRiskReportListerExpansion interface{}
+
 // ClusterVulnerabilityReportListerExpansion allows custom methods to be added to
 // ClusterVulnerabilityReportLister.
 type ClusterVulnerabilityReportListerExpansion interface{}
 
+// ComplianceReportListerExpansion allows custom methods to be added to
+// ComplianceReportLister.
+type ComplianceReportListerExpansion interface{}
+
+// ComplianceReportNamespaceListerExpansion allows custom methods to be added to
+// ComplianceReportNamespaceLister.
+type ComplianceReportNamespaceListerExpansion interface{}
+
 // ConfigAuditReportListerExpansion allows custom methods to be added to
 // ConfigAuditReportLister.
 type ConfigAuditReportListerExpansion interface{}
@@ -38,10 +50,22 @@ type ConfigAuditReportListerExpansion interface{}
 // ConfigAuditReportNamespaceLister.
 type ConfigAuditReportNamespaceListerExpansion interface{}
 
+// ImageInventoryListerExpansion allows custom methods to be added to
+// ImageInventoryLister.
+type ImageInventoryListerExpansion interface{}
+
 // KubeHunterReportListerExpansion allows custom methods to be added to
 // KubeHunterReportLister.
 type KubeHunterReportListerExpansion interface{}
 
+// PackageInventoryListerExpansion allows custom methods to be added to
+// PackageInventoryLister.
+type PackageInventoryListerExpansion interface{}
+
+// PackageInventoryNamespaceListerExpansion allows custom methods to be added to
+// PackageInventoryNamespaceLister.
+type PackageInventoryNamespaceListerExpansion interface{}
+
 // VulnerabilityReportListerExpansion allows custom methods to be added to
 // VulnerabilityReportLister.
 type VulnerabilityReportListerExpansion interface{}
@@ -49,3 +73,11 @@ type VulnerabilityReportListerExpansion interface{}
 // VulnerabilityReportNamespaceListerExpansion allows custom methods to be added to
 // VulnerabilityReportNamespaceLister.
 type VulnerabilityReportNamespaceListerExpansion interface{}
+
+// WorkloadVulnerabilitySummaryListerExpansion allows custom methods to be added to
+// WorkloadVulnerabilitySummaryLister.
+type WorkloadVulnerabilitySummaryListerExpansion interface{}
+
+// WorkloadVulnerabilitySummaryNamespaceListerExpansion allows custom methods to be added to
+// WorkloadVulnerabilitySummaryNamespaceLister.
+type WorkloadVulnerabilitySummaryNamespaceListerExpansion interface{}
@@ -0,0 +1,52 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ImageInventoryLister helps list ImageInventories.
+// All objects returned here must be treated as read-only.
+type ImageInventoryLister interface {
+	// List lists all ImageInventories in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ImageInventory, err error)
+	// Get retrieves the ImageInventory from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.ImageInventory, error)
+	ImageInventoryListerExpansion
+}
+
+// imageInventoryLister implements the ImageInventoryLister interface.
+type imageInventoryLister struct {
+	indexer cache.Indexer
+}
+
+// NewImageInventoryLister returns a new ImageInventoryLister.
+func NewImageInventoryLister(indexer cache.Indexer) ImageInventoryLister {
+	return &imageInventoryLister{indexer: indexer}
+}
+
+// List lists all ImageInventories in the indexer.
+func (s *imageInventoryLister) List(selector labels.Selector) (ret []*v1alpha1.ImageInventory, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ImageInventory))
+	})
+	return ret, err
+}
+
+// Get retrieves the ImageInventory from the index for a given name.
+func (s *imageInventoryLister) Get(name string) (*v1alpha1.ImageInventory, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("imageinventory"), name)
+	}
+	return obj.(*v1alpha1.ImageInventory), nil
+}
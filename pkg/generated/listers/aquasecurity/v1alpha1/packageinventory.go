@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// PackageInventoryLister helps list PackageInventories.
+// All objects returned here must be treated as read-only.
+type PackageInventoryLister interface {
+	// List lists all PackageInventories in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.PackageInventory, err error)
+	// PackageInventories returns an object that can list and get PackageInventories.
+	PackageInventories(namespace string) PackageInventoryNamespaceLister
+	PackageInventoryListerExpansion
+}
+
+// packageInventoryLister implements the PackageInventoryLister interface.
+type packageInventoryLister struct {
+	indexer cache.Indexer
+}
+
+// NewPackageInventoryLister returns a new PackageInventoryLister.
+func NewPackageInventoryLister(indexer cache.Indexer) PackageInventoryLister {
+	return &packageInventoryLister{indexer: indexer}
+}
+
+// List lists all PackageInventories in the indexer.
+func (s *packageInventoryLister) List(selector labels.Selector) (ret []*v1alpha1.PackageInventory, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.PackageInventory))
+	})
+	return ret, err
+}
+
+// PackageInventories returns an object that can list and get PackageInventories.
+func (s *packageInventoryLister) PackageInventories(namespace string) PackageInventoryNamespaceLister {
+	return packageInventoryNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// PackageInventoryNamespaceLister helps list and get PackageInventories.
+// All objects returned here must be treated as read-only.
+type PackageInventoryNamespaceLister interface {
+	// List lists all PackageInventories in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.PackageInventory, err error)
+	// Get retrieves the PackageInventory from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.PackageInventory, error)
+	PackageInventoryNamespaceListerExpansion
+}
+
+// packageInventoryNamespaceLister implements the PackageInventoryNamespaceLister
+// interface.
+type packageInventoryNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all PackageInventories in the indexer for a given namespace.
+func (s packageInventoryNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.PackageInventory, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.PackageInventory))
+	})
+	return ret, err
+}
+
+// Get retrieves the PackageInventory from the indexer for a given namespace and name.
+func (s packageInventoryNamespaceLister) Get(name string) (*v1alpha1.PackageInventory, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("packageinventory"), name)
+	}
+	return obj.(*v1alpha1.PackageInventory), nil
+}
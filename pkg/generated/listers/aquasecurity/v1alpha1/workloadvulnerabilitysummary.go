@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// WorkloadVulnerabilitySummaryLister helps list WorkloadVulnerabilitySummaries.
+// All objects returned here must be treated as read-only.
+type WorkloadVulnerabilitySummaryLister interface {
+	// List lists all WorkloadVulnerabilitySummaries in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.WorkloadVulnerabilitySummary, err error)
+	// WorkloadVulnerabilitySummaries returns an object that can list and get WorkloadVulnerabilitySummaries.
+	WorkloadVulnerabilitySummaries(namespace string) WorkloadVulnerabilitySummaryNamespaceLister
+	WorkloadVulnerabilitySummaryListerExpansion
+}
+
+// workloadVulnerabilitySummaryLister implements the WorkloadVulnerabilitySummaryLister interface.
+type workloadVulnerabilitySummaryLister struct {
+	indexer cache.Indexer
+}
+
+// NewWorkloadVulnerabilitySummaryLister returns a new WorkloadVulnerabilitySummaryLister.
+func NewWorkloadVulnerabilitySummaryLister(indexer cache.Indexer) WorkloadVulnerabilitySummaryLister {
+	return &workloadVulnerabilitySummaryLister{indexer: indexer}
+}
+
+// List lists all WorkloadVulnerabilitySummaries in the indexer.
+func (s *workloadVulnerabilitySummaryLister) List(selector labels.Selector) (ret []*v1alpha1.WorkloadVulnerabilitySummary, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.WorkloadVulnerabilitySummary))
+	})
+	return ret, err
+}
+
+// WorkloadVulnerabilitySummaries returns an object that can list and get WorkloadVulnerabilitySummaries.
+func (s *workloadVulnerabilitySummaryLister) WorkloadVulnerabilitySummaries(namespace string) WorkloadVulnerabilitySummaryNamespaceLister {
+	return workloadVulnerabilitySummaryNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// WorkloadVulnerabilitySummaryNamespaceLister helps list and get WorkloadVulnerabilitySummaries.
+// All objects returned here must be treated as read-only.
+type WorkloadVulnerabilitySummaryNamespaceLister interface {
+	// List lists all WorkloadVulnerabilitySummaries in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.WorkloadVulnerabilitySummary, err error)
+	// Get retrieves the WorkloadVulnerabilitySummary from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.WorkloadVulnerabilitySummary, error)
+	WorkloadVulnerabilitySummaryNamespaceListerExpansion
+}
+
+// workloadVulnerabilitySummaryNamespaceLister implements the WorkloadVulnerabilitySummaryNamespaceLister
+// interface.
+type workloadVulnerabilitySummaryNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all WorkloadVulnerabilitySummaries in the indexer for a given namespace.
+func (s workloadVulnerabilitySummaryNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.WorkloadVulnerabilitySummary, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.WorkloadVulnerabilitySummary))
+	})
+	return ret, err
+}
+
+// Get retrieves the WorkloadVulnerabilitySummary from the indexer for a given namespace and name.
+func (s workloadVulnerabilitySummaryNamespaceLister) Get(name string) (*v1alpha1.WorkloadVulnerabilitySummary, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("workloadvulnerabilitysummary"), name)
+	}
+	return obj.(*v1alpha1.WorkloadVulnerabilitySummary), nil
+}
@@ -0,0 +1,199 @@
+package imageinventory
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// MaxWorkloadsPerInventory is the maximum number of workload containers
+// listed in a single v1alpha1.ImageInventory. Registries with more workload
+// containers are split into multiple inventories so that none of them exceeds
+// the size limit of Kubernetes objects.
+const MaxWorkloadsPerInventory = 5000
+
+// imageRef identifies a container image by digest.
+type imageRef struct {
+	registry   string
+	repository string
+	digest     string
+}
+
+// FromPods returns image inventories of images run by containers of the given
+// pods. Images are grouped by registry, and each registry is split into chunks
+// of at most maxWorkloads workload containers. Pods that are not running or
+// pending, and containers which image digest is not known yet, are skipped.
+//
+// The returned inventories are sorted by name and their content is sorted, so
+// that the same set of pods always results in the same inventories.
+func FromPods(pods []corev1.Pod, maxWorkloads int) []v1alpha1.ImageInventory {
+	workloadsByImage := make(map[imageRef]map[v1alpha1.ImageWorkload]bool)
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		kind, workloadName := workloadOf(pod)
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			ref, ok := parseImageID(status.ImageID, status.Image)
+			if !ok {
+				continue
+			}
+			if _, ok := workloadsByImage[ref]; !ok {
+				workloadsByImage[ref] = make(map[v1alpha1.ImageWorkload]bool)
+			}
+			workloadsByImage[ref][v1alpha1.ImageWorkload{
+				Namespace: pod.Namespace,
+				Kind:      kind,
+				Name:      workloadName,
+				Container: status.Name,
+			}] = true
+		}
+	}
+
+	imagesByRegistry := make(map[string][]imageRef)
+	for ref := range workloadsByImage {
+		imagesByRegistry[ref.registry] = append(imagesByRegistry[ref.registry], ref)
+	}
+
+	var inventories []v1alpha1.ImageInventory
+	for registry, refs := range imagesByRegistry {
+		sort.Slice(refs, func(i, j int) bool {
+			if refs[i].repository != refs[j].repository {
+				return refs[i].repository < refs[j].repository
+			}
+			return refs[i].digest < refs[j].digest
+		})
+		var chunks [][]v1alpha1.InventoryImage
+		var chunk []v1alpha1.InventoryImage
+		var chunkSize int
+		for _, ref := range refs {
+			workloads := sortedWorkloads(workloadsByImage[ref])
+			// Workloads of an image that doesn't fit into a single chunk are
+			// split across chunks, and merged back by Reader.
+			for len(workloads) > 0 {
+				if chunkSize == maxWorkloads {
+					chunks = append(chunks, chunk)
+					chunk, chunkSize = nil, 0
+				}
+				n := maxWorkloads - chunkSize
+				if n > len(workloads) {
+					n = len(workloads)
+				}
+				chunk = append(chunk, v1alpha1.InventoryImage{
+					Repository: ref.repository,
+					Digest:     ref.digest,
+					Workloads:  workloads[:n],
+				})
+				chunkSize += n
+				workloads = workloads[n:]
+			}
+		}
+		chunks = append(chunks, chunk)
+
+		for i, images := range chunks {
+			inventories = append(inventories, v1alpha1.ImageInventory{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: InventoryName(registry, i),
+					Labels: map[string]string{
+						starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+					},
+				},
+				Report: v1alpha1.ImageInventoryData{
+					Registry: v1alpha1.Registry{Server: registry},
+					Images:   images,
+				},
+			})
+		}
+	}
+	sort.Slice(inventories, func(i, j int) bool {
+		return inventories[i].Name < inventories[j].Name
+	})
+	return inventories
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// InventoryName returns the name of the i-th v1alpha1.ImageInventory of the
+// specified registry, e.g. index.docker.io-0.
+func InventoryName(registry string, i int) string {
+	prefix := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(registry), "-"), ".-")
+	if prefix == "" || len(validation.IsDNS1123Subdomain(prefix)) > 0 || len(prefix) > 200 {
+		prefix = kube.ComputeHash(registry)
+	}
+	return fmt.Sprintf("%s-%d", prefix, i)
+}
+
+// workloadOf returns the kind and name of the controller of the given pod, or
+// the pod itself if it's not controlled.
+func workloadOf(pod corev1.Pod) (string, string) {
+	if controller := metav1.GetControllerOf(&pod); controller != nil {
+		return controller.Kind, controller.Name
+	}
+	return string(kube.KindPod), pod.Name
+}
+
+// parseImageID parses the image ID reported in a container status, e.g.
+// docker-pullable://nginx@sha256:..., falling back to the image reference for
+// the registry and repository if the image ID is a bare digest.
+func parseImageID(imageID, image string) (imageRef, bool) {
+	for _, prefix := range []string{"docker-pullable://", "docker://"} {
+		imageID = strings.TrimPrefix(imageID, prefix)
+	}
+	if imageID == "" {
+		return imageRef{}, false
+	}
+	if strings.Contains(imageID, "@") {
+		digest, err := name.NewDigest(imageID)
+		if err != nil {
+			return imageRef{}, false
+		}
+		return imageRef{
+			registry:   digest.Context().RegistryStr(),
+			repository: digest.Context().RepositoryStr(),
+			digest:     digest.DigestStr(),
+		}, true
+	}
+	if !strings.HasPrefix(imageID, "sha256:") {
+		return imageRef{}, false
+	}
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return imageRef{}, false
+	}
+	return imageRef{
+		registry:   ref.Context().RegistryStr(),
+		repository: ref.Context().RepositoryStr(),
+		digest:     imageID,
+	}, true
+}
+
+func sortedWorkloads(set map[v1alpha1.ImageWorkload]bool) []v1alpha1.ImageWorkload {
+	workloads := make([]v1alpha1.ImageWorkload, 0, len(set))
+	for workload := range set {
+		workloads = append(workloads, workload)
+	}
+	sort.Slice(workloads, func(i, j int) bool {
+		a, b := workloads[i], workloads[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Container < b.Container
+	})
+	return workloads
+}
@@ -0,0 +1,180 @@
+package imageinventory_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/imageinventory"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	nginxDigest = "sha256:4ed64c2e0857ad21c38b98345ebb5edb01791a0a10b0e9e3d9ddde185cdbd31a"
+	redisDigest = "sha256:e96c03a6dda7d0f28e2de632048a3d34bb1636d0858b65ef9a554441c70f6633"
+)
+
+func newPod(namespace, name string, owner *metav1.OwnerReference, statuses ...corev1.ContainerStatus) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			ContainerStatuses: statuses,
+		},
+	}
+	if owner != nil {
+		pod.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+	return pod
+}
+
+func replicaSet(name string) *metav1.OwnerReference {
+	return &metav1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: name, Controller: pointer.BoolPtr(true)}
+}
+
+func TestFromPods(t *testing.T) {
+	labels := map[string]string{starboard.LabelK8SAppManagedBy: starboard.AppStarboard}
+
+	t.Run("Should list images by digest with workloads that run them", func(t *testing.T) {
+		pods := []corev1.Pod{
+			newPod("default", "nginx-6d4cf56db6-hkpgx", replicaSet("nginx-6d4cf56db6"),
+				corev1.ContainerStatus{Name: "nginx", Image: "nginx:1.16", ImageID: "docker-pullable://nginx@" + nginxDigest}),
+			newPod("default", "nginx-6d4cf56db6-vzj7l", replicaSet("nginx-6d4cf56db6"),
+				corev1.ContainerStatus{Name: "nginx", Image: "nginx:1.16", ImageID: "docker-pullable://nginx@" + nginxDigest}),
+			newPod("staging", "redis", nil,
+				corev1.ContainerStatus{Name: "redis", Image: "redis:6", ImageID: "docker.io/library/redis@" + redisDigest},
+				corev1.ContainerStatus{Name: "sidecar", Image: "nginx:1.16", ImageID: nginxDigest}),
+			newPod("staging", "pending", nil,
+				corev1.ContainerStatus{Name: "app", Image: "nginx:1.16"}),
+			func() corev1.Pod {
+				pod := newPod("staging", "completed", nil,
+					corev1.ContainerStatus{Name: "app", Image: "nginx:1.16", ImageID: "docker-pullable://nginx@" + nginxDigest})
+				pod.Status.Phase = corev1.PodSucceeded
+				return pod
+			}(),
+			newPod("staging", "local", nil,
+				corev1.ContainerStatus{Name: "app", Image: "localhost:5000/app:dev", ImageID: "localhost:5000/app@" + redisDigest}),
+		}
+
+		inventories := imageinventory.FromPods(pods, imageinventory.MaxWorkloadsPerInventory)
+		assert.Equal(t, []v1alpha1.ImageInventory{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "index.docker.io-0", Labels: labels},
+				Report: v1alpha1.ImageInventoryData{
+					Registry: v1alpha1.Registry{Server: "index.docker.io"},
+					Images: []v1alpha1.InventoryImage{
+						{
+							Repository: "library/nginx",
+							Digest:     nginxDigest,
+							Workloads: []v1alpha1.ImageWorkload{
+								{Namespace: "default", Kind: "ReplicaSet", Name: "nginx-6d4cf56db6", Container: "nginx"},
+								{Namespace: "staging", Kind: "Pod", Name: "redis", Container: "sidecar"},
+							},
+						},
+						{
+							Repository: "library/redis",
+							Digest:     redisDigest,
+							Workloads: []v1alpha1.ImageWorkload{
+								{Namespace: "staging", Kind: "Pod", Name: "redis", Container: "redis"},
+							},
+						},
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "localhost-5000-0", Labels: labels},
+				Report: v1alpha1.ImageInventoryData{
+					Registry: v1alpha1.Registry{Server: "localhost:5000"},
+					Images: []v1alpha1.InventoryImage{
+						{
+							Repository: "app",
+							Digest:     redisDigest,
+							Workloads: []v1alpha1.ImageWorkload{
+								{Namespace: "staging", Kind: "Pod", Name: "local", Container: "app"},
+							},
+						},
+					},
+				},
+			},
+		}, inventories)
+	})
+
+	t.Run("Should split registry into chunks", func(t *testing.T) {
+		var pods []corev1.Pod
+		for i := 0; i < 5; i++ {
+			pods = append(pods, newPod("default", fmt.Sprintf("nginx-%d", i), nil,
+				corev1.ContainerStatus{Name: "nginx", Image: "nginx:1.16", ImageID: "docker-pullable://nginx@" + nginxDigest}))
+		}
+		pods = append(pods, newPod("default", "redis", nil,
+			corev1.ContainerStatus{Name: "redis", Image: "redis:6", ImageID: "docker-pullable://redis@" + redisDigest}))
+
+		inventories := imageinventory.FromPods(pods, 2)
+		require.Len(t, inventories, 3)
+		var names []string
+		var workloads int
+		for _, inventory := range inventories {
+			names = append(names, inventory.Name)
+			var size int
+			for _, image := range inventory.Report.Images {
+				size += len(image.Workloads)
+			}
+			assert.LessOrEqual(t, size, 2)
+			workloads += size
+		}
+		assert.Equal(t, []string{"index.docker.io-0", "index.docker.io-1", "index.docker.io-2"}, names)
+		assert.Equal(t, 6, workloads)
+	})
+
+	t.Run("Should build the same inventories at scale regardless of pods order", func(t *testing.T) {
+		pods := newPods(5000)
+
+		inventories := imageinventory.FromPods(pods, 1000)
+		rand.New(rand.NewSource(1)).Shuffle(len(pods), func(i, j int) { pods[i], pods[j] = pods[j], pods[i] })
+		assert.Equal(t, inventories, imageinventory.FromPods(pods, 1000))
+
+		workloadsByDigest := make(map[string]int)
+		for _, inventory := range inventories {
+			var size int
+			for _, image := range inventory.Report.Images {
+				size += len(image.Workloads)
+				workloadsByDigest[image.Digest] += len(image.Workloads)
+			}
+			assert.LessOrEqual(t, size, 1000)
+		}
+		// Each ReplicaSet runs 10 pods, which are listed once.
+		var workloads int
+		for _, count := range workloadsByDigest {
+			workloads += count
+		}
+		assert.Equal(t, 500, workloads)
+		assert.Len(t, workloadsByDigest, 200)
+	})
+}
+
+func TestInventoryName(t *testing.T) {
+	assert.Equal(t, "index.docker.io-0", imageinventory.InventoryName("index.docker.io", 0))
+	assert.Equal(t, "localhost-5000-1", imageinventory.InventoryName("localhost:5000", 1))
+	assert.Equal(t, "my.registry.example.com-2", imageinventory.InventoryName("My.Registry.Example.com", 2))
+}
+
+// newPods returns the specified number of pods that belong to ReplicaSets of
+// 10 pods each, and run 200 distinct images hosted in 3 registries.
+func newPods(count int) []corev1.Pod {
+	registries := []string{"index.docker.io", "quay.io", "gcr.io"}
+	pods := make([]corev1.Pod, 0, count)
+	for i := 0; i < count; i++ {
+		rs := i / 10
+		image := rs % 200
+		ref := fmt.Sprintf("%s/team/app-%d", registries[image%len(registries)], image)
+		digest := fmt.Sprintf("sha256:%064x", image)
+		pods = append(pods, newPod(fmt.Sprintf("ns-%d", rs%20), fmt.Sprintf("app-%d-%d", rs, i), replicaSet(fmt.Sprintf("app-%d", rs)),
+			corev1.ContainerStatus{Name: "app", Image: ref + ":latest", ImageID: "docker-pullable://" + ref + "@" + digest}))
+	}
+	return pods
+}
@@ -0,0 +1,3 @@
+// Package imageinventory provides primitives for working with the inventory
+// of container images running in the cluster.
+package imageinventory
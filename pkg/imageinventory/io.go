@@ -0,0 +1,121 @@
+package imageinventory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Writer is the interface that wraps the basic Write method.
+//
+// Write replaces all v1alpha1.ImageInventory instances with the given ones.
+// Only inventories which content has changed are updated, and inventories
+// which are not given are deleted. It returns the number of created, updated,
+// and deleted inventories.
+type Writer interface {
+	Write(ctx context.Context, inventories []v1alpha1.ImageInventory, now metav1.Time) (int, error)
+}
+
+// Reader is the interface that wraps methods for finding images in
+// v1alpha1.ImageInventory objects.
+//
+// FindByDigest returns workload containers running the image with the given
+// digest, or an empty slice if the image is not found.
+type Reader interface {
+	FindByDigest(ctx context.Context, digest string) ([]v1alpha1.ImageWorkload, error)
+}
+
+type ReadWriter interface {
+	Reader
+	Writer
+}
+
+type readWriter struct {
+	client client.Client
+}
+
+// NewReadWriter constructs a new ReadWriter which is using the client package
+// provided by the controller-runtime libraries for interacting with the
+// Kubernetes API server.
+func NewReadWriter(client client.Client) ReadWriter {
+	return &readWriter{
+		client: client,
+	}
+}
+
+func (r *readWriter) Write(ctx context.Context, inventories []v1alpha1.ImageInventory, now metav1.Time) (int, error) {
+	existing, err := r.list(ctx)
+	if err != nil {
+		return 0, err
+	}
+	existingByName := make(map[string]v1alpha1.ImageInventory, len(existing))
+	for _, inventory := range existing {
+		existingByName[inventory.Name] = inventory
+	}
+
+	var writes int
+	for _, inventory := range inventories {
+		current, found := existingByName[inventory.Name]
+		delete(existingByName, inventory.Name)
+
+		if found && current.Report.Registry == inventory.Report.Registry &&
+			equality.Semantic.DeepEqual(current.Report.Images, inventory.Report.Images) {
+			continue
+		}
+		inventory.Report.UpdateTimestamp = now
+		if found {
+			copied := current.DeepCopy()
+			copied.Labels = inventory.Labels
+			copied.Report = inventory.Report
+			err = r.client.Update(ctx, copied)
+		} else {
+			err = r.client.Create(ctx, &inventory)
+		}
+		if err != nil {
+			return writes, fmt.Errorf("writing image inventory %q: %w", inventory.Name, err)
+		}
+		writes++
+	}
+
+	for _, stale := range existingByName {
+		err = r.client.Delete(ctx, stale.DeepCopy())
+		if err != nil && !errors.IsNotFound(err) {
+			return writes, fmt.Errorf("deleting image inventory %q: %w", stale.Name, err)
+		}
+		writes++
+	}
+	return writes, nil
+}
+
+func (r *readWriter) FindByDigest(ctx context.Context, digest string) ([]v1alpha1.ImageWorkload, error) {
+	inventories, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workloads := make([]v1alpha1.ImageWorkload, 0)
+	for _, inventory := range inventories {
+		for _, image := range inventory.Report.Images {
+			if image.Digest == digest {
+				workloads = append(workloads, image.Workloads...)
+			}
+		}
+	}
+	return workloads, nil
+}
+
+func (r *readWriter) list(ctx context.Context) ([]v1alpha1.ImageInventory, error) {
+	var list v1alpha1.ImageInventoryList
+	err := r.client.List(ctx, &list, client.MatchingLabels{
+		starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing image inventories: %w", err)
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,72 @@
+package imageinventory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/imageinventory"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReadWriter(t *testing.T) {
+	now := metav1.NewTime(time.Date(2022, 4, 20, 10, 14, 38, 0, time.UTC))
+
+	t.Run("Should write only changed inventories and delete stale ones", func(t *testing.T) {
+		stale := &v1alpha1.ImageInventory{ObjectMeta: metav1.ObjectMeta{
+			Name:   "ghcr.io-0",
+			Labels: map[string]string{starboard.LabelK8SAppManagedBy: starboard.AppStarboard},
+		}}
+		client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(stale).Build()
+		readWriter := imageinventory.NewReadWriter(client)
+
+		inventories := imageinventory.FromPods(newPods(2000), 300)
+		writes, err := readWriter.Write(context.TODO(), inventories, now)
+		require.NoError(t, err)
+		assert.Equal(t, len(inventories)+1, writes)
+
+		var list v1alpha1.ImageInventoryList
+		require.NoError(t, client.List(context.TODO(), &list))
+		assert.Len(t, list.Items, len(inventories))
+		resourceVersions := make(map[string]string)
+		for _, item := range list.Items {
+			resourceVersions[item.Name] = item.ResourceVersion
+			assert.Equal(t, now.Unix(), item.Report.UpdateTimestamp.Unix())
+		}
+
+		writes, err = readWriter.Write(context.TODO(), imageinventory.FromPods(newPods(2000), 300), metav1.NewTime(now.Add(time.Minute)))
+		require.NoError(t, err)
+		assert.Equal(t, 0, writes)
+
+		require.NoError(t, client.List(context.TODO(), &list))
+		for _, item := range list.Items {
+			assert.Equal(t, resourceVersions[item.Name], item.ResourceVersion)
+		}
+	})
+
+	t.Run("Should find workloads by digest across chunks", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+		readWriter := imageinventory.NewReadWriter(client)
+
+		_, err := readWriter.Write(context.TODO(), imageinventory.FromPods(newPods(5000), 7), now)
+		require.NoError(t, err)
+
+		workloads, err := readWriter.FindByDigest(context.TODO(), "sha256:0000000000000000000000000000000000000000000000000000000000000000")
+		require.NoError(t, err)
+		// Image 0 is run by ReplicaSets 0, 200, and 400.
+		assert.Equal(t, []v1alpha1.ImageWorkload{
+			{Namespace: "ns-0", Kind: "ReplicaSet", Name: "app-0", Container: "app"},
+			{Namespace: "ns-0", Kind: "ReplicaSet", Name: "app-200", Container: "app"},
+			{Namespace: "ns-0", Kind: "ReplicaSet", Name: "app-400", Container: "app"},
+		}, workloads)
+
+		workloads, err = readWriter.FindByDigest(context.TODO(), redisDigest)
+		require.NoError(t, err)
+		assert.Empty(t, workloads)
+	})
+}
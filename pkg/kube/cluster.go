@@ -0,0 +1,123 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ClusterMetadataReader reads metadata of the Kubernetes cluster, which is
+// stamped into security reports.
+type ClusterMetadataReader interface {
+	Read(ctx context.Context) (v1alpha1.ClusterMetadata, error)
+}
+
+type clusterMetadataReader struct {
+	clientset   kubernetes.Interface
+	clusterName string
+	ttl         time.Duration
+	clock       ext.Clock
+
+	mu        sync.Mutex
+	metadata  v1alpha1.ClusterMetadata
+	expiresAt time.Time
+}
+
+// NewClusterMetadataReader constructs a ClusterMetadataReader which gets
+// the server version and API groups with the discovery client, counts nodes,
+// and detects the provider of the cluster. Metadata is
+// cached for the specified TTL, so that generating reports does not call the
+// API server every time. Zero TTL disables caching.
+func NewClusterMetadataReader(clientset kubernetes.Interface, clusterName string, ttl time.Duration, clock ext.Clock) ClusterMetadataReader {
+	return &clusterMetadataReader{
+		clientset:   clientset,
+		clusterName: clusterName,
+		ttl:         ttl,
+		clock:       clock,
+	}
+}
+
+func (r *clusterMetadataReader) Read(ctx context.Context) (v1alpha1.ClusterMetadata, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.clock.Now()
+	if now.Before(r.expiresAt) {
+		return r.metadata, nil
+	}
+
+	version, err := r.clientset.Discovery().ServerVersion()
+	if err != nil {
+		return v1alpha1.ClusterMetadata{}, fmt.Errorf("getting server version: %w", err)
+	}
+	nodes, err := r.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return v1alpha1.ClusterMetadata{}, fmt.Errorf("listing nodes: %w", err)
+	}
+
+	groups, err := r.clientset.Discovery().ServerGroups()
+	if err != nil {
+		return v1alpha1.ClusterMetadata{}, fmt.Errorf("getting server groups: %w", err)
+	}
+	var apiGroups []string
+	for _, group := range groups.Groups {
+		if group.Name != "" {
+			apiGroups = append(apiGroups, group.Name)
+		}
+	}
+
+	r.metadata = v1alpha1.ClusterMetadata{
+		Name:              r.clusterName,
+		KubernetesVersion: version.GitVersion,
+		NodeCount:         len(nodes.Items),
+		Provider:          DetectProvider(nodes.Items),
+		APIGroups:         apiGroups,
+	}
+	r.expiresAt = now.Add(r.ttl)
+	return r.metadata, nil
+}
+
+const (
+	ProviderEKS       = "eks"
+	ProviderGKE       = "gke"
+	ProviderAKS       = "aks"
+	ProviderBareMetal = "baremetal"
+)
+
+// providerIDPrefixes maps prefixes of provider IDs of nodes, which are set by
+// cloud controller managers, to providers of managed clusters.
+var providerIDPrefixes = map[string]string{
+	"aws://":   ProviderEKS,
+	"gce://":   ProviderGKE,
+	"azure://": ProviderAKS,
+}
+
+// DetectProvider returns the provider of the cluster with the specified nodes
+// detected from provider IDs of the nodes. It returns ProviderBareMetal if no
+// node has a provider ID, or the scheme of the provider ID, e.g. openstack,
+// if the provider is not recognized.
+func DetectProvider(nodes []corev1.Node) string {
+	for _, node := range nodes {
+		providerID := node.Spec.ProviderID
+		if providerID == "" {
+			continue
+		}
+		for prefix, provider := range providerIDPrefixes {
+			if strings.HasPrefix(providerID, prefix) {
+				return provider
+			}
+		}
+		if i := strings.Index(providerID, "://"); i > 0 {
+			return providerID[:i]
+		}
+	}
+	return ProviderBareMetal
+}
@@ -0,0 +1,97 @@
+package kube_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestClusterMetadataReader_Read(t *testing.T) {
+	newNode := func(name string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+
+	t.Run("should read and cache cluster metadata", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		clientset := fake.NewSimpleClientset(newNode("node-1"), newNode("node-2"))
+		clientset.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.23.4"}
+		clientset.Resources = []*metav1.APIResourceList{
+			{GroupVersion: "v1"},
+			{GroupVersion: "policy/v1beta1"},
+		}
+		reader := kube.NewClusterMetadataReader(clientset, "production", time.Hour,
+			ext.NewFixedClock(time.Date(2022, 4, 1, 10, 0, 0, 0, time.UTC)))
+
+		metadata, err := reader.Read(context.TODO())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(metadata).To(Equal(v1alpha1.ClusterMetadata{
+			Name:              "production",
+			KubernetesVersion: "v1.23.4",
+			NodeCount:         2,
+			Provider:          kube.ProviderBareMetal,
+			APIGroups:         []string{"policy"},
+		}))
+
+		g.Expect(clientset.CoreV1().Nodes().Delete(context.TODO(), "node-2", metav1.DeleteOptions{})).To(Succeed())
+		clientset.ClearActions()
+
+		metadata, err = reader.Read(context.TODO())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(metadata.NodeCount).To(Equal(2))
+		g.Expect(clientset.Actions()).To(BeEmpty())
+	})
+
+	t.Run("should read cluster metadata again when TTL expires", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		clientset := fake.NewSimpleClientset(newNode("node-1"), newNode("node-2"))
+		reader := kube.NewClusterMetadataReader(clientset, "", 0, ext.NewSystemClock())
+
+		metadata, err := reader.Read(context.TODO())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(metadata.NodeCount).To(Equal(2))
+
+		g.Expect(clientset.CoreV1().Nodes().Delete(context.TODO(), "node-2", metav1.DeleteOptions{})).To(Succeed())
+
+		metadata, err = reader.Read(context.TODO())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(metadata.NodeCount).To(Equal(1))
+	})
+}
+
+func TestDetectProvider(t *testing.T) {
+	newNode := func(providerID string) corev1.Node {
+		return corev1.Node{Spec: corev1.NodeSpec{ProviderID: providerID}}
+	}
+	testCases := []struct {
+		name     string
+		nodes    []corev1.Node
+		provider string
+	}{
+		{name: "eks", nodes: []corev1.Node{newNode("aws:///us-east-1a/i-0b0c4ec2e0d3d9ba5")}, provider: kube.ProviderEKS},
+		{name: "gke", nodes: []corev1.Node{newNode("gce://my-project/us-central1-c/gke-default-pool-9a5f")}, provider: kube.ProviderGKE},
+		{name: "aks", nodes: []corev1.Node{newNode("azure:///subscriptions/0b1f/resourceGroups/mc_aks/providers/Microsoft.Compute/virtualMachineScaleSets/aks-nodepool1/virtualMachines/0")}, provider: kube.ProviderAKS},
+		{name: "bare metal", nodes: []corev1.Node{newNode(""), newNode("")}, provider: kube.ProviderBareMetal},
+		{name: "no nodes", nodes: nil, provider: kube.ProviderBareMetal},
+		{name: "unrecognized provider", nodes: []corev1.Node{newNode("openstack:///5c1f3ee6")}, provider: "openstack"},
+		{name: "nodes without provider ID are skipped", nodes: []corev1.Node{newNode(""), newNode("gce://my-project/us-central1-c/gke-default-pool-9a5f")}, provider: kube.ProviderGKE},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			g.Expect(kube.DetectProvider(tc.nodes)).To(Equal(tc.provider))
+		})
+	}
+}
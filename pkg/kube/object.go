@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/aquasecurity/starboard/pkg/starboard"
@@ -18,6 +19,7 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -92,7 +94,15 @@ func IsWorkload(kind string) bool {
 		kind == "StatefulSet" ||
 		kind == "DaemonSet" ||
 		kind == "Job" ||
-		kind == "CronJob"
+		kind == "CronJob" ||
+		kind == string(KindRollout)
+}
+
+// IsProtected returns true if the specified report is annotated with
+// starboard.AnnotationProtected set to "true". Protected reports are never
+// deleted by Starboard itself, so that they're kept as audit evidence.
+func IsProtected(report metav1.Object) bool {
+	return report.GetAnnotations()[starboard.AnnotationProtected] == "true"
 }
 
 // IsClusterScopedKind returns true if the specified kind is ClusterRole,
@@ -228,7 +238,7 @@ func ObjectRefFromKindAndObjectKey(kind Kind, name client.ObjectKey) ObjectRef {
 // security report.
 func ComputeSpecHash(obj client.Object) (string, error) {
 	switch t := obj.(type) {
-	case *corev1.Pod, *appsv1.Deployment, *appsv1.ReplicaSet, *corev1.ReplicationController, *appsv1.StatefulSet, *appsv1.DaemonSet, *batchv1beta1.CronJob, *batchv1.Job:
+	case *corev1.Pod, *appsv1.Deployment, *appsv1.ReplicaSet, *corev1.ReplicationController, *appsv1.StatefulSet, *appsv1.DaemonSet, *batchv1beta1.CronJob, *batchv1.Job, *unstructured.Unstructured:
 		spec, err := GetPodSpec(obj)
 		if err != nil {
 			return "", err
@@ -263,6 +273,64 @@ func ComputeSpecHash(obj client.Object) (string, error) {
 	}
 }
 
+// ComputeAuditHash computes hash of the specified K8s client.Object normalized
+// for configuration audits. The hash covers the pod spec of a workload or the
+// content of any other object, labels, and annotations which do not match any
+// of the specified glob patterns. Other metadata and the status are left out,
+// so that changes which do not affect results of an audit, such as annotations
+// rewritten by GitOps tools, do not trigger a new audit.
+func ComputeAuditHash(obj client.Object, ignoredAnnotations []string) (string, error) {
+	var content interface{}
+	switch obj.(type) {
+	case *corev1.Pod, *appsv1.Deployment, *appsv1.ReplicaSet, *corev1.ReplicationController, *appsv1.StatefulSet, *appsv1.DaemonSet, *batchv1beta1.CronJob, *batchv1.Job, *unstructured.Unstructured:
+		spec, err := GetPodSpec(obj)
+		if err != nil {
+			return "", err
+		}
+		content = spec
+	default:
+		fields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return "", fmt.Errorf("converting %T to unstructured: %w", obj, err)
+		}
+		for _, field := range []string{"apiVersion", "kind", "metadata", "status"} {
+			delete(fields, field)
+		}
+		content = fields
+	}
+	return ComputeHash(struct {
+		Labels      map[string]string
+		Annotations map[string]string
+		Content     interface{}
+	}{
+		Labels:      filterKeys(obj.GetLabels(), nil),
+		Annotations: filterKeys(obj.GetAnnotations(), ignoredAnnotations),
+		Content:     content,
+	}), nil
+}
+
+// filterKeys returns a copy of the specified labels or annotations without
+// the ones whose keys match any of the specified glob patterns.
+func filterKeys(values map[string]string, patterns []string) map[string]string {
+	filtered := make(map[string]string)
+	for key, value := range values {
+		if !matchesAny(key, patterns) {
+			filtered[key] = value
+		}
+	}
+	return filtered
+}
+
+func matchesAny(key string, patterns []string) bool {
+	for _, pattern := range patterns {
+		matches, err := filepath.Match(pattern, key)
+		if err == nil && matches {
+			return true
+		}
+	}
+	return false
+}
+
 // GetPodSpec returns v1.PodSpec from the specified Kubernetes client.Object.
 // Returns error if the given client.Object is not a Kubernetes workload.
 func GetPodSpec(obj client.Object) (corev1.PodSpec, error) {
@@ -283,11 +351,79 @@ func GetPodSpec(obj client.Object) (corev1.PodSpec, error) {
 		return (obj.(*batchv1beta1.CronJob)).Spec.JobTemplate.Spec.Template.Spec, nil
 	case *batchv1.Job:
 		return (obj.(*batchv1.Job)).Spec.Template.Spec, nil
+	case *unstructured.Unstructured:
+		template, err := rolloutPodTemplate(t)
+		return template.Spec, err
 	default:
 		return corev1.PodSpec{}, fmt.Errorf("unsupported workload: %T", t)
 	}
 }
 
+// GetPodTemplateAnnotations returns annotations of the pod template of the
+// specified Kubernetes workload. For a bare Pod these are the Pod's own
+// annotations. Returns error if the given client.Object is not a Kubernetes
+// workload.
+func GetPodTemplateAnnotations(obj client.Object) (map[string]string, error) {
+	switch t := obj.(type) {
+	case *corev1.Pod:
+		return t.Annotations, nil
+	case *appsv1.Deployment:
+		return t.Spec.Template.Annotations, nil
+	case *appsv1.ReplicaSet:
+		return t.Spec.Template.Annotations, nil
+	case *corev1.ReplicationController:
+		if t.Spec.Template == nil {
+			return nil, nil
+		}
+		return t.Spec.Template.Annotations, nil
+	case *appsv1.StatefulSet:
+		return t.Spec.Template.Annotations, nil
+	case *appsv1.DaemonSet:
+		return t.Spec.Template.Annotations, nil
+	case *batchv1beta1.CronJob:
+		return t.Spec.JobTemplate.Spec.Template.Annotations, nil
+	case *batchv1.Job:
+		return t.Spec.Template.Annotations, nil
+	case *unstructured.Unstructured:
+		template, err := rolloutPodTemplate(t)
+		return template.Annotations, err
+	default:
+		return nil, fmt.Errorf("unsupported workload: %T", t)
+	}
+}
+
+// GetPodSelector returns the label selector of pods controlled by the
+// specified Kubernetes workload. For a bare Pod the selector matches the Pod's
+// own labels. Returns error if the given client.Object is not a Kubernetes
+// workload.
+func GetPodSelector(obj client.Object) (*metav1.LabelSelector, error) {
+	switch t := obj.(type) {
+	case *corev1.Pod:
+		return &metav1.LabelSelector{MatchLabels: t.Labels}, nil
+	case *appsv1.Deployment:
+		return t.Spec.Selector, nil
+	case *appsv1.ReplicaSet:
+		return t.Spec.Selector, nil
+	case *corev1.ReplicationController:
+		return &metav1.LabelSelector{MatchLabels: t.Spec.Selector}, nil
+	case *appsv1.StatefulSet:
+		return t.Spec.Selector, nil
+	case *appsv1.DaemonSet:
+		return t.Spec.Selector, nil
+	case *batchv1beta1.CronJob:
+		if t.Spec.JobTemplate.Spec.Selector != nil {
+			return t.Spec.JobTemplate.Spec.Selector, nil
+		}
+		return &metav1.LabelSelector{MatchLabels: t.Spec.JobTemplate.Spec.Template.Labels}, nil
+	case *batchv1.Job:
+		return t.Spec.Selector, nil
+	case *unstructured.Unstructured:
+		return rolloutSelector(t)
+	default:
+		return nil, fmt.Errorf("unsupported workload: %T", t)
+	}
+}
+
 var ErrReplicaSetNotFound = errors.New("replicaset not found")
 var ErrNoRunningPods = errors.New("no active pods for controller")
 var ErrUnSupportedKind = errors.New("unsupported workload kind")
@@ -315,6 +451,8 @@ func (o *ObjectResolver) ObjectFromObjectRef(ctx context.Context, ref ObjectRef)
 		obj = &batchv1beta1.CronJob{}
 	case KindJob:
 		obj = &batchv1.Job{}
+	case KindRollout:
+		obj = NewRollout()
 	case KindService:
 		obj = &corev1.Service{}
 	case KindConfigMap:
@@ -375,7 +513,11 @@ func (o *ObjectResolver) ReportOwner(ctx context.Context, obj client.Object) (cl
 			return obj, nil
 		}
 		if controller.Kind == string(KindReplicaSet) {
-			return o.ReplicaSetByPod(ctx, obj.(*corev1.Pod))
+			replicaSet, err := o.ReplicaSetByPod(ctx, obj.(*corev1.Pod))
+			if err != nil {
+				return nil, err
+			}
+			return o.ReportOwner(ctx, replicaSet)
 		}
 		if controller.Kind == string(KindJob) {
 			// Managed by Job or CronJob
@@ -387,7 +529,13 @@ func (o *ObjectResolver) ReportOwner(ctx context.Context, obj client.Object) (cl
 		}
 		// Pod controlled by sth else (usually frameworks)
 		return obj, nil
-	case *appsv1.ReplicaSet, *corev1.ReplicationController, *appsv1.StatefulSet, *appsv1.DaemonSet, *batchv1beta1.CronJob:
+	case *appsv1.ReplicaSet:
+		if IsRolloutRef(metav1.GetControllerOf(obj)) {
+			// ReplicaSet managed by Rollout, which owns reports instead
+			return o.RolloutByReplicaSet(ctx, obj.(*appsv1.ReplicaSet))
+		}
+		return obj, nil
+	case *corev1.ReplicationController, *appsv1.StatefulSet, *appsv1.DaemonSet, *batchv1beta1.CronJob:
 		return obj, nil
 	default:
 		return obj, nil
@@ -592,6 +740,19 @@ func (o *ObjectResolver) GetNodeName(ctx context.Context, obj client.Object) (st
 			return "", err
 		}
 		return pods[0].Spec.NodeName, nil
+	case *unstructured.Unstructured:
+		selector, err := rolloutSelector(obj.(*unstructured.Unstructured))
+		if err != nil {
+			return "", err
+		}
+		if selector == nil {
+			return "", ErrNoRunningPods
+		}
+		pods, err := o.getActivePodsByLabelSelector(ctx, obj.GetNamespace(), selector.MatchLabels)
+		if err != nil {
+			return "", err
+		}
+		return pods[0].Spec.NodeName, nil
 	default:
 		return "", ErrUnSupportedKind
 	}
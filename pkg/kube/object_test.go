@@ -93,6 +93,10 @@ func TestIsWorkload(t *testing.T) {
 			kind: "CronJob",
 			want: true,
 		},
+		{
+			kind: "Rollout",
+			want: true,
+		},
 		{
 			kind: "ConfigMap",
 			want: false,
@@ -311,6 +315,166 @@ func TestObjectRefFromKindAndObjectKey(t *testing.T) {
 	}, partial)
 }
 
+func TestComputeAuditHash(t *testing.T) {
+	ignoredAnnotations := []string{"kubectl.kubernetes.io/*", "argocd.argoproj.io/*", "deployment.kubernetes.io/*"}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "nginx",
+			Namespace:       "default",
+			ResourceVersion: "1",
+			Labels:          map[string]string{"app": "nginx"},
+			Annotations:     map[string]string{"argocd.argoproj.io/tracking-id": "nginx:apps/Deployment:default/nginx"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: "nginx:1.16"}}},
+			},
+		},
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "nginx-6d4cf56db6",
+			Namespace:       "default",
+			ResourceVersion: "1",
+			Labels:          map[string]string{"app": "nginx", "pod-template-hash": "6d4cf56db6"},
+			Annotations: map[string]string{
+				"deployment.kubernetes.io/desired-replicas": "2",
+				"deployment.kubernetes.io/max-replicas":     "3",
+				"deployment.kubernetes.io/revision":         "1",
+			},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Replicas: pointer.Int32(2),
+			Template: deployment.Spec.Template,
+		},
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "nginx",
+			Namespace:       "default",
+			ResourceVersion: "1",
+			Annotations:     map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}"},
+		},
+		Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+	}
+
+	testCases := []struct {
+		name            string
+		object          client.Object
+		modify          func(obj client.Object)
+		expectedChanged bool
+	}{
+		{
+			name:   "Should ignore metadata managed by API server",
+			object: deployment,
+			modify: func(obj client.Object) {
+				obj.SetResourceVersion("2")
+				obj.SetGeneration(2)
+			},
+			expectedChanged: false,
+		},
+		{
+			name:   "Should ignore change of ignored annotation",
+			object: deployment,
+			modify: func(obj client.Object) {
+				obj.SetAnnotations(map[string]string{
+					"argocd.argoproj.io/tracking-id":    "nginx:apps/Deployment:default/nginx-v2",
+					"kubectl.kubernetes.io/restartedAt": "2022-06-01T10:00:00Z",
+				})
+			},
+			expectedChanged: false,
+		},
+		{
+			name:   "Should detect change of other annotation",
+			object: deployment,
+			modify: func(obj client.Object) {
+				obj.SetAnnotations(map[string]string{
+					"argocd.argoproj.io/tracking-id":           "nginx:apps/Deployment:default/nginx",
+					"seccomp.security.alpha.kubernetes.io/pod": "runtime/default",
+				})
+			},
+			expectedChanged: true,
+		},
+		{
+			name:   "Should detect change of label",
+			object: deployment,
+			modify: func(obj client.Object) {
+				obj.SetLabels(map[string]string{"app": "nginx", "tier": "frontend"})
+			},
+			expectedChanged: true,
+		},
+		{
+			name:   "Should detect change of pod spec",
+			object: deployment,
+			modify: func(obj client.Object) {
+				obj.(*appsv1.Deployment).Spec.Template.Spec.Containers[0].Image = "nginx:1.17"
+			},
+			expectedChanged: true,
+		},
+		{
+			name:   "Should ignore change of replicas",
+			object: deployment,
+			modify: func(obj client.Object) {
+				obj.(*appsv1.Deployment).Spec.Replicas = pointer.Int32(3)
+			},
+			expectedChanged: false,
+		},
+		{
+			name:   "Should ignore scaling of ReplicaSet by its Deployment",
+			object: replicaSet,
+			modify: func(obj client.Object) {
+				obj.SetResourceVersion("2")
+				obj.(*appsv1.ReplicaSet).Spec.Replicas = pointer.Int32(5)
+				obj.SetAnnotations(map[string]string{
+					"deployment.kubernetes.io/desired-replicas": "5",
+					"deployment.kubernetes.io/max-replicas":     "6",
+					"deployment.kubernetes.io/revision":         "1",
+				})
+			},
+			expectedChanged: false,
+		},
+		{
+			name:   "Should ignore change of ignored annotation of Service",
+			object: service,
+			modify: func(obj client.Object) {
+				obj.SetResourceVersion("2")
+				obj.SetAnnotations(map[string]string{"kubectl.kubernetes.io/last-applied-configuration": `{"kind":"Service"}`})
+			},
+			expectedChanged: false,
+		},
+		{
+			name:   "Should detect change of Service spec",
+			object: service,
+			modify: func(obj client.Object) {
+				obj.(*corev1.Service).Spec.Type = corev1.ServiceTypeNodePort
+			},
+			expectedChanged: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hash, err := kube.ComputeAuditHash(tc.object, ignoredAnnotations)
+			require.NoError(t, err)
+
+			modified := tc.object.DeepCopyObject().(client.Object)
+			tc.modify(modified)
+			modifiedHash, err := kube.ComputeAuditHash(modified, ignoredAnnotations)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedChanged, hash != modifiedHash)
+		})
+	}
+
+	t.Run("Should detect change of annotation which is no longer ignored", func(t *testing.T) {
+		hash, err := kube.ComputeAuditHash(deployment, ignoredAnnotations)
+		require.NoError(t, err)
+		notIgnoredHash, err := kube.ComputeAuditHash(deployment, nil)
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, notIgnoredHash)
+	})
+}
+
 func TestGetPodSpec(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -62,3 +62,44 @@ func DeepHashObject(hasher hash.Hash, objectToWrite interface{}) {
 	}
 	printer.Fprintf(hasher, "%#v", objectToWrite)
 }
+
+// SetDefaultResources sets resource requests and limits of containers and init
+// containers of the specified v1.PodSpec, which are set in the given defaults
+// but not in containers. Otherwise, containers would inherit defaults of a
+// LimitRange of the namespace, which may be too high for the pod to be
+// scheduled. A default request is lowered to the limit of the container, and
+// a default limit is raised to the request of the container, so that requests
+// never exceed limits.
+func SetDefaultResources(spec *corev1.PodSpec, defaults corev1.ResourceRequirements) {
+	setDefaults := func(containers []corev1.Container) {
+		for i := range containers {
+			resources := &containers[i].Resources
+			for name, limit := range defaults.Limits {
+				if _, ok := resources.Limits[name]; ok {
+					continue
+				}
+				if request, ok := resources.Requests[name]; ok && request.Cmp(limit) > 0 {
+					limit = request
+				}
+				if resources.Limits == nil {
+					resources.Limits = corev1.ResourceList{}
+				}
+				resources.Limits[name] = limit.DeepCopy()
+			}
+			for name, request := range defaults.Requests {
+				if _, ok := resources.Requests[name]; ok {
+					continue
+				}
+				if limit, ok := resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+					request = limit
+				}
+				if resources.Requests == nil {
+					resources.Requests = corev1.ResourceList{}
+				}
+				resources.Requests[name] = request.DeepCopy()
+			}
+		}
+	}
+	setDefaults(spec.InitContainers)
+	setDefaults(spec.Containers)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -151,3 +152,55 @@ func TestComputeHash(t *testing.T) {
 	})
 
 }
+
+func TestSetDefaultResources(t *testing.T) {
+	defaults := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:              resource.MustParse("50m"),
+			corev1.ResourceMemory:           resource.MustParse("50M"),
+			corev1.ResourceEphemeralStorage: resource.MustParse("100Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:              resource.MustParse("500m"),
+			corev1.ResourceMemory:           resource.MustParse("500M"),
+			corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+		},
+	}
+	spec := corev1.PodSpec{
+		InitContainers: []corev1.Container{
+			{Name: "init"},
+		},
+		Containers: []corev1.Container{
+			{
+				Name: "scanner",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("100m"),
+						corev1.ResourceMemory: resource.MustParse("1G"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:              resource.MustParse("1"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("50Mi"),
+					},
+				},
+			},
+		},
+	}
+
+	kube.SetDefaultResources(&spec, defaults)
+	assert.Equal(t, defaults, spec.InitContainers[0].Resources)
+	assert.Equal(t, corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("1G"),
+			// lowered to the limit
+			corev1.ResourceEphemeralStorage: resource.MustParse("50Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("1"),
+			// raised to the request
+			corev1.ResourceMemory:           resource.MustParse("1G"),
+			corev1.ResourceEphemeralStorage: resource.MustParse("50Mi"),
+		},
+	}, spec.Containers[0].Resources)
+}
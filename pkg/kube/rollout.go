@@ -0,0 +1,122 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// KindRollout is the kind of Argo Rollouts, which manage ReplicaSets in place
+// of Deployments.
+const KindRollout Kind = "Rollout"
+
+// RolloutGVK is the GroupVersionKind of Argo Rollouts. Rollouts are handled
+// as unstructured objects, so that Starboard does not depend on the Argo
+// Rollouts API, and they're supported only if the API is served.
+var RolloutGVK = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: string(KindRollout)}
+
+// NewRollout returns an empty unstructured Rollout.
+func NewRollout() *unstructured.Unstructured {
+	rollout := &unstructured.Unstructured{}
+	rollout.SetGroupVersionKind(RolloutGVK)
+	return rollout
+}
+
+// NewRolloutList returns an empty unstructured list of Rollouts.
+func NewRolloutList() *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(RolloutGVK.GroupVersion().WithKind(string(KindRollout) + "List"))
+	return list
+}
+
+// RolloutAPIAvailable returns true if the API server serves Argo Rollouts,
+// i.e. the Rollout CRD is installed.
+func RolloutAPIAvailable(mapper meta.RESTMapper) (bool, error) {
+	_, err := mapper.RESTMapping(RolloutGVK.GroupKind(), RolloutGVK.Version)
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("checking whether %s API is available: %w", RolloutGVK.GroupVersion(), err)
+	}
+	return true, nil
+}
+
+// IsRolloutRef returns true if the specified v1.OwnerReference refers to an
+// Argo Rollout.
+func IsRolloutRef(controller *metav1.OwnerReference) bool {
+	if controller == nil || controller.Kind != string(KindRollout) {
+		return false
+	}
+	gv, err := schema.ParseGroupVersion(controller.APIVersion)
+	return err == nil && gv.Group == RolloutGVK.Group
+}
+
+func isRollout(obj *unstructured.Unstructured) bool {
+	return obj.GroupVersionKind().GroupKind() == RolloutGVK.GroupKind()
+}
+
+// rolloutPodTemplate returns the pod template of the given Rollout. Rollouts
+// which reference the pod template of another workload with spec.workloadRef
+// are not supported.
+func rolloutPodTemplate(rollout *unstructured.Unstructured) (corev1.PodTemplateSpec, error) {
+	var template corev1.PodTemplateSpec
+	if !isRollout(rollout) {
+		return template, fmt.Errorf("unsupported workload: %s", rollout.GroupVersionKind())
+	}
+	value, found, err := unstructured.NestedMap(rollout.Object, "spec", "template")
+	if err != nil {
+		return template, fmt.Errorf("getting pod template of rollout %s/%s: %w", rollout.GetNamespace(), rollout.GetName(), err)
+	}
+	if !found {
+		if _, ok, _ := unstructured.NestedMap(rollout.Object, "spec", "workloadRef"); ok {
+			return template, fmt.Errorf("rollout %s/%s references a workload, which is not supported", rollout.GetNamespace(), rollout.GetName())
+		}
+		return template, fmt.Errorf("rollout %s/%s has no pod template", rollout.GetNamespace(), rollout.GetName())
+	}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(value, &template)
+	if err != nil {
+		return template, fmt.Errorf("converting pod template of rollout %s/%s: %w", rollout.GetNamespace(), rollout.GetName(), err)
+	}
+	return template, nil
+}
+
+// rolloutSelector returns the label selector of pods of the given Rollout.
+func rolloutSelector(rollout *unstructured.Unstructured) (*metav1.LabelSelector, error) {
+	if !isRollout(rollout) {
+		return nil, fmt.Errorf("unsupported workload: %s", rollout.GroupVersionKind())
+	}
+	value, found, err := unstructured.NestedMap(rollout.Object, "spec", "selector")
+	if err != nil || !found {
+		return nil, err
+	}
+	selector := &metav1.LabelSelector{}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(value, selector)
+	if err != nil {
+		return nil, fmt.Errorf("converting selector of rollout %s/%s: %w", rollout.GetNamespace(), rollout.GetName(), err)
+	}
+	return selector, nil
+}
+
+// RolloutByReplicaSet returns the Rollout which controls the specified
+// ReplicaSet.
+func (o *ObjectResolver) RolloutByReplicaSet(ctx context.Context, replicaSet *appsv1.ReplicaSet) (*unstructured.Unstructured, error) {
+	controller := metav1.GetControllerOf(replicaSet)
+	if !IsRolloutRef(controller) {
+		return nil, fmt.Errorf("replicaset %s/%s is not controlled by a rollout", replicaSet.Namespace, replicaSet.Name)
+	}
+	rollout := NewRollout()
+	err := o.Client.Get(ctx, client.ObjectKey{Namespace: replicaSet.Namespace, Name: controller.Name}, rollout)
+	if err != nil {
+		return nil, err
+	}
+	return rollout, nil
+}
@@ -0,0 +1,90 @@
+package kube_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+// TestRollout_EnvTest verifies resolution of Argo Rollouts against an API
+// server with the Rollout CRD installed. It requires control plane binaries
+// referenced by the KUBEBUILDER_ASSETS environment variable.
+func TestRollout_EnvTest(t *testing.T) {
+	if testing.Short() || os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("Skipping test which requires KUBEBUILDER_ASSETS")
+	}
+
+	testEnv := &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("testdata", "crd")},
+		ErrorIfCRDPathMissing: true,
+	}
+	cfg, err := testEnv.Start()
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, testEnv.Stop())
+	}()
+
+	testClient, err := client.New(cfg, client.Options{Scheme: starboard.NewScheme()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	available, err := kube.RolloutAPIAvailable(testClient.RESTMapper())
+	require.NoError(t, err)
+	require.True(t, available)
+
+	rollout := newRollout("default", "app", rolloutSpec())
+	rollout.SetUID("")
+	require.NoError(t, testClient.Create(ctx, rollout))
+
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "app-7d9f8c6b5",
+			Labels:    map[string]string{"app": "app"},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: kube.RolloutGVK.GroupVersion().String(),
+					Kind:       kube.RolloutGVK.Kind,
+					Name:       rollout.GetName(),
+					UID:        rollout.GetUID(),
+					Controller: pointer.BoolPtr(true),
+				},
+			},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "app"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "app"}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Image: "app:1.0"}},
+				},
+			},
+		},
+	}
+	require.NoError(t, testClient.Create(ctx, replicaSet))
+
+	resolver := kube.ObjectResolver{Client: testClient}
+
+	obj, err := resolver.ObjectFromObjectRef(ctx, kube.ObjectRef{Kind: kube.KindRollout, Name: "app", Namespace: "default"})
+	require.NoError(t, err)
+	spec, err := kube.GetPodSpec(obj)
+	require.NoError(t, err)
+	assert.Equal(t, "app:1.0", spec.Containers[0].Image)
+
+	owner, err := resolver.ReportOwner(ctx, replicaSet)
+	require.NoError(t, err)
+	assert.Equal(t, kube.RolloutGVK, owner.GetObjectKind().GroupVersionKind())
+	assert.Equal(t, rollout.GetUID(), owner.GetUID())
+}
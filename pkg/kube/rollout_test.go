@@ -0,0 +1,198 @@
+package kube_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newRollout(namespace, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	rollout := kube.NewRollout()
+	rollout.SetNamespace(namespace)
+	rollout.SetName(name)
+	rollout.SetUID("b6a9d6e2-3c1b-4b5a-9b61-6f1f1f7ad2a0")
+	rollout.Object["spec"] = spec
+	return rollout
+}
+
+func rolloutSpec() map[string]interface{} {
+	return map[string]interface{}{
+		"replicas": int64(2),
+		"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{"app": "app"},
+		},
+		"template": map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels":      map[string]interface{}{"app": "app"},
+				"annotations": map[string]interface{}{"starboard.aquasecurity.github.io/skip-containers": "istio-proxy"},
+			},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"name": "app", "image": "app:1.0"},
+				},
+			},
+		},
+		"strategy": map[string]interface{}{
+			"canary": map[string]interface{}{},
+		},
+	}
+}
+
+func TestRolloutAPIAvailable(t *testing.T) {
+	t.Run("Should return true if Rollout is served", func(t *testing.T) {
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(kube.RolloutGVK, meta.RESTScopeNamespace)
+		available, err := kube.RolloutAPIAvailable(mapper)
+		require.NoError(t, err)
+		assert.True(t, available)
+	})
+
+	t.Run("Should return false if Rollout is not served", func(t *testing.T) {
+		available, err := kube.RolloutAPIAvailable(meta.NewDefaultRESTMapper(nil))
+		require.NoError(t, err)
+		assert.False(t, available)
+	})
+}
+
+func TestIsRolloutRef(t *testing.T) {
+	testCases := []struct {
+		name       string
+		controller *metav1.OwnerReference
+		want       bool
+	}{
+		{
+			name: "Should return false for nil controller",
+		},
+		{
+			name:       "Should return true for Argo Rollout",
+			controller: &metav1.OwnerReference{APIVersion: "argoproj.io/v1alpha1", Kind: "Rollout", Name: "app"},
+			want:       true,
+		},
+		{
+			name:       "Should return false for Rollout of another API group",
+			controller: &metav1.OwnerReference{APIVersion: "example.com/v1", Kind: "Rollout", Name: "app"},
+		},
+		{
+			name:       "Should return false for Deployment",
+			controller: &metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "app"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, kube.IsRolloutRef(tc.controller))
+		})
+	}
+}
+
+func TestGetPodSpec_Rollout(t *testing.T) {
+	t.Run("Should return pod spec, annotations and selector of pod template", func(t *testing.T) {
+		rollout := newRollout("default", "app", rolloutSpec())
+
+		spec, err := kube.GetPodSpec(rollout)
+		require.NoError(t, err)
+		assert.Equal(t, corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Image: "app:1.0"}},
+		}, spec)
+
+		annotations, err := kube.GetPodTemplateAnnotations(rollout)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"starboard.aquasecurity.github.io/skip-containers": "istio-proxy"}, annotations)
+
+		selector, err := kube.GetPodSelector(rollout)
+		require.NoError(t, err)
+		assert.Equal(t, &metav1.LabelSelector{MatchLabels: map[string]string{"app": "app"}}, selector)
+
+		hash, err := kube.ComputeSpecHash(rollout)
+		require.NoError(t, err)
+		assert.Equal(t, kube.ComputeHash(spec), hash)
+	})
+
+	t.Run("Should return error for Rollout referencing a workload", func(t *testing.T) {
+		rollout := newRollout("default", "app", map[string]interface{}{
+			"workloadRef": map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "app"},
+		})
+		_, err := kube.GetPodSpec(rollout)
+		assert.EqualError(t, err, "rollout default/app references a workload, which is not supported")
+	})
+
+	t.Run("Should return error for unstructured object of another kind", func(t *testing.T) {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("example.com/v1")
+		obj.SetKind("Rollout")
+		_, err := kube.GetPodSpec(obj)
+		assert.EqualError(t, err, "unsupported workload: example.com/v1, Kind=Rollout")
+	})
+}
+
+func TestObjectResolver_ReportOwner_Rollout(t *testing.T) {
+	rollout := newRollout("default", "app", rolloutSpec())
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "app-7d9f8c6b5",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "argoproj.io/v1alpha1",
+					Kind:       "Rollout",
+					Name:       "app",
+					UID:        rollout.GetUID(),
+					Controller: pointer.BoolPtr(true),
+				},
+			},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "app"}},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "app-7d9f8c6b5-x2v4k",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "apps/v1",
+					Kind:       "ReplicaSet",
+					Name:       "app-7d9f8c6b5",
+					Controller: pointer.BoolPtr(true),
+				},
+			},
+		},
+	}
+	testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+		WithObjects(rollout, replicaSet, pod).
+		Build()
+	resolver := kube.ObjectResolver{Client: testClient}
+
+	t.Run("Should resolve Rollout as owner of its ReplicaSet", func(t *testing.T) {
+		owner, err := resolver.ReportOwner(context.TODO(), replicaSet)
+		require.NoError(t, err)
+		assert.Equal(t, kube.RolloutGVK, owner.GetObjectKind().GroupVersionKind())
+		assert.Equal(t, "app", owner.GetName())
+	})
+
+	t.Run("Should resolve Rollout as owner of pod controlled by its ReplicaSet", func(t *testing.T) {
+		owner, err := resolver.ReportOwner(context.TODO(), pod)
+		require.NoError(t, err)
+		assert.Equal(t, kube.RolloutGVK, owner.GetObjectKind().GroupVersionKind())
+		assert.Equal(t, "app", owner.GetName())
+	})
+
+	t.Run("Should get Rollout from object reference", func(t *testing.T) {
+		obj, err := resolver.ObjectFromObjectRef(context.TODO(), kube.ObjectRef{Kind: kube.KindRollout, Name: "app", Namespace: "default"})
+		require.NoError(t, err)
+		owner, err := resolver.ReportOwner(context.TODO(), obj)
+		require.NoError(t, err)
+		assert.Equal(t, obj, owner)
+	})
+}
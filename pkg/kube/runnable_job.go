@@ -169,3 +169,16 @@ func GetActiveDeadlineSeconds(d time.Duration) *int64 {
 	}
 	return nil
 }
+
+// JobFinishedCondition returns the first condition of the specified job which
+// is not of the JobSuspended type, and false if there's no such condition. Jobs
+// which were suspended or resumed carry the JobSuspended condition although
+// they're not finished.
+func JobFinishedCondition(job *batchv1.Job) (batchv1.JobCondition, bool) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type != batchv1.JobSuspended {
+			return condition, true
+		}
+	}
+	return batchv1.JobCondition{}, false
+}
@@ -0,0 +1,56 @@
+package kube
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WriteOptions holds settings of writing security reports.
+type WriteOptions struct {
+	// DryRun submits writes with the server-side dry-run flag, so that reports
+	// are validated against the CRD schema and admission webhooks, but never
+	// persisted.
+	DryRun bool
+}
+
+// WriteOption configures WriteOptions.
+type WriteOption func(*WriteOptions)
+
+// DryRun submits writes with the server-side dry-run flag.
+func DryRun() WriteOption {
+	return func(o *WriteOptions) {
+		o.DryRun = true
+	}
+}
+
+// NewWriteOptions returns WriteOptions configured with the given options.
+func NewWriteOptions(opts ...WriteOption) WriteOptions {
+	var o WriteOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// CreateOptions returns options of the client.Writer Create method.
+func (o WriteOptions) CreateOptions() []client.CreateOption {
+	if o.DryRun {
+		return []client.CreateOption{client.DryRunAll}
+	}
+	return nil
+}
+
+// UpdateOptions returns options of the client.Writer Update method.
+func (o WriteOptions) UpdateOptions() []client.UpdateOption {
+	if o.DryRun {
+		return []client.UpdateOption{client.DryRunAll}
+	}
+	return nil
+}
+
+// DeleteOptions returns options of the client.Writer Delete method.
+func (o WriteOptions) DeleteOptions() []client.DeleteOption {
+	if o.DryRun {
+		return []client.DeleteOption{client.DryRunAll}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package kubebench
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Reasons why a node is not eligible for CIS Kubernetes Benchmark checks.
+const (
+	// SkipReasonNotLinux is the reason of a node which does not run Linux,
+	// e.g. a Windows node.
+	SkipReasonNotLinux = "NotLinux"
+	// SkipReasonFargate is the reason of an AWS Fargate node, where scan
+	// jobs which mount host paths can never run.
+	SkipReasonFargate = "Fargate"
+	// SkipReasonVirtualKubelet is the reason of a node backed by a
+	// virtual-kubelet provider, e.g. an Azure Container Instances node.
+	SkipReasonVirtualKubelet = "VirtualKubelet"
+	// SkipReasonSelector is the reason of a node which matches the
+	// kube-bench.nodeSkipSelector setting.
+	SkipReasonSelector = "SkipSelector"
+)
+
+// skippedStatusPrefix prefixes the reason of a skipped node in the status of
+// its report.
+const skippedStatusPrefix = "Skipped: "
+
+const (
+	labelFargateComputeType     = "eks.amazonaws.com/compute-type"
+	labelVirtualKubeletType     = "type"
+	taintVirtualKubeletProvider = "virtual-kubelet.io/provider"
+)
+
+// NodeSkipReason returns the reason why CIS Kubernetes Benchmark checks
+// cannot run on the given node, or an empty string if the node is eligible.
+// Nodes matching the given skip selector are not eligible either.
+func NodeSkipReason(node corev1.Node, skipSelector labels.Selector) string {
+	nodeLabels := labels.Set(node.Labels)
+	if nodeLabels[corev1.LabelOSStable] != "linux" {
+		return SkipReasonNotLinux
+	}
+	if nodeLabels[labelFargateComputeType] == "fargate" || hasTaint(node, labelFargateComputeType, "fargate") {
+		return SkipReasonFargate
+	}
+	if nodeLabels[labelVirtualKubeletType] == "virtual-kubelet" || hasTaint(node, taintVirtualKubeletProvider, "") {
+		return SkipReasonVirtualKubelet
+	}
+	if skipSelector != nil && skipSelector.Matches(nodeLabels) {
+		return SkipReasonSelector
+	}
+	return ""
+}
+
+// hasTaint returns true if the node has a taint with the given key and, if
+// value is not empty, with the given value.
+func hasTaint(node corev1.Node, key, value string) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == key && (value == "" || taint.Value == value) {
+			return true
+		}
+	}
+	return false
+}
+
+// SkippedStatus returns the status of the report of a node which was skipped
+// for the given reason.
+func SkippedStatus(reason string) string {
+	return skippedStatusPrefix + reason
+}
+
+// SkipReason returns the reason why the node of the given report was skipped,
+// or false if the report holds results of CIS Kubernetes Benchmark checks.
+func SkipReason(report v1alpha1.CISKubeBenchReport) (string, bool) {
+	if !strings.HasPrefix(report.Report.Status, skippedStatusPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(report.Report.Status, skippedStatusPrefix), true
+}
@@ -0,0 +1,102 @@
+package kubebench_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kubebench"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestNodeSkipReason(t *testing.T) {
+	skipSelector := labels.SelectorFromSet(labels.Set{"pool": "gpu"})
+	linuxNode := func(nodeLabels map[string]string, taints ...corev1.Taint) corev1.Node {
+		node := corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "node",
+				Labels: map[string]string{corev1.LabelOSStable: "linux"},
+			},
+			Spec: corev1.NodeSpec{Taints: taints},
+		}
+		for key, value := range nodeLabels {
+			node.Labels[key] = value
+		}
+		return node
+	}
+	testCases := []struct {
+		name string
+		node corev1.Node
+		want string
+	}{
+		{
+			name: "Should return no reason for Linux node",
+			node: linuxNode(nil),
+		},
+		{
+			name: "Should skip Windows node",
+			node: corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{corev1.LabelOSStable: "windows"},
+			}},
+			want: kubebench.SkipReasonNotLinux,
+		},
+		{
+			name: "Should skip node without OS label",
+			node: corev1.Node{},
+			want: kubebench.SkipReasonNotLinux,
+		},
+		{
+			name: "Should skip Fargate node by label",
+			node: linuxNode(map[string]string{"eks.amazonaws.com/compute-type": "fargate"}),
+			want: kubebench.SkipReasonFargate,
+		},
+		{
+			name: "Should skip Fargate node by taint",
+			node: linuxNode(nil, corev1.Taint{Key: "eks.amazonaws.com/compute-type", Value: "fargate", Effect: corev1.TaintEffectNoSchedule}),
+			want: kubebench.SkipReasonFargate,
+		},
+		{
+			name: "Should skip virtual-kubelet node by label",
+			node: linuxNode(map[string]string{"type": "virtual-kubelet"}),
+			want: kubebench.SkipReasonVirtualKubelet,
+		},
+		{
+			name: "Should skip virtual-kubelet node by taint",
+			node: linuxNode(nil, corev1.Taint{Key: "virtual-kubelet.io/provider", Value: "azure", Effect: corev1.TaintEffectNoSchedule}),
+			want: kubebench.SkipReasonVirtualKubelet,
+		},
+		{
+			name: "Should skip node matching skip selector",
+			node: linuxNode(map[string]string{"pool": "gpu"}),
+			want: kubebench.SkipReasonSelector,
+		},
+		{
+			name: "Should return no reason for node with other taints",
+			node: linuxNode(map[string]string{"pool": "default"}, corev1.Taint{Key: "dedicated", Value: "db", Effect: corev1.TaintEffectNoSchedule}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, kubebench.NodeSkipReason(tc.node, skipSelector))
+		})
+	}
+}
+
+func TestSkipReason(t *testing.T) {
+	t.Run("Should return reason of skipped node", func(t *testing.T) {
+		reason, ok := kubebench.SkipReason(v1alpha1.CISKubeBenchReport{
+			Report: v1alpha1.CISKubeBenchReportData{Status: kubebench.SkippedStatus(kubebench.SkipReasonFargate)},
+		})
+		assert.True(t, ok)
+		assert.Equal(t, kubebench.SkipReasonFargate, reason)
+	})
+
+	t.Run("Should return false for report with results", func(t *testing.T) {
+		_, ok := kubebench.SkipReason(v1alpha1.CISKubeBenchReport{
+			Report: v1alpha1.CISKubeBenchReportData{Summary: v1alpha1.CISKubeBenchSummary{PassCount: 3}},
+		})
+		assert.False(t, ok)
+	})
+}
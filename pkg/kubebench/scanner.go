@@ -115,6 +115,12 @@ func (s *Scanner) prepareKubeBenchJob(node corev1.Node) (*batchv1.Job, error) {
 	}
 	templateSpec.Tolerations = append(templateSpec.Tolerations, scanJobTolerations...)
 
+	defaultResources, err := s.config.GetScanJobDefaultResources()
+	if err != nil {
+		return nil, err
+	}
+	kube.SetDefaultResources(&templateSpec, defaultResources)
+
 	scanJobAnnotations, err := s.config.GetScanJobAnnotations()
 	if err != nil {
 		return nil, err
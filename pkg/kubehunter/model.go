@@ -1,7 +1,10 @@
 package kubehunter
 
 import (
+	"compress/gzip"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"io"
 	"time"
 
@@ -50,5 +53,28 @@ func OutputFrom(config Config, reader io.Reader) (v1alpha1.KubeHunterReportData,
 	}
 
 	report.Summary = toSummary(report.Vulnerabilities)
+
+	maxFindings, err := config.GetKubeHunterMaxFindings()
+	if err != nil {
+		return v1alpha1.KubeHunterReportData{}, err
+	}
+	if maxFindings > 0 && len(report.Vulnerabilities) > maxFindings {
+		report.Truncated = true
+		report.Vulnerabilities = report.Vulnerabilities[:maxFindings]
+	}
 	return report, nil
 }
+
+// CompressedOutputFrom is like OutputFrom, but reads kube-hunter output
+// which is gzip compressed and base64 encoded, as uploaded by the results
+// container of the kube-hunter job.
+func CompressedOutputFrom(config Config, reader io.Reader) (v1alpha1.KubeHunterReportData, error) {
+	gzipReader, err := gzip.NewReader(base64.NewDecoder(base64.StdEncoding, reader))
+	if err != nil {
+		return v1alpha1.KubeHunterReportData{}, fmt.Errorf("decompressing kube-hunter output: %w", err)
+	}
+	defer func() {
+		_ = gzipReader.Close()
+	}()
+	return OutputFrom(config, gzipReader)
+}
@@ -1 +1,72 @@
 package kubehunter_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kubehunter"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const kubeHunterOutput = `{
+  "vulnerabilities": [
+    {"vid": "KHV002", "severity": "medium"},
+    {"vid": "KHV005", "severity": "high"},
+    {"vid": "KHV050", "severity": "low"}
+  ]
+}`
+
+func TestOutputFrom(t *testing.T) {
+	t.Run("Should keep all findings when max findings is not set", func(t *testing.T) {
+		report, err := kubehunter.OutputFrom(starboard.ConfigData{
+			"kube-hunter.imageRef": "docker.io/aquasec/kube-hunter:0.6.5",
+		}, strings.NewReader(kubeHunterOutput))
+		require.NoError(t, err)
+		assert.False(t, report.Truncated)
+		assert.Len(t, report.Vulnerabilities, 3)
+		assert.Equal(t, v1alpha1.KubeHunterSummary{HighCount: 1, MediumCount: 1, LowCount: 1}, report.Summary)
+	})
+
+	t.Run("Should truncate findings and keep pre-truncation summary", func(t *testing.T) {
+		report, err := kubehunter.OutputFrom(starboard.ConfigData{
+			"kube-hunter.imageRef":    "docker.io/aquasec/kube-hunter:0.6.5",
+			"kube-hunter.maxFindings": "2",
+		}, strings.NewReader(kubeHunterOutput))
+		require.NoError(t, err)
+		assert.True(t, report.Truncated)
+		assert.Len(t, report.Vulnerabilities, 2)
+		assert.Equal(t, v1alpha1.KubeHunterSummary{HighCount: 1, MediumCount: 1, LowCount: 1}, report.Summary)
+	})
+}
+
+func TestCompressedOutputFrom(t *testing.T) {
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	_, err := gzipWriter.Write([]byte(kubeHunterOutput))
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+
+	// Encoded the way base64 of BusyBox wraps lines.
+	var encoded strings.Builder
+	for data := base64.StdEncoding.EncodeToString(compressed.Bytes()); len(data) > 0; {
+		n := 76
+		if len(data) < n {
+			n = len(data)
+		}
+		encoded.WriteString(data[:n] + "\n")
+		data = data[n:]
+	}
+
+	report, err := kubehunter.CompressedOutputFrom(starboard.ConfigData{
+		"kube-hunter.imageRef": "docker.io/aquasec/kube-hunter:0.6.5",
+	}, strings.NewReader(encoded.String()))
+	require.NoError(t, err)
+	assert.Len(t, report.Vulnerabilities, 3)
+	assert.Equal(t, v1alpha1.KubeHunterSummary{HighCount: 1, MediumCount: 1, LowCount: 1}, report.Summary)
+}
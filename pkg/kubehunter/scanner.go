@@ -11,7 +11,6 @@ import (
 	"github.com/hashicorp/go-version"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
@@ -20,12 +19,17 @@ import (
 )
 
 const (
-	kubeHunterContainerName = "kube-hunter"
+	kubeHunterContainerName        = "kube-hunter"
+	kubeHunterResultsContainerName = "kube-hunter-results"
+	kubeHunterResultsVolumeName    = "results"
+	kubeHunterResultsDir           = "/var/starboard"
+	kubeHunterResultsFile          = kubeHunterResultsDir + "/kube-hunter.json"
 )
 
 type Config interface {
 	GetKubeHunterImageRef() (string, error)
 	GetKubeHunterQuick() (bool, error)
+	GetKubeHunterMaxFindings() (int, error)
 }
 
 type Scanner struct {
@@ -77,10 +81,11 @@ func (s *Scanner) Scan(ctx context.Context) (v1alpha1.KubeHunterReportData, erro
 		})
 	}()
 
-	// 3. Get kube-hunter JSON output from the kube-hunter Pod
-	klog.V(3).Infof("Getting logs for %s container in job: %s/%s", kubeHunterContainerName,
+	// 3. Get compressed kube-hunter JSON output from the results container,
+	// which uploads the file written by kube-hunter to the results volume.
+	klog.V(3).Infof("Getting logs for %s container in job: %s/%s", kubeHunterResultsContainerName,
 		job.Namespace, job.Name)
-	logsStream, err := s.logsReader.GetLogsByJobAndContainerName(ctx, job, kubeHunterContainerName)
+	logsStream, err := s.logsReader.GetLogsByJobAndContainerName(ctx, job, kubeHunterResultsContainerName)
 	if err != nil {
 		return v1alpha1.KubeHunterReportData{}, fmt.Errorf("getting logs: %w", err)
 	}
@@ -89,7 +94,7 @@ func (s *Scanner) Scan(ctx context.Context) (v1alpha1.KubeHunterReportData, erro
 	}()
 
 	// 4. Parse the KubeHuberOutput from the logs Reader
-	return OutputFrom(s.config, logsStream)
+	return CompressedOutputFrom(s.config, logsStream)
 }
 
 func (s *Scanner) prepareKubeHunterJob() (*batchv1.Job, error) {
@@ -149,7 +154,32 @@ func (s *Scanner) prepareKubeHunterJob() (*batchv1.Job, error) {
 		}
 	}
 
-	return &batchv1.Job{
+	customPodSecurityContext, err := s.config.GetKubeHunterPodSecurityContext()
+	if err != nil {
+		return nil, err
+	}
+	if customPodSecurityContext != nil {
+		podSecurityContext = customPodSecurityContext
+	}
+	customContainerSecurityContext, err := s.config.GetKubeHunterSecurityContext()
+	if err != nil {
+		return nil, err
+	}
+	if customContainerSecurityContext != nil {
+		containerSecurityContext = customContainerSecurityContext
+	}
+
+	resourceRequirements, err := s.config.GetKubeHunterResourceRequirements()
+	if err != nil {
+		return nil, err
+	}
+
+	defaultResources, err := s.config.GetScanJobDefaultResources()
+	if err != nil {
+		return nil, err
+	}
+
+	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("scan-kubehunterreports-%s", kube.ComputeHash("cluster")),
 			Namespace: starboard.NamespaceName,
@@ -170,30 +200,59 @@ func (s *Scanner) prepareKubeHunterJob() (*batchv1.Job, error) {
 					Affinity:           starboard.LinuxNodeAffinity(),
 					Tolerations:        scanJobTolerations,
 					SecurityContext:    podSecurityContext,
-					Containers: []corev1.Container{
+					Volumes: []corev1.Volume{
+						{
+							Name: kubeHunterResultsVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+					// kube-hunter writes its JSON output to a file in the results
+					// volume instead of the logs. The results container uploads
+					// the file gzip compressed and base64 encoded, so that output
+					// of large clusters fits the logs read by the scanner.
+					InitContainers: []corev1.Container{
 						{
 							Name:                     kubeHunterContainerName,
 							Image:                    imageRef,
 							ImagePullPolicy:          corev1.PullIfNotPresent,
 							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
-							Args:                     kubeHunterArgs,
+							Command:                  []string{"/bin/sh", "-c"},
+							Args:                     append([]string{fmt.Sprintf("kube-hunter \"$@\" > %s", kubeHunterResultsFile), "kube-hunter"}, kubeHunterArgs...),
 							SecurityContext:          containerSecurityContext,
-							Resources: corev1.ResourceRequirements{
-								Limits: corev1.ResourceList{
-									corev1.ResourceCPU:    resource.MustParse("300m"),
-									corev1.ResourceMemory: resource.MustParse("400M"),
-								},
-								Requests: corev1.ResourceList{
-									corev1.ResourceCPU:    resource.MustParse("50m"),
-									corev1.ResourceMemory: resource.MustParse("100M"),
-								},
-							},
+							Resources:                resourceRequirements,
+							VolumeMounts:             resultsVolumeMounts(),
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:                     kubeHunterResultsContainerName,
+							Image:                    imageRef,
+							ImagePullPolicy:          corev1.PullIfNotPresent,
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+							Command:                  []string{"/bin/sh", "-c"},
+							Args:                     []string{fmt.Sprintf("gzip -c %s | base64", kubeHunterResultsFile)},
+							SecurityContext:          containerSecurityContext,
+							Resources:                resourceRequirements,
+							VolumeMounts:             resultsVolumeMounts(),
 						},
 					},
 				},
 			},
 		},
-	}, nil
+	}
+	kube.SetDefaultResources(&job.Spec.Template.Spec, defaultResources)
+	return job, nil
+}
+
+func resultsVolumeMounts() []corev1.VolumeMount {
+	return []corev1.VolumeMount{
+		{
+			Name:      kubeHunterResultsVolumeName,
+			MountPath: kubeHunterResultsDir,
+		},
+	}
 }
 
 func isAtLeast(ver string, targetVer string) bool {
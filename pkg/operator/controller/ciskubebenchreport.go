@@ -7,9 +7,13 @@ import (
 	"fmt"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/kubebench"
+	"github.com/aquasecurity/starboard/pkg/operator/drain"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
+	"github.com/aquasecurity/starboard/pkg/operator/throttle"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/go-logr/logr"
 	batchv1 "k8s.io/api/batch/v1"
@@ -21,7 +25,10 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // CISKubeBenchReportReconciler reconciles corev1.Node and corev1.Job objects
@@ -39,27 +46,53 @@ type CISKubeBenchReportReconciler struct {
 	client.Client
 	kube.LogsReader
 	LimitChecker
+	*ScanJobsSwitch
 	kubebench.ReadWriter
 	kubebench.Plugin
 	starboard.ConfigData
+	// ScanFailures records failures of scan jobs for the scan summary. It is
+	// optional.
+	ScanFailures metrics.ScanFailureRecorder
+	// Drain lets reconciliations of scan jobs in flight finish when the
+	// operator shuts down. It is optional.
+	Drain *drain.Drain
+	// ResumedJobs are finished scan jobs enqueued when the operator starts
+	// leading. It is optional.
+	ResumedJobs <-chan event.GenericEvent
+	// Throttle requeues reconciliations throttled by the API server after
+	// backoff. It is optional.
+	Throttle *throttle.Throttle
+	// RequeuedResources are scanned resources of stuck scan jobs which were
+	// deleted. It is optional.
+	RequeuedResources <-chan event.GenericEvent
+	// ImagePolicy restricts the kube-bench image of scan jobs. It is optional.
+	ImagePolicy starboard.ImagePolicy
 }
 
 func (r *CISKubeBenchReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	err := ctrl.NewControllerManagedBy(mgr).
-		For(&corev1.Node{}, builder.WithPredicates(IsLinuxNode)).
-		Owns(&v1alpha1.CISKubeBenchReport{}).
-		Complete(r.reconcileNodes())
+	nodes := ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Node{}).
+		Owns(&v1alpha1.CISKubeBenchReport{})
+	if r.RequeuedResources != nil {
+		nodes = nodes.Watches(&source.Channel{Source: r.RequeuedResources}, &handler.EnqueueRequestForObject{},
+			builder.WithPredicates(HasSameType(&corev1.Node{})))
+	}
+	err := nodes.Complete(r.Throttle.Reconciler(r.reconcileNodes()))
 	if err != nil {
 		return err
 	}
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&batchv1.Job{}, builder.WithPredicates(
-			InNamespace(r.Config.Namespace),
-			ManagedByStarboardOperator,
-			IsKubeBenchReportScan,
-			JobHasAnyCondition,
-		)).
-		Complete(r.reconcileJobs())
+	jobPredicates := builder.WithPredicates(
+		InNamespace(r.Config.Namespace),
+		ManagedByStarboardOperator,
+		IsKubeBenchReportScan,
+		JobHasAnyCondition,
+	)
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1.Job{}, jobPredicates)
+	if r.ResumedJobs != nil {
+		b = b.Watches(&source.Channel{Source: r.ResumedJobs}, &handler.EnqueueRequestForObject{}, jobPredicates)
+	}
+	return b.Complete(r.Throttle.Reconciler(r.Drain.Reconciler(r.reconcileJobs())))
 }
 
 func (r *CISKubeBenchReportReconciler) reconcileNodes() reconcile.Func {
@@ -78,6 +111,14 @@ func (r *CISKubeBenchReportReconciler) reconcileNodes() reconcile.Func {
 			return ctrl.Result{}, fmt.Errorf("getting node from cache: %w", err)
 		}
 
+		skipSelector, err := r.ConfigData.GetKubeBenchNodeSkipSelector()
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if reason := kubebench.NodeSkipReason(*node, skipSelector); reason != "" {
+			return ctrl.Result{}, r.skipNode(ctx, node, reason)
+		}
+
 		log.V(1).Info("Checking whether CIS Kubernetes Benchmark report exists")
 		hasReport, err := r.hasReport(ctx, node)
 		if err != nil {
@@ -116,7 +157,9 @@ func (r *CISKubeBenchReportReconciler) reconcileNodes() reconcile.Func {
 			return ctrl.Result{}, fmt.Errorf("preparing job: %w", err)
 		}
 
-		log.V(1).Info("Scheduling CIS Kubernetes Benchmark checks")
+		r.ScanJobsSwitch.ApplyTo(job)
+
+		log.V(1).Info("Scheduling CIS Kubernetes Benchmark checks", "suspended", r.ScanJobsSwitch.Suspended())
 		err = r.Client.Create(ctx, job)
 		if err != nil {
 			if errors.IsAlreadyExists(err) {
@@ -129,12 +172,65 @@ func (r *CISKubeBenchReportReconciler) reconcileNodes() reconcile.Func {
 	}
 }
 
+// hasReport returns true if the given node has a report with results of CIS
+// Kubernetes Benchmark checks. A report of a node which was skipped does not
+// count, so that the node is scanned once it becomes eligible.
 func (r *CISKubeBenchReportReconciler) hasReport(ctx context.Context, node *corev1.Node) (bool, error) {
 	report, err := r.ReadWriter.FindByOwner(ctx, kube.ObjectRef{Kind: kube.KindNode, Name: node.Name})
 	if err != nil {
 		return false, err
 	}
-	return report != nil, nil
+	if report == nil {
+		return false, nil
+	}
+	_, skipped := kubebench.SkipReason(*report)
+	return !skipped, nil
+}
+
+// skipNode writes a report with the reason why the given node is not eligible
+// for CIS Kubernetes Benchmark checks instead of scheduling a scan job. A scan
+// job which was scheduled before is deleted, because it can never run on the
+// node and would count against the limit of concurrent scan jobs forever.
+func (r *CISKubeBenchReportReconciler) skipNode(ctx context.Context, node *corev1.Node, reason string) error {
+	log := r.Logger.WithValues("node", node.Name, "reason", reason)
+
+	_, job, err := r.hasScanJob(ctx, node)
+	if err != nil {
+		return fmt.Errorf("checking whether scan job has been scheduled: %w", err)
+	}
+	if job != nil {
+		log.V(1).Info("Deleting scan job of node which is not eligible",
+			"job", fmt.Sprintf("%s/%s", job.Namespace, job.Name))
+		if err := r.deleteJob(ctx, job); err != nil {
+			return err
+		}
+	}
+
+	existing, err := r.ReadWriter.FindByOwner(ctx, kube.ObjectRef{Kind: kube.KindNode, Name: node.Name})
+	if err != nil {
+		return fmt.Errorf("finding report: %w", err)
+	}
+	status := kubebench.SkippedStatus(reason)
+	if existing != nil && existing.Report.Status == status {
+		log.V(1).Info("Node has already been skipped")
+		return nil
+	}
+
+	report, err := kubebench.NewBuilder(r.Client.Scheme()).
+		Controller(node).
+		Data(v1alpha1.CISKubeBenchReportData{
+			UpdateTimestamp: metav1.NewTime(ext.NewSystemClock().Now()),
+			Status:          status,
+		}).
+		Get()
+	if err != nil {
+		return fmt.Errorf("building report: %w", err)
+	}
+	log.V(1).Info("Skipping CIS Kubernetes Benchmark checks of node which is not eligible")
+	if err := r.ReadWriter.Write(ctx, report); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	return nil
 }
 
 func (r *CISKubeBenchReportReconciler) hasScanJob(ctx context.Context, node *corev1.Node) (bool, *batchv1.Job, error) {
@@ -164,6 +260,16 @@ func (r *CISKubeBenchReportReconciler) newScanJob(node *corev1.Node) (*batchv1.J
 	}
 	templateSpec.Tolerations = append(templateSpec.Tolerations, scanJobTolerations...)
 
+	defaultResources, err := r.ConfigData.GetScanJobDefaultResources()
+	if err != nil {
+		return nil, err
+	}
+	kube.SetDefaultResources(&templateSpec, defaultResources)
+	err = r.ImagePolicy.VerifyPodSpec(templateSpec, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	scanJobAnnotations, err := r.ConfigData.GetScanJobAnnotations()
 	if err != nil {
 		return nil, err
@@ -229,12 +335,13 @@ func (r *CISKubeBenchReportReconciler) reconcileJobs() reconcile.Func {
 			return ctrl.Result{}, fmt.Errorf("getting job from cache: %w", err)
 		}
 
-		if len(job.Status.Conditions) == 0 {
+		condition, finished := kube.JobFinishedCondition(job)
+		if !finished {
 			log.V(1).Info("Ignoring job without conditions")
 			return ctrl.Result{}, nil
 		}
 
-		switch jobCondition := job.Status.Conditions[0].Type; jobCondition {
+		switch jobCondition := condition.Type; jobCondition {
 		case batchv1.JobComplete:
 			err = r.processCompleteScanJob(ctx, job)
 		case batchv1.JobFailed:
@@ -344,6 +451,10 @@ func (r *CISKubeBenchReportReconciler) processFailedScanJob(ctx context.Context,
 			continue
 		}
 		log.Error(nil, "Scan job container", "container", container, "status.reason", status.Reason, "status.message", status.Message)
+		if r.ScanFailures != nil {
+			owner, _ := kube.ObjectRefFromObjectMeta(job.ObjectMeta)
+			r.ScanFailures.RecordScanFailure(v1alpha1.CISKubeBenchReportKind, owner, metrics.ScanFailureReason(container, status))
+		}
 	}
 	log.V(1).Info("Deleting failed scan job")
 	return r.deleteJob(ctx, job)
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/risk"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClusterRiskReportWriter periodically writes the risk score of the cluster,
+// computed from security reports in the cache, to the v1alpha1.ClusterRiskReport
+// named risk.ReportName. Weights of components are read from the starboard
+// ConfigMap at each refresh, so that they can be changed at runtime.
+type ClusterRiskReportWriter struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	ext.Clock
+}
+
+// Start refreshes the cluster risk report every etc.Config
+// ClusterRiskReportInterval until the given context is done. It implements
+// manager.Runnable.
+func (w *ClusterRiskReportWriter) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		// Do not stop the manager if the refresh fails.
+		if err := w.Refresh(ctx); err != nil {
+			w.Logger.Error(err, "Unable to refresh cluster risk report")
+		}
+	}, w.Config.ClusterRiskReportInterval)
+	return nil
+}
+
+// Refresh computes the current risk score and writes it to the
+// v1alpha1.ClusterRiskReport.
+func (w *ClusterRiskReportWriter) Refresh(ctx context.Context) error {
+	weights, err := w.weights(ctx)
+	if err != nil {
+		return err
+	}
+	inputs, err := risk.Measure(ctx, w.Client)
+	if err != nil {
+		return err
+	}
+	data := risk.Evaluate(inputs, weights)
+	data.UpdateTimestamp = metav1.NewTime(w.Clock.Now())
+	w.Logger.V(1).Info("Computed cluster risk score", "score", data.Score)
+
+	var report v1alpha1.ClusterRiskReport
+	err = w.Client.Get(ctx, client.ObjectKey{Name: risk.ReportName}, &report)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("getting cluster risk report: %w", err)
+		}
+		report = v1alpha1.ClusterRiskReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: risk.ReportName,
+				Labels: map[string]string{
+					starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+				},
+			},
+			Report: data,
+		}
+		return w.Client.Create(ctx, &report)
+	}
+	report.Report = data
+	return w.Client.Update(ctx, &report)
+}
+
+func (w *ClusterRiskReportWriter) weights(ctx context.Context) (risk.Weights, error) {
+	var cm corev1.ConfigMap
+	err := w.Client.Get(ctx, client.ObjectKey{Namespace: w.Config.Namespace, Name: starboard.ConfigMapName}, &cm)
+	if err != nil && !errors.IsNotFound(err) {
+		return risk.Weights{}, fmt.Errorf("getting starboard ConfigMap: %w", err)
+	}
+	return risk.GetWeights(cm.Data)
+}
@@ -0,0 +1,86 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/risk"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("ClusterRiskReportWriter", func() {
+
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	newWriter := func(objects ...client.Object) *controller.ClusterRiskReportWriter {
+		return &controller.ClusterRiskReportWriter{
+			Logger: logr.Discard(),
+			Config: etc.Config{Namespace: "starboard-system"},
+			Client: fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build(),
+			Clock:  ext.NewFixedClock(now),
+		}
+	}
+
+	kubeBenchReport := &v1alpha1.CISKubeBenchReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "kind-control-plane"},
+		Report:     v1alpha1.CISKubeBenchReportData{Summary: v1alpha1.CISKubeBenchSummary{FailCount: 4}},
+	}
+
+	getReport := func(writer *controller.ClusterRiskReportWriter) v1alpha1.ClusterRiskReport {
+		var report v1alpha1.ClusterRiskReport
+		Expect(writer.Client.Get(context.TODO(), client.ObjectKey{Name: risk.ReportName}, &report)).To(Succeed())
+		return report
+	}
+
+	It("Should create report with default weights", func() {
+		writer := newWriter(kubeBenchReport.DeepCopy())
+		Expect(writer.Refresh(context.TODO())).To(Succeed())
+
+		report := getReport(writer)
+		Expect(report.Labels).To(HaveKeyWithValue(starboard.LabelK8SAppManagedBy, starboard.AppStarboard))
+		Expect(report.Report.UpdateTimestamp.Time).To(BeTemporally("==", now))
+		Expect(report.Report.Score).To(Equal(2.0))
+		Expect(report.Report.Components).To(ContainElement(v1alpha1.RiskComponent{
+			Name:   risk.ComponentFailedCISChecks,
+			Value:  4,
+			Weight: 0.5,
+			Score:  2,
+		}))
+	})
+
+	It("Should update report with weights of starboard ConfigMap", func() {
+		writer := newWriter(kubeBenchReport.DeepCopy(), &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: starboard.ConfigMapName, Namespace: "starboard-system"},
+			Data: map[string]string{
+				risk.KeyWeightFailedCISChecks: "3",
+			},
+		})
+		Expect(writer.Refresh(context.TODO())).To(Succeed())
+		Expect(writer.Refresh(context.TODO())).To(Succeed())
+
+		Expect(getReport(writer).Report.Score).To(Equal(12.0))
+	})
+
+	It("Should return error for invalid weights", func() {
+		writer := newWriter(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: starboard.ConfigMapName, Namespace: "starboard-system"},
+			Data: map[string]string{
+				risk.KeyWeightFailedCISChecks: "-3",
+			},
+		})
+		Expect(writer.Refresh(context.TODO())).To(MatchError(
+			`property riskScore.weights.failedCISChecks must be a non-negative number, got "-3"`))
+	})
+})
@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/compliance"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ReasonInvalidComplianceSpec is the reason of warning events of
+	// ConfigMaps whose compliance spec cannot be parsed or is invalid.
+	ReasonInvalidComplianceSpec = "InvalidComplianceSpec"
+	// ReasonComplianceSpecConflict is the reason of warning events of
+	// ConfigMaps whose compliance spec has the name of a ClusterComplianceReport
+	// which is not created from the ConfigMap.
+	ReasonComplianceSpecConflict = "ComplianceSpecConflict"
+	// ReasonComplianceSpecLoaded is the reason of events of ConfigMaps whose
+	// compliance spec was loaded into a ClusterComplianceReport.
+	ReasonComplianceSpecLoaded = "ComplianceSpecLoaded"
+)
+
+// ComplianceSpecReconciler creates ClusterComplianceReports from custom
+// compliance specs held by ConfigMaps in the operator namespace, which are
+// labeled with starboard.LabelComplianceSpec. The spec is stored under the
+// compliance.SpecConfigMapKey key. Reports are generated by the cluster
+// compliance report reconciler according to their cron expression, and are
+// deleted once their ConfigMap is deleted or no longer labeled.
+//
+// Invalid specs are reported with events of the ConfigMap instead of being
+// retried, because they can only be fixed by editing the ConfigMap.
+type ComplianceSpecReconciler struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	Recorder record.EventRecorder
+}
+
+func (r *ComplianceSpecReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.ConfigMap{}, builder.WithPredicates(
+			predicate.InNamespace(r.Config.Namespace),
+			predicate.IsComplianceSpec,
+		)).
+		Complete(r.ReconcileConfigMap())
+}
+
+// ReconcileConfigMap returns reconcile.Func that creates or updates the
+// ClusterComplianceReport of a compliance spec ConfigMap.
+func (r *ComplianceSpecReconciler) ReconcileConfigMap() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("configMap", req.NamespacedName)
+
+		cm := &corev1.ConfigMap{}
+		err := r.Client.Get(ctx, req.NamespacedName, cm)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.V(1).Info("Deleting compliance reports of deleted ConfigMap")
+				return ctrl.Result{}, r.deleteReports(ctx, req.Name, "")
+			}
+			return ctrl.Result{}, fmt.Errorf("getting ConfigMap from cache: %w", err)
+		}
+		if cm.Labels[starboard.LabelComplianceSpec] != "true" {
+			log.V(1).Info("Deleting compliance reports of ConfigMap which is no longer labeled")
+			return ctrl.Result{}, r.deleteReports(ctx, cm.Name, "")
+		}
+
+		spec, err := compliance.ParseSpec([]byte(cm.Data[compliance.SpecConfigMapKey]))
+		if err != nil {
+			log.Info("Ignoring invalid compliance spec", "error", err.Error())
+			r.Recorder.Event(cm, corev1.EventTypeWarning, ReasonInvalidComplianceSpec, err.Error())
+			return ctrl.Result{}, nil
+		}
+		name := strings.ToLower(spec.Name)
+		// The report of the previous name of a renamed spec is replaced.
+		err = r.deleteReports(ctx, cm.Name, name)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		var report v1alpha1.ClusterComplianceReport
+		err = r.Client.Get(ctx, types.NamespacedName{Name: name}, &report)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return ctrl.Result{}, fmt.Errorf("getting ClusterComplianceReport: %w", err)
+			}
+			report = v1alpha1.ClusterComplianceReport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					Labels: map[string]string{
+						starboard.LabelComplianceSpecConfigMap: cm.Name,
+					},
+				},
+				Spec: spec,
+			}
+			log.V(1).Info("Creating ClusterComplianceReport", "report", name)
+			err = r.Client.Create(ctx, &report)
+			return r.loaded(cm, "Created", name, err)
+		}
+		if report.Labels[starboard.LabelComplianceSpecConfigMap] != cm.Name {
+			r.Recorder.Eventf(cm, corev1.EventTypeWarning, ReasonComplianceSpecConflict,
+				"ClusterComplianceReport %s already exists and is not created from this ConfigMap", name)
+			return ctrl.Result{}, nil
+		}
+		if equality.Semantic.DeepEqual(report.Spec, spec) {
+			log.V(1).Info("ClusterComplianceReport is up to date", "report", name)
+			return ctrl.Result{}, nil
+		}
+		report.Spec = spec
+		log.V(1).Info("Updating ClusterComplianceReport", "report", name)
+		err = r.Client.Update(ctx, &report)
+		return r.loaded(cm, "Updated", name, err)
+	}
+}
+
+// loaded records an event of the ConfigMap after the ClusterComplianceReport
+// with the given name was created or updated. Specs rejected by validation
+// of the API server are reported like other invalid specs.
+func (r *ComplianceSpecReconciler) loaded(cm *corev1.ConfigMap, verb, name string, err error) (ctrl.Result, error) {
+	if errors.IsInvalid(err) {
+		r.Recorder.Event(cm, corev1.EventTypeWarning, ReasonInvalidComplianceSpec, err.Error())
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("%s ClusterComplianceReport: %w", strings.ToLower(verb), err)
+	}
+	r.Recorder.Eventf(cm, corev1.EventTypeNormal, ReasonComplianceSpecLoaded, "%s ClusterComplianceReport %s", verb, name)
+	return ctrl.Result{}, nil
+}
+
+// deleteReports deletes ClusterComplianceReports created from the ConfigMap
+// with the given name, except the report with the keep name.
+func (r *ComplianceSpecReconciler) deleteReports(ctx context.Context, configMapName, keep string) error {
+	var reportList v1alpha1.ClusterComplianceReportList
+	err := r.Client.List(ctx, &reportList, client.MatchingLabels{
+		starboard.LabelComplianceSpecConfigMap: configMapName,
+	})
+	if err != nil {
+		return fmt.Errorf("listing ClusterComplianceReports: %w", err)
+	}
+	for i := range reportList.Items {
+		report := &reportList.Items[i]
+		if report.Name == keep {
+			continue
+		}
+		r.Logger.V(1).Info("Deleting ClusterComplianceReport", "report", report.Name, "configMap", configMapName)
+		err = r.Client.Delete(ctx, report)
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("deleting ClusterComplianceReport: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("ComplianceSpecReconciler", func() {
+
+	const spec = `name: ACME
+description: ACME hardening standard
+version: "1.0"
+cron: "0 */6 * * *"
+controls:
+  - name: Non-root containers
+    id: '1.0'
+    kinds:
+      - Workload
+    mapping:
+      scanner: config-audit
+      checks:
+        - id: KSV012
+    severity: MEDIUM
+`
+
+	newConfigMap := func(data string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "acme-compliance-spec",
+				Namespace: "starboard",
+				Labels: map[string]string{
+					starboard.LabelComplianceSpec: "true",
+				},
+			},
+			Data: map[string]string{
+				"spec.yaml": data,
+			},
+		}
+	}
+
+	newReconciler := func(objects ...client.Object) (*controller.ComplianceSpecReconciler, client.Client, *record.FakeRecorder) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build()
+		recorder := record.NewFakeRecorder(10)
+		return &controller.ComplianceSpecReconciler{
+			Logger:   logr.Discard(),
+			Config:   etc.Config{Namespace: "starboard"},
+			Client:   c,
+			Recorder: recorder,
+		}, c, recorder
+	}
+
+	request := ctrl.Request{NamespacedName: types.NamespacedName{
+		Namespace: "starboard",
+		Name:      "acme-compliance-spec",
+	}}
+
+	It("Should create report from spec of config map", func() {
+		reconciler, c, recorder := newReconciler(newConfigMap(spec))
+
+		_, err := reconciler.ReconcileConfigMap()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		var report v1alpha1.ClusterComplianceReport
+		Expect(c.Get(context.Background(), types.NamespacedName{Name: "acme"}, &report)).To(Succeed())
+		Expect(report.Labels).To(Equal(map[string]string{starboard.LabelComplianceSpecConfigMap: "acme-compliance-spec"}))
+		Expect(report.Spec.Name).To(Equal("ACME"))
+		Expect(report.Spec.Cron).To(Equal("0 */6 * * *"))
+		Expect(report.Spec.Controls).To(HaveLen(1))
+		Expect(recorder.Events).To(Receive(Equal("Normal ComplianceSpecLoaded Created ClusterComplianceReport acme")))
+	})
+
+	It("Should update report when spec of config map changes", func() {
+		reconciler, c, recorder := newReconciler(newConfigMap(spec))
+		_, err := reconciler.ReconcileConfigMap()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.Events).To(Receive())
+
+		cm := &corev1.ConfigMap{}
+		Expect(c.Get(context.Background(), request.NamespacedName, cm)).To(Succeed())
+		cm.Data["spec.yaml"] = spec + "excludedControls: ['1.0']\n"
+		Expect(c.Update(context.Background(), cm)).To(Succeed())
+
+		_, err = reconciler.ReconcileConfigMap()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		var report v1alpha1.ClusterComplianceReport
+		Expect(c.Get(context.Background(), types.NamespacedName{Name: "acme"}, &report)).To(Succeed())
+		Expect(report.Spec.ExcludedControls).To(Equal([]string{"1.0"}))
+		Expect(recorder.Events).To(Receive(Equal("Normal ComplianceSpecLoaded Updated ClusterComplianceReport acme")))
+	})
+
+	It("Should record warning event of invalid spec", func() {
+		reconciler, c, recorder := newReconciler(newConfigMap("name: acme\ncron: daily\n"))
+
+		result, err := reconciler.ReconcileConfigMap()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+
+		var reports v1alpha1.ClusterComplianceReportList
+		Expect(c.List(context.Background(), &reports)).To(Succeed())
+		Expect(reports.Items).To(BeEmpty())
+		Expect(recorder.Events).To(Receive(Equal("Warning InvalidComplianceSpec compliance spec version is required")))
+	})
+
+	It("Should not overwrite report which is not created from config map", func() {
+		existing := &v1alpha1.ClusterComplianceReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "acme"},
+			Spec:       v1alpha1.ReportSpec{Name: "acme", Cron: "* * * * *"},
+		}
+		reconciler, c, recorder := newReconciler(newConfigMap(spec), existing)
+
+		_, err := reconciler.ReconcileConfigMap()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		var report v1alpha1.ClusterComplianceReport
+		Expect(c.Get(context.Background(), types.NamespacedName{Name: "acme"}, &report)).To(Succeed())
+		Expect(report.Spec.Cron).To(Equal("* * * * *"))
+		Expect(recorder.Events).To(Receive(Equal("Warning ComplianceSpecConflict ClusterComplianceReport acme already exists and is not created from this ConfigMap")))
+	})
+
+	It("Should delete report when config map is deleted", func() {
+		report := &v1alpha1.ClusterComplianceReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "acme",
+				Labels: map[string]string{starboard.LabelComplianceSpecConfigMap: "acme-compliance-spec"},
+			},
+		}
+		other := &v1alpha1.ClusterComplianceReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "nsa"},
+		}
+		reconciler, c, _ := newReconciler(report, other)
+
+		_, err := reconciler.ReconcileConfigMap()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = c.Get(context.Background(), types.NamespacedName{Name: "acme"}, &v1alpha1.ClusterComplianceReport{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		Expect(c.Get(context.Background(), types.NamespacedName{Name: "nsa"}, &v1alpha1.ClusterComplianceReport{})).To(Succeed())
+	})
+
+	It("Should replace report when spec is renamed", func() {
+		previous := &v1alpha1.ClusterComplianceReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "acme-draft",
+				Labels: map[string]string{starboard.LabelComplianceSpecConfigMap: "acme-compliance-spec"},
+			},
+		}
+		reconciler, c, _ := newReconciler(newConfigMap(spec), previous)
+
+		_, err := reconciler.ReconcileConfigMap()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = c.Get(context.Background(), types.NamespacedName{Name: "acme-draft"}, &v1alpha1.ClusterComplianceReport{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		Expect(c.Get(context.Background(), types.NamespacedName{Name: "acme"}, &v1alpha1.ClusterComplianceReport{})).To(Succeed())
+	})
+})
@@ -9,13 +9,17 @@ import (
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/configauditreport"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/drain"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
+	"github.com/aquasecurity/starboard/pkg/operator/throttle"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -24,7 +28,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 type ConfigAuditReportReconciler struct {
@@ -34,10 +41,26 @@ type ConfigAuditReportReconciler struct {
 	client.Client
 	kube.ObjectResolver
 	LimitChecker
+	*ScanJobsSwitch
 	kube.LogsReader
 	configauditreport.Plugin
 	starboard.PluginContext
 	configauditreport.ReadWriter
+	// ScanFailures records failures of scan jobs for the scan summary. It is
+	// optional.
+	ScanFailures metrics.ScanFailureRecorder
+	// Drain lets reconciliations of scan jobs in flight finish when the
+	// operator shuts down. It is optional.
+	Drain *drain.Drain
+	// ResumedJobs are finished scan jobs enqueued when the operator starts
+	// leading. It is optional.
+	ResumedJobs <-chan event.GenericEvent
+	// Throttle requeues reconciliations throttled by the API server after
+	// backoff. It is optional.
+	Throttle *throttle.Throttle
+	// RequeuedResources are scanned resources of stuck scan jobs which were
+	// deleted. It is optional.
+	RequeuedResources <-chan event.GenericEvent
 }
 
 func (r *ConfigAuditReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -59,6 +82,7 @@ func (r *ConfigAuditReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		{kind: kube.KindCronJob, forObject: &batchv1beta1.CronJob{}, ownsObject: &v1alpha1.ConfigAuditReport{}},
 		{kind: kube.KindJob, forObject: &batchv1.Job{}, ownsObject: &v1alpha1.ConfigAuditReport{}},
 		{kind: kube.KindService, forObject: &corev1.Service{}, ownsObject: &v1alpha1.ConfigAuditReport{}},
+		{kind: kube.KindIngress, forObject: &networkingv1.Ingress{}, ownsObject: &v1alpha1.ConfigAuditReport{}},
 		{kind: kube.KindConfigMap, forObject: &corev1.ConfigMap{}, ownsObject: &v1alpha1.ConfigAuditReport{}},
 		{kind: kube.KindRole, forObject: &rbacv1.Role{}, ownsObject: &v1alpha1.ConfigAuditReport{}},
 		{kind: kube.KindRoleBinding, forObject: &rbacv1.RoleBinding{}, ownsObject: &v1alpha1.ConfigAuditReport{}},
@@ -74,20 +98,31 @@ func (r *ConfigAuditReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		{kind: kube.KindCustomResourceDefinition, forObject: &apiextensionsv1.CustomResourceDefinition{}, ownsObject: &v1alpha1.ClusterConfigAuditReport{}},
 	}
 
+	// All controllers of resources watch the same channel, which sends each
+	// requeued resource to all of them.
+	var requeued *source.Channel
+	if r.RequeuedResources != nil {
+		requeued = &source.Channel{Source: r.RequeuedResources}
+	}
+
 	for _, resource := range resources {
 		if !r.supportsKind(resource.kind) {
 			r.Logger.Info("Skipping unsupported kind", "pluginName", r.PluginContext.GetName(), "kind", resource.kind)
 			continue
 		}
-		err = ctrl.NewControllerManagedBy(mgr).
+		b := ctrl.NewControllerManagedBy(mgr).
 			For(resource.forObject, builder.WithPredicates(
 				Not(ManagedByStarboardOperator),
 				Not(IsLeaderElectionResource),
 				Not(IsBeingTerminated),
 				installModePredicate,
 			)).
-			Owns(resource.ownsObject).
-			Complete(r.reconcileResource(resource.kind))
+			Owns(resource.ownsObject)
+		if requeued != nil {
+			b = b.Watches(requeued, &handler.EnqueueRequestForObject{},
+				builder.WithPredicates(HasSameType(resource.forObject)))
+		}
+		err = b.Complete(r.Throttle.Reconciler(r.reconcileResource(resource.kind)))
 		if err != nil {
 			return fmt.Errorf("constructing controller for %s: %w", resource.kind, err)
 		}
@@ -98,26 +133,34 @@ func (r *ConfigAuditReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			r.Logger.Info("Skipping unsupported kind", "pluginName", r.PluginContext.GetName(), "kind", resource.kind)
 			continue
 		}
-		err = ctrl.NewControllerManagedBy(mgr).
+		b := ctrl.NewControllerManagedBy(mgr).
 			For(resource.forObject, builder.WithPredicates(
 				Not(ManagedByStarboardOperator),
 				Not(IsBeingTerminated),
 			)).
-			Owns(resource.ownsObject).
-			Complete(r.reconcileResource(resource.kind))
+			Owns(resource.ownsObject)
+		if requeued != nil {
+			b = b.Watches(requeued, &handler.EnqueueRequestForObject{},
+				builder.WithPredicates(HasSameType(resource.forObject)))
+		}
+		err = b.Complete(r.Throttle.Reconciler(r.reconcileResource(resource.kind)))
 		if err != nil {
 			return fmt.Errorf("constructing controller for %s: %w", resource.kind, err)
 		}
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&batchv1.Job{}, builder.WithPredicates(
-			InNamespace(r.Config.Namespace),
-			ManagedByStarboardOperator,
-			IsConfigAuditReportScan,
-			JobHasAnyCondition,
-		)).
-		Complete(r.reconcileJobs())
+	jobPredicates := builder.WithPredicates(
+		InNamespace(r.Config.Namespace),
+		ManagedByStarboardOperator,
+		IsConfigAuditReportScan,
+		JobHasAnyCondition,
+	)
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1.Job{}, jobPredicates)
+	if r.ResumedJobs != nil {
+		b = b.Watches(&source.Channel{Source: r.ResumedJobs}, &handler.EnqueueRequestForObject{}, jobPredicates)
+	}
+	return b.Complete(r.Throttle.Reconciler(r.Drain.Reconciler(r.reconcileJobs())))
 }
 
 func (r *ConfigAuditReportReconciler) supportsKind(kind kube.Kind) bool {
@@ -179,12 +222,12 @@ func (r *ConfigAuditReportReconciler) reconcileResource(resourceKind kube.Kind)
 			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
 		}
 
-		resourceSpecHash, err := kube.ComputeSpecHash(resource)
+		resourceSpecHash, err := configauditreport.ResourceHash(r.PluginContext, resource)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("computing spec hash: %w", err)
 		}
 
-		pluginConfigHash, err := r.Plugin.ConfigHash(r.PluginContext, kube.Kind(resource.GetObjectKind().GroupVersionKind().Kind))
+		pluginConfigHash, err := configauditreport.ConfigHash(r.Plugin, r.PluginContext, kube.Kind(resource.GetObjectKind().GroupVersionKind().Kind))
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("computing plugin config hash: %w", err)
 		}
@@ -242,6 +285,11 @@ func (r *ConfigAuditReportReconciler) reconcileResource(resourceKind kube.Kind)
 			return ctrl.Result{}, fmt.Errorf("getting scan job template labels: %w", err)
 		}
 
+		scanJobTTL, err := starboard.GetScanJobTTLSecondsAfterFinished(r.PluginContext, r.ConfigData)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("getting scan job TTL: %w", err)
+		}
+
 		job, secrets, err := configauditreport.NewScanJobBuilder().
 			WithPlugin(r.Plugin).
 			WithPluginContext(r.PluginContext).
@@ -250,6 +298,7 @@ func (r *ConfigAuditReportReconciler) reconcileResource(resourceKind kube.Kind)
 			WithTolerations(scanJobTolerations).
 			WithAnnotations(scanJobAnnotations).
 			WithPodTemplateLabels(scanJobPodTemplateLabels).
+			WithTTLSecondsAfterFinished(scanJobTTL).
 			Get()
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("constructing scan job: %w", err)
@@ -266,11 +315,14 @@ func (r *ConfigAuditReportReconciler) reconcileResource(resourceKind kube.Kind)
 			}
 		}
 
-		log.V(1).Info("Scheduling configuration audit", "secrets", len(secrets))
+		r.ScanJobsSwitch.ApplyTo(job)
+
+		log.V(1).Info("Scheduling configuration audit", "secrets", len(secrets), "suspended", r.ScanJobsSwitch.Suspended())
 		err = r.Client.Create(ctx, job)
 		if err != nil {
 			if errors.IsAlreadyExists(err) {
-				// TODO Delete secrets that were created in the previous step. Alternatively we can delete them on schedule.
+				// Secrets created in the previous step are either owned by the existing
+				// scan job or eventually deleted by ScanJobSecretReconciler.
 				log.V(1).Info("Job already exists", "jobName", job.Name)
 				return ctrl.Result{}, nil
 			}
@@ -351,12 +403,13 @@ func (r *ConfigAuditReportReconciler) reconcileJobs() reconcile.Func {
 			return ctrl.Result{}, fmt.Errorf("getting job from cache: %w", err)
 		}
 
-		if len(job.Status.Conditions) == 0 {
+		condition, finished := kube.JobFinishedCondition(job)
+		if !finished {
 			log.V(1).Info("Ignoring job without conditions")
 			return ctrl.Result{}, nil
 		}
 
-		switch jobCondition := job.Status.Conditions[0].Type; jobCondition {
+		switch jobCondition := condition.Type; jobCondition {
 		case batchv1.JobComplete:
 			err = r.processCompleteScanJob(ctx, job)
 		case batchv1.JobFailed:
@@ -420,10 +473,22 @@ func (r *ConfigAuditReportReconciler) processCompleteScanJob(ctx context.Context
 		return fmt.Errorf("getting logs: %w", err)
 	}
 
-	reportData, err := r.Plugin.ParseConfigAuditReportData(r.PluginContext, logsStream)
+	pluginContext := starboard.WithScannerImage(r.PluginContext, job.Annotations[starboard.AnnotationScannerImage])
+	reportData, err := r.Plugin.ParseConfigAuditReportData(pluginContext, logsStream)
 	defer func() {
 		_ = logsStream.Close()
 	}()
+	if err != nil {
+		if starboard.IsScannerMismatch(err) {
+			// Do not persist questionable results. The resource is scanned
+			// again as it has no report.
+			log.Error(err, "Rejected scan job results", "failure", "ScannerMismatch")
+			return r.deleteJob(ctx, job)
+		}
+		return err
+	}
+
+	maxMessageLength, err := r.ConfigData.GetConfigAuditMaxMessageLength()
 	if err != nil {
 		return err
 	}
@@ -432,7 +497,10 @@ func (r *ConfigAuditReportReconciler) processCompleteScanJob(ctx context.Context
 		Controller(owner).
 		ResourceSpecHash(resourceSpecHash).
 		PluginConfigHash(pluginConfigHash).
-		Data(reportData)
+		Data(reportData).
+		SkipOwnerReference(r.Config.SkipOwnerReference()).
+		MaxMessageLength(maxMessageLength).
+		StoreFullMessages(r.ConfigData.ConfigAuditStoreFullMessages(), r.Config.Namespace)
 	err = reportBuilder.Write(ctx, r.ReadWriter)
 	if err != nil {
 		return err
@@ -462,6 +530,10 @@ func (r *ConfigAuditReportReconciler) processFailedScanJob(ctx context.Context,
 			continue
 		}
 		log.Error(nil, "Scan job container", "container", container, "status.reason", status.Reason, "status.message", status.Message)
+		if r.ScanFailures != nil {
+			owner, _ := kube.ObjectRefFromObjectMeta(scanJob.ObjectMeta)
+			r.ScanFailures.RecordScanFailure(v1alpha1.ConfigAuditReportKind, owner, metrics.ScanFailureReason(container, status))
+		}
 	}
 	log.V(1).Info("Deleting failed scan job")
 	return r.Client.Delete(ctx, scanJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/imageinventory"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	crpredicate "sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// imageInventoryRequest is the single request which all pod events are mapped
+// to. The whole inventory is rebuilt for each request.
+var imageInventoryRequest = reconcile.Request{NamespacedName: types.NamespacedName{Name: "image-inventory"}}
+
+// ImageInventoryReconciler maintains v1alpha1.ImageInventory objects listing
+// container images, identified by digest, that run in the cluster along with
+// the workloads that run them.
+type ImageInventoryReconciler struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	ext.Clock
+	imageinventory.ReadWriter
+}
+
+func (r *ImageInventoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	installModePredicate, err := predicate.InstallModePredicate(r.Config)
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		// Rebuild the inventory if any of its objects is deleted.
+		For(&v1alpha1.ImageInventory{}, builder.WithPredicates(
+			predicate.ManagedByStarboardOperator,
+			crpredicate.Funcs{
+				CreateFunc:  func(event.CreateEvent) bool { return false },
+				UpdateFunc:  func(event.UpdateEvent) bool { return false },
+				GenericFunc: func(event.GenericEvent) bool { return false },
+			},
+		)).
+		Watches(&source.Kind{Type: &corev1.Pod{}}, r.podEventHandler(), builder.WithPredicates(installModePredicate)).
+		Complete(r.ReconcileInventory())
+}
+
+// podEventHandler maps events of pods to imageInventoryRequest delayed by
+// ImageInventoryDebounce. The request is added to the queue once while it's
+// waiting, hence a burst of events results in a single reconciliation. Pod
+// updates that do not change image IDs of containers are ignored.
+func (r *ImageInventoryReconciler) podEventHandler() handler.EventHandler {
+	enqueue := func(q workqueue.RateLimitingInterface) {
+		q.AddAfter(imageInventoryRequest, r.ImageInventoryDebounce)
+	}
+	return handler.Funcs{
+		CreateFunc: func(_ event.CreateEvent, q workqueue.RateLimitingInterface) {
+			enqueue(q)
+		},
+		UpdateFunc: func(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			oldPod, ok := e.ObjectOld.(*corev1.Pod)
+			if !ok {
+				return
+			}
+			newPod, ok := e.ObjectNew.(*corev1.Pod)
+			if !ok {
+				return
+			}
+			if imageIDs(oldPod) == imageIDs(newPod) && oldPod.Status.Phase == newPod.Status.Phase {
+				return
+			}
+			enqueue(q)
+		},
+		DeleteFunc: func(_ event.DeleteEvent, q workqueue.RateLimitingInterface) {
+			enqueue(q)
+		},
+	}
+}
+
+func imageIDs(pod *corev1.Pod) string {
+	var ids string
+	for _, status := range pod.Status.InitContainerStatuses {
+		ids += status.Name + "=" + status.ImageID + ";"
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		ids += status.Name + "=" + status.ImageID + ";"
+	}
+	return ids
+}
+
+// ReconcileInventory returns reconcile.Func that rebuilds image inventories
+// from pods and writes inventories which content has changed.
+func (r *ImageInventoryReconciler) ReconcileInventory() reconcile.Func {
+	return func(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+		installModePredicate, err := predicate.InstallModePredicate(r.Config)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		var podList corev1.PodList
+		err = r.Client.List(ctx, &podList)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("listing pods: %w", err)
+		}
+		pods := make([]corev1.Pod, 0, len(podList.Items))
+		for i := range podList.Items {
+			if installModePredicate.Generic(event.GenericEvent{Object: &podList.Items[i]}) {
+				pods = append(pods, podList.Items[i])
+			}
+		}
+
+		inventories := imageinventory.FromPods(pods, imageinventory.MaxWorkloadsPerInventory)
+		writes, err := r.ReadWriter.Write(ctx, inventories, metav1.NewTime(r.Clock.Now()))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		r.Logger.V(1).Info("Updated image inventory", "pods", len(pods), "inventories", len(inventories), "writes", writes)
+		return ctrl.Result{}, nil
+	}
+}
@@ -0,0 +1,137 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/imageinventory"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("ImageInventoryReconciler", func() {
+
+	now := time.Date(2022, 4, 20, 10, 14, 38, 0, time.UTC)
+
+	// newPods returns pods of ReplicaSets of 5 pods each, which run 100
+	// distinct images hosted in 2 registries.
+	newPods := func(namespace string, count int) []client.Object {
+		pods := make([]client.Object, 0, count)
+		for i := 0; i < count; i++ {
+			rs := i / 5
+			image := rs % 100
+			ref := fmt.Sprintf("%s/app-%d", []string{"quay.io/team", "nginx"}[image%2], image)
+			pods = append(pods, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      fmt.Sprintf("app-%d-%d", rs, i),
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: "apps/v1",
+						Kind:       "ReplicaSet",
+						Name:       fmt.Sprintf("app-%d", rs),
+						Controller: pointer.BoolPtr(true),
+					}},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name:    "app",
+						Image:   ref + ":latest",
+						ImageID: fmt.Sprintf("docker-pullable://%s@sha256:%064x", ref, image),
+					}},
+				},
+			})
+		}
+		return pods
+	}
+
+	newReconciler := func(config etc.Config, objects ...client.Object) (*controller.ImageInventoryReconciler, client.Client) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build()
+		return &controller.ImageInventoryReconciler{
+			Logger:     logr.Discard(),
+			Config:     config,
+			Client:     c,
+			Clock:      ext.NewFixedClock(now),
+			ReadWriter: imageinventory.NewReadWriter(c),
+		}, c
+	}
+
+	config := etc.Config{Namespace: "starboard-system", ExcludeNamespaces: "kube-system"}
+
+	It("Should list images of thousands of pods", func() {
+		objects := append(newPods("default", 2000), newPods("staging", 1000)...)
+		objects = append(objects, newPods("kube-system", 500)...)
+		reconciler, c := newReconciler(config, objects...)
+
+		_, err := reconciler.ReconcileInventory()(context.TODO(), ctrl.Request{})
+		Expect(err).ToNot(HaveOccurred())
+
+		var list v1alpha1.ImageInventoryList
+		Expect(c.List(context.TODO(), &list)).To(Succeed())
+		Expect(list.Items).To(HaveLen(2))
+		Expect(list.Items[0].Name).To(Equal("index.docker.io-0"))
+		Expect(list.Items[1].Name).To(Equal("quay.io-0"))
+
+		var images, workloads int
+		for _, inventory := range list.Items {
+			Expect(inventory.Report.UpdateTimestamp.Time.Equal(now)).To(BeTrue())
+			for _, image := range inventory.Report.Images {
+				images++
+				workloads += len(image.Workloads)
+				for _, workload := range image.Workloads {
+					Expect(workload.Namespace).ToNot(Equal("kube-system"))
+				}
+			}
+		}
+		Expect(images).To(Equal(100))
+		Expect(workloads).To(Equal(600))
+	})
+
+	It("Should not update inventories when images did not change", func() {
+		reconciler, c := newReconciler(config, newPods("default", 1000)...)
+
+		_, err := reconciler.ReconcileInventory()(context.TODO(), ctrl.Request{})
+		Expect(err).ToNot(HaveOccurred())
+		var before v1alpha1.ImageInventoryList
+		Expect(c.List(context.TODO(), &before)).To(Succeed())
+
+		reconciler.Clock = ext.NewFixedClock(now.Add(time.Hour))
+		_, err = reconciler.ReconcileInventory()(context.TODO(), ctrl.Request{})
+		Expect(err).ToNot(HaveOccurred())
+		var after v1alpha1.ImageInventoryList
+		Expect(c.List(context.TODO(), &after)).To(Succeed())
+
+		Expect(after.Items).To(Equal(before.Items))
+	})
+
+	It("Should delete inventory of registry which images are no longer running", func() {
+		reconciler, c := newReconciler(config,
+			&v1alpha1.ImageInventory{ObjectMeta: metav1.ObjectMeta{
+				Name:   "gcr.io-0",
+				Labels: map[string]string{starboard.LabelK8SAppManagedBy: starboard.AppStarboard},
+			}},
+		)
+
+		_, err := reconciler.ReconcileInventory()(context.TODO(), ctrl.Request{})
+		Expect(err).ToNot(HaveOccurred())
+
+		var list v1alpha1.ImageInventoryList
+		Expect(c.List(context.TODO(), &list)).To(Succeed())
+		Expect(list.Items).To(BeEmpty())
+	})
+
+})
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/throttle"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	batchv1 "k8s.io/api/batch/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,10 +22,22 @@ func NewLimitChecker(config etc.Config, client client.Client, starboardConfig st
 	}
 }
 
+// NewLimitCheckerWithThrottle constructs a LimitChecker which lowers the limit
+// of concurrent scan jobs while the specified Throttle is throttling.
+func NewLimitCheckerWithThrottle(config etc.Config, client client.Client, starboardConfig starboard.ConfigData, requestThrottle *throttle.Throttle) LimitChecker {
+	return &checker{
+		config:          config,
+		client:          client,
+		starboardConfig: starboardConfig,
+		throttle:        requestThrottle,
+	}
+}
+
 type checker struct {
 	config          etc.Config
 	client          client.Client
 	starboardConfig starboard.ConfigData
+	throttle        *throttle.Throttle
 }
 
 func (c *checker) Check(ctx context.Context) (bool, int, error) {
@@ -33,22 +46,37 @@ func (c *checker) Check(ctx context.Context) (bool, int, error) {
 		return false, 0, err
 	}
 
-	return scanJobsCount >= c.config.ConcurrentScanJobsLimit, scanJobsCount, nil
+	return scanJobsCount >= c.throttle.Limit(c.config.ConcurrentScanJobsLimit), scanJobsCount, nil
 }
 
+// countScanJobs returns the number of scan jobs which are not suspended.
 func (c *checker) countScanJobs(ctx context.Context) (int, error) {
+	scanJobs, err := listScanJobs(ctx, c.client, c.config, c.starboardConfig)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, job := range scanJobs {
+		if !isSuspended(job) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// listScanJobs returns scan jobs created by Starboard.
+func listScanJobs(ctx context.Context, c client.Client, config etc.Config, starboardConfig starboard.ConfigData) ([]batchv1.Job, error) {
 	var scanJobs batchv1.JobList
 	listOptions := []client.ListOption{client.MatchingLabels{
 		starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
 	}}
-	if !c.starboardConfig.VulnerabilityScanJobsInSameNamespace() {
+	if !starboardConfig.VulnerabilityScanJobsInSameNamespace() {
 		// scan jobs are running in only starboard operator namespace
-		listOptions = append(listOptions, client.InNamespace(c.config.Namespace))
+		listOptions = append(listOptions, client.InNamespace(config.Namespace))
 	}
-	err := c.client.List(ctx, &scanJobs, listOptions...)
+	err := c.List(ctx, &scanJobs, listOptions...)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-
-	return len(scanJobs.Items), nil
+	return scanJobs.Items, nil
 }
@@ -5,12 +5,19 @@ import (
 	. "github.com/onsi/gomega"
 
 	"context"
+	"time"
 
+	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/operator/controller"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/throttle"
 	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
 	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -130,4 +137,69 @@ var _ = Describe("LimitChecker", func() {
 
 	})
 
+	Context("When requests are throttled", func() {
+
+		It("Should lower the limit", func() {
+			client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+				&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+					Name:      "scan-vulnerabilityreport-hash1",
+					Namespace: "starboard-operator",
+					Labels: map[string]string{
+						starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+					},
+				}},
+			).Build()
+			requestThrottle := throttle.New(logr.Discard(), ext.NewSystemClock(), time.Second, time.Minute)
+
+			instance := controller.NewLimitCheckerWithThrottle(config, client, defaultStarboardConfig, requestThrottle)
+			limitExceeded, jobsCount, err := instance.Check(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(limitExceeded).To(BeFalse())
+			Expect(jobsCount).To(Equal(1))
+
+			_, _ = requestThrottle.Requeue(ctrl.Result{}, errors.NewTooManyRequests("throttled", 1))
+			limitExceeded, jobsCount, err = instance.Check(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(limitExceeded).To(BeTrue())
+			Expect(jobsCount).To(Equal(1))
+		})
+
+	})
+
+	Context("When there are suspended jobs", func() {
+
+		It("Should not count suspended jobs", func() {
+			client := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+				&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+					Name:      "scan-vulnerabilityreport-hash1",
+					Namespace: "starboard-operator",
+					Labels: map[string]string{
+						starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+					},
+				}},
+				&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+					Name:      "scan-vulnerabilityreport-hash2",
+					Namespace: "starboard-operator",
+					Labels: map[string]string{
+						starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+					},
+				}, Spec: batchv1.JobSpec{Suspend: pointer.Bool(true)}},
+				&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+					Name:      "scan-configauditreport-hash3",
+					Namespace: "starboard-operator",
+					Labels: map[string]string{
+						starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+					},
+				}, Spec: batchv1.JobSpec{Suspend: pointer.Bool(true)}},
+			).Build()
+
+			instance := controller.NewLimitChecker(config, client, starboard.GetDefaultConfig())
+			limitExceeded, jobsCount, err := instance.Check(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(limitExceeded).To(BeFalse())
+			Expect(jobsCount).To(Equal(1))
+		})
+
+	})
+
 })
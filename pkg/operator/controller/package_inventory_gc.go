@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// PackageInventoryGCReconciler deletes a v1alpha1.PackageInventory once the
+// v1alpha1.VulnerabilityReport with the same name is gone, e.g. because the
+// report was deleted when its TTL expired or by a user. Inventories are not
+// owned by their reports, because GitOps tools prune objects with owner
+// references.
+type PackageInventoryGCReconciler struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	// Reader reads reports bypassing the cache, so that an inventory is not
+	// deleted just because the cache has not yet seen a new report.
+	Reader client.Reader
+}
+
+func (r *PackageInventoryGCReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	installModePredicate, err := predicate.InstallModePredicate(r.Config)
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.PackageInventory{}, builder.WithPredicates(
+			predicate.Not(predicate.IsBeingTerminated),
+			installModePredicate)).
+		Complete(r.ReconcileInventory())
+}
+
+func (r *PackageInventoryGCReconciler) ReconcileInventory() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("inventory", req.NamespacedName)
+
+		inventory := &v1alpha1.PackageInventory{}
+		err := r.Client.Get(ctx, req.NamespacedName, inventory)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.V(1).Info("Ignoring cached inventory that must have been deleted")
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("getting inventory from cache: %w", err)
+		}
+
+		err = r.Reader.Get(ctx, req.NamespacedName, &v1alpha1.VulnerabilityReport{})
+		if err == nil {
+			return ctrl.Result{}, nil
+		}
+		if !errors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("getting vulnerability report: %w", err)
+		}
+
+		log.V(1).Info("Deleting inventory of deleted vulnerability report")
+		err = r.Client.Delete(ctx, inventory)
+		if err != nil && !errors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("deleting inventory: %w", err)
+		}
+		return ctrl.Result{}, nil
+	}
+}
@@ -0,0 +1,62 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("PackageInventoryGCReconciler", func() {
+
+	meta := metav1.ObjectMeta{Name: "replicaset-wordpress-wordpress", Namespace: "default"}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: meta.Namespace, Name: meta.Name}}
+
+	newReconciler := func(objects ...client.Object) (*controller.PackageInventoryGCReconciler, client.Client) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build()
+		return &controller.PackageInventoryGCReconciler{
+			Logger: logr.Discard(),
+			Config: etc.Config{},
+			Client: c,
+			Reader: c,
+		}, c
+	}
+
+	It("Should keep inventory of existing report", func() {
+		reconciler, c := newReconciler(
+			&v1alpha1.VulnerabilityReport{ObjectMeta: meta},
+			&v1alpha1.PackageInventory{ObjectMeta: meta},
+		)
+
+		_, err := reconciler.ReconcileInventory()(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		var inventories v1alpha1.PackageInventoryList
+		Expect(c.List(context.TODO(), &inventories)).To(Succeed())
+		Expect(inventories.Items).To(HaveLen(1))
+	})
+
+	It("Should delete inventory of deleted report", func() {
+		reconciler, c := newReconciler(
+			&v1alpha1.PackageInventory{ObjectMeta: meta},
+		)
+
+		_, err := reconciler.ReconcileInventory()(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		var inventories v1alpha1.PackageInventoryList
+		Expect(c.List(context.TODO(), &inventories)).To(Succeed())
+		Expect(inventories.Items).To(BeEmpty())
+	})
+})
@@ -70,7 +70,7 @@ func (r *PluginsConfigReconciler) reconcileConfig(kind kube.Kind) reconcile.Func
 			return ctrl.Result{}, fmt.Errorf("getting ConfigMap from cache: %w", err)
 		}
 
-		configHash, err := r.Plugin.ConfigHash(r.PluginContext, kind)
+		configHash, err := configauditreport.ConfigHash(r.Plugin, r.PluginContext, kind)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("getting config hash: %w", err)
 		}
@@ -130,7 +130,7 @@ func (r *PluginsConfigReconciler) reconcileClusterConfig(kind kube.Kind) reconci
 			return ctrl.Result{}, fmt.Errorf("getting ConfigMap from cache: %w", err)
 		}
 
-		configHash, err := r.Plugin.ConfigHash(r.PluginContext, kind)
+		configHash, err := configauditreport.ConfigHash(r.Plugin, r.PluginContext, kind)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("getting config hash: %w", err)
 		}
@@ -0,0 +1,259 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ReportsFinalizer is added to workloads when OPERATOR_REPORTS_FINALIZER_ENABLED
+// is set to guarantee that security reports are deleted before workloads
+// disappear.
+const ReportsFinalizer = "starboard.aquasecurity.github.io/reports"
+
+// ReportGCReconciler deletes security reports of deleted Kubernetes resources.
+// It is used with etc.LabelsOnly reports ownership, where reports carry no
+// owner references and hence are not garbage collected by Kubernetes.
+// Protected reports, see kube.IsProtected, and their package inventories are
+// kept, but they never block removal of the ReportsFinalizer.
+type ReportGCReconciler struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	kube.ObjectResolver
+}
+
+func (r *ReportGCReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	installModePredicate, err := predicate.InstallModePredicate(r.Config)
+	if err != nil {
+		return err
+	}
+
+	resources := []struct {
+		kind      kube.Kind
+		forObject client.Object
+	}{
+		{kind: kube.KindPod, forObject: &corev1.Pod{}},
+		{kind: kube.KindReplicaSet, forObject: &appsv1.ReplicaSet{}},
+		{kind: kube.KindReplicationController, forObject: &corev1.ReplicationController{}},
+		{kind: kube.KindStatefulSet, forObject: &appsv1.StatefulSet{}},
+		{kind: kube.KindDaemonSet, forObject: &appsv1.DaemonSet{}},
+		{kind: kube.KindCronJob, forObject: &batchv1beta1.CronJob{}},
+		{kind: kube.KindJob, forObject: &batchv1.Job{}},
+	}
+
+	rolloutsAvailable, err := kube.RolloutAPIAvailable(mgr.GetRESTMapper())
+	if err != nil {
+		return err
+	}
+	if rolloutsAvailable {
+		resources = append(resources, struct {
+			kind      kube.Kind
+			forObject client.Object
+		}{kind: kube.KindRollout, forObject: kube.NewRollout()})
+	}
+
+	if r.ConfigAuditScannerEnabled || r.ConfigAuditScannerBuiltIn {
+		resources = append(resources, []struct {
+			kind      kube.Kind
+			forObject client.Object
+		}{
+			{kind: kube.KindService, forObject: &corev1.Service{}},
+			{kind: kube.KindConfigMap, forObject: &corev1.ConfigMap{}},
+			{kind: kube.KindRole, forObject: &rbacv1.Role{}},
+			{kind: kube.KindRoleBinding, forObject: &rbacv1.RoleBinding{}},
+			{kind: kube.KindNetworkPolicy, forObject: &networkingv1.NetworkPolicy{}},
+			{kind: kube.KindIngress, forObject: &networkingv1.Ingress{}},
+			{kind: kube.KindResourceQuota, forObject: &corev1.ResourceQuota{}},
+			{kind: kube.KindLimitRange, forObject: &corev1.LimitRange{}},
+			{kind: kube.KindClusterRole, forObject: &rbacv1.ClusterRole{}},
+			{kind: kube.KindClusterRoleBindings, forObject: &rbacv1.ClusterRoleBinding{}},
+			{kind: kube.KindCustomResourceDefinition, forObject: &apiextensionsv1.CustomResourceDefinition{}},
+			{kind: kube.KindPodSecurityPolicy, forObject: &policyv1beta1.PodSecurityPolicy{}},
+		}...)
+	}
+
+	for _, resource := range resources {
+		err = ctrl.NewControllerManagedBy(mgr).
+			For(resource.forObject, builder.WithPredicates(installModePredicate)).
+			Complete(r.ReconcileResource(resource.kind))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReconcileResource returns reconcile.Func that deletes security reports of
+// a deleted resource of the specified kind. If the resource is a workload which
+// owns reports and ReportsFinalizerEnabled is set, it also manages the
+// ReportsFinalizer.
+func (r *ReportGCReconciler) ReconcileResource(resourceKind kube.Kind) reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("kind", resourceKind, "name", req.NamespacedName)
+		resourceRef := kube.ObjectRefFromKindAndObjectKey(resourceKind, req.NamespacedName)
+
+		resource, err := r.ObjectFromObjectRef(ctx, resourceRef)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.V(1).Info("Deleting reports of deleted resource")
+				return ctrl.Result{}, r.deleteReports(ctx, resourceRef)
+			}
+			return ctrl.Result{}, fmt.Errorf("getting %s from cache: %w", resourceKind, err)
+		}
+
+		if !resource.GetDeletionTimestamp().IsZero() {
+			if !controllerutil.ContainsFinalizer(resource, ReportsFinalizer) {
+				return ctrl.Result{}, nil
+			}
+			log.V(1).Info("Deleting reports of terminating resource")
+			err = r.deleteReports(ctx, resourceRef)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			controllerutil.RemoveFinalizer(resource, ReportsFinalizer)
+			return ctrl.Result{}, r.Client.Update(ctx, resource)
+		}
+
+		if r.ReportsFinalizerEnabled && ownsReports(resourceKind, resource) &&
+			!controllerutil.ContainsFinalizer(resource, ReportsFinalizer) {
+			log.V(1).Info("Adding reports finalizer")
+			controllerutil.AddFinalizer(resource, ReportsFinalizer)
+			return ctrl.Result{}, r.Client.Update(ctx, resource)
+		}
+
+		return ctrl.Result{}, nil
+	}
+}
+
+// ownsReports returns true if the specified resource is a workload which owns
+// security reports. Pods and ReplicaSets controlled by other workloads, and
+// Jobs controlled by CronJobs, are not scanned, because reports are owned by
+// their controllers instead.
+func ownsReports(kind kube.Kind, resource client.Object) bool {
+	if !kube.IsWorkload(string(kind)) {
+		return false
+	}
+	controller := metav1.GetControllerOf(resource)
+	switch kind {
+	case kube.KindPod:
+		return !kube.IsBuiltInWorkload(controller)
+	case kube.KindReplicaSet:
+		return !kube.IsRolloutRef(controller)
+	case kube.KindJob:
+		return controller == nil || controller.Kind != string(kube.KindCronJob)
+	}
+	return true
+}
+
+func (r *ReportGCReconciler) deleteReports(ctx context.Context, resourceRef kube.ObjectRef) error {
+	labels := client.MatchingLabels(kube.ObjectRefToLabels(resourceRef))
+
+	if kube.IsClusterScopedKind(string(resourceRef.Kind)) {
+		var clusterReports v1alpha1.ClusterConfigAuditReportList
+		err := r.Client.List(ctx, &clusterReports, labels)
+		if err != nil {
+			return fmt.Errorf("listing cluster config audit reports: %w", err)
+		}
+		for i := range clusterReports.Items {
+			err = r.deleteReport(ctx, &clusterReports.Items[i])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var vulnerabilityReports v1alpha1.VulnerabilityReportList
+	err := r.Client.List(ctx, &vulnerabilityReports, labels, client.InNamespace(resourceRef.Namespace))
+	if err != nil {
+		return fmt.Errorf("listing vulnerability reports: %w", err)
+	}
+	for i := range vulnerabilityReports.Items {
+		err = r.deleteReport(ctx, &vulnerabilityReports.Items[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	// The CRD of package inventories is optional unless Trivy lists all
+	// packages.
+	var packageInventories v1alpha1.PackageInventoryList
+	err = r.Client.List(ctx, &packageInventories, labels, client.InNamespace(resourceRef.Namespace))
+	if err != nil && !meta.IsNoMatchError(err) {
+		return fmt.Errorf("listing package inventories: %w", err)
+	}
+	protected := make(map[string]bool)
+	for i := range vulnerabilityReports.Items {
+		protected[vulnerabilityReports.Items[i].Name] = kube.IsProtected(&vulnerabilityReports.Items[i])
+	}
+	for i := range packageInventories.Items {
+		if protected[packageInventories.Items[i].Name] {
+			continue
+		}
+		err = r.deleteReport(ctx, &packageInventories.Items[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.Config.VulnerabilityScannerWorkloadSummaryEnabled {
+		var summaries v1alpha1.WorkloadVulnerabilitySummaryList
+		err = r.Client.List(ctx, &summaries, labels, client.InNamespace(resourceRef.Namespace))
+		if err != nil {
+			return fmt.Errorf("listing workload vulnerability summaries: %w", err)
+		}
+		for i := range summaries.Items {
+			err = r.deleteReport(ctx, &summaries.Items[i])
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	var configAuditReports v1alpha1.ConfigAuditReportList
+	err = r.Client.List(ctx, &configAuditReports, labels, client.InNamespace(resourceRef.Namespace))
+	if err != nil {
+		return fmt.Errorf("listing config audit reports: %w", err)
+	}
+	for i := range configAuditReports.Items {
+		err = r.deleteReport(ctx, &configAuditReports.Items[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ReportGCReconciler) deleteReport(ctx context.Context, report client.Object) error {
+	if kube.IsProtected(report) {
+		r.Logger.V(1).Info("Keeping protected report", "report", client.ObjectKeyFromObject(report))
+		return nil
+	}
+	err := r.Client.Delete(ctx, report)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("deleting report %q: %w", report.GetName(), err)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package controller_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/crd"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+// TestReportGCReconciler_EnvTest verifies that security reports of a deleted
+// workload are cleaned up with both reports ownership modes. It requires
+// control plane binaries referenced by the KUBEBUILDER_ASSETS environment
+// variable.
+func TestReportGCReconciler_EnvTest(t *testing.T) {
+	if testing.Short() || os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("Skipping test which requires KUBEBUILDER_ASSETS")
+	}
+
+	testEnv := &envtest.Environment{}
+	cfg, err := testEnv.Start()
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, testEnv.Stop())
+	}()
+
+	testClient, err := client.New(cfg, client.Options{Scheme: starboard.NewScheme()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	crds, err := crd.Embedded()
+	require.NoError(t, err)
+	require.NoError(t, crd.Apply(ctx, logr.Discard(), testClient, crds))
+	require.NoError(t, crd.Verify(ctx, testClient, crd.VulnerabilityReports...))
+	require.NoError(t, crd.Verify(ctx, testClient, crd.ConfigAuditReports...))
+
+	// startOperator starts the ReportGCReconciler for replica sets the way the
+	// operator does, i.e. only with label-based reports ownership.
+	startOperator := func(t *testing.T, config etc.Config) {
+		t.Helper()
+		mgr, err := ctrl.NewManager(cfg, ctrl.Options{Scheme: starboard.NewScheme(), MetricsBindAddress: "0"})
+		require.NoError(t, err)
+		if config.SkipOwnerReference() {
+			reconciler := &controller.ReportGCReconciler{
+				Logger:         logr.Discard(),
+				Config:         config,
+				Client:         mgr.GetClient(),
+				ObjectResolver: kube.ObjectResolver{Client: mgr.GetClient()},
+			}
+			err = ctrl.NewControllerManagedBy(mgr).
+				For(&appsv1.ReplicaSet{}).
+				Complete(reconciler.ReconcileResource(kube.KindReplicaSet))
+			require.NoError(t, err)
+		}
+		mgrCtx, cancel := context.WithCancel(ctx)
+		t.Cleanup(cancel)
+		go func() {
+			assert.NoError(t, mgr.Start(mgrCtx))
+		}()
+	}
+
+	newNamespace := func(t *testing.T, name string) string {
+		t.Helper()
+		require.NoError(t, testClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}))
+		return name
+	}
+
+	newReplicaSet := func(t *testing.T, namespace string) *appsv1.ReplicaSet {
+		t.Helper()
+		rs := &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "wordpress", Namespace: namespace},
+			Spec: appsv1.ReplicaSetSpec{
+				Replicas: pointer.Int32(0),
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "wordpress"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "wordpress"}},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "wordpress", Image: "wordpress:4.9"}},
+					},
+				},
+			},
+		}
+		require.NoError(t, testClient.Create(ctx, rs))
+		return rs
+	}
+
+	// newReports creates reports of the given replica set with the report
+	// builders of the operator, which set owner references depending on the
+	// reports ownership of the given configuration.
+	newReports := func(t *testing.T, rs *appsv1.ReplicaSet, config etc.Config) []client.Object {
+		t.Helper()
+		owner := rs.DeepCopy()
+		owner.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind(string(kube.KindReplicaSet)))
+		vulnerabilityReport, err := vulnerabilityreport.NewReportBuilder(starboard.NewScheme()).
+			Controller(owner).
+			Container("wordpress").
+			Data(v1alpha1.VulnerabilityReportData{Vulnerabilities: []v1alpha1.Vulnerability{}}).
+			SkipOwnerReference(config.SkipOwnerReference()).
+			Get()
+		require.NoError(t, err)
+		configAuditReport, err := configauditreport.NewReportBuilder(starboard.NewScheme()).
+			Controller(owner).
+			Data(v1alpha1.ConfigAuditReportData{Checks: []v1alpha1.Check{}}).
+			SkipOwnerReference(config.SkipOwnerReference()).
+			GetReport()
+		require.NoError(t, err)
+		reports := []client.Object{&vulnerabilityReport, &configAuditReport}
+		for _, report := range reports {
+			require.NoError(t, testClient.Create(ctx, report))
+		}
+		return reports
+	}
+
+	reportsDeleted := func(reports []client.Object) func() bool {
+		return func() bool {
+			for _, report := range reports {
+				err := testClient.Get(ctx, client.ObjectKeyFromObject(report), report.DeepCopyObject().(client.Object))
+				if !errors.IsNotFound(err) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+
+	replicaSetFinalizers := func(rs *appsv1.ReplicaSet) func() []string {
+		return func() []string {
+			var actual appsv1.ReplicaSet
+			if err := testClient.Get(ctx, client.ObjectKeyFromObject(rs), &actual); err != nil {
+				return nil
+			}
+			return actual.Finalizers
+		}
+	}
+
+	t.Run("Should delete reports of deleted workload with labels only ownership", func(t *testing.T) {
+		config := etc.Config{ReportsOwnership: etc.LabelsOnly}
+		startOperator(t, config)
+		rs := newReplicaSet(t, newNamespace(t, "labels-only"))
+		reports := newReports(t, rs, config)
+
+		assert.Never(t, reportsDeleted(reports), 2*time.Second, 100*time.Millisecond)
+
+		require.NoError(t, testClient.Delete(ctx, rs))
+		assert.Eventually(t, reportsDeleted(reports), 30*time.Second, 100*time.Millisecond)
+	})
+
+	t.Run("Should delete reports before terminating workload disappears with reports finalizer", func(t *testing.T) {
+		config := etc.Config{ReportsOwnership: etc.LabelsOnly, ReportsFinalizerEnabled: true}
+		startOperator(t, config)
+		rs := newReplicaSet(t, newNamespace(t, "labels-only-finalizer"))
+		reports := newReports(t, rs, config)
+
+		assert.Eventually(t, func() bool {
+			for _, finalizer := range replicaSetFinalizers(rs)() {
+				if finalizer == controller.ReportsFinalizer {
+					return true
+				}
+			}
+			return false
+		}, 30*time.Second, 100*time.Millisecond)
+
+		require.NoError(t, testClient.Delete(ctx, rs))
+		assert.Eventually(t, func() bool {
+			err := testClient.Get(ctx, client.ObjectKeyFromObject(rs), &appsv1.ReplicaSet{})
+			return errors.IsNotFound(err)
+		}, 30*time.Second, 100*time.Millisecond)
+		assert.True(t, reportsDeleted(reports)())
+	})
+
+	t.Run("Should leave reports to Kubernetes garbage collector with owner reference ownership", func(t *testing.T) {
+		config := etc.Config{ReportsOwnership: etc.OwnerReference, ReportsFinalizerEnabled: true}
+		startOperator(t, config)
+		rs := newReplicaSet(t, newNamespace(t, "owner-reference"))
+		reports := newReports(t, rs, config)
+
+		// The reports finalizer takes effect only with labels only ownership.
+		assert.Never(t, func() bool {
+			return len(replicaSetFinalizers(rs)()) > 0
+		}, 2*time.Second, 100*time.Millisecond)
+
+		require.NoError(t, testClient.Delete(ctx, rs))
+		err := testClient.Get(ctx, client.ObjectKeyFromObject(rs), &appsv1.ReplicaSet{})
+		assert.True(t, errors.IsNotFound(err))
+
+		// The envtest control plane runs no garbage collector, so reports are
+		// kept with owner references to the deleted replica set.
+		for _, report := range reports {
+			actual := report.DeepCopyObject().(client.Object)
+			require.NoError(t, testClient.Get(ctx, client.ObjectKeyFromObject(report), actual))
+			require.Len(t, actual.GetOwnerReferences(), 1)
+			assert.Equal(t, rs.UID, actual.GetOwnerReferences()[0].UID)
+		}
+	})
+}
@@ -0,0 +1,289 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("ReportGCReconciler", func() {
+
+	replicaSetLabels := map[string]string{
+		starboard.LabelResourceKind:      "ReplicaSet",
+		starboard.LabelResourceName:      "wordpress",
+		starboard.LabelResourceNamespace: "default",
+	}
+
+	newVulnerabilityReport := func(name string, labels map[string]string) *v1alpha1.VulnerabilityReport {
+		return &v1alpha1.VulnerabilityReport{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    labels,
+		}}
+	}
+
+	newConfigAuditReport := func(name string, labels map[string]string) *v1alpha1.ConfigAuditReport {
+		return &v1alpha1.ConfigAuditReport{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    labels,
+		}}
+	}
+
+	newReconciler := func(config etc.Config, objects ...client.Object) (*controller.ReportGCReconciler, client.Client) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build()
+		return &controller.ReportGCReconciler{
+			Logger:         logr.Discard(),
+			Config:         config,
+			Client:         c,
+			ObjectResolver: kube.ObjectResolver{Client: c},
+		}, c
+	}
+
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "wordpress"}}
+
+	Context("When reports are owned by labels only", func() {
+
+		config := etc.Config{ReportsOwnership: etc.LabelsOnly}
+
+		It("Should keep reports of existing workload", func() {
+			reconciler, c := newReconciler(config,
+				&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "wordpress", Namespace: "default"}},
+				newVulnerabilityReport("replicaset-wordpress-wordpress", replicaSetLabels),
+				newConfigAuditReport("replicaset-wordpress", replicaSetLabels),
+			)
+
+			_, err := reconciler.ReconcileResource(kube.KindReplicaSet)(context.TODO(), request)
+			Expect(err).ToNot(HaveOccurred())
+
+			var vulnerabilityReports v1alpha1.VulnerabilityReportList
+			Expect(c.List(context.TODO(), &vulnerabilityReports)).To(Succeed())
+			Expect(vulnerabilityReports.Items).To(HaveLen(1))
+
+			var configAuditReports v1alpha1.ConfigAuditReportList
+			Expect(c.List(context.TODO(), &configAuditReports)).To(Succeed())
+			Expect(configAuditReports.Items).To(HaveLen(1))
+		})
+
+		It("Should delete reports of deleted workload", func() {
+			reconciler, c := newReconciler(config,
+				newVulnerabilityReport("replicaset-wordpress-wordpress", replicaSetLabels),
+				newConfigAuditReport("replicaset-wordpress", replicaSetLabels),
+				newVulnerabilityReport("replicaset-nginx-nginx", map[string]string{
+					starboard.LabelResourceKind:      "ReplicaSet",
+					starboard.LabelResourceName:      "nginx",
+					starboard.LabelResourceNamespace: "default",
+				}),
+			)
+
+			_, err := reconciler.ReconcileResource(kube.KindReplicaSet)(context.TODO(), request)
+			Expect(err).ToNot(HaveOccurred())
+
+			var vulnerabilityReports v1alpha1.VulnerabilityReportList
+			Expect(c.List(context.TODO(), &vulnerabilityReports)).To(Succeed())
+			Expect(vulnerabilityReports.Items).To(HaveLen(1))
+			Expect(vulnerabilityReports.Items[0].Name).To(Equal("replicaset-nginx-nginx"))
+
+			var configAuditReports v1alpha1.ConfigAuditReportList
+			Expect(c.List(context.TODO(), &configAuditReports)).To(Succeed())
+			Expect(configAuditReports.Items).To(BeEmpty())
+		})
+
+		It("Should delete reports of deleted cluster scoped resource", func() {
+			clusterRoleLabels := map[string]string{
+				starboard.LabelResourceKind:      "ClusterRole",
+				starboard.LabelResourceName:      "view",
+				starboard.LabelResourceNamespace: "",
+			}
+			reconciler, c := newReconciler(etc.Config{ReportsOwnership: etc.LabelsOnly, ConfigAuditScannerBuiltIn: true},
+				&v1alpha1.ClusterConfigAuditReport{ObjectMeta: metav1.ObjectMeta{
+					Name:   "clusterrole-view",
+					Labels: clusterRoleLabels,
+				}},
+			)
+
+			_, err := reconciler.ReconcileResource(kube.KindClusterRole)(context.TODO(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: "view"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			var clusterReports v1alpha1.ClusterConfigAuditReportList
+			Expect(c.List(context.TODO(), &clusterReports)).To(Succeed())
+			Expect(clusterReports.Items).To(BeEmpty())
+		})
+
+		It("Should not add finalizer when it is disabled", func() {
+			reconciler, c := newReconciler(config,
+				&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "wordpress", Namespace: "default"}},
+			)
+
+			_, err := reconciler.ReconcileResource(kube.KindReplicaSet)(context.TODO(), request)
+			Expect(err).ToNot(HaveOccurred())
+
+			var rs appsv1.ReplicaSet
+			Expect(c.Get(context.TODO(), request.NamespacedName, &rs)).To(Succeed())
+			Expect(rs.Finalizers).To(BeEmpty())
+		})
+	})
+
+	Context("When reports finalizer is enabled", func() {
+
+		config := etc.Config{ReportsOwnership: etc.LabelsOnly, ReportsFinalizerEnabled: true}
+
+		It("Should add finalizer to workload", func() {
+			reconciler, c := newReconciler(config,
+				&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "wordpress", Namespace: "default"}},
+			)
+
+			_, err := reconciler.ReconcileResource(kube.KindReplicaSet)(context.TODO(), request)
+			Expect(err).ToNot(HaveOccurred())
+
+			var rs appsv1.ReplicaSet
+			Expect(c.Get(context.TODO(), request.NamespacedName, &rs)).To(Succeed())
+			Expect(rs.Finalizers).To(ConsistOf(controller.ReportsFinalizer))
+		})
+
+		It("Should not add finalizer to resource other than workload", func() {
+			reconciler, c := newReconciler(config,
+				&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "wordpress", Namespace: "default"}},
+			)
+
+			_, err := reconciler.ReconcileResource(kube.KindRole)(context.TODO(), request)
+			Expect(err).ToNot(HaveOccurred())
+
+			var role rbacv1.Role
+			Expect(c.Get(context.TODO(), request.NamespacedName, &role)).To(Succeed())
+			Expect(role.Finalizers).To(BeEmpty())
+		})
+
+		It("Should not add finalizer to workload controlled by another workload", func() {
+			controlledBy := func(apiVersion string, kind kube.Kind) []metav1.OwnerReference {
+				return []metav1.OwnerReference{{
+					APIVersion: apiVersion,
+					Kind:       string(kind),
+					Name:       "wordpress",
+					UID:        "b2a5d0e5-3e4c-4a2f-9d1e-0c7f2d6f1a11",
+					Controller: pointer.BoolPtr(true),
+				}}
+			}
+			reconciler, c := newReconciler(config,
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "wordpress", Namespace: "default",
+					OwnerReferences: controlledBy("apps/v1", kube.KindReplicaSet)}},
+				&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "wordpress", Namespace: "default",
+					OwnerReferences: controlledBy("batch/v1", kube.KindCronJob)}},
+				&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "wordpress", Namespace: "default",
+					OwnerReferences: controlledBy("argoproj.io/v1alpha1", kube.KindRollout)}},
+			)
+
+			for _, resource := range []struct {
+				kind kube.Kind
+				obj  client.Object
+			}{
+				{kind: kube.KindPod, obj: &corev1.Pod{}},
+				{kind: kube.KindJob, obj: &batchv1.Job{}},
+				{kind: kube.KindReplicaSet, obj: &appsv1.ReplicaSet{}},
+			} {
+				_, err := reconciler.ReconcileResource(resource.kind)(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(c.Get(context.TODO(), request.NamespacedName, resource.obj)).To(Succeed())
+				Expect(resource.obj.GetFinalizers()).To(BeEmpty(), string(resource.kind))
+			}
+		})
+
+		It("Should delete reports and remove finalizer of terminating workload", func() {
+			now := metav1.Now()
+			reconciler, c := newReconciler(config,
+				&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+					Name:              "wordpress",
+					Namespace:         "default",
+					DeletionTimestamp: &now,
+					Finalizers:        []string{controller.ReportsFinalizer, "example.com/other"},
+				}},
+				newVulnerabilityReport("replicaset-wordpress-wordpress", replicaSetLabels),
+				newConfigAuditReport("replicaset-wordpress", replicaSetLabels),
+			)
+
+			_, err := reconciler.ReconcileResource(kube.KindReplicaSet)(context.TODO(), request)
+			Expect(err).ToNot(HaveOccurred())
+
+			var vulnerabilityReports v1alpha1.VulnerabilityReportList
+			Expect(c.List(context.TODO(), &vulnerabilityReports)).To(Succeed())
+			Expect(vulnerabilityReports.Items).To(BeEmpty())
+
+			var configAuditReports v1alpha1.ConfigAuditReportList
+			Expect(c.List(context.TODO(), &configAuditReports)).To(Succeed())
+			Expect(configAuditReports.Items).To(BeEmpty())
+
+			var rs appsv1.ReplicaSet
+			err = c.Get(context.TODO(), request.NamespacedName, &rs)
+			if !errors.IsNotFound(err) {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rs.Finalizers).To(ConsistOf("example.com/other"))
+			}
+		})
+
+		It("Should keep protected reports and remove finalizer of terminating workload", func() {
+			now := metav1.Now()
+			protected := newVulnerabilityReport("replicaset-wordpress-wordpress", replicaSetLabels)
+			protected.Annotations = map[string]string{starboard.AnnotationProtected: "true"}
+			reconciler, c := newReconciler(config,
+				&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+					Name:              "wordpress",
+					Namespace:         "default",
+					DeletionTimestamp: &now,
+					Finalizers:        []string{controller.ReportsFinalizer, "example.com/other"},
+				}},
+				protected,
+				&v1alpha1.PackageInventory{ObjectMeta: metav1.ObjectMeta{
+					Name:      "replicaset-wordpress-wordpress",
+					Namespace: "default",
+					Labels:    replicaSetLabels,
+				}},
+				newConfigAuditReport("replicaset-wordpress", replicaSetLabels),
+			)
+
+			_, err := reconciler.ReconcileResource(kube.KindReplicaSet)(context.TODO(), request)
+			Expect(err).ToNot(HaveOccurred())
+
+			var vulnerabilityReports v1alpha1.VulnerabilityReportList
+			Expect(c.List(context.TODO(), &vulnerabilityReports)).To(Succeed())
+			Expect(vulnerabilityReports.Items).To(HaveLen(1))
+
+			var packageInventories v1alpha1.PackageInventoryList
+			Expect(c.List(context.TODO(), &packageInventories)).To(Succeed())
+			Expect(packageInventories.Items).To(HaveLen(1))
+
+			var configAuditReports v1alpha1.ConfigAuditReportList
+			Expect(c.List(context.TODO(), &configAuditReports)).To(Succeed())
+			Expect(configAuditReports.Items).To(BeEmpty())
+
+			var rs appsv1.ReplicaSet
+			err = c.Get(context.TODO(), request.NamespacedName, &rs)
+			if !errors.IsNotFound(err) {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rs.Finalizers).To(ConsistOf("example.com/other"))
+			}
+		})
+	})
+
+})
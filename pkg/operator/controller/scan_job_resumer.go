@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// ScanJobResumer resumes ingestion of results of finished scan jobs left over
+// by a previous instance of the operator, e.g. because it was stopped before
+// results of the jobs were persisted. When the operator starts leading, it
+// enqueues finished scan jobs in reconcilers of scan jobs, which select scan
+// jobs of their scanners.
+type ScanJobResumer struct {
+	logr.Logger
+	// Reader reads scan jobs from the API server rather than from the cache,
+	// which might not be synced yet.
+	Reader client.Reader
+	// Destinations are channels watched by reconcilers of scan jobs.
+	Destinations []chan<- event.GenericEvent
+}
+
+// Start enqueues finished scan jobs once. Failing to list scan jobs is not
+// fatal, because scan jobs are reconciled anyway when the cache is synced.
+func (r *ScanJobResumer) Start(ctx context.Context) error {
+	var jobList batchv1.JobList
+	err := r.Reader.List(ctx, &jobList, client.MatchingLabels{
+		starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+	})
+	if err != nil {
+		r.Logger.Error(err, "Unable to list scan jobs")
+		return nil
+	}
+	resumed := 0
+	for i := range jobList.Items {
+		job := &jobList.Items[i]
+		if _, finished := kube.JobFinishedCondition(job); !finished {
+			continue
+		}
+		for _, destination := range r.Destinations {
+			select {
+			case destination <- event.GenericEvent{Object: job}:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		resumed++
+	}
+	r.Logger.Info("Resumed finished scan jobs", "count", resumed)
+	return nil
+}
+
+// NeedLeaderElection returns true, so that only the leader resumes scan jobs.
+func (r *ScanJobResumer) NeedLeaderElection() bool {
+	return true
+}
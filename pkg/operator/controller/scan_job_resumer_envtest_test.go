@@ -0,0 +1,127 @@
+package controller_test
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// TestScanJobResumer_EnvTest verifies that a complete scan job left over by a
+// previous instance of the operator is reconciled again when the operator is
+// restarted. It requires control plane binaries referenced by the
+// KUBEBUILDER_ASSETS environment variable.
+func TestScanJobResumer_EnvTest(t *testing.T) {
+	if testing.Short() || os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("Skipping test which requires KUBEBUILDER_ASSETS")
+	}
+
+	testEnv := &envtest.Environment{}
+	cfg, err := testEnv.Start()
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, testEnv.Stop())
+	}()
+
+	testClient, err := client.New(cfg, client.Options{Scheme: starboard.NewScheme()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, testClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "starboard-system"},
+	}))
+
+	// The scan job completed while the previous instance of the operator was
+	// shutting down, so its results were never ingested.
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "scan-configauditreport-5d4f8b6c7",
+			Namespace: "starboard-system",
+			Labels: map[string]string{
+				starboard.LabelK8SAppManagedBy:          starboard.AppStarboard,
+				starboard.LabelConfigAuditReportScanner: "Polaris",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers:    []corev1.Container{{Name: "polaris", Image: "fairwinds/polaris:4.2"}},
+				},
+			},
+		},
+	}
+	require.NoError(t, testClient.Create(ctx, job))
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	require.NoError(t, testClient.Status().Update(ctx, job))
+
+	// Restart the operator with a reconciler of scan jobs set up like the
+	// ones of scanners.
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{Scheme: starboard.NewScheme(), MetricsBindAddress: "0"})
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var reconciled []types.NamespacedName
+	resumedJobs := make(chan event.GenericEvent)
+	jobPredicates := builder.WithPredicates(
+		predicate.InNamespace("starboard-system"),
+		predicate.ManagedByStarboardOperator,
+		predicate.IsConfigAuditReportScan,
+		predicate.JobHasAnyCondition,
+	)
+	err = ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1.Job{}, jobPredicates).
+		Watches(&source.Channel{Source: resumedJobs}, &handler.EnqueueRequestForObject{}, jobPredicates).
+		Complete(reconcile.Func(func(_ context.Context, req ctrl.Request) (ctrl.Result, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			reconciled = append(reconciled, req.NamespacedName)
+			return ctrl.Result{}, nil
+		}))
+	require.NoError(t, err)
+	require.NoError(t, mgr.Add(&controller.ScanJobResumer{
+		Logger:       logr.Discard(),
+		Reader:       mgr.GetAPIReader(),
+		Destinations: []chan<- event.GenericEvent{resumedJobs},
+	}))
+
+	mgrCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		assert.NoError(t, mgr.Start(mgrCtx))
+	}()
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, name := range reconciled {
+			if name == client.ObjectKeyFromObject(job) {
+				return true
+			}
+		}
+		return false
+	}, 30*time.Second, 100*time.Millisecond)
+
+	// The resumer leaves deletion of the scan job to the reconciler.
+	require.NoError(t, testClient.Get(ctx, client.ObjectKeyFromObject(job), &batchv1.Job{}))
+}
@@ -0,0 +1,73 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+var _ = Describe("ScanJobResumer", func() {
+
+	newJob := func(name string, labels map[string]string, conditions ...batchv1.JobCondition) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "starboard-system", Labels: labels},
+			Status:     batchv1.JobStatus{Conditions: conditions},
+		}
+	}
+	managed := map[string]string{starboard.LabelK8SAppManagedBy: starboard.AppStarboard}
+	complete := batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}
+	failed := batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}
+
+	It("Should enqueue finished scan jobs in all destinations", func() {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			newJob("scan-vulnerabilityreport-complete", managed, complete),
+			newJob("scan-configauditreport-failed", managed, failed),
+			newJob("scan-vulnerabilityreport-active", managed),
+			newJob("unmanaged-complete", nil, complete),
+		).Build()
+		first := make(chan event.GenericEvent, 10)
+		second := make(chan event.GenericEvent, 10)
+		resumer := &controller.ScanJobResumer{
+			Logger:       logr.Discard(),
+			Reader:       c,
+			Destinations: []chan<- event.GenericEvent{first, second},
+		}
+		Expect(resumer.Start(context.TODO())).To(Succeed())
+		Expect(resumer.NeedLeaderElection()).To(BeTrue())
+
+		names := func(events chan event.GenericEvent) []string {
+			close(events)
+			var names []string
+			for e := range events {
+				names = append(names, e.Object.GetName())
+			}
+			return names
+		}
+		Expect(names(first)).To(ConsistOf("scan-vulnerabilityreport-complete", "scan-configauditreport-failed"))
+		Expect(names(second)).To(ConsistOf("scan-vulnerabilityreport-complete", "scan-configauditreport-failed"))
+	})
+
+	It("Should stop when context is done", func() {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			newJob("scan-vulnerabilityreport-complete", managed, complete),
+		).Build()
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		resumer := &controller.ScanJobResumer{
+			Logger:       logr.Discard(),
+			Reader:       c,
+			Destinations: []chan<- event.GenericEvent{make(chan event.GenericEvent)},
+		}
+		Expect(resumer.Start(ctx)).To(Succeed())
+	})
+})
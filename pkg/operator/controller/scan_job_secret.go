@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ScanJobSecretOrphanTTL is the time after which a scan job Secret without an
+// owner reference and without the corresponding scan job is deleted.
+const ScanJobSecretOrphanTTL = time.Hour
+
+// ScanJobSecretReconciler makes sure that Secrets created for scan jobs, e.g.
+// to pass registry credentials, do not outlive scan jobs. Normally such Secrets
+// are owned by scan jobs and garbage collected by Kubernetes. However, if the
+// operator is restarted after a Secret is created but before its owner
+// reference is set, the Secret would be left behind.
+type ScanJobSecretReconciler struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	ext.Clock
+}
+
+func (r *ScanJobSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Secret{}, builder.WithPredicates(
+			predicate.ManagedByStarboardOperator,
+			predicate.IsScanJobSecret,
+			predicate.Not(predicate.IsBeingTerminated),
+		)).
+		Complete(r.ReconcileSecret())
+}
+
+// ReconcileSecret returns reconcile.Func that sets the owner reference of a scan
+// job Secret to its scan job or deletes the Secret if the scan job is gone for
+// longer than ScanJobSecretOrphanTTL.
+func (r *ScanJobSecretReconciler) ReconcileSecret() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("secret", req.NamespacedName)
+
+		secret := &corev1.Secret{}
+		err := r.Client.Get(ctx, req.NamespacedName, secret)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.V(1).Info("Ignoring cached secret that must have been deleted")
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("getting secret from cache: %w", err)
+		}
+
+		if len(secret.OwnerReferences) > 0 {
+			log.V(1).Info("Ignoring secret with owner reference")
+			return ctrl.Result{}, nil
+		}
+
+		job := &batchv1.Job{}
+		err = r.Client.Get(ctx, types.NamespacedName{
+			Namespace: secret.Namespace,
+			Name:      secret.Labels[starboard.LabelScanJobName],
+		}, job)
+		if err == nil {
+			log.V(1).Info("Setting owner reference of secret to scan job", "job", job.Name)
+			err = controllerutil.SetOwnerReference(job, secret, r.Client.Scheme())
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("setting owner reference: %w", err)
+			}
+			return ctrl.Result{}, r.Client.Update(ctx, secret)
+		}
+		if !errors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("getting scan job: %w", err)
+		}
+
+		age := r.Clock.Now().Sub(secret.CreationTimestamp.Time)
+		if age < ScanJobSecretOrphanTTL {
+			requeueAfter := ScanJobSecretOrphanTTL - age
+			log.V(1).Info("RequeueAfter", "durationToOrphanTTL", requeueAfter)
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+
+		log.V(1).Info("Deleting orphaned scan job secret")
+		err = r.Client.Delete(ctx, secret)
+		if err != nil && !errors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("deleting secret: %w", err)
+		}
+		return ctrl.Result{}, nil
+	}
+}
@@ -0,0 +1,123 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("ScanJobSecretReconciler", func() {
+
+	createdAt := time.Date(2022, 4, 20, 10, 0, 0, 0, time.UTC)
+
+	newScanJob := func() *batchv1.Job {
+		return &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Name:      "scan-vulnerabilityreport-64d65c457",
+			Namespace: "starboard",
+			Labels: map[string]string{
+				starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+			},
+		}}
+	}
+
+	newScanJobSecret := func() *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:              "scan-vulnerabilityreport-64d65c457-regcred",
+			Namespace:         "starboard",
+			CreationTimestamp: metav1.NewTime(createdAt),
+			Labels: map[string]string{
+				starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+				starboard.LabelScanJobName:     "scan-vulnerabilityreport-64d65c457",
+			},
+		}}
+	}
+
+	newReconciler := func(now time.Time, objects ...client.Object) (*controller.ScanJobSecretReconciler, client.Client) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build()
+		return &controller.ScanJobSecretReconciler{
+			Logger: logr.Discard(),
+			Config: etc.Config{},
+			Client: c,
+			Clock:  ext.NewFixedClock(now),
+		}, c
+	}
+
+	request := ctrl.Request{NamespacedName: types.NamespacedName{
+		Namespace: "starboard",
+		Name:      "scan-vulnerabilityreport-64d65c457-regcred",
+	}}
+
+	Context("When operator restarted after creating scan job but before setting owner reference of secret", func() {
+
+		It("Should set owner reference of secret to existing scan job", func() {
+			reconciler, c := newReconciler(createdAt.Add(time.Minute), newScanJob(), newScanJobSecret())
+
+			_, err := reconciler.ReconcileSecret()(context.TODO(), request)
+			Expect(err).ToNot(HaveOccurred())
+
+			var secret corev1.Secret
+			Expect(c.Get(context.TODO(), request.NamespacedName, &secret)).To(Succeed())
+			Expect(secret.OwnerReferences).To(HaveLen(1))
+			Expect(secret.OwnerReferences[0].Kind).To(Equal("Job"))
+			Expect(secret.OwnerReferences[0].Name).To(Equal("scan-vulnerabilityreport-64d65c457"))
+		})
+
+		It("Should keep orphaned secret until it is older than an hour", func() {
+			// The scan job was parsed and deleted, but the secret without owner
+			// reference is not garbage collected by Kubernetes.
+			reconciler, c := newReconciler(createdAt.Add(20*time.Minute), newScanJobSecret())
+
+			result, err := reconciler.ReconcileSecret()(context.TODO(), request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(40 * time.Minute))
+
+			var secret corev1.Secret
+			Expect(c.Get(context.TODO(), request.NamespacedName, &secret)).To(Succeed())
+		})
+
+		It("Should delete orphaned secret older than an hour", func() {
+			reconciler, c := newReconciler(createdAt.Add(controller.ScanJobSecretOrphanTTL), newScanJobSecret())
+
+			result, err := reconciler.ReconcileSecret()(context.TODO(), request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			var secret corev1.Secret
+			err = c.Get(context.TODO(), request.NamespacedName, &secret)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	It("Should ignore secret owned by scan job", func() {
+		secret := newScanJobSecret()
+		secret.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+			Name:       "scan-vulnerabilityreport-64d65c457",
+		}}
+		reconciler, c := newReconciler(createdAt.Add(2*time.Hour), secret)
+
+		result, err := reconciler.ReconcileSecret()(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+
+		Expect(c.Get(context.TODO(), request.NamespacedName, &corev1.Secret{})).To(Succeed())
+	})
+
+})
@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/operator/throttle"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ScanJobsSwitch holds whether scan jobs are suspended. It's safe for
+// concurrent use. A nil ScanJobsSwitch never suspends scan jobs.
+type ScanJobsSwitch struct {
+	suspended int32
+}
+
+// NewScanJobsSwitch constructs a ScanJobsSwitch which suspends scan jobs if
+// etc.Config.ScanJobsSuspended is set, unless it's overridden with the
+// scanJob.suspended key of the given starboard.ConfigData.
+func NewScanJobsSwitch(config etc.Config, starboardConfig starboard.ConfigData) (*ScanJobsSwitch, error) {
+	suspended, err := scanJobsSuspended(config, starboardConfig)
+	if err != nil {
+		return nil, err
+	}
+	s := &ScanJobsSwitch{}
+	s.SetSuspended(suspended)
+	return s, nil
+}
+
+// Suspended returns true if new scan jobs should be suspended.
+func (s *ScanJobsSwitch) Suspended() bool {
+	return s != nil && atomic.LoadInt32(&s.suspended) == 1
+}
+
+// SetSuspended sets whether scan jobs are suspended. It returns true
+// if the switch was flipped.
+func (s *ScanJobsSwitch) SetSuspended(suspended bool) bool {
+	var value int32
+	if suspended {
+		value = 1
+	}
+	return atomic.SwapInt32(&s.suspended, value) != value
+}
+
+// ApplyTo sets the suspend field of the given scan job, which is about to be
+// created, if scan jobs are suspended.
+func (s *ScanJobsSwitch) ApplyTo(job *batchv1.Job) {
+	if s.Suspended() {
+		job.Spec.Suspend = pointer.Bool(true)
+	}
+}
+
+func scanJobsSuspended(config etc.Config, starboardConfig starboard.ConfigData) (bool, error) {
+	suspended, err := starboardConfig.GetScanJobsSuspended()
+	if err != nil {
+		return false, err
+	}
+	if suspended != nil {
+		return *suspended, nil
+	}
+	return config.ScanJobsSuspended, nil
+}
+
+func isSuspended(job batchv1.Job) bool {
+	return job.Spec.Suspend != nil && *job.Spec.Suspend
+}
+
+func isFinished(job batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) &&
+			condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// ScanJobsSwitchReconciler watches the starboard ConfigMap and flips the
+// ScanJobsSwitch when the scanJob.suspended key changes. Existing scan jobs are
+// suspended when scanning is paused. When scanning is resumed, suspended scan
+// jobs are resumed within the limit of concurrent scan jobs.
+type ScanJobsSwitchReconciler struct {
+	logr.Logger
+	etc.Config
+	starboard.ConfigData
+	client.Client
+	LimitChecker
+	*ScanJobsSwitch
+	// Throttle lowers the number of resumed scan jobs while requests are
+	// throttled. It is optional.
+	Throttle *throttle.Throttle
+}
+
+func (r *ScanJobsSwitchReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.ConfigMap{}, builder.WithPredicates(
+			predicate.Not(predicate.IsBeingTerminated),
+			predicate.HasName(starboard.ConfigMapName),
+			predicate.InNamespace(r.Config.Namespace))).
+		Complete(r)
+}
+
+func (r *ScanJobsSwitchReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Logger.WithValues("configMap", req.NamespacedName)
+
+	cm := &corev1.ConfigMap{}
+	err := r.Client.Get(ctx, req.NamespacedName, cm)
+	if err != nil && !errors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("getting ConfigMap from cache: %w", err)
+	}
+
+	suspended, err := scanJobsSuspended(r.Config, cm.Data)
+	if err != nil {
+		log.Error(err, "Ignoring invalid scan jobs switch")
+		suspended = r.Suspended()
+	}
+	if r.SetSuspended(suspended) {
+		log.Info("Flipped scan jobs switch", "suspended", suspended)
+	}
+
+	scanJobs, err := listScanJobs(ctx, r.Client, r.Config, r.ConfigData)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing scan jobs: %w", err)
+	}
+
+	if suspended {
+		for _, job := range scanJobs {
+			if isSuspended(job) || isFinished(job) {
+				continue
+			}
+			log.V(1).Info("Suspending scan job", "job", job.Namespace+"/"+job.Name)
+			err = r.setSuspend(ctx, job, true)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	_, scanJobsCount, err := r.LimitChecker.Check(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	capacity := r.Throttle.Limit(r.Config.ConcurrentScanJobsLimit) - scanJobsCount
+	pending := 0
+	for _, job := range scanJobs {
+		if !isSuspended(job) {
+			continue
+		}
+		if capacity <= 0 {
+			pending++
+			continue
+		}
+		log.V(1).Info("Resuming scan job", "job", job.Namespace+"/"+job.Name)
+		err = r.setSuspend(ctx, job, false)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		capacity--
+	}
+	if pending > 0 {
+		log.V(1).Info("Pushing back resuming of scan jobs",
+			"pendingScanJobs", pending,
+			"retryAfter", r.Config.ScanJobRetryAfter)
+		return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *ScanJobsSwitchReconciler) setSuspend(ctx context.Context, job batchv1.Job, suspend bool) error {
+	patch := client.MergeFrom(job.DeepCopy())
+	job.Spec.Suspend = pointer.Bool(suspend)
+	err := r.Client.Patch(ctx, &job, patch)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("patching scan job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	return nil
+}
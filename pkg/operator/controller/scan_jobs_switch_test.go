@@ -0,0 +1,146 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("ScanJobsSwitchReconciler", func() {
+
+	config := etc.Config{
+		Namespace:               "starboard-operator",
+		ConcurrentScanJobsLimit: 2,
+		ScanJobRetryAfter:       30 * time.Second,
+	}
+
+	newConfigMap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      starboard.ConfigMapName,
+				Namespace: "starboard-operator",
+			},
+			Data: data,
+		}
+	}
+
+	newScanJob := func(name string, suspend bool) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "starboard-operator",
+				Labels: map[string]string{
+					starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+				},
+			},
+			Spec: batchv1.JobSpec{Suspend: pointer.Bool(suspend)},
+		}
+	}
+
+	newReconciler := func(config etc.Config, objects ...client.Object) (*controller.ScanJobsSwitchReconciler, client.Client) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build()
+		starboardConfig := starboard.GetDefaultConfig()
+		scanJobsSwitch, err := controller.NewScanJobsSwitch(config, starboardConfig)
+		Expect(err).ToNot(HaveOccurred())
+		return &controller.ScanJobsSwitchReconciler{
+			Logger:         logr.Discard(),
+			Config:         config,
+			ConfigData:     starboardConfig,
+			Client:         c,
+			LimitChecker:   controller.NewLimitChecker(config, c, starboardConfig),
+			ScanJobsSwitch: scanJobsSwitch,
+		}, c
+	}
+
+	suspended := func(c client.Client, name string) bool {
+		var job batchv1.Job
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: "starboard-operator", Name: name}, &job)).To(Succeed())
+		return job.Spec.Suspend != nil && *job.Spec.Suspend
+	}
+
+	request := ctrl.Request{NamespacedName: types.NamespacedName{
+		Namespace: "starboard-operator",
+		Name:      starboard.ConfigMapName,
+	}}
+
+	Context("When scanning is paused with the starboard ConfigMap", func() {
+
+		It("Should suspend new and running scan jobs", func() {
+			reconciler, c := newReconciler(config,
+				newConfigMap(map[string]string{"scanJob.suspended": "true"}),
+				newScanJob("scan-vulnerabilityreport-hash1", false),
+			)
+
+			result, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(ctrl.Result{}))
+			Expect(reconciler.ScanJobsSwitch.Suspended()).To(BeTrue())
+			Expect(suspended(c, "scan-vulnerabilityreport-hash1")).To(BeTrue())
+
+			job := &batchv1.Job{}
+			reconciler.ScanJobsSwitch.ApplyTo(job)
+			Expect(job.Spec.Suspend).To(Equal(pointer.Bool(true)))
+		})
+
+	})
+
+	Context("When scanning is resumed with the starboard ConfigMap", func() {
+
+		It("Should resume suspended scan jobs within the limit", func() {
+			reconciler, c := newReconciler(etc.Config{
+				Namespace:               config.Namespace,
+				ConcurrentScanJobsLimit: config.ConcurrentScanJobsLimit,
+				ScanJobRetryAfter:       config.ScanJobRetryAfter,
+				ScanJobsSuspended:       true,
+			},
+				newConfigMap(map[string]string{"scanJob.suspended": "false"}),
+				newScanJob("scan-vulnerabilityreport-hash1", false),
+				newScanJob("scan-vulnerabilityreport-hash2", true),
+				newScanJob("scan-vulnerabilityreport-hash3", true),
+			)
+			Expect(reconciler.ScanJobsSwitch.Suspended()).To(BeTrue())
+
+			result, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(config.ScanJobRetryAfter))
+			Expect(reconciler.ScanJobsSwitch.Suspended()).To(BeFalse())
+			Expect(suspended(c, "scan-vulnerabilityreport-hash1")).To(BeFalse())
+			Expect([]bool{
+				suspended(c, "scan-vulnerabilityreport-hash2"),
+				suspended(c, "scan-vulnerabilityreport-hash3"),
+			}).To(ConsistOf(false, true), "only one scan job should be resumed within the limit")
+		})
+
+	})
+
+	Context("When the starboard ConfigMap does not override the environment", func() {
+
+		It("Should suspend scan jobs if OPERATOR_SCAN_JOBS_SUSPENDED is set", func() {
+			reconciler, _ := newReconciler(etc.Config{
+				Namespace:         config.Namespace,
+				ScanJobsSuspended: true,
+			}, newConfigMap(nil))
+
+			_, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reconciler.ScanJobsSwitch.Suspended()).To(BeTrue())
+		})
+
+	})
+
+})
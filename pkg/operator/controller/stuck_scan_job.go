@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var scanJobsRecoveredTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "starboard_scan_jobs_recovered_total",
+	Help: "Number of stuck scan jobs without pending or running pods which were deleted.",
+})
+
+func init() {
+	metrics.Registry.MustRegister(scanJobsRecoveredTotal)
+}
+
+// StuckScanJobReconciler deletes active scan jobs which were started, or
+// resumed, more than etc.Config.ScanJobMaxAge ago and have no pending or
+// running pod, e.g. because
+// the pod was evicted without the job being marked failed. Reconcilers of
+// scan jobs only process complete or failed jobs, and reconcilers of scanned
+// resources do not scan resources with an active scan job, so such resources
+// would never be scanned again. Suspended scan jobs have no pods by design
+// and are never deleted. Scanned resources of deleted scan jobs are sent to
+// Destinations to be rescanned.
+type StuckScanJobReconciler struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	kube.ObjectResolver
+	ext.Clock
+	// Destinations are channels of reconcilers of scanned resources. It is
+	// optional.
+	Destinations []chan<- event.GenericEvent
+}
+
+func (r *StuckScanJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1.Job{}, builder.WithPredicates(
+			predicate.ManagedByStarboardOperator,
+			predicate.Not(predicate.JobHasAnyCondition),
+		)).
+		Complete(r.ReconcileJob())
+}
+
+// ReconcileJob returns reconcile.Func that deletes a stuck scan job and
+// requeues its scanned resource once the scan job is old enough.
+func (r *StuckScanJobReconciler) ReconcileJob() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("job", req.NamespacedName)
+
+		job := &batchv1.Job{}
+		err := r.Client.Get(ctx, req.NamespacedName, job)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("getting job from cache: %w", err)
+		}
+		if _, finished := kube.JobFinishedCondition(job); finished || !job.DeletionTimestamp.IsZero() {
+			return ctrl.Result{}, nil
+		}
+		// Resuming a suspended job updates its spec, which triggers another
+		// reconciliation.
+		if job.Spec.Suspend != nil && *job.Spec.Suspend {
+			log.V(1).Info("Ignoring suspended scan job")
+			return ctrl.Result{}, nil
+		}
+
+		if pending := r.maxAge(job) - r.Clock.Now().Sub(startTime(job)); pending > 0 {
+			return ctrl.Result{RequeueAfter: pending}, nil
+		}
+		active, err := r.hasActivePod(ctx, job)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if active {
+			return ctrl.Result{RequeueAfter: r.Config.ScanJobMaxAge}, nil
+		}
+
+		log.Info("Deleting stuck scan job without pending or running pods")
+		err = r.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("deleting stuck scan job: %w", err)
+		}
+		scanJobsRecoveredTotal.Inc()
+
+		ref, err := kube.ObjectRefFromObjectMeta(job.ObjectMeta)
+		if err != nil {
+			log.V(1).Info("Not requeueing scan job without reference to scanned resource", "reason", err.Error())
+			return ctrl.Result{}, nil
+		}
+		obj, err := scannedObject(ctx, r.ObjectResolver, ref)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("getting scanned resource %s/%s: %w", ref.Kind, ref.Name, err)
+		}
+		for _, destination := range r.Destinations {
+			select {
+			case destination <- event.GenericEvent{Object: obj}:
+			case <-ctx.Done():
+				return ctrl.Result{}, nil
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+}
+
+// maxAge returns the age after which the specified scan job is stuck if it
+// has no pending or running pod. It is never shorter than the active deadline
+// of the scan job, after which the job controller fails the scan job itself.
+func (r *StuckScanJobReconciler) maxAge(job *batchv1.Job) time.Duration {
+	maxAge := r.Config.ScanJobMaxAge
+	if job.Spec.ActiveDeadlineSeconds != nil {
+		if deadline := time.Duration(*job.Spec.ActiveDeadlineSeconds) * time.Second; deadline > maxAge {
+			maxAge = deadline
+		}
+	}
+	return maxAge
+}
+
+// startTime returns the time when the specified job was started or last
+// resumed, or its creation time if the job controller has not started it yet.
+func startTime(job *batchv1.Job) time.Time {
+	if job.Status.StartTime != nil {
+		return job.Status.StartTime.Time
+	}
+	return job.CreationTimestamp.Time
+}
+
+// hasActivePod returns true if any pod of the specified job is pending or
+// running.
+func (r *StuckScanJobReconciler) hasActivePod(ctx context.Context, job *batchv1.Job) (bool, error) {
+	selector := labels.SelectorFromSet(labels.Set{"job-name": job.Name})
+	if job.Spec.Selector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(job.Spec.Selector)
+		if err != nil {
+			return false, fmt.Errorf("parsing selector of job: %w", err)
+		}
+	}
+	pods := &corev1.PodList{}
+	err := r.Client.List(ctx, pods, client.InNamespace(job.Namespace), client.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		return false, fmt.Errorf("listing pods of job: %w", err)
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodPending || pod.Status.Phase == corev1.PodRunning {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,150 @@
+package controller_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// TestStuckScanJobReconciler_EnvTest verifies that an active scan job whose
+// pod was evicted without the job being marked failed is deleted, and that
+// the scanned workload is requeued, whereas a suspended scan job without pods
+// is kept. There is no job controller in the test
+// environment, so the status of the scan job never changes. It requires
+// control plane binaries referenced by the KUBEBUILDER_ASSETS environment
+// variable.
+func TestStuckScanJobReconciler_EnvTest(t *testing.T) {
+	if testing.Short() || os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("Skipping test which requires KUBEBUILDER_ASSETS")
+	}
+
+	testEnv := &envtest.Environment{}
+	cfg, err := testEnv.Start()
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, testEnv.Stop())
+	}()
+
+	testClient, err := client.New(cfg, client.Options{Scheme: starboard.NewScheme()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, testClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "starboard-system"},
+	}))
+
+	labels := map[string]string{"app": "nginx"}
+	workload := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6d4cf56db6",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "nginx", Image: "nginx:1.16"}},
+				},
+			},
+		},
+	}
+	require.NoError(t, testClient.Create(ctx, workload))
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "scan-vulnerabilityreport-5b4d8f7c9",
+			Namespace: "starboard-system",
+			Labels: map[string]string{
+				starboard.LabelK8SAppManagedBy:   starboard.AppStarboard,
+				starboard.LabelResourceKind:      string(kube.KindReplicaSet),
+				starboard.LabelResourceName:      workload.Name,
+				starboard.LabelResourceNamespace: workload.Namespace,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers:    []corev1.Container{{Name: "trivy", Image: "aquasec/trivy:0.25.2"}},
+				},
+			},
+		},
+	}
+	suspendedJob := job.DeepCopy()
+	suspendedJob.Name = "scan-vulnerabilityreport-7f6c9d8b4"
+	suspendedJob.Spec.Suspend = pointer.BoolPtr(true)
+	require.NoError(t, testClient.Create(ctx, job))
+	require.NoError(t, testClient.Create(ctx, suspendedJob))
+
+	// The API server labels pods of the scan job with its controller-uid,
+	// which is matched by the selector of the scan job.
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "scan-vulnerabilityreport-5b4d8f7c9-x2v7q",
+			Namespace: "starboard-system",
+			Labels:    job.Spec.Template.Labels,
+		},
+		Spec: job.Spec.Template.Spec,
+	}
+	require.NoError(t, testClient.Create(ctx, pod))
+	pod.Status.Phase = corev1.PodFailed
+	pod.Status.Reason = "Evicted"
+	require.NoError(t, testClient.Status().Update(ctx, pod))
+
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{Scheme: starboard.NewScheme(), MetricsBindAddress: "0"})
+	require.NoError(t, err)
+
+	requeued := make(chan event.GenericEvent, 1)
+	require.NoError(t, (&controller.StuckScanJobReconciler{
+		Logger: logr.Discard(),
+		Config: etc.Config{
+			Namespace:     "starboard-system",
+			ScanJobMaxAge: time.Second,
+		},
+		Client:         mgr.GetClient(),
+		ObjectResolver: kube.ObjectResolver{Client: mgr.GetClient()},
+		Clock:          ext.NewSystemClock(),
+		Destinations:   []chan<- event.GenericEvent{requeued},
+	}).SetupWithManager(mgr))
+
+	mgrCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		assert.NoError(t, mgr.Start(mgrCtx))
+	}()
+
+	select {
+	case e := <-requeued:
+		assert.Equal(t, client.ObjectKeyFromObject(workload), client.ObjectKeyFromObject(e.Object))
+	case <-time.After(30 * time.Second):
+		t.Fatal("Workload of stuck scan job was not requeued")
+	}
+	err = testClient.Get(ctx, client.ObjectKeyFromObject(job), &batchv1.Job{})
+	assert.True(t, errors.IsNotFound(err), "Stuck scan job was not deleted: %v", err)
+
+	// Both scan jobs are older than the max age by now.
+	time.Sleep(2 * time.Second)
+	assert.NoError(t, testClient.Get(ctx, client.ObjectKeyFromObject(suspendedJob), &batchv1.Job{}),
+		"Suspended scan job was deleted")
+}
@@ -0,0 +1,196 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+var _ = Describe("StuckScanJobReconciler", func() {
+
+	now := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	workload := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6d4cf56db6",
+			Namespace: "default",
+		},
+	}
+
+	newJob := func(createdAt time.Time) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "scan-vulnerabilityreport-5b4d8f7c9",
+				Namespace:         "starboard",
+				CreationTimestamp: metav1.NewTime(createdAt),
+				Labels: map[string]string{
+					starboard.LabelK8SAppManagedBy:   starboard.AppStarboard,
+					starboard.LabelResourceKind:      string(kube.KindReplicaSet),
+					starboard.LabelResourceName:      "nginx-6d4cf56db6",
+					starboard.LabelResourceNamespace: "default",
+				},
+			},
+		}
+	}
+
+	newPod := func(phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "scan-vulnerabilityreport-5b4d8f7c9-x2v7q",
+				Namespace: "starboard",
+				Labels: map[string]string{
+					"job-name": "scan-vulnerabilityreport-5b4d8f7c9",
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: phase,
+			},
+		}
+	}
+
+	newReconciler := func(objects ...client.Object) (*controller.StuckScanJobReconciler, client.Client, chan event.GenericEvent) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build()
+		requeued := make(chan event.GenericEvent, 1)
+		return &controller.StuckScanJobReconciler{
+			Logger: logr.Discard(),
+			Config: etc.Config{
+				Namespace:     "starboard",
+				ScanJobMaxAge: 10 * time.Minute,
+			},
+			Client:         c,
+			ObjectResolver: kube.ObjectResolver{Client: c},
+			Clock:          ext.NewFixedClock(now),
+			Destinations:   []chan<- event.GenericEvent{requeued},
+		}, c, requeued
+	}
+
+	request := ctrl.Request{NamespacedName: types.NamespacedName{
+		Namespace: "starboard",
+		Name:      "scan-vulnerabilityreport-5b4d8f7c9",
+	}}
+
+	It("Should requeue job until max age elapses", func() {
+		reconciler, _, requeued := newReconciler(workload, newJob(now.Add(-4*time.Minute)))
+
+		result, err := reconciler.ReconcileJob()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(6 * time.Minute))
+		Expect(requeued).To(BeEmpty())
+	})
+
+	It("Should requeue job until active deadline elapses", func() {
+		job := newJob(now.Add(-12 * time.Minute))
+		job.Spec.ActiveDeadlineSeconds = pointer.Int64Ptr(900)
+		reconciler, _, requeued := newReconciler(workload, job)
+
+		result, err := reconciler.ReconcileJob()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(3 * time.Minute))
+		Expect(requeued).To(BeEmpty())
+	})
+
+	It("Should keep job with running pod", func() {
+		reconciler, c, requeued := newReconciler(workload, newJob(now.Add(-12*time.Minute)), newPod(corev1.PodRunning))
+
+		result, err := reconciler.ReconcileJob()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(10 * time.Minute))
+		Expect(requeued).To(BeEmpty())
+		Expect(c.Get(context.Background(), request.NamespacedName, &batchv1.Job{})).To(Succeed())
+	})
+
+	It("Should delete job with evicted pod and requeue workload", func() {
+		reconciler, c, requeued := newReconciler(workload, newJob(now.Add(-12*time.Minute)), newPod(corev1.PodFailed))
+
+		result, err := reconciler.ReconcileJob()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+		err = c.Get(context.Background(), request.NamespacedName, &batchv1.Job{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+
+		var e event.GenericEvent
+		Expect(requeued).To(Receive(&e))
+		Expect(e.Object.GetName()).To(Equal("nginx-6d4cf56db6"))
+		Expect(e.Object).To(BeAssignableToTypeOf(&appsv1.ReplicaSet{}))
+	})
+
+	It("Should delete job of deleted workload without requeueing it", func() {
+		reconciler, c, requeued := newReconciler(newJob(now.Add(-12 * time.Minute)))
+
+		result, err := reconciler.ReconcileJob()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+		err = c.Get(context.Background(), request.NamespacedName, &batchv1.Job{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		Expect(requeued).To(BeEmpty())
+	})
+
+	It("Should delete resumed job with evicted pod", func() {
+		job := newJob(now.Add(-12 * time.Minute))
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobSuspended, Status: corev1.ConditionFalse}}
+		reconciler, c, requeued := newReconciler(workload, job, newPod(corev1.PodFailed))
+
+		result, err := reconciler.ReconcileJob()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+		err = c.Get(context.Background(), request.NamespacedName, &batchv1.Job{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		Expect(requeued).To(HaveLen(1))
+	})
+
+	It("Should ignore suspended job", func() {
+		job := newJob(now.Add(-12 * time.Minute))
+		job.Spec.Suspend = pointer.BoolPtr(true)
+		reconciler, c, requeued := newReconciler(workload, job)
+
+		result, err := reconciler.ReconcileJob()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+		Expect(requeued).To(BeEmpty())
+		Expect(c.Get(context.Background(), request.NamespacedName, &batchv1.Job{})).To(Succeed())
+	})
+
+	It("Should requeue resumed job until max age since its start elapses", func() {
+		job := newJob(now.Add(-12 * time.Minute))
+		startTime := metav1.NewTime(now.Add(-4 * time.Minute))
+		job.Status.StartTime = &startTime
+		reconciler, _, requeued := newReconciler(workload, job, newPod(corev1.PodFailed))
+
+		result, err := reconciler.ReconcileJob()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(6 * time.Minute))
+		Expect(requeued).To(BeEmpty())
+	})
+
+	It("Should ignore finished job", func() {
+		job := newJob(now.Add(-12 * time.Minute))
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+		reconciler, c, requeued := newReconciler(workload, job)
+
+		result, err := reconciler.ReconcileJob()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+		Expect(requeued).To(BeEmpty())
+		Expect(c.Get(context.Background(), request.NamespacedName, &batchv1.Job{})).To(Succeed())
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/utils"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
@@ -68,6 +69,10 @@ func (r *TTLReportReconciler) reconcileReport() reconcile.Func {
 			return ctrl.Result{}, fmt.Errorf("failed parsing %v with value %v %w", v1alpha1.TTLReportAnnotation, ttlReportAnnotationStr, err)
 		}
 		ttlExpired, durationToTTLExpiration := utils.IsTTLExpired(reportTTLTime, report.Report.UpdateTimestamp.Time, r.Clock)
+		if ttlExpired && kube.IsProtected(report) {
+			log.V(1).Info("Keeping protected vulnerabilityReport with expired TTL")
+			return ctrl.Result{}, nil
+		}
 		if ttlExpired {
 			log.V(1).Info("Removing vulnerabilityReport with expired TTL")
 			err := r.Client.Delete(ctx, report, &client.DeleteOptions{})
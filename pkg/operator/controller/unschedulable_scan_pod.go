@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ReasonScanJobUnschedulable is the reason of warning events of workloads
+// whose scan pods cannot be scheduled, e.g. because no node has enough
+// resources requested by scan containers.
+const ReasonScanJobUnschedulable = "ScanJobUnschedulable"
+
+// UnschedulableScanPodReconciler records a warning event of the scanned
+// workload when its scan pod remains unschedulable for longer than
+// etc.Config.ScanJobUnschedulableGracePeriod. Otherwise such scans are only
+// noticed when the scan job times out.
+type UnschedulableScanPodReconciler struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	kube.ObjectResolver
+	Recorder record.EventRecorder
+	ext.Clock
+}
+
+func (r *UnschedulableScanPodReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Pod{}, builder.WithPredicates(
+			predicate.InNamespace(r.Config.Namespace),
+			predicate.ManagedByStarboardOperator,
+			predicate.PodIsUnschedulable,
+		)).
+		Complete(r.ReconcilePod())
+}
+
+// ReconcilePod returns reconcile.Func that records the warning event once the
+// grace period of an unschedulable scan pod has elapsed.
+func (r *UnschedulableScanPodReconciler) ReconcilePod() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("pod", req.NamespacedName)
+
+		pod := &corev1.Pod{}
+		err := r.Client.Get(ctx, req.NamespacedName, pod)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("getting pod from cache: %w", err)
+		}
+
+		condition := unschedulableCondition(pod)
+		if condition == nil {
+			return ctrl.Result{}, nil
+		}
+		if pending := r.Config.ScanJobUnschedulableGracePeriod - r.Clock.Now().Sub(condition.LastTransitionTime.Time); pending > 0 {
+			return ctrl.Result{RequeueAfter: pending}, nil
+		}
+
+		ref, err := kube.ObjectRefFromObjectMeta(pod.ObjectMeta)
+		if err != nil {
+			log.V(1).Info("Ignoring pod without reference to scanned resource", "reason", err.Error())
+			return ctrl.Result{}, nil
+		}
+		obj, err := scannedObject(ctx, r.ObjectResolver, ref)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("getting scanned resource %s/%s: %w", ref.Kind, ref.Name, err)
+		}
+
+		log.Info("Scan pod is unschedulable", "message", condition.Message)
+		r.Recorder.Eventf(obj, corev1.EventTypeWarning, ReasonScanJobUnschedulable,
+			"Scan pod %s/%s cannot be scheduled: %s", pod.Namespace, pod.Name, condition.Message)
+		return ctrl.Result{}, nil
+	}
+}
+
+// scannedObject returns the resource scanned by a scan pod or job. Nodes are
+// scanned by CIS Kubernetes Benchmark scan jobs.
+func scannedObject(ctx context.Context, resolver kube.ObjectResolver, ref kube.ObjectRef) (client.Object, error) {
+	if ref.Kind != kube.KindNode {
+		return resolver.ObjectFromObjectRef(ctx, ref)
+	}
+	node := &corev1.Node{}
+	err := resolver.Client.Get(ctx, client.ObjectKey{Name: ref.Name}, node)
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// unschedulableCondition returns the PodScheduled condition of a pending pod
+// which the scheduler failed to place, or nil.
+func unschedulableCondition(pod *corev1.Pod) *corev1.PodCondition {
+	if pod.Status.Phase != corev1.PodPending {
+		return nil
+	}
+	for i := range pod.Status.Conditions {
+		condition := &pod.Status.Conditions[i]
+		if condition.Type == corev1.PodScheduled &&
+			condition.Status == corev1.ConditionFalse &&
+			condition.Reason == corev1.PodReasonUnschedulable {
+			return condition
+		}
+	}
+	return nil
+}
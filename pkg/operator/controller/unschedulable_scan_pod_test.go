@@ -0,0 +1,123 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("UnschedulableScanPodReconciler", func() {
+
+	now := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	workload := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6d4cf56db6",
+			Namespace: "default",
+		},
+	}
+
+	newPod := func(unschedulableSince time.Time) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "scan-vulnerabilityreport-5b4d8f7c9-x2v7q",
+				Namespace: "starboard",
+				Labels: map[string]string{
+					starboard.LabelK8SAppManagedBy:   starboard.AppStarboard,
+					starboard.LabelResourceKind:      string(kube.KindReplicaSet),
+					starboard.LabelResourceName:      "nginx-6d4cf56db6",
+					starboard.LabelResourceNamespace: "default",
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				Conditions: []corev1.PodCondition{
+					{
+						Type:               corev1.PodScheduled,
+						Status:             corev1.ConditionFalse,
+						Reason:             corev1.PodReasonUnschedulable,
+						Message:            "0/3 nodes are available: 3 Insufficient memory.",
+						LastTransitionTime: metav1.NewTime(unschedulableSince),
+					},
+				},
+			},
+		}
+	}
+
+	newReconciler := func(objects ...client.Object) (*controller.UnschedulableScanPodReconciler, *record.FakeRecorder) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build()
+		recorder := record.NewFakeRecorder(10)
+		return &controller.UnschedulableScanPodReconciler{
+			Logger: logr.Discard(),
+			Config: etc.Config{
+				Namespace:                       "starboard",
+				ScanJobUnschedulableGracePeriod: 2 * time.Minute,
+			},
+			Client:         c,
+			ObjectResolver: kube.ObjectResolver{Client: c},
+			Recorder:       recorder,
+			Clock:          ext.NewFixedClock(now),
+		}, recorder
+	}
+
+	request := ctrl.Request{NamespacedName: types.NamespacedName{
+		Namespace: "starboard",
+		Name:      "scan-vulnerabilityreport-5b4d8f7c9-x2v7q",
+	}}
+
+	It("Should requeue pod until grace period elapses", func() {
+		reconciler, recorder := newReconciler(workload, newPod(now.Add(-30*time.Second)))
+
+		result, err := reconciler.ReconcilePod()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(90 * time.Second))
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("Should record event of workload when grace period elapsed", func() {
+		reconciler, recorder := newReconciler(workload, newPod(now.Add(-3*time.Minute)))
+
+		result, err := reconciler.ReconcilePod()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+		Expect(recorder.Events).To(Receive(Equal("Warning ScanJobUnschedulable Scan pod " +
+			"starboard/scan-vulnerabilityreport-5b4d8f7c9-x2v7q cannot be scheduled: 0/3 nodes are available: 3 Insufficient memory.")))
+	})
+
+	It("Should ignore scheduled pod", func() {
+		pod := newPod(now.Add(-3 * time.Minute))
+		pod.Status.Phase = corev1.PodRunning
+		reconciler, recorder := newReconciler(workload, pod)
+
+		result, err := reconciler.ReconcilePod()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("Should ignore pod of deleted workload", func() {
+		reconciler, recorder := newReconciler(newPod(now.Add(-3 * time.Minute)))
+
+		result, err := reconciler.ReconcilePod()(context.Background(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+		Expect(recorder.Events).To(BeEmpty())
+	})
+})
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// UntargetedNamespaceCleaner cleans up reports in namespaces which are not
+// targeted by the operator, e.g. because they were removed from
+// OPERATOR_TARGET_NAMESPACES or added to OPERATOR_EXCLUDE_NAMESPACES. Such
+// reports are no longer updated nor garbage collected by the operator.
+//
+// Depending on etc.Config UntargetedNamespaceCleanup reports are deleted or
+// labeled with starboard.LabelOrphaned. Protected reports, see
+// kube.IsProtected, are labeled rather than deleted. Reports in targeted
+// namespaces which were marked as orphaned before are unmarked.
+//
+// Since the operator configuration cannot change without restarting the
+// operator, the cleanup runs once on startup.
+type UntargetedNamespaceCleaner struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	// Reader reads reports in all namespaces, including namespaces which are
+	// not cached by the manager.
+	Reader client.Reader
+}
+
+func (r *UntargetedNamespaceCleaner) SetupWithManager(mgr ctrl.Manager) error {
+	return mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		// Do not stop the manager if the cleanup fails.
+		if err := r.Cleanup(ctx); err != nil {
+			r.Logger.Error(err, "Unable to clean up reports in untargeted namespaces")
+		}
+		return nil
+	}))
+}
+
+// Cleanup deletes or marks reports in untargeted namespaces, and unmarks
+// reports in targeted namespaces.
+func (r *UntargetedNamespaceCleaner) Cleanup(ctx context.Context) error {
+	if r.Config.UntargetedNamespaceCleanup == etc.CleanupIgnore {
+		return nil
+	}
+	installModePredicate, err := predicate.InstallModePredicate(r.Config)
+	if err != nil {
+		return err
+	}
+
+	hasResourceKind, err := labels.NewRequirement(starboard.LabelResourceKind, selection.Exists, nil)
+	if err != nil {
+		return err
+	}
+	selector := labels.NewSelector().Add(*hasResourceKind)
+
+	for _, list := range []client.ObjectList{
+		&v1alpha1.VulnerabilityReportList{},
+		&v1alpha1.PackageInventoryList{},
+		&v1alpha1.ConfigAuditReportList{},
+	} {
+		err = r.Reader.List(ctx, list, client.MatchingLabelsSelector{Selector: selector})
+		if meta.IsNoMatchError(err) {
+			// The CRD of package inventories is optional.
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("listing reports: %w", err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			report, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			targeted := installModePredicate.Generic(event.GenericEvent{Object: report})
+			if err = r.cleanupReport(ctx, report, targeted); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *UntargetedNamespaceCleaner) cleanupReport(ctx context.Context, report client.Object, targeted bool) error {
+	log := r.Logger.WithValues("kind", fmt.Sprintf("%T", report), "report", client.ObjectKeyFromObject(report))
+	_, orphaned := report.GetLabels()[starboard.LabelOrphaned]
+
+	switch {
+	case targeted && orphaned:
+		log.V(1).Info("Unmarking orphaned report in targeted namespace")
+		reportLabels := report.GetLabels()
+		delete(reportLabels, starboard.LabelOrphaned)
+		report.SetLabels(reportLabels)
+		return r.update(ctx, report)
+	case targeted:
+		return nil
+	case r.Config.UntargetedNamespaceCleanup == etc.CleanupDelete && !kube.IsProtected(report):
+		log.V(1).Info("Deleting report in untargeted namespace")
+		err := r.Client.Delete(ctx, report)
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("deleting report %q: %w", client.ObjectKeyFromObject(report), err)
+		}
+		return nil
+	case !orphaned:
+		log.V(1).Info("Marking report in untargeted namespace as orphaned")
+		reportLabels := report.GetLabels()
+		reportLabels[starboard.LabelOrphaned] = "true"
+		report.SetLabels(reportLabels)
+		return r.update(ctx, report)
+	}
+	return nil
+}
+
+func (r *UntargetedNamespaceCleaner) update(ctx context.Context, report client.Object) error {
+	err := r.Client.Update(ctx, report)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("updating report %q: %w", client.ObjectKeyFromObject(report), err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("UntargetedNamespaceCleaner", func() {
+
+	reportLabels := func(namespace string, orphaned bool) map[string]string {
+		labels := map[string]string{
+			starboard.LabelResourceKind:      "ReplicaSet",
+			starboard.LabelResourceName:      "wordpress",
+			starboard.LabelResourceNamespace: namespace,
+		}
+		if orphaned {
+			labels[starboard.LabelOrphaned] = "true"
+		}
+		return labels
+	}
+
+	newObjects := func() []client.Object {
+		return []client.Object{
+			&v1alpha1.VulnerabilityReport{ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-wordpress-wordpress",
+				Namespace: "default",
+				Labels:    reportLabels("default", true),
+			}},
+			&v1alpha1.VulnerabilityReport{ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-wordpress-wordpress",
+				Namespace: "staging",
+				Labels:    reportLabels("staging", false),
+			}},
+			&v1alpha1.PackageInventory{ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-wordpress-wordpress",
+				Namespace: "staging",
+				Labels:    reportLabels("staging", false),
+			}},
+			&v1alpha1.ConfigAuditReport{ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-wordpress",
+				Namespace: "staging",
+				Labels:    reportLabels("staging", false),
+			}},
+			// Not created by Starboard, hence it's never cleaned up.
+			&v1alpha1.ConfigAuditReport{ObjectMeta: metav1.ObjectMeta{
+				Name:      "custom",
+				Namespace: "staging",
+			}},
+		}
+	}
+
+	newCleaner := func(cleanup etc.UntargetedNamespaceCleanup) (*controller.UntargetedNamespaceCleaner, client.Client) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(newObjects()...).Build()
+		return &controller.UntargetedNamespaceCleaner{
+			Logger: logr.Discard(),
+			Config: etc.Config{
+				Namespace:                  "starboard-system",
+				TargetNamespaces:           "default,production",
+				UntargetedNamespaceCleanup: cleanup,
+			},
+			Client: c,
+			Reader: c,
+		}, c
+	}
+
+	getLabels := func(c client.Client, obj client.Object, namespace, name string) map[string]string {
+		Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: name}, obj)).To(Succeed())
+		return obj.GetLabels()
+	}
+
+	It("Should mark reports in untargeted namespaces as orphaned", func() {
+		cleaner, c := newCleaner(etc.CleanupMark)
+		Expect(cleaner.Cleanup(context.TODO())).To(Succeed())
+
+		Expect(getLabels(c, &v1alpha1.VulnerabilityReport{}, "staging", "replicaset-wordpress-wordpress")).
+			To(HaveKeyWithValue(starboard.LabelOrphaned, "true"))
+		Expect(getLabels(c, &v1alpha1.PackageInventory{}, "staging", "replicaset-wordpress-wordpress")).
+			To(HaveKeyWithValue(starboard.LabelOrphaned, "true"))
+		Expect(getLabels(c, &v1alpha1.ConfigAuditReport{}, "staging", "replicaset-wordpress")).
+			To(HaveKeyWithValue(starboard.LabelOrphaned, "true"))
+		Expect(getLabels(c, &v1alpha1.ConfigAuditReport{}, "staging", "custom")).
+			ToNot(HaveKey(starboard.LabelOrphaned))
+		Expect(getLabels(c, &v1alpha1.VulnerabilityReport{}, "default", "replicaset-wordpress-wordpress")).
+			ToNot(HaveKey(starboard.LabelOrphaned))
+	})
+
+	It("Should delete reports in untargeted namespaces", func() {
+		cleaner, c := newCleaner(etc.CleanupDelete)
+		Expect(cleaner.Cleanup(context.TODO())).To(Succeed())
+
+		var vulnerabilityReports v1alpha1.VulnerabilityReportList
+		Expect(c.List(context.TODO(), &vulnerabilityReports)).To(Succeed())
+		Expect(vulnerabilityReports.Items).To(HaveLen(1))
+		Expect(vulnerabilityReports.Items[0].Namespace).To(Equal("default"))
+		Expect(vulnerabilityReports.Items[0].Labels).ToNot(HaveKey(starboard.LabelOrphaned))
+
+		var packageInventories v1alpha1.PackageInventoryList
+		Expect(c.List(context.TODO(), &packageInventories)).To(Succeed())
+		Expect(packageInventories.Items).To(BeEmpty())
+
+		var configAuditReports v1alpha1.ConfigAuditReportList
+		Expect(c.List(context.TODO(), &configAuditReports)).To(Succeed())
+		Expect(configAuditReports.Items).To(HaveLen(1))
+		Expect(configAuditReports.Items[0].Name).To(Equal("custom"))
+	})
+
+	It("Should leave reports as they are when cleanup is ignored", func() {
+		cleaner, c := newCleaner(etc.CleanupIgnore)
+		Expect(cleaner.Cleanup(context.TODO())).To(Succeed())
+
+		Expect(getLabels(c, &v1alpha1.VulnerabilityReport{}, "staging", "replicaset-wordpress-wordpress")).
+			ToNot(HaveKey(starboard.LabelOrphaned))
+		Expect(getLabels(c, &v1alpha1.VulnerabilityReport{}, "default", "replicaset-wordpress-wordpress")).
+			To(HaveKeyWithValue(starboard.LabelOrphaned, "true"))
+	})
+
+})
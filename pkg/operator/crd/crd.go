@@ -0,0 +1,170 @@
+// Package crd provides primitives for installing, upgrading and verifying
+// custom resource definitions of Starboard when the operator starts.
+package crd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	embedded "github.com/aquasecurity/starboard"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/go-logr/logr"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationUnmanaged is the annotation of a CRD which the operator must
+	// not apply, e.g. because it's managed by a GitOps tool. Set it to "true"
+	// to opt out the CRD from management by the operator.
+	AnnotationUnmanaged = "starboard.aquasecurity.github.io/unmanaged"
+
+	// FieldOwner is the field manager of CRDs applied by the operator.
+	FieldOwner = "starboard-operator"
+
+	// ExpectedVersion is the version of custom resources read and written by
+	// the operator.
+	ExpectedVersion = "v1alpha1"
+)
+
+// Embedded returns CRDs embedded in the operator binary.
+func Embedded() ([]apiextensionsv1.CustomResourceDefinition, error) {
+	getters := []func() (apiextensionsv1.CustomResourceDefinition, error){
+		embedded.GetVulnerabilityReportsCRD,
+		embedded.GetClusterVulnerabilityReportsCRD,
+		embedded.GetPackageInventoriesCRD,
+		embedded.GetWorkloadVulnerabilitySummariesCRD,
+		embedded.GetImageInventoriesCRD,
+		embedded.GetConfigAuditReportsCRD,
+		embedded.GetClusterConfigAuditReportsCRD,
+		embedded.GetClusterComplianceReportsCRD,
+		embedded.GetClusterComplianceDetailReportsCRD,
+		embedded.GetComplianceReportsCRD,
+		embedded.GetClusterRiskReportsCRD,
+		embedded.GetCISKubeBenchReportsCRD,
+		embedded.GetKubeHunterReportsCRD,
+	}
+	var crds []apiextensionsv1.CustomResourceDefinition
+	for _, get := range getters {
+		crd, err := get()
+		if err != nil {
+			return nil, fmt.Errorf("decoding embedded CRD: %w", err)
+		}
+		crds = append(crds, crd)
+	}
+	return crds, nil
+}
+
+// Apply creates or updates the specified CRDs with server-side apply. CRDs
+// annotated with AnnotationUnmanaged are left as they are.
+func Apply(ctx context.Context, logger logr.Logger, c client.Client, crds []apiextensionsv1.CustomResourceDefinition) error {
+	for _, crd := range crds {
+		log := logger.WithValues("crd", crd.Name)
+
+		var existing apiextensionsv1.CustomResourceDefinition
+		err := c.Get(ctx, client.ObjectKey{Name: crd.Name}, &existing)
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("getting CRD %s: %w", crd.Name, err)
+		}
+		if err == nil && existing.Annotations[AnnotationUnmanaged] == "true" {
+			log.Info("Skipping CRD annotated as unmanaged", "annotation", AnnotationUnmanaged)
+			continue
+		}
+
+		applied := &apiextensionsv1.CustomResourceDefinition{
+			TypeMeta:   crd.TypeMeta,
+			ObjectMeta: crd.ObjectMeta,
+			Spec:       crd.Spec,
+		}
+		applied.APIVersion = apiextensionsv1.SchemeGroupVersion.String()
+		applied.Kind = "CustomResourceDefinition"
+		applied.ResourceVersion = ""
+		applied.ManagedFields = nil
+
+		log.V(1).Info("Applying CRD")
+		err = c.Patch(ctx, applied, client.Apply, client.FieldOwner(FieldOwner), client.ForceOwnership)
+		if err != nil {
+			return fmt.Errorf("applying CRD %s: %w", crd.Name, err)
+		}
+	}
+	return nil
+}
+
+// Verify checks that each of the specified CRDs exists, serves and stores
+// custom resources in ExpectedVersion, and that no custom resources are
+// stored in any other version.
+func Verify(ctx context.Context, c client.Reader, names ...string) error {
+	for _, name := range names {
+		var crd apiextensionsv1.CustomResourceDefinition
+		err := c.Get(ctx, client.ObjectKey{Name: name}, &crd)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return fmt.Errorf("CRD %s not found: expected version %s to be installed", name, ExpectedVersion)
+			}
+			return fmt.Errorf("getting CRD %s: %w", name, err)
+		}
+		if err = verifyVersions(crd); err != nil {
+			return fmt.Errorf("CRD %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func verifyVersions(crd apiextensionsv1.CustomResourceDefinition) error {
+	var served, storage bool
+	for _, version := range crd.Spec.Versions {
+		if version.Name == ExpectedVersion {
+			served = version.Served
+			storage = version.Storage
+		}
+	}
+	if !served {
+		return fmt.Errorf("expected version %s is not served", ExpectedVersion)
+	}
+	if !storage {
+		return fmt.Errorf("expected version %s is not the storage version", ExpectedVersion)
+	}
+	var unexpected []string
+	for _, version := range crd.Status.StoredVersions {
+		if version != ExpectedVersion {
+			unexpected = append(unexpected, version)
+		}
+	}
+	if len(unexpected) > 0 {
+		return fmt.Errorf("custom resources are stored in version %s instead of expected version %s",
+			strings.Join(unexpected, ", "), ExpectedVersion)
+	}
+	return nil
+}
+
+// Names of CRDs required by controllers of the operator.
+var (
+	VulnerabilityReports = []string{
+		v1alpha1.VulnerabilityReportsCRName,
+	}
+	PackageInventories = []string{
+		v1alpha1.PackageInventoriesCRName,
+	}
+	WorkloadVulnerabilitySummaries = []string{
+		v1alpha1.WorkloadVulnerabilitySummariesCRName,
+	}
+	ConfigAuditReports = []string{
+		v1alpha1.ConfigAuditReportCRName,
+		v1alpha1.ClusterConfigAuditReportCRName,
+	}
+	CISKubeBenchReports = []string{
+		v1alpha1.CISKubeBenchReportCRName,
+	}
+	ImageInventories = []string{
+		v1alpha1.ImageInventoriesCRName,
+	}
+	ClusterRiskReports = []string{
+		v1alpha1.ClusterRiskReportsCRName,
+	}
+	ClusterComplianceReports = []string{
+		v1alpha1.ClusterComplianceReportCRName,
+		v1alpha1.ClusterComplianceDetailReportCRName,
+	}
+)
@@ -0,0 +1,87 @@
+package crd_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/crd"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+// TestApply_EnvTest verifies that CRDs of an older version of Starboard are
+// upgraded to the embedded ones, except for CRDs annotated as unmanaged. It
+// requires control plane binaries referenced by the KUBEBUILDER_ASSETS
+// environment variable.
+func TestApply_EnvTest(t *testing.T) {
+	if testing.Short() || os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("Skipping test which requires KUBEBUILDER_ASSETS")
+	}
+
+	testEnv := &envtest.Environment{}
+	cfg, err := testEnv.Start()
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, testEnv.Stop())
+	}()
+
+	testClient, err := client.New(cfg, client.Options{Scheme: starboard.NewScheme()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	crds, err := crd.Embedded()
+	require.NoError(t, err)
+	embedded := make(map[string]apiextensionsv1.CustomResourceDefinition)
+	for _, c := range crds {
+		embedded[c.Name] = c
+	}
+
+	err = crd.Verify(ctx, testClient, crd.VulnerabilityReports...)
+	require.EqualError(t, err, "CRD vulnerabilityreports.aquasecurity.github.io not found: expected version v1alpha1 to be installed")
+
+	// CRDs installed by an older version of Starboard miss printer columns
+	// added since then.
+	older := func(name string, annotations map[string]string) *apiextensionsv1.CustomResourceDefinition {
+		c := embedded[name]
+		older := &apiextensionsv1.CustomResourceDefinition{Spec: *c.Spec.DeepCopy()}
+		older.Name = name
+		older.Annotations = annotations
+		for i := range older.Spec.Versions {
+			older.Spec.Versions[i].AdditionalPrinterColumns = nil
+		}
+		return older
+	}
+	require.NoError(t, testClient.Create(ctx, older(v1alpha1.VulnerabilityReportsCRName, nil)))
+	require.NoError(t, testClient.Create(ctx, older(v1alpha1.ConfigAuditReportCRName, map[string]string{
+		crd.AnnotationUnmanaged: "true",
+	})))
+
+	require.NoError(t, crd.Apply(ctx, logr.Discard(), testClient, crds))
+	// Applying CRDs again must not conflict with the previous apply.
+	require.NoError(t, crd.Apply(ctx, logr.Discard(), testClient, crds))
+
+	t.Run("Should upgrade older CRD", func(t *testing.T) {
+		var actual apiextensionsv1.CustomResourceDefinition
+		require.NoError(t, testClient.Get(ctx, client.ObjectKey{Name: v1alpha1.VulnerabilityReportsCRName}, &actual))
+		assert.Equal(t, embedded[v1alpha1.VulnerabilityReportsCRName].Spec.Versions[0].AdditionalPrinterColumns,
+			actual.Spec.Versions[0].AdditionalPrinterColumns)
+	})
+
+	t.Run("Should not upgrade unmanaged CRD", func(t *testing.T) {
+		var actual apiextensionsv1.CustomResourceDefinition
+		require.NoError(t, testClient.Get(ctx, client.ObjectKey{Name: v1alpha1.ConfigAuditReportCRName}, &actual))
+		assert.Empty(t, actual.Spec.Versions[0].AdditionalPrinterColumns)
+	})
+
+	t.Run("Should install missing CRDs", func(t *testing.T) {
+		names := append(append(append([]string{}, crd.VulnerabilityReports...), crd.PackageInventories...), crd.ClusterComplianceReports...)
+		assert.NoError(t, crd.Verify(ctx, testClient, names...))
+	})
+}
@@ -0,0 +1,101 @@
+package crd_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/crd"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newCRD(name string, storedVersions []string, versions ...apiextensionsv1.CustomResourceDefinitionVersion) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       apiextensionsv1.CustomResourceDefinitionSpec{Versions: versions},
+		Status:     apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: storedVersions},
+	}
+}
+
+func TestEmbedded(t *testing.T) {
+	crds, err := crd.Embedded()
+	require.NoError(t, err)
+	assert.Len(t, crds, 13)
+	for _, embedded := range crds {
+		assert.NotEmpty(t, embedded.Name)
+		assert.Equal(t, v1alpha1.SchemeGroupVersion.Group, embedded.Spec.Group)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	v1alpha1Version := apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1alpha1", Served: true, Storage: true}
+
+	testCases := []struct {
+		name          string
+		crd           *apiextensionsv1.CustomResourceDefinition
+		expectedError string
+	}{
+		{
+			name: "Should accept CRD which stores expected version",
+			crd:  newCRD(v1alpha1.VulnerabilityReportsCRName, []string{"v1alpha1"}, v1alpha1Version),
+		},
+		{
+			name:          "Should reject missing CRD",
+			expectedError: "CRD vulnerabilityreports.aquasecurity.github.io not found: expected version v1alpha1 to be installed",
+		},
+		{
+			name: "Should reject CRD which does not serve expected version",
+			crd: newCRD(v1alpha1.VulnerabilityReportsCRName, []string{"v1alpha1"},
+				apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1alpha1", Storage: true}),
+			expectedError: "CRD vulnerabilityreports.aquasecurity.github.io: expected version v1alpha1 is not served",
+		},
+		{
+			name: "Should reject CRD which stores another version",
+			crd: newCRD(v1alpha1.VulnerabilityReportsCRName, []string{"v1alpha1"},
+				apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1alpha1", Served: true},
+				apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1beta1", Served: true, Storage: true}),
+			expectedError: "CRD vulnerabilityreports.aquasecurity.github.io: expected version v1alpha1 is not the storage version",
+		},
+		{
+			name:          "Should reject CRD with resources stored in another version",
+			crd:           newCRD(v1alpha1.VulnerabilityReportsCRName, []string{"v1alpha0", "v1alpha1"}, v1alpha1Version),
+			expectedError: "CRD vulnerabilityreports.aquasecurity.github.io: custom resources are stored in version v1alpha0 instead of expected version v1alpha1",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(starboard.NewScheme())
+			if tc.crd != nil {
+				builder = builder.WithObjects(tc.crd)
+			}
+			err := crd.Verify(context.TODO(), builder.Build(), v1alpha1.VulnerabilityReportsCRName)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestApply_Unmanaged(t *testing.T) {
+	unmanaged := newCRD(v1alpha1.VulnerabilityReportsCRName, []string{"v1alpha1"})
+	unmanaged.Annotations = map[string]string{crd.AnnotationUnmanaged: "true"}
+	kubeClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(unmanaged).Build()
+
+	crds, err := crd.Embedded()
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1.VulnerabilityReportsCRName, crds[0].Name)
+	require.NoError(t, crd.Apply(context.TODO(), logr.Discard(), kubeClient, crds[:1]))
+
+	var actual apiextensionsv1.CustomResourceDefinition
+	require.NoError(t, kubeClient.Get(context.TODO(), client.ObjectKey{Name: v1alpha1.VulnerabilityReportsCRName}, &actual))
+	assert.Empty(t, actual.Spec.Versions)
+}
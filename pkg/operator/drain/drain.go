@@ -0,0 +1,88 @@
+// Package drain provides primitives for finishing reconciliations in flight
+// when the operator shuts down.
+package drain
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Drain lets reconciliations in flight when the operator shuts down finish,
+// e.g. parsing results of a complete scan job and writing reports, for up to
+// the drain timeout. Controllers wait for reconciliations in flight before they
+// stop, but the context passed to them is cancelled right away, so that their
+// requests to the API server fail and results of scan jobs are lost until the
+// jobs are reconciled again.
+//
+// It's safe for concurrent use. A nil Drain cancels reconciliations right away.
+type Drain struct {
+	timeout time.Duration
+}
+
+// New constructs a Drain with the specified timeout.
+func New(timeout time.Duration) *Drain {
+	return &Drain{timeout: timeout}
+}
+
+// Reconciler wraps the specified reconciler so that its reconciliations are
+// cancelled only after the drain timeout once the operator shuts down.
+func (d *Drain) Reconciler(r reconcile.Reconciler) reconcile.Reconciler {
+	if d == nil {
+		return r
+	}
+	return reconcile.Func(func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		ctx, cancel := d.Context(ctx)
+		defer cancel()
+		return r.Reconcile(ctx, req)
+	})
+}
+
+// Context returns a context carrying values of the specified parent context,
+// which is cancelled the drain timeout after the parent context is done, or
+// when the returned cancel function is called.
+func (d *Drain) Context(parent context.Context) (context.Context, context.CancelFunc) {
+	if d == nil {
+		return context.WithCancel(parent)
+	}
+	ctx, cancel := context.WithCancel(detachedContext{parent: parent})
+	go func() {
+		select {
+		case <-parent.Done():
+		case <-ctx.Done():
+			return
+		}
+		timer := time.NewTimer(d.timeout)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// detachedContext carries values of its parent context, but it's never
+// cancelled along with it.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
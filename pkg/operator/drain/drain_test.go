@@ -0,0 +1,77 @@
+package drain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/operator/drain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type contextKey struct{}
+
+func TestDrain_Context(t *testing.T) {
+	t.Run("Should keep values of parent context", func(t *testing.T) {
+		parent := context.WithValue(context.Background(), contextKey{}, "value")
+		ctx, cancel := drain.New(time.Minute).Context(parent)
+		defer cancel()
+		assert.Equal(t, "value", ctx.Value(contextKey{}))
+	})
+
+	t.Run("Should not cancel context before drain timeout", func(t *testing.T) {
+		parent, cancelParent := context.WithCancel(context.Background())
+		ctx, cancel := drain.New(time.Minute).Context(parent)
+		defer cancel()
+
+		cancelParent()
+		select {
+		case <-ctx.Done():
+			t.Fatal("context cancelled along with parent context")
+		case <-time.After(50 * time.Millisecond):
+		}
+		assert.NoError(t, ctx.Err())
+	})
+
+	t.Run("Should cancel context after drain timeout", func(t *testing.T) {
+		parent, cancelParent := context.WithCancel(context.Background())
+		ctx, cancel := drain.New(10 * time.Millisecond).Context(parent)
+		defer cancel()
+
+		cancelParent()
+		select {
+		case <-ctx.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatal("context not cancelled after drain timeout")
+		}
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+
+	t.Run("Should cancel context with parent context when drain is nil", func(t *testing.T) {
+		var d *drain.Drain
+		parent, cancelParent := context.WithCancel(context.Background())
+		ctx, cancel := d.Context(parent)
+		defer cancel()
+
+		cancelParent()
+		<-ctx.Done()
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+}
+
+func TestDrain_Reconciler(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	cancelParent()
+
+	var reconcileErr error
+	r := drain.New(time.Minute).Reconciler(reconcile.Func(func(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+		reconcileErr = ctx.Err()
+		return ctrl.Result{}, nil
+	}))
+	_, err := r.Reconcile(parent, ctrl.Request{})
+	require.NoError(t, err)
+	assert.NoError(t, reconcileErr)
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/caarlos0/env/v6"
 )
 
@@ -41,8 +42,237 @@ type Config struct {
 
 	LeaderElectionEnabled bool   `env:"OPERATOR_LEADER_ELECTION_ENABLED" envDefault:"false"`
 	LeaderElectionID      string `env:"OPERATOR_LEADER_ELECTION_ID" envDefault:"starboard-lock"`
+
+	// ReportsOwnership tells Starboard how security reports are associated
+	// with Kubernetes resources they describe, and therefore how they are
+	// cleaned up when those resources are deleted.
+	//
+	// With OwnerReference reports are garbage collected by Kubernetes. With
+	// LabelsOnly reports carry no owner references and are deleted by the
+	// operator based on starboard.resource.* labels.
+	ReportsOwnership ReportsOwnership `env:"OPERATOR_REPORTS_OWNERSHIP" envDefault:"ownerReference"`
+
+	// ReportsFinalizerEnabled tells Starboard to add a finalizer to workloads
+	// so that their reports are deleted before workloads disappear. It takes
+	// effect only with LabelsOnly reports ownership.
+	ReportsFinalizerEnabled bool `env:"OPERATOR_REPORTS_FINALIZER_ENABLED" envDefault:"false"`
+
+	// ImageInventoryEnabled tells Starboard to maintain the inventory of
+	// container images running in the cluster as ImageInventory objects.
+	ImageInventoryEnabled bool `env:"OPERATOR_IMAGE_INVENTORY_ENABLED" envDefault:"false"`
+
+	// ImageInventoryDebounce is the time to wait after a change of pods before
+	// the image inventory is updated, so that bursts of changes, e.g. rolling
+	// updates, result in a single update.
+	ImageInventoryDebounce time.Duration `env:"OPERATOR_IMAGE_INVENTORY_DEBOUNCE" envDefault:"30s"`
+
+	// UntargetedNamespaceCleanup tells Starboard what to do on startup with
+	// reports in namespaces which are no longer targeted, e.g. removed from
+	// OPERATOR_TARGET_NAMESPACES.
+	UntargetedNamespaceCleanup UntargetedNamespaceCleanup `env:"OPERATOR_UNTARGETED_NAMESPACE_CLEANUP" envDefault:"ignore"`
+
+	// MaxReportsPerNamespace is the maximum number of vulnerability and
+	// configuration audit reports in a namespace. When the limit is reached
+	// the oldest reports are evicted to make room for new ones. Zero means
+	// unlimited.
+	MaxReportsPerNamespace int `env:"OPERATOR_MAX_REPORTS_PER_NAMESPACE" envDefault:"0"`
+
+	// MetricsWorkloadReportMissingEnabled tells Starboard to export the
+	// starboard_workload_report_missing metric for workloads without reports.
+	// It enumerates workloads from the cache at each scrape, which might be
+	// expensive in very large clusters.
+	MetricsWorkloadReportMissingEnabled bool `env:"OPERATOR_METRICS_WORKLOAD_REPORT_MISSING_ENABLED" envDefault:"false"`
+
+	// MetricsNamespaceScoped tells Starboard to serve metrics of each
+	// namespace at a separate path, so that tenants can be granted access to
+	// metrics of their own namespaces only.
+	MetricsNamespaceScoped bool `env:"OPERATOR_METRICS_NAMESPACE_SCOPED" envDefault:"false"`
+
+	// MetricsAllowedNamespaces is a comma-separated list of namespaces whose
+	// metrics are served at separate paths, and at the metrics endpoint of
+	// the operator. Metrics of all namespaces are served if it's blank.
+	MetricsAllowedNamespaces string `env:"OPERATOR_METRICS_ALLOWED_NAMESPACES"`
+
+	// ScanJobsSuspended tells Starboard to create scan jobs with the suspend
+	// field set, so that scanning is paused without deleting anything. It can
+	// be flipped at runtime with the scanJob.suspended key of the starboard
+	// ConfigMap, which takes precedence.
+	ScanJobsSuspended bool `env:"OPERATOR_SCAN_JOBS_SUSPENDED" envDefault:"false"`
+
+	// ClusterName is the name of the cluster stamped into cluster-scoped
+	// reports, so that reports exported off-cluster retain their origin.
+	ClusterName string `env:"OPERATOR_CLUSTER_NAME"`
+
+	// ClusterMetadataTTL is the time for which cluster metadata, i.e. the
+	// Kubernetes version and the number of nodes, is cached.
+	ClusterMetadataTTL time.Duration `env:"OPERATOR_CLUSTER_METADATA_TTL" envDefault:"10m"`
+
+	// SkipScanRegistries is a comma separated list of registry hosts (or glob
+	// patterns) whose images must not be scanned, e.g. because their license
+	// forbids scanning. Containers running such images get vulnerability
+	// reports which state that scanning was skipped.
+	SkipScanRegistries string `env:"OPERATOR_SKIP_SCAN_REGISTRIES"`
+
+	// ScannerImageDigestRequired requires images of scanners which scan jobs
+	// are launched with, e.g. trivy.imageRef, to be pinned by sha256 digest.
+	ScannerImageDigestRequired bool `env:"OPERATOR_SCANNER_IMAGE_DIGEST_REQUIRED" envDefault:"false"`
+
+	// ScannerImageDigestAllowlist is a comma separated list of sha256 digests
+	// which images of scanners must be pinned by. Setting it implies
+	// ScannerImageDigestRequired.
+	ScannerImageDigestAllowlist string `env:"OPERATOR_SCANNER_IMAGE_DIGEST_ALLOWLIST"`
+
+	// VulnerabilityScannerDaemonSetNodeGroupLabel is the label of nodes, e.g.
+	// node.kubernetes.io/instance-type, by which DaemonSets are scanned per
+	// node group when their images resolve to different digests on nodes of
+	// different groups. By default each DaemonSet is scanned once.
+	VulnerabilityScannerDaemonSetNodeGroupLabel string `env:"OPERATOR_VULNERABILITY_SCANNER_DAEMONSET_NODE_GROUP_LABEL"`
+
+	// VulnerabilityScannerPlatformFromNodes tells Starboard to scan images of
+	// multi-arch image indexes for the platform of the nodes which run the
+	// scanned workload, as opposed to the default platform of the index.
+	VulnerabilityScannerPlatformFromNodes bool `env:"OPERATOR_VULNERABILITY_SCANNER_PLATFORM_FROM_NODES" envDefault:"false"`
+
+	// VulnerabilityScannerPlatform is the platform in the os/arch[/variant]
+	// format, e.g. linux/arm64, for which images of multi-arch image indexes
+	// are scanned when it's not determined from nodes, e.g. for workloads
+	// without running pods.
+	VulnerabilityScannerPlatform string `env:"OPERATOR_VULNERABILITY_SCANNER_PLATFORM"`
+
+	// VulnerabilityScannerWorkloadSummaryEnabled tells Starboard to maintain a
+	// WorkloadVulnerabilitySummary of each scanned workload, which consolidates
+	// VulnerabilityReports of all its containers.
+	VulnerabilityScannerWorkloadSummaryEnabled bool `env:"OPERATOR_VULNERABILITY_SCANNER_WORKLOAD_SUMMARY_ENABLED" envDefault:"false"`
+
+	// ScanSummaryInterval is the interval at which the starboard-scan-summary
+	// ConfigMap in the operator namespace is refreshed with per-namespace scan
+	// statistics. Set to 0 to disable the scan summary.
+	ScanSummaryInterval time.Duration `env:"OPERATOR_SCAN_SUMMARY_INTERVAL" envDefault:"0"`
+
+	// ScanSummaryMaxNamespaces is the maximum number of namespaces listed in
+	// the scan summary, which bounds the size of the ConfigMap. Namespaces
+	// beyond the limit are counted in cluster totals only, thus 0 lists no
+	// namespaces at all.
+	ScanSummaryMaxNamespaces int `env:"OPERATOR_SCAN_SUMMARY_MAX_NAMESPACES" envDefault:"100"`
+
+	// ClusterRiskReportInterval is the interval at which the ClusterRiskReport
+	// with the risk score of the cluster is refreshed. Set to 0 to disable the
+	// cluster risk report.
+	ClusterRiskReportInterval time.Duration `env:"OPERATOR_CLUSTER_RISK_REPORT_INTERVAL" envDefault:"0"`
+
+	// ConfigAuditEventsEnabled tells Starboard to record a warning event of a
+	// resource when its updated ConfigAuditReport has checks which passed
+	// before, and a normal event when failing checks are resolved.
+	ConfigAuditEventsEnabled bool `env:"OPERATOR_CONFIG_AUDIT_EVENTS_ENABLED" envDefault:"false"`
+
+	// ConfigAuditEventsInterval is the minimum time between events of the
+	// same reason recorded for a resource. Changes in between are not
+	// reported.
+	ConfigAuditEventsInterval time.Duration `env:"OPERATOR_CONFIG_AUDIT_EVENTS_INTERVAL" envDefault:"5m"`
+
+	// SIEMSyslogAddress is the URL of the syslog endpoint, e.g.
+	// tls://siem.example.com:6514, to which CEF events of created or updated
+	// vulnerability and configuration audit reports are sent. The udp, tcp and
+	// tls schemes are supported. Events are not sent if it's blank.
+	SIEMSyslogAddress string `env:"OPERATOR_SIEM_SYSLOG_ADDRESS"`
+
+	// SIEMSyslogTLSCAFile is the path to the PEM encoded CA certificates used
+	// to verify the syslog endpoint with the tls scheme. System roots are
+	// used if it's blank.
+	SIEMSyslogTLSCAFile string `env:"OPERATOR_SIEM_SYSLOG_TLS_CA_FILE"`
+
+	// SIEMEventMode is either report, to send a summary event per report, or
+	// finding, to send an event per vulnerability or failed check.
+	SIEMEventMode string `env:"OPERATOR_SIEM_EVENT_MODE" envDefault:"report"`
+
+	// SIEMSeverityFloor is the minimum severity of vulnerabilities and failed
+	// checks for which CEF events are sent.
+	SIEMSeverityFloor string `env:"OPERATOR_SIEM_SEVERITY_FLOOR" envDefault:"HIGH"`
+
+	// SIEMBufferSize is the maximum number of CEF events waiting to be sent.
+	// Events are dropped while the buffer is full, so that reconciliations
+	// never wait for the syslog endpoint.
+	SIEMBufferSize int `env:"OPERATOR_SIEM_BUFFER_SIZE" envDefault:"1000"`
+
+	// SIEMBatchSize is the maximum number of CEF events sent at once.
+	SIEMBatchSize int `env:"OPERATOR_SIEM_BATCH_SIZE" envDefault:"100"`
+
+	// SIEMFlushInterval is the maximum time a CEF event waits for a batch to
+	// fill before it's sent.
+	SIEMFlushInterval time.Duration `env:"OPERATOR_SIEM_FLUSH_INTERVAL" envDefault:"5s"`
+
+	// ShutdownDrainTimeout is the maximum time to wait for reconciliations in
+	// flight, e.g. ingesting results of complete scan jobs, when the operator
+	// shuts down.
+	ShutdownDrainTimeout time.Duration `env:"OPERATOR_SHUTDOWN_DRAIN_TIMEOUT" envDefault:"20s"`
+
+	// ScanJobUnschedulableGracePeriod is the time for which a scan pod may
+	// remain unschedulable before a warning event is recorded for the scanned
+	// resource. Set to 0 to disable the events.
+	ScanJobUnschedulableGracePeriod time.Duration `env:"OPERATOR_SCAN_JOB_UNSCHEDULABLE_GRACE_PERIOD" envDefault:"2m"`
+
+	// ScanJobMaxAge is the time since the start, or resumption, after which
+	// an active scan job without any pending or running pod, e.g. because its
+	// pod was evicted, is deleted as stuck and the scanned resource is
+	// rescanned. Scan jobs are never deleted before their active deadline, and
+	// suspended scan jobs are never deleted. Set to 0 to disable the recovery.
+	ScanJobMaxAge time.Duration `env:"OPERATOR_SCAN_JOB_MAX_AGE" envDefault:"10m"`
+
+	// ManageCRDs tells the operator to apply CRDs embedded in its binary when
+	// it starts, except for the ones annotated as unmanaged, and to disable
+	// features whose CRDs still can't be verified.
+	ManageCRDs bool `env:"OPERATOR_MANAGE_CRDS" envDefault:"false"`
+
+	// ThrottleBackoffBase is the initial time to wait before requeueing a
+	// reconciliation whose requests were throttled by the API server. It's
+	// doubled for each consecutive throttled request.
+	ThrottleBackoffBase time.Duration `env:"OPERATOR_THROTTLE_BACKOFF_BASE" envDefault:"1s"`
+
+	// ThrottleBackoffMax is the maximum time to wait before requeueing a
+	// throttled reconciliation. Throttling is considered to persist, and the
+	// limit of concurrent scan jobs is lowered, until no request was
+	// throttled for that long.
+	ThrottleBackoffMax time.Duration `env:"OPERATOR_THROTTLE_BACKOFF_MAX" envDefault:"5m"`
+
+	// WebhookEnabled tells Starboard to serve the validating webhook which
+	// denies deletion of reports annotated as protected.
+	WebhookEnabled bool `env:"OPERATOR_WEBHOOK_ENABLED" envDefault:"false"`
+
+	// WebhookPort is the port of the webhook server.
+	WebhookPort int `env:"OPERATOR_WEBHOOK_PORT" envDefault:"9443"`
+
+	// WebhookCertDir is the directory with the tls.crt and tls.key files of
+	// the serving certificate of the webhook server.
+	WebhookCertDir string `env:"OPERATOR_WEBHOOK_CERT_DIR" envDefault:"/tmp/k8s-webhook-server/serving-certs"`
+
+	// ProtectedReportsOverrideUsers is a comma separated list of usernames
+	// which are allowed to delete protected reports, e.g. by impersonating
+	// them with kubectl delete --as.
+	ProtectedReportsOverrideUsers string `env:"OPERATOR_PROTECTED_REPORTS_OVERRIDE_USERS"`
 }
 
+// ReportsOwnership represents the way security reports are associated with
+// Kubernetes resources.
+type ReportsOwnership string
+
+const (
+	OwnerReference ReportsOwnership = "ownerReference"
+	LabelsOnly     ReportsOwnership = "labelsOnly"
+)
+
+// UntargetedNamespaceCleanup represents the way reports in namespaces which
+// are not targeted by the operator are cleaned up.
+type UntargetedNamespaceCleanup string
+
+const (
+	// CleanupDelete deletes reports in untargeted namespaces.
+	CleanupDelete UntargetedNamespaceCleanup = "delete"
+	// CleanupMark labels reports in untargeted namespaces as orphaned.
+	CleanupMark UntargetedNamespaceCleanup = "mark"
+	// CleanupIgnore leaves reports in untargeted namespaces as they are.
+	CleanupIgnore UntargetedNamespaceCleanup = "ignore"
+)
+
 // GetOperatorConfig loads Config from environment variables.
 func GetOperatorConfig() (Config, error) {
 	var config Config
@@ -52,9 +282,70 @@ func GetOperatorConfig() (Config, error) {
 		return Config{}, fmt.Errorf("plugin-based and built-in configuration audit scanners cannot be enabled at the same time")
 	}
 
+	switch config.ReportsOwnership {
+	case OwnerReference, LabelsOnly:
+	default:
+		return Config{}, fmt.Errorf("invalid value (%s) of %s; allowed values (%s, %s)",
+			config.ReportsOwnership, "OPERATOR_REPORTS_OWNERSHIP", OwnerReference, LabelsOnly)
+	}
+
+	switch config.UntargetedNamespaceCleanup {
+	case CleanupDelete, CleanupMark, CleanupIgnore:
+	default:
+		return Config{}, fmt.Errorf("invalid value (%s) of %s; allowed values (%s, %s, %s)",
+			config.UntargetedNamespaceCleanup, "OPERATOR_UNTARGETED_NAMESPACE_CLEANUP", CleanupDelete, CleanupMark, CleanupIgnore)
+	}
+
+	if config.MaxReportsPerNamespace < 0 {
+		return Config{}, fmt.Errorf("invalid value (%d) of %s; must not be negative",
+			config.MaxReportsPerNamespace, "OPERATOR_MAX_REPORTS_PER_NAMESPACE")
+	}
+
+	if config.VulnerabilityScannerPlatform != "" && !IsValidPlatform(config.VulnerabilityScannerPlatform) {
+		return Config{}, fmt.Errorf("invalid value (%s) of %s; must be in the os/arch[/variant] format",
+			config.VulnerabilityScannerPlatform, "OPERATOR_VULNERABILITY_SCANNER_PLATFORM")
+	}
+
+	if config.ScanSummaryMaxNamespaces < 0 {
+		return Config{}, fmt.Errorf("invalid value (%d) of %s; must not be negative",
+			config.ScanSummaryMaxNamespaces, "OPERATOR_SCAN_SUMMARY_MAX_NAMESPACES")
+	}
+
+	if config.SIEMSyslogAddress != "" {
+		switch config.SIEMEventMode {
+		case "report", "finding":
+		default:
+			return Config{}, fmt.Errorf("invalid value (%s) of %s; allowed values (%s, %s)",
+				config.SIEMEventMode, "OPERATOR_SIEM_EVENT_MODE", "report", "finding")
+		}
+		if _, err := v1alpha1.StringToSeverity(config.SIEMSeverityFloor); err != nil {
+			return Config{}, fmt.Errorf("invalid value (%s) of %s: %w",
+				config.SIEMSeverityFloor, "OPERATOR_SIEM_SEVERITY_FLOOR", err)
+		}
+		if config.SIEMBufferSize < 1 || config.SIEMBatchSize < 1 || config.SIEMFlushInterval <= 0 {
+			return Config{}, fmt.Errorf("%s, %s and %s must be positive",
+				"OPERATOR_SIEM_BUFFER_SIZE", "OPERATOR_SIEM_BATCH_SIZE", "OPERATOR_SIEM_FLUSH_INTERVAL")
+		}
+	}
+
 	return config, err
 }
 
+// IsValidPlatform returns true if the given platform is in the
+// os/arch[/variant] format, e.g. linux/arm64 or linux/arm/v7.
+func IsValidPlatform(platform string) bool {
+	parts := strings.Split(platform, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return false
+	}
+	for _, part := range parts {
+		if part == "" {
+			return false
+		}
+	}
+	return true
+}
+
 // GetOperatorNamespace returns the namespace the operator should be running in.
 func (c Config) GetOperatorNamespace() (string, error) {
 	namespace := c.Namespace
@@ -64,6 +355,12 @@ func (c Config) GetOperatorNamespace() (string, error) {
 	return "", fmt.Errorf("%s must be set", "OPERATOR_NAMESPACE")
 }
 
+// SkipOwnerReference returns true if security reports should not carry owner
+// references to Kubernetes resources they describe.
+func (c Config) SkipOwnerReference() bool {
+	return c.ReportsOwnership == LabelsOnly
+}
+
 // GetTargetNamespaces returns namespaces the operator should be watching for changes.
 func (c Config) GetTargetNamespaces() []string {
 	namespaces := c.TargetNamespaces
@@ -73,6 +370,54 @@ func (c Config) GetTargetNamespaces() []string {
 	return []string{}
 }
 
+// GetSkipScanRegistries returns registry hosts (or glob patterns) whose
+// images must not be scanned.
+func (c Config) GetSkipScanRegistries() []string {
+	var registries []string
+	for _, registry := range strings.Split(c.SkipScanRegistries, ",") {
+		if registry = strings.TrimSpace(registry); registry != "" {
+			registries = append(registries, registry)
+		}
+	}
+	return registries
+}
+
+// GetScannerImageDigestAllowlist returns sha256 digests which images of
+// scanners must be pinned by, if any.
+func (c Config) GetScannerImageDigestAllowlist() []string {
+	var digests []string
+	for _, digest := range strings.Split(c.ScannerImageDigestAllowlist, ",") {
+		if digest = strings.TrimSpace(digest); digest != "" {
+			digests = append(digests, digest)
+		}
+	}
+	return digests
+}
+
+// GetMetricsAllowedNamespaces returns namespaces whose metrics are served at
+// separate paths.
+func (c Config) GetMetricsAllowedNamespaces() []string {
+	var namespaces []string
+	for _, namespace := range strings.Split(c.MetricsAllowedNamespaces, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
+
+// GetProtectedReportsOverrideUsers returns usernames which are allowed to
+// delete protected reports.
+func (c Config) GetProtectedReportsOverrideUsers() []string {
+	var users []string
+	for _, user := range strings.Split(c.ProtectedReportsOverrideUsers, ",") {
+		if user = strings.TrimSpace(user); user != "" {
+			users = append(users, user)
+		}
+	}
+	return users
+}
+
 // InstallMode represents multitenancy support defined by the Operator Lifecycle Manager spec.
 type InstallMode string
 
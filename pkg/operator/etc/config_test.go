@@ -18,6 +18,80 @@ func TestGetOperatorConfig(t *testing.T) {
 		assert.EqualError(t, err, "plugin-based and built-in configuration audit scanners cannot be enabled at the same time")
 	})
 
+	t.Run("Should return error when reports ownership is invalid", func(t *testing.T) {
+		t.Setenv("OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED", "false")
+		t.Setenv("OPERATOR_REPORTS_OWNERSHIP", "finalizer")
+		_, err := etc.GetOperatorConfig()
+		assert.EqualError(t, err, "invalid value (finalizer) of OPERATOR_REPORTS_OWNERSHIP; allowed values (ownerReference, labelsOnly)")
+	})
+
+	t.Run("Should return labelsOnly reports ownership", func(t *testing.T) {
+		t.Setenv("OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED", "false")
+		t.Setenv("OPERATOR_REPORTS_OWNERSHIP", "labelsOnly")
+		config, err := etc.GetOperatorConfig()
+		require.NoError(t, err)
+		assert.Equal(t, etc.LabelsOnly, config.ReportsOwnership)
+		assert.True(t, config.SkipOwnerReference())
+	})
+
+	t.Run("Should return error when untargeted namespace cleanup is invalid", func(t *testing.T) {
+		t.Setenv("OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED", "false")
+		t.Setenv("OPERATOR_UNTARGETED_NAMESPACE_CLEANUP", "archive")
+		_, err := etc.GetOperatorConfig()
+		assert.EqualError(t, err, "invalid value (archive) of OPERATOR_UNTARGETED_NAMESPACE_CLEANUP; allowed values (delete, mark, ignore)")
+	})
+
+	t.Run("Should return ignore untargeted namespace cleanup by default", func(t *testing.T) {
+		t.Setenv("OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED", "false")
+		config, err := etc.GetOperatorConfig()
+		require.NoError(t, err)
+		assert.Equal(t, etc.CleanupIgnore, config.UntargetedNamespaceCleanup)
+	})
+
+	t.Run("Should return error when max reports per namespace is negative", func(t *testing.T) {
+		t.Setenv("OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED", "false")
+		t.Setenv("OPERATOR_MAX_REPORTS_PER_NAMESPACE", "-1")
+		_, err := etc.GetOperatorConfig()
+		assert.EqualError(t, err, "invalid value (-1) of OPERATOR_MAX_REPORTS_PER_NAMESPACE; must not be negative")
+	})
+
+	t.Run("Should return unlimited reports per namespace by default", func(t *testing.T) {
+		t.Setenv("OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED", "false")
+		config, err := etc.GetOperatorConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 0, config.MaxReportsPerNamespace)
+	})
+
+	t.Run("Should return error when vulnerability scanner platform is invalid", func(t *testing.T) {
+		t.Setenv("OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED", "false")
+		t.Setenv("OPERATOR_VULNERABILITY_SCANNER_PLATFORM", "arm64")
+		_, err := etc.GetOperatorConfig()
+		assert.EqualError(t, err, "invalid value (arm64) of OPERATOR_VULNERABILITY_SCANNER_PLATFORM; must be in the os/arch[/variant] format")
+	})
+
+	t.Run("Should return error when max scan summary namespaces is negative", func(t *testing.T) {
+		t.Setenv("OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED", "false")
+		t.Setenv("OPERATOR_SCAN_SUMMARY_MAX_NAMESPACES", "-1")
+		_, err := etc.GetOperatorConfig()
+		assert.EqualError(t, err, "invalid value (-1) of OPERATOR_SCAN_SUMMARY_MAX_NAMESPACES; must not be negative")
+	})
+
+	t.Run("Should return error when SIEM event mode is invalid", func(t *testing.T) {
+		t.Setenv("OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED", "false")
+		t.Setenv("OPERATOR_SIEM_SYSLOG_ADDRESS", "udp://siem.example.com:514")
+		t.Setenv("OPERATOR_SIEM_EVENT_MODE", "check")
+		_, err := etc.GetOperatorConfig()
+		assert.EqualError(t, err, "invalid value (check) of OPERATOR_SIEM_EVENT_MODE; allowed values (report, finding)")
+	})
+
+	t.Run("Should return error when SIEM severity floor is invalid", func(t *testing.T) {
+		t.Setenv("OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED", "false")
+		t.Setenv("OPERATOR_SIEM_SYSLOG_ADDRESS", "udp://siem.example.com:514")
+		t.Setenv("OPERATOR_SIEM_SEVERITY_FLOOR", "SEVERE")
+		_, err := etc.GetOperatorConfig()
+		assert.EqualError(t, err, "invalid value (SEVERE) of OPERATOR_SIEM_SEVERITY_FLOOR: unrecognized name literal: SEVERE")
+	})
+
 }
 
 func TestOperator_GetTargetNamespaces(t *testing.T) {
@@ -56,6 +130,60 @@ func TestOperator_GetTargetNamespaces(t *testing.T) {
 	}
 }
 
+func TestOperator_GetSkipScanRegistries(t *testing.T) {
+	testCases := []struct {
+		name               string
+		operator           etc.Config
+		expectedRegistries []string
+	}{
+		{
+			name:               "Should return no registries",
+			operator:           etc.Config{},
+			expectedRegistries: nil,
+		},
+		{
+			name: "Should return trimmed registries",
+			operator: etc.Config{
+				SkipScanRegistries: "registry.vendor.example, *.vendor.example,",
+			},
+			expectedRegistries: []string{"registry.vendor.example", "*.vendor.example"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedRegistries, tc.operator.GetSkipScanRegistries())
+		})
+	}
+}
+
+func TestOperator_GetProtectedReportsOverrideUsers(t *testing.T) {
+	config := etc.Config{
+		ProtectedReportsOverrideUsers: "admin, system:serviceaccount:backup:restore,",
+	}
+	assert.Equal(t, []string{"admin", "system:serviceaccount:backup:restore"}, config.GetProtectedReportsOverrideUsers())
+	assert.Nil(t, etc.Config{}.GetProtectedReportsOverrideUsers())
+}
+
+func TestOperator_GetMetricsAllowedNamespaces(t *testing.T) {
+	config := etc.Config{
+		MetricsAllowedNamespaces: "team-a, team-b,",
+	}
+	assert.Equal(t, []string{"team-a", "team-b"}, config.GetMetricsAllowedNamespaces())
+	assert.Nil(t, etc.Config{}.GetMetricsAllowedNamespaces())
+}
+
+func TestOperator_GetScannerImageDigestAllowlist(t *testing.T) {
+	config := etc.Config{
+		ScannerImageDigestAllowlist: "sha256:5020dac24a63ef4f24452a0c63ebbfe93a5309e40f6353d1ee8221d2184ee954, sha256:8e1c4bd1a9a4b2c5e3d2a1b7e0f9c8d7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1,",
+	}
+	assert.Equal(t, []string{
+		"sha256:5020dac24a63ef4f24452a0c63ebbfe93a5309e40f6353d1ee8221d2184ee954",
+		"sha256:8e1c4bd1a9a4b2c5e3d2a1b7e0f9c8d7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1",
+	}, config.GetScannerImageDigestAllowlist())
+	assert.Nil(t, etc.Config{}.GetScannerImageDigestAllowlist())
+}
+
 func TestOperator_ResolveInstallMode(t *testing.T) {
 	testCases := []struct {
 		name string
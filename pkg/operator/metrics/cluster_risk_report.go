@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var clusterRiskScoreDesc = prometheus.NewDesc(
+	"starboard_cluster_risk_score",
+	"Risk score of the cluster, which is the weighted sum of its components.",
+	[]string{"name"}, nil,
+)
+
+var clusterRiskComponentDesc = prometheus.NewDesc(
+	"starboard_cluster_risk_component",
+	"Measured value, weight, and score of a component of the cluster risk score.",
+	[]string{"name", "component", "type"}, nil,
+)
+
+// ClusterRiskReportCollector is a prometheus.Collector which exports the risk
+// score of each v1alpha1.ClusterRiskReport along with the breakdown of the
+// score by component.
+//
+// Metrics are computed from reports at scrape time.
+type ClusterRiskReportCollector struct {
+	logr.Logger
+	client.Client
+}
+
+// NewClusterRiskReportCollector constructs a new ClusterRiskReportCollector.
+func NewClusterRiskReportCollector(logger logr.Logger, client client.Client) *ClusterRiskReportCollector {
+	return &ClusterRiskReportCollector{
+		Logger: logger,
+		Client: client,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *ClusterRiskReportCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- clusterRiskScoreDesc
+	descs <- clusterRiskComponentDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *ClusterRiskReportCollector) Collect(metrics chan<- prometheus.Metric) {
+	var reports v1alpha1.ClusterRiskReportList
+	err := c.Client.List(context.Background(), &reports)
+	if err != nil {
+		c.Logger.Error(err, "Unable to collect cluster risk report metrics")
+		return
+	}
+	for _, report := range reports.Items {
+		metrics <- prometheus.MustNewConstMetric(clusterRiskScoreDesc, prometheus.GaugeValue,
+			report.Report.Score, report.Name)
+		for _, component := range report.Report.Components {
+			metrics <- prometheus.MustNewConstMetric(clusterRiskComponentDesc, prometheus.GaugeValue,
+				component.Value, report.Name, component.Name, "value")
+			metrics <- prometheus.MustNewConstMetric(clusterRiskComponentDesc, prometheus.GaugeValue,
+				component.Weight, report.Name, component.Name, "weight")
+			metrics <- prometheus.MustNewConstMetric(clusterRiskComponentDesc, prometheus.GaugeValue,
+				component.Score, report.Name, component.Name, "score")
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestClusterRiskReportCollector(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.ClusterRiskReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+			Report: v1alpha1.ClusterRiskReportData{
+				Score: 19.5,
+				Components: []v1alpha1.RiskComponent{
+					{Name: "criticalVulnerabilities", Value: 12, Weight: 1, Score: 12},
+					{Name: "configAuditDangers", Value: 30, Weight: 0.25, Score: 7.5},
+				},
+			},
+		},
+	).Build()
+	collector := metrics.NewClusterRiskReportCollector(logr.Discard(), c)
+
+	expected := `
+# HELP starboard_cluster_risk_component Measured value, weight, and score of a component of the cluster risk score.
+# TYPE starboard_cluster_risk_component gauge
+starboard_cluster_risk_component{component="configAuditDangers",name="cluster",type="score"} 7.5
+starboard_cluster_risk_component{component="configAuditDangers",name="cluster",type="value"} 30
+starboard_cluster_risk_component{component="configAuditDangers",name="cluster",type="weight"} 0.25
+starboard_cluster_risk_component{component="criticalVulnerabilities",name="cluster",type="score"} 12
+starboard_cluster_risk_component{component="criticalVulnerabilities",name="cluster",type="value"} 12
+starboard_cluster_risk_component{component="criticalVulnerabilities",name="cluster",type="weight"} 1
+# HELP starboard_cluster_risk_score Risk score of the cluster, which is the weighted sum of its components.
+# TYPE starboard_cluster_risk_score gauge
+starboard_cluster_risk_score{name="cluster"} 19.5
+`
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+}
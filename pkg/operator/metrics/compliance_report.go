@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/compliance"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var complianceControlsDesc = prometheus.NewDesc(
+	"starboard_cluster_compliance_controls",
+	"Number of passing and failing controls of a cluster compliance report by control severity.",
+	[]string{"name", "severity", "status"}, nil,
+)
+
+var complianceControlStatusDesc = prometheus.NewDesc(
+	"starboard_compliance_control_status",
+	"Status of a control of a cluster compliance report, which is always 1.",
+	[]string{"report", "version", "control_id", "severity", "status"}, nil,
+)
+
+var complianceSummaryDesc = prometheus.NewDesc(
+	"starboard_compliance_summary",
+	"Number of passing, failing and warning checks of a cluster compliance report.",
+	[]string{"report", "version", "status"}, nil,
+)
+
+var complianceScoreDesc = prometheus.NewDesc(
+	"starboard_compliance_score",
+	"Percentage of passing controls of a cluster compliance report.",
+	[]string{"report", "version"}, nil,
+)
+
+var complianceGenerationsDesc = prometheus.NewDesc(
+	"starboard_compliance_report_generations",
+	"Number of generations of a cluster compliance report.",
+	[]string{"report", "version"}, nil,
+)
+
+var complianceLastChangedDesc = prometheus.NewDesc(
+	"starboard_compliance_report_last_changed_timestamp_seconds",
+	"Time when results of a cluster compliance report last changed, in seconds since the epoch.",
+	[]string{"report", "version"}, nil,
+)
+
+// ComplianceReportCollector is a prometheus.Collector which exports the number
+// of passing and failing controls of each v1alpha1.ClusterComplianceReport
+// grouped by control severity, as well as the status of each control, the
+// number of passing, failing and warning checks, the score, the number of
+// generations and the time of the last change of results of each report.
+//
+// Metrics are computed from the status of reports, which is written at the end
+// of each generation, at scrape time. Therefore, there are no stale metrics of
+// controls which were removed from a spec, or of deleted reports.
+type ComplianceReportCollector struct {
+	logr.Logger
+	client.Client
+}
+
+// NewComplianceReportCollector constructs a new ComplianceReportCollector.
+func NewComplianceReportCollector(logger logr.Logger, client client.Client) *ComplianceReportCollector {
+	return &ComplianceReportCollector{
+		Logger: logger,
+		Client: client,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *ComplianceReportCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- complianceControlsDesc
+	descs <- complianceControlStatusDesc
+	descs <- complianceSummaryDesc
+	descs <- complianceScoreDesc
+	descs <- complianceGenerationsDesc
+	descs <- complianceLastChangedDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *ComplianceReportCollector) Collect(metrics chan<- prometheus.Metric) {
+	var reports v1alpha1.ClusterComplianceReportList
+	err := c.Client.List(context.Background(), &reports)
+	if err != nil {
+		c.Logger.Error(err, "Unable to collect cluster compliance report metrics")
+		return
+	}
+	for _, report := range reports.Items {
+		for severity, count := range report.Status.Summary.SummaryBySeverity {
+			metrics <- prometheus.MustNewConstMetric(complianceControlsDesc, prometheus.GaugeValue,
+				float64(count.Pass), report.Name, severity, "pass")
+			metrics <- prometheus.MustNewConstMetric(complianceControlsDesc, prometheus.GaugeValue,
+				float64(count.Fail), report.Name, severity, "fail")
+		}
+		if report.Status.UpdateTimestamp.IsZero() {
+			continue
+		}
+		version := report.Spec.Version
+		for _, control := range report.Status.ControlChecks {
+			metrics <- prometheus.MustNewConstMetric(complianceControlStatusDesc, prometheus.GaugeValue,
+				1, report.Name, version, control.ID, string(control.Severity), compliance.ControlState(control))
+		}
+		summary := report.Status.Summary
+		metrics <- prometheus.MustNewConstMetric(complianceSummaryDesc, prometheus.GaugeValue,
+			float64(summary.PassCount), report.Name, version, "pass")
+		metrics <- prometheus.MustNewConstMetric(complianceSummaryDesc, prometheus.GaugeValue,
+			float64(summary.FailCount), report.Name, version, "fail")
+		metrics <- prometheus.MustNewConstMetric(complianceSummaryDesc, prometheus.GaugeValue,
+			float64(summary.WarnCount), report.Name, version, "warn")
+		if summary.Score != nil {
+			metrics <- prometheus.MustNewConstMetric(complianceScoreDesc, prometheus.GaugeValue,
+				float64(*summary.Score), report.Name, version)
+		}
+		if report.Status.GenerationCount > 0 {
+			metrics <- prometheus.MustNewConstMetric(complianceGenerationsDesc, prometheus.GaugeValue,
+				float64(report.Status.GenerationCount), report.Name, version)
+		}
+		if report.Status.LastChangedTimestamp != nil {
+			metrics <- prometheus.MustNewConstMetric(complianceLastChangedDesc, prometheus.GaugeValue,
+				float64(report.Status.LastChangedTimestamp.Unix()), report.Name, version)
+		}
+	}
+}
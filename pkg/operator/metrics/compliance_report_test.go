@@ -0,0 +1,74 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestComplianceReportCollector(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.ClusterComplianceReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "nsa"},
+			Spec:       v1alpha1.ReportSpec{Version: "1.0"},
+			Status: v1alpha1.ReportStatus{
+				UpdateTimestamp:      metav1.NewTime(time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)),
+				GenerationCount:      4,
+				LastChangedTimestamp: &metav1.Time{Time: time.Date(2022, 4, 30, 10, 0, 0, 0, time.UTC)},
+				Summary: v1alpha1.ClusterComplianceSummary{PassCount: 15, FailCount: 5, WarnCount: 2, Score: pointer.Int(83),
+					SummaryBySeverity: map[string]v1alpha1.ControlCount{
+						"CRITICAL": {Pass: 2, Fail: 1},
+						"HIGH":     {Pass: 3},
+					}},
+				ControlChecks: []v1alpha1.ControlCheck{
+					{ID: "1.0", Severity: "CRITICAL", PassTotal: 3, FailTotal: 2},
+					{ID: "1.1", Severity: "HIGH", PassTotal: 4},
+					{ID: "2.0", Severity: "HIGH", Status: v1alpha1.WaivedStatus},
+				},
+			},
+		},
+		&v1alpha1.ClusterComplianceReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "pss"},
+		},
+	).Build()
+	collector := metrics.NewComplianceReportCollector(logr.Discard(), c)
+
+	expected := `
+# HELP starboard_cluster_compliance_controls Number of passing and failing controls of a cluster compliance report by control severity.
+# TYPE starboard_cluster_compliance_controls gauge
+starboard_cluster_compliance_controls{name="nsa",severity="CRITICAL",status="fail"} 1
+starboard_cluster_compliance_controls{name="nsa",severity="CRITICAL",status="pass"} 2
+starboard_cluster_compliance_controls{name="nsa",severity="HIGH",status="fail"} 0
+starboard_cluster_compliance_controls{name="nsa",severity="HIGH",status="pass"} 3
+# HELP starboard_compliance_control_status Status of a control of a cluster compliance report, which is always 1.
+# TYPE starboard_compliance_control_status gauge
+starboard_compliance_control_status{control_id="1.0",report="nsa",severity="CRITICAL",status="FAIL",version="1.0"} 1
+starboard_compliance_control_status{control_id="1.1",report="nsa",severity="HIGH",status="PASS",version="1.0"} 1
+starboard_compliance_control_status{control_id="2.0",report="nsa",severity="HIGH",status="WAIVED",version="1.0"} 1
+# HELP starboard_compliance_report_generations Number of generations of a cluster compliance report.
+# TYPE starboard_compliance_report_generations gauge
+starboard_compliance_report_generations{report="nsa",version="1.0"} 4
+# HELP starboard_compliance_report_last_changed_timestamp_seconds Time when results of a cluster compliance report last changed, in seconds since the epoch.
+# TYPE starboard_compliance_report_last_changed_timestamp_seconds gauge
+starboard_compliance_report_last_changed_timestamp_seconds{report="nsa",version="1.0"} 1.6513128e+09
+# HELP starboard_compliance_score Percentage of passing controls of a cluster compliance report.
+# TYPE starboard_compliance_score gauge
+starboard_compliance_score{report="nsa",version="1.0"} 83
+# HELP starboard_compliance_summary Number of passing, failing and warning checks of a cluster compliance report.
+# TYPE starboard_compliance_summary gauge
+starboard_compliance_summary{report="nsa",status="fail",version="1.0"} 5
+starboard_compliance_summary{report="nsa",status="pass",version="1.0"} 15
+starboard_compliance_summary{report="nsa",status="warn",version="1.0"} 2
+`
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kubebench"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var kubeBenchSkippedNodesDesc = prometheus.NewDesc(
+	"starboard_kube_bench_skipped_nodes",
+	"Number of nodes which are not eligible for CIS Kubernetes Benchmark checks by reason.",
+	[]string{"reason"}, nil,
+)
+
+// KubeBenchReportCollector is a prometheus.Collector which exports the number
+// of nodes skipped by CIS Kubernetes Benchmark checks grouped by the reason,
+// e.g. Fargate or VirtualKubelet.
+//
+// Metrics are computed from v1alpha1.CISKubeBenchReport stubs written for
+// skipped nodes at scrape time, so that nodes which were deleted or became
+// eligible are not counted.
+type KubeBenchReportCollector struct {
+	logr.Logger
+	client.Client
+}
+
+// NewKubeBenchReportCollector constructs a new KubeBenchReportCollector.
+func NewKubeBenchReportCollector(logger logr.Logger, client client.Client) *KubeBenchReportCollector {
+	return &KubeBenchReportCollector{
+		Logger: logger,
+		Client: client,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *KubeBenchReportCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- kubeBenchSkippedNodesDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *KubeBenchReportCollector) Collect(metrics chan<- prometheus.Metric) {
+	var reports v1alpha1.CISKubeBenchReportList
+	err := c.Client.List(context.Background(), &reports)
+	if err != nil {
+		c.Logger.Error(err, "Unable to collect CIS Kubernetes Benchmark report metrics")
+		return
+	}
+	skipped := make(map[string]int)
+	for _, report := range reports.Items {
+		if reason, ok := kubebench.SkipReason(report); ok {
+			skipped[reason]++
+		}
+	}
+	for reason, count := range skipped {
+		metrics <- prometheus.MustNewConstMetric(kubeBenchSkippedNodesDesc, prometheus.GaugeValue,
+			float64(count), reason)
+	}
+}
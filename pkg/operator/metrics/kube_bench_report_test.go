@@ -0,0 +1,43 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kubebench"
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestKubeBenchReportCollector(t *testing.T) {
+	skipped := func(name, reason string) *v1alpha1.CISKubeBenchReport {
+		return &v1alpha1.CISKubeBenchReport{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Report:     v1alpha1.CISKubeBenchReportData{Status: kubebench.SkippedStatus(reason)},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		skipped("fargate-ip-10-0-1-1", kubebench.SkipReasonFargate),
+		skipped("fargate-ip-10-0-1-2", kubebench.SkipReasonFargate),
+		skipped("virtual-node-aci-linux", kubebench.SkipReasonVirtualKubelet),
+		&v1alpha1.CISKubeBenchReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "kind-control-plane"},
+			Report:     v1alpha1.CISKubeBenchReportData{Summary: v1alpha1.CISKubeBenchSummary{PassCount: 10}},
+		},
+	).Build()
+	collector := metrics.NewKubeBenchReportCollector(logr.Discard(), c)
+
+	expected := `
+# HELP starboard_kube_bench_skipped_nodes Number of nodes which are not eligible for CIS Kubernetes Benchmark checks by reason.
+# TYPE starboard_kube_bench_skipped_nodes gauge
+starboard_kube_bench_skipped_nodes{reason="Fargate"} 2
+starboard_kube_bench_skipped_nodes{reason="VirtualKubelet"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+}
@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// NamespacedPath is the path prefix of metrics of a single namespace, which
+// is followed by the name of the namespace.
+const NamespacedPath = "/metrics/namespaces/"
+
+// NamespaceGatherer returns a prometheus.Gatherer which gathers only metrics
+// of the given gatherer whose namespace label equals the given namespace.
+// Metrics without the namespace label, e.g. metrics of cluster compliance
+// reports or of the operator itself, are omitted.
+func NamespaceGatherer(gatherer prometheus.Gatherer, namespace string) prometheus.Gatherer {
+	return filterGatherer(gatherer, func(metric *dto.Metric) bool {
+		value, ok := labelValue(metric, "namespace")
+		return ok && value == namespace
+	})
+}
+
+// NewAllowedNamespacesRegistry returns a registry which registers collectors
+// with the given registry, and gathers its metrics except the ones whose
+// namespace label is not one of allowedNamespaces. Metrics without the
+// namespace label are kept. All metrics are gathered if allowedNamespaces is
+// empty.
+func NewAllowedNamespacesRegistry(registry ctrlmetrics.RegistererGatherer, allowedNamespaces []string) ctrlmetrics.RegistererGatherer {
+	if len(allowedNamespaces) == 0 {
+		return registry
+	}
+	allowed := make(map[string]bool)
+	for _, namespace := range allowedNamespaces {
+		allowed[namespace] = true
+	}
+	return struct {
+		prometheus.Registerer
+		prometheus.Gatherer
+	}{
+		Registerer: registry,
+		Gatherer: filterGatherer(registry, func(metric *dto.Metric) bool {
+			value, ok := labelValue(metric, "namespace")
+			return !ok || allowed[value]
+		}),
+	}
+}
+
+// filterGatherer returns a prometheus.Gatherer which gathers metrics of the
+// given gatherer which are kept by the given function. Families without any
+// kept metric are omitted.
+func filterGatherer(gatherer prometheus.Gatherer, keep func(metric *dto.Metric) bool) prometheus.Gatherer {
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		families, err := gatherer.Gather()
+		var filtered []*dto.MetricFamily
+		for _, family := range families {
+			var metrics []*dto.Metric
+			for _, metric := range family.GetMetric() {
+				if keep(metric) {
+					metrics = append(metrics, metric)
+				}
+			}
+			if len(metrics) == 0 {
+				continue
+			}
+			family.Metric = metrics
+			filtered = append(filtered, family)
+		}
+		return filtered, err
+	})
+}
+
+func labelValue(metric *dto.Metric, name string) (string, bool) {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue(), true
+		}
+	}
+	return "", false
+}
+
+// NewNamespacedHandler returns a http.Handler which serves metrics of the
+// given gatherer for the namespace named in the path after NamespacedPath,
+// so that access to metrics of each namespace can be authorized separately,
+// e.g. by a proxy in front of the metrics endpoint. If allowedNamespaces is
+// not empty, metrics of other namespaces are not found.
+func NewNamespacedHandler(gatherer prometheus.Gatherer, allowedNamespaces []string) http.Handler {
+	allowed := make(map[string]bool)
+	for _, namespace := range allowedNamespaces {
+		allowed[namespace] = true
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		namespace := strings.TrimPrefix(r.URL.Path, NamespacedPath)
+		if namespace == "" || strings.Contains(namespace, "/") || len(allowed) > 0 && !allowed[namespace] {
+			http.NotFound(w, r)
+			return
+		}
+		promhttp.HandlerFor(NamespaceGatherer(gatherer, namespace), promhttp.HandlerOpts{}).ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,125 @@
+package metrics_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newNamespacedRegistry(t *testing.T) *prometheus.Registry {
+	t.Helper()
+	registry := prometheus.NewRegistry()
+	ages := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "starboard_workload_report_age_seconds",
+		Help: "Time since the most recent report of a workload was updated.",
+	}, []string{"namespace", "name"})
+	ages.WithLabelValues("team-a", "app").Set(60)
+	ages.WithLabelValues("team-b", "db").Set(120)
+	score := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "starboard_compliance_score",
+		Help: "Percentage of passing controls of a cluster compliance report.",
+	}, []string{"report"})
+	score.WithLabelValues("nsa").Set(80)
+	registry.MustRegister(ages, score)
+	return registry
+}
+
+func TestNamespaceGatherer(t *testing.T) {
+	gatherer := metrics.NamespaceGatherer(newNamespacedRegistry(t), "team-a")
+
+	expected := `
+# HELP starboard_workload_report_age_seconds Time since the most recent report of a workload was updated.
+# TYPE starboard_workload_report_age_seconds gauge
+starboard_workload_report_age_seconds{name="app",namespace="team-a"} 60
+`
+	assert.NoError(t, testutil.GatherAndCompare(gatherer, strings.NewReader(expected)))
+
+	t.Run("Should omit metrics of other namespaces and cluster-scoped metrics", func(t *testing.T) {
+		count, err := testutil.GatherAndCount(gatherer)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
+
+func TestNewNamespacedHandler(t *testing.T) {
+	handler := metrics.NewNamespacedHandler(newNamespacedRegistry(t), []string{"team-a", "team-c"})
+
+	get := func(path string) (int, string) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		body, err := ioutil.ReadAll(recorder.Body)
+		require.NoError(t, err)
+		return recorder.Code, string(body)
+	}
+
+	t.Run("Should serve metrics of allowed namespace", func(t *testing.T) {
+		code, body := get("/metrics/namespaces/team-a")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, `starboard_workload_report_age_seconds{name="app",namespace="team-a"} 60`)
+		assert.NotContains(t, body, "team-b")
+		assert.NotContains(t, body, "starboard_compliance_score")
+	})
+
+	t.Run("Should serve no metrics of allowed namespace without metrics", func(t *testing.T) {
+		code, body := get("/metrics/namespaces/team-c")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Empty(t, body)
+	})
+
+	t.Run("Should not find metrics of namespace which is not allowed", func(t *testing.T) {
+		code, _ := get("/metrics/namespaces/team-b")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("Should not find metrics without namespace", func(t *testing.T) {
+		code, _ := get("/metrics/namespaces/")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
+
+func TestNewAllowedNamespacesRegistry(t *testing.T) {
+	t.Run("Should omit metrics of namespaces which are not allowed", func(t *testing.T) {
+		registry := metrics.NewAllowedNamespacesRegistry(newNamespacedRegistry(t), []string{"team-a"})
+
+		expected := `
+# HELP starboard_compliance_score Percentage of passing controls of a cluster compliance report.
+# TYPE starboard_compliance_score gauge
+starboard_compliance_score{report="nsa"} 80
+# HELP starboard_workload_report_age_seconds Time since the most recent report of a workload was updated.
+# TYPE starboard_workload_report_age_seconds gauge
+starboard_workload_report_age_seconds{name="app",namespace="team-a"} 60
+`
+		assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected)))
+	})
+
+	t.Run("Should gather metrics of collectors registered with it", func(t *testing.T) {
+		registry := metrics.NewAllowedNamespacesRegistry(prometheus.NewRegistry(), []string{"team-a"})
+		findings := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "starboard_findings",
+			Help: "Number of findings.",
+		}, []string{"namespace"})
+		findings.WithLabelValues("team-a").Set(1)
+		findings.WithLabelValues("team-b").Set(2)
+		require.NoError(t, registry.Register(findings))
+
+		count, err := testutil.GatherAndCount(registry, "starboard_findings")
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("Should gather metrics of all namespaces if none is allowed explicitly", func(t *testing.T) {
+		registry := metrics.NewAllowedNamespacesRegistry(newNamespacedRegistry(t), nil)
+
+		count, err := testutil.GatherAndCount(registry)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+}
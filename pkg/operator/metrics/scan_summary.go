@@ -0,0 +1,383 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	reportsummary "github.com/aquasecurity/starboard/pkg/summary"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ScanSummaryConfigMapName is the name of the ConfigMap in the operator
+	// namespace which holds the scan summary.
+	ScanSummaryConfigMapName = "starboard-scan-summary"
+	// ScanSummaryKey is the key of the ConfigMap data which holds the scan
+	// summary encoded as YAML.
+	ScanSummaryKey = "summary.yaml"
+
+	// maxRecentScanFailures is the number of the most recent scan failures
+	// kept in memory and listed in the scan summary.
+	maxRecentScanFailures = 5
+	// maxScanFailureMessageLength bounds the length of termination messages
+	// of scan job containers quoted in scan failure reasons.
+	maxScanFailureMessageLength = 256
+)
+
+// ScanSummary summarizes scan statistics of the cluster for users who don't
+// run a metrics stack nor a dashboard.
+type ScanSummary struct {
+	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
+	// Total sums up statistics of all namespaces, including truncated ones.
+	Total NamespaceScanSummary `json:"total"`
+	// Namespaces lists statistics of each namespace sorted by name.
+	Namespaces []NamespaceScanSummary `json:"namespaces,omitempty"`
+	// TruncatedNamespaces is the number of namespaces omitted from Namespaces
+	// because of etc.Config ScanSummaryMaxNamespaces.
+	TruncatedNamespaces int `json:"truncatedNamespaces,omitempty"`
+	// Compliance lists the last score of each ClusterComplianceReport.
+	Compliance []ComplianceScore `json:"compliance,omitempty"`
+	// RecentScanFailures lists the most recent failures of scan jobs, the
+	// most recent first.
+	RecentScanFailures []ScanFailure `json:"recentScanFailures,omitempty"`
+}
+
+// NamespaceScanSummary holds scan statistics of a namespace.
+type NamespaceScanSummary struct {
+	Namespace string `json:"namespace,omitempty"`
+	// ScannedWorkloads is the number of watched workloads which have reports
+	// of all enabled scanners.
+	ScannedWorkloads int `json:"scannedWorkloads"`
+	// UnscannedWorkloads is the number of watched workloads which miss a
+	// report of any of the enabled scanners.
+	UnscannedWorkloads int `json:"unscannedWorkloads"`
+	// Vulnerabilities counts vulnerabilities of VulnerabilityReports.
+	Vulnerabilities SeverityCounts `json:"vulnerabilities"`
+	// FailedConfigAuditChecks counts failed checks of ConfigAuditReports.
+	FailedConfigAuditChecks SeverityCounts `json:"failedConfigAuditChecks"`
+	// KnownExploitedVulnerabilities counts known exploited vulnerabilities
+	// of VulnerabilityReports whose vulnerabilities are prioritized.
+	KnownExploitedVulnerabilities int `json:"knownExploitedVulnerabilities,omitempty"`
+	// PrioritizedVulnerabilities counts vulnerabilities of
+	// VulnerabilityReports whose vulnerabilities are prioritized by priority.
+	PrioritizedVulnerabilities map[v1alpha1.Priority]int `json:"prioritizedVulnerabilities,omitempty"`
+}
+
+// SeverityCounts counts findings by severity.
+type SeverityCounts struct {
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+	Unknown  int `json:"unknown,omitempty"`
+}
+
+func (c *SeverityCounts) add(other SeverityCounts) {
+	c.Critical += other.Critical
+	c.High += other.High
+	c.Medium += other.Medium
+	c.Low += other.Low
+	c.Unknown += other.Unknown
+}
+
+func (s *NamespaceScanSummary) add(other NamespaceScanSummary) {
+	s.ScannedWorkloads += other.ScannedWorkloads
+	s.UnscannedWorkloads += other.UnscannedWorkloads
+	s.Vulnerabilities.add(other.Vulnerabilities)
+	s.FailedConfigAuditChecks.add(other.FailedConfigAuditChecks)
+	s.addPrioritized(other.KnownExploitedVulnerabilities, other.PrioritizedVulnerabilities)
+}
+
+func (s *NamespaceScanSummary) addPrioritized(knownExploited int, priorities map[v1alpha1.Priority]int) {
+	s.KnownExploitedVulnerabilities += knownExploited
+	for priority, count := range priorities {
+		if s.PrioritizedVulnerabilities == nil {
+			s.PrioritizedVulnerabilities = make(map[v1alpha1.Priority]int)
+		}
+		s.PrioritizedVulnerabilities[priority] += count
+	}
+}
+
+// ComplianceScore is the last score of a ClusterComplianceReport.
+type ComplianceScore struct {
+	Name            string      `json:"name"`
+	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
+	PassCount       int         `json:"passCount"`
+	FailCount       int         `json:"failCount"`
+	// Score is the percentage of passed controls.
+	Score int `json:"score"`
+}
+
+// ScanFailure describes a failed scan job.
+type ScanFailure struct {
+	Timestamp metav1.Time `json:"timestamp"`
+	// Scanner is the kind of reports generated by the scan job, e.g.
+	// VulnerabilityReport.
+	Scanner   string `json:"scanner"`
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+// ScanFailureRecorder records failures of scan jobs. Failed scan jobs are
+// deleted right away, therefore failures are recorded by controllers which
+// process them.
+type ScanFailureRecorder interface {
+	RecordScanFailure(scanner string, owner kube.ObjectRef, reason string)
+}
+
+// ScanFailureReason describes the failure of the given terminated scan job
+// container. The termination message is truncated to keep the scan summary
+// bounded.
+func ScanFailureReason(container string, status *corev1.ContainerStateTerminated) string {
+	reason := fmt.Sprintf("container %s exited with code %d", container, status.ExitCode)
+	if status.Reason != "" {
+		reason += ": " + status.Reason
+	}
+	if message := status.Message; message != "" {
+		if len(message) > maxScanFailureMessageLength {
+			message = message[:maxScanFailureMessageLength] + "..."
+		}
+		reason += ": " + message
+	}
+	return reason
+}
+
+// RecentScanFailures is a ScanFailureRecorder which keeps the most recent
+// scan failures in memory. It is safe for concurrent use.
+type RecentScanFailures struct {
+	clock    ext.Clock
+	mu       sync.Mutex
+	failures []ScanFailure
+}
+
+// NewRecentScanFailures constructs a new RecentScanFailures.
+func NewRecentScanFailures(clock ext.Clock) *RecentScanFailures {
+	return &RecentScanFailures{clock: clock}
+}
+
+// RecordScanFailure implements ScanFailureRecorder.
+func (r *RecentScanFailures) RecordScanFailure(scanner string, owner kube.ObjectRef, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures = append(r.failures, ScanFailure{
+		Timestamp: metav1.NewTime(r.clock.Now()),
+		Scanner:   scanner,
+		Kind:      string(owner.Kind),
+		Namespace: owner.Namespace,
+		Name:      owner.Name,
+		Reason:    reason,
+	})
+	if len(r.failures) > maxRecentScanFailures {
+		r.failures = r.failures[len(r.failures)-maxRecentScanFailures:]
+	}
+}
+
+// List returns the recorded scan failures, the most recent first.
+func (r *RecentScanFailures) List() []ScanFailure {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var failures []ScanFailure
+	for i := len(r.failures) - 1; i >= 0; i-- {
+		failures = append(failures, r.failures[i])
+	}
+	return failures
+}
+
+// ScanSummaryWriter periodically writes the ScanSummary computed from objects
+// in the cache to the ScanSummaryConfigMapName ConfigMap in the operator
+// namespace.
+type ScanSummaryWriter struct {
+	logr.Logger
+	client.Client
+	etc.Config
+	Clock    ext.Clock
+	Failures *RecentScanFailures
+}
+
+// Start refreshes the scan summary every etc.Config ScanSummaryInterval until
+// the given context is done. It implements manager.Runnable.
+func (w *ScanSummaryWriter) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		// Do not stop the manager if the refresh fails.
+		if err := w.Refresh(ctx); err != nil {
+			w.Logger.Error(err, "Unable to refresh scan summary")
+		}
+	}, w.Config.ScanSummaryInterval)
+	return nil
+}
+
+// Refresh writes the current ScanSummary to the ConfigMap.
+func (w *ScanSummaryWriter) Refresh(ctx context.Context) error {
+	namespace, err := w.Config.GetOperatorNamespace()
+	if err != nil {
+		return err
+	}
+	summary, err := w.Summarize(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(summary)
+	if err != nil {
+		return err
+	}
+
+	var cm corev1.ConfigMap
+	err = w.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ScanSummaryConfigMapName}, &cm)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		cm = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ScanSummaryConfigMapName,
+				Namespace: namespace,
+				Labels: map[string]string{
+					starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+				},
+			},
+			Data: map[string]string{
+				ScanSummaryKey: string(data),
+			},
+		}
+		return w.Client.Create(ctx, &cm)
+	}
+	cm.Data = map[string]string{
+		ScanSummaryKey: string(data),
+	}
+	return w.Client.Update(ctx, &cm)
+}
+
+// Summarize computes the ScanSummary from objects in the cache.
+func (w *ScanSummaryWriter) Summarize(ctx context.Context) (ScanSummary, error) {
+	namespaces := make(map[string]*NamespaceScanSummary)
+	namespaceSummary := func(namespace string) *NamespaceScanSummary {
+		s, ok := namespaces[namespace]
+		if !ok {
+			s = &NamespaceScanSummary{Namespace: namespace}
+			namespaces[namespace] = s
+		}
+		return s
+	}
+
+	updated, err := reportsUpdated(ctx, w.Client)
+	if err != nil {
+		return ScanSummary{}, fmt.Errorf("listing reports: %w", err)
+	}
+	workloads, err := watchedWorkloads(ctx, w.Client, w.Config)
+	if err != nil {
+		return ScanSummary{}, fmt.Errorf("listing workloads: %w", err)
+	}
+	for _, workload := range workloads {
+		scanned := true
+		for _, reportType := range reportTypes(w.Config) {
+			key := workloadKey{
+				namespace:  workload.Namespace,
+				kind:       string(workload.Kind),
+				name:       workload.Name,
+				reportType: reportType,
+			}
+			if _, ok := updated[key]; !ok {
+				scanned = false
+				break
+			}
+		}
+		if scanned {
+			namespaceSummary(workload.Namespace).ScannedWorkloads++
+		} else {
+			namespaceSummary(workload.Namespace).UnscannedWorkloads++
+		}
+	}
+
+	// Reports are read from the cache, which does not support paginated lists.
+	vulnerabilities, err := reportsummary.SummarizeVulnerabilitiesByNamespace(ctx, w.Client, reportsummary.WithPageSize(0))
+	if err != nil {
+		return ScanSummary{}, fmt.Errorf("summarizing vulnerability reports: %w", err)
+	}
+	for namespace, summary := range vulnerabilities {
+		namespaceSummary(namespace).Vulnerabilities.add(SeverityCounts{
+			Critical: summary.CriticalCount,
+			High:     summary.HighCount,
+			Medium:   summary.MediumCount,
+			Low:      summary.LowCount,
+			Unknown:  summary.UnknownCount,
+		})
+		namespaceSummary(namespace).addPrioritized(summary.KnownExploitedCount, summary.PriorityCounts)
+	}
+
+	configAudits, err := reportsummary.SummarizeConfigAuditsByNamespace(ctx, w.Client, reportsummary.WithPageSize(0))
+	if err != nil {
+		return ScanSummary{}, fmt.Errorf("summarizing config audit reports: %w", err)
+	}
+	for namespace, summary := range configAudits {
+		namespaceSummary(namespace).FailedConfigAuditChecks.add(SeverityCounts{
+			Critical: summary.CriticalCount,
+			High:     summary.HighCount,
+			Medium:   summary.MediumCount,
+			Low:      summary.LowCount,
+		})
+	}
+
+	summary := ScanSummary{
+		UpdateTimestamp: metav1.NewTime(w.Clock.Now()),
+	}
+	var names []string
+	for name := range namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		summary.Total.add(*namespaces[name])
+		if len(summary.Namespaces) < w.Config.ScanSummaryMaxNamespaces {
+			summary.Namespaces = append(summary.Namespaces, *namespaces[name])
+		} else {
+			summary.TruncatedNamespaces++
+		}
+	}
+
+	if w.Config.ClusterComplianceEnabled {
+		var complianceReports v1alpha1.ClusterComplianceReportList
+		err = w.Client.List(ctx, &complianceReports)
+		if err != nil {
+			return ScanSummary{}, fmt.Errorf("listing cluster compliance reports: %w", err)
+		}
+		for _, report := range complianceReports.Items {
+			summary.Compliance = append(summary.Compliance, complianceScore(report))
+		}
+		sort.Slice(summary.Compliance, func(i, j int) bool {
+			return summary.Compliance[i].Name < summary.Compliance[j].Name
+		})
+	}
+
+	if w.Failures != nil {
+		summary.RecentScanFailures = w.Failures.List()
+	}
+	return summary, nil
+}
+
+func complianceScore(report v1alpha1.ClusterComplianceReport) ComplianceScore {
+	score := ComplianceScore{
+		Name:            report.Name,
+		UpdateTimestamp: report.Status.UpdateTimestamp,
+		PassCount:       report.Status.Summary.PassCount,
+		FailCount:       report.Status.Summary.FailCount,
+	}
+	if total := score.PassCount + score.FailCount; total > 0 {
+		score.Score = score.PassCount * 100 / total
+	}
+	return score
+}
@@ -0,0 +1,196 @@
+package metrics_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+func TestRecentScanFailures(t *testing.T) {
+	failures := metrics.NewRecentScanFailures(ext.NewFixedClock(now))
+	for i := 1; i <= 7; i++ {
+		failures.RecordScanFailure(v1alpha1.VulnerabilityReportKind,
+			kube.ObjectRef{Kind: kube.KindPod, Name: fmt.Sprintf("pod-%d", i), Namespace: "default"}, "OOMKilled")
+	}
+
+	var names []string
+	for _, failure := range failures.List() {
+		names = append(names, failure.Name)
+	}
+	assert.Equal(t, []string{"pod-7", "pod-6", "pod-5", "pod-4", "pod-3"}, names)
+}
+
+func TestScanFailureReason(t *testing.T) {
+	t.Run("Should describe container status", func(t *testing.T) {
+		reason := metrics.ScanFailureReason("nginx", &corev1.ContainerStateTerminated{
+			ExitCode: 1,
+			Reason:   "Error",
+			Message:  "unable to pull image",
+		})
+		assert.Equal(t, "container nginx exited with code 1: Error: unable to pull image", reason)
+	})
+
+	t.Run("Should truncate long messages", func(t *testing.T) {
+		reason := metrics.ScanFailureReason("nginx", &corev1.ContainerStateTerminated{
+			ExitCode: 137,
+			Message:  strings.Repeat("x", 1000),
+		})
+		assert.Equal(t, "container nginx exited with code 137: "+strings.Repeat("x", 256)+"...", reason)
+	})
+}
+
+func TestScanSummaryWriter(t *testing.T) {
+	nginx := workloadLabels("default", "ReplicaSet", "nginx-6d4cf56db6")
+	redis := workloadLabels("prod", "StatefulSet", "redis")
+
+	nginxVulnerabilities := newVulnerabilityReport("replicaset-nginx-6d4cf56db6-nginx", nginx, time.Hour)
+	nginxVulnerabilities.Report.Summary = v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 2, MediumCount: 3,
+		KnownExploitedCount: 1, PriorityCounts: map[v1alpha1.Priority]int{v1alpha1.PriorityP1: 1, v1alpha1.PriorityP3: 2, v1alpha1.PriorityP4: 3}}
+	nginxConfigAudit := newConfigAuditReport("replicaset-nginx-6d4cf56db6", nginx, time.Hour)
+	nginxConfigAudit.Report.Summary = v1alpha1.ConfigAuditSummary{HighCount: 1, LowCount: 4}
+	redisVulnerabilities := newVulnerabilityReport("statefulset-redis-redis", redis, time.Hour)
+	redisVulnerabilities.Report.Summary = v1alpha1.VulnerabilitySummary{HighCount: 5, UnknownCount: 1}
+
+	objects := []client.Object{
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "nginx-6d4cf56db6", Namespace: "default"},
+			Spec: appsv1.ReplicaSetSpec{Replicas: pointer.Int32Ptr(1)}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "default"}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "redis", Namespace: "prod"}},
+		nginxVulnerabilities,
+		nginxConfigAudit,
+		redisVulnerabilities,
+		&v1alpha1.ClusterComplianceReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "nsa"},
+			Status: v1alpha1.ReportStatus{
+				UpdateTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+				Summary:         v1alpha1.ClusterComplianceSummary{PassCount: 15, FailCount: 5},
+			},
+		},
+	}
+	config := etc.Config{
+		Namespace:                   "starboard-system",
+		VulnerabilityScannerEnabled: true,
+		ConfigAuditScannerBuiltIn:   true,
+		ClusterComplianceEnabled:    true,
+		ScanSummaryInterval:         5 * time.Minute,
+		ScanSummaryMaxNamespaces:    100,
+	}
+	newWriter := func(config etc.Config, objects ...client.Object) *metrics.ScanSummaryWriter {
+		failures := metrics.NewRecentScanFailures(ext.NewFixedClock(now.Add(-time.Minute)))
+		failures.RecordScanFailure(v1alpha1.VulnerabilityReportKind,
+			kube.ObjectRef{Kind: kube.KindStatefulSet, Name: "redis", Namespace: "prod"}, "container redis exited with code 1")
+		return &metrics.ScanSummaryWriter{
+			Logger:   logr.Discard(),
+			Client:   fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build(),
+			Config:   config,
+			Clock:    ext.NewFixedClock(now),
+			Failures: failures,
+		}
+	}
+
+	t.Run("Should summarize scans per namespace", func(t *testing.T) {
+		writer := newWriter(config, objects...)
+
+		summary, err := writer.Summarize(context.TODO())
+		require.NoError(t, err)
+		assert.Equal(t, metrics.ScanSummary{
+			UpdateTimestamp: metav1.NewTime(now),
+			Total: metrics.NamespaceScanSummary{
+				ScannedWorkloads:              1,
+				UnscannedWorkloads:            2,
+				Vulnerabilities:               metrics.SeverityCounts{Critical: 1, High: 7, Medium: 3, Unknown: 1},
+				FailedConfigAuditChecks:       metrics.SeverityCounts{High: 1, Low: 4},
+				KnownExploitedVulnerabilities: 1,
+				PrioritizedVulnerabilities:    map[v1alpha1.Priority]int{v1alpha1.PriorityP1: 1, v1alpha1.PriorityP3: 2, v1alpha1.PriorityP4: 3},
+			},
+			Namespaces: []metrics.NamespaceScanSummary{
+				{
+					Namespace:                     "default",
+					ScannedWorkloads:              1,
+					UnscannedWorkloads:            1,
+					Vulnerabilities:               metrics.SeverityCounts{Critical: 1, High: 2, Medium: 3},
+					FailedConfigAuditChecks:       metrics.SeverityCounts{High: 1, Low: 4},
+					KnownExploitedVulnerabilities: 1,
+					PrioritizedVulnerabilities:    map[v1alpha1.Priority]int{v1alpha1.PriorityP1: 1, v1alpha1.PriorityP3: 2, v1alpha1.PriorityP4: 3},
+				},
+				{
+					Namespace:          "prod",
+					UnscannedWorkloads: 1,
+					Vulnerabilities:    metrics.SeverityCounts{High: 5, Unknown: 1},
+				},
+			},
+			Compliance: []metrics.ComplianceScore{
+				{
+					Name: "nsa",
+					// the fake client decodes timestamps in the local time zone
+					UpdateTimestamp: metav1.NewTime(now.Add(-time.Hour).Local()),
+					PassCount:       15,
+					FailCount:       5,
+					Score:           75,
+				},
+			},
+			RecentScanFailures: []metrics.ScanFailure{
+				{
+					Timestamp: metav1.NewTime(now.Add(-time.Minute)),
+					Scanner:   "VulnerabilityReport",
+					Kind:      "StatefulSet",
+					Namespace: "prod",
+					Name:      "redis",
+					Reason:    "container redis exited with code 1",
+				},
+			},
+		}, summary)
+	})
+
+	t.Run("Should truncate namespaces beyond the limit", func(t *testing.T) {
+		limited := config
+		limited.ScanSummaryMaxNamespaces = 1
+		writer := newWriter(limited, objects...)
+
+		summary, err := writer.Summarize(context.TODO())
+		require.NoError(t, err)
+		require.Len(t, summary.Namespaces, 1)
+		assert.Equal(t, "default", summary.Namespaces[0].Namespace)
+		assert.Equal(t, 1, summary.TruncatedNamespaces)
+		assert.Equal(t, 2, summary.Total.UnscannedWorkloads)
+	})
+
+	t.Run("Should create and update the ConfigMap", func(t *testing.T) {
+		writer := newWriter(config, objects...)
+
+		require.NoError(t, writer.Refresh(context.TODO()))
+		require.NoError(t, writer.Refresh(context.TODO()))
+
+		var cm corev1.ConfigMap
+		err := writer.Client.Get(context.TODO(), client.ObjectKey{
+			Namespace: "starboard-system",
+			Name:      metrics.ScanSummaryConfigMapName,
+		}, &cm)
+		require.NoError(t, err)
+		assert.Equal(t, "starboard", cm.Labels["app.kubernetes.io/managed-by"])
+
+		var summary metrics.ScanSummary
+		require.NoError(t, yaml.Unmarshal([]byte(cm.Data[metrics.ScanSummaryKey]), &summary))
+		assert.Equal(t, 1, summary.Total.ScannedWorkloads)
+		assert.Len(t, summary.Namespaces, 2)
+	})
+}
@@ -0,0 +1,417 @@
+// Package metrics provides Prometheus collectors of the operator, which are
+// computed from objects in the cache at scrape time, and the scan summary
+// ConfigMap for users without a metrics stack.
+package metrics
+
+import (
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	. "github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// ReportType is the value of the report_type label of workload report metrics.
+type ReportType string
+
+const (
+	ReportTypeVulnerability ReportType = "vulnerability"
+	ReportTypeConfigAudit   ReportType = "configaudit"
+)
+
+var (
+	workloadReportLabels = []string{"namespace", "kind", "name", "report_type"}
+
+	workloadReportAgeDesc = prometheus.NewDesc(
+		"starboard_workload_report_age_seconds",
+		"Time since the most recent report of a workload was updated.",
+		workloadReportLabels, nil,
+	)
+	workloadReportMissingDesc = prometheus.NewDesc(
+		"starboard_workload_report_missing",
+		"Set to 1 for a workload watched by the operator which has no report.",
+		workloadReportLabels, nil,
+	)
+	workloadVulnerabilityReportSkippedDesc = prometheus.NewDesc(
+		"starboard_workload_vulnerability_report_skipped",
+		"Set to 1 for a container of a workload whose image was deliberately not scanned for vulnerabilities.",
+		[]string{"namespace", "kind", "name", "container", "reason"}, nil,
+	)
+	workloadVulnerabilityReportGenerationsDesc = prometheus.NewDesc(
+		"starboard_workload_vulnerability_report_generations",
+		"Number of times the vulnerability report of a container of a workload was written.",
+		[]string{"namespace", "kind", "name", "container"}, nil,
+	)
+	workloadVulnerabilityReportLastChangedDesc = prometheus.NewDesc(
+		"starboard_workload_vulnerability_report_last_changed_timestamp_seconds",
+		"Time when findings of the vulnerability report of a container of a workload last changed, in seconds since the epoch.",
+		[]string{"namespace", "kind", "name", "container"}, nil,
+	)
+	workloadVulnerabilitiesDesc = prometheus.NewDesc(
+		"starboard_workload_vulnerabilities",
+		"Number of vulnerabilities of all containers of a workload by severity.",
+		[]string{"namespace", "kind", "name", "severity"}, nil,
+	)
+	workloadVulnerabilityMaxSeverityDesc = prometheus.NewDesc(
+		"starboard_workload_vulnerability_max_severity",
+		"Set to 1 for the highest severity of vulnerabilities of all containers of a workload.",
+		[]string{"namespace", "kind", "name", "severity"}, nil,
+	)
+)
+
+// workloadKey identifies a report of a given type of a workload.
+type workloadKey struct {
+	namespace  string
+	kind       string
+	name       string
+	reportType ReportType
+}
+
+func (k workloadKey) labelValues() []string {
+	return []string{k.namespace, k.kind, k.name, string(k.reportType)}
+}
+
+// WorkloadReportCollector is a prometheus.Collector which exports the age of
+// the most recent v1alpha1.VulnerabilityReport and v1alpha1.ConfigAuditReport
+// of each workload, so that alerts can be raised for workloads which were not
+// scanned recently. Containers whose images were deliberately not scanned are
+// exported separately, so that they are not mistaken for clean ones. The number
+// of generations of vulnerability reports and the time when their findings last
+// changed are exported per container. If workload vulnerability summaries are
+// enabled, vulnerability counts and the highest severity of each workload are
+// read from them.
+//
+// Optionally, it also exports a metric for each watched workload without a
+// report, which requires listing all workloads at each scrape.
+type WorkloadReportCollector struct {
+	logr.Logger
+	client.Client
+	etc.Config
+	Clock ext.Clock
+}
+
+// NewWorkloadReportCollector constructs a new WorkloadReportCollector.
+func NewWorkloadReportCollector(logger logr.Logger, client client.Client, config etc.Config, clock ext.Clock) *WorkloadReportCollector {
+	return &WorkloadReportCollector{
+		Logger: logger,
+		Client: client,
+		Config: config,
+		Clock:  clock,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *WorkloadReportCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- workloadReportAgeDesc
+	descs <- workloadVulnerabilityReportSkippedDesc
+	descs <- workloadVulnerabilityReportGenerationsDesc
+	descs <- workloadVulnerabilityReportLastChangedDesc
+	if c.Config.VulnerabilityScannerWorkloadSummaryEnabled {
+		descs <- workloadVulnerabilitiesDesc
+		descs <- workloadVulnerabilityMaxSeverityDesc
+	}
+	if c.Config.MetricsWorkloadReportMissingEnabled {
+		descs <- workloadReportMissingDesc
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *WorkloadReportCollector) Collect(metrics chan<- prometheus.Metric) {
+	ctx := context.Background()
+
+	updated, err := reportsUpdated(ctx, c.Client)
+	if err != nil {
+		c.Logger.Error(err, "Unable to collect workload report metrics")
+		return
+	}
+	now := c.Clock.Now()
+	for key, timestamp := range updated {
+		age := now.Sub(timestamp.Time).Seconds()
+		if age < 0 {
+			age = 0
+		}
+		metrics <- prometheus.MustNewConstMetric(workloadReportAgeDesc, prometheus.GaugeValue, age, key.labelValues()...)
+	}
+
+	err = c.collectVulnerabilityReports(ctx, metrics)
+	if err != nil {
+		c.Logger.Error(err, "Unable to collect vulnerability report metrics")
+	}
+
+	if c.Config.VulnerabilityScannerWorkloadSummaryEnabled {
+		err = c.collectWorkloadSummaries(ctx, metrics)
+		if err != nil {
+			c.Logger.Error(err, "Unable to collect workload vulnerability summary metrics")
+		}
+	}
+
+	if !c.Config.MetricsWorkloadReportMissingEnabled {
+		return
+	}
+	workloads, err := watchedWorkloads(ctx, c.Client, c.Config)
+	if err != nil {
+		c.Logger.Error(err, "Unable to collect missing workload report metrics")
+		return
+	}
+	for _, workload := range workloads {
+		for _, reportType := range reportTypes(c.Config) {
+			key := workloadKey{
+				namespace:  workload.Namespace,
+				kind:       string(workload.Kind),
+				name:       workload.Name,
+				reportType: reportType,
+			}
+			if _, ok := updated[key]; ok {
+				continue
+			}
+			metrics <- prometheus.MustNewConstMetric(workloadReportMissingDesc, prometheus.GaugeValue, 1, key.labelValues()...)
+		}
+	}
+}
+
+// reportTypes returns the types of reports generated by enabled scanners.
+func reportTypes(config etc.Config) []ReportType {
+	var types []ReportType
+	if config.VulnerabilityScannerEnabled {
+		types = append(types, ReportTypeVulnerability)
+	}
+	if config.ConfigAuditScannerEnabled || config.ConfigAuditScannerBuiltIn {
+		types = append(types, ReportTypeConfigAudit)
+	}
+	return types
+}
+
+// reportsUpdated returns the update timestamp of the most recent report of
+// each workload. A workload has one vulnerability report per container, or per
+// container and node group. Reports labeled with starboard.LabelOrphaned are
+// ignored, because the operator no longer scans their workloads.
+func reportsUpdated(ctx context.Context, c client.Reader) (map[workloadKey]metav1.Time, error) {
+	updated := make(map[workloadKey]metav1.Time)
+	observe := func(reportType ReportType, labels map[string]string, timestamp metav1.Time) {
+		if _, orphaned := labels[starboard.LabelOrphaned]; orphaned {
+			return
+		}
+		key := workloadKey{
+			namespace:  labels[starboard.LabelResourceNamespace],
+			kind:       labels[starboard.LabelResourceKind],
+			name:       labels[starboard.LabelResourceName],
+			reportType: reportType,
+		}
+		if key.kind == "" || key.name == "" {
+			return
+		}
+		if last, ok := updated[key]; !ok || last.Before(&timestamp) {
+			updated[key] = timestamp
+		}
+	}
+
+	var vulnerabilityReports v1alpha1.VulnerabilityReportList
+	err := c.List(ctx, &vulnerabilityReports)
+	if err != nil {
+		return nil, err
+	}
+	for _, report := range vulnerabilityReports.Items {
+		observe(ReportTypeVulnerability, report.Labels, report.Report.UpdateTimestamp)
+	}
+
+	var configAuditReports v1alpha1.ConfigAuditReportList
+	err = c.List(ctx, &configAuditReports)
+	if err != nil {
+		return nil, err
+	}
+	for _, report := range configAuditReports.Items {
+		observe(ReportTypeConfigAudit, report.Labels, report.Report.UpdateTimestamp)
+	}
+	return updated, nil
+}
+
+// containerKey identifies a container of a workload.
+type containerKey struct {
+	namespace string
+	kind      string
+	name      string
+	container string
+}
+
+func (k containerKey) labelValues() []string {
+	return []string{k.namespace, k.kind, k.name, k.container}
+}
+
+// containerReports aggregates vulnerability reports of a container of a
+// workload, which has one report per node group if its pods are spread across
+// nodes with different container runtimes.
+type containerReports struct {
+	generationCount int64
+	lastChanged     *metav1.Time
+	skipReasons     map[v1alpha1.SkipReason]bool
+}
+
+// collectVulnerabilityReports exports a metric for each container of a workload
+// whose vulnerability report states that its image was not scanned, and the
+// generations and last change of vulnerability reports written with them.
+// Reports of the same container in different node groups are aggregated, so
+// that each container is exported once, and orphaned reports are ignored.
+func (c *WorkloadReportCollector) collectVulnerabilityReports(ctx context.Context, metrics chan<- prometheus.Metric) error {
+	var vulnerabilityReports v1alpha1.VulnerabilityReportList
+	err := c.Client.List(ctx, &vulnerabilityReports)
+	if err != nil {
+		return err
+	}
+	containers := make(map[containerKey]*containerReports)
+	for _, report := range vulnerabilityReports.Items {
+		if _, orphaned := report.Labels[starboard.LabelOrphaned]; orphaned {
+			continue
+		}
+		key := containerKey{
+			namespace: report.Labels[starboard.LabelResourceNamespace],
+			kind:      report.Labels[starboard.LabelResourceKind],
+			name:      report.Labels[starboard.LabelResourceName],
+			container: report.Labels[starboard.LabelContainerName],
+		}
+		reports, ok := containers[key]
+		if !ok {
+			reports = &containerReports{skipReasons: make(map[v1alpha1.SkipReason]bool)}
+			containers[key] = reports
+		}
+		if report.Report.GenerationCount > reports.generationCount {
+			reports.generationCount = report.Report.GenerationCount
+		}
+		if lastChanged := report.Report.LastChangedTimestamp; lastChanged != nil &&
+			(reports.lastChanged == nil || reports.lastChanged.Before(lastChanged)) {
+			reports.lastChanged = lastChanged
+		}
+		if report.Report.SkipReason != "" {
+			reports.skipReasons[report.Report.SkipReason] = true
+		}
+	}
+	for key, reports := range containers {
+		labelValues := key.labelValues()
+		if reports.generationCount > 0 {
+			metrics <- prometheus.MustNewConstMetric(workloadVulnerabilityReportGenerationsDesc, prometheus.GaugeValue,
+				float64(reports.generationCount), labelValues...)
+		}
+		if reports.lastChanged != nil {
+			metrics <- prometheus.MustNewConstMetric(workloadVulnerabilityReportLastChangedDesc, prometheus.GaugeValue,
+				float64(reports.lastChanged.Unix()), labelValues...)
+		}
+		for reason := range reports.skipReasons {
+			metrics <- prometheus.MustNewConstMetric(workloadVulnerabilityReportSkippedDesc, prometheus.GaugeValue, 1,
+				append(labelValues, string(reason))...)
+		}
+	}
+	return nil
+}
+
+// collectWorkloadSummaries exports vulnerability counts by severity and the
+// highest severity of each workload from its WorkloadVulnerabilitySummary, so
+// that VulnerabilityReports of its containers are not aggregated again.
+func (c *WorkloadReportCollector) collectWorkloadSummaries(ctx context.Context, metrics chan<- prometheus.Metric) error {
+	var summaries v1alpha1.WorkloadVulnerabilitySummaryList
+	err := c.Client.List(ctx, &summaries)
+	if err != nil {
+		return err
+	}
+	for _, summary := range summaries.Items {
+		labelValues := []string{
+			summary.Labels[starboard.LabelResourceNamespace],
+			summary.Labels[starboard.LabelResourceKind],
+			summary.Labels[starboard.LabelResourceName],
+		}
+		counts := summary.Report.Summary
+		for severity, count := range map[v1alpha1.Severity]int{
+			v1alpha1.SeverityCritical: counts.CriticalCount,
+			v1alpha1.SeverityHigh:     counts.HighCount,
+			v1alpha1.SeverityMedium:   counts.MediumCount,
+			v1alpha1.SeverityLow:      counts.LowCount,
+			v1alpha1.SeverityUnknown:  counts.UnknownCount,
+		} {
+			metrics <- prometheus.MustNewConstMetric(workloadVulnerabilitiesDesc, prometheus.GaugeValue, float64(count),
+				append(labelValues, string(severity))...)
+		}
+		metrics <- prometheus.MustNewConstMetric(workloadVulnerabilityMaxSeverityDesc, prometheus.GaugeValue, 1,
+			append(labelValues, string(summary.Report.MaxSeverity))...)
+	}
+	return nil
+}
+
+// watchedWorkloads returns workloads which the operator scans, i.e. workloads
+// in target namespaces excluding pods and jobs controlled by other workloads,
+// workloads managed by Starboard, and ReplicaSets scaled down to zero, which
+// are typically old revisions of Deployments.
+func watchedWorkloads(ctx context.Context, c client.Reader, config etc.Config) ([]kube.ObjectRef, error) {
+	installModePredicate, err := InstallModePredicate(config)
+	if err != nil {
+		return nil, err
+	}
+	watched := func(obj client.Object) bool {
+		e := event.GenericEvent{Object: obj}
+		for _, p := range []predicate.Predicate{Not(ManagedByStarboardOperator), Not(IsBeingTerminated), installModePredicate} {
+			if !p.Generic(e) {
+				return false
+			}
+		}
+		return true
+	}
+
+	var refs []kube.ObjectRef
+	for _, workload := range []struct {
+		kind kube.Kind
+		list client.ObjectList
+	}{
+		{kind: kube.KindPod, list: &corev1.PodList{}},
+		{kind: kube.KindReplicaSet, list: &appsv1.ReplicaSetList{}},
+		{kind: kube.KindReplicationController, list: &corev1.ReplicationControllerList{}},
+		{kind: kube.KindStatefulSet, list: &appsv1.StatefulSetList{}},
+		{kind: kube.KindDaemonSet, list: &appsv1.DaemonSetList{}},
+		{kind: kube.KindCronJob, list: &batchv1beta1.CronJobList{}},
+		{kind: kube.KindJob, list: &batchv1.JobList{}},
+	} {
+		err := c.List(ctx, workload.list)
+		if err != nil {
+			return nil, err
+		}
+		err = meta.EachListItem(workload.list, func(item runtime.Object) error {
+			obj, ok := item.(client.Object)
+			if !ok || !watched(obj) || isControlled(obj) {
+				return nil
+			}
+			if rs, ok := obj.(*appsv1.ReplicaSet); ok && rs.Spec.Replicas != nil && *rs.Spec.Replicas == 0 {
+				return nil
+			}
+			refs = append(refs, kube.ObjectRef{Kind: workload.kind, Name: obj.GetName(), Namespace: obj.GetNamespace()})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return refs, nil
+}
+
+// isControlled returns true if the given pod or job is controlled by another
+// workload, which owns the reports instead.
+func isControlled(obj client.Object) bool {
+	controller := metav1.GetControllerOf(obj)
+	switch obj.(type) {
+	case *corev1.Pod:
+		return kube.IsBuiltInWorkload(controller)
+	case *batchv1.Job:
+		return controller != nil && controller.Kind == string(kube.KindCronJob)
+	}
+	return false
+}
@@ -0,0 +1,267 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var now = time.Date(2022, 5, 12, 12, 0, 0, 0, time.UTC)
+
+func workloadLabels(namespace, kind, name string) map[string]string {
+	return map[string]string{
+		starboard.LabelResourceNamespace: namespace,
+		starboard.LabelResourceKind:      kind,
+		starboard.LabelResourceName:      name,
+	}
+}
+
+func newVulnerabilityReport(name string, labels map[string]string, age time.Duration) *v1alpha1.VulnerabilityReport {
+	return &v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: labels[starboard.LabelResourceNamespace], Labels: labels},
+		Report:     v1alpha1.VulnerabilityReportData{UpdateTimestamp: metav1.NewTime(now.Add(-age))},
+	}
+}
+
+func newConfigAuditReport(name string, labels map[string]string, age time.Duration) *v1alpha1.ConfigAuditReport {
+	return &v1alpha1.ConfigAuditReport{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: labels[starboard.LabelResourceNamespace], Labels: labels},
+		Report:     v1alpha1.ConfigAuditReportData{UpdateTimestamp: metav1.NewTime(now.Add(-age))},
+	}
+}
+
+func newCollector(config etc.Config, objects ...client.Object) *metrics.WorkloadReportCollector {
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build()
+	return metrics.NewWorkloadReportCollector(logr.Discard(), c, config, ext.NewFixedClock(now))
+}
+
+func TestWorkloadReportCollector(t *testing.T) {
+	nginx := workloadLabels("default", "ReplicaSet", "nginx-6d4cf56db6")
+	redis := workloadLabels("prod", "StatefulSet", "redis")
+
+	reports := []client.Object{
+		newVulnerabilityReport("replicaset-nginx-6d4cf56db6-nginx", nginx, 26*time.Hour),
+		newVulnerabilityReport("replicaset-nginx-6d4cf56db6-sidecar", nginx, 2*time.Hour),
+		newConfigAuditReport("replicaset-nginx-6d4cf56db6", nginx, 30*time.Minute),
+		newVulnerabilityReport("statefulset-redis-redis", redis, 48*time.Hour),
+	}
+
+	t.Run("Should export age of the most recent report of each workload", func(t *testing.T) {
+		collector := newCollector(etc.Config{
+			VulnerabilityScannerEnabled: true,
+			ConfigAuditScannerBuiltIn:   true,
+		}, reports...)
+
+		expected := `
+# HELP starboard_workload_report_age_seconds Time since the most recent report of a workload was updated.
+# TYPE starboard_workload_report_age_seconds gauge
+starboard_workload_report_age_seconds{kind="ReplicaSet",name="nginx-6d4cf56db6",namespace="default",report_type="configaudit"} 1800
+starboard_workload_report_age_seconds{kind="ReplicaSet",name="nginx-6d4cf56db6",namespace="default",report_type="vulnerability"} 7200
+starboard_workload_report_age_seconds{kind="StatefulSet",name="redis",namespace="prod",report_type="vulnerability"} 172800
+`
+		assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+	})
+
+	t.Run("Should not export missing reports unless enabled", func(t *testing.T) {
+		collector := newCollector(etc.Config{
+			VulnerabilityScannerEnabled: true,
+		}, &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "fluentd", Namespace: "kube-system"}})
+
+		assert.Equal(t, 0, testutil.CollectAndCount(collector))
+	})
+
+	t.Run("Should export missing reports of watched workloads", func(t *testing.T) {
+		objects := append([]client.Object{
+			// Has both vulnerability and config audit reports.
+			&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "nginx-6d4cf56db6", Namespace: "default"},
+				Spec: appsv1.ReplicaSetSpec{Replicas: pointer.Int32Ptr(1)}},
+			// Old revision of a Deployment.
+			&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "nginx-5b4b7f7d9f", Namespace: "default"},
+				Spec: appsv1.ReplicaSetSpec{Replicas: pointer.Int32Ptr(0)}},
+			// Controlled by the ReplicaSet.
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "nginx-6d4cf56db6-x7kqp", Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "nginx-6d4cf56db6", UID: "1", Controller: pointer.BoolPtr(true)}}}},
+			// Controlled by a CronJob.
+			&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "backup-27536400", Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "batch/v1beta1", Kind: "CronJob", Name: "backup", UID: "2", Controller: pointer.BoolPtr(true)}}}},
+			// Scan job managed by Starboard.
+			&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "scan-vulnerabilityreport-5d8f9f8d9", Namespace: "default",
+				Labels: map[string]string{"app.kubernetes.io/managed-by": "starboard"}}},
+			// Not scanned at all.
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "default"}},
+			// In excluded namespace.
+			&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "fluentd", Namespace: "kube-system"}},
+		}, reports...)
+		collector := newCollector(etc.Config{
+			Namespace:                           "starboard-system",
+			ExcludeNamespaces:                   "kube-system",
+			VulnerabilityScannerEnabled:         true,
+			ConfigAuditScannerBuiltIn:           true,
+			MetricsWorkloadReportMissingEnabled: true,
+		}, objects...)
+
+		expected := `
+# HELP starboard_workload_report_missing Set to 1 for a workload watched by the operator which has no report.
+# TYPE starboard_workload_report_missing gauge
+starboard_workload_report_missing{kind="Pod",name="debug",namespace="default",report_type="configaudit"} 1
+starboard_workload_report_missing{kind="Pod",name="debug",namespace="default",report_type="vulnerability"} 1
+`
+		assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected), "starboard_workload_report_missing"))
+	})
+
+	t.Run("Should export skipped vulnerability reports", func(t *testing.T) {
+		skipped := newVulnerabilityReport("replicaset-nginx-6d4cf56db6-vendor",
+			map[string]string{
+				starboard.LabelResourceNamespace: "default",
+				starboard.LabelResourceKind:      "ReplicaSet",
+				starboard.LabelResourceName:      "nginx-6d4cf56db6",
+				starboard.LabelContainerName:     "vendor",
+			}, time.Hour)
+		skipped.Report.SkipReason = v1alpha1.SkipReasonRegistryExcluded
+		collector := newCollector(etc.Config{
+			VulnerabilityScannerEnabled: true,
+		}, append([]client.Object{skipped}, reports...)...)
+
+		expected := `
+# HELP starboard_workload_vulnerability_report_skipped Set to 1 for a container of a workload whose image was deliberately not scanned for vulnerabilities.
+# TYPE starboard_workload_vulnerability_report_skipped gauge
+starboard_workload_vulnerability_report_skipped{container="vendor",kind="ReplicaSet",name="nginx-6d4cf56db6",namespace="default",reason="RegistryExcluded"} 1
+`
+		assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected), "starboard_workload_vulnerability_report_skipped"))
+	})
+
+	t.Run("Should export generations and last change of vulnerability reports", func(t *testing.T) {
+		tracked := newVulnerabilityReport("replicaset-nginx-6d4cf56db6-app",
+			map[string]string{
+				starboard.LabelResourceNamespace: "default",
+				starboard.LabelResourceKind:      "ReplicaSet",
+				starboard.LabelResourceName:      "nginx-6d4cf56db6",
+				starboard.LabelContainerName:     "app",
+			}, time.Hour)
+		tracked.Report.GenerationCount = 7
+		tracked.Report.LastChangedTimestamp = &metav1.Time{Time: now.Add(-3 * time.Hour)}
+		collector := newCollector(etc.Config{
+			VulnerabilityScannerEnabled: true,
+		}, append([]client.Object{tracked}, reports...)...)
+
+		expected := `
+# HELP starboard_workload_vulnerability_report_generations Number of times the vulnerability report of a container of a workload was written.
+# TYPE starboard_workload_vulnerability_report_generations gauge
+starboard_workload_vulnerability_report_generations{container="app",kind="ReplicaSet",name="nginx-6d4cf56db6",namespace="default"} 7
+# HELP starboard_workload_vulnerability_report_last_changed_timestamp_seconds Time when findings of the vulnerability report of a container of a workload last changed, in seconds since the epoch.
+# TYPE starboard_workload_vulnerability_report_last_changed_timestamp_seconds gauge
+starboard_workload_vulnerability_report_last_changed_timestamp_seconds{container="app",kind="ReplicaSet",name="nginx-6d4cf56db6",namespace="default"} 1.652346e+09
+`
+		assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"starboard_workload_vulnerability_report_generations",
+			"starboard_workload_vulnerability_report_last_changed_timestamp_seconds"))
+	})
+
+	t.Run("Should ignore orphaned reports", func(t *testing.T) {
+		orphaned := map[string]string{
+			starboard.LabelResourceNamespace: "legacy",
+			starboard.LabelResourceKind:      "ReplicaSet",
+			starboard.LabelResourceName:      "nginx-6d4cf56db6",
+			starboard.LabelContainerName:     "nginx",
+			starboard.LabelOrphaned:          "true",
+		}
+		vulnerabilityReport := newVulnerabilityReport("replicaset-nginx-6d4cf56db6-nginx", orphaned, time.Hour)
+		vulnerabilityReport.Report.GenerationCount = 3
+		vulnerabilityReport.Report.SkipReason = v1alpha1.SkipReasonRegistryExcluded
+		collector := newCollector(etc.Config{
+			VulnerabilityScannerEnabled: true,
+			ConfigAuditScannerBuiltIn:   true,
+		}, vulnerabilityReport, newConfigAuditReport("replicaset-nginx-6d4cf56db6", orphaned, time.Hour))
+
+		assert.Equal(t, 0, testutil.CollectAndCount(collector))
+	})
+
+	t.Run("Should export each container of reports of different node groups once", func(t *testing.T) {
+		newNodeGroupReport := func(name, nodeGroup string, generations int64, lastChanged time.Duration) client.Object {
+			report := newVulnerabilityReport(name,
+				map[string]string{
+					starboard.LabelResourceNamespace: "kube-system",
+					starboard.LabelResourceKind:      "DaemonSet",
+					starboard.LabelResourceName:      "fluentd",
+					starboard.LabelContainerName:     "fluentd",
+					starboard.LabelNodeGroup:         nodeGroup,
+				}, time.Hour)
+			report.Report.GenerationCount = generations
+			report.Report.LastChangedTimestamp = &metav1.Time{Time: now.Add(-lastChanged)}
+			report.Report.SkipReason = v1alpha1.SkipReasonContainerExcluded
+			return report
+		}
+		collector := newCollector(etc.Config{
+			VulnerabilityScannerEnabled: true,
+		},
+			newNodeGroupReport("daemonset-fluentd-fluentd-7b8c9d", "7b8c9d", 4, 3*time.Hour),
+			newNodeGroupReport("daemonset-fluentd-fluentd-5f6e7a", "5f6e7a", 2, 2*time.Hour),
+		)
+
+		expected := `
+# HELP starboard_workload_vulnerability_report_generations Number of times the vulnerability report of a container of a workload was written.
+# TYPE starboard_workload_vulnerability_report_generations gauge
+starboard_workload_vulnerability_report_generations{container="fluentd",kind="DaemonSet",name="fluentd",namespace="kube-system"} 4
+# HELP starboard_workload_vulnerability_report_last_changed_timestamp_seconds Time when findings of the vulnerability report of a container of a workload last changed, in seconds since the epoch.
+# TYPE starboard_workload_vulnerability_report_last_changed_timestamp_seconds gauge
+starboard_workload_vulnerability_report_last_changed_timestamp_seconds{container="fluentd",kind="DaemonSet",name="fluentd",namespace="kube-system"} 1.6523496e+09
+# HELP starboard_workload_vulnerability_report_skipped Set to 1 for a container of a workload whose image was deliberately not scanned for vulnerabilities.
+# TYPE starboard_workload_vulnerability_report_skipped gauge
+starboard_workload_vulnerability_report_skipped{container="fluentd",kind="DaemonSet",name="fluentd",namespace="kube-system",reason="ContainerExcluded"} 1
+`
+		assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"starboard_workload_vulnerability_report_generations",
+			"starboard_workload_vulnerability_report_last_changed_timestamp_seconds",
+			"starboard_workload_vulnerability_report_skipped"))
+	})
+
+	t.Run("Should export vulnerabilities of workloads from summaries", func(t *testing.T) {
+		summary := &v1alpha1.WorkloadVulnerabilitySummary{
+			ObjectMeta: metav1.ObjectMeta{Name: "replicaset-nginx-6d4cf56db6", Namespace: "default", Labels: nginx},
+			Report: v1alpha1.WorkloadVulnerabilitySummaryData{
+				UpdateTimestamp: metav1.NewTime(now),
+				MaxSeverity:     v1alpha1.SeverityHigh,
+				Summary:         v1alpha1.VulnerabilitySummary{HighCount: 2, MediumCount: 5, LowCount: 1},
+			},
+		}
+		expected := `
+# HELP starboard_workload_vulnerabilities Number of vulnerabilities of all containers of a workload by severity.
+# TYPE starboard_workload_vulnerabilities gauge
+starboard_workload_vulnerabilities{kind="ReplicaSet",name="nginx-6d4cf56db6",namespace="default",severity="CRITICAL"} 0
+starboard_workload_vulnerabilities{kind="ReplicaSet",name="nginx-6d4cf56db6",namespace="default",severity="HIGH"} 2
+starboard_workload_vulnerabilities{kind="ReplicaSet",name="nginx-6d4cf56db6",namespace="default",severity="LOW"} 1
+starboard_workload_vulnerabilities{kind="ReplicaSet",name="nginx-6d4cf56db6",namespace="default",severity="MEDIUM"} 5
+starboard_workload_vulnerabilities{kind="ReplicaSet",name="nginx-6d4cf56db6",namespace="default",severity="UNKNOWN"} 0
+# HELP starboard_workload_vulnerability_max_severity Set to 1 for the highest severity of vulnerabilities of all containers of a workload.
+# TYPE starboard_workload_vulnerability_max_severity gauge
+starboard_workload_vulnerability_max_severity{kind="ReplicaSet",name="nginx-6d4cf56db6",namespace="default",severity="HIGH"} 1
+`
+		collector := newCollector(etc.Config{
+			VulnerabilityScannerEnabled:                true,
+			VulnerabilityScannerWorkloadSummaryEnabled: true,
+		}, append([]client.Object{summary}, reports...)...)
+		assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"starboard_workload_vulnerabilities",
+			"starboard_workload_vulnerability_max_severity"))
+
+		collector = newCollector(etc.Config{VulnerabilityScannerEnabled: true}, append([]client.Object{summary}, reports...)...)
+		assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(""),
+			"starboard_workload_vulnerabilities"))
+	})
+}
@@ -3,23 +3,44 @@ package operator
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/archive"
 	"github.com/aquasecurity/starboard/pkg/compliance"
 	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/exploitability"
 	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/imageinventory"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/kubebench"
 	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/crd"
+	"github.com/aquasecurity/starboard/pkg/operator/drain"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
+	"github.com/aquasecurity/starboard/pkg/operator/permissions"
+	"github.com/aquasecurity/starboard/pkg/operator/quota"
+	"github.com/aquasecurity/starboard/pkg/operator/siem"
+	"github.com/aquasecurity/starboard/pkg/operator/throttle"
+	"github.com/aquasecurity/starboard/pkg/operator/webhook"
 	"github.com/aquasecurity/starboard/pkg/plugin"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/kubernetes"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 var (
@@ -45,6 +66,15 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 		HealthProbeBindAddress: operatorConfig.HealthProbeBindAddress,
 	}
 
+	// Let reconciliations in flight finish before the manager gives up on
+	// stopping controllers.
+	options.GracefulShutdownTimeout = &operatorConfig.ShutdownDrainTimeout
+
+	if operatorConfig.WebhookEnabled {
+		options.Port = operatorConfig.WebhookPort
+		options.CertDir = operatorConfig.WebhookCertDir
+	}
+
 	if operatorConfig.LeaderElectionEnabled {
 		options.LeaderElection = operatorConfig.LeaderElectionEnabled
 		options.LeaderElectionID = operatorConfig.LeaderElectionID
@@ -99,6 +129,88 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 		return fmt.Errorf("constructing kube client: %w", err)
 	}
 
+	// Controllers whose permissions are missing are disabled instead of
+	// failing each reconciliation, and the operator reports itself as not
+	// ready until the permissions are granted.
+	setupLog.Info("Checking permissions")
+	missingPermissions, err := permissions.Check(ctx, permissions.NewSelfReviewer(kubeClientset),
+		permissions.Required(permissions.Namespaces{Operator: operatorNamespace, Targets: targetNamespaces},
+			permissions.EnabledFeatures(operatorConfig)...))
+	if err != nil {
+		return fmt.Errorf("checking permissions: %w", err)
+	}
+	if len(missingPermissions) > 0 {
+		var table strings.Builder
+		if err = permissions.WriteTable(&table, missingPermissions); err != nil {
+			return err
+		}
+		disabled := permissions.MissingFeatures(missingPermissions)
+		setupLog.Info("Disabling features with missing permissions\n"+table.String(), "features", disabled)
+		permissions.Disable(&operatorConfig, disabled...)
+	}
+
+	configManager := starboard.NewConfigManager(kubeClientset, operatorNamespace)
+	err = configManager.EnsureDefault(context.Background())
+	if err != nil {
+		return err
+	}
+
+	starboardConfig, err := configManager.Read(context.Background())
+	if err != nil {
+		return err
+	}
+
+	// The client returned by the ctrl.Manager cannot read CRDs before the
+	// manager is started, so that CRDs are applied and verified with an
+	// uncached client.
+	crdClient, err := client.New(kubeConfig, client.Options{Scheme: options.Scheme})
+	if err != nil {
+		return fmt.Errorf("constructing CRD client: %w", err)
+	}
+	if operatorConfig.ManageCRDs {
+		setupLog.Info("Applying CRDs")
+		crds, err := crd.Embedded()
+		if err != nil {
+			return err
+		}
+		if err = crd.Apply(ctx, ctrl.Log.WithName("crd"), crdClient, crds); err != nil {
+			return err
+		}
+	}
+	// PackageInventory objects are written only if Trivy lists all packages,
+	// otherwise their CRD is optional.
+	listAllPackages, err := listAllPackages(crdClient, operatorNamespace, operatorConfig, starboardConfig)
+	if err != nil {
+		return err
+	}
+	// If the operator manages CRDs, features whose CRDs are still missing or
+	// outdated are disabled instead of failing the operator, and the operator
+	// reports itself as not ready until the CRDs are upgraded. Otherwise CRDs
+	// are upgraded by other means, e.g. by hand since Helm does not upgrade
+	// CRDs, and unverified CRDs are only logged.
+	setupLog.Info("Verifying CRDs")
+	var unverifiedFeatures []permissions.Feature
+	for _, feature := range permissions.EnabledFeatures(operatorConfig) {
+		crds := featureCRDs[feature]
+		if feature == permissions.FeatureVulnerabilityScanner && listAllPackages {
+			crds = append(crds, crd.PackageInventories...)
+		}
+		err = crd.Verify(ctx, crdClient, crds...)
+		if err == nil {
+			continue
+		}
+		if !operatorConfig.ManageCRDs {
+			setupLog.Info("WARNING: Feature may fail due to unverified CRDs", "feature", feature, "error", err.Error())
+			continue
+		}
+		setupLog.Error(err, "Disabling feature with unverified CRDs", "feature", feature)
+		unverifiedFeatures = append(unverifiedFeatures, feature)
+	}
+	permissions.Disable(&operatorConfig, unverifiedFeatures...)
+	// Package inventories are garbage collected whenever their CRD is
+	// installed, even if Trivy no longer lists all packages.
+	packageInventoriesInstalled := crd.Verify(ctx, crdClient, crd.PackageInventories...) == nil
+
 	mgr, err := ctrl.NewManager(kubeConfig, options)
 	if err != nil {
 		return fmt.Errorf("constructing controllers manager: %w", err)
@@ -109,27 +221,143 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 		return err
 	}
 
-	err = mgr.AddHealthzCheck("ping", healthz.Ping)
+	err = mgr.AddReadyzCheck("permissions", func(_ *http.Request) error {
+		if len(missingPermissions) > 0 {
+			return fmt.Errorf("degraded: missing permissions: %s", permissions.Summary(missingPermissions))
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	configManager := starboard.NewConfigManager(kubeClientset, operatorNamespace)
-	err = configManager.EnsureDefault(context.Background())
+	err = mgr.AddReadyzCheck("crds", func(_ *http.Request) error {
+		if len(unverifiedFeatures) > 0 {
+			return fmt.Errorf("degraded: features disabled due to unverified CRDs: %v", unverifiedFeatures)
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	starboardConfig, err := configManager.Read(context.Background())
+	err = mgr.AddHealthzCheck("ping", healthz.Ping)
 	if err != nil {
 		return err
 	}
 
+	if operatorConfig.WebhookEnabled {
+		overrideUsers := operatorConfig.GetProtectedReportsOverrideUsers()
+		setupLog.Info("Enabling report deletion webhook", "override users", overrideUsers)
+		mgr.GetWebhookServer().Register(webhook.ReportDeletionPath, &ctrlwebhook.Admission{
+			Handler: &webhook.ReportDeletionValidator{
+				Logger:        ctrl.Log.WithName("webhook").WithName("reportdeletion"),
+				OverrideUsers: overrideUsers,
+			},
+		})
+	}
+
 	objectResolver := kube.ObjectResolver{Client: mgr.GetClient()}
-	limitChecker := controller.NewLimitChecker(operatorConfig, mgr.GetClient(), starboardConfig)
+	requestThrottle := throttle.New(ctrl.Log.WithName("throttle"), ext.NewSystemClock(),
+		operatorConfig.ThrottleBackoffBase, operatorConfig.ThrottleBackoffMax)
+	limitChecker := controller.NewLimitCheckerWithThrottle(operatorConfig, mgr.GetClient(), starboardConfig, requestThrottle)
+	scanJobsSwitch, err := controller.NewScanJobsSwitch(operatorConfig, starboardConfig)
+	if err != nil {
+		return err
+	}
+	if scanJobsSwitch.Suspended() {
+		setupLog.Info("Suspending scan jobs")
+	}
 	logsReader := kube.NewLogsReader(kubeClientset)
 	secretsReader := kube.NewSecretsReader(mgr.GetClient())
 
+	var reportQuota quota.Quota
+	if operatorConfig.MaxReportsPerNamespace > 0 {
+		setupLog.Info("Enabling eviction of reports", "maxReportsPerNamespace", operatorConfig.MaxReportsPerNamespace)
+		namespaceQuota := quota.NewNamespaceQuota(ctrl.Log.WithName("quota"), mgr.GetClient(),
+			mgr.GetEventRecorderFor("starboard-operator"), operatorConfig.MaxReportsPerNamespace)
+		if err = namespaceQuota.SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup report quota: %w", err)
+		}
+		reportQuota = namespaceQuota
+	}
+
+	configAuditReadWriter := configauditreport.NewReadWriterWithQuota(mgr.GetClient(), reportQuota)
+	if operatorConfig.ConfigAuditEventsEnabled {
+		setupLog.Info("Enabling config audit events", "interval", operatorConfig.ConfigAuditEventsInterval)
+		configAuditReadWriter = configauditreport.NewReadWriterWithEvents(configAuditReadWriter, mgr.GetClient(),
+			mgr.GetEventRecorderFor("starboard-operator"), operatorConfig.ConfigAuditEventsInterval, ext.NewSystemClock())
+	}
+
+	vulnerabilityReadWriter := vulnerabilityreport.NewReadWriterWithQuota(mgr.GetClient(), reportQuota)
+	if operatorConfig.SIEMSyslogAddress != "" {
+		setupLog.Info("Enabling CEF events", "address", operatorConfig.SIEMSyslogAddress,
+			"mode", operatorConfig.SIEMEventMode, "severityFloor", operatorConfig.SIEMSeverityFloor)
+		tlsConfig, err := siem.NewTLSConfig(operatorConfig.SIEMSyslogTLSCAFile)
+		if err != nil {
+			return err
+		}
+		emitter, err := siem.NewEmitter(ctrl.Log.WithName("siem"), ext.NewSystemClock(), siem.Config{
+			Address:       operatorConfig.SIEMSyslogAddress,
+			TLSConfig:     tlsConfig,
+			BufferSize:    operatorConfig.SIEMBufferSize,
+			BatchSize:     operatorConfig.SIEMBatchSize,
+			FlushInterval: operatorConfig.SIEMFlushInterval,
+			DialTimeout:   10 * time.Second,
+		})
+		if err != nil {
+			return err
+		}
+		if err = mgr.Add(emitter); err != nil {
+			return fmt.Errorf("unable to add CEF events emitter: %w", err)
+		}
+		severityFloor, _ := v1alpha1.StringToSeverity(operatorConfig.SIEMSeverityFloor)
+		siemOptions := siem.Options{
+			Mode:          siem.Mode(operatorConfig.SIEMEventMode),
+			SeverityFloor: severityFloor,
+			DeviceVersion: buildInfo.Version,
+		}
+		vulnerabilityReadWriter = siem.NewVulnerabilityReadWriter(vulnerabilityReadWriter, emitter, siemOptions)
+		configAuditReadWriter = siem.NewConfigAuditReadWriter(configAuditReadWriter, emitter, siemOptions)
+	}
+
+	// Reconciliations of scan jobs in flight when the operator shuts down are
+	// allowed to finish ingesting results, and finished scan jobs left over
+	// by a previous instance of the operator are resumed when it starts.
+	scanJobsDrain := drain.New(operatorConfig.ShutdownDrainTimeout)
+	var resumedJobs []chan<- event.GenericEvent
+	newResumedJobs := func() <-chan event.GenericEvent {
+		jobs := make(chan event.GenericEvent)
+		resumedJobs = append(resumedJobs, jobs)
+		return jobs
+	}
+
+	// Scanned resources of stuck scan jobs are requeued after the scan jobs
+	// are deleted.
+	var requeuedResources []chan<- event.GenericEvent
+	newRequeuedResources := func() <-chan event.GenericEvent {
+		if operatorConfig.ScanJobMaxAge <= 0 {
+			return nil
+		}
+		resources := make(chan event.GenericEvent)
+		requeuedResources = append(requeuedResources, resources)
+		return resources
+	}
+
+	// Images of scanners are verified when plugins are initialized, and again
+	// when scan jobs are created.
+	imagePolicy := starboard.ImagePolicy{
+		DigestRequired: operatorConfig.ScannerImageDigestRequired,
+		AllowedDigests: operatorConfig.GetScannerImageDigestAllowlist(),
+	}
+
+	// Failures of scan jobs are recorded only if the scan summary is enabled.
+	var scanFailures metrics.ScanFailureRecorder
+	recentScanFailures := metrics.NewRecentScanFailures(ext.NewSystemClock())
+	if operatorConfig.ScanSummaryInterval > 0 {
+		scanFailures = recentScanFailures
+	}
+
 	if operatorConfig.VulnerabilityScannerEnabled {
 		plugin, pluginContext, err := plugin.NewResolver().
 			WithBuildInfo(buildInfo).
@@ -137,6 +365,7 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 			WithServiceAccountName(operatorConfig.ServiceAccount).
 			WithConfig(starboardConfig).
 			WithClient(mgr.GetClient()).
+			WithImagePolicy(imagePolicy).
 			GetVulnerabilityPlugin()
 		if err != nil {
 			return err
@@ -147,22 +376,63 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 			return fmt.Errorf("initializing %s plugin: %w", pluginContext.GetName(), err)
 		}
 
+		exploitabilityConfig, exploitabilityEnabled, err := exploitability.GetConfig(starboardConfig)
+		if err != nil {
+			return err
+		}
+		var exploitabilityEnricher *exploitability.Enricher
+		if exploitabilityEnabled {
+			setupLog.Info("Enabling prioritization of vulnerabilities",
+				"kevURL", exploitabilityConfig.KEVURL, "epssURL", exploitabilityConfig.EPSSURL)
+			if err = mgr.Add(&exploitability.Updater{
+				Logger:     ctrl.Log.WithName("exploitability"),
+				Client:     mgr.GetClient(),
+				Config:     exploitabilityConfig,
+				Namespace:  operatorNamespace,
+				HTTPClient: http.DefaultClient,
+				Clock:      ext.NewSystemClock(),
+			}); err != nil {
+				return fmt.Errorf("unable to add exploitability feeds updater: %w", err)
+			}
+			exploitabilityEnricher = exploitability.NewEnricher(mgr.GetClient(), operatorNamespace, exploitabilityConfig)
+		}
+
 		if err = (&vulnerabilityreport.WorkloadController{
-			Logger:         ctrl.Log.WithName("reconciler").WithName("vulnerabilityreport"),
-			Config:         operatorConfig,
-			ConfigData:     starboardConfig,
-			Client:         mgr.GetClient(),
-			ObjectResolver: objectResolver,
-			LimitChecker:   limitChecker,
-			LogsReader:     logsReader,
-			SecretsReader:  secretsReader,
-			Plugin:         plugin,
-			PluginContext:  pluginContext,
-			ReadWriter:     vulnerabilityreport.NewReadWriter(mgr.GetClient()),
+			Logger:            ctrl.Log.WithName("reconciler").WithName("vulnerabilityreport"),
+			Config:            operatorConfig,
+			ConfigData:        starboardConfig,
+			Client:            mgr.GetClient(),
+			ObjectResolver:    objectResolver,
+			LimitChecker:      limitChecker,
+			ScanJobsSwitch:    scanJobsSwitch,
+			LogsReader:        logsReader,
+			SecretsReader:     secretsReader,
+			Plugin:            plugin,
+			PluginContext:     pluginContext,
+			ReadWriter:        vulnerabilityReadWriter,
+			BuildInfo:         buildInfo,
+			ScanFailures:      scanFailures,
+			Recorder:          mgr.GetEventRecorderFor("starboard-operator"),
+			Throttle:          requestThrottle,
+			Drain:             scanJobsDrain,
+			ResumedJobs:       newResumedJobs(),
+			Exploitability:    exploitabilityEnricher,
+			RequeuedResources: newRequeuedResources(),
 		}).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup vulnerabilityreport reconciler: %w", err)
 		}
 
+		if packageInventoriesInstalled {
+			if err = (&controller.PackageInventoryGCReconciler{
+				Logger: ctrl.Log.WithName("reconciler").WithName("packageinventorygc"),
+				Config: operatorConfig,
+				Client: mgr.GetClient(),
+				Reader: mgr.GetAPIReader(),
+			}).SetupWithManager(mgr); err != nil {
+				return fmt.Errorf("unable to setup package inventory GC reconciler: %w", err)
+			}
+		}
+
 		if operatorConfig.VulnerabilityScannerReportTTL != nil {
 			if err = (&controller.TTLReportReconciler{
 				Logger: ctrl.Log.WithName("reconciler").WithName("ttlreport"),
@@ -173,6 +443,17 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 				return fmt.Errorf("unable to setup TTLreport reconciler: %w", err)
 			}
 		}
+
+		archiveConfig, archiveEnabled, err := archive.GetConfig(starboardConfig)
+		if err != nil {
+			return err
+		}
+		if archiveEnabled {
+			setupLog.Info("Enabling archival of vulnerability reports", "url", starboardConfig[archive.KeyURL], "cluster", archiveConfig.Cluster)
+			if err = archive.NewArchiver(ctrl.Log.WithName("archiver"), archiveConfig).SetupWithManager(mgr); err != nil {
+				return fmt.Errorf("unable to setup archiver: %w", err)
+			}
+		}
 	}
 
 	if operatorConfig.ConfigAuditScannerEnabled {
@@ -182,6 +463,7 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 			WithServiceAccountName(operatorConfig.ServiceAccount).
 			WithConfig(starboardConfig).
 			WithClient(mgr.GetClient()).
+			WithImagePolicy(imagePolicy).
 			GetConfigAuditPlugin()
 		if err != nil {
 			return err
@@ -193,16 +475,22 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 		}
 
 		if err = (&controller.ConfigAuditReportReconciler{
-			Logger:         ctrl.Log.WithName("reconciler").WithName("configauditreport"),
-			Config:         operatorConfig,
-			ConfigData:     starboardConfig,
-			Client:         mgr.GetClient(),
-			ObjectResolver: objectResolver,
-			LimitChecker:   limitChecker,
-			LogsReader:     logsReader,
-			Plugin:         plugin,
-			PluginContext:  pluginContext,
-			ReadWriter:     configauditreport.NewReadWriter(mgr.GetClient()),
+			Logger:            ctrl.Log.WithName("reconciler").WithName("configauditreport"),
+			Config:            operatorConfig,
+			ConfigData:        starboardConfig,
+			Client:            mgr.GetClient(),
+			ObjectResolver:    objectResolver,
+			LimitChecker:      limitChecker,
+			ScanJobsSwitch:    scanJobsSwitch,
+			LogsReader:        logsReader,
+			Plugin:            plugin,
+			PluginContext:     pluginContext,
+			ReadWriter:        configAuditReadWriter,
+			ScanFailures:      scanFailures,
+			Throttle:          requestThrottle,
+			Drain:             scanJobsDrain,
+			ResumedJobs:       newResumedJobs(),
+			RequeuedResources: newRequeuedResources(),
 		}).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup configauditreport reconciler: %w", err)
 		}
@@ -218,19 +506,48 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 		}
 	}
 
+	if operatorConfig.VulnerabilityScannerEnabled || operatorConfig.ConfigAuditScannerEnabled {
+		if err = (&controller.ScanJobSecretReconciler{
+			Logger: ctrl.Log.WithName("reconciler").WithName("scanjobsecret"),
+			Config: operatorConfig,
+			Client: mgr.GetClient(),
+			Clock:  ext.NewSystemClock(),
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup scanjobsecret reconciler: %w", err)
+		}
+	}
+
 	if operatorConfig.CISKubernetesBenchmarkEnabled {
+		kubeBenchImageRef, err := starboardConfig.GetKubeBenchImageRef()
+		if err != nil {
+			return err
+		}
+		if err = imagePolicy.VerifyImageRef(kubeBenchImageRef); err != nil {
+			return fmt.Errorf("verifying kube-bench image: %w", err)
+		}
 		if err = (&controller.CISKubeBenchReportReconciler{
-			Logger:       ctrl.Log.WithName("reconciler").WithName("ciskubebenchreport"),
-			Config:       operatorConfig,
-			ConfigData:   starboardConfig,
-			Client:       mgr.GetClient(),
-			LogsReader:   logsReader,
-			LimitChecker: limitChecker,
-			ReadWriter:   kubebench.NewReadWriter(mgr.GetClient()),
-			Plugin:       kubebench.NewKubeBenchPlugin(ext.NewSystemClock(), starboardConfig),
+			Logger:            ctrl.Log.WithName("reconciler").WithName("ciskubebenchreport"),
+			Config:            operatorConfig,
+			ConfigData:        starboardConfig,
+			Client:            mgr.GetClient(),
+			LogsReader:        logsReader,
+			LimitChecker:      limitChecker,
+			ScanJobsSwitch:    scanJobsSwitch,
+			ReadWriter:        kubebench.NewReadWriter(mgr.GetClient()),
+			Plugin:            kubebench.NewKubeBenchPlugin(ext.NewSystemClock(), starboardConfig),
+			ScanFailures:      scanFailures,
+			Throttle:          requestThrottle,
+			Drain:             scanJobsDrain,
+			ResumedJobs:       newResumedJobs(),
+			RequeuedResources: newRequeuedResources(),
+			ImagePolicy:       imagePolicy,
 		}).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup ciskubebenchreport reconciler: %w", err)
 		}
+		collector := metrics.NewKubeBenchReportCollector(ctrl.Log.WithName("metrics").WithName("kubebenchreport"), mgr.GetClient())
+		if err = ctrlmetrics.Registry.Register(collector); err != nil {
+			return fmt.Errorf("unable to register kube-bench report metrics: %w", err)
+		}
 	}
 
 	if operatorConfig.ConfigAuditScannerBuiltIn {
@@ -241,25 +558,179 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 			ConfigData:     starboardConfig,
 			Client:         mgr.GetClient(),
 			ObjectResolver: objectResolver,
-			ReadWriter:     configauditreport.NewReadWriter(mgr.GetClient()),
+			ReadWriter:     configAuditReadWriter,
 			BuildInfo:      buildInfo,
+			Throttle:       requestThrottle,
 		}).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup resource controller: %w", err)
 		}
 	}
 
+	if operatorConfig.VulnerabilityScannerEnabled || operatorConfig.ConfigAuditScannerEnabled ||
+		operatorConfig.CISKubernetesBenchmarkEnabled {
+		if err = (&controller.ScanJobsSwitchReconciler{
+			Logger:         ctrl.Log.WithName("reconciler").WithName("scanjobsswitch"),
+			Config:         operatorConfig,
+			ConfigData:     starboardConfig,
+			Client:         mgr.GetClient(),
+			LimitChecker:   limitChecker,
+			ScanJobsSwitch: scanJobsSwitch,
+			Throttle:       requestThrottle,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup scanjobsswitch reconciler: %w", err)
+		}
+	}
+
+	if operatorConfig.SkipOwnerReference() {
+		setupLog.Info("Enabling label-based garbage collection of reports")
+		if err = (&controller.ReportGCReconciler{
+			Logger:         ctrl.Log.WithName("reconciler").WithName("reportgc"),
+			Config:         operatorConfig,
+			Client:         mgr.GetClient(),
+			ObjectResolver: objectResolver,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup reportgc reconciler: %w", err)
+		}
+	}
+
+	if operatorConfig.UntargetedNamespaceCleanup != etc.CleanupIgnore {
+		setupLog.Info("Enabling cleanup of reports in untargeted namespaces", "cleanup", operatorConfig.UntargetedNamespaceCleanup)
+		if err = (&controller.UntargetedNamespaceCleaner{
+			Logger: ctrl.Log.WithName("reconciler").WithName("untargetednamespacecleaner"),
+			Config: operatorConfig,
+			Client: mgr.GetClient(),
+			Reader: mgr.GetAPIReader(),
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup untargeted namespace cleaner: %w", err)
+		}
+	}
+
+	if operatorConfig.ImageInventoryEnabled {
+		if err = (&controller.ImageInventoryReconciler{
+			Logger:     ctrl.Log.WithName("reconciler").WithName("imageinventory"),
+			Config:     operatorConfig,
+			Client:     mgr.GetClient(),
+			Clock:      ext.NewSystemClock(),
+			ReadWriter: imageinventory.NewReadWriter(mgr.GetClient()),
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup imageinventory reconciler: %w", err)
+		}
+	}
+
+	if operatorConfig.VulnerabilityScannerEnabled || operatorConfig.ConfigAuditScannerEnabled || operatorConfig.ConfigAuditScannerBuiltIn {
+		collector := metrics.NewWorkloadReportCollector(ctrl.Log.WithName("metrics").WithName("workloadreport"),
+			mgr.GetClient(), operatorConfig, ext.NewSystemClock())
+		if err = ctrlmetrics.Registry.Register(collector); err != nil {
+			return fmt.Errorf("unable to register workload report metrics: %w", err)
+		}
+	}
+
+	if operatorConfig.ScanSummaryInterval > 0 {
+		setupLog.Info("Enabling scan summary", "interval", operatorConfig.ScanSummaryInterval)
+		if err = mgr.Add(&metrics.ScanSummaryWriter{
+			Logger:   ctrl.Log.WithName("metrics").WithName("scansummary"),
+			Client:   mgr.GetClient(),
+			Config:   operatorConfig,
+			Clock:    ext.NewSystemClock(),
+			Failures: recentScanFailures,
+		}); err != nil {
+			return fmt.Errorf("unable to setup scan summary: %w", err)
+		}
+	}
+
+	if operatorConfig.ClusterRiskReportInterval > 0 {
+		setupLog.Info("Enabling cluster risk report", "interval", operatorConfig.ClusterRiskReportInterval)
+		if err = mgr.Add(&controller.ClusterRiskReportWriter{
+			Logger: ctrl.Log.WithName("reconciler").WithName("clusterriskreport"),
+			Config: operatorConfig,
+			Client: mgr.GetClient(),
+			Clock:  ext.NewSystemClock(),
+		}); err != nil {
+			return fmt.Errorf("unable to setup cluster risk report: %w", err)
+		}
+		collector := metrics.NewClusterRiskReportCollector(ctrl.Log.WithName("metrics").WithName("clusterriskreport"), mgr.GetClient())
+		if err = ctrlmetrics.Registry.Register(collector); err != nil {
+			return fmt.Errorf("unable to register cluster risk report metrics: %w", err)
+		}
+	}
+
 	if operatorConfig.ClusterComplianceEnabled {
 		logger := ctrl.Log.WithName("reconciler").WithName("clustercompliancereport")
+		clusterMetadata := kube.NewClusterMetadataReader(kubeClientset, operatorConfig.ClusterName,
+			operatorConfig.ClusterMetadataTTL, ext.NewSystemClock())
 		cc := &compliance.ClusterComplianceReportReconciler{
 			Logger: logger,
 			Client: mgr.GetClient(),
-			Mgr:    compliance.NewMgr(mgr.GetClient(), logger, starboardConfig),
+			Mgr:    compliance.NewMgr(mgr.GetClient(), logger, starboardConfig, clusterMetadata, mgr.GetEventRecorderFor("starboard-operator")),
 			Clock:  ext.NewSystemClock(),
 		}
 		if err := cc.SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup clustercompliancereport reconciler: %w", err)
 		}
+		if err = (&controller.ComplianceSpecReconciler{
+			Logger:   ctrl.Log.WithName("reconciler").WithName("compliancespec"),
+			Config:   operatorConfig,
+			Client:   mgr.GetClient(),
+			Recorder: mgr.GetEventRecorderFor("starboard-operator"),
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup compliancespec reconciler: %w", err)
+		}
+		collector := metrics.NewComplianceReportCollector(ctrl.Log.WithName("metrics").WithName("compliancereport"), mgr.GetClient())
+		if err = ctrlmetrics.Registry.Register(collector); err != nil {
+			return fmt.Errorf("unable to register compliance report metrics: %w", err)
+		}
 	}
+	if operatorConfig.ScanJobUnschedulableGracePeriod > 0 {
+		if err = (&controller.UnschedulableScanPodReconciler{
+			Logger:         ctrl.Log.WithName("reconciler").WithName("unschedulablescanpod"),
+			Config:         operatorConfig,
+			Client:         mgr.GetClient(),
+			ObjectResolver: objectResolver,
+			Recorder:       mgr.GetEventRecorderFor("starboard-operator"),
+			Clock:          ext.NewSystemClock(),
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup unschedulable scan pod reconciler: %w", err)
+		}
+	}
+
+	if len(requeuedResources) > 0 {
+		if err = (&controller.StuckScanJobReconciler{
+			Logger:         ctrl.Log.WithName("reconciler").WithName("stuckscanjob"),
+			Config:         operatorConfig,
+			Client:         mgr.GetClient(),
+			ObjectResolver: objectResolver,
+			Clock:          ext.NewSystemClock(),
+			Destinations:   requeuedResources,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup stuck scan job reconciler: %w", err)
+		}
+	}
+
+	if len(resumedJobs) > 0 {
+		if err = mgr.Add(&controller.ScanJobResumer{
+			Logger:       ctrl.Log.WithName("reconciler").WithName("scanjobresumer"),
+			Reader:       mgr.GetAPIReader(),
+			Destinations: resumedJobs,
+		}); err != nil {
+			return fmt.Errorf("unable to setup scan job resumer: %w", err)
+		}
+	}
+
+	if operatorConfig.MetricsNamespaceScoped {
+		allowedNamespaces := operatorConfig.GetMetricsAllowedNamespaces()
+		setupLog.Info("Serving metrics of namespaces", "path", metrics.NamespacedPath,
+			"allowedNamespaces", allowedNamespaces)
+		err = mgr.AddMetricsExtraHandler(metrics.NamespacedPath,
+			metrics.NewNamespacedHandler(ctrlmetrics.Registry, allowedNamespaces))
+		if err != nil {
+			return fmt.Errorf("unable to serve namespaced metrics: %w", err)
+		}
+		// The manager serves /metrics from ctrlmetrics.Registry when it's
+		// started, so that metrics of namespaces which are not allowed are
+		// omitted there as well.
+		ctrlmetrics.Registry = metrics.NewAllowedNamespacesRegistry(ctrlmetrics.Registry, allowedNamespaces)
+	}
+
 	setupLog.Info("Starting controllers manager")
 	if err := mgr.Start(ctx); err != nil {
 		return fmt.Errorf("starting controllers manager: %w", err)
@@ -267,3 +738,43 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 
 	return nil
 }
+
+// listAllPackages returns true if the vulnerability scanner is enabled, and
+// it is Trivy configured to list all packages.
+func listAllPackages(c client.Client, namespace string, operatorConfig etc.Config, config starboard.ConfigData) (bool, error) {
+	if !operatorConfig.VulnerabilityScannerEnabled {
+		return false, nil
+	}
+	_, pluginContext, err := plugin.NewResolver().
+		WithNamespace(namespace).
+		WithConfig(config).
+		WithClient(c).
+		GetVulnerabilityPlugin()
+	if err != nil {
+		return false, err
+	}
+	if pluginContext.GetName() != trivy.Plugin {
+		return false, nil
+	}
+	pluginConfig, err := pluginContext.GetConfig()
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("getting %s plugin config: %w", trivy.Plugin, err)
+	}
+	return trivy.Config{PluginConfig: pluginConfig}.ListAllPackages(), nil
+}
+
+// featureCRDs maps features of the operator to names of CRDs of reports
+// written by their controllers, which are verified before controllers start.
+var featureCRDs = map[permissions.Feature][]string{
+	permissions.FeatureVulnerabilityScanner:   crd.VulnerabilityReports,
+	permissions.FeatureWorkloadSummary:        crd.WorkloadVulnerabilitySummaries,
+	permissions.FeatureConfigAuditScanner:     crd.ConfigAuditReports,
+	permissions.FeatureConfigAuditBuiltIn:     crd.ConfigAuditReports,
+	permissions.FeatureCISKubernetesBenchmark: crd.CISKubeBenchReports,
+	permissions.FeatureImageInventory:         crd.ImageInventories,
+	permissions.FeatureClusterRiskReport:      crd.ClusterRiskReports,
+	permissions.FeatureClusterCompliance:      crd.ClusterComplianceReports,
+}
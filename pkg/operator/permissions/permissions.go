@@ -0,0 +1,399 @@
+// Package permissions provides primitives for checking, before controllers
+// are started, that the operator is granted the permissions required by each
+// enabled feature, so that missing permissions are reported at once rather
+// than as Forbidden errors of each reconciliation.
+package permissions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Feature is a feature of the operator, which is enabled by its configuration
+// and implemented by one or more controllers.
+type Feature string
+
+const (
+	FeatureVulnerabilityScanner   Feature = "vulnerability-scanner"
+	FeatureWorkloadSummary        Feature = "workload-vulnerability-summary"
+	FeatureConfigAuditScanner     Feature = "config-audit-scanner"
+	FeatureConfigAuditBuiltIn     Feature = "config-audit-builtin"
+	FeatureCISKubernetesBenchmark Feature = "cis-kubernetes-benchmark"
+	FeatureImageInventory         Feature = "image-inventory"
+	FeatureClusterRiskReport      Feature = "cluster-risk-report"
+	FeatureClusterCompliance      Feature = "cluster-compliance"
+)
+
+// Features returns all features in the order they're checked.
+func Features() []Feature {
+	return []Feature{
+		FeatureVulnerabilityScanner,
+		FeatureWorkloadSummary,
+		FeatureConfigAuditScanner,
+		FeatureConfigAuditBuiltIn,
+		FeatureCISKubernetesBenchmark,
+		FeatureImageInventory,
+		FeatureClusterRiskReport,
+		FeatureClusterCompliance,
+	}
+}
+
+// EnabledFeatures returns features enabled by the specified configuration.
+func EnabledFeatures(config etc.Config) []Feature {
+	var features []Feature
+	for _, feature := range Features() {
+		if enabled(config, feature) {
+			features = append(features, feature)
+		}
+	}
+	return features
+}
+
+func enabled(config etc.Config, feature Feature) bool {
+	switch feature {
+	case FeatureVulnerabilityScanner:
+		return config.VulnerabilityScannerEnabled
+	case FeatureWorkloadSummary:
+		return config.VulnerabilityScannerEnabled && config.VulnerabilityScannerWorkloadSummaryEnabled
+	case FeatureConfigAuditScanner:
+		return config.ConfigAuditScannerEnabled
+	case FeatureConfigAuditBuiltIn:
+		return config.ConfigAuditScannerBuiltIn
+	case FeatureCISKubernetesBenchmark:
+		return config.CISKubernetesBenchmarkEnabled
+	case FeatureImageInventory:
+		return config.ImageInventoryEnabled
+	case FeatureClusterRiskReport:
+		return config.ClusterRiskReportInterval > 0
+	case FeatureClusterCompliance:
+		return config.ClusterComplianceEnabled
+	}
+	return false
+}
+
+// Disable disables the specified features in the given configuration, so that
+// their controllers are not started.
+func Disable(config *etc.Config, features ...Feature) {
+	for _, feature := range features {
+		switch feature {
+		case FeatureVulnerabilityScanner:
+			config.VulnerabilityScannerEnabled = false
+		case FeatureWorkloadSummary:
+			config.VulnerabilityScannerWorkloadSummaryEnabled = false
+		case FeatureConfigAuditScanner:
+			config.ConfigAuditScannerEnabled = false
+		case FeatureConfigAuditBuiltIn:
+			config.ConfigAuditScannerBuiltIn = false
+		case FeatureCISKubernetesBenchmark:
+			config.CISKubernetesBenchmarkEnabled = false
+		case FeatureImageInventory:
+			config.ImageInventoryEnabled = false
+		case FeatureClusterRiskReport:
+			config.ClusterRiskReportInterval = 0
+		case FeatureClusterCompliance:
+			config.ClusterComplianceEnabled = false
+		}
+	}
+}
+
+// Permission is a verb on a resource in a namespace, or cluster-wide if the
+// namespace is blank.
+type Permission struct {
+	Namespace   string
+	Verb        string
+	Group       string
+	Resource    string
+	Subresource string
+}
+
+func (p Permission) String() string {
+	resource := p.Resource
+	if p.Subresource != "" {
+		resource += "/" + p.Subresource
+	}
+	if p.Group != "" {
+		resource += "." + p.Group
+	}
+	return p.Verb + " " + resource
+}
+
+// Requirement is a permission required by a feature.
+type Requirement struct {
+	Feature    Feature
+	Permission Permission
+}
+
+// Namespaces holds namespaces where the operator runs scan jobs and scans
+// resources. Blank TargetNamespaces stand for all namespaces.
+type Namespaces struct {
+	Operator string
+	Targets  []string
+}
+
+// rule is a set of verbs on resources of a group, which are required in
+// target namespaces, or in the operator namespace if operatorNamespace is
+// true, or cluster-wide if clusterScoped is true.
+type rule struct {
+	group             string
+	resources         []string
+	verbs             []string
+	operatorNamespace bool
+	clusterScoped     bool
+}
+
+var (
+	readVerbs  = []string{"get", "list", "watch"}
+	writeVerbs = []string{"get", "list", "watch", "create", "update", "delete"}
+
+	workloadRules = []rule{
+		{group: "", resources: []string{"pods", "replicationcontrollers"}, verbs: readVerbs},
+		{group: "apps", resources: []string{"replicasets", "statefulsets", "daemonsets", "deployments"}, verbs: readVerbs},
+		{group: "batch", resources: []string{"jobs", "cronjobs"}, verbs: readVerbs},
+	}
+	configAuditResourceRules = []rule{
+		{group: "", resources: []string{"services", "configmaps", "resourcequotas", "limitranges"}, verbs: readVerbs},
+		{group: "rbac.authorization.k8s.io", resources: []string{"roles", "rolebindings"}, verbs: readVerbs},
+		{group: "networking.k8s.io", resources: []string{"networkpolicies", "ingresses"}, verbs: readVerbs},
+		{group: "aquasecurity.github.io", resources: []string{"configauditreports"}, verbs: writeVerbs},
+		{group: "rbac.authorization.k8s.io", resources: []string{"clusterroles", "clusterrolebindings"}, verbs: readVerbs, clusterScoped: true},
+		{group: "aquasecurity.github.io", resources: []string{"clusterconfigauditreports"}, verbs: writeVerbs, clusterScoped: true},
+	}
+	scanJobRules = []rule{
+		{group: "batch", resources: []string{"jobs"}, verbs: []string{"get", "list", "watch", "create", "delete"}, operatorNamespace: true},
+		{group: "", resources: []string{"pods"}, verbs: readVerbs, operatorNamespace: true},
+		{group: "", resources: []string{"pods/log"}, verbs: []string{"get"}, operatorNamespace: true},
+		{group: "", resources: []string{"secrets"}, verbs: []string{"get", "create", "delete"}, operatorNamespace: true},
+		{group: "", resources: []string{"events"}, verbs: []string{"create"}, operatorNamespace: true},
+	}
+
+	featureRules = map[Feature][][]rule{
+		FeatureVulnerabilityScanner: {workloadRules, scanJobRules, {
+			{group: "", resources: []string{"secrets", "serviceaccounts"}, verbs: []string{"get"}},
+			{group: "aquasecurity.github.io", resources: []string{"vulnerabilityreports", "packageinventories"}, verbs: writeVerbs},
+		}},
+		FeatureWorkloadSummary: {{
+			{group: "aquasecurity.github.io", resources: []string{"workloadvulnerabilitysummaries"}, verbs: writeVerbs},
+		}},
+		FeatureConfigAuditScanner: {workloadRules, configAuditResourceRules, scanJobRules},
+		FeatureConfigAuditBuiltIn: {workloadRules, configAuditResourceRules},
+		FeatureCISKubernetesBenchmark: {scanJobRules, {
+			{group: "", resources: []string{"nodes"}, verbs: readVerbs, clusterScoped: true},
+			{group: "aquasecurity.github.io", resources: []string{"ciskubebenchreports"}, verbs: writeVerbs, clusterScoped: true},
+		}},
+		FeatureImageInventory: {{
+			{group: "", resources: []string{"pods"}, verbs: readVerbs},
+			{group: "aquasecurity.github.io", resources: []string{"imageinventories"}, verbs: writeVerbs, clusterScoped: true},
+		}},
+		FeatureClusterRiskReport: {{
+			{group: "aquasecurity.github.io", resources: []string{"vulnerabilityreports", "configauditreports"}, verbs: []string{"list"}},
+			{group: "aquasecurity.github.io", resources: []string{"clusterriskreports"}, verbs: writeVerbs, clusterScoped: true},
+		}},
+		FeatureClusterCompliance: {{
+			{group: "", resources: []string{"configmaps"}, verbs: readVerbs, operatorNamespace: true},
+			{group: "aquasecurity.github.io", resources: []string{"configauditreports"}, verbs: []string{"list"}},
+			{group: "aquasecurity.github.io", resources: []string{"compliancereports"}, verbs: writeVerbs},
+			{group: "aquasecurity.github.io", resources: []string{"ciskubebenchreports", "kubehunterreports"}, verbs: []string{"list"}, clusterScoped: true},
+			{group: "aquasecurity.github.io", resources: []string{"clustercompliancereports", "clustercompliancedetailreports"}, verbs: writeVerbs, clusterScoped: true},
+			{group: "aquasecurity.github.io", resources: []string{"clustercompliancereports/status"}, verbs: []string{"update"}, clusterScoped: true},
+		}},
+	}
+)
+
+// Required returns permissions required by the specified features. Permissions
+// on resources which are scanned are required in each target namespace, and
+// permissions on scan jobs in the operator namespace.
+func Required(namespaces Namespaces, features ...Feature) []Requirement {
+	targets := namespaces.Targets
+	if len(targets) == 0 {
+		targets = []string{""}
+	}
+	var requirements []Requirement
+	for _, feature := range features {
+		seen := make(map[Permission]bool)
+		for _, rules := range featureRules[feature] {
+			for _, r := range rules {
+				scope := targets
+				if r.operatorNamespace {
+					scope = []string{namespaces.Operator}
+				}
+				if r.clusterScoped {
+					scope = []string{""}
+				}
+				for _, namespace := range scope {
+					for _, resource := range r.resources {
+						for _, verb := range r.verbs {
+							permission := newPermission(namespace, verb, r.group, resource)
+							if seen[permission] {
+								continue
+							}
+							seen[permission] = true
+							requirements = append(requirements, Requirement{Feature: feature, Permission: permission})
+						}
+					}
+				}
+			}
+		}
+	}
+	return requirements
+}
+
+func newPermission(namespace, verb, group, resource string) Permission {
+	permission := Permission{Namespace: namespace, Verb: verb, Group: group, Resource: resource}
+	if i := strings.Index(resource, "/"); i >= 0 {
+		permission.Resource = resource[:i]
+		permission.Subresource = resource[i+1:]
+	}
+	return permission
+}
+
+// Reviewer reviews whether a permission is granted.
+type Reviewer interface {
+	Allowed(ctx context.Context, permission Permission) (bool, error)
+}
+
+type selfReviewer struct {
+	clientset kubernetes.Interface
+}
+
+// NewSelfReviewer constructs a Reviewer which reviews permissions of the
+// current user, e.g. the service account of the operator, with
+// SelfSubjectAccessReviews.
+func NewSelfReviewer(clientset kubernetes.Interface) Reviewer {
+	return &selfReviewer{clientset: clientset}
+}
+
+func (r *selfReviewer) Allowed(ctx context.Context, permission Permission) (bool, error) {
+	review, err := r.clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: resourceAttributes(permission),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("reviewing permission to %s: %w", permission, err)
+	}
+	return review.Status.Allowed, nil
+}
+
+type serviceAccountReviewer struct {
+	clientset kubernetes.Interface
+	namespace string
+	name      string
+}
+
+// NewServiceAccountReviewer constructs a Reviewer which reviews permissions
+// of the specified service account with SubjectAccessReviews.
+func NewServiceAccountReviewer(clientset kubernetes.Interface, namespace, name string) Reviewer {
+	return &serviceAccountReviewer{clientset: clientset, namespace: namespace, name: name}
+}
+
+func (r *serviceAccountReviewer) Allowed(ctx context.Context, permission Permission) (bool, error) {
+	review, err := r.clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User: fmt.Sprintf("system:serviceaccount:%s:%s", r.namespace, r.name),
+			Groups: []string{
+				"system:serviceaccounts",
+				"system:serviceaccounts:" + r.namespace,
+				"system:authenticated",
+			},
+			ResourceAttributes: resourceAttributes(permission),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("reviewing permission to %s: %w", permission, err)
+	}
+	return review.Status.Allowed, nil
+}
+
+func resourceAttributes(permission Permission) *authorizationv1.ResourceAttributes {
+	return &authorizationv1.ResourceAttributes{
+		Namespace:   permission.Namespace,
+		Verb:        permission.Verb,
+		Group:       permission.Group,
+		Resource:    permission.Resource,
+		Subresource: permission.Subresource,
+	}
+}
+
+// Check reviews the specified requirements and returns the ones which are
+// not granted.
+func Check(ctx context.Context, reviewer Reviewer, requirements []Requirement) ([]Requirement, error) {
+	allowed := make(map[Permission]bool)
+	var missing []Requirement
+	for _, requirement := range requirements {
+		granted, reviewed := allowed[requirement.Permission]
+		if !reviewed {
+			var err error
+			granted, err = reviewer.Allowed(ctx, requirement.Permission)
+			if err != nil {
+				return nil, err
+			}
+			allowed[requirement.Permission] = granted
+		}
+		if !granted {
+			missing = append(missing, requirement)
+		}
+	}
+	return missing, nil
+}
+
+// MissingFeatures returns features of the specified missing requirements.
+func MissingFeatures(missing []Requirement) []Feature {
+	var features []Feature
+	seen := make(map[Feature]bool)
+	for _, requirement := range missing {
+		if !seen[requirement.Feature] {
+			seen[requirement.Feature] = true
+			features = append(features, requirement.Feature)
+		}
+	}
+	return features
+}
+
+// WriteTable writes the specified missing requirements as a table.
+func WriteTable(out io.Writer, missing []Requirement) error {
+	rows := append([]Requirement{}, missing...)
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].Feature < rows[j].Feature
+	})
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "FEATURE\tNAMESPACE\tVERB\tRESOURCE")
+	for _, requirement := range rows {
+		p := requirement.Permission
+		namespace := p.Namespace
+		if namespace == "" {
+			namespace = "*"
+		}
+		resource := p.Resource
+		if p.Subresource != "" {
+			resource += "/" + p.Subresource
+		}
+		if p.Group != "" {
+			resource += "." + p.Group
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", requirement.Feature, namespace, p.Verb, resource)
+	}
+	return w.Flush()
+}
+
+// Summary returns a one-line summary of the specified missing requirements,
+// e.g. for a readiness check.
+func Summary(missing []Requirement) string {
+	var permissions []string
+	for _, requirement := range missing {
+		permission := requirement.Permission.String()
+		if requirement.Permission.Namespace != "" {
+			permission += " in " + requirement.Permission.Namespace
+		}
+		permissions = append(permissions, fmt.Sprintf("%s: %s", requirement.Feature, permission))
+	}
+	return strings.Join(permissions, "; ")
+}
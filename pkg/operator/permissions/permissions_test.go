@@ -0,0 +1,216 @@
+package permissions_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/permissions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// deny returns a reactor which denies access to the specified resources and
+// allows access to any other resource.
+func deny(resources ...string) k8stesting.ReactionFunc {
+	denied := make(map[string]bool)
+	for _, resource := range resources {
+		denied[resource] = true
+	}
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		switch review := action.(k8stesting.CreateAction).GetObject().(type) {
+		case *authorizationv1.SelfSubjectAccessReview:
+			review.Status.Allowed = !denied[review.Spec.ResourceAttributes.Resource]
+			return true, review, nil
+		case *authorizationv1.SubjectAccessReview:
+			review.Status.Allowed = review.Spec.User == "system:serviceaccount:starboard-system:starboard-operator" &&
+				!denied[review.Spec.ResourceAttributes.Resource]
+			return true, review, nil
+		}
+		return false, nil, nil
+	}
+}
+
+func TestEnabledFeatures(t *testing.T) {
+	features := permissions.EnabledFeatures(etc.Config{
+		VulnerabilityScannerEnabled: true,
+		ConfigAuditScannerBuiltIn:   true,
+	})
+	assert.Equal(t, []permissions.Feature{
+		permissions.FeatureVulnerabilityScanner,
+		permissions.FeatureConfigAuditBuiltIn,
+	}, features)
+}
+
+func TestDisable(t *testing.T) {
+	config := etc.Config{
+		VulnerabilityScannerEnabled:   true,
+		CISKubernetesBenchmarkEnabled: true,
+		ClusterComplianceEnabled:      true,
+	}
+	permissions.Disable(&config, permissions.FeatureCISKubernetesBenchmark, permissions.FeatureClusterCompliance)
+	assert.Equal(t, []permissions.Feature{permissions.FeatureVulnerabilityScanner}, permissions.EnabledFeatures(config))
+}
+
+func TestRequired(t *testing.T) {
+	t.Run("Should require permissions on scanned resources in each target namespace", func(t *testing.T) {
+		requirements := permissions.Required(permissions.Namespaces{
+			Operator: "starboard-system",
+			Targets:  []string{"default", "qa"},
+		}, permissions.FeatureVulnerabilityScanner)
+
+		assert.Contains(t, requirements, permissions.Requirement{
+			Feature:    permissions.FeatureVulnerabilityScanner,
+			Permission: permissions.Permission{Namespace: "default", Verb: "watch", Group: "apps", Resource: "deployments"},
+		})
+		assert.Contains(t, requirements, permissions.Requirement{
+			Feature:    permissions.FeatureVulnerabilityScanner,
+			Permission: permissions.Permission{Namespace: "qa", Verb: "create", Group: "aquasecurity.github.io", Resource: "vulnerabilityreports"},
+		})
+		assert.Contains(t, requirements, permissions.Requirement{
+			Feature:    permissions.FeatureVulnerabilityScanner,
+			Permission: permissions.Permission{Namespace: "starboard-system", Verb: "get", Resource: "pods", Subresource: "log"},
+		})
+		assert.NotContains(t, requirements, permissions.Requirement{
+			Feature:    permissions.FeatureVulnerabilityScanner,
+			Permission: permissions.Permission{Namespace: "default", Verb: "create", Group: "batch", Resource: "jobs"},
+		})
+	})
+
+	t.Run("Should require permissions cluster-wide without target namespaces", func(t *testing.T) {
+		requirements := permissions.Required(permissions.Namespaces{Operator: "starboard-system"},
+			permissions.FeatureCISKubernetesBenchmark, permissions.FeatureImageInventory)
+
+		assert.Contains(t, requirements, permissions.Requirement{
+			Feature:    permissions.FeatureCISKubernetesBenchmark,
+			Permission: permissions.Permission{Verb: "list", Resource: "nodes"},
+		})
+		assert.Contains(t, requirements, permissions.Requirement{
+			Feature:    permissions.FeatureImageInventory,
+			Permission: permissions.Permission{Verb: "watch", Resource: "pods"},
+		})
+	})
+}
+
+func TestCheck(t *testing.T) {
+	namespaces := permissions.Namespaces{Operator: "starboard-system"}
+
+	t.Run("Should return nothing when all permissions are granted", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset()
+		clientset.PrependReactor("create", "selfsubjectaccessreviews", deny())
+
+		missing, err := permissions.Check(context.TODO(), permissions.NewSelfReviewer(clientset),
+			permissions.Required(namespaces, permissions.Features()...))
+		require.NoError(t, err)
+		assert.Empty(t, missing)
+	})
+
+	t.Run("Should return missing permissions of features", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset()
+		clientset.PrependReactor("create", "selfsubjectaccessreviews", deny("nodes"))
+
+		missing, err := permissions.Check(context.TODO(), permissions.NewSelfReviewer(clientset),
+			permissions.Required(namespaces, permissions.FeatureVulnerabilityScanner, permissions.FeatureCISKubernetesBenchmark))
+		require.NoError(t, err)
+		assert.Equal(t, []permissions.Feature{permissions.FeatureCISKubernetesBenchmark}, permissions.MissingFeatures(missing))
+		assert.Len(t, missing, 3)
+		assert.Equal(t, "cis-kubernetes-benchmark: get nodes; cis-kubernetes-benchmark: list nodes; cis-kubernetes-benchmark: watch nodes",
+			permissions.Summary(missing))
+
+		out := &bytes.Buffer{}
+		require.NoError(t, permissions.WriteTable(out, missing))
+		assert.Equal(t, `FEATURE                   NAMESPACE  VERB   RESOURCE
+cis-kubernetes-benchmark  *          get    nodes
+cis-kubernetes-benchmark  *          list   nodes
+cis-kubernetes-benchmark  *          watch  nodes
+`, out.String())
+	})
+
+	t.Run("Should review permissions of service account", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset()
+		clientset.PrependReactor("create", "subjectaccessreviews", deny("secrets"))
+
+		missing, err := permissions.Check(context.TODO(),
+			permissions.NewServiceAccountReviewer(clientset, "starboard-system", "starboard-operator"),
+			permissions.Required(namespaces, permissions.FeatureVulnerabilityScanner))
+		require.NoError(t, err)
+		require.NotEmpty(t, missing)
+		for _, requirement := range missing {
+			assert.Equal(t, "secrets", requirement.Permission.Resource)
+		}
+
+		missing, err = permissions.Check(context.TODO(),
+			permissions.NewServiceAccountReviewer(clientset, "starboard-system", "default"),
+			permissions.Required(namespaces, permissions.FeatureImageInventory))
+		require.NoError(t, err)
+		assert.Len(t, missing, 9)
+	})
+}
+
+// clusterRoleReviewer reviews permissions granted by rules of a ClusterRole.
+type clusterRoleReviewer struct {
+	rules []rbacv1.PolicyRule
+}
+
+func (r *clusterRoleReviewer) Allowed(_ context.Context, permission permissions.Permission) (bool, error) {
+	resource := permission.Resource
+	if permission.Subresource != "" {
+		resource += "/" + permission.Subresource
+	}
+	contains := func(values []string, value string) bool {
+		for _, v := range values {
+			if v == value || v == rbacv1.ResourceAll {
+				return true
+			}
+		}
+		return false
+	}
+	for _, rule := range r.rules {
+		if contains(rule.APIGroups, permission.Group) && contains(rule.Resources, resource) && contains(rule.Verbs, permission.Verb) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// readClusterRole reads the ClusterRole with the specified name from the
+// specified multi-document YAML manifest.
+func readClusterRole(t *testing.T, path, name string) rbacv1.ClusterRole {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	decoder := yaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		var role rbacv1.ClusterRole
+		err := decoder.Decode(&role)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		if role.Kind == "ClusterRole" && role.Name == name {
+			return role
+		}
+	}
+	t.Fatalf("ClusterRole %s not found in %s", name, path)
+	return rbacv1.ClusterRole{}
+}
+
+func TestStaticManifest(t *testing.T) {
+	role := readClusterRole(t, "../../../deploy/static/02-starboard-operator.rbac.yaml", "starboard-operator")
+
+	missing, err := permissions.Check(context.TODO(), &clusterRoleReviewer{rules: role.Rules},
+		permissions.Required(permissions.Namespaces{Operator: "starboard-system"}, permissions.Features()...))
+	require.NoError(t, err)
+	assert.Empty(t, permissions.Summary(missing), "permissions required by the operator must be granted by the ClusterRole")
+}
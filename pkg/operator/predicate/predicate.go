@@ -2,9 +2,11 @@ package predicate
 
 import (
 	"path/filepath"
+	"reflect"
 	"strings"
 
 	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	batchv1 "k8s.io/api/batch/v1"
@@ -77,6 +79,16 @@ var InNamespace = func(namespace string) predicate.Predicate {
 	})
 }
 
+// HasSameType is a predicate.Predicate that returns true if the specified
+// client.Object has the same Go type as the desired object. It routes events
+// sent to channels shared by controllers of different kinds.
+var HasSameType = func(obj client.Object) predicate.Predicate {
+	t := reflect.TypeOf(obj)
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return t == reflect.TypeOf(obj)
+	})
+}
+
 // ManagedByStarboardOperator is a predicate.Predicate that returns true if the
 // specified client.Object is managed by Starboard.
 //
@@ -96,10 +108,12 @@ var IsBeingTerminated = predicate.NewPredicateFuncs(func(obj client.Object) bool
 })
 
 // JobHasAnyCondition is a predicate.Predicate that returns true if the
-// specified client.Object is a v1.Job with any v1.JobConditionType.
+// specified client.Object is a v1.Job with any v1.JobConditionType other than
+// v1.JobSuspended, i.e. the job is complete or failed.
 var JobHasAnyCondition = predicate.NewPredicateFuncs(func(obj client.Object) bool {
 	if job, ok := obj.(*batchv1.Job); ok {
-		return len(job.Status.Conditions) > 0
+		_, finished := kube.JobFinishedCondition(job)
+		return finished
 	}
 	return false
 })
@@ -125,6 +139,38 @@ var IsKubeBenchReportScan = predicate.NewPredicateFuncs(func(obj client.Object)
 	return false
 })
 
+// IsScanJobSecret is a predicate.Predicate that returns true if the specified
+// client.Object is a Secret created for a scan job.
+var IsScanJobSecret = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	if _, ok := obj.GetLabels()[starboard.LabelScanJobName]; ok {
+		return true
+	}
+	return false
+})
+
+// IsComplianceSpec is a predicate.Predicate that returns true if the specified
+// client.Object is labeled as a custom compliance spec. Updates are accepted
+// if either the old or the new object is labeled, so that removal of the label
+// is handled as well.
+var IsComplianceSpec = predicate.Funcs{
+	CreateFunc: func(event event.CreateEvent) bool {
+		return isComplianceSpec(event.Object)
+	},
+	DeleteFunc: func(event event.DeleteEvent) bool {
+		return isComplianceSpec(event.Object)
+	},
+	UpdateFunc: func(event event.UpdateEvent) bool {
+		return isComplianceSpec(event.ObjectOld) || isComplianceSpec(event.ObjectNew)
+	},
+	GenericFunc: func(event event.GenericEvent) bool {
+		return isComplianceSpec(event.Object)
+	},
+}
+
+func isComplianceSpec(obj client.Object) bool {
+	return obj.GetLabels()[starboard.LabelComplianceSpec] == "true"
+}
+
 var IsLinuxNode = predicate.NewPredicateFuncs(func(obj client.Object) bool {
 	if os, exists := obj.GetLabels()[corev1.LabelOSStable]; exists && os == "linux" {
 		return true
@@ -132,6 +178,24 @@ var IsLinuxNode = predicate.NewPredicateFuncs(func(obj client.Object) bool {
 	return false
 })
 
+// PodIsUnschedulable is a predicate.Predicate that returns true if the
+// specified client.Object is a pending v1.Pod which the scheduler failed to
+// place on any node.
+var PodIsUnschedulable = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok || pod.Status.Phase != corev1.PodPending {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled &&
+			condition.Status == corev1.ConditionFalse &&
+			condition.Reason == corev1.PodReasonUnschedulable {
+			return true
+		}
+	}
+	return false
+})
+
 // IsLeaderElectionResource returns true for resources used in leader election, means resources
 // annotated with resourcelock.LeaderElectionRecordAnnotationKey.
 var IsLeaderElectionResource = predicate.NewPredicateFuncs(func(obj client.Object) bool {
@@ -243,6 +243,32 @@ var _ = Describe("Predicate", func() {
 		})
 	})
 
+	Describe("When checking a HasSameType predicate", func() {
+		instance := predicate.HasSameType(&corev1.Pod{})
+
+		Context("When object has desired type", func() {
+			It("Should return true", func() {
+				obj := &corev1.Pod{}
+
+				Expect(instance.Create(event.CreateEvent{Object: obj})).To(BeTrue())
+				Expect(instance.Update(event.UpdateEvent{ObjectNew: obj})).To(BeTrue())
+				Expect(instance.Delete(event.DeleteEvent{Object: obj})).To(BeTrue())
+				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeTrue())
+			})
+		})
+
+		Context("When object has another type", func() {
+			It("Should return false", func() {
+				obj := &corev1.Node{}
+
+				Expect(instance.Create(event.CreateEvent{Object: obj})).To(BeFalse())
+				Expect(instance.Update(event.UpdateEvent{ObjectNew: obj})).To(BeFalse())
+				Expect(instance.Delete(event.DeleteEvent{Object: obj})).To(BeFalse())
+				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("When checking a ManagedByStarboardOperator predicate", func() {
 		instance := predicate.ManagedByStarboardOperator
 
@@ -365,6 +391,103 @@ var _ = Describe("Predicate", func() {
 				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeFalse())
 			})
 		})
+		Context("Where job has only suspended condition", func() {
+			It("Should return false", func() {
+				obj := &batchv1.Job{
+					Status: batchv1.JobStatus{
+						Conditions: []batchv1.JobCondition{
+							{
+								Type:   batchv1.JobSuspended,
+								Status: corev1.ConditionFalse,
+							},
+						},
+					},
+				}
+
+				Expect(instance.Create(event.CreateEvent{Object: obj})).To(BeFalse())
+				Expect(instance.Update(event.UpdateEvent{ObjectNew: obj})).To(BeFalse())
+				Expect(instance.Delete(event.DeleteEvent{Object: obj})).To(BeFalse())
+				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("When checking a PodIsUnschedulable predicate", func() {
+		instance := predicate.PodIsUnschedulable
+		Context("Where pod is pending and unschedulable", func() {
+			It("Should return true", func() {
+				obj := &corev1.Pod{
+					Status: corev1.PodStatus{
+						Phase: corev1.PodPending,
+						Conditions: []corev1.PodCondition{
+							{
+								Type:   corev1.PodScheduled,
+								Status: corev1.ConditionFalse,
+								Reason: corev1.PodReasonUnschedulable,
+							},
+						},
+					},
+				}
+
+				Expect(instance.Create(event.CreateEvent{Object: obj})).To(BeTrue())
+				Expect(instance.Update(event.UpdateEvent{ObjectNew: obj})).To(BeTrue())
+				Expect(instance.Delete(event.DeleteEvent{Object: obj})).To(BeTrue())
+				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeTrue())
+			})
+		})
+		Context("Where pod is scheduled", func() {
+			It("Should return false", func() {
+				obj := &corev1.Pod{
+					Status: corev1.PodStatus{
+						Phase: corev1.PodPending,
+						Conditions: []corev1.PodCondition{
+							{
+								Type:   corev1.PodScheduled,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				}
+
+				Expect(instance.Create(event.CreateEvent{Object: obj})).To(BeFalse())
+				Expect(instance.Update(event.UpdateEvent{ObjectNew: obj})).To(BeFalse())
+				Expect(instance.Delete(event.DeleteEvent{Object: obj})).To(BeFalse())
+				Expect(instance.Generic(event.GenericEvent{Object: obj})).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("When checking a IsComplianceSpec predicate", func() {
+		instance := predicate.IsComplianceSpec
+		spec := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					"starboard.compliance.spec": "true",
+				},
+			},
+		}
+		other := &corev1.ConfigMap{}
+		Context("Where config map is labeled as compliance spec", func() {
+			It("Should return true", func() {
+				Expect(instance.Create(event.CreateEvent{Object: spec})).To(BeTrue())
+				Expect(instance.Update(event.UpdateEvent{ObjectOld: spec, ObjectNew: spec})).To(BeTrue())
+				Expect(instance.Delete(event.DeleteEvent{Object: spec})).To(BeTrue())
+				Expect(instance.Generic(event.GenericEvent{Object: spec})).To(BeTrue())
+			})
+		})
+		Context("Where label of config map is removed", func() {
+			It("Should return true", func() {
+				Expect(instance.Update(event.UpdateEvent{ObjectOld: spec, ObjectNew: other})).To(BeTrue())
+			})
+		})
+		Context("Where config map is not labeled as compliance spec", func() {
+			It("Should return false", func() {
+				Expect(instance.Create(event.CreateEvent{Object: other})).To(BeFalse())
+				Expect(instance.Update(event.UpdateEvent{ObjectOld: other, ObjectNew: other})).To(BeFalse())
+				Expect(instance.Delete(event.DeleteEvent{Object: other})).To(BeFalse())
+				Expect(instance.Generic(event.GenericEvent{Object: other})).To(BeFalse())
+			})
+		})
 	})
 
 	Describe("When checking a Not predicate", func() {
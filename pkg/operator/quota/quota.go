@@ -0,0 +1,231 @@
+// Package quota provides primitives for capping the number of security
+// reports per namespace.
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// ReasonReportsEvicted is the reason of the event recorded on a namespace
+	// when reports are evicted from it.
+	ReasonReportsEvicted = "ReportsEvicted"
+)
+
+var evictionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "starboard_operator_report_evictions_total",
+	Help: "Number of reports evicted to stay within the maximum number of reports per namespace.",
+}, []string{"namespace"})
+
+func init() {
+	metrics.Registry.MustRegister(evictionsTotal)
+}
+
+// Quota is the interface that wraps the Admit method.
+//
+// Admit makes room for a new report in the given namespace. It's called by
+// report writers before they create a report, but not when they update an
+// existing one.
+type Quota interface {
+	Admit(ctx context.Context, namespace string) error
+}
+
+// NamespaceQuota caps the total number of v1alpha1.VulnerabilityReport and
+// v1alpha1.ConfigAuditReport objects in a namespace. When a namespace holds
+// the maximum number of reports, the oldest reports are evicted, i.e. deleted,
+// to make room for new ones.
+//
+// Reports are counted with informers rather than listed on each write, so
+// that admitting a report is cheap. Since informers are eventually consistent,
+// the limit may be briefly exceeded by reports created concurrently.
+type NamespaceQuota struct {
+	logr.Logger
+	client.Client
+	Recorder record.EventRecorder
+
+	limit int
+
+	mu     sync.Mutex
+	counts map[string]int
+
+	// evictMu serializes evictions so that concurrent writers do not evict
+	// more reports than necessary.
+	evictMu sync.Mutex
+}
+
+// NewNamespaceQuota constructs a new NamespaceQuota with the specified
+// maximum number of reports per namespace.
+func NewNamespaceQuota(logger logr.Logger, client client.Client, recorder record.EventRecorder, limit int) *NamespaceQuota {
+	return &NamespaceQuota{
+		Logger:   logger,
+		Client:   client,
+		Recorder: recorder,
+		limit:    limit,
+		counts:   make(map[string]int),
+	}
+}
+
+// SetupWithManager counts reports with informers of the manager's cache.
+func (q *NamespaceQuota) SetupWithManager(mgr ctrl.Manager) error {
+	return q.Watch(context.Background(), mgr.GetCache())
+}
+
+// Watch counts reports with the given informers.
+func (q *NamespaceQuota) Watch(ctx context.Context, informers cache.Informers) error {
+	for _, object := range []client.Object{
+		&v1alpha1.VulnerabilityReport{},
+		&v1alpha1.ConfigAuditReport{},
+	} {
+		informer, err := informers.GetInformer(ctx, object)
+		if err != nil {
+			return fmt.Errorf("getting informer for %T: %w", object, err)
+		}
+		informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				q.count(obj, 1)
+			},
+			DeleteFunc: func(obj interface{}) {
+				q.count(obj, -1)
+			},
+		})
+	}
+	return nil
+}
+
+func (q *NamespaceQuota) count(obj interface{}, delta int) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	object, ok := obj.(client.Object)
+	if !ok {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.counts[object.GetNamespace()] += delta
+	if q.counts[object.GetNamespace()] <= 0 {
+		delete(q.counts, object.GetNamespace())
+	}
+}
+
+// Count returns the number of reports in the given namespace.
+func (q *NamespaceQuota) Count(namespace string) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.counts[namespace]
+}
+
+// Admit evicts the oldest reports in the given namespace if it already holds
+// the maximum number of reports. Protected reports are never evicted.
+func (q *NamespaceQuota) Admit(ctx context.Context, namespace string) error {
+	if q.Count(namespace) < q.limit {
+		return nil
+	}
+	q.evictMu.Lock()
+	defer q.evictMu.Unlock()
+	return q.evict(ctx, namespace)
+}
+
+func (q *NamespaceQuota) evict(ctx context.Context, namespace string) error {
+	var vulnerabilityReports v1alpha1.VulnerabilityReportList
+	err := q.Client.List(ctx, &vulnerabilityReports, client.InNamespace(namespace))
+	if err != nil {
+		return fmt.Errorf("listing vulnerability reports: %w", err)
+	}
+	var configAuditReports v1alpha1.ConfigAuditReportList
+	err = q.Client.List(ctx, &configAuditReports, client.InNamespace(namespace))
+	if err != nil {
+		return fmt.Errorf("listing config audit reports: %w", err)
+	}
+
+	// Protected reports count towards the quota, but are never evicted.
+	count := len(vulnerabilityReports.Items) + len(configAuditReports.Items)
+	reports := make([]client.Object, 0, count)
+	for i := range vulnerabilityReports.Items {
+		if !kube.IsProtected(&vulnerabilityReports.Items[i]) {
+			reports = append(reports, &vulnerabilityReports.Items[i])
+		}
+	}
+	for i := range configAuditReports.Items {
+		if !kube.IsProtected(&configAuditReports.Items[i]) {
+			reports = append(reports, &configAuditReports.Items[i])
+		}
+	}
+	// Listed reports include reports evicted by previous calls, which are not
+	// yet deleted from the cache.
+	excess := ext.MinInt(count-q.limit+1, len(reports))
+	if excess <= 0 {
+		return nil
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		ti, tj := reports[i].GetCreationTimestamp(), reports[j].GetCreationTimestamp()
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return reports[i].GetName() < reports[j].GetName()
+	})
+
+	evicted := 0
+	for _, report := range reports[:excess] {
+		deleted, err := q.delete(ctx, report)
+		if err != nil {
+			q.recordEvictions(namespace, evicted)
+			return err
+		}
+		if deleted {
+			evicted++
+		}
+	}
+	q.recordEvictions(namespace, evicted)
+	return nil
+}
+
+func (q *NamespaceQuota) delete(ctx context.Context, report client.Object) (bool, error) {
+	q.Logger.V(1).Info("Evicting report", "kind", fmt.Sprintf("%T", report), "report", client.ObjectKeyFromObject(report))
+	err := q.Client.Delete(ctx, report)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("evicting report %q: %w", client.ObjectKeyFromObject(report), err)
+	}
+	if _, ok := report.(*v1alpha1.VulnerabilityReport); ok {
+		err = q.Client.Delete(ctx, &v1alpha1.PackageInventory{ObjectMeta: metav1.ObjectMeta{
+			Name:      report.GetName(),
+			Namespace: report.GetNamespace(),
+		}})
+		if err != nil && !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return true, fmt.Errorf("deleting package inventory %q: %w", client.ObjectKeyFromObject(report), err)
+		}
+	}
+	return true, nil
+}
+
+func (q *NamespaceQuota) recordEvictions(namespace string, evicted int) {
+	if evicted == 0 {
+		return
+	}
+	evictionsTotal.WithLabelValues(namespace).Add(float64(evicted))
+	q.Logger.Info("Evicted reports", "namespace", namespace, "count", evicted, "limit", q.limit)
+	q.Recorder.Eventf(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}, corev1.EventTypeWarning, ReasonReportsEvicted,
+		"Evicted %d oldest report(s) to keep at most %d reports in namespace", evicted, q.limit)
+}
@@ -0,0 +1,165 @@
+package quota_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/quota"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var created = time.Date(2022, 4, 20, 10, 0, 0, 0, time.UTC)
+
+func newVulnerabilityReport(namespace string, age int) *v1alpha1.VulnerabilityReport {
+	return &v1alpha1.VulnerabilityReport{ObjectMeta: metav1.ObjectMeta{
+		Name:              fmt.Sprintf("replicaset-app-%d-app", age),
+		Namespace:         namespace,
+		CreationTimestamp: metav1.NewTime(created.Add(-time.Duration(age) * time.Hour)),
+	}}
+}
+
+func newConfigAuditReport(namespace string, age int) *v1alpha1.ConfigAuditReport {
+	return &v1alpha1.ConfigAuditReport{ObjectMeta: metav1.ObjectMeta{
+		Name:              fmt.Sprintf("replicaset-app-%d", age),
+		Namespace:         namespace,
+		CreationTimestamp: metav1.NewTime(created.Add(-time.Duration(age) * time.Hour)),
+	}}
+}
+
+// newQuota returns a NamespaceQuota which counts the given reports with fake
+// informers, as if they were listed by informers of the manager's cache.
+func newQuota(t *testing.T, limit int, reports ...client.Object) (*quota.NamespaceQuota, client.Client, *record.FakeRecorder) {
+	t.Helper()
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(reports...).Build()
+	recorder := record.NewFakeRecorder(10)
+	q := quota.NewNamespaceQuota(logr.Discard(), c, recorder, limit)
+
+	informers := &informertest.FakeInformers{Scheme: starboard.NewScheme()}
+	require.NoError(t, q.Watch(context.TODO(), informers))
+	for _, report := range reports {
+		informer, err := informers.FakeInformerFor(report)
+		require.NoError(t, err)
+		informer.Add(report)
+	}
+	return q, c, recorder
+}
+
+func TestNamespaceQuota_Admit(t *testing.T) {
+
+	t.Run("Should not evict reports when namespace is below limit", func(t *testing.T) {
+		q, c, recorder := newQuota(t, 3,
+			newVulnerabilityReport("default", 2),
+			newConfigAuditReport("default", 1),
+			newVulnerabilityReport("staging", 3),
+			newVulnerabilityReport("staging", 4),
+		)
+		assert.Equal(t, 2, q.Count("default"))
+		require.NoError(t, q.Admit(context.TODO(), "default"))
+
+		var list v1alpha1.VulnerabilityReportList
+		require.NoError(t, c.List(context.TODO(), &list))
+		assert.Len(t, list.Items, 3)
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("Should evict oldest reports when namespace is at limit", func(t *testing.T) {
+		evictionsBefore := evictionsTotal(t, "ci")
+		q, c, recorder := newQuota(t, 3,
+			newVulnerabilityReport("ci", 5),
+			newConfigAuditReport("ci", 4),
+			newVulnerabilityReport("ci", 3),
+			newConfigAuditReport("ci", 1),
+			newVulnerabilityReport("default", 10),
+			&v1alpha1.PackageInventory{ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-app-5-app",
+				Namespace: "ci",
+			}},
+		)
+		assert.Equal(t, 4, q.Count("ci"))
+		require.NoError(t, q.Admit(context.TODO(), "ci"))
+
+		var vulnerabilityReports v1alpha1.VulnerabilityReportList
+		require.NoError(t, c.List(context.TODO(), &vulnerabilityReports))
+		names := []string{}
+		for _, report := range vulnerabilityReports.Items {
+			names = append(names, report.Namespace+"/"+report.Name)
+		}
+		assert.ElementsMatch(t, []string{"ci/replicaset-app-3-app", "default/replicaset-app-10-app"}, names)
+
+		var configAuditReports v1alpha1.ConfigAuditReportList
+		require.NoError(t, c.List(context.TODO(), &configAuditReports))
+		require.Len(t, configAuditReports.Items, 1)
+		assert.Equal(t, "replicaset-app-1", configAuditReports.Items[0].Name)
+
+		var packageInventories v1alpha1.PackageInventoryList
+		require.NoError(t, c.List(context.TODO(), &packageInventories))
+		assert.Empty(t, packageInventories.Items)
+
+		require.Len(t, recorder.Events, 1)
+		assert.Equal(t, "Warning ReportsEvicted Evicted 2 oldest report(s) to keep at most 3 reports in namespace", <-recorder.Events)
+		assert.Equal(t, evictionsBefore+2, evictionsTotal(t, "ci"))
+	})
+
+	t.Run("Should not evict protected reports", func(t *testing.T) {
+		protected := newVulnerabilityReport("qa", 5)
+		protected.Annotations = map[string]string{starboard.AnnotationProtected: "true"}
+		q, c, _ := newQuota(t, 2,
+			protected,
+			newVulnerabilityReport("qa", 3),
+		)
+		require.NoError(t, q.Admit(context.TODO(), "qa"))
+
+		var list v1alpha1.VulnerabilityReportList
+		require.NoError(t, c.List(context.TODO(), &list))
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, "replicaset-app-5-app", list.Items[0].Name)
+	})
+
+	t.Run("Should count deleted reports", func(t *testing.T) {
+		report := newVulnerabilityReport("default", 1)
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+		q := quota.NewNamespaceQuota(logr.Discard(), c, record.NewFakeRecorder(10), 1)
+		informers := &informertest.FakeInformers{Scheme: starboard.NewScheme()}
+		require.NoError(t, q.Watch(context.TODO(), informers))
+		informer, err := informers.FakeInformerFor(report)
+		require.NoError(t, err)
+
+		informer.Add(report)
+		assert.Equal(t, 1, q.Count("default"))
+		informer.Delete(report)
+		assert.Equal(t, 0, q.Count("default"))
+	})
+}
+
+// evictionsTotal returns the evictions counter of the given namespace from
+// the metrics registry of the operator.
+func evictionsTotal(t *testing.T, namespace string) float64 {
+	t.Helper()
+	families, err := metrics.Registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "starboard_operator_report_evictions_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "namespace" && label.GetValue() == namespace {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
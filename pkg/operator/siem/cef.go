@@ -0,0 +1,306 @@
+// Package siem exports security reports to security information and event
+// management (SIEM) systems as Common Event Format (CEF) events sent to a
+// syslog endpoint.
+package siem
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DeviceVendor is the vendor in the header of CEF events.
+	DeviceVendor = "Aqua Security"
+	// DeviceProduct is the product in the header of CEF events.
+	DeviceProduct = "Starboard"
+)
+
+// Mode represents what CEF events are emitted for a report.
+type Mode string
+
+const (
+	// ModeReport emits a single event which summarizes a report.
+	ModeReport Mode = "report"
+	// ModeFinding emits an event for each vulnerability or failed check of a
+	// report.
+	ModeFinding Mode = "finding"
+)
+
+// Extension is a key-value pair of the extension of a CEF event.
+type Extension struct {
+	Key   string
+	Value string
+}
+
+// Event is a CEF event.
+type Event struct {
+	DeviceVersion string
+	// SignatureID is the Device Event Class ID, e.g. the ID of a
+	// vulnerability or a configuration check.
+	SignatureID string
+	Name        string
+	// Severity is the importance of the event from 0 to 10.
+	Severity   int
+	Extensions []Extension
+}
+
+var (
+	headerReplacer    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\r\n", " ", "\n", " ", "\r", " ")
+	extensionReplacer = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r\n", `\n`, "\n", `\n`, "\r", `\r`)
+)
+
+// String returns the event in the CEF format. Backslashes and pipes in header
+// fields, and backslashes and equal signs in extension values are escaped.
+// Line breaks are replaced with spaces in header fields and escaped in
+// extension values, so that an event always fits in a single line. Characters
+// other than letters and digits are removed from extension keys.
+func (e Event) String() string {
+	var b strings.Builder
+	b.WriteString("CEF:0")
+	for _, field := range []string{DeviceVendor, DeviceProduct, e.DeviceVersion, e.SignatureID, e.Name, strconv.Itoa(clampSeverity(e.Severity))} {
+		b.WriteByte('|')
+		b.WriteString(headerReplacer.Replace(field))
+	}
+	b.WriteByte('|')
+	separator := ""
+	for _, ext := range e.Extensions {
+		key := extensionKey(ext.Key)
+		if key == "" {
+			continue
+		}
+		b.WriteString(separator)
+		separator = " "
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(extensionReplacer.Replace(ext.Value))
+	}
+	return b.String()
+}
+
+func extensionKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, key)
+}
+
+func clampSeverity(severity int) int {
+	if severity < 0 {
+		return 0
+	}
+	if severity > 10 {
+		return 10
+	}
+	return severity
+}
+
+var severityRank = map[v1alpha1.Severity]int{
+	v1alpha1.SeverityNone:     0,
+	v1alpha1.SeverityUnknown:  1,
+	v1alpha1.SeverityLow:      2,
+	v1alpha1.SeverityMedium:   3,
+	v1alpha1.SeverityHigh:     4,
+	v1alpha1.SeverityCritical: 5,
+}
+
+// AtLeast returns true if the given severity is equal to or higher than the
+// floor.
+func AtLeast(severity, floor v1alpha1.Severity) bool {
+	return severityRank[severity] >= severityRank[floor]
+}
+
+// CEFSeverity maps the given severity to the importance of a CEF event.
+func CEFSeverity(severity v1alpha1.Severity) int {
+	switch severity {
+	case v1alpha1.SeverityCritical:
+		return 10
+	case v1alpha1.SeverityHigh:
+		return 8
+	case v1alpha1.SeverityMedium:
+		return 5
+	case v1alpha1.SeverityLow:
+		return 3
+	}
+	return 0
+}
+
+// Options represents how CEF events are built from reports.
+type Options struct {
+	Mode          Mode
+	SeverityFloor v1alpha1.Severity
+	DeviceVersion string
+}
+
+// VulnerabilityReportEvents returns CEF events of the given report. In the
+// ModeReport mode the summary event is returned if the report has any
+// vulnerability at or above the severity floor. In the ModeFinding mode an
+// event is returned for each such vulnerability.
+func VulnerabilityReportEvents(report v1alpha1.VulnerabilityReport, opts Options) []Event {
+	resource := resourceExtensions(report.ObjectMeta, report.Report.UpdateTimestamp, "vulnerability")
+	resource = append(resource,
+		Extension{Key: "cs3Label", Value: "container"},
+		Extension{Key: "cs3", Value: report.Labels[starboard.LabelContainerName]},
+		Extension{Key: "cs4Label", Value: "image"},
+		Extension{Key: "cs4", Value: imageRef(report.Report.Registry, report.Report.Artifact)},
+	)
+
+	if opts.Mode == ModeFinding {
+		var events []Event
+		for _, vulnerability := range report.Report.Vulnerabilities {
+			if !AtLeast(vulnerability.Severity, opts.SeverityFloor) {
+				continue
+			}
+			extensions := append(append([]Extension{}, resource...),
+				Extension{Key: "cs5Label", Value: "resource"},
+				Extension{Key: "cs5", Value: fmt.Sprintf("%s %s", vulnerability.Resource, vulnerability.InstalledVersion)},
+				Extension{Key: "cs6Label", Value: "fixedVersion"},
+				Extension{Key: "cs6", Value: vulnerability.FixedVersion},
+			)
+			if vulnerability.PrimaryLink != "" {
+				extensions = append(extensions, Extension{Key: "request", Value: vulnerability.PrimaryLink})
+			}
+			events = append(events, Event{
+				DeviceVersion: opts.DeviceVersion,
+				SignatureID:   vulnerability.VulnerabilityID,
+				Name:          firstNonEmpty(vulnerability.Title, vulnerability.VulnerabilityID),
+				Severity:      CEFSeverity(vulnerability.Severity),
+				Extensions:    extensions,
+			})
+		}
+		return events
+	}
+
+	summary := report.Report.Summary
+	maxSeverity := vulnerabilityreport.MaxSeverity(summary)
+	if maxSeverity == v1alpha1.SeverityNone || !AtLeast(maxSeverity, opts.SeverityFloor) {
+		return nil
+	}
+	return []Event{{
+		DeviceVersion: opts.DeviceVersion,
+		SignatureID:   "VulnerabilityReport",
+		Name:          "Vulnerability report updated",
+		Severity:      CEFSeverity(maxSeverity),
+		Extensions: append(resource,
+			Extension{Key: "cn1Label", Value: "critical"},
+			Extension{Key: "cn1", Value: strconv.Itoa(summary.CriticalCount)},
+			Extension{Key: "cn2Label", Value: "high"},
+			Extension{Key: "cn2", Value: strconv.Itoa(summary.HighCount)},
+			Extension{Key: "cn3Label", Value: "medium"},
+			Extension{Key: "cn3", Value: strconv.Itoa(summary.MediumCount)},
+			Extension{Key: "msg", Value: fmt.Sprintf("%d critical, %d high, %d medium, %d low, %d unknown vulnerabilities",
+				summary.CriticalCount, summary.HighCount, summary.MediumCount, summary.LowCount, summary.UnknownCount)},
+		),
+	}}
+}
+
+// ConfigAuditReportEvents returns CEF events of the given ConfigAuditReport
+// or ClusterConfigAuditReport, described by its metadata and data. In the
+// ModeReport mode the summary event is returned if any check at or above the
+// severity floor failed. In the ModeFinding mode an event is returned for
+// each such check.
+func ConfigAuditReportEvents(meta metav1.ObjectMeta, report v1alpha1.ConfigAuditReportData, opts Options) []Event {
+	resource := resourceExtensions(meta, report.UpdateTimestamp, "configuration")
+
+	maxSeverity := v1alpha1.SeverityNone
+	var events []Event
+	for _, check := range report.Checks {
+		if check.Success || check.Error != "" || !AtLeast(check.Severity, opts.SeverityFloor) {
+			continue
+		}
+		if severityRank[check.Severity] > severityRank[maxSeverity] {
+			maxSeverity = check.Severity
+		}
+		if opts.Mode != ModeFinding {
+			continue
+		}
+		extensions := append(append([]Extension{}, resource...),
+			Extension{Key: "cs3Label", Value: "category"},
+			Extension{Key: "cs3", Value: check.Category},
+		)
+		if len(check.Messages) > 0 {
+			extensions = append(extensions, Extension{Key: "msg", Value: strings.Join(check.Messages, "; ")})
+		}
+		events = append(events, Event{
+			DeviceVersion: opts.DeviceVersion,
+			SignatureID:   check.ID,
+			Name:          firstNonEmpty(check.Title, check.ID),
+			Severity:      CEFSeverity(check.Severity),
+			Extensions:    extensions,
+		})
+	}
+	if opts.Mode == ModeFinding || maxSeverity == v1alpha1.SeverityNone {
+		return events
+	}
+
+	summary := report.Summary
+	return []Event{{
+		DeviceVersion: opts.DeviceVersion,
+		SignatureID:   "ConfigAuditReport",
+		Name:          "Configuration audit report updated",
+		Severity:      CEFSeverity(maxSeverity),
+		Extensions: append(resource,
+			Extension{Key: "cn1Label", Value: "critical"},
+			Extension{Key: "cn1", Value: strconv.Itoa(summary.CriticalCount)},
+			Extension{Key: "cn2Label", Value: "high"},
+			Extension{Key: "cn2", Value: strconv.Itoa(summary.HighCount)},
+			Extension{Key: "cn3Label", Value: "medium"},
+			Extension{Key: "cn3", Value: strconv.Itoa(summary.MediumCount)},
+			Extension{Key: "msg", Value: fmt.Sprintf("%d critical, %d high, %d medium, %d low failed checks",
+				summary.CriticalCount, summary.HighCount, summary.MediumCount, summary.LowCount)},
+		),
+	}}
+}
+
+// resourceExtensions returns extensions which identify the resource described
+// by the report with the given metadata.
+func resourceExtensions(meta metav1.ObjectMeta, updated metav1.Time, category string) []Extension {
+	extensions := []Extension{
+		{Key: "rt", Value: strconv.FormatInt(updated.UnixMilli(), 10)},
+		{Key: "cat", Value: category},
+	}
+	if namespace := meta.Labels[starboard.LabelResourceNamespace]; namespace != "" {
+		extensions = append(extensions,
+			Extension{Key: "cs1Label", Value: "namespace"},
+			Extension{Key: "cs1", Value: namespace},
+		)
+	}
+	name := meta.Labels[starboard.LabelResourceName]
+	if name == "" {
+		name = meta.Annotations[starboard.LabelResourceName]
+	}
+	return append(extensions,
+		Extension{Key: "cs2Label", Value: "resource"},
+		Extension{Key: "cs2", Value: fmt.Sprintf("%s/%s", meta.Labels[starboard.LabelResourceKind], name)},
+	)
+}
+
+func imageRef(registry v1alpha1.Registry, artifact v1alpha1.Artifact) string {
+	ref := artifact.Repository
+	if registry.Server != "" {
+		ref = registry.Server + "/" + ref
+	}
+	if artifact.Digest != "" {
+		return ref + "@" + artifact.Digest
+	}
+	if artifact.Tag != "" {
+		return ref + ":" + artifact.Tag
+	}
+	return ref
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,211 @@
+package siem_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/siem"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEvent_String(t *testing.T) {
+	testCases := []struct {
+		name     string
+		event    siem.Event
+		expected string
+	}{
+		{
+			name: "Should format event without extensions",
+			event: siem.Event{
+				DeviceVersion: "0.15.0",
+				SignatureID:   "CVE-2022-0001",
+				Name:          "openssl: buffer overflow",
+				Severity:      8,
+			},
+			expected: `CEF:0|Aqua Security|Starboard|0.15.0|CVE-2022-0001|openssl: buffer overflow|8|`,
+		},
+		{
+			name: "Should escape pipes and backslashes in header",
+			event: siem.Event{
+				DeviceVersion: `0.15|0`,
+				SignatureID:   `KSV\001`,
+				Name:          `a|b\c\|d`,
+				Severity:      5,
+			},
+			expected: `CEF:0|Aqua Security|Starboard|0.15\|0|KSV\\001|a\|b\\c\\\|d|5|`,
+		},
+		{
+			name: "Should replace line breaks in header",
+			event: siem.Event{
+				SignatureID: "KSV001",
+				Name:        "first\r\nsecond\nthird\rfourth",
+				Severity:    3,
+			},
+			expected: `CEF:0|Aqua Security|Starboard||KSV001|first second third fourth|3|`,
+		},
+		{
+			name: "Should not escape equal signs in header",
+			event: siem.Event{
+				SignatureID: "a=b",
+				Name:        "c=d",
+			},
+			expected: `CEF:0|Aqua Security|Starboard||a=b|c=d|0|`,
+		},
+		{
+			name: "Should escape equal signs, backslashes and line breaks in extension values",
+			event: siem.Event{
+				SignatureID: "KSV001",
+				Name:        "check",
+				Severity:    3,
+				Extensions: []siem.Extension{
+					{Key: "msg", Value: `key=value\path` + "\nline\r\nnext\rend"},
+					{Key: "cs1", Value: "a|b"},
+				},
+			},
+			expected: `CEF:0|Aqua Security|Starboard||KSV001|check|3|msg=key\=value\\path\nline\nnext\rend cs1=a|b`,
+		},
+		{
+			name: "Should remove invalid characters from extension keys",
+			event: siem.Event{
+				SignatureID: "KSV001",
+				Name:        "check",
+				Extensions: []siem.Extension{
+					{Key: "=|", Value: "dropped"},
+					{Key: "cs1 Label=", Value: "namespace"},
+					{Key: "cs1", Value: "default"},
+				},
+			},
+			expected: `CEF:0|Aqua Security|Starboard||KSV001|check|0|cs1Label=namespace cs1=default`,
+		},
+		{
+			name: "Should clamp severity",
+			event: siem.Event{
+				SignatureID: "CVE-2022-0001",
+				Name:        "vulnerability",
+				Severity:    42,
+			},
+			expected: `CEF:0|Aqua Security|Starboard||CVE-2022-0001|vulnerability|10|`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.event.String())
+		})
+	}
+}
+
+func TestVulnerabilityReportEvents(t *testing.T) {
+	report := v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "replicaset-nginx-6d4cf56db6-nginx",
+			Namespace: "default",
+			Labels: map[string]string{
+				starboard.LabelResourceKind:      "ReplicaSet",
+				starboard.LabelResourceName:      "nginx-6d4cf56db6",
+				starboard.LabelResourceNamespace: "default",
+				starboard.LabelContainerName:     "nginx",
+			},
+		},
+		Report: v1alpha1.VulnerabilityReportData{
+			UpdateTimestamp: metav1.NewTime(time.Unix(1652356800, 0)),
+			Registry:        v1alpha1.Registry{Server: "index.docker.io"},
+			Artifact:        v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+			Summary:         v1alpha1.VulnerabilitySummary{HighCount: 1, MediumCount: 1},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{
+					VulnerabilityID:  "CVE-2022-0001",
+					Resource:         "openssl",
+					InstalledVersion: "1.1.1d",
+					FixedVersion:     "1.1.1n",
+					Severity:         v1alpha1.SeverityHigh,
+					Title:            "openssl: infinite loop",
+					PrimaryLink:      "https://avd.aquasec.com/nvd/cve-2022-0001",
+				},
+				{
+					VulnerabilityID: "CVE-2022-0002",
+					Resource:        "zlib",
+					Severity:        v1alpha1.SeverityMedium,
+				},
+			},
+		},
+	}
+
+	t.Run("Should return summary event", func(t *testing.T) {
+		events := siem.VulnerabilityReportEvents(report, siem.Options{Mode: siem.ModeReport, SeverityFloor: v1alpha1.SeverityHigh, DeviceVersion: "0.15.0"})
+		require.Len(t, events, 1)
+		assert.Equal(t, "CEF:0|Aqua Security|Starboard|0.15.0|VulnerabilityReport|Vulnerability report updated|8|"+
+			"rt=1652356800000 cat=vulnerability cs1Label=namespace cs1=default cs2Label=resource cs2=ReplicaSet/nginx-6d4cf56db6 "+
+			"cs3Label=container cs3=nginx cs4Label=image cs4=index.docker.io/library/nginx:1.16 "+
+			"cn1Label=critical cn1=0 cn2Label=high cn2=1 cn3Label=medium cn3=1 "+
+			"msg=0 critical, 1 high, 1 medium, 0 low, 0 unknown vulnerabilities", events[0].String())
+	})
+
+	t.Run("Should not return summary event below severity floor", func(t *testing.T) {
+		events := siem.VulnerabilityReportEvents(report, siem.Options{Mode: siem.ModeReport, SeverityFloor: v1alpha1.SeverityCritical})
+		assert.Empty(t, events)
+	})
+
+	t.Run("Should return event per vulnerability at or above severity floor", func(t *testing.T) {
+		events := siem.VulnerabilityReportEvents(report, siem.Options{Mode: siem.ModeFinding, SeverityFloor: v1alpha1.SeverityHigh})
+		require.Len(t, events, 1)
+		assert.Equal(t, "CEF:0|Aqua Security|Starboard||CVE-2022-0001|openssl: infinite loop|8|"+
+			"rt=1652356800000 cat=vulnerability cs1Label=namespace cs1=default cs2Label=resource cs2=ReplicaSet/nginx-6d4cf56db6 "+
+			"cs3Label=container cs3=nginx cs4Label=image cs4=index.docker.io/library/nginx:1.16 "+
+			"cs5Label=resource cs5=openssl 1.1.1d cs6Label=fixedVersion cs6=1.1.1n "+
+			"request=https://avd.aquasec.com/nvd/cve-2022-0001", events[0].String())
+
+		events = siem.VulnerabilityReportEvents(report, siem.Options{Mode: siem.ModeFinding, SeverityFloor: v1alpha1.SeverityLow})
+		require.Len(t, events, 2)
+		assert.Equal(t, "CVE-2022-0002", events[1].Name)
+		assert.Equal(t, 5, events[1].Severity)
+	})
+}
+
+func TestConfigAuditReportEvents(t *testing.T) {
+	meta := metav1.ObjectMeta{
+		Name: "clusterrole-admin",
+		Labels: map[string]string{
+			starboard.LabelResourceKind: "ClusterRole",
+			starboard.LabelResourceName: "admin",
+		},
+	}
+	report := v1alpha1.ConfigAuditReportData{
+		UpdateTimestamp: metav1.NewTime(time.Unix(1652356800, 0)),
+		Summary:         v1alpha1.ConfigAuditSummary{CriticalCount: 1, LowCount: 1},
+		Checks: []v1alpha1.Check{
+			{ID: "KSV041", Title: "Manage secrets", Severity: v1alpha1.SeverityCritical, Category: "Kubernetes Security Check",
+				Messages: []string{"ClusterRole 'admin' shouldn't have access to manage secrets"}},
+			{ID: "KSV042", Severity: v1alpha1.SeverityLow},
+			{ID: "KSV043", Severity: v1alpha1.SeverityCritical, Success: true},
+			{ID: "KSV044", Severity: v1alpha1.SeverityCritical, Error: "rego_type_error"},
+		},
+	}
+
+	t.Run("Should return summary event", func(t *testing.T) {
+		events := siem.ConfigAuditReportEvents(meta, report, siem.Options{Mode: siem.ModeReport, SeverityFloor: v1alpha1.SeverityHigh})
+		require.Len(t, events, 1)
+		assert.Equal(t, "CEF:0|Aqua Security|Starboard||ConfigAuditReport|Configuration audit report updated|10|"+
+			"rt=1652356800000 cat=configuration cs2Label=resource cs2=ClusterRole/admin "+
+			"cn1Label=critical cn1=1 cn2Label=high cn2=0 cn3Label=medium cn3=0 "+
+			"msg=1 critical, 0 high, 0 medium, 1 low failed checks", events[0].String())
+	})
+
+	t.Run("Should return event per failed check at or above severity floor", func(t *testing.T) {
+		events := siem.ConfigAuditReportEvents(meta, report, siem.Options{Mode: siem.ModeFinding, SeverityFloor: v1alpha1.SeverityHigh})
+		require.Len(t, events, 1)
+		assert.Equal(t, "CEF:0|Aqua Security|Starboard||KSV041|Manage secrets|10|"+
+			"rt=1652356800000 cat=configuration cs2Label=resource cs2=ClusterRole/admin "+
+			"cs3Label=category cs3=Kubernetes Security Check "+
+			"msg=ClusterRole 'admin' shouldn't have access to manage secrets", events[0].String())
+	})
+
+	t.Run("Should not return events without failed checks above severity floor", func(t *testing.T) {
+		report := v1alpha1.ConfigAuditReportData{Checks: []v1alpha1.Check{{ID: "KSV042", Severity: v1alpha1.SeverityLow}}}
+		assert.Empty(t, siem.ConfigAuditReportEvents(meta, report, siem.Options{Mode: siem.ModeReport, SeverityFloor: v1alpha1.SeverityMedium}))
+		assert.Empty(t, siem.ConfigAuditReportEvents(meta, report, siem.Options{Mode: siem.ModeFinding, SeverityFloor: v1alpha1.SeverityMedium}))
+	})
+}
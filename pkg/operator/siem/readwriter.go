@@ -0,0 +1,72 @@
+package siem
+
+import (
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+)
+
+type vulnerabilityReadWriter struct {
+	vulnerabilityreport.ReadWriter
+	emitter *Emitter
+	opts    Options
+}
+
+// NewVulnerabilityReadWriter decorates the given ReadWriter to emit CEF
+// events of VulnerabilityReports which are created or updated.
+func NewVulnerabilityReadWriter(readWriter vulnerabilityreport.ReadWriter, emitter *Emitter, opts Options) vulnerabilityreport.ReadWriter {
+	return &vulnerabilityReadWriter{
+		ReadWriter: readWriter,
+		emitter:    emitter,
+		opts:       opts,
+	}
+}
+
+func (w *vulnerabilityReadWriter) Write(ctx context.Context, reports []v1alpha1.VulnerabilityReport, opts ...kube.WriteOption) error {
+	err := w.ReadWriter.Write(ctx, reports, opts...)
+	if err != nil || kube.NewWriteOptions(opts...).DryRun {
+		return err
+	}
+	for _, report := range reports {
+		w.emitter.Emit(VulnerabilityReportEvents(report, w.opts)...)
+	}
+	return nil
+}
+
+type configAuditReadWriter struct {
+	configauditreport.ReadWriter
+	emitter *Emitter
+	opts    Options
+}
+
+// NewConfigAuditReadWriter decorates the given ReadWriter to emit CEF events
+// of ConfigAuditReports and ClusterConfigAuditReports which are created or
+// updated.
+func NewConfigAuditReadWriter(readWriter configauditreport.ReadWriter, emitter *Emitter, opts Options) configauditreport.ReadWriter {
+	return &configAuditReadWriter{
+		ReadWriter: readWriter,
+		emitter:    emitter,
+		opts:       opts,
+	}
+}
+
+func (w *configAuditReadWriter) WriteReport(ctx context.Context, report v1alpha1.ConfigAuditReport, opts ...kube.WriteOption) error {
+	err := w.ReadWriter.WriteReport(ctx, report, opts...)
+	if err != nil || kube.NewWriteOptions(opts...).DryRun {
+		return err
+	}
+	w.emitter.Emit(ConfigAuditReportEvents(report.ObjectMeta, report.Report, w.opts)...)
+	return nil
+}
+
+func (w *configAuditReadWriter) WriteClusterReport(ctx context.Context, report v1alpha1.ClusterConfigAuditReport, opts ...kube.WriteOption) error {
+	err := w.ReadWriter.WriteClusterReport(ctx, report, opts...)
+	if err != nil || kube.NewWriteOptions(opts...).DryRun {
+		return err
+	}
+	w.emitter.Emit(ConfigAuditReportEvents(report.ObjectMeta, report.Report, w.opts)...)
+	return nil
+}
@@ -0,0 +1,261 @@
+package siem
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// DropReasonOverflow is the reason of events dropped because the buffer
+	// of the Emitter is full.
+	DropReasonOverflow = "overflow"
+	// DropReasonUnavailable is the reason of events dropped because they
+	// could not be sent to the syslog endpoint.
+	DropReasonUnavailable = "unavailable"
+)
+
+var (
+	eventsSentTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "starboard_siem_events_sent_total",
+		Help: "Number of CEF events sent to the syslog endpoint.",
+	})
+	eventsDroppedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "starboard_siem_events_dropped_total",
+		Help: "Number of CEF events dropped because the buffer was full or the syslog endpoint was unavailable.",
+	}, []string{"reason"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(eventsSentTotal, eventsDroppedTotal)
+}
+
+// syslogFacility is the facility of syslog messages, i.e. log audit.
+const syslogFacility = 13
+
+// Config represents the syslog endpoint and buffering of an Emitter.
+type Config struct {
+	// Address is the URL of the syslog endpoint with the udp, tcp or tls
+	// scheme, e.g. tls://siem.example.com:6514.
+	Address string
+	// TLSConfig is used to connect to the endpoint with the tls scheme.
+	TLSConfig *tls.Config
+	// BufferSize is the maximum number of events waiting to be sent. Events
+	// emitted while the buffer is full are dropped.
+	BufferSize int
+	// BatchSize is the maximum number of events sent at once.
+	BatchSize int
+	// FlushInterval is the maximum time an event waits for a batch to fill.
+	FlushInterval time.Duration
+	// DialTimeout is the timeout of connecting and writing to the endpoint.
+	DialTimeout time.Duration
+}
+
+// Emitter sends CEF events to a syslog endpoint asynchronously. Events are
+// buffered and sent in batches by the Start method, so that Emit never
+// blocks callers, e.g. reconciliation loops. Events which do not fit in the
+// buffer, or which cannot be sent because the endpoint is unavailable, are
+// dropped and counted by the starboard_siem_events_dropped_total metric.
+//
+// Messages are formatted as defined by RFC 5424 and terminated by a newline
+// when they're sent over TCP or TLS. Each message is sent in a separate
+// datagram over UDP.
+type Emitter struct {
+	logr.Logger
+	ext.Clock
+
+	config   Config
+	network  string
+	host     string
+	hostname string
+	messages chan string
+	conn     net.Conn
+	dropped  uint64
+}
+
+// NewEmitter constructs a new Emitter with the given Config.
+func NewEmitter(logger logr.Logger, clock ext.Clock, config Config) (*Emitter, error) {
+	endpoint, err := url.Parse(config.Address)
+	if err != nil {
+		return nil, fmt.Errorf("parsing syslog address: %w", err)
+	}
+	switch endpoint.Scheme {
+	case "udp", "tcp", "tls":
+	default:
+		return nil, fmt.Errorf("unsupported scheme of syslog address %q: must be one of udp, tcp, tls", config.Address)
+	}
+	if endpoint.Port() == "" {
+		return nil, fmt.Errorf("syslog address %q must specify port", config.Address)
+	}
+	if config.BufferSize < 1 || config.BatchSize < 1 {
+		return nil, fmt.Errorf("buffer size and batch size must be positive")
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &Emitter{
+		Logger:   logger,
+		Clock:    clock,
+		config:   config,
+		network:  endpoint.Scheme,
+		host:     endpoint.Host,
+		hostname: hostname,
+		messages: make(chan string, config.BufferSize),
+	}, nil
+}
+
+// Emit queues the given events to be sent. It never blocks, and events which
+// do not fit in the buffer are dropped.
+func (e *Emitter) Emit(events ...Event) {
+	for _, event := range events {
+		select {
+		case e.messages <- e.format(event):
+		default:
+			e.drop(DropReasonOverflow, 1)
+		}
+	}
+}
+
+// Dropped returns the number of events dropped so far.
+func (e *Emitter) Dropped() uint64 {
+	return atomic.LoadUint64(&e.dropped)
+}
+
+// format returns the syslog message of the given event.
+func (e *Emitter) format(event Event) string {
+	return fmt.Sprintf("<%d>1 %s %s starboard-operator - - - %s",
+		syslogFacility*8+syslogSeverity(event.Severity),
+		e.Now().UTC().Format(time.RFC3339), e.hostname, event.String())
+}
+
+// syslogSeverity maps the importance of a CEF event to the syslog severity.
+func syslogSeverity(severity int) int {
+	switch {
+	case severity >= 9:
+		return 2 // critical
+	case severity >= 7:
+		return 3 // error
+	case severity >= 4:
+		return 4 // warning
+	case severity >= 1:
+		return 5 // notice
+	}
+	return 6 // informational
+}
+
+// Start sends buffered events in batches until the given context is done.
+// It implements manager.Runnable.
+func (e *Emitter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(e.config.FlushInterval)
+	defer ticker.Stop()
+	defer e.close()
+
+	batch := make([]string, 0, e.config.BatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message := <-e.messages:
+			batch = append(batch, message)
+			if len(batch) < e.config.BatchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+		e.send(batch)
+		batch = batch[:0]
+	}
+}
+
+// send writes the given messages to the endpoint. The connection is closed on
+// failure, and reopened when the next batch is sent.
+func (e *Emitter) send(batch []string) {
+	err := e.write(batch)
+	if err != nil {
+		e.close()
+		e.drop(DropReasonUnavailable, len(batch))
+		e.Error(err, "Dropping CEF events", "address", e.config.Address, "count", len(batch), "dropped", e.Dropped())
+		return
+	}
+	eventsSentTotal.Add(float64(len(batch)))
+}
+
+func (e *Emitter) write(batch []string) error {
+	if e.conn == nil {
+		conn, err := e.dial()
+		if err != nil {
+			return err
+		}
+		e.conn = conn
+	}
+	if e.config.DialTimeout > 0 {
+		if err := e.conn.SetWriteDeadline(time.Now().Add(e.config.DialTimeout)); err != nil {
+			return err
+		}
+	}
+	if e.network == "udp" {
+		for _, message := range batch {
+			if _, err := e.conn.Write([]byte(message)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	_, err := e.conn.Write([]byte(strings.Join(batch, "\n") + "\n"))
+	return err
+}
+
+func (e *Emitter) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: e.config.DialTimeout}
+	if e.network == "tls" {
+		return tls.DialWithDialer(dialer, "tcp", e.host, e.config.TLSConfig)
+	}
+	return dialer.Dial(e.network, e.host)
+}
+
+func (e *Emitter) close() {
+	if e.conn != nil {
+		_ = e.conn.Close()
+		e.conn = nil
+	}
+}
+
+func (e *Emitter) drop(reason string, count int) {
+	atomic.AddUint64(&e.dropped, uint64(count))
+	eventsDroppedTotal.WithLabelValues(reason).Add(float64(count))
+}
+
+// NewTLSConfig returns the configuration of TLS connections to the syslog
+// endpoint, which is verified with CA certificates in the given PEM file, or
+// with system roots if the file is blank.
+func NewTLSConfig(caFile string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile == "" {
+		return config, nil
+	}
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA file: %w", err)
+	}
+	config.RootCAs = x509.NewCertPool()
+	if !config.RootCAs.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no CA certificates found in %s", caFile)
+	}
+	return config, nil
+}
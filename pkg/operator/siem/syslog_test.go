@@ -0,0 +1,139 @@
+package siem_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/operator/siem"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fixedClock = ext.NewFixedClock(time.Date(2022, 5, 12, 12, 0, 0, 0, time.UTC))
+
+func newEmitter(t *testing.T, config siem.Config) *siem.Emitter {
+	t.Helper()
+	if config.FlushInterval == 0 {
+		config.FlushInterval = 50 * time.Millisecond
+	}
+	if config.DialTimeout == 0 {
+		config.DialTimeout = time.Second
+	}
+	emitter, err := siem.NewEmitter(logr.Discard(), fixedClock, config)
+	require.NoError(t, err)
+	return emitter
+}
+
+func start(t *testing.T, emitter *siem.Emitter) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = emitter.Start(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func TestNewEmitter(t *testing.T) {
+	_, err := siem.NewEmitter(logr.Discard(), fixedClock, siem.Config{Address: "http://siem.example.com:514", BufferSize: 1, BatchSize: 1})
+	assert.EqualError(t, err, `unsupported scheme of syslog address "http://siem.example.com:514": must be one of udp, tcp, tls`)
+
+	_, err = siem.NewEmitter(logr.Discard(), fixedClock, siem.Config{Address: "tcp://siem.example.com", BufferSize: 1, BatchSize: 1})
+	assert.EqualError(t, err, `syslog address "tcp://siem.example.com" must specify port`)
+
+	_, err = siem.NewEmitter(logr.Discard(), fixedClock, siem.Config{Address: "udp://siem.example.com:514"})
+	assert.EqualError(t, err, "buffer size and batch size must be positive")
+}
+
+func TestEmitter(t *testing.T) {
+	event := func(id string, severity int) siem.Event {
+		return siem.Event{SignatureID: id, Name: "name", Severity: severity}
+	}
+
+	t.Run("Should send batches over TCP", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+
+		emitter := newEmitter(t, siem.Config{Address: "tcp://" + listener.Addr().String(), BufferSize: 10, BatchSize: 2})
+		start(t, emitter)
+		emitter.Emit(event("CVE-2022-0001", 10), event("CVE-2022-0002", 8), event("KSV001", 0))
+
+		conn, err := listener.Accept()
+		require.NoError(t, err)
+		defer conn.Close()
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+		var messages []string
+		scanner := bufio.NewScanner(conn)
+		for len(messages) < 3 && scanner.Scan() {
+			messages = append(messages, scanner.Text())
+		}
+		require.Len(t, messages, 3)
+		assert.Regexp(t, `^<106>1 2022-05-12T12:00:00Z \S+ starboard-operator - - - CEF:0\|Aqua Security\|Starboard\|\|CVE-2022-0001\|name\|10\|$`, messages[0])
+		assert.Regexp(t, `^<107>1 2022-05-12T12:00:00Z \S+ starboard-operator - - - CEF:0\|Aqua Security\|Starboard\|\|CVE-2022-0002\|name\|8\|$`, messages[1])
+		assert.Regexp(t, `^<110>1 2022-05-12T12:00:00Z \S+ starboard-operator - - - CEF:0\|Aqua Security\|Starboard\|\|KSV001\|name\|0\|$`, messages[2])
+		assert.Zero(t, emitter.Dropped())
+	})
+
+	t.Run("Should send message per datagram over UDP", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer conn.Close()
+
+		emitter := newEmitter(t, siem.Config{Address: "udp://" + conn.LocalAddr().String(), BufferSize: 10, BatchSize: 10})
+		start(t, emitter)
+		emitter.Emit(event("CVE-2022-0001", 5), event("CVE-2022-0002", 3))
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		buf := make([]byte, 1024)
+		for _, expected := range []string{
+			`^<108>1 \S+ \S+ starboard-operator - - - CEF:0\|Aqua Security\|Starboard\|\|CVE-2022-0001\|name\|5\|$`,
+			`^<109>1 \S+ \S+ starboard-operator - - - CEF:0\|Aqua Security\|Starboard\|\|CVE-2022-0002\|name\|3\|$`,
+		} {
+			n, _, err := conn.ReadFrom(buf)
+			require.NoError(t, err)
+			assert.Regexp(t, expected, string(buf[:n]))
+		}
+	})
+
+	t.Run("Should drop events without blocking when buffer is full", func(t *testing.T) {
+		emitter := newEmitter(t, siem.Config{Address: "tcp://127.0.0.1:1", BufferSize: 2, BatchSize: 10})
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			emitter.Emit(event("CVE-2022-0001", 10), event("CVE-2022-0002", 10), event("CVE-2022-0003", 10))
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Emit blocked")
+		}
+		assert.Equal(t, uint64(1), emitter.Dropped())
+	})
+
+	t.Run("Should drop events when endpoint is unavailable", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := listener.Addr().String()
+		require.NoError(t, listener.Close())
+
+		emitter := newEmitter(t, siem.Config{Address: "tcp://" + address, BufferSize: 10, BatchSize: 10})
+		start(t, emitter)
+		emitter.Emit(event("CVE-2022-0001", 10), event("CVE-2022-0002", 10))
+
+		assert.Eventually(t, func() bool {
+			return emitter.Dropped() == 2
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,150 @@
+// Package throttle provides primitives for backing off reconciliations while
+// requests are throttled by the API server.
+package throttle
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var apiServerThrottledTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "starboard_apiserver_throttled_total",
+	Help: "Number of reconciliations which were requeued because requests were throttled.",
+})
+
+func init() {
+	metrics.Registry.MustRegister(apiServerThrottledTotal)
+}
+
+// throttleJitterFactor is the maximum fraction of the backoff added to it, so
+// that requeued requests do not hit the API server at the same time.
+const throttleJitterFactor = 0.2
+
+// IsThrottled returns true if the specified error means that a request was
+// throttled, either by the API server with 429 Too Many Requests, e.g. by API
+// priority and fairness, or by the client-side rate limiter.
+func IsThrottled(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.IsTooManyRequests(err) || strings.Contains(err.Error(), "client rate limiter")
+}
+
+// Throttle backs off reconciliations while requests are throttled. Instead of
+// returning errors, which are retried immediately by the default rate limiter
+// of controllers, throttled reconciliations are requeued after exponential
+// backoff with jitter. The effective limit of concurrent scan jobs is halved
+// for each consecutive throttled request for as long as throttling persists,
+// i.e. until no request was throttled for the maximum backoff.
+//
+// It's safe for concurrent use. A nil Throttle never backs off.
+type Throttle struct {
+	logr.Logger
+	ext.Clock
+
+	baseDelay time.Duration
+	maxDelay  time.Duration
+
+	mu            sync.Mutex
+	level         int
+	lastThrottled time.Time
+}
+
+// New constructs a Throttle with the specified initial and maximum backoff.
+func New(logger logr.Logger, clock ext.Clock, baseDelay, maxDelay time.Duration) *Throttle {
+	return &Throttle{
+		Logger:    logger,
+		Clock:     clock,
+		baseDelay: baseDelay,
+		maxDelay:  maxDelay,
+	}
+}
+
+// Requeue translates the error of a throttled reconciliation into a result
+// which requeues it after backoff. Other results and errors are returned as
+// they are.
+func (t *Throttle) Requeue(result ctrl.Result, err error) (ctrl.Result, error) {
+	if t == nil || !IsThrottled(err) {
+		return result, err
+	}
+	apiServerThrottledTotal.Inc()
+	retryAfter := wait.Jitter(t.throttled(), throttleJitterFactor)
+	t.Logger.V(1).Info("Pushing back throttled reconciliation", "retryAfter", retryAfter, "reason", err.Error())
+	return ctrl.Result{RequeueAfter: retryAfter}, nil
+}
+
+// Reconciler wraps the specified reconciler so that its throttled
+// reconciliations are requeued after backoff.
+func (t *Throttle) Reconciler(r reconcile.Reconciler) reconcile.Reconciler {
+	if t == nil {
+		return r
+	}
+	return reconcile.Func(func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		return t.Requeue(r.Reconcile(ctx, req))
+	})
+}
+
+// Limit returns the effective limit of concurrent scan jobs given the
+// configured limit. It's never lower than one.
+func (t *Throttle) Limit(limit int) int {
+	if t == nil {
+		return limit
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i := 0; i < t.currentLevel() && limit > 1; i++ {
+		limit /= 2
+	}
+	if limit < 1 {
+		return 1
+	}
+	return limit
+}
+
+// Throttling returns true if throttling persists.
+func (t *Throttle) Throttling() bool {
+	if t == nil {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.currentLevel() > 0
+}
+
+// throttled records a throttled request and returns the backoff before the
+// next attempt.
+func (t *Throttle) throttled() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.level = t.currentLevel() + 1
+	t.lastThrottled = t.Clock.Now()
+	delay := t.baseDelay
+	for i := 1; i < t.level && delay < t.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > t.maxDelay {
+		delay = t.maxDelay
+	}
+	return delay
+}
+
+// currentLevel returns the number of consecutive throttled requests, or zero
+// if no request was throttled for the maximum backoff. It must be called with
+// the mutex held.
+func (t *Throttle) currentLevel() int {
+	if t.level > 0 && t.Clock.Now().Sub(t.lastThrottled) > t.maxDelay {
+		t.level = 0
+	}
+	return t.level
+}
@@ -0,0 +1,168 @@
+package throttle_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/operator/throttle"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// testClock is a clock which is advanced manually.
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+var errTooManyRequests = k8sapierror.NewTooManyRequests("the server has received too many requests", 1)
+
+func TestIsThrottled(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "Should return false for nil error",
+		},
+		{
+			name: "Should return true for too many requests",
+			err:  errTooManyRequests,
+			want: true,
+		},
+		{
+			name: "Should return true for wrapped too many requests",
+			err:  fmt.Errorf("creating scan job failed: %w", errTooManyRequests),
+			want: true,
+		},
+		{
+			name: "Should return true for client rate limiter",
+			err:  errors.New("client rate limiter Wait returned an error: context deadline exceeded"),
+			want: true,
+		},
+		{
+			name: "Should return false for not found",
+			err:  k8sapierror.NewNotFound(schema.GroupResource{Resource: "jobs"}, "scan-job"),
+		},
+		{
+			name: "Should return false for other errors",
+			err:  errors.New("connection refused"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, throttle.IsThrottled(tc.err))
+		})
+	}
+}
+
+func TestThrottle_Requeue(t *testing.T) {
+	t.Run("Should return results and errors which are not throttled", func(t *testing.T) {
+		instance := throttle.New(logr.Discard(), &testClock{}, time.Second, time.Minute)
+		err := errors.New("connection refused")
+
+		result, actualErr := instance.Requeue(ctrl.Result{}, err)
+		assert.Equal(t, ctrl.Result{}, result)
+		assert.Equal(t, err, actualErr)
+
+		result, actualErr = instance.Requeue(ctrl.Result{RequeueAfter: time.Hour}, nil)
+		assert.Equal(t, ctrl.Result{RequeueAfter: time.Hour}, result)
+		assert.NoError(t, actualErr)
+		assert.False(t, instance.Throttling())
+	})
+
+	t.Run("Should requeue throttled reconciliations after exponential backoff", func(t *testing.T) {
+		clock := &testClock{now: time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)}
+		instance := throttle.New(logr.Discard(), clock, time.Second, 10*time.Second)
+		throttledBefore := throttledTotal(t)
+
+		for _, backoff := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+			result, err := instance.Requeue(ctrl.Result{}, errTooManyRequests)
+			require.NoError(t, err)
+			assertBackoff(t, backoff, result.RequeueAfter)
+			clock.now = clock.now.Add(time.Second)
+		}
+		assert.True(t, instance.Throttling())
+		assert.Equal(t, throttledBefore+6, throttledTotal(t))
+
+		clock.now = clock.now.Add(11 * time.Second)
+		assert.False(t, instance.Throttling())
+		result, err := instance.Requeue(ctrl.Result{}, errTooManyRequests)
+		require.NoError(t, err)
+		assertBackoff(t, time.Second, result.RequeueAfter)
+	})
+
+	t.Run("Should return errors as they are with nil throttle", func(t *testing.T) {
+		var instance *throttle.Throttle
+		result, err := instance.Requeue(ctrl.Result{}, errTooManyRequests)
+		assert.Equal(t, ctrl.Result{}, result)
+		assert.Equal(t, errTooManyRequests, err)
+	})
+}
+
+func TestThrottle_Reconciler(t *testing.T) {
+	instance := throttle.New(logr.Discard(), &testClock{}, time.Second, time.Minute)
+	reconciler := instance.Reconciler(reconcile.Func(func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		return ctrl.Result{}, fmt.Errorf("writing reports: %w", errTooManyRequests)
+	}))
+
+	result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{})
+	require.NoError(t, err)
+	assertBackoff(t, time.Second, result.RequeueAfter)
+}
+
+func TestThrottle_Limit(t *testing.T) {
+	clock := &testClock{now: time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)}
+	instance := throttle.New(logr.Discard(), clock, time.Second, time.Minute)
+
+	assert.Equal(t, 10, instance.Limit(10), "not throttling")
+	for _, limit := range []int{5, 2, 1, 1} {
+		_, _ = instance.Requeue(ctrl.Result{}, errTooManyRequests)
+		assert.Equal(t, limit, instance.Limit(10))
+	}
+	assert.Equal(t, 1, instance.Limit(0), "never lower than one")
+
+	clock.now = clock.now.Add(time.Minute)
+	assert.Equal(t, 1, instance.Limit(10), "throttling persists")
+
+	clock.now = clock.now.Add(time.Second)
+	assert.Equal(t, 10, instance.Limit(10), "throttling stopped")
+
+	var nilThrottle *throttle.Throttle
+	assert.Equal(t, 10, nilThrottle.Limit(10))
+}
+
+// assertBackoff asserts that the actual backoff is the expected one with
+// jitter of at most 20%.
+func assertBackoff(t *testing.T, expected, actual time.Duration) {
+	t.Helper()
+	assert.GreaterOrEqual(t, actual, expected)
+	assert.LessOrEqual(t, actual, expected+expected/5)
+}
+
+// throttledTotal returns the throttled requests counter from the metrics
+// registry of the operator.
+func throttledTotal(t *testing.T) float64 {
+	t.Helper()
+	families, err := metrics.Registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() == "starboard_apiserver_throttled_total" {
+			return family.GetMetric()[0].GetCounter().GetValue()
+		}
+	}
+	return 0
+}
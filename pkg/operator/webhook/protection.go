@@ -0,0 +1,72 @@
+// Package webhook provides admission webhooks served by Starboard Operator.
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ReportDeletionPath is the path at which ReportDeletionValidator is served.
+const ReportDeletionPath = "/validate-report-deletion"
+
+// NamespaceControllerUser is the username of the namespace controller of
+// kube-controller-manager, which deletes all resources of a terminating
+// namespace.
+const NamespaceControllerUser = "system:serviceaccount:kube-system:namespace-controller"
+
+// ReportDeletionValidator denies deletion of reports annotated with
+// starboard.AnnotationProtected set to "true", unless they're deleted by one
+// of the override users.
+//
+// Deletion by the NamespaceControllerUser is always allowed, otherwise
+// namespaces with protected reports would be stuck terminating. Deletion by
+// the garbage collector, and by other kube-system controllers, is denied so
+// that protected reports of deleted workloads are kept as audit evidence.
+// The operator itself skips protected reports, see kube.IsProtected, rather
+// than deleting them.
+//
+// Kubernetes does not pass request headers to admission webhooks, therefore
+// the override is based on the username of the request, which can be set by
+// impersonation, e.g. with kubectl delete --as.
+type ReportDeletionValidator struct {
+	Logger        logr.Logger
+	OverrideUsers []string
+}
+
+// Handle implements admission.Handler.
+func (v *ReportDeletionValidator) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Delete || len(req.OldObject.Raw) == 0 {
+		return admission.Allowed("")
+	}
+	var report metav1.PartialObjectMetadata
+	err := json.Unmarshal(req.OldObject.Raw, &report)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if report.Annotations[starboard.AnnotationProtected] != "true" {
+		return admission.Allowed("")
+	}
+	log := v.Logger.WithValues("kind", req.Kind.Kind, "name", req.Name, "namespace", req.Namespace,
+		"user", req.UserInfo.Username)
+	if req.UserInfo.Username == NamespaceControllerUser {
+		log.V(1).Info("Allowing deletion of protected report in terminating namespace")
+		return admission.Allowed("protected report deleted with its namespace")
+	}
+	for _, user := range v.OverrideUsers {
+		if req.UserInfo.Username == user {
+			log.Info("Allowing deletion of protected report by override user")
+			return admission.Allowed("protected report deleted by override user")
+		}
+	}
+	log.V(1).Info("Denying deletion of protected report")
+	return admission.Denied(fmt.Sprintf("%s %s is protected by the %s=true annotation, remove the annotation before deleting it",
+		req.Kind.Kind, req.Name, starboard.AnnotationProtected))
+}
@@ -0,0 +1,109 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/webhook"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func newDeleteRequest(t *testing.T, annotations map[string]string, username string) admission.Request {
+	t.Helper()
+	report := v1alpha1.VulnerabilityReport{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       v1alpha1.VulnerabilityReportKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "replicaset-nginx-6d4cf56db6-nginx",
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+	}
+	raw, err := json.Marshal(report)
+	require.NoError(t, err)
+	return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: admissionv1.Delete,
+		Kind:      metav1.GroupVersionKind{Group: "aquasecurity.github.io", Version: "v1alpha1", Kind: v1alpha1.VulnerabilityReportKind},
+		Name:      report.Name,
+		Namespace: report.Namespace,
+		UserInfo:  authenticationv1.UserInfo{Username: username},
+		OldObject: runtime.RawExtension{Raw: raw},
+	}}
+}
+
+func TestReportDeletionValidator(t *testing.T) {
+	validator := &webhook.ReportDeletionValidator{
+		Logger:        logr.Discard(),
+		OverrideUsers: []string{"auditor"},
+	}
+	protected := map[string]string{starboard.AnnotationProtected: "true"}
+
+	testCases := []struct {
+		name    string
+		request admission.Request
+		allowed bool
+	}{
+		{
+			name:    "Should allow deletion of unprotected report",
+			request: newDeleteRequest(t, nil, "admin"),
+			allowed: true,
+		},
+		{
+			name:    "Should allow deletion of report whose protection is not true",
+			request: newDeleteRequest(t, map[string]string{starboard.AnnotationProtected: "false"}, "admin"),
+			allowed: true,
+		},
+		{
+			name:    "Should deny deletion of protected report",
+			request: newDeleteRequest(t, protected, "admin"),
+			allowed: false,
+		},
+		{
+			name:    "Should allow deletion of protected report by override user",
+			request: newDeleteRequest(t, protected, "auditor"),
+			allowed: true,
+		},
+		{
+			name:    "Should allow deletion of protected report by namespace controller",
+			request: newDeleteRequest(t, protected, webhook.NamespaceControllerUser),
+			allowed: true,
+		},
+		{
+			name:    "Should deny deletion of protected report by garbage collector",
+			request: newDeleteRequest(t, protected, "system:serviceaccount:kube-system:generic-garbage-collector"),
+			allowed: false,
+		},
+		{
+			name: "Should allow operations other than deletion",
+			request: admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+			}},
+			allowed: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := validator.Handle(context.TODO(), tc.request)
+			assert.Equal(t, tc.allowed, response.Allowed)
+		})
+	}
+
+	t.Run("Should explain denial", func(t *testing.T) {
+		response := validator.Handle(context.TODO(), newDeleteRequest(t, protected, "admin"))
+		require.NotNil(t, response.Result)
+		assert.Equal(t, "VulnerabilityReport replicaset-nginx-6d4cf56db6-nginx is protected by the starboard.aquasecurity.github.io/protected=true annotation, remove the annotation before deleting it",
+			string(response.Result.Reason))
+	})
+}
@@ -133,9 +133,8 @@ func NewPlugin(
 	}
 }
 
-func (s *plugin) Init(_ starboard.PluginContext) error {
-	// Do nothing
-	return nil
+func (s *plugin) Init(ctx starboard.PluginContext) error {
+	return starboard.VerifyPluginImages(ctx)
 }
 
 func (s *plugin) GetScanJobSpec(ctx starboard.PluginContext, object client.Object,
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
@@ -28,7 +29,7 @@ const (
 const (
 	containerName        = "conftest"
 	workloadKey          = "starboard.workload.yaml"
-	defaultCheckCategory = "Security"
+	defaultCheckCategory = "Custom Policy"
 )
 
 const (
@@ -41,6 +42,7 @@ const (
 	keyPrefixLibrary           = "conftest.library."
 	keySuffixKinds             = ".kinds"
 	keySuffixRego              = ".rego"
+	keySuffixCategory          = ".category"
 )
 
 const (
@@ -48,6 +50,16 @@ const (
 	kindWorkload = "Workload"
 )
 
+const (
+	policyDir  = "/project/policy/"
+	libraryDir = "/project/lib/"
+
+	// policyMarker precedes the output of Conftest evaluating a single policy.
+	policyMarker = "--- policy: "
+	// errorMarker follows the output of Conftest which failed to evaluate a policy.
+	errorMarker = "--- error"
+)
+
 // Config defines configuration params for this plugin.
 type Config struct {
 	starboard.PluginConfig
@@ -106,6 +118,42 @@ func (c Config) GetPoliciesByKind(kind string) (map[string]string, error) {
 	return policies, nil
 }
 
+var regoPackageRegexp = regexp.MustCompile(`(?m)^\s*package\s+([\w.]+)`)
+
+// GetCategoriesByNamespace returns categories defined with the
+// conftest.policy.<name>.category keys indexed by the Rego package of the
+// conftest.policy.<name>.rego policy. The Rego package is reported as the
+// namespace of Conftest results.
+func (c Config) GetCategoriesByNamespace() map[string]string {
+	categories := make(map[string]string)
+	for key, value := range c.Data {
+		if !strings.HasPrefix(key, keyPrefixPolicy) || !strings.HasSuffix(key, keySuffixCategory) {
+			continue
+		}
+		policy, ok := c.Data[strings.TrimSuffix(key, keySuffixCategory)+keySuffixRego]
+		if !ok {
+			continue
+		}
+		matches := regoPackageRegexp.FindStringSubmatch(policy)
+		if len(matches) < 2 {
+			continue
+		}
+		categories[matches[1]] = value
+	}
+	return categories
+}
+
+// GetCategoryByPolicy returns the category defined with the
+// conftest.policy.<name>.category key for the specified policy file, e.g.
+// <name>.rego, or the default category.
+func (c Config) GetCategoryByPolicy(policy string) string {
+	key := keyPrefixPolicy + strings.TrimSuffix(policy, keySuffixRego) + keySuffixCategory
+	if value, ok := c.Data[key]; ok && value != "" {
+		return value
+	}
+	return defaultCheckCategory
+}
+
 // GetResourceRequirements constructs ResourceRequirements from the Config.
 func (c Config) GetResourceRequirements() (corev1.ResourceRequirements, error) {
 	requirements := corev1.ResourceRequirements{
@@ -207,7 +255,7 @@ func (p *plugin) IsApplicable(ctx starboard.PluginContext, obj client.Object) (b
 }
 
 func (p *plugin) Init(ctx starboard.PluginContext) error {
-	return ctx.EnsureConfig(starboard.PluginConfig{
+	err := ctx.EnsureConfig(starboard.PluginConfig{
 		Data: map[string]string{
 			keyImageRef:                "openpolicyagent/conftest:v0.30.0",
 			keyResourcesRequestsCPU:    "50m",
@@ -216,6 +264,10 @@ func (p *plugin) Init(ctx starboard.PluginContext) error {
 			keyResourcesLimitsMemory:   "300M",
 		},
 	})
+	if err != nil {
+		return err
+	}
+	return starboard.VerifyPluginImages(ctx)
 }
 
 func (p *plugin) ConfigHash(ctx starboard.PluginContext, kind kube.Kind) (string, error) {
@@ -251,9 +303,15 @@ func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, obj client.Object)
 	secretName := configauditreport.GetScanJobName(obj) + "-volume"
 	secretData := make(map[string]string)
 
+	withLibraries := false
 	for module, script := range modules {
 		moduleName := strings.TrimPrefix(module, keyPrefixPolicy)
-		moduleName = strings.TrimPrefix(moduleName, keyPrefixLibrary)
+		moduleDir := policyDir
+		if strings.HasPrefix(module, keyPrefixLibrary) {
+			moduleName = strings.TrimPrefix(module, keyPrefixLibrary)
+			moduleDir = libraryDir
+			withLibraries = true
+		}
 
 		// Copy policies so even if the starboard-conftest-config ConfigMap has changed
 		// before the scan Job is run, it won't fail with references to non-existent config key error.
@@ -266,7 +324,7 @@ func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, obj client.Object)
 
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
 			Name:      secretName,
-			MountPath: "/project/policy/" + moduleName,
+			MountPath: moduleDir + moduleName,
 			SubPath:   moduleName,
 			ReadOnly:  true,
 		})
@@ -324,7 +382,7 @@ func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, obj client.Object)
 					// TODO Follow up with Conftest maintainers to allow returning 0 exit code in case of failures
 					Args: []string{
 						"-c",
-						"conftest test --no-fail --output json --all-namespaces --policy /project/policy /project/workload.yaml",
+						scanScript(withLibraries),
 					},
 					SecurityContext: &corev1.SecurityContext{
 						Privileged:               pointer.BoolPtr(false),
@@ -346,6 +404,20 @@ func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, obj client.Object)
 		}}, nil
 }
 
+// scanScript returns the shell script which evaluates each policy separately,
+// so that an error evaluating one policy doesn't suppress results of other
+// policies. The output of each evaluation is preceded by the policyMarker line,
+// and followed by the errorMarker line if Conftest failed.
+func scanScript(withLibraries bool) string {
+	libraries := ""
+	if withLibraries {
+		libraries = "--policy " + libraryDir + " "
+	}
+	return fmt.Sprintf(`for policy in %[1]s*; do [ -e "$policy" ] || continue; echo "%[2]s${policy##*/}"; `+
+		`conftest test --no-fail --output json --all-namespaces %[3]s--policy "$policy" /project/workload.yaml 2>&1 || echo "%[4]s"; done`,
+		policyDir, policyMarker, libraries, errorMarker)
+}
+
 func (p *plugin) modulesByKind(config Config, kind string) (map[string]string, error) {
 	modules, err := config.GetPoliciesByKind(kind)
 	if err != nil {
@@ -366,11 +438,45 @@ func (p *plugin) ParseConfigAuditReportData(ctx starboard.PluginContext, logsRea
 	if err != nil {
 		return v1alpha1.ConfigAuditReportData{}, fmt.Errorf("constructing config from plugin context: %w", err)
 	}
-	var checkResults []CheckResult
-	err = json.NewDecoder(logsReader).Decode(&checkResults)
+	output, err := io.ReadAll(logsReader)
+	if err != nil {
+		return v1alpha1.ConfigAuditReportData{}, fmt.Errorf("reading conftest output: %w", err)
+	}
 
 	checks := make([]v1alpha1.Check, 0)
-	var lowCount, criticalCount int
+	var lowCount, criticalCount, errorCount int
+	categories := config.GetCategoriesByNamespace()
+
+	var checkResults []CheckResult
+	policyOutputs, ok := splitPolicyOutputs(output)
+	if !ok {
+		// Output of scan jobs which evaluated all policies at once
+		err = json.Unmarshal(output, &checkResults)
+		if err != nil {
+			return v1alpha1.ConfigAuditReportData{}, fmt.Errorf("decoding conftest output: %w", err)
+		}
+	}
+	for _, po := range policyOutputs {
+		if !po.failed {
+			var results []CheckResult
+			if err := json.Unmarshal([]byte(po.output), &results); err == nil {
+				checkResults = append(checkResults, results...)
+				continue
+			}
+		}
+		message := strings.TrimSpace(po.output)
+		if message == "" {
+			message = "conftest exited without output"
+		}
+		checks = append(checks, v1alpha1.Check{
+			ID:       strings.TrimSuffix(po.policy, keySuffixRego),
+			Severity: v1alpha1.SeverityUnknown,
+			Category: config.GetCategoryByPolicy(po.policy),
+			Success:  false,
+			Error:    message,
+		})
+		errorCount++
+	}
 
 	for _, cr := range checkResults {
 
@@ -379,7 +485,7 @@ func (p *plugin) ParseConfigAuditReportData(ctx starboard.PluginContext, logsRea
 				ID:       p.getPolicyTitleFromResult(warning),
 				Severity: v1alpha1.SeverityLow,
 				Messages: []string{warning.Message},
-				Category: defaultCheckCategory,
+				Category: p.getCategoryFromResult(warning, cr.Namespace, categories),
 				Success:  false,
 			})
 			lowCount++
@@ -390,7 +496,7 @@ func (p *plugin) ParseConfigAuditReportData(ctx starboard.PluginContext, logsRea
 				ID:       p.getPolicyTitleFromResult(failure),
 				Severity: v1alpha1.SeverityCritical,
 				Messages: []string{failure.Message},
-				Category: defaultCheckCategory,
+				Category: p.getCategoryFromResult(failure, cr.Namespace, categories),
 				Success:  false,
 			})
 			criticalCount++
@@ -417,6 +523,7 @@ func (p *plugin) ParseConfigAuditReportData(ctx starboard.PluginContext, logsRea
 		Summary: v1alpha1.ConfigAuditSummary{
 			CriticalCount: criticalCount,
 			LowCount:      lowCount,
+			ErrorCount:    errorCount,
 		},
 		Checks: checks,
 		// TODO Deprecate PodChecks and ContainerChecks in 0.12+
@@ -425,6 +532,41 @@ func (p *plugin) ParseConfigAuditReportData(ctx starboard.PluginContext, logsRea
 	}, nil
 }
 
+// policyOutput is the output of Conftest evaluating a single policy.
+type policyOutput struct {
+	policy string
+	output string
+	failed bool
+}
+
+// splitPolicyOutputs splits the output of the scanScript into outputs of
+// evaluating each policy. It returns false if the output was not produced
+// by the scanScript, i.e. the output is not empty and it does not start with
+// the policyMarker line.
+func splitPolicyOutputs(output []byte) ([]policyOutput, bool) {
+	lines := strings.Split(string(output), "\n")
+	var outputs []policyOutput
+	for _, line := range lines {
+		if strings.HasPrefix(line, policyMarker) {
+			outputs = append(outputs, policyOutput{policy: strings.TrimPrefix(line, policyMarker)})
+			continue
+		}
+		if len(outputs) == 0 {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			return nil, false
+		}
+		current := &outputs[len(outputs)-1]
+		if line == errorMarker {
+			current.failed = true
+			continue
+		}
+		current.output += line + "\n"
+	}
+	return outputs, true
+}
+
 func (p *plugin) getPolicyTitleFromResult(result Result) string {
 	// we check 1st if id exist
 	if value, ok := result.Metadata["id"]; ok {
@@ -438,6 +580,19 @@ func (p *plugin) getPolicyTitleFromResult(result Result) string {
 	return p.idGenerator.GenerateID()
 }
 
+// getCategoryFromResult returns the category from the result metadata, falls
+// back to the category configured for the policy, and finally to the default
+// category.
+func (p *plugin) getCategoryFromResult(result Result, namespace string, categories map[string]string) string {
+	if value, ok := result.Metadata["category"].(string); ok && value != "" {
+		return value
+	}
+	if value, ok := categories[namespace]; ok && value != "" {
+		return value
+	}
+	return defaultCheckCategory
+}
+
 func (p *plugin) newConfigFrom(ctx starboard.PluginContext) (Config, error) {
 	pluginConfig, err := ctx.GetConfig()
 	if err != nil {
@@ -427,13 +427,13 @@ func TestPlugin_GetScanJobSpec(t *testing.T) {
 				"VolumeMounts": ConsistOf(
 					corev1.VolumeMount{
 						Name:      "scan-configauditreport-789cbb5cc4-volume",
-						MountPath: "/project/policy/kubernetes.rego",
+						MountPath: "/project/lib/kubernetes.rego",
 						SubPath:   "kubernetes.rego",
 						ReadOnly:  true,
 					},
 					corev1.VolumeMount{
 						Name:      "scan-configauditreport-789cbb5cc4-volume",
-						MountPath: "/project/policy/utils.rego",
+						MountPath: "/project/lib/utils.rego",
 						SubPath:   "utils.rego",
 						ReadOnly:  true,
 					},
@@ -461,7 +461,8 @@ func TestPlugin_GetScanJobSpec(t *testing.T) {
 				}),
 				"Args": Equal([]string{
 					"-c",
-					"conftest test --no-fail --output json --all-namespaces --policy /project/policy /project/workload.yaml",
+					`for policy in /project/policy/*; do [ -e "$policy" ] || continue; echo "--- policy: ${policy##*/}"; ` +
+						`conftest test --no-fail --output json --all-namespaces --policy /project/lib/ --policy "$policy" /project/workload.yaml 2>&1 || echo "--- error"; done`,
 				}),
 				"SecurityContext": Equal(&corev1.SecurityContext{
 					Privileged:               pointer.BoolPtr(false),
@@ -575,35 +576,35 @@ func TestPlugin_ParseConfigAuditReportData(t *testing.T) {
 					Messages: []string{"container kubedns of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container dnsmasq of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop": Equal(v1alpha1.Check{
 					ID:       "Default capabilities: some containers do not drop all",
 					Messages: []string{"container dnsmasq of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container sidecar of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop": Equal(v1alpha1.Check{
 					ID:       "Default capabilities: some containers do not drop all",
 					Messages: []string{"container sidecar of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container prometheus-to-sd of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop": Equal(v1alpha1.Check{
 					ID:       "Default capabilities: some containers do not drop all",
 					Messages: []string{"container prometheus-to-sd of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container dnsmasq of deployment kube-dns in default namespace should set securityContext.readOnlyRootFilesystem to true": Equal(v1alpha1.Check{
 					ID:       "Root file system is not read-only",
 					Messages: []string{"container dnsmasq of deployment kube-dns in default namespace should set securityContext.readOnlyRootFilesystem to true"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container prometheus-to-sd of deployment kube-dns in default namespace should set resources.requests.cpu": Equal(v1alpha1.Check{
 					// If the author of a Rego script does not provide the title property
@@ -613,7 +614,7 @@ func TestPlugin_ParseConfigAuditReportData(t *testing.T) {
 					Messages: []string{"container prometheus-to-sd of deployment kube-dns in default namespace should set resources.requests.cpu"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 			}),
 			// Most Rego scripts do not return structured response object to indicate
@@ -625,35 +626,35 @@ func TestPlugin_ParseConfigAuditReportData(t *testing.T) {
 					Messages: []string{"container kubedns of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container dnsmasq of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop": Equal(v1alpha1.Check{
 					ID:       "Default capabilities: some containers do not drop all",
 					Messages: []string{"container dnsmasq of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container sidecar of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop": Equal(v1alpha1.Check{
 					ID:       "Default capabilities: some containers do not drop all",
 					Messages: []string{"container sidecar of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container prometheus-to-sd of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop": Equal(v1alpha1.Check{
 					ID:       "Default capabilities: some containers do not drop all",
 					Messages: []string{"container prometheus-to-sd of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container dnsmasq of deployment kube-dns in default namespace should set securityContext.readOnlyRootFilesystem to true": Equal(v1alpha1.Check{
 					ID:       "Root file system is not read-only",
 					Messages: []string{"container dnsmasq of deployment kube-dns in default namespace should set securityContext.readOnlyRootFilesystem to true"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container prometheus-to-sd of deployment kube-dns in default namespace should set resources.requests.cpu": Equal(v1alpha1.Check{
 					// If the author of a Rego script does not provide the title property
@@ -663,7 +664,7 @@ func TestPlugin_ParseConfigAuditReportData(t *testing.T) {
 					Messages: []string{"container prometheus-to-sd of deployment kube-dns in default namespace should set resources.requests.cpu"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 			}),
 		}))
@@ -717,35 +718,35 @@ func TestPlugin_ParseConfigAuditReportData(t *testing.T) {
 					Messages: []string{"container kubedns of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container dnsmasq of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop": Equal(v1alpha1.Check{
 					ID:       "KSV003",
 					Messages: []string{"container dnsmasq of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container sidecar of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop": Equal(v1alpha1.Check{
 					ID:       "KSV003",
 					Messages: []string{"container sidecar of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container prometheus-to-sd of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop": Equal(v1alpha1.Check{
 					ID:       "KSV003",
 					Messages: []string{"container prometheus-to-sd of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container dnsmasq of deployment kube-dns in default namespace should set securityContext.readOnlyRootFilesystem to true": Equal(v1alpha1.Check{
 					ID:       "KSV014",
 					Messages: []string{"container dnsmasq of deployment kube-dns in default namespace should set securityContext.readOnlyRootFilesystem to true"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container prometheus-to-sd of deployment kube-dns in default namespace should set resources.requests.cpu": Equal(v1alpha1.Check{
 					// If the author of a Rego script does not provide the title property
@@ -755,7 +756,7 @@ func TestPlugin_ParseConfigAuditReportData(t *testing.T) {
 					Messages: []string{"container prometheus-to-sd of deployment kube-dns in default namespace should set resources.requests.cpu"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 			}),
 			// Most Rego scripts do not return structured response object to indicate
@@ -767,35 +768,35 @@ func TestPlugin_ParseConfigAuditReportData(t *testing.T) {
 					Messages: []string{"container kubedns of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container dnsmasq of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop": Equal(v1alpha1.Check{
 					ID:       "KSV003",
 					Messages: []string{"container dnsmasq of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container sidecar of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop": Equal(v1alpha1.Check{
 					ID:       "KSV003",
 					Messages: []string{"container sidecar of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container prometheus-to-sd of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop": Equal(v1alpha1.Check{
 					ID:       "KSV003",
 					Messages: []string{"container prometheus-to-sd of deployment kube-dns in default namespace should add 'ALL' to securityContext.capabilities.drop"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container dnsmasq of deployment kube-dns in default namespace should set securityContext.readOnlyRootFilesystem to true": Equal(v1alpha1.Check{
 					ID:       "KSV014",
 					Messages: []string{"container dnsmasq of deployment kube-dns in default namespace should set securityContext.readOnlyRootFilesystem to true"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 				"container prometheus-to-sd of deployment kube-dns in default namespace should set resources.requests.cpu": Equal(v1alpha1.Check{
 					// If the author of a Rego script does not provide the title property
@@ -805,11 +806,104 @@ func TestPlugin_ParseConfigAuditReportData(t *testing.T) {
 					Messages: []string{"container prometheus-to-sd of deployment kube-dns in default namespace should set resources.requests.cpu"},
 					Success:  false,
 					Severity: v1alpha1.SeverityCritical,
-					Category: "Security",
+					Category: "Custom Policy",
 				}),
 			}),
 		}))
 	})
+	t.Run("data with mixed categorized and uncategorized results", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		plugin := conftest.NewPlugin(ext.NewSimpleIDGenerator(), fixedClock)
+		logsReaderByte, err := ioutil.ReadFile("./testdata/fixture/config_audit_log_reader_with_category.json")
+		g.Expect(err).ToNot(HaveOccurred())
+		logsReader := ioutil.NopCloser(strings.NewReader(string(logsReaderByte)))
+		pluginContext := starboard.NewPluginContext().
+			WithName(conftest.Plugin).
+			WithNamespace("starboard-ns").
+			WithServiceAccountName("starboard-sa").
+			WithClient(fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "starboard-conftest-config",
+					Namespace: "starboard-ns",
+				},
+				Data: map[string]string{
+					"conftest.imageRef":                     "openpolicyagent/conftest:v0.30.0",
+					"conftest.policy.host_network.kinds":    "Workload",
+					"conftest.policy.host_network.rego":     "package appshield.kubernetes.KSV009\n",
+					"conftest.policy.host_network.category": "Host Access",
+					"conftest.policy.host_pid.kinds":        "Workload",
+					"conftest.policy.host_pid.rego":         "package appshield.kubernetes.KSV010\n",
+					"conftest.policy.host_pid.category":     "Host Access",
+				},
+			}).Build()).
+			Get()
+
+		data, err := plugin.ParseConfigAuditReportData(pluginContext, logsReader)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		categoriesByID := make(map[string]string)
+		for _, check := range data.Checks {
+			categoriesByID[check.ID] = check.Category
+		}
+		g.Expect(categoriesByID).To(Equal(map[string]string{
+			// category set in the deny object takes precedence over plugin config
+			"KSV009":     "Networking",
+			"KSV010":     "Host Access",
+			"CPU_LIMITS": "Custom Policy",
+		}))
+	})
+	t.Run("data with policy evaluation errors", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		plugin := conftest.NewPlugin(ext.NewSimpleIDGenerator(), fixedClock)
+		logsReaderByte, err := ioutil.ReadFile("./testdata/fixture/config_audit_log_reader_with_errors.txt")
+		g.Expect(err).ToNot(HaveOccurred())
+		logsReader := ioutil.NopCloser(strings.NewReader(string(logsReaderByte)))
+		pluginContext := starboard.NewPluginContext().
+			WithName(conftest.Plugin).
+			WithNamespace("starboard-ns").
+			WithServiceAccountName("starboard-sa").
+			WithClient(fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "starboard-conftest-config",
+					Namespace: "starboard-ns",
+				},
+				Data: map[string]string{
+					"conftest.imageRef":                          "openpolicyagent/conftest:v0.30.0",
+					"conftest.policy.configmap_volumes.kinds":    "Workload",
+					"conftest.policy.configmap_volumes.rego":     "package starboard.volumes\n",
+					"conftest.policy.configmap_volumes.category": "Volumes",
+				},
+			}).Build()).
+			Get()
+
+		data, err := plugin.ParseConfigAuditReportData(pluginContext, logsReader)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(data.Summary).To(Equal(v1alpha1.ConfigAuditSummary{
+			CriticalCount: 1,
+			LowCount:      1,
+			ErrorCount:    1,
+		}))
+		g.Expect(data.Checks).To(ConsistOf(
+			v1alpha1.Check{
+				ID:       "configmap_volumes",
+				Severity: v1alpha1.SeverityUnknown,
+				Category: "Volumes",
+				Error:    "Error: running test: query rule: eval_type_error: object.get: operand 1 must be object but got null",
+			},
+			v1alpha1.Check{
+				ID:       "KSV010",
+				Severity: v1alpha1.SeverityCritical,
+				Category: "Custom Policy",
+				Messages: []string{"deployment nginx in default namespace should not set spec.template.spec.hostPID to true"},
+			},
+			v1alpha1.Check{
+				ID:       "CPU_LIMITS",
+				Severity: v1alpha1.SeverityLow,
+				Category: "Custom Policy",
+				Messages: []string{"container nginx of deployment nginx in default namespace should set resources.limits.cpu"},
+			},
+		))
+	})
 }
 func TestPlugin_ConfigHash(t *testing.T) {
 
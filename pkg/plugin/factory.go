@@ -27,6 +27,7 @@ type Resolver struct {
 	namespace          string
 	serviceAccountName string
 	client             client.Client
+	imagePolicy        starboard.ImagePolicy
 }
 
 func NewResolver() *Resolver {
@@ -58,6 +59,13 @@ func (r *Resolver) WithClient(client client.Client) *Resolver {
 	return r
 }
 
+// WithImagePolicy sets the starboard.ImagePolicy which images of plugins are
+// verified against.
+func (r *Resolver) WithImagePolicy(policy starboard.ImagePolicy) *Resolver {
+	r.imagePolicy = policy
+	return r
+}
+
 // GetVulnerabilityPlugin is a factory method that instantiates the vulnerabilityreport.Plugin.
 //
 // Starboard currently supports Trivy scanner in Standalone and ClientServer
@@ -76,6 +84,7 @@ func (r *Resolver) GetVulnerabilityPlugin() (vulnerabilityreport.Plugin, starboa
 		WithServiceAccountName(r.serviceAccountName).
 		WithClient(r.client).
 		WithStarboardConfig(r.config).
+		WithImagePolicy(r.imagePolicy).
 		Get()
 
 	switch scanner {
@@ -103,6 +112,8 @@ func (r *Resolver) GetConfigAuditPlugin() (configauditreport.Plugin, starboard.P
 		WithNamespace(r.namespace).
 		WithServiceAccountName(r.serviceAccountName).
 		WithClient(r.client).
+		WithImagePolicy(r.imagePolicy).
+		WithStarboardConfig(r.config).
 		Get()
 
 	switch scanner {
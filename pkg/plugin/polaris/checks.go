@@ -0,0 +1,52 @@
+package polaris
+
+import (
+	"sort"
+)
+
+// checkIDAliases maps IDs of checks renamed between versions of Polaris to
+// canonical Starboard check IDs, which are the IDs reported by the default
+// version of Polaris. Checks are recorded with canonical IDs in reports, so
+// that dashboards and compliance specs keyed on IDs keep working after an
+// upgrade of Polaris.
+var checkIDAliases = map[string]string{
+	"cpuRequestsNotSet":         "cpuRequestsMissing",
+	"cpuLimitsNotSet":           "cpuLimitsMissing",
+	"memoryRequestsNotSet":      "memoryRequestsMissing",
+	"memoryLimitsNotSet":        "memoryLimitsMissing",
+	"notReadOnlyRootFileSystem": "notReadOnlyRootFilesystem",
+}
+
+// CanonicalCheckID returns the canonical Starboard ID of the check with the
+// specified ID reported by Polaris. IDs without aliases are returned as is.
+func CanonicalCheckID(scannerCheckID string) string {
+	if id, ok := checkIDAliases[scannerCheckID]; ok {
+		return id
+	}
+	return scannerCheckID
+}
+
+// CheckAlias describes a canonical Starboard check ID and the IDs reported for
+// the same check by other versions of Polaris.
+type CheckAlias struct {
+	ID      string   `json:"id"`
+	Aliases []string `json:"aliases"`
+}
+
+// CheckAliases returns the catalog of Polaris checks with aliases sorted by
+// canonical ID.
+func CheckAliases() []CheckAlias {
+	aliasesByID := make(map[string][]string)
+	for alias, id := range checkIDAliases {
+		aliasesByID[id] = append(aliasesByID[id], alias)
+	}
+	catalog := make([]CheckAlias, 0, len(aliasesByID))
+	for id, aliases := range aliasesByID {
+		sort.Strings(aliases)
+		catalog = append(catalog, CheckAlias{ID: id, Aliases: aliases})
+	}
+	sort.Slice(catalog, func(i, j int) bool {
+		return catalog[i].ID < catalog[j].ID
+	})
+	return catalog
+}
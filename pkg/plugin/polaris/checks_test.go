@@ -0,0 +1,41 @@
+package polaris_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/plugin/polaris"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalCheckID(t *testing.T) {
+	testCases := []struct {
+		scannerCheckID string
+		expected       string
+	}{
+		{scannerCheckID: "cpuRequestsNotSet", expected: "cpuRequestsMissing"},
+		{scannerCheckID: "notReadOnlyRootFileSystem", expected: "notReadOnlyRootFilesystem"},
+		{scannerCheckID: "cpuRequestsMissing", expected: "cpuRequestsMissing"},
+		{scannerCheckID: "tlsSettingsMissing", expected: "tlsSettingsMissing"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.scannerCheckID, func(t *testing.T) {
+			assert.Equal(t, tc.expected, polaris.CanonicalCheckID(tc.scannerCheckID))
+		})
+	}
+}
+
+func TestCheckAliases(t *testing.T) {
+	catalog := polaris.CheckAliases()
+	assert.Equal(t, []polaris.CheckAlias{
+		{ID: "cpuLimitsMissing", Aliases: []string{"cpuLimitsNotSet"}},
+		{ID: "cpuRequestsMissing", Aliases: []string{"cpuRequestsNotSet"}},
+		{ID: "memoryLimitsMissing", Aliases: []string{"memoryLimitsNotSet"}},
+		{ID: "memoryRequestsMissing", Aliases: []string{"memoryRequestsNotSet"}},
+		{ID: "notReadOnlyRootFilesystem", Aliases: []string{"notReadOnlyRootFileSystem"}},
+	}, catalog)
+	for _, check := range catalog {
+		for _, alias := range check.Aliases {
+			assert.Equal(t, check.ID, polaris.CanonicalCheckID(alias))
+		}
+	}
+}
@@ -18,10 +18,11 @@ type ClusterInfo struct {
 }
 
 type Result struct {
-	Name      string    `json:"Name"`
-	Namespace string    `json:"Namespace"`
-	Kind      string    `json:"Kind"`
-	PodResult PodResult `json:"PodResult"`
+	Name      string           `json:"Name"`
+	Namespace string           `json:"Namespace"`
+	Kind      string           `json:"Kind"`
+	Results   map[string]Check `json:"Results"`
+	PodResult *PodResult       `json:"PodResult"`
 }
 
 type PodResult struct {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
@@ -11,6 +12,7 @@ import (
 	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/hashicorp/go-version"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,6 +33,7 @@ const (
 const (
 	keyImageRef                = "polaris.imageRef"
 	keyConfigYaml              = "polaris.config.yaml"
+	keyAuditNonWorkloads       = "polaris.auditNonWorkloads"
 	keyResourcesRequestsCPU    = "polaris.resources.requests.cpu"
 	keyResourcesRequestsMemory = "polaris.resources.requests.memory"
 	keyResourcesLimitsCPU      = "polaris.resources.limits.cpu"
@@ -235,6 +238,34 @@ func (c Config) GetImageRef() (string, error) {
 	return c.GetRequiredData(keyImageRef)
 }
 
+// AuditsNonWorkloads returns true if Polaris can audit non-workload kinds,
+// i.e. Services and Ingresses. It's determined by the optional
+// polaris.auditNonWorkloads key, or by the version of Polaris from the image
+// reference. Unless the key is set, images tagged with anything but a 5.0+
+// version, e.g. latest or a digest, are considered not capable.
+func (c Config) AuditsNonWorkloads() (bool, error) {
+	if value, ok := c.Data[keyAuditNonWorkloads]; ok {
+		capable, err := strconv.ParseBool(value)
+		if err != nil {
+			return false, fmt.Errorf("parsing %s: %w", keyAuditNonWorkloads, err)
+		}
+		return capable, nil
+	}
+	imageRef, err := c.GetImageRef()
+	if err != nil {
+		return false, err
+	}
+	tag, err := starboard.GetVersionFromImageRef(imageRef)
+	if err != nil {
+		return false, fmt.Errorf("getting version from image ref: %w", err)
+	}
+	v, err := version.NewVersion(tag)
+	if err != nil {
+		return false, nil
+	}
+	return v.Segments()[0] >= minNonWorkloadsMajorVersion, nil
+}
+
 // GetResourceRequirements constructs ResourceRequirements from the Config.
 func (c Config) GetResourceRequirements() (corev1.ResourceRequirements, error) {
 	requirements := corev1.ResourceRequirements{
@@ -299,20 +330,47 @@ var (
 		kube.KindDaemonSet,
 		kube.KindCronJob,
 		kube.KindJob,
+		kube.KindService,
+		kube.KindIngress,
 	}
 )
 
+// polarisOutputVersion is the version of the JSON output format of all
+// supported versions of Polaris.
+const polarisOutputVersion = "1.0"
+
+// minNonWorkloadsMajorVersion is the major version of Polaris that can audit
+// non-workload kinds.
+const minNonWorkloadsMajorVersion = 5
+
 func (p *plugin) SupportedKinds() []kube.Kind {
 	return supportedKinds
 }
 
-func (p *plugin) IsApplicable(_ starboard.PluginContext, _ client.Object) (bool, string, error) {
+// IsApplicable returns true for workloads. For Services and Ingresses it
+// returns true only if the configured Polaris can audit them, false otherwise.
+func (p *plugin) IsApplicable(ctx starboard.PluginContext, obj client.Object) (bool, string, error) {
+	kind := kube.Kind(obj.GetObjectKind().GroupVersionKind().Kind)
+	if kind != kube.KindService && kind != kube.KindIngress {
+		return true, "", nil
+	}
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return false, "", err
+	}
+	capable, err := config.AuditsNonWorkloads()
+	if err != nil {
+		return false, "", err
+	}
+	if !capable {
+		return false, fmt.Sprintf("auditing kind %s requires Polaris %d.0+", kind, minNonWorkloadsMajorVersion), nil
+	}
 	return true, "", nil
 }
 
 // Init ensures the default Config required by this plugin.
 func (p *plugin) Init(ctx starboard.PluginContext) error {
-	return ctx.EnsureConfig(starboard.PluginConfig{
+	err := ctx.EnsureConfig(starboard.PluginConfig{
 		Data: map[string]string{
 			keyImageRef:                "quay.io/fairwinds/polaris:4.2",
 			keyConfigYaml:              DefaultConfigYAML,
@@ -322,6 +380,10 @@ func (p *plugin) Init(ctx starboard.PluginContext) error {
 			keyResourcesLimitsMemory:   "300M",
 		},
 	})
+	if err != nil {
+		return err
+	}
+	return starboard.VerifyPluginImages(ctx)
 }
 
 func (p *plugin) ConfigHash(ctx starboard.PluginContext, _ kube.Kind) (string, error) {
@@ -420,11 +482,27 @@ func (p *plugin) ParseConfigAuditReportData(ctx starboard.PluginContext, logsRea
 	if err != nil {
 		return v1alpha1.ConfigAuditReportData{}, fmt.Errorf("constructing config from plugin context: %w", err)
 	}
+	// Results are verified against the scanner recorded on the scan job, which
+	// may differ from the configured one if the config changed since then.
+	imageRef, ok := starboard.ScannerImageFrom(ctx)
+	if !ok {
+		imageRef, err = config.GetImageRef()
+		if err != nil {
+			return v1alpha1.ConfigAuditReportData{}, fmt.Errorf("getting image ref: %w", err)
+		}
+	}
 	var report Report
 	err = json.NewDecoder(logsReader).Decode(&report)
 	if err != nil {
 		return v1alpha1.ConfigAuditReportData{}, err
 	}
+	if report.PolarisOutputVersion != polarisOutputVersion {
+		return v1alpha1.ConfigAuditReportData{}, &starboard.ScannerMismatchError{
+			Scanner:  "Polaris",
+			Expected: imageRef,
+			Reason:   fmt.Sprintf("output has PolarisOutputVersion %q, want %q", report.PolarisOutputVersion, polarisOutputVersion),
+		}
+	}
 
 	var checks []v1alpha1.Check
 	var podChecks []v1alpha1.Check
@@ -433,23 +511,44 @@ func (p *plugin) ParseConfigAuditReportData(ctx starboard.PluginContext, logsRea
 	if len(report.Results) != 1 {
 		return v1alpha1.ConfigAuditReportData{}, fmt.Errorf("unexpected report results count, got: %d, want: %d", len(report.Results), 1)
 	}
-	for _, pr := range report.Results[0].PodResult.Results {
+	// Results of non-workload kinds, such as Services and Ingresses, have
+	// checks of the resource itself, but no pod results.
+	for _, rr := range report.Results[0].Results {
+		severity, err := v1alpha1.StringToSeverity(rr.Severity)
+		if err != nil {
+			return v1alpha1.ConfigAuditReportData{}, err
+		}
+		checks = append(checks, v1alpha1.Check{
+			ID:             CanonicalCheckID(rr.ID),
+			ScannerCheckID: rr.ID,
+			Messages:       []string{rr.Message},
+			Success:        rr.Success,
+			Severity:       severity,
+			Category:       rr.Category,
+		})
+	}
+	podResult := report.Results[0].PodResult
+	if podResult == nil {
+		podResult = &PodResult{}
+	}
+	for _, pr := range podResult.Results {
 		severity, err := v1alpha1.StringToSeverity(pr.Severity)
 		if err != nil {
 			return v1alpha1.ConfigAuditReportData{}, err
 		}
 		check := v1alpha1.Check{
-			ID:       pr.ID,
-			Messages: []string{pr.Message},
-			Success:  pr.Success,
-			Severity: severity,
-			Category: pr.Category,
+			ID:             CanonicalCheckID(pr.ID),
+			ScannerCheckID: pr.ID,
+			Messages:       []string{pr.Message},
+			Success:        pr.Success,
+			Severity:       severity,
+			Category:       pr.Category,
 		}
 		checks = append(checks, check)
 		podChecks = append(podChecks, check)
 	}
 
-	for _, cr := range report.Results[0].PodResult.ContainerResults {
+	for _, cr := range podResult.ContainerResults {
 		var containerChecks []v1alpha1.Check
 		for _, crr := range cr.Results {
 			severity, err := v1alpha1.StringToSeverity(crr.Severity)
@@ -457,11 +556,12 @@ func (p *plugin) ParseConfigAuditReportData(ctx starboard.PluginContext, logsRea
 				return v1alpha1.ConfigAuditReportData{}, err
 			}
 			containerChecks = append(containerChecks, v1alpha1.Check{
-				ID:       crr.ID,
-				Messages: []string{crr.Message},
-				Success:  crr.Success,
-				Severity: severity,
-				Category: crr.Category,
+				ID:             CanonicalCheckID(crr.ID),
+				ScannerCheckID: crr.ID,
+				Messages:       []string{crr.Message},
+				Success:        crr.Success,
+				Severity:       severity,
+				Category:       crr.Category,
 				Scope: &v1alpha1.CheckScope{
 					Type:  "Container",
 					Value: cr.Name,
@@ -473,11 +573,6 @@ func (p *plugin) ParseConfigAuditReportData(ctx starboard.PluginContext, logsRea
 		containerNameToChecks[cr.Name] = containerChecks
 	}
 
-	imageRef, err := config.GetImageRef()
-	if err != nil {
-		return v1alpha1.ConfigAuditReportData{}, fmt.Errorf("getting image ref: %w", err)
-	}
-
 	version, err := starboard.GetVersionFromImageRef(imageRef)
 	if err != nil {
 		return v1alpha1.ConfigAuditReportData{}, fmt.Errorf("getting version from image ref: %w", err)
@@ -489,7 +584,7 @@ func (p *plugin) ParseConfigAuditReportData(ctx starboard.PluginContext, logsRea
 			Vendor:  "Fairwinds Ops",
 			Version: version,
 		},
-		Summary:         p.configAuditSummaryFrom(podChecks, containerNameToChecks),
+		Summary:         v1alpha1.ConfigAuditSummaryFromChecks(checks),
 		UpdateTimestamp: metav1.NewTime(p.clock.Now()),
 		Checks:          checks,
 		// TODO Deprecate PodChecks and ContainerChecks in 0.12+
@@ -512,32 +607,3 @@ func (p *plugin) sourceNameFrom(obj client.Object) string {
 		obj.GetName(),
 	)
 }
-
-func (p *plugin) configAuditSummaryFrom(podChecks []v1alpha1.Check, containerChecks map[string][]v1alpha1.Check) v1alpha1.ConfigAuditSummary {
-	var summary v1alpha1.ConfigAuditSummary
-	for _, c := range podChecks {
-		if c.Success {
-			continue
-		}
-		switch c.Severity {
-		case v1alpha1.SeverityCritical:
-			summary.CriticalCount++
-		case v1alpha1.SeverityLow:
-			summary.LowCount++
-		}
-	}
-	for _, checks := range containerChecks {
-		for _, c := range checks {
-			if c.Success {
-				continue
-			}
-			switch c.Severity {
-			case v1alpha1.SeverityCritical:
-				summary.CriticalCount++
-			case v1alpha1.SeverityLow:
-				summary.LowCount++
-			}
-		}
-	}
-	return summary
-}
@@ -102,9 +102,90 @@ func TestConfig_GetResourceRequirements(t *testing.T) {
 	}
 }
 
+func TestConfig_AuditsNonWorkloads(t *testing.T) {
+	testCases := []struct {
+		name          string
+		data          map[string]string
+		expected      bool
+		expectedError string
+	}{
+		{
+			name:     "Should return false for Polaris 4.x",
+			data:     map[string]string{"polaris.imageRef": "quay.io/fairwinds/polaris:4.2"},
+			expected: false,
+		},
+		{
+			name:     "Should return true for Polaris 5.x",
+			data:     map[string]string{"polaris.imageRef": "quay.io/fairwinds/polaris:5.2"},
+			expected: true,
+		},
+		{
+			name:     "Should return false for image tag which is not a version",
+			data:     map[string]string{"polaris.imageRef": "quay.io/fairwinds/polaris:latest"},
+			expected: false,
+		},
+		{
+			name: "Should prefer config key over image tag",
+			data: map[string]string{
+				"polaris.imageRef":          "quay.io/fairwinds/polaris@sha256:9d1c4a5b0b2f1a3e8f7c6d5e4b3a2f1e0d9c8b7a6f5e4d3c2b1a0f9e8d7c6b5a",
+				"polaris.auditNonWorkloads": "true",
+			},
+			expected: true,
+		},
+		{
+			name: "Should return error when config key is not a bool",
+			data: map[string]string{
+				"polaris.imageRef":          "quay.io/fairwinds/polaris:5.2",
+				"polaris.auditNonWorkloads": "maybe",
+			},
+			expectedError: "parsing polaris.auditNonWorkloads: strconv.ParseBool: parsing \"maybe\": invalid syntax",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := polaris.Config{PluginConfig: starboard.PluginConfig{Data: tc.data}}
+			capable, err := config.AuditsNonWorkloads()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, capable)
+		})
+	}
+}
+
 func TestPlugin_IsApplicable(t *testing.T) {
 
-	t.Run("Should always return true", func(t *testing.T) {
+	newPluginContext := func(imageRef string) starboard.PluginContext {
+		return starboard.NewPluginContext().
+			WithName(polaris.Plugin).
+			WithNamespace("starboard-ns").
+			WithServiceAccountName("starboard-sa").
+			WithClient(fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "starboard-polaris-config",
+					Namespace: "starboard-ns",
+				},
+				Data: map[string]string{
+					"polaris.imageRef": imageRef,
+				},
+			}).Build()).
+			Get()
+	}
+
+	service := &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx",
+			Namespace: "default",
+		},
+	}
+
+	t.Run("Should always return true for workloads", func(t *testing.T) {
 		g := NewGomegaWithT(t)
 
 		client := fake.NewClientBuilder().Build()
@@ -121,6 +202,25 @@ func TestPlugin_IsApplicable(t *testing.T) {
 		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(ready).To(BeTrue())
 	})
+
+	t.Run("Should return true for Service with Polaris 5.x", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		instance := polaris.NewPlugin(fixedClock)
+		ready, _, err := instance.IsApplicable(newPluginContext("quay.io/fairwinds/polaris:5.2"), service)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ready).To(BeTrue())
+	})
+
+	t.Run("Should return false for Service with Polaris 4.x", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		instance := polaris.NewPlugin(fixedClock)
+		ready, reason, err := instance.IsApplicable(newPluginContext("quay.io/fairwinds/polaris:4.2"), service)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ready).To(BeFalse())
+		g.Expect(reason).To(Equal("auditing kind Service requires Polaris 5.0+"))
+	})
 }
 
 func TestPlugin_Init(t *testing.T) {
@@ -381,35 +481,39 @@ func TestPlugin_ParseConfigAuditReportData(t *testing.T) {
 		LowCount:      1,
 	}))
 	g.Expect(result.PodChecks).To(ConsistOf(v1alpha1.Check{
-		ID:       "hostIPCSet",
-		Messages: []string{"Host IPC is not configured"},
-		Success:  false,
-		Severity: v1alpha1.SeverityCritical,
-		Category: "Security",
+		ID:             "hostIPCSet",
+		ScannerCheckID: "hostIPCSet",
+		Messages:       []string{"Host IPC is not configured"},
+		Success:        false,
+		Severity:       v1alpha1.SeverityCritical,
+		Category:       "Security",
 	}, v1alpha1.Check{
-		ID:       "hostNetworkSet",
-		Messages: []string{"Host network is not configured"},
-		Success:  true,
-		Severity: v1alpha1.SeverityLow,
-		Category: "Networking",
+		ID:             "hostNetworkSet",
+		ScannerCheckID: "hostNetworkSet",
+		Messages:       []string{"Host network is not configured"},
+		Success:        true,
+		Severity:       v1alpha1.SeverityLow,
+		Category:       "Networking",
 	}))
 	g.Expect(result.ContainerChecks).To(HaveLen(1))
 	g.Expect(result.ContainerChecks["db"]).To(ConsistOf(v1alpha1.Check{
-		ID:       "cpuLimitsMissing",
-		Messages: []string{"CPU limits are set"},
-		Success:  false,
-		Severity: v1alpha1.SeverityLow,
-		Category: "Resources",
+		ID:             "cpuLimitsMissing",
+		ScannerCheckID: "cpuLimitsMissing",
+		Messages:       []string{"CPU limits are set"},
+		Success:        false,
+		Severity:       v1alpha1.SeverityLow,
+		Category:       "Resources",
 		Scope: &v1alpha1.CheckScope{
 			Type:  "Container",
 			Value: "db",
 		},
 	}, v1alpha1.Check{
-		ID:       "cpuRequestsMissing",
-		Messages: []string{"CPU requests are set"},
-		Success:  true,
-		Severity: v1alpha1.SeverityLow,
-		Category: "Resources",
+		ID:             "cpuRequestsMissing",
+		ScannerCheckID: "cpuRequestsMissing",
+		Messages:       []string{"CPU requests are set"},
+		Success:        true,
+		Severity:       v1alpha1.SeverityLow,
+		Category:       "Resources",
 		Scope: &v1alpha1.CheckScope{
 			Type:  "Container",
 			Value: "db",
@@ -417,6 +521,99 @@ func TestPlugin_ParseConfigAuditReportData(t *testing.T) {
 	}))
 }
 
+func TestPlugin_ParseConfigAuditReportData_Ingress(t *testing.T) {
+	g := NewGomegaWithT(t)
+	testReport, err := os.Open("testdata/polaris-ingress-report.json")
+	g.Expect(err).ToNot(HaveOccurred())
+	defer func() {
+		_ = testReport.Close()
+	}()
+
+	pluginContext := starboard.NewPluginContext().
+		WithName(polaris.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "starboard-polaris-config",
+				Namespace: "starboard-ns",
+			},
+			Data: map[string]string{
+				"polaris.imageRef": "quay.io/fairwinds/polaris:5.2",
+			},
+		}).Build()).
+		Get()
+
+	plugin := polaris.NewPlugin(fixedClock)
+	result, err := plugin.ParseConfigAuditReportData(pluginContext, testReport)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.Scanner.Version).To(Equal("5.2"))
+	g.Expect(result.Summary).To(Equal(v1alpha1.ConfigAuditSummary{
+		LowCount: 1,
+	}))
+	g.Expect(result.Checks).To(ConsistOf(v1alpha1.Check{
+		ID:             "tlsSettingsMissing",
+		ScannerCheckID: "tlsSettingsMissing",
+		Messages:       []string{"Ingress does not have TLS configured"},
+		Success:        false,
+		Severity:       v1alpha1.SeverityLow,
+		Category:       "Security",
+	}))
+	g.Expect(result.PodChecks).To(BeEmpty())
+	g.Expect(result.ContainerChecks).To(BeEmpty())
+}
+
+func TestPlugin_ParseConfigAuditReportData_RenamedChecks(t *testing.T) {
+	g := NewGomegaWithT(t)
+	testReport, err := os.Open("testdata/polaris-renamed-checks-report.json")
+	g.Expect(err).ToNot(HaveOccurred())
+	defer func() {
+		_ = testReport.Close()
+	}()
+
+	pluginContext := starboard.NewPluginContext().
+		WithName(polaris.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "starboard-polaris-config",
+				Namespace: "starboard-ns",
+			},
+			Data: map[string]string{
+				"polaris.imageRef": "quay.io/fairwinds/polaris:5.2",
+			},
+		}).Build()).
+		Get()
+
+	plugin := polaris.NewPlugin(fixedClock)
+	result, err := plugin.ParseConfigAuditReportData(pluginContext, testReport)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.Checks).To(ConsistOf(v1alpha1.Check{
+		ID:             "cpuRequestsMissing",
+		ScannerCheckID: "cpuRequestsNotSet",
+		Messages:       []string{"CPU requests should be set"},
+		Success:        false,
+		Severity:       v1alpha1.SeverityLow,
+		Category:       "Efficiency",
+		Scope: &v1alpha1.CheckScope{
+			Type:  "Container",
+			Value: "nginx",
+		},
+	}, v1alpha1.Check{
+		ID:             "notReadOnlyRootFilesystem",
+		ScannerCheckID: "notReadOnlyRootFileSystem",
+		Messages:       []string{"Filesystem should be read only"},
+		Success:        false,
+		Severity:       v1alpha1.SeverityLow,
+		Category:       "Security",
+		Scope: &v1alpha1.CheckScope{
+			Type:  "Container",
+			Value: "nginx",
+		},
+	}))
+}
+
 func TestPlugin_ConfigHash(t *testing.T) {
 
 	newPluginContextWithConfigData := func(data map[string]string) starboard.PluginContext {
@@ -498,3 +695,32 @@ func TestPlugin_ConfigHash(t *testing.T) {
 		g.Expect(hash1).To(Equal(hash2))
 	})
 }
+
+func TestPlugin_ParseConfigAuditReportData_ScannerMismatch(t *testing.T) {
+	g := NewGomegaWithT(t)
+	testReport, err := os.Open("testdata/polaris-report-output-version-mismatch.json")
+	g.Expect(err).ToNot(HaveOccurred())
+	defer func() {
+		_ = testReport.Close()
+	}()
+
+	pluginContext := starboard.NewPluginContext().
+		WithName(polaris.Plugin).
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "starboard-polaris-config",
+				Namespace: "starboard-ns",
+			},
+			Data: map[string]string{
+				"polaris.imageRef": "quay.io/fairwinds/polaris:5.2",
+			},
+		}).Build()).
+		Get()
+
+	plugin := polaris.NewPlugin(fixedClock)
+	_, err = plugin.ParseConfigAuditReportData(starboard.WithScannerImage(pluginContext, "quay.io/fairwinds/polaris:4.2"), testReport)
+	g.Expect(err).To(MatchError(`Polaris scan results do not match expected scanner quay.io/fairwinds/polaris:4.2: output has PolarisOutputVersion "2.0", want "1.0"`))
+	g.Expect(starboard.IsScannerMismatch(err)).To(BeTrue())
+}
@@ -0,0 +1,211 @@
+package plugin_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/configauditreport"
+	"github.com/aquasecurity/starboard/pkg/plugin"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var nginx = &corev1.Pod{
+	TypeMeta: metav1.TypeMeta{
+		Kind:       "Pod",
+		APIVersion: "v1",
+	},
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "nginx",
+		Namespace: "default",
+	},
+	Spec: corev1.PodSpec{
+		NodeName: "kind-control-plane",
+		InitContainers: []corev1.Container{
+			{Name: "init", Image: "busybox:1.34"},
+		},
+		Containers: []corev1.Container{
+			{Name: "nginx", Image: "nginx:1.16"},
+			{Name: "sidecar", Image: "busybox:1.34"},
+		},
+	},
+}
+
+// assertResourcesComplete asserts that each container and init container of
+// the given pod spec requests and limits CPU, memory and ephemeral storage, so
+// that none of them is defaulted by a LimitRange of the namespace.
+func assertResourcesComplete(t *testing.T, spec corev1.PodSpec) {
+	t.Helper()
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	require.NotEmpty(t, containers)
+	for _, container := range containers {
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage} {
+			request, ok := container.Resources.Requests[name]
+			assert.True(t, ok, "container %s misses %s request", container.Name, name)
+			limit, ok := container.Resources.Limits[name]
+			assert.True(t, ok, "container %s misses %s limit", container.Name, name)
+			assert.LessOrEqual(t, request.Cmp(limit), 0, "container %s requests more %s than its limit", container.Name, name)
+		}
+	}
+}
+
+func newResolver(t *testing.T, config starboard.ConfigData, pluginName string, pluginConfig map[string]string) *plugin.Resolver {
+	t.Helper()
+	var objects []client.Object
+	if pluginConfig != nil {
+		objects = append(objects, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      starboard.GetPluginConfigMapName(pluginName),
+				Namespace: "starboard-system",
+			},
+			Data: pluginConfig,
+		})
+	}
+	return plugin.NewResolver().
+		WithNamespace("starboard-system").
+		WithServiceAccountName("starboard").
+		WithConfig(config).
+		WithClient(fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build())
+}
+
+func TestScanJobResources(t *testing.T) {
+	vulnerabilityPlugins := []struct {
+		name         string
+		pluginName   string
+		pluginConfig map[string]string
+	}{
+		{
+			name:       "Trivy Standalone",
+			pluginName: "Trivy",
+			pluginConfig: map[string]string{
+				"trivy.imageRef":     "docker.io/aquasec/trivy:0.25.2",
+				"trivy.dbRepository": "ghcr.io/aquasecurity/trivy-db",
+				"trivy.mode":         "Standalone",
+			},
+		},
+		{
+			name:       "Trivy ClientServer",
+			pluginName: "Trivy",
+			pluginConfig: map[string]string{
+				"trivy.imageRef":     "docker.io/aquasec/trivy:0.25.2",
+				"trivy.dbRepository": "ghcr.io/aquasecurity/trivy-db",
+				"trivy.mode":         "ClientServer",
+				"trivy.serverURL":    "http://trivy.trivy:4954",
+			},
+		},
+		{
+			name:       "Trivy Standalone filesystem",
+			pluginName: "Trivy",
+			pluginConfig: map[string]string{
+				"trivy.imageRef":     "docker.io/aquasec/trivy:0.25.2",
+				"trivy.dbRepository": "ghcr.io/aquasecurity/trivy-db",
+				"trivy.mode":         "Standalone",
+				"trivy.command":      "filesystem",
+			},
+		},
+		{
+			name:       "Aqua",
+			pluginName: "Aqua",
+			pluginConfig: map[string]string{
+				"aqua.imageRef":                     "docker.io/aquasec/scanner:5.3",
+				"aqua.imageRefStarboardAquaScanner": "docker.io/aquasec/starboard-scanner-aqua:dev",
+				"aqua.serverURL":                    "http://csp-console.aqua:8080",
+			},
+		},
+		{
+			name:       "Aqua filesystem",
+			pluginName: "Aqua",
+			pluginConfig: map[string]string{
+				"aqua.imageRef":                     "docker.io/aquasec/scanner:5.3",
+				"aqua.imageRefStarboardAquaScanner": "docker.io/aquasec/starboard-scanner-aqua:dev",
+				"aqua.serverURL":                    "http://csp-console.aqua:8080",
+				"aqua.command":                      "filesystem",
+			},
+		},
+	}
+	for _, tc := range vulnerabilityPlugins {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := newResolver(t, starboard.ConfigData{"vulnerabilityReports.scanner": tc.pluginName}, tc.pluginName, tc.pluginConfig)
+			vulnerabilityPlugin, pluginContext, err := resolver.GetVulnerabilityPlugin()
+			require.NoError(t, err)
+			require.NoError(t, vulnerabilityPlugin.Init(pluginContext))
+
+			job, _, err := vulnerabilityreport.NewScanJobBuilder().
+				WithPlugin(vulnerabilityPlugin).
+				WithPluginContext(pluginContext).
+				WithObject(nginx).
+				Get()
+			require.NoError(t, err)
+			assertResourcesComplete(t, job.Spec.Template.Spec)
+		})
+	}
+
+	configAuditPlugins := []struct {
+		name         string
+		pluginConfig map[string]string
+	}{
+		{
+			name: "Polaris",
+		},
+		{
+			name: "Conftest",
+			pluginConfig: map[string]string{
+				"conftest.imageRef":                "openpolicyagent/conftest:v0.30.0",
+				"conftest.policy.replicas.rego":    "package main\n",
+				"conftest.policy.replicas.kinds":   "Workload",
+				"conftest.library.kubernetes.rego": "package lib.kubernetes\n",
+			},
+		},
+	}
+	for _, tc := range configAuditPlugins {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := newResolver(t, starboard.ConfigData{"configAuditReports.scanner": tc.name}, tc.name, tc.pluginConfig)
+			configAuditPlugin, pluginContext, err := resolver.GetConfigAuditPlugin()
+			require.NoError(t, err)
+			require.NoError(t, configAuditPlugin.Init(pluginContext))
+
+			job, _, err := configauditreport.NewScanJobBuilder().
+				WithPlugin(configAuditPlugin).
+				WithPluginContext(pluginContext).
+				WithObject(nginx).
+				Get()
+			require.NoError(t, err)
+			assertResourcesComplete(t, job.Spec.Template.Spec)
+		})
+	}
+
+	t.Run("Should honor scanner resources", func(t *testing.T) {
+		resolver := newResolver(t, starboard.ConfigData{
+			"vulnerabilityReports.scanner":                 "Trivy",
+			"scanJob.resources.limits.ephemeral-storage":   "4Gi",
+			"scanJob.resources.requests.ephemeral-storage": "",
+		}, "Trivy", map[string]string{
+			"trivy.imageRef":                  "docker.io/aquasec/trivy:0.25.2",
+			"trivy.dbRepository":              "ghcr.io/aquasecurity/trivy-db",
+			"trivy.mode":                      "Standalone",
+			"trivy.resources.limits.memory":   "1G",
+			"trivy.resources.requests.memory": "800M",
+		})
+		vulnerabilityPlugin, pluginContext, err := resolver.GetVulnerabilityPlugin()
+		require.NoError(t, err)
+
+		job, _, err := vulnerabilityreport.NewScanJobBuilder().
+			WithPlugin(vulnerabilityPlugin).
+			WithPluginContext(pluginContext).
+			WithObject(nginx).
+			Get()
+		require.NoError(t, err)
+		for _, container := range job.Spec.Template.Spec.Containers {
+			assert.Equal(t, "1G", container.Resources.Limits.Memory().String())
+			assert.Equal(t, "800M", container.Resources.Requests.Memory().String())
+			assert.Equal(t, "4Gi", container.Resources.Limits.StorageEphemeral().String())
+			_, ok := container.Resources.Requests[corev1.ResourceEphemeralStorage]
+			assert.False(t, ok)
+		}
+	})
+}
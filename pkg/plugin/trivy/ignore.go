@@ -0,0 +1,147 @@
+package trivy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// NamespaceIgnoreFileConfigMapName is the name of the ConfigMap in the
+	// namespace of scanned workloads whose .trivyignore key lists
+	// vulnerabilities ignored in addition to the ones listed in the global
+	// ignore file configured with the trivy.ignoreFile property.
+	NamespaceIgnoreFileConfigMapName = "starboard-trivy-ignore"
+	keyNamespaceIgnoreFile           = ".trivyignore"
+)
+
+// MergeIgnoreFiles concatenates the content of the specified .trivyignore
+// files. Blank lines, comments and duplicate entries are dropped, and the
+// first entry of a vulnerability wins.
+func MergeIgnoreFiles(contents ...string) string {
+	var merged strings.Builder
+	seen := make(map[string]bool)
+	for _, content := range contents {
+		for _, line := range strings.Split(content, "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 0 || strings.HasPrefix(fields[0], "#") || seen[fields[0]] {
+				continue
+			}
+			seen[fields[0]] = true
+			merged.WriteString(strings.TrimSpace(line))
+			merged.WriteString("\n")
+		}
+	}
+	return merged.String()
+}
+
+// getNamespaceIgnoreFile returns the content of the ignore file held by the
+// NamespaceIgnoreFileConfigMapName ConfigMap in the specified namespace, or
+// false if there's no such ConfigMap.
+func (p *plugin) getNamespaceIgnoreFile(namespace string) (string, bool, error) {
+	if namespace == "" {
+		return "", false, nil
+	}
+	var cm corev1.ConfigMap
+	err := p.objectResolver.Client.Get(context.Background(), client.ObjectKey{
+		Namespace: namespace,
+		Name:      NamespaceIgnoreFileConfigMapName,
+	}, &cm)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("getting configmap %s/%s: %w", namespace, NamespaceIgnoreFileConfigMapName, err)
+	}
+	return cm.Data[keyNamespaceIgnoreFile], true, nil
+}
+
+// ignoreFileVolume returns the volume which holds the .trivyignore file used
+// to scan the specified workload, or nil if no vulnerabilities are ignored.
+//
+// By default, the volume refers to the global ignore file in the plugin
+// ConfigMap. If the namespace of the workload has the
+// NamespaceIgnoreFileConfigMapName ConfigMap, which cannot be mounted by scan
+// jobs running in another namespace, the merged ignore files are copied to
+// the returned secret created with the scan job, which the volume refers to.
+func (p *plugin) ignoreFileVolume(config Config, workload client.Object) (*corev1.Volume, *corev1.Secret, error) {
+	namespaceIgnoreFile, ok, err := p.getNamespaceIgnoreFile(workload.GetNamespace())
+	if err != nil {
+		return nil, nil, err
+	}
+	if ok {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("%s-ignorefile", vulnerabilityreport.GetScanJobName(workload)),
+			},
+			Data: map[string][]byte{
+				keyNamespaceIgnoreFile: []byte(MergeIgnoreFiles(config.Data[keyTrivyIgnoreFile], namespaceIgnoreFile)),
+			},
+		}
+		return &corev1.Volume{
+			Name: ignoreFileVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secret.Name,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  keyNamespaceIgnoreFile,
+							Path: ".trivyignore",
+						},
+					},
+				},
+			},
+		}, secret, nil
+	}
+	if !config.IgnoreFileExists() {
+		return nil, nil, nil
+	}
+	return &corev1.Volume{
+		Name: ignoreFileVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: starboard.GetPluginConfigMapName(Plugin),
+				},
+				Items: []corev1.KeyToPath{
+					{
+						Key:  keyTrivyIgnoreFile,
+						Path: ".trivyignore",
+					},
+				},
+			},
+		},
+	}, nil, nil
+}
+
+// NamespaceConfigMapName implements vulnerabilityreport.NamespaceConfigPlugin.
+func (p *plugin) NamespaceConfigMapName() string {
+	return NamespaceIgnoreFileConfigMapName
+}
+
+// NamespaceConfigHash implements vulnerabilityreport.NamespaceConfigPlugin.
+// It returns the hash of both the global and namespace ignore files, or blank
+// if the specified namespace has no NamespaceIgnoreFileConfigMapName
+// ConfigMap.
+func (p *plugin) NamespaceConfigHash(ctx starboard.PluginContext, namespace string) (string, error) {
+	namespaceIgnoreFile, ok, err := p.getNamespaceIgnoreFile(namespace)
+	if err != nil || !ok {
+		return "", err
+	}
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return "", err
+	}
+	return kube.ComputeHash([]string{
+		kube.ComputeHash(config.Data[keyTrivyIgnoreFile]),
+		kube.ComputeHash(namespaceIgnoreFile),
+	}), nil
+}
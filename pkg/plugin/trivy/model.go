@@ -1,16 +1,101 @@
 package trivy
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 )
 
 type ScanResult struct {
 	Target          string          `json:"Target"`
+	Type            string          `json:"Type,omitempty"`
+	Packages        []Package       `json:"Packages,omitempty"`
 	Vulnerabilities []Vulnerability `json:"Vulnerabilities"`
 }
 
+// Package is an entry of the Packages array which Trivy outputs when it's
+// run with the --list-all-pkgs flag.
+type Package struct {
+	Name    string `json:"Name"`
+	Version string `json:"Version"`
+	Release string `json:"Release,omitempty"`
+	Epoch   int    `json:"Epoch,omitempty"`
+}
+
+// FullVersion returns the version of the package including epoch and release
+// if they are set, e.g. 1:2.17-317.el7.
+func (p Package) FullVersion() string {
+	version := p.Version
+	if p.Release != "" {
+		version = fmt.Sprintf("%s-%s", version, p.Release)
+	}
+	if p.Epoch != 0 {
+		version = fmt.Sprintf("%d:%s", p.Epoch, version)
+	}
+	return version
+}
+
 type ScanReport struct {
-	Results []ScanResult `json:"Results"`
+	// SchemaVersion is the version of the JSON output format, which is set
+	// since Trivy 0.20.0.
+	SchemaVersion int          `json:"SchemaVersion"`
+	Metadata      Metadata     `json:"Metadata"`
+	Results       []ScanResult `json:"Results"`
+}
+
+// Metadata is the metadata of the scanned artifact. ImageConfig is only
+// output for container images.
+type Metadata struct {
+	ImageConfig ImageConfig `json:"ImageConfig"`
+}
+
+// ImageConfig is the subset of the OCI image configuration used to describe
+// the age and layers of a container image.
+type ImageConfig struct {
+	Created string         `json:"created"`
+	History []ImageHistory `json:"history"`
+	RootFS  ImageRootFS    `json:"rootfs"`
+}
+
+type ImageHistory struct {
+	EmptyLayer bool `json:"empty_layer,omitempty"`
+}
+
+type ImageRootFS struct {
+	DiffIDs []string `json:"diff_ids"`
+}
+
+// CreatedAt returns the time when the image was built. It returns false if
+// the creation time is missing or malformed, e.g. for images built with
+// reproducible builds tools which set it to the Unix epoch or zero time.
+func (c ImageConfig) CreatedAt() (time.Time, bool) {
+	if c.Created == "" {
+		return time.Time{}, false
+	}
+	created, err := time.Parse(time.RFC3339Nano, c.Created)
+	if err != nil || created.Unix() <= 0 {
+		return time.Time{}, false
+	}
+	return created, true
+}
+
+// LayersCount returns the number of filesystem layers of the image. If the
+// root filesystem is not listed, layers are counted from history entries
+// which do not describe empty layers.
+func (c ImageConfig) LayersCount() int {
+	if len(c.RootFS.DiffIDs) > 0 {
+		return len(c.RootFS.DiffIDs)
+	}
+	var count int
+	for _, h := range c.History {
+		if !h.EmptyLayer {
+			count++
+		}
+	}
+	return count
 }
 
 type Vulnerability struct {
@@ -35,3 +120,160 @@ type Layer struct {
 	Digest string `json:"Digest"`
 	DiffID string `json:"DiffID"`
 }
+
+// DecodeVulnerabilities reads a Trivy JSON report from the specified reader
+// and calls fn for each vulnerability as soon as it is decoded. Unlike
+// decoding into ScanReport, it never holds more than one vulnerability in
+// memory, which bounds memory usage for very large reports. A null report is
+// treated as a report without vulnerabilities.
+func DecodeVulnerabilities(r io.Reader, fn func(v Vulnerability) error) error {
+	_, err := DecodeScanReport(r, fn, nil)
+	return err
+}
+
+// DecodeScanReport is similar to DecodeVulnerabilities, but it also calls
+// onPackage for each package listed in the Packages array of a result. The
+// packages of a result are passed to onPackage along with the result type
+// once the whole result is decoded. If onPackage is nil, packages are skipped.
+// It returns the report with SchemaVersion and Metadata, but without Results.
+// Metadata is left empty if it's missing or malformed, e.g. for scratch images
+// or OCI image indexes.
+func DecodeScanReport(r io.Reader, onVulnerability func(v Vulnerability) error, onPackage func(resultType string, p Package) error) (ScanReport, error) {
+	var metadata Metadata
+	var schemaVersion int
+	dec := json.NewDecoder(r)
+	err := decodeObject(dec, func(key string) error {
+		if key == "SchemaVersion" {
+			return dec.Decode(&schemaVersion)
+		}
+		if key == "Metadata" {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return err
+			}
+			if err := json.Unmarshal(raw, &metadata); err != nil {
+				metadata = Metadata{}
+			}
+			return nil
+		}
+		if key != "Results" {
+			return skipValue(dec)
+		}
+		return decodeArray(dec, func() error {
+			var resultType string
+			var packages []Package
+			err := decodeObject(dec, func(key string) error {
+				switch {
+				case key == "Type":
+					return dec.Decode(&resultType)
+				case key == "Packages" && onPackage != nil:
+					return decodeArray(dec, func() error {
+						var p Package
+						if err := dec.Decode(&p); err != nil {
+							return err
+						}
+						packages = append(packages, p)
+						return nil
+					})
+				case key == "Vulnerabilities":
+					return decodeArray(dec, func() error {
+						var v Vulnerability
+						if err := dec.Decode(&v); err != nil {
+							return err
+						}
+						return onVulnerability(v)
+					})
+				default:
+					return skipValue(dec)
+				}
+			})
+			if err != nil {
+				return err
+			}
+			for _, p := range packages {
+				if err := onPackage(resultType, p); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+	return ScanReport{SchemaVersion: schemaVersion, Metadata: metadata}, err
+}
+
+// decodeObject calls fn for each key of the JSON object at the current
+// position of the decoder. fn must consume the value associated with the key.
+func decodeObject(dec *json.Decoder, fn func(key string) error) error {
+	ok, err := openDelim(dec, '{')
+	if err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := t.(string)
+		if !ok {
+			return fmt.Errorf("expected object key, got %v", t)
+		}
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// decodeArray calls fn for each element of the JSON array at the current
+// position of the decoder. fn must consume the element.
+func decodeArray(dec *json.Decoder, fn func() error) error {
+	ok, err := openDelim(dec, '[')
+	if err != nil || !ok {
+		return err
+	}
+	for dec.More() {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// openDelim consumes the opening delimiter of a JSON object or array. It
+// returns false if the value is null.
+func openDelim(dec *json.Decoder, delim json.Delim) (bool, error) {
+	t, err := dec.Token()
+	if err != nil {
+		return false, err
+	}
+	if t == nil {
+		return false, nil
+	}
+	if d, ok := t.(json.Delim); !ok || d != delim {
+		return false, fmt.Errorf("expected %v, got %v", delim, t)
+	}
+	return true, nil
+}
+
+// skipValue consumes the JSON value at the current position of the decoder
+// without unmarshaling it.
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch t {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
@@ -1 +1,271 @@
 package trivy_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeVulnerabilities(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		expectedIDs   []string
+		expectedError string
+	}{
+		{
+			name:        "Should decode null report",
+			input:       `null`,
+			expectedIDs: nil,
+		},
+		{
+			name:        "Should decode report without vulnerabilities",
+			input:       `{"SchemaVersion":2,"Results":[{"Target":"alpine:3.10.2","Vulnerabilities":null}]}`,
+			expectedIDs: nil,
+		},
+		{
+			name: "Should decode vulnerabilities from all results and skip unknown fields",
+			input: `{
+				"SchemaVersion": 2,
+				"ArtifactName": "alpine:3.10.2",
+				"Metadata": {"RepoTags": ["alpine:3.10.2"], "ImageConfig": {"config": {"Env": ["A=B"]}}},
+				"Results": [
+					{"Target": "alpine:3.10.2", "Class": "os-pkgs", "Packages": [{"Name": "openssl"}],
+					 "Vulnerabilities": [{"VulnerabilityID": "CVE-2019-1549", "Severity": "MEDIUM"}]},
+					{"Target": "app/package-lock.json", "Vulnerabilities": [
+						{"VulnerabilityID": "CVE-2021-23337", "Severity": "HIGH", "CVSS": {"nvd": {"V3Score": 7.2}}},
+						{"VulnerabilityID": "CVE-2020-8203", "Severity": "HIGH"}
+					]}
+				]
+			}`,
+			expectedIDs: []string{"CVE-2019-1549", "CVE-2021-23337", "CVE-2020-8203"},
+		},
+		{
+			name:          "Should return error when results is not an array",
+			input:         `{"Results":{}}`,
+			expectedError: "expected [, got {",
+		},
+		{
+			name:          "Should return error when input is truncated",
+			input:         `{"Results":[{"Vulnerabilities":[{"VulnerabilityID":"CVE-2019-1549"}`,
+			expectedError: "unexpected end of JSON input",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var ids []string
+			err := trivy.DecodeVulnerabilities(strings.NewReader(tc.input), func(v trivy.Vulnerability) error {
+				ids = append(ids, v.VulnerabilityID)
+				return nil
+			})
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func TestDecodeScanReport_Packages(t *testing.T) {
+	input := `{
+		"Results": [
+			{"Target": "alpine:3.10.2", "Type": "alpine",
+			 "Vulnerabilities": [{"VulnerabilityID": "CVE-2019-1549"}],
+			 "Packages": [{"Name": "musl", "Version": "1.1.22", "Release": "r3"}]},
+			{"Target": "app/package-lock.json", "Type": "npm",
+			 "Packages": [{"Name": "lodash", "Version": "4.17.20"}]}
+		]
+	}`
+	var ids, packages []string
+	_, err := trivy.DecodeScanReport(strings.NewReader(input), func(v trivy.Vulnerability) error {
+		ids = append(ids, v.VulnerabilityID)
+		return nil
+	}, func(resultType string, p trivy.Package) error {
+		packages = append(packages, resultType+"/"+p.Name+"@"+p.FullVersion())
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CVE-2019-1549"}, ids)
+	assert.Equal(t, []string{"alpine/musl@1.1.22-r3", "npm/lodash@4.17.20"}, packages)
+}
+
+func TestDecodeScanReport_Metadata(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected trivy.Metadata
+	}{
+		{
+			name:  "Should decode image config",
+			input: `{"Metadata":{"RepoTags":["alpine:3.10.2"],"ImageConfig":{"created":"2019-08-20T20:19:55Z","rootfs":{"diff_ids":["sha256:a"]}}},"Results":[]}`,
+			expected: trivy.Metadata{ImageConfig: trivy.ImageConfig{
+				Created: "2019-08-20T20:19:55Z",
+				RootFS:  trivy.ImageRootFS{DiffIDs: []string{"sha256:a"}},
+			}},
+		},
+		{
+			name:     "Should return empty metadata when it's missing",
+			input:    `{"Results":[]}`,
+			expected: trivy.Metadata{},
+		},
+		{
+			name:     "Should return empty metadata when it's malformed",
+			input:    `{"Metadata":{"ImageConfig":{"rootfs":"layers"}},"Results":[]}`,
+			expected: trivy.Metadata{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report, err := trivy.DecodeScanReport(strings.NewReader(tc.input), func(v trivy.Vulnerability) error {
+				return nil
+			}, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, report.Metadata)
+		})
+	}
+}
+
+func TestImageConfig_CreatedAt(t *testing.T) {
+	testCases := []struct {
+		name       string
+		created    string
+		expected   time.Time
+		expectedOK bool
+	}{
+		{name: "Should parse RFC 3339 timestamp", created: "2019-08-20T20:19:55.211423266Z", expected: time.Date(2019, 8, 20, 20, 19, 55, 211423266, time.UTC), expectedOK: true},
+		{name: "Should ignore missing timestamp", created: ""},
+		{name: "Should ignore zero timestamp", created: "0001-01-01T00:00:00Z"},
+		{name: "Should ignore Unix epoch", created: "1970-01-01T00:00:00Z"},
+		{name: "Should ignore malformed timestamp", created: "yesterday"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			created, ok := trivy.ImageConfig{Created: tc.created}.CreatedAt()
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.True(t, tc.expected.Equal(created))
+		})
+	}
+}
+
+func TestImageConfig_LayersCount(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   trivy.ImageConfig
+		expected int
+	}{
+		{
+			name:     "Should count root filesystem layers",
+			config:   trivy.ImageConfig{RootFS: trivy.ImageRootFS{DiffIDs: []string{"sha256:a", "sha256:b"}}},
+			expected: 2,
+		},
+		{
+			name:     "Should count non-empty history entries when root filesystem is not listed",
+			config:   trivy.ImageConfig{History: []trivy.ImageHistory{{}, {EmptyLayer: true}, {}}},
+			expected: 2,
+		},
+		{
+			name:     "Should return zero for scratch image",
+			config:   trivy.ImageConfig{},
+			expected: 0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.config.LayersCount())
+		})
+	}
+}
+
+func TestPackage_FullVersion(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pkg      trivy.Package
+		expected string
+	}{
+		{name: "Should return version", pkg: trivy.Package{Version: "4.17.20"}, expected: "4.17.20"},
+		{name: "Should append release", pkg: trivy.Package{Version: "1.1.22", Release: "r3"}, expected: "1.1.22-r3"},
+		{name: "Should prepend epoch", pkg: trivy.Package{Version: "1.1.1c", Release: "r0", Epoch: 1}, expected: "1:1.1.1c-r0"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.pkg.FullVersion())
+		})
+	}
+}
+
+// newLargeScanReport returns a synthetic Trivy report of at least the
+// specified size in bytes, with most vulnerabilities of LOW severity.
+func newLargeScanReport(t testing.TB, size int) []byte {
+	t.Helper()
+	description := strings.Repeat("Long description of the vulnerability. ", 50)
+	var buf bytes.Buffer
+	buf.WriteString(`{"SchemaVersion":2,"Results":[`)
+	for i := 0; buf.Len() < size; i++ {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		var vulnerabilities []trivy.Vulnerability
+		for j := 0; j < 1000; j++ {
+			severity := "LOW"
+			if j%100 == 0 {
+				severity = "CRITICAL"
+			}
+			vulnerabilities = append(vulnerabilities, trivy.Vulnerability{
+				VulnerabilityID:  fmt.Sprintf("CVE-2022-%d", i*1000+j),
+				PkgName:          fmt.Sprintf("pkg-%d", j),
+				InstalledVersion: "1.0.0",
+				FixedVersion:     "1.0.1",
+				Title:            "Synthetic vulnerability",
+				Description:      description,
+				Severity:         v1alpha1.Severity(severity),
+				References:       []string{"https://example.com"},
+			})
+		}
+		result, err := json.Marshal(trivy.ScanResult{
+			Target:          fmt.Sprintf("target-%d", i),
+			Vulnerabilities: vulnerabilities,
+		})
+		require.NoError(t, err)
+		buf.Write(result)
+	}
+	buf.WriteString(`]}`)
+	return buf.Bytes()
+}
+
+// BenchmarkDecodeVulnerabilities compares memory allocated while decoding a
+// 100MB report into ScanReport with streaming CRITICAL vulnerabilities.
+func BenchmarkDecodeVulnerabilities(b *testing.B) {
+	report := newLargeScanReport(b, 100*1024*1024)
+
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var sr trivy.ScanReport
+			require.NoError(b, json.NewDecoder(bytes.NewReader(report)).Decode(&sr))
+		}
+	})
+
+	b.Run("Stream", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var critical []trivy.Vulnerability
+			err := trivy.DecodeVulnerabilities(bytes.NewReader(report), func(v trivy.Vulnerability) error {
+				if v.Severity == v1alpha1.SeverityCritical {
+					critical = append(critical, v)
+				}
+				return nil
+			})
+			require.NoError(b, err)
+		}
+	})
+}
@@ -1,11 +1,15 @@
 package trivy
 
 import (
+	"bufio"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/docker"
@@ -44,6 +48,7 @@ const (
 	keyTrivySkipFiles              = "trivy.skipFiles"
 	keyTrivySkipDirs               = "trivy.skipDirs"
 	keyTrivyDBRepository           = "trivy.dbRepository"
+	keyTrivyListAllPackages        = "trivy.listAllPackages"
 
 	keyTrivyServerURL           = "trivy.serverURL"
 	keyTrivyServerTokenHeader   = "trivy.serverTokenHeader"
@@ -51,6 +56,10 @@ const (
 	keyTrivyServerToken         = "trivy.serverToken"
 	keyTrivyServerCustomHeaders = "trivy.serverCustomHeaders"
 
+	keyTrivyServerRetries         = "trivy.serverRetries"
+	keyTrivyServerRetryBackoff    = "trivy.serverRetryBackoff"
+	keyTrivyServerRetryMaxBackoff = "trivy.serverRetryMaxBackoff"
+
 	keyResourcesRequestsCPU    = "trivy.resources.requests.cpu"
 	keyResourcesRequestsMemory = "trivy.resources.requests.memory"
 	keyResourcesLimitsCPU      = "trivy.resources.limits.cpu"
@@ -59,6 +68,11 @@ const (
 
 const defaultDBRepository = "ghcr.io/aquasecurity/trivy-db"
 
+const (
+	defaultServerRetryBackoff    = 5 * time.Second
+	defaultServerRetryMaxBackoff = time.Minute
+)
+
 // Mode in which Trivy client operates.
 type Mode string
 
@@ -129,6 +143,51 @@ func (c Config) GetServerInsecure() bool {
 	return ok
 }
 
+// GetServerRetries returns the maximum number of times the Trivy client retries
+// a scan when Trivy server, or a proxy in front of it, responds with HTTP 429
+// or 5xx status codes. Zero, which is the default, disables retries.
+func (c Config) GetServerRetries() (int, error) {
+	value, ok := c.Data[keyTrivyServerRetries]
+	if !ok {
+		return 0, nil
+	}
+	retries, err := strconv.Atoi(value)
+	if err != nil || retries < 0 {
+		return 0, fmt.Errorf("invalid value (%s) of %s; must be a non-negative integer", value, keyTrivyServerRetries)
+	}
+	return retries, nil
+}
+
+// GetServerRetryBackoff returns the initial and maximum delay between retries
+// of a scan. The delay doubles with each retry and is randomized with jitter.
+func (c Config) GetServerRetryBackoff() (time.Duration, time.Duration, error) {
+	backoff, err := c.getDuration(keyTrivyServerRetryBackoff, defaultServerRetryBackoff)
+	if err != nil {
+		return 0, 0, err
+	}
+	maxBackoff, err := c.getDuration(keyTrivyServerRetryMaxBackoff, defaultServerRetryMaxBackoff)
+	if err != nil {
+		return 0, 0, err
+	}
+	if maxBackoff < backoff {
+		return 0, 0, fmt.Errorf("%s (%s) must not be less than %s (%s)",
+			keyTrivyServerRetryMaxBackoff, maxBackoff, keyTrivyServerRetryBackoff, backoff)
+	}
+	return backoff, maxBackoff, nil
+}
+
+func (c Config) getDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	value, ok := c.Data[key]
+	if !ok {
+		return defaultValue, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid value (%s) of %s; must be a positive duration", value, key)
+	}
+	return duration, nil
+}
+
 func (c Config) IgnoreFileExists() bool {
 	_, ok := c.Data[keyTrivyIgnoreFile]
 	return ok
@@ -139,6 +198,41 @@ func (c Config) IgnoreUnfixed() bool {
 	return ok
 }
 
+// ListAllPackages returns true if Trivy should output all packages found in
+// a container image, not only the vulnerable ones.
+func (c Config) ListAllPackages() bool {
+	return c.Data[keyTrivyListAllPackages] == "true"
+}
+
+// GetSeverities returns the set of severities configured with the
+// trivy.severity property, or nil if all severities should be reported.
+func (c Config) GetSeverities() map[v1alpha1.Severity]bool {
+	value, ok := c.Data[keyTrivySeverity]
+	if !ok || strings.TrimSpace(value) == "" {
+		return nil
+	}
+	severities := make(map[v1alpha1.Severity]bool)
+	for _, severity := range strings.Split(value, ",") {
+		severities[v1alpha1.Severity(strings.ToUpper(strings.TrimSpace(severity)))] = true
+	}
+	return severities
+}
+
+// GetIgnoredVulnerabilityIDs returns the set of vulnerability identifiers
+// listed in the .trivyignore file configured with the trivy.ignoreFile
+// property. Blank lines and comments are skipped.
+func (c Config) GetIgnoredVulnerabilityIDs() map[string]bool {
+	ignored := make(map[string]bool)
+	for _, line := range strings.Split(c.Data[keyTrivyIgnoreFile], "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		ignored[fields[0]] = true
+	}
+	return ignored
+}
+
 func (c Config) GetInsecureRegistries() map[string]bool {
 	insecureRegistries := make(map[string]bool)
 	for key, val := range c.Data {
@@ -245,7 +339,7 @@ func NewPlugin(clock ext.Clock, idGenerator ext.IDGenerator, client client.Clien
 
 // Init ensures the default Config required by this plugin.
 func (p *plugin) Init(ctx starboard.PluginContext) error {
-	return ctx.EnsureConfig(starboard.PluginConfig{
+	err := ctx.EnsureConfig(starboard.PluginConfig{
 		Data: map[string]string{
 			keyTrivyImageRef:     "docker.io/aquasec/trivy:0.25.2",
 			keyTrivySeverity:     "UNKNOWN,LOW,MEDIUM,HIGH,CRITICAL",
@@ -259,6 +353,10 @@ func (p *plugin) Init(ctx starboard.PluginContext) error {
 			keyResourcesLimitsMemory:   "500M",
 		},
 	})
+	if err != nil {
+		return err
+	}
+	return starboard.VerifyPluginImages(ctx)
 }
 
 func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, workload client.Object, credentials map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
@@ -297,6 +395,106 @@ func (p *plugin) GetScanJobSpec(ctx starboard.PluginContext, workload client.Obj
 	return corev1.PodSpec{}, nil, fmt.Errorf("unrecognized trivy command %q", command)
 }
 
+// GetDirectScanCommand implements vulnerabilityreport.DirectScanPlugin. The
+// Trivy image scan command is configured with the same settings as containers
+// of scan jobs, except that Trivy downloads its database on each scan in the
+// Standalone mode, and that images are scanned by reference regardless of the
+// configured command.
+func (p *plugin) GetDirectScanCommand(ctx starboard.PluginContext, workload client.Object, image string, credentials *docker.Auth) (vulnerabilityreport.DirectScanCommand, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return vulnerabilityreport.DirectScanCommand{}, err
+	}
+	mode, err := config.GetMode()
+	if err != nil {
+		return vulnerabilityreport.DirectScanCommand{}, err
+	}
+	trivyImageRef, err := config.GetImageRef()
+	if err != nil {
+		return vulnerabilityreport.DirectScanCommand{}, err
+	}
+	optionalMirroredImage, err := GetMirroredImage(image, config.GetMirrors())
+	if err != nil {
+		return vulnerabilityreport.DirectScanCommand{}, err
+	}
+
+	env := []corev1.EnvVar{
+		{Name: "TRIVY_SEVERITY", Value: config.Data[keyTrivySeverity]},
+		{Name: "TRIVY_IGNORE_UNFIXED", Value: config.Data[keyTrivyIgnoreUnfixed]},
+		{Name: "TRIVY_TIMEOUT", Value: config.Data[keyTrivyTimeout]},
+		{Name: "TRIVY_SKIP_FILES", Value: config.Data[keyTrivySkipFiles]},
+		{Name: "TRIVY_SKIP_DIRS", Value: config.Data[keyTrivySkipDirs]},
+		{Name: "HTTP_PROXY", Value: config.Data[keyTrivyHTTPProxy]},
+		{Name: "HTTPS_PROXY", Value: config.Data[keyTrivyHTTPSProxy]},
+		{Name: "NO_PROXY", Value: config.Data[keyTrivyNoProxy]},
+	}
+	if config.ListAllPackages() {
+		env = append(env, corev1.EnvVar{Name: "TRIVY_LIST_ALL_PKGS", Value: "true"})
+	}
+	if credentials != nil {
+		env = append(env,
+			corev1.EnvVar{Name: "TRIVY_USERNAME", Value: credentials.Username},
+			corev1.EnvVar{Name: "TRIVY_PASSWORD", Value: credentials.Password})
+	}
+	env, err = p.appendTrivyInsecureEnv(config, image, env)
+	if err != nil {
+		return vulnerabilityreport.DirectScanCommand{}, err
+	}
+	env, err = p.appendTrivyNonSSLEnv(config, image, env)
+	if err != nil {
+		return vulnerabilityreport.DirectScanCommand{}, err
+	}
+
+	var args []string
+	switch mode {
+	case Standalone:
+		dbRepository, err := config.GetDBRepository()
+		if err != nil {
+			return vulnerabilityreport.DirectScanCommand{}, err
+		}
+		env = append(env, corev1.EnvVar{Name: "GITHUB_TOKEN", Value: string(config.SecretData[keyTrivyGitHubToken])})
+		args = []string{"--quiet", "image", "--format", "json", "--db-repository", dbRepository}
+	case ClientServer:
+		trivyServerURL, err := config.GetServerURL()
+		if err != nil {
+			return vulnerabilityreport.DirectScanCommand{}, err
+		}
+		env = append(env,
+			corev1.EnvVar{Name: "TRIVY_TOKEN_HEADER", Value: config.Data[keyTrivyServerTokenHeader]},
+			corev1.EnvVar{Name: "TRIVY_TOKEN", Value: string(config.SecretData[keyTrivyServerToken])},
+			corev1.EnvVar{Name: "TRIVY_CUSTOM_HEADERS", Value: string(config.SecretData[keyTrivyServerCustomHeaders])})
+		if config.GetServerInsecure() {
+			env = append(env, corev1.EnvVar{Name: "TRIVY_INSECURE", Value: "true"})
+		}
+		args = []string{"--quiet", "client", "--format", "json", "--remote", trivyServerURL}
+	default:
+		return vulnerabilityreport.DirectScanCommand{}, fmt.Errorf("unrecognized trivy mode %q", mode)
+	}
+
+	command := vulnerabilityreport.DirectScanCommand{
+		Binary: "trivy",
+		Image:  trivyImageRef,
+		Args:   append(append(args, platformArgs(workload)...), optionalMirroredImage),
+	}
+	for _, e := range env {
+		if e.Value != "" {
+			command.Env = append(command.Env, fmt.Sprintf("%s=%s", e.Name, e.Value))
+		}
+	}
+	return command, nil
+}
+
+// platformArgs returns arguments of the Trivy image scan command which select
+// the image of a multi-arch image index for the platform recorded in the
+// starboard.AnnotationPlatform annotation of the scanned workload.
+func platformArgs(workload client.Object) []string {
+	platform, ok := workload.GetAnnotations()[starboard.AnnotationPlatform]
+	if !ok {
+		return nil
+	}
+	return []string{"--platform", platform}
+}
+
 func (p *plugin) newSecretWithAggregateImagePullCredentials(obj client.Object, spec corev1.PodSpec, credentials map[string]docker.Auth) *corev1.Secret {
 	containerImages := kube.GetContainerImagesFromPodSpec(spec)
 	secretData := kube.AggregateImagePullSecretsData(containerImages, credentials)
@@ -329,6 +527,13 @@ const (
 //
 //     trivy --cache-dir /tmp/trivy/.cache image --skip-update \
 //       --format json <container image>
+//
+// If the platform of nodes running the workload is known, the --platform flag
+// selects the image of a multi-arch image index built for that platform.
+//
+// If the workload refers to an SBOM with the starboard.AnnotationSBOMConfigMap
+// annotation, there is an additional container which runs the Trivy SBOM scan
+// command. See newSBOMContainer for details.
 func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config Config, workload client.Object, credentials map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
 	var secret *corev1.Secret
 	var secrets []*corev1.Secret
@@ -456,23 +661,15 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 		},
 	}
 
-	if config.IgnoreFileExists() {
-		volumes = append(volumes, corev1.Volume{
-			Name: ignoreFileVolumeName,
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: trivyConfigName,
-					},
-					Items: []corev1.KeyToPath{
-						{
-							Key:  keyTrivyIgnoreFile,
-							Path: ".trivyignore",
-						},
-					},
-				},
-			},
-		})
+	ignoreFile, ignoreFileSecret, err := p.ignoreFileVolume(config, workload)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+	if ignoreFileSecret != nil {
+		secrets = append(secrets, ignoreFileSecret)
+	}
+	if ignoreFile != nil {
+		volumes = append(volumes, *ignoreFile)
 
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
 			Name:      ignoreFileVolumeName,
@@ -582,13 +779,20 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 			},
 		}
 
-		if config.IgnoreFileExists() {
+		if ignoreFile != nil {
 			env = append(env, corev1.EnvVar{
 				Name:  "TRIVY_IGNOREFILE",
 				Value: "/etc/trivy/.trivyignore",
 			})
 		}
 
+		if config.ListAllPackages() {
+			env = append(env, corev1.EnvVar{
+				Name:  "TRIVY_LIST_ALL_PKGS",
+				Value: "true",
+			})
+		}
+
 		if _, ok := credentials[c.Name]; ok && secret != nil {
 			registryUsernameKey := fmt.Sprintf("%s.username", c.Name)
 			registryPasswordKey := fmt.Sprintf("%s.password", c.Name)
@@ -645,7 +849,7 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 			Command: []string{
 				"trivy",
 			},
-			Args: []string{
+			Args: append(append([]string{
 				"--cache-dir",
 				"/tmp/trivy/.cache",
 				"--quiet",
@@ -653,8 +857,7 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 				"--skip-update",
 				"--format",
 				"json",
-				optionalMirroredImage,
-			},
+			}, platformArgs(workload)...), optionalMirroredImage),
 			Resources:    resourceRequirements,
 			VolumeMounts: volumeMounts,
 			SecurityContext: &corev1.SecurityContext{
@@ -668,6 +871,11 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 		})
 	}
 
+	if sbomContainer, sbomVolume, ok := p.newSBOMContainer(trivyImageRef, requirements, workload, volumeMounts); ok {
+		containers = append(containers, sbomContainer)
+		volumes = append(volumes, sbomVolume)
+	}
+
 	return corev1.PodSpec{
 		Affinity:                     starboard.LinuxNodeAffinity(),
 		RestartPolicy:                corev1.RestartPolicyNever,
@@ -687,6 +895,8 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 //
 //     trivy client --remote <server URL> \
 //       --format json <container image>
+//
+// The --platform flag is added as in the Standalone mode.
 func (p *plugin) getPodSpecForClientServerMode(ctx starboard.PluginContext, config Config, workload client.Object, credentials map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
 	var secret *corev1.Secret
 	var secrets []*corev1.Secret
@@ -708,11 +918,29 @@ func (p *plugin) getPodSpecForClientServerMode(ctx starboard.PluginContext, conf
 		return corev1.PodSpec{}, nil, err
 	}
 
+	retries, err := config.GetServerRetries()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	backoff, maxBackoff, err := config.GetServerRetryBackoff()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
 	if len(credentials) > 0 {
 		secret = p.newSecretWithAggregateImagePullCredentials(workload, spec, credentials)
 		secrets = append(secrets, secret)
 	}
 
+	ignoreFile, ignoreFileSecret, err := p.ignoreFileVolume(config, workload)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+	if ignoreFileSecret != nil {
+		secrets = append(secrets, ignoreFileSecret)
+	}
+
 	var containers []corev1.Container
 
 	trivyConfigName := starboard.GetPluginConfigMapName(Plugin)
@@ -898,25 +1126,8 @@ func (p *plugin) getPodSpecForClientServerMode(ctx starboard.PluginContext, conf
 			})
 		}
 
-		if config.IgnoreFileExists() {
-			volumes = []corev1.Volume{
-				{
-					Name: ignoreFileVolumeName,
-					VolumeSource: corev1.VolumeSource{
-						ConfigMap: &corev1.ConfigMapVolumeSource{
-							LocalObjectReference: corev1.LocalObjectReference{
-								Name: trivyConfigName,
-							},
-							Items: []corev1.KeyToPath{
-								{
-									Key:  keyTrivyIgnoreFile,
-									Path: ".trivyignore",
-								},
-							},
-						},
-					},
-				},
-			}
+		if ignoreFile != nil {
+			volumes = []corev1.Volume{*ignoreFile}
 
 			volumeMounts = []corev1.VolumeMount{
 				{
@@ -932,6 +1143,13 @@ func (p *plugin) getPodSpecForClientServerMode(ctx starboard.PluginContext, conf
 			})
 		}
 
+		if config.ListAllPackages() {
+			env = append(env, corev1.EnvVar{
+				Name:  "TRIVY_LIST_ALL_PKGS",
+				Value: "true",
+			})
+		}
+
 		requirements, err := config.GetResourceRequirements()
 		if err != nil {
 			return corev1.PodSpec{}, nil, err
@@ -942,26 +1160,31 @@ func (p *plugin) getPodSpecForClientServerMode(ctx starboard.PluginContext, conf
 			return corev1.PodSpec{}, nil, err
 		}
 
+		command := []string{
+			"trivy",
+		}
+		args := append(append([]string{
+			"--quiet",
+			"client",
+			"--format",
+			"json",
+			"--remote",
+			trivyServerURL,
+		}, platformArgs(workload)...), optionalMirroredImage)
+		if retries > 0 {
+			command, args = withServerRetries(append(command, args...), retries, backoff, maxBackoff)
+		}
+
 		containers = append(containers, corev1.Container{
 			Name:                     container.Name,
 			Image:                    trivyImageRef,
 			ImagePullPolicy:          corev1.PullIfNotPresent,
 			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 			Env:                      env,
-			Command: []string{
-				"trivy",
-			},
-			Args: []string{
-				"--quiet",
-				"client",
-				"--format",
-				"json",
-				"--remote",
-				trivyServerURL,
-				optionalMirroredImage,
-			},
-			VolumeMounts: volumeMounts,
-			Resources:    requirements,
+			Command:                  command,
+			Args:                     args,
+			VolumeMounts:             volumeMounts,
+			Resources:                requirements,
 		})
 	}
 
@@ -1090,24 +1313,15 @@ func (p *plugin) getPodSpecForStandaloneFSMode(ctx starboard.PluginContext, conf
 		},
 	}
 
-	//TODO Move this to function and refactor the code to use it
-	if config.IgnoreFileExists() {
-		volumes = append(volumes, corev1.Volume{
-			Name: ignoreFileVolumeName,
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: trivyConfigName,
-					},
-					Items: []corev1.KeyToPath{
-						{
-							Key:  keyTrivyIgnoreFile,
-							Path: ".trivyignore",
-						},
-					},
-				},
-			},
-		})
+	ignoreFile, ignoreFileSecret, err := p.ignoreFileVolume(config, workload)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+	if ignoreFileSecret != nil {
+		secrets = append(secrets, ignoreFileSecret)
+	}
+	if ignoreFile != nil {
+		volumes = append(volumes, *ignoreFile)
 
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
 			Name:      ignoreFileVolumeName,
@@ -1126,12 +1340,19 @@ func (p *plugin) getPodSpecForStandaloneFSMode(ctx starboard.PluginContext, conf
 			constructEnvVarSourceFromConfigMap("HTTPS_PROXY", trivyConfigName, keyTrivyHTTPSProxy),
 			constructEnvVarSourceFromConfigMap("NO_PROXY", trivyConfigName, keyTrivyNoProxy),
 		}
-		if config.IgnoreFileExists() {
+		if ignoreFile != nil {
 			env = append(env, corev1.EnvVar{
 				Name:  "TRIVY_IGNOREFILE",
 				Value: "/tmp/trivy/.trivyignore",
 			})
 		}
+
+		if config.ListAllPackages() {
+			env = append(env, corev1.EnvVar{
+				Name:  "TRIVY_LIST_ALL_PKGS",
+				Value: "true",
+			})
+		}
 		if config.IgnoreUnfixed() {
 			env = append(env, constructEnvVarSourceFromConfigMap("TRIVY_IGNORE_UNFIXED",
 				trivyConfigName, keyTrivyIgnoreUnfixed))
@@ -1200,6 +1421,85 @@ func (p *plugin) getPodSpecForStandaloneFSMode(ctx starboard.PluginContext, conf
 	return podSpec, secrets, nil
 }
 
+const (
+	sbomVolumeName          = "sbom"
+	sbomMountPath           = "/etc/starboard/sbom"
+	sbomFileName            = "sbom.json"
+	defaultSBOMConfigMapKey = "sbom.json"
+)
+
+// newSBOMContainer returns the vulnerabilityreport.SBOMContainerName container
+// which scans the SBOM referred to by the starboard.AnnotationSBOMConfigMap
+// annotation of the specified workload, and the volume the SBOM is mounted
+// from. It returns false if the workload does not have the annotation.
+//
+// The ConfigMap and its key are optional, and the container always succeeds,
+// so that a missing or malformed SBOM does not fail the whole scan job. In such
+// case the container outputs the error instead of the JSON report:
+//
+//     trivy --cache-dir /tmp/trivy/.cache --quiet sbom --skip-update \
+//       --format json /etc/starboard/sbom/sbom.json || true
+func (p *plugin) newSBOMContainer(trivyImageRef string, requirements corev1.ResourceRequirements, workload client.Object, volumeMounts []corev1.VolumeMount) (corev1.Container, corev1.Volume, bool) {
+	value, ok := workload.GetAnnotations()[starboard.AnnotationSBOMConfigMap]
+	if !ok || strings.TrimSpace(value) == "" {
+		return corev1.Container{}, corev1.Volume{}, false
+	}
+	configMapName, key := strings.TrimSpace(value), defaultSBOMConfigMapKey
+	if i := strings.Index(configMapName, "/"); i >= 0 {
+		configMapName, key = configMapName[:i], configMapName[i+1:]
+	}
+
+	volume := corev1.Volume{
+		Name: sbomVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: configMapName,
+				},
+				Items: []corev1.KeyToPath{
+					{
+						Key:  key,
+						Path: sbomFileName,
+					},
+				},
+				Optional: pointer.BoolPtr(true),
+			},
+		},
+	}
+
+	sbomPath := sbomMountPath + "/" + sbomFileName
+	script := fmt.Sprintf(`if [ ! -f %[1]s ]; then echo "SBOM not found in ConfigMap %[2]s under key %[3]s"; exit 0; fi; `+
+		`trivy --cache-dir /tmp/trivy/.cache --quiet sbom --skip-update --format json %[1]s || true`, sbomPath, configMapName, key)
+
+	return corev1.Container{
+		Name:                     vulnerabilityreport.SBOMContainerName,
+		Image:                    trivyImageRef,
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		Command: []string{
+			"/bin/sh",
+		},
+		Args: []string{
+			"-c",
+			script,
+		},
+		Resources: requirements,
+		VolumeMounts: append(append([]corev1.VolumeMount{}, volumeMounts...), corev1.VolumeMount{
+			Name:      sbomVolumeName,
+			MountPath: sbomMountPath,
+			ReadOnly:  true,
+		}),
+		SecurityContext: &corev1.SecurityContext{
+			Privileged:               pointer.BoolPtr(false),
+			AllowPrivilegeEscalation: pointer.BoolPtr(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"all"},
+			},
+			ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+		},
+	}, volume, true
+}
+
 func (p *plugin) appendTrivyInsecureEnv(config Config, image string, env []corev1.EnvVar) ([]corev1.EnvVar, error) {
 	ref, err := name.ParseReference(image)
 	if err != nil {
@@ -1239,38 +1539,53 @@ func (p *plugin) ParseVulnerabilityReportData(ctx starboard.PluginContext, image
 	if err != nil {
 		return v1alpha1.VulnerabilityReportData{}, err
 	}
-	var reports ScanReport
-	err = json.NewDecoder(logsReader).Decode(&reports)
-	if err != nil {
-		return v1alpha1.VulnerabilityReportData{}, err
-	}
 	vulnerabilities := make([]v1alpha1.Vulnerability, 0)
-
-	for _, report := range reports.Results {
-		for _, sr := range report.Vulnerabilities {
-			vulnerabilities = append(vulnerabilities, v1alpha1.Vulnerability{
-				VulnerabilityID:  sr.VulnerabilityID,
-				Resource:         sr.PkgName,
-				InstalledVersion: sr.InstalledVersion,
-				FixedVersion:     sr.FixedVersion,
-				Severity:         sr.Severity,
-				Title:            sr.Title,
-				PrimaryLink:      sr.PrimaryURL,
-				Links:            []string{},
-				Score:            GetScoreFromCVSS(sr.Cvss),
+	var packages []v1alpha1.Package
+
+	var onPackage func(resultType string, p Package) error
+	if config.ListAllPackages() {
+		onPackage = func(resultType string, p Package) error {
+			packages = append(packages, v1alpha1.Package{
+				Name:    p.Name,
+				Version: p.FullVersion(),
+				Type:    resultType,
 			})
+			return nil
 		}
 	}
 
-	registry, artifact, err := p.parseImageRef(imageRef)
+	// Results are verified against the scanner recorded on the scan job, which
+	// may differ from the configured one if the config changed since then.
+	trivyImageRef, ok := starboard.ScannerImageFrom(ctx)
+	if !ok {
+		trivyImageRef, err = config.GetImageRef()
+		if err != nil {
+			return v1alpha1.VulnerabilityReportData{}, err
+		}
+	}
+
+	// Trivy results for large images may be hundreds of megabytes, therefore
+	// we decode vulnerabilities one by one and only keep those that will be
+	// persisted in the report.
+	reader := bufio.NewReader(logsReader)
+	start := peekStart(reader)
+	scanReport, err := DecodeScanReport(reader, collectVulnerabilities(config, &vulnerabilities), onPackage)
+	if verifyErr := verifyScanReport(trivyImageRef, start, scanReport.SchemaVersion); verifyErr != nil {
+		return v1alpha1.VulnerabilityReportData{}, verifyErr
+	}
 	if err != nil {
 		return v1alpha1.VulnerabilityReportData{}, err
 	}
+	metadata := scanReport.Metadata
 
-	trivyImageRef, err := config.GetImageRef()
+	registry, artifact, err := p.parseImageRef(imageRef)
 	if err != nil {
 		return v1alpha1.VulnerabilityReportData{}, err
 	}
+	if created, ok := metadata.ImageConfig.CreatedAt(); ok {
+		artifact.Created = &metav1.Time{Time: created}
+	}
+	artifact.LayersCount = metadata.ImageConfig.LayersCount()
 
 	version, err := starboard.GetVersionFromImageRef(trivyImageRef)
 	if err != nil {
@@ -1288,9 +1603,91 @@ func (p *plugin) ParseVulnerabilityReportData(ctx starboard.PluginContext, image
 		Artifact:        artifact,
 		Summary:         p.toSummary(vulnerabilities),
 		Vulnerabilities: vulnerabilities,
+		Packages:        packages,
 	}, nil
 }
 
+// ParseSBOMVulnerabilities parses logs of the vulnerabilityreport.SBOMContainerName
+// container. It returns the error output by the container if the SBOM is
+// missing or could not be scanned.
+func (p *plugin) ParseSBOMVulnerabilities(ctx starboard.PluginContext, logsReader io.ReadCloser) ([]v1alpha1.Vulnerability, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(logsReader)
+	if err := checkSBOMScanOutput(reader); err != nil {
+		return nil, err
+	}
+
+	vulnerabilities := make([]v1alpha1.Vulnerability, 0)
+	_, err = DecodeScanReport(reader, collectVulnerabilities(config, &vulnerabilities), nil)
+	if err != nil {
+		return nil, fmt.Errorf("malformed SBOM scan results: %w", err)
+	}
+	return vulnerabilities, nil
+}
+
+// checkSBOMScanOutput returns an error with the first line of the output read
+// by the given reader unless the output is a JSON report.
+func checkSBOMScanOutput(reader *bufio.Reader) error {
+	for {
+		b, err := reader.Peek(1)
+		if err == io.EOF {
+			return errors.New("SBOM scan produced no output")
+		}
+		if err != nil {
+			return err
+		}
+		if !unicode.IsSpace(rune(b[0])) {
+			break
+		}
+		_, _ = reader.ReadByte()
+	}
+	if b, _ := reader.Peek(1); b[0] == '{' || b[0] == 'n' {
+		return nil
+	}
+	line, _ := reader.ReadString('\n')
+	if len(line) > 512 {
+		line = line[:512]
+	}
+	return errors.New(strings.TrimSpace(line))
+}
+
+// collectVulnerabilities returns a callback for DecodeScanReport which converts
+// Trivy vulnerabilities and appends them to the given slice, unless they're
+// excluded by severity, unfixed, or ignored IDs settings of the given Config.
+func collectVulnerabilities(config Config, vulnerabilities *[]v1alpha1.Vulnerability) func(sr Vulnerability) error {
+	severities := config.GetSeverities()
+	ignoreUnfixed := config.IgnoreUnfixed()
+	ignoredIDs := config.GetIgnoredVulnerabilityIDs()
+
+	return func(sr Vulnerability) error {
+		if severities != nil && !severities[sr.Severity] {
+			return nil
+		}
+		if ignoreUnfixed && sr.FixedVersion == "" {
+			return nil
+		}
+		if ignoredIDs[sr.VulnerabilityID] {
+			return nil
+		}
+		*vulnerabilities = append(*vulnerabilities, v1alpha1.Vulnerability{
+			VulnerabilityID:  sr.VulnerabilityID,
+			Resource:         sr.PkgName,
+			InstalledVersion: sr.InstalledVersion,
+			FixedVersion:     sr.FixedVersion,
+			Severity:         sr.Severity,
+			Title:            sr.Title,
+			PrimaryLink:      sr.PrimaryURL,
+			Links:            []string{},
+			Score:            GetScoreFromCVSS(sr.Cvss),
+		})
+		return nil
+	}
+}
+
 func (p *plugin) newConfigFrom(ctx starboard.PluginContext) (Config, error) {
 	pluginConfig, err := ctx.GetConfig()
 	if err != nil {
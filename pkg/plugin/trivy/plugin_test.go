@@ -9,9 +9,11 @@ import (
 	"time"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
 	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
 	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
@@ -326,6 +328,119 @@ func TestConfig_IgnoreUnfixed(t *testing.T) {
 	}
 }
 
+func TestConfig_ListAllPackages(t *testing.T) {
+	testCases := []struct {
+		name           string
+		configData     trivy.Config
+		expectedOutput bool
+	}{
+		{
+			name: "Should return false",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"foo": "bar",
+				},
+			}},
+			expectedOutput: false,
+		},
+		{
+			name: "Should return true",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"trivy.listAllPackages": "true",
+				},
+			}},
+			expectedOutput: true,
+		},
+		{
+			name: "Should return false when set it as false",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"trivy.listAllPackages": "false",
+				},
+			}},
+			expectedOutput: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedOutput, tc.configData.ListAllPackages())
+		})
+	}
+}
+
+func TestConfig_GetSeverities(t *testing.T) {
+	testCases := []struct {
+		name           string
+		configData     trivy.Config
+		expectedOutput map[v1alpha1.Severity]bool
+	}{
+		{
+			name: "Should return nil when severity is not set",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"foo": "bar",
+				},
+			}},
+			expectedOutput: nil,
+		},
+		{
+			name: "Should return configured severities",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"trivy.severity": "HIGH, critical",
+				},
+			}},
+			expectedOutput: map[v1alpha1.Severity]bool{
+				v1alpha1.SeverityHigh:     true,
+				v1alpha1.SeverityCritical: true,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			severities := tc.configData.GetSeverities()
+			assert.Equal(t, tc.expectedOutput, severities)
+		})
+	}
+}
+
+func TestConfig_GetIgnoredVulnerabilityIDs(t *testing.T) {
+	testCases := []struct {
+		name           string
+		configData     trivy.Config
+		expectedOutput map[string]bool
+	}{
+		{
+			name: "Should return empty set when ignore file is not set",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"foo": "bar",
+				},
+			}},
+			expectedOutput: map[string]bool{},
+		},
+		{
+			name: "Should return identifiers and skip comments",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"trivy.ignoreFile": "# Accept the risk\nCVE-2018-14618\n\n  CVE-2019-1543 exp:2023-01-01\n",
+				},
+			}},
+			expectedOutput: map[string]bool{
+				"CVE-2018-14618": true,
+				"CVE-2019-1543":  true,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ids := tc.configData.GetIgnoredVulnerabilityIDs()
+			assert.Equal(t, tc.expectedOutput, ids)
+		})
+	}
+}
+
 func TestConfig_GetInsecureRegistries(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -437,6 +552,90 @@ func TestConfig_GetMirrors(t *testing.T) {
 	}
 }
 
+func TestConfig_GetServerRetries(t *testing.T) {
+	testCases := []struct {
+		name            string
+		configData      map[string]string
+		expectedRetries int
+		expectedError   string
+	}{
+		{
+			name:            "Should return zero by default",
+			expectedRetries: 0,
+		},
+		{
+			name:            "Should return configured retries",
+			configData:      map[string]string{"trivy.serverRetries": "3"},
+			expectedRetries: 3,
+		},
+		{
+			name:          "Should return error when retries is negative",
+			configData:    map[string]string{"trivy.serverRetries": "-1"},
+			expectedError: "invalid value (-1) of trivy.serverRetries; must be a non-negative integer",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := trivy.Config{PluginConfig: starboard.PluginConfig{Data: tc.configData}}
+			retries, err := config.GetServerRetries()
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRetries, retries)
+		})
+	}
+}
+
+func TestConfig_GetServerRetryBackoff(t *testing.T) {
+	testCases := []struct {
+		name               string
+		configData         map[string]string
+		expectedBackoff    time.Duration
+		expectedMaxBackoff time.Duration
+		expectedError      string
+	}{
+		{
+			name:               "Should return defaults",
+			expectedBackoff:    5 * time.Second,
+			expectedMaxBackoff: time.Minute,
+		},
+		{
+			name: "Should return configured backoff",
+			configData: map[string]string{
+				"trivy.serverRetryBackoff":    "10s",
+				"trivy.serverRetryMaxBackoff": "2m",
+			},
+			expectedBackoff:    10 * time.Second,
+			expectedMaxBackoff: 2 * time.Minute,
+		},
+		{
+			name:          "Should return error when backoff is not a duration",
+			configData:    map[string]string{"trivy.serverRetryBackoff": "soon"},
+			expectedError: "invalid value (soon) of trivy.serverRetryBackoff; must be a positive duration",
+		},
+		{
+			name:          "Should return error when max backoff is less than backoff",
+			configData:    map[string]string{"trivy.serverRetryMaxBackoff": "1s"},
+			expectedError: "trivy.serverRetryMaxBackoff (1s) must not be less than trivy.serverRetryBackoff (5s)",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := trivy.Config{PluginConfig: starboard.PluginConfig{Data: tc.configData}}
+			backoff, maxBackoff, err := config.GetServerRetryBackoff()
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedBackoff, backoff)
+			assert.Equal(t, tc.expectedMaxBackoff, maxBackoff)
+		})
+	}
+}
+
 func TestPlugin_Init(t *testing.T) {
 
 	t.Run("Should create the default config", func(t *testing.T) {
@@ -540,6 +739,50 @@ func TestPlugin_Init(t *testing.T) {
 			},
 		}, cm)
 	})
+
+	t.Run("Should return error if image is not pinned by digest", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithObjects().Build()
+
+		instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), client)
+
+		pluginContext := starboard.NewPluginContext().
+			WithName(trivy.Plugin).
+			WithNamespace("starboard-ns").
+			WithServiceAccountName("starboard-sa").
+			WithClient(client).
+			WithImagePolicy(starboard.ImagePolicy{DigestRequired: true}).
+			Get()
+		err := instance.Init(pluginContext)
+		assert.EqualError(t, err, `Trivy plugin property trivy.imageRef: image "docker.io/aquasec/trivy:0.25.2" is not pinned by sha256 digest`)
+	})
+
+	t.Run("Should accept image pinned by allowed digest", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithObjects(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "starboard-trivy-config",
+					Namespace: "starboard-ns",
+				},
+				Data: map[string]string{
+					"trivy.imageRef": "docker.io/aquasec/trivy:0.25.2@sha256:5020dac24a63ef4f24452a0c63ebbfe93a5309e40f6353d1ee8221d2184ee954",
+					"trivy.mode":     "Standalone",
+				},
+			}).Build()
+
+		instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), client)
+
+		pluginContext := starboard.NewPluginContext().
+			WithName(trivy.Plugin).
+			WithNamespace("starboard-ns").
+			WithServiceAccountName("starboard-sa").
+			WithClient(client).
+			WithImagePolicy(starboard.ImagePolicy{
+				AllowedDigests: []string{"sha256:5020dac24a63ef4f24452a0c63ebbfe93a5309e40f6353d1ee8221d2184ee954"},
+			}).
+			Get()
+		err := instance.Init(pluginContext)
+		require.NoError(t, err)
+	})
 }
 
 func TestPlugin_GetScanJobSpec(t *testing.T) {
@@ -3570,18 +3813,10 @@ var (
 )
 
 func TestPlugin_ParseVulnerabilityReportData(t *testing.T) {
-	config := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "starboard-trivy-config",
-			Namespace: "starboard-ns",
-		},
-		Data: map[string]string{
-			"trivy.imageRef": "aquasec/trivy:0.9.1",
-		},
-	}
-
 	testCases := []struct {
 		name           string
+		configData     map[string]string
+		scannerImage   string
 		imageRef       string
 		input          string
 		expectedError  error
@@ -3624,16 +3859,187 @@ func TestPlugin_ParseVulnerabilityReportData(t *testing.T) {
 				Vulnerabilities: []v1alpha1.Vulnerability{},
 			},
 		},
+		{
+			name: "Should skip vulnerabilities below severity threshold",
+			configData: map[string]string{
+				"trivy.severity": "MEDIUM,HIGH,CRITICAL",
+			},
+			imageRef:      "alpine:3.10.2",
+			input:         sampleReportAsString,
+			expectedError: nil,
+			expectedReport: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(fixedTime),
+				Scanner:         sampleReport.Scanner,
+				Registry:        sampleReport.Registry,
+				Artifact:        sampleReport.Artifact,
+				Summary: v1alpha1.VulnerabilitySummary{
+					MediumCount: 1,
+				},
+				Vulnerabilities: sampleReport.Vulnerabilities[:1],
+			},
+		},
+		{
+			name: "Should skip vulnerabilities listed in ignore file",
+			configData: map[string]string{
+				"trivy.ignoreFile": "# Accept the risk\nCVE-2019-1549\n",
+			},
+			imageRef:      "alpine:3.10.2",
+			input:         sampleReportAsString,
+			expectedError: nil,
+			expectedReport: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(fixedTime),
+				Scanner:         sampleReport.Scanner,
+				Registry:        sampleReport.Registry,
+				Artifact:        sampleReport.Artifact,
+				Summary: v1alpha1.VulnerabilitySummary{
+					LowCount: 1,
+				},
+				Vulnerabilities: sampleReport.Vulnerabilities[1:],
+			},
+		},
+		{
+			name: "Should skip unfixed vulnerabilities",
+			configData: map[string]string{
+				"trivy.ignoreUnfixed": "true",
+			},
+			imageRef: "alpine:3.10.2",
+			input: `{"Results":[{"Target":"alpine:3.10.2 (alpine 3.10.2)","Vulnerabilities":[
+				{"VulnerabilityID":"CVE-2019-1549","PkgName":"openssl","InstalledVersion":"1.1.1c-r0","Severity":"MEDIUM"}
+			]}]}`,
+			expectedError: nil,
+			expectedReport: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(fixedTime),
+				Scanner:         sampleReport.Scanner,
+				Registry:        sampleReport.Registry,
+				Artifact:        sampleReport.Artifact,
+				Vulnerabilities: []v1alpha1.Vulnerability{},
+			},
+		},
+		{
+			name: "Should list all packages",
+			configData: map[string]string{
+				"trivy.listAllPackages": "true",
+			},
+			imageRef: "alpine:3.10.2",
+			input: `{"Results":[{"Target":"alpine:3.10.2 (alpine 3.10.2)","Type":"alpine","Packages":[
+				{"Name":"musl","Version":"1.1.22","Release":"r3"},
+				{"Name":"openssl","Version":"1.1.1c","Release":"r0","Epoch":1}
+			],"Vulnerabilities":null}]}`,
+			expectedError: nil,
+			expectedReport: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(fixedTime),
+				Scanner:         sampleReport.Scanner,
+				Registry:        sampleReport.Registry,
+				Artifact:        sampleReport.Artifact,
+				Vulnerabilities: []v1alpha1.Vulnerability{},
+				Packages: []v1alpha1.Package{
+					{Name: "musl", Version: "1.1.22-r3", Type: "alpine"},
+					{Name: "openssl", Version: "1:1.1.1c-r0", Type: "alpine"},
+				},
+			},
+		},
+		{
+			name:     "Should capture image creation time and layers count",
+			imageRef: "alpine:3.10.2",
+			input: `{"Metadata":{"ImageConfig":{"created":"2019-08-20T20:19:55.211423266Z",
+				"history":[{"created_by":"ADD file:fe64057fbb83dccb960efabbf1cd8777920ef279a7fa8dbca0a8801c651bdf7 in / "},{"created_by":"CMD [\"/bin/sh\"]","empty_layer":true}],
+				"rootfs":{"type":"layers","diff_ids":["sha256:03901b4a2ea88eeaad62dbe59b072b28b6efa00491962b8741081c5df50c65e0"]}}},
+				"Results":[{"Target":"alpine:3.10.2 (alpine 3.10.2)","Vulnerabilities":null}]}`,
+			expectedError: nil,
+			expectedReport: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(fixedTime),
+				Scanner:         sampleReport.Scanner,
+				Registry:        sampleReport.Registry,
+				Artifact: v1alpha1.Artifact{
+					Repository:  "library/alpine",
+					Tag:         "3.10.2",
+					Created:     &metav1.Time{Time: time.Date(2019, 8, 20, 20, 19, 55, 211423266, time.UTC)},
+					LayersCount: 1,
+				},
+				Vulnerabilities: []v1alpha1.Vulnerability{},
+			},
+		},
+		{
+			name:     "Should ignore missing or malformed image metadata",
+			imageRef: "alpine:3.10.2",
+			input: `{"Metadata":{"ImageConfig":{"created":"not a timestamp","rootfs":null}},
+				"Results":[{"Target":"alpine:3.10.2 (alpine 3.10.2)","Vulnerabilities":null}]}`,
+			expectedError: nil,
+			expectedReport: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(fixedTime),
+				Scanner:         sampleReport.Scanner,
+				Registry:        sampleReport.Registry,
+				Artifact:        sampleReport.Artifact,
+				Vulnerabilities: []v1alpha1.Vulnerability{},
+			},
+		},
 		{
 			name:          "Should return error when image reference cannot be parsed",
 			imageRef:      ":",
 			input:         "null",
 			expectedError: errors.New("could not parse reference: :"),
 		},
+		{
+			name:          "Should return error when input is not a JSON object",
+			imageRef:      "alpine:3.10.2",
+			input:         `[]`,
+			expectedError: errors.New("expected {, got ["),
+		},
+		{
+			name:          "Should return scanner mismatch when input is in the format of older Trivy",
+			configData:    map[string]string{"trivy.imageRef": "aquasec/trivy:0.25.2"},
+			imageRef:      "alpine:3.10.2",
+			input:         `[{"Target": "alpine:3.10.2 (alpine 3.10.2)", "Vulnerabilities": null}]`,
+			expectedError: errors.New("Trivy scan results do not match expected scanner aquasec/trivy:0.25.2: output is a JSON array, which is the format of Trivy < 0.20.0"),
+		},
+		{
+			name:          "Should return scanner mismatch when SchemaVersion is not expected",
+			configData:    map[string]string{"trivy.imageRef": "aquasec/trivy:0.25.2"},
+			imageRef:      "alpine:3.10.2",
+			input:         `{"SchemaVersion": 3, "Results": []}`,
+			expectedError: errors.New("Trivy scan results do not match expected scanner aquasec/trivy:0.25.2: output has SchemaVersion 3, want 2"),
+		},
+		{
+			name:          "Should verify input against scanner image of scan job",
+			scannerImage:  "aquasec/trivy:0.25.2",
+			imageRef:      "alpine:3.10.2",
+			input:         `{"Results": []}`,
+			expectedError: errors.New("Trivy scan results do not match expected scanner aquasec/trivy:0.25.2: output has SchemaVersion 0, want 2"),
+		},
+		{
+			name:         "Should report version of scanner image of scan job",
+			configData:   map[string]string{"trivy.imageRef": "aquasec/trivy:0.9.1"},
+			scannerImage: "aquasec/trivy:0.25.2",
+			imageRef:     "alpine:3.10.2",
+			input:        `{"SchemaVersion": 2, "Results": []}`,
+			expectedReport: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(fixedTime),
+				Scanner: v1alpha1.Scanner{
+					Name:    "Trivy",
+					Vendor:  "Aqua Security",
+					Version: "0.25.2",
+				},
+				Registry:        sampleReport.Registry,
+				Artifact:        sampleReport.Artifact,
+				Vulnerabilities: []v1alpha1.Vulnerability{},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			config := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "starboard-trivy-config",
+					Namespace: "starboard-ns",
+				},
+				Data: map[string]string{
+					"trivy.imageRef": "aquasec/trivy:0.9.1",
+				},
+			}
+			for key, value := range tc.configData {
+				config.Data[key] = value
+			}
 			fakeClient := fake.NewClientBuilder().WithObjects(config).Build()
 			ctx := starboard.NewPluginContext().
 				WithName("Trivy").
@@ -3641,6 +4047,7 @@ func TestPlugin_ParseVulnerabilityReportData(t *testing.T) {
 				WithServiceAccountName("starboard-sa").
 				WithClient(fakeClient).
 				Get()
+			ctx = starboard.WithScannerImage(ctx, tc.scannerImage)
 			instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), fakeClient)
 			report, err := instance.ParseVulnerabilityReportData(ctx, tc.imageRef, io.NopCloser(strings.NewReader(tc.input)))
 			switch {
@@ -3655,6 +4062,427 @@ func TestPlugin_ParseVulnerabilityReportData(t *testing.T) {
 
 }
 
+func TestPlugin_GetScanJobSpec_SBOM(t *testing.T) {
+	testCases := []struct {
+		name           string
+		annotations    map[string]string
+		expectedVolume *corev1.Volume
+		expectedScript string
+	}{
+		{
+			name: "Should not add SBOM container without annotation",
+		},
+		{
+			name:        "Should add SBOM container with default key",
+			annotations: map[string]string{starboard.AnnotationSBOMConfigMap: "nginx-sbom"},
+			expectedVolume: &corev1.Volume{
+				Name: "sbom",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "nginx-sbom"},
+						Items:                []corev1.KeyToPath{{Key: "sbom.json", Path: "sbom.json"}},
+						Optional:             pointer.BoolPtr(true),
+					},
+				},
+			},
+			expectedScript: `if [ ! -f /etc/starboard/sbom/sbom.json ]; then echo "SBOM not found in ConfigMap nginx-sbom under key sbom.json"; exit 0; fi; ` +
+				`trivy --cache-dir /tmp/trivy/.cache --quiet sbom --skip-update --format json /etc/starboard/sbom/sbom.json || true`,
+		},
+		{
+			name:        "Should add SBOM container with custom key",
+			annotations: map[string]string{starboard.AnnotationSBOMConfigMap: "nginx-sbom/bom.spdx.json"},
+			expectedVolume: &corev1.Volume{
+				Name: "sbom",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "nginx-sbom"},
+						Items:                []corev1.KeyToPath{{Key: "bom.spdx.json", Path: "sbom.json"}},
+						Optional:             pointer.BoolPtr(true),
+					},
+				},
+			},
+			expectedScript: `if [ ! -f /etc/starboard/sbom/sbom.json ]; then echo "SBOM not found in ConfigMap nginx-sbom under key bom.spdx.json"; exit 0; fi; ` +
+				`trivy --cache-dir /tmp/trivy/.cache --quiet sbom --skip-update --format json /etc/starboard/sbom/sbom.json || true`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeclient := fake.NewClientBuilder().WithObjects(
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "starboard-trivy-config",
+						Namespace: "starboard-ns",
+					},
+					Data: map[string]string{
+						"trivy.imageRef":     "docker.io/aquasec/trivy:0.25.2",
+						"trivy.mode":         string(trivy.Standalone),
+						"trivy.dbRepository": defaultDBRepository,
+					},
+				},
+			).Build()
+			pluginContext := starboard.NewPluginContext().
+				WithName(trivy.Plugin).
+				WithNamespace("starboard-ns").
+				WithServiceAccountName("starboard-sa").
+				WithClient(fakeclient).
+				Get()
+			workload := &appsv1.ReplicaSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "nginx-6799fc88d8",
+					Namespace:   "prod-ns",
+					Annotations: tc.annotations,
+				},
+				Spec: appsv1.ReplicaSetSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "nginx", Image: "nginx:1.16"}},
+						},
+					},
+				},
+			}
+			instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), fakeclient)
+			jobSpec, _, err := instance.GetScanJobSpec(pluginContext, workload, nil)
+			require.NoError(t, err)
+
+			if tc.expectedVolume == nil {
+				require.Len(t, jobSpec.Containers, 1)
+				assert.Equal(t, "nginx", jobSpec.Containers[0].Name)
+				return
+			}
+			require.Len(t, jobSpec.Containers, 2)
+			sbomContainer := jobSpec.Containers[1]
+			assert.Equal(t, vulnerabilityreport.SBOMContainerName, sbomContainer.Name)
+			assert.Equal(t, "docker.io/aquasec/trivy:0.25.2", sbomContainer.Image)
+			assert.Equal(t, []string{"/bin/sh"}, sbomContainer.Command)
+			assert.Equal(t, []string{"-c", tc.expectedScript}, sbomContainer.Args)
+			assert.Contains(t, sbomContainer.VolumeMounts, corev1.VolumeMount{Name: "tmp", MountPath: "/tmp"})
+			assert.Contains(t, sbomContainer.VolumeMounts, corev1.VolumeMount{Name: "sbom", MountPath: "/etc/starboard/sbom", ReadOnly: true})
+			assert.Contains(t, jobSpec.Volumes, *tc.expectedVolume)
+		})
+	}
+}
+
+func TestPlugin_GetScanJobSpec_ServerRetries(t *testing.T) {
+	newJobSpec := func(t *testing.T, configData map[string]string) corev1.PodSpec {
+		t.Helper()
+		data := map[string]string{
+			"trivy.imageRef":  "docker.io/aquasec/trivy:0.25.2",
+			"trivy.mode":      string(trivy.ClientServer),
+			"trivy.serverURL": "http://trivy.trivy:4954",
+		}
+		for key, value := range configData {
+			data[key] = value
+		}
+		fakeclient := fake.NewClientBuilder().WithObjects(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "starboard-trivy-config",
+					Namespace: "starboard-ns",
+				},
+				Data: data,
+			},
+		).Build()
+		pluginContext := starboard.NewPluginContext().
+			WithName(trivy.Plugin).
+			WithNamespace("starboard-ns").
+			WithServiceAccountName("starboard-sa").
+			WithClient(fakeclient).
+			Get()
+		workload := &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nginx-6799fc88d8",
+				Namespace: "prod-ns",
+			},
+			Spec: appsv1.ReplicaSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "nginx", Image: "nginx:1.16"}},
+					},
+				},
+			},
+		}
+		instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), fakeclient)
+		jobSpec, _, err := instance.GetScanJobSpec(pluginContext, workload, nil)
+		require.NoError(t, err)
+		require.Len(t, jobSpec.Containers, 1)
+		return jobSpec
+	}
+
+	t.Run("Should run Trivy client without retries by default", func(t *testing.T) {
+		container := newJobSpec(t, nil).Containers[0]
+		assert.Equal(t, []string{"trivy"}, container.Command)
+		assert.Equal(t, []string{"--quiet", "client", "--format", "json", "--remote", "http://trivy.trivy:4954", "nginx:1.16"}, container.Args)
+	})
+
+	t.Run("Should wrap Trivy client with retries", func(t *testing.T) {
+		container := newJobSpec(t, map[string]string{
+			"trivy.serverRetries":         "3",
+			"trivy.serverRetryBackoff":    "1500ms",
+			"trivy.serverRetryMaxBackoff": "30s",
+		}).Containers[0]
+		assert.Equal(t, []string{"/bin/sh"}, container.Command)
+		require.Len(t, container.Args, 2)
+		assert.Equal(t, "-c", container.Args[0])
+		script := container.Args[1]
+		assert.Contains(t, script, "retries=3\ndelay=2\nmax_delay=30\n")
+		assert.Contains(t, script, `err=$('trivy' '--quiet' 'client' '--format' 'json' '--remote' 'http://trivy.trivy:4954' 'nginx:1.16' 2>&1 1>&3 3>&-)`)
+		assert.Contains(t, script, `grep -qE 'HTTP status code (429|5[0-9][0-9])'`)
+		assert.Contains(t, script, "exit 75")
+	})
+
+}
+
+func TestPlugin_GetScanJobSpec_Platform(t *testing.T) {
+	newJobSpec := func(t *testing.T, mode trivy.Mode, annotations map[string]string) corev1.PodSpec {
+		t.Helper()
+		fakeclient := fake.NewClientBuilder().WithObjects(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "starboard-trivy-config",
+					Namespace: "starboard-ns",
+				},
+				Data: map[string]string{
+					"trivy.imageRef":     "docker.io/aquasec/trivy:0.25.2",
+					"trivy.mode":         string(mode),
+					"trivy.serverURL":    "http://trivy.trivy:4954",
+					"trivy.dbRepository": defaultDBRepository,
+				},
+			},
+		).Build()
+		pluginContext := starboard.NewPluginContext().
+			WithName(trivy.Plugin).
+			WithNamespace("starboard-ns").
+			WithServiceAccountName("starboard-sa").
+			WithClient(fakeclient).
+			Get()
+		workload := &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "nginx-6799fc88d8",
+				Namespace:   "prod-ns",
+				Annotations: annotations,
+			},
+			Spec: appsv1.ReplicaSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "nginx", Image: "nginx:1.16"}},
+					},
+				},
+			},
+		}
+		instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), fakeclient)
+		jobSpec, _, err := instance.GetScanJobSpec(pluginContext, workload, nil)
+		require.NoError(t, err)
+		require.Len(t, jobSpec.Containers, 1)
+		return jobSpec
+	}
+	platform := map[string]string{starboard.AnnotationPlatform: "linux/arm64"}
+
+	t.Run("Should pass platform to Trivy in Standalone mode", func(t *testing.T) {
+		container := newJobSpec(t, trivy.Standalone, platform).Containers[0]
+		assert.Equal(t, []string{"--cache-dir", "/tmp/trivy/.cache", "--quiet", "image", "--skip-update",
+			"--format", "json", "--platform", "linux/arm64", "nginx:1.16"}, container.Args)
+	})
+
+	t.Run("Should pass platform to Trivy in ClientServer mode", func(t *testing.T) {
+		container := newJobSpec(t, trivy.ClientServer, platform).Containers[0]
+		assert.Equal(t, []string{"--quiet", "client", "--format", "json", "--remote", "http://trivy.trivy:4954",
+			"--platform", "linux/arm64", "nginx:1.16"}, container.Args)
+	})
+
+	t.Run("Should not pass platform to Trivy unless known", func(t *testing.T) {
+		container := newJobSpec(t, trivy.Standalone, nil).Containers[0]
+		assert.NotContains(t, container.Args, "--platform")
+	})
+}
+
+func TestMergeIgnoreFiles(t *testing.T) {
+	global := "# accepted risks\nCVE-2019-1543\n\nCVE-2020-1967 exp:2022-12-31\n"
+	namespace := "CVE-2020-1967\n  CVE-2021-3449  \n#CVE-2021-3450\n"
+	assert.Equal(t, "CVE-2019-1543\nCVE-2020-1967 exp:2022-12-31\nCVE-2021-3449\n",
+		trivy.MergeIgnoreFiles(global, namespace))
+	assert.Equal(t, "", trivy.MergeIgnoreFiles("", "# nothing ignored\n"))
+}
+
+func TestPlugin_GetScanJobSpec_NamespaceIgnoreFile(t *testing.T) {
+	newPlugin := func(objects ...client.Object) (vulnerabilityreport.Plugin, starboard.PluginContext) {
+		fakeclient := fake.NewClientBuilder().WithObjects(append(objects,
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "starboard-trivy-config",
+					Namespace: "starboard-ns",
+				},
+				Data: map[string]string{
+					"trivy.imageRef":     "docker.io/aquasec/trivy:0.25.2",
+					"trivy.mode":         string(trivy.Standalone),
+					"trivy.dbRepository": defaultDBRepository,
+					"trivy.ignoreFile":   "CVE-2019-1543\n",
+				},
+			})...,
+		).Build()
+		pluginContext := starboard.NewPluginContext().
+			WithName(trivy.Plugin).
+			WithNamespace("starboard-ns").
+			WithServiceAccountName("starboard-sa").
+			WithClient(fakeclient).
+			Get()
+		return trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), fakeclient), pluginContext
+	}
+	workload := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6799fc88d8",
+			Namespace: "prod-ns",
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "nginx", Image: "nginx:1.16"}},
+				},
+			},
+		},
+	}
+	namespaceIgnoreFile := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      trivy.NamespaceIgnoreFileConfigMapName,
+			Namespace: "prod-ns",
+		},
+		Data: map[string]string{
+			".trivyignore": "CVE-2020-1967\nCVE-2019-1543\n",
+		},
+	}
+	findVolume := func(t *testing.T, spec corev1.PodSpec) corev1.Volume {
+		t.Helper()
+		for _, volume := range spec.Volumes {
+			if volume.Name == "ignorefile" {
+				return volume
+			}
+		}
+		require.FailNow(t, "ignorefile volume not found")
+		return corev1.Volume{}
+	}
+
+	t.Run("Should mount global ignore file", func(t *testing.T) {
+		instance, pluginContext := newPlugin()
+		jobSpec, secrets, err := instance.GetScanJobSpec(pluginContext, workload, nil)
+		require.NoError(t, err)
+		assert.Empty(t, secrets)
+		volume := findVolume(t, jobSpec)
+		require.NotNil(t, volume.ConfigMap)
+		assert.Equal(t, "starboard-trivy-config", volume.ConfigMap.Name)
+	})
+
+	t.Run("Should mount merged ignore files from secret", func(t *testing.T) {
+		instance, pluginContext := newPlugin(namespaceIgnoreFile)
+		jobSpec, secrets, err := instance.GetScanJobSpec(pluginContext, workload, nil)
+		require.NoError(t, err)
+		require.Len(t, secrets, 1)
+		assert.Equal(t, "CVE-2019-1543\nCVE-2020-1967\n", string(secrets[0].Data[".trivyignore"]))
+		volume := findVolume(t, jobSpec)
+		require.NotNil(t, volume.Secret)
+		assert.Equal(t, secrets[0].Name, volume.Secret.SecretName)
+	})
+
+	t.Run("Should compute config hash only for namespaces with ignore file", func(t *testing.T) {
+		instance, pluginContext := newPlugin(namespaceIgnoreFile)
+		plugin := instance.(vulnerabilityreport.NamespaceConfigPlugin)
+		hash, err := plugin.NamespaceConfigHash(pluginContext, "prod-ns")
+		require.NoError(t, err)
+		assert.NotEmpty(t, hash)
+		hash, err = plugin.NamespaceConfigHash(pluginContext, "default")
+		require.NoError(t, err)
+		assert.Empty(t, hash)
+	})
+}
+
+func TestPlugin_ParseSBOMVulnerabilities(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		configData              map[string]string
+		input                   string
+		expectedVulnerabilities []v1alpha1.Vulnerability
+		expectedError           string
+	}{
+		{
+			name: "Should convert vulnerabilities found in SBOM",
+			configData: map[string]string{
+				"trivy.severity": "HIGH,CRITICAL",
+			},
+			input: `{"Results":[{"Target":"/etc/starboard/sbom/sbom.json","Vulnerabilities":[` +
+				`{"VulnerabilityID":"CVE-2021-44228","PkgName":"org.apache.logging.log4j:log4j-core","InstalledVersion":"2.14.1","FixedVersion":"2.15.0","Severity":"CRITICAL","Title":"log4j RCE","PrimaryURL":"https://avd.aquasec.com/nvd/cve-2021-44228"},` +
+				`{"VulnerabilityID":"CVE-2020-9488","PkgName":"org.apache.logging.log4j:log4j-core","InstalledVersion":"2.14.1","FixedVersion":"2.13.2","Severity":"LOW"}]}]}`,
+			expectedVulnerabilities: []v1alpha1.Vulnerability{
+				{
+					VulnerabilityID:  "CVE-2021-44228",
+					Resource:         "org.apache.logging.log4j:log4j-core",
+					InstalledVersion: "2.14.1",
+					FixedVersion:     "2.15.0",
+					Severity:         v1alpha1.SeverityCritical,
+					Title:            "log4j RCE",
+					PrimaryLink:      "https://avd.aquasec.com/nvd/cve-2021-44228",
+					Links:            []string{},
+				},
+			},
+		},
+		{
+			name:                    "Should return empty vulnerabilities for null report",
+			input:                   "null",
+			expectedVulnerabilities: []v1alpha1.Vulnerability{},
+		},
+		{
+			name:          "Should return error when SBOM is missing",
+			input:         "SBOM not found in ConfigMap nginx-sbom under key sbom.json\n",
+			expectedError: "SBOM not found in ConfigMap nginx-sbom under key sbom.json",
+		},
+		{
+			name:          "Should return error output by Trivy",
+			input:         "2022-04-20T10:14:38.000Z\tFATAL\tsbom scan error: failed to detect SBOM format\nmore details\n",
+			expectedError: "2022-04-20T10:14:38.000Z\tFATAL\tsbom scan error: failed to detect SBOM format",
+		},
+		{
+			name:          "Should return error when there is no output",
+			input:         "  \n",
+			expectedError: "SBOM scan produced no output",
+		},
+		{
+			name:          "Should return error when report is malformed",
+			input:         `{"Results":[{"Vulnerabilities":`,
+			expectedError: "malformed SBOM scan results: EOF",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "starboard-trivy-config",
+					Namespace: "starboard-ns",
+				},
+				Data: map[string]string{
+					"trivy.imageRef": "aquasec/trivy:0.25.2",
+				},
+			}
+			for key, value := range tc.configData {
+				config.Data[key] = value
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(config).Build()
+			ctx := starboard.NewPluginContext().
+				WithName("Trivy").
+				WithNamespace("starboard-ns").
+				WithServiceAccountName("starboard-sa").
+				WithClient(fakeClient).
+				Get()
+			instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), fakeClient).(vulnerabilityreport.SBOMPlugin)
+			vulnerabilities, err := instance.ParseSBOMVulnerabilities(ctx, io.NopCloser(strings.NewReader(tc.input)))
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedVulnerabilities, vulnerabilities)
+		})
+	}
+}
+
 func TestGetScoreFromCVSS(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -3760,3 +4588,88 @@ func TestGetMirroredImage(t *testing.T) {
 		})
 	}
 }
+
+func TestPlugin_GetDirectScanCommand(t *testing.T) {
+	workload := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "nginx",
+			Namespace:   "prod-ns",
+			Annotations: map[string]string{starboard.AnnotationPlatform: "linux/arm64"},
+		},
+	}
+	newContext := func(configData map[string]string, secretData map[string][]byte) starboard.PluginContext {
+		fakeClient := fake.NewClientBuilder().WithObjects(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "starboard-trivy-config", Namespace: "starboard-ns"},
+				Data:       configData,
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "starboard-trivy-config", Namespace: "starboard-ns"},
+				Data:       secretData,
+			},
+		).Build()
+		return starboard.NewPluginContext().
+			WithName("Trivy").
+			WithNamespace("starboard-ns").
+			WithServiceAccountName("starboard-sa").
+			WithClient(fakeClient).
+			Get()
+	}
+	instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), nil).(vulnerabilityreport.DirectScanPlugin)
+
+	t.Run("Should get command in Standalone mode", func(t *testing.T) {
+		ctx := newContext(map[string]string{
+			"trivy.imageRef":                        "docker.io/aquasec/trivy:0.25.2",
+			"trivy.mode":                            string(trivy.Standalone),
+			"trivy.dbRepository":                    defaultDBRepository,
+			"trivy.severity":                        "HIGH,CRITICAL",
+			"trivy.insecureRegistry.poc":            "poc.myregistry.harbor.com.pl",
+			"trivy.registry.mirror.index.docker.io": "mirror.io",
+		}, map[string][]byte{
+			"trivy.githubToken": []byte("ghp_token"),
+		})
+		command, err := instance.GetDirectScanCommand(ctx, workload, "poc.myregistry.harbor.com.pl/nginx:1.16",
+			&docker.Auth{Username: "admin", Password: "s3cret"})
+		require.NoError(t, err)
+		assert.Equal(t, vulnerabilityreport.DirectScanCommand{
+			Binary: "trivy",
+			Image:  "docker.io/aquasec/trivy:0.25.2",
+			Args: []string{"--quiet", "image", "--format", "json", "--db-repository", defaultDBRepository,
+				"--platform", "linux/arm64", "poc.myregistry.harbor.com.pl/nginx:1.16"},
+			Env: []string{
+				"TRIVY_SEVERITY=HIGH,CRITICAL",
+				"TRIVY_USERNAME=admin",
+				"TRIVY_PASSWORD=s3cret",
+				"TRIVY_INSECURE=true",
+				"GITHUB_TOKEN=ghp_token",
+			},
+		}, command)
+
+		command, err = instance.GetDirectScanCommand(ctx, workload, "nginx:1.16", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "mirror.io/library/nginx:1.16", command.Args[len(command.Args)-1])
+	})
+
+	t.Run("Should get command in ClientServer mode", func(t *testing.T) {
+		ctx := newContext(map[string]string{
+			"trivy.imageRef":          "docker.io/aquasec/trivy:0.25.2",
+			"trivy.mode":              string(trivy.ClientServer),
+			"trivy.serverURL":         "http://trivy.trivy:4954",
+			"trivy.serverTokenHeader": "Trivy-Token",
+		}, map[string][]byte{
+			"trivy.serverToken": []byte("server-token"),
+		})
+		command, err := instance.GetDirectScanCommand(ctx, workload, "nginx:1.16", nil)
+		require.NoError(t, err)
+		assert.Equal(t, vulnerabilityreport.DirectScanCommand{
+			Binary: "trivy",
+			Image:  "docker.io/aquasec/trivy:0.25.2",
+			Args: []string{"--quiet", "client", "--format", "json", "--remote", "http://trivy.trivy:4954",
+				"--platform", "linux/arm64", "nginx:1.16"},
+			Env: []string{
+				"TRIVY_TOKEN_HEADER=Trivy-Token",
+				"TRIVY_TOKEN=server-token",
+			},
+		}, command)
+	})
+}
@@ -0,0 +1,77 @@
+package trivy
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+)
+
+// serverOverloadedPattern matches errors of Trivy client when Trivy server, or
+// a proxy in front of it, responds with HTTP 429 or 5xx status codes, e.g.
+//
+//     twirp error unavailable: Error from intermediary with HTTP status code 429 "Too Many Requests"
+const serverOverloadedPattern = `HTTP status code (429|5[0-9][0-9])`
+
+// withServerRetries returns the command and args of a container that runs the
+// given Trivy client command and retries it up to the given number of times
+// when the server is overloaded. Delays between retries start at the given
+// backoff and double up to the given maximum, with jitter so that concurrent
+// scan jobs do not retry in lockstep.
+//
+// Only the output of the last attempt is written to the container logs, which
+// are parsed as the scan report. If the server is still overloaded after the
+// last retry, the container exits with
+// vulnerabilityreport.ExitCodeServerOverloaded.
+func withServerRetries(trivyCommand []string, retries int, backoff, maxBackoff time.Duration) ([]string, []string) {
+	quoted := make([]string, len(trivyCommand))
+	for i, arg := range trivyCommand {
+		quoted[i] = shellQuote(arg)
+	}
+	script := fmt.Sprintf(`retries=%d
+delay=%d
+max_delay=%d
+attempt=0
+while true; do
+  { err=$(%s 2>&1 1>&3 3>&-); } 3>&1
+  rc=$?
+  if [ $rc -eq 0 ]; then
+    exit 0
+  fi
+  if ! echo "$err" | grep -qE '%s'; then
+    echo "$err" >&2
+    exit $rc
+  fi
+  if [ $attempt -ge $retries ]; then
+    echo "$err" >&2
+    echo "Trivy server overloaded: giving up after $retries retries" >&2
+    exit %d
+  fi
+  attempt=$((attempt + 1))
+  sleep $((delay / 2 + $(od -An -N2 -tu2 /dev/urandom) %% (delay / 2 + 1)))
+  delay=$((delay * 2))
+  if [ $delay -gt $max_delay ]; then
+    delay=$max_delay
+  fi
+done`,
+		retries,
+		seconds(backoff),
+		seconds(maxBackoff),
+		strings.Join(quoted, " "),
+		serverOverloadedPattern,
+		vulnerabilityreport.ExitCodeServerOverloaded,
+	)
+	return []string{"/bin/sh"}, []string{"-c", script}
+}
+
+// seconds returns the given duration in whole seconds, rounded up.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// shellQuote quotes the given string as a single argument of a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,77 @@
+package trivy
+
+import (
+	"bufio"
+	"fmt"
+	"unicode"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/hashicorp/go-version"
+)
+
+// schemaVersions are versions of the JSON output format of Trivy along with
+// the first version of Trivy which outputs them. Earlier versions of Trivy
+// output a JSON array of results instead.
+var schemaVersions = []struct {
+	since         *version.Version
+	schemaVersion int
+}{
+	{since: version.Must(version.NewVersion("0.20.0")), schemaVersion: 2},
+}
+
+// expectedSchemaVersion returns the SchemaVersion of the JSON output of the
+// Trivy image with the specified reference. It returns false if the version of
+// Trivy cannot be determined from the image tag, e.g. for digests or the latest
+// tag, or if Trivy predates versioned output.
+func expectedSchemaVersion(imageRef string) (int, bool) {
+	tag, err := starboard.GetVersionFromImageRef(imageRef)
+	if err != nil {
+		return 0, false
+	}
+	v, err := version.NewVersion(tag)
+	if err != nil {
+		return 0, false
+	}
+	for i := len(schemaVersions) - 1; i >= 0; i-- {
+		if !v.LessThan(schemaVersions[i].since) {
+			return schemaVersions[i].schemaVersion, true
+		}
+	}
+	return 0, false
+}
+
+// verifyScanReport returns a starboard.ScannerMismatchError if a scan report,
+// which starts with the specified byte and has the specified SchemaVersion, was
+// not output by the Trivy image with the specified reference.
+func verifyScanReport(imageRef string, start byte, schemaVersion int) error {
+	expected, ok := expectedSchemaVersion(imageRef)
+	if !ok {
+		return nil
+	}
+	var reason string
+	switch {
+	case start == '[':
+		reason = fmt.Sprintf("output is a JSON array, which is the format of Trivy < %s", schemaVersions[0].since)
+	case start == '{' && schemaVersion != expected:
+		reason = fmt.Sprintf("output has SchemaVersion %d, want %d", schemaVersion, expected)
+	default:
+		return nil
+	}
+	return &starboard.ScannerMismatchError{Scanner: "Trivy", Expected: imageRef, Reason: reason}
+}
+
+// peekStart skips leading white space of the output read by the given reader
+// and returns the first byte of the output without consuming it, or 0 if the
+// output is empty.
+func peekStart(reader *bufio.Reader) byte {
+	for {
+		b, err := reader.Peek(1)
+		if err != nil {
+			return 0
+		}
+		if !unicode.IsSpace(rune(b[0])) {
+			return b[0]
+		}
+		_, _ = reader.ReadByte()
+	}
+}
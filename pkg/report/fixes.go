@@ -0,0 +1,97 @@
+package report
+
+import (
+	"sort"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/report/templates"
+)
+
+// severityWeights are weights of vulnerabilities removed by a fix, which
+// favor fixes of a few critical vulnerabilities over fixes of many low ones.
+var severityWeights = map[v1alpha1.Severity]int{
+	v1alpha1.SeverityCritical: 10,
+	v1alpha1.SeverityHigh:     5,
+	v1alpha1.SeverityMedium:   2,
+	v1alpha1.SeverityLow:      1,
+	v1alpha1.SeverityUnknown:  1,
+}
+
+type fixKey struct {
+	resource     string
+	fixedVersion string
+}
+
+// GroupFixes groups vulnerabilities of the given containers by package and
+// fixed version, and returns fixes ordered by score, i.e. the fix which
+// removes the most vulnerabilities weighted by severity goes first.
+// Vulnerabilities without a fixed version are skipped.
+func GroupFixes(reports map[string]v1alpha1.VulnerabilityReportData) []templates.Fix {
+	fixes := make(map[fixKey]*templates.Fix)
+	containers := make(map[fixKey]map[string]bool)
+	ids := make(map[fixKey]map[string]bool)
+
+	for container, report := range reports {
+		for _, v := range report.Vulnerabilities {
+			if v.FixedVersion == "" {
+				continue
+			}
+			key := fixKey{resource: v.Resource, fixedVersion: v.FixedVersion}
+			fix, ok := fixes[key]
+			if !ok {
+				fix = &templates.Fix{Resource: v.Resource, FixedVersion: v.FixedVersion}
+				fixes[key] = fix
+				containers[key] = make(map[string]bool)
+				ids[key] = make(map[string]bool)
+			}
+			switch v.Severity {
+			case v1alpha1.SeverityCritical:
+				fix.Summary.CriticalCount++
+			case v1alpha1.SeverityHigh:
+				fix.Summary.HighCount++
+			case v1alpha1.SeverityMedium:
+				fix.Summary.MediumCount++
+			case v1alpha1.SeverityLow:
+				fix.Summary.LowCount++
+			default:
+				fix.Summary.UnknownCount++
+			}
+			fix.Score += severityWeights[v.Severity]
+			if !containers[key][container] {
+				containers[key][container] = true
+				fix.Containers = append(fix.Containers, container)
+			}
+			if !ids[key][v.VulnerabilityID] {
+				ids[key][v.VulnerabilityID] = true
+				fix.VulnerabilityIDs = append(fix.VulnerabilityIDs, v.VulnerabilityID)
+			}
+		}
+	}
+
+	result := make([]templates.Fix, 0, len(fixes))
+	for _, fix := range fixes {
+		sort.Strings(fix.Containers)
+		sort.Strings(fix.VulnerabilityIDs)
+		result = append(result, *fix)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		if result[i].Findings() != result[j].Findings() {
+			return result[i].Findings() > result[j].Findings()
+		}
+		if result[i].Resource != result[j].Resource {
+			return result[i].Resource < result[j].Resource
+		}
+		return result[i].FixedVersion < result[j].FixedVersion
+	})
+	return result
+}
+
+// TopFixes returns at most N fixes with the highest score.
+func TopFixes(reports map[string]v1alpha1.VulnerabilityReportData, N int) []templates.Fix {
+	fixes := GroupFixes(reports)
+	return fixes[:ext.MinInt(N, len(fixes))]
+}
@@ -0,0 +1,89 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/report/templates"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupFixes(t *testing.T) {
+	reports := map[string]v1alpha1.VulnerabilityReportData{
+		"nginx": {
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2021-3711", Resource: "libssl1.1", FixedVersion: "1.1.1d-0+deb10u7", Severity: v1alpha1.SeverityCritical},
+				{VulnerabilityID: "CVE-2021-3712", Resource: "libssl1.1", FixedVersion: "1.1.1d-0+deb10u7", Severity: v1alpha1.SeverityHigh},
+				{VulnerabilityID: "CVE-2021-33574", Resource: "libc6", FixedVersion: "2.28-10+deb10u2", Severity: v1alpha1.SeverityHigh},
+				{VulnerabilityID: "CVE-2019-25013", Resource: "libc6", FixedVersion: "2.28-10+deb10u2", Severity: v1alpha1.SeverityHigh},
+				{VulnerabilityID: "CVE-2021-3520", Resource: "liblz4-1", FixedVersion: "1.8.3-1+deb10u1", Severity: v1alpha1.SeverityCritical},
+				{VulnerabilityID: "CVE-2011-3374", Resource: "apt", FixedVersion: "", Severity: v1alpha1.SeverityLow},
+			},
+		},
+		"sidecar": {
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2021-3711", Resource: "libssl1.1", FixedVersion: "1.1.1d-0+deb10u7", Severity: v1alpha1.SeverityCritical},
+				{VulnerabilityID: "CVE-2021-3449", Resource: "libssl1.1", FixedVersion: "1.1.1d-0+deb10u6", Severity: v1alpha1.SeverityMedium},
+				{VulnerabilityID: "CVE-2020-1712", Resource: "libsystemd0", FixedVersion: "241-7~deb10u3", Severity: v1alpha1.SeverityUnknown},
+			},
+		},
+	}
+
+	expected := []templates.Fix{
+		{
+			Resource:         "libssl1.1",
+			FixedVersion:     "1.1.1d-0+deb10u7",
+			Containers:       []string{"nginx", "sidecar"},
+			VulnerabilityIDs: []string{"CVE-2021-3711", "CVE-2021-3712"},
+			Summary:          v1alpha1.VulnerabilitySummary{CriticalCount: 2, HighCount: 1},
+			Score:            25,
+		},
+		{
+			Resource:         "libc6",
+			FixedVersion:     "2.28-10+deb10u2",
+			Containers:       []string{"nginx"},
+			VulnerabilityIDs: []string{"CVE-2019-25013", "CVE-2021-33574"},
+			Summary:          v1alpha1.VulnerabilitySummary{HighCount: 2},
+			Score:            10,
+		},
+		{
+			Resource:         "liblz4-1",
+			FixedVersion:     "1.8.3-1+deb10u1",
+			Containers:       []string{"nginx"},
+			VulnerabilityIDs: []string{"CVE-2021-3520"},
+			Summary:          v1alpha1.VulnerabilitySummary{CriticalCount: 1},
+			Score:            10,
+		},
+		{
+			Resource:         "libssl1.1",
+			FixedVersion:     "1.1.1d-0+deb10u6",
+			Containers:       []string{"sidecar"},
+			VulnerabilityIDs: []string{"CVE-2021-3449"},
+			Summary:          v1alpha1.VulnerabilitySummary{MediumCount: 1},
+			Score:            2,
+		},
+		{
+			Resource:         "libsystemd0",
+			FixedVersion:     "241-7~deb10u3",
+			Containers:       []string{"sidecar"},
+			VulnerabilityIDs: []string{"CVE-2020-1712"},
+			Summary:          v1alpha1.VulnerabilitySummary{UnknownCount: 1},
+			Score:            1,
+		},
+	}
+
+	t.Run("Should group vulnerabilities by package and fixed version", func(t *testing.T) {
+		assert.Equal(t, expected, GroupFixes(reports))
+	})
+
+	t.Run("Should return top N fixes", func(t *testing.T) {
+		assert.Equal(t, expected[:2], TopFixes(reports, 2))
+		assert.Equal(t, expected, TopFixes(reports, 10))
+	})
+
+	t.Run("Should return no fixes without fixed versions", func(t *testing.T) {
+		assert.Empty(t, GroupFixes(map[string]v1alpha1.VulnerabilityReportData{
+			"nginx": {Vulnerabilities: []v1alpha1.Vulnerability{{VulnerabilityID: "CVE-2011-3374", Resource: "apt"}}},
+		}))
+	})
+}
@@ -12,21 +12,27 @@ import (
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/kubebench"
 	"github.com/aquasecurity/starboard/pkg/report/templates"
+	"github.com/aquasecurity/starboard/pkg/risk"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type workloadReporter struct {
 	clock                      ext.Clock
+	clusterMetadata            kube.ClusterMetadataReader
 	vulnerabilityReportsReader vulnerabilityreport.ReadWriter
 	configAuditReportsReader   configauditreport.ReadWriter
 }
 
-func NewWorkloadReporter(clock ext.Clock, client client.Client) WorkloadReporter {
+func NewWorkloadReporter(clock ext.Clock, client client.Client, clusterMetadata kube.ClusterMetadataReader) WorkloadReporter {
 	return &workloadReporter{
 		clock:                      clock,
+		clusterMetadata:            clusterMetadata,
 		vulnerabilityReportsReader: vulnerabilityreport.NewReadWriter(client),
 		configAuditReportsReader:   configauditreport.NewReadWriter(client),
 	}
@@ -61,8 +67,10 @@ func (h *workloadReporter) RetrieveData(workload kube.ObjectRef) (templates.Work
 	return templates.WorkloadReport{
 		Workload:          workload,
 		GeneratedAt:       h.clock.Now(),
+		Cluster:           readClusterMetadata(ctx, h.clusterMetadata),
 		VulnsReports:      vulnsReports,
 		ConfigAuditReport: configAuditReport,
+		TopFixes:          TopFixes(vulnsReports, 10),
 	}, nil
 }
 
@@ -77,14 +85,16 @@ func (h *workloadReporter) Generate(workload kube.ObjectRef, writer io.Writer) e
 }
 
 type namespaceReporter struct {
-	clock  ext.Clock
-	client client.Client
+	clock           ext.Clock
+	client          client.Client
+	clusterMetadata kube.ClusterMetadataReader
 }
 
-func NewNamespaceReporter(clock ext.Clock, client client.Client) NamespaceReporter {
+func NewNamespaceReporter(clock ext.Clock, client client.Client, clusterMetadata kube.ClusterMetadataReader) NamespaceReporter {
 	return &namespaceReporter{
-		clock:  clock,
-		client: client,
+		clock:           clock,
+		client:          client,
+		clusterMetadata: clusterMetadata,
 	}
 }
 
@@ -104,6 +114,7 @@ func (r *namespaceReporter) RetrieveData(namespace kube.ObjectRef) (templates.Na
 	return templates.NamespaceReport{
 		Namespace:            namespace,
 		GeneratedAt:          r.clock.Now(),
+		Cluster:              readClusterMetadata(context.Background(), r.clusterMetadata),
 		Top5VulnerableImages: r.topNImagesBySeverityCount(vulnerabilityReportList.Items, 5),
 		Top5FailedChecks:     r.topNFailedChecksByAffectedWorkloadsCount(configAuditReportList.Items, 5),
 		Top5Vulnerability:    r.topNVulnerabilitiesByScore(vulnerabilityReportList.Items, 5),
@@ -241,14 +252,16 @@ func (r *namespaceReporter) Generate(namespace kube.ObjectRef, out io.Writer) er
 type nodeReporter struct {
 	clock                  ext.Clock
 	client                 client.Client
+	clusterMetadata        kube.ClusterMetadataReader
 	kubebenchReportsReader kubebench.ReadWriter
 }
 
 // NewNodeReporter generate the html reporter
-func NewNodeReporter(clock ext.Clock, client client.Client) NodeReporter {
+func NewNodeReporter(clock ext.Clock, client client.Client, clusterMetadata kube.ClusterMetadataReader) NodeReporter {
 	return &nodeReporter{
 		clock:                  clock,
 		client:                 client,
+		clusterMetadata:        clusterMetadata,
 		kubebenchReportsReader: kubebench.NewReadWriter(client),
 	}
 }
@@ -271,7 +284,76 @@ func (r *nodeReporter) RetrieveData(node kube.ObjectRef) (templates.NodeReport,
 
 	return templates.NodeReport{
 		GeneratedAt:        r.clock.Now(),
+		Cluster:            readClusterMetadata(context.Background(), r.clusterMetadata),
 		Node:               node,
 		CisKubeBenchReport: found,
 	}, nil
 }
+
+type clusterReporter struct {
+	clock           ext.Clock
+	client          client.Client
+	clusterMetadata kube.ClusterMetadataReader
+}
+
+// NewClusterReporter constructs a new ClusterReporter, which reports the risk
+// score of the cluster followed by the summary of compliance reports.
+func NewClusterReporter(clock ext.Clock, client client.Client, clusterMetadata kube.ClusterMetadataReader) ClusterReporter {
+	return &clusterReporter{
+		clock:           clock,
+		client:          client,
+		clusterMetadata: clusterMetadata,
+	}
+}
+
+func (r *clusterReporter) RetrieveData() (templates.ClusterReport, error) {
+	ctx := context.Background()
+	var riskReport *v1alpha1.ClusterRiskReport
+	found := &v1alpha1.ClusterRiskReport{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: risk.ReportName}, found)
+	switch {
+	case err == nil:
+		riskReport = found
+	case !errors.IsNotFound(err) && !meta.IsNoMatchError(err):
+		return templates.ClusterReport{}, err
+	}
+
+	var complianceReports v1alpha1.ClusterComplianceReportList
+	err = r.client.List(ctx, &complianceReports)
+	if err != nil && !meta.IsNoMatchError(err) {
+		return templates.ClusterReport{}, err
+	}
+	sort.Slice(complianceReports.Items, func(i, j int) bool {
+		return complianceReports.Items[i].Name < complianceReports.Items[j].Name
+	})
+
+	return templates.ClusterReport{
+		GeneratedAt:       r.clock.Now(),
+		Cluster:           readClusterMetadata(ctx, r.clusterMetadata),
+		RiskReport:        riskReport,
+		ComplianceReports: complianceReports.Items,
+	}, nil
+}
+
+func (r *clusterReporter) Generate(out io.Writer) error {
+	data, err := r.RetrieveData()
+	if err != nil {
+		return err
+	}
+	templates.WritePageTemplate(out, &data)
+	return nil
+}
+
+// readClusterMetadata returns metadata of the cluster, or nil if it cannot be
+// read, so that reports are generated without metadata rather than not at all.
+func readClusterMetadata(ctx context.Context, reader kube.ClusterMetadataReader) *v1alpha1.ClusterMetadata {
+	if reader == nil {
+		return nil
+	}
+	metadata, err := reader.Read(ctx)
+	if err != nil {
+		klog.Warningf("Unable to read cluster metadata: %v", err)
+		return nil
+	}
+	return &metadata
+}
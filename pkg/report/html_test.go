@@ -1,12 +1,20 @@
 package report
 
 import (
+	"bytes"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/report/templates"
+	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func Test_topNVulnerabilitiesByScore(t *testing.T) {
@@ -216,3 +224,48 @@ func Test_topNVulnerabilitiesByScore(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterReporter_Generate(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Should report risk score before compliance", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			&v1alpha1.ClusterRiskReport{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Report: v1alpha1.ClusterRiskReportData{
+					UpdateTimestamp: metav1.NewTime(now),
+					Score:           19.5,
+					Components: []v1alpha1.RiskComponent{
+						{Name: "criticalVulnerabilities", Value: 12, Weight: 1, Score: 12},
+						{Name: "configAuditDangers", Value: 30, Weight: 0.25, Score: 7.5},
+					},
+				},
+			},
+			&v1alpha1.ClusterComplianceReport{
+				ObjectMeta: metav1.ObjectMeta{Name: "nsa"},
+				Status: v1alpha1.ReportStatus{
+					Summary: v1alpha1.ClusterComplianceSummary{PassCount: 15, FailCount: 5},
+				},
+			},
+		).Build()
+
+		var out bytes.Buffer
+		err := NewClusterReporter(ext.NewFixedClock(now), c, nil).Generate(&out)
+		require.NoError(t, err)
+		html := out.String()
+		assert.Contains(t, html, "<h2 class=\"text-muted mx-auto\">Aqua Starboard Cluster Security Report</h2>")
+		assert.Contains(t, html, `<p class="display-4 mb-1">19.50</p>`)
+		assert.Contains(t, html, "<td>configAuditDangers</td>")
+		assert.Contains(t, html, "<td>nsa</td>")
+		assert.Less(t, strings.Index(html, "Risk Score"), strings.Index(html, "Compliance"))
+	})
+
+	t.Run("Should generate report without risk score", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+
+		var out bytes.Buffer
+		err := NewClusterReporter(ext.NewFixedClock(now), c, nil).Generate(&out)
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "The risk score of the cluster has not been computed.")
+	})
+}
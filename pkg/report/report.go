@@ -21,3 +21,8 @@ type NodeReporter interface {
 	RetrieveData(node kube.ObjectRef) (templates.NodeReport, error)
 	Generate(node kube.ObjectRef, out io.Writer) error
 }
+
+type ClusterReporter interface {
+	RetrieveData() (templates.ClusterReport, error)
+	Generate(out io.Writer) error
+}
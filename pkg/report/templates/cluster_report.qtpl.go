@@ -0,0 +1,259 @@
+// Code generated by qtc from "cluster_report.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line pkg/report/templates/cluster_report.qtpl:1
+package templates
+
+//line pkg/report/templates/cluster_report.qtpl:1
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line pkg/report/templates/cluster_report.qtpl:1
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line pkg/report/templates/cluster_report.qtpl:1
+func (p *ClusterReport) StreamTitle(qw422016 *qt422016.Writer) {
+//line pkg/report/templates/cluster_report.qtpl:1
+	qw422016.N().S(`
+Aqua Starboard Cluster Security Report
+`)
+//line pkg/report/templates/cluster_report.qtpl:3
+}
+
+//line pkg/report/templates/cluster_report.qtpl:3
+func (p *ClusterReport) WriteTitle(qq422016 qtio422016.Writer) {
+//line pkg/report/templates/cluster_report.qtpl:3
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line pkg/report/templates/cluster_report.qtpl:3
+	p.StreamTitle(qw422016)
+//line pkg/report/templates/cluster_report.qtpl:3
+	qt422016.ReleaseWriter(qw422016)
+//line pkg/report/templates/cluster_report.qtpl:3
+}
+
+//line pkg/report/templates/cluster_report.qtpl:3
+func (p *ClusterReport) Title() string {
+//line pkg/report/templates/cluster_report.qtpl:3
+	qb422016 := qt422016.AcquireByteBuffer()
+//line pkg/report/templates/cluster_report.qtpl:3
+	p.WriteTitle(qb422016)
+//line pkg/report/templates/cluster_report.qtpl:3
+	qs422016 := string(qb422016.B)
+//line pkg/report/templates/cluster_report.qtpl:3
+	qt422016.ReleaseByteBuffer(qb422016)
+//line pkg/report/templates/cluster_report.qtpl:3
+	return qs422016
+//line pkg/report/templates/cluster_report.qtpl:3
+}
+
+//line pkg/report/templates/cluster_report.qtpl:5
+func (p *ClusterReport) StreamBody(qw422016 *qt422016.Writer) {
+//line pkg/report/templates/cluster_report.qtpl:5
+	qw422016.N().S(`
+<div class="container">
+
+  <div class="col mt-5">
+    <div class="row text-center">`)
+//line pkg/report/templates/cluster_report.qtpl:9
+	streamimgAquaLogo(qw422016)
+//line pkg/report/templates/cluster_report.qtpl:9
+	qw422016.N().S(`</div>
+    <div class="row mt-4 text-center">
+      <h2 class="text-muted mx-auto">Aqua Starboard Cluster Security Report</h2>
+    </div>
+    <div class="row text-center">
+      <h3 class="text-muted mx-auto">Generated on `)
+//line pkg/report/templates/cluster_report.qtpl:14
+	qw422016.E().S(p.GeneratedAt.Format("2 Jan 2006 15:04:01"))
+//line pkg/report/templates/cluster_report.qtpl:14
+	qw422016.N().S(`</h3>
+    </div>
+    `)
+//line pkg/report/templates/cluster_report.qtpl:16
+	streamclusterMetadata(qw422016, p.Cluster)
+//line pkg/report/templates/cluster_report.qtpl:16
+	qw422016.N().S(`
+  </div>
+
+  <div class="row text-center border-bottom mt-4">
+    <h3 class="mx-auto" id="risk_header" style="color: rgb(0, 160, 170);">Risk Score</h3>
+  </div>
+  `)
+//line pkg/report/templates/cluster_report.qtpl:22
+	if p.RiskReport != nil {
+//line pkg/report/templates/cluster_report.qtpl:22
+		qw422016.N().S(`
+  `)
+//line pkg/report/templates/cluster_report.qtpl:24
+		report := p.RiskReport.Report
+
+//line pkg/report/templates/cluster_report.qtpl:25
+		qw422016.N().S(`
+  <div class="row my-4 text-center">
+    <div class="col">
+      <p class="display-4 mb-1">`)
+//line pkg/report/templates/cluster_report.qtpl:28
+		qw422016.N().FPrec(report.Score, 2)
+//line pkg/report/templates/cluster_report.qtpl:28
+		qw422016.N().S(`</p>
+      <p class="text-muted">Computed on `)
+//line pkg/report/templates/cluster_report.qtpl:29
+		qw422016.E().S(report.UpdateTimestamp.Format("2 Jan 2006 15:04:01"))
+//line pkg/report/templates/cluster_report.qtpl:29
+		qw422016.N().S(`</p>
+    </div>
+  </div>
+  <div class="row">
+    <table class="table table-sm table-bordered">
+      <thead>
+        <tr>
+          <th scope="col">Component</th>
+          <th scope="col">Value</th>
+          <th scope="col">Weight</th>
+          <th scope="col">Score</th>
+        </tr>
+      </thead>
+      <tbody>
+      `)
+//line pkg/report/templates/cluster_report.qtpl:43
+		for _, component := range report.Components {
+//line pkg/report/templates/cluster_report.qtpl:43
+			qw422016.N().S(`
+      <tr>
+        <td>`)
+//line pkg/report/templates/cluster_report.qtpl:45
+			qw422016.E().S(component.Name)
+//line pkg/report/templates/cluster_report.qtpl:45
+			qw422016.N().S(`</td>
+        <td>`)
+//line pkg/report/templates/cluster_report.qtpl:46
+			qw422016.N().FPrec(component.Value, 2)
+//line pkg/report/templates/cluster_report.qtpl:46
+			qw422016.N().S(`</td>
+        <td>`)
+//line pkg/report/templates/cluster_report.qtpl:47
+			qw422016.N().FPrec(component.Weight, 2)
+//line pkg/report/templates/cluster_report.qtpl:47
+			qw422016.N().S(`</td>
+        <td>`)
+//line pkg/report/templates/cluster_report.qtpl:48
+			qw422016.N().FPrec(component.Score, 2)
+//line pkg/report/templates/cluster_report.qtpl:48
+			qw422016.N().S(`</td>
+      </tr>
+      `)
+//line pkg/report/templates/cluster_report.qtpl:50
+		}
+//line pkg/report/templates/cluster_report.qtpl:50
+		qw422016.N().S(`
+      </tbody>
+    </table>
+  </div>
+  `)
+//line pkg/report/templates/cluster_report.qtpl:54
+	} else {
+//line pkg/report/templates/cluster_report.qtpl:54
+		qw422016.N().S(`
+  <div class="row my-4 text-center">
+    <p class="mx-auto text-muted">The risk score of the cluster has not been computed.</p>
+  </div>
+  `)
+//line pkg/report/templates/cluster_report.qtpl:58
+	}
+//line pkg/report/templates/cluster_report.qtpl:58
+	qw422016.N().S(`
+
+  `)
+//line pkg/report/templates/cluster_report.qtpl:60
+	if len(p.ComplianceReports) > 0 {
+//line pkg/report/templates/cluster_report.qtpl:60
+		qw422016.N().S(`
+  <div class="row">
+    <h3>Compliance</h3>
+    <table class="table table-sm table-bordered">
+      <thead>
+        <tr>
+          <th scope="col">Name</th>
+          <th scope="col">Pass</th>
+          <th scope="col">Fail</th>
+          <th scope="col">Updated</th>
+        </tr>
+      </thead>
+      <tbody>
+      `)
+//line pkg/report/templates/cluster_report.qtpl:73
+		for _, compliance := range p.ComplianceReports {
+//line pkg/report/templates/cluster_report.qtpl:73
+			qw422016.N().S(`
+      <tr>
+        <td>`)
+//line pkg/report/templates/cluster_report.qtpl:75
+			qw422016.E().S(compliance.Name)
+//line pkg/report/templates/cluster_report.qtpl:75
+			qw422016.N().S(`</td>
+        <td>`)
+//line pkg/report/templates/cluster_report.qtpl:76
+			qw422016.N().D(compliance.Status.Summary.PassCount)
+//line pkg/report/templates/cluster_report.qtpl:76
+			qw422016.N().S(`</td>
+        <td>`)
+//line pkg/report/templates/cluster_report.qtpl:77
+			qw422016.N().D(compliance.Status.Summary.FailCount)
+//line pkg/report/templates/cluster_report.qtpl:77
+			qw422016.N().S(`</td>
+        <td>`)
+//line pkg/report/templates/cluster_report.qtpl:78
+			qw422016.E().S(compliance.Status.UpdateTimestamp.Format("2 Jan 2006 15:04:01"))
+//line pkg/report/templates/cluster_report.qtpl:78
+			qw422016.N().S(`</td>
+      </tr>
+      `)
+//line pkg/report/templates/cluster_report.qtpl:80
+		}
+//line pkg/report/templates/cluster_report.qtpl:80
+		qw422016.N().S(`
+      </tbody>
+    </table>
+  </div>
+  `)
+//line pkg/report/templates/cluster_report.qtpl:84
+	}
+//line pkg/report/templates/cluster_report.qtpl:84
+	qw422016.N().S(`
+
+</div>
+`)
+//line pkg/report/templates/cluster_report.qtpl:87
+}
+
+//line pkg/report/templates/cluster_report.qtpl:87
+func (p *ClusterReport) WriteBody(qq422016 qtio422016.Writer) {
+//line pkg/report/templates/cluster_report.qtpl:87
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line pkg/report/templates/cluster_report.qtpl:87
+	p.StreamBody(qw422016)
+//line pkg/report/templates/cluster_report.qtpl:87
+	qt422016.ReleaseWriter(qw422016)
+//line pkg/report/templates/cluster_report.qtpl:87
+}
+
+//line pkg/report/templates/cluster_report.qtpl:87
+func (p *ClusterReport) Body() string {
+//line pkg/report/templates/cluster_report.qtpl:87
+	qb422016 := qt422016.AcquireByteBuffer()
+//line pkg/report/templates/cluster_report.qtpl:87
+	p.WriteBody(qb422016)
+//line pkg/report/templates/cluster_report.qtpl:87
+	qs422016 := string(qb422016.B)
+//line pkg/report/templates/cluster_report.qtpl:87
+	qt422016.ReleaseByteBuffer(qb422016)
+//line pkg/report/templates/cluster_report.qtpl:87
+	return qs422016
+//line pkg/report/templates/cluster_report.qtpl:87
+}
@@ -92,6 +92,11 @@ func (p *NamespaceReport) StreamBody(qw422016 *qt422016.Writer) {
 //line pkg/report/templates/namespace_report.qtpl:19
 	qw422016.N().S(`</h3>
     </div>
+    `)
+//line pkg/report/templates/namespace_report.qtpl:21
+	streamclusterMetadata(qw422016, p.Cluster)
+//line pkg/report/templates/namespace_report.qtpl:21
+	qw422016.N().S(`
   </div>
 
   <div class="row">
@@ -107,43 +112,43 @@ func (p *NamespaceReport) StreamBody(qw422016 *qt422016.Writer) {
       </thead>
       <tbody>
       `)
-//line pkg/report/templates/namespace_report.qtpl:35
+//line pkg/report/templates/namespace_report.qtpl:36
 	for _, report := range p.Top5VulnerableImages {
-//line pkg/report/templates/namespace_report.qtpl:35
+//line pkg/report/templates/namespace_report.qtpl:36
 		qw422016.N().S(`
       `)
-//line pkg/report/templates/namespace_report.qtpl:37
+//line pkg/report/templates/namespace_report.qtpl:38
 		summary := report.Report.Summary
 		otherCount := summary.MediumCount + summary.LowCount + summary.UnknownCount
 
-//line pkg/report/templates/namespace_report.qtpl:39
+//line pkg/report/templates/namespace_report.qtpl:40
 		qw422016.N().S(`
       <tr>
         <td>`)
-//line pkg/report/templates/namespace_report.qtpl:41
-		streamimageReference(qw422016, report.Report.Registry, report.Report.Artifact)
-//line pkg/report/templates/namespace_report.qtpl:41
-		qw422016.N().S(`</td>
-        <td>`)
 //line pkg/report/templates/namespace_report.qtpl:42
-		qw422016.N().D(summary.CriticalCount)
+		streamimageReference(qw422016, report.Report.Registry, report.Report.Artifact)
 //line pkg/report/templates/namespace_report.qtpl:42
 		qw422016.N().S(`</td>
         <td>`)
 //line pkg/report/templates/namespace_report.qtpl:43
-		qw422016.N().D(summary.HighCount)
+		qw422016.N().D(summary.CriticalCount)
 //line pkg/report/templates/namespace_report.qtpl:43
 		qw422016.N().S(`</td>
         <td>`)
 //line pkg/report/templates/namespace_report.qtpl:44
-		qw422016.N().D(otherCount)
+		qw422016.N().D(summary.HighCount)
 //line pkg/report/templates/namespace_report.qtpl:44
 		qw422016.N().S(`</td>
+        <td>`)
+//line pkg/report/templates/namespace_report.qtpl:45
+		qw422016.N().D(otherCount)
+//line pkg/report/templates/namespace_report.qtpl:45
+		qw422016.N().S(`</td>
       </tr>
       `)
-//line pkg/report/templates/namespace_report.qtpl:46
+//line pkg/report/templates/namespace_report.qtpl:47
 	}
-//line pkg/report/templates/namespace_report.qtpl:46
+//line pkg/report/templates/namespace_report.qtpl:47
 	qw422016.N().S(`
       </tbody>
     </table>
@@ -162,40 +167,40 @@ func (p *NamespaceReport) StreamBody(qw422016 *qt422016.Writer) {
       </thead>
       <tbody>
       `)
-//line pkg/report/templates/namespace_report.qtpl:63
+//line pkg/report/templates/namespace_report.qtpl:64
 	for _, vulnerability := range p.Top5Vulnerability {
-//line pkg/report/templates/namespace_report.qtpl:63
+//line pkg/report/templates/namespace_report.qtpl:64
 		qw422016.N().S(`
       <tr>
         <td><a href="`)
-//line pkg/report/templates/namespace_report.qtpl:65
+//line pkg/report/templates/namespace_report.qtpl:66
 		qw422016.E().S(vulnerability.PrimaryLink)
-//line pkg/report/templates/namespace_report.qtpl:65
+//line pkg/report/templates/namespace_report.qtpl:66
 		qw422016.N().S(`">`)
-//line pkg/report/templates/namespace_report.qtpl:65
+//line pkg/report/templates/namespace_report.qtpl:66
 		qw422016.E().S(vulnerability.VulnerabilityID)
-//line pkg/report/templates/namespace_report.qtpl:65
+//line pkg/report/templates/namespace_report.qtpl:66
 		qw422016.N().S(`</a></td>
         <td>`)
-//line pkg/report/templates/namespace_report.qtpl:66
+//line pkg/report/templates/namespace_report.qtpl:67
 		qw422016.E().S(string(vulnerability.Severity))
-//line pkg/report/templates/namespace_report.qtpl:66
+//line pkg/report/templates/namespace_report.qtpl:67
 		qw422016.N().S(`</td>
         <td>`)
-//line pkg/report/templates/namespace_report.qtpl:67
+//line pkg/report/templates/namespace_report.qtpl:68
 		qw422016.N().F(*vulnerability.Score)
-//line pkg/report/templates/namespace_report.qtpl:67
+//line pkg/report/templates/namespace_report.qtpl:68
 		qw422016.N().S(`</td>
         <td>`)
-//line pkg/report/templates/namespace_report.qtpl:68
+//line pkg/report/templates/namespace_report.qtpl:69
 		qw422016.N().D(vulnerability.AffectedWorkloads)
-//line pkg/report/templates/namespace_report.qtpl:68
+//line pkg/report/templates/namespace_report.qtpl:69
 		qw422016.N().S(`</td>
       </tr>
       `)
-//line pkg/report/templates/namespace_report.qtpl:70
+//line pkg/report/templates/namespace_report.qtpl:71
 	}
-//line pkg/report/templates/namespace_report.qtpl:70
+//line pkg/report/templates/namespace_report.qtpl:71
 	qw422016.N().S(`
       </tbody>
     </table>
@@ -214,36 +219,36 @@ func (p *NamespaceReport) StreamBody(qw422016 *qt422016.Writer) {
       </thead>
       <tbody>
       `)
-//line pkg/report/templates/namespace_report.qtpl:87
+//line pkg/report/templates/namespace_report.qtpl:88
 	for _, report := range p.Top5FailedChecks {
-//line pkg/report/templates/namespace_report.qtpl:87
+//line pkg/report/templates/namespace_report.qtpl:88
 		qw422016.N().S(`
       <tr>
         <td>`)
-//line pkg/report/templates/namespace_report.qtpl:89
-		qw422016.E().S(report.ID)
-//line pkg/report/templates/namespace_report.qtpl:89
-		qw422016.N().S(`</td>
-        <td>`)
 //line pkg/report/templates/namespace_report.qtpl:90
-		qw422016.E().V(report.Severity)
+		qw422016.E().S(report.ID)
 //line pkg/report/templates/namespace_report.qtpl:90
 		qw422016.N().S(`</td>
         <td>`)
 //line pkg/report/templates/namespace_report.qtpl:91
-		qw422016.E().S(report.Category)
+		qw422016.E().V(report.Severity)
 //line pkg/report/templates/namespace_report.qtpl:91
 		qw422016.N().S(`</td>
         <td>`)
 //line pkg/report/templates/namespace_report.qtpl:92
-		qw422016.N().D(report.AffectedWorkloads)
+		qw422016.E().S(report.Category)
 //line pkg/report/templates/namespace_report.qtpl:92
 		qw422016.N().S(`</td>
+        <td>`)
+//line pkg/report/templates/namespace_report.qtpl:93
+		qw422016.N().D(report.AffectedWorkloads)
+//line pkg/report/templates/namespace_report.qtpl:93
+		qw422016.N().S(`</td>
       </tr>
       `)
-//line pkg/report/templates/namespace_report.qtpl:94
+//line pkg/report/templates/namespace_report.qtpl:95
 	}
-//line pkg/report/templates/namespace_report.qtpl:94
+//line pkg/report/templates/namespace_report.qtpl:95
 	qw422016.N().S(`
       </tbody>
     </table>
@@ -251,160 +256,160 @@ func (p *NamespaceReport) StreamBody(qw422016 *qt422016.Writer) {
 
 </div>
 `)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:101
 }
 
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:101
 func (p *NamespaceReport) WriteBody(qq422016 qtio422016.Writer) {
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:101
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:101
 	p.StreamBody(qw422016)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:101
 	qt422016.ReleaseWriter(qw422016)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:101
 }
 
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:101
 func (p *NamespaceReport) Body() string {
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:101
 	qb422016 := qt422016.AcquireByteBuffer()
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:101
 	p.WriteBody(qb422016)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:101
 	qs422016 := string(qb422016.B)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:101
 	qt422016.ReleaseByteBuffer(qb422016)
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:101
 	return qs422016
-//line pkg/report/templates/namespace_report.qtpl:100
+//line pkg/report/templates/namespace_report.qtpl:101
 }
 
-//line pkg/report/templates/namespace_report.qtpl:102
+//line pkg/report/templates/namespace_report.qtpl:103
 func streamimageReference(qw422016 *qt422016.Writer, registry v1alpha1.Registry, artifact v1alpha1.Artifact) {
-//line pkg/report/templates/namespace_report.qtpl:102
+//line pkg/report/templates/namespace_report.qtpl:103
 	qw422016.N().S(`
   `)
-//line pkg/report/templates/namespace_report.qtpl:103
+//line pkg/report/templates/namespace_report.qtpl:104
 	if artifact.Tag != "" && artifact.Digest != "" {
-//line pkg/report/templates/namespace_report.qtpl:103
+//line pkg/report/templates/namespace_report.qtpl:104
 		qw422016.N().S(`
     `)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:105
 		qw422016.E().S(registry.Server)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:105
 		qw422016.N().S(`/`)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:105
 		qw422016.E().S(artifact.Repository)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:105
 		qw422016.N().S(`:`)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:105
 		qw422016.E().S(artifact.Tag)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:105
 		qw422016.N().S(`@`)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:105
 		qw422016.E().S(artifact.Digest)
-//line pkg/report/templates/namespace_report.qtpl:104
+//line pkg/report/templates/namespace_report.qtpl:105
 		qw422016.N().S(`
     `)
-//line pkg/report/templates/namespace_report.qtpl:105
-		return
 //line pkg/report/templates/namespace_report.qtpl:106
+		return
+//line pkg/report/templates/namespace_report.qtpl:107
 	}
-//line pkg/report/templates/namespace_report.qtpl:106
+//line pkg/report/templates/namespace_report.qtpl:107
 	qw422016.N().S(`
 
   `)
-//line pkg/report/templates/namespace_report.qtpl:108
+//line pkg/report/templates/namespace_report.qtpl:109
 	if artifact.Tag == "" && artifact.Digest != "" {
-//line pkg/report/templates/namespace_report.qtpl:108
+//line pkg/report/templates/namespace_report.qtpl:109
 		qw422016.N().S(`
     `)
-//line pkg/report/templates/namespace_report.qtpl:109
+//line pkg/report/templates/namespace_report.qtpl:110
 		qw422016.E().S(registry.Server)
-//line pkg/report/templates/namespace_report.qtpl:109
+//line pkg/report/templates/namespace_report.qtpl:110
 		qw422016.N().S(`/`)
-//line pkg/report/templates/namespace_report.qtpl:109
+//line pkg/report/templates/namespace_report.qtpl:110
 		qw422016.E().S(artifact.Repository)
-//line pkg/report/templates/namespace_report.qtpl:109
+//line pkg/report/templates/namespace_report.qtpl:110
 		qw422016.N().S(`@`)
-//line pkg/report/templates/namespace_report.qtpl:109
+//line pkg/report/templates/namespace_report.qtpl:110
 		qw422016.E().S(artifact.Digest)
-//line pkg/report/templates/namespace_report.qtpl:109
+//line pkg/report/templates/namespace_report.qtpl:110
 		qw422016.N().S(`
     `)
-//line pkg/report/templates/namespace_report.qtpl:110
-		return
 //line pkg/report/templates/namespace_report.qtpl:111
+		return
+//line pkg/report/templates/namespace_report.qtpl:112
 	}
-//line pkg/report/templates/namespace_report.qtpl:111
+//line pkg/report/templates/namespace_report.qtpl:112
 	qw422016.N().S(`
 
   `)
-//line pkg/report/templates/namespace_report.qtpl:113
+//line pkg/report/templates/namespace_report.qtpl:114
 	if artifact.Tag != "" && artifact.Digest == "" {
-//line pkg/report/templates/namespace_report.qtpl:113
+//line pkg/report/templates/namespace_report.qtpl:114
 		qw422016.N().S(`
     `)
-//line pkg/report/templates/namespace_report.qtpl:114
+//line pkg/report/templates/namespace_report.qtpl:115
 		qw422016.E().S(registry.Server)
-//line pkg/report/templates/namespace_report.qtpl:114
+//line pkg/report/templates/namespace_report.qtpl:115
 		qw422016.N().S(`/`)
-//line pkg/report/templates/namespace_report.qtpl:114
+//line pkg/report/templates/namespace_report.qtpl:115
 		qw422016.E().S(artifact.Repository)
-//line pkg/report/templates/namespace_report.qtpl:114
+//line pkg/report/templates/namespace_report.qtpl:115
 		qw422016.N().S(`:`)
-//line pkg/report/templates/namespace_report.qtpl:114
+//line pkg/report/templates/namespace_report.qtpl:115
 		qw422016.E().S(artifact.Tag)
-//line pkg/report/templates/namespace_report.qtpl:114
+//line pkg/report/templates/namespace_report.qtpl:115
 		qw422016.N().S(`
     `)
-//line pkg/report/templates/namespace_report.qtpl:115
-		return
 //line pkg/report/templates/namespace_report.qtpl:116
+		return
+//line pkg/report/templates/namespace_report.qtpl:117
 	}
-//line pkg/report/templates/namespace_report.qtpl:116
+//line pkg/report/templates/namespace_report.qtpl:117
 	qw422016.N().S(`
 
   `)
-//line pkg/report/templates/namespace_report.qtpl:118
+//line pkg/report/templates/namespace_report.qtpl:119
 	qw422016.E().S(registry.Server)
-//line pkg/report/templates/namespace_report.qtpl:118
+//line pkg/report/templates/namespace_report.qtpl:119
 	qw422016.N().S(`/`)
-//line pkg/report/templates/namespace_report.qtpl:118
+//line pkg/report/templates/namespace_report.qtpl:119
 	qw422016.E().S(artifact.Repository)
-//line pkg/report/templates/namespace_report.qtpl:118
+//line pkg/report/templates/namespace_report.qtpl:119
 	qw422016.N().S(`:`)
-//line pkg/report/templates/namespace_report.qtpl:118
+//line pkg/report/templates/namespace_report.qtpl:119
 	qw422016.E().S(artifact.Tag)
-//line pkg/report/templates/namespace_report.qtpl:118
+//line pkg/report/templates/namespace_report.qtpl:119
 	qw422016.N().S(`
 `)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:120
 }
 
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:120
 func writeimageReference(qq422016 qtio422016.Writer, registry v1alpha1.Registry, artifact v1alpha1.Artifact) {
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:120
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:120
 	streamimageReference(qw422016, registry, artifact)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:120
 	qt422016.ReleaseWriter(qw422016)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:120
 }
 
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:120
 func imageReference(registry v1alpha1.Registry, artifact v1alpha1.Artifact) string {
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:120
 	qb422016 := qt422016.AcquireByteBuffer()
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:120
 	writeimageReference(qb422016, registry, artifact)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:120
 	qs422016 := string(qb422016.B)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:120
 	qt422016.ReleaseByteBuffer(qb422016)
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:120
 	return qs422016
-//line pkg/report/templates/namespace_report.qtpl:119
+//line pkg/report/templates/namespace_report.qtpl:120
 }
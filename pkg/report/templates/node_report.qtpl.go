@@ -92,13 +92,18 @@ func (p *NodeReport) StreamBody(qw422016 *qt422016.Writer) {
 //line pkg/report/templates/node_report.qtpl:19
 	qw422016.N().S(`</h3>
     </div>
+    `)
+//line pkg/report/templates/node_report.qtpl:21
+	streamclusterMetadata(qw422016, p.Cluster)
+//line pkg/report/templates/node_report.qtpl:21
+	qw422016.N().S(`
   </div>
 
 <!-- Resume START -->
   `)
-//line pkg/report/templates/node_report.qtpl:24
+//line pkg/report/templates/node_report.qtpl:25
 	if p.CisKubeBenchReport != nil {
-//line pkg/report/templates/node_report.qtpl:24
+//line pkg/report/templates/node_report.qtpl:25
 		qw422016.N().S(`
 
   <div class="row text-center border-bottom mt-4">
@@ -117,29 +122,29 @@ func (p *NodeReport) StreamBody(qw422016 *qt422016.Writer) {
                <div class="row">
                   <div class="col">
                   `)
-//line pkg/report/templates/node_report.qtpl:42
+//line pkg/report/templates/node_report.qtpl:43
 		report := p.CisKubeBenchReport.Report
 		scanner_name := report.Scanner.Name
 		scanner_vendor := report.Scanner.Vendor
 		scanner_version := report.Scanner.Version
 		creation_timestamp := report.UpdateTimestamp.Format("2 Jan 2006 15:04:01")
 
-//line pkg/report/templates/node_report.qtpl:47
+//line pkg/report/templates/node_report.qtpl:48
 		qw422016.N().S(`
                       <p class="my-0">Name:  `)
-//line pkg/report/templates/node_report.qtpl:48
+//line pkg/report/templates/node_report.qtpl:49
 		qw422016.E().S(scanner_name)
-//line pkg/report/templates/node_report.qtpl:48
+//line pkg/report/templates/node_report.qtpl:49
 		qw422016.N().S(`</p>
                       <p class="my-0">Vendor:  `)
-//line pkg/report/templates/node_report.qtpl:49
+//line pkg/report/templates/node_report.qtpl:50
 		qw422016.E().S(scanner_vendor)
-//line pkg/report/templates/node_report.qtpl:49
+//line pkg/report/templates/node_report.qtpl:50
 		qw422016.N().S(`</p>
                       <p class="my-0">Version:  `)
-//line pkg/report/templates/node_report.qtpl:50
+//line pkg/report/templates/node_report.qtpl:51
 		qw422016.E().S(scanner_version)
-//line pkg/report/templates/node_report.qtpl:50
+//line pkg/report/templates/node_report.qtpl:51
 		qw422016.N().S(`</p>
                   </div>
                </div>
@@ -153,72 +158,72 @@ func (p *NodeReport) StreamBody(qw422016 *qt422016.Writer) {
               </div>
               <div class="row">
                   `)
-//line pkg/report/templates/node_report.qtpl:63
+//line pkg/report/templates/node_report.qtpl:64
 		summary := report.Summary
 
-//line pkg/report/templates/node_report.qtpl:64
+//line pkg/report/templates/node_report.qtpl:65
 		qw422016.N().S(`
                   `)
-//line pkg/report/templates/node_report.qtpl:65
+//line pkg/report/templates/node_report.qtpl:66
 		if summary.FailCount > 0 {
-//line pkg/report/templates/node_report.qtpl:65
+//line pkg/report/templates/node_report.qtpl:66
 			qw422016.N().S(`
                   <div class="col text-center p-0 text-danger font-weight-bold">
                   `)
-//line pkg/report/templates/node_report.qtpl:67
+//line pkg/report/templates/node_report.qtpl:68
 		} else {
-//line pkg/report/templates/node_report.qtpl:67
+//line pkg/report/templates/node_report.qtpl:68
 			qw422016.N().S(`
                   <div class="col text-center p-0">
                   `)
-//line pkg/report/templates/node_report.qtpl:69
+//line pkg/report/templates/node_report.qtpl:70
 		}
-//line pkg/report/templates/node_report.qtpl:69
+//line pkg/report/templates/node_report.qtpl:70
 		qw422016.N().S(`
                       <p class="mx-auto mb-1">`)
-//line pkg/report/templates/node_report.qtpl:70
+//line pkg/report/templates/node_report.qtpl:71
 		qw422016.N().D(summary.FailCount)
-//line pkg/report/templates/node_report.qtpl:70
+//line pkg/report/templates/node_report.qtpl:71
 		qw422016.N().S(`</p>
                       <p class="mx-auto ">FAIL</p>
                   </div>
                   `)
-//line pkg/report/templates/node_report.qtpl:73
+//line pkg/report/templates/node_report.qtpl:74
 		if summary.WarnCount > 0 {
-//line pkg/report/templates/node_report.qtpl:73
+//line pkg/report/templates/node_report.qtpl:74
 			qw422016.N().S(`
                   <div class="col text-center p-0 text-warning font-weight-bold">
                   `)
-//line pkg/report/templates/node_report.qtpl:75
+//line pkg/report/templates/node_report.qtpl:76
 		} else {
-//line pkg/report/templates/node_report.qtpl:75
+//line pkg/report/templates/node_report.qtpl:76
 			qw422016.N().S(`
                   <div class="col text-center p-0">
                   `)
-//line pkg/report/templates/node_report.qtpl:77
+//line pkg/report/templates/node_report.qtpl:78
 		}
-//line pkg/report/templates/node_report.qtpl:77
+//line pkg/report/templates/node_report.qtpl:78
 		qw422016.N().S(`
                       <p class="mx-auto mb-1">`)
-//line pkg/report/templates/node_report.qtpl:78
+//line pkg/report/templates/node_report.qtpl:79
 		qw422016.N().D(summary.WarnCount)
-//line pkg/report/templates/node_report.qtpl:78
+//line pkg/report/templates/node_report.qtpl:79
 		qw422016.N().S(`</p>
                       <p class="mx-auto ">WARN</p>
                   </div>
                   <div class="col text-center p-0">
                       <p class="mx-auto mb-1">`)
-//line pkg/report/templates/node_report.qtpl:82
+//line pkg/report/templates/node_report.qtpl:83
 		qw422016.N().D(summary.InfoCount)
-//line pkg/report/templates/node_report.qtpl:82
+//line pkg/report/templates/node_report.qtpl:83
 		qw422016.N().S(`</p>
                       <p class="mx-auto ">INFO</p>
                   </div>
                   <div class="col text-center p-0">
                       <p class="mx-auto mb-1">`)
-//line pkg/report/templates/node_report.qtpl:86
+//line pkg/report/templates/node_report.qtpl:87
 		qw422016.N().D(summary.PassCount)
-//line pkg/report/templates/node_report.qtpl:86
+//line pkg/report/templates/node_report.qtpl:87
 		qw422016.N().S(`</p>
                       <p class="mx-auto ">PASS</p>
                   </div>
@@ -235,9 +240,9 @@ func (p *NodeReport) StreamBody(qw422016 *qt422016.Writer) {
                   <div class="col">
                       <p class="my-0">
                           Generated at:  `)
-//line pkg/report/templates/node_report.qtpl:101
+//line pkg/report/templates/node_report.qtpl:102
 		qw422016.E().S(creation_timestamp)
-//line pkg/report/templates/node_report.qtpl:101
+//line pkg/report/templates/node_report.qtpl:102
 		qw422016.N().S(`
                       </p>
                   </div>
@@ -246,24 +251,24 @@ func (p *NodeReport) StreamBody(qw422016 *qt422016.Writer) {
       </div>
   </div>
   `)
-//line pkg/report/templates/node_report.qtpl:108
+//line pkg/report/templates/node_report.qtpl:109
 	}
-//line pkg/report/templates/node_report.qtpl:108
+//line pkg/report/templates/node_report.qtpl:109
 	qw422016.N().S(`
 <!-- Resume END -->
 
 <!-- Sections START -->
   `)
-//line pkg/report/templates/node_report.qtpl:113
+//line pkg/report/templates/node_report.qtpl:114
 	report := p.CisKubeBenchReport.Report
 
-//line pkg/report/templates/node_report.qtpl:114
+//line pkg/report/templates/node_report.qtpl:115
 	qw422016.N().S(`
   <div class="row">
   `)
-//line pkg/report/templates/node_report.qtpl:116
+//line pkg/report/templates/node_report.qtpl:117
 	for _, section := range report.Sections {
-//line pkg/report/templates/node_report.qtpl:116
+//line pkg/report/templates/node_report.qtpl:117
 		qw422016.N().S(`
     <table class="table table-sm table-bordered">
       <thead>
@@ -276,136 +281,136 @@ func (p *NodeReport) StreamBody(qw422016 *qt422016.Writer) {
       </thead>
       <tbody>
    <h3> `)
-//line pkg/report/templates/node_report.qtpl:127
+//line pkg/report/templates/node_report.qtpl:128
 		qw422016.E().S(section.Text)
-//line pkg/report/templates/node_report.qtpl:127
+//line pkg/report/templates/node_report.qtpl:128
 		qw422016.N().S(` </h3>
    `)
-//line pkg/report/templates/node_report.qtpl:128
+//line pkg/report/templates/node_report.qtpl:129
 		for _, test := range section.Tests {
-//line pkg/report/templates/node_report.qtpl:128
+//line pkg/report/templates/node_report.qtpl:129
 			qw422016.N().S(`
     `)
-//line pkg/report/templates/node_report.qtpl:129
+//line pkg/report/templates/node_report.qtpl:130
 			for _, result := range test.Results {
-//line pkg/report/templates/node_report.qtpl:129
+//line pkg/report/templates/node_report.qtpl:130
 				qw422016.N().S(`
       <tr>
         <td>`)
-//line pkg/report/templates/node_report.qtpl:131
-				qw422016.E().S(result.TestNumber)
-//line pkg/report/templates/node_report.qtpl:131
-				qw422016.N().S(`</td>
-        <td>`)
 //line pkg/report/templates/node_report.qtpl:132
-				qw422016.E().S(result.Status)
+				qw422016.E().S(result.TestNumber)
 //line pkg/report/templates/node_report.qtpl:132
 				qw422016.N().S(`</td>
         <td>`)
 //line pkg/report/templates/node_report.qtpl:133
-				qw422016.E().S(result.TestDesc)
+				qw422016.E().S(result.Status)
 //line pkg/report/templates/node_report.qtpl:133
 				qw422016.N().S(`</td>
         <td>`)
 //line pkg/report/templates/node_report.qtpl:134
-				qw422016.E().S(result.Remediation)
+				qw422016.E().S(result.TestDesc)
 //line pkg/report/templates/node_report.qtpl:134
 				qw422016.N().S(`</td>
+        <td>`)
+//line pkg/report/templates/node_report.qtpl:135
+				qw422016.E().S(result.Remediation)
+//line pkg/report/templates/node_report.qtpl:135
+				qw422016.N().S(`</td>
       </tr>
 `)
-//line pkg/report/templates/node_report.qtpl:136
+//line pkg/report/templates/node_report.qtpl:137
 			}
-//line pkg/report/templates/node_report.qtpl:136
+//line pkg/report/templates/node_report.qtpl:137
 			qw422016.N().S(`
       </tbody>
     `)
-//line pkg/report/templates/node_report.qtpl:138
+//line pkg/report/templates/node_report.qtpl:139
 		}
-//line pkg/report/templates/node_report.qtpl:138
+//line pkg/report/templates/node_report.qtpl:139
 		qw422016.N().S(`
     </table>
 `)
-//line pkg/report/templates/node_report.qtpl:140
+//line pkg/report/templates/node_report.qtpl:141
 	}
-//line pkg/report/templates/node_report.qtpl:140
+//line pkg/report/templates/node_report.qtpl:141
 	qw422016.N().S(`
   </div>
 <!-- Sections END -->
 
 </div>
 `)
-//line pkg/report/templates/node_report.qtpl:145
+//line pkg/report/templates/node_report.qtpl:146
 }
 
-//line pkg/report/templates/node_report.qtpl:145
+//line pkg/report/templates/node_report.qtpl:146
 func (p *NodeReport) WriteBody(qq422016 qtio422016.Writer) {
-//line pkg/report/templates/node_report.qtpl:145
+//line pkg/report/templates/node_report.qtpl:146
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line pkg/report/templates/node_report.qtpl:145
+//line pkg/report/templates/node_report.qtpl:146
 	p.StreamBody(qw422016)
-//line pkg/report/templates/node_report.qtpl:145
+//line pkg/report/templates/node_report.qtpl:146
 	qt422016.ReleaseWriter(qw422016)
-//line pkg/report/templates/node_report.qtpl:145
+//line pkg/report/templates/node_report.qtpl:146
 }
 
-//line pkg/report/templates/node_report.qtpl:145
+//line pkg/report/templates/node_report.qtpl:146
 func (p *NodeReport) Body() string {
-//line pkg/report/templates/node_report.qtpl:145
+//line pkg/report/templates/node_report.qtpl:146
 	qb422016 := qt422016.AcquireByteBuffer()
-//line pkg/report/templates/node_report.qtpl:145
+//line pkg/report/templates/node_report.qtpl:146
 	p.WriteBody(qb422016)
-//line pkg/report/templates/node_report.qtpl:145
+//line pkg/report/templates/node_report.qtpl:146
 	qs422016 := string(qb422016.B)
-//line pkg/report/templates/node_report.qtpl:145
+//line pkg/report/templates/node_report.qtpl:146
 	qt422016.ReleaseByteBuffer(qb422016)
-//line pkg/report/templates/node_report.qtpl:145
+//line pkg/report/templates/node_report.qtpl:146
 	return qs422016
-//line pkg/report/templates/node_report.qtpl:145
+//line pkg/report/templates/node_report.qtpl:146
 }
 
-//line pkg/report/templates/node_report.qtpl:147
+//line pkg/report/templates/node_report.qtpl:148
 func streamnodeReference(qw422016 *qt422016.Writer, section []v1alpha1.CISKubeBenchSection) {
-//line pkg/report/templates/node_report.qtpl:147
+//line pkg/report/templates/node_report.qtpl:148
 	qw422016.N().S(`
   `)
-//line pkg/report/templates/node_report.qtpl:148
+//line pkg/report/templates/node_report.qtpl:149
 	qw422016.E().S(section[0].ID)
-//line pkg/report/templates/node_report.qtpl:148
+//line pkg/report/templates/node_report.qtpl:149
 	qw422016.N().S(`/`)
-//line pkg/report/templates/node_report.qtpl:148
+//line pkg/report/templates/node_report.qtpl:149
 	qw422016.E().S(section[0].Text)
-//line pkg/report/templates/node_report.qtpl:148
+//line pkg/report/templates/node_report.qtpl:149
 	qw422016.N().S(`:`)
-//line pkg/report/templates/node_report.qtpl:148
+//line pkg/report/templates/node_report.qtpl:149
 	qw422016.E().S(section[0].NodeType)
-//line pkg/report/templates/node_report.qtpl:148
+//line pkg/report/templates/node_report.qtpl:149
 	qw422016.N().S(`/
 `)
-//line pkg/report/templates/node_report.qtpl:149
+//line pkg/report/templates/node_report.qtpl:150
 }
 
-//line pkg/report/templates/node_report.qtpl:149
+//line pkg/report/templates/node_report.qtpl:150
 func writenodeReference(qq422016 qtio422016.Writer, section []v1alpha1.CISKubeBenchSection) {
-//line pkg/report/templates/node_report.qtpl:149
+//line pkg/report/templates/node_report.qtpl:150
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line pkg/report/templates/node_report.qtpl:149
+//line pkg/report/templates/node_report.qtpl:150
 	streamnodeReference(qw422016, section)
-//line pkg/report/templates/node_report.qtpl:149
+//line pkg/report/templates/node_report.qtpl:150
 	qt422016.ReleaseWriter(qw422016)
-//line pkg/report/templates/node_report.qtpl:149
+//line pkg/report/templates/node_report.qtpl:150
 }
 
-//line pkg/report/templates/node_report.qtpl:149
+//line pkg/report/templates/node_report.qtpl:150
 func nodeReference(section []v1alpha1.CISKubeBenchSection) string {
-//line pkg/report/templates/node_report.qtpl:149
+//line pkg/report/templates/node_report.qtpl:150
 	qb422016 := qt422016.AcquireByteBuffer()
-//line pkg/report/templates/node_report.qtpl:149
+//line pkg/report/templates/node_report.qtpl:150
 	writenodeReference(qb422016, section)
-//line pkg/report/templates/node_report.qtpl:149
+//line pkg/report/templates/node_report.qtpl:150
 	qs422016 := string(qb422016.B)
-//line pkg/report/templates/node_report.qtpl:149
+//line pkg/report/templates/node_report.qtpl:150
 	qt422016.ReleaseByteBuffer(qb422016)
-//line pkg/report/templates/node_report.qtpl:149
+//line pkg/report/templates/node_report.qtpl:150
 	return qs422016
-//line pkg/report/templates/node_report.qtpl:149
+//line pkg/report/templates/node_report.qtpl:150
 }
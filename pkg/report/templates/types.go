@@ -5,6 +5,7 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/summary"
 )
 
 // WorkloadReport is a structure that holds data to render
@@ -12,10 +13,25 @@ import (
 type WorkloadReport struct {
 	Workload    kube.ObjectRef
 	GeneratedAt time.Time
+	Cluster     *v1alpha1.ClusterMetadata
 
 	// FIXME Do not use map as the order of iteration is unpredictable.
 	VulnsReports      map[string]v1alpha1.VulnerabilityReportData
 	ConfigAuditReport *v1alpha1.ConfigAuditReport
+
+	// TopFixes are package upgrades which remove the most vulnerabilities,
+	// weighted by severity.
+	TopFixes []Fix
+}
+
+// GetMergedVulnsSummary returns the sum of vulnerability summaries of all
+// containers of the workload.
+func (p *WorkloadReport) GetMergedVulnsSummary() v1alpha1.VulnerabilitySummary {
+	summaries := make([]v1alpha1.VulnerabilitySummary, 0, len(p.VulnsReports))
+	for _, report := range p.VulnsReports {
+		summaries = append(summaries, report.Summary)
+	}
+	return summary.MergeVulnerabilities(summaries...)
 }
 
 // NamespaceReport is a structure that holds data to render
@@ -23,6 +39,7 @@ type WorkloadReport struct {
 type NamespaceReport struct {
 	Namespace   kube.ObjectRef
 	GeneratedAt time.Time
+	Cluster     *v1alpha1.ClusterMetadata
 
 	Top5VulnerableImages []v1alpha1.VulnerabilityReport
 	Top5FailedChecks     []CheckWithCount
@@ -39,11 +56,43 @@ type CheckWithCount struct {
 	AffectedWorkloads int
 }
 
+// Fix is an upgrade of a package to a fixed version, which removes the
+// vulnerabilities found in that package across containers of a workload.
+type Fix struct {
+	Resource         string                        `json:"resource"`
+	FixedVersion     string                        `json:"fixedVersion"`
+	Containers       []string                      `json:"containers"`
+	VulnerabilityIDs []string                      `json:"vulnerabilityIDs"`
+	Summary          v1alpha1.VulnerabilitySummary `json:"summary"`
+	// Score is the sum of severity weights of removed vulnerabilities.
+	Score int `json:"score"`
+}
+
+// Findings returns the number of vulnerabilities removed by the fix.
+func (f Fix) Findings() int {
+	return f.Summary.CriticalCount + f.Summary.HighCount + f.Summary.MediumCount +
+		f.Summary.LowCount + f.Summary.UnknownCount
+}
+
 // NodeReport is a structure that holds data to render
 // an HTML report for a specified K8s node.
 type NodeReport struct {
 	Node        kube.ObjectRef
 	GeneratedAt time.Time
+	Cluster     *v1alpha1.ClusterMetadata
 
 	CisKubeBenchReport *v1alpha1.CISKubeBenchReport
 }
+
+// ClusterReport is a structure that holds data to render
+// an HTML report for the cluster.
+type ClusterReport struct {
+	GeneratedAt time.Time
+	Cluster     *v1alpha1.ClusterMetadata
+
+	// RiskReport holds the risk score of the cluster, or nil if the operator
+	// does not compute it.
+	RiskReport *v1alpha1.ClusterRiskReport
+
+	ComplianceReports []v1alpha1.ClusterComplianceReport
+}
@@ -5,7 +5,7 @@
 package templates
 
 //line pkg/report/templates/workload_report.qtpl:1
-import "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+import "strings"
 
 //line pkg/report/templates/workload_report.qtpl:3
 import (
@@ -67,24 +67,9 @@ func (p *WorkloadReport) Title() string {
 //line pkg/report/templates/workload_report.qtpl:5
 }
 
-// TODO Check if we need that summary logic. If yes move this logic out of HTML templating.
-
-//line pkg/report/templates/workload_report.qtpl:9
-func (p *WorkloadReport) GetMergedVulnsSummary() v1alpha1.VulnerabilitySummary {
-	merged := v1alpha1.VulnerabilitySummary{}
-	for _, report := range p.VulnsReports {
-		merged.CriticalCount += report.Summary.CriticalCount
-		merged.HighCount += report.Summary.HighCount
-		merged.MediumCount += report.Summary.MediumCount
-		merged.LowCount += report.Summary.LowCount
-		merged.UnknownCount += report.Summary.UnknownCount
-	}
-	return merged
-}
-
-//line pkg/report/templates/workload_report.qtpl:22
+//line pkg/report/templates/workload_report.qtpl:7
 func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
-//line pkg/report/templates/workload_report.qtpl:22
+//line pkg/report/templates/workload_report.qtpl:7
 	qw422016.N().S(`
   <style>
   a {
@@ -100,9 +85,9 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
     <div class="col mt-5">
       <div class="row text-center">
         `)
-//line pkg/report/templates/workload_report.qtpl:36
+//line pkg/report/templates/workload_report.qtpl:21
 	streamimgAquaLogo(qw422016)
-//line pkg/report/templates/workload_report.qtpl:36
+//line pkg/report/templates/workload_report.qtpl:21
 	qw422016.N().S(`
       </div>
       <div class="row mt-4 text-center">
@@ -110,29 +95,34 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
       </div>
       <div class="row text-center">
         <h3 class="text-muted mx-auto">Workload: `)
-//line pkg/report/templates/workload_report.qtpl:42
+//line pkg/report/templates/workload_report.qtpl:27
 	qw422016.E().V(p.Workload.Kind)
-//line pkg/report/templates/workload_report.qtpl:42
+//line pkg/report/templates/workload_report.qtpl:27
 	qw422016.N().S(`/`)
-//line pkg/report/templates/workload_report.qtpl:42
+//line pkg/report/templates/workload_report.qtpl:27
 	qw422016.E().S(p.Workload.Name)
-//line pkg/report/templates/workload_report.qtpl:42
+//line pkg/report/templates/workload_report.qtpl:27
 	qw422016.N().S(`</h3>
       </div>
       <div class="row text-center">
         <h3 class="text-muted mx-auto">Namespace: `)
-//line pkg/report/templates/workload_report.qtpl:45
+//line pkg/report/templates/workload_report.qtpl:30
 	qw422016.E().S(p.Workload.Namespace)
-//line pkg/report/templates/workload_report.qtpl:45
+//line pkg/report/templates/workload_report.qtpl:30
 	qw422016.N().S(`</h3>
       </div>
       <div class="row text-center">
         <h3 class="text-muted mx-auto">Generated on `)
-//line pkg/report/templates/workload_report.qtpl:48
+//line pkg/report/templates/workload_report.qtpl:33
 	qw422016.E().S(p.GeneratedAt.Format("2 Jan 2006 15:04:01"))
-//line pkg/report/templates/workload_report.qtpl:48
+//line pkg/report/templates/workload_report.qtpl:33
 	qw422016.N().S(`</h3>
       </div>
+      `)
+//line pkg/report/templates/workload_report.qtpl:35
+	streamclusterMetadata(qw422016, p.Cluster)
+//line pkg/report/templates/workload_report.qtpl:35
+	qw422016.N().S(`
 
       <div class="row mt-5 px-3">
         <h4>Table Of Contents</h4>
@@ -141,82 +131,93 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                 <div class="row">
                     <ul>
                         `)
-//line pkg/report/templates/workload_report.qtpl:57
+//line pkg/report/templates/workload_report.qtpl:43
 	if len(p.VulnsReports) > 0 {
-//line pkg/report/templates/workload_report.qtpl:57
+//line pkg/report/templates/workload_report.qtpl:43
 		qw422016.N().S(`
                         <li>
                             <a href="#vuln_header">Vulnerabilities</a></li>
                             <ul>
                               `)
-//line pkg/report/templates/workload_report.qtpl:61
+//line pkg/report/templates/workload_report.qtpl:47
+		if len(p.TopFixes) > 0 {
+//line pkg/report/templates/workload_report.qtpl:47
+			qw422016.N().S(`
+                                <li><a href="#vulns_top_fixes">Top fixes</a></li>
+                              `)
+//line pkg/report/templates/workload_report.qtpl:49
+		}
+//line pkg/report/templates/workload_report.qtpl:49
+		qw422016.N().S(`
+                              `)
+//line pkg/report/templates/workload_report.qtpl:50
 		for container, _ := range p.VulnsReports {
-//line pkg/report/templates/workload_report.qtpl:61
+//line pkg/report/templates/workload_report.qtpl:50
 			qw422016.N().S(`
                                 <li><a href="#vulns_container_`)
-//line pkg/report/templates/workload_report.qtpl:62
+//line pkg/report/templates/workload_report.qtpl:51
 			qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:62
+//line pkg/report/templates/workload_report.qtpl:51
 			qw422016.N().S(`">`)
-//line pkg/report/templates/workload_report.qtpl:62
+//line pkg/report/templates/workload_report.qtpl:51
 			qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:62
+//line pkg/report/templates/workload_report.qtpl:51
 			qw422016.N().S(`</a></li>
                               `)
-//line pkg/report/templates/workload_report.qtpl:63
+//line pkg/report/templates/workload_report.qtpl:52
 		}
-//line pkg/report/templates/workload_report.qtpl:63
+//line pkg/report/templates/workload_report.qtpl:52
 		qw422016.N().S(`
                             </ul>
                         </li>
                         `)
-//line pkg/report/templates/workload_report.qtpl:66
+//line pkg/report/templates/workload_report.qtpl:55
 	}
-//line pkg/report/templates/workload_report.qtpl:66
+//line pkg/report/templates/workload_report.qtpl:55
 	qw422016.N().S(`
                         `)
-//line pkg/report/templates/workload_report.qtpl:67
+//line pkg/report/templates/workload_report.qtpl:56
 	if p.ConfigAuditReport != nil && len(p.ConfigAuditReport.Report.PodChecks) > 0 {
-//line pkg/report/templates/workload_report.qtpl:67
+//line pkg/report/templates/workload_report.qtpl:56
 		qw422016.N().S(`
                         <li>
                             <a href="#ca_header">Configuration Audit</a>
                             <ul>
                               <li><a href="#ca_pod_checks">Pod Checks</a></li>
                                 `)
-//line pkg/report/templates/workload_report.qtpl:72
+//line pkg/report/templates/workload_report.qtpl:61
 		for container, _ := range p.ConfigAuditReport.Report.ContainerChecks {
-//line pkg/report/templates/workload_report.qtpl:72
+//line pkg/report/templates/workload_report.qtpl:61
 			qw422016.N().S(`
                                   <li><a href="#ca_container_`)
-//line pkg/report/templates/workload_report.qtpl:73
+//line pkg/report/templates/workload_report.qtpl:62
 			qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:73
+//line pkg/report/templates/workload_report.qtpl:62
 			qw422016.N().S(`">`)
-//line pkg/report/templates/workload_report.qtpl:73
+//line pkg/report/templates/workload_report.qtpl:62
 			qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:73
+//line pkg/report/templates/workload_report.qtpl:62
 			qw422016.N().S(`</a></li>
                                 `)
-//line pkg/report/templates/workload_report.qtpl:74
+//line pkg/report/templates/workload_report.qtpl:63
 		}
-//line pkg/report/templates/workload_report.qtpl:74
+//line pkg/report/templates/workload_report.qtpl:63
 		qw422016.N().S(`
                             </ul>
                         </li>
                         `)
-//line pkg/report/templates/workload_report.qtpl:77
+//line pkg/report/templates/workload_report.qtpl:66
 	}
-//line pkg/report/templates/workload_report.qtpl:77
+//line pkg/report/templates/workload_report.qtpl:66
 	qw422016.N().S(`
                     </ul>
                 </div>
 
 
                 `)
-//line pkg/report/templates/workload_report.qtpl:82
+//line pkg/report/templates/workload_report.qtpl:71
 	if len(p.VulnsReports) > 0 {
-//line pkg/report/templates/workload_report.qtpl:82
+//line pkg/report/templates/workload_report.qtpl:71
 		qw422016.N().S(`
                 <!-- Vulnerabilities -->
                 <div class="row text-center border-bottom mt-4">
@@ -235,7 +236,7 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                              <div class="row">
                                 <div class="col">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:100
+//line pkg/report/templates/workload_report.qtpl:89
 		var scanner_name, scanner_vendor, scanner_version, creation_timestamp string
 		for _, report := range p.VulnsReports {
 			scanner_name = report.Scanner.Name
@@ -245,22 +246,22 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
 			break
 		}
 
-//line pkg/report/templates/workload_report.qtpl:108
+//line pkg/report/templates/workload_report.qtpl:97
 		qw422016.N().S(`
                                     <p class="my-0">Name:  `)
-//line pkg/report/templates/workload_report.qtpl:109
+//line pkg/report/templates/workload_report.qtpl:98
 		qw422016.E().S(scanner_name)
-//line pkg/report/templates/workload_report.qtpl:109
+//line pkg/report/templates/workload_report.qtpl:98
 		qw422016.N().S(`</p>
                                     <p class="my-0">Vendor:  `)
-//line pkg/report/templates/workload_report.qtpl:110
+//line pkg/report/templates/workload_report.qtpl:99
 		qw422016.E().S(scanner_vendor)
-//line pkg/report/templates/workload_report.qtpl:110
+//line pkg/report/templates/workload_report.qtpl:99
 		qw422016.N().S(`</p>
                                     <p class="my-0">Version:  `)
-//line pkg/report/templates/workload_report.qtpl:111
+//line pkg/report/templates/workload_report.qtpl:100
 		qw422016.E().S(scanner_version)
-//line pkg/report/templates/workload_report.qtpl:111
+//line pkg/report/templates/workload_report.qtpl:100
 		qw422016.N().S(`</p>
                                 </div>
                              </div>
@@ -274,96 +275,96 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                             </div>
                             <div class="row">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:124
+//line pkg/report/templates/workload_report.qtpl:113
 		summary := p.GetMergedVulnsSummary()
 
-//line pkg/report/templates/workload_report.qtpl:125
+//line pkg/report/templates/workload_report.qtpl:114
 		qw422016.N().S(`
                                 `)
-//line pkg/report/templates/workload_report.qtpl:126
+//line pkg/report/templates/workload_report.qtpl:115
 		if summary.CriticalCount > 0 {
-//line pkg/report/templates/workload_report.qtpl:126
+//line pkg/report/templates/workload_report.qtpl:115
 			qw422016.N().S(`
                                 <div class="col text-center p-0 text-danger font-weight-bold">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:128
+//line pkg/report/templates/workload_report.qtpl:117
 		} else {
-//line pkg/report/templates/workload_report.qtpl:128
+//line pkg/report/templates/workload_report.qtpl:117
 			qw422016.N().S(`
                                 <div class="col text-center p-0">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:130
+//line pkg/report/templates/workload_report.qtpl:119
 		}
-//line pkg/report/templates/workload_report.qtpl:130
+//line pkg/report/templates/workload_report.qtpl:119
 		qw422016.N().S(`
                                     <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:131
+//line pkg/report/templates/workload_report.qtpl:120
 		qw422016.N().D(summary.CriticalCount)
-//line pkg/report/templates/workload_report.qtpl:131
+//line pkg/report/templates/workload_report.qtpl:120
 		qw422016.N().S(`</p>
                                     <p class="mx-auto ">CRITICAL</p>
                                 </div>
                                 `)
-//line pkg/report/templates/workload_report.qtpl:134
+//line pkg/report/templates/workload_report.qtpl:123
 		if summary.HighCount > 0 {
-//line pkg/report/templates/workload_report.qtpl:134
+//line pkg/report/templates/workload_report.qtpl:123
 			qw422016.N().S(`
                                 <div class="col text-center p-0 text-danger font-weight-bold">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:136
+//line pkg/report/templates/workload_report.qtpl:125
 		} else {
-//line pkg/report/templates/workload_report.qtpl:136
+//line pkg/report/templates/workload_report.qtpl:125
 			qw422016.N().S(`
                                 <div class="col text-center p-0">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:138
+//line pkg/report/templates/workload_report.qtpl:127
 		}
-//line pkg/report/templates/workload_report.qtpl:138
+//line pkg/report/templates/workload_report.qtpl:127
 		qw422016.N().S(`
                                     <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:139
+//line pkg/report/templates/workload_report.qtpl:128
 		qw422016.N().D(summary.HighCount)
-//line pkg/report/templates/workload_report.qtpl:139
+//line pkg/report/templates/workload_report.qtpl:128
 		qw422016.N().S(`</p>
                                     <p class="mx-auto ">HIGH</p>
                                 </div>
                                 `)
-//line pkg/report/templates/workload_report.qtpl:142
+//line pkg/report/templates/workload_report.qtpl:131
 		if summary.MediumCount > 0 {
-//line pkg/report/templates/workload_report.qtpl:142
+//line pkg/report/templates/workload_report.qtpl:131
 			qw422016.N().S(`
                                 <div class="col text-center p-0 text-warning font-weight-bold">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:144
+//line pkg/report/templates/workload_report.qtpl:133
 		} else {
-//line pkg/report/templates/workload_report.qtpl:144
+//line pkg/report/templates/workload_report.qtpl:133
 			qw422016.N().S(`
                                 <div class="col text-center p-0">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:146
+//line pkg/report/templates/workload_report.qtpl:135
 		}
-//line pkg/report/templates/workload_report.qtpl:146
+//line pkg/report/templates/workload_report.qtpl:135
 		qw422016.N().S(`
                                     <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:147
+//line pkg/report/templates/workload_report.qtpl:136
 		qw422016.N().D(summary.MediumCount)
-//line pkg/report/templates/workload_report.qtpl:147
+//line pkg/report/templates/workload_report.qtpl:136
 		qw422016.N().S(`</p>
                                     <p class="mx-auto ">MEDIUM</p>
                                 </div>
                                 <div class="col text-center p-0">
                                     <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:151
+//line pkg/report/templates/workload_report.qtpl:140
 		qw422016.N().D(summary.LowCount)
-//line pkg/report/templates/workload_report.qtpl:151
+//line pkg/report/templates/workload_report.qtpl:140
 		qw422016.N().S(`</p>
                                     <p class="mx-auto ">LOW</p>
                                 </div>
                                 <div class="col text-center p-0">
                                     <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:155
+//line pkg/report/templates/workload_report.qtpl:144
 		qw422016.N().D(summary.UnknownCount)
-//line pkg/report/templates/workload_report.qtpl:155
+//line pkg/report/templates/workload_report.qtpl:144
 		qw422016.N().S(`</p>
                                     <p class="mx-auto ">UNKNOWN</p>
                                 </div>
@@ -380,9 +381,9 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                                 <div class="col">
                                     <p class="my-0">
                                         Generated at:  `)
-//line pkg/report/templates/workload_report.qtpl:170
+//line pkg/report/templates/workload_report.qtpl:159
 		qw422016.E().S(creation_timestamp)
-//line pkg/report/templates/workload_report.qtpl:170
+//line pkg/report/templates/workload_report.qtpl:159
 		qw422016.N().S(`
                                     </p>
                                 </div>
@@ -392,51 +393,133 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                     </div>      
                 </div>
                 `)
-//line pkg/report/templates/workload_report.qtpl:178
+//line pkg/report/templates/workload_report.qtpl:167
 	}
-//line pkg/report/templates/workload_report.qtpl:178
+//line pkg/report/templates/workload_report.qtpl:167
+	qw422016.N().S(`
+
+                `)
+//line pkg/report/templates/workload_report.qtpl:169
+	if len(p.TopFixes) > 0 {
+//line pkg/report/templates/workload_report.qtpl:169
+		qw422016.N().S(`
+                  <div class="row"><h5 class="text-info" id="vulns_top_fixes">Top fixes</h5></div>
+                  <div class="row">
+                    <table class="table table-sm table-bordered">
+                      <thead>
+                        <tr>
+                          <th scope="col">Resource</th>
+                          <th scope="col">Fixed Version</th>
+                          <th scope="col">Containers</th>
+                          <th scope="col">Critical</th>
+                          <th scope="col">High</th>
+                          <th scope="col">Medium</th>
+                          <th scope="col">Low</th>
+                          <th scope="col">Unknown</th>
+                        </tr>
+                      </thead>
+                      <tbody>
+                        `)
+//line pkg/report/templates/workload_report.qtpl:186
+		for _, fix := range p.TopFixes {
+//line pkg/report/templates/workload_report.qtpl:186
+			qw422016.N().S(`
+                        <tr>
+                          <td>`)
+//line pkg/report/templates/workload_report.qtpl:188
+			qw422016.E().S(fix.Resource)
+//line pkg/report/templates/workload_report.qtpl:188
+			qw422016.N().S(`</td>
+                          <td>`)
+//line pkg/report/templates/workload_report.qtpl:189
+			qw422016.E().S(fix.FixedVersion)
+//line pkg/report/templates/workload_report.qtpl:189
+			qw422016.N().S(`</td>
+                          <td>`)
+//line pkg/report/templates/workload_report.qtpl:190
+			qw422016.E().S(strings.Join(fix.Containers, ", "))
+//line pkg/report/templates/workload_report.qtpl:190
+			qw422016.N().S(`</td>
+                          <td>`)
+//line pkg/report/templates/workload_report.qtpl:191
+			qw422016.N().D(fix.Summary.CriticalCount)
+//line pkg/report/templates/workload_report.qtpl:191
+			qw422016.N().S(`</td>
+                          <td>`)
+//line pkg/report/templates/workload_report.qtpl:192
+			qw422016.N().D(fix.Summary.HighCount)
+//line pkg/report/templates/workload_report.qtpl:192
+			qw422016.N().S(`</td>
+                          <td>`)
+//line pkg/report/templates/workload_report.qtpl:193
+			qw422016.N().D(fix.Summary.MediumCount)
+//line pkg/report/templates/workload_report.qtpl:193
+			qw422016.N().S(`</td>
+                          <td>`)
+//line pkg/report/templates/workload_report.qtpl:194
+			qw422016.N().D(fix.Summary.LowCount)
+//line pkg/report/templates/workload_report.qtpl:194
+			qw422016.N().S(`</td>
+                          <td>`)
+//line pkg/report/templates/workload_report.qtpl:195
+			qw422016.N().D(fix.Summary.UnknownCount)
+//line pkg/report/templates/workload_report.qtpl:195
+			qw422016.N().S(`</td>
+                        </tr>
+                        `)
+//line pkg/report/templates/workload_report.qtpl:197
+		}
+//line pkg/report/templates/workload_report.qtpl:197
+		qw422016.N().S(`
+                      </tbody>
+                    </table>
+                  </div>
+                `)
+//line pkg/report/templates/workload_report.qtpl:201
+	}
+//line pkg/report/templates/workload_report.qtpl:201
 	qw422016.N().S(`
                 
                 `)
-//line pkg/report/templates/workload_report.qtpl:180
+//line pkg/report/templates/workload_report.qtpl:203
 	for container, report := range p.VulnsReports {
-//line pkg/report/templates/workload_report.qtpl:180
+//line pkg/report/templates/workload_report.qtpl:203
 		qw422016.N().S(`
                 
                   <div class="row"><h5 class="text-info" id="vulns_container_`)
-//line pkg/report/templates/workload_report.qtpl:182
+//line pkg/report/templates/workload_report.qtpl:205
 		qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:182
+//line pkg/report/templates/workload_report.qtpl:205
 		qw422016.N().S(`">Container `)
-//line pkg/report/templates/workload_report.qtpl:182
+//line pkg/report/templates/workload_report.qtpl:205
 		qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:182
+//line pkg/report/templates/workload_report.qtpl:205
 		qw422016.N().S(`</h5></div>
                   <div class="row"><p>`)
-//line pkg/report/templates/workload_report.qtpl:183
+//line pkg/report/templates/workload_report.qtpl:206
 		qw422016.E().S(report.Registry.Server)
-//line pkg/report/templates/workload_report.qtpl:183
+//line pkg/report/templates/workload_report.qtpl:206
 		qw422016.N().S(`/`)
-//line pkg/report/templates/workload_report.qtpl:183
+//line pkg/report/templates/workload_report.qtpl:206
 		qw422016.E().S(report.Artifact.Repository)
-//line pkg/report/templates/workload_report.qtpl:183
+//line pkg/report/templates/workload_report.qtpl:206
 		qw422016.N().S(`:`)
-//line pkg/report/templates/workload_report.qtpl:183
+//line pkg/report/templates/workload_report.qtpl:206
 		qw422016.E().S(report.Artifact.Tag)
-//line pkg/report/templates/workload_report.qtpl:183
+//line pkg/report/templates/workload_report.qtpl:206
 		qw422016.N().S(`</p></div>
                   `)
-//line pkg/report/templates/workload_report.qtpl:184
+//line pkg/report/templates/workload_report.qtpl:207
 		if len(report.Vulnerabilities) == 0 {
-//line pkg/report/templates/workload_report.qtpl:184
+//line pkg/report/templates/workload_report.qtpl:207
 			qw422016.N().S(`
                     <div class="row">
                       <p class="alert alert-success py-0 m-0" style="font-size: small;">No Vulnerabilities</p>
                     </div>                  
                   `)
-//line pkg/report/templates/workload_report.qtpl:188
+//line pkg/report/templates/workload_report.qtpl:211
 		} else {
-//line pkg/report/templates/workload_report.qtpl:188
+//line pkg/report/templates/workload_report.qtpl:211
 			qw422016.N().S(`
 
                   <div class="row">
@@ -452,67 +535,67 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                       </thead>
                       <tbody>
                         `)
-//line pkg/report/templates/workload_report.qtpl:202
+//line pkg/report/templates/workload_report.qtpl:225
 			for _, v := range report.Vulnerabilities {
-//line pkg/report/templates/workload_report.qtpl:202
+//line pkg/report/templates/workload_report.qtpl:225
 				qw422016.N().S(`
                         <tr>
                           <td>
                             <a target="_blank" href="`)
-//line pkg/report/templates/workload_report.qtpl:205
+//line pkg/report/templates/workload_report.qtpl:228
 				qw422016.E().S(v.PrimaryLink)
-//line pkg/report/templates/workload_report.qtpl:205
+//line pkg/report/templates/workload_report.qtpl:228
 				qw422016.N().S(`">`)
-//line pkg/report/templates/workload_report.qtpl:205
+//line pkg/report/templates/workload_report.qtpl:228
 				qw422016.E().S(v.VulnerabilityID)
-//line pkg/report/templates/workload_report.qtpl:205
+//line pkg/report/templates/workload_report.qtpl:228
 				qw422016.N().S(`</a>
                           </td>
                           <td>`)
-//line pkg/report/templates/workload_report.qtpl:207
+//line pkg/report/templates/workload_report.qtpl:230
 				qw422016.E().V(v.Severity)
-//line pkg/report/templates/workload_report.qtpl:207
+//line pkg/report/templates/workload_report.qtpl:230
 				qw422016.N().S(`</td>
                           <td>`)
-//line pkg/report/templates/workload_report.qtpl:208
+//line pkg/report/templates/workload_report.qtpl:231
 				qw422016.E().S(v.Resource)
-//line pkg/report/templates/workload_report.qtpl:208
+//line pkg/report/templates/workload_report.qtpl:231
 				qw422016.N().S(`</td>
                           <td>`)
-//line pkg/report/templates/workload_report.qtpl:209
+//line pkg/report/templates/workload_report.qtpl:232
 				qw422016.E().S(v.InstalledVersion)
-//line pkg/report/templates/workload_report.qtpl:209
+//line pkg/report/templates/workload_report.qtpl:232
 				qw422016.N().S(`</td>
                           <td>`)
-//line pkg/report/templates/workload_report.qtpl:210
+//line pkg/report/templates/workload_report.qtpl:233
 				qw422016.E().S(v.FixedVersion)
-//line pkg/report/templates/workload_report.qtpl:210
+//line pkg/report/templates/workload_report.qtpl:233
 				qw422016.N().S(`</td>
                         </tr>
                         `)
-//line pkg/report/templates/workload_report.qtpl:212
+//line pkg/report/templates/workload_report.qtpl:235
 			}
-//line pkg/report/templates/workload_report.qtpl:212
+//line pkg/report/templates/workload_report.qtpl:235
 			qw422016.N().S(`
                       </tbody>
                     </table>
                   </div>
                 `)
-//line pkg/report/templates/workload_report.qtpl:216
+//line pkg/report/templates/workload_report.qtpl:239
 		}
-//line pkg/report/templates/workload_report.qtpl:216
+//line pkg/report/templates/workload_report.qtpl:239
 		qw422016.N().S(`
                 `)
-//line pkg/report/templates/workload_report.qtpl:217
+//line pkg/report/templates/workload_report.qtpl:240
 	}
-//line pkg/report/templates/workload_report.qtpl:217
+//line pkg/report/templates/workload_report.qtpl:240
 	qw422016.N().S(`
 
                 <!-- Config Audits -->
                 `)
-//line pkg/report/templates/workload_report.qtpl:220
+//line pkg/report/templates/workload_report.qtpl:243
 	if p.ConfigAuditReport != nil && len(p.ConfigAuditReport.Report.PodChecks) > 0 {
-//line pkg/report/templates/workload_report.qtpl:220
+//line pkg/report/templates/workload_report.qtpl:243
 		qw422016.N().S(`
                   <div class="row pt-3 text-center border-bottom my-4">
                       <h3 class="mx-auto" id="ca_header" style="color: rgb(0, 160, 170);">Configuration Audit</h3>
@@ -530,19 +613,19 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                              <div class="row">
                                 <div class="col">
                                     <p class="my-0">Name:  `)
-//line pkg/report/templates/workload_report.qtpl:236
+//line pkg/report/templates/workload_report.qtpl:259
 		qw422016.E().S(p.ConfigAuditReport.Report.Scanner.Name)
-//line pkg/report/templates/workload_report.qtpl:236
+//line pkg/report/templates/workload_report.qtpl:259
 		qw422016.N().S(`</p>
                                     <p class="my-0">Vendor:  `)
-//line pkg/report/templates/workload_report.qtpl:237
+//line pkg/report/templates/workload_report.qtpl:260
 		qw422016.E().S(p.ConfigAuditReport.Report.Scanner.Vendor)
-//line pkg/report/templates/workload_report.qtpl:237
+//line pkg/report/templates/workload_report.qtpl:260
 		qw422016.N().S(`</p>
                                     <p class="my-0">Version:  `)
-//line pkg/report/templates/workload_report.qtpl:238
+//line pkg/report/templates/workload_report.qtpl:261
 		qw422016.E().S(p.ConfigAuditReport.Report.Scanner.Version)
-//line pkg/report/templates/workload_report.qtpl:238
+//line pkg/report/templates/workload_report.qtpl:261
 		qw422016.N().S(`</p>
                                 </div>
                              </div>
@@ -556,47 +639,47 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                             </div>
                             <div class="row">
                               `)
-//line pkg/report/templates/workload_report.qtpl:251
+//line pkg/report/templates/workload_report.qtpl:274
 		sumCritical := p.ConfigAuditReport.Report.Summary.CriticalCount
 		sumHigh := p.ConfigAuditReport.Report.Summary.HighCount
 		sumMedium := p.ConfigAuditReport.Report.Summary.MediumCount
 		sumLow := p.ConfigAuditReport.Report.Summary.LowCount
 
-//line pkg/report/templates/workload_report.qtpl:255
+//line pkg/report/templates/workload_report.qtpl:278
 		qw422016.N().S(`
 
                               <div class="col text-center p-0 text-danger font-weight-bold">
                                 <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:258
+//line pkg/report/templates/workload_report.qtpl:281
 		qw422016.N().D(sumCritical)
-//line pkg/report/templates/workload_report.qtpl:258
+//line pkg/report/templates/workload_report.qtpl:281
 		qw422016.N().S(`</p>
                                 <p class="mx-auto">CRITICAL</p>
                               </div>
 
                               <div class="col text-center p-0 text-danger font-weight-bold">
                                 <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:263
+//line pkg/report/templates/workload_report.qtpl:286
 		qw422016.N().D(sumHigh)
-//line pkg/report/templates/workload_report.qtpl:263
+//line pkg/report/templates/workload_report.qtpl:286
 		qw422016.N().S(`</p>
                                 <p class="mx-auto">HIGH</p>
                               </div>
 
                               <div class="col text-center p-0 text-warning font-weight-bold">
                                 <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:268
+//line pkg/report/templates/workload_report.qtpl:291
 		qw422016.N().D(sumMedium)
-//line pkg/report/templates/workload_report.qtpl:268
+//line pkg/report/templates/workload_report.qtpl:291
 		qw422016.N().S(`</p>
                                 <p class="mx-auto">MEDIUM</p>
                               </div>
 
                               <div class="col text-center p-0">
                                 <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:273
+//line pkg/report/templates/workload_report.qtpl:296
 		qw422016.N().D(sumLow)
-//line pkg/report/templates/workload_report.qtpl:273
+//line pkg/report/templates/workload_report.qtpl:296
 		qw422016.N().S(`</p>
                                 <p class="mx-auto">LOW</p>
                               </div>
@@ -613,9 +696,9 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                                 <div class="col">
                                     <p class="my-0">
                                         Generated at:  `)
-//line pkg/report/templates/workload_report.qtpl:288
+//line pkg/report/templates/workload_report.qtpl:311
 		qw422016.E().S(p.ConfigAuditReport.Report.UpdateTimestamp.Format("2 Jan 2006 15:04:01"))
-//line pkg/report/templates/workload_report.qtpl:288
+//line pkg/report/templates/workload_report.qtpl:311
 		qw422016.N().S(`
                                     </p>
                                 </div>
@@ -636,53 +719,53 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                             </thead>
                             <tbody>
                               `)
-//line pkg/report/templates/workload_report.qtpl:307
+//line pkg/report/templates/workload_report.qtpl:330
 		for _, check := range p.ConfigAuditReport.Report.PodChecks {
-//line pkg/report/templates/workload_report.qtpl:307
+//line pkg/report/templates/workload_report.qtpl:330
 			qw422016.N().S(`
                                 <tr>
                                   <td>`)
-//line pkg/report/templates/workload_report.qtpl:309
+//line pkg/report/templates/workload_report.qtpl:332
 			qw422016.E().V(check.Success)
-//line pkg/report/templates/workload_report.qtpl:309
+//line pkg/report/templates/workload_report.qtpl:332
 			qw422016.N().S(`</td>
                                   <td>`)
-//line pkg/report/templates/workload_report.qtpl:310
+//line pkg/report/templates/workload_report.qtpl:333
 			qw422016.E().S(check.ID)
-//line pkg/report/templates/workload_report.qtpl:310
+//line pkg/report/templates/workload_report.qtpl:333
 			qw422016.N().S(`</td>
                                   <td>`)
-//line pkg/report/templates/workload_report.qtpl:311
+//line pkg/report/templates/workload_report.qtpl:334
 			qw422016.E().V(check.Severity)
-//line pkg/report/templates/workload_report.qtpl:311
+//line pkg/report/templates/workload_report.qtpl:334
 			qw422016.N().S(`</td>
                                   <td>`)
-//line pkg/report/templates/workload_report.qtpl:312
+//line pkg/report/templates/workload_report.qtpl:335
 			qw422016.E().S(check.Category)
-//line pkg/report/templates/workload_report.qtpl:312
+//line pkg/report/templates/workload_report.qtpl:335
 			qw422016.N().S(`</td>
                                 </tr>
                               `)
-//line pkg/report/templates/workload_report.qtpl:314
+//line pkg/report/templates/workload_report.qtpl:337
 		}
-//line pkg/report/templates/workload_report.qtpl:314
+//line pkg/report/templates/workload_report.qtpl:337
 		qw422016.N().S(`
                             </tbody>
                       </table>
                   </div>
                   `)
-//line pkg/report/templates/workload_report.qtpl:318
+//line pkg/report/templates/workload_report.qtpl:341
 		for container, checks := range p.ConfigAuditReport.Report.ContainerChecks {
-//line pkg/report/templates/workload_report.qtpl:318
+//line pkg/report/templates/workload_report.qtpl:341
 			qw422016.N().S(`
                     <div class="row"><h5 class="text-info" id="ca_container_`)
-//line pkg/report/templates/workload_report.qtpl:319
+//line pkg/report/templates/workload_report.qtpl:342
 			qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:319
+//line pkg/report/templates/workload_report.qtpl:342
 			qw422016.N().S(`">Container `)
-//line pkg/report/templates/workload_report.qtpl:319
+//line pkg/report/templates/workload_report.qtpl:342
 			qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:319
+//line pkg/report/templates/workload_report.qtpl:342
 			qw422016.N().S(`</h5></div>
                     <div class="row">
                         <table class="table table-sm table-bordered">
@@ -696,78 +779,78 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                               </thead>
                               <tbody>
                                 `)
-//line pkg/report/templates/workload_report.qtpl:331
+//line pkg/report/templates/workload_report.qtpl:354
 			for _, check := range checks {
-//line pkg/report/templates/workload_report.qtpl:331
+//line pkg/report/templates/workload_report.qtpl:354
 				qw422016.N().S(`
                                   <tr>
                                     <td>`)
-//line pkg/report/templates/workload_report.qtpl:333
+//line pkg/report/templates/workload_report.qtpl:356
 				qw422016.E().V(check.Success)
-//line pkg/report/templates/workload_report.qtpl:333
+//line pkg/report/templates/workload_report.qtpl:356
 				qw422016.N().S(`</td>
                                     <td>`)
-//line pkg/report/templates/workload_report.qtpl:334
+//line pkg/report/templates/workload_report.qtpl:357
 				qw422016.E().S(check.ID)
-//line pkg/report/templates/workload_report.qtpl:334
+//line pkg/report/templates/workload_report.qtpl:357
 				qw422016.N().S(`</td>
                                     <td>`)
-//line pkg/report/templates/workload_report.qtpl:335
+//line pkg/report/templates/workload_report.qtpl:358
 				qw422016.E().V(check.Severity)
-//line pkg/report/templates/workload_report.qtpl:335
+//line pkg/report/templates/workload_report.qtpl:358
 				qw422016.N().S(`</td>
                                     <td>`)
-//line pkg/report/templates/workload_report.qtpl:336
+//line pkg/report/templates/workload_report.qtpl:359
 				qw422016.E().S(check.Category)
-//line pkg/report/templates/workload_report.qtpl:336
+//line pkg/report/templates/workload_report.qtpl:359
 				qw422016.N().S(`</td>
                                   </tr>
                                 `)
-//line pkg/report/templates/workload_report.qtpl:338
+//line pkg/report/templates/workload_report.qtpl:361
 			}
-//line pkg/report/templates/workload_report.qtpl:338
+//line pkg/report/templates/workload_report.qtpl:361
 			qw422016.N().S(`
                               </tbody>
                         </table>
                     </div>
                   `)
-//line pkg/report/templates/workload_report.qtpl:342
+//line pkg/report/templates/workload_report.qtpl:365
 		}
-//line pkg/report/templates/workload_report.qtpl:342
+//line pkg/report/templates/workload_report.qtpl:365
 		qw422016.N().S(`
                   `)
-//line pkg/report/templates/workload_report.qtpl:343
+//line pkg/report/templates/workload_report.qtpl:366
 	}
-//line pkg/report/templates/workload_report.qtpl:343
+//line pkg/report/templates/workload_report.qtpl:366
 	qw422016.N().S(`
             </div>
         </div>
 `)
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:369
 }
 
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:369
 func (p *WorkloadReport) WriteBody(qq422016 qtio422016.Writer) {
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:369
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:369
 	p.StreamBody(qw422016)
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:369
 	qt422016.ReleaseWriter(qw422016)
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:369
 }
 
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:369
 func (p *WorkloadReport) Body() string {
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:369
 	qb422016 := qt422016.AcquireByteBuffer()
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:369
 	p.WriteBody(qb422016)
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:369
 	qs422016 := string(qb422016.B)
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:369
 	qt422016.ReleaseByteBuffer(qb422016)
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:369
 	return qs422016
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:369
 }
@@ -0,0 +1,4 @@
+// Package risk provides primitives for computing the risk score of the
+// cluster, which combines findings of all scanners into a single number that
+// can be trended over time.
+package risk
@@ -0,0 +1,89 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Measure returns the inputs of the risk score measured from security reports
+// read with the given client.
+//
+// Vulnerability reports carry no data on the maturity of exploits, therefore
+// all critical vulnerabilities are counted. Compliance reports which have not
+// been generated yet are not counted.
+func Measure(ctx context.Context, c client.Reader) (Inputs, error) {
+	var inputs Inputs
+
+	criticalVulnerabilities := make(map[string]bool)
+	countCritical := func(report v1alpha1.VulnerabilityReportData) {
+		for _, vulnerability := range report.Vulnerabilities {
+			if vulnerability.Severity == v1alpha1.SeverityCritical {
+				criticalVulnerabilities[vulnerability.VulnerabilityID] = true
+			}
+		}
+	}
+	var vulnerabilityReports v1alpha1.VulnerabilityReportList
+	err := c.List(ctx, &vulnerabilityReports)
+	if err != nil {
+		return Inputs{}, fmt.Errorf("listing vulnerability reports: %w", err)
+	}
+	for _, report := range vulnerabilityReports.Items {
+		countCritical(report.Report)
+	}
+	var clusterVulnerabilityReports v1alpha1.ClusterVulnerabilityReportList
+	err = c.List(ctx, &clusterVulnerabilityReports)
+	if err != nil {
+		return Inputs{}, fmt.Errorf("listing cluster vulnerability reports: %w", err)
+	}
+	for _, report := range clusterVulnerabilityReports.Items {
+		countCritical(report.Report)
+	}
+	inputs.CriticalVulnerabilities = len(criticalVulnerabilities)
+
+	var kubeBenchReports v1alpha1.CISKubeBenchReportList
+	err = c.List(ctx, &kubeBenchReports)
+	if err != nil {
+		return Inputs{}, fmt.Errorf("listing CIS Kubernetes Benchmark reports: %w", err)
+	}
+	for _, report := range kubeBenchReports.Items {
+		inputs.FailedCISChecks += report.Report.Summary.FailCount
+	}
+
+	var configAuditReports v1alpha1.ConfigAuditReportList
+	err = c.List(ctx, &configAuditReports)
+	if err != nil {
+		return Inputs{}, fmt.Errorf("listing config audit reports: %w", err)
+	}
+	for _, report := range configAuditReports.Items {
+		inputs.ConfigAuditDangers += report.Report.Summary.CriticalCount
+	}
+	var clusterConfigAuditReports v1alpha1.ClusterConfigAuditReportList
+	err = c.List(ctx, &clusterConfigAuditReports)
+	if err != nil {
+		return Inputs{}, fmt.Errorf("listing cluster config audit reports: %w", err)
+	}
+	for _, report := range clusterConfigAuditReports.Items {
+		inputs.ConfigAuditDangers += report.Report.Summary.CriticalCount
+	}
+
+	var complianceReports v1alpha1.ClusterComplianceReportList
+	err = c.List(ctx, &complianceReports)
+	if err != nil {
+		return Inputs{}, fmt.Errorf("listing cluster compliance reports: %w", err)
+	}
+	var passCount, failCount int
+	for _, report := range complianceReports.Items {
+		if report.Status.UpdateTimestamp.IsZero() {
+			continue
+		}
+		passCount += report.Status.Summary.PassCount
+		failCount += report.Status.Summary.FailCount
+	}
+	if total := passCount + failCount; total > 0 {
+		inputs.ComplianceFailPercentage = float64(failCount) * 100 / float64(total)
+	}
+	return inputs, nil
+}
@@ -0,0 +1,87 @@
+package risk_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/risk"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMeasure(t *testing.T) {
+	critical := func(id string) v1alpha1.Vulnerability {
+		return v1alpha1.Vulnerability{VulnerabilityID: id, Severity: v1alpha1.SeverityCritical}
+	}
+	generated := metav1.NewTime(time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC))
+
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "replicaset-nginx-6d4cf56db6-nginx", Namespace: "default"},
+			Report: v1alpha1.VulnerabilityReportData{Vulnerabilities: []v1alpha1.Vulnerability{
+				critical("CVE-2022-0001"),
+				critical("CVE-2022-0002"),
+				{VulnerabilityID: "CVE-2022-0003", Severity: v1alpha1.SeverityHigh},
+			}},
+		},
+		&v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "statefulset-redis-redis", Namespace: "prod"},
+			Report: v1alpha1.VulnerabilityReportData{Vulnerabilities: []v1alpha1.Vulnerability{
+				critical("CVE-2022-0001"),
+			}},
+		},
+		&v1alpha1.ClusterVulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-kind-control-plane"},
+			Report: v1alpha1.VulnerabilityReportData{Vulnerabilities: []v1alpha1.Vulnerability{
+				critical("CVE-2022-0004"),
+			}},
+		},
+		&v1alpha1.CISKubeBenchReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "kind-control-plane"},
+			Report:     v1alpha1.CISKubeBenchReportData{Summary: v1alpha1.CISKubeBenchSummary{FailCount: 7, WarnCount: 20}},
+		},
+		&v1alpha1.CISKubeBenchReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "kind-worker"},
+			Report:     v1alpha1.CISKubeBenchReportData{Summary: v1alpha1.CISKubeBenchSummary{FailCount: 2}},
+		},
+		&v1alpha1.ConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "replicaset-nginx-6d4cf56db6", Namespace: "default"},
+			Report:     v1alpha1.ConfigAuditReportData{Summary: v1alpha1.ConfigAuditSummary{CriticalCount: 3, HighCount: 5}},
+		},
+		&v1alpha1.ClusterConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "clusterrole-admin"},
+			Report:     v1alpha1.ConfigAuditReportData{Summary: v1alpha1.ConfigAuditSummary{CriticalCount: 1}},
+		},
+		&v1alpha1.ClusterComplianceReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "nsa"},
+			Status: v1alpha1.ReportStatus{
+				UpdateTimestamp: generated,
+				Summary:         v1alpha1.ClusterComplianceSummary{PassCount: 20, FailCount: 5},
+			},
+		},
+		&v1alpha1.ClusterComplianceReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "pss"},
+			Status: v1alpha1.ReportStatus{
+				UpdateTimestamp: generated,
+				Summary:         v1alpha1.ClusterComplianceSummary{PassCount: 10, FailCount: 5},
+			},
+		},
+		&v1alpha1.ClusterComplianceReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+		},
+	).Build()
+
+	inputs, err := risk.Measure(context.TODO(), c)
+	require.NoError(t, err)
+	assert.Equal(t, risk.Inputs{
+		CriticalVulnerabilities:  3,
+		FailedCISChecks:          9,
+		ConfigAuditDangers:       4,
+		ComplianceFailPercentage: 25,
+	}, inputs)
+}
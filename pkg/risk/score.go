@@ -0,0 +1,120 @@
+package risk
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+)
+
+// ReportName is the name of the v1alpha1.ClusterRiskReport which holds the
+// risk score of the cluster.
+const ReportName = "cluster"
+
+// Names of components of the risk score.
+const (
+	// ComponentCriticalVulnerabilities is the number of unique IDs of
+	// critical vulnerabilities found in the cluster.
+	ComponentCriticalVulnerabilities = "criticalVulnerabilities"
+	// ComponentFailedCISChecks is the number of failed checks of the CIS
+	// Kubernetes Benchmark summed up over all nodes.
+	ComponentFailedCISChecks = "failedCISChecks"
+	// ComponentConfigAuditDangers is the number of failed configuration audit
+	// checks with danger, i.e. critical, severity.
+	ComponentConfigAuditDangers = "configAuditDangers"
+	// ComponentComplianceFailPercentage is the percentage of failed controls
+	// of all cluster compliance reports.
+	ComponentComplianceFailPercentage = "complianceFailPercentage"
+)
+
+// Keys of weights of components in the starboard ConfigMap.
+const (
+	KeyWeightCriticalVulnerabilities  = "riskScore.weights." + ComponentCriticalVulnerabilities
+	KeyWeightFailedCISChecks          = "riskScore.weights." + ComponentFailedCISChecks
+	KeyWeightConfigAuditDangers       = "riskScore.weights." + ComponentConfigAuditDangers
+	KeyWeightComplianceFailPercentage = "riskScore.weights." + ComponentComplianceFailPercentage
+)
+
+// Weights holds the weight of each component of the risk score.
+type Weights struct {
+	CriticalVulnerabilities  float64
+	FailedCISChecks          float64
+	ConfigAuditDangers       float64
+	ComplianceFailPercentage float64
+}
+
+// DefaultWeights are weights of components which are not configured.
+var DefaultWeights = Weights{
+	CriticalVulnerabilities:  1,
+	FailedCISChecks:          0.5,
+	ConfigAuditDangers:       0.25,
+	ComplianceFailPercentage: 1,
+}
+
+// GetWeights returns weights of components configured in the given
+// starboard.ConfigData. Weights which are not set fall back to
+// DefaultWeights.
+func GetWeights(config starboard.ConfigData) (Weights, error) {
+	weights := DefaultWeights
+	for key, weight := range map[string]*float64{
+		KeyWeightCriticalVulnerabilities:  &weights.CriticalVulnerabilities,
+		KeyWeightFailedCISChecks:          &weights.FailedCISChecks,
+		KeyWeightConfigAuditDangers:       &weights.ConfigAuditDangers,
+		KeyWeightComplianceFailPercentage: &weights.ComplianceFailPercentage,
+	} {
+		value, ok := config[key]
+		if !ok || strings.TrimSpace(value) == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || parsed < 0 || math.IsInf(parsed, 0) || math.IsNaN(parsed) {
+			return Weights{}, fmt.Errorf("property %s must be a non-negative number, got %q", key, value)
+		}
+		*weight = parsed
+	}
+	return weights, nil
+}
+
+// Inputs are the measured values of components of the risk score.
+type Inputs struct {
+	CriticalVulnerabilities  int
+	FailedCISChecks          int
+	ConfigAuditDangers       int
+	ComplianceFailPercentage float64
+}
+
+// Evaluate computes the risk score of the given inputs, which is the sum of
+// each input multiplied by its weight. Scores are rounded to two decimal
+// places so that they don't change with floating-point errors.
+func Evaluate(inputs Inputs, weights Weights) v1alpha1.ClusterRiskReportData {
+	components := []v1alpha1.RiskComponent{
+		component(ComponentCriticalVulnerabilities, float64(inputs.CriticalVulnerabilities), weights.CriticalVulnerabilities),
+		component(ComponentFailedCISChecks, float64(inputs.FailedCISChecks), weights.FailedCISChecks),
+		component(ComponentConfigAuditDangers, float64(inputs.ConfigAuditDangers), weights.ConfigAuditDangers),
+		component(ComponentComplianceFailPercentage, inputs.ComplianceFailPercentage, weights.ComplianceFailPercentage),
+	}
+	var score float64
+	for _, c := range components {
+		score += c.Score
+	}
+	return v1alpha1.ClusterRiskReportData{
+		Score:      round(score),
+		Components: components,
+	}
+}
+
+func component(name string, value, weight float64) v1alpha1.RiskComponent {
+	return v1alpha1.RiskComponent{
+		Name:   name,
+		Value:  round(value),
+		Weight: weight,
+		Score:  round(value * weight),
+	}
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,167 @@
+package risk_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/risk"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetWeights(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        starboard.ConfigData
+		expected      risk.Weights
+		expectedError string
+	}{
+		{
+			name:     "Should return default weights",
+			config:   starboard.ConfigData{},
+			expected: risk.DefaultWeights,
+		},
+		{
+			name: "Should override configured weights",
+			config: starboard.ConfigData{
+				risk.KeyWeightCriticalVulnerabilities: "2",
+				risk.KeyWeightConfigAuditDangers:      " 0.1 ",
+				risk.KeyWeightFailedCISChecks:         "",
+			},
+			expected: risk.Weights{
+				CriticalVulnerabilities:  2,
+				FailedCISChecks:          0.5,
+				ConfigAuditDangers:       0.1,
+				ComplianceFailPercentage: 1,
+			},
+		},
+		{
+			name: "Should allow disabling components",
+			config: starboard.ConfigData{
+				risk.KeyWeightComplianceFailPercentage: "0",
+			},
+			expected: risk.Weights{
+				CriticalVulnerabilities:  1,
+				FailedCISChecks:          0.5,
+				ConfigAuditDangers:       0.25,
+				ComplianceFailPercentage: 0,
+			},
+		},
+		{
+			name: "Should return error for negative weight",
+			config: starboard.ConfigData{
+				risk.KeyWeightFailedCISChecks: "-1",
+			},
+			expectedError: `property riskScore.weights.failedCISChecks must be a non-negative number, got "-1"`,
+		},
+		{
+			name: "Should return error for invalid weight",
+			config: starboard.ConfigData{
+				risk.KeyWeightCriticalVulnerabilities: "high",
+			},
+			expectedError: `property riskScore.weights.criticalVulnerabilities must be a non-negative number, got "high"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			weights, err := risk.GetWeights(tc.config)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, weights)
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		inputs     risk.Inputs
+		weights    risk.Weights
+		score      float64
+		components []float64
+	}{
+		{
+			name:       "Should return zero for clean cluster",
+			inputs:     risk.Inputs{},
+			weights:    risk.DefaultWeights,
+			score:      0,
+			components: []float64{0, 0, 0, 0},
+		},
+		{
+			name: "Should weight inputs with default weights",
+			inputs: risk.Inputs{
+				CriticalVulnerabilities:  12,
+				FailedCISChecks:          9,
+				ConfigAuditDangers:       30,
+				ComplianceFailPercentage: 25,
+			},
+			weights:    risk.DefaultWeights,
+			score:      49,
+			components: []float64{12, 4.5, 7.5, 25},
+		},
+		{
+			name: "Should weight inputs with configured weights",
+			inputs: risk.Inputs{
+				CriticalVulnerabilities:  12,
+				FailedCISChecks:          9,
+				ConfigAuditDangers:       30,
+				ComplianceFailPercentage: 25,
+			},
+			weights: risk.Weights{
+				CriticalVulnerabilities:  2,
+				FailedCISChecks:          1,
+				ConfigAuditDangers:       0.1,
+				ComplianceFailPercentage: 0,
+			},
+			score:      36,
+			components: []float64{24, 9, 3, 0},
+		},
+		{
+			name: "Should round scores to two decimal places",
+			inputs: risk.Inputs{
+				CriticalVulnerabilities:  3,
+				ComplianceFailPercentage: 100.0 / 3,
+			},
+			weights: risk.Weights{
+				CriticalVulnerabilities:  0.1,
+				ComplianceFailPercentage: 1,
+			},
+			score:      33.63,
+			components: []float64{0.3, 0, 0, 33.33},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := risk.Evaluate(tc.inputs, tc.weights)
+			assert.Equal(t, tc.score, data.Score)
+			require.Len(t, data.Components, 4)
+			var names []string
+			var scores []float64
+			for _, component := range data.Components {
+				names = append(names, component.Name)
+				scores = append(scores, component.Score)
+			}
+			assert.Equal(t, []string{
+				risk.ComponentCriticalVulnerabilities,
+				risk.ComponentFailedCISChecks,
+				risk.ComponentConfigAuditDangers,
+				risk.ComponentComplianceFailPercentage,
+			}, names)
+			assert.Equal(t, tc.components, scores)
+		})
+	}
+
+	t.Run("Should record values and weights of components", func(t *testing.T) {
+		data := risk.Evaluate(risk.Inputs{FailedCISChecks: 9}, risk.DefaultWeights)
+		assert.Equal(t, v1alpha1.RiskComponent{
+			Name:   risk.ComponentFailedCISChecks,
+			Value:  9,
+			Weight: 0.5,
+			Score:  4.5,
+		}, data.Components[1])
+	})
+}
@@ -0,0 +1,278 @@
+package sarif
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FromVulnerabilityReports converts the given vulnerability reports to a SARIF
+// log with one run per scanner. Each vulnerability is a rule, and each
+// occurrence of a vulnerability in a container image is a result located by
+// the image reference and the coordinates of the workload container.
+func FromVulnerabilityReports(reports []v1alpha1.VulnerabilityReport) Log {
+	b := newBuilder()
+	for _, report := range reports {
+		// Images which were not scanned have no results.
+		if report.Report.SkipReason != "" {
+			continue
+		}
+		run := b.run(report.Report.Scanner)
+		image := imageRef(report.Report.Registry, report.Report.Artifact)
+		workload := workloadRef(report.ObjectMeta)
+		container := report.Labels[starboard.LabelContainerName]
+
+		for _, v := range report.Report.Vulnerabilities {
+			level := levelFromSeverity(v.Severity)
+			ruleIndex := run.rule(v.VulnerabilityID, func() ReportingDescriptor {
+				return vulnerabilityRule(v, level)
+			})
+			artifact := image
+			if v.Target != "" {
+				artifact = v.Target
+			}
+			run.Results = append(run.Results, Result{
+				RuleID:    v.VulnerabilityID,
+				RuleIndex: ruleIndex,
+				Level:     level,
+				Message:   Message{Text: vulnerabilityMessage(v, artifact)},
+				Locations: []Location{newLocation(artifact, workload, container)},
+			})
+		}
+	}
+	return b.log()
+}
+
+// FromConfigAuditReports converts failed checks of the given configuration
+// audit reports to a SARIF log with one run per scanner. Each check is a rule,
+// and each failed check of a workload is a result located by the coordinates
+// of the workload.
+func FromConfigAuditReports(reports []v1alpha1.ConfigAuditReport) Log {
+	b := newBuilder()
+	for _, report := range reports {
+		run := b.run(report.Report.Scanner)
+		workload := workloadRef(report.ObjectMeta)
+
+		for _, check := range report.Report.Checks {
+			if check.Success {
+				continue
+			}
+			level := levelFromSeverity(check.Severity)
+			ruleIndex := run.rule(check.ID, func() ReportingDescriptor {
+				return checkRule(check, level)
+			})
+			var container string
+			if check.Scope != nil && check.Scope.Type == "Container" {
+				container = check.Scope.Value
+			}
+			run.Results = append(run.Results, Result{
+				RuleID:    check.ID,
+				RuleIndex: ruleIndex,
+				Level:     level,
+				Message:   Message{Text: checkMessage(check, workload)},
+				Locations: []Location{newLocation(workload, workload, container)},
+			})
+		}
+	}
+	return b.log()
+}
+
+// builder accumulates runs of a Log in the order in which scanners appear.
+type builder struct {
+	runs []*runBuilder
+}
+
+type runBuilder struct {
+	Run
+	ruleIndexes map[string]int
+}
+
+func newBuilder() *builder {
+	return &builder{}
+}
+
+func (b *builder) run(scanner v1alpha1.Scanner) *runBuilder {
+	for _, run := range b.runs {
+		if run.Tool.Driver.Name == scanner.Name && run.Tool.Driver.Version == scanner.Version {
+			return run
+		}
+	}
+	run := &runBuilder{
+		Run: Run{
+			Tool:    Tool{Driver: ToolComponent{Name: scanner.Name, Version: scanner.Version}},
+			Results: []Result{},
+		},
+		ruleIndexes: make(map[string]int),
+	}
+	b.runs = append(b.runs, run)
+	return run
+}
+
+// rule returns the index of the rule with the given ID, and adds the rule
+// returned by newRule if it has not been added yet.
+func (r *runBuilder) rule(id string, newRule func() ReportingDescriptor) int {
+	if index, ok := r.ruleIndexes[id]; ok {
+		return index
+	}
+	index := len(r.Tool.Driver.Rules)
+	r.Tool.Driver.Rules = append(r.Tool.Driver.Rules, newRule())
+	r.ruleIndexes[id] = index
+	return index
+}
+
+func (b *builder) log() Log {
+	runs := make([]Run, 0, len(b.runs))
+	for _, run := range b.runs {
+		runs = append(runs, run.Run)
+	}
+	return Log{Version: Version, Schema: Schema, Runs: runs}
+}
+
+func vulnerabilityRule(v v1alpha1.Vulnerability, level Level) ReportingDescriptor {
+	title := v.Title
+	if title == "" {
+		title = v.VulnerabilityID
+	}
+	rule := ReportingDescriptor{
+		ID:                   v.VulnerabilityID,
+		ShortDescription:     &MultiformatMessage{Text: title},
+		HelpURI:              absoluteURI(v.PrimaryLink),
+		DefaultConfiguration: &ReportingConfiguration{Level: level},
+		Properties: map[string]interface{}{
+			"tags": []string{"vulnerability", "security", string(v.Severity)},
+		},
+	}
+	if v.Description != "" {
+		rule.FullDescription = &MultiformatMessage{Text: v.Description}
+	}
+	if v.Score != nil {
+		// Used by GitHub code scanning to rank security alerts.
+		rule.Properties["security-severity"] = fmt.Sprintf("%.1f", *v.Score)
+	}
+	if len(v.Links) > 0 {
+		rule.Help = &MultiformatMessage{Text: strings.Join(v.Links, "\n")}
+	}
+	return rule
+}
+
+func vulnerabilityMessage(v v1alpha1.Vulnerability, artifact string) string {
+	msg := fmt.Sprintf("Package %s %s in %s is affected by %s.", v.Resource, v.InstalledVersion, artifact, v.VulnerabilityID)
+	if v.FixedVersion != "" {
+		msg += fmt.Sprintf(" Fixed version: %s.", v.FixedVersion)
+	}
+	return msg
+}
+
+func checkRule(check v1alpha1.Check, level Level) ReportingDescriptor {
+	title := check.Title
+	if title == "" {
+		title = check.ID
+	}
+	tags := []string{"config-audit", "security", string(check.Severity)}
+	if check.Category != "" {
+		tags = append(tags, check.Category)
+	}
+	rule := ReportingDescriptor{
+		ID:                   check.ID,
+		ShortDescription:     &MultiformatMessage{Text: title},
+		DefaultConfiguration: &ReportingConfiguration{Level: level},
+		Properties:           map[string]interface{}{"tags": tags},
+	}
+	if check.Description != "" {
+		rule.FullDescription = &MultiformatMessage{Text: check.Description}
+	}
+	if check.Remediation != "" {
+		rule.Help = &MultiformatMessage{Text: check.Remediation}
+		rule.HelpURI = absoluteURI(check.Remediation)
+	}
+	return rule
+}
+
+func checkMessage(check v1alpha1.Check, workload string) string {
+	if len(check.Messages) > 0 {
+		return strings.Join(check.Messages, "\n")
+	}
+	if check.Title != "" {
+		return fmt.Sprintf("%s: %s", workload, check.Title)
+	}
+	return fmt.Sprintf("%s failed check %s", workload, check.ID)
+}
+
+func newLocation(artifact, workload, container string) Location {
+	logical := LogicalLocation{
+		Name:               workload,
+		FullyQualifiedName: workload,
+		Kind:               "workload",
+	}
+	if container != "" {
+		logical = LogicalLocation{
+			Name:               container,
+			FullyQualifiedName: workload + "/" + container,
+			Kind:               "container",
+		}
+	}
+	return Location{
+		PhysicalLocation: &PhysicalLocation{ArtifactLocation: ArtifactLocation{URI: uriReference(artifact)}},
+		LogicalLocations: []LogicalLocation{logical},
+	}
+}
+
+// levelFromSeverity maps the severity of a vulnerability or check to the
+// level of a SARIF result.
+func levelFromSeverity(severity v1alpha1.Severity) Level {
+	switch severity {
+	case v1alpha1.SeverityCritical, v1alpha1.SeverityHigh:
+		return LevelError
+	case v1alpha1.SeverityMedium:
+		return LevelWarning
+	case v1alpha1.SeverityNone:
+		return LevelNone
+	default:
+		return LevelNote
+	}
+}
+
+func imageRef(registry v1alpha1.Registry, artifact v1alpha1.Artifact) string {
+	ref := artifact.Repository
+	if registry.Server != "" {
+		ref = registry.Server + "/" + ref
+	}
+	if artifact.Tag != "" {
+		ref += ":" + artifact.Tag
+	}
+	if artifact.Digest != "" {
+		ref += "@" + artifact.Digest
+	}
+	return ref
+}
+
+// workloadRef returns the namespace, kind and name of the workload that owns
+// the report with the given metadata, e.g. default/ReplicaSet/nginx.
+func workloadRef(meta metav1.ObjectMeta) string {
+	ref := meta.Labels[starboard.LabelResourceKind] + "/" + meta.Labels[starboard.LabelResourceName]
+	if namespace := meta.Labels[starboard.LabelResourceNamespace]; namespace != "" {
+		ref = namespace + "/" + ref
+	}
+	return ref
+}
+
+// uriReference returns the given reference as a relative URI reference, e.g.
+// a reference of an image hosted in a registry with a port is not mistaken
+// for a URI with scheme.
+func uriReference(ref string) string {
+	return (&url.URL{Path: ref}).String()
+}
+
+// absoluteURI returns the given link if it's an absolute URI, or empty string
+// otherwise.
+func absoluteURI(link string) string {
+	u, err := url.Parse(link)
+	if err != nil || !u.IsAbs() {
+		return ""
+	}
+	return link
+}
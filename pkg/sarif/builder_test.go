@@ -0,0 +1,261 @@
+package sarif_test
+
+import (
+	"bytes"
+	_ "embed"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/sarif"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+//go:embed testdata/sarif-schema-2.1.0.json
+var schema string
+
+var (
+	vulnerabilityReports = []v1alpha1.VulnerabilityReport{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-nginx-6d4cf56db6-nginx",
+				Namespace: "default",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "ReplicaSet",
+					starboard.LabelResourceName:      "nginx-6d4cf56db6",
+					starboard.LabelResourceNamespace: "default",
+					starboard.LabelContainerName:     "nginx",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Scanner:  v1alpha1.Scanner{Name: "Trivy", Vendor: "Aqua Security", Version: "0.25.2"},
+				Registry: v1alpha1.Registry{Server: "index.docker.io"},
+				Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+				Vulnerabilities: []v1alpha1.Vulnerability{
+					{
+						VulnerabilityID:  "CVE-2019-1543",
+						Resource:         "libssl1.1",
+						InstalledVersion: "1.1.1c-1",
+						FixedVersion:     "1.1.1d-0+deb10u1",
+						Severity:         v1alpha1.SeverityHigh,
+						Title:            "openssl: ChaCha20-Poly1305 with long nonces",
+						Description:      "ChaCha20-Poly1305 is an AEAD cipher.",
+						PrimaryLink:      "https://avd.aquasec.com/nvd/cve-2019-1543",
+						Links:            []string{"https://www.openssl.org/news/secadv/20190306.txt"},
+						Score:            pointer.Float64Ptr(7.4),
+					},
+					{
+						VulnerabilityID:  "CVE-2021-44228",
+						Resource:         "org.apache.logging.log4j:log4j-core",
+						InstalledVersion: "2.14.1",
+						Severity:         v1alpha1.SeverityCritical,
+						Target:           "sbom:nginx-sbom",
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-nginx-6d4cf56db6-sidecar",
+				Namespace: "default",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "ReplicaSet",
+					starboard.LabelResourceName:      "nginx-6d4cf56db6",
+					starboard.LabelResourceNamespace: "default",
+					starboard.LabelContainerName:     "sidecar",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Scanner:  v1alpha1.Scanner{Name: "Trivy", Vendor: "Aqua Security", Version: "0.25.2"},
+				Registry: v1alpha1.Registry{Server: "localhost:5000"},
+				Artifact: v1alpha1.Artifact{Repository: "sidecar", Digest: "sha256:2b6e7d1c"},
+				Vulnerabilities: []v1alpha1.Vulnerability{
+					{
+						VulnerabilityID:  "CVE-2019-1543",
+						Resource:         "libssl1.1",
+						InstalledVersion: "1.1.1c-1",
+						Severity:         v1alpha1.SeverityHigh,
+						Title:            "openssl: ChaCha20-Poly1305 with long nonces",
+						PrimaryLink:      "https://avd.aquasec.com/nvd/cve-2019-1543",
+					},
+				},
+			},
+		},
+	}
+
+	configAuditReports = []v1alpha1.ConfigAuditReport{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-nginx-6d4cf56db6",
+				Namespace: "default",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "ReplicaSet",
+					starboard.LabelResourceName:      "nginx-6d4cf56db6",
+					starboard.LabelResourceNamespace: "default",
+				},
+			},
+			Report: v1alpha1.ConfigAuditReportData{
+				Scanner: v1alpha1.Scanner{Name: "Starboard", Vendor: "Aqua Security", Version: "dev"},
+				Checks: []v1alpha1.Check{
+					{
+						ID:          "KSV012",
+						Title:       "Runs as root user",
+						Description: "Force the running image to run as a non-root user.",
+						Severity:    v1alpha1.SeverityMedium,
+						Category:    "Kubernetes Security Check",
+						Messages:    []string{"Container 'nginx' should set 'securityContext.runAsNonRoot' to true"},
+						Remediation: "https://avd.aquasec.com/appshield/ksv012",
+						Scope:       &v1alpha1.CheckScope{Type: "Container", Value: "nginx"},
+					},
+					{
+						ID:       "KSV001",
+						Title:    "Process can elevate its own privileges",
+						Severity: v1alpha1.SeverityMedium,
+						Success:  true,
+					},
+					{
+						ID:       "KSV021",
+						Severity: v1alpha1.SeverityLow,
+					},
+				},
+			},
+		},
+	}
+)
+
+func TestFromVulnerabilityReports(t *testing.T) {
+	log := sarif.FromVulnerabilityReports(vulnerabilityReports)
+	assertValid(t, log)
+
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, sarif.ToolComponent{Name: "Trivy", Version: "0.25.2", Rules: run.Tool.Driver.Rules}, run.Tool.Driver)
+
+	require.Len(t, run.Tool.Driver.Rules, 2, "rules must not be duplicated")
+	assert.Equal(t, sarif.ReportingDescriptor{
+		ID:                   "CVE-2019-1543",
+		ShortDescription:     &sarif.MultiformatMessage{Text: "openssl: ChaCha20-Poly1305 with long nonces"},
+		FullDescription:      &sarif.MultiformatMessage{Text: "ChaCha20-Poly1305 is an AEAD cipher."},
+		HelpURI:              "https://avd.aquasec.com/nvd/cve-2019-1543",
+		Help:                 &sarif.MultiformatMessage{Text: "https://www.openssl.org/news/secadv/20190306.txt"},
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: sarif.LevelError},
+		Properties: map[string]interface{}{
+			"tags":              []string{"vulnerability", "security", "HIGH"},
+			"security-severity": "7.4",
+		},
+	}, run.Tool.Driver.Rules[0])
+	assert.Equal(t, "CVE-2021-44228", run.Tool.Driver.Rules[1].ID)
+
+	assert.Equal(t, []sarif.Result{
+		{
+			RuleID:    "CVE-2019-1543",
+			RuleIndex: 0,
+			Level:     sarif.LevelError,
+			Message:   sarif.Message{Text: "Package libssl1.1 1.1.1c-1 in index.docker.io/library/nginx:1.16 is affected by CVE-2019-1543. Fixed version: 1.1.1d-0+deb10u1."},
+			Locations: []sarif.Location{{
+				PhysicalLocation: &sarif.PhysicalLocation{ArtifactLocation: sarif.ArtifactLocation{URI: "index.docker.io/library/nginx:1.16"}},
+				LogicalLocations: []sarif.LogicalLocation{{Name: "nginx", FullyQualifiedName: "default/ReplicaSet/nginx-6d4cf56db6/nginx", Kind: "container"}},
+			}},
+		},
+		{
+			RuleID:    "CVE-2021-44228",
+			RuleIndex: 1,
+			Level:     sarif.LevelError,
+			Message:   sarif.Message{Text: "Package org.apache.logging.log4j:log4j-core 2.14.1 in sbom:nginx-sbom is affected by CVE-2021-44228."},
+			Locations: []sarif.Location{{
+				PhysicalLocation: &sarif.PhysicalLocation{ArtifactLocation: sarif.ArtifactLocation{URI: "./sbom:nginx-sbom"}},
+				LogicalLocations: []sarif.LogicalLocation{{Name: "nginx", FullyQualifiedName: "default/ReplicaSet/nginx-6d4cf56db6/nginx", Kind: "container"}},
+			}},
+		},
+		{
+			RuleID:    "CVE-2019-1543",
+			RuleIndex: 0,
+			Level:     sarif.LevelError,
+			Message:   sarif.Message{Text: "Package libssl1.1 1.1.1c-1 in localhost:5000/sidecar@sha256:2b6e7d1c is affected by CVE-2019-1543."},
+			Locations: []sarif.Location{{
+				PhysicalLocation: &sarif.PhysicalLocation{ArtifactLocation: sarif.ArtifactLocation{URI: "./localhost:5000/sidecar@sha256:2b6e7d1c"}},
+				LogicalLocations: []sarif.LogicalLocation{{Name: "sidecar", FullyQualifiedName: "default/ReplicaSet/nginx-6d4cf56db6/sidecar", Kind: "container"}},
+			}},
+		},
+	}, run.Results)
+}
+
+func TestFromConfigAuditReports(t *testing.T) {
+	log := sarif.FromConfigAuditReports(configAuditReports)
+	assertValid(t, log)
+
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	require.Len(t, run.Tool.Driver.Rules, 2, "successful checks must be skipped")
+	assert.Equal(t, sarif.ReportingDescriptor{
+		ID:                   "KSV012",
+		ShortDescription:     &sarif.MultiformatMessage{Text: "Runs as root user"},
+		FullDescription:      &sarif.MultiformatMessage{Text: "Force the running image to run as a non-root user."},
+		HelpURI:              "https://avd.aquasec.com/appshield/ksv012",
+		Help:                 &sarif.MultiformatMessage{Text: "https://avd.aquasec.com/appshield/ksv012"},
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: sarif.LevelWarning},
+		Properties: map[string]interface{}{
+			"tags": []string{"config-audit", "security", "MEDIUM", "Kubernetes Security Check"},
+		},
+	}, run.Tool.Driver.Rules[0])
+
+	assert.Equal(t, []sarif.Result{
+		{
+			RuleID:    "KSV012",
+			RuleIndex: 0,
+			Level:     sarif.LevelWarning,
+			Message:   sarif.Message{Text: "Container 'nginx' should set 'securityContext.runAsNonRoot' to true"},
+			Locations: []sarif.Location{{
+				PhysicalLocation: &sarif.PhysicalLocation{ArtifactLocation: sarif.ArtifactLocation{URI: "default/ReplicaSet/nginx-6d4cf56db6"}},
+				LogicalLocations: []sarif.LogicalLocation{{Name: "nginx", FullyQualifiedName: "default/ReplicaSet/nginx-6d4cf56db6/nginx", Kind: "container"}},
+			}},
+		},
+		{
+			RuleID:    "KSV021",
+			RuleIndex: 1,
+			Level:     sarif.LevelNote,
+			Message:   sarif.Message{Text: "default/ReplicaSet/nginx-6d4cf56db6 failed check KSV021"},
+			Locations: []sarif.Location{{
+				PhysicalLocation: &sarif.PhysicalLocation{ArtifactLocation: sarif.ArtifactLocation{URI: "default/ReplicaSet/nginx-6d4cf56db6"}},
+				LogicalLocations: []sarif.LogicalLocation{{Name: "default/ReplicaSet/nginx-6d4cf56db6", FullyQualifiedName: "default/ReplicaSet/nginx-6d4cf56db6", Kind: "workload"}},
+			}},
+		},
+	}, run.Results)
+}
+
+func TestFromReports_Empty(t *testing.T) {
+	assertValid(t, sarif.FromVulnerabilityReports(nil))
+	assertValid(t, sarif.FromConfigAuditReports(nil))
+}
+
+func TestFromVulnerabilityReports_Skipped(t *testing.T) {
+	log := sarif.FromVulnerabilityReports([]v1alpha1.VulnerabilityReport{{
+		Report: v1alpha1.VulnerabilityReportData{
+			Scanner:    v1alpha1.Scanner{Name: "Starboard", Vendor: "Aqua Security", Version: "dev"},
+			Registry:   v1alpha1.Registry{Server: "registry.vendor.example"},
+			Artifact:   v1alpha1.Artifact{Repository: "appliance", Tag: "1.0"},
+			SkipReason: v1alpha1.SkipReasonRegistryExcluded,
+		},
+	}})
+
+	assertValid(t, log)
+	assert.Empty(t, log.Runs)
+}
+
+// assertValid asserts that the given log is valid against the SARIF 2.1.0
+// JSON schema.
+func assertValid(t *testing.T, log sarif.Log) {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, sarif.Write(&buf, log))
+
+	result, err := gojsonschema.Validate(gojsonschema.NewStringLoader(schema), gojsonschema.NewBytesLoader(buf.Bytes()))
+	require.NoError(t, err)
+	for _, desc := range result.Errors() {
+		t.Errorf("invalid SARIF: %s", desc)
+	}
+}
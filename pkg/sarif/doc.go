@@ -0,0 +1,4 @@
+// Package sarif provides primitives for converting security reports to the
+// Static Analysis Results Interchange Format (SARIF) 2.1.0, which is ingested
+// by GitHub code scanning and other security dashboards.
+package sarif
@@ -0,0 +1,117 @@
+package sarif
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	// Version is the version of the SARIF specification implemented by this
+	// package.
+	Version = "2.1.0"
+	// Schema is the URI of the JSON schema of SARIF Version.
+	Schema = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// Level is the severity of a Result.
+type Level string
+
+const (
+	LevelError   Level = "error"
+	LevelWarning Level = "warning"
+	LevelNote    Level = "note"
+	LevelNone    Level = "none"
+)
+
+// Log is the top-level element of a SARIF file.
+type Log struct {
+	Version string `json:"version"`
+	Schema  string `json:"$schema"`
+	Runs    []Run  `json:"runs"`
+}
+
+// Run describes a single run of an analysis tool and contains its results.
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+// Tool describes the analysis tool that was run.
+type Tool struct {
+	Driver ToolComponent `json:"driver"`
+}
+
+// ToolComponent describes the primary executable of an analysis tool, and
+// the rules it evaluated.
+type ToolComponent struct {
+	Name    string                `json:"name"`
+	Version string                `json:"version,omitempty"`
+	Rules   []ReportingDescriptor `json:"rules,omitempty"`
+}
+
+// ReportingDescriptor describes a rule, i.e. a vulnerability or a check.
+type ReportingDescriptor struct {
+	ID                   string                  `json:"id"`
+	ShortDescription     *MultiformatMessage     `json:"shortDescription,omitempty"`
+	FullDescription      *MultiformatMessage     `json:"fullDescription,omitempty"`
+	HelpURI              string                  `json:"helpUri,omitempty"`
+	Help                 *MultiformatMessage     `json:"help,omitempty"`
+	DefaultConfiguration *ReportingConfiguration `json:"defaultConfiguration,omitempty"`
+	Properties           map[string]interface{}  `json:"properties,omitempty"`
+}
+
+// ReportingConfiguration holds the default configuration of a rule.
+type ReportingConfiguration struct {
+	Level Level `json:"level"`
+}
+
+// MultiformatMessage is a message string with optional Markdown rendering.
+type MultiformatMessage struct {
+	Text     string `json:"text"`
+	Markdown string `json:"markdown,omitempty"`
+}
+
+// Result is a single finding of a rule.
+type Result struct {
+	RuleID    string     `json:"ruleId"`
+	RuleIndex int        `json:"ruleIndex"`
+	Level     Level      `json:"level"`
+	Message   Message    `json:"message"`
+	Locations []Location `json:"locations"`
+}
+
+// Message is a plain text message of a Result.
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Location is where a Result was found.
+type Location struct {
+	PhysicalLocation *PhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []LogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+// PhysicalLocation identifies an artifact, e.g. a container image.
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+}
+
+// ArtifactLocation is the URI reference of an artifact.
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// LogicalLocation identifies a logical entity, e.g. a Kubernetes workload or
+// one of its containers.
+type LogicalLocation struct {
+	Name               string `json:"name,omitempty"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+// Write writes the given log to the specified writer as indented JSON.
+func Write(w io.Writer, log Log) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
@@ -0,0 +1,29 @@
+package starboard
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CountChange is the change of a count of findings of a report.
+type CountChange struct {
+	Name     string
+	Previous int
+	Current  int
+}
+
+// Changelog returns the value of the AnnotationChangelog annotation of a report
+// whose findings changed in the specified generation. Counts which did not
+// change are omitted.
+func Changelog(generation int64, changes ...CountChange) string {
+	var changed []string
+	for _, change := range changes {
+		if change.Previous != change.Current {
+			changed = append(changed, fmt.Sprintf("%s %d -> %d", change.Name, change.Previous, change.Current))
+		}
+	}
+	if len(changed) == 0 {
+		return fmt.Sprintf("generation %d: findings changed, counts unchanged", generation)
+	}
+	return fmt.Sprintf("generation %d: %s", generation, strings.Join(changed, ", "))
+}
@@ -0,0 +1,38 @@
+package starboard_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangelog(t *testing.T) {
+	testCases := []struct {
+		name     string
+		changes  []starboard.CountChange
+		expected string
+	}{
+		{
+			name: "Should describe changed counts",
+			changes: []starboard.CountChange{
+				{Name: "criticalCount", Previous: 2, Current: 3},
+				{Name: "highCount", Previous: 1, Current: 1},
+				{Name: "lowCount", Previous: 4, Current: 0},
+			},
+			expected: "generation 5: criticalCount 2 -> 3, lowCount 4 -> 0",
+		},
+		{
+			name: "Should describe change without changed counts",
+			changes: []starboard.CountChange{
+				{Name: "criticalCount", Previous: 2, Current: 2},
+			},
+			expected: "generation 5: findings changed, counts unchanged",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, starboard.Changelog(5, tc.changes...))
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	embedded "github.com/aquasecurity/starboard"
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
@@ -20,10 +21,12 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
 )
 
 func NewScheme() *runtime.Scheme {
@@ -58,12 +61,37 @@ const (
 	KeyVulnerabilityScansInSameNamespace = "vulnerabilityReports.scanJobsInSameNamespace"
 	keyConfigAuditReportsScanner         = "configAuditReports.scanner"
 	keyKubeBenchImageRef                 = "kube-bench.imageRef"
+	keyKubeBenchNodeSkipSelector         = "kube-bench.nodeSkipSelector"
 	keyKubeHunterImageRef                = "kube-hunter.imageRef"
 	keyKubeHunterQuick                   = "kube-hunter.quick"
+	keyKubeHunterMaxFindings             = "kube-hunter.maxFindings"
+	keyKubeHunterSecurityContext         = "kube-hunter.securityContext"
+	keyKubeHunterPodSecurityContext      = "kube-hunter.podSecurityContext"
+	keyKubeHunterRequestsCPU             = "kube-hunter.resources.requests.cpu"
+	keyKubeHunterRequestsMemory          = "kube-hunter.resources.requests.memory"
+	keyKubeHunterLimitsCPU               = "kube-hunter.resources.limits.cpu"
+	keyKubeHunterLimitsMemory            = "kube-hunter.resources.limits.memory"
 	keyScanJobTolerations                = "scanJob.tolerations"
 	keyScanJobAnnotations                = "scanJob.annotations"
 	keyScanJobPodTemplateLabels          = "scanJob.podTemplateLabels"
+	keyScanJobAvoidWorkloadNodes         = "scanJob.avoidWorkloadNodes"
+	keyScanJobTTLSecondsAfterFinished    = "scanJob.ttlSecondsAfterFinished"
+	keyScanJobsSuspended                 = "scanJob.suspended"
+	keyScanJobRequestsCPU                = "scanJob.resources.requests.cpu"
+	keyScanJobRequestsMemory             = "scanJob.resources.requests.memory"
+	keyScanJobRequestsEphemeralStorage   = "scanJob.resources.requests.ephemeral-storage"
+	keyScanJobLimitsCPU                  = "scanJob.resources.limits.cpu"
+	keyScanJobLimitsMemory               = "scanJob.resources.limits.memory"
+	keyScanJobLimitsEphemeralStorage     = "scanJob.resources.limits.ephemeral-storage"
 	keyComplianceFailEntriesLimit        = "compliance.failEntriesLimit"
+	keyComplianceScannerTimeout          = "compliance.scannerTimeout"
+	keyComplianceEventsInterval          = "compliance.eventsInterval"
+	keyComplianceHistoryLimit            = "compliance.historyLimit"
+	keyComplianceSeparateWarnings        = "compliance.separateWarnings"
+	keyComplianceListWorkers             = "compliance.listWorkers"
+	keyConfigAuditMaxMessageLength       = "configAudit.maxMessageLength"
+	keyConfigAuditStoreFullMessages      = "configAudit.storeFullMessages"
+	keyConfigAuditIgnoredAnnotations     = "configAudit.ignoredAnnotations"
 )
 
 // ConfigData holds Starboard configuration settings as a set of key-value
@@ -108,6 +136,12 @@ func (c ConfigData) VulnerabilityScanJobsInSameNamespace() bool {
 	return value == "true"
 }
 
+// ScanJobAvoidWorkloadNodes returns true if scan jobs should prefer nodes
+// which do not run pods of the scanned workload.
+func (c ConfigData) ScanJobAvoidWorkloadNodes() bool {
+	return c[keyScanJobAvoidWorkloadNodes] == "true"
+}
+
 func (c ConfigData) GetConfigAuditReportsScanner() (Scanner, error) {
 	var ok bool
 	var value string
@@ -117,6 +151,62 @@ func (c ConfigData) GetConfigAuditReportsScanner() (Scanner, error) {
 	return Scanner(value), nil
 }
 
+// defaultConfigAuditMaxMessageLength is the maximum number of characters of
+// check messages in configuration audit reports unless configured otherwise.
+const defaultConfigAuditMaxMessageLength = 2000
+
+// GetConfigAuditMaxMessageLength returns the maximum number of characters of
+// check messages kept in configuration audit reports. Zero means that messages
+// are not truncated.
+func (c ConfigData) GetConfigAuditMaxMessageLength() (int, error) {
+	val, ok := c[keyConfigAuditMaxMessageLength]
+	if !ok || strings.TrimSpace(val) == "" {
+		return defaultConfigAuditMaxMessageLength, nil
+	}
+	maxLength, err := strconv.Atoi(val)
+	if err != nil || maxLength < 0 {
+		return 0, fmt.Errorf("property %s must be a non-negative integer, got %q", keyConfigAuditMaxMessageLength, val)
+	}
+	return maxLength, nil
+}
+
+// ConfigAuditStoreFullMessages returns true if full messages of truncated
+// checks should be stored in a Secret referenced from the configuration audit
+// report.
+func (c ConfigData) ConfigAuditStoreFullMessages() bool {
+	return c[keyConfigAuditStoreFullMessages] == "true"
+}
+
+// defaultConfigAuditIgnoredAnnotations are the glob patterns of annotations
+// ignored by configuration audits unless configured otherwise. Such
+// annotations are rewritten by kubectl, GitOps tools, and the deployment
+// controller, e.g. whenever a Deployment is scaled, without changing the
+// semantics of resources.
+var defaultConfigAuditIgnoredAnnotations = []string{
+	"kubectl.kubernetes.io/*",
+	"argocd.argoproj.io/*",
+	"deployment.kubernetes.io/*",
+}
+
+// GetConfigAuditIgnoredAnnotations returns the glob patterns of annotations
+// whose changes do not trigger configuration audits of resources. An empty
+// value means that no annotations are ignored.
+func (c ConfigData) GetConfigAuditIgnoredAnnotations() []string {
+	value, ok := c[keyConfigAuditIgnoredAnnotations]
+	if !ok {
+		return defaultConfigAuditIgnoredAnnotations
+	}
+	var patterns []string
+	for _, pattern := range strings.Split(value, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns
+}
+
 func (c ConfigData) GetScanJobTolerations() ([]corev1.Toleration, error) {
 	var scanJobTolerations []corev1.Toleration
 	if c[keyScanJobTolerations] == "" {
@@ -165,10 +255,98 @@ func (c ConfigData) GetScanJobPodTemplateLabels() (labels.Set, error) {
 	return scanJobPodTemplateLabelsMap, nil
 }
 
+// GetScanJobTTLSecondsAfterFinished returns the TTLSecondsAfterFinished of
+// scan jobs or nil if it's not set.
+func (c ConfigData) GetScanJobTTLSecondsAfterFinished() (*int32, error) {
+	return parseTTLSecondsAfterFinished(c[keyScanJobTTLSecondsAfterFinished])
+}
+
+// GetScanJobsSuspended returns whether scan jobs are suspended or nil if it's
+// not set.
+func (c ConfigData) GetScanJobsSuspended() (*bool, error) {
+	value, ok := c[keyScanJobsSuspended]
+	if !ok || strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	if value != "false" && value != "true" {
+		return nil, fmt.Errorf("property %s must be either \"false\" or \"true\", got %q", keyScanJobsSuspended, value)
+	}
+	return pointer.Bool(value == "true"), nil
+}
+
+// SetScanJobsSuspended sets whether scan jobs are suspended.
+func (c ConfigData) SetScanJobsSuspended(suspended bool) {
+	c[keyScanJobsSuspended] = strconv.FormatBool(suspended)
+}
+
+// GetScanJobDefaultResources returns resource requests and limits of
+// containers and init containers of scan jobs, which are not set by scanners.
+// A resource whose key is set to an empty value is left unset.
+func (c ConfigData) GetScanJobDefaultResources() (corev1.ResourceRequirements, error) {
+	requirements := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{},
+	}
+	settings := []struct {
+		key          string
+		defaultValue string
+		resourceList corev1.ResourceList
+		resourceName corev1.ResourceName
+	}{
+		{key: keyScanJobRequestsCPU, defaultValue: "50m", resourceList: requirements.Requests, resourceName: corev1.ResourceCPU},
+		{key: keyScanJobRequestsMemory, defaultValue: "50M", resourceList: requirements.Requests, resourceName: corev1.ResourceMemory},
+		{key: keyScanJobRequestsEphemeralStorage, defaultValue: "100Mi", resourceList: requirements.Requests, resourceName: corev1.ResourceEphemeralStorage},
+		{key: keyScanJobLimitsCPU, defaultValue: "500m", resourceList: requirements.Limits, resourceName: corev1.ResourceCPU},
+		{key: keyScanJobLimitsMemory, defaultValue: "500M", resourceList: requirements.Limits, resourceName: corev1.ResourceMemory},
+		{key: keyScanJobLimitsEphemeralStorage, defaultValue: "2Gi", resourceList: requirements.Limits, resourceName: corev1.ResourceEphemeralStorage},
+	}
+	for _, setting := range settings {
+		value, found := c[setting.key]
+		if !found {
+			value = setting.defaultValue
+		}
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return requirements, fmt.Errorf("parsing resource definition %s: %s %w", setting.key, value, err)
+		}
+		setting.resourceList[setting.resourceName] = quantity
+	}
+	return requirements, nil
+}
+
+func parseTTLSecondsAfterFinished(value string) (*int32, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	ttl, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || ttl < 0 {
+		return nil, fmt.Errorf("parsing incorrectly formatted %s: %s", keyScanJobTTLSecondsAfterFinished, value)
+	}
+	return pointer.Int32(int32(ttl)), nil
+}
+
 func (c ConfigData) GetKubeBenchImageRef() (string, error) {
 	return c.GetRequiredData(keyKubeBenchImageRef)
 }
 
+// GetKubeBenchNodeSkipSelector returns the label selector of nodes which are
+// not eligible for CIS Kubernetes Benchmark checks, or labels.Nothing if it's
+// not configured.
+func (c ConfigData) GetKubeBenchNodeSkipSelector() (labels.Selector, error) {
+	val, ok := c[keyKubeBenchNodeSkipSelector]
+	if !ok || strings.TrimSpace(val) == "" {
+		return labels.Nothing(), nil
+	}
+	selector, err := labels.Parse(val)
+	if err != nil {
+		return nil, fmt.Errorf("parsing incorrectly formatted %s: %w", keyKubeBenchNodeSkipSelector, err)
+	}
+	return selector, nil
+}
+
 func (c ConfigData) GetKubeHunterImageRef() (string, error) {
 	return c.GetRequiredData(keyKubeHunterImageRef)
 }
@@ -184,6 +362,88 @@ func (c ConfigData) GetKubeHunterQuick() (bool, error) {
 	return val == "true", nil
 }
 
+// GetKubeHunterMaxFindings returns the maximum number of findings kept in a
+// KubeHunterReport. Zero means that findings are not truncated.
+func (c ConfigData) GetKubeHunterMaxFindings() (int, error) {
+	val, ok := c[keyKubeHunterMaxFindings]
+	if !ok || strings.TrimSpace(val) == "" {
+		return 0, nil
+	}
+	maxFindings, err := strconv.Atoi(val)
+	if err != nil || maxFindings < 0 {
+		return 0, fmt.Errorf("property %s must be a non-negative integer, got %q", keyKubeHunterMaxFindings, val)
+	}
+	return maxFindings, nil
+}
+
+// GetKubeHunterResourceRequirements returns ResourceRequirements of the
+// kube-hunter container. Settings which are not configured fall back to
+// the defaults.
+func (c ConfigData) GetKubeHunterResourceRequirements() (corev1.ResourceRequirements, error) {
+	requirements := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("300m"),
+			corev1.ResourceMemory: resource.MustParse("400M"),
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("50m"),
+			corev1.ResourceMemory: resource.MustParse("100M"),
+		},
+	}
+	settings := []struct {
+		key          string
+		resourceList corev1.ResourceList
+		resourceName corev1.ResourceName
+	}{
+		{key: keyKubeHunterRequestsCPU, resourceList: requirements.Requests, resourceName: corev1.ResourceCPU},
+		{key: keyKubeHunterRequestsMemory, resourceList: requirements.Requests, resourceName: corev1.ResourceMemory},
+		{key: keyKubeHunterLimitsCPU, resourceList: requirements.Limits, resourceName: corev1.ResourceCPU},
+		{key: keyKubeHunterLimitsMemory, resourceList: requirements.Limits, resourceName: corev1.ResourceMemory},
+	}
+	for _, setting := range settings {
+		value, found := c[setting.key]
+		if !found {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return requirements, fmt.Errorf("parsing resource definition %s: %s %w", setting.key, value, err)
+		}
+		setting.resourceList[setting.resourceName] = quantity
+	}
+	return requirements, nil
+}
+
+// GetKubeHunterSecurityContext returns the SecurityContext of the kube-hunter
+// container or nil if it's not configured.
+func (c ConfigData) GetKubeHunterSecurityContext() (*corev1.SecurityContext, error) {
+	value, found := c[keyKubeHunterSecurityContext]
+	if !found || strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var securityContext corev1.SecurityContext
+	err := json.Unmarshal([]byte(value), &securityContext)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", keyKubeHunterSecurityContext, err)
+	}
+	return &securityContext, nil
+}
+
+// GetKubeHunterPodSecurityContext returns the PodSecurityContext of the
+// kube-hunter pod or nil if it's not configured.
+func (c ConfigData) GetKubeHunterPodSecurityContext() (*corev1.PodSecurityContext, error) {
+	value, found := c[keyKubeHunterPodSecurityContext]
+	if !found || strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var securityContext corev1.PodSecurityContext
+	err := json.Unmarshal([]byte(value), &securityContext)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", keyKubeHunterPodSecurityContext, err)
+	}
+	return &securityContext, nil
+}
+
 func (c ConfigData) GetRequiredData(key string) (string, error) {
 	var ok bool
 	var value string
@@ -212,6 +472,9 @@ func GetVersionFromImageRef(imageRef string) (string, error) {
 	return version, nil
 }
 
+// ComplianceFailEntriesLimit returns the maximum number of failed entries
+// stored per check in compliance detail reports. Entries beyond the limit are
+// truncated, so that reports of large clusters fit the size limit of objects.
 func (c ConfigData) ComplianceFailEntriesLimit() int {
 	const defaultValue = 10
 	var value string
@@ -220,12 +483,81 @@ func (c ConfigData) ComplianceFailEntriesLimit() int {
 		return defaultValue
 	}
 	intVal, err := strconv.Atoi(value)
-	if err != nil {
+	if err != nil || intVal < 0 {
 		return defaultValue
 	}
 	return intVal
 }
 
+// ComplianceScannerTimeout returns the maximum duration of reading results of
+// a single scanner while generating compliance reports. Zero, the default,
+// means that reading results never times out.
+func (c ConfigData) ComplianceScannerTimeout() time.Duration {
+	value, ok := c[keyComplianceScannerTimeout]
+	if !ok {
+		return 0
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		return 0
+	}
+	return timeout
+}
+
+// ComplianceEventsInterval returns the minimum time between events of the
+// same reason recorded for a compliance report, or for a control of the
+// report. It defaults to one hour.
+func (c ConfigData) ComplianceEventsInterval() time.Duration {
+	const defaultValue = time.Hour
+	value, ok := c[keyComplianceEventsInterval]
+	if !ok {
+		return defaultValue
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < 0 {
+		return defaultValue
+	}
+	return interval
+}
+
+// ComplianceHistoryLimit returns the maximum number of snapshots of previous
+// generations kept in the history of a compliance report. Zero, the default,
+// means that no history is kept.
+func (c ConfigData) ComplianceHistoryLimit() int {
+	value, ok := c[keyComplianceHistoryLimit]
+	if !ok {
+		return 0
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		return 0
+	}
+	return limit
+}
+
+// ComplianceSeparateWarnings returns true if results of compliance checks
+// with the WARN status are counted separately from passed results, so that
+// passed, failed and warned results add up to all results.
+func (c ConfigData) ComplianceSeparateWarnings() bool {
+	return c[keyComplianceSeparateWarnings] == "true"
+}
+
+// ComplianceListWorkers returns the maximum number of scanner reports of
+// distinct kinds of resources listed concurrently while generating compliance
+// reports. It defaults to 5.
+func (c ConfigData) ComplianceListWorkers() int {
+	const defaultValue = 5
+	value, ok := c[keyComplianceListWorkers]
+	if !ok {
+		return defaultValue
+	}
+	workers, err := strconv.Atoi(value)
+	if err != nil || workers < 1 {
+		return defaultValue
+	}
+	return workers
+}
+
 // NewConfigManager constructs a new ConfigManager that is using kubernetes.Interface
 // to manage ConfigData backed by the ConfigMap stored in the specified namespace.
 func NewConfigManager(client kubernetes.Interface, namespace string) ConfigManager {
@@ -3,6 +3,7 @@ package starboard_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/onsi/gomega"
@@ -10,9 +11,11 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
 )
 
 func TestConfigData_GetVulnerabilityReportsScanner(t *testing.T) {
@@ -217,6 +220,95 @@ func TestConfigData_GetScanJobAnnotations(t *testing.T) {
 	}
 }
 
+func TestConfigData_GetScanJobTTLSecondsAfterFinished(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      starboard.ConfigData
+		expected    *int32
+		expectError string
+	}{
+		{
+			name: "scan job TTL can be fetched successfully",
+			config: starboard.ConfigData{
+				"scanJob.ttlSecondsAfterFinished": "600",
+			},
+			expected: pointer.Int32(600),
+		},
+		{
+			name:     "gracefully deal with unprovided TTL",
+			config:   starboard.ConfigData{},
+			expected: nil,
+		},
+		{
+			name: "raise an error on being provided with TTL in wrong format",
+			config: starboard.ConfigData{
+				"scanJob.ttlSecondsAfterFinished": "10m",
+			},
+			expectError: "parsing incorrectly formatted scanJob.ttlSecondsAfterFinished: 10m",
+		},
+		{
+			name: "raise an error on being provided with negative TTL",
+			config: starboard.ConfigData{
+				"scanJob.ttlSecondsAfterFinished": "-1",
+			},
+			expectError: "parsing incorrectly formatted scanJob.ttlSecondsAfterFinished: -1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ttl, err := tc.config.GetScanJobTTLSecondsAfterFinished()
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError, tc.name)
+			} else {
+				assert.NoError(t, err, tc.name)
+				assert.Equal(t, tc.expected, ttl, tc.name)
+			}
+		})
+	}
+}
+
+func TestConfigData_GetScanJobsSuspended(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      starboard.ConfigData
+		expected    *bool
+		expectError string
+	}{
+		{
+			name: "scan jobs suspension can be fetched successfully",
+			config: starboard.ConfigData{
+				"scanJob.suspended": "true",
+			},
+			expected: pointer.Bool(true),
+		},
+		{
+			name:     "gracefully deal with unprovided suspension",
+			config:   starboard.ConfigData{},
+			expected: nil,
+		},
+		{
+			name: "raise an error on being provided with suspension in wrong format",
+			config: starboard.ConfigData{
+				"scanJob.suspended": "yes",
+			},
+			expectError: "property scanJob.suspended must be either \"false\" or \"true\", got \"yes\"",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			suspended, err := tc.config.GetScanJobsSuspended()
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError, tc.name)
+			} else {
+				assert.NoError(t, err, tc.name)
+				assert.Equal(t, tc.expected, suspended, tc.name)
+			}
+		})
+	}
+}
+
 func TestConfigData_GetScanJobPodTemplateLabels(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -269,6 +361,56 @@ func TestConfigData_GetScanJobPodTemplateLabels(t *testing.T) {
 		})
 	}
 }
+func TestConfigData_GetKubeBenchNodeSkipSelector(t *testing.T) {
+	testCases := []struct {
+		name        string
+		configData  starboard.ConfigData
+		nodeLabels  labels.Set
+		wantMatches bool
+		wantErr     string
+	}{
+		{
+			name:       "Should match no nodes by default",
+			configData: starboard.ConfigData{},
+			nodeLabels: labels.Set{"pool": "gpu"},
+		},
+		{
+			name: "Should match nodes with selected labels",
+			configData: starboard.ConfigData{
+				"kube-bench.nodeSkipSelector": "pool in (gpu, spot)",
+			},
+			nodeLabels:  labels.Set{"pool": "gpu"},
+			wantMatches: true,
+		},
+		{
+			name: "Should not match nodes without selected labels",
+			configData: starboard.ConfigData{
+				"kube-bench.nodeSkipSelector": "pool in (gpu, spot)",
+			},
+			nodeLabels: labels.Set{"pool": "default"},
+		},
+		{
+			name: "Should return error when selector is invalid",
+			configData: starboard.ConfigData{
+				"kube-bench.nodeSkipSelector": "pool in gpu",
+			},
+			wantErr: "parsing incorrectly formatted kube-bench.nodeSkipSelector",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			selector, err := tc.configData.GetKubeBenchNodeSkipSelector()
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantMatches, selector.Matches(tc.nodeLabels))
+		})
+	}
+}
+
 func TestConfigData_GetComplianceFailEntriesLimit(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -287,6 +429,13 @@ func TestConfigData_GetComplianceFailEntriesLimit(t *testing.T) {
 			},
 			want: 15,
 		},
+		{
+			name: "Should return compliance fail entries limit default value for negative limit",
+			configData: starboard.ConfigData{
+				"compliance.failEntriesLimit": "-1",
+			},
+			want: 10,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -296,6 +445,72 @@ func TestConfigData_GetComplianceFailEntriesLimit(t *testing.T) {
 	}
 }
 
+func TestConfigData_ComplianceScannerTimeout(t *testing.T) {
+	testCases := []struct {
+		name       string
+		configData starboard.ConfigData
+		want       time.Duration
+	}{
+		{
+			name:       "Should return no timeout by default",
+			configData: starboard.ConfigData{},
+			want:       0,
+		},
+		{
+			name: "Should return compliance scanner timeout from config data",
+			configData: starboard.ConfigData{
+				"compliance.scannerTimeout": "30s",
+			},
+			want: 30 * time.Second,
+		},
+		{
+			name: "Should return no timeout for invalid duration",
+			configData: starboard.ConfigData{
+				"compliance.scannerTimeout": "soon",
+			},
+			want: 0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.configData.ComplianceScannerTimeout())
+		})
+	}
+}
+
+func TestConfigData_ComplianceEventsInterval(t *testing.T) {
+	testCases := []struct {
+		name       string
+		configData starboard.ConfigData
+		want       time.Duration
+	}{
+		{
+			name:       "Should return one hour by default",
+			configData: starboard.ConfigData{},
+			want:       time.Hour,
+		},
+		{
+			name: "Should return compliance events interval from config data",
+			configData: starboard.ConfigData{
+				"compliance.eventsInterval": "15m",
+			},
+			want: 15 * time.Minute,
+		},
+		{
+			name: "Should return default interval for invalid duration",
+			configData: starboard.ConfigData{
+				"compliance.eventsInterval": "hourly",
+			},
+			want: time.Hour,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.configData.ComplianceEventsInterval())
+		})
+	}
+}
+
 func TestConfigData_GetKubeBenchImageRef(t *testing.T) {
 	testCases := []struct {
 		name             string
@@ -410,6 +625,284 @@ func TestConfigData_GetKubeHunterQuick(t *testing.T) {
 	}
 }
 
+func TestConfigData_GetKubeHunterMaxFindings(t *testing.T) {
+	testCases := []struct {
+		name                string
+		configData          starboard.ConfigData
+		expectedError       string
+		expectedMaxFindings int
+	}{
+		{
+			name:                "Should return zero when parameter is not set",
+			configData:          starboard.ConfigData{},
+			expectedMaxFindings: 0,
+		},
+		{
+			name: "Should return error when parameter is not a number",
+			configData: starboard.ConfigData{
+				"kube-hunter.maxFindings": "many",
+			},
+			expectedError: "property kube-hunter.maxFindings must be a non-negative integer, got \"many\"",
+		},
+		{
+			name: "Should return error when parameter is negative",
+			configData: starboard.ConfigData{
+				"kube-hunter.maxFindings": "-1",
+			},
+			expectedError: "property kube-hunter.maxFindings must be a non-negative integer, got \"-1\"",
+		},
+		{
+			name: "Should return max findings from config data",
+			configData: starboard.ConfigData{
+				"kube-hunter.maxFindings": "100",
+			},
+			expectedMaxFindings: 100,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			maxFindings, err := tc.configData.GetKubeHunterMaxFindings()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedMaxFindings, maxFindings)
+			}
+		})
+	}
+}
+
+func TestConfigData_GetConfigAuditMaxMessageLength(t *testing.T) {
+	testCases := []struct {
+		name              string
+		configData        starboard.ConfigData
+		expectedError     string
+		expectedMaxLength int
+	}{
+		{
+			name:              "Should return default when parameter is not set",
+			configData:        starboard.ConfigData{},
+			expectedMaxLength: 2000,
+		},
+		{
+			name: "Should return error when parameter is not a number",
+			configData: starboard.ConfigData{
+				"configAudit.maxMessageLength": "long",
+			},
+			expectedError: "property configAudit.maxMessageLength must be a non-negative integer, got \"long\"",
+		},
+		{
+			name: "Should return zero when truncation is disabled",
+			configData: starboard.ConfigData{
+				"configAudit.maxMessageLength": "0",
+			},
+			expectedMaxLength: 0,
+		},
+		{
+			name: "Should return max message length from config data",
+			configData: starboard.ConfigData{
+				"configAudit.maxMessageLength": "512",
+			},
+			expectedMaxLength: 512,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			maxLength, err := tc.configData.GetConfigAuditMaxMessageLength()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedMaxLength, maxLength)
+			}
+		})
+	}
+}
+
+func TestConfigData_GetConfigAuditIgnoredAnnotations(t *testing.T) {
+	testCases := []struct {
+		name             string
+		configData       starboard.ConfigData
+		expectedPatterns []string
+	}{
+		{
+			name:             "Should return default when parameter is not set",
+			configData:       starboard.ConfigData{},
+			expectedPatterns: []string{"kubectl.kubernetes.io/*", "argocd.argoproj.io/*", "deployment.kubernetes.io/*"},
+		},
+		{
+			name: "Should return no patterns when parameter is empty",
+			configData: starboard.ConfigData{
+				"configAudit.ignoredAnnotations": "",
+			},
+		},
+		{
+			name: "Should return patterns from config data",
+			configData: starboard.ConfigData{
+				"configAudit.ignoredAnnotations": " fluxcd.io/*, ,deployment.kubernetes.io/revision",
+			},
+			expectedPatterns: []string{"fluxcd.io/*", "deployment.kubernetes.io/revision"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedPatterns, tc.configData.GetConfigAuditIgnoredAnnotations())
+		})
+	}
+}
+
+func TestConfigData_GetKubeHunterResourceRequirements(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		configData           starboard.ConfigData
+		expectedError        string
+		expectedRequirements corev1.ResourceRequirements
+	}{
+		{
+			name:       "Should return default requirements",
+			configData: starboard.ConfigData{},
+			expectedRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("50m"),
+					corev1.ResourceMemory: resource.MustParse("100M"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("300m"),
+					corev1.ResourceMemory: resource.MustParse("400M"),
+				},
+			},
+		},
+		{
+			name: "Should override default requirements",
+			configData: starboard.ConfigData{
+				"kube-hunter.resources.requests.cpu":  "100m",
+				"kube-hunter.resources.limits.memory": "1Gi",
+			},
+			expectedRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+					corev1.ResourceMemory: resource.MustParse("100M"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("300m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+		},
+		{
+			name: "Should return error when quantity cannot be parsed",
+			configData: starboard.ConfigData{
+				"kube-hunter.resources.limits.cpu": "roughly-one",
+			},
+			expectedError: "parsing resource definition kube-hunter.resources.limits.cpu: roughly-one quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requirements, err := tc.configData.GetKubeHunterResourceRequirements()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedRequirements, requirements)
+			}
+		})
+	}
+}
+
+func TestConfigData_GetScanJobDefaultResources(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		configData           starboard.ConfigData
+		expectedError        string
+		expectedRequirements corev1.ResourceRequirements
+	}{
+		{
+			name:       "Should return default requirements",
+			configData: starboard.ConfigData{},
+			expectedRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:              resource.MustParse("50m"),
+					corev1.ResourceMemory:           resource.MustParse("50M"),
+					corev1.ResourceEphemeralStorage: resource.MustParse("100Mi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:              resource.MustParse("500m"),
+					corev1.ResourceMemory:           resource.MustParse("500M"),
+					corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+				},
+			},
+		},
+		{
+			name: "Should override default requirements and leave empty ones unset",
+			configData: starboard.ConfigData{
+				"scanJob.resources.requests.ephemeral-storage": "1Gi",
+				"scanJob.resources.limits.cpu":                 "",
+			},
+			expectedRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:              resource.MustParse("50m"),
+					corev1.ResourceMemory:           resource.MustParse("50M"),
+					corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory:           resource.MustParse("500M"),
+					corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+				},
+			},
+		},
+		{
+			name: "Should return error when quantity cannot be parsed",
+			configData: starboard.ConfigData{
+				"scanJob.resources.limits.ephemeral-storage": "plenty",
+			},
+			expectedError: "parsing resource definition scanJob.resources.limits.ephemeral-storage: plenty quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requirements, err := tc.configData.GetScanJobDefaultResources()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedRequirements, requirements)
+			}
+		})
+	}
+}
+
+func TestConfigData_GetKubeHunterSecurityContext(t *testing.T) {
+	t.Run("Should return nil when parameter is not set", func(t *testing.T) {
+		securityContext, err := starboard.ConfigData{}.GetKubeHunterSecurityContext()
+		require.NoError(t, err)
+		assert.Nil(t, securityContext)
+	})
+
+	t.Run("Should return security context from config data", func(t *testing.T) {
+		securityContext, err := starboard.ConfigData{
+			"kube-hunter.securityContext": `{"runAsNonRoot":true,"readOnlyRootFilesystem":true}`,
+		}.GetKubeHunterSecurityContext()
+		require.NoError(t, err)
+		assert.Equal(t, &corev1.SecurityContext{
+			RunAsNonRoot:           pointer.BoolPtr(true),
+			ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+		}, securityContext)
+	})
+
+	t.Run("Should return error when security context is not valid JSON", func(t *testing.T) {
+		_, err := starboard.ConfigData{
+			"kube-hunter.podSecurityContext": `{runAsNonRoot`,
+		}.GetKubeHunterPodSecurityContext()
+		require.Error(t, err)
+	})
+}
+
 func TestGetVersionFromImageRef(t *testing.T) {
 	testCases := []struct {
 		imageRef        string
@@ -607,3 +1100,109 @@ func TestConfigManager_Delete(t *testing.T) {
 		assert.True(t, errors.IsNotFound(err))
 	})
 }
+
+func TestConfigData_ComplianceHistoryLimit(t *testing.T) {
+	testCases := []struct {
+		name       string
+		configData starboard.ConfigData
+		want       int
+	}{
+		{
+			name:       "Should return no history by default",
+			configData: starboard.ConfigData{},
+			want:       0,
+		},
+		{
+			name: "Should return compliance history limit from config data",
+			configData: starboard.ConfigData{
+				"compliance.historyLimit": "30",
+			},
+			want: 30,
+		},
+		{
+			name: "Should return no history for invalid limit",
+			configData: starboard.ConfigData{
+				"compliance.historyLimit": "-1",
+			},
+			want: 0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.configData.ComplianceHistoryLimit())
+		})
+	}
+}
+
+func TestConfigData_ComplianceSeparateWarnings(t *testing.T) {
+	testCases := []struct {
+		name       string
+		configData starboard.ConfigData
+		want       bool
+	}{
+		{
+			name:       "Should count warnings as passes by default",
+			configData: starboard.ConfigData{},
+			want:       false,
+		},
+		{
+			name: "Should count warnings separately",
+			configData: starboard.ConfigData{
+				"compliance.separateWarnings": "true",
+			},
+			want: true,
+		},
+		{
+			name: "Should count warnings as passes for invalid value",
+			configData: starboard.ConfigData{
+				"compliance.separateWarnings": "yes",
+			},
+			want: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.configData.ComplianceSeparateWarnings())
+		})
+	}
+}
+
+func TestConfigData_ComplianceListWorkers(t *testing.T) {
+	testCases := []struct {
+		name       string
+		configData starboard.ConfigData
+		want       int
+	}{
+		{
+			name:       "Should return 5 workers by default",
+			configData: starboard.ConfigData{},
+			want:       5,
+		},
+		{
+			name: "Should return compliance list workers from config data",
+			configData: starboard.ConfigData{
+				"compliance.listWorkers": "20",
+			},
+			want: 20,
+		},
+		{
+			name: "Should return default for zero workers",
+			configData: starboard.ConfigData{
+				"compliance.listWorkers": "0",
+			},
+			want: 5,
+		},
+		{
+			name: "Should return default for invalid number",
+			configData: starboard.ConfigData{
+				"compliance.listWorkers": "many",
+			},
+			want: 5,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.configData.ComplianceListWorkers())
+		})
+	}
+}
@@ -36,6 +36,23 @@ const (
 	LabelContainerName     = "starboard.container.name"
 	LabelResourceSpecHash  = "resource-spec-hash"
 	LabelPluginConfigHash  = "plugin-config-hash"
+	LabelScanJobName       = "starboard.scan-job.name"
+	// LabelNodeGroup is the label of vulnerability reports and scan jobs of a
+	// DaemonSet scanned per node group. Its value is the value of the node
+	// group label shared by nodes of the group.
+	LabelNodeGroup = "starboard.node-group"
+
+	// LabelComplianceReportName is the label of namespaced ComplianceReports
+	// whose value is the name of the ClusterComplianceReport they belong to.
+	LabelComplianceReportName = "starboard.compliance-report.name"
+
+	// LabelComplianceSpec is the label of ConfigMaps in the operator namespace
+	// which hold custom compliance specs, set to "true".
+	LabelComplianceSpec = "starboard.compliance.spec"
+	// LabelComplianceSpecConfigMap is the label of ClusterComplianceReports
+	// created from custom compliance specs whose value is the name of the
+	// ConfigMap holding the spec.
+	LabelComplianceSpecConfigMap = "starboard.compliance-spec.configmap"
 
 	LabelConfigAuditReportScanner   = "configAuditReport.scanner"
 	LabelVulnerabilityReportScanner = "vulnerabilityReport.scanner"
@@ -45,6 +62,64 @@ const (
 	AppStarboard         = "starboard"
 )
 
+const (
+	// LabelOrphaned is the label of reports in namespaces which are no longer
+	// targeted by the operator, set to "true" when such reports are kept.
+	LabelOrphaned = "starboard.aquasecurity.github.io/orphaned"
+)
+
 const (
 	AnnotationContainerImages = "starboard.container-images"
+
+	// AnnotationSBOMConfigMap is the annotation of a workload which refers to
+	// a ConfigMap, in the namespace of the workload, that holds a CycloneDX or
+	// SPDX SBOM of the workload. The value is either the name of the ConfigMap,
+	// in which case the SBOM is read from the sbom.json key, or the name and
+	// key separated by a slash, e.g. nginx-sbom/bom.json.
+	AnnotationSBOMConfigMap = "starboard.sbom-configmap"
+
+	// AnnotationScannerImage is the annotation of a scan job which records the
+	// image of the scanner the job was created with. Plugins verify that scan
+	// results were output by this scanner before they're persisted.
+	AnnotationScannerImage = "starboard.scanner-image"
+
+	// AnnotationFullMessagesSecret is the annotation of a configuration audit
+	// report which refers to a Secret, in the namespace/name format, that holds
+	// full messages of truncated checks. The report is valid without the Secret.
+	AnnotationFullMessagesSecret = "starboard.full-messages-secret"
+
+	// AnnotationNodeName is the annotation of a vulnerability report of a node
+	// group, which records the node where the scanned images were resolved.
+	AnnotationNodeName = "starboard.node-name"
+
+	// AnnotationContainerRuntime is the annotation of a vulnerability report
+	// of a node group, which records the container runtime version of the node
+	// where the scanned images were resolved.
+	AnnotationContainerRuntime = "starboard.container-runtime"
+
+	// AnnotationPlatform is the annotation of a scan job which records the
+	// platform, e.g. linux/arm64, of the nodes running the scanned workload.
+	// Images of multi-arch image indexes are scanned for this platform.
+	AnnotationPlatform = "starboard.platform"
+
+	// AnnotationPlatformWarning is the annotation of a vulnerability report of
+	// a DaemonSet running on nodes of different platforms, which was scanned
+	// for the platform of the majority of its pods only.
+	AnnotationPlatformWarning = "starboard.platform-warning"
+
+	// AnnotationSkipContainers is the annotation of a pod template which
+	// lists comma separated names of containers excluded from vulnerability
+	// scans, e.g. sidecars with huge data files.
+	AnnotationSkipContainers = "starboard.aquasecurity.github.io/skip-containers"
+
+	// AnnotationProtected is the annotation of a report which must not be
+	// deleted if set to "true". It's enforced by the validating webhook of
+	// the operator, if enabled, and the operator never deletes such reports
+	// itself.
+	AnnotationProtected = "starboard.aquasecurity.github.io/protected"
+
+	// AnnotationChangelog is the annotation of a vulnerability or compliance
+	// report which describes the latest generation whose findings changed,
+	// e.g. "generation 7: criticalCount 2 -> 3". See Changelog.
+	AnnotationChangelog = "starboard.changelog"
 )
@@ -0,0 +1,29 @@
+package starboard
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ScannerMismatchError is returned by plugins when scan results were not output
+// by the expected scanner, e.g. because a node ran a stale image cached under the
+// tag of a different version. Such results must not be persisted.
+type ScannerMismatchError struct {
+	// Scanner is the name of the scanner, e.g. Trivy.
+	Scanner string
+	// Expected is the image of the expected scanner.
+	Expected string
+	// Reason describes how the scan results differ from the expected ones.
+	Reason string
+}
+
+func (e *ScannerMismatchError) Error() string {
+	return fmt.Sprintf("%s scan results do not match expected scanner %s: %s", e.Scanner, e.Expected, e.Reason)
+}
+
+// IsScannerMismatch returns true if the given error is or wraps a
+// ScannerMismatchError.
+func IsScannerMismatch(err error) bool {
+	var mismatch *ScannerMismatchError
+	return errors.As(err, &mismatch)
+}
@@ -0,0 +1,97 @@
+package starboard
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ImagePolicy restricts images of scanners which scan jobs are launched with,
+// e.g. to comply with a supply chain policy which requires images pinned by
+// digest. The zero value allows any image.
+type ImagePolicy struct {
+	// DigestRequired requires images to be pinned by sha256 digest.
+	DigestRequired bool
+	// AllowedDigests are sha256 digests which images must be pinned by, e.g.
+	// sha256:2d0b1e..., if any. Setting them implies DigestRequired.
+	AllowedDigests []string
+}
+
+// Strict returns true if images must be pinned by digest.
+func (p ImagePolicy) Strict() bool {
+	return p.DigestRequired || len(p.AllowedDigests) > 0
+}
+
+// VerifyImageRef returns an error if the given image reference is not pinned
+// by a sha256 digest, or by an allowed one, in strict mode. Both the digest
+// form, e.g. aquasec/trivy@sha256:2d0b1e..., and the tag and digest form, e.g.
+// aquasec/trivy:0.25.2@sha256:2d0b1e..., are pinned by digest.
+func (p ImagePolicy) VerifyImageRef(imageRef string) error {
+	if !p.Strict() {
+		return nil
+	}
+	digest, err := name.NewDigest(imageRef)
+	if err != nil || !strings.HasPrefix(digest.DigestStr(), "sha256:") {
+		return fmt.Errorf("image %q is not pinned by sha256 digest", imageRef)
+	}
+	if len(p.AllowedDigests) == 0 {
+		return nil
+	}
+	for _, allowed := range p.AllowedDigests {
+		if digest.DigestStr() == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("digest of image %q is not in the allow-list", imageRef)
+}
+
+// VerifyPodSpec verifies images of containers and init containers of the given
+// pod spec of a scan job. Images of the scanned workload, which scan jobs run
+// to scan their file systems, are exempt from the policy.
+func (p ImagePolicy) VerifyPodSpec(spec corev1.PodSpec, workloadImages map[string]bool) error {
+	if !p.Strict() {
+		return nil
+	}
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		if workloadImages[container.Image] {
+			continue
+		}
+		if err := p.VerifyImageRef(container.Image); err != nil {
+			return fmt.Errorf("container %s: %w", container.Name, err)
+		}
+	}
+	return nil
+}
+
+// VerifyPluginImages verifies image references of the plugin, i.e. values of
+// keys of the plugin config such as trivy.imageRef, against the ImagePolicy of
+// the plugin context. It's called when plugins are initialized, so that the
+// operator refuses to start rather than launching scan jobs with images which
+// violate the policy.
+func VerifyPluginImages(ctx PluginContext) error {
+	policy := ctx.GetImagePolicy()
+	if !policy.Strict() {
+		return nil
+	}
+	config, err := ctx.GetConfig()
+	if err != nil {
+		return err
+	}
+	var keys []string
+	for key := range config.Data {
+		if strings.Contains(key, ".imageRef") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := policy.VerifyImageRef(config.Data[key]); err != nil {
+			return fmt.Errorf("%s plugin property %s: %w", ctx.GetName(), key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package starboard_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	trivyDigest   = "sha256:5020dac24a63ef4f24452a0c63ebbfe93a5309e40f6353d1ee8221d2184ee954"
+	polarisDigest = "sha256:8e1c4bd1a9a4b2c5e3d2a1b7e0f9c8d7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1"
+)
+
+func TestImagePolicy_VerifyImageRef(t *testing.T) {
+	testCases := []struct {
+		name          string
+		policy        starboard.ImagePolicy
+		imageRef      string
+		expectedError string
+	}{
+		{
+			name:     "Should allow tag without policy",
+			imageRef: "docker.io/aquasec/trivy:0.25.2",
+		},
+		{
+			name:          "Should reject tag if digest is required",
+			policy:        starboard.ImagePolicy{DigestRequired: true},
+			imageRef:      "docker.io/aquasec/trivy:0.25.2",
+			expectedError: `image "docker.io/aquasec/trivy:0.25.2" is not pinned by sha256 digest`,
+		},
+		{
+			name:          "Should reject image without tag if digest is required",
+			policy:        starboard.ImagePolicy{DigestRequired: true},
+			imageRef:      "aquasec/trivy",
+			expectedError: `image "aquasec/trivy" is not pinned by sha256 digest`,
+		},
+		{
+			name:     "Should allow digest if digest is required",
+			policy:   starboard.ImagePolicy{DigestRequired: true},
+			imageRef: "docker.io/aquasec/trivy@" + trivyDigest,
+		},
+		{
+			name:     "Should allow tag and digest if digest is required",
+			policy:   starboard.ImagePolicy{DigestRequired: true},
+			imageRef: "docker.io/aquasec/trivy:0.25.2@" + trivyDigest,
+		},
+		{
+			name:     "Should allow digest in allow-list",
+			policy:   starboard.ImagePolicy{AllowedDigests: []string{polarisDigest, trivyDigest}},
+			imageRef: "docker.io/aquasec/trivy:0.25.2@" + trivyDigest,
+		},
+		{
+			name:          "Should reject digest which is not in allow-list",
+			policy:        starboard.ImagePolicy{AllowedDigests: []string{polarisDigest}},
+			imageRef:      "docker.io/aquasec/trivy@" + trivyDigest,
+			expectedError: `digest of image "docker.io/aquasec/trivy@` + trivyDigest + `" is not in the allow-list`,
+		},
+		{
+			name:          "Should reject tag if allow-list is set",
+			policy:        starboard.ImagePolicy{AllowedDigests: []string{trivyDigest}},
+			imageRef:      "docker.io/aquasec/trivy:0.25.2",
+			expectedError: `image "docker.io/aquasec/trivy:0.25.2" is not pinned by sha256 digest`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.VerifyImageRef(tc.imageRef)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestImagePolicy_VerifyPodSpec(t *testing.T) {
+	policy := starboard.ImagePolicy{AllowedDigests: []string{trivyDigest}}
+	spec := corev1.PodSpec{
+		InitContainers: []corev1.Container{
+			{Name: "trivy", Image: "docker.io/aquasec/trivy:0.25.2@" + trivyDigest},
+		},
+		Containers: []corev1.Container{
+			{Name: "nginx", Image: "nginx:1.16"},
+		},
+	}
+
+	t.Run("Should allow images of scanned workload", func(t *testing.T) {
+		assert.NoError(t, policy.VerifyPodSpec(spec, map[string]bool{"nginx:1.16": true}))
+	})
+
+	t.Run("Should reject tag-only image of scan job", func(t *testing.T) {
+		err := policy.VerifyPodSpec(spec, nil)
+		assert.EqualError(t, err, `container nginx: image "nginx:1.16" is not pinned by sha256 digest`)
+	})
+}
+
+func TestVerifyPluginImages(t *testing.T) {
+	newPluginContext := func(policy starboard.ImagePolicy, data map[string]string) starboard.PluginContext {
+		return starboard.NewPluginContext().
+			WithName("Aqua").
+			WithNamespace("starboard-system").
+			WithClient(fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "starboard-aqua-config", Namespace: "starboard-system"},
+				Data:       data,
+			}).Build()).
+			WithImagePolicy(policy).
+			Get()
+	}
+
+	t.Run("Should verify all image references of plugin", func(t *testing.T) {
+		err := starboard.VerifyPluginImages(newPluginContext(starboard.ImagePolicy{DigestRequired: true}, map[string]string{
+			"aqua.imageRef":                     "docker.io/aquasec/scanner@" + trivyDigest,
+			"aqua.imageRefStarboardAquaScanner": "docker.io/aquasec/starboard-scanner-aqua:dev",
+			"aqua.serverURL":                    "http://csp-console.aqua:8080",
+		}))
+		assert.EqualError(t, err, `Aqua plugin property aqua.imageRefStarboardAquaScanner: image "docker.io/aquasec/starboard-scanner-aqua:dev" is not pinned by sha256 digest`)
+	})
+
+	t.Run("Should not read plugin config without policy", func(t *testing.T) {
+		pluginContext := starboard.NewPluginContext().WithName("Aqua").Get()
+		require.NoError(t, starboard.VerifyPluginImages(pluginContext))
+	})
+}
@@ -28,6 +28,27 @@ func (c PluginConfig) GetRequiredData(key string) (string, error) {
 	return value, nil
 }
 
+// GetScanJobTTLSecondsAfterFinished returns the TTLSecondsAfterFinished of
+// scan jobs run by the plugin or nil if it's not set.
+func (c PluginConfig) GetScanJobTTLSecondsAfterFinished() (*int32, error) {
+	return parseTTLSecondsAfterFinished(c.Data[keyScanJobTTLSecondsAfterFinished])
+}
+
+// GetScanJobTTLSecondsAfterFinished returns the TTLSecondsAfterFinished of
+// scan jobs run by the plugin with the specified context. The value set in the
+// plugin config takes precedence over the value set in Starboard config.
+func GetScanJobTTLSecondsAfterFinished(pluginContext PluginContext, config ConfigData) (*int32, error) {
+	pluginConfig, err := pluginContext.GetConfig()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	ttl, err := pluginConfig.GetScanJobTTLSecondsAfterFinished()
+	if err != nil || ttl != nil {
+		return ttl, err
+	}
+	return config.GetScanJobTTLSecondsAfterFinished()
+}
+
 // PluginContext is plugin's execution context within the Starboard toolkit.
 // The context is used to grant access to other methods so that this plugin
 // can interact with the toolkit.
@@ -46,6 +67,8 @@ type PluginContext interface {
 	GetServiceAccountName() string
 	// GetStarboardConfig returns starboard configuration.
 	GetStarboardConfig() ConfigData
+	// GetImagePolicy returns the ImagePolicy of images of the plugin.
+	GetImagePolicy() ImagePolicy
 }
 
 // GetPluginConfigMapName returns the name of a ConfigMap used to configure a plugin
@@ -61,6 +84,7 @@ type pluginContext struct {
 	namespace          string
 	serviceAccountName string
 	starboardConfig    ConfigData
+	imagePolicy        ImagePolicy
 }
 
 func (p *pluginContext) GetName() string {
@@ -128,6 +152,10 @@ func (p *pluginContext) GetStarboardConfig() ConfigData {
 	return p.starboardConfig
 }
 
+func (p *pluginContext) GetImagePolicy() ImagePolicy {
+	return p.imagePolicy
+}
+
 type PluginContextBuilder struct {
 	ctx *pluginContext
 }
@@ -163,6 +191,38 @@ func (b *PluginContextBuilder) WithStarboardConfig(config ConfigData) *PluginCon
 	return b
 }
 
+func (b *PluginContextBuilder) WithImagePolicy(policy ImagePolicy) *PluginContextBuilder {
+	b.ctx.imagePolicy = policy
+	return b
+}
+
 func (b *PluginContextBuilder) Get() PluginContext {
 	return b.ctx
 }
+
+// scannerImagePluginContext is a PluginContext which carries the image of the
+// scanner a scan job was created with.
+type scannerImagePluginContext struct {
+	PluginContext
+	scannerImage string
+}
+
+// WithScannerImage returns a PluginContext which carries the given image of the
+// scanner recorded on a scan job with the AnnotationScannerImage annotation, so
+// that plugins parse scan results of the job against that scanner rather than
+// the one currently configured.
+func WithScannerImage(ctx PluginContext, scannerImage string) PluginContext {
+	if scannerImage == "" {
+		return ctx
+	}
+	return &scannerImagePluginContext{PluginContext: ctx, scannerImage: scannerImage}
+}
+
+// ScannerImageFrom returns the image of the scanner carried by the given
+// PluginContext, if any.
+func ScannerImageFrom(ctx PluginContext) (string, bool) {
+	if c, ok := ctx.(*scannerImagePluginContext); ok {
+		return c.scannerImage, true
+	}
+	return "", false
+}
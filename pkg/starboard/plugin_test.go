@@ -7,6 +7,8 @@ import (
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -95,3 +97,49 @@ func TestPluginContext_GetConfig(t *testing.T) {
 			}))
 	})
 }
+
+func TestGetScanJobTTLSecondsAfterFinished(t *testing.T) {
+	newPluginContext := func(objects ...client.Object) starboard.PluginContext {
+		return starboard.NewPluginContext().
+			WithName("trivy").
+			WithNamespace("starboard-ns").
+			WithClient(fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build()).
+			Get()
+	}
+
+	t.Run("Should return TTL set in plugin config", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pluginContext := newPluginContext(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "starboard-trivy-config",
+				Namespace: "starboard-ns",
+			},
+			Data: map[string]string{
+				"scanJob.ttlSecondsAfterFinished": "60",
+			},
+		})
+		ttl, err := starboard.GetScanJobTTLSecondsAfterFinished(pluginContext, starboard.ConfigData{
+			"scanJob.ttlSecondsAfterFinished": "600",
+		})
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(ttl).To(gomega.Equal(pointer.Int32(60)))
+	})
+
+	t.Run("Should return TTL set in Starboard config", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pluginContext := newPluginContext()
+		ttl, err := starboard.GetScanJobTTLSecondsAfterFinished(pluginContext, starboard.ConfigData{
+			"scanJob.ttlSecondsAfterFinished": "600",
+		})
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(ttl).To(gomega.Equal(pointer.Int32(600)))
+	})
+
+	t.Run("Should return nil when TTL is not set", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pluginContext := newPluginContext()
+		ttl, err := starboard.GetScanJobTTLSecondsAfterFinished(pluginContext, starboard.ConfigData{})
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(ttl).To(gomega.BeNil())
+	})
+}
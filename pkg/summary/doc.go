@@ -0,0 +1,3 @@
+// Package summary provides functions for rolling up summaries of security
+// reports selected by namespace and labels.
+package summary
@@ -0,0 +1,150 @@
+package summary
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultPageSize = 500
+
+// ErrNamespaceNotAllowed is returned when reports outside the namespaces
+// allowed with WithAllowedNamespaces are requested.
+var ErrNamespaceNotAllowed = errors.New("namespace is not allowed")
+
+// ListOptions holds settings which select the reports to summarize.
+type ListOptions struct {
+	// Namespace restricts the reports to the given namespace. Reports from
+	// all namespaces are selected if Namespace is blank.
+	Namespace string
+	// Labels restricts the reports to the ones matching all the given labels.
+	Labels map[string]string
+	// MinSeverity excludes counts of severities lower than the given one.
+	MinSeverity v1alpha1.Severity
+	// PageSize is the maximum number of reports fetched in a single list call.
+	// Zero fetches all reports in a single call, which is required to read
+	// reports from the cache of a controller manager, because it does not
+	// support paginated lists.
+	PageSize int64
+	// AllowedNamespaces restricts the reports to the given namespaces, so that
+	// services embedding this package can enforce tenancy. Reports from all
+	// namespaces are allowed if AllowedNamespaces is nil.
+	AllowedNamespaces []string
+}
+
+// ListOption configures ListOptions.
+type ListOption func(*ListOptions)
+
+// InNamespace selects reports in the given namespace.
+func InNamespace(namespace string) ListOption {
+	return func(o *ListOptions) {
+		o.Namespace = namespace
+	}
+}
+
+// MatchingLabels selects reports matching all the given labels.
+func MatchingLabels(labels map[string]string) ListOption {
+	return func(o *ListOptions) {
+		if o.Labels == nil {
+			o.Labels = make(map[string]string)
+		}
+		for k, v := range labels {
+			o.Labels[k] = v
+		}
+	}
+}
+
+// ForResource selects reports associated with the given Kubernetes resource
+// by using the standard starboard resource labels.
+func ForResource(kind, name string) ListOption {
+	return MatchingLabels(map[string]string{
+		starboard.LabelResourceKind: kind,
+		starboard.LabelResourceName: name,
+	})
+}
+
+// WithMinSeverity excludes counts of severities lower than the given one.
+func WithMinSeverity(severity v1alpha1.Severity) ListOption {
+	return func(o *ListOptions) {
+		o.MinSeverity = severity
+	}
+}
+
+// WithAllowedNamespaces restricts reports to the given namespaces. Reports of
+// all namespaces are then summarized from the allowed namespaces only, whereas
+// reports of any other namespace, as well as cluster-scoped reports, are
+// rejected with ErrNamespaceNotAllowed.
+func WithAllowedNamespaces(namespaces ...string) ListOption {
+	return func(o *ListOptions) {
+		// the list is never nil, so that no namespace is allowed if none is given
+		allowed := make([]string, 0, len(o.AllowedNamespaces)+len(namespaces))
+		o.AllowedNamespaces = append(append(allowed, o.AllowedNamespaces...), namespaces...)
+	}
+}
+
+// WithPageSize sets the maximum number of reports fetched in a single list call.
+func WithPageSize(size int64) ListOption {
+	return func(o *ListOptions) {
+		o.PageSize = size
+	}
+}
+
+func newListOptions(opts ...ListOption) *ListOptions {
+	o := &ListOptions{
+		PageSize: defaultPageSize,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// namespaces returns the namespaces to list reports from, where a blank
+// namespace stands for all namespaces.
+func (o *ListOptions) namespaces() ([]string, error) {
+	if o.AllowedNamespaces == nil {
+		return []string{o.Namespace}, nil
+	}
+	if o.Namespace == "" {
+		return o.AllowedNamespaces, nil
+	}
+	for _, namespace := range o.AllowedNamespaces {
+		if namespace == o.Namespace {
+			return []string{namespace}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNamespaceNotAllowed, o.Namespace)
+}
+
+func (o *ListOptions) clientOptions(namespace, continueToken string) []client.ListOption {
+	listOptions := []client.ListOption{
+		client.Limit(o.PageSize),
+		client.Continue(continueToken),
+	}
+	if namespace != "" {
+		listOptions = append(listOptions, client.InNamespace(namespace))
+	}
+	if len(o.Labels) > 0 {
+		listOptions = append(listOptions, client.MatchingLabels(o.Labels))
+	}
+	return listOptions
+}
+
+var severityRank = map[v1alpha1.Severity]int{
+	v1alpha1.SeverityCritical: 4,
+	v1alpha1.SeverityHigh:     3,
+	v1alpha1.SeverityMedium:   2,
+	v1alpha1.SeverityLow:      1,
+	v1alpha1.SeverityUnknown:  0,
+}
+
+// includes returns true if counts of the given severity pass the severity floor.
+func (o *ListOptions) includes(severity v1alpha1.Severity) bool {
+	if o.MinSeverity == "" {
+		return true
+	}
+	return severityRank[severity] >= severityRank[o.MinSeverity]
+}
@@ -0,0 +1,207 @@
+package summary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SummarizeVulnerabilities returns the sum of summaries of VulnerabilityReports
+// selected by the given options.
+func SummarizeVulnerabilities(ctx context.Context, c client.Reader, opts ...ListOption) (v1alpha1.VulnerabilitySummary, error) {
+	byNamespace, err := SummarizeVulnerabilitiesByNamespace(ctx, c, opts...)
+	if err != nil {
+		return v1alpha1.VulnerabilitySummary{}, err
+	}
+	var summaries []v1alpha1.VulnerabilitySummary
+	for _, summary := range byNamespace {
+		summaries = append(summaries, summary)
+	}
+	return MergeVulnerabilities(summaries...), nil
+}
+
+// SummarizeVulnerabilitiesByNamespace returns the sum of summaries of
+// VulnerabilityReports selected by the given options for each namespace.
+func SummarizeVulnerabilitiesByNamespace(ctx context.Context, c client.Reader, opts ...ListOption) (map[string]v1alpha1.VulnerabilitySummary, error) {
+	o := newListOptions(opts...)
+	namespaces, err := o.namespaces()
+	if err != nil {
+		return nil, err
+	}
+	summaries := make(map[string]v1alpha1.VulnerabilitySummary)
+	for _, namespace := range namespaces {
+		var continueToken string
+		for {
+			var list v1alpha1.VulnerabilityReportList
+			err := c.List(ctx, &list, o.clientOptions(namespace, continueToken)...)
+			if err != nil {
+				return nil, err
+			}
+			for _, report := range list.Items {
+				summaries[report.Namespace] = MergeVulnerabilities(summaries[report.Namespace], report.Report.Summary)
+			}
+			if continueToken = list.Continue; continueToken == "" {
+				break
+			}
+		}
+	}
+	for namespace, summary := range summaries {
+		summaries[namespace] = o.filterVulnerabilities(summary)
+	}
+	return summaries, nil
+}
+
+// SummarizeConfigAudits returns the sum of summaries of ConfigAuditReports
+// selected by the given options.
+func SummarizeConfigAudits(ctx context.Context, c client.Reader, opts ...ListOption) (v1alpha1.ConfigAuditSummary, error) {
+	byNamespace, err := SummarizeConfigAuditsByNamespace(ctx, c, opts...)
+	if err != nil {
+		return v1alpha1.ConfigAuditSummary{}, err
+	}
+	var summaries []v1alpha1.ConfigAuditSummary
+	for _, summary := range byNamespace {
+		summaries = append(summaries, summary)
+	}
+	return MergeConfigAudits(summaries...), nil
+}
+
+// SummarizeConfigAuditsByNamespace returns the sum of summaries of
+// ConfigAuditReports selected by the given options for each namespace.
+func SummarizeConfigAuditsByNamespace(ctx context.Context, c client.Reader, opts ...ListOption) (map[string]v1alpha1.ConfigAuditSummary, error) {
+	o := newListOptions(opts...)
+	namespaces, err := o.namespaces()
+	if err != nil {
+		return nil, err
+	}
+	summaries := make(map[string]v1alpha1.ConfigAuditSummary)
+	for _, namespace := range namespaces {
+		var continueToken string
+		for {
+			var list v1alpha1.ConfigAuditReportList
+			err := c.List(ctx, &list, o.clientOptions(namespace, continueToken)...)
+			if err != nil {
+				return nil, err
+			}
+			for _, report := range list.Items {
+				summaries[report.Namespace] = MergeConfigAudits(summaries[report.Namespace], report.Report.Summary)
+			}
+			if continueToken = list.Continue; continueToken == "" {
+				break
+			}
+		}
+	}
+	for namespace, summary := range summaries {
+		summaries[namespace] = o.filterConfigAudits(summary)
+	}
+	return summaries, nil
+}
+
+// SummarizeCompliance returns the sum of pass and fail counts of
+// ClusterComplianceReports selected by the given options. The namespace option
+// is ignored as compliance reports are cluster-scoped, but summarizing them is
+// not allowed along with WithAllowedNamespaces, because they contain results of
+// all namespaces. When the severity floor is set the counts are computed from
+// control checks of matching severity.
+func SummarizeCompliance(ctx context.Context, c client.Reader, opts ...ListOption) (v1alpha1.ClusterComplianceSummary, error) {
+	o := newListOptions(opts...)
+	if o.AllowedNamespaces != nil {
+		return v1alpha1.ClusterComplianceSummary{}, fmt.Errorf("%w: cluster compliance reports are cluster-scoped", ErrNamespaceNotAllowed)
+	}
+	var summary v1alpha1.ClusterComplianceSummary
+	var continueToken string
+	for {
+		var list v1alpha1.ClusterComplianceReportList
+		err := c.List(ctx, &list, o.clientOptions("", continueToken)...)
+		if err != nil {
+			return v1alpha1.ClusterComplianceSummary{}, err
+		}
+		for _, report := range list.Items {
+			if o.MinSeverity == "" {
+				summary.PassCount += report.Status.Summary.PassCount
+				summary.FailCount += report.Status.Summary.FailCount
+				continue
+			}
+			for _, controlCheck := range report.Status.ControlChecks {
+				if !o.includes(controlCheck.Severity) {
+					continue
+				}
+				summary.PassCount += controlCheck.PassTotal
+				summary.FailCount += controlCheck.FailTotal
+			}
+		}
+		if continueToken = list.Continue; continueToken == "" {
+			break
+		}
+	}
+	return summary, nil
+}
+
+// MergeVulnerabilities returns the sum of the given vulnerability summaries.
+func MergeVulnerabilities(summaries ...v1alpha1.VulnerabilitySummary) v1alpha1.VulnerabilitySummary {
+	merged := v1alpha1.VulnerabilitySummary{}
+	for _, summary := range summaries {
+		merged.CriticalCount += summary.CriticalCount
+		merged.HighCount += summary.HighCount
+		merged.MediumCount += summary.MediumCount
+		merged.LowCount += summary.LowCount
+		merged.UnknownCount += summary.UnknownCount
+		merged.NoneCount += summary.NoneCount
+		merged.KnownExploitedCount += summary.KnownExploitedCount
+		for priority, count := range summary.PriorityCounts {
+			if merged.PriorityCounts == nil {
+				merged.PriorityCounts = make(map[v1alpha1.Priority]int)
+			}
+			merged.PriorityCounts[priority] += count
+		}
+	}
+	return merged
+}
+
+// MergeConfigAudits returns the sum of the given config audit summaries.
+func MergeConfigAudits(summaries ...v1alpha1.ConfigAuditSummary) v1alpha1.ConfigAuditSummary {
+	merged := v1alpha1.ConfigAuditSummary{}
+	for _, summary := range summaries {
+		merged.CriticalCount += summary.CriticalCount
+		merged.HighCount += summary.HighCount
+		merged.MediumCount += summary.MediumCount
+		merged.LowCount += summary.LowCount
+	}
+	return merged
+}
+
+func (o *ListOptions) filterVulnerabilities(summary v1alpha1.VulnerabilitySummary) v1alpha1.VulnerabilitySummary {
+	if !o.includes(v1alpha1.SeverityCritical) {
+		summary.CriticalCount = 0
+	}
+	if !o.includes(v1alpha1.SeverityHigh) {
+		summary.HighCount = 0
+	}
+	if !o.includes(v1alpha1.SeverityMedium) {
+		summary.MediumCount = 0
+	}
+	if !o.includes(v1alpha1.SeverityLow) {
+		summary.LowCount = 0
+	}
+	if !o.includes(v1alpha1.SeverityUnknown) {
+		summary.UnknownCount = 0
+	}
+	return summary
+}
+
+func (o *ListOptions) filterConfigAudits(summary v1alpha1.ConfigAuditSummary) v1alpha1.ConfigAuditSummary {
+	if !o.includes(v1alpha1.SeverityCritical) {
+		summary.CriticalCount = 0
+	}
+	if !o.includes(v1alpha1.SeverityHigh) {
+		summary.HighCount = 0
+	}
+	if !o.includes(v1alpha1.SeverityMedium) {
+		summary.MediumCount = 0
+	}
+	if !o.includes(v1alpha1.SeverityLow) {
+		summary.LowCount = 0
+	}
+	return summary
+}
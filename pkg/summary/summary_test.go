@@ -0,0 +1,172 @@
+package summary_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/summary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func vulnerabilityReport(namespace, name, kind string, s v1alpha1.VulnerabilitySummary) *v1alpha1.VulnerabilityReport {
+	return &v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels: map[string]string{
+				starboard.LabelResourceKind: kind,
+				starboard.LabelResourceName: name,
+			},
+		},
+		Report: v1alpha1.VulnerabilityReportData{Summary: s},
+	}
+}
+
+func TestSummarizeVulnerabilities(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		vulnerabilityReport("default", "nginx", "Deployment", v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 2, LowCount: 3}),
+		vulnerabilityReport("default", "redis", "StatefulSet", v1alpha1.VulnerabilitySummary{HighCount: 1, MediumCount: 4, UnknownCount: 1}),
+		vulnerabilityReport("kube-system", "coredns", "Deployment", v1alpha1.VulnerabilitySummary{CriticalCount: 5}),
+	).Build()
+
+	testCases := []struct {
+		name     string
+		opts     []summary.ListOption
+		expected v1alpha1.VulnerabilitySummary
+	}{
+		{
+			name:     "Should summarize all reports",
+			expected: v1alpha1.VulnerabilitySummary{CriticalCount: 6, HighCount: 3, MediumCount: 4, LowCount: 3, UnknownCount: 1},
+		},
+		{
+			name:     "Should summarize reports in namespace",
+			opts:     []summary.ListOption{summary.InNamespace("default")},
+			expected: v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 3, MediumCount: 4, LowCount: 3, UnknownCount: 1},
+		},
+		{
+			name:     "Should summarize reports matching labels",
+			opts:     []summary.ListOption{summary.MatchingLabels(map[string]string{starboard.LabelResourceKind: "Deployment"})},
+			expected: v1alpha1.VulnerabilitySummary{CriticalCount: 6, HighCount: 2, LowCount: 3},
+		},
+		{
+			name:     "Should summarize reports of resource",
+			opts:     []summary.ListOption{summary.InNamespace("default"), summary.ForResource("StatefulSet", "redis")},
+			expected: v1alpha1.VulnerabilitySummary{HighCount: 1, MediumCount: 4, UnknownCount: 1},
+		},
+		{
+			name:     "Should apply severity floor",
+			opts:     []summary.ListOption{summary.WithMinSeverity(v1alpha1.SeverityHigh)},
+			expected: v1alpha1.VulnerabilitySummary{CriticalCount: 6, HighCount: 3},
+		},
+		{
+			name:     "Should summarize reports in allowed namespaces",
+			opts:     []summary.ListOption{summary.WithAllowedNamespaces("default", "qa")},
+			expected: v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 3, MediumCount: 4, LowCount: 3, UnknownCount: 1},
+		},
+		{
+			name:     "Should summarize reports in allowed namespace",
+			opts:     []summary.ListOption{summary.InNamespace("kube-system"), summary.WithAllowedNamespaces("default", "kube-system")},
+			expected: v1alpha1.VulnerabilitySummary{CriticalCount: 5},
+		},
+		{
+			name: "Should summarize no reports without allowed namespaces",
+			opts: []summary.ListOption{summary.WithAllowedNamespaces()},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := summary.SummarizeVulnerabilities(context.TODO(), c, tc.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, s)
+		})
+	}
+
+	t.Run("Should reject namespace which is not allowed", func(t *testing.T) {
+		_, err := summary.SummarizeVulnerabilities(context.TODO(), c,
+			summary.InNamespace("kube-system"), summary.WithAllowedNamespaces("default"))
+		assert.ErrorIs(t, err, summary.ErrNamespaceNotAllowed)
+		assert.EqualError(t, err, "namespace is not allowed: kube-system")
+	})
+}
+
+func TestSummarizeVulnerabilitiesByNamespace(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		vulnerabilityReport("default", "nginx", "Deployment", v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 2}),
+		vulnerabilityReport("default", "redis", "StatefulSet", v1alpha1.VulnerabilitySummary{HighCount: 1}),
+		vulnerabilityReport("kube-system", "coredns", "Deployment", v1alpha1.VulnerabilitySummary{CriticalCount: 5}),
+	).Build()
+
+	s, err := summary.SummarizeVulnerabilitiesByNamespace(context.TODO(), c, summary.WithPageSize(0))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]v1alpha1.VulnerabilitySummary{
+		"default":     {CriticalCount: 1, HighCount: 3},
+		"kube-system": {CriticalCount: 5},
+	}, s)
+}
+
+func TestMergeVulnerabilities(t *testing.T) {
+	assert.Equal(t, v1alpha1.VulnerabilitySummary{
+		CriticalCount:       2,
+		HighCount:           1,
+		KnownExploitedCount: 1,
+		PriorityCounts:      map[v1alpha1.Priority]int{v1alpha1.PriorityP1: 1, v1alpha1.PriorityP2: 2},
+	}, summary.MergeVulnerabilities(
+		v1alpha1.VulnerabilitySummary{CriticalCount: 1, KnownExploitedCount: 1, PriorityCounts: map[v1alpha1.Priority]int{v1alpha1.PriorityP1: 1}},
+		v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 1, PriorityCounts: map[v1alpha1.Priority]int{v1alpha1.PriorityP2: 2}},
+	))
+}
+
+func TestSummarizeConfigAudits(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.ConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "replicaset-nginx"},
+			Report:     v1alpha1.ConfigAuditReportData{Summary: v1alpha1.ConfigAuditSummary{HighCount: 1, LowCount: 2}},
+		},
+		&v1alpha1.ConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "service-nginx"},
+			Report:     v1alpha1.ConfigAuditReportData{Summary: v1alpha1.ConfigAuditSummary{CriticalCount: 1, MediumCount: 3}},
+		},
+	).Build()
+
+	s, err := summary.SummarizeConfigAudits(context.TODO(), c, summary.WithMinSeverity(v1alpha1.SeverityMedium))
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ConfigAuditSummary{CriticalCount: 1, HighCount: 1, MediumCount: 3}, s)
+}
+
+func TestSummarizeCompliance(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+		&v1alpha1.ClusterComplianceReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "nsa"},
+			Status: v1alpha1.ReportStatus{
+				Summary: v1alpha1.ClusterComplianceSummary{PassCount: 5, FailCount: 3},
+				ControlChecks: []v1alpha1.ControlCheck{
+					{ID: "1.0", Severity: v1alpha1.SeverityCritical, PassTotal: 1, FailTotal: 2},
+					{ID: "1.1", Severity: v1alpha1.SeverityLow, PassTotal: 4, FailTotal: 1},
+				},
+			},
+		},
+	).Build()
+
+	t.Run("Should summarize compliance reports", func(t *testing.T) {
+		s, err := summary.SummarizeCompliance(context.TODO(), c)
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.ClusterComplianceSummary{PassCount: 5, FailCount: 3}, s)
+	})
+
+	t.Run("Should summarize control checks above severity floor", func(t *testing.T) {
+		s, err := summary.SummarizeCompliance(context.TODO(), c, summary.WithMinSeverity(v1alpha1.SeverityHigh))
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.ClusterComplianceSummary{PassCount: 1, FailCount: 2}, s)
+	})
+
+	t.Run("Should reject compliance reports along with allowed namespaces", func(t *testing.T) {
+		_, err := summary.SummarizeCompliance(context.TODO(), c, summary.WithAllowedNamespaces("default"))
+		assert.ErrorIs(t, err, summary.ErrNamespaceNotAllowed)
+	})
+}
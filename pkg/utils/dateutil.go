@@ -10,11 +10,21 @@ import (
 // if activation time has not reached return false and remaining time
 // in case it failed to parse cron expression return error
 func NextCronDuration(cronString string, creationTime time.Time, clock ext.Clock) (time.Duration, error) {
-	expr, err := cronexpr.Parse(cronString)
+	nextTime, err := NextCronTime(cronString, creationTime)
 	if err != nil {
 		return time.Duration(0), err
 	}
-	return timeToExpiration(expr.Next(creationTime), clock), nil
+	return timeToExpiration(nextTime, clock), nil
+}
+
+// NextCronTime returns the next activation time of the cron expression after
+// the specified time, or error if it failed to parse the cron expression
+func NextCronTime(cronString string, after time.Time) (time.Time, error) {
+	expr, err := cronexpr.Parse(cronString)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return expr.Next(after), nil
 }
 
 //DurationExceeded  check if duration is now meaning zero
@@ -34,6 +34,17 @@ func TestNextCronDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestNextCronTime(t *testing.T) {
+	after := time.Date(2022, 3, 13, 19, 29, 30, 0, time.UTC)
+	next, err := NextCronTime("0 */6 * * *", after)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 3, 14, 0, 0, 0, 0, time.UTC), next)
+
+	_, err = NextCronTime("* *", after)
+	assert.EqualError(t, err, "missing field(s)")
+}
+
 func parseTime(creationTime string) (time.Time, error) {
 	layout := "2006-01-02T15:04:05"
 	tm, err := time.Parse(layout, creationTime)
@@ -9,6 +9,7 @@ import (
 	"github.com/aquasecurity/starboard/pkg/docker"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/starboard"
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,6 +30,10 @@ type ScanJobBuilder struct {
 	tolerations       []corev1.Toleration
 	annotations       map[string]string
 	podTemplateLabels labels.Set
+	ttl               *int32
+	skippedContainers kube.ContainerImages
+	nodeGroup         NodeGroup
+	pluginConfigHash  string
 }
 
 func NewScanJobBuilder() *ScanJobBuilder {
@@ -75,24 +80,91 @@ func (s *ScanJobBuilder) WithCredentials(credentials map[string]docker.Auth) *Sc
 	return s
 }
 
+// WithTTLSecondsAfterFinished sets the TTLSecondsAfterFinished of the scan
+// job, which is honored by clusters running the TTL controller.
+func (s *ScanJobBuilder) WithTTLSecondsAfterFinished(ttl *int32) *ScanJobBuilder {
+	s.ttl = ttl
+	return s
+}
+
+// WithSkippedContainers sets containers of the workload which must not be
+// scanned. They are omitted from the scan job.
+func (s *ScanJobBuilder) WithSkippedContainers(containers kube.ContainerImages) *ScanJobBuilder {
+	s.skippedContainers = containers
+	return s
+}
+
+// WithNodeGroup sets the node group of a DaemonSet scanned per node group.
+// Images resolved on a node of the group are scanned instead of images of the
+// DaemonSet. The platform of the group, which may be set for any workload
+// scanned as a whole, is passed to the plugin in the AnnotationPlatform
+// annotation of the scanned object.
+func (s *ScanJobBuilder) WithNodeGroup(group NodeGroup) *ScanJobBuilder {
+	s.nodeGroup = group
+	return s
+}
+
+// WithPluginConfigHash sets the hash of the namespace configuration of the
+// plugin, which is recorded in the LabelPluginConfigHash label of the job.
+func (s *ScanJobBuilder) WithPluginConfigHash(hash string) *ScanJobBuilder {
+	s.pluginConfigHash = hash
+	return s
+}
+
 func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 	spec, err := kube.GetPodSpec(s.object)
 	if err != nil {
 		return nil, nil, err
 	}
+	podSpecHash := kube.ComputeHash(spec)
 
-	templateSpec, secrets, err := s.plugin.GetScanJobSpec(s.pluginContext, s.object, s.credentials)
+	object, err := s.nodeGroupObject()
+	if err != nil {
+		return nil, nil, err
+	}
+	spec, err = kube.GetPodSpec(object)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	templateSpec, secrets, err := s.plugin.GetScanJobSpec(s.pluginContext, object, s.credentials)
 	if err != nil {
 		return nil, nil, err
 	}
 	templateSpec.Tolerations = append(templateSpec.Tolerations, s.tolerations...)
+	defaultResources, err := s.pluginContext.GetStarboardConfig().GetScanJobDefaultResources()
+	if err != nil {
+		return nil, nil, err
+	}
+	kube.SetDefaultResources(&templateSpec, defaultResources)
 
-	containerImagesAsJSON, err := kube.GetContainerImagesFromPodSpec(spec).AsJSON()
+	containerImages := kube.GetContainerImagesFromPodSpec(spec)
+	// Images of the workload are run by scan jobs which scan file systems.
+	workloadImages := make(map[string]bool)
+	for _, image := range containerImages {
+		workloadImages[image] = true
+	}
+	err = s.pluginContext.GetImagePolicy().VerifyPodSpec(templateSpec, workloadImages)
 	if err != nil {
 		return nil, nil, err
 	}
+	if len(s.skippedContainers) > 0 {
+		var containers []corev1.Container
+		for _, container := range templateSpec.Containers {
+			if _, skipped := s.skippedContainers[container.Name]; !skipped {
+				containers = append(containers, container)
+			}
+		}
+		templateSpec.Containers = containers
+		for containerName := range s.skippedContainers {
+			delete(containerImages, containerName)
+		}
+	}
 
-	podSpecHash := kube.ComputeHash(spec)
+	containerImagesAsJSON, err := containerImages.AsJSON()
+	if err != nil {
+		return nil, nil, err
+	}
 
 	labelsSet := map[string]string{
 		starboard.LabelResourceSpecHash:           podSpecHash,
@@ -107,19 +179,39 @@ func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 		podTemplateLabelsSet[index] = element
 	}
 
+	jobAnnotations := map[string]string{
+		starboard.AnnotationContainerImages: containerImagesAsJSON,
+	}
+	if s.pluginConfigHash != "" {
+		labelsSet[starboard.LabelPluginConfigHash] = s.pluginConfigHash
+	}
+	if s.nodeGroup.Name != "" {
+		labelsSet[starboard.LabelNodeGroup] = s.nodeGroup.Name
+		jobAnnotations[starboard.AnnotationNodeName] = s.nodeGroup.NodeName
+		jobAnnotations[starboard.AnnotationContainerRuntime] = s.nodeGroup.ContainerRuntime
+	}
+	if s.nodeGroup.Platform != "" {
+		jobAnnotations[starboard.AnnotationPlatform] = s.nodeGroup.Platform
+	}
+	if s.nodeGroup.PlatformWarning != "" {
+		jobAnnotations[starboard.AnnotationPlatformWarning] = s.nodeGroup.PlatformWarning
+	}
+	if len(templateSpec.Containers) > 0 {
+		jobAnnotations[starboard.AnnotationScannerImage] = templateSpec.Containers[0].Image
+	}
+
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      GetScanJobName(s.object),
-			Namespace: s.pluginContext.GetNamespace(),
-			Labels:    labelsSet,
-			Annotations: map[string]string{
-				starboard.AnnotationContainerImages: containerImagesAsJSON,
-			},
+			Name:        GetScanJobName(object),
+			Namespace:   s.pluginContext.GetNamespace(),
+			Labels:      labelsSet,
+			Annotations: jobAnnotations,
 		},
 		Spec: batchv1.JobSpec{
-			BackoffLimit:          pointer.Int32Ptr(0),
-			Completions:           pointer.Int32Ptr(1),
-			ActiveDeadlineSeconds: kube.GetActiveDeadlineSeconds(s.timeout),
+			BackoffLimit:            pointer.Int32Ptr(0),
+			Completions:             pointer.Int32Ptr(1),
+			ActiveDeadlineSeconds:   kube.GetActiveDeadlineSeconds(s.timeout),
+			TTLSecondsAfterFinished: s.ttl,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      podTemplateLabelsSet,
@@ -132,8 +224,14 @@ func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 	// secrets will be created with scan jobs in same namespace where scan job will run
 	for i, _ := range secrets {
 		secrets[i].Namespace = s.pluginContext.GetNamespace()
+		if secrets[i].Labels == nil {
+			secrets[i].Labels = make(map[string]string)
+		}
+		secrets[i].Labels[starboard.LabelK8SAppManagedBy] = starboard.AppStarboard
+		secrets[i].Labels[starboard.LabelScanJobName] = job.Name
 	}
 	s.updateScanJobForWorkloadNamespace(job, spec, secrets)
+	s.updateScanJobForWorkloadAntiAffinity(job)
 
 	err = kube.ObjectToObjectMeta(s.object, &job.ObjectMeta)
 	if err != nil {
@@ -148,6 +246,43 @@ func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 	return job, secrets, nil
 }
 
+// nodeGroupObject returns the scanned object. For a node group it's a copy of
+// the DaemonSet with images resolved on a node of the group, which carries
+// the name of the group in the LabelNodeGroup annotation, so that scan jobs
+// and secrets of different groups have different names. If the platform of
+// the node group is known, it's a copy which carries the platform in the
+// AnnotationPlatform annotation.
+func (s *ScanJobBuilder) nodeGroupObject() (client.Object, error) {
+	if s.nodeGroup.Name == "" && s.nodeGroup.Platform == "" {
+		return s.object, nil
+	}
+	object, ok := s.object.DeepCopyObject().(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("copying %s", s.object.GetObjectKind().GroupVersionKind().Kind)
+	}
+	annotations := make(map[string]string)
+	for key, value := range object.GetAnnotations() {
+		annotations[key] = value
+	}
+	if s.nodeGroup.Name != "" {
+		daemonSet, ok := object.(*appsv1.DaemonSet)
+		if !ok {
+			return nil, fmt.Errorf("node groups are not supported for %s", s.object.GetObjectKind().GroupVersionKind().Kind)
+		}
+		for i, container := range daemonSet.Spec.Template.Spec.Containers {
+			if image, ok := s.nodeGroup.Images[container.Name]; ok {
+				daemonSet.Spec.Template.Spec.Containers[i].Image = image
+			}
+		}
+		annotations[starboard.LabelNodeGroup] = s.nodeGroup.Name
+	}
+	if s.nodeGroup.Platform != "" {
+		annotations[starboard.AnnotationPlatform] = s.nodeGroup.Platform
+	}
+	object.SetAnnotations(annotations)
+	return object, nil
+}
+
 // When run scan job in workload namespace is enabled then this method will update scanjob spec with these changes
 // - namespace same as workload
 // - service account same as workload service account
@@ -165,12 +300,57 @@ func (s *ScanJobBuilder) updateScanJobForWorkloadNamespace(job *batchv1.Job, pod
 	}
 }
 
+// When avoiding workload nodes is enabled then this method will add a preferred
+// pod anti-affinity term against pods of the scanned workload, so that a scan job
+// is not scheduled on a node already running the workload. Existing affinity
+// settings of the scan job are preserved.
+func (s *ScanJobBuilder) updateScanJobForWorkloadAntiAffinity(job *batchv1.Job) {
+	operatorConfig := s.pluginContext.GetStarboardConfig()
+	if !operatorConfig.ScanJobAvoidWorkloadNodes() {
+		return
+	}
+	selector, err := kube.GetPodSelector(s.object)
+	if err != nil || selector == nil || (len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0) {
+		return
+	}
+	podSpec := &job.Spec.Template.Spec
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.PodAntiAffinity == nil {
+		podSpec.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+	}
+	podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+		podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+		corev1.WeightedPodAffinityTerm{
+			Weight: 100,
+			PodAffinityTerm: corev1.PodAffinityTerm{
+				LabelSelector: selector,
+				Namespaces:    []string{s.object.GetNamespace()},
+				TopologyKey:   corev1.LabelHostname,
+			},
+		})
+}
+
+// GetScanJobName returns the name of the scan job of the specified object. A
+// DaemonSet scanned per node group carries the name of the group in the
+// LabelNodeGroup annotation, which is appended to the name.
 func GetScanJobName(obj client.Object) string {
-	return fmt.Sprintf("scan-vulnerabilityreport-%s", kube.ComputeHash(kube.ObjectRef{
+	return ScanJobName(kube.ObjectRef{
 		Kind:      kube.Kind(obj.GetObjectKind().GroupVersionKind().Kind),
 		Namespace: obj.GetNamespace(),
 		Name:      obj.GetName(),
-	}))
+	}, obj.GetAnnotations()[starboard.LabelNodeGroup])
+}
+
+// ScanJobName returns the name of the scan job of the specified object, or of
+// the specified node group of the object.
+func ScanJobName(ref kube.ObjectRef, nodeGroup string) string {
+	name := fmt.Sprintf("scan-vulnerabilityreport-%s", kube.ComputeHash(ref))
+	if nodeGroup == "" {
+		return name
+	}
+	return fmt.Sprintf("%s-%s", name, kube.ComputeHash(nodeGroup))
 }
 
 func RegistryCredentialsSecretName(obj client.Object) string {
@@ -178,12 +358,16 @@ func RegistryCredentialsSecretName(obj client.Object) string {
 }
 
 type ReportBuilder struct {
-	scheme     *runtime.Scheme
-	controller client.Object
-	container  string
-	hash       string
-	data       v1alpha1.VulnerabilityReportData
-	reportTTL  *time.Duration
+	scheme             *runtime.Scheme
+	controller         client.Object
+	container          string
+	hash               string
+	data               v1alpha1.VulnerabilityReportData
+	reportTTL          *time.Duration
+	skipOwnerReference bool
+	initiator          string
+	nodeGroup          NodeGroup
+	pluginConfigHash   string
 }
 
 func NewReportBuilder(scheme *runtime.Scheme) *ReportBuilder {
@@ -207,6 +391,13 @@ func (b *ReportBuilder) PodSpecHash(hash string) *ReportBuilder {
 	return b
 }
 
+// PluginConfigHash sets the hash of the namespace configuration of the plugin
+// the report was generated with.
+func (b *ReportBuilder) PluginConfigHash(hash string) *ReportBuilder {
+	b.pluginConfigHash = hash
+	return b
+}
+
 func (b *ReportBuilder) Data(data v1alpha1.VulnerabilityReportData) *ReportBuilder {
 	b.data = data
 	return b
@@ -217,15 +408,45 @@ func (b *ReportBuilder) ReportTTL(ttl *time.Duration) *ReportBuilder {
 	return b
 }
 
+// Initiator sets the v1alpha1.ReportInitiatorAnnotation of a report, i.e.
+// v1alpha1.InitiatorOperator or v1alpha1.InitiatorCLI.
+func (b *ReportBuilder) Initiator(initiator string) *ReportBuilder {
+	b.initiator = initiator
+	return b
+}
+
+// SkipOwnerReference tells the builder not to set the controller reference
+// to the owner of a report. Such reports are cleaned up based on labels.
+func (b *ReportBuilder) SkipOwnerReference(skip bool) *ReportBuilder {
+	b.skipOwnerReference = skip
+	return b
+}
+
+// NodeGroup sets the node group of a DaemonSet scanned per node group. The
+// name of the group is appended to the report name. The platform of the group
+// is recorded in the artifact of a scanned image.
+func (b *ReportBuilder) NodeGroup(group NodeGroup) *ReportBuilder {
+	b.nodeGroup = group
+	return b
+}
+
 func (b *ReportBuilder) reportName() string {
 	kind := b.controller.GetObjectKind().GroupVersionKind().Kind
 	name := b.controller.GetName()
-	reportName := fmt.Sprintf("%s-%s-%s", strings.ToLower(kind), name, b.container)
+	container := b.container
+	if b.nodeGroup.Name != "" {
+		suffix := b.nodeGroup.Name
+		if len(validation.IsDNS1123Label(suffix)) > 0 {
+			suffix = kube.ComputeHash(suffix)
+		}
+		container = fmt.Sprintf("%s-%s", container, suffix)
+	}
+	reportName := fmt.Sprintf("%s-%s-%s", strings.ToLower(kind), name, container)
 	if len(validation.IsValidLabelValue(reportName)) == 0 {
 		return reportName
 	}
 
-	return fmt.Sprintf("%s-%s", strings.ToLower(kind), kube.ComputeHash(name+"-"+b.container))
+	return fmt.Sprintf("%s-%s", strings.ToLower(kind), kube.ComputeHash(name+"-"+container))
 }
 
 func (b *ReportBuilder) Get() (v1alpha1.VulnerabilityReport, error) {
@@ -236,6 +457,12 @@ func (b *ReportBuilder) Get() (v1alpha1.VulnerabilityReport, error) {
 	if b.hash != "" {
 		labels[starboard.LabelResourceSpecHash] = b.hash
 	}
+	if b.nodeGroup.Name != "" {
+		labels[starboard.LabelNodeGroup] = b.nodeGroup.Name
+	}
+	if b.pluginConfigHash != "" {
+		labels[starboard.LabelPluginConfigHash] = b.pluginConfigHash
+	}
 
 	report := v1alpha1.VulnerabilityReport{
 		ObjectMeta: metav1.ObjectMeta{
@@ -245,16 +472,33 @@ func (b *ReportBuilder) Get() (v1alpha1.VulnerabilityReport, error) {
 		},
 		Report: b.data,
 	}
+	if b.nodeGroup.Platform != "" && b.data.SkipReason == "" {
+		report.Report.Artifact.Platform = b.nodeGroup.Platform
+	}
 
+	if b.reportTTL != nil || b.initiator != "" || b.nodeGroup.Name != "" || b.nodeGroup.PlatformWarning != "" {
+		report.Annotations = make(map[string]string)
+	}
 	if b.reportTTL != nil {
-		report.Annotations = map[string]string{
-			v1alpha1.TTLReportAnnotation: b.reportTTL.String(),
-		}
+		report.Annotations[v1alpha1.TTLReportAnnotation] = b.reportTTL.String()
+	}
+	if b.initiator != "" {
+		report.Annotations[v1alpha1.ReportInitiatorAnnotation] = b.initiator
+	}
+	if b.nodeGroup.Name != "" {
+		report.Annotations[starboard.AnnotationNodeName] = b.nodeGroup.NodeName
+		report.Annotations[starboard.AnnotationContainerRuntime] = b.nodeGroup.ContainerRuntime
+	}
+	if b.nodeGroup.PlatformWarning != "" {
+		report.Annotations[starboard.AnnotationPlatformWarning] = b.nodeGroup.PlatformWarning
 	}
 	err := kube.ObjectToObjectMeta(b.controller, &report.ObjectMeta)
 	if err != nil {
 		return v1alpha1.VulnerabilityReport{}, err
 	}
+	if b.skipOwnerReference {
+		return report, nil
+	}
 	err = controllerutil.SetControllerReference(b.controller, &report, b.scheme)
 	if err != nil {
 		return v1alpha1.VulnerabilityReport{}, fmt.Errorf("setting controller reference: %w", err)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"github.com/onsi/gomega"
@@ -63,6 +64,174 @@ func TestReportBuilder(t *testing.T) {
 	}))
 }
 
+func TestReportBuilder_SkipOwnerReference(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	report, err := vulnerabilityreport.NewReportBuilder(scheme.Scheme).
+		Controller(&appsv1.ReplicaSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ReplicaSet",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-owner",
+				Namespace: "qa",
+			},
+		}).
+		Container("my-container").
+		PodSpecHash("xyz").
+		Data(v1alpha1.VulnerabilityReportData{}).
+		SkipOwnerReference(true).
+		Get()
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(report).To(gomega.Equal(v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "replicaset-some-owner-my-container",
+			Namespace: "qa",
+			Labels: map[string]string{
+				starboard.LabelResourceKind:      "ReplicaSet",
+				starboard.LabelResourceName:      "some-owner",
+				starboard.LabelResourceNamespace: "qa",
+				starboard.LabelContainerName:     "my-container",
+				starboard.LabelResourceSpecHash:  "xyz",
+			},
+		},
+		Report: v1alpha1.VulnerabilityReportData{},
+	}))
+}
+
+func TestReportBuilder_Initiator(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	report, err := vulnerabilityreport.NewReportBuilder(scheme.Scheme).
+		Controller(&appsv1.ReplicaSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ReplicaSet",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-owner",
+				Namespace: "qa",
+			},
+		}).
+		Container("my-container").
+		Data(v1alpha1.VulnerabilityReportData{}).
+		Initiator(v1alpha1.InitiatorCLI).
+		SkipOwnerReference(true).
+		Get()
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(report.Annotations).To(gomega.Equal(map[string]string{
+		v1alpha1.ReportInitiatorAnnotation: v1alpha1.InitiatorCLI,
+	}))
+}
+
+func TestReportBuilder_NodeGroup(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	report, err := vulnerabilityreport.NewReportBuilder(scheme.Scheme).
+		Controller(&appsv1.DaemonSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "DaemonSet",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "fluentd",
+				Namespace: "kube-system",
+			},
+		}).
+		Container("fluentd").
+		PodSpecHash("xyz").
+		NodeGroup(vulnerabilityreport.NodeGroup{
+			Name:             "arm64",
+			NodeName:         "node-2",
+			ContainerRuntime: "containerd://1.5.11",
+		}).
+		Data(v1alpha1.VulnerabilityReportData{}).
+		SkipOwnerReference(true).
+		Get()
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(report.Name).To(gomega.Equal("daemonset-fluentd-fluentd-arm64"))
+	g.Expect(report.Labels).To(gomega.Equal(map[string]string{
+		starboard.LabelContainerName:     "fluentd",
+		starboard.LabelResourceSpecHash:  "xyz",
+		starboard.LabelNodeGroup:         "arm64",
+		starboard.LabelResourceKind:      "DaemonSet",
+		starboard.LabelResourceName:      "fluentd",
+		starboard.LabelResourceNamespace: "kube-system",
+	}))
+	g.Expect(report.Annotations).To(gomega.Equal(map[string]string{
+		starboard.AnnotationNodeName:         "node-2",
+		starboard.AnnotationContainerRuntime: "containerd://1.5.11",
+	}))
+}
+
+func TestReportBuilder_PluginConfigHash(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	report, err := vulnerabilityreport.NewReportBuilder(scheme.Scheme).
+		Controller(&appsv1.ReplicaSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ReplicaSet",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-owner",
+				Namespace: "qa",
+			},
+		}).
+		Container("my-container").
+		PodSpecHash("xyz").
+		PluginConfigHash("abc").
+		Data(v1alpha1.VulnerabilityReportData{}).
+		SkipOwnerReference(true).
+		Get()
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(report.Labels).To(gomega.Equal(map[string]string{
+		starboard.LabelContainerName:     "my-container",
+		starboard.LabelResourceSpecHash:  "xyz",
+		starboard.LabelPluginConfigHash:  "abc",
+		starboard.LabelResourceKind:      "ReplicaSet",
+		starboard.LabelResourceName:      "some-owner",
+		starboard.LabelResourceNamespace: "qa",
+	}))
+}
+
+func TestReportBuilder_Platform(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	report, err := vulnerabilityreport.NewReportBuilder(scheme.Scheme).
+		Controller(&appsv1.DaemonSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "DaemonSet",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "fluentd",
+				Namespace: "kube-system",
+			},
+		}).
+		Container("fluentd").
+		NodeGroup(vulnerabilityreport.NodeGroup{
+			Platform:        "linux/arm64",
+			PlatformWarning: "pods run on nodes of different platforms (linux/amd64: 1, linux/arm64: 2), only linux/arm64 was scanned",
+		}).
+		Data(v1alpha1.VulnerabilityReportData{
+			Artifact: v1alpha1.Artifact{Repository: "fluent/fluentd", Tag: "v1.14"},
+		}).
+		SkipOwnerReference(true).
+		Get()
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(report.Name).To(gomega.Equal("daemonset-fluentd-fluentd"))
+	g.Expect(report.Report.Artifact).To(gomega.Equal(v1alpha1.Artifact{
+		Repository: "fluent/fluentd",
+		Tag:        "v1.14",
+		Platform:   "linux/arm64",
+	}))
+	g.Expect(report.Annotations).To(gomega.Equal(map[string]string{
+		starboard.AnnotationPlatformWarning: "pods run on nodes of different platforms (linux/amd64: 1, linux/arm64: 2), only linux/arm64 was scanned",
+	}))
+}
+
 func TestScanJobBuilder(t *testing.T) {
 	t.Run("Should get scan job with labels", func(t *testing.T) {
 		g := gomega.NewGomegaWithT(t)
@@ -212,17 +381,291 @@ func TestScanJobBuilder(t *testing.T) {
 			},
 		}))
 	})
+
+	t.Run("Should get scan job with anti-affinity against workload pods", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		job, _, err := vulnerabilityreport.NewScanJobBuilder().
+			WithPlugin(&testPlugin{affinity: starboard.LinuxNodeAffinity()}).
+			WithPluginContext(starboard.NewPluginContext().
+				WithName("test-plugin").
+				WithNamespace("starboard-ns").
+				WithServiceAccountName("starboard-sa").
+				WithStarboardConfig(starboard.ConfigData{
+					"scanJob.avoidWorkloadNodes": "true"},
+				).
+				Get()).
+			WithTimeout(3 * time.Second).
+			WithObject(&appsv1.ReplicaSet{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "ReplicaSet",
+					APIVersion: "apps/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "nginx-6799fc88d8",
+					Namespace: "prod-ns",
+				},
+				Spec: appsv1.ReplicaSetSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "nginx",
+									Image: "nginx:1.16",
+								},
+							},
+						},
+					},
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "nginx"},
+					},
+				},
+			}).
+			Get()
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(job.Spec.Template.Spec.Affinity).To(gomega.Equal(&corev1.Affinity{
+			NodeAffinity: starboard.LinuxNodeAffinity().NodeAffinity,
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+					{
+						Weight: 100,
+						PodAffinityTerm: corev1.PodAffinityTerm{
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"app": "nginx"},
+							},
+							Namespaces:  []string{"prod-ns"},
+							TopologyKey: "kubernetes.io/hostname",
+						},
+					},
+				},
+			},
+		}))
+	})
+
+	t.Run("Should not add anti-affinity when workload selector is empty", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		job, _, err := vulnerabilityreport.NewScanJobBuilder().
+			WithPlugin(&testPlugin{}).
+			WithPluginContext(starboard.NewPluginContext().
+				WithName("test-plugin").
+				WithNamespace("starboard-ns").
+				WithStarboardConfig(starboard.ConfigData{
+					"scanJob.avoidWorkloadNodes": "true"},
+				).
+				Get()).
+			WithObject(&corev1.Pod{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Pod",
+					APIVersion: "v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "nginx",
+					Namespace: "prod-ns",
+				},
+			}).
+			Get()
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(job.Spec.Template.Spec.Affinity).To(gomega.BeNil())
+	})
+
+	t.Run("Should get scan job with TTL and secrets labeled with scan job name", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		job, secrets, err := vulnerabilityreport.NewScanJobBuilder().
+			WithPlugin(&testPlugin{secrets: []*corev1.Secret{
+				{ObjectMeta: metav1.ObjectMeta{Name: "scan-vulnerabilityreport-64d65c457-regcred"}},
+			}}).
+			WithPluginContext(starboard.NewPluginContext().
+				WithName("test-plugin").
+				WithNamespace("starboard-ns").
+				WithServiceAccountName("starboard-sa").
+				Get()).
+			WithTimeout(3 * time.Second).
+			WithTTLSecondsAfterFinished(pointer.Int32(600)).
+			WithObject(&appsv1.ReplicaSet{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "ReplicaSet",
+					APIVersion: "apps/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "nginx-6799fc88d8",
+					Namespace: "prod-ns",
+				},
+				Spec: appsv1.ReplicaSetSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "nginx",
+									Image: "nginx:1.16",
+								},
+							},
+						},
+					},
+					Selector: &metav1.LabelSelector{},
+				},
+			}).
+			Get()
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(job.Spec.TTLSecondsAfterFinished).To(gomega.Equal(pointer.Int32(600)))
+		g.Expect(secrets).To(gomega.Equal([]*corev1.Secret{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "scan-vulnerabilityreport-64d65c457-regcred",
+					Namespace: "starboard-ns",
+					Labels: map[string]string{
+						starboard.LabelK8SAppManagedBy: "starboard",
+						starboard.LabelScanJobName:     "scan-vulnerabilityreport-64d65c457",
+					},
+				},
+			},
+		}))
+	})
+
+	t.Run("Should get scan job without skipped containers", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		job, _, err := vulnerabilityreport.NewScanJobBuilder().
+			WithPlugin(&testPlugin{containers: []corev1.Container{
+				{Name: "nginx", Image: "aquasec/trivy:0.25.2"},
+				{Name: "vendor", Image: "aquasec/trivy:0.25.2"},
+			}}).
+			WithPluginContext(starboard.NewPluginContext().
+				WithName("test-plugin").
+				WithNamespace("starboard-ns").
+				Get()).
+			WithSkippedContainers(kube.ContainerImages{"vendor": "registry.vendor.example/appliance:1.0"}).
+			WithObject(&corev1.Pod{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Pod",
+					APIVersion: "v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "nginx",
+					Namespace: "prod-ns",
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "nginx", Image: "nginx:1.16"},
+						{Name: "vendor", Image: "registry.vendor.example/appliance:1.0"},
+					},
+				},
+			}).
+			Get()
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(job.Annotations[starboard.AnnotationContainerImages]).To(gomega.Equal(`{"nginx":"nginx:1.16"}`))
+		g.Expect(job.Spec.Template.Spec.Containers).To(gomega.HaveLen(1))
+		g.Expect(job.Spec.Template.Spec.Containers[0].Name).To(gomega.Equal("nginx"))
+	})
+
+	t.Run("Should get scan job of node group", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		job, _, err := vulnerabilityreport.NewScanJobBuilder().
+			WithPlugin(&testPlugin{}).
+			WithPluginContext(starboard.NewPluginContext().
+				WithName("test-plugin").
+				WithNamespace("starboard-ns").
+				Get()).
+			WithNodeGroup(vulnerabilityreport.NodeGroup{
+				Name:             "arm64",
+				NodeName:         "node-2",
+				ContainerRuntime: "containerd://1.5.11",
+				Images:           kube.ContainerImages{"fluentd": "index.docker.io/fluent/fluentd@sha256:3d2d8ba6f5ff3dc9b7e0b2bbaf8bcbcd07e7cbf2a0f9c6e1bd0c6c1c8f3b4e01"},
+			}).
+			WithObject(&appsv1.DaemonSet{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DaemonSet",
+					APIVersion: "apps/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "fluentd",
+					Namespace: "kube-system",
+				},
+				Spec: appsv1.DaemonSetSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{Name: "fluentd", Image: "fluent/fluentd:v1.14"},
+							},
+						},
+					},
+				},
+			}).
+			Get()
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(job.Name).To(gomega.Equal(vulnerabilityreport.ScanJobName(kube.ObjectRef{
+			Kind:      kube.KindDaemonSet,
+			Name:      "fluentd",
+			Namespace: "kube-system",
+		}, "arm64")))
+		g.Expect(job.Labels[starboard.LabelNodeGroup]).To(gomega.Equal("arm64"))
+		g.Expect(job.Annotations[starboard.AnnotationNodeName]).To(gomega.Equal("node-2"))
+		g.Expect(job.Annotations[starboard.AnnotationContainerRuntime]).To(gomega.Equal("containerd://1.5.11"))
+		g.Expect(job.Annotations[starboard.AnnotationContainerImages]).To(gomega.Equal(
+			`{"fluentd":"index.docker.io/fluent/fluentd@sha256:3d2d8ba6f5ff3dc9b7e0b2bbaf8bcbcd07e7cbf2a0f9c6e1bd0c6c1c8f3b4e01"}`))
+	})
+
+	t.Run("Should get scan job with platform", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		plugin := &testPlugin{}
+		replicaSet := &appsv1.ReplicaSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ReplicaSet",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nginx-6799fc88d8",
+				Namespace: "prod-ns",
+			},
+			Spec: appsv1.ReplicaSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: "nginx", Image: "nginx:1.16"},
+						},
+					},
+				},
+			},
+		}
+		job, _, err := vulnerabilityreport.NewScanJobBuilder().
+			WithPlugin(plugin).
+			WithPluginContext(starboard.NewPluginContext().
+				WithName("test-plugin").
+				WithNamespace("starboard-ns").
+				Get()).
+			WithNodeGroup(vulnerabilityreport.NodeGroup{
+				Images:   kube.ContainerImages{"nginx": "nginx:1.16"},
+				Platform: "linux/arm64",
+			}).
+			WithObject(replicaSet).
+			Get()
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(job.Name).To(gomega.Equal(vulnerabilityreport.ScanJobName(kube.ObjectRef{
+			Kind:      kube.KindReplicaSet,
+			Name:      "nginx-6799fc88d8",
+			Namespace: "prod-ns",
+		}, "")))
+		g.Expect(job.Labels).ToNot(gomega.HaveKey(starboard.LabelNodeGroup))
+		g.Expect(job.Annotations[starboard.AnnotationPlatform]).To(gomega.Equal("linux/arm64"))
+		g.Expect(plugin.object.GetAnnotations()).To(gomega.Equal(map[string]string{
+			starboard.AnnotationPlatform: "linux/arm64",
+		}))
+		g.Expect(replicaSet.Annotations).To(gomega.BeNil())
+	})
 }
 
 type testPlugin struct {
+	affinity   *corev1.Affinity
+	secrets    []*corev1.Secret
+	containers []corev1.Container
+	// object is the object passed to GetScanJobSpec.
+	object client.Object
 }
 
 func (p *testPlugin) Init(_ starboard.PluginContext) error {
 	return nil
 }
 
-func (p *testPlugin) GetScanJobSpec(_ starboard.PluginContext, _ client.Object, _ map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
-	return corev1.PodSpec{}, nil, nil
+func (p *testPlugin) GetScanJobSpec(_ starboard.PluginContext, obj client.Object, _ map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	p.object = obj
+	return corev1.PodSpec{Affinity: p.affinity, Containers: p.containers}, p.secrets, nil
 }
 
 func (p *testPlugin) ParseVulnerabilityReportData(_ starboard.PluginContext, _ string, _ io.ReadCloser) (v1alpha1.VulnerabilityReportData, error) {
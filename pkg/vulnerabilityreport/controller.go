@@ -6,12 +6,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"reflect"
+	"strings"
+	"time"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/exploitability"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/operator/controller"
+	"github.com/aquasecurity/starboard/pkg/operator/drain"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/metrics"
+	"github.com/aquasecurity/starboard/pkg/operator/throttle"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -19,13 +24,18 @@ import (
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // WorkloadController watches Kubernetes workloads and generates
@@ -37,43 +47,102 @@ type WorkloadController struct {
 	client.Client
 	kube.ObjectResolver
 	controller.LimitChecker
+	*controller.ScanJobsSwitch
 	kube.LogsReader
 	kube.SecretsReader
 	Plugin
 	starboard.PluginContext
 	ReadWriter
 	starboard.ConfigData
+	starboard.BuildInfo
+	// ScanFailures records failures of scan jobs for the scan summary. It is
+	// optional.
+	ScanFailures metrics.ScanFailureRecorder
+	// Recorder records warning events of workloads whose skip-containers
+	// annotation lists unknown containers. It is optional.
+	Recorder record.EventRecorder
+	// Drain lets reconciliations of scan jobs in flight finish when the
+	// operator shuts down. It is optional.
+	Drain *drain.Drain
+	// ResumedJobs are finished scan jobs enqueued when the operator starts
+	// leading. It is optional.
+	ResumedJobs <-chan event.GenericEvent
+	// RequeuedResources are scanned resources of stuck scan jobs which were
+	// deleted. It is optional.
+	RequeuedResources <-chan event.GenericEvent
+	// Exploitability prioritizes vulnerabilities of reports. It is optional.
+	Exploitability *exploitability.Enricher
+	// Throttle requeues reconciliations throttled by the API server after
+	// backoff. It is optional.
+	Throttle *throttle.Throttle
+
+	// rolloutsWatched is true if Argo Rollouts are watched, which own reports
+	// of their ReplicaSets.
+	rolloutsWatched bool
 }
 
+// ReasonUnknownSkippedContainers is the reason of a warning event of a
+// workload whose skip-containers annotation lists unknown containers.
+const ReasonUnknownSkippedContainers = "UnknownSkippedContainers"
+
 func (r *WorkloadController) SetupWithManager(mgr ctrl.Manager) error {
 	installModePredicate, err := InstallModePredicate(r.Config)
 	if err != nil {
 		return err
 	}
 
-	workloads := []struct {
+	type workload struct {
 		kind       kube.Kind
 		forObject  client.Object
 		ownsObject client.Object
-	}{
-		{kind: kube.KindPod, forObject: &corev1.Pod{}, ownsObject: &v1alpha1.VulnerabilityReport{}},
-		{kind: kube.KindReplicaSet, forObject: &appsv1.ReplicaSet{}, ownsObject: &v1alpha1.VulnerabilityReport{}},
-		{kind: kube.KindReplicationController, forObject: &corev1.ReplicationController{}, ownsObject: &v1alpha1.VulnerabilityReport{}},
-		{kind: kube.KindStatefulSet, forObject: &appsv1.StatefulSet{}, ownsObject: &v1alpha1.VulnerabilityReport{}},
-		{kind: kube.KindDaemonSet, forObject: &appsv1.DaemonSet{}, ownsObject: &v1alpha1.VulnerabilityReport{}},
-		{kind: kube.KindCronJob, forObject: &batchv1beta1.CronJob{}, ownsObject: &v1alpha1.VulnerabilityReport{}},
-		{kind: kube.KindJob, forObject: &batchv1.Job{}, ownsObject: &v1alpha1.VulnerabilityReport{}},
+		listObject client.ObjectList
+	}
+	workloads := []workload{
+		{kind: kube.KindPod, forObject: &corev1.Pod{}, ownsObject: &v1alpha1.VulnerabilityReport{}, listObject: &corev1.PodList{}},
+		{kind: kube.KindReplicaSet, forObject: &appsv1.ReplicaSet{}, ownsObject: &v1alpha1.VulnerabilityReport{}, listObject: &appsv1.ReplicaSetList{}},
+		{kind: kube.KindReplicationController, forObject: &corev1.ReplicationController{}, ownsObject: &v1alpha1.VulnerabilityReport{}, listObject: &corev1.ReplicationControllerList{}},
+		{kind: kube.KindStatefulSet, forObject: &appsv1.StatefulSet{}, ownsObject: &v1alpha1.VulnerabilityReport{}, listObject: &appsv1.StatefulSetList{}},
+		{kind: kube.KindDaemonSet, forObject: &appsv1.DaemonSet{}, ownsObject: &v1alpha1.VulnerabilityReport{}, listObject: &appsv1.DaemonSetList{}},
+		{kind: kube.KindCronJob, forObject: &batchv1beta1.CronJob{}, ownsObject: &v1alpha1.VulnerabilityReport{}, listObject: &batchv1beta1.CronJobList{}},
+		{kind: kube.KindJob, forObject: &batchv1.Job{}, ownsObject: &v1alpha1.VulnerabilityReport{}, listObject: &batchv1.JobList{}},
+	}
+
+	r.rolloutsWatched, err = kube.RolloutAPIAvailable(mgr.GetRESTMapper())
+	if err != nil {
+		return err
+	}
+	if r.rolloutsWatched {
+		r.Logger.Info("Watching Argo Rollouts", "apiVersion", kube.RolloutGVK.GroupVersion())
+		workloads = append(workloads, workload{kind: kube.KindRollout, forObject: kube.NewRollout(), ownsObject: &v1alpha1.VulnerabilityReport{}, listObject: kube.NewRolloutList()})
+	}
+
+	// All controllers of workloads watch the same channel, which sends each
+	// requeued workload to all of them.
+	var requeued *source.Channel
+	if r.RequeuedResources != nil {
+		requeued = &source.Channel{Source: r.RequeuedResources}
 	}
 
 	for _, workload := range workloads {
-		err = ctrl.NewControllerManagedBy(mgr).
+		b := ctrl.NewControllerManagedBy(mgr).
 			For(workload.forObject, builder.WithPredicates(
 				Not(ManagedByStarboardOperator),
 				Not(IsBeingTerminated),
 				installModePredicate,
 			)).
-			Owns(workload.ownsObject).
-			Complete(r.reconcileWorkload(workload.kind))
+			Owns(workload.ownsObject)
+		// Rescan workloads in the namespace of the ConfigMap which extends
+		// the configuration of the plugin when it changes.
+		if plugin, ok := r.Plugin.(NamespaceConfigPlugin); ok {
+			b = b.Watches(&source.Kind{Type: &corev1.ConfigMap{}},
+				handler.EnqueueRequestsFromMapFunc(r.workloadsInNamespace(workload.listObject)),
+				builder.WithPredicates(HasName(plugin.NamespaceConfigMapName()), installModePredicate))
+		}
+		if requeued != nil {
+			b = b.Watches(requeued, &handler.EnqueueRequestForObject{},
+				builder.WithPredicates(HasSameType(workload.forObject)))
+		}
+		err = b.Complete(r.Throttle.Reconciler(r.reconcileWorkload(workload.kind)))
 		if err != nil {
 			return err
 		}
@@ -83,9 +152,40 @@ func (r *WorkloadController) SetupWithManager(mgr ctrl.Manager) error {
 		predicates = append(predicates, InNamespace(r.Config.Namespace))
 	}
 	predicates = append(predicates, ManagedByStarboardOperator, IsVulnerabilityReportScan, JobHasAnyCondition)
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&batchv1.Job{}, builder.WithPredicates(predicates...)).
-		Complete(r.reconcileJobs())
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1.Job{}, builder.WithPredicates(predicates...))
+	if r.ResumedJobs != nil {
+		b = b.Watches(&source.Channel{Source: r.ResumedJobs}, &handler.EnqueueRequestForObject{},
+			builder.WithPredicates(predicates...))
+	}
+	return b.Complete(r.Throttle.Reconciler(r.Drain.Reconciler(r.reconcileJobs())))
+}
+
+// workloadsInNamespace returns handler.MapFunc which maps an object to
+// requests of workloads of the specified list type in its namespace.
+func (r *WorkloadController) workloadsInNamespace(list client.ObjectList) handler.MapFunc {
+	return func(obj client.Object) []reconcile.Request {
+		workloads := list.DeepCopyObject().(client.ObjectList)
+		err := r.Client.List(context.Background(), workloads, client.InNamespace(obj.GetNamespace()))
+		if err != nil {
+			r.Logger.Error(err, "Unable to list workloads", "namespace", obj.GetNamespace())
+			return nil
+		}
+		items, err := meta.ExtractList(workloads)
+		if err != nil {
+			r.Logger.Error(err, "Unable to extract workloads", "namespace", obj.GetNamespace())
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, item := range items {
+			workload, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(workload)})
+		}
+		return requests
+	}
 }
 
 func (r *WorkloadController) reconcileWorkload(workloadKind kube.Kind) reconcile.Func {
@@ -113,6 +213,16 @@ func (r *WorkloadController) reconcileWorkload(workloadKind kube.Kind) reconcile
 			}
 		}
 
+		// Skip processing if it's a ReplicaSet controlled by Rollout, which is
+		// scanned instead.
+		if workloadKind == kube.KindReplicaSet && r.rolloutsWatched {
+			controller := metav1.GetControllerOf(workloadObj)
+			if kube.IsRolloutRef(controller) {
+				log.V(1).Info("Ignoring managed replicaset", "controllerKind", controller.Kind, "controllerName", controller.Name)
+				return ctrl.Result{}, nil
+			}
+		}
+
 		if r.Config.VulnerabilityScannerScanOnlyCurrentRevisions && workloadKind == kube.KindReplicaSet {
 			controller := metav1.GetControllerOf(workloadObj)
 			activeReplicaSet, err := r.IsActiveReplicaSet(ctx, workloadObj, controller)
@@ -144,69 +254,292 @@ func (r *WorkloadController) reconcileWorkload(workloadKind kube.Kind) reconcile
 
 		log = log.WithValues("podSpecHash", hash)
 
-		// Check if containers of the Pod have corresponding VulnerabilityReports.
-		hasReports, err := r.hasReports(ctx, workloadRef, hash, containerImages)
-		if err != nil {
-			return ctrl.Result{}, fmt.Errorf("getting vulnerability reports: %w", err)
+		nodeGroups := []NodeGroup{{Images: containerImages}}
+		if workloadKind == kube.KindDaemonSet && r.Config.VulnerabilityScannerDaemonSetNodeGroupLabel != "" {
+			groups, err := GetNodeGroups(ctx, r.Client, workloadObj, r.Config.VulnerabilityScannerDaemonSetNodeGroupLabel,
+				r.Config.VulnerabilityScannerPlatformFromNodes)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("getting node groups: %w", err)
+			}
+			if len(groups) > 0 {
+				nodeGroups = groups
+			}
 		}
-
-		if hasReports {
-			log.V(1).Info("VulnerabilityReports already exist")
-			return ctrl.Result{}, nil
+		if nodeGroups[0].Name == "" {
+			nodeGroups[0].Platform, nodeGroups[0].PlatformWarning, err = r.workloadPlatform(ctx, workloadObj)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("getting platform: %w", err)
+			}
 		}
 
-		_, job, err := r.hasActiveScanJob(ctx, workloadRef, hash)
+		configHash, err := r.namespaceConfigHash(workloadObj.GetNamespace())
 		if err != nil {
-			return ctrl.Result{}, fmt.Errorf("checking scan job: %w", err)
+			return ctrl.Result{}, fmt.Errorf("getting plugin config hash: %w", err)
 		}
 
-		if job != nil {
-			log.V(1).Info("Scan job already exists",
-				"job", fmt.Sprintf("%s/%s", job.Namespace, job.Name))
-			return ctrl.Result{}, nil
+		for _, nodeGroup := range nodeGroups {
+			result, err := r.reconcileNodeGroup(ctx, log, workloadObj, workloadRef, hash, configHash, nodeGroup)
+			if err != nil || !result.IsZero() {
+				return result, err
+			}
 		}
+		return ctrl.Result{}, nil
+	}
+}
 
-		limitExceeded, scanJobsCount, err := r.LimitChecker.Check(ctx)
-		if err != nil {
-			return ctrl.Result{}, err
+// namespaceConfigHash returns the hash of the namespace configuration of the
+// plugin if it implements NamespaceConfigPlugin, or blank otherwise.
+func (r *WorkloadController) namespaceConfigHash(namespace string) (string, error) {
+	plugin, ok := r.Plugin.(NamespaceConfigPlugin)
+	if !ok {
+		return "", nil
+	}
+	return plugin.NamespaceConfigHash(r.PluginContext, namespace)
+}
+
+// workloadPlatform returns the platform for which images of multi-arch image
+// indexes of the specified workload are scanned. Unless the platform is
+// determined from nodes running pods of the workload, the configured default
+// platform is returned. DaemonSets are scanned for the platform of the
+// majority of their pods, with a warning if their pods run on nodes of
+// different platforms.
+func (r *WorkloadController) workloadPlatform(ctx context.Context, workload client.Object) (string, string, error) {
+	if !r.Config.VulnerabilityScannerPlatformFromNodes {
+		return r.Config.VulnerabilityScannerPlatform, "", nil
+	}
+	if _, ok := workload.(*appsv1.DaemonSet); ok {
+		platform, warning, err := GetMajorityPlatform(ctx, r.Client, workload)
+		if err != nil || platform != "" {
+			return platform, warning, err
 		}
-		log.V(1).Info("Checking scan jobs limit", "count", scanJobsCount, "limit", r.ConcurrentScanJobsLimit)
+		return r.Config.VulnerabilityScannerPlatform, "", nil
+	}
+	nodeName, err := r.GetNodeName(ctx, workload)
+	if err != nil {
+		if errors.Is(err, kube.ErrNoRunningPods) || errors.Is(err, kube.ErrReplicaSetNotFound) ||
+			errors.Is(err, kube.ErrUnSupportedKind) {
+			return r.Config.VulnerabilityScannerPlatform, "", nil
+		}
+		return "", "", err
+	}
+	var node corev1.Node
+	err = r.Client.Get(ctx, client.ObjectKey{Name: nodeName}, &node)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			return r.Config.VulnerabilityScannerPlatform, "", nil
+		}
+		return "", "", err
+	}
+	if platform := NodePlatform(node); platform != "" {
+		return platform, "", nil
+	}
+	return r.Config.VulnerabilityScannerPlatform, "", nil
+}
+
+// reconcileNodeGroup submits a scan job of containers of the workload which
+// have no VulnerabilityReports. Unless the workload is a DaemonSet scanned per
+// node group, the group is the zero NodeGroup with images of the workload.
+func (r *WorkloadController) reconcileNodeGroup(ctx context.Context, log logr.Logger, workloadObj client.Object,
+	workloadRef kube.ObjectRef, hash, configHash string, nodeGroup NodeGroup) (ctrl.Result, error) {
+	containerImages := nodeGroup.Images
+	if nodeGroup.Name != "" {
+		log = log.WithValues("nodeGroup", nodeGroup.Name)
+	}
+
+	annotatedContainers, unknownContainers, err := r.annotatedContainers(workloadObj, containerImages)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Containers excluded with the skip-containers annotation and containers
+	// running images from excluded registries get reports stating that they
+	// were skipped instead of being scanned.
+	skipReasons := map[string]v1alpha1.SkipReason{}
+	for containerName := range GetExcludedContainers(containerImages, r.Config.GetSkipScanRegistries()) {
+		skipReasons[containerName] = v1alpha1.SkipReasonRegistryExcluded
+	}
+	for containerName := range annotatedContainers {
+		skipReasons[containerName] = v1alpha1.SkipReasonContainerExcluded
+	}
+
+	// Check if containers of the Pod have corresponding VulnerabilityReports.
+	missing, err := r.missingReports(ctx, workloadRef, hash, configHash, nodeGroup.Name, containerImages, skipReasons)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting vulnerability reports: %w", err)
+	}
+
+	if len(missing) == 0 {
+		log.V(1).Info("VulnerabilityReports already exist")
+		return ctrl.Result{}, nil
+	}
+
+	if len(unknownContainers) > 0 && r.Recorder != nil {
+		r.Recorder.Eventf(workloadObj, corev1.EventTypeWarning, ReasonUnknownSkippedContainers,
+			"Annotation %s lists unknown containers: %s", starboard.AnnotationSkipContainers, strings.Join(unknownContainers, ", "))
+	}
 
-		if limitExceeded {
-			log.V(1).Info("Pushing back scan job", "count", scanJobsCount, "retryAfter", r.ScanJobRetryAfter)
-			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+	skipped := kube.ContainerImages{}
+	for _, reason := range []v1alpha1.SkipReason{v1alpha1.SkipReasonContainerExcluded, v1alpha1.SkipReasonRegistryExcluded} {
+		missingSkipped := kube.ContainerImages{}
+		for containerName, containerReason := range skipReasons {
+			if containerReason != reason {
+				continue
+			}
+			skipped[containerName] = containerImages[containerName]
+			if containerImage, ok := missing[containerName]; ok {
+				missingSkipped[containerName] = containerImage
+				delete(missing, containerName)
+			}
+		}
+		if len(missingSkipped) > 0 {
+			log.V(1).Info("Skipping scan of containers", "reason", reason, "containers", missingSkipped)
+			err = r.writeSkippedReports(ctx, workloadObj, hash, configHash, nodeGroup, missingSkipped, reason)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("writing skipped vulnerability reports: %w", err)
+			}
 		}
+	}
+	if len(missing) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	_, job, err := r.hasActiveScanJob(ctx, workloadRef, hash, configHash, nodeGroup.Name)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("checking scan job: %w", err)
+	}
 
-		return ctrl.Result{}, r.submitScanJob(ctx, workloadObj)
+	if job != nil {
+		log.V(1).Info("Scan job already exists",
+			"job", fmt.Sprintf("%s/%s", job.Namespace, job.Name))
+		return ctrl.Result{}, nil
 	}
+
+	limitExceeded, scanJobsCount, err := r.LimitChecker.Check(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	log.V(1).Info("Checking scan jobs limit", "count", scanJobsCount, "limit", r.ConcurrentScanJobsLimit)
+
+	if limitExceeded {
+		log.V(1).Info("Pushing back scan job", "count", scanJobsCount, "retryAfter", r.ScanJobRetryAfter)
+		return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+	}
+
+	return ctrl.Result{}, r.submitScanJob(ctx, workloadObj, skipped, configHash, nodeGroup)
 }
 
-func (r *WorkloadController) hasReports(ctx context.Context, owner kube.ObjectRef, hash string, images kube.ContainerImages) (bool, error) {
+// missingReports returns containers of the specified images which have no
+// up to date VulnerabilityReports, see GetMissingReports.
+func (r *WorkloadController) missingReports(ctx context.Context, owner kube.ObjectRef, hash, configHash string, nodeGroup string,
+	images kube.ContainerImages, skipReasons map[string]v1alpha1.SkipReason) (kube.ContainerImages, error) {
 	// TODO FindByOwner should accept optional label selector to further narrow down search results
 	list, err := r.FindByOwner(ctx, owner)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
+	return GetMissingReports(list, hash, configHash, nodeGroup, images, skipReasons), nil
+}
 
-	actual := map[string]bool{}
-	for _, report := range list {
-		if containerName, ok := report.Labels[starboard.LabelContainerName]; ok {
-			if hash == report.Labels[starboard.LabelResourceSpecHash] {
-				actual[containerName] = true
-			}
-		}
+// annotatedContainers returns containers of the specified images which are
+// excluded from scanning with the skip-containers annotation of the pod
+// template of the workload, and names of unknown containers listed in the
+// annotation.
+func (r *WorkloadController) annotatedContainers(workload client.Object, images kube.ContainerImages) (kube.ContainerImages, []string, error) {
+	annotations, err := kube.GetPodTemplateAnnotations(workload)
+	if err != nil {
+		return nil, nil, err
+	}
+	containers, unknown := GetAnnotatedContainers(annotations, images)
+	return containers, unknown, nil
+}
+
+// writeSkippedReports writes VulnerabilityReports of the specified containers
+// of the owner, which were skipped rather than scanned for the specified
+// reason.
+func (r *WorkloadController) writeSkippedReports(ctx context.Context, owner client.Object, hash, configHash string, nodeGroup NodeGroup, containers kube.ContainerImages, reason v1alpha1.SkipReason) error {
+	scanner := v1alpha1.Scanner{
+		Name:    "Starboard",
+		Vendor:  "Aqua Security",
+		Version: r.BuildInfo.Version,
 	}
+	var reports []v1alpha1.VulnerabilityReport
+	for containerName, containerImage := range containers {
+		reportData, err := NewSkippedReportData(containerImage, reason, scanner, time.Now())
+		if err != nil {
+			return err
+		}
+		reportBuilder := NewReportBuilder(r.Client.Scheme()).
+			Controller(owner).
+			Container(containerName).
+			Data(reportData).
+			PodSpecHash(hash).
+			PluginConfigHash(configHash).
+			NodeGroup(nodeGroup).
+			Initiator(v1alpha1.InitiatorOperator).
+			SkipOwnerReference(r.Config.SkipOwnerReference())
 
-	expected := map[string]bool{}
-	for containerName := range images {
-		expected[containerName] = true
+		if r.Config.VulnerabilityScannerReportTTL != nil {
+			reportBuilder.ReportTTL(r.Config.VulnerabilityScannerReportTTL)
+		}
+
+		report, err := reportBuilder.Get()
+		if err != nil {
+			return err
+		}
+		reports = append(reports, report)
+	}
+	err := r.ReadWriter.Write(ctx, reports)
+	if err != nil {
+		return err
 	}
+	return r.writeWorkloadSummary(ctx, owner, hash, reports)
+}
 
-	return reflect.DeepEqual(actual, expected), nil
+// writeWorkloadSummary writes the WorkloadVulnerabilitySummary of the owner,
+// if enabled, once the given reports of a scan are written. The summary
+// consolidates them with other reports of the owner with the same pod spec
+// hash, e.g. reports of skipped containers or of other node groups. The given
+// reports take precedence over listed ones, which may not be cached yet.
+func (r *WorkloadController) writeWorkloadSummary(ctx context.Context, owner client.Object, hash string, written []v1alpha1.VulnerabilityReport) error {
+	if !r.Config.VulnerabilityScannerWorkloadSummaryEnabled {
+		return nil
+	}
+	var listed v1alpha1.VulnerabilityReportList
+	labels := client.MatchingLabels(kube.ObjectRefToLabels(kube.ObjectRef{
+		Kind:      kube.Kind(owner.GetObjectKind().GroupVersionKind().Kind),
+		Name:      owner.GetName(),
+		Namespace: owner.GetNamespace(),
+	}))
+	err := r.Client.List(ctx, &listed, labels, client.InNamespace(owner.GetNamespace()))
+	if err != nil {
+		return fmt.Errorf("listing vulnerability reports: %w", err)
+	}
+	reports := make(map[string]v1alpha1.VulnerabilityReport)
+	for _, report := range listed.Items {
+		if report.Labels[starboard.LabelResourceSpecHash] == hash {
+			reports[report.Name] = report
+		}
+	}
+	for _, report := range written {
+		reports[report.Name] = report
+	}
+	var consolidated []v1alpha1.VulnerabilityReport
+	for _, report := range reports {
+		consolidated = append(consolidated, report)
+	}
+	summary, err := NewWorkloadSummary(r.Client.Scheme(), owner, consolidated, r.Config.SkipOwnerReference(), time.Now())
+	if err != nil {
+		return err
+	}
+	err = WriteWorkloadSummary(ctx, r.Client, summary)
+	if err != nil {
+		return fmt.Errorf("writing workload vulnerability summary: %w", err)
+	}
+	return nil
 }
 
-func (r *WorkloadController) hasActiveScanJob(ctx context.Context, owner kube.ObjectRef, hash string) (bool, *batchv1.Job, error) {
-	jobName := fmt.Sprintf("scan-vulnerabilityreport-%s", kube.ComputeHash(owner))
+func (r *WorkloadController) hasActiveScanJob(ctx context.Context, owner kube.ObjectRef, hash, configHash string, nodeGroup string) (bool, *batchv1.Job, error) {
+	jobName := ScanJobName(owner, nodeGroup)
 	job := &batchv1.Job{}
 	err := r.Get(ctx, client.ObjectKey{Namespace: r.Config.Namespace, Name: jobName}, job)
 	if err != nil {
@@ -215,13 +548,13 @@ func (r *WorkloadController) hasActiveScanJob(ctx context.Context, owner kube.Ob
 		}
 		return false, nil, fmt.Errorf("getting job from cache: %w", err)
 	}
-	if job.Labels[starboard.LabelResourceSpecHash] == hash {
+	if job.Labels[starboard.LabelResourceSpecHash] == hash && job.Labels[starboard.LabelPluginConfigHash] == configHash {
 		return true, job, nil
 	}
 	return false, nil, nil
 }
 
-func (r *WorkloadController) submitScanJob(ctx context.Context, owner client.Object) error {
+func (r *WorkloadController) submitScanJob(ctx context.Context, owner client.Object, skipped kube.ContainerImages, configHash string, nodeGroup NodeGroup) error {
 	log := r.Logger.WithValues("kind", owner.GetObjectKind().GroupVersionKind().Kind,
 		"name", owner.GetName(), "namespace", owner.GetNamespace())
 	credentials, err := r.CredentialsByWorkload(ctx, owner)
@@ -244,6 +577,11 @@ func (r *WorkloadController) submitScanJob(ctx context.Context, owner client.Obj
 		return fmt.Errorf("getting scan job template labels: %w", err)
 	}
 
+	scanJobTTL, err := starboard.GetScanJobTTLSecondsAfterFinished(r.PluginContext, r.ConfigData)
+	if err != nil {
+		return fmt.Errorf("getting scan job TTL: %w", err)
+	}
+
 	scanJob, secrets, err := NewScanJobBuilder().
 		WithPlugin(r.Plugin).
 		WithPluginContext(r.PluginContext).
@@ -252,7 +590,11 @@ func (r *WorkloadController) submitScanJob(ctx context.Context, owner client.Obj
 		WithTolerations(scanJobTolerations).
 		WithAnnotations(scanJobAnnotations).
 		WithPodTemplateLabels(scanJobPodTemplateLabels).
+		WithTTLSecondsAfterFinished(scanJobTTL).
 		WithCredentials(credentials).
+		WithSkippedContainers(skipped).
+		WithNodeGroup(nodeGroup).
+		WithPluginConfigHash(configHash).
 		Get()
 
 	if err != nil {
@@ -274,10 +616,13 @@ func (r *WorkloadController) submitScanJob(ctx context.Context, owner client.Obj
 		}
 	}
 
+	r.ScanJobsSwitch.ApplyTo(scanJob)
+
 	err = r.Client.Create(ctx, scanJob)
 	if err != nil {
 		if k8sapierror.IsAlreadyExists(err) {
-			// TODO Delete secrets that were created in the previous step. Alternatively we can delete them on schedule.
+			// Secrets created in the previous step are either owned by the existing
+			// scan job or eventually deleted by controller.ScanJobSecretReconciler.
 			return nil
 		}
 		return fmt.Errorf("creating scan job failed: %s: %w", scanJob.Namespace+"/"+scanJob.Name, err)
@@ -311,12 +656,13 @@ func (r *WorkloadController) reconcileJobs() reconcile.Func {
 			return ctrl.Result{}, fmt.Errorf("getting job from cache: %w", err)
 		}
 
-		if len(job.Status.Conditions) == 0 {
+		condition, finished := kube.JobFinishedCondition(job)
+		if !finished {
 			log.V(1).Info("Ignoring Job without conditions")
 			return ctrl.Result{}, nil
 		}
 
-		switch jobCondition := job.Status.Conditions[0].Type; jobCondition {
+		switch jobCondition := condition.Type; jobCondition {
 		case batchv1.JobComplete:
 			err = r.processCompleteScanJob(ctx, job)
 		case batchv1.JobFailed:
@@ -357,12 +703,16 @@ func (r *WorkloadController) processCompleteScanJob(ctx context.Context, job *ba
 		return fmt.Errorf("expected label %s not set", starboard.LabelResourceSpecHash)
 	}
 
-	hasReports, err := r.hasReports(ctx, ownerRef, podSpecHash, containerImages)
+	configHash := job.Labels[starboard.LabelPluginConfigHash]
+	nodeGroup := NodeGroupFromObjectMeta(job.ObjectMeta)
+
+	// Containers of scan jobs are scanned rather than skipped.
+	missing, err := r.missingReports(ctx, ownerRef, podSpecHash, configHash, nodeGroup.Name, containerImages, nil)
 	if err != nil {
 		return err
 	}
 
-	if hasReports {
+	if len(missing) == 0 {
 		log.V(1).Info("VulnerabilityReports already exist", "owner", owner)
 		log.V(1).Info("Deleting complete scan job", "owner", owner)
 		return r.deleteJob(ctx, job)
@@ -370,6 +720,9 @@ func (r *WorkloadController) processCompleteScanJob(ctx context.Context, job *ba
 
 	var vulnerabilityReports []v1alpha1.VulnerabilityReport
 
+	sbom := getSBOMResults(ctx, r.LogsReader, r.Plugin, r.PluginContext, job, owner, containerImages)
+	pluginContext := starboard.WithScannerImage(r.PluginContext, job.Annotations[starboard.AnnotationScannerImage])
+
 	for containerName, containerImage := range containerImages {
 		logsStream, err := r.LogsReader.GetLogsByJobAndContainerName(ctx, job, containerName)
 		if err != nil {
@@ -383,17 +736,33 @@ func (r *WorkloadController) processCompleteScanJob(ctx context.Context, job *ba
 			}
 			return fmt.Errorf("getting logs for pod %q: %w", job.Namespace+"/"+job.Name, err)
 		}
-		reportData, err := r.Plugin.ParseVulnerabilityReportData(r.PluginContext, containerImage, logsStream)
+		reportData, err := r.Plugin.ParseVulnerabilityReportData(pluginContext, containerImage, logsStream)
+		_ = logsStream.Close()
 		if err != nil {
+			if starboard.IsScannerMismatch(err) {
+				// Do not persist questionable results. The workload is scanned
+				// again as it has no reports.
+				log.Error(err, "Rejected scan job results", "container", containerName, "failure", ScanJobFailureScannerMismatch)
+				return r.deleteJob(ctx, job)
+			}
 			return err
 		}
-		_ = logsStream.Close()
+
+		data := sbom.mergeInto(reportData)
+		if err := r.Exploitability.Enrich(ctx, &data); err != nil {
+			// Do not hold back the report, it's prioritized at the next scan.
+			log.Error(err, "Unable to prioritize vulnerabilities", "container", containerName)
+		}
 
 		reportBuilder := NewReportBuilder(r.Client.Scheme()).
 			Controller(owner).
 			Container(containerName).
-			Data(reportData).
-			PodSpecHash(podSpecHash)
+			Data(data).
+			PodSpecHash(podSpecHash).
+			PluginConfigHash(configHash).
+			NodeGroup(nodeGroup).
+			Initiator(v1alpha1.InitiatorOperator).
+			SkipOwnerReference(r.Config.SkipOwnerReference())
 
 		if r.Config.VulnerabilityScannerReportTTL != nil {
 			reportBuilder.ReportTTL(r.Config.VulnerabilityScannerReportTTL)
@@ -413,6 +782,11 @@ func (r *WorkloadController) processCompleteScanJob(ctx context.Context, job *ba
 		return err
 	}
 
+	err = r.writeWorkloadSummary(ctx, owner, podSpecHash, vulnerabilityReports)
+	if err != nil {
+		return err
+	}
+
 	log.V(1).Info("Deleting complete scan job", "owner", owner)
 	return r.deleteJob(ctx, job)
 }
@@ -436,7 +810,12 @@ func (r *WorkloadController) processFailedScanJob(ctx context.Context, scanJob *
 		if status.ExitCode == 0 {
 			continue
 		}
-		log.Error(nil, "Scan job container", "container", container, "status.reason", status.Reason, "status.message", status.Message)
+		log.Error(nil, "Scan job container", "container", container, "failure", ScanJobFailureFrom(status),
+			"status.reason", status.Reason, "status.message", status.Message)
+		if r.ScanFailures != nil {
+			owner, _ := kube.ObjectRefFromObjectMeta(scanJob.ObjectMeta)
+			r.ScanFailures.RecordScanFailure(v1alpha1.VulnerabilityReportKind, owner, metrics.ScanFailureReason(container, status))
+		}
 	}
 	log.V(1).Info("Deleting failed scan job")
 	return r.deleteJob(ctx, scanJob)
@@ -0,0 +1,170 @@
+package vulnerabilityreport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CommandRunner runs commands of the DirectScanner.
+type CommandRunner interface {
+	// Run runs the named program with the specified arguments and environment
+	// variables, in addition to the ones of the current process, and writes
+	// its standard output to stdout.
+	Run(ctx context.Context, name string, args []string, env []string, stdout io.Writer) error
+}
+
+type execRunner struct {
+}
+
+// NewExecRunner constructs a CommandRunner which executes programs with the
+// os/exec package.
+func NewExecRunner() CommandRunner {
+	return &execRunner{}
+}
+
+func (r *execRunner) Run(ctx context.Context, name string, args []string, env []string, stdout io.Writer) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("running %s: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// DirectScanOpts holds configuration of the DirectScanner.
+type DirectScanOpts struct {
+	// ScannerPath is the path of the scanner binary. If blank, the binary is
+	// looked up in PATH, and the scanner runs with docker if it's not found.
+	ScannerPath string
+	// Runner runs scanner commands. If nil, commands are executed with the
+	// os/exec package.
+	Runner CommandRunner
+}
+
+// DirectScanner scans container images of workloads synchronously by running
+// the scanner in the current process, e.g. in CI environments where scan jobs
+// are slow to schedule. Registry credentials of workloads are resolved, and
+// scanner output is converted to instances of v1alpha1.VulnerabilityReport,
+// in the same way as by the Scanner.
+type DirectScanner struct {
+	scheme         *runtime.Scheme
+	plugin         Plugin
+	directPlugin   DirectScanPlugin
+	pluginContext  starboard.PluginContext
+	objectResolver *kube.ObjectResolver
+	secretsReader  kube.SecretsReader
+	opts           DirectScanOpts
+}
+
+// NewDirectScanner constructs a new DirectScanner with the specified Plugin,
+// which must implement the DirectScanPlugin interface.
+func NewDirectScanner(
+	client client.Client,
+	plugin Plugin,
+	pluginContext starboard.PluginContext,
+	opts DirectScanOpts,
+) (*DirectScanner, error) {
+	directPlugin, ok := plugin.(DirectScanPlugin)
+	if !ok {
+		return nil, fmt.Errorf("%s plugin does not support direct scans", pluginContext.GetName())
+	}
+	if opts.Runner == nil {
+		opts.Runner = NewExecRunner()
+	}
+	return &DirectScanner{
+		scheme:         client.Scheme(),
+		plugin:         plugin,
+		directPlugin:   directPlugin,
+		pluginContext:  pluginContext,
+		objectResolver: &kube.ObjectResolver{Client: client},
+		secretsReader:  kube.NewSecretsReader(client),
+		opts:           opts,
+	}, nil
+}
+
+// Scan runs the scanner for each container image of the specified workload
+// and converts its output to instances of v1alpha1.VulnerabilityReport. It is
+// a blocking method which returns when all images are scanned.
+func (s *DirectScanner) Scan(ctx context.Context, workload kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error) {
+	owner, credentials, err := resolveScanTarget(ctx, s.objectResolver, s.secretsReader, workload)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := kube.GetPodSpec(owner)
+	if err != nil {
+		return nil, err
+	}
+	podSpecHash := kube.ComputeHash(spec)
+
+	var reports []v1alpha1.VulnerabilityReport
+	for _, container := range spec.Containers {
+		var auth *docker.Auth
+		if containerAuth, ok := credentials[container.Name]; ok {
+			auth = &containerAuth
+		}
+		command, err := s.directPlugin.GetDirectScanCommand(s.pluginContext, owner, container.Image, auth)
+		if err != nil {
+			return nil, err
+		}
+
+		klog.V(3).Infof("Scanning image %s of container %s", container.Image, container.Name)
+		var stdout bytes.Buffer
+		if err = s.run(ctx, command, &stdout); err != nil {
+			return nil, fmt.Errorf("scanning image %s of container %s: %w", container.Image, container.Name, err)
+		}
+
+		data, err := s.plugin.ParseVulnerabilityReportData(s.pluginContext, container.Image, ioutil.NopCloser(&stdout))
+		if err != nil {
+			return nil, err
+		}
+
+		report, err := newCLIReport(s.scheme, owner, container.Name, podSpecHash, data)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// run runs the scanner binary at the configured path, or found in PATH.
+// Otherwise, it runs the scanner image with docker. Environment variables are
+// passed to the docker container by name, so that their values, e.g. registry
+// passwords, are not visible in arguments of the docker process.
+func (s *DirectScanner) run(ctx context.Context, command DirectScanCommand, stdout io.Writer) error {
+	if s.opts.ScannerPath != "" {
+		return s.opts.Runner.Run(ctx, s.opts.ScannerPath, command.Args, command.Env, stdout)
+	}
+	if path, err := exec.LookPath(command.Binary); err == nil {
+		return s.opts.Runner.Run(ctx, path, command.Args, command.Env, stdout)
+	}
+	dockerPath, err := exec.LookPath("docker")
+	if err != nil {
+		return fmt.Errorf("neither %s nor docker found in PATH", command.Binary)
+	}
+	klog.V(3).Infof("Running %s with docker", command.Image)
+	args := []string{"run", "--rm"}
+	for _, env := range command.Env {
+		args = append(args, "--env", strings.SplitN(env, "=", 2)[0])
+	}
+	args = append(append(args, command.Image), command.Args...)
+	return s.opts.Runner.Run(ctx, dockerPath, args, command.Env, stdout)
+}
@@ -0,0 +1,166 @@
+package vulnerabilityreport_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// directTestPlugin scans images with a command which outputs the identifier
+// of a vulnerability.
+type directTestPlugin struct {
+	testPlugin
+}
+
+func (p *directTestPlugin) GetDirectScanCommand(_ starboard.PluginContext, _ client.Object, image string, credentials *docker.Auth) (vulnerabilityreport.DirectScanCommand, error) {
+	command := vulnerabilityreport.DirectScanCommand{
+		Binary: "scanner-binary-which-does-not-exist",
+		Image:  "scanner:1.0",
+		Args:   []string{"image", image},
+	}
+	if credentials != nil {
+		command.Env = []string{"SCANNER_USERNAME=" + credentials.Username, "SCANNER_PASSWORD=" + credentials.Password}
+	}
+	return command, nil
+}
+
+func (p *directTestPlugin) ParseVulnerabilityReportData(_ starboard.PluginContext, _ string, logsReader io.ReadCloser) (v1alpha1.VulnerabilityReportData, error) {
+	output, err := ioutil.ReadAll(logsReader)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	return v1alpha1.VulnerabilityReportData{
+		Vulnerabilities: []v1alpha1.Vulnerability{{VulnerabilityID: strings.TrimSpace(string(output))}},
+	}, nil
+}
+
+type runCommand struct {
+	name string
+	args []string
+	env  []string
+}
+
+// fakeRunner records commands and outputs the last argument in uppercase.
+type fakeRunner struct {
+	commands []runCommand
+}
+
+func (r *fakeRunner) Run(_ context.Context, name string, args []string, env []string, stdout io.Writer) error {
+	r.commands = append(r.commands, runCommand{name: name, args: args, env: env})
+	_, err := stdout.Write([]byte(strings.ToUpper(args[len(args)-1])))
+	return err
+}
+
+func TestDirectScanner_Scan(t *testing.T) {
+	pullSecret, err := kube.NewImagePullSecret(metav1.ObjectMeta{Name: "private-registry", Namespace: "default"},
+		"registry.example.com", "scanner", "s3cret")
+	require.NoError(t, err)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "private-registry"}},
+			Containers: []corev1.Container{
+				{Name: "nginx", Image: "nginx:1.16"},
+				{Name: "app", Image: "registry.example.com/app:1.0"},
+			},
+		},
+	}
+	serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}}
+	kubeClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+		WithObjects(pod, pullSecret, serviceAccount).Build()
+	pluginContext := starboard.NewPluginContext().WithName("test-plugin").Get()
+	workload := kube.ObjectRef{Kind: kube.KindPod, Name: "nginx", Namespace: "default"}
+
+	t.Run("Should run scanner binary at configured path", func(t *testing.T) {
+		runner := &fakeRunner{}
+		scanner, err := vulnerabilityreport.NewDirectScanner(kubeClient, &directTestPlugin{}, pluginContext,
+			vulnerabilityreport.DirectScanOpts{ScannerPath: "/usr/local/bin/scanner", Runner: runner})
+		require.NoError(t, err)
+
+		reports, err := scanner.Scan(context.TODO(), workload)
+		require.NoError(t, err)
+		assert.Equal(t, []runCommand{
+			{name: "/usr/local/bin/scanner", args: []string{"image", "nginx:1.16"}},
+			{name: "/usr/local/bin/scanner", args: []string{"image", "registry.example.com/app:1.0"},
+				env: []string{"SCANNER_USERNAME=scanner", "SCANNER_PASSWORD=s3cret"}},
+		}, runner.commands)
+
+		require.Len(t, reports, 2)
+		assert.Equal(t, "pod-nginx-nginx", reports[0].Name)
+		assert.Equal(t, "NGINX:1.16", reports[0].Report.Vulnerabilities[0].VulnerabilityID)
+		assert.Equal(t, "pod-nginx-app", reports[1].Name)
+		assert.Equal(t, "app", reports[1].Labels[starboard.LabelContainerName])
+		assert.Equal(t, "REGISTRY.EXAMPLE.COM/APP:1.0", reports[1].Report.Vulnerabilities[0].VulnerabilityID)
+	})
+
+	t.Run("Should run scanner image with docker when binary is not found", func(t *testing.T) {
+		dir := t.TempDir()
+		dockerPath := filepath.Join(dir, "docker")
+		require.NoError(t, ioutil.WriteFile(dockerPath, []byte("#!/bin/sh\n"), 0755))
+		t.Setenv("PATH", dir)
+
+		runner := &fakeRunner{}
+		scanner, err := vulnerabilityreport.NewDirectScanner(kubeClient, &directTestPlugin{}, pluginContext,
+			vulnerabilityreport.DirectScanOpts{Runner: runner})
+		require.NoError(t, err)
+
+		_, err = scanner.Scan(context.TODO(), workload)
+		require.NoError(t, err)
+		require.Len(t, runner.commands, 2)
+		assert.Equal(t, runCommand{
+			name: dockerPath,
+			args: []string{"run", "--rm", "--env", "SCANNER_USERNAME", "--env", "SCANNER_PASSWORD",
+				"scanner:1.0", "image", "registry.example.com/app:1.0"},
+			env: []string{"SCANNER_USERNAME=scanner", "SCANNER_PASSWORD=s3cret"},
+		}, runner.commands[1])
+	})
+
+	t.Run("Should return error when neither binary nor docker is found", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+
+		scanner, err := vulnerabilityreport.NewDirectScanner(kubeClient, &directTestPlugin{}, pluginContext,
+			vulnerabilityreport.DirectScanOpts{Runner: &fakeRunner{}})
+		require.NoError(t, err)
+
+		_, err = scanner.Scan(context.TODO(), workload)
+		assert.EqualError(t, err, "scanning image nginx:1.16 of container nginx: "+
+			"neither scanner-binary-which-does-not-exist nor docker found in PATH")
+	})
+
+	t.Run("Should return error when plugin does not support direct scans", func(t *testing.T) {
+		_, err := vulnerabilityreport.NewDirectScanner(kubeClient, &testPlugin{}, pluginContext,
+			vulnerabilityreport.DirectScanOpts{})
+		assert.EqualError(t, err, "test-plugin plugin does not support direct scans")
+	})
+}
+
+func TestExecRunner_Run(t *testing.T) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("Skipping test which requires /bin/sh")
+	}
+	var stdout strings.Builder
+	err := vulnerabilityreport.NewExecRunner().Run(context.TODO(), "/bin/sh",
+		[]string{"-c", "echo $SCANNER_USERNAME"}, []string{"SCANNER_USERNAME=scanner"}, &stdout)
+	require.NoError(t, err)
+	assert.Equal(t, "scanner\n", stdout.String())
+
+	err = vulnerabilityreport.NewExecRunner().Run(context.TODO(), "/bin/sh",
+		[]string{"-c", "echo failed >&2; exit 3"}, nil, &stdout)
+	assert.EqualError(t, err, "running /bin/sh: exit status 3: failed")
+}
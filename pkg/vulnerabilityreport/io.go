@@ -6,17 +6,37 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/quota"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// PackagesInlineLimit is the maximum number of packages stored inline in
+// v1alpha1.VulnerabilityReport. Larger inventories are stored in the
+// v1alpha1.PackageInventory with the same name as the report so that the
+// report does not exceed the size limit of Kubernetes objects.
+const PackagesInlineLimit = 500
+
 // Writer is the interface that wraps the basic Write method.
 //
 // Write creates or updates the given slice of v1alpha1.VulnerabilityReport
-// instances.
+// instances. If a report lists more than PackagesInlineLimit packages, the
+// packages are written to the corresponding v1alpha1.PackageInventory.
+//
+// A report is identified by its owner, container, and scanner, regardless of
+// whether it was written by Starboard Operator or Starboard CLI. Existing
+// reports with the same identity but a different name are replaced.
+//
+// With the kube.DryRun option reports are validated by the API server, but
+// not persisted, and errors returned by admission webhooks are returned as is.
 type Writer interface {
-	Write(context.Context, []v1alpha1.VulnerabilityReport) error
+	Write(context.Context, []v1alpha1.VulnerabilityReport, ...kube.WriteOption) error
 }
 
 // Reader is the interface that wraps methods for finding v1alpha1.VulnerabilityReport objects.
@@ -28,9 +48,14 @@ type Writer interface {
 // v1alpha1.VulnerabilityReport objects owned by related Kubernetes objects.
 // For example, if the given owner is a Deployment, but reports are owned by the
 // active ReplicaSet (current revision) this method will return the reports.
+//
+// FindPackages returns packages listed in the given v1alpha1.VulnerabilityReport
+// or in the corresponding v1alpha1.PackageInventory, or an empty slice if the
+// packages are not found.
 type Reader interface {
 	FindByOwner(context.Context, kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error)
 	FindByOwnerInHierarchy(ctx context.Context, object kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error)
+	FindPackages(ctx context.Context, report v1alpha1.VulnerabilityReport) ([]v1alpha1.Package, error)
 }
 
 type ReadWriter interface {
@@ -40,20 +65,30 @@ type ReadWriter interface {
 
 type readWriter struct {
 	*kube.ObjectResolver
+	quota quota.Quota
 }
 
 // NewReadWriter constructs a new ReadWriter which is using the client package
 // provided by the controller-runtime libraries for interacting with the
 // Kubernetes API server.
 func NewReadWriter(client client.Client) ReadWriter {
+	return NewReadWriterWithQuota(client, nil)
+}
+
+// NewReadWriterWithQuota is similar to NewReadWriter except that the returned
+// ReadWriter admits new reports with the given quota.Quota before it creates
+// them. A nil quota.Quota admits all reports.
+func NewReadWriterWithQuota(client client.Client, quota quota.Quota) ReadWriter {
 	return &readWriter{
 		ObjectResolver: &kube.ObjectResolver{Client: client},
+		quota:          quota,
 	}
 }
 
-func (r *readWriter) Write(ctx context.Context, reports []v1alpha1.VulnerabilityReport) error {
+func (r *readWriter) Write(ctx context.Context, reports []v1alpha1.VulnerabilityReport, opts ...kube.WriteOption) error {
+	options := kube.NewWriteOptions(opts...)
 	for _, report := range reports {
-		err := r.createOrUpdate(ctx, report)
+		err := r.createOrUpdate(ctx, report, options)
 		if err != nil {
 			return err
 		}
@@ -61,23 +96,181 @@ func (r *readWriter) Write(ctx context.Context, reports []v1alpha1.Vulnerability
 	return nil
 }
 
-func (r *readWriter) createOrUpdate(ctx context.Context, report v1alpha1.VulnerabilityReport) error {
-	var existing v1alpha1.VulnerabilityReport
-	err := r.Get(ctx, types.NamespacedName{
+func (r *readWriter) createOrUpdate(ctx context.Context, report v1alpha1.VulnerabilityReport, options kube.WriteOptions) error {
+	// The package inventory is written after the report, otherwise it might
+	// be garbage collected as stale before the report is created.
+	inventory := report
+	if len(report.Report.Packages) > PackagesInlineLimit {
+		report.Report.Packages = nil
+	}
+
+	err := r.createOrUpdateReport(ctx, report, options)
+	if err != nil {
+		return err
+	}
+
+	if len(inventory.Report.Packages) > PackagesInlineLimit {
+		err = r.createOrUpdatePackageInventory(ctx, inventory, options)
+		if err != nil {
+			return fmt.Errorf("writing package inventory: %w", err)
+		}
+		return nil
+	}
+
+	// Reports list packages only if the scanner is configured to list all
+	// packages, which is the only case where an inventory might have been
+	// written for a previous version of the report. The packages fit inline,
+	// so such an inventory is stale.
+	if len(inventory.Report.Packages) == 0 {
+		return nil
+	}
+	err = r.Delete(ctx, &v1alpha1.PackageInventory{ObjectMeta: metav1.ObjectMeta{
 		Name:      report.Name,
 		Namespace: report.Namespace,
+	}}, options.DeleteOptions()...)
+	if err != nil && !isInventoryNotFound(err) {
+		return fmt.Errorf("deleting package inventory: %w", err)
+	}
+	return nil
+}
+
+// isInventoryNotFound returns true if the specified error is returned because
+// a v1alpha1.PackageInventory is not found, or because its CRD, which is
+// optional unless the scanner lists all packages, is not installed.
+func isInventoryNotFound(err error) bool {
+	return errors.IsNotFound(err) || meta.IsNoMatchError(err)
+}
+
+func (r *readWriter) createOrUpdateReport(ctx context.Context, report v1alpha1.VulnerabilityReport, options kube.WriteOptions) error {
+	// The operator and the CLI may write a report for the same container
+	// concurrently, in which case the write is retried with the latest state.
+	return retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return errors.IsConflict(err) || errors.IsAlreadyExists(err)
+	}, func() error {
+		err := r.deleteVariants(ctx, report, options)
+		if err != nil {
+			return err
+		}
+
+		var existing v1alpha1.VulnerabilityReport
+		err = r.Get(ctx, types.NamespacedName{
+			Name:      report.Name,
+			Namespace: report.Namespace,
+		}, &existing)
+
+		if err == nil {
+			copied := existing.DeepCopy()
+			copied.Labels = report.Labels
+			if len(report.Annotations) > 0 && copied.Annotations == nil {
+				copied.Annotations = make(map[string]string)
+			}
+			for key, value := range report.Annotations {
+				copied.Annotations[key] = value
+			}
+			copied.Report = report.Report
+			trackChanges(copied, &existing)
+
+			return r.Update(ctx, copied, options.UpdateOptions()...)
+		}
+
+		if errors.IsNotFound(err) {
+			trackChanges(&report, nil)
+			// quota admission evicts reports, which must never happen on dry run
+			if r.quota != nil && !options.DryRun {
+				err = r.quota.Admit(ctx, report.Namespace)
+				if err != nil {
+					return fmt.Errorf("admitting vulnerability report: %w", err)
+				}
+			}
+			return r.Create(ctx, &report, options.CreateOptions()...)
+		}
+
+		return err
+	})
+}
+
+// deleteVariants deletes reports, and their package inventories, that have
+// the same owner, container, node group, and scanner as the given report but
+// a different name, e.g. because they were named by a different version of
+// Starboard. Reports without the starboard.LabelNodeGroup label belong to the
+// unnamed node group. Protected variants, see kube.IsProtected, are kept.
+func (r *readWriter) deleteVariants(ctx context.Context, report v1alpha1.VulnerabilityReport, options kube.WriteOptions) error {
+	labels := client.MatchingLabels{}
+	for _, key := range []string{
+		starboard.LabelResourceKind,
+		starboard.LabelResourceName,
+		starboard.LabelResourceNameHash,
+		starboard.LabelResourceNamespace,
+		starboard.LabelContainerName,
+	} {
+		if value, ok := report.Labels[key]; ok {
+			labels[key] = value
+		}
+	}
+	if _, ok := labels[starboard.LabelResourceKind]; !ok {
+		return nil
+	}
+
+	var list v1alpha1.VulnerabilityReportList
+	err := r.List(ctx, &list, labels, client.InNamespace(report.Namespace))
+	if err != nil {
+		return fmt.Errorf("listing vulnerability reports: %w", err)
+	}
+	for i := range list.Items {
+		variant := &list.Items[i]
+		if variant.Name == report.Name ||
+			variant.Report.Scanner.Name != report.Report.Scanner.Name ||
+			variant.Labels[starboard.LabelNodeGroup] != report.Labels[starboard.LabelNodeGroup] ||
+			kube.IsProtected(variant) {
+			continue
+		}
+		err = r.Delete(ctx, variant, options.DeleteOptions()...)
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("deleting vulnerability report %q: %w", variant.Name, err)
+		}
+		err = r.Delete(ctx, &v1alpha1.PackageInventory{ObjectMeta: metav1.ObjectMeta{
+			Name:      variant.Name,
+			Namespace: variant.Namespace,
+		}}, options.DeleteOptions()...)
+		if err != nil && !isInventoryNotFound(err) {
+			return fmt.Errorf("deleting package inventory %q: %w", variant.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *readWriter) createOrUpdatePackageInventory(ctx context.Context, report v1alpha1.VulnerabilityReport, options kube.WriteOptions) error {
+	inventory := v1alpha1.PackageInventory{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            report.Name,
+			Namespace:       report.Namespace,
+			Labels:          report.Labels,
+			Annotations:     report.Annotations,
+			OwnerReferences: report.OwnerReferences,
+		},
+		Report: v1alpha1.PackageInventoryData{
+			UpdateTimestamp: report.Report.UpdateTimestamp,
+			Artifact:        report.Report.Artifact,
+			Packages:        report.Report.Packages,
+		},
+	}
+
+	var existing v1alpha1.PackageInventory
+	err := r.Get(ctx, types.NamespacedName{
+		Name:      inventory.Name,
+		Namespace: inventory.Namespace,
 	}, &existing)
 
 	if err == nil {
 		copied := existing.DeepCopy()
-		copied.Labels = report.Labels
-		copied.Report = report.Report
+		copied.Labels = inventory.Labels
+		copied.Report = inventory.Report
 
-		return r.Update(ctx, copied)
+		return r.Update(ctx, copied, options.UpdateOptions()...)
 	}
 
 	if errors.IsNotFound(err) {
-		return r.Create(ctx, &report)
+		return r.Create(ctx, &inventory, options.CreateOptions()...)
 	}
 
 	return err
@@ -118,5 +311,97 @@ func (r *readWriter) FindByOwnerInHierarchy(ctx context.Context, owner kube.Obje
 		}
 	}
 
+	// no reports found for provided owner, look for reports in the rollout
+	// which manages the replicaset
+	if len(reports) == 0 && (owner.Kind == kube.KindReplicaSet || owner.Kind == kube.KindPod) {
+		rolloutRef, err := r.rolloutRef(ctx, owner)
+		if err != nil || rolloutRef == nil {
+			return reports, err
+		}
+		reports, err = r.FindByOwner(ctx, *rolloutRef)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return reports, nil
 }
+
+// rolloutRef returns the reference to the Rollout which manages the specified
+// ReplicaSet or Pod, or nil if it's not managed by a Rollout.
+func (r *readWriter) rolloutRef(ctx context.Context, object kube.ObjectRef) (*kube.ObjectRef, error) {
+	obj, err := r.ObjectFromObjectRef(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := r.ReportOwner(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	if owner.GetObjectKind().GroupVersionKind().GroupKind() != kube.RolloutGVK.GroupKind() {
+		return nil, nil
+	}
+	return &kube.ObjectRef{Kind: kube.KindRollout, Name: owner.GetName(), Namespace: owner.GetNamespace()}, nil
+}
+
+func (r *readWriter) FindPackages(ctx context.Context, report v1alpha1.VulnerabilityReport) ([]v1alpha1.Package, error) {
+	if len(report.Report.Packages) > 0 {
+		return report.Report.Packages, nil
+	}
+
+	var inventory v1alpha1.PackageInventory
+	err := r.Get(ctx, types.NamespacedName{
+		Name:      report.Name,
+		Namespace: report.Namespace,
+	}, &inventory)
+	if err != nil {
+		if isInventoryNotFound(err) {
+			return []v1alpha1.Package{}, nil
+		}
+		return nil, err
+	}
+
+	return inventory.Report.Packages, nil
+}
+
+// trackChanges counts the generation of the given report, which is written
+// over the existing one, if not nil. LastChangedTimestamp and the
+// starboard.AnnotationChangelog annotation are advanced only if the data of
+// the report, excluding timestamps, differs from the existing one.
+func trackChanges(report *v1alpha1.VulnerabilityReport, existing *v1alpha1.VulnerabilityReport) {
+	data := &report.Report
+	if existing == nil {
+		data.GenerationCount = 1
+		data.LastChangedTimestamp = data.UpdateTimestamp.DeepCopy()
+		return
+	}
+	previous := existing.Report
+	data.GenerationCount = previous.GenerationCount + 1
+	if equality.Semantic.DeepEqual(withoutTimestamps(*data), withoutTimestamps(previous)) {
+		data.LastChangedTimestamp = previous.LastChangedTimestamp
+		if data.LastChangedTimestamp == nil {
+			data.LastChangedTimestamp = previous.UpdateTimestamp.DeepCopy()
+		}
+		return
+	}
+	data.LastChangedTimestamp = data.UpdateTimestamp.DeepCopy()
+	if report.Annotations == nil {
+		report.Annotations = make(map[string]string)
+	}
+	report.Annotations[starboard.AnnotationChangelog] = starboard.Changelog(data.GenerationCount,
+		starboard.CountChange{Name: "criticalCount", Previous: previous.Summary.CriticalCount, Current: data.Summary.CriticalCount},
+		starboard.CountChange{Name: "highCount", Previous: previous.Summary.HighCount, Current: data.Summary.HighCount},
+		starboard.CountChange{Name: "mediumCount", Previous: previous.Summary.MediumCount, Current: data.Summary.MediumCount},
+		starboard.CountChange{Name: "lowCount", Previous: previous.Summary.LowCount, Current: data.Summary.LowCount},
+		starboard.CountChange{Name: "unknownCount", Previous: previous.Summary.UnknownCount, Current: data.Summary.UnknownCount},
+	)
+}
+
+// withoutTimestamps returns the given report data without fields maintained
+// by trackChanges and the update timestamp.
+func withoutTimestamps(data v1alpha1.VulnerabilityReportData) v1alpha1.VulnerabilityReportData {
+	data.UpdateTimestamp = metav1.Time{}
+	data.GenerationCount = 0
+	data.LastChangedTimestamp = nil
+	return data
+}
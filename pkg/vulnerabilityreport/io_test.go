@@ -2,7 +2,10 @@ package vulnerabilityreport_test
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
@@ -10,8 +13,10 @@ import (
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -72,6 +77,9 @@ func TestNewReadWriter(t *testing.T) {
 					},
 					ResourceVersion: "1",
 				},
+				Report: v1alpha1.VulnerabilityReportData{
+					GenerationCount: 1,
+				},
 			},
 			"deployment-app1-container2": {
 				ObjectMeta: metav1.ObjectMeta{
@@ -86,6 +94,9 @@ func TestNewReadWriter(t *testing.T) {
 					},
 					ResourceVersion: "1",
 				},
+				Report: v1alpha1.VulnerabilityReportData{
+					GenerationCount: 1,
+				},
 			},
 		}, reports)
 	})
@@ -174,6 +185,9 @@ func TestNewReadWriter(t *testing.T) {
 					starboard.LabelResourceSpecHash:  "h2",
 				},
 			},
+			Report: v1alpha1.VulnerabilityReportData{
+				GenerationCount: 1,
+			},
 		}, found)
 
 		err = client.Get(context.TODO(), types.NamespacedName{
@@ -198,9 +212,199 @@ func TestNewReadWriter(t *testing.T) {
 					starboard.LabelResourceSpecHash:  "h2",
 				},
 			},
+			Report: v1alpha1.VulnerabilityReportData{
+				GenerationCount: 1,
+			},
 		}, found)
 	})
 
+	t.Run("Should track generations and last change of VulnerabilityReports", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		readWriter := vulnerabilityreport.NewReadWriter(client)
+		newReport := func(updated time.Time, summary v1alpha1.VulnerabilitySummary) v1alpha1.VulnerabilityReport {
+			return v1alpha1.VulnerabilityReport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment-app1-container1",
+					Namespace: "qa",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:      "Deployment",
+						starboard.LabelResourceName:      "app1",
+						starboard.LabelResourceNamespace: "qa",
+						starboard.LabelContainerName:     "container1",
+					},
+				},
+				Report: v1alpha1.VulnerabilityReportData{
+					UpdateTimestamp: metav1.NewTime(updated),
+					Summary:         summary,
+				},
+			}
+		}
+		t1 := time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+		t2 := t1.Add(time.Hour)
+		t3 := t2.Add(time.Hour)
+		get := func() v1alpha1.VulnerabilityReport {
+			var found v1alpha1.VulnerabilityReport
+			require.NoError(t, client.Get(context.TODO(), types.NamespacedName{
+				Namespace: "qa",
+				Name:      "deployment-app1-container1",
+			}, &found))
+			return found
+		}
+
+		require.NoError(t, readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{
+			newReport(t1, v1alpha1.VulnerabilitySummary{CriticalCount: 2, HighCount: 1}),
+		}))
+		found := get()
+		assert.Equal(t, int64(1), found.Report.GenerationCount)
+		assert.Equal(t, t1, found.Report.LastChangedTimestamp.UTC())
+		assert.NotContains(t, found.Annotations, starboard.AnnotationChangelog)
+
+		require.NoError(t, readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{
+			newReport(t2, v1alpha1.VulnerabilitySummary{CriticalCount: 2, HighCount: 1}),
+		}))
+		found = get()
+		assert.Equal(t, int64(2), found.Report.GenerationCount)
+		assert.Equal(t, t2, found.Report.UpdateTimestamp.UTC())
+		assert.Equal(t, t1, found.Report.LastChangedTimestamp.UTC())
+		assert.NotContains(t, found.Annotations, starboard.AnnotationChangelog)
+
+		require.NoError(t, readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{
+			newReport(t3, v1alpha1.VulnerabilitySummary{CriticalCount: 3}),
+		}))
+		found = get()
+		assert.Equal(t, int64(3), found.Report.GenerationCount)
+		assert.Equal(t, t3, found.Report.LastChangedTimestamp.UTC())
+		assert.Equal(t, "generation 3: criticalCount 2 -> 3, highCount 1 -> 0", found.Annotations[starboard.AnnotationChangelog])
+	})
+
+	t.Run("Should replace VulnerabilityReport with the same identity but different name", func(t *testing.T) {
+		labels := map[string]string{
+			starboard.LabelResourceKind:      "ReplicaSet",
+			starboard.LabelResourceName:      "app1-7b8d9",
+			starboard.LabelResourceNamespace: "qa",
+			starboard.LabelContainerName:     "container1",
+		}
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(
+			&v1alpha1.VulnerabilityReport{
+				ObjectMeta: metav1.ObjectMeta{Name: "replicaset-5d8c4bd6f7", Namespace: "qa", Labels: labels},
+				Report:     v1alpha1.VulnerabilityReportData{Scanner: v1alpha1.Scanner{Name: "Trivy"}},
+			},
+			&v1alpha1.VulnerabilityReport{
+				ObjectMeta: metav1.ObjectMeta{Name: "replicaset-app1-7b8d9-container1-grype", Namespace: "qa", Labels: labels},
+				Report:     v1alpha1.VulnerabilityReportData{Scanner: v1alpha1.Scanner{Name: "Grype"}},
+			},
+		).Build()
+		readWriter := vulnerabilityreport.NewReadWriter(client)
+		err := readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "replicaset-app1-7b8d9-container1",
+					Namespace:   "qa",
+					Labels:      labels,
+					Annotations: map[string]string{v1alpha1.ReportInitiatorAnnotation: v1alpha1.InitiatorCLI},
+				},
+				Report: v1alpha1.VulnerabilityReportData{Scanner: v1alpha1.Scanner{Name: "Trivy"}},
+			},
+		})
+		require.NoError(t, err)
+
+		var list v1alpha1.VulnerabilityReportList
+		require.NoError(t, client.List(context.TODO(), &list))
+		names := map[string]string{}
+		for _, item := range list.Items {
+			names[item.Name] = item.Annotations[v1alpha1.ReportInitiatorAnnotation]
+		}
+		assert.Equal(t, map[string]string{
+			"replicaset-app1-7b8d9-container1":       v1alpha1.InitiatorCLI,
+			"replicaset-app1-7b8d9-container1-grype": "",
+		}, names)
+	})
+
+	t.Run("Should keep VulnerabilityReports of other node groups", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		newReport := func(nodeGroup string) v1alpha1.VulnerabilityReport {
+			return v1alpha1.VulnerabilityReport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "daemonset-fluentd-fluentd-" + nodeGroup,
+					Namespace: "qa",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:      "DaemonSet",
+						starboard.LabelResourceName:      "fluentd",
+						starboard.LabelResourceNamespace: "qa",
+						starboard.LabelContainerName:     "fluentd",
+						starboard.LabelNodeGroup:         nodeGroup,
+					},
+				},
+				Report: v1alpha1.VulnerabilityReportData{Scanner: v1alpha1.Scanner{Name: "Trivy"}},
+			}
+		}
+		readWriter := vulnerabilityreport.NewReadWriter(client)
+		require.NoError(t, readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{newReport("amd64")}))
+		require.NoError(t, readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{newReport("arm64")}))
+
+		var list v1alpha1.VulnerabilityReportList
+		require.NoError(t, client.List(context.TODO(), &list))
+		var names []string
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+		assert.ElementsMatch(t, []string{
+			"daemonset-fluentd-fluentd-amd64",
+			"daemonset-fluentd-fluentd-arm64",
+		}, names)
+	})
+
+	t.Run("Should write a single VulnerabilityReport when operator and CLI race", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		newReport := func(initiator string, i int) v1alpha1.VulnerabilityReport {
+			return v1alpha1.VulnerabilityReport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "replicaset-app1-7b8d9-container1",
+					Namespace: "qa",
+					Labels: map[string]string{
+						starboard.LabelResourceKind:      "ReplicaSet",
+						starboard.LabelResourceName:      "app1-7b8d9",
+						starboard.LabelResourceNamespace: "qa",
+						starboard.LabelContainerName:     "container1",
+					},
+					Annotations: map[string]string{v1alpha1.ReportInitiatorAnnotation: initiator},
+				},
+				Report: v1alpha1.VulnerabilityReportData{
+					Scanner: v1alpha1.Scanner{Name: "Trivy"},
+					Summary: v1alpha1.VulnerabilitySummary{CriticalCount: i},
+				},
+			}
+		}
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 2)
+		for _, initiator := range []string{v1alpha1.InitiatorOperator, v1alpha1.InitiatorCLI} {
+			wg.Add(1)
+			go func(initiator string) {
+				defer wg.Done()
+				readWriter := vulnerabilityreport.NewReadWriter(client)
+				for i := 0; i < 20; i++ {
+					if err := readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{newReport(initiator, i)}); err != nil {
+						errs <- err
+						return
+					}
+				}
+			}(initiator)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		var list v1alpha1.VulnerabilityReportList
+		require.NoError(t, client.List(context.TODO(), &list))
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, 19, list.Items[0].Report.Summary.CriticalCount)
+		assert.Contains(t, []string{v1alpha1.InitiatorOperator, v1alpha1.InitiatorCLI},
+			list.Items[0].Annotations[v1alpha1.ReportInitiatorAnnotation])
+	})
+
 	t.Run("Should find VulnerabilityReports", func(t *testing.T) {
 		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(&v1alpha1.VulnerabilityReport{
 			ObjectMeta: metav1.ObjectMeta{
@@ -257,4 +461,226 @@ func TestNewReadWriter(t *testing.T) {
 		}, reports)
 	})
 
+	t.Run("Should store large package inventory in PackageInventory", func(t *testing.T) {
+		packages := make([]v1alpha1.Package, vulnerabilityreport.PackagesInlineLimit+1)
+		for i := range packages {
+			packages[i] = v1alpha1.Package{Name: fmt.Sprintf("pkg-%d", i), Version: "1.0.0", Type: "alpine"}
+		}
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		readWriter := vulnerabilityreport.NewReadWriter(client)
+		err := readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment-app1-container1",
+					Namespace: "qa",
+				},
+				Report: v1alpha1.VulnerabilityReportData{
+					Packages: packages,
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		var report v1alpha1.VulnerabilityReport
+		err = client.Get(context.TODO(), types.NamespacedName{Namespace: "qa", Name: "deployment-app1-container1"}, &report)
+		require.NoError(t, err)
+		assert.Empty(t, report.Report.Packages)
+
+		var inventory v1alpha1.PackageInventory
+		err = client.Get(context.TODO(), types.NamespacedName{Namespace: "qa", Name: "deployment-app1-container1"}, &inventory)
+		require.NoError(t, err)
+		assert.Equal(t, packages, inventory.Report.Packages)
+
+		found, err := readWriter.FindPackages(context.TODO(), report)
+		require.NoError(t, err)
+		assert.Equal(t, packages, found)
+	})
+
+	t.Run("Should delete stale PackageInventory when packages fit inline", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(
+			&v1alpha1.PackageInventory{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment-app1-container1",
+					Namespace: "qa",
+				},
+				Report: v1alpha1.PackageInventoryData{
+					Packages: []v1alpha1.Package{
+						{Name: "openssl", Version: "1.1.1k-r0", Type: "alpine"},
+					},
+				},
+			},
+		).Build()
+		readWriter := vulnerabilityreport.NewReadWriter(client)
+		report := v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "deployment-app1-container1",
+				Namespace: "qa",
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Packages: []v1alpha1.Package{
+					{Name: "musl", Version: "1.2.2-r7", Type: "alpine"},
+				},
+			},
+		}
+		err := readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{report})
+		require.NoError(t, err)
+
+		var inventories v1alpha1.PackageInventoryList
+		require.NoError(t, client.List(context.TODO(), &inventories))
+		assert.Empty(t, inventories.Items)
+	})
+
+	t.Run("Should write VulnerabilityReport without packages when PackageInventory CRD is missing", func(t *testing.T) {
+		client := &noInventoriesClient{Client: fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()}
+		readWriter := vulnerabilityreport.NewReadWriter(client)
+		report := v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "deployment-app1-container1",
+				Namespace: "qa",
+			},
+		}
+		require.NoError(t, readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{report}))
+		assert.Zero(t, client.inventoryRequests)
+
+		report.Report.Packages = []v1alpha1.Package{{Name: "musl", Version: "1.2.2-r7", Type: "alpine"}}
+		require.NoError(t, readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{report}))
+		assert.Equal(t, 1, client.inventoryRequests)
+
+		packages, err := readWriter.FindPackages(context.TODO(), v1alpha1.VulnerabilityReport{ObjectMeta: report.ObjectMeta})
+		require.NoError(t, err)
+		assert.Empty(t, packages)
+	})
+
+	t.Run("Should find inline packages", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		readWriter := vulnerabilityreport.NewReadWriter(client)
+		report := v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "deployment-app1-container1",
+				Namespace: "qa",
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Packages: []v1alpha1.Package{
+					{Name: "musl", Version: "1.2.2-r7", Type: "alpine"},
+				},
+			},
+		}
+		err := readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{report})
+		require.NoError(t, err)
+
+		var inventories v1alpha1.PackageInventoryList
+		require.NoError(t, client.List(context.TODO(), &inventories))
+		assert.Empty(t, inventories.Items)
+
+		found, err := readWriter.FindPackages(context.TODO(), report)
+		require.NoError(t, err)
+		assert.Equal(t, []v1alpha1.Package{
+			{Name: "musl", Version: "1.2.2-r7", Type: "alpine"},
+		}, found)
+	})
+
+	t.Run("Should return empty packages when inventory is missing", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		readWriter := vulnerabilityreport.NewReadWriter(client)
+		found, err := readWriter.FindPackages(context.TODO(), v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "deployment-app1-container1",
+				Namespace: "qa",
+			},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("Should admit VulnerabilityReports with quota before creating them", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		admitted := &admittedNamespaces{}
+		readWriter := vulnerabilityreport.NewReadWriterWithQuota(client, admitted)
+		report := v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-app-container",
+				Namespace: "ci",
+			},
+		}
+
+		require.NoError(t, readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{report}))
+		assert.Equal(t, []string{"ci"}, admitted.namespaces)
+
+		require.NoError(t, readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{report}))
+		assert.Equal(t, []string{"ci"}, admitted.namespaces, "updated reports must not be admitted")
+	})
+
+	t.Run("Should not persist VulnerabilityReports on dry run", func(t *testing.T) {
+		existing := &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "replicaset-app-container",
+				Namespace:       "ci",
+				ResourceVersion: "0",
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Summary: v1alpha1.VulnerabilitySummary{CriticalCount: 1},
+			},
+		}
+		client := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(existing).Build()
+		admitted := &admittedNamespaces{}
+		readWriter := vulnerabilityreport.NewReadWriterWithQuota(client, admitted)
+
+		err := readWriter.Write(context.TODO(), []v1alpha1.VulnerabilityReport{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "replicaset-app-container",
+					Namespace: "ci",
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "replicaset-app-sidecar",
+					Namespace: "ci",
+				},
+			},
+		}, kube.DryRun())
+		require.NoError(t, err)
+		assert.Empty(t, admitted.namespaces, "reports must not be admitted on dry run")
+
+		var list v1alpha1.VulnerabilityReportList
+		require.NoError(t, client.List(context.TODO(), &list))
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, "replicaset-app-container", list.Items[0].Name)
+		assert.Equal(t, 1, list.Items[0].Report.Summary.CriticalCount)
+	})
+
+}
+
+// admittedNamespaces is a quota.Quota which records namespaces of admitted
+// reports.
+type admittedNamespaces struct {
+	namespaces []string
+}
+
+func (a *admittedNamespaces) Admit(_ context.Context, namespace string) error {
+	a.namespaces = append(a.namespaces, namespace)
+	return nil
+}
+
+// noInventoriesClient is a client.Client of a cluster without the CRD of
+// v1alpha1.PackageInventory, which counts requests for inventories.
+type noInventoriesClient struct {
+	client.Client
+	inventoryRequests int
+}
+
+func (c *noInventoriesClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	if _, ok := obj.(*v1alpha1.PackageInventory); ok {
+		c.inventoryRequests++
+		return &meta.NoKindMatchError{GroupKind: v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.PackageInventoryKind).GroupKind()}
+	}
+	return c.Client.Get(ctx, key, obj)
+}
+
+func (c *noInventoriesClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if _, ok := obj.(*v1alpha1.PackageInventory); ok {
+		c.inventoryRequests++
+		return &meta.NoKindMatchError{GroupKind: v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.PackageInventoryKind).GroupKind()}
+	}
+	return c.Client.Delete(ctx, obj, opts...)
 }
@@ -0,0 +1,237 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NodeGroup is a group of nodes which share the value of the node group label
+// and run pods of a DaemonSet. The zero value represents all nodes, i.e. the
+// DaemonSet scanned as a whole.
+type NodeGroup struct {
+	// Name is the value of the node group label.
+	Name string
+	// NodeName is the name of the node where Images were resolved.
+	NodeName string
+	// ContainerRuntime is the container runtime version of the node.
+	ContainerRuntime string
+	// Images are container images resolved to digests by the container
+	// runtime of the node, e.g. nginx@sha256:...
+	Images kube.ContainerImages
+	// Platform is the platform of nodes of the group, e.g. linux/arm64, for
+	// which images of multi-arch image indexes are scanned. Empty if the
+	// default platform of the index is scanned.
+	Platform string
+	// PlatformWarning explains that nodes of the group have different
+	// platforms, of which only Platform is scanned.
+	PlatformWarning string
+}
+
+// NodeGroupFromObjectMeta returns the NodeGroup recorded in the specified
+// metadata of a scan job or a report.
+func NodeGroupFromObjectMeta(meta metav1.ObjectMeta) NodeGroup {
+	return NodeGroup{
+		Name:             meta.Labels[starboard.LabelNodeGroup],
+		NodeName:         meta.Annotations[starboard.AnnotationNodeName],
+		ContainerRuntime: meta.Annotations[starboard.AnnotationContainerRuntime],
+		Platform:         meta.Annotations[starboard.AnnotationPlatform],
+		PlatformWarning:  meta.Annotations[starboard.AnnotationPlatformWarning],
+	}
+}
+
+// NodePlatform returns the platform of the specified node in the os/arch
+// format, e.g. linux/arm64, or an empty string if it's unknown.
+func NodePlatform(node corev1.Node) string {
+	os, arch := node.Labels[corev1.LabelOSStable], node.Labels[corev1.LabelArchStable]
+	if os == "" {
+		os = node.Status.NodeInfo.OperatingSystem
+	}
+	if arch == "" {
+		arch = node.Status.NodeInfo.Architecture
+	}
+	if os == "" || arch == "" {
+		return ""
+	}
+	return os + "/" + arch
+}
+
+// GetNodeGroups groups running pods of the specified DaemonSet by the value of
+// the specified label of their nodes. Each group holds container images
+// resolved to digests on one node of the group and the platform of the node.
+// Groups are returned only if resolved images differ between groups, or if
+// byPlatform is true and platforms differ, otherwise the DaemonSet is scanned
+// as a whole and nil is returned.
+//
+// Pods which run a previous revision of the DaemonSet, whose images are not
+// resolved yet, or whose nodes don't have the label are ignored.
+func GetNodeGroups(ctx context.Context, c client.Reader, daemonSet client.Object, label string, byPlatform bool) ([]NodeGroup, error) {
+	pods, err := getCurrentPods(ctx, c, daemonSet)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]NodeGroup)
+	for _, pod := range pods {
+		resolved, ok := GetResolvedImages(pod)
+		if !ok {
+			continue
+		}
+		var node corev1.Node
+		err = c.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, &node)
+		if err != nil {
+			if k8sapierror.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		groupName, ok := node.Labels[label]
+		if !ok {
+			continue
+		}
+		if _, ok := groups[groupName]; ok {
+			continue
+		}
+		groups[groupName] = NodeGroup{
+			Name:             groupName,
+			NodeName:         node.Name,
+			ContainerRuntime: node.Status.NodeInfo.ContainerRuntimeVersion,
+			Images:           resolved,
+			Platform:         NodePlatform(node),
+		}
+	}
+
+	var nodeGroups []NodeGroup
+	for _, group := range groups {
+		if !byPlatform {
+			group.Platform = ""
+		}
+		nodeGroups = append(nodeGroups, group)
+	}
+	sort.Slice(nodeGroups, func(i, j int) bool {
+		return nodeGroups[i].Name < nodeGroups[j].Name
+	})
+	for _, group := range nodeGroups {
+		if !reflect.DeepEqual(group.Images, nodeGroups[0].Images) || group.Platform != nodeGroups[0].Platform {
+			return nodeGroups, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetMajorityPlatform returns the platform of nodes which run the majority of
+// running pods of the specified DaemonSet, ties are broken by the platform
+// name. If pods run on nodes of different platforms, it also returns a warning
+// which lists the number of pods per platform. It returns empty strings if
+// platforms of nodes are unknown.
+func GetMajorityPlatform(ctx context.Context, c client.Reader, daemonSet client.Object) (string, string, error) {
+	pods, err := getCurrentPods(ctx, c, daemonSet)
+	if err != nil {
+		return "", "", err
+	}
+	counts := make(map[string]int)
+	for _, pod := range pods {
+		var node corev1.Node
+		err = c.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, &node)
+		if err != nil {
+			if k8sapierror.IsNotFound(err) {
+				continue
+			}
+			return "", "", err
+		}
+		if platform := NodePlatform(node); platform != "" {
+			counts[platform]++
+		}
+	}
+
+	var platforms []string
+	for platform := range counts {
+		platforms = append(platforms, platform)
+	}
+	sort.Strings(platforms)
+	var majority string
+	for _, platform := range platforms {
+		if majority == "" || counts[platform] > counts[majority] {
+			majority = platform
+		}
+	}
+	if len(platforms) < 2 {
+		return majority, "", nil
+	}
+	var pairs []string
+	for _, platform := range platforms {
+		pairs = append(pairs, fmt.Sprintf("%s: %d", platform, counts[platform]))
+	}
+	return majority, fmt.Sprintf("pods run on nodes of different platforms (%s), only %s was scanned",
+		strings.Join(pairs, ", "), majority), nil
+}
+
+// getCurrentPods returns pods of the specified DaemonSet which are scheduled
+// to nodes and run its current revision, i.e. its container images.
+func getCurrentPods(ctx context.Context, c client.Reader, daemonSet client.Object) ([]corev1.Pod, error) {
+	podSpec, err := kube.GetPodSpec(daemonSet)
+	if err != nil {
+		return nil, err
+	}
+	images := kube.GetContainerImagesFromPodSpec(podSpec)
+	selector, err := kube.GetPodSelector(daemonSet)
+	if err != nil {
+		return nil, err
+	}
+	podSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	var pods corev1.PodList
+	err = c.List(ctx, &pods, client.InNamespace(daemonSet.GetNamespace()),
+		client.MatchingLabelsSelector{Selector: podSelector})
+	if err != nil {
+		return nil, err
+	}
+	var current []corev1.Pod
+	for _, pod := range pods.Items {
+		if !metav1.IsControlledBy(&pod, daemonSet) || pod.Spec.NodeName == "" ||
+			!reflect.DeepEqual(kube.GetContainerImagesFromPodSpec(pod.Spec), images) {
+			continue
+		}
+		current = append(current, pod)
+	}
+	return current, nil
+}
+
+// GetResolvedImages returns images of containers of the specified pod with
+// tags replaced by digests reported by the container runtime. It returns false
+// if any of the images is not resolved to a digest yet.
+func GetResolvedImages(pod corev1.Pod) (kube.ContainerImages, bool) {
+	imageIDs := make(map[string]string)
+	for _, status := range pod.Status.ContainerStatuses {
+		imageIDs[status.Name] = status.ImageID
+	}
+	resolved := kube.ContainerImages{}
+	for _, container := range pod.Spec.Containers {
+		i := strings.LastIndex(imageIDs[container.Name], "@")
+		if i < 0 {
+			return nil, false
+		}
+		ref, err := name.ParseReference(container.Image)
+		if err != nil {
+			return nil, false
+		}
+		digest, err := name.NewDigest(ref.Context().Name() + imageIDs[container.Name][i:])
+		if err != nil {
+			return nil, false
+		}
+		resolved[container.Name] = digest.String()
+	}
+	return resolved, true
+}
@@ -0,0 +1,315 @@
+package vulnerabilityreport_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	digestAMD64 = "sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31"
+	digestARM64 = "sha256:3d2d8ba6f5ff3dc9b7e0b2bbaf8bcbcd07e7cbf2a0f9c6e1bd0c6c1c8f3b4e01"
+)
+
+func TestGetResolvedImages(t *testing.T) {
+	t.Run("Should resolve images to digests", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		images, ok := vulnerabilityreport.GetResolvedImages(corev1.Pod{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "fluentd", Image: "fluent/fluentd:v1.14"},
+				},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "fluentd", ImageID: "docker-pullable://fluent/fluentd@" + digestAMD64},
+				},
+			},
+		})
+		g.Expect(ok).To(gomega.BeTrue())
+		g.Expect(images).To(gomega.Equal(kube.ContainerImages{
+			"fluentd": "index.docker.io/fluent/fluentd@" + digestAMD64,
+		}))
+	})
+
+	t.Run("Should not resolve images which are not pulled yet", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		_, ok := vulnerabilityreport.GetResolvedImages(corev1.Pod{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "fluentd", Image: "fluent/fluentd:v1.14"},
+				},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "fluentd", ImageID: ""},
+				},
+			},
+		})
+		g.Expect(ok).To(gomega.BeFalse())
+	})
+}
+
+func TestGetNodeGroups(t *testing.T) {
+	daemonSet := &appsv1.DaemonSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DaemonSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fluentd",
+			Namespace: "kube-system",
+			UID:       types.UID("d1b0c8e1-3c8f-4c1e-9a53-0c4f5b3f4a61"),
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "fluentd"},
+			},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "fluentd", Image: "fluent/fluentd:v1.14"},
+					},
+				},
+			},
+		},
+	}
+	newNode := func(name, arch string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"kubernetes.io/os": "linux", "kubernetes.io/arch": arch},
+			},
+			Status: corev1.NodeStatus{
+				NodeInfo: corev1.NodeSystemInfo{ContainerRuntimeVersion: "containerd://1.5.11"},
+			},
+		}
+	}
+	newPod := func(name, nodeName, digest string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "kube-system",
+				Labels:    map[string]string{"app": "fluentd"},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "apps/v1",
+						Kind:       "DaemonSet",
+						Name:       "fluentd",
+						UID:        daemonSet.UID,
+						Controller: pointer.BoolPtr(true),
+					},
+				},
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{
+					{Name: "fluentd", Image: "fluent/fluentd:v1.14"},
+				},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "fluentd", ImageID: "docker.io/fluent/fluentd@" + digest},
+				},
+			},
+		}
+	}
+
+	t.Run("Should return node groups when digests differ", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			newNode("node-1", "amd64"),
+			newNode("node-2", "arm64"),
+			newNode("node-3", "amd64"),
+			newPod("fluentd-1", "node-1", digestAMD64),
+			newPod("fluentd-2", "node-2", digestARM64),
+			newPod("fluentd-3", "node-3", digestAMD64),
+		).Build()
+
+		groups, err := vulnerabilityreport.GetNodeGroups(context.TODO(), c, daemonSet, "kubernetes.io/arch", false)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(groups).To(gomega.Equal([]vulnerabilityreport.NodeGroup{
+			{
+				Name:             "amd64",
+				NodeName:         "node-1",
+				ContainerRuntime: "containerd://1.5.11",
+				Images:           kube.ContainerImages{"fluentd": "index.docker.io/fluent/fluentd@" + digestAMD64},
+			},
+			{
+				Name:             "arm64",
+				NodeName:         "node-2",
+				ContainerRuntime: "containerd://1.5.11",
+				Images:           kube.ContainerImages{"fluentd": "index.docker.io/fluent/fluentd@" + digestARM64},
+			},
+		}))
+	})
+
+	t.Run("Should return nil when digests are the same", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			newNode("node-1", "amd64"),
+			newNode("node-2", "arm64"),
+			newPod("fluentd-1", "node-1", digestAMD64),
+			newPod("fluentd-2", "node-2", digestAMD64),
+		).Build()
+
+		groups, err := vulnerabilityreport.GetNodeGroups(context.TODO(), c, daemonSet, "kubernetes.io/arch", false)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(groups).To(gomega.BeNil())
+	})
+
+	t.Run("Should return node groups when platforms differ", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			newNode("node-1", "amd64"),
+			newNode("node-2", "arm64"),
+			newPod("fluentd-1", "node-1", digestAMD64),
+			newPod("fluentd-2", "node-2", digestAMD64),
+		).Build()
+
+		groups, err := vulnerabilityreport.GetNodeGroups(context.TODO(), c, daemonSet, "kubernetes.io/arch", true)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(groups).To(gomega.Equal([]vulnerabilityreport.NodeGroup{
+			{
+				Name:             "amd64",
+				NodeName:         "node-1",
+				ContainerRuntime: "containerd://1.5.11",
+				Images:           kube.ContainerImages{"fluentd": "index.docker.io/fluent/fluentd@" + digestAMD64},
+				Platform:         "linux/amd64",
+			},
+			{
+				Name:             "arm64",
+				NodeName:         "node-2",
+				ContainerRuntime: "containerd://1.5.11",
+				Images:           kube.ContainerImages{"fluentd": "index.docker.io/fluent/fluentd@" + digestAMD64},
+				Platform:         "linux/arm64",
+			},
+		}))
+	})
+
+	t.Run("Should ignore pods of other controllers and nodes without the label", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		orphan := newPod("fluentd-2", "node-2", digestARM64)
+		orphan.OwnerReferences = nil
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			newNode("node-1", "amd64"),
+			newNode("node-2", "arm64"),
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-3"}},
+			newPod("fluentd-1", "node-1", digestAMD64),
+			orphan,
+			newPod("fluentd-3", "node-3", digestARM64),
+		).Build()
+
+		groups, err := vulnerabilityreport.GetNodeGroups(context.TODO(), c, daemonSet, "kubernetes.io/arch", false)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(groups).To(gomega.BeNil())
+	})
+}
+
+func TestGetMajorityPlatform(t *testing.T) {
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fluentd",
+			Namespace: "kube-system",
+			UID:       types.UID("d1b0c8e1-3c8f-4c1e-9a53-0c4f5b3f4a61"),
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "fluentd"},
+			},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "fluentd", Image: "fluent/fluentd:v1.14"},
+					},
+				},
+			},
+		},
+	}
+	newNode := func(name, arch string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				NodeInfo: corev1.NodeSystemInfo{OperatingSystem: "linux", Architecture: arch},
+			},
+		}
+	}
+	newPod := func(name, nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "kube-system",
+				Labels:    map[string]string{"app": "fluentd"},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "apps/v1",
+						Kind:       "DaemonSet",
+						Name:       "fluentd",
+						UID:        daemonSet.UID,
+						Controller: pointer.BoolPtr(true),
+					},
+				},
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{
+					{Name: "fluentd", Image: "fluent/fluentd:v1.14"},
+				},
+			},
+		}
+	}
+
+	t.Run("Should return the platform of the majority of pods with a warning", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			newNode("node-1", "amd64"),
+			newNode("node-2", "arm64"),
+			newNode("node-3", "arm64"),
+			newPod("fluentd-1", "node-1"),
+			newPod("fluentd-2", "node-2"),
+			newPod("fluentd-3", "node-3"),
+		).Build()
+
+		platform, warning, err := vulnerabilityreport.GetMajorityPlatform(context.TODO(), c, daemonSet)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(platform).To(gomega.Equal("linux/arm64"))
+		g.Expect(warning).To(gomega.Equal("pods run on nodes of different platforms (linux/amd64: 1, linux/arm64: 2), only linux/arm64 was scanned"))
+	})
+
+	t.Run("Should return the platform without a warning", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			newNode("node-1", "arm64"),
+			newNode("node-2", "arm64"),
+			newPod("fluentd-1", "node-1"),
+			newPod("fluentd-2", "node-2"),
+		).Build()
+
+		platform, warning, err := vulnerabilityreport.GetMajorityPlatform(context.TODO(), c, daemonSet)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(platform).To(gomega.Equal("linux/arm64"))
+		g.Expect(warning).To(gomega.BeEmpty())
+	})
+
+	t.Run("Should return empty platform without running pods", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(
+			newNode("node-1", "arm64"),
+		).Build()
+
+		platform, warning, err := vulnerabilityreport.GetMajorityPlatform(context.TODO(), c, daemonSet)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(platform).To(gomega.BeEmpty())
+		g.Expect(warning).To(gomega.BeEmpty())
+	})
+}
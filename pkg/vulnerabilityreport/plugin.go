@@ -32,3 +32,89 @@ type Plugin interface {
 	ParseVulnerabilityReportData(ctx starboard.PluginContext, imageRef string, logsReader io.ReadCloser) (
 		v1alpha1.VulnerabilityReportData, error)
 }
+
+// SBOMContainerName is the name of the scan job container which scans the SBOM
+// referred to by the starboard.AnnotationSBOMConfigMap annotation of the
+// scanned workload.
+const SBOMContainerName = "starboard-sbom"
+
+// SBOMPlugin is an optional interface implemented by a Plugin that adds the
+// SBOMContainerName container to scan jobs of workloads with the
+// starboard.AnnotationSBOMConfigMap annotation.
+type SBOMPlugin interface {
+
+	// ParseSBOMVulnerabilities is a callback to parse and convert logs of the
+	// SBOMContainerName container to v1alpha1.Vulnerability items. It returns
+	// an error if the SBOM is missing or malformed.
+	ParseSBOMVulnerabilities(ctx starboard.PluginContext, logsReader io.ReadCloser) ([]v1alpha1.Vulnerability, error)
+}
+
+// NamespaceConfigPlugin is an optional interface implemented by a Plugin whose
+// configuration can be extended with a ConfigMap in the namespace of scanned
+// workloads. Reports are labeled with the hash of the configuration, so that
+// workloads in a namespace are scanned again when its ConfigMap changes.
+type NamespaceConfigPlugin interface {
+
+	// NamespaceConfigMapName returns the name of the ConfigMap which extends
+	// the configuration for workloads in its namespace.
+	NamespaceConfigMapName() string
+
+	// NamespaceConfigHash returns the hash of the configuration used to scan
+	// workloads in the specified namespace, or blank if the namespace has no
+	// ConfigMap.
+	NamespaceConfigHash(ctx starboard.PluginContext, namespace string) (string, error)
+}
+
+// DirectScanPlugin is an optional interface implemented by a Plugin which can
+// scan container images synchronously in the process of the CLI, without
+// scan jobs. See DirectScanner for details.
+type DirectScanPlugin interface {
+
+	// GetDirectScanCommand describes the scanner command which scans the
+	// specified container image of the workload, and whose output is
+	// converted by ParseVulnerabilityReportData. The credentials, if not nil,
+	// authenticate the scanner to the registry of the image.
+	GetDirectScanCommand(ctx starboard.PluginContext, workload client.Object, image string, credentials *docker.Auth) (
+		DirectScanCommand, error)
+}
+
+// DirectScanCommand describes the command run by the DirectScanner.
+type DirectScanCommand struct {
+	// Binary is the name of the scanner binary looked up in PATH.
+	Binary string
+	// Image is the container image of the scanner, which runs the command
+	// with docker if the scanner binary is not available.
+	Image string
+	// Args are the arguments of the scanner binary.
+	Args []string
+	// Env are the environment variables of the scanner in the key=value form.
+	Env []string
+}
+
+// ExitCodeServerOverloaded is the exit code of a scan job container which gave
+// up scanning because the scanner server, or a proxy in front of it, kept
+// responding that it's overloaded, e.g. with HTTP 429 status codes. It's
+// EX_TEMPFAIL defined in sysexits.h.
+const ExitCodeServerOverloaded = 75
+
+// ScanJobFailure classifies the failure of a scan job container.
+type ScanJobFailure string
+
+const (
+	ScanJobFailureServerOverloaded ScanJobFailure = "ServerOverloaded"
+	ScanJobFailureScanError        ScanJobFailure = "ScanError"
+	// ScanJobFailureScannerMismatch is the failure of a complete scan job
+	// whose results were not output by the expected scanner.
+	ScanJobFailureScannerMismatch ScanJobFailure = "ScannerMismatch"
+)
+
+// ScanJobFailureFrom classifies the failure of the given terminated scan job
+// container. Containers which exited with ExitCodeServerOverloaded failed
+// because the scanner server was overloaded, and may succeed if scanned again
+// later. Any other failure is a scan error.
+func ScanJobFailureFrom(status *corev1.ContainerStateTerminated) ScanJobFailure {
+	if status.ExitCode == ExitCodeServerOverloaded {
+		return ScanJobFailureServerOverloaded
+	}
+	return ScanJobFailureScanError
+}
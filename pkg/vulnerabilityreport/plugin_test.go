@@ -1 +1,33 @@
 package vulnerabilityreport_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestScanJobFailureFrom(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   *corev1.ContainerStateTerminated
+		expected vulnerabilityreport.ScanJobFailure
+	}{
+		{
+			name:     "Should classify server overloaded exit code",
+			status:   &corev1.ContainerStateTerminated{ExitCode: vulnerabilityreport.ExitCodeServerOverloaded, Reason: "Error"},
+			expected: vulnerabilityreport.ScanJobFailureServerOverloaded,
+		},
+		{
+			name:     "Should classify any other exit code as scan error",
+			status:   &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"},
+			expected: vulnerabilityreport.ScanJobFailureScanError,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, vulnerabilityreport.ScanJobFailureFrom(tc.status))
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// sbomResults holds vulnerabilities found in the SBOM attached to a workload,
+// and warnings about the SBOM that could not be scanned.
+type sbomResults struct {
+	vulnerabilities []v1alpha1.Vulnerability
+	warnings        []string
+}
+
+// getSBOMResults parses logs of the SBOMContainerName container of the given
+// scan job. Problems with the SBOM are returned as warnings rather than errors,
+// so that they do not prevent reports of container images from being written.
+// It returns nil if the scan job does not scan an SBOM.
+func getSBOMResults(ctx context.Context, logsReader kube.LogsReader, plugin Plugin, pluginContext starboard.PluginContext,
+	job *batchv1.Job, owner client.Object, containerImages kube.ContainerImages) *sbomResults {
+	sbomPlugin, ok := plugin.(SBOMPlugin)
+	if !ok || !hasSBOMContainer(job, containerImages) {
+		return nil
+	}
+	source := owner.GetAnnotations()[starboard.AnnotationSBOMConfigMap]
+
+	logsStream, err := logsReader.GetLogsByJobAndContainerName(ctx, job, SBOMContainerName)
+	if err != nil {
+		return &sbomResults{warnings: []string{fmt.Sprintf("getting SBOM scan results of ConfigMap %q: %v", source, err)}}
+	}
+	defer func() {
+		_ = logsStream.Close()
+	}()
+
+	vulnerabilities, err := sbomPlugin.ParseSBOMVulnerabilities(pluginContext, logsStream)
+	if err != nil {
+		return &sbomResults{warnings: []string{fmt.Sprintf("scanning SBOM of ConfigMap %q: %v", source, err)}}
+	}
+	for i := range vulnerabilities {
+		vulnerabilities[i].Target = "sbom:" + source
+	}
+	return &sbomResults{vulnerabilities: vulnerabilities}
+}
+
+func hasSBOMContainer(job *batchv1.Job, containerImages kube.ContainerImages) bool {
+	if _, ok := containerImages[SBOMContainerName]; ok {
+		return false
+	}
+	for _, container := range job.Spec.Template.Spec.Containers {
+		if container.Name == SBOMContainerName {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeInto appends SBOM vulnerabilities and warnings to the given report
+// data and updates its summary accordingly.
+func (r *sbomResults) mergeInto(data v1alpha1.VulnerabilityReportData) v1alpha1.VulnerabilityReportData {
+	if r == nil {
+		return data
+	}
+	vulnerabilities := make([]v1alpha1.Vulnerability, 0, len(data.Vulnerabilities)+len(r.vulnerabilities))
+	data.Vulnerabilities = append(append(vulnerabilities, data.Vulnerabilities...), r.vulnerabilities...)
+	if len(r.warnings) > 0 {
+		data.Warnings = append(append([]string{}, data.Warnings...), r.warnings...)
+	}
+	for _, v := range r.vulnerabilities {
+		switch v.Severity {
+		case v1alpha1.SeverityCritical:
+			data.Summary.CriticalCount++
+		case v1alpha1.SeverityHigh:
+			data.Summary.HighCount++
+		case v1alpha1.SeverityMedium:
+			data.Summary.MediumCount++
+		case v1alpha1.SeverityLow:
+			data.Summary.LowCount++
+		default:
+			data.Summary.UnknownCount++
+		}
+	}
+	return data
+}
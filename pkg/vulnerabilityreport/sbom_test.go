@@ -0,0 +1,117 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type fakeLogsReader struct {
+	logs map[string]string
+}
+
+func (r *fakeLogsReader) GetLogsByJobAndContainerName(_ context.Context, _ *batchv1.Job, containerName string) (io.ReadCloser, error) {
+	logs, ok := r.logs[containerName]
+	if !ok {
+		return nil, errors.New("container not found")
+	}
+	return io.NopCloser(strings.NewReader(logs)), nil
+}
+
+func (r *fakeLogsReader) GetTerminatedContainersStatusesByJob(_ context.Context, _ *batchv1.Job) (map[string]*corev1.ContainerStateTerminated, error) {
+	return nil, nil
+}
+
+type fakeSBOMPlugin struct{}
+
+func (p *fakeSBOMPlugin) Init(_ starboard.PluginContext) error {
+	return nil
+}
+
+func (p *fakeSBOMPlugin) GetScanJobSpec(_ starboard.PluginContext, _ client.Object, _ map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	return corev1.PodSpec{}, nil, nil
+}
+
+func (p *fakeSBOMPlugin) ParseVulnerabilityReportData(_ starboard.PluginContext, _ string, _ io.ReadCloser) (v1alpha1.VulnerabilityReportData, error) {
+	return v1alpha1.VulnerabilityReportData{}, nil
+}
+
+func (p *fakeSBOMPlugin) ParseSBOMVulnerabilities(_ starboard.PluginContext, logsReader io.ReadCloser) ([]v1alpha1.Vulnerability, error) {
+	logs, err := io.ReadAll(logsReader)
+	if err != nil {
+		return nil, err
+	}
+	if string(logs) != "ok" {
+		return nil, errors.New(string(logs))
+	}
+	return []v1alpha1.Vulnerability{
+		{VulnerabilityID: "CVE-2021-44228", Severity: v1alpha1.SeverityCritical},
+		{VulnerabilityID: "CVE-2020-9488", Severity: v1alpha1.SeverityLow},
+	}, nil
+}
+
+func TestGetSBOMResults(t *testing.T) {
+	owner := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name:        "nginx-6799fc88d8",
+		Namespace:   "prod-ns",
+		Annotations: map[string]string{starboard.AnnotationSBOMConfigMap: "nginx-sbom"},
+	}}
+	job := &batchv1.Job{Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "nginx"}, {Name: SBOMContainerName}},
+	}}}}
+	images := kube.ContainerImages{"nginx": "nginx:1.16"}
+	data := v1alpha1.VulnerabilityReportData{
+		Summary:         v1alpha1.VulnerabilitySummary{HighCount: 1},
+		Vulnerabilities: []v1alpha1.Vulnerability{{VulnerabilityID: "CVE-2019-1543", Severity: v1alpha1.SeverityHigh}},
+	}
+
+	t.Run("Should merge vulnerabilities found in SBOM", func(t *testing.T) {
+		logsReader := &fakeLogsReader{logs: map[string]string{SBOMContainerName: "ok"}}
+		sbom := getSBOMResults(context.TODO(), logsReader, &fakeSBOMPlugin{}, nil, job, owner, images)
+		assert.Equal(t, v1alpha1.VulnerabilityReportData{
+			Summary: v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 1, LowCount: 1},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2019-1543", Severity: v1alpha1.SeverityHigh},
+				{VulnerabilityID: "CVE-2021-44228", Severity: v1alpha1.SeverityCritical, Target: "sbom:nginx-sbom"},
+				{VulnerabilityID: "CVE-2020-9488", Severity: v1alpha1.SeverityLow, Target: "sbom:nginx-sbom"},
+			},
+		}, sbom.mergeInto(data))
+		assert.Len(t, data.Vulnerabilities, 1, "report data of other containers must not be modified")
+	})
+
+	t.Run("Should add warning when SBOM is missing or malformed", func(t *testing.T) {
+		logsReader := &fakeLogsReader{logs: map[string]string{SBOMContainerName: "SBOM not found"}}
+		sbom := getSBOMResults(context.TODO(), logsReader, &fakeSBOMPlugin{}, nil, job, owner, images)
+		merged := sbom.mergeInto(data)
+		assert.Equal(t, data.Vulnerabilities, merged.Vulnerabilities)
+		assert.Equal(t, data.Summary, merged.Summary)
+		assert.Equal(t, []string{`scanning SBOM of ConfigMap "nginx-sbom": SBOM not found`}, merged.Warnings)
+	})
+
+	t.Run("Should add warning when logs cannot be read", func(t *testing.T) {
+		sbom := getSBOMResults(context.TODO(), &fakeLogsReader{}, &fakeSBOMPlugin{}, nil, job, owner, images)
+		assert.Equal(t, []string{`getting SBOM scan results of ConfigMap "nginx-sbom": container not found`}, sbom.mergeInto(data).Warnings)
+	})
+
+	t.Run("Should not merge anything when scan job does not scan SBOM", func(t *testing.T) {
+		jobWithoutSBOM := &batchv1.Job{Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "nginx"}},
+		}}}}
+		sbom := getSBOMResults(context.TODO(), &fakeLogsReader{}, &fakeSBOMPlugin{}, nil, jobWithoutSBOM, owner, images)
+		assert.Nil(t, sbom)
+		assert.Equal(t, data, sbom.mergeInto(data))
+	})
+}
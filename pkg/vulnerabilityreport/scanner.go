@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/runner"
 	"github.com/aquasecurity/starboard/pkg/starboard"
@@ -62,14 +63,7 @@ func NewScanner(
 // to instances of v1alpha1.VulnerabilityReport by delegating such transformation
 // logic also to the Plugin.
 func (s *Scanner) Scan(ctx context.Context, workload kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error) {
-	klog.V(3).Infof("Getting Pod template for workload: %v", workload)
-
-	workloadObj, err := s.objectResolver.ObjectFromObjectRef(ctx, workload)
-	if err != nil {
-		return nil, fmt.Errorf("resolving object: %w", err)
-	}
-
-	owner, err := s.objectResolver.ReportOwner(ctx, workloadObj)
+	owner, credentials, err := resolveScanTarget(ctx, s.objectResolver, s.secretsReader, workload)
 	if err != nil {
 		return nil, err
 	}
@@ -91,11 +85,6 @@ func (s *Scanner) Scan(ctx context.Context, workload kube.ObjectRef) ([]v1alpha1
 
 	klog.V(3).Infof("Scanning with options: %+v", s.opts)
 
-	credentials, err := s.secretsReader.CredentialsByWorkload(ctx, owner)
-	if err != nil {
-		return nil, err
-	}
-
 	job, secrets, err := NewScanJobBuilder().
 		WithPlugin(s.plugin).
 		WithPluginContext(s.pluginContext).
@@ -151,6 +140,8 @@ func (s *Scanner) getVulnerabilityReportsByScanJob(ctx context.Context, job *bat
 		return nil, fmt.Errorf("expected label %s not set", starboard.LabelResourceSpecHash)
 	}
 
+	sbom := getSBOMResults(ctx, s.logsReader, s.plugin, s.pluginContext, job, owner, containerImages)
+
 	for containerName, containerImage := range containerImages {
 		klog.V(3).Infof("Getting logs for %s container in job: %s/%s", containerName, job.Namespace, job.Name)
 		logsStream, err := s.logsReader.GetLogsByJobAndContainerName(ctx, job, containerName)
@@ -164,12 +155,7 @@ func (s *Scanner) getVulnerabilityReportsByScanJob(ctx context.Context, job *bat
 
 		_ = logsStream.Close()
 
-		report, err := NewReportBuilder(s.scheme).
-			Controller(owner).
-			Container(containerName).
-			Data(result).
-			PodSpecHash(podSpecHash).
-			Get()
+		report, err := newCLIReport(s.scheme, owner, containerName, podSpecHash, sbom.mergeInto(result))
 		if err != nil {
 			return nil, err
 		}
@@ -179,3 +165,39 @@ func (s *Scanner) getVulnerabilityReportsByScanJob(ctx context.Context, job *bat
 	}
 	return reports, nil
 }
+
+// resolveScanTarget returns the owner of reports of the specified workload and
+// registry credentials of its containers, mapped by container names.
+func resolveScanTarget(ctx context.Context, objectResolver *kube.ObjectResolver, secretsReader kube.SecretsReader,
+	workload kube.ObjectRef) (client.Object, map[string]docker.Auth, error) {
+	klog.V(3).Infof("Getting Pod template for workload: %v", workload)
+
+	workloadObj, err := objectResolver.ObjectFromObjectRef(ctx, workload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolving object: %w", err)
+	}
+
+	owner, err := objectResolver.ReportOwner(ctx, workloadObj)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	credentials, err := secretsReader.CredentialsByWorkload(ctx, owner)
+	if err != nil {
+		return nil, nil, err
+	}
+	return owner, credentials, nil
+}
+
+// newCLIReport returns the report of the specified container of the owner
+// initiated by the CLI.
+func newCLIReport(scheme *runtime.Scheme, owner client.Object, containerName, podSpecHash string,
+	data v1alpha1.VulnerabilityReportData) (v1alpha1.VulnerabilityReport, error) {
+	return NewReportBuilder(scheme).
+		Controller(owner).
+		Container(containerName).
+		Data(data).
+		PodSpecHash(podSpecHash).
+		Initiator(v1alpha1.InitiatorCLI).
+		Get()
+}
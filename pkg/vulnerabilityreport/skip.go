@@ -0,0 +1,131 @@
+package vulnerabilityreport
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/google/go-containerregistry/pkg/name"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IsRegistryExcluded returns true if the registry of the specified image
+// matches any of the specified registry hosts or glob patterns. Images with
+// invalid references are never excluded.
+func IsRegistryExcluded(imageRef string, registries []string) bool {
+	if len(registries) == 0 {
+		return false
+	}
+	server, err := docker.GetServerFromImageRef(imageRef)
+	if err != nil {
+		return false
+	}
+	for _, registry := range registries {
+		matches, err := filepath.Match(registry, server)
+		if err == nil && matches {
+			return true
+		}
+	}
+	return false
+}
+
+// GetExcludedContainers returns containers of the specified images whose
+// registries are excluded from scanning.
+func GetExcludedContainers(images kube.ContainerImages, registries []string) kube.ContainerImages {
+	excluded := kube.ContainerImages{}
+	for containerName, imageRef := range images {
+		if IsRegistryExcluded(imageRef, registries) {
+			excluded[containerName] = imageRef
+		}
+	}
+	return excluded
+}
+
+// GetAnnotatedContainers returns containers of the specified images which are
+// listed in the starboard.AnnotationSkipContainers annotation of the specified
+// pod template annotations, and names listed in the annotation which do not
+// match any container, e.g. because of typos.
+func GetAnnotatedContainers(annotations map[string]string, images kube.ContainerImages) (kube.ContainerImages, []string) {
+	excluded := kube.ContainerImages{}
+	var unknown []string
+	value, ok := annotations[starboard.AnnotationSkipContainers]
+	if !ok {
+		return excluded, unknown
+	}
+	for _, containerName := range strings.Split(value, ",") {
+		containerName = strings.TrimSpace(containerName)
+		if containerName == "" {
+			continue
+		}
+		imageRef, ok := images[containerName]
+		if !ok {
+			unknown = append(unknown, containerName)
+			continue
+		}
+		excluded[containerName] = imageRef
+	}
+	return excluded, unknown
+}
+
+// GetMissingReports returns containers of the specified images which have no
+// up to date report among the specified reports of their owner. A report is up
+// to date if it has the specified hashes of the owner and the plugin config,
+// and the specified node group, which is blank unless the owner is scanned per
+// node group. Moreover, it must be skipped for the reason of its container in
+// the specified skip reasons, or not skipped if its container has no reason.
+// Hence, reports of containers whose exclusion from scanning changed, which is
+// not covered by the hash of the owner, are missing.
+func GetMissingReports(reports []v1alpha1.VulnerabilityReport, hash, configHash, nodeGroup string,
+	images kube.ContainerImages, skipReasons map[string]v1alpha1.SkipReason) kube.ContainerImages {
+	actual := map[string]bool{}
+	for _, report := range reports {
+		if containerName, ok := report.Labels[starboard.LabelContainerName]; ok {
+			if hash == report.Labels[starboard.LabelResourceSpecHash] &&
+				configHash == report.Labels[starboard.LabelPluginConfigHash] &&
+				nodeGroup == report.Labels[starboard.LabelNodeGroup] &&
+				skipReasons[containerName] == report.Report.SkipReason {
+				actual[containerName] = true
+			}
+		}
+	}
+
+	missing := kube.ContainerImages{}
+	for containerName, containerImage := range images {
+		if !actual[containerName] {
+			missing[containerName] = containerImage
+		}
+	}
+	return missing
+}
+
+// NewSkippedReportData returns the data of a report of the specified image,
+// which was not scanned for the specified reason. The report has no
+// vulnerabilities and an empty summary.
+func NewSkippedReportData(imageRef string, reason v1alpha1.SkipReason, scanner v1alpha1.Scanner, now time.Time) (v1alpha1.VulnerabilityReportData, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	artifact := v1alpha1.Artifact{
+		Repository: ref.Context().RepositoryStr(),
+	}
+	switch t := ref.(type) {
+	case name.Tag:
+		artifact.Tag = t.TagStr()
+	case name.Digest:
+		artifact.Digest = t.DigestStr()
+	}
+	return v1alpha1.VulnerabilityReportData{
+		UpdateTimestamp: metav1.NewTime(now),
+		Scanner:         scanner,
+		Registry:        v1alpha1.Registry{Server: ref.Context().RegistryStr()},
+		Artifact:        artifact,
+		Summary:         v1alpha1.VulnerabilitySummary{},
+		Vulnerabilities: []v1alpha1.Vulnerability{},
+		SkipReason:      reason,
+	}, nil
+}
@@ -0,0 +1,170 @@
+package vulnerabilityreport_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsRegistryExcluded(t *testing.T) {
+	testCases := []struct {
+		imageRef   string
+		registries []string
+		excluded   bool
+	}{
+		{imageRef: "registry.vendor.example/appliance:1.0", registries: nil, excluded: false},
+		{imageRef: "registry.vendor.example/appliance:1.0", registries: []string{"registry.vendor.example"}, excluded: true},
+		{imageRef: "registry.vendor.example/appliance:1.0", registries: []string{"quay.io", "*.vendor.example"}, excluded: true},
+		{imageRef: "quay.io/vendor/appliance:1.0", registries: []string{"*.vendor.example"}, excluded: false},
+		{imageRef: "nginx:1.16", registries: []string{"index.docker.io"}, excluded: true},
+		{imageRef: "nginx:1.16", registries: []string{"registry.vendor.example"}, excluded: false},
+		{imageRef: "NOT A VALID IMAGE", registries: []string{"*"}, excluded: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.imageRef, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			g.Expect(vulnerabilityreport.IsRegistryExcluded(tc.imageRef, tc.registries)).To(gomega.Equal(tc.excluded))
+		})
+	}
+}
+
+func TestGetExcludedContainers(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	excluded := vulnerabilityreport.GetExcludedContainers(kube.ContainerImages{
+		"nginx":  "nginx:1.16",
+		"vendor": "registry.vendor.example/appliance:1.0",
+	}, []string{"*.vendor.example"})
+	g.Expect(excluded).To(gomega.Equal(kube.ContainerImages{
+		"vendor": "registry.vendor.example/appliance:1.0",
+	}))
+}
+
+func TestGetAnnotatedContainers(t *testing.T) {
+	images := kube.ContainerImages{
+		"nginx":     "nginx:1.16",
+		"sidecar-a": "example.com/data-a:1.0",
+		"sidecar-b": "example.com/data-b:1.0",
+	}
+
+	t.Run("Should return containers listed in annotation", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		excluded, unknown := vulnerabilityreport.GetAnnotatedContainers(map[string]string{
+			starboard.AnnotationSkipContainers: "sidecar-a, sidecar-b",
+		}, images)
+		g.Expect(excluded).To(gomega.Equal(kube.ContainerImages{
+			"sidecar-a": "example.com/data-a:1.0",
+			"sidecar-b": "example.com/data-b:1.0",
+		}))
+		g.Expect(unknown).To(gomega.BeEmpty())
+	})
+
+	t.Run("Should return unknown containers listed in annotation", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		excluded, unknown := vulnerabilityreport.GetAnnotatedContainers(map[string]string{
+			starboard.AnnotationSkipContainers: "sidecar-a,sidcar-b,",
+		}, images)
+		g.Expect(excluded).To(gomega.Equal(kube.ContainerImages{
+			"sidecar-a": "example.com/data-a:1.0",
+		}))
+		g.Expect(unknown).To(gomega.Equal([]string{"sidcar-b"}))
+	})
+
+	t.Run("Should return no containers without annotation", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		excluded, unknown := vulnerabilityreport.GetAnnotatedContainers(nil, images)
+		g.Expect(excluded).To(gomega.BeEmpty())
+		g.Expect(unknown).To(gomega.BeEmpty())
+	})
+}
+
+func TestGetMissingReports(t *testing.T) {
+	images := kube.ContainerImages{
+		"nginx":  "nginx:1.16",
+		"vendor": "registry.vendor.example/appliance:1.0",
+	}
+	newReport := func(containerName string, reason v1alpha1.SkipReason) v1alpha1.VulnerabilityReport {
+		return v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					starboard.LabelContainerName:    containerName,
+					starboard.LabelResourceSpecHash: "7d4c8f9b6",
+					starboard.LabelPluginConfigHash: "5b8f6d7c4",
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{SkipReason: reason},
+		}
+	}
+
+	t.Run("Should return no containers with up to date reports", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		missing := vulnerabilityreport.GetMissingReports([]v1alpha1.VulnerabilityReport{
+			newReport("nginx", ""),
+			newReport("vendor", v1alpha1.SkipReasonRegistryExcluded),
+		}, "7d4c8f9b6", "5b8f6d7c4", "", images, map[string]v1alpha1.SkipReason{
+			"vendor": v1alpha1.SkipReasonRegistryExcluded,
+		})
+		g.Expect(missing).To(gomega.BeEmpty())
+	})
+
+	t.Run("Should return containers with reports of previous revision", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		missing := vulnerabilityreport.GetMissingReports([]v1alpha1.VulnerabilityReport{
+			newReport("nginx", ""),
+		}, "6c9d8b4f7", "5b8f6d7c4", "", kube.ContainerImages{"nginx": "nginx:1.16"}, nil)
+		g.Expect(missing).To(gomega.Equal(kube.ContainerImages{"nginx": "nginx:1.16"}))
+	})
+
+	t.Run("Should return container whose registry is no longer excluded", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		missing := vulnerabilityreport.GetMissingReports([]v1alpha1.VulnerabilityReport{
+			newReport("nginx", ""),
+			newReport("vendor", v1alpha1.SkipReasonRegistryExcluded),
+		}, "7d4c8f9b6", "5b8f6d7c4", "", images, nil)
+		g.Expect(missing).To(gomega.Equal(kube.ContainerImages{
+			"vendor": "registry.vendor.example/appliance:1.0",
+		}))
+	})
+
+	t.Run("Should return container removed from skip-containers annotation", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		missing := vulnerabilityreport.GetMissingReports([]v1alpha1.VulnerabilityReport{
+			newReport("nginx", v1alpha1.SkipReasonContainerExcluded),
+		}, "7d4c8f9b6", "5b8f6d7c4", "", kube.ContainerImages{"nginx": "nginx:1.16"}, nil)
+		g.Expect(missing).To(gomega.Equal(kube.ContainerImages{"nginx": "nginx:1.16"}))
+	})
+
+	t.Run("Should return container added to skip-containers annotation", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		missing := vulnerabilityreport.GetMissingReports([]v1alpha1.VulnerabilityReport{
+			newReport("nginx", ""),
+		}, "7d4c8f9b6", "5b8f6d7c4", "", kube.ContainerImages{"nginx": "nginx:1.16"}, map[string]v1alpha1.SkipReason{
+			"nginx": v1alpha1.SkipReasonContainerExcluded,
+		})
+		g.Expect(missing).To(gomega.Equal(kube.ContainerImages{"nginx": "nginx:1.16"}))
+	})
+}
+
+func TestNewSkippedReportData(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	scanner := v1alpha1.Scanner{Name: "Starboard", Vendor: "Aqua Security", Version: "dev"}
+
+	data, err := vulnerabilityreport.NewSkippedReportData("registry.vendor.example/appliance:1.0",
+		v1alpha1.SkipReasonRegistryExcluded, scanner, now)
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(data).To(gomega.Equal(v1alpha1.VulnerabilityReportData{
+		UpdateTimestamp: metav1.NewTime(now),
+		Scanner:         scanner,
+		Registry:        v1alpha1.Registry{Server: "registry.vendor.example"},
+		Artifact:        v1alpha1.Artifact{Repository: "appliance", Tag: "1.0"},
+		Vulnerabilities: []v1alpha1.Vulnerability{},
+		SkipReason:      v1alpha1.SkipReasonRegistryExcluded,
+	}))
+}
@@ -0,0 +1,132 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	reportsummary "github.com/aquasecurity/starboard/pkg/summary"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// WorkloadSummaryName returns the name of the v1alpha1.WorkloadVulnerabilitySummary
+// of the specified workload.
+func WorkloadSummaryName(workload client.Object) string {
+	kind := strings.ToLower(workload.GetObjectKind().GroupVersionKind().Kind)
+	name := fmt.Sprintf("%s-%s", kind, workload.GetName())
+	if len(validation.IsValidLabelValue(name)) == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s-%s", kind, kube.ComputeHash(workload.GetName()))
+}
+
+// MaxSeverity returns the highest severity of vulnerabilities counted in the
+// given summary, or v1alpha1.SeverityNone if there are none.
+func MaxSeverity(summary v1alpha1.VulnerabilitySummary) v1alpha1.Severity {
+	switch {
+	case summary.CriticalCount > 0:
+		return v1alpha1.SeverityCritical
+	case summary.HighCount > 0:
+		return v1alpha1.SeverityHigh
+	case summary.MediumCount > 0:
+		return v1alpha1.SeverityMedium
+	case summary.LowCount > 0:
+		return v1alpha1.SeverityLow
+	case summary.UnknownCount > 0:
+		return v1alpha1.SeverityUnknown
+	}
+	return v1alpha1.SeverityNone
+}
+
+// NewWorkloadSummary consolidates the given VulnerabilityReports of the
+// containers of the specified workload into a v1alpha1.WorkloadVulnerabilitySummary.
+// Unless skipOwnerReference is true, the summary is owned by the workload.
+func NewWorkloadSummary(scheme *runtime.Scheme, workload client.Object, reports []v1alpha1.VulnerabilityReport, skipOwnerReference bool, now time.Time) (v1alpha1.WorkloadVulnerabilitySummary, error) {
+	summary := v1alpha1.WorkloadVulnerabilitySummary{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      WorkloadSummaryName(workload),
+			Namespace: workload.GetNamespace(),
+		},
+		Report: v1alpha1.WorkloadVulnerabilitySummaryData{
+			UpdateTimestamp: metav1.NewTime(now),
+			Containers:      []v1alpha1.ContainerVulnerabilitySummary{},
+		},
+	}
+	var summaries []v1alpha1.VulnerabilitySummary
+	for _, report := range reports {
+		s := report.Report.Summary
+		summaries = append(summaries, s)
+		summary.Report.Containers = append(summary.Report.Containers, v1alpha1.ContainerVulnerabilitySummary{
+			Name:       report.Labels[starboard.LabelContainerName],
+			Report:     report.Name,
+			Artifact:   report.Report.Artifact,
+			Summary:    *s.DeepCopy(),
+			SkipReason: report.Report.SkipReason,
+		})
+	}
+	sort.Slice(summary.Report.Containers, func(i, j int) bool {
+		a, b := summary.Report.Containers[i], summary.Report.Containers[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Report < b.Report
+	})
+	summary.Report.Summary = reportsummary.MergeVulnerabilities(summaries...)
+	summary.Report.MaxSeverity = MaxSeverity(summary.Report.Summary)
+
+	err := kube.ObjectToObjectMeta(workload, &summary.ObjectMeta)
+	if err != nil {
+		return v1alpha1.WorkloadVulnerabilitySummary{}, err
+	}
+	if skipOwnerReference {
+		return summary, nil
+	}
+	err = controllerutil.SetOwnerReference(workload, &summary, scheme)
+	if err != nil {
+		return v1alpha1.WorkloadVulnerabilitySummary{}, fmt.Errorf("setting owner reference: %w", err)
+	}
+	// Same as VulnerabilityReports, so that no additional RBAC permissions
+	// are required by the OwnerReferencesPermissionsEnforcement admission
+	// controller.
+	summary.OwnerReferences[0].BlockOwnerDeletion = pointer.BoolPtr(false)
+	return summary, nil
+}
+
+// WriteWorkloadSummary creates the given v1alpha1.WorkloadVulnerabilitySummary
+// or replaces the existing one with a single update, so that consumers never
+// observe a summary of some containers only. The write is retried with the
+// latest state on conflict.
+func WriteWorkloadSummary(ctx context.Context, c client.Client, summary v1alpha1.WorkloadVulnerabilitySummary) error {
+	return retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return errors.IsConflict(err) || errors.IsAlreadyExists(err)
+	}, func() error {
+		var existing v1alpha1.WorkloadVulnerabilitySummary
+		err := c.Get(ctx, types.NamespacedName{Name: summary.Name, Namespace: summary.Namespace}, &existing)
+		if err == nil {
+			copied := existing.DeepCopy()
+			copied.Labels = summary.Labels
+			copied.Annotations = summary.Annotations
+			copied.OwnerReferences = summary.OwnerReferences
+			copied.Report = summary.Report
+			return c.Update(ctx, copied)
+		}
+		if errors.IsNotFound(err) {
+			created := summary.DeepCopy()
+			return c.Create(ctx, created)
+		}
+		return err
+	})
+}
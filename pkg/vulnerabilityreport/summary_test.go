@@ -0,0 +1,154 @@
+package vulnerabilityreport_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMaxSeverity(t *testing.T) {
+	testCases := []struct {
+		summary  v1alpha1.VulnerabilitySummary
+		expected v1alpha1.Severity
+	}{
+		{summary: v1alpha1.VulnerabilitySummary{CriticalCount: 1, LowCount: 3}, expected: v1alpha1.SeverityCritical},
+		{summary: v1alpha1.VulnerabilitySummary{HighCount: 2, UnknownCount: 1}, expected: v1alpha1.SeverityHigh},
+		{summary: v1alpha1.VulnerabilitySummary{MediumCount: 1}, expected: v1alpha1.SeverityMedium},
+		{summary: v1alpha1.VulnerabilitySummary{LowCount: 1}, expected: v1alpha1.SeverityLow},
+		{summary: v1alpha1.VulnerabilitySummary{UnknownCount: 1}, expected: v1alpha1.SeverityUnknown},
+		{summary: v1alpha1.VulnerabilitySummary{NoneCount: 42}, expected: v1alpha1.SeverityNone},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.expected), func(t *testing.T) {
+			assert.Equal(t, tc.expected, vulnerabilityreport.MaxSeverity(tc.summary))
+		})
+	}
+}
+
+func TestNewWorkloadSummary(t *testing.T) {
+	now := time.Date(2022, 5, 12, 12, 0, 0, 0, time.UTC)
+	replicaSet := &appsv1.ReplicaSet{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSet"},
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx-6d4cf56db6", Namespace: "default", UID: "rs-uid"},
+	}
+	reports := []v1alpha1.VulnerabilityReport{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "replicaset-nginx-6d4cf56db6-sidecar",
+				Labels: map[string]string{starboard.LabelContainerName: "sidecar"}},
+			Report: v1alpha1.VulnerabilityReportData{
+				Artifact: v1alpha1.Artifact{Repository: "envoyproxy/envoy", Tag: "v1.22.0"},
+				Summary: v1alpha1.VulnerabilitySummary{HighCount: 1, LowCount: 2,
+					PriorityCounts: map[v1alpha1.Priority]int{v1alpha1.PriorityP1: 1}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "replicaset-nginx-6d4cf56db6-nginx",
+				Labels: map[string]string{starboard.LabelContainerName: "nginx"}},
+			Report: v1alpha1.VulnerabilityReportData{
+				Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+				Summary: v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 3,
+					PriorityCounts: map[v1alpha1.Priority]int{v1alpha1.PriorityP1: 2}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "replicaset-nginx-6d4cf56db6-vendor",
+				Labels: map[string]string{starboard.LabelContainerName: "vendor"}},
+			Report: v1alpha1.VulnerabilityReportData{
+				Artifact:   v1alpha1.Artifact{Repository: "vendor/agent", Tag: "1.0"},
+				SkipReason: v1alpha1.SkipReasonRegistryExcluded,
+			},
+		},
+	}
+
+	t.Run("Should consolidate reports of containers", func(t *testing.T) {
+		summary, err := vulnerabilityreport.NewWorkloadSummary(starboard.NewScheme(), replicaSet, reports, false, now)
+		require.NoError(t, err)
+		assert.Equal(t, "replicaset-nginx-6d4cf56db6", summary.Name)
+		assert.Equal(t, "default", summary.Namespace)
+		assert.Equal(t, map[string]string{
+			starboard.LabelResourceKind:      "ReplicaSet",
+			starboard.LabelResourceName:      "nginx-6d4cf56db6",
+			starboard.LabelResourceNamespace: "default",
+		}, summary.Labels)
+		assert.Equal(t, []metav1.OwnerReference{{
+			APIVersion:         "apps/v1",
+			Kind:               "ReplicaSet",
+			Name:               "nginx-6d4cf56db6",
+			UID:                "rs-uid",
+			BlockOwnerDeletion: pointer.BoolPtr(false),
+		}}, summary.OwnerReferences)
+		assert.Equal(t, v1alpha1.WorkloadVulnerabilitySummaryData{
+			UpdateTimestamp: metav1.NewTime(now),
+			MaxSeverity:     v1alpha1.SeverityCritical,
+			Summary: v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 4, LowCount: 2,
+				PriorityCounts: map[v1alpha1.Priority]int{v1alpha1.PriorityP1: 3}},
+			Containers: []v1alpha1.ContainerVulnerabilitySummary{
+				{Name: "nginx", Report: "replicaset-nginx-6d4cf56db6-nginx",
+					Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+					Summary: v1alpha1.VulnerabilitySummary{CriticalCount: 1, HighCount: 3,
+						PriorityCounts: map[v1alpha1.Priority]int{v1alpha1.PriorityP1: 2}}},
+				{Name: "sidecar", Report: "replicaset-nginx-6d4cf56db6-sidecar",
+					Artifact: v1alpha1.Artifact{Repository: "envoyproxy/envoy", Tag: "v1.22.0"},
+					Summary: v1alpha1.VulnerabilitySummary{HighCount: 1, LowCount: 2,
+						PriorityCounts: map[v1alpha1.Priority]int{v1alpha1.PriorityP1: 1}}},
+				{Name: "vendor", Report: "replicaset-nginx-6d4cf56db6-vendor",
+					Artifact:   v1alpha1.Artifact{Repository: "vendor/agent", Tag: "1.0"},
+					SkipReason: v1alpha1.SkipReasonRegistryExcluded},
+			},
+		}, summary.Report)
+	})
+
+	t.Run("Should skip owner reference", func(t *testing.T) {
+		summary, err := vulnerabilityreport.NewWorkloadSummary(starboard.NewScheme(), replicaSet, nil, true, now)
+		require.NoError(t, err)
+		assert.Empty(t, summary.OwnerReferences)
+		assert.Equal(t, v1alpha1.SeverityNone, summary.Report.MaxSeverity)
+		assert.Empty(t, summary.Report.Containers)
+	})
+}
+
+func TestWriteWorkloadSummary(t *testing.T) {
+	replicaSet := &appsv1.ReplicaSet{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSet"},
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx-6d4cf56db6", Namespace: "default", UID: "rs-uid"},
+	}
+	c := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+	report := func(container string, summary v1alpha1.VulnerabilitySummary) v1alpha1.VulnerabilityReport {
+		return v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "replicaset-nginx-6d4cf56db6-" + container,
+				Labels: map[string]string{starboard.LabelContainerName: container}},
+			Report: v1alpha1.VulnerabilityReportData{Summary: summary},
+		}
+	}
+
+	summary, err := vulnerabilityreport.NewWorkloadSummary(starboard.NewScheme(), replicaSet, []v1alpha1.VulnerabilityReport{
+		report("nginx", v1alpha1.VulnerabilitySummary{HighCount: 1}),
+	}, false, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, vulnerabilityreport.WriteWorkloadSummary(context.TODO(), c, summary))
+
+	summary, err = vulnerabilityreport.NewWorkloadSummary(starboard.NewScheme(), replicaSet, []v1alpha1.VulnerabilityReport{
+		report("nginx", v1alpha1.VulnerabilitySummary{LowCount: 1}),
+		report("sidecar", v1alpha1.VulnerabilitySummary{MediumCount: 2}),
+	}, false, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, vulnerabilityreport.WriteWorkloadSummary(context.TODO(), c, summary))
+
+	var written v1alpha1.WorkloadVulnerabilitySummary
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "replicaset-nginx-6d4cf56db6"}, &written))
+	assert.Equal(t, v1alpha1.SeverityMedium, written.Report.MaxSeverity)
+	assert.Equal(t, v1alpha1.VulnerabilitySummary{MediumCount: 2, LowCount: 1}, written.Report.Summary)
+	assert.Len(t, written.Report.Containers, 2)
+	assert.Len(t, written.OwnerReferences, 1)
+}